@@ -0,0 +1,219 @@
+package io.gomatcha.matcha;
+
+import android.graphics.Color;
+
+import com.google.protobuf.InvalidProtocolBufferException;
+
+import java.nio.ByteBuffer;
+import java.nio.ByteOrder;
+import java.nio.charset.Charset;
+import java.util.ArrayList;
+import java.util.List;
+
+// FlatViewRoot reads a view hierarchy in the flat encoding in place. The layout
+// is documented in gomatcha.io/matcha/internal/flat.
+class FlatViewRoot implements ViewRoot {
+    static final int HEADER_SIZE = 16;
+    static final int NODE_SIZE = 192;
+    static final int ENTRY_SIZE = 16;
+
+    static final int HAS_BUILD = 1;
+    static final int HAS_LAYOUT_PAINT = 2;
+    static final int HAS_BACKGROUND_COLOR = 4;
+    static final int HAS_BORDER_COLOR = 8;
+    static final int HAS_SHADOW_COLOR = 16;
+
+    static final Charset UTF8 = Charset.forName("UTF-8");
+
+    ByteBuffer buf;
+    int count;
+
+    FlatViewRoot(byte[] data) {
+        buf = ByteBuffer.wrap(data).order(ByteOrder.LITTLE_ENDIAN);
+        if (data.length < HEADER_SIZE || data[0] != 'M' || data[1] != 'T' || data[2] != 'F' || data[3] != '1') {
+            throw new IllegalArgumentException("Invalid flat view root");
+        }
+        count = buf.getInt(4);
+    }
+
+    // find returns the offset of the node with id, or -1.
+    int find(long id) {
+        int lo = 0;
+        int hi = count;
+        while (lo < hi) {
+            int mid = (lo + hi) >>> 1;
+            long v = buf.getLong(HEADER_SIZE + mid * NODE_SIZE);
+            if (v < id) {
+                lo = mid + 1;
+            } else if (v > id) {
+                hi = mid;
+            } else {
+                return HEADER_SIZE + mid * NODE_SIZE;
+            }
+        }
+        return -1;
+    }
+
+    public ViewRoot.BuildNode getBuildNode(long id) {
+        int offset = find(id);
+        if (offset < 0 || (buf.getInt(offset + 72) & HAS_BUILD) == 0) {
+            return null;
+        }
+        return new Node(offset);
+    }
+
+    public ViewRoot.LayoutPaintNode getLayoutPaintNode(long id) {
+        int offset = find(id);
+        if (offset < 0 || (buf.getInt(offset + 72) & HAS_LAYOUT_PAINT) == 0) {
+            return null;
+        }
+        return new Node(offset);
+    }
+
+    public com.google.protobuf.Any getMiddleware(String key) {
+        byte[] value = lookup(buf.getInt(8), buf.getInt(12), key);
+        if (value == null) {
+            return null;
+        }
+        try {
+            return com.google.protobuf.Any.parseFrom(value);
+        } catch (InvalidProtocolBufferException e) {
+            return null;
+        }
+    }
+
+    String string(int offset) {
+        return new String(buf.array(), buf.getInt(offset), buf.getInt(offset + 4), UTF8);
+    }
+
+    byte[] bytes(int offset) {
+        byte[] b = new byte[buf.getInt(offset + 4)];
+        System.arraycopy(buf.array(), buf.getInt(offset), b, 0, b.length);
+        return b;
+    }
+
+    // lookup returns the value of key in the table of count entries at offset.
+    byte[] lookup(int count, int offset, String key) {
+        for (int i = 0; i < count; i++) {
+            int entry = offset + i * ENTRY_SIZE;
+            if (string(entry).equals(key)) {
+                return bytes(entry + 8);
+            }
+        }
+        return null;
+    }
+
+    static int color(long c) {
+        int r = (int)(c & 0xffff);
+        int g = (int)((c >>> 16) & 0xffff);
+        int b = (int)((c >>> 32) & 0xffff);
+        int a = (int)((c >>> 48) & 0xffff);
+        return Color.argb(a*255/65535, r*255/65535, g*255/65535, b*255/65535);
+    }
+
+    class Node implements ViewRoot.BuildNode, ViewRoot.LayoutPaintNode {
+        int offset;
+
+        Node(int offset) {
+            this.offset = offset;
+        }
+
+        public long getBuildId() {
+            return buf.getLong(offset + 8);
+        }
+
+        public String getBridgeName() {
+            return string(offset + 92);
+        }
+
+        public byte[] getBridgeValue() {
+            return bytes(offset + 100);
+        }
+
+        public List<Long> getChildren() {
+            int count = buf.getInt(offset + 76);
+            int ids = buf.getInt(offset + 80);
+            List<Long> children = new ArrayList<Long>(count);
+            for (int i = 0; i < count; i++) {
+                children.add(buf.getLong(ids + i * 8));
+            }
+            return children;
+        }
+
+        public byte[] getValue(String key) {
+            return lookup(buf.getInt(offset + 108), buf.getInt(offset + 112), key);
+        }
+
+        public long getLayoutId() {
+            return buf.getLong(offset + 16);
+        }
+
+        public long getPaintId() {
+            return buf.getLong(offset + 24);
+        }
+
+        public double getMinx() {
+            return buf.getDouble(offset + 32);
+        }
+
+        public double getMiny() {
+            return buf.getDouble(offset + 40);
+        }
+
+        public double getMaxx() {
+            return buf.getDouble(offset + 48);
+        }
+
+        public double getMaxy() {
+            return buf.getDouble(offset + 56);
+        }
+
+        public int getChildOrderCount() {
+            return buf.getInt(offset + 84);
+        }
+
+        public long getChildOrder(int index) {
+            return buf.getLong(buf.getInt(offset + 88) + index * 8);
+        }
+
+        public double getTransparency() {
+            return buf.getDouble(offset + 120);
+        }
+
+        public double getBorderWidth() {
+            return buf.getDouble(offset + 128);
+        }
+
+        public double getCornerRadius() {
+            return buf.getDouble(offset + 136);
+        }
+
+        public boolean hasBackgroundColor() {
+            return (buf.getInt(offset + 72) & HAS_BACKGROUND_COLOR) != 0;
+        }
+
+        public int getBackgroundColor() {
+            return color(buf.getLong(offset + 168));
+        }
+
+        public boolean hasBorderColor() {
+            return (buf.getInt(offset + 72) & HAS_BORDER_COLOR) != 0;
+        }
+
+        public int getBorderColor() {
+            return color(buf.getLong(offset + 176));
+        }
+
+        public double getShadowRadius() {
+            return buf.getDouble(offset + 144);
+        }
+
+        public boolean hasShadowColor() {
+            return (buf.getInt(offset + 72) & HAS_SHADOW_COLOR) != 0;
+        }
+
+        public int getShadowColor() {
+            return color(buf.getLong(offset + 184));
+        }
+    }
+}
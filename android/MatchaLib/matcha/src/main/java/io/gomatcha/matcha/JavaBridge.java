@@ -1,15 +1,20 @@
 package io.gomatcha.matcha;
 
 import android.app.AlertDialog;
+import android.app.UiModeManager;
 import android.content.Context;
 import android.content.DialogInterface;
 import android.content.Intent;
+import android.content.res.Configuration;
 import android.content.res.Resources;
 import android.graphics.Bitmap;
 import android.graphics.BitmapFactory;
 import android.graphics.PointF;
 import android.graphics.drawable.Drawable;
 import android.net.Uri;
+import android.os.Build;
+import android.text.InputType;
+import android.text.Layout;
 import android.text.SpannableString;
 import android.util.DisplayMetrics;
 import android.util.Log;
@@ -17,6 +22,8 @@ import android.view.Choreographer;
 import android.view.Surface;
 import android.view.View;
 import android.view.WindowManager;
+import android.widget.EditText;
+import android.widget.LinearLayout;
 import android.widget.RelativeLayout;
 import android.widget.TextView;
 
@@ -24,22 +31,26 @@ import com.google.protobuf.InvalidProtocolBufferException;
 
 import java.lang.ref.WeakReference;
 import java.nio.ByteBuffer;
+import java.util.ArrayList;
 import java.util.HashMap;
 import java.util.List;
 
 import io.gomatcha.bridge.Bridge;
 import io.gomatcha.bridge.GoValue;
 import io.gomatcha.matcha.proto.Proto;
-import io.gomatcha.matcha.proto.layout.PbLayout;
 import io.gomatcha.matcha.proto.text.PbText;
 import io.gomatcha.matcha.proto.view.PbAlert;
 import io.gomatcha.matcha.proto.view.PbView;
 
 public class JavaBridge {
+    // PROTOCOL_VERSION is the bridge protocol version of MatchaLib. See
+    // bridge.ProtocolMajor and bridge.ProtocolMinor.
+    static final String PROTOCOL_VERSION = "1.1";
     static JavaBridge javaBridge;
     static Choreographer.FrameCallback callback;
     static Context context;
     static TextView textView;
+    static MatchaMediaLibrary mediaLibrary;
     static HashMap<Long, WeakReference<MatchaView>> viewMap = new HashMap<Long, WeakReference<MatchaView>>();
 
     static synchronized void init(Context ctx) {
@@ -48,6 +59,7 @@ public class JavaBridge {
         }
         context = ctx;
         textView = new TextView(context);
+        mediaLibrary = new MatchaMediaLibrary(context);
         callback = new Choreographer.FrameCallback() {
             @Override
             public void doFrame(long frameTimeNanos) {
@@ -56,11 +68,45 @@ public class JavaBridge {
             }
         };
         Choreographer.getInstance().postFrameCallback(callback);
+        if (!handshake().equals("1.0")) {
+            GoValue.withFunc("gomatcha.io/matcha/view NegotiateEncoding").call("", new GoValue("flat,protobuf"));
+        }
         javaBridge = new JavaBridge();
         javaBridge.didChangeOrientation();
         Bridge.singleton().put("", javaBridge);
     }
 
+    // handshake checks that the Go library speaks a compatible bridge protocol,
+    // and returns its version. Go libraries that predate the handshake speak 1.0.
+    static String handshake() {
+        String version = "1.0";
+        try {
+            version = (String)GoValue.class.getField("PROTOCOL_VERSION").get(null);
+        } catch (NoSuchFieldException e) {
+        } catch (IllegalAccessException e) {
+        }
+        if (!compatibleProtocol(version)) {
+            throw new IllegalStateException("matcha: the Go library speaks bridge protocol " + version + " but MatchaLib speaks " + PROTOCOL_VERSION + ", rebuild the Go library with the matcha version of MatchaLib");
+        }
+        if (!version.equals("1.0")) {
+            GoValue.withFunc("gomatcha.io/matcha/bridge Handshake").callOrThrow("", new GoValue(PROTOCOL_VERSION));
+        }
+        return version;
+    }
+
+    static boolean compatibleProtocol(String version) {
+        String[] a = version.split("\\.");
+        String[] b = PROTOCOL_VERSION.split("\\.");
+        if (a.length != 2 || !a[0].equals(b[0])) {
+            return false;
+        }
+        try {
+            return Math.abs(Integer.parseInt(a[1]) - Integer.parseInt(b[1])) <= 1;
+        } catch (NumberFormatException e) {
+            return false;
+        }
+    }
+
     public boolean updateViewWithProtobuf(Long id, byte[] protobuf) {
         WeakReference<MatchaView> v = viewMap.get(id);
         if (v == null || v.get() == null) {
@@ -68,12 +114,22 @@ public class JavaBridge {
             return false;
         }
         try {
-            v.get().update(PbView.Root.parseFrom(protobuf));
+            v.get().update(new ProtobufViewRoot(PbView.Root.parseFrom(protobuf)));
         } catch (InvalidProtocolBufferException e) {
         }
         return true;
     }
 
+    public boolean updateViewWithFlatBuffer(Long id, byte[] data) {
+        WeakReference<MatchaView> v = viewMap.get(id);
+        if (v == null || v.get() == null) {
+            viewMap.remove(id);
+            return false;
+        }
+        v.get().update(new FlatViewRoot(data));
+        return true;
+    }
+
     public GoValue sizeForStyledText(byte[] protobuf, Long maxLines) {
         try {
             PbText.SizeFunc sizeFunc = PbText.SizeFunc.parseFrom(protobuf);
@@ -91,22 +147,34 @@ public class JavaBridge {
 
             textView.setText(str);
             textView.setMaxLines(maxLines.intValue());
+            if (android.os.Build.VERSION.SDK_INT >= 23) {
+                PbText.StyledText text = sizeFunc.getText();
+                textView.setHyphenationFrequency(MatchaTextSpans.hyphenationFrequency(text.getStylesCount() > 0 ? text.getStyles(0) : PbText.TextStyle.getDefaultInstance()));
+            }
             textView.measure(widthMeasureSpec, heightMeasureSpec);
             textView.setLayoutParams(new RelativeLayout.LayoutParams(0, 0)); // We need this or setText throws a null pointer exception.
 
-            PointF calculatedSize = new PointF();
-            calculatedSize.x = (float)textView.getMeasuredWidth() / ratio + 1;
-            calculatedSize.y = (float)textView.getMeasuredHeight() / ratio;
-            PbLayout.Point p = Protobuf.toProtobuf(calculatedSize);
-
-            return new GoValue(p.toByteArray());
+            PbText.TextMetrics.Builder metrics = PbText.TextMetrics.newBuilder()
+                    .setWidth((float)textView.getMeasuredWidth() / ratio + 1)
+                    .setHeight((float)textView.getMeasuredHeight() / ratio);
+            Layout layout = textView.getLayout();
+            if (layout != null && layout.getLineCount() > 0) {
+                int lastLine = Math.min(layout.getLineCount(), maxLines.intValue()) - 1;
+                metrics.setFirstBaseline((float)(textView.getTotalPaddingTop() + layout.getLineBaseline(0)) / ratio);
+                metrics.setLastBaseline((float)(textView.getTotalPaddingTop() + layout.getLineBaseline(lastLine)) / ratio);
+            }
+            return new GoValue(metrics.build().toByteArray());
         } catch (InvalidProtocolBufferException e) {
             Log.v("x", "exception" + e);
-            PbLayout.Point p = Protobuf.toProtobuf(new PointF(0, 0));
-            return new GoValue(p.toByteArray());
+            return new GoValue(PbText.TextMetrics.getDefaultInstance().toByteArray());
         }
     }
 
+    public String registerFont(String name, byte[] data) {
+        String error = MatchaFonts.register(context, name, data);
+        return error == null ? "" : error;
+    }
+
     public GoValue getImageForResource(String path) {
         Resources res = context.getResources();
         int id = res.getIdentifier(path, "drawable", context.getPackageName());
@@ -131,6 +199,30 @@ public class JavaBridge {
         return new GoValue(builder.build().toByteArray());
     }
 
+    public Object[] getMediaAlbums() {
+        return mediaLibrary.albums();
+    }
+
+    public Object[] getMediaAssets(String album) {
+        return mediaLibrary.assets(album);
+    }
+
+    public void requestMediaThumbnail(String asset, Long size, Long id) {
+        mediaLibrary.requestThumbnail(asset, size, id);
+    }
+
+    public void cancelMediaThumbnail(Long id) {
+        mediaLibrary.cancelThumbnail(id);
+    }
+
+    public void startCachingMedia(Object[] assets, Long size) {
+        mediaLibrary.startCaching(assets, size);
+    }
+
+    public void stopCachingMedia(Object[] assets, Long size) {
+        mediaLibrary.stopCaching(assets, size);
+    }
+
     public boolean openURL(String url) {
         Intent browserIntent = new Intent(Intent.ACTION_VIEW, Uri.parse("http://www.google.com"));
         context.startActivity(browserIntent);
@@ -157,6 +249,45 @@ public class JavaBridge {
         GoValue.withFunc("gomatcha.io/matcha/application SetOrientation").call("", new GoValue(orientation()));
     }
 
+    public String osVersion() {
+        return Integer.toString(Build.VERSION.SDK_INT);
+    }
+
+    public long nativeViewCount(Long id) {
+        WeakReference<MatchaView> v = viewMap.get(id);
+        if (v == null || v.get() == null) {
+            return -1;
+        }
+        return v.get().node.nodeCount();
+    }
+
+    public int formFactor() {
+        UiModeManager uiModeManager = (UiModeManager) context.getSystemService(Context.UI_MODE_SERVICE);
+        int modeType = uiModeManager.getCurrentModeType();
+        if (Build.VERSION.SDK_INT >= 20 && modeType == Configuration.UI_MODE_TYPE_WATCH) {
+            return 2;
+        } else if (modeType == Configuration.UI_MODE_TYPE_TELEVISION) {
+            return 3;
+        } else if (context.getResources().getConfiguration().smallestScreenWidthDp >= 600) {
+            return 1;
+        }
+        return 0;
+    }
+
+    public boolean isScreenRound() {
+        return Build.VERSION.SDK_INT >= 23 && context.getResources().getConfiguration().isScreenRound();
+    }
+
+    public double roundScreenInset() {
+        if (!isScreenRound()) {
+            return 0;
+        }
+        // The largest square that fits in the screen.
+        DisplayMetrics metrics = context.getResources().getDisplayMetrics();
+        double diameter = (double)Math.min(metrics.widthPixels, metrics.heightPixels) / metrics.densityDpi * DisplayMetrics.DENSITY_DEFAULT;
+        return diameter / 2 * (1 - 1 / Math.sqrt(2));
+    }
+
     public void displayAlert(byte[] protobuf) {
         try {
             final PbAlert.Alert alert = PbAlert.Alert.parseFrom(protobuf);
@@ -201,4 +332,116 @@ public class JavaBridge {
         } catch (InvalidProtocolBufferException e) {
         }
     }
+
+    public void presentDialog(final Long id, Long style, String title, String message, Object[] buttons, Object[] fields) {
+        AlertDialog.Builder builder = new AlertDialog.Builder(context);
+        boolean actionSheet = style == 1 || buttons.length > 3;
+        if (actionSheet && title.length() > 0 && message.length() > 0) {
+            // Dialogs with a list of items can't display a message.
+            title = title + "\n" + message;
+        } else if (actionSheet && title.length() == 0) {
+            title = message;
+        }
+        if (title.length() > 0) {
+            builder.setTitle(title);
+        }
+        if (!actionSheet && message.length() > 0) {
+            builder.setMessage(message);
+        }
+
+        final List<EditText> editTexts = new ArrayList<EditText>();
+        if (fields.length > 0) {
+            float ratio = (float)context.getResources().getDisplayMetrics().densityDpi / DisplayMetrics.DENSITY_DEFAULT;
+            int padding = (int)(20 * ratio);
+            LinearLayout layout = new LinearLayout(context);
+            layout.setOrientation(LinearLayout.VERTICAL);
+            layout.setPadding(padding, 0, padding, 0);
+            for (Object i : fields) {
+                Object[] field = (Object[])i;
+                EditText editText = new EditText(context);
+                editText.setHint((String)field[0]);
+                editText.setText((String)field[1]);
+                editText.setSingleLine(true);
+                if ((Boolean)field[2]) {
+                    editText.setInputType(InputType.TYPE_CLASS_TEXT | InputType.TYPE_TEXT_VARIATION_PASSWORD);
+                }
+                layout.addView(editText);
+                editTexts.add(editText);
+            }
+            builder.setView(layout);
+        }
+
+        int cancel = -1;
+        final List<Integer> others = new ArrayList<Integer>();
+        for (int i = 0; i < buttons.length; i++) {
+            if ((Long)((Object[])buttons[i])[1] == 1 && cancel == -1) {
+                cancel = i;
+            } else {
+                others.add(i);
+            }
+        }
+        final int cancelIndex = cancel;
+        if (cancel >= 0) {
+            builder.setNegativeButton((String)((Object[])buttons[cancel])[0], new DialogInterface.OnClickListener() {
+                public void onClick(DialogInterface dialog, int which) {
+                    dismissDialog(id, cancelIndex, editTexts);
+                }
+            });
+        }
+        if (actionSheet) {
+            CharSequence[] titles = new CharSequence[others.size()];
+            for (int i = 0; i < others.size(); i++) {
+                titles[i] = (String)((Object[])buttons[others.get(i)])[0];
+            }
+            builder.setItems(titles, new DialogInterface.OnClickListener() {
+                public void onClick(DialogInterface dialog, int which) {
+                    dismissDialog(id, others.get(which), editTexts);
+                }
+            });
+        } else {
+            if (others.size() > 0) {
+                builder.setPositiveButton((String)((Object[])buttons[others.get(0)])[0], new DialogInterface.OnClickListener() {
+                    public void onClick(DialogInterface dialog, int which) {
+                        dismissDialog(id, others.get(0), editTexts);
+                    }
+                });
+            }
+            if (others.size() > 1) {
+                builder.setNeutralButton((String)((Object[])buttons[others.get(1)])[0], new DialogInterface.OnClickListener() {
+                    public void onClick(DialogInterface dialog, int which) {
+                        dismissDialog(id, others.get(1), editTexts);
+                    }
+                });
+            }
+            if (others.size() > 2) {
+                builder.setNegativeButton((String)((Object[])buttons[others.get(2)])[0], new DialogInterface.OnClickListener() {
+                    public void onClick(DialogInterface dialog, int which) {
+                        dismissDialog(id, others.get(2), editTexts);
+                    }
+                });
+            }
+        }
+        builder.setOnCancelListener(new DialogInterface.OnCancelListener() {
+            public void onCancel(DialogInterface dialog) {
+                dismissDialog(id, cancelIndex, editTexts);
+            }
+        });
+        builder.show();
+    }
+
+    public void presentSnackbar(Long id, String text, String action, Long duration) {
+        MatchaSnackbar.present(id, text, action, duration);
+    }
+
+    public void dismissSnackbar(Long id) {
+        MatchaSnackbar.dismiss(id);
+    }
+
+    void dismissDialog(Long id, int button, List<EditText> editTexts) {
+        GoValue[] text = new GoValue[editTexts.size()];
+        for (int i = 0; i < text.length; i++) {
+            text[i] = new GoValue(editTexts.get(i).getText().toString());
+        }
+        GoValue.withFunc("gomatcha.io/matcha/view/alert onDismiss").call("", new GoValue(id.longValue()), new GoValue((long)button), new GoValue(text));
+    }
 }
@@ -0,0 +1,229 @@
+package io.gomatcha.matcha;
+
+import android.content.Context;
+import android.graphics.Canvas;
+import android.graphics.ImageFormat;
+import android.graphics.Paint;
+import android.graphics.Rect;
+import android.graphics.RectF;
+import android.hardware.Camera;
+import android.os.Handler;
+import android.util.SparseArray;
+
+import com.google.android.gms.vision.Frame;
+import com.google.android.gms.vision.barcode.Barcode;
+import com.google.android.gms.vision.barcode.BarcodeDetector;
+
+import java.nio.ByteBuffer;
+
+import io.gomatcha.bridge.GoValue;
+
+// MatchaBarcodeScannerView decodes the camera's preview frames with the Google
+// Play services barcode detector.
+class MatchaBarcodeScannerView extends MatchaCameraView {
+    // The Play services formats, in the order of the Format constants in Go.
+    static final int[] FORMATS = {
+        Barcode.QR_CODE,
+        Barcode.EAN_13,
+        Barcode.EAN_8,
+        Barcode.UPC_A,
+        Barcode.UPC_E,
+        Barcode.CODE_128,
+        Barcode.CODE_39,
+        Barcode.PDF417,
+        Barcode.AZTEC,
+        Barcode.DATA_MATRIX,
+    };
+    BarcodeDetector detector; // Only accessed on frameThread once created.
+    long formats;
+    RectF region = new RectF();
+    Paint overlayPaint;
+    volatile boolean paused;
+
+    static {
+        MatchaView.registerView("gomatcha.io/matcha/view/barcodescanner", new MatchaView.ViewFactory() {
+            @Override
+            public MatchaChildView createView(Context context, MatchaViewNode node) {
+                return new MatchaBarcodeScannerView(context, node);
+            }
+        });
+    }
+
+    public MatchaBarcodeScannerView(Context context, MatchaViewNode node) {
+        super(context, node);
+        setWillNotDraw(false);
+    }
+
+    @Override
+    void updateState() {
+        super.updateState();
+        GoValue[] state = viewNode.call("ScannerState");
+        if (state == null || state.length < 7) {
+            return;
+        }
+        long formats = state[0].toLong();
+        float ratio = getResources().getDisplayMetrics().density;
+        float x = (float)state[1].toDouble() * ratio;
+        float y = (float)state[2].toDouble() * ratio;
+        region.set(x, y, x + (float)state[3].toDouble() * ratio, y + (float)state[4].toDouble() * ratio);
+        long overlayColor = state[5].toLong();
+        paused = state[6].toBool();
+
+        if (overlayColor == -1) {
+            overlayPaint = null;
+        } else {
+            overlayPaint = new Paint();
+            overlayPaint.setColor((int)overlayColor);
+        }
+        if (formats != this.formats) {
+            this.formats = formats;
+            final BarcodeDetector detector = newDetector(formats);
+            final Handler handler = frameHandler;
+            if (handler == null) {
+                setDetector(detector);
+            } else {
+                handler.post(new Runnable() {
+                    @Override
+                    public void run() {
+                        setDetector(detector);
+                    }
+                });
+            }
+        }
+        invalidate();
+    }
+
+    BarcodeDetector newDetector(long formats) {
+        int barcodeFormats = 0;
+        for (int i = 0; i < FORMATS.length; i++) {
+            if ((formats & (1 << i)) != 0) {
+                barcodeFormats |= FORMATS[i];
+            }
+        }
+        BarcodeDetector detector = new BarcodeDetector.Builder(getContext()).setBarcodeFormats(barcodeFormats).build();
+        if (!detector.isOperational()) {
+            // The detector's native library is downloaded by Play services
+            // the first time it is used, so it may become available later.
+            callError("barcodescanner: detector is not available");
+        }
+        return detector;
+    }
+
+    void setDetector(BarcodeDetector detector) {
+        if (this.detector != null) {
+            this.detector.release();
+        }
+        this.detector = detector;
+    }
+
+    @Override
+    public void onPreviewFrame(final byte[] data, final Camera camera) {
+        final Handler handler = frameHandler;
+        if (paused || handler == null) {
+            camera.addCallbackBuffer(data);
+            return;
+        }
+        final int width = previewSize.width;
+        final int height = previewSize.height;
+        final int orientation = displayOrientation();
+        handler.post(new Runnable() {
+            @Override
+            public void run() {
+                BarcodeDetector detector = MatchaBarcodeScannerView.this.detector;
+                SparseArray<Barcode> barcodes = null;
+                if (detector != null && detector.isOperational()) {
+                    Frame frame = new Frame.Builder()
+                        .setImageData(ByteBuffer.wrap(data), width, height, ImageFormat.NV21)
+                        .setRotation(orientation / 90)
+                        .build();
+                    barcodes = detector.detect(frame);
+                }
+                final SparseArray<Barcode> results = barcodes;
+                post(new Runnable() {
+                    @Override
+                    public void run() {
+                        if (MatchaBarcodeScannerView.this.camera != camera) {
+                            return;
+                        }
+                        camera.addCallbackBuffer(data);
+                        if (results != null) {
+                            boolean rotated = orientation == 90 || orientation == 270;
+                            report(results, rotated ? height : width, rotated ? width : height);
+                        }
+                    }
+                });
+            }
+        });
+    }
+
+    // report sends the barcodes to Go, with their bounds converted from the
+    // rotated frame, which is stretched to fill the view, to the view's
+    // coordinates.
+    void report(SparseArray<Barcode> barcodes, int frameWidth, int frameHeight) {
+        if (paused) {
+            return;
+        }
+        float ratio = getResources().getDisplayMetrics().density;
+        float scaleX = (float)getWidth() / frameWidth / ratio;
+        float scaleY = (float)getHeight() / frameHeight / ratio;
+        for (int i = 0; i < barcodes.size(); i++) {
+            Barcode barcode = barcodes.valueAt(i);
+            int format = 0;
+            for (int j = 0; j < FORMATS.length; j++) {
+                if (FORMATS[j] == barcode.format) {
+                    format = 1 << j;
+                    break;
+                }
+            }
+            if (format == 0 || barcode.rawValue == null) {
+                continue;
+            }
+            Rect bounds = barcode.getBoundingBox();
+            viewNode.call("OnScan",
+                new GoValue(format),
+                new GoValue(barcode.rawValue),
+                new GoValue(bounds.left * scaleX),
+                new GoValue(bounds.top * scaleY),
+                new GoValue(bounds.width() * scaleX),
+                new GoValue(bounds.height() * scaleY));
+        }
+    }
+
+    // displayOrientation returns the rotation of the open camera's frames.
+    int displayOrientation() {
+        Camera.CameraInfo info = new Camera.CameraInfo();
+        int facing = position == 1 ? Camera.CameraInfo.CAMERA_FACING_FRONT : Camera.CameraInfo.CAMERA_FACING_BACK;
+        for (int i = 0; i < Camera.getNumberOfCameras(); i++) {
+            Camera.getCameraInfo(i, info);
+            if (info.facing == facing) {
+                return displayOrientation(info);
+            }
+        }
+        return 0;
+    }
+
+    @Override
+    protected void dispatchDraw(Canvas canvas) {
+        super.dispatchDraw(canvas);
+        if (overlayPaint == null) {
+            return;
+        }
+        if (region.isEmpty()) {
+            canvas.drawRect(0, 0, getWidth(), getHeight(), overlayPaint);
+            return;
+        }
+        // The overlay dims the view around the region.
+        canvas.drawRect(0, 0, getWidth(), region.top, overlayPaint);
+        canvas.drawRect(0, region.bottom, getWidth(), getHeight(), overlayPaint);
+        canvas.drawRect(0, region.top, region.left, region.bottom, overlayPaint);
+        canvas.drawRect(region.right, region.top, getWidth(), region.bottom, overlayPaint);
+    }
+
+    @Override
+    protected void onDetachedFromWindow() {
+        super.onDetachedFromWindow();
+        // The frame thread has quit, so the detector is released here.
+        setDetector(null);
+        formats = 0;
+    }
+}
@@ -0,0 +1,148 @@
+package io.gomatcha.matcha;
+
+import android.content.Context;
+import android.graphics.Color;
+import android.graphics.Shader;
+import android.util.DisplayMetrics;
+import android.view.View;
+
+import java.lang.reflect.Method;
+import java.util.ArrayList;
+import java.util.List;
+
+import io.gomatcha.bridge.GoValue;
+
+class MatchaBlurView extends MatchaChildView {
+    MatchaViewNode viewNode;
+    List<View> childViews = new ArrayList<View>();
+    View contentView;
+    View tintView;
+    View overlayView;
+    boolean needsUpdate;
+
+    static {
+        MatchaView.registerView("gomatcha.io/matcha/view/blurview", new MatchaView.ViewFactory() {
+            @Override
+            public MatchaChildView createView(Context context, MatchaViewNode node) {
+                return new MatchaBlurView(context, node);
+            }
+        });
+    }
+
+    public MatchaBlurView(Context context, MatchaViewNode node) {
+        super(context);
+        viewNode = node;
+
+        tintView = new View(context);
+        addView(tintView);
+    }
+
+    @Override
+    public void setNativeState(byte[] nativeState) {
+        super.setNativeState(nativeState);
+        setNeedsUpdate();
+    }
+
+    @Override
+    public boolean isContainerView() {
+        return true;
+    }
+
+    @Override
+    public void setChildViews(List<View> childViews) {
+        this.childViews = childViews;
+        setNeedsUpdate();
+    }
+
+    // Go can't be called while it is updating the views, so the state is read
+    // once the update completes.
+    void setNeedsUpdate() {
+        if (needsUpdate) {
+            return;
+        }
+        needsUpdate = true;
+        post(new Runnable() {
+            @Override
+            public void run() {
+                needsUpdate = false;
+                update();
+            }
+        });
+    }
+
+    void update() {
+        GoValue[] state = viewNode.call("State");
+        long style = state[0].toLong();
+        double radius = state[1].toDouble();
+        int contentIdx = (int)state[3].toLong();
+        int overlayIdx = (int)state[4].toLong();
+
+        View contentView = contentIdx >= 0 && contentIdx < childViews.size() ? childViews.get(contentIdx) : null;
+        if (this.contentView != contentView) {
+            if (this.contentView != null && this.contentView.getParent() == this) {
+                setBlur(this.contentView, 0);
+                removeView(this.contentView);
+            }
+            this.contentView = contentView;
+            if (contentView != null) {
+                addView(contentView, 0);
+            }
+        }
+
+        View overlayView = overlayIdx >= 0 && overlayIdx < childViews.size() ? childViews.get(overlayIdx) : null;
+        if (this.overlayView != overlayView) {
+            if (this.overlayView != null && this.overlayView.getParent() == this) {
+                removeView(this.overlayView);
+            }
+            this.overlayView = overlayView;
+            if (overlayView != null) {
+                addView(overlayView);
+            }
+        }
+
+        float ratio = (float)getResources().getDisplayMetrics().densityDpi / DisplayMetrics.DENSITY_DEFAULT;
+        boolean blurred = contentView != null && setBlur(contentView, (float)(radius * ratio));
+        tintView.setBackgroundColor(tintColor(style, blurred));
+    }
+
+    // The material is a translucent tint over the blurred content, or a more
+    // opaque one if the content can't be blurred.
+    static int tintColor(long style, boolean blurred) {
+        int alpha = blurred ? 0x66 : 0xCC;
+        switch ((int)style) {
+        case 1: // Light
+            return Color.argb(alpha, 0xFF, 0xFF, 0xFF);
+        case 2: // Dark
+            return Color.argb(alpha, 0x1C, 0x1C, 0x1E);
+        case 3: // Thin
+            return Color.argb(alpha / 2, 0xF2, 0xF2, 0xF7);
+        case 4: // Thick
+            return Color.argb(Math.min(alpha * 3 / 2, 0xFF), 0xF2, 0xF2, 0xF7);
+        default:
+            return Color.argb(alpha, 0xF2, 0xF2, 0xF7);
+        }
+    }
+
+    // setBlur blurs view with a RenderEffect, which is only available on
+    // Android 12 and later. It is called with reflection, since the library is
+    // compiled against an earlier SDK. It returns false if the view can't be
+    // blurred.
+    static boolean setBlur(View view, float radius) {
+        if (android.os.Build.VERSION.SDK_INT < 31) {
+            return false;
+        }
+        try {
+            Class<?> renderEffect = Class.forName("android.graphics.RenderEffect");
+            Object effect = null;
+            if (radius > 0) {
+                Method create = renderEffect.getMethod("createBlurEffect", float.class, float.class, Shader.TileMode.class);
+                effect = create.invoke(null, radius, radius, Shader.TileMode.CLAMP);
+            }
+            Method set = View.class.getMethod("setRenderEffect", renderEffect);
+            set.invoke(view, effect);
+            return radius > 0;
+        } catch (Exception e) {
+            return false;
+        }
+    }
+}
@@ -0,0 +1,218 @@
+package io.gomatcha.matcha;
+
+import android.content.Context;
+import android.content.res.ColorStateList;
+import android.graphics.Color;
+import android.graphics.drawable.GradientDrawable;
+import android.support.annotation.NonNull;
+import android.support.design.widget.BottomNavigationView;
+import android.util.TypedValue;
+import android.view.Gravity;
+import android.view.Menu;
+import android.view.MenuItem;
+import android.view.View;
+import android.view.ViewGroup;
+import android.widget.FrameLayout;
+import android.widget.TextView;
+
+import com.google.protobuf.InvalidProtocolBufferException;
+
+import java.util.ArrayList;
+import java.util.Arrays;
+import java.util.List;
+
+import io.gomatcha.bridge.GoValue;
+import io.gomatcha.matcha.proto.Proto;
+
+class MatchaBottomNavView extends MatchaChildView {
+    MatchaViewNode viewNode;
+    BottomNavigationView bottomNav;
+    View childView;
+    List<String> titles = new ArrayList<String>();
+    List<TextView> badgeViews = new ArrayList<TextView>();
+    int selected = -1;
+    boolean needsUpdate;
+
+    static {
+        MatchaView.registerView("gomatcha.io/matcha/view/android/bottomnav", new MatchaView.ViewFactory() {
+            @Override
+            public MatchaChildView createView(Context context, MatchaViewNode node) {
+                return new MatchaBottomNavView(context, node);
+            }
+        });
+    }
+
+    public MatchaBottomNavView(Context context, MatchaViewNode node) {
+        super(context);
+        viewNode = node;
+
+        float ratio = getResources().getDisplayMetrics().density;
+        bottomNav = new BottomNavigationView(context);
+        bottomNav.setOnNavigationItemSelectedListener(new BottomNavigationView.OnNavigationItemSelectedListener() {
+            @Override
+            public boolean onNavigationItemSelected(@NonNull MenuItem item) {
+                int index = item.getItemId() - Menu.FIRST;
+                if (index != selected) {
+                    selected = index;
+                    viewNode.call("OnSelect", new GoValue(index));
+                }
+                return true;
+            }
+        });
+        bottomNav.setOnNavigationItemReselectedListener(new BottomNavigationView.OnNavigationItemReselectedListener() {
+            @Override
+            public void onNavigationItemReselected(@NonNull MenuItem item) {
+                viewNode.call("OnReselect", new GoValue(item.getItemId() - Menu.FIRST));
+            }
+        });
+        FrameLayout.LayoutParams params = new FrameLayout.LayoutParams(FrameLayout.LayoutParams.MATCH_PARENT, (int)(56 * ratio));
+        params.gravity = Gravity.BOTTOM;
+        addView(bottomNav, params);
+    }
+
+    @Override
+    public void setNativeState(byte[] nativeState) {
+        super.setNativeState(nativeState);
+        setNeedsUpdate();
+    }
+
+    @Override
+    public boolean isContainerView() {
+        return true;
+    }
+
+    @Override
+    public void setChildViews(List<View> childViews) {
+        View childView = childViews.size() > 0 ? childViews.get(0) : null;
+        if (childView != this.childView) {
+            if (this.childView != null) {
+                removeView(this.childView);
+            }
+            this.childView = childView;
+            if (childView != null) {
+                // The stack is below the bar, so that the bar's shadow is drawn
+                // over it.
+                addView(childView, 0);
+            }
+        }
+    }
+
+    // Go can't be called while it is updating the views, so the state is read
+    // once the update completes.
+    void setNeedsUpdate() {
+        if (needsUpdate) {
+            return;
+        }
+        needsUpdate = true;
+        post(new Runnable() {
+            @Override
+            public void run() {
+                needsUpdate = false;
+                update();
+            }
+        });
+    }
+
+    void update() {
+        GoValue[] state = viewNode.call("State");
+        GoValue[] titleValues = state[0].toArray();
+        GoValue[] icons = state[1].toArray();
+        GoValue[] badges = state[2].toArray();
+        int selected = (int)state[3].toLong();
+        long barColor = state[4].toLong();
+        long selectedColor = state[5].toLong();
+        long unselectedColor = state[6].toLong();
+
+        String[] titles = new String[titleValues.length];
+        for (int i = 0; i < titleValues.length; i++) {
+            titles[i] = titleValues[i].toString();
+        }
+        Menu menu = bottomNav.getMenu();
+        if (!this.titles.equals(Arrays.asList(titles)) || menu.size() != titles.length) {
+            this.titles = Arrays.asList(titles);
+            menu.clear();
+            for (int i = 0; i < titles.length; i++) {
+                menu.add(Menu.NONE, Menu.FIRST + i, Menu.NONE, titles[i]);
+            }
+            badgeViews.clear();
+        }
+        for (int i = 0; i < icons.length && i < menu.size(); i++) {
+            byte[] icon = icons[i].toByteArray();
+            MenuItem item = menu.getItem(i);
+            item.setIcon(null);
+            if (icon != null && icon.length > 0) {
+                try {
+                    item.setIcon(Protobuf.newDrawable(Proto.ImageOrResource.parseFrom(icon), getContext()));
+                } catch (InvalidProtocolBufferException e) {
+                }
+            }
+        }
+
+        // Go's selection is stored first, so that it isn't reported back.
+        this.selected = selected;
+        if (selected >= 0 && selected < menu.size() && bottomNav.getSelectedItemId() != Menu.FIRST + selected) {
+            bottomNav.setSelectedItemId(Menu.FIRST + selected);
+        }
+
+        bottomNav.setBackgroundColor(barColor == -1 ? Color.WHITE : (int)barColor);
+        int checkedColor = selectedColor == -1 ? primaryColor() : (int)selectedColor;
+        int uncheckedColor = unselectedColor == -1 ? 0x8A000000 : (int)unselectedColor;
+        ColorStateList colors = new ColorStateList(
+            new int[][]{new int[]{android.R.attr.state_checked}, new int[]{}},
+            new int[]{checkedColor, uncheckedColor});
+        bottomNav.setItemIconTintList(colors);
+        bottomNav.setItemTextColor(colors);
+
+        for (int i = 0; i < badges.length; i++) {
+            setBadge(i, badges[i].toString());
+        }
+    }
+
+    // setBadge displays text over the icon of the item at index.
+    // BottomNavigationView doesn't support badges, so a label is added to the
+    // item's view.
+    void setBadge(int index, String text) {
+        if (bottomNav.getChildCount() == 0) {
+            return;
+        }
+        ViewGroup menuView = (ViewGroup)bottomNav.getChildAt(0);
+        if (index >= menuView.getChildCount()) {
+            return;
+        }
+        while (badgeViews.size() <= index) {
+            badgeViews.add(null);
+        }
+        TextView badge = badgeViews.get(index);
+        if (badge == null || badge.getParent() != menuView.getChildAt(index)) {
+            float ratio = getResources().getDisplayMetrics().density;
+            GradientDrawable background = new GradientDrawable();
+            background.setColor(0xFFF44336);
+            background.setCornerRadius(8 * ratio);
+            badge = new TextView(getContext());
+            badge.setBackground(background);
+            badge.setTextColor(Color.WHITE);
+            badge.setTextSize(TypedValue.COMPLEX_UNIT_SP, 10);
+            badge.setGravity(Gravity.CENTER);
+            badge.setMinWidth((int)(16 * ratio));
+            badge.setPadding((int)(4 * ratio), 0, (int)(4 * ratio), 0);
+            FrameLayout.LayoutParams params = new FrameLayout.LayoutParams(FrameLayout.LayoutParams.WRAP_CONTENT, (int)(16 * ratio));
+            params.gravity = Gravity.TOP | Gravity.CENTER_HORIZONTAL;
+            params.leftMargin = (int)(20 * ratio);
+            params.topMargin = (int)(4 * ratio);
+            ((ViewGroup)menuView.getChildAt(index)).addView(badge, params);
+            badgeViews.set(index, badge);
+        }
+        badge.setText(text);
+        badge.setVisibility(text.length() > 0 ? View.VISIBLE : View.GONE);
+    }
+
+    // primaryColor returns the theme's primary color, which is only available
+    // on Android 5 and later.
+    int primaryColor() {
+        TypedValue value = new TypedValue();
+        if (android.os.Build.VERSION.SDK_INT >= 21 && getContext().getTheme().resolveAttribute(android.R.attr.colorPrimary, value, true)) {
+            return value.data;
+        }
+        return 0xFF3F51B5;
+    }
+}
@@ -0,0 +1,303 @@
+package io.gomatcha.matcha;
+
+import android.Manifest;
+import android.content.Context;
+import android.content.pm.PackageManager;
+import android.graphics.ImageFormat;
+import android.hardware.Camera;
+import android.os.Handler;
+import android.os.HandlerThread;
+import android.view.Surface;
+import android.view.SurfaceHolder;
+import android.view.SurfaceView;
+import android.view.WindowManager;
+
+import java.io.IOException;
+import java.nio.ByteBuffer;
+import java.util.List;
+
+import io.gomatcha.bridge.GoValue;
+
+class MatchaCameraView extends MatchaChildView implements SurfaceHolder.Callback, Camera.PreviewCallback {
+    MatchaViewNode viewNode;
+    SurfaceView surfaceView;
+    boolean surfaceReady;
+    boolean attached;
+    Camera camera;
+    Camera.Size previewSize;
+    HandlerThread frameThread;
+    Handler frameHandler;
+    ByteBuffer frameBuffer; // Reused for every frame, only accessed on frameThread.
+    GoValue onFrame = GoValue.withFunc("gomatcha.io/matcha/view/camera onFrame");
+    boolean needsState;
+    boolean configured;
+    int position = -1;
+    boolean torch;
+    long capture;
+    volatile long viewId;
+    volatile int frameWidth;
+
+    static {
+        MatchaView.registerView("gomatcha.io/matcha/view/camera", new MatchaView.ViewFactory() {
+            @Override
+            public MatchaChildView createView(Context context, MatchaViewNode node) {
+                return new MatchaCameraView(context, node);
+            }
+        });
+    }
+
+    public MatchaCameraView(Context context, MatchaViewNode node) {
+        super(context);
+        viewNode = node;
+
+        surfaceView = new SurfaceView(context);
+        surfaceView.getHolder().addCallback(this);
+        addView(surfaceView);
+    }
+
+    @Override
+    public void setNativeState(byte[] nativeState) {
+        super.setNativeState(nativeState);
+        // Go can't be called while it is updating the views, so the state is
+        // read once the update completes.
+        if (needsState) {
+            return;
+        }
+        needsState = true;
+        post(new Runnable() {
+            @Override
+            public void run() {
+                needsState = false;
+                updateState();
+            }
+        });
+    }
+
+    void updateState() {
+        GoValue[] state = viewNode.call("State");
+        if (state == null || state.length < 5) {
+            return;
+        }
+        viewId = state[0].toLong();
+        int position = (int)state[1].toLong();
+        boolean torch = state[2].toBool();
+        frameWidth = (int)state[3].toLong();
+        long capture = state[4].toLong();
+
+        if (!configured) {
+            configured = true;
+            this.capture = capture;
+        }
+        if (position != this.position) {
+            this.position = position;
+            this.torch = false;
+            openCamera();
+        }
+        if (torch != this.torch) {
+            this.torch = torch;
+            updateTorch();
+        }
+        if (capture != this.capture) {
+            this.capture = capture;
+            capturePhoto();
+        }
+    }
+
+    void openCamera() {
+        closeCamera();
+        if (position < 0 || !attached) {
+            return;
+        }
+        if (getContext().checkCallingOrSelfPermission(Manifest.permission.CAMERA) != PackageManager.PERMISSION_GRANTED) {
+            callError("camera: permission denied");
+            return;
+        }
+
+        int facing = position == 1 ? Camera.CameraInfo.CAMERA_FACING_FRONT : Camera.CameraInfo.CAMERA_FACING_BACK;
+        Camera.CameraInfo info = new Camera.CameraInfo();
+        int id = -1;
+        for (int i = 0; i < Camera.getNumberOfCameras(); i++) {
+            Camera.getCameraInfo(i, info);
+            if (info.facing == facing) {
+                id = i;
+                break;
+            }
+        }
+        if (id == -1) {
+            callError("camera: no camera available");
+            return;
+        }
+        try {
+            camera = Camera.open(id);
+        } catch (RuntimeException e) {
+            callError("camera: " + e.getMessage());
+            return;
+        }
+        camera.setDisplayOrientation(displayOrientation(info));
+        Camera.Parameters params = camera.getParameters();
+        params.setPreviewFormat(ImageFormat.NV21);
+        List<String> focusModes = params.getSupportedFocusModes();
+        if (focusModes != null && focusModes.contains(Camera.Parameters.FOCUS_MODE_CONTINUOUS_PICTURE)) {
+            params.setFocusMode(Camera.Parameters.FOCUS_MODE_CONTINUOUS_PICTURE);
+        }
+        camera.setParameters(params);
+        previewSize = params.getPreviewSize();
+
+        // A single callback buffer drops the frames that arrive while Go
+        // processes the previous one.
+        frameThread = new HandlerThread("io.gomatcha.camera.frames");
+        frameThread.start();
+        frameHandler = new Handler(frameThread.getLooper());
+        camera.addCallbackBuffer(new byte[previewSize.width * previewSize.height * ImageFormat.getBitsPerPixel(ImageFormat.NV21) / 8]);
+        camera.setPreviewCallbackWithBuffer(this);
+        startPreview();
+    }
+
+    void startPreview() {
+        if (camera == null || !surfaceReady) {
+            return;
+        }
+        try {
+            camera.setPreviewDisplay(surfaceView.getHolder());
+            camera.startPreview();
+        } catch (IOException e) {
+            callError("camera: " + e.getMessage());
+        }
+    }
+
+    void closeCamera() {
+        if (camera != null) {
+            camera.setPreviewCallbackWithBuffer(null);
+            camera.stopPreview();
+            camera.release();
+            camera = null;
+        }
+        if (frameThread != null) {
+            frameThread.quit();
+            frameThread = null;
+            frameHandler = null;
+        }
+    }
+
+    int displayOrientation(Camera.CameraInfo info) {
+        int rotation = ((WindowManager)getContext().getSystemService(Context.WINDOW_SERVICE)).getDefaultDisplay().getRotation();
+        int degrees = 0;
+        switch (rotation) {
+            case Surface.ROTATION_90: degrees = 90; break;
+            case Surface.ROTATION_180: degrees = 180; break;
+            case Surface.ROTATION_270: degrees = 270; break;
+        }
+        if (info.facing == Camera.CameraInfo.CAMERA_FACING_FRONT) {
+            return (360 - (info.orientation + degrees) % 360) % 360;
+        }
+        return (info.orientation - degrees + 360) % 360;
+    }
+
+    void updateTorch() {
+        if (camera == null) {
+            return;
+        }
+        Camera.Parameters params = camera.getParameters();
+        List<String> flashModes = params.getSupportedFlashModes();
+        if (flashModes == null || !flashModes.contains(Camera.Parameters.FLASH_MODE_TORCH)) {
+            return;
+        }
+        params.setFlashMode(torch ? Camera.Parameters.FLASH_MODE_TORCH : Camera.Parameters.FLASH_MODE_OFF);
+        camera.setParameters(params);
+    }
+
+    void capturePhoto() {
+        if (camera == null) {
+            callError("camera: not running");
+            return;
+        }
+        camera.takePicture(null, null, new Camera.PictureCallback() {
+            @Override
+            public void onPictureTaken(byte[] data, Camera camera) {
+                viewNode.call("OnPhoto", new GoValue(data));
+                camera.startPreview();
+            }
+        });
+    }
+
+    @Override
+    public void onPreviewFrame(final byte[] data, final Camera camera) {
+        final int frameWidth = this.frameWidth;
+        final Handler handler = frameHandler;
+        if (frameWidth <= 0 || handler == null) {
+            camera.addCallbackBuffer(data);
+            return;
+        }
+        final int width = previewSize.width;
+        final int height = previewSize.height;
+        handler.post(new Runnable() {
+            @Override
+            public void run() {
+                // Downsample the luminance plane, which comes first in NV21, by
+                // skipping pixels.
+                int step = Math.max((width + frameWidth - 1) / frameWidth, 1);
+                int w = width / step;
+                int h = height / step;
+                if (frameBuffer == null || frameBuffer.capacity() < w * h) {
+                    frameBuffer = ByteBuffer.allocateDirect(w * h);
+                }
+                frameBuffer.clear();
+                for (int y = 0; y < h; y++) {
+                    int row = y * step * width;
+                    for (int x = 0; x < w; x++) {
+                        frameBuffer.put(data[row + x * step]);
+                    }
+                }
+                frameBuffer.flip();
+                onFrame.call("", new GoValue(viewId), new GoValue(w), new GoValue(h), GoValue.fromDirectBuffer(frameBuffer));
+
+                post(new Runnable() {
+                    @Override
+                    public void run() {
+                        if (MatchaCameraView.this.camera == camera) {
+                            camera.addCallbackBuffer(data);
+                        }
+                    }
+                });
+            }
+        });
+    }
+
+    void callError(String message) {
+        viewNode.call("OnError", new GoValue(message));
+    }
+
+    @Override
+    protected void onAttachedToWindow() {
+        super.onAttachedToWindow();
+        attached = true;
+        if (position >= 0) {
+            openCamera();
+        }
+    }
+
+    @Override
+    protected void onDetachedFromWindow() {
+        super.onDetachedFromWindow();
+        attached = false;
+        closeCamera();
+    }
+
+    @Override
+    public void surfaceCreated(SurfaceHolder holder) {
+        surfaceReady = true;
+        startPreview();
+    }
+
+    @Override
+    public void surfaceChanged(SurfaceHolder holder, int format, int width, int height) {
+    }
+
+    @Override
+    public void surfaceDestroyed(SurfaceHolder holder) {
+        surfaceReady = false;
+        if (camera != null) {
+            camera.stopPreview();
+        }
+    }
+}
@@ -0,0 +1,216 @@
+package io.gomatcha.matcha;
+
+import android.content.Context;
+import android.graphics.Canvas;
+import android.graphics.Color;
+import android.graphics.Paint;
+import android.graphics.Path;
+import android.graphics.RectF;
+import android.graphics.Typeface;
+import android.util.DisplayMetrics;
+
+import org.json.JSONArray;
+import org.json.JSONException;
+
+import java.util.ArrayList;
+
+import io.gomatcha.bridge.GoValue;
+
+class MatchaCanvasView extends MatchaChildView {
+    MatchaViewNode viewNode;
+
+    static {
+        MatchaView.registerView("gomatcha.io/matcha/view/canvas", new MatchaView.ViewFactory() {
+            @Override
+            public MatchaChildView createView(Context context, MatchaViewNode node) {
+                return new MatchaCanvasView(context, node);
+            }
+        });
+    }
+
+    public MatchaCanvasView(Context context, MatchaViewNode node) {
+        super(context);
+        viewNode = node;
+        setWillNotDraw(false);
+    }
+
+    @Override
+    public void setNativeState(byte[] nativeState) {
+        super.setNativeState(nativeState);
+        // Go can't be called while it is updating the views, so the display
+        // list is read when the view is drawn.
+        invalidate();
+    }
+
+    @Override
+    protected void onSizeChanged(int w, int h, int oldw, int oldh) {
+        super.onSizeChanged(w, h, oldw, oldh);
+        invalidate();
+    }
+
+    // State is the part of the drawing settings that isn't saved by
+    // Canvas.save().
+    static class State {
+        Paint fill;
+        Paint stroke;
+        int direction;
+
+        State() {
+            fill = new Paint(Paint.ANTI_ALIAS_FLAG);
+            fill.setStyle(Paint.Style.FILL);
+            fill.setColor(Color.BLACK);
+            stroke = new Paint(Paint.ANTI_ALIAS_FLAG);
+            stroke.setStyle(Paint.Style.STROKE);
+            stroke.setColor(Color.BLACK);
+            stroke.setStrokeWidth(1);
+            stroke.setStrokeMiter(10);
+        }
+
+        State(State s) {
+            fill = new Paint(s.fill);
+            stroke = new Paint(s.stroke);
+            direction = s.direction;
+        }
+    }
+
+    @Override
+    protected void onDraw(Canvas canvas) {
+        super.onDraw(canvas);
+
+        float ratio = (float)getResources().getDisplayMetrics().densityDpi / DisplayMetrics.DENSITY_DEFAULT;
+        GoValue[] rlt = viewNode.call("Draw", new GoValue(getWidth() / ratio), new GoValue(getHeight() / ratio));
+        JSONArray ops;
+        try {
+            ops = new JSONArray(new String(rlt[0].toByteArray(), "UTF-8"));
+        } catch (Exception e) {
+            return;
+        }
+
+        int saveCount = canvas.save();
+        canvas.scale(ratio, ratio);
+        Path path = new Path();
+        State state = new State();
+        state.fill.setTextSize(14);
+        ArrayList<State> stack = new ArrayList<State>();
+        for (int i = 0; i < ops.length(); i++) {
+            try {
+                JSONArray op = ops.getJSONArray(i);
+                String name = op.getString(0);
+                if (name.equals("save")) {
+                    canvas.save();
+                    stack.add(state);
+                    state = new State(state);
+                } else if (name.equals("restore")) {
+                    if (stack.size() > 0) {
+                        canvas.restore();
+                        state = stack.remove(stack.size() - 1);
+                    }
+                } else if (name.equals("translate")) {
+                    canvas.translate(arg(op, 1), arg(op, 2));
+                } else if (name.equals("scale")) {
+                    canvas.scale(arg(op, 1), arg(op, 2));
+                } else if (name.equals("rotate")) {
+                    canvas.rotate((float)Math.toDegrees(op.getDouble(1)));
+                } else if (name.equals("begin")) {
+                    path = new Path();
+                } else if (name.equals("move")) {
+                    path.moveTo(arg(op, 1), arg(op, 2));
+                } else if (name.equals("line")) {
+                    if (path.isEmpty()) {
+                        path.moveTo(arg(op, 1), arg(op, 2));
+                    } else {
+                        path.lineTo(arg(op, 1), arg(op, 2));
+                    }
+                } else if (name.equals("quad")) {
+                    path.quadTo(arg(op, 1), arg(op, 2), arg(op, 3), arg(op, 4));
+                } else if (name.equals("cubic")) {
+                    path.cubicTo(arg(op, 1), arg(op, 2), arg(op, 3), arg(op, 4), arg(op, 5), arg(op, 6));
+                } else if (name.equals("arc")) {
+                    float x = arg(op, 1), y = arg(op, 2), r = arg(op, 3);
+                    RectF oval = new RectF(x - r, y - r, x + r, y + r);
+                    path.arcTo(oval, (float)Math.toDegrees(op.getDouble(4)), (float)Math.toDegrees(op.getDouble(5)), path.isEmpty());
+                } else if (name.equals("rect")) {
+                    path.addRect(arg(op, 1), arg(op, 2), arg(op, 1) + arg(op, 3), arg(op, 2) + arg(op, 4), Path.Direction.CW);
+                } else if (name.equals("ellipse")) {
+                    path.addOval(new RectF(arg(op, 1), arg(op, 2), arg(op, 1) + arg(op, 3), arg(op, 2) + arg(op, 4)), Path.Direction.CW);
+                } else if (name.equals("close")) {
+                    path.close();
+                } else if (name.equals("fillColor")) {
+                    state.fill.setColor(color(op));
+                } else if (name.equals("strokeColor")) {
+                    state.stroke.setColor(color(op));
+                } else if (name.equals("lineWidth")) {
+                    state.stroke.setStrokeWidth(arg(op, 1));
+                } else if (name.equals("lineCap")) {
+                    Paint.Cap[] caps = {Paint.Cap.BUTT, Paint.Cap.ROUND, Paint.Cap.SQUARE};
+                    state.stroke.setStrokeCap(caps[Math.min(Math.max(op.getInt(1), 0), 2)]);
+                } else if (name.equals("lineJoin")) {
+                    Paint.Join[] joins = {Paint.Join.MITER, Paint.Join.ROUND, Paint.Join.BEVEL};
+                    state.stroke.setStrokeJoin(joins[Math.min(Math.max(op.getInt(1), 0), 2)]);
+                } else if (name.equals("font")) {
+                    state.fill.setTypeface(typeface(op.getString(1)));
+                    state.fill.setTextSize(arg(op, 2));
+                } else if (name.equals("textAlign")) {
+                    Paint.Align[] aligns = {Paint.Align.LEFT, Paint.Align.CENTER, Paint.Align.RIGHT};
+                    state.fill.setTextAlign(aligns[Math.min(Math.max(op.getInt(1), 0), 2)]);
+                } else if (name.equals("direction")) {
+                    state.direction = op.getInt(1);
+                } else if (name.equals("fill")) {
+                    canvas.drawPath(path, state.fill);
+                } else if (name.equals("stroke")) {
+                    canvas.drawPath(path, state.stroke);
+                } else if (name.equals("clip")) {
+                    canvas.clipPath(path);
+                } else if (name.equals("text")) {
+                    canvas.drawText(embed(op.getString(1), state.direction), arg(op, 2), arg(op, 3) - state.fill.ascent(), state.fill);
+                }
+            } catch (JSONException e) {
+            }
+        }
+        canvas.restoreToCount(saveCount);
+    }
+
+    // embed wraps str in directional embedding characters, since Paint only
+    // takes the direction from the first strong character.
+    static String embed(String str, int direction) {
+        if (direction == 1) {
+            return "\u202A" + str + "\u202C";
+        } else if (direction == 2) {
+            return "\u202B" + str + "\u202C";
+        }
+        return str;
+    }
+
+    static float arg(JSONArray op, int idx) throws JSONException {
+        return (float)op.getDouble(idx);
+    }
+
+    static int color(JSONArray op) throws JSONException {
+        return Color.argb((int)Math.round(op.getDouble(4) * 255), (int)Math.round(op.getDouble(1) * 255), (int)Math.round(op.getDouble(2) * 255), (int)Math.round(op.getDouble(3) * 255));
+    }
+
+    // typeface returns the typeface for a font family, which may end in -bold,
+    // -italic or -bolditalic like the text views' fonts.
+    static Typeface typeface(String family) {
+        Typeface registered = MatchaFonts.get(family);
+        if (registered != null) {
+            return registered;
+        }
+        int style = Typeface.NORMAL;
+        if (family.endsWith("-bolditalic")) {
+            family = family.substring(0, family.length() - 11);
+            style = Typeface.BOLD_ITALIC;
+        } else if (family.endsWith("-bold")) {
+            family = family.substring(0, family.length() - 5);
+            style = Typeface.BOLD;
+        } else if (family.endsWith("-italic")) {
+            family = family.substring(0, family.length() - 7);
+            style = Typeface.ITALIC;
+        }
+        registered = MatchaFonts.get(family);
+        if (registered != null) {
+            return Typeface.create(registered, style);
+        }
+        return Typeface.create(family, style);
+    }
+}
@@ -0,0 +1,186 @@
+package io.gomatcha.matcha;
+
+import android.content.Context;
+import android.support.v4.view.PagerAdapter;
+import android.support.v4.view.ViewPager;
+import android.util.SparseArray;
+import android.view.View;
+import android.view.ViewGroup;
+import android.widget.FrameLayout;
+
+import java.util.ArrayList;
+import java.util.HashMap;
+import java.util.List;
+import java.util.Map;
+
+import io.gomatcha.bridge.GoValue;
+
+class MatchaCarouselView extends MatchaChildView {
+    MatchaViewNode viewNode;
+    ViewPager viewPager;
+    MatchaCarouselAdapter adapter;
+    List<View> childViews = new ArrayList<View>();
+    Map<Long, View> pageViews = new HashMap<Long, View>();
+    SparseArray<MatchaCarouselPage> pages = new SparseArray<MatchaCarouselPage>();
+    boolean needsUpdate;
+    int count;
+    int current = -1;
+
+    static {
+        MatchaView.registerView("gomatcha.io/matcha/view/pagerview", new MatchaView.ViewFactory() {
+            @Override
+            public MatchaChildView createView(Context context, MatchaViewNode node) {
+                return new MatchaCarouselView(context, node);
+            }
+        });
+    }
+
+    public MatchaCarouselView(Context context, MatchaViewNode node) {
+        super(context);
+        viewNode = node;
+
+        adapter = new MatchaCarouselAdapter();
+        viewPager = new ViewPager(context);
+        viewPager.setAdapter(adapter);
+        viewPager.setOffscreenPageLimit(1);
+        viewPager.addOnPageChangeListener(new ViewPager.SimpleOnPageChangeListener() {
+            @Override
+            public void onPageSelected(int position) {
+                if (position != current) {
+                    current = position;
+                    viewNode.call("OnChange", new GoValue(position));
+                }
+            }
+        });
+        addView(viewPager);
+    }
+
+    @Override
+    public void setNativeState(byte[] nativeState) {
+        super.setNativeState(nativeState);
+        setNeedsUpdate();
+    }
+
+    @Override
+    public boolean isContainerView() {
+        return true;
+    }
+
+    @Override
+    public void setChildViews(List<View> childViews) {
+        this.childViews = childViews;
+        setNeedsUpdate();
+    }
+
+    // Go can't be called while it is updating the views, so the pages are read
+    // once the update completes.
+    void setNeedsUpdate() {
+        if (needsUpdate) {
+            return;
+        }
+        needsUpdate = true;
+        post(new Runnable() {
+            @Override
+            public void run() {
+                needsUpdate = false;
+                update();
+            }
+        });
+    }
+
+    void update() {
+        GoValue[] state = viewNode.call("State");
+        int count = (int)state[0].toLong();
+        int current = (int)state[1].toLong();
+        GoValue[] indexes = state[2].toArray();
+
+        Map<Long, View> pageViews = new HashMap<Long, View>();
+        for (int i = 0; i < indexes.length && i < childViews.size(); i++) {
+            pageViews.put(indexes[i].toLong(), childViews.get(i));
+        }
+        this.pageViews = pageViews;
+        for (int i = 0; i < pages.size(); i++) {
+            pages.valueAt(i).setMatchaView(pageViews.get((long)pages.keyAt(i)));
+        }
+
+        // Go's current page is stored before the pages change, so that it isn't
+        // reported back as a swipe.
+        boolean animated = this.current != -1;
+        boolean scroll = current != this.current;
+        this.current = current;
+        if (count != this.count) {
+            this.count = count;
+            adapter.notifyDataSetChanged();
+        }
+        if (scroll && current < count) {
+            // Scroll to the page set by Go. The first page is shown without
+            // animating.
+            viewPager.setCurrentItem(current, animated);
+        }
+    }
+
+    class MatchaCarouselAdapter extends PagerAdapter {
+        @Override
+        public int getCount() {
+            return count;
+        }
+
+        @Override
+        public int getItemPosition(Object object) {
+            MatchaCarouselPage page = (MatchaCarouselPage)object;
+            return page.position < count ? POSITION_UNCHANGED : POSITION_NONE;
+        }
+
+        @Override
+        public boolean isViewFromObject(View view, Object object) {
+            return object == view;
+        }
+
+        @Override
+        public Object instantiateItem(ViewGroup container, int position) {
+            // The page is empty until Go builds it.
+            MatchaCarouselPage page = new MatchaCarouselPage(container.getContext(), position);
+            page.setMatchaView(pageViews.get((long)position));
+            pages.put(position, page);
+            container.addView(page);
+            return page;
+        }
+
+        @Override
+        public void destroyItem(ViewGroup container, int position, Object object) {
+            MatchaCarouselPage page = (MatchaCarouselPage)object;
+            page.setMatchaView(null);
+            if (pages.get(position) == page) {
+                pages.remove(position);
+            }
+            container.removeView(page);
+        }
+    }
+
+    static class MatchaCarouselPage extends FrameLayout {
+        int position;
+        View matchaView;
+
+        MatchaCarouselPage(Context context, int position) {
+            super(context);
+            this.position = position;
+        }
+
+        void setMatchaView(View view) {
+            if (matchaView == view) {
+                return;
+            }
+            // The view may have moved to another page already.
+            if (matchaView != null && matchaView.getParent() == this) {
+                removeView(matchaView);
+            }
+            matchaView = view;
+            if (view != null) {
+                if (view.getParent() != null) {
+                    ((ViewGroup)view.getParent()).removeView(view);
+                }
+                addView(view, new FrameLayout.LayoutParams(FrameLayout.LayoutParams.MATCH_PARENT, FrameLayout.LayoutParams.MATCH_PARENT));
+            }
+        }
+    }
+}
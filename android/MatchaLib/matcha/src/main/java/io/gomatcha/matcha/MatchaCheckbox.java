@@ -0,0 +1,91 @@
+package io.gomatcha.matcha;
+
+import android.content.Context;
+import android.content.res.ColorStateList;
+import android.support.v4.widget.CompoundButtonCompat;
+import android.support.v7.widget.AppCompatCheckBox;
+import android.view.Gravity;
+import android.widget.CompoundButton;
+import android.widget.FrameLayout;
+
+import io.gomatcha.bridge.GoValue;
+
+class MatchaCheckbox extends MatchaChildView {
+    MatchaViewNode viewNode;
+    AppCompatCheckBox view;
+    ColorStateList defaultTint;
+    boolean checked;
+    boolean needsUpdate;
+
+    static {
+        MatchaView.registerView("gomatcha.io/matcha/view/checkbox", new MatchaView.ViewFactory() {
+            @Override
+            public MatchaChildView createView(Context context, MatchaViewNode node) {
+                return new MatchaCheckbox(context, node);
+            }
+        });
+    }
+
+    public MatchaCheckbox(Context context, MatchaViewNode node) {
+        super(context);
+        viewNode = node;
+
+        view = new AppCompatCheckBox(context);
+        view.setGravity(Gravity.CENTER_VERTICAL);
+        defaultTint = CompoundButtonCompat.getButtonTintList(view);
+        view.setOnCheckedChangeListener(new CompoundButton.OnCheckedChangeListener() {
+            @Override
+            public void onCheckedChanged(CompoundButton buttonView, boolean isChecked) {
+                if (isChecked != checked) {
+                    checked = isChecked;
+                    viewNode.call("OnChange", new GoValue(isChecked));
+                }
+            }
+        });
+        addView(view, new FrameLayout.LayoutParams(FrameLayout.LayoutParams.MATCH_PARENT, FrameLayout.LayoutParams.MATCH_PARENT));
+    }
+
+    @Override
+    public void setNativeState(byte[] nativeState) {
+        super.setNativeState(nativeState);
+        setNeedsUpdate();
+    }
+
+    // Go can't be called while it is updating the views, so the state is read
+    // once the update completes.
+    void setNeedsUpdate() {
+        if (needsUpdate) {
+            return;
+        }
+        needsUpdate = true;
+        post(new Runnable() {
+            @Override
+            public void run() {
+                needsUpdate = false;
+                update();
+            }
+        });
+    }
+
+    void update() {
+        GoValue[] state = viewNode.call("State");
+        // Go's value is stored first, so that it isn't reported back.
+        checked = state[0].toBool();
+        view.setChecked(checked);
+        view.setEnabled(state[1].toBool());
+        view.setText(state[2].toString());
+        long color = state[3].toLong();
+        CompoundButtonCompat.setButtonTintList(view, color == -1 ? defaultTint : tintList((int)color));
+    }
+
+    // tintList returns colors that use color when the button is checked.
+    static ColorStateList tintList(int color) {
+        int[][] states = new int[][]{
+            new int[]{-android.R.attr.state_enabled},
+            new int[]{android.R.attr.state_checked},
+            new int[]{},
+        };
+        int[] colors = new int[]{0x61000000, color, 0x8A000000};
+        return new ColorStateList(states, colors);
+    }
+}
@@ -0,0 +1,253 @@
+package io.gomatcha.matcha;
+
+import android.content.Context;
+import android.graphics.Rect;
+import android.support.v7.widget.GridLayoutManager;
+import android.support.v7.widget.RecyclerView;
+import android.util.DisplayMetrics;
+import android.view.View;
+import android.view.ViewGroup;
+import android.widget.FrameLayout;
+
+import java.util.ArrayList;
+import java.util.HashMap;
+import java.util.List;
+import java.util.Map;
+
+import io.gomatcha.bridge.GoValue;
+
+class MatchaCollectionView extends MatchaChildView {
+    MatchaViewNode viewNode;
+    RecyclerView recyclerView;
+    GridLayoutManager layoutManager;
+    MatchaCollectionAdapter adapter;
+    List<View> childViews = new ArrayList<View>();
+    Map<Long, View> itemViews = new HashMap<Long, View>();
+    boolean needsReload;
+    long generation = -1;
+    int count;
+    int columns = 1;
+    boolean horizontal;
+    double spacing;
+    int firstVisibleItem = -1;
+    int lastVisibleItem = -1;
+
+    static {
+        MatchaView.registerView("gomatcha.io/matcha/view/collectionview", new MatchaView.ViewFactory() {
+            @Override
+            public MatchaChildView createView(Context context, MatchaViewNode node) {
+                return new MatchaCollectionView(context, node);
+            }
+        });
+    }
+
+    public MatchaCollectionView(Context context, MatchaViewNode node) {
+        super(context);
+        viewNode = node;
+
+        layoutManager = new GridLayoutManager(context, 1);
+        layoutManager.setSpanSizeLookup(new GridLayoutManager.SpanSizeLookup() {
+            @Override
+            public int getSpanSize(int position) {
+                return (int)viewNode.call("Item", new GoValue(position))[1].toLong();
+            }
+        });
+        adapter = new MatchaCollectionAdapter();
+        recyclerView = new RecyclerView(context);
+        recyclerView.setLayoutManager(layoutManager);
+        recyclerView.setAdapter(adapter);
+        recyclerView.addItemDecoration(new SpacingDecoration());
+        recyclerView.addOnScrollListener(new RecyclerView.OnScrollListener() {
+            @Override
+            public void onScrolled(RecyclerView recyclerView, int dx, int dy) {
+                updateVisibleItems();
+            }
+        });
+        addView(recyclerView);
+    }
+
+    @Override
+    public void setNativeState(byte[] nativeState) {
+        super.setNativeState(nativeState);
+        setNeedsReload();
+    }
+
+    @Override
+    public boolean isContainerView() {
+        return true;
+    }
+
+    @Override
+    public void setChildViews(List<View> childViews) {
+        this.childViews = childViews;
+        setNeedsReload();
+    }
+
+    // Go can't be called while it is updating the views, so the items are read
+    // once the update completes.
+    void setNeedsReload() {
+        if (needsReload) {
+            return;
+        }
+        needsReload = true;
+        post(new Runnable() {
+            @Override
+            public void run() {
+                needsReload = false;
+                reloadItems();
+            }
+        });
+    }
+
+    void reloadItems() {
+        GoValue[] rows = viewNode.call("Rows");
+        int count = (int)rows[0].toLong();
+        long generation = rows[1].toLong();
+
+        GoValue[] childRows = viewNode.call("ChildRows")[0].toArray();
+        Map<Long, View> itemViews = new HashMap<Long, View>();
+        for (int i = 0; i < childRows.length && i < childViews.size(); i++) {
+            itemViews.put(childRows[i].toLong(), childViews.get(i));
+        }
+        this.itemViews = itemViews;
+
+        if (generation != this.generation || count != this.count) {
+            GoValue[] grid = viewNode.call("Grid");
+            columns = (int)grid[0].toLong();
+            horizontal = grid[1].toBool();
+            spacing = grid[2].toDouble();
+            layoutManager.setSpanCount(columns);
+            layoutManager.setOrientation(horizontal ? GridLayoutManager.HORIZONTAL : GridLayoutManager.VERTICAL);
+
+            this.generation = generation;
+            this.count = count;
+            adapter.notifyDataSetChanged();
+            recyclerView.invalidateItemDecorations();
+        } else {
+            for (int i = 0; i < recyclerView.getChildCount(); i++) {
+                MatchaCollectionViewHolder holder = (MatchaCollectionViewHolder)recyclerView.getChildViewHolder(recyclerView.getChildAt(i));
+                holder.setMatchaView(itemViews.get((long)holder.getAdapterPosition()));
+            }
+        }
+        updateVisibleItems();
+    }
+
+    void updateVisibleItems() {
+        int first = Math.max(layoutManager.findFirstVisibleItemPosition(), 0);
+        int last = layoutManager.findLastVisibleItemPosition() + 1;
+        if (first == firstVisibleItem && last == lastVisibleItem) {
+            return;
+        }
+        firstVisibleItem = first;
+        lastVisibleItem = last;
+        viewNode.call("OnVisible", new GoValue(first), new GoValue(last));
+    }
+
+    double ratio() {
+        return (float)getResources().getDisplayMetrics().densityDpi / DisplayMetrics.DENSITY_DEFAULT;
+    }
+
+    // SpacingDecoration spaces the items so that their sizes match the ones
+    // computed in Go.
+    class SpacingDecoration extends RecyclerView.ItemDecoration {
+        @Override
+        public void getItemOffsets(Rect outRect, View view, RecyclerView parent, RecyclerView.State state) {
+            GridLayoutManager.LayoutParams params = (GridLayoutManager.LayoutParams)view.getLayoutParams();
+            int column = params.getSpanIndex();
+            int span = params.getSpanSize();
+            int spacing = (int)(MatchaCollectionView.this.spacing * ratio());
+            int start = column * spacing / columns;
+            int end = spacing - (column + span) * spacing / columns;
+            int position = params.getViewAdapterPosition();
+            int line = position == RecyclerView.NO_POSITION ? 0 : layoutManager.getSpanSizeLookup().getSpanGroupIndex(position, columns);
+            int previous = line > 0 ? spacing : 0;
+            if (horizontal) {
+                outRect.set(previous, start, 0, end);
+            } else {
+                outRect.set(start, previous, end, 0);
+            }
+        }
+    }
+
+    class MatchaCollectionAdapter extends RecyclerView.Adapter<MatchaCollectionViewHolder> {
+        Map<String, Integer> viewTypes = new HashMap<String, Integer>();
+
+        @Override
+        public int getItemCount() {
+            return count;
+        }
+
+        @Override
+        public int getItemViewType(int position) {
+            String reuseId = viewNode.call("Item", new GoValue(position))[0].toString();
+            Integer viewType = viewTypes.get(reuseId);
+            if (viewType == null) {
+                viewType = viewTypes.size();
+                viewTypes.put(reuseId, viewType);
+            }
+            return viewType;
+        }
+
+        @Override
+        public MatchaCollectionViewHolder onCreateViewHolder(ViewGroup parent, int viewType) {
+            FrameLayout frameLayout = new FrameLayout(parent.getContext());
+            frameLayout.setLayoutParams(new GridLayoutManager.LayoutParams(GridLayoutManager.LayoutParams.MATCH_PARENT, GridLayoutManager.LayoutParams.MATCH_PARENT));
+            return new MatchaCollectionViewHolder(frameLayout);
+        }
+
+        @Override
+        public void onBindViewHolder(MatchaCollectionViewHolder holder, int position) {
+            GoValue[] item = viewNode.call("Item", new GoValue(position));
+            RecyclerView.LayoutParams params = (RecyclerView.LayoutParams)holder.itemView.getLayoutParams();
+            if (horizontal) {
+                params.width = (int)(item[2].toDouble() * ratio());
+                params.height = RecyclerView.LayoutParams.MATCH_PARENT;
+            } else {
+                params.width = RecyclerView.LayoutParams.MATCH_PARENT;
+                params.height = (int)(item[3].toDouble() * ratio());
+            }
+            holder.itemView.setLayoutParams(params);
+            holder.setMatchaView(itemViews.get((long)position));
+        }
+
+        @Override
+        public void onViewRecycled(MatchaCollectionViewHolder holder) {
+            holder.setMatchaView(null);
+        }
+    }
+
+    class MatchaCollectionViewHolder extends RecyclerView.ViewHolder {
+        View matchaView;
+
+        MatchaCollectionViewHolder(View itemView) {
+            super(itemView);
+            itemView.setOnClickListener(new OnClickListener() {
+                @Override
+                public void onClick(View v) {
+                    int position = getAdapterPosition();
+                    if (position != RecyclerView.NO_POSITION) {
+                        viewNode.call("OnSelect", new GoValue(position));
+                    }
+                }
+            });
+        }
+
+        void setMatchaView(View view) {
+            if (matchaView == view) {
+                return;
+            }
+            // The view may have moved to another cell already.
+            FrameLayout frameLayout = (FrameLayout)itemView;
+            if (matchaView != null && matchaView.getParent() == frameLayout) {
+                frameLayout.removeView(matchaView);
+            }
+            matchaView = view;
+            if (view != null) {
+                if (view.getParent() != null) {
+                    ((ViewGroup)view.getParent()).removeView(view);
+                }
+                frameLayout.addView(view, new FrameLayout.LayoutParams(FrameLayout.LayoutParams.MATCH_PARENT, FrameLayout.LayoutParams.MATCH_PARENT));
+            }
+        }
+    }
+}
@@ -0,0 +1,94 @@
+package io.gomatcha.matcha;
+
+import android.graphics.Color;
+import android.text.SpannableString;
+import android.text.style.ForegroundColorSpan;
+import android.view.Menu;
+import android.view.MenuItem;
+import android.view.SubMenu;
+import android.view.View;
+import android.widget.PopupMenu;
+
+import com.google.protobuf.InvalidProtocolBufferException;
+
+import io.gomatcha.bridge.GoValue;
+import io.gomatcha.matcha.proto.Proto;
+
+// MatchaContextMenu shows the context menu of a view in a PopupMenu when it is
+// long-pressed.
+class MatchaContextMenu implements View.OnLongClickListener {
+    static final String KEY = "gomatcha.io/matcha/contextmenu";
+
+    // Must match the flags in view/contextmenu.
+    static final long FLAG_DESTRUCTIVE = 1 << 0;
+    static final long FLAG_DISABLED = 1 << 1;
+    static final long FLAG_SUBMENU = 1 << 2;
+
+    MatchaViewNode viewNode;
+
+    MatchaContextMenu(MatchaViewNode viewNode) {
+        this.viewNode = viewNode;
+    }
+
+    @Override
+    public boolean onLongClick(View view) {
+        GoValue[] rlt = viewNode.call(KEY + " Items");
+        if (rlt.length < 5) {
+            return false;
+        }
+        GoValue[] parents = rlt[1].toArray();
+        GoValue[] titles = rlt[2].toArray();
+        GoValue[] flags = rlt[3].toArray();
+        GoValue[] icons = rlt[4].toArray();
+        if (parents.length == 0) {
+            return false;
+        }
+
+        PopupMenu popup = new PopupMenu(view.getContext(), view);
+        // Parents always come before their children, so their menus exist when
+        // the children are added.
+        Menu[] submenus = new Menu[parents.length];
+        for (int i = 0; i < parents.length; i++) {
+            int parent = (int)parents[i].toLong();
+            Menu menu = parent >= 0 && parent < i && submenus[parent] != null ? submenus[parent] : popup.getMenu();
+            long flag = flags[i].toLong();
+
+            CharSequence title = titles[i].toString();
+            if ((flag & FLAG_DESTRUCTIVE) != 0) {
+                SpannableString str = new SpannableString(title);
+                str.setSpan(new ForegroundColorSpan(Color.RED), 0, str.length(), 0);
+                title = str;
+            }
+
+            MenuItem item;
+            if ((flag & FLAG_SUBMENU) != 0) {
+                SubMenu submenu = menu.addSubMenu(Menu.NONE, i, i, title);
+                submenus[i] = submenu;
+                item = submenu.getItem();
+            } else {
+                item = menu.add(Menu.NONE, i, i, title);
+            }
+            item.setEnabled((flag & FLAG_DISABLED) == 0);
+
+            byte[] icon = icons[i].toByteArray();
+            if (icon != null && icon.length > 0) {
+                try {
+                    item.setIcon(Protobuf.newDrawable(Proto.ImageOrResource.parseFrom(icon), view.getContext()));
+                } catch (InvalidProtocolBufferException e) {
+                }
+            }
+        }
+        popup.setOnMenuItemClickListener(new PopupMenu.OnMenuItemClickListener() {
+            @Override
+            public boolean onMenuItemClick(MenuItem item) {
+                if (item.hasSubMenu()) {
+                    return false;
+                }
+                viewNode.call(KEY + " OnSelect", new GoValue(item.getItemId()));
+                return true;
+            }
+        });
+        popup.show();
+        return true;
+    }
+}
@@ -0,0 +1,142 @@
+package io.gomatcha.matcha;
+
+import android.app.DatePickerDialog;
+import android.app.TimePickerDialog;
+import android.content.Context;
+import android.util.TypedValue;
+import android.view.Gravity;
+import android.view.View;
+import android.widget.DatePicker;
+import android.widget.TextView;
+import android.widget.TimePicker;
+
+import java.text.DateFormat;
+import java.util.Calendar;
+import java.util.Date;
+
+import io.gomatcha.bridge.GoValue;
+
+class MatchaDatePicker extends MatchaChildView {
+    TextView view;
+    MatchaViewNode viewNode;
+    long mode;
+    long value;
+    long min;
+    long max;
+    boolean needsUpdate;
+
+    static {
+        MatchaView.registerView("gomatcha.io/matcha/view/datepicker", new MatchaView.ViewFactory() {
+            @Override
+            public MatchaChildView createView(Context context, MatchaViewNode node) {
+                return new MatchaDatePicker(context, node);
+            }
+        });
+    }
+
+    public MatchaDatePicker(Context context, MatchaViewNode node) {
+        super(context);
+        viewNode = node;
+
+        view = new TextView(context);
+        view.setGravity(Gravity.CENTER_VERTICAL);
+        view.setTextSize(TypedValue.COMPLEX_UNIT_SP, 16);
+        view.setOnClickListener(new View.OnClickListener() {
+            @Override
+            public void onClick(View v) {
+                if (mode == 1) {
+                    showTimeDialog(value);
+                } else {
+                    showDateDialog();
+                }
+            }
+        });
+        addView(view);
+    }
+
+    @Override
+    public void setNativeState(byte[] nativeState) {
+        super.setNativeState(nativeState);
+
+        // Go can't be called while it is updating the views, so the state is read
+        // once the update completes.
+        if (needsUpdate) {
+            return;
+        }
+        needsUpdate = true;
+        post(new Runnable() {
+            @Override
+            public void run() {
+                needsUpdate = false;
+                update();
+            }
+        });
+    }
+
+    void update() {
+        GoValue[] state = viewNode.call("State");
+        mode = state[0].toLong();
+        value = state[1].toLong();
+        min = state[2].toLong();
+        max = state[3].toLong();
+        view.setEnabled(state[4].toBool());
+
+        DateFormat format;
+        if (mode == 1) {
+            format = DateFormat.getTimeInstance(DateFormat.SHORT);
+        } else if (mode == 2) {
+            format = DateFormat.getDateTimeInstance(DateFormat.MEDIUM, DateFormat.SHORT);
+        } else {
+            format = DateFormat.getDateInstance(DateFormat.MEDIUM);
+        }
+        view.setText(format.format(new Date(value)));
+    }
+
+    void showDateDialog() {
+        Calendar c = Calendar.getInstance();
+        c.setTimeInMillis(value);
+        DatePickerDialog dialog = new DatePickerDialog(getContext(), new DatePickerDialog.OnDateSetListener() {
+            @Override
+            public void onDateSet(DatePicker picker, int year, int month, int day) {
+                Calendar c = Calendar.getInstance();
+                c.setTimeInMillis(value);
+                c.set(year, month, day);
+                if (mode == 2) {
+                    showTimeDialog(c.getTimeInMillis());
+                } else {
+                    onChange(c.getTimeInMillis());
+                }
+            }
+        }, c.get(Calendar.YEAR), c.get(Calendar.MONTH), c.get(Calendar.DAY_OF_MONTH));
+        if (min != 0) {
+            dialog.getDatePicker().setMinDate(min);
+        }
+        if (max != 0) {
+            dialog.getDatePicker().setMaxDate(max);
+        }
+        dialog.show();
+    }
+
+    // The time dialog doesn't support bounds, the chosen time is clamped by Go.
+    void showTimeDialog(final long date) {
+        Calendar c = Calendar.getInstance();
+        c.setTimeInMillis(date);
+        TimePickerDialog dialog = new TimePickerDialog(getContext(), new TimePickerDialog.OnTimeSetListener() {
+            @Override
+            public void onTimeSet(TimePicker picker, int hour, int minute) {
+                Calendar c = Calendar.getInstance();
+                c.setTimeInMillis(date);
+                c.set(Calendar.HOUR_OF_DAY, hour);
+                c.set(Calendar.MINUTE, minute);
+                c.set(Calendar.SECOND, 0);
+                c.set(Calendar.MILLISECOND, 0);
+                onChange(c.getTimeInMillis());
+            }
+        }, c.get(Calendar.HOUR_OF_DAY), c.get(Calendar.MINUTE), android.text.format.DateFormat.is24HourFormat(getContext()));
+        dialog.show();
+    }
+
+    void onChange(long millis) {
+        viewNode.call("OnChange", new GoValue(millis));
+    }
+}
@@ -0,0 +1,143 @@
+package io.gomatcha.matcha;
+
+import android.content.Context;
+import android.support.v4.view.GravityCompat;
+import android.support.v4.view.ViewCompat;
+import android.support.v4.widget.DrawerLayout;
+import android.view.View;
+import android.view.ViewGroup;
+import android.widget.FrameLayout;
+
+import java.util.List;
+
+import io.gomatcha.bridge.GoValue;
+
+class MatchaDrawerView extends MatchaChildView {
+    MatchaViewNode viewNode;
+    DrawerLayout drawerLayout;
+    FrameLayout contentView;
+    FrameLayout menuView;
+    View contentChild;
+    View menuChild;
+    boolean open;
+    boolean needsUpdate;
+
+    static {
+        MatchaView.registerView("gomatcha.io/matcha/view/android/drawer", new MatchaView.ViewFactory() {
+            @Override
+            public MatchaChildView createView(Context context, MatchaViewNode node) {
+                return new MatchaDrawerView(context, node);
+            }
+        });
+    }
+
+    public MatchaDrawerView(Context context, MatchaViewNode node) {
+        super(context);
+        viewNode = node;
+
+        drawerLayout = new DrawerLayout(context);
+        contentView = new FrameLayout(context);
+        drawerLayout.addView(contentView, new DrawerLayout.LayoutParams(DrawerLayout.LayoutParams.MATCH_PARENT, DrawerLayout.LayoutParams.MATCH_PARENT));
+        menuView = new FrameLayout(context);
+        // The menu doesn't pass touches through to the content.
+        menuView.setClickable(true);
+        drawerLayout.addView(menuView, new DrawerLayout.LayoutParams(0, DrawerLayout.LayoutParams.MATCH_PARENT, GravityCompat.START));
+        drawerLayout.addDrawerListener(new DrawerLayout.SimpleDrawerListener() {
+            @Override
+            public void onDrawerOpened(View drawerView) {
+                setOpen(true);
+            }
+
+            @Override
+            public void onDrawerClosed(View drawerView) {
+                setOpen(false);
+            }
+        });
+        addView(drawerLayout);
+    }
+
+    @Override
+    public void setNativeState(byte[] nativeState) {
+        super.setNativeState(nativeState);
+        setNeedsUpdate();
+    }
+
+    @Override
+    public boolean isContainerView() {
+        return true;
+    }
+
+    @Override
+    public void setChildViews(List<View> childViews) {
+        contentChild = replaceChild(contentView, contentChild, childViews.size() > 0 ? childViews.get(0) : null);
+        menuChild = replaceChild(menuView, menuChild, childViews.size() > 1 ? childViews.get(1) : null);
+        setNeedsUpdate();
+    }
+
+    static View replaceChild(ViewGroup parent, View prev, View child) {
+        if (child != prev) {
+            if (prev != null) {
+                parent.removeView(prev);
+            }
+            if (child != null) {
+                parent.addView(child, new FrameLayout.LayoutParams(FrameLayout.LayoutParams.MATCH_PARENT, FrameLayout.LayoutParams.MATCH_PARENT));
+            }
+        }
+        return child;
+    }
+
+    // Go can't be called while it is updating the views, so the state is read
+    // once the update completes.
+    void setNeedsUpdate() {
+        if (needsUpdate) {
+            return;
+        }
+        needsUpdate = true;
+        post(new Runnable() {
+            @Override
+            public void run() {
+                needsUpdate = false;
+                update();
+            }
+        });
+    }
+
+    void update() {
+        GoValue[] state = viewNode.call("State");
+        boolean open = state[0].toBool();
+        float ratio = getResources().getDisplayMetrics().density;
+        int width = (int)(state[1].toDouble() * ratio);
+        drawerLayout.setScrimColor((int)state[2].toLong());
+        boolean locked = state[3].toBool();
+
+        ViewGroup.LayoutParams params = menuView.getLayoutParams();
+        if (params.width != width) {
+            params.width = width;
+            menuView.setLayoutParams(params);
+        }
+
+        // Go's value is stored first, so that it isn't reported back.
+        boolean animated = ViewCompat.isAttachedToWindow(this);
+        if (open != this.open) {
+            this.open = open;
+            if (open) {
+                drawerLayout.openDrawer(GravityCompat.START, animated);
+            } else {
+                drawerLayout.closeDrawer(GravityCompat.START, animated);
+            }
+        }
+        // Locked drawers can still be opened and closed by Go.
+        if (locked) {
+            drawerLayout.setDrawerLockMode(open ? DrawerLayout.LOCK_MODE_LOCKED_OPEN : DrawerLayout.LOCK_MODE_LOCKED_CLOSED);
+        } else {
+            drawerLayout.setDrawerLockMode(DrawerLayout.LOCK_MODE_UNLOCKED);
+        }
+    }
+
+    void setOpen(boolean open) {
+        if (open != this.open) {
+            this.open = open;
+            viewNode.call("OnChange", new GoValue(open));
+        }
+    }
+}
@@ -0,0 +1,83 @@
+package io.gomatcha.matcha;
+
+import android.content.Context;
+import android.graphics.Paint;
+import android.graphics.Typeface;
+import android.text.TextPaint;
+import android.text.style.MetricAffectingSpan;
+
+import java.io.File;
+import java.io.FileOutputStream;
+import java.io.IOException;
+import java.util.HashMap;
+import java.util.Map;
+
+// MatchaFonts holds the fonts registered by text.RegisterFont.
+class MatchaFonts {
+    static Map<String, Typeface> typefaces = new HashMap<String, Typeface>();
+
+    // register loads the font in data and stores it under name. It returns an
+    // error message, or null if the font was registered.
+    static String register(Context context, String name, byte[] data) {
+        // Typefaces can only be created from files before Android 8.0.
+        File file = new File(new File(context.getCacheDir(), "matcha-fonts"), Integer.toHexString(name.hashCode()) + ".ttf");
+        Typeface typeface;
+        try {
+            file.getParentFile().mkdirs();
+            FileOutputStream out = new FileOutputStream(file);
+            try {
+                out.write(data);
+            } finally {
+                out.close();
+            }
+            typeface = Typeface.createFromFile(file);
+        } catch (IOException e) {
+            return "text: " + e.getMessage();
+        } catch (RuntimeException e) {
+            return "text: font data can't be read";
+        }
+        synchronized (MatchaFonts.class) {
+            typefaces.put(name, typeface);
+        }
+        return null;
+    }
+
+    // get returns the font registered under name, or null.
+    static synchronized Typeface get(String name) {
+        return typefaces.get(name);
+    }
+
+    // TypefaceSpan draws text with a registered font. The framework's
+    // TypefaceSpan only accepts font families before Android 9.
+    static class TypefaceSpan extends MetricAffectingSpan {
+        Typeface typeface;
+
+        TypefaceSpan(Typeface typeface) {
+            this.typeface = typeface;
+        }
+
+        @Override
+        public void updateDrawState(TextPaint paint) {
+            apply(paint);
+        }
+
+        @Override
+        public void updateMeasureState(TextPaint paint) {
+            apply(paint);
+        }
+
+        void apply(Paint paint) {
+            // Styles that the font lacks are synthesized, like the framework's
+            // span does.
+            Typeface old = paint.getTypeface();
+            int style = (old == null ? 0 : old.getStyle()) & ~typeface.getStyle();
+            if ((style & Typeface.BOLD) != 0) {
+                paint.setFakeBoldText(true);
+            }
+            if ((style & Typeface.ITALIC) != 0) {
+                paint.setTextSkewX(-0.25f);
+            }
+            paint.setTypeface(typeface);
+        }
+    }
+}
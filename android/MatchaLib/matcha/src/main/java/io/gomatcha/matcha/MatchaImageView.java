@@ -1,16 +1,29 @@
 package io.gomatcha.matcha;
 
 import android.content.Context;
-import android.util.Log;
+import android.graphics.Canvas;
+import android.graphics.ColorFilter;
+import android.graphics.Movie;
+import android.graphics.Paint;
+import android.graphics.PixelFormat;
+import android.graphics.Rect;
+import android.graphics.drawable.Drawable;
+import android.os.SystemClock;
+import android.view.View;
 import android.widget.ImageView;
 
 import com.google.protobuf.InvalidProtocolBufferException;
 import com.makeramen.roundedimageview.RoundedImageView;
 
+import io.gomatcha.bridge.GoValue;
 import io.gomatcha.matcha.proto.view.PbImageView;
 
 class MatchaImageView extends MatchaChildView {
     RoundedImageView view;
+    ImageView animationView; // RoundedImageView flattens drawables to bitmaps, so animations are displayed separately.
+    MovieDrawable movieDrawable;
+    long animationGeneration;
+    boolean needsAnimation;
     MatchaViewNode viewNode;
     
     static {
@@ -28,6 +41,11 @@ class MatchaImageView extends MatchaChildView {
 
         view = new RoundedImageView(context);
         addView(view);
+
+        animationView = new ImageView(context);
+        animationView.setLayerType(View.LAYER_TYPE_SOFTWARE, null); // Movie doesn't draw on hardware canvases.
+        animationView.setVisibility(View.GONE);
+        addView(animationView);
     }
 
     @Override
@@ -37,33 +55,148 @@ class MatchaImageView extends MatchaChildView {
             PbImageView.ImageView proto  = PbImageView.ImageView.parseFrom(nativeState);
             view.setImageDrawable(Protobuf.newDrawable(proto.getImage(), getContext()));
 
+            ImageView.ScaleType scaleType = ImageView.ScaleType.FIT_XY;
+            boolean adjustViewBounds = true;
             switch (proto.getResizeMode()) {
                 case FIT:
-                    view.setScaleType(ImageView.ScaleType.FIT_XY);
-                    view.setAdjustViewBounds(true);
                     break;
                 case FILL:
-                    view.setScaleType(ImageView.ScaleType.FIT_XY); // TODO(KD): not correct...
-                    view.setAdjustViewBounds(true);
-                    break;
+                    break; // TODO(KD): not correct...
                 case STRETCH:
-                    view.setScaleType(ImageView.ScaleType.FIT_XY);
-                    view.setAdjustViewBounds(false);
+                    adjustViewBounds = false;
                     break;
                 case CENTER:
-                    view.setScaleType(ImageView.ScaleType.CENTER);
-                    view.setAdjustViewBounds(false);
+                    scaleType = ImageView.ScaleType.CENTER;
+                    adjustViewBounds = false;
                     break;
                 case UNRECOGNIZED:
-                    view.setScaleType(ImageView.ScaleType.FIT_XY);
-                    view.setAdjustViewBounds(true);
                     break;
             }
+            view.setScaleType(scaleType);
+            view.setAdjustViewBounds(adjustViewBounds);
+            animationView.setScaleType(scaleType);
+            animationView.setAdjustViewBounds(adjustViewBounds);
 
             if (proto.hasTint()) {
                 view.setColorFilter(Protobuf.newColor(proto.getTint()));
+                animationView.setColorFilter(Protobuf.newColor(proto.getTint()));
             }
         } catch (InvalidProtocolBufferException e) {
         }
+
+        // Go can't be called while it is updating the views, so the animation
+        // is read once the update completes.
+        if (needsAnimation) {
+            return;
+        }
+        needsAnimation = true;
+        post(new Runnable() {
+            @Override
+            public void run() {
+                needsAnimation = false;
+                updateAnimation();
+            }
+        });
+    }
+
+    void updateAnimation() {
+        GoValue[] animation = viewNode.call("Animation");
+        long generation = animation[0].toLong();
+        boolean paused = animation[1].toBool();
+        int loopCount = (int)animation[2].toLong();
+
+        if (generation != animationGeneration) {
+            animationGeneration = generation;
+            movieDrawable = null;
+            if (generation != 0) {
+                byte[] data = viewNode.call("AnimationData")[0].toByteArray();
+                Movie movie = data == null ? null : Movie.decodeByteArray(data, 0, data.length);
+                if (movie != null && movie.duration() > 0) {
+                    movieDrawable = new MovieDrawable(movie);
+                }
+            }
+            animationView.setImageDrawable(movieDrawable);
+            animationView.setVisibility(movieDrawable != null ? View.VISIBLE : View.GONE);
+            view.setVisibility(movieDrawable != null ? View.INVISIBLE : View.VISIBLE);
+        }
+        if (movieDrawable != null) {
+            movieDrawable.setLoopCount(loopCount);
+            movieDrawable.setPaused(paused);
+        }
+    }
+
+    // MovieDrawable plays a GIF decoded by android.graphics.Movie.
+    static class MovieDrawable extends Drawable {
+        Movie movie;
+        Paint paint = new Paint(Paint.FILTER_BITMAP_FLAG);
+        long start = SystemClock.uptimeMillis();
+        long pausedAt = -1; // Elapsed time when paused, or -1.
+        int loopCount;
+
+        MovieDrawable(Movie movie) {
+            this.movie = movie;
+        }
+
+        void setLoopCount(int loopCount) {
+            if (this.loopCount != loopCount) {
+                this.loopCount = loopCount;
+                start = SystemClock.uptimeMillis();
+                invalidateSelf();
+            }
+        }
+
+        void setPaused(boolean paused) {
+            if (paused && pausedAt == -1) {
+                pausedAt = SystemClock.uptimeMillis() - start;
+            } else if (!paused && pausedAt != -1) {
+                start = SystemClock.uptimeMillis() - pausedAt;
+                pausedAt = -1;
+                invalidateSelf();
+            }
+        }
+
+        @Override
+        public void draw(Canvas canvas) {
+            long elapsed = pausedAt != -1 ? pausedAt : SystemClock.uptimeMillis() - start;
+            int duration = movie.duration();
+            boolean finished = loopCount > 0 && elapsed >= (long)duration * loopCount;
+            movie.setTime(finished ? duration - 1 : (int)(elapsed % duration));
+
+            Rect bounds = getBounds();
+            canvas.save();
+            canvas.translate(bounds.left, bounds.top);
+            canvas.scale((float)bounds.width() / movie.width(), (float)bounds.height() / movie.height());
+            movie.draw(canvas, 0, 0, paint);
+            canvas.restore();
+
+            if (pausedAt == -1 && !finished) {
+                invalidateSelf();
+            }
+        }
+
+        @Override
+        public int getIntrinsicWidth() {
+            return movie.width();
+        }
+
+        @Override
+        public int getIntrinsicHeight() {
+            return movie.height();
+        }
+
+        @Override
+        public void setAlpha(int alpha) {
+            paint.setAlpha(alpha);
+        }
+
+        @Override
+        public void setColorFilter(ColorFilter colorFilter) {
+            paint.setColorFilter(colorFilter);
+        }
+
+        @Override
+        public int getOpacity() {
+            return PixelFormat.TRANSLUCENT;
+        }
     }
 }
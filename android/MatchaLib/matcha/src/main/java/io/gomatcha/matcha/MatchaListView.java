@@ -0,0 +1,347 @@
+package io.gomatcha.matcha;
+
+import android.content.Context;
+import android.graphics.Canvas;
+import android.graphics.Paint;
+import android.support.v4.widget.SwipeRefreshLayout;
+import android.support.v7.widget.LinearLayoutManager;
+import android.support.v7.widget.RecyclerView;
+import android.support.v7.widget.helper.ItemTouchHelper;
+import android.util.DisplayMetrics;
+import android.view.Gravity;
+import android.view.MotionEvent;
+import android.view.View;
+import android.view.ViewGroup;
+import android.widget.FrameLayout;
+
+import java.util.ArrayList;
+import java.util.HashMap;
+import java.util.List;
+import java.util.Map;
+
+import io.gomatcha.bridge.GoValue;
+
+class MatchaListView extends MatchaChildView {
+    MatchaViewNode viewNode;
+    SwipeRefreshLayout refreshLayout;
+    RecyclerView recyclerView;
+    LinearLayoutManager layoutManager;
+    MatchaListAdapter adapter;
+    List<View> childViews = new ArrayList<View>();
+    Map<Long, View> rowViews = new HashMap<Long, View>();
+    boolean needsReload;
+    long generation = -1;
+    int count;
+    int firstVisibleRow = -1;
+    int lastVisibleRow = -1;
+    ItemTouchHelper touchHelper;
+    int reorder;
+    boolean dragging;
+    boolean needsReloadAfterDrag;
+
+    static {
+        MatchaView.registerView("gomatcha.io/matcha/view/listview", new MatchaView.ViewFactory() {
+            @Override
+            public MatchaChildView createView(Context context, MatchaViewNode node) {
+                return new MatchaListView(context, node);
+            }
+        });
+    }
+
+    public MatchaListView(Context context, MatchaViewNode node) {
+        super(context);
+        viewNode = node;
+
+        layoutManager = new LinearLayoutManager(context);
+        adapter = new MatchaListAdapter();
+        recyclerView = new RecyclerView(context);
+        recyclerView.setLayoutManager(layoutManager);
+        recyclerView.setAdapter(adapter);
+        recyclerView.addOnScrollListener(new RecyclerView.OnScrollListener() {
+            @Override
+            public void onScrolled(RecyclerView recyclerView, int dx, int dy) {
+                updateVisibleRows();
+            }
+        });
+        refreshLayout = new SwipeRefreshLayout(context);
+        refreshLayout.setEnabled(false);
+        refreshLayout.setOnRefreshListener(new SwipeRefreshLayout.OnRefreshListener() {
+            @Override
+            public void onRefresh() {
+                viewNode.call("OnRefresh");
+            }
+        });
+        refreshLayout.addView(recyclerView);
+        addView(refreshLayout);
+
+        touchHelper = new ItemTouchHelper(new ItemTouchHelper.SimpleCallback(ItemTouchHelper.UP | ItemTouchHelper.DOWN, 0) {
+            @Override
+            public int getMovementFlags(RecyclerView recyclerView, RecyclerView.ViewHolder viewHolder) {
+                return reorder == 0 ? 0 : super.getMovementFlags(recyclerView, viewHolder);
+            }
+
+            @Override
+            public boolean isLongPressDragEnabled() {
+                return reorder == 1;
+            }
+
+            @Override
+            public boolean onMove(RecyclerView recyclerView, RecyclerView.ViewHolder viewHolder, RecyclerView.ViewHolder target) {
+                int from = viewHolder.getAdapterPosition();
+                int to = target.getAdapterPosition();
+                if (from == RecyclerView.NO_POSITION || to == RecyclerView.NO_POSITION) {
+                    return false;
+                }
+                adapter.notifyItemMoved(from, to);
+                viewNode.call("OnMove", new GoValue(from), new GoValue(to));
+                return true;
+            }
+
+            @Override
+            public void onSwiped(RecyclerView.ViewHolder viewHolder, int direction) {
+            }
+
+            @Override
+            public void onSelectedChanged(RecyclerView.ViewHolder viewHolder, int actionState) {
+                super.onSelectedChanged(viewHolder, actionState);
+                if (actionState == ItemTouchHelper.ACTION_STATE_DRAG) {
+                    dragging = true;
+                    refreshLayout.setEnabled(false);
+                }
+            }
+
+            @Override
+            public void clearView(RecyclerView recyclerView, RecyclerView.ViewHolder viewHolder) {
+                super.clearView(recyclerView, viewHolder);
+                dragging = false;
+                if (needsReloadAfterDrag) {
+                    needsReloadAfterDrag = false;
+                    adapter.notifyDataSetChanged();
+                }
+                updateRefreshLayout();
+            }
+        });
+        touchHelper.attachToRecyclerView(recyclerView);
+    }
+
+    @Override
+    public void setNativeState(byte[] nativeState) {
+        super.setNativeState(nativeState);
+        setNeedsReload();
+    }
+
+    @Override
+    public boolean isContainerView() {
+        return true;
+    }
+
+    @Override
+    public void setChildViews(List<View> childViews) {
+        this.childViews = childViews;
+        setNeedsReload();
+    }
+
+    // Go can't be called while it is updating the views, so the rows are read
+    // once the update completes.
+    void setNeedsReload() {
+        if (needsReload) {
+            return;
+        }
+        needsReload = true;
+        post(new Runnable() {
+            @Override
+            public void run() {
+                needsReload = false;
+                reloadRows();
+                updateRefreshLayout();
+                updateReorder();
+            }
+        });
+    }
+
+    void reloadRows() {
+        GoValue[] rows = viewNode.call("Rows");
+        int count = (int)rows[0].toLong();
+        long generation = rows[1].toLong();
+
+        GoValue[] childRows = viewNode.call("ChildRows")[0].toArray();
+        Map<Long, View> rowViews = new HashMap<Long, View>();
+        for (int i = 0; i < childRows.length && i < childViews.size(); i++) {
+            rowViews.put(childRows[i].toLong(), childViews.get(i));
+        }
+        this.rowViews = rowViews;
+
+        if (generation != this.generation || count != this.count) {
+            this.generation = generation;
+            this.count = count;
+            if (dragging) {
+                // Reloading would cancel the drag, so the rows are only
+                // updated in place until it ends.
+                needsReloadAfterDrag = true;
+                updateVisibleViews();
+            } else {
+                adapter.notifyDataSetChanged();
+            }
+        } else {
+            updateVisibleViews();
+        }
+        updateVisibleRows();
+    }
+
+    void updateVisibleViews() {
+        for (int i = 0; i < recyclerView.getChildCount(); i++) {
+            MatchaListViewHolder holder = (MatchaListViewHolder)recyclerView.getChildViewHolder(recyclerView.getChildAt(i));
+            holder.setMatchaView(rowViews.get((long)holder.getAdapterPosition()));
+        }
+    }
+
+    void updateRefreshLayout() {
+        GoValue[] state = viewNode.call("Refresh");
+        refreshLayout.setEnabled(state[0].toBool() && !dragging);
+        if (refreshLayout.isRefreshing() != state[1].toBool()) {
+            refreshLayout.setRefreshing(state[1].toBool());
+        }
+    }
+
+    void updateReorder() {
+        int reorder = (int)viewNode.call("Reorder")[0].toLong();
+        if (reorder != this.reorder) {
+            this.reorder = reorder;
+            // The rows are bound again to add or remove their handles.
+            adapter.notifyDataSetChanged();
+        }
+    }
+
+    void updateVisibleRows() {
+        int first = Math.max(layoutManager.findFirstVisibleItemPosition(), 0);
+        int last = layoutManager.findLastVisibleItemPosition() + 1;
+        if (first == firstVisibleRow && last == lastVisibleRow) {
+            return;
+        }
+        firstVisibleRow = first;
+        lastVisibleRow = last;
+        viewNode.call("OnVisible", new GoValue(first), new GoValue(last));
+    }
+
+    class MatchaListAdapter extends RecyclerView.Adapter<MatchaListViewHolder> {
+        Map<String, Integer> viewTypes = new HashMap<String, Integer>();
+
+        @Override
+        public int getItemCount() {
+            return count;
+        }
+
+        @Override
+        public int getItemViewType(int position) {
+            String reuseId = viewNode.call("Row", new GoValue(position))[0].toString();
+            Integer viewType = viewTypes.get(reuseId);
+            if (viewType == null) {
+                viewType = viewTypes.size();
+                viewTypes.put(reuseId, viewType);
+            }
+            return viewType;
+        }
+
+        @Override
+        public MatchaListViewHolder onCreateViewHolder(ViewGroup parent, int viewType) {
+            FrameLayout frameLayout = new FrameLayout(parent.getContext());
+            frameLayout.setLayoutParams(new RecyclerView.LayoutParams(RecyclerView.LayoutParams.MATCH_PARENT, 0));
+            return new MatchaListViewHolder(frameLayout);
+        }
+
+        @Override
+        public void onBindViewHolder(MatchaListViewHolder holder, int position) {
+            double ratio = (float)getResources().getDisplayMetrics().densityDpi / DisplayMetrics.DENSITY_DEFAULT;
+            double height = viewNode.call("Row", new GoValue(position))[1].toDouble();
+            RecyclerView.LayoutParams params = (RecyclerView.LayoutParams)holder.itemView.getLayoutParams();
+            params.height = (int)(height * ratio);
+            holder.itemView.setLayoutParams(params);
+            holder.setHandle(reorder == 2);
+            holder.setMatchaView(rowViews.get((long)position));
+        }
+
+        @Override
+        public void onViewRecycled(MatchaListViewHolder holder) {
+            holder.setMatchaView(null);
+        }
+    }
+
+    class MatchaListViewHolder extends RecyclerView.ViewHolder {
+        View matchaView;
+        MatchaListHandle handle;
+
+        MatchaListViewHolder(View itemView) {
+            super(itemView);
+            itemView.setOnClickListener(new OnClickListener() {
+                @Override
+                public void onClick(View v) {
+                    int position = getAdapterPosition();
+                    if (position != RecyclerView.NO_POSITION) {
+                        viewNode.call("OnSelect", new GoValue(position));
+                    }
+                }
+            });
+        }
+
+        void setHandle(boolean visible) {
+            if (visible && handle == null) {
+                float ratio = getResources().getDisplayMetrics().density;
+                handle = new MatchaListHandle(itemView.getContext());
+                handle.setOnTouchListener(new OnTouchListener() {
+                    @Override
+                    public boolean onTouch(View v, MotionEvent event) {
+                        if (event.getActionMasked() == MotionEvent.ACTION_DOWN) {
+                            touchHelper.startDrag(MatchaListViewHolder.this);
+                        }
+                        return true;
+                    }
+                });
+                FrameLayout.LayoutParams params = new FrameLayout.LayoutParams((int)(44 * ratio), FrameLayout.LayoutParams.MATCH_PARENT);
+                params.gravity = Gravity.RIGHT;
+                ((FrameLayout)itemView).addView(handle, params);
+            } else if (!visible && handle != null) {
+                ((FrameLayout)itemView).removeView(handle);
+                handle = null;
+            }
+        }
+
+        void setMatchaView(View view) {
+            if (matchaView == view) {
+                return;
+            }
+            // The view may have moved to another cell already.
+            FrameLayout frameLayout = (FrameLayout)itemView;
+            if (matchaView != null && matchaView.getParent() == frameLayout) {
+                frameLayout.removeView(matchaView);
+            }
+            matchaView = view;
+            if (view != null) {
+                if (view.getParent() != null) {
+                    ((ViewGroup)view.getParent()).removeView(view);
+                }
+                // The view is added below the handle, if there is one.
+                frameLayout.addView(view, 0, new FrameLayout.LayoutParams(FrameLayout.LayoutParams.MATCH_PARENT, FrameLayout.LayoutParams.MATCH_PARENT));
+            }
+        }
+    }
+
+    // MatchaListHandle draws the grip of a row that is reordered by its handle.
+    static class MatchaListHandle extends View {
+        Paint paint = new Paint(Paint.ANTI_ALIAS_FLAG);
+
+        MatchaListHandle(Context context) {
+            super(context);
+            paint.setColor(0x61000000);
+        }
+
+        @Override
+        protected void onDraw(Canvas canvas) {
+            float ratio = getResources().getDisplayMetrics().density;
+            float x = getWidth() / 2f;
+            float y = getHeight() / 2f;
+            for (int i = -1; i <= 1; i++) {
+                canvas.drawRect(x - 9 * ratio, y + (i * 5 - 1) * ratio, x + 9 * ratio, y + (i * 5 + 1) * ratio, paint);
+            }
+        }
+    }
+}
@@ -0,0 +1,222 @@
+package io.gomatcha.matcha;
+
+import android.Manifest;
+import android.content.ContentResolver;
+import android.content.ContentUris;
+import android.content.Context;
+import android.content.pm.PackageManager;
+import android.database.Cursor;
+import android.graphics.Bitmap;
+import android.graphics.BitmapFactory;
+import android.media.ThumbnailUtils;
+import android.net.Uri;
+import android.provider.MediaStore;
+import android.util.LruCache;
+
+import java.io.ByteArrayOutputStream;
+import java.util.ArrayList;
+import java.util.HashMap;
+import java.util.LinkedHashMap;
+import java.util.concurrent.ExecutorService;
+import java.util.concurrent.Executors;
+import java.util.concurrent.Future;
+
+import io.gomatcha.bridge.GoValue;
+
+// MatchaMediaLibrary backs the gomatcha.io/matcha/view/media package with MediaStore.
+class MatchaMediaLibrary {
+    static final String[] projection = {
+            MediaStore.Files.FileColumns._ID,
+            MediaStore.Files.FileColumns.MEDIA_TYPE,
+            MediaStore.Images.ImageColumns.BUCKET_ID,
+            MediaStore.Images.ImageColumns.BUCKET_DISPLAY_NAME,
+    };
+    static final String selection = MediaStore.Files.FileColumns.MEDIA_TYPE + " IN ("
+            + MediaStore.Files.FileColumns.MEDIA_TYPE_IMAGE + ","
+            + MediaStore.Files.FileColumns.MEDIA_TYPE_VIDEO + ")";
+    static final String sortOrder = MediaStore.Files.FileColumns.DATE_ADDED + " DESC";
+
+    final Context context;
+    final ExecutorService executor = Executors.newFixedThreadPool(2);
+    final LruCache<String, Bitmap> cache;
+    final HashMap<Long, Future<?>> requests = new HashMap<Long, Future<?>>();
+    final HashMap<String, Future<?>> caching = new HashMap<String, Future<?>>();
+
+    MatchaMediaLibrary(Context ctx) {
+        context = ctx;
+        int maxKb = (int)(Runtime.getRuntime().maxMemory() / 1024 / 8);
+        cache = new LruCache<String, Bitmap>(maxKb) {
+            @Override
+            protected int sizeOf(String key, Bitmap bitmap) {
+                return bitmap.getByteCount() / 1024;
+            }
+        };
+    }
+
+    boolean authorized() {
+        return context.checkCallingOrSelfPermission(Manifest.permission.READ_EXTERNAL_STORAGE) == PackageManager.PERMISSION_GRANTED;
+    }
+
+    Cursor query(String album) {
+        String sel = selection;
+        String[] args = null;
+        if (album.length() > 0) {
+            sel += " AND " + MediaStore.Images.ImageColumns.BUCKET_ID + " = ?";
+            args = new String[]{album};
+        }
+        ContentResolver resolver = context.getContentResolver();
+        return resolver.query(MediaStore.Files.getContentUri("external"), projection, sel, args, sortOrder);
+    }
+
+    // albums returns an array of [id, title, count] arrays. The first album contains every asset.
+    Object[] albums() {
+        if (!authorized()) {
+            return null;
+        }
+        Cursor cursor = query("");
+        if (cursor == null) {
+            return null;
+        }
+        LinkedHashMap<String, Object[]> buckets = new LinkedHashMap<String, Object[]>();
+        long total = 0;
+        try {
+            while (cursor.moveToNext()) {
+                String id = cursor.getString(2);
+                String title = cursor.getString(3);
+                if (id == null) {
+                    id = "";
+                }
+                Object[] bucket = buckets.get(id);
+                if (bucket == null) {
+                    bucket = new Object[]{id, title == null ? "" : title, 0L};
+                    buckets.put(id, bucket);
+                }
+                bucket[2] = (Long)bucket[2] + 1;
+                total += 1;
+            }
+        } finally {
+            cursor.close();
+        }
+
+        ArrayList<Object> albums = new ArrayList<Object>();
+        albums.add(new Object[]{"", "All", total});
+        for (Object[] i : buckets.values()) {
+            if (!i[0].equals("")) {
+                albums.add(i);
+            }
+        }
+        return albums.toArray();
+    }
+
+    // assets returns the content URIs of the assets in album, newest first.
+    Object[] assets(String album) {
+        if (!authorized()) {
+            return null;
+        }
+        Cursor cursor = query(album);
+        if (cursor == null) {
+            return null;
+        }
+        ArrayList<Object> assets = new ArrayList<Object>();
+        try {
+            while (cursor.moveToNext()) {
+                long id = cursor.getLong(0);
+                Uri base = MediaStore.Images.Media.EXTERNAL_CONTENT_URI;
+                if (cursor.getInt(1) == MediaStore.Files.FileColumns.MEDIA_TYPE_VIDEO) {
+                    base = MediaStore.Video.Media.EXTERNAL_CONTENT_URI;
+                }
+                assets.add(ContentUris.withAppendedId(base, id).toString());
+            }
+        } finally {
+            cursor.close();
+        }
+        return assets.toArray();
+    }
+
+    Bitmap thumbnail(String asset, int size) {
+        String key = asset + " " + size;
+        Bitmap bitmap = cache.get(key);
+        if (bitmap != null) {
+            return bitmap;
+        }
+
+        Uri uri = Uri.parse(asset);
+        long id = ContentUris.parseId(uri);
+        ContentResolver resolver = context.getContentResolver();
+        BitmapFactory.Options options = new BitmapFactory.Options();
+        if (asset.startsWith(MediaStore.Video.Media.EXTERNAL_CONTENT_URI.toString())) {
+            bitmap = MediaStore.Video.Thumbnails.getThumbnail(resolver, id, MediaStore.Video.Thumbnails.MINI_KIND, options);
+        } else {
+            bitmap = MediaStore.Images.Thumbnails.getThumbnail(resolver, id, MediaStore.Images.Thumbnails.MINI_KIND, options);
+        }
+        if (bitmap == null) {
+            return null;
+        }
+        bitmap = ThumbnailUtils.extractThumbnail(bitmap, size, size);
+        cache.put(key, bitmap);
+        return bitmap;
+    }
+
+    synchronized void requestThumbnail(final String asset, final long size, final long id) {
+        Future<?> future = executor.submit(new Runnable() {
+            @Override
+            public void run() {
+                byte[] data = new byte[0];
+                try {
+                    Bitmap bitmap = thumbnail(asset, (int)size);
+                    if (bitmap != null) {
+                        ByteArrayOutputStream stream = new ByteArrayOutputStream();
+                        bitmap.compress(Bitmap.CompressFormat.JPEG, 80, stream);
+                        data = stream.toByteArray();
+                    }
+                } catch (Exception e) {
+                }
+                synchronized (MatchaMediaLibrary.this) {
+                    if (requests.remove(id) == null) {
+                        return;
+                    }
+                }
+                GoValue.withFunc("gomatcha.io/matcha/view/media onThumbnail").call("", new GoValue(id), new GoValue(data));
+            }
+        });
+        requests.put(id, future);
+    }
+
+    synchronized void cancelThumbnail(long id) {
+        Future<?> future = requests.remove(id);
+        if (future != null) {
+            future.cancel(false);
+        }
+    }
+
+    synchronized void startCaching(Object[] assets, final long size) {
+        for (Object i : assets) {
+            final String asset = (String)i;
+            final String key = asset + " " + size;
+            if (caching.containsKey(key)) {
+                continue;
+            }
+            caching.put(key, executor.submit(new Runnable() {
+                @Override
+                public void run() {
+                    try {
+                        thumbnail(asset, (int)size);
+                    } catch (Exception e) {
+                    }
+                    synchronized (MatchaMediaLibrary.this) {
+                        caching.remove(key);
+                    }
+                }
+            }));
+        }
+    }
+
+    synchronized void stopCaching(Object[] assets, long size) {
+        for (Object i : assets) {
+            Future<?> future = caching.remove((String)i + " " + size);
+            if (future != null) {
+                future.cancel(false);
+            }
+        }
+    }
+}
@@ -0,0 +1,177 @@
+package io.gomatcha.matcha;
+
+import android.app.Dialog;
+import android.content.Context;
+import android.content.DialogInterface;
+import android.graphics.Color;
+import android.util.DisplayMetrics;
+import android.view.Gravity;
+import android.view.View;
+import android.view.ViewGroup;
+import android.view.Window;
+import android.view.WindowManager;
+import android.widget.FrameLayout;
+
+import java.util.ArrayList;
+import java.util.List;
+
+import io.gomatcha.bridge.GoValue;
+
+class MatchaModalView extends MatchaChildView {
+    MatchaViewNode viewNode;
+    List<View> childViews = new ArrayList<View>();
+    View contentView;
+    View modalView;
+    Dialog dialog;
+    FrameLayout dialogLayout;
+    boolean needsUpdate;
+
+    static {
+        MatchaView.registerView("gomatcha.io/matcha/view/modal", new MatchaView.ViewFactory() {
+            @Override
+            public MatchaChildView createView(Context context, MatchaViewNode node) {
+                return new MatchaModalView(context, node);
+            }
+        });
+    }
+
+    public MatchaModalView(Context context, MatchaViewNode node) {
+        super(context);
+        viewNode = node;
+    }
+
+    @Override
+    public void setNativeState(byte[] nativeState) {
+        super.setNativeState(nativeState);
+        setNeedsUpdate();
+    }
+
+    @Override
+    public boolean isContainerView() {
+        return true;
+    }
+
+    @Override
+    public void setChildViews(List<View> childViews) {
+        this.childViews = childViews;
+        setNeedsUpdate();
+    }
+
+    // Go can't be called while it is updating the views, so the state is read
+    // once the update completes.
+    void setNeedsUpdate() {
+        if (needsUpdate) {
+            return;
+        }
+        needsUpdate = true;
+        post(new Runnable() {
+            @Override
+            public void run() {
+                needsUpdate = false;
+                update();
+            }
+        });
+    }
+
+    void update() {
+        GoValue[] state = viewNode.call("State");
+        boolean presented = state[0].toBool();
+        long style = state[1].toLong();
+        GoValue[] detents = state[2].toArray();
+        int contentIdx = (int)state[3].toLong();
+        int modalIdx = (int)state[4].toLong();
+
+        View contentView = contentIdx >= 0 && contentIdx < childViews.size() ? childViews.get(contentIdx) : null;
+        if (this.contentView != contentView) {
+            if (this.contentView != null && this.contentView.getParent() == this) {
+                removeView(this.contentView);
+            }
+            this.contentView = contentView;
+            if (contentView != null) {
+                addView(contentView);
+            }
+        }
+
+        View modalView = modalIdx >= 0 && modalIdx < childViews.size() ? childViews.get(modalIdx) : null;
+        if (modalView != null && dialogLayout != null && this.modalView != modalView) {
+            setModalView(modalView);
+        }
+
+        if (presented && dialog == null && modalView != null) {
+            long detent = detents.length > 0 ? detents[0].toLong() : 0;
+            present(modalView, style, detent);
+        } else if (!presented && dialog != null) {
+            dialog.dismiss();
+        }
+    }
+
+    void setModalView(View modalView) {
+        if (this.modalView != null && this.modalView.getParent() == dialogLayout) {
+            dialogLayout.removeView(this.modalView);
+        }
+        this.modalView = modalView;
+        if (modalView != null) {
+            if (modalView.getParent() != null) {
+                ((ViewGroup)modalView.getParent()).removeView(modalView);
+            }
+            dialogLayout.addView(modalView, new FrameLayout.LayoutParams(FrameLayout.LayoutParams.MATCH_PARENT, FrameLayout.LayoutParams.MATCH_PARENT));
+        }
+    }
+
+    void present(View modalView, long style, long detent) {
+        dialogLayout = new FrameLayout(getContext()) {
+            @Override
+            protected void onSizeChanged(int w, int h, int oldw, int oldh) {
+                super.onSizeChanged(w, h, oldw, oldh);
+                final double ratio = (float)getResources().getDisplayMetrics().densityDpi / DisplayMetrics.DENSITY_DEFAULT;
+                final double width = w / ratio;
+                final double height = h / ratio;
+                // Go may be updating the views during layout.
+                post(new Runnable() {
+                    @Override
+                    public void run() {
+                        viewNode.call("OnSize", new GoValue(width), new GoValue(height));
+                    }
+                });
+            }
+        };
+        dialogLayout.setBackgroundColor(Color.WHITE);
+        setModalView(modalView);
+
+        dialog = new Dialog(getContext(), android.R.style.Theme_Light_NoTitleBar);
+        dialog.setContentView(dialogLayout);
+        dialog.setCancelable(true);
+        Window window = dialog.getWindow();
+        if (style == 2) {
+            // Bottom sheets slide up from the bottom of the screen, with the
+            // height of their first detent.
+            DisplayMetrics metrics = getResources().getDisplayMetrics();
+            int height = (int)(metrics.heightPixels * (detent == 0 ? 0.5 : 0.9));
+            window.setLayout(WindowManager.LayoutParams.MATCH_PARENT, height);
+            window.setGravity(Gravity.BOTTOM);
+            window.setWindowAnimations(android.R.style.Animation_InputMethod);
+            window.addFlags(WindowManager.LayoutParams.FLAG_DIM_BEHIND);
+            window.setDimAmount(0.5f);
+            dialog.setCanceledOnTouchOutside(true);
+        } else {
+            window.setLayout(WindowManager.LayoutParams.MATCH_PARENT, WindowManager.LayoutParams.MATCH_PARENT);
+            dialog.setCanceledOnTouchOutside(false);
+        }
+        dialog.setOnShowListener(new DialogInterface.OnShowListener() {
+            @Override
+            public void onShow(DialogInterface d) {
+                viewNode.call("OnPresent");
+            }
+        });
+        dialog.setOnDismissListener(new DialogInterface.OnDismissListener() {
+            @Override
+            public void onDismiss(DialogInterface d) {
+                setModalView(null);
+                dialog = null;
+                dialogLayout = null;
+                viewNode.call("OnDismiss");
+            }
+        });
+        dialog.show();
+    }
+}
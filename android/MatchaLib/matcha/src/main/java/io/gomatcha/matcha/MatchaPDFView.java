@@ -0,0 +1,338 @@
+package io.gomatcha.matcha;
+
+import android.annotation.TargetApi;
+import android.content.Context;
+import android.graphics.Bitmap;
+import android.graphics.Color;
+import android.graphics.pdf.PdfRenderer;
+import android.os.Build;
+import android.os.ParcelFileDescriptor;
+import android.support.v4.view.PagerAdapter;
+import android.support.v4.view.ViewPager;
+import android.view.GestureDetector;
+import android.view.MotionEvent;
+import android.view.ScaleGestureDetector;
+import android.view.View;
+import android.view.ViewGroup;
+import android.widget.FrameLayout;
+import android.widget.ImageView;
+
+import java.io.File;
+import java.io.FileOutputStream;
+import java.io.IOException;
+
+import io.gomatcha.bridge.GoValue;
+
+class MatchaPDFView extends MatchaChildView {
+    static final float MAX_SCALE = 4;
+    static final float DOUBLE_TAP_SCALE = 2;
+    MatchaViewNode viewNode;
+    ViewPager viewPager;
+    MatchaPDFAdapter adapter;
+    File file;
+    ParcelFileDescriptor descriptor;
+    PdfRenderer renderer;
+    boolean needsUpdate;
+    long generation;
+    long searchGeneration;
+    int pageCount;
+    int page; // The last page read from or sent to Go.
+
+    static {
+        MatchaView.registerView("gomatcha.io/matcha/view/pdfview", new MatchaView.ViewFactory() {
+            @Override
+            public MatchaChildView createView(Context context, MatchaViewNode node) {
+                return new MatchaPDFView(context, node);
+            }
+        });
+    }
+
+    public MatchaPDFView(Context context, MatchaViewNode node) {
+        super(context);
+        viewNode = node;
+
+        adapter = new MatchaPDFAdapter();
+        viewPager = new ViewPager(context);
+        viewPager.setAdapter(adapter);
+        viewPager.setOffscreenPageLimit(1);
+        viewPager.addOnPageChangeListener(new ViewPager.SimpleOnPageChangeListener() {
+            @Override
+            public void onPageSelected(int position) {
+                if (position != page) {
+                    page = position;
+                    viewNode.call("OnPage", new GoValue(position));
+                }
+            }
+        });
+        addView(viewPager);
+    }
+
+    @Override
+    public void setNativeState(byte[] nativeState) {
+        super.setNativeState(nativeState);
+        setNeedsUpdate();
+    }
+
+    // Go can't be called while it is updating the views, so the state is read
+    // once the update completes.
+    void setNeedsUpdate() {
+        if (needsUpdate) {
+            return;
+        }
+        needsUpdate = true;
+        post(new Runnable() {
+            @Override
+            public void run() {
+                needsUpdate = false;
+                update();
+            }
+        });
+    }
+
+    void update() {
+        GoValue[] state = viewNode.call("State");
+        long generation = state[0].toLong();
+        int page = (int)state[1].toLong();
+        long searchGeneration = state[2].toLong();
+
+        if (generation != this.generation) {
+            this.generation = generation;
+            this.page = 0;
+            this.searchGeneration = 0;
+            load(viewNode.call("Document")[0].toByteArray());
+        }
+        if (page != this.page && page >= 0 && page < pageCount) {
+            this.page = page;
+            viewPager.setCurrentItem(page, true);
+        }
+        if (searchGeneration != this.searchGeneration) {
+            // PdfRenderer can't read the text of the document, so searches
+            // don't find anything.
+            this.searchGeneration = searchGeneration;
+            viewNode.call("OnSearch", new GoValue(searchGeneration), new GoValue(new GoValue[0]), new GoValue(new GoValue[0]));
+        }
+    }
+
+    void load(byte[] data) {
+        if (Build.VERSION.SDK_INT >= 21) {
+            close();
+        }
+        pageCount = 0;
+        if (data != null && data.length > 0) {
+            if (Build.VERSION.SDK_INT < 21) {
+                viewNode.call("OnError", new GoValue("pdfview: PdfRenderer requires Android 5.0"));
+            } else {
+                try {
+                    open(data);
+                    pageCount = renderer.getPageCount();
+                    viewNode.call("OnLoad", new GoValue(pageCount));
+                } catch (IOException e) {
+                    close();
+                    viewNode.call("OnError", new GoValue("pdfview: " + e.getMessage()));
+                } catch (SecurityException e) {
+                    // The document is password protected.
+                    close();
+                    viewNode.call("OnError", new GoValue("pdfview: " + e.getMessage()));
+                }
+            }
+        }
+        adapter.notifyDataSetChanged();
+        viewPager.setCurrentItem(0, false);
+    }
+
+    // PdfRenderer reads from a file descriptor, so the document is written to
+    // the cache directory.
+    @TargetApi(21)
+    void open(byte[] data) throws IOException {
+        file = File.createTempFile("matcha", ".pdf", getContext().getCacheDir());
+        FileOutputStream out = new FileOutputStream(file);
+        try {
+            out.write(data);
+        } finally {
+            out.close();
+        }
+        descriptor = ParcelFileDescriptor.open(file, ParcelFileDescriptor.MODE_READ_ONLY);
+        renderer = new PdfRenderer(descriptor);
+    }
+
+    @TargetApi(21)
+    void close() {
+        if (renderer != null) {
+            renderer.close();
+            renderer = null;
+        }
+        if (descriptor != null) {
+            try {
+                descriptor.close();
+            } catch (IOException e) {
+                // The file is deleted regardless.
+            }
+            descriptor = null;
+        }
+        if (file != null) {
+            file.delete();
+            file = null;
+        }
+    }
+
+    // render draws the page at twice the width of the view, so that it stays
+    // sharp when zoomed in.
+    @TargetApi(21)
+    Bitmap render(int index) {
+        PdfRenderer.Page page = renderer.openPage(index);
+        int width = Math.min(Math.max(getWidth(), 1) * 2, 2048);
+        int height = Math.max((int)((long)width * page.getHeight() / Math.max(page.getWidth(), 1)), 1);
+        Bitmap bitmap = Bitmap.createBitmap(width, height, Bitmap.Config.ARGB_8888);
+        bitmap.eraseColor(Color.WHITE);
+        page.render(bitmap, null, null, PdfRenderer.Page.RENDER_MODE_FOR_DISPLAY);
+        page.close();
+        return bitmap;
+    }
+
+    @Override
+    protected void onDetachedFromWindow() {
+        super.onDetachedFromWindow();
+        if (Build.VERSION.SDK_INT >= 21) {
+            close();
+        }
+        generation = 0;
+        pageCount = 0;
+        adapter.notifyDataSetChanged();
+    }
+
+    @Override
+    protected void onAttachedToWindow() {
+        super.onAttachedToWindow();
+        // The document is closed while detached, so it is read again.
+        setNeedsUpdate();
+    }
+
+    class MatchaPDFAdapter extends PagerAdapter {
+        @Override
+        public int getCount() {
+            return pageCount;
+        }
+
+        @Override
+        public int getItemPosition(Object object) {
+            // Pages are rendered again when the document changes.
+            return POSITION_NONE;
+        }
+
+        @Override
+        public boolean isViewFromObject(View view, Object object) {
+            return object == view;
+        }
+
+        @Override
+        public Object instantiateItem(ViewGroup container, int position) {
+            final MatchaPDFPage page = new MatchaPDFPage(container.getContext());
+            final int index = position;
+            container.addView(page);
+            if (getWidth() > 0) {
+                page.setBitmap(render(index));
+            } else {
+                // The page is rendered once the view has a width.
+                post(new Runnable() {
+                    @Override
+                    public void run() {
+                        if (renderer != null && index < pageCount && page.getParent() != null) {
+                            page.setBitmap(render(index));
+                        }
+                    }
+                });
+            }
+            return page;
+        }
+
+        @Override
+        public void destroyItem(ViewGroup container, int position, Object object) {
+            container.removeView((View)object);
+        }
+    }
+
+    // MatchaPDFPage displays a page that can be pinched or double tapped to
+    // zoom, and dragged once zoomed.
+    static class MatchaPDFPage extends FrameLayout {
+        ImageView imageView;
+        ScaleGestureDetector scaleDetector;
+        GestureDetector gestureDetector;
+        float scale = 1;
+        float translateX;
+        float translateY;
+
+        MatchaPDFPage(Context context) {
+            super(context);
+            setClipChildren(true);
+
+            imageView = new ImageView(context);
+            imageView.setScaleType(ImageView.ScaleType.FIT_CENTER);
+            imageView.setPivotX(0);
+            imageView.setPivotY(0);
+            addView(imageView, new FrameLayout.LayoutParams(FrameLayout.LayoutParams.MATCH_PARENT, FrameLayout.LayoutParams.MATCH_PARENT));
+
+            scaleDetector = new ScaleGestureDetector(context, new ScaleGestureDetector.SimpleOnScaleGestureListener() {
+                @Override
+                public boolean onScale(ScaleGestureDetector detector) {
+                    float s = Math.max(1, Math.min(MAX_SCALE, scale * detector.getScaleFactor()));
+                    zoomTo(s, detector.getFocusX(), detector.getFocusY());
+                    return true;
+                }
+            });
+            gestureDetector = new GestureDetector(context, new GestureDetector.SimpleOnGestureListener() {
+                @Override
+                public boolean onDown(MotionEvent e) {
+                    return true;
+                }
+
+                @Override
+                public boolean onScroll(MotionEvent e1, MotionEvent e2, float distanceX, float distanceY) {
+                    translateX -= distanceX;
+                    translateY -= distanceY;
+                    applyTransform();
+                    return true;
+                }
+
+                @Override
+                public boolean onDoubleTap(MotionEvent e) {
+                    zoomTo(scale > 1.01f ? 1 : DOUBLE_TAP_SCALE, e.getX(), e.getY());
+                    return true;
+                }
+            });
+        }
+
+        void setBitmap(Bitmap bitmap) {
+            imageView.setImageBitmap(bitmap);
+        }
+
+        // zoomTo scales the page, keeping the point under (focusX, focusY) in
+        // place.
+        void zoomTo(float s, float focusX, float focusY) {
+            translateX = focusX - (focusX - translateX) * s / scale;
+            translateY = focusY - (focusY - translateY) * s / scale;
+            scale = s;
+            applyTransform();
+        }
+
+        void applyTransform() {
+            // The page covers the view at scales above 1.
+            translateX = Math.max(getWidth() * (1 - scale), Math.min(0, translateX));
+            translateY = Math.max(getHeight() * (1 - scale), Math.min(0, translateY));
+            imageView.setScaleX(scale);
+            imageView.setScaleY(scale);
+            imageView.setTranslationX(translateX);
+            imageView.setTranslationY(translateY);
+        }
+
+        @Override
+        public boolean onTouchEvent(MotionEvent event) {
+            scaleDetector.onTouchEvent(event);
+            gestureDetector.onTouchEvent(event);
+            // The pager only swipes between pages while the page isn't zoomed.
+            if (getParent() != null) {
+                getParent().requestDisallowInterceptTouchEvent(scale > 1.01f || event.getPointerCount() > 1);
+            }
+            return true;
+        }
+    }
+}
@@ -25,6 +25,8 @@ class MatchaPagerView extends MatchaChildView {
     MatchaViewNode viewNode;
     RelativeLayout relativeLayout;
     int selectedIndex;
+    long[] tabFlags = new long[0];
+    boolean needsTabs;
 
     static {
         MatchaView.registerView("gomatcha.io/matcha/view/android PagerView", new MatchaView.ViewFactory() {
@@ -65,6 +67,11 @@ class MatchaPagerView extends MatchaChildView {
             }
             @Override
             public void onPageSelected(int position) {
+                if (position < tabFlags.length && (tabFlags[position] & 1) != 0) {
+                    // The page is disabled.
+                    viewPager.setCurrentItem(selectedIndex);
+                    return;
+                }
                 if (position != selectedIndex) {
                     selectedIndex = position;
                     viewNode.call("OnSelect", new GoValue(position));
@@ -85,7 +92,7 @@ class MatchaPagerView extends MatchaChildView {
         super.setNativeState(nativeState);
         try {
             PbPagerView.PagerView proto  = PbPagerView.PagerView.parseFrom(nativeState);
-            if (pagerAdapter.protoChildViews == null || proto.getChildViewsCount() != pagerAdapter.protoChildViews.size()) { // TODO(KD): Hack for better scrollperformance
+            if (pagerAdapter.protoChildViews == null || !proto.getChildViewsList().equals(pagerAdapter.protoChildViews)) {
                 pagerAdapter.protoChildViews = proto.getChildViewsList();
                 pagerAdapter.notifyDataSetChanged();
                 tabStrip.setViewPager(viewPager);
@@ -102,6 +109,43 @@ class MatchaPagerView extends MatchaChildView {
             }
         } catch (InvalidProtocolBufferException e) {
         }
+        setNeedsTabs();
+    }
+
+    // Go can't be called while it is updating the views, so the badges and
+    // flags of the tabs are read once the update completes.
+    void setNeedsTabs() {
+        if (needsTabs) {
+            return;
+        }
+        needsTabs = true;
+        post(new Runnable() {
+            @Override
+            public void run() {
+                needsTabs = false;
+                updateTabs();
+            }
+        });
+    }
+
+    void updateTabs() {
+        GoValue[] rlt = viewNode.call("Tabs");
+        GoValue[] badges = rlt[0].toArray();
+        GoValue[] flags = rlt[1].toArray();
+        tabFlags = new long[flags.length];
+        for (int i = 0; i < flags.length; i++) {
+            tabFlags[i] = flags[i].toLong();
+        }
+        for (int i = 0; i < badges.length && i < pagerAdapter.getCount(); i++) {
+            String title = pagerAdapter.getPageTitle(i).toString();
+            String badge = badges[i].toString();
+            if ((tabFlags[i] & 2) != 0) {
+                title = title + " \u2022";
+            } else if (badge.length() > 0) {
+                title = title + " " + badge;
+            }
+            tabStrip.updateTab(i, title, (tabFlags[i] & 1) == 0);
+        }
     }
 
     @Override
@@ -111,11 +155,12 @@ class MatchaPagerView extends MatchaChildView {
 
     @Override
     public void setChildViews(List<View> childViews) {
-        if (pagerAdapter.childViews == null || childViews.size() != pagerAdapter.childViews.size()) { // TODO(KD): Hack for better scrollperformance
+        if (pagerAdapter.childViews == null || !childViews.equals(pagerAdapter.childViews)) {
             pagerAdapter.childViews = childViews;
             pagerAdapter.notifyDataSetChanged();
             tabStrip.setViewPager(viewPager);
         }
+        setNeedsTabs();
     }
 
     private static final AtomicInteger sNextGeneratedId = new AtomicInteger(1);
@@ -143,6 +188,12 @@ class MatchaPagerView extends MatchaChildView {
             return childViews.size();
         }
         @Override
+        public int getItemPosition(Object object) {
+            // Pages are added and removed when their tabs are shown or hidden.
+            int position = childViews == null ? -1 : childViews.indexOf(object);
+            return position >= 0 ? position : POSITION_NONE;
+        }
+        @Override
         public boolean isViewFromObject(View view, Object object) {
             return object == view;
         }
@@ -163,6 +214,9 @@ class MatchaPagerView extends MatchaChildView {
         }
         @Override
         public CharSequence getPageTitle(int position) {
+            if (protoChildViews == null || position >= protoChildViews.size()) {
+                return "";
+            }
             return protoChildViews.get(position).getTitle();
         }
     }
@@ -0,0 +1,124 @@
+package io.gomatcha.matcha;
+
+import android.content.Context;
+import android.view.View;
+import android.widget.AdapterView;
+import android.widget.ArrayAdapter;
+import android.widget.LinearLayout;
+import android.widget.Spinner;
+
+import java.util.ArrayList;
+import java.util.List;
+
+import io.gomatcha.bridge.GoValue;
+
+class MatchaPicker extends MatchaChildView {
+    LinearLayout layout;
+    List<Spinner> spinners = new ArrayList<Spinner>();
+    List<List<String>> components = new ArrayList<List<String>>();
+    int[] selectedRows = new int[0];
+    MatchaViewNode viewNode;
+    boolean needsUpdate;
+
+    static {
+        MatchaView.registerView("gomatcha.io/matcha/view/picker", new MatchaView.ViewFactory() {
+            @Override
+            public MatchaChildView createView(Context context, MatchaViewNode node) {
+                return new MatchaPicker(context, node);
+            }
+        });
+    }
+
+    public MatchaPicker(Context context, MatchaViewNode node) {
+        super(context);
+        viewNode = node;
+
+        layout = new LinearLayout(context);
+        layout.setOrientation(LinearLayout.HORIZONTAL);
+        addView(layout);
+    }
+
+    @Override
+    public void setNativeState(byte[] nativeState) {
+        super.setNativeState(nativeState);
+
+        // Go can't be called while it is updating the views, so the state is read
+        // once the update completes.
+        if (needsUpdate) {
+            return;
+        }
+        needsUpdate = true;
+        post(new Runnable() {
+            @Override
+            public void run() {
+                needsUpdate = false;
+                update();
+            }
+        });
+    }
+
+    void update() {
+        GoValue[] state = viewNode.call("State");
+        GoValue[] counts = state[0].toArray();
+        GoValue[] options = state[1].toArray();
+        GoValue[] selected = state[2].toArray();
+        boolean enabled = state[3].toBool();
+
+        List<List<String>> components = new ArrayList<List<String>>();
+        int offset = 0;
+        for (GoValue i : counts) {
+            List<String> component = new ArrayList<String>();
+            for (long j = 0; j < i.toLong() && offset < options.length; j++, offset++) {
+                component.add(options[offset].toString());
+            }
+            components.add(component);
+        }
+        if (!components.equals(this.components)) {
+            this.components = components;
+            layout.removeAllViews();
+            spinners.clear();
+            for (int i = 0; i < components.size(); i++) {
+                spinners.add(createSpinner(i, components.get(i)));
+            }
+        }
+
+        selectedRows = new int[spinners.size()];
+        for (int i = 0; i < selected.length && i < spinners.size(); i++) {
+            int row = (int)selected[i].toLong();
+            selectedRows[i] = row;
+            Spinner spinner = spinners.get(i);
+            if (row < spinner.getCount() && spinner.getSelectedItemPosition() != row) {
+                spinner.setSelection(row);
+            }
+        }
+        for (Spinner i : spinners) {
+            i.setEnabled(enabled);
+        }
+    }
+
+    Spinner createSpinner(final int component, List<String> options) {
+        ArrayAdapter<String> adapter = new ArrayAdapter<String>(getContext(), android.R.layout.simple_spinner_item, options);
+        adapter.setDropDownViewResource(android.R.layout.simple_spinner_dropdown_item);
+
+        Spinner spinner = new Spinner(getContext());
+        spinner.setAdapter(adapter);
+        spinner.setOnItemSelectedListener(new AdapterView.OnItemSelectedListener() {
+            @Override
+            public void onItemSelected(AdapterView<?> parent, View view, int position, long id) {
+                // Spinners also call the listener when the selection is set
+                // programmatically.
+                if (component >= selectedRows.length || selectedRows[component] == position) {
+                    return;
+                }
+                selectedRows[component] = position;
+                viewNode.call("OnSelect", new GoValue((long)component), new GoValue((long)position));
+            }
+
+            @Override
+            public void onNothingSelected(AdapterView<?> parent) {
+            }
+        });
+        layout.addView(spinner, new LinearLayout.LayoutParams(0, LinearLayout.LayoutParams.MATCH_PARENT, 1));
+        return spinner;
+    }
+}
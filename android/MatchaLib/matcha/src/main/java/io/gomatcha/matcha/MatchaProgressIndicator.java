@@ -0,0 +1,183 @@
+package io.gomatcha.matcha;
+
+import android.animation.ValueAnimator;
+import android.content.Context;
+import android.graphics.Canvas;
+import android.graphics.Color;
+import android.graphics.Paint;
+import android.graphics.RectF;
+import android.util.TypedValue;
+import android.view.animation.LinearInterpolator;
+
+import io.gomatcha.bridge.GoValue;
+
+class MatchaProgressIndicator extends MatchaChildView {
+    MatchaViewNode viewNode;
+    Paint trackPaint;
+    Paint progressPaint;
+    long style;
+    float progress;
+    boolean indeterminate;
+    float phase; // Position of the indeterminate animation, between 0 and 1.
+    ValueAnimator progressAnimator;
+    ValueAnimator indeterminateAnimator;
+    boolean needsUpdate;
+
+    static {
+        MatchaView.registerView("gomatcha.io/matcha/view/progress", new MatchaView.ViewFactory() {
+            @Override
+            public MatchaChildView createView(Context context, MatchaViewNode node) {
+                return new MatchaProgressIndicator(context, node);
+            }
+        });
+    }
+
+    public MatchaProgressIndicator(Context context, MatchaViewNode node) {
+        super(context);
+        viewNode = node;
+        setWillNotDraw(false);
+
+        trackPaint = new Paint(Paint.ANTI_ALIAS_FLAG);
+        trackPaint.setStyle(Paint.Style.STROKE);
+        trackPaint.setStrokeCap(Paint.Cap.ROUND);
+        progressPaint = new Paint(trackPaint);
+    }
+
+    @Override
+    public void setNativeState(byte[] nativeState) {
+        super.setNativeState(nativeState);
+
+        // Go can't be called while it is updating the views, so the state is read
+        // once the update completes.
+        if (needsUpdate) {
+            return;
+        }
+        needsUpdate = true;
+        post(new Runnable() {
+            @Override
+            public void run() {
+                needsUpdate = false;
+                update();
+            }
+        });
+    }
+
+    void update() {
+        GoValue[] state = viewNode.call("State");
+        style = state[0].toLong();
+        float progress = (float)state[1].toDouble();
+        boolean indeterminate = state[2].toBool();
+        boolean animated = state[3].toBool();
+        long color = state[4].toLong();
+        long trackColor = state[5].toLong();
+
+        int c = color == -1 ? accentColor() : (int)color;
+        progressPaint.setColor(c);
+        trackPaint.setColor(trackColor == -1 ? Color.argb(Color.alpha(c) / 5, Color.red(c), Color.green(c), Color.blue(c)) : (int)trackColor);
+
+        this.indeterminate = indeterminate;
+        setAnimating(indeterminate && getWindowToken() != null);
+
+        if (progressAnimator != null) {
+            progressAnimator.cancel();
+            progressAnimator = null;
+        }
+        if (animated && !indeterminate && progress != this.progress) {
+            progressAnimator = ValueAnimator.ofFloat(this.progress, progress);
+            progressAnimator.setDuration(250);
+            progressAnimator.addUpdateListener(new ValueAnimator.AnimatorUpdateListener() {
+                @Override
+                public void onAnimationUpdate(ValueAnimator animation) {
+                    MatchaProgressIndicator.this.progress = (float)animation.getAnimatedValue();
+                    invalidate();
+                }
+            });
+            progressAnimator.start();
+        } else {
+            this.progress = progress;
+        }
+        invalidate();
+    }
+
+    // accentColor returns the theme's accent color, which is only available on
+    // Android 5 and later.
+    int accentColor() {
+        TypedValue value = new TypedValue();
+        if (android.os.Build.VERSION.SDK_INT >= 21 && getContext().getTheme().resolveAttribute(android.R.attr.colorAccent, value, true)) {
+            return value.data;
+        }
+        return 0xFF2196F3;
+    }
+
+    // setAnimating starts or stops the indeterminate animation.
+    void setAnimating(boolean animating) {
+        if (animating && indeterminateAnimator == null) {
+            indeterminateAnimator = ValueAnimator.ofFloat(0, 1);
+            indeterminateAnimator.setDuration(1000);
+            indeterminateAnimator.setRepeatCount(ValueAnimator.INFINITE);
+            indeterminateAnimator.setInterpolator(new LinearInterpolator());
+            indeterminateAnimator.addUpdateListener(new ValueAnimator.AnimatorUpdateListener() {
+                @Override
+                public void onAnimationUpdate(ValueAnimator animation) {
+                    phase = (float)animation.getAnimatedValue();
+                    invalidate();
+                }
+            });
+            indeterminateAnimator.start();
+        } else if (!animating && indeterminateAnimator != null) {
+            indeterminateAnimator.cancel();
+            indeterminateAnimator = null;
+        }
+    }
+
+    @Override
+    protected void onAttachedToWindow() {
+        super.onAttachedToWindow();
+        setAnimating(indeterminate);
+    }
+
+    @Override
+    protected void onDetachedFromWindow() {
+        super.onDetachedFromWindow();
+        setAnimating(false);
+    }
+
+    @Override
+    protected void onDraw(Canvas canvas) {
+        super.onDraw(canvas);
+        float w = getWidth();
+        float h = getHeight();
+
+        if (style == 1) { // Circular
+            float d = Math.min(w, h);
+            float lineWidth = d / 10;
+            RectF oval = new RectF((w - d + lineWidth) / 2, (h - d + lineWidth) / 2, (w + d - lineWidth) / 2, (h + d - lineWidth) / 2);
+            trackPaint.setStrokeWidth(lineWidth);
+            progressPaint.setStrokeWidth(lineWidth);
+            canvas.drawArc(oval, 0, 360, false, trackPaint);
+            if (indeterminate) {
+                // A partial ring spins around the track.
+                canvas.drawArc(oval, -90 + 360 * phase, 270, false, progressPaint);
+            } else if (progress > 0) {
+                canvas.drawArc(oval, -90, 360 * progress, false, progressPaint);
+            }
+            return;
+        }
+
+        float start = h / 2;
+        float end = Math.max(w - h / 2, start);
+        trackPaint.setStrokeWidth(h);
+        progressPaint.setStrokeWidth(h);
+        canvas.drawLine(start, h / 2, end, h / 2, trackPaint);
+        float from = 0;
+        float to = progress;
+        if (indeterminate) {
+            // A segment slides across the track.
+            to = Math.min(phase / 0.75f, 1);
+            from = Math.max((phase - 0.25f) / 0.75f, 0);
+        }
+        if (to > from) {
+            canvas.drawLine(start + (end - start) * from, h / 2, start + (end - start) * to, h / 2, progressPaint);
+        }
+    }
+}
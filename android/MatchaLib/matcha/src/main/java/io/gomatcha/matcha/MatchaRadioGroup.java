@@ -0,0 +1,110 @@
+package io.gomatcha.matcha;
+
+import android.content.Context;
+import android.content.res.ColorStateList;
+import android.support.v4.widget.CompoundButtonCompat;
+import android.support.v7.widget.AppCompatRadioButton;
+import android.view.Gravity;
+import android.widget.RadioGroup;
+
+import java.util.ArrayList;
+import java.util.Arrays;
+import java.util.List;
+
+import io.gomatcha.bridge.GoValue;
+
+class MatchaRadioGroup extends MatchaChildView {
+    MatchaViewNode viewNode;
+    RadioGroup group;
+    List<String> options = new ArrayList<String>();
+    ColorStateList defaultTint;
+    int selected = -1;
+    boolean needsUpdate;
+
+    static {
+        MatchaView.registerView("gomatcha.io/matcha/view/radiogroup", new MatchaView.ViewFactory() {
+            @Override
+            public MatchaChildView createView(Context context, MatchaViewNode node) {
+                return new MatchaRadioGroup(context, node);
+            }
+        });
+    }
+
+    public MatchaRadioGroup(Context context, MatchaViewNode node) {
+        super(context);
+        viewNode = node;
+
+        defaultTint = CompoundButtonCompat.getButtonTintList(new AppCompatRadioButton(context));
+        group = new RadioGroup(context);
+        group.setOrientation(RadioGroup.VERTICAL);
+        group.setOnCheckedChangeListener(new RadioGroup.OnCheckedChangeListener() {
+            @Override
+            public void onCheckedChanged(RadioGroup group, int checkedId) {
+                // The buttons' ids are their indexes plus one.
+                if (checkedId != -1 && checkedId - 1 != selected) {
+                    selected = checkedId - 1;
+                    viewNode.call("OnSelect", new GoValue(selected));
+                }
+            }
+        });
+        addView(group);
+    }
+
+    @Override
+    public void setNativeState(byte[] nativeState) {
+        super.setNativeState(nativeState);
+        setNeedsUpdate();
+    }
+
+    // Go can't be called while it is updating the views, so the state is read
+    // once the update completes.
+    void setNeedsUpdate() {
+        if (needsUpdate) {
+            return;
+        }
+        needsUpdate = true;
+        post(new Runnable() {
+            @Override
+            public void run() {
+                needsUpdate = false;
+                update();
+            }
+        });
+    }
+
+    void update() {
+        GoValue[] state = viewNode.call("State");
+        GoValue[] values = state[0].toArray();
+        String[] options = new String[values.length];
+        for (int i = 0; i < values.length; i++) {
+            options[i] = values[i].toString();
+        }
+        // Go's selection is stored first, so that it isn't reported back.
+        selected = (int)state[1].toLong();
+        boolean enabled = state[2].toBool();
+        long color = state[3].toLong();
+
+        if (!this.options.equals(Arrays.asList(options))) {
+            this.options = Arrays.asList(options);
+            group.removeAllViews();
+            float ratio = getResources().getDisplayMetrics().density;
+            for (int i = 0; i < options.length; i++) {
+                AppCompatRadioButton button = new AppCompatRadioButton(getContext());
+                button.setId(i + 1);
+                button.setText(options[i]);
+                button.setGravity(Gravity.CENTER_VERTICAL);
+                group.addView(button, new RadioGroup.LayoutParams(RadioGroup.LayoutParams.MATCH_PARENT, (int)(48 * ratio)));
+            }
+        }
+        if (selected == -1) {
+            group.clearCheck();
+        } else if (group.getCheckedRadioButtonId() != selected + 1) {
+            group.check(selected + 1);
+        }
+        for (int i = 0; i < group.getChildCount(); i++) {
+            AppCompatRadioButton button = (AppCompatRadioButton)group.getChildAt(i);
+            button.setEnabled(enabled);
+            CompoundButtonCompat.setButtonTintList(button, color == -1 ? defaultTint : MatchaCheckbox.tintList((int)color));
+        }
+    }
+}
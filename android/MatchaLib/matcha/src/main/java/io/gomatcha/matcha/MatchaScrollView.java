@@ -1,6 +1,7 @@
 package io.gomatcha.matcha;
 
 import android.content.Context;
+import android.support.v4.widget.SwipeRefreshLayout;
 import android.util.DisplayMetrics;
 import android.util.Log;
 import android.view.MotionEvent;
@@ -16,12 +17,14 @@ import io.gomatcha.matcha.proto.layout.PbLayout;
 import io.gomatcha.matcha.proto.view.PbScrollView;
 
 class MatchaScrollView extends MatchaChildView {
+    SwipeRefreshLayout refreshLayout;
     ScrollView scrollView;
     MatchaLayout childView;
     MatchaViewNode viewNode;
     boolean hasOnTouchListener;
     int matchaX;
     int matchaY;
+    boolean needsRefreshState;
 
     static {
         MatchaView.registerView("gomatcha.io/matcha/view/scrollview", new MatchaView.ViewFactory() {
@@ -57,7 +60,16 @@ class MatchaScrollView extends MatchaChildView {
                 MatchaScrollView.this.viewNode.call("OnScroll", new GoValue(event.toByteArray()));
             }
         });
-        addView(scrollView);
+        refreshLayout = new SwipeRefreshLayout(context);
+        refreshLayout.setEnabled(false);
+        refreshLayout.setOnRefreshListener(new SwipeRefreshLayout.OnRefreshListener() {
+            @Override
+            public void onRefresh() {
+                viewNode.call("OnRefresh");
+            }
+        });
+        refreshLayout.addView(scrollView);
+        addView(refreshLayout);
 
         childView = new MatchaLayout(context);
         scrollView.addView(childView);
@@ -89,5 +101,26 @@ class MatchaScrollView extends MatchaChildView {
             }
         } catch (InvalidProtocolBufferException e) {
         }
+        setNeedsRefreshState();
+    }
+
+    // Go can't be called while it is updating the views, so the refresh state is
+    // read once the update completes.
+    void setNeedsRefreshState() {
+        if (needsRefreshState) {
+            return;
+        }
+        needsRefreshState = true;
+        post(new Runnable() {
+            @Override
+            public void run() {
+                needsRefreshState = false;
+                GoValue[] state = viewNode.call("Refresh");
+                refreshLayout.setEnabled(state[0].toBool());
+                if (refreshLayout.isRefreshing() != state[1].toBool()) {
+                    refreshLayout.setRefreshing(state[1].toBool());
+                }
+            }
+        });
     }
 }
@@ -0,0 +1,69 @@
+package io.gomatcha.matcha;
+
+import android.support.design.widget.Snackbar;
+import android.view.View;
+
+import java.lang.ref.WeakReference;
+
+import io.gomatcha.bridge.GoValue;
+
+// MatchaSnackbar displays the messages of the view/snackbar package. Go queues
+// the messages, so at most one is displayed at a time.
+class MatchaSnackbar {
+    static Snackbar snackbar;
+    static long snackbarId;
+
+    static void present(final long id, String text, String action, long duration) {
+        View view = rootView();
+        if (view == null) {
+            // Nothing is displayed, so the next message is displayed instead.
+            onDismiss(id, false);
+            return;
+        }
+
+        int length = duration < 0 ? Snackbar.LENGTH_INDEFINITE : (int)duration;
+        final Snackbar snackbar = Snackbar.make(view, text, length);
+        if (action.length() > 0) {
+            snackbar.setAction(action, new View.OnClickListener() {
+                @Override
+                public void onClick(View v) {
+                    // The action is reported when the snackbar is dismissed.
+                }
+            });
+        }
+        snackbar.addCallback(new Snackbar.Callback() {
+            @Override
+            public void onDismissed(Snackbar s, int event) {
+                if (MatchaSnackbar.snackbar == snackbar) {
+                    MatchaSnackbar.snackbar = null;
+                }
+                onDismiss(id, event == DISMISS_EVENT_ACTION);
+            }
+        });
+        MatchaSnackbar.snackbar = snackbar;
+        MatchaSnackbar.snackbarId = id;
+        snackbar.show();
+    }
+
+    static void dismiss(long id) {
+        if (snackbar != null && snackbarId == id) {
+            snackbar.dismiss();
+        }
+    }
+
+    static void onDismiss(long id, boolean action) {
+        GoValue.withFunc("gomatcha.io/matcha/view/snackbar onDismiss").call("", new GoValue(id), new GoValue(action));
+    }
+
+    // rootView returns a displayed Matcha view. The snackbar is added to the
+    // content view of its window, or the CoordinatorLayout that contains it.
+    static View rootView() {
+        for (WeakReference<MatchaView> i : JavaBridge.viewMap.values()) {
+            MatchaView view = i.get();
+            if (view != null && view.isShown()) {
+                return view;
+            }
+        }
+        return null;
+    }
+}
@@ -15,6 +15,7 @@ import io.gomatcha.matcha.proto.view.android.PbStackView;
 class MatchaStackView extends MatchaChildView {
     MatchaViewNode viewNode;
     MatchaStackView2 stackView2;
+    List<PbStackView.StackChildView> children = new ArrayList<PbStackView.StackChildView>();
 
     static {
         MatchaView.registerView("gomatcha.io/matcha/view/android StackView", new MatchaView.ViewFactory() {
@@ -38,6 +39,7 @@ class MatchaStackView extends MatchaChildView {
         super.setNativeState(nativeState);
         try {
             PbStackView.StackView proto  = PbStackView.StackView.parseFrom(nativeState);
+            children = proto.getChildrenList();
         } catch (InvalidProtocolBufferException e) {
         }
     }
@@ -67,6 +69,7 @@ class MatchaStackView extends MatchaChildView {
                 toolbar.setId(MatchaPagerView.generateViewId());
             }
             toolbar.stackView = this;
+            toolbar.setContentView(childViews.get(i * 2 + 1));
             wrapper.setToolbarView(toolbar);
 
             View childView = childViews.get(i * 2 + 1);
@@ -74,7 +77,7 @@ class MatchaStackView extends MatchaChildView {
 
             toolbarViews.add(wrapper);
         }
-        stackView2.setChildViews(toolbarViews);
+        stackView2.setChildViews(toolbarViews, children);
     }
 
     public void back() {
@@ -3,12 +3,17 @@ package io.gomatcha.matcha;
 import android.animation.Animator;
 import android.animation.AnimatorListenerAdapter;
 import android.content.Context;
+import android.support.v4.view.animation.PathInterpolatorCompat;
 import android.view.View;
+import android.view.ViewPropertyAnimator;
+import android.view.animation.AccelerateDecelerateInterpolator;
 import android.widget.RelativeLayout;
 
 import java.util.ArrayList;
 import java.util.List;
 
+import io.gomatcha.matcha.proto.view.android.PbStackView;
+
 class MatchaStackView2 extends RelativeLayout {
     public MatchaStackView2(Context context) {
         super(context);
@@ -16,50 +21,97 @@ class MatchaStackView2 extends RelativeLayout {
 
     List<View> childViews = new ArrayList<View>();
     View topView;
+    PbStackView.StackChildView topTransition;
+
+    // These match the transition styles in view/android/transition.go.
+    static final long TRANSITION_DEFAULT = 0;
+    static final long TRANSITION_SLIDE = 1;
+    static final long TRANSITION_FADE = 2;
+    static final long TRANSITION_MODAL = 3;
+    static final long TRANSITION_NONE = 4;
 
     List<View> getChildViews() {
         return childViews;
     }
 
-    void setChildViews(List<View> v) {
+    // setChildViews shows the top view of v. Views that are pushed are animated
+    // in with their transition, and views that are popped are animated out with
+    // the reverse of the transition that they were pushed with.
+    void setChildViews(List<View> v, List<PbStackView.StackChildView> transitions) {
         boolean enter = childViews.size() <= v.size();
 
         childViews = v;
 
         if (childViews.size() > 0) {
-            long duration = getResources().getInteger(android.R.integer.config_shortAnimTime);
             View top = childViews.get(childViews.size()-1);
+            PbStackView.StackChildView transition = transitions.size() == childViews.size() ? transitions.get(transitions.size()-1) : null;
             if (enter) {
                 this.addView(top);
                 if (childViews.size() > 1) {
-                    top.setAlpha(0f);
-                    top.setTranslationY(500);
-                    top.animate()
-                            .translationY(0)
-                            .alpha(1)
-                            .setDuration(duration)
-                            .setListener(new AnimatorListenerAdapter() {
-                                @Override
-                                public void onAnimationEnd(Animator animation) {
-                                    reload();
-                                }
-                            });
+                    animate(top, transition, true);
                 }
             } else {
                 this.addView(top, 0);
-                topView.animate()
-                        .translationY(500)
-                        .alpha(0)
-                        .setDuration(duration)
-                        .setListener(new AnimatorListenerAdapter() {
-                            @Override
-                            public void onAnimationEnd(Animator animation) {
-                                reload();
-                            }
-                        });
+                animate(topView, topTransition, false);
             }
             topView = top;
+            topTransition = transition;
+        }
+    }
+
+    // animate animates view in from where transition hides it, or out to it,
+    // and removes the views below the top view once it is done.
+    void animate(View view, PbStackView.StackChildView transition, boolean in) {
+        if (transition == null) {
+            transition = PbStackView.StackChildView.getDefaultInstance();
+        }
+        if (transition.getTransition() == TRANSITION_NONE) {
+            reload();
+            return;
+        }
+
+        float translationX = 0;
+        float translationY = 0;
+        float alpha = 1;
+        if (transition.getTransition() == TRANSITION_SLIDE) {
+            translationX = getWidth();
+            if (android.os.Build.VERSION.SDK_INT >= 17 && getLayoutDirection() == View.LAYOUT_DIRECTION_RTL) {
+                translationX = -translationX;
+            }
+        } else if (transition.getTransition() == TRANSITION_FADE) {
+            alpha = 0;
+        } else if (transition.getTransition() == TRANSITION_MODAL) {
+            translationY = getHeight();
+        } else {
+            translationY = 500;
+            alpha = 0;
+        }
+
+        long duration = getResources().getInteger(android.R.integer.config_shortAnimTime);
+        if (transition.getTransitionDuration() > 0) {
+            duration = Math.round(transition.getTransitionDuration() * 1000);
+        }
+        ViewPropertyAnimator animator = view.animate();
+        if (transition.getCurveX0() != 0 || transition.getCurveY0() != 0 || transition.getCurveX1() != 0 || transition.getCurveY1() != 0) {
+            animator.setInterpolator(PathInterpolatorCompat.create((float)transition.getCurveX0(), (float)transition.getCurveY0(), (float)transition.getCurveX1(), (float)transition.getCurveY1()));
+        } else {
+            animator.setInterpolator(new AccelerateDecelerateInterpolator());
+        }
+        if (in) {
+            view.setTranslationX(translationX);
+            view.setTranslationY(translationY);
+            view.setAlpha(alpha);
+            animator.translationX(0).translationY(0).alpha(1);
+        } else {
+            animator.translationX(translationX).translationY(translationY).alpha(alpha);
         }
+        animator.setDuration(duration)
+                .setListener(new AnimatorListenerAdapter() {
+                    @Override
+                    public void onAnimationEnd(Animator animation) {
+                        reload();
+                    }
+                });
     }
 
     void reload() {
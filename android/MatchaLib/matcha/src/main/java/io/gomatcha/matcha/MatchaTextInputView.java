@@ -27,6 +27,7 @@ class MatchaTextInputView extends MatchaChildView {
     EditText view;
     boolean editing;
     boolean focused;
+    long maxLines = -1;
     MatchaViewNode viewNode;
 
     static {
@@ -180,7 +181,16 @@ class MatchaTextInputView extends MatchaChildView {
                 default:
             }
             view.setImeOptions(imeOptions);
-            view.setSingleLine(proto.getMaxLines() == 1);
+            if (proto.getMaxLines() != maxLines) {
+                // Multiline inputs grow up to maxLines lines, sized by Go, and
+                // scroll beyond.
+                maxLines = proto.getMaxLines();
+                view.setSingleLine(maxLines == 1);
+                if (maxLines != 1) {
+                    view.setMaxLines(maxLines == 0 ? Integer.MAX_VALUE : (int)maxLines);
+                    view.setVerticalScrollBarEnabled(true);
+                }
+            }
 
             view.setHint(Protobuf.newAttributedString(proto.getPlaceholderText()));
             focused = proto.getFocused();
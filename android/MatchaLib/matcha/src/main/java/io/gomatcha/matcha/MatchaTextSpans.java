@@ -0,0 +1,78 @@
+package io.gomatcha.matcha;
+
+import android.graphics.Paint;
+import android.text.Layout;
+import android.text.TextPaint;
+import android.text.style.MetricAffectingSpan;
+
+import io.gomatcha.matcha.proto.text.PbText;
+
+// MatchaTextSpans holds the spans of the text.Style options that the framework
+// has no spans for.
+class MatchaTextSpans {
+    // hyphenationFrequency returns the hyphenation of a text view for a
+    // text.Style hyphenation factor.
+    static int hyphenationFrequency(PbText.TextStyle style) {
+        if (style.getHyphenation() <= 0) {
+            return Layout.HYPHENATION_FREQUENCY_NONE;
+        } else if (style.getHyphenation() < 1) {
+            return Layout.HYPHENATION_FREQUENCY_NORMAL;
+        }
+        return Layout.HYPHENATION_FREQUENCY_FULL;
+    }
+
+    // LetterSpacingSpan adds space between characters, in pixels. Paint measures
+    // letter spacing in ems, so it is converted once the size of the text is
+    // known.
+    static class LetterSpacingSpan extends MetricAffectingSpan {
+        float spacing;
+
+        LetterSpacingSpan(float spacing) {
+            this.spacing = spacing;
+        }
+
+        @Override
+        public void updateDrawState(TextPaint paint) {
+            apply(paint);
+        }
+
+        @Override
+        public void updateMeasureState(TextPaint paint) {
+            apply(paint);
+        }
+
+        void apply(Paint paint) {
+            if (android.os.Build.VERSION.SDK_INT >= 21 && paint.getTextSize() > 0) {
+                paint.setLetterSpacing(spacing / paint.getTextSize());
+            }
+        }
+    }
+
+    // LineHeightSpan sets the height of lines, in pixels or as a multiple of
+    // their natural height, and adds paragraphSpacing after the lines that end
+    // a paragraph. The extra height is added above the text, like on iOS.
+    static class LineHeightSpan implements android.text.style.LineHeightSpan {
+        int height;
+        float multiple;
+        int paragraphSpacing;
+
+        LineHeightSpan(int height, float multiple, int paragraphSpacing) {
+            this.height = height;
+            this.multiple = multiple;
+            this.paragraphSpacing = paragraphSpacing;
+        }
+
+        @Override
+        public void chooseHeight(CharSequence text, int start, int end, int spanstartv, int v, Paint.FontMetricsInt fm) {
+            int natural = fm.descent - fm.ascent;
+            int target = height > 0 ? height : Math.round(natural * multiple);
+            int extra = target - natural;
+            fm.ascent -= extra;
+            fm.top -= extra;
+            if (paragraphSpacing > 0 && end > start && text.charAt(end - 1) == '\n') {
+                fm.descent += paragraphSpacing;
+                fm.bottom += paragraphSpacing;
+            }
+        }
+    }
+}
@@ -1,16 +1,30 @@
 package io.gomatcha.matcha;
 
 import android.content.Context;
+import android.text.Selection;
+import android.text.Spannable;
 import android.text.SpannableString;
+import android.text.Spanned;
+import android.text.TextPaint;
+import android.text.TextUtils;
+import android.text.method.LinkMovementMethod;
+import android.text.style.BackgroundColorSpan;
+import android.text.style.ClickableSpan;
+import android.view.View;
 import android.widget.TextView;
 
 import com.google.protobuf.InvalidProtocolBufferException;
 
+import io.gomatcha.bridge.GoValue;
 import io.gomatcha.matcha.proto.text.PbText;
 
 class MatchaTextView extends MatchaChildView {
     TextView view;
     MatchaViewNode viewNode;
+    SpannableString styledText;
+    boolean needsSpans;
+    boolean selectable;
+    boolean updating;
 
     static {
         MatchaView.registerView("gomatcha.io/matcha/view/textview", new MatchaView.ViewFactory() {
@@ -25,7 +39,17 @@ class MatchaTextView extends MatchaChildView {
         super(context);
         viewNode = node;
 
-        view = new TextView(context);
+        view = new TextView(context) {
+            @Override
+            protected void onSelectionChanged(int selStart, int selEnd) {
+                super.onSelectionChanged(selStart, selEnd);
+                // Go's text is set first, so that the selection it clears
+                // isn't reported back.
+                if (selectable && !updating && viewNode != null) {
+                    viewNode.call("OnSelectionChange", new GoValue(Math.min(selStart, selEnd)), new GoValue(Math.max(selStart, selEnd)));
+                }
+            }
+        };
         addView(view);
     }
 
@@ -35,8 +59,145 @@ class MatchaTextView extends MatchaChildView {
         try {
             PbText.StyledText proto  = PbText.StyledText.parseFrom(nativeState);
             SpannableString str = Protobuf.newAttributedString(proto);
+            PbText.TextStyle style = proto.getStylesCount() > 0 ? proto.getStyles(0) : PbText.TextStyle.getDefaultInstance();
+            updating = true;
             view.setText(str);
+            view.setEllipsize(truncateAt(style.getTruncation()));
+            if (android.os.Build.VERSION.SDK_INT >= 17) {
+                view.setTextDirection(textDirection(style.getWritingDirection()));
+            }
+            if (android.os.Build.VERSION.SDK_INT >= 23) {
+                view.setHyphenationFrequency(MatchaTextSpans.hyphenationFrequency(style));
+            }
+            updating = false;
+            styledText = str;
+            updateMaxLines();
         } catch (InvalidProtocolBufferException e) {
+            return;
+        }
+
+        // Go can't be called while it is updating the views, so the spans are
+        // read once the update completes.
+        if (needsSpans) {
+            return;
+        }
+        needsSpans = true;
+        post(new Runnable() {
+            @Override
+            public void run() {
+                needsSpans = false;
+                updateSpans();
+            }
+        });
+    }
+
+    @Override
+    protected void onSizeChanged(int w, int h, int oldw, int oldh) {
+        super.onSizeChanged(w, h, oldw, oldh);
+        // The view is being laid out, so its lines are changed afterwards.
+        post(new Runnable() {
+            @Override
+            public void run() {
+                updateMaxLines();
+            }
+        });
+    }
+
+    // TextView only ellipsizes the last line it is allowed to display, so the
+    // number of lines that fit is computed from the height. The start and
+    // middle can only be ellipsized on a single line.
+    void updateMaxLines() {
+        TextUtils.TruncateAt truncateAt = view.getEllipsize();
+        int maxLines = Integer.MAX_VALUE;
+        if (truncateAt == TextUtils.TruncateAt.START || truncateAt == TextUtils.TruncateAt.MIDDLE) {
+            maxLines = 1;
+        } else if (truncateAt != null && getHeight() > 0 && view.getLineHeight() > 0) {
+            maxLines = Math.max(1, getHeight() / view.getLineHeight());
+        }
+        if (view.getMaxLines() != maxLines) {
+            view.setMaxLines(maxLines);
+        }
+    }
+
+    static TextUtils.TruncateAt truncateAt(PbText.Truncation truncation) {
+        switch (truncation) {
+            case TRUNCATION_START:
+                return TextUtils.TruncateAt.START;
+            case TRUNCATION_MIDDLE:
+                return TextUtils.TruncateAt.MIDDLE;
+            case TRUNCATION_END:
+                return TextUtils.TruncateAt.END;
+            default:
+                return null;
+        }
+    }
+
+    static int textDirection(PbText.WritingDirection direction) {
+        switch (direction) {
+            case WRITING_DIRECTION_LEFT_TO_RIGHT:
+                return View.TEXT_DIRECTION_LTR;
+            case WRITING_DIRECTION_RIGHT_TO_LEFT:
+                return View.TEXT_DIRECTION_RTL;
+            default:
+                return View.TEXT_DIRECTION_FIRST_STRONG;
+        }
+    }
+
+    void updateSpans() {
+        GoValue[] spans = viewNode.call("Spans")[0].toArray();
+        SpannableString str = null;
+        boolean tappable = false;
+        for (int i = 0; i + 3 < spans.length; i += 4) {
+            final int start = (int)spans[i].toLong();
+            int end = (int)spans[i+1].toLong();
+            int argb = (int)spans[i+2].toLong();
+            if (start < 0 || end > styledText.length() || start >= end) {
+                continue;
+            }
+            if (str == null) {
+                str = new SpannableString(styledText);
+            }
+            if (argb != 0) {
+                str.setSpan(new BackgroundColorSpan(argb), start, end, Spanned.SPAN_EXCLUSIVE_EXCLUSIVE);
+            }
+            if (spans[i+3].toLong() != 0) {
+                tappable = true;
+                str.setSpan(new ClickableSpan() {
+                    @Override
+                    public void onClick(View widget) {
+                        // LinkMovementMethod highlights the pressed span by
+                        // selecting it, and leaves it selected.
+                        if (!selectable && view.getText() instanceof Spannable) {
+                            Selection.removeSelection((Spannable)view.getText());
+                        }
+                        viewNode.call("OnTap", new GoValue(start));
+                    }
+
+                    @Override
+                    public void updateDrawState(TextPaint ds) {
+                        // The span is styled by Go.
+                    }
+                }, start, end, Spanned.SPAN_EXCLUSIVE_EXCLUSIVE);
+            }
+        }
+        updating = true;
+        if (str != null) {
+            view.setText(str);
+        }
+        selectable = viewNode.call("Selectable")[0].toBool();
+        if (view.isTextSelectable() != selectable) {
+            view.setTextIsSelectable(selectable);
+        }
+        // Selectable text keeps the movement method that handles selection,
+        // unless it has links.
+        if (tappable) {
+            view.setMovementMethod(LinkMovementMethod.getInstance());
+            if (!selectable) {
+                view.setHighlightColor(0x33000000);
+            }
+        } else if (!selectable) {
+            view.setMovementMethod(null);
         }
+        updating = false;
     }
 }
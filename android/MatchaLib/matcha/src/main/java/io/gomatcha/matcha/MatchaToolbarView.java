@@ -3,22 +3,43 @@ package io.gomatcha.matcha;
 import android.content.Context;
 import android.graphics.PorterDuff;
 import android.graphics.drawable.Drawable;
+import android.support.v7.widget.SearchView;
 import android.support.v7.widget.Toolbar;
 import android.util.DisplayMetrics;
+import android.view.Gravity;
 import android.view.Menu;
 import android.view.MenuItem;
 import android.view.View;
+import android.view.ViewGroup;
+import android.view.ViewTreeObserver;
+import android.widget.TextView;
 
 import com.google.protobuf.InvalidProtocolBufferException;
 
 import java.util.List;
 
+import io.gomatcha.bridge.GoValue;
+import io.gomatcha.matcha.proto.Proto;
 import io.gomatcha.matcha.proto.view.android.PbStackView;
 
 class MatchaToolbarView extends MatchaChildView {
     Toolbar toolbar;
     MatchaStackView stackView;
     MatchaViewNode viewNode;
+    MenuItem searchItem;
+    SearchView searchView;
+    String searchText = "";
+    boolean needsUpdate;
+    TextView largeTitleView;
+    CharSequence title = "";
+    int titleMode;
+    Integer scrollEdgeColor;
+    float expanded = 1; // How much of the large title is shown, while collapsing.
+    View contentView;
+    ViewTreeObserver.OnScrollChangedListener scrollListener;
+
+    static final int TITLE_MODE_STANDARD = 0;
+    static final int TITLE_MODE_COLLAPSING = 2;
 
     static {
         MatchaView.registerView("gomatcha.io/matcha/view/android stackBarView", new MatchaView.ViewFactory() {
@@ -42,10 +63,45 @@ class MatchaToolbarView extends MatchaChildView {
             }
         });
         if (android.os.Build.VERSION.SDK_INT >= 21){
-            float ratio = (float)context.getResources().getDisplayMetrics().densityDpi / DisplayMetrics.DENSITY_DEFAULT;
-            this.setElevation(4*ratio);
+            this.setElevation(4*ratio());
         }
         addView(toolbar);
+
+        largeTitleView = new TextView(context);
+        largeTitleView.setTextAppearance(context, R.style.TextAppearance_AppCompat_Headline);
+        largeTitleView.setGravity(Gravity.BOTTOM | Gravity.START);
+        largeTitleView.setSingleLine(true);
+        largeTitleView.setPadding((int)(16*ratio()), 0, (int)(16*ratio()), (int)(12*ratio()));
+        largeTitleView.setVisibility(GONE);
+        addView(largeTitleView);
+
+        scrollListener = new ViewTreeObserver.OnScrollChangedListener() {
+            @Override
+            public void onScrollChanged() {
+                updateScroll();
+            }
+        };
+    }
+
+    float ratio() {
+        return (float)getContext().getResources().getDisplayMetrics().densityDpi / DisplayMetrics.DENSITY_DEFAULT;
+    }
+
+    @Override
+    protected void onAttachedToWindow() {
+        super.onAttachedToWindow();
+        getViewTreeObserver().addOnScrollChangedListener(scrollListener);
+    }
+
+    @Override
+    protected void onDetachedFromWindow() {
+        super.onDetachedFromWindow();
+        getViewTreeObserver().removeOnScrollChangedListener(scrollListener);
+    }
+
+    void setContentView(View view) {
+        contentView = view;
+        updateScroll();
     }
 
     @Override
@@ -55,10 +111,11 @@ class MatchaToolbarView extends MatchaChildView {
             PbStackView.StackBar proto  = PbStackView.StackBar.parseFrom(nativeState);
 
             if (proto.hasStyledTitle()) {
-                toolbar.setTitle(Protobuf.newAttributedString(proto.getStyledTitle()));
+                title = Protobuf.newAttributedString(proto.getStyledTitle());
             } else {
-                toolbar.setTitle(proto.getTitle());
+                title = proto.getTitle();
             }
+            updateTitle();
             if (proto.hasStyledSubtitle()) {
                 toolbar.setSubtitle(Protobuf.newAttributedString(proto.getStyledSubtitle()));
             } else {
@@ -72,7 +129,7 @@ class MatchaToolbarView extends MatchaChildView {
 
             List<PbStackView.StackBarItem> itemList = proto.getItemsList();
             Menu menu = toolbar.getMenu();
-            menu.clear();
+            menu.removeGroup(0); // The search item is in group 1, and keeps its state.
             for (int i = 0; i < itemList.size(); i++) {
                 PbStackView.StackBarItem protoItem = itemList.get(i);
                 final String onPressFunc = protoItem.getOnPressFunc();
@@ -98,15 +155,210 @@ class MatchaToolbarView extends MatchaChildView {
             }
         } catch (InvalidProtocolBufferException e) {
         }
+        setNeedsUpdate();
+    }
+
+    // Go can't be called while it is updating the views, so the bar's options
+    // and search bar are read once the update completes.
+    void setNeedsUpdate() {
+        if (needsUpdate) {
+            return;
+        }
+        needsUpdate = true;
+        post(new Runnable() {
+            @Override
+            public void run() {
+                needsUpdate = false;
+                updateBar();
+                updateSearch();
+            }
+        });
+    }
+
+    void updateBar() {
+        GoValue[] state = viewNode.call("Bar State");
+        titleMode = (int)state[0].toLong();
+        scrollEdgeColor = null;
+        byte[] color = state[1].toByteArray();
+        if (color != null && color.length > 0) {
+            try {
+                scrollEdgeColor = Protobuf.newColor(Proto.Color.parseFrom(color));
+            } catch (InvalidProtocolBufferException e) {
+            }
+        }
+        GoValue[] overflow = state[2].toArray();
+        Menu menu = toolbar.getMenu();
+        for (int i = 0; i < overflow.length; i++) {
+            MenuItem item = menu.findItem(Menu.FIRST + i);
+            if (item != null) {
+                item.setShowAsAction(overflow[i].toBool() ? MenuItem.SHOW_AS_ACTION_NEVER : MenuItem.SHOW_AS_ACTION_ALWAYS);
+            }
+        }
+        largeTitleView.setVisibility(titleMode == TITLE_MODE_STANDARD ? GONE : VISIBLE);
+        updateScroll();
+        updateTitle();
+        requestLayout();
+    }
+
+    // updateScroll collapses the large title and raises the bar as the content
+    // scrolls.
+    void updateScroll() {
+        View scrollView = findScrollView(contentView);
+        boolean atEdge = scrollView == null || !scrollView.canScrollVertically(-1);
+
+        float expanded = 1;
+        if (titleMode == TITLE_MODE_COLLAPSING && !atEdge) {
+            // Views such as RecyclerView don't report their offset in getScrollY.
+            int offset = scrollView.getScrollY() > 0 ? scrollView.getScrollY() : Integer.MAX_VALUE;
+            expanded = Math.max(0, 1 - offset / (56 * ratio()));
+        }
+        if (expanded != this.expanded) {
+            this.expanded = expanded;
+            updateTitle();
+            requestLayout();
+        }
+
+        if (scrollEdgeColor != null && atEdge) {
+            toolbar.setBackgroundColor(scrollEdgeColor);
+        } else {
+            toolbar.setBackgroundColor(0);
+        }
+        if (android.os.Build.VERSION.SDK_INT >= 21) {
+            this.setElevation(scrollEdgeColor != null && atEdge ? 0 : 4*ratio());
+        }
+    }
+
+    // findScrollView returns the first view in the content that scrolls
+    // vertically.
+    static View findScrollView(View view) {
+        if (view == null) {
+            return null;
+        }
+        if (view.canScrollVertically(1) || view.canScrollVertically(-1)) {
+            return view;
+        }
+        if (view instanceof ViewGroup) {
+            ViewGroup group = (ViewGroup)view;
+            for (int i = 0; i < group.getChildCount(); i++) {
+                View scrollView = findScrollView(group.getChildAt(i));
+                if (scrollView != null) {
+                    return scrollView;
+                }
+            }
+        }
+        return null;
+    }
+
+    void updateTitle() {
+        if (titleMode == TITLE_MODE_STANDARD) {
+            toolbar.setTitle(title);
+            return;
+        }
+        // The title moves into the toolbar once the large title is mostly
+        // hidden.
+        largeTitleView.setText(title);
+        largeTitleView.setAlpha(expanded);
+        toolbar.setTitle(expanded < 0.5 ? title : "");
+    }
+
+    void updateSearch() {
+        GoValue[] state = viewNode.call("Search State");
+        boolean enabled = state[0].toBool();
+        String placeholder = state[1].toString();
+        String text = state[2].toString();
+        boolean focused = state[3].toBool();
+
+        Menu menu = toolbar.getMenu();
+        if (!enabled) {
+            if (searchItem != null) {
+                menu.removeGroup(1);
+                searchItem = null;
+                searchView = null;
+            }
+            return;
+        }
+        if (searchItem == null) {
+            searchView = new SearchView(getContext());
+            searchView.setOnQueryTextListener(new SearchView.OnQueryTextListener() {
+                @Override
+                public boolean onQueryTextSubmit(String query) {
+                    viewNode.call("Search OnSubmit", new GoValue(query));
+                    return false;
+                }
+
+                @Override
+                public boolean onQueryTextChange(String newText) {
+                    if (!newText.equals(searchText)) {
+                        searchText = newText;
+                        viewNode.call("Search OnChange", new GoValue(newText));
+                    }
+                    return true;
+                }
+            });
+            searchView.setOnQueryTextFocusChangeListener(new OnFocusChangeListener() {
+                @Override
+                public void onFocusChange(View view, boolean hasFocus) {
+                    viewNode.call("Search OnFocus", new GoValue(hasFocus));
+                }
+            });
+
+            searchItem = menu.add(1, Menu.NONE, Menu.NONE, "Search");
+            searchItem.setIcon(R.drawable.abc_ic_search_api_material);
+            searchItem.setShowAsAction(MenuItem.SHOW_AS_ACTION_ALWAYS | MenuItem.SHOW_AS_ACTION_COLLAPSE_ACTION_VIEW);
+            searchItem.setActionView(searchView);
+            searchItem.setOnActionExpandListener(new MenuItem.OnActionExpandListener() {
+                @Override
+                public boolean onMenuItemActionExpand(MenuItem item) {
+                    return true;
+                }
+
+                @Override
+                public boolean onMenuItemActionCollapse(MenuItem item) {
+                    searchText = "";
+                    viewNode.call("Search OnCancel");
+                    return true;
+                }
+            });
+        }
+        searchView.setQueryHint(placeholder);
+        if (!text.equals(searchText)) {
+            searchText = text;
+            if (text.length() > 0 && !searchItem.isActionViewExpanded()) {
+                searchItem.expandActionView();
+            }
+            searchView.setQuery(text, false);
+        }
+        if (focused && !searchView.hasFocus()) {
+            searchItem.expandActionView();
+            searchView.requestFocus();
+        } else if (!focused && searchView.hasFocus()) {
+            searchView.clearFocus();
+        }
     }
 
     @Override
     protected void onMeasure(int widthMeasureSpec, int heightMeasureSpec) {
-        int desiredHeight = (int)Math.ceil(56.0 * (float)getContext().getResources().getDisplayMetrics().densityDpi / DisplayMetrics.DENSITY_DEFAULT);
+        int desiredHeight = (int)Math.ceil(56.0 * ratio());
+        if (titleMode != TITLE_MODE_STANDARD) {
+            desiredHeight += (int)Math.ceil(56.0 * ratio() * expanded);
+        }
         if (MeasureSpec.getMode(heightMeasureSpec) == MeasureSpec.UNSPECIFIED ||
                 (MeasureSpec.getMode(heightMeasureSpec) == MeasureSpec.AT_MOST && MeasureSpec.getSize(heightMeasureSpec) > desiredHeight)) {
             heightMeasureSpec = MeasureSpec.makeMeasureSpec(desiredHeight, MeasureSpec.EXACTLY);
         }
         super.onMeasure(widthMeasureSpec, heightMeasureSpec);
     }
+
+    @Override
+    protected void onLayout(boolean changed, int left, int top, int right, int bottom) {
+        // The toolbar keeps its height, and the large title fills the rest of
+        // the view.
+        int width = right - left;
+        int height = bottom - top;
+        int toolbarHeight = Math.min((int)Math.ceil(56.0 * ratio()), height);
+        toolbar.measure(MeasureSpec.makeMeasureSpec(width, MeasureSpec.EXACTLY), MeasureSpec.makeMeasureSpec(toolbarHeight, MeasureSpec.EXACTLY));
+        toolbar.layout(0, 0, width, toolbarHeight);
+        largeTitleView.measure(MeasureSpec.makeMeasureSpec(width, MeasureSpec.EXACTLY), MeasureSpec.makeMeasureSpec(height - toolbarHeight, MeasureSpec.EXACTLY));
+        largeTitleView.layout(0, toolbarHeight, width, height);
+    }
 }
@@ -3,13 +3,20 @@ package io.gomatcha.matcha;
 import android.app.Activity;
 import android.content.Context;
 import android.content.res.Configuration;
+import android.graphics.Rect;
 import android.os.Build;
 import android.util.DisplayMetrics;
 import android.util.Log;
+import android.view.InputDevice;
 import android.view.KeyEvent;
+import android.view.MotionEvent;
 import android.view.View;
+import android.view.ViewConfiguration;
+import android.view.ViewTreeObserver;
 import android.view.Window;
+import android.view.WindowInsets;
 import android.view.WindowManager;
+import android.widget.EditText;
 import android.widget.RelativeLayout;
 
 import java.lang.ref.WeakReference;
@@ -18,7 +25,6 @@ import java.util.HashMap;
 import java.util.Map;
 
 import io.gomatcha.bridge.GoValue;
-import io.gomatcha.matcha.proto.view.PbView;
 import io.gomatcha.matcha.proto.view.android.PbStatusBar;
 
 public class MatchaView extends RelativeLayout {
@@ -41,6 +47,47 @@ public class MatchaView extends RelativeLayout {
         // Initialize JavaBridge
         JavaBridge.init(context);
         JavaBridge.viewMap.put(identifier, new WeakReference<MatchaView>(this));
+
+        updateLayoutDirection();
+        getViewTreeObserver().addOnGlobalLayoutListener(new ViewTreeObserver.OnGlobalLayoutListener() {
+            @Override
+            public void onGlobalLayout() {
+                updateKeyboardInset();
+            }
+        });
+    }
+
+    void updateLayoutDirection() {
+        boolean rightToLeft = false;
+        if (Build.VERSION.SDK_INT >= 17) {
+            rightToLeft = getResources().getConfiguration().getLayoutDirection() == View.LAYOUT_DIRECTION_RTL;
+        }
+        GoValue.withFunc("gomatcha.io/matcha/application SetRightToLeft").call("", new GoValue(rightToLeft));
+    }
+
+    int keyboardInset = 0;
+
+    // The keyboard covers the part of the view that is outside of the window's
+    // visible frame. If the window is resized for the keyboard, there is none.
+    void updateKeyboardInset() {
+        Rect visible = new Rect();
+        getWindowVisibleDisplayFrame(visible);
+        int[] location = new int[2];
+        getLocationOnScreen(location);
+        int inset = Math.max(location[1] + getHeight() - visible.bottom, 0);
+        if (inset == keyboardInset) {
+            return;
+        }
+        keyboardInset = inset;
+
+        double height = (double)inset / this.getResources().getDisplayMetrics().densityDpi * DisplayMetrics.DENSITY_DEFAULT;
+        GoValue.withFunc("gomatcha.io/matcha/keyboard SetInset").call("",
+                new GoValue(height),
+                new GoValue(0.0),
+                new GoValue(0.42),
+                new GoValue(0.0),
+                new GoValue(0.58),
+                new GoValue(1.0));
     }
 
     public void stop() {
@@ -49,7 +96,7 @@ public class MatchaView extends RelativeLayout {
 
     boolean loaded = false;
 
-    void update(PbView.Root root) {
+    void update(ViewRoot root) {
         updating = true;
         node.setRoot(root);
 
@@ -62,8 +109,7 @@ public class MatchaView extends RelativeLayout {
             addView(node.view);
         }
 
-        Map<String, com.google.protobuf.Any> map = root.getMiddlewareMap();
-        com.google.protobuf.Any any = map.get("gomatcha.io/matcha/view/android statusbar");
+        com.google.protobuf.Any any = root.getMiddleware("gomatcha.io/matcha/view/android statusbar");
         if (any != null) {
             try {
                 PbStatusBar.StatusBar proto = any.unpack(PbStatusBar.StatusBar.class);
@@ -103,6 +149,38 @@ public class MatchaView extends RelativeLayout {
         });
     }
 
+    @Override
+    public WindowInsets onApplyWindowInsets(WindowInsets insets) {
+        int top = insets.getSystemWindowInsetTop();
+        int left = insets.getSystemWindowInsetLeft();
+        int bottom = insets.getSystemWindowInsetBottom();
+        int right = insets.getSystemWindowInsetRight();
+
+        // Display cutouts were added in API 28, which is newer than the SDK the
+        // library compiles against.
+        if (Build.VERSION.SDK_INT >= 28) {
+            try {
+                Object cutout = WindowInsets.class.getMethod("getDisplayCutout").invoke(insets);
+                if (cutout != null) {
+                    top = Math.max(top, (Integer)cutout.getClass().getMethod("getSafeInsetTop").invoke(cutout));
+                    left = Math.max(left, (Integer)cutout.getClass().getMethod("getSafeInsetLeft").invoke(cutout));
+                    bottom = Math.max(bottom, (Integer)cutout.getClass().getMethod("getSafeInsetBottom").invoke(cutout));
+                    right = Math.max(right, (Integer)cutout.getClass().getMethod("getSafeInsetRight").invoke(cutout));
+                }
+            } catch (Exception e) {
+                Log.v("x", "Unable to read display cutout " + e);
+            }
+        }
+
+        double ratio = (double)this.getResources().getDisplayMetrics().densityDpi / DisplayMetrics.DENSITY_DEFAULT;
+        GoValue.withFunc("gomatcha.io/matcha/application SetSafeAreaInsets").call("",
+                new GoValue(top / ratio),
+                new GoValue(left / ratio),
+                new GoValue(bottom / ratio),
+                new GoValue(right / ratio));
+        return super.onApplyWindowInsets(insets);
+    }
+
     public GoValue[] call(String func, long viewId, GoValue... args) {
         GoValue[] args2 = new GoValue[]{new GoValue(func), new GoValue(viewId), new GoValue(args)};
         return this.goValue.call("Call", args2);
@@ -121,6 +199,23 @@ public class MatchaView extends RelativeLayout {
             Class.forName("io.gomatcha.matcha.MatchaButton");
             Class.forName("io.gomatcha.matcha.MatchaSlider");
             Class.forName("io.gomatcha.matcha.MatchaScrollView");
+            Class.forName("io.gomatcha.matcha.MatchaListView");
+            Class.forName("io.gomatcha.matcha.MatchaCollectionView");
+            Class.forName("io.gomatcha.matcha.MatchaCameraView");
+            Class.forName("io.gomatcha.matcha.MatchaModalView");
+            Class.forName("io.gomatcha.matcha.MatchaDatePicker");
+            Class.forName("io.gomatcha.matcha.MatchaPicker");
+            Class.forName("io.gomatcha.matcha.MatchaBlurView");
+            Class.forName("io.gomatcha.matcha.MatchaCanvasView");
+            Class.forName("io.gomatcha.matcha.MatchaProgressIndicator");
+            Class.forName("io.gomatcha.matcha.MatchaCarouselView");
+            Class.forName("io.gomatcha.matcha.MatchaZoomImageView");
+            Class.forName("io.gomatcha.matcha.MatchaPDFView");
+            Class.forName("io.gomatcha.matcha.MatchaCheckbox");
+            Class.forName("io.gomatcha.matcha.MatchaRadioGroup");
+            Class.forName("io.gomatcha.matcha.MatchaBottomNavView");
+            Class.forName("io.gomatcha.matcha.MatchaDrawerView");
+            Class.forName("io.gomatcha.matcha.MatchaBarcodeScannerView");
             Class.forName("io.gomatcha.matcha.MatchaStackView");
             Class.forName("io.gomatcha.matcha.MatchaPagerView");
             Class.forName("io.gomatcha.matcha.MatchaToolbarView");
@@ -171,12 +266,69 @@ public class MatchaView extends RelativeLayout {
         return super.dispatchKeyEventPreIme(event);
     }
 
+    // Navigation
+
+    int navigationKeyCode = -1;
+    @Override
+    public boolean dispatchKeyEvent(KeyEvent event) {
+        int key = navigationKey(event.getKeyCode());
+        if (key >= 0 && !(findFocus() instanceof EditText)) {
+            if (event.getAction() == KeyEvent.ACTION_DOWN && onNavigation(key, 0)) {
+                navigationKeyCode = event.getKeyCode();
+                return true;
+            } else if (event.getAction() == KeyEvent.ACTION_UP && navigationKeyCode == event.getKeyCode()) {
+                // Don't let the focused view see the end of a key press that Go handled.
+                navigationKeyCode = -1;
+                return true;
+            }
+        }
+        return super.dispatchKeyEvent(event);
+    }
+
+    @Override
+    public boolean dispatchGenericMotionEvent(MotionEvent event) {
+        if (Build.VERSION.SDK_INT >= 26 && event.getAction() == MotionEvent.ACTION_SCROLL && event.isFromSource(InputDevice.SOURCE_ROTARY_ENCODER)) {
+            float pixels = -event.getAxisValue(MotionEvent.AXIS_SCROLL) * ViewConfiguration.get(getContext()).getScaledVerticalScrollFactor();
+            double delta = (double)pixels / this.getResources().getDisplayMetrics().densityDpi * DisplayMetrics.DENSITY_DEFAULT;
+            if (onNavigation(5, delta)) {
+                return true;
+            }
+        }
+        return super.dispatchGenericMotionEvent(event);
+    }
+
+    // navigationKey returns the application.NavigationKey of keyCode, or -1.
+    static int navigationKey(int keyCode) {
+        switch (keyCode) {
+        case KeyEvent.KEYCODE_DPAD_UP:
+            return 0;
+        case KeyEvent.KEYCODE_DPAD_DOWN:
+            return 1;
+        case KeyEvent.KEYCODE_DPAD_LEFT:
+            return 2;
+        case KeyEvent.KEYCODE_DPAD_RIGHT:
+            return 3;
+        case KeyEvent.KEYCODE_DPAD_CENTER:
+        case KeyEvent.KEYCODE_ENTER:
+            return 4;
+        default:
+            return -1;
+        }
+    }
+
+    // onNavigation returns true if Go handled the event.
+    boolean onNavigation(int key, double delta) {
+        GoValue[] rlt = GoValue.withFunc("gomatcha.io/matcha/application OnNavigation").call("", new GoValue(key), new GoValue(delta));
+        return rlt[0].toBool();
+    }
+
     // Orientation
 
     @Override
     public void onConfigurationChanged(Configuration newConfig) {
         super.onConfigurationChanged(newConfig);
         JavaBridge.javaBridge.didChangeOrientation();
+        updateLayoutDirection();
     }
 
 }
@@ -6,20 +6,25 @@ import android.graphics.drawable.GradientDrawable;
 import android.util.DisplayMetrics;
 import android.util.Log;
 import android.view.View;
+import android.view.ViewOutlineProvider;
 import android.widget.ScrollView;
 
 import com.google.protobuf.InvalidProtocolBufferException;
 
+import org.json.JSONArray;
+import org.json.JSONException;
+import org.json.JSONObject;
+
 import java.util.ArrayList;
+import java.util.Arrays;
 import java.util.HashMap;
 import java.util.Map;
 
 import io.gomatcha.bridge.GoValue;
-import io.gomatcha.matcha.proto.paint.PbPaint;
 import io.gomatcha.matcha.proto.pointer.PbPointer;
-import io.gomatcha.matcha.proto.view.PbView;
 
 public class MatchaViewNode extends Object {
+    static final String GRADIENT_KEY = "gomatcha.io/matcha/paint gradient";
     MatchaViewNode parent;
     public MatchaView rootView;
     private long id;
@@ -29,6 +34,9 @@ public class MatchaViewNode extends Object {
     Map<Long, MatchaViewNode> children = new HashMap<Long, MatchaViewNode>();
     ArrayList<MatchaViewNode> childList = new ArrayList<MatchaViewNode>();
     MatchaChildView view;
+    MatchaContextMenu contextMenu;
+    boolean hasShadow; // The elevation was set by the paint style.
+    byte[] gradient;
 
     MatchaViewNode(MatchaViewNode parent, MatchaView rootView, long id) {
         this.parent = parent;
@@ -40,9 +48,17 @@ public class MatchaViewNode extends Object {
         return this.rootView.call(func, this.id, args);
     }
 
-    void setRoot(PbView.Root root) {
-        PbView.LayoutPaintNode layoutPaintNode = root.getLayoutPaintNodesOrDefault(id, null);
-        PbView.BuildNode buildNode = root.getBuildNodesOrDefault(id, null);
+    int nodeCount() {
+        int count = 1;
+        for (MatchaViewNode i : children.values()) {
+            count += i.nodeCount();
+        }
+        return count;
+    }
+
+    void setRoot(ViewRoot root) {
+        ViewRoot.LayoutPaintNode layoutPaintNode = root.getLayoutPaintNode(id);
+        ViewRoot.BuildNode buildNode = root.getBuildNode(id);
 
         // Create view
         if (this.view == null) {
@@ -63,11 +79,11 @@ public class MatchaViewNode extends Object {
         ArrayList<Long> unmodifiedKeys = new ArrayList<Long>();
         if (buildNode != null && this.buildId != buildNode.getBuildId()) {
             for (Long i : this.children.keySet()) {
-                if (!root.containsBuildNodes(i)) {
+                if (root.getBuildNode(i) == null) {
                     removedKeys.add(i);
                 }
             }
-            for (Long i : buildNode.getChildrenList()) {
+            for (Long i : buildNode.getChildren()) {
                 MatchaViewNode prevChild = this.children.get(i);
                 if (prevChild == null) {
                     addedKeys.add(i);
@@ -95,7 +111,7 @@ public class MatchaViewNode extends Object {
             this.buildId = buildNode.getBuildId();
 
             // Update the views with native values
-            this.view.setNativeState(buildNode.getBridgeValue().toByteArray());
+            this.view.setNativeState(buildNode.getBridgeValue());
 
             // Add/remove subviews
             if (this.view.isContainerView()) {
@@ -116,7 +132,7 @@ public class MatchaViewNode extends Object {
             }
 
             // Update gesture recognizers... TODO(KD):
-            com.google.protobuf.ByteString gestures = buildNode.getValuesMap().get("gomatcha.io/matcha/touch");
+            byte[] gestures = buildNode.getValue("gomatcha.io/matcha/touch");
             if (gestures != null) {
                 try {
                     PbPointer.RecognizerList proto = PbPointer.RecognizerList.parseFrom(gestures);
@@ -135,8 +151,27 @@ public class MatchaViewNode extends Object {
                 } catch (InvalidProtocolBufferException e) {
                 }
             }
+
+            // Update context menu
+            if (buildNode.getValue(MatchaContextMenu.KEY) != null) {
+                if (this.contextMenu == null) {
+                    this.contextMenu = new MatchaContextMenu(this);
+                    this.view.setOnLongClickListener(this.contextMenu);
+                }
+            } else if (this.contextMenu != null) {
+                this.contextMenu = null;
+                this.view.setOnLongClickListener(null);
+                this.view.setLongClickable(false);
+            }
         }
 
+        // Gradients are sent with the native values, since the paint style
+        // doesn't include them. They are sized to the view, so they are also
+        // redrawn when it is laid out.
+        byte[] gradient = buildNode == null ? this.gradient : buildNode.getValue(GRADIENT_KEY);
+        boolean repaintGradient = !Arrays.equals(gradient, this.gradient) || (gradient != null && layoutPaintNode != null && this.layoutId != layoutPaintNode.getLayoutId());
+        this.gradient = gradient;
+
         // Layout subviews
         if (layoutPaintNode != null && this.layoutId != layoutPaintNode.getLayoutId()) {
             this.layoutId = layoutPaintNode.getLayoutId();
@@ -180,39 +215,149 @@ public class MatchaViewNode extends Object {
         }
 
         // Paint scrollView
-        if (layoutPaintNode != null & this.paintId != layoutPaintNode.getPaintId()) {
+        if (layoutPaintNode != null && (this.paintId != layoutPaintNode.getPaintId() || repaintGradient)) {
             this.paintId = layoutPaintNode.getPaintId();
 
-            PbPaint.Style paintStyle = layoutPaintNode.getPaintStyle();
-
             double ratio = (float)this.view.getResources().getDisplayMetrics().densityDpi / DisplayMetrics.DENSITY_DEFAULT;
             GradientDrawable gd = new GradientDrawable();
 
-            double cornerRadius = paintStyle.getCornerRadius();
+            double cornerRadius = layoutPaintNode.getCornerRadius();
             gd.setCornerRadius((float)(cornerRadius * ratio));
 
-            if (paintStyle.hasBorderColor()) {
-                gd.setStroke((int)(paintStyle.getBorderWidth() * ratio), Protobuf.newColor(paintStyle.getBorderColor()));
+            if (layoutPaintNode.hasBorderColor()) {
+                gd.setStroke((int)(layoutPaintNode.getBorderWidth() * ratio), layoutPaintNode.getBorderColor());
             } else {
                 gd.setStroke(0, 0);
             }
 
             if (this.view instanceof MatchaImageView) {
                 ((MatchaImageView)this.view).view.setCornerRadius((float)(cornerRadius*ratio));
-                ((MatchaImageView)this.view).view.setBorderColor(Protobuf.newColor(paintStyle.getBorderColor()));
-                ((MatchaImageView)this.view).view.setBorderWidth((float)(paintStyle.getBorderWidth()*ratio));
+                ((MatchaImageView)this.view).view.setBorderColor(layoutPaintNode.getBorderColor());
+                ((MatchaImageView)this.view).view.setBorderWidth((float)(layoutPaintNode.getBorderWidth()*ratio));
             }
 
-            if (paintStyle.hasBackgroundColor()) {
-                gd.setColor(Protobuf.newColor(paintStyle.getBackgroundColor()));
+            double width = (layoutPaintNode.getMaxx() - layoutPaintNode.getMinx()) * ratio;
+            if (gradient != null && setGradient(gd, gradient, width)) {
+                // The gradient replaces the background color.
+            } else if (layoutPaintNode.hasBackgroundColor()) {
+                gd.setColor(layoutPaintNode.getBackgroundColor());
             } else {
                 gd.setColor(Color.alpha(0));
             }
             this.view.setBackground(gd);
 
-            this.view.setAlpha((float)(1.0 - paintStyle.getTransparency()));
+            // Shadows are drawn with the view's elevation, and follow the
+            // outline of its background, including the rounded corners.
+            if (android.os.Build.VERSION.SDK_INT >= 21) {
+                if (layoutPaintNode.hasShadowColor()) {
+                    this.hasShadow = true;
+                    this.view.setOutlineProvider(ViewOutlineProvider.BACKGROUND);
+                    this.view.setElevation((float)(layoutPaintNode.getShadowRadius() * ratio));
+                    setShadowColor(this.view, layoutPaintNode.getShadowColor());
+                } else if (this.hasShadow) {
+                    this.hasShadow = false;
+                    this.view.setElevation(0);
+                }
+            }
+
+            this.view.setAlpha((float)(1.0 - layoutPaintNode.getTransparency()));
         }
 
         this.children = children;
     }
+
+    // setGradient fills gd with the JSON gradient sent by the paint style. It
+    // returns false if the gradient can't be parsed. GradientDrawable spaces its
+    // colors evenly, so the stops are resampled at even offsets, and linear
+    // gradients are rounded to the nearest multiple of 45 degrees.
+    static boolean setGradient(GradientDrawable gd, byte[] data, double width) {
+        try {
+            JSONObject gradient = new JSONObject(new String(data, "UTF-8"));
+            JSONArray colors = gradient.getJSONArray("colors");
+            JSONArray locations = gradient.getJSONArray("locations");
+            int count = colors.length();
+            if (count == 0 || locations.length() != count) {
+                return false;
+            }
+            int[] stops = new int[count];
+            double[] offsets = new double[count];
+            for (int i = 0; i < count; i++) {
+                JSONArray c = colors.getJSONArray(i);
+                stops[i] = Color.argb((int)Math.round(c.getDouble(3) * 255), (int)Math.round(c.getDouble(0) * 255), (int)Math.round(c.getDouble(1) * 255), (int)Math.round(c.getDouble(2) * 255));
+                offsets[i] = locations.getDouble(i);
+            }
+
+            int samples = 16;
+            int[] sampled = new int[samples];
+            for (int i = 0; i < samples; i++) {
+                sampled[i] = gradientColor(stops, offsets, (double)i / (samples - 1));
+            }
+            gd.setColors(sampled);
+
+            if (gradient.getInt("type") == 1) { // Radial
+                JSONArray center = gradient.getJSONArray("center");
+                gd.setGradientType(GradientDrawable.RADIAL_GRADIENT);
+                gd.setGradientCenter((float)center.getDouble(0), (float)center.getDouble(1));
+                gd.setGradientRadius((float)Math.max(gradient.getDouble("radius") * width, 1));
+                return true;
+            }
+
+            GradientDrawable.Orientation[] orientations = {
+                GradientDrawable.Orientation.TOP_BOTTOM,
+                GradientDrawable.Orientation.TL_BR,
+                GradientDrawable.Orientation.LEFT_RIGHT,
+                GradientDrawable.Orientation.BL_TR,
+                GradientDrawable.Orientation.BOTTOM_TOP,
+                GradientDrawable.Orientation.BR_TL,
+                GradientDrawable.Orientation.RIGHT_LEFT,
+                GradientDrawable.Orientation.TR_BL,
+            };
+            int idx = (int)Math.round(gradient.getDouble("angle") / 45) % 8;
+            if (idx < 0) {
+                idx += 8;
+            }
+            gd.setGradientType(GradientDrawable.LINEAR_GRADIENT);
+            gd.setOrientation(orientations[idx]);
+            return true;
+        } catch (JSONException e) {
+            return false;
+        } catch (java.io.UnsupportedEncodingException e) {
+            return false;
+        }
+    }
+
+    // gradientColor returns the color of the gradient at offset, interpolating
+    // between the surrounding stops. Offsets are sorted.
+    static int gradientColor(int[] stops, double[] offsets, double offset) {
+        if (offset <= offsets[0]) {
+            return stops[0];
+        }
+        for (int i = 1; i < stops.length; i++) {
+            if (offset <= offsets[i]) {
+                double span = offsets[i] - offsets[i - 1];
+                double t = span > 0 ? (offset - offsets[i - 1]) / span : 1;
+                int a = stops[i - 1], b = stops[i];
+                return Color.argb(
+                        (int)Math.round(Color.alpha(a) + (Color.alpha(b) - Color.alpha(a)) * t),
+                        (int)Math.round(Color.red(a) + (Color.red(b) - Color.red(a)) * t),
+                        (int)Math.round(Color.green(a) + (Color.green(b) - Color.green(a)) * t),
+                        (int)Math.round(Color.blue(a) + (Color.blue(b) - Color.blue(a)) * t));
+            }
+        }
+        return stops[stops.length - 1];
+    }
+
+    // setShadowColor sets the color of the view's elevation shadow, which is
+    // only supported on Android 9 and later. It is called with reflection, since
+    // the library is compiled against an earlier SDK.
+    static void setShadowColor(View view, int color) {
+        if (android.os.Build.VERSION.SDK_INT < 28) {
+            return;
+        }
+        try {
+            View.class.getMethod("setOutlineAmbientShadowColor", int.class).invoke(view, color);
+            View.class.getMethod("setOutlineSpotShadowColor", int.class).invoke(view, color);
+        } catch (Exception e) {
+        }
+    }
 }
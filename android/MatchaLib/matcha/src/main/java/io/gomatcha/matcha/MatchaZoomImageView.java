@@ -0,0 +1,256 @@
+package io.gomatcha.matcha;
+
+import android.animation.Animator;
+import android.animation.AnimatorListenerAdapter;
+import android.animation.ValueAnimator;
+import android.content.Context;
+import android.view.GestureDetector;
+import android.view.MotionEvent;
+import android.view.ScaleGestureDetector;
+import android.view.View;
+import android.widget.FrameLayout;
+import android.widget.OverScroller;
+
+import java.util.List;
+
+import io.gomatcha.bridge.GoValue;
+
+class MatchaZoomImageView extends MatchaChildView {
+    MatchaViewNode viewNode;
+    FrameLayout contentView;
+    View childView;
+    ScaleGestureDetector scaleDetector;
+    GestureDetector gestureDetector;
+    OverScroller scroller;
+    ValueAnimator animator;
+    boolean needsUpdate;
+    float minScale = 1;
+    float maxScale = 4;
+    float doubleTapScale = 2;
+    float scale = 1; // The displayed scale.
+    float reportedScale = 1; // The last scale read from or sent to Go.
+    float translateX;
+    float translateY;
+
+    static {
+        MatchaView.registerView("gomatcha.io/matcha/view/zoomimageview", new MatchaView.ViewFactory() {
+            @Override
+            public MatchaChildView createView(Context context, MatchaViewNode node) {
+                return new MatchaZoomImageView(context, node);
+            }
+        });
+    }
+
+    public MatchaZoomImageView(Context context, MatchaViewNode node) {
+        super(context);
+        viewNode = node;
+        setClipChildren(true);
+
+        contentView = new FrameLayout(context);
+        contentView.setPivotX(0);
+        contentView.setPivotY(0);
+        addView(contentView);
+
+        scroller = new OverScroller(context);
+        scaleDetector = new ScaleGestureDetector(context, new ScaleGestureDetector.SimpleOnScaleGestureListener() {
+            @Override
+            public boolean onScale(ScaleGestureDetector detector) {
+                float s = Math.max(minScale, Math.min(maxScale, scale * detector.getScaleFactor()));
+                zoomTo(s, detector.getFocusX(), detector.getFocusY());
+                return true;
+            }
+
+            @Override
+            public void onScaleEnd(ScaleGestureDetector detector) {
+                reportScale();
+            }
+        });
+        gestureDetector = new GestureDetector(context, new GestureDetector.SimpleOnGestureListener() {
+            @Override
+            public boolean onDown(MotionEvent e) {
+                scroller.forceFinished(true);
+                return true;
+            }
+
+            @Override
+            public boolean onScroll(MotionEvent e1, MotionEvent e2, float distanceX, float distanceY) {
+                translateX -= distanceX;
+                translateY -= distanceY;
+                applyTransform();
+                return true;
+            }
+
+            @Override
+            public boolean onFling(MotionEvent e1, MotionEvent e2, float velocityX, float velocityY) {
+                float[] bounds = translateBounds(scale);
+                scroller.fling((int)translateX, (int)translateY, (int)velocityX, (int)velocityY, (int)bounds[0], (int)bounds[1], (int)bounds[2], (int)bounds[3]);
+                postOnAnimation(new Runnable() {
+                    @Override
+                    public void run() {
+                        if (scroller.computeScrollOffset()) {
+                            translateX = scroller.getCurrX();
+                            translateY = scroller.getCurrY();
+                            applyTransform();
+                            postOnAnimation(this);
+                        }
+                    }
+                });
+                return true;
+            }
+
+            @Override
+            public boolean onDoubleTap(MotionEvent e) {
+                if (scale > minScale + 0.01f) {
+                    animateTo(minScale, e.getX(), e.getY());
+                } else {
+                    animateTo(doubleTapScale, e.getX(), e.getY());
+                }
+                return true;
+            }
+        });
+    }
+
+    @Override
+    public void setNativeState(byte[] nativeState) {
+        super.setNativeState(nativeState);
+        setNeedsUpdate();
+    }
+
+    @Override
+    public boolean isContainerView() {
+        return true;
+    }
+
+    @Override
+    public void setChildViews(List<View> childViews) {
+        View childView = childViews.size() > 0 ? childViews.get(0) : null;
+        if (childView != this.childView) {
+            if (this.childView != null) {
+                contentView.removeView(this.childView);
+            }
+            this.childView = childView;
+            if (childView != null) {
+                contentView.addView(childView, new FrameLayout.LayoutParams(FrameLayout.LayoutParams.MATCH_PARENT, FrameLayout.LayoutParams.MATCH_PARENT));
+            }
+        }
+        setNeedsUpdate();
+    }
+
+    // Go can't be called while it is updating the views, so the scales are read
+    // once the update completes.
+    void setNeedsUpdate() {
+        if (needsUpdate) {
+            return;
+        }
+        needsUpdate = true;
+        post(new Runnable() {
+            @Override
+            public void run() {
+                needsUpdate = false;
+                update();
+            }
+        });
+    }
+
+    void update() {
+        GoValue[] state = viewNode.call("State");
+        minScale = (float)state[0].toDouble();
+        maxScale = (float)state[1].toDouble();
+        doubleTapScale = (float)state[2].toDouble();
+        float s = (float)state[3].toDouble();
+        if (Math.abs(s - reportedScale) > 0.001f) {
+            // Zoom around the center of the view.
+            reportedScale = s;
+            animateTo(s, getWidth() / 2f, getHeight() / 2f);
+        } else if (scale < minScale || scale > maxScale) {
+            zoomTo(Math.max(minScale, Math.min(maxScale, scale)), getWidth() / 2f, getHeight() / 2f);
+        }
+    }
+
+    void reportScale() {
+        if (Math.abs(scale - reportedScale) > 0.001f) {
+            reportedScale = scale;
+            viewNode.call("OnZoom", new GoValue((double)scale));
+        }
+    }
+
+    // zoomTo scales the content, keeping the point under (focusX, focusY) in
+    // place.
+    void zoomTo(float s, float focusX, float focusY) {
+        translateX = focusX - (focusX - translateX) * s / scale;
+        translateY = focusY - (focusY - translateY) * s / scale;
+        scale = s;
+        applyTransform();
+    }
+
+    void animateTo(float s, final float focusX, final float focusY) {
+        if (animator != null) {
+            // The replaced animation's scale isn't reported.
+            animator.removeAllListeners();
+            animator.cancel();
+        }
+        animator = ValueAnimator.ofFloat(scale, s);
+        animator.setDuration(250);
+        animator.addUpdateListener(new ValueAnimator.AnimatorUpdateListener() {
+            @Override
+            public void onAnimationUpdate(ValueAnimator animation) {
+                zoomTo((Float)animation.getAnimatedValue(), focusX, focusY);
+            }
+        });
+        animator.addListener(new AnimatorListenerAdapter() {
+            @Override
+            public void onAnimationEnd(Animator animation) {
+                reportScale();
+            }
+        });
+        animator.start();
+    }
+
+    // translateBounds returns the minimum and maximum translations, so that the
+    // content covers the view, or is centered while it is smaller.
+    float[] translateBounds(float s) {
+        float width = getWidth() * s;
+        float height = getHeight() * s;
+        float minX = Math.min(getWidth() - width, (getWidth() - width) / 2);
+        float maxX = Math.max(0, (getWidth() - width) / 2);
+        float minY = Math.min(getHeight() - height, (getHeight() - height) / 2);
+        float maxY = Math.max(0, (getHeight() - height) / 2);
+        return new float[]{minX, maxX, minY, maxY};
+    }
+
+    void applyTransform() {
+        float[] bounds = translateBounds(scale);
+        translateX = Math.max(bounds[0], Math.min(bounds[1], translateX));
+        translateY = Math.max(bounds[2], Math.min(bounds[3], translateY));
+        contentView.setScaleX(scale);
+        contentView.setScaleY(scale);
+        contentView.setTranslationX(translateX);
+        contentView.setTranslationY(translateY);
+    }
+
+    @Override
+    protected void onSizeChanged(int w, int h, int oldw, int oldh) {
+        super.onSizeChanged(w, h, oldw, oldh);
+        applyTransform();
+    }
+
+    @Override
+    public boolean onInterceptTouchEvent(MotionEvent event) {
+        // The image doesn't handle touches, so they are all used for zooming.
+        return true;
+    }
+
+    @Override
+    public boolean onTouchEvent(MotionEvent event) {
+        if (event.getActionMasked() == MotionEvent.ACTION_DOWN && animator != null) {
+            animator.cancel();
+        }
+        scaleDetector.onTouchEvent(event);
+        gestureDetector.onTouchEvent(event);
+        // Parent views such as pagers only scroll while the image isn't zoomed.
+        if (getParent() != null) {
+            getParent().requestDisallowInterceptTouchEvent(scale > minScale + 0.01f || event.getPointerCount() > 1);
+        }
+        return true;
+    }
+}
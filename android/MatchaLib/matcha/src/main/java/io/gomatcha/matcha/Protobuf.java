@@ -14,6 +14,7 @@ import android.text.SpannableStringBuilder;
 import android.text.style.AbsoluteSizeSpan;
 import android.text.style.AlignmentSpan;
 import android.text.style.ForegroundColorSpan;
+import android.text.style.LeadingMarginSpan;
 import android.text.style.StrikethroughSpan;
 import android.text.style.StyleSpan;
 import android.text.style.TypefaceSpan;
@@ -205,13 +206,34 @@ class Protobuf {
             fontName = fontName.substring(0, fontName.length() - 11);
             arrayList.add(new StyleSpan(Typeface.BOLD_ITALIC));
         }
-        span = new TypefaceSpan(font.getFamily());
+        Typeface typeface = MatchaFonts.get(font.getFamily());
+        if (typeface == null) {
+            typeface = MatchaFonts.get(fontName);
+        }
+        if (typeface != null) {
+            span = new MatchaFonts.TypefaceSpan(typeface);
+        } else {
+            span = new TypefaceSpan(font.getFamily());
+        }
         arrayList.add(span);
 
         span = new AbsoluteSizeSpan((int)font.getSize(), true);
         arrayList.add(span);
 
-        // span = new LineHeightSpan();
+        // Spans are measured in pixels, and styles in points.
+        float density = Resources.getSystem().getDisplayMetrics().density;
+        if (textStyle.getLetterSpacing() != 0) {
+            arrayList.add(new MatchaTextSpans.LetterSpacingSpan((float)textStyle.getLetterSpacing() * density));
+        }
+        float multiple = textStyle.getLineHeightMultiple() > 0 ? (float)textStyle.getLineHeightMultiple() : 1;
+        if (textStyle.getLineHeight() > 0 || multiple != 1 || textStyle.getParagraphSpacing() > 0) {
+            int height = Math.round((float)textStyle.getLineHeight() * density);
+            int paragraphSpacing = Math.round((float)textStyle.getParagraphSpacing() * density);
+            arrayList.add(new MatchaTextSpans.LineHeightSpan(height, multiple, paragraphSpacing));
+        }
+        if (textStyle.getFirstLineIndent() != 0) {
+            arrayList.add(new LeadingMarginSpan.Standard(Math.round((float)textStyle.getFirstLineIndent() * density), 0));
+        }
 
         int color = newColor(textStyle.getTextColor());
         span = new ForegroundColorSpan(color);
@@ -0,0 +1,141 @@
+package io.gomatcha.matcha;
+
+import com.google.protobuf.ByteString;
+
+import java.util.List;
+
+import io.gomatcha.matcha.proto.paint.PbPaint;
+import io.gomatcha.matcha.proto.view.PbView;
+
+class ProtobufViewRoot implements ViewRoot {
+    PbView.Root root;
+
+    ProtobufViewRoot(PbView.Root root) {
+        this.root = root;
+    }
+
+    public ViewRoot.BuildNode getBuildNode(long id) {
+        PbView.BuildNode node = root.getBuildNodesOrDefault(id, null);
+        return node == null ? null : new BuildNode(node);
+    }
+
+    public ViewRoot.LayoutPaintNode getLayoutPaintNode(long id) {
+        PbView.LayoutPaintNode node = root.getLayoutPaintNodesOrDefault(id, null);
+        return node == null ? null : new LayoutPaintNode(node);
+    }
+
+    public com.google.protobuf.Any getMiddleware(String key) {
+        return root.getMiddlewareMap().get(key);
+    }
+
+    static class BuildNode implements ViewRoot.BuildNode {
+        PbView.BuildNode node;
+
+        BuildNode(PbView.BuildNode node) {
+            this.node = node;
+        }
+
+        public long getBuildId() {
+            return node.getBuildId();
+        }
+
+        public String getBridgeName() {
+            return node.getBridgeName();
+        }
+
+        public byte[] getBridgeValue() {
+            return node.getBridgeValue().toByteArray();
+        }
+
+        public List<Long> getChildren() {
+            return node.getChildrenList();
+        }
+
+        public byte[] getValue(String key) {
+            ByteString value = node.getValuesMap().get(key);
+            return value == null ? null : value.toByteArray();
+        }
+    }
+
+    static class LayoutPaintNode implements ViewRoot.LayoutPaintNode {
+        PbView.LayoutPaintNode node;
+        PbPaint.Style style;
+
+        LayoutPaintNode(PbView.LayoutPaintNode node) {
+            this.node = node;
+            this.style = node.getPaintStyle();
+        }
+
+        public long getLayoutId() {
+            return node.getLayoutId();
+        }
+
+        public long getPaintId() {
+            return node.getPaintId();
+        }
+
+        public double getMinx() {
+            return node.getMinx();
+        }
+
+        public double getMiny() {
+            return node.getMiny();
+        }
+
+        public double getMaxx() {
+            return node.getMaxx();
+        }
+
+        public double getMaxy() {
+            return node.getMaxy();
+        }
+
+        public int getChildOrderCount() {
+            return node.getChildOrderCount();
+        }
+
+        public long getChildOrder(int index) {
+            return node.getChildOrder(index);
+        }
+
+        public double getTransparency() {
+            return style.getTransparency();
+        }
+
+        public double getBorderWidth() {
+            return style.getBorderWidth();
+        }
+
+        public double getCornerRadius() {
+            return style.getCornerRadius();
+        }
+
+        public boolean hasBackgroundColor() {
+            return style.hasBackgroundColor();
+        }
+
+        public int getBackgroundColor() {
+            return Protobuf.newColor(style.getBackgroundColor());
+        }
+
+        public boolean hasBorderColor() {
+            return style.hasBorderColor();
+        }
+
+        public int getBorderColor() {
+            return Protobuf.newColor(style.getBorderColor());
+        }
+
+        public double getShadowRadius() {
+            return style.getShadowRadius();
+        }
+
+        public boolean hasShadowColor() {
+            return style.hasShadowColor();
+        }
+
+        public int getShadowColor() {
+            return Protobuf.newColor(style.getShadowColor());
+        }
+    }
+}
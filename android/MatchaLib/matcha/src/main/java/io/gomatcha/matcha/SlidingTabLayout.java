@@ -224,6 +224,28 @@ class SlidingTabLayout extends HorizontalScrollView {
         }
     }
 
+    /**
+     * Updates the title and enabled state of the tab at position. Disabled tabs can't be clicked.
+     */
+    public void updateTab(int position, CharSequence title, boolean enabled) {
+        if (position < 0 || position >= mTabStrip.getChildCount()) {
+            return;
+        }
+        View tabView = mTabStrip.getChildAt(position);
+        TextView tabTitleView = null;
+        if (mTabViewTextViewId != 0) {
+            tabTitleView = (TextView) tabView.findViewById(mTabViewTextViewId);
+        }
+        if (tabTitleView == null && TextView.class.isInstance(tabView)) {
+            tabTitleView = (TextView) tabView;
+        }
+        if (tabTitleView != null) {
+            tabTitleView.setText(title);
+        }
+        tabView.setEnabled(enabled);
+        tabView.setAlpha(enabled ? 1 : 0.4f);
+    }
+
     public void setContentDescription(int i, String desc) {
         mContentDescriptions.put(i, desc);
     }
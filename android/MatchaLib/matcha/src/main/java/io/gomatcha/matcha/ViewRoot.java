@@ -0,0 +1,41 @@
+package io.gomatcha.matcha;
+
+import java.util.List;
+
+// ViewRoot is a view hierarchy update sent by Go, in the protobuf or the flat
+// encoding. See gomatcha.io/matcha/internal/flat for the flat encoding.
+interface ViewRoot {
+    BuildNode getBuildNode(long id); // null if the node wasn't rebuilt.
+    LayoutPaintNode getLayoutPaintNode(long id);
+    com.google.protobuf.Any getMiddleware(String key);
+
+    interface BuildNode {
+        long getBuildId();
+        String getBridgeName();
+        byte[] getBridgeValue();
+        List<Long> getChildren();
+        byte[] getValue(String key);
+    }
+
+    interface LayoutPaintNode {
+        long getLayoutId();
+        long getPaintId();
+        double getMinx();
+        double getMiny();
+        double getMaxx();
+        double getMaxy();
+        int getChildOrderCount();
+        long getChildOrder(int index);
+
+        double getTransparency();
+        double getBorderWidth();
+        double getCornerRadius();
+        boolean hasBackgroundColor();
+        int getBackgroundColor();
+        boolean hasBorderColor();
+        int getBorderColor();
+        double getShadowRadius();
+        boolean hasShadowColor();
+        int getShadowColor();
+    }
+}
@@ -621,6 +621,113 @@ public final class PbText {
     // @@protoc_insertion_point(enum_scope:matcha.text.Truncation)
   }
 
+  /**
+   * Protobuf enum {@code matcha.text.WritingDirection}
+   */
+  public enum WritingDirection
+      implements com.google.protobuf.ProtocolMessageEnum {
+    /**
+     * <code>WRITING_DIRECTION_NATURAL = 0;</code>
+     */
+    WRITING_DIRECTION_NATURAL(0),
+    /**
+     * <code>WRITING_DIRECTION_LEFT_TO_RIGHT = 1;</code>
+     */
+    WRITING_DIRECTION_LEFT_TO_RIGHT(1),
+    /**
+     * <code>WRITING_DIRECTION_RIGHT_TO_LEFT = 2;</code>
+     */
+    WRITING_DIRECTION_RIGHT_TO_LEFT(2),
+    UNRECOGNIZED(-1),
+    ;
+
+    /**
+     * <code>WRITING_DIRECTION_NATURAL = 0;</code>
+     */
+    public static final int WRITING_DIRECTION_NATURAL_VALUE = 0;
+    /**
+     * <code>WRITING_DIRECTION_LEFT_TO_RIGHT = 1;</code>
+     */
+    public static final int WRITING_DIRECTION_LEFT_TO_RIGHT_VALUE = 1;
+    /**
+     * <code>WRITING_DIRECTION_RIGHT_TO_LEFT = 2;</code>
+     */
+    public static final int WRITING_DIRECTION_RIGHT_TO_LEFT_VALUE = 2;
+
+
+    public final int getNumber() {
+      if (this == UNRECOGNIZED) {
+        throw new java.lang.IllegalArgumentException(
+            "Can't get the number of an unknown enum value.");
+      }
+      return value;
+    }
+
+    /**
+     * @deprecated Use {@link #forNumber(int)} instead.
+     */
+    @java.lang.Deprecated
+    public static WritingDirection valueOf(int value) {
+      return forNumber(value);
+    }
+
+    public static WritingDirection forNumber(int value) {
+      switch (value) {
+        case 0: return WRITING_DIRECTION_NATURAL;
+        case 1: return WRITING_DIRECTION_LEFT_TO_RIGHT;
+        case 2: return WRITING_DIRECTION_RIGHT_TO_LEFT;
+        default: return null;
+      }
+    }
+
+    public static com.google.protobuf.Internal.EnumLiteMap<WritingDirection>
+        internalGetValueMap() {
+      return internalValueMap;
+    }
+    private static final com.google.protobuf.Internal.EnumLiteMap<
+        WritingDirection> internalValueMap =
+          new com.google.protobuf.Internal.EnumLiteMap<WritingDirection>() {
+            public WritingDirection findValueByNumber(int number) {
+              return WritingDirection.forNumber(number);
+            }
+          };
+
+    public final com.google.protobuf.Descriptors.EnumValueDescriptor
+        getValueDescriptor() {
+      return getDescriptor().getValues().get(ordinal());
+    }
+    public final com.google.protobuf.Descriptors.EnumDescriptor
+        getDescriptorForType() {
+      return getDescriptor();
+    }
+    public static final com.google.protobuf.Descriptors.EnumDescriptor
+        getDescriptor() {
+      return io.gomatcha.matcha.proto.text.PbText.getDescriptor().getEnumTypes().get(5);
+    }
+
+    private static final WritingDirection[] VALUES = values();
+
+    public static WritingDirection valueOf(
+        com.google.protobuf.Descriptors.EnumValueDescriptor desc) {
+      if (desc.getType() != getDescriptor()) {
+        throw new java.lang.IllegalArgumentException(
+          "EnumValueDescriptor is not for this type.");
+      }
+      if (desc.getIndex() == -1) {
+        return UNRECOGNIZED;
+      }
+      return VALUES[desc.getIndex()];
+    }
+
+    private final int value;
+
+    private WritingDirection(int value) {
+      this.value = value;
+    }
+
+    // @@protoc_insertion_point(enum_scope:matcha.text.WritingDirection)
+  }
+
   public interface SizeFuncOrBuilder extends
       // @@protoc_insertion_point(interface_extends:matcha.text.SizeFunc)
       com.google.protobuf.MessageOrBuilder {
@@ -3888,6 +3995,35 @@ public final class PbText {
      */
     com.google.protobuf.ByteString
         getTruncationStringBytes();
+
+    /**
+     * <code>.matcha.text.WritingDirection writingDirection = 28;</code>
+     */
+    int getWritingDirectionValue();
+    /**
+     * <code>.matcha.text.WritingDirection writingDirection = 28;</code>
+     */
+    io.gomatcha.matcha.proto.text.PbText.WritingDirection getWritingDirection();
+
+    /**
+     * <code>double letterSpacing = 30;</code>
+     */
+    double getLetterSpacing();
+
+    /**
+     * <code>double lineHeight = 32;</code>
+     */
+    double getLineHeight();
+
+    /**
+     * <code>double paragraphSpacing = 34;</code>
+     */
+    double getParagraphSpacing();
+
+    /**
+     * <code>double firstLineIndent = 36;</code>
+     */
+    double getFirstLineIndent();
   }
   /**
    * Protobuf type {@code matcha.text.TextStyle}
@@ -3911,6 +4047,11 @@ public final class PbText {
       wrap_ = 0;
       truncation_ = 0;
       truncationString_ = "";
+      writingDirection_ = 0;
+      letterSpacing_ = 0D;
+      lineHeight_ = 0D;
+      paragraphSpacing_ = 0D;
+      firstLineIndent_ = 0D;
     }
 
     @java.lang.Override
@@ -4046,6 +4187,32 @@ public final class PbText {
               truncationString_ = s;
               break;
             }
+            case 224: {
+              int rawValue = input.readEnum();
+
+              writingDirection_ = rawValue;
+              break;
+            }
+            case 241: {
+
+              letterSpacing_ = input.readDouble();
+              break;
+            }
+            case 257: {
+
+              lineHeight_ = input.readDouble();
+              break;
+            }
+            case 273: {
+
+              paragraphSpacing_ = input.readDouble();
+              break;
+            }
+            case 289: {
+
+              firstLineIndent_ = input.readDouble();
+              break;
+            }
           }
         }
       } catch (com.google.protobuf.InvalidProtocolBufferException e) {
@@ -4307,6 +4474,58 @@ public final class PbText {
       }
     }
 
+    public static final int WRITINGDIRECTION_FIELD_NUMBER = 28;
+    private int writingDirection_;
+    /**
+     * <code>.matcha.text.WritingDirection writingDirection = 28;</code>
+     */
+    public int getWritingDirectionValue() {
+      return writingDirection_;
+    }
+    /**
+     * <code>.matcha.text.WritingDirection writingDirection = 28;</code>
+     */
+    public io.gomatcha.matcha.proto.text.PbText.WritingDirection getWritingDirection() {
+      io.gomatcha.matcha.proto.text.PbText.WritingDirection result = io.gomatcha.matcha.proto.text.PbText.WritingDirection.valueOf(writingDirection_);
+      return result == null ? io.gomatcha.matcha.proto.text.PbText.WritingDirection.UNRECOGNIZED : result;
+    }
+
+    public static final int LETTERSPACING_FIELD_NUMBER = 30;
+    private double letterSpacing_;
+    /**
+     * <code>double letterSpacing = 30;</code>
+     */
+    public double getLetterSpacing() {
+      return letterSpacing_;
+    }
+
+    public static final int LINEHEIGHT_FIELD_NUMBER = 32;
+    private double lineHeight_;
+    /**
+     * <code>double lineHeight = 32;</code>
+     */
+    public double getLineHeight() {
+      return lineHeight_;
+    }
+
+    public static final int PARAGRAPHSPACING_FIELD_NUMBER = 34;
+    private double paragraphSpacing_;
+    /**
+     * <code>double paragraphSpacing = 34;</code>
+     */
+    public double getParagraphSpacing() {
+      return paragraphSpacing_;
+    }
+
+    public static final int FIRSTLINEINDENT_FIELD_NUMBER = 36;
+    private double firstLineIndent_;
+    /**
+     * <code>double firstLineIndent = 36;</code>
+     */
+    public double getFirstLineIndent() {
+      return firstLineIndent_;
+    }
+
     private byte memoizedIsInitialized = -1;
     public final boolean isInitialized() {
       byte isInitialized = memoizedIsInitialized;
@@ -4361,6 +4580,21 @@ public final class PbText {
       if (!getTruncationStringBytes().isEmpty()) {
         com.google.protobuf.GeneratedMessageV3.writeString(output, 26, truncationString_);
       }
+      if (writingDirection_ != io.gomatcha.matcha.proto.text.PbText.WritingDirection.WRITING_DIRECTION_NATURAL.getNumber()) {
+        output.writeEnum(28, writingDirection_);
+      }
+      if (letterSpacing_ != 0D) {
+        output.writeDouble(30, letterSpacing_);
+      }
+      if (lineHeight_ != 0D) {
+        output.writeDouble(32, lineHeight_);
+      }
+      if (paragraphSpacing_ != 0D) {
+        output.writeDouble(34, paragraphSpacing_);
+      }
+      if (firstLineIndent_ != 0D) {
+        output.writeDouble(36, firstLineIndent_);
+      }
     }
 
     public int getSerializedSize() {
@@ -4423,6 +4657,26 @@ public final class PbText {
       if (!getTruncationStringBytes().isEmpty()) {
         size += com.google.protobuf.GeneratedMessageV3.computeStringSize(26, truncationString_);
       }
+      if (writingDirection_ != io.gomatcha.matcha.proto.text.PbText.WritingDirection.WRITING_DIRECTION_NATURAL.getNumber()) {
+        size += com.google.protobuf.CodedOutputStream
+          .computeEnumSize(28, writingDirection_);
+      }
+      if (letterSpacing_ != 0D) {
+        size += com.google.protobuf.CodedOutputStream
+          .computeDoubleSize(30, letterSpacing_);
+      }
+      if (lineHeight_ != 0D) {
+        size += com.google.protobuf.CodedOutputStream
+          .computeDoubleSize(32, lineHeight_);
+      }
+      if (paragraphSpacing_ != 0D) {
+        size += com.google.protobuf.CodedOutputStream
+          .computeDoubleSize(34, paragraphSpacing_);
+      }
+      if (firstLineIndent_ != 0D) {
+        size += com.google.protobuf.CodedOutputStream
+          .computeDoubleSize(36, firstLineIndent_);
+      }
       memoizedSize = size;
       return size;
     }
@@ -4478,6 +4732,23 @@ public final class PbText {
       result = result && truncation_ == other.truncation_;
       result = result && getTruncationString()
           .equals(other.getTruncationString());
+      result = result && writingDirection_ == other.writingDirection_;
+      result = result && (
+          java.lang.Double.doubleToLongBits(getLetterSpacing())
+          == java.lang.Double.doubleToLongBits(
+              other.getLetterSpacing()));
+      result = result && (
+          java.lang.Double.doubleToLongBits(getLineHeight())
+          == java.lang.Double.doubleToLongBits(
+              other.getLineHeight()));
+      result = result && (
+          java.lang.Double.doubleToLongBits(getParagraphSpacing())
+          == java.lang.Double.doubleToLongBits(
+              other.getParagraphSpacing()));
+      result = result && (
+          java.lang.Double.doubleToLongBits(getFirstLineIndent())
+          == java.lang.Double.doubleToLongBits(
+              other.getFirstLineIndent()));
       return result;
     }
 
@@ -4528,6 +4799,20 @@ public final class PbText {
       hash = (53 * hash) + truncation_;
       hash = (37 * hash) + TRUNCATIONSTRING_FIELD_NUMBER;
       hash = (53 * hash) + getTruncationString().hashCode();
+      hash = (37 * hash) + WRITINGDIRECTION_FIELD_NUMBER;
+      hash = (53 * hash) + writingDirection_;
+      hash = (37 * hash) + LETTERSPACING_FIELD_NUMBER;
+      hash = (53 * hash) + com.google.protobuf.Internal.hashLong(
+          java.lang.Double.doubleToLongBits(getLetterSpacing()));
+      hash = (37 * hash) + LINEHEIGHT_FIELD_NUMBER;
+      hash = (53 * hash) + com.google.protobuf.Internal.hashLong(
+          java.lang.Double.doubleToLongBits(getLineHeight()));
+      hash = (37 * hash) + PARAGRAPHSPACING_FIELD_NUMBER;
+      hash = (53 * hash) + com.google.protobuf.Internal.hashLong(
+          java.lang.Double.doubleToLongBits(getParagraphSpacing()));
+      hash = (37 * hash) + FIRSTLINEINDENT_FIELD_NUMBER;
+      hash = (53 * hash) + com.google.protobuf.Internal.hashLong(
+          java.lang.Double.doubleToLongBits(getFirstLineIndent()));
       hash = (29 * hash) + unknownFields.hashCode();
       memoizedHashCode = hash;
       return hash;
@@ -4701,6 +4986,16 @@ public final class PbText {
 
         truncationString_ = "";
 
+        writingDirection_ = 0;
+
+        letterSpacing_ = 0D;
+
+        lineHeight_ = 0D;
+
+        paragraphSpacing_ = 0D;
+
+        firstLineIndent_ = 0D;
+
         return this;
       }
 
@@ -4753,6 +5048,11 @@ public final class PbText {
         result.wrap_ = wrap_;
         result.truncation_ = truncation_;
         result.truncationString_ = truncationString_;
+        result.writingDirection_ = writingDirection_;
+        result.letterSpacing_ = letterSpacing_;
+        result.lineHeight_ = lineHeight_;
+        result.paragraphSpacing_ = paragraphSpacing_;
+        result.firstLineIndent_ = firstLineIndent_;
         onBuilt();
         return result;
       }
@@ -4837,6 +5137,21 @@ public final class PbText {
           truncationString_ = other.truncationString_;
           onChanged();
         }
+        if (other.writingDirection_ != 0) {
+          setWritingDirectionValue(other.getWritingDirectionValue());
+        }
+        if (other.getLetterSpacing() != 0D) {
+          setLetterSpacing(other.getLetterSpacing());
+        }
+        if (other.getLineHeight() != 0D) {
+          setLineHeight(other.getLineHeight());
+        }
+        if (other.getParagraphSpacing() != 0D) {
+          setParagraphSpacing(other.getParagraphSpacing());
+        }
+        if (other.getFirstLineIndent() != 0D) {
+          setFirstLineIndent(other.getFirstLineIndent());
+        }
         onChanged();
         return this;
       }
@@ -5735,55 +6050,845 @@ public final class PbText {
         onChanged();
         return this;
       }
-      public final Builder setUnknownFields(
-          final com.google.protobuf.UnknownFieldSet unknownFields) {
+
+      private int writingDirection_ = 0;
+      /**
+       * <code>.matcha.text.WritingDirection writingDirection = 28;</code>
+       */
+      public int getWritingDirectionValue() {
+        return writingDirection_;
+      }
+      /**
+       * <code>.matcha.text.WritingDirection writingDirection = 28;</code>
+       */
+      public Builder setWritingDirectionValue(int value) {
+        writingDirection_ = value;
+        onChanged();
         return this;
       }
-
-      public final Builder mergeUnknownFields(
-          final com.google.protobuf.UnknownFieldSet unknownFields) {
+      /**
+       * <code>.matcha.text.WritingDirection writingDirection = 28;</code>
+       */
+      public io.gomatcha.matcha.proto.text.PbText.WritingDirection getWritingDirection() {
+        io.gomatcha.matcha.proto.text.PbText.WritingDirection result = io.gomatcha.matcha.proto.text.PbText.WritingDirection.valueOf(writingDirection_);
+        return result == null ? io.gomatcha.matcha.proto.text.PbText.WritingDirection.UNRECOGNIZED : result;
+      }
+      /**
+       * <code>.matcha.text.WritingDirection writingDirection = 28;</code>
+       */
+      public Builder setWritingDirection(io.gomatcha.matcha.proto.text.PbText.WritingDirection value) {
+        if (value == null) {
+          throw new NullPointerException();
+        }
+        
+        writingDirection_ = value.getNumber();
+        onChanged();
+        return this;
+      }
+      /**
+       * <code>.matcha.text.WritingDirection writingDirection = 28;</code>
+       */
+      public Builder clearWritingDirection() {
+        
+        writingDirection_ = 0;
+        onChanged();
         return this;
       }
 
-
-      // @@protoc_insertion_point(builder_scope:matcha.text.TextStyle)
-    }
-
-    // @@protoc_insertion_point(class_scope:matcha.text.TextStyle)
-    private static final io.gomatcha.matcha.proto.text.PbText.TextStyle DEFAULT_INSTANCE;
-    static {
-      DEFAULT_INSTANCE = new io.gomatcha.matcha.proto.text.PbText.TextStyle();
-    }
-
-    public static io.gomatcha.matcha.proto.text.PbText.TextStyle getDefaultInstance() {
-      return DEFAULT_INSTANCE;
-    }
-
-    private static final com.google.protobuf.Parser<TextStyle>
-        PARSER = new com.google.protobuf.AbstractParser<TextStyle>() {
-      public TextStyle parsePartialFrom(
-          com.google.protobuf.CodedInputStream input,
-          com.google.protobuf.ExtensionRegistryLite extensionRegistry)
-          throws com.google.protobuf.InvalidProtocolBufferException {
-          return new TextStyle(input, extensionRegistry);
+      private double letterSpacing_ ;
+      /**
+       * <code>double letterSpacing = 30;</code>
+       */
+      public double getLetterSpacing() {
+        return letterSpacing_;
+      }
+      /**
+       * <code>double letterSpacing = 30;</code>
+       */
+      public Builder setLetterSpacing(double value) {
+        
+        letterSpacing_ = value;
+        onChanged();
+        return this;
+      }
+      /**
+       * <code>double letterSpacing = 30;</code>
+       */
+      public Builder clearLetterSpacing() {
+        
+        letterSpacing_ = 0D;
+        onChanged();
+        return this;
       }
-    };
 
-    public static com.google.protobuf.Parser<TextStyle> parser() {
-      return PARSER;
-    }
+      private double lineHeight_ ;
+      /**
+       * <code>double lineHeight = 32;</code>
+       */
+      public double getLineHeight() {
+        return lineHeight_;
+      }
+      /**
+       * <code>double lineHeight = 32;</code>
+       */
+      public Builder setLineHeight(double value) {
+        
+        lineHeight_ = value;
+        onChanged();
+        return this;
+      }
+      /**
+       * <code>double lineHeight = 32;</code>
+       */
+      public Builder clearLineHeight() {
+        
+        lineHeight_ = 0D;
+        onChanged();
+        return this;
+      }
 
-    @java.lang.Override
-    public com.google.protobuf.Parser<TextStyle> getParserForType() {
-      return PARSER;
+      private double paragraphSpacing_ ;
+      /**
+       * <code>double paragraphSpacing = 34;</code>
+       */
+      public double getParagraphSpacing() {
+        return paragraphSpacing_;
+      }
+      /**
+       * <code>double paragraphSpacing = 34;</code>
+       */
+      public Builder setParagraphSpacing(double value) {
+        
+        paragraphSpacing_ = value;
+        onChanged();
+        return this;
+      }
+      /**
+       * <code>double paragraphSpacing = 34;</code>
+       */
+      public Builder clearParagraphSpacing() {
+        
+        paragraphSpacing_ = 0D;
+        onChanged();
+        return this;
+      }
+
+      private double firstLineIndent_ ;
+      /**
+       * <code>double firstLineIndent = 36;</code>
+       */
+      public double getFirstLineIndent() {
+        return firstLineIndent_;
+      }
+      /**
+       * <code>double firstLineIndent = 36;</code>
+       */
+      public Builder setFirstLineIndent(double value) {
+        
+        firstLineIndent_ = value;
+        onChanged();
+        return this;
+      }
+      /**
+       * <code>double firstLineIndent = 36;</code>
+       */
+      public Builder clearFirstLineIndent() {
+        
+        firstLineIndent_ = 0D;
+        onChanged();
+        return this;
+      }
+      public final Builder setUnknownFields(
+          final com.google.protobuf.UnknownFieldSet unknownFields) {
+        return this;
+      }
+
+      public final Builder mergeUnknownFields(
+          final com.google.protobuf.UnknownFieldSet unknownFields) {
+        return this;
+      }
+
+
+      // @@protoc_insertion_point(builder_scope:matcha.text.TextStyle)
+    }
+
+    // @@protoc_insertion_point(class_scope:matcha.text.TextStyle)
+    private static final io.gomatcha.matcha.proto.text.PbText.TextStyle DEFAULT_INSTANCE;
+    static {
+      DEFAULT_INSTANCE = new io.gomatcha.matcha.proto.text.PbText.TextStyle();
+    }
+
+    public static io.gomatcha.matcha.proto.text.PbText.TextStyle getDefaultInstance() {
+      return DEFAULT_INSTANCE;
+    }
+
+    private static final com.google.protobuf.Parser<TextStyle>
+        PARSER = new com.google.protobuf.AbstractParser<TextStyle>() {
+      public TextStyle parsePartialFrom(
+          com.google.protobuf.CodedInputStream input,
+          com.google.protobuf.ExtensionRegistryLite extensionRegistry)
+          throws com.google.protobuf.InvalidProtocolBufferException {
+          return new TextStyle(input, extensionRegistry);
+      }
+    };
+
+    public static com.google.protobuf.Parser<TextStyle> parser() {
+      return PARSER;
+    }
+
+    @java.lang.Override
+    public com.google.protobuf.Parser<TextStyle> getParserForType() {
+      return PARSER;
+    }
+
+    public io.gomatcha.matcha.proto.text.PbText.TextStyle getDefaultInstanceForType() {
+      return DEFAULT_INSTANCE;
+    }
+
+  }
+
+  public interface TextMetricsOrBuilder extends
+      // @@protoc_insertion_point(interface_extends:matcha.text.TextMetrics)
+      com.google.protobuf.MessageOrBuilder {
+
+    /**
+     * <code>double width = 1;</code>
+     */
+    double getWidth();
+
+    /**
+     * <code>double height = 2;</code>
+     */
+    double getHeight();
+
+    /**
+     * <code>double firstBaseline = 3;</code>
+     */
+    double getFirstBaseline();
+
+    /**
+     * <code>double lastBaseline = 4;</code>
+     */
+    double getLastBaseline();
+  }
+  /**
+   * Protobuf type {@code matcha.text.TextMetrics}
+   */
+  public  static final class TextMetrics extends
+      com.google.protobuf.GeneratedMessageV3 implements
+      // @@protoc_insertion_point(message_implements:matcha.text.TextMetrics)
+      TextMetricsOrBuilder {
+    // Use TextMetrics.newBuilder() to construct.
+    private TextMetrics(com.google.protobuf.GeneratedMessageV3.Builder<?> builder) {
+      super(builder);
+    }
+    private TextMetrics() {
+      width_ = 0D;
+      height_ = 0D;
+      firstBaseline_ = 0D;
+      lastBaseline_ = 0D;
+    }
+
+    @java.lang.Override
+    public final com.google.protobuf.UnknownFieldSet
+    getUnknownFields() {
+      return com.google.protobuf.UnknownFieldSet.getDefaultInstance();
+    }
+    private TextMetrics(
+        com.google.protobuf.CodedInputStream input,
+        com.google.protobuf.ExtensionRegistryLite extensionRegistry)
+        throws com.google.protobuf.InvalidProtocolBufferException {
+      this();
+      int mutable_bitField0_ = 0;
+      try {
+        boolean done = false;
+        while (!done) {
+          int tag = input.readTag();
+          switch (tag) {
+            case 0:
+              done = true;
+              break;
+            default: {
+              if (!input.skipField(tag)) {
+                done = true;
+              }
+              break;
+            }
+            case 9: {
+
+              width_ = input.readDouble();
+              break;
+            }
+            case 17: {
+
+              height_ = input.readDouble();
+              break;
+            }
+            case 25: {
+
+              firstBaseline_ = input.readDouble();
+              break;
+            }
+            case 33: {
+
+              lastBaseline_ = input.readDouble();
+              break;
+            }
+          }
+        }
+      } catch (com.google.protobuf.InvalidProtocolBufferException e) {
+        throw e.setUnfinishedMessage(this);
+      } catch (java.io.IOException e) {
+        throw new com.google.protobuf.InvalidProtocolBufferException(
+            e).setUnfinishedMessage(this);
+      } finally {
+        makeExtensionsImmutable();
+      }
+    }
+    public static final com.google.protobuf.Descriptors.Descriptor
+        getDescriptor() {
+      return io.gomatcha.matcha.proto.text.PbText.internal_static_matcha_text_TextMetrics_descriptor;
+    }
+
+    protected com.google.protobuf.GeneratedMessageV3.FieldAccessorTable
+        internalGetFieldAccessorTable() {
+      return io.gomatcha.matcha.proto.text.PbText.internal_static_matcha_text_TextMetrics_fieldAccessorTable
+          .ensureFieldAccessorsInitialized(
+              io.gomatcha.matcha.proto.text.PbText.TextMetrics.class, io.gomatcha.matcha.proto.text.PbText.TextMetrics.Builder.class);
+    }
+
+    public static final int WIDTH_FIELD_NUMBER = 1;
+    private double width_;
+    /**
+     * <code>double width = 1;</code>
+     */
+    public double getWidth() {
+      return width_;
+    }
+
+    public static final int HEIGHT_FIELD_NUMBER = 2;
+    private double height_;
+    /**
+     * <code>double height = 2;</code>
+     */
+    public double getHeight() {
+      return height_;
+    }
+
+    public static final int FIRSTBASELINE_FIELD_NUMBER = 3;
+    private double firstBaseline_;
+    /**
+     * <code>double firstBaseline = 3;</code>
+     */
+    public double getFirstBaseline() {
+      return firstBaseline_;
+    }
+
+    public static final int LASTBASELINE_FIELD_NUMBER = 4;
+    private double lastBaseline_;
+    /**
+     * <code>double lastBaseline = 4;</code>
+     */
+    public double getLastBaseline() {
+      return lastBaseline_;
+    }
+
+    private byte memoizedIsInitialized = -1;
+    public final boolean isInitialized() {
+      byte isInitialized = memoizedIsInitialized;
+      if (isInitialized == 1) return true;
+      if (isInitialized == 0) return false;
+
+      memoizedIsInitialized = 1;
+      return true;
+    }
+
+    public void writeTo(com.google.protobuf.CodedOutputStream output)
+                        throws java.io.IOException {
+      if (width_ != 0D) {
+        output.writeDouble(1, width_);
+      }
+      if (height_ != 0D) {
+        output.writeDouble(2, height_);
+      }
+      if (firstBaseline_ != 0D) {
+        output.writeDouble(3, firstBaseline_);
+      }
+      if (lastBaseline_ != 0D) {
+        output.writeDouble(4, lastBaseline_);
+      }
+    }
+
+    public int getSerializedSize() {
+      int size = memoizedSize;
+      if (size != -1) return size;
+
+      size = 0;
+      if (width_ != 0D) {
+        size += com.google.protobuf.CodedOutputStream
+          .computeDoubleSize(1, width_);
+      }
+      if (height_ != 0D) {
+        size += com.google.protobuf.CodedOutputStream
+          .computeDoubleSize(2, height_);
+      }
+      if (firstBaseline_ != 0D) {
+        size += com.google.protobuf.CodedOutputStream
+          .computeDoubleSize(3, firstBaseline_);
+      }
+      if (lastBaseline_ != 0D) {
+        size += com.google.protobuf.CodedOutputStream
+          .computeDoubleSize(4, lastBaseline_);
+      }
+      memoizedSize = size;
+      return size;
+    }
+
+    private static final long serialVersionUID = 0L;
+    @java.lang.Override
+    public boolean equals(final java.lang.Object obj) {
+      if (obj == this) {
+       return true;
+      }
+      if (!(obj instanceof io.gomatcha.matcha.proto.text.PbText.TextMetrics)) {
+        return super.equals(obj);
+      }
+      io.gomatcha.matcha.proto.text.PbText.TextMetrics other = (io.gomatcha.matcha.proto.text.PbText.TextMetrics) obj;
+
+      boolean result = true;
+      result = result && (
+          java.lang.Double.doubleToLongBits(getWidth())
+          == java.lang.Double.doubleToLongBits(
+              other.getWidth()));
+      result = result && (
+          java.lang.Double.doubleToLongBits(getHeight())
+          == java.lang.Double.doubleToLongBits(
+              other.getHeight()));
+      result = result && (
+          java.lang.Double.doubleToLongBits(getFirstBaseline())
+          == java.lang.Double.doubleToLongBits(
+              other.getFirstBaseline()));
+      result = result && (
+          java.lang.Double.doubleToLongBits(getLastBaseline())
+          == java.lang.Double.doubleToLongBits(
+              other.getLastBaseline()));
+      return result;
+    }
+
+    @java.lang.Override
+    public int hashCode() {
+      if (memoizedHashCode != 0) {
+        return memoizedHashCode;
+      }
+      int hash = 41;
+      hash = (19 * hash) + getDescriptor().hashCode();
+      hash = (37 * hash) + WIDTH_FIELD_NUMBER;
+      hash = (53 * hash) + com.google.protobuf.Internal.hashLong(
+          java.lang.Double.doubleToLongBits(getWidth()));
+      hash = (37 * hash) + HEIGHT_FIELD_NUMBER;
+      hash = (53 * hash) + com.google.protobuf.Internal.hashLong(
+          java.lang.Double.doubleToLongBits(getHeight()));
+      hash = (37 * hash) + FIRSTBASELINE_FIELD_NUMBER;
+      hash = (53 * hash) + com.google.protobuf.Internal.hashLong(
+          java.lang.Double.doubleToLongBits(getFirstBaseline()));
+      hash = (37 * hash) + LASTBASELINE_FIELD_NUMBER;
+      hash = (53 * hash) + com.google.protobuf.Internal.hashLong(
+          java.lang.Double.doubleToLongBits(getLastBaseline()));
+      hash = (29 * hash) + unknownFields.hashCode();
+      memoizedHashCode = hash;
+      return hash;
+    }
+
+    public static io.gomatcha.matcha.proto.text.PbText.TextMetrics parseFrom(
+        java.nio.ByteBuffer data)
+        throws com.google.protobuf.InvalidProtocolBufferException {
+      return PARSER.parseFrom(data);
+    }
+    public static io.gomatcha.matcha.proto.text.PbText.TextMetrics parseFrom(
+        java.nio.ByteBuffer data,
+        com.google.protobuf.ExtensionRegistryLite extensionRegistry)
+        throws com.google.protobuf.InvalidProtocolBufferException {
+      return PARSER.parseFrom(data, extensionRegistry);
+    }
+    public static io.gomatcha.matcha.proto.text.PbText.TextMetrics parseFrom(
+        com.google.protobuf.ByteString data)
+        throws com.google.protobuf.InvalidProtocolBufferException {
+      return PARSER.parseFrom(data);
+    }
+    public static io.gomatcha.matcha.proto.text.PbText.TextMetrics parseFrom(
+        com.google.protobuf.ByteString data,
+        com.google.protobuf.ExtensionRegistryLite extensionRegistry)
+        throws com.google.protobuf.InvalidProtocolBufferException {
+      return PARSER.parseFrom(data, extensionRegistry);
+    }
+    public static io.gomatcha.matcha.proto.text.PbText.TextMetrics parseFrom(byte[] data)
+        throws com.google.protobuf.InvalidProtocolBufferException {
+      return PARSER.parseFrom(data);
+    }
+    public static io.gomatcha.matcha.proto.text.PbText.TextMetrics parseFrom(
+        byte[] data,
+        com.google.protobuf.ExtensionRegistryLite extensionRegistry)
+        throws com.google.protobuf.InvalidProtocolBufferException {
+      return PARSER.parseFrom(data, extensionRegistry);
+    }
+    public static io.gomatcha.matcha.proto.text.PbText.TextMetrics parseFrom(java.io.InputStream input)
+        throws java.io.IOException {
+      return com.google.protobuf.GeneratedMessageV3
+          .parseWithIOException(PARSER, input);
+    }
+    public static io.gomatcha.matcha.proto.text.PbText.TextMetrics parseFrom(
+        java.io.InputStream input,
+        com.google.protobuf.ExtensionRegistryLite extensionRegistry)
+        throws java.io.IOException {
+      return com.google.protobuf.GeneratedMessageV3
+          .parseWithIOException(PARSER, input, extensionRegistry);
+    }
+    public static io.gomatcha.matcha.proto.text.PbText.TextMetrics parseDelimitedFrom(java.io.InputStream input)
+        throws java.io.IOException {
+      return com.google.protobuf.GeneratedMessageV3
+          .parseDelimitedWithIOException(PARSER, input);
+    }
+    public static io.gomatcha.matcha.proto.text.PbText.TextMetrics parseDelimitedFrom(
+        java.io.InputStream input,
+        com.google.protobuf.ExtensionRegistryLite extensionRegistry)
+        throws java.io.IOException {
+      return com.google.protobuf.GeneratedMessageV3
+          .parseDelimitedWithIOException(PARSER, input, extensionRegistry);
+    }
+    public static io.gomatcha.matcha.proto.text.PbText.TextMetrics parseFrom(
+        com.google.protobuf.CodedInputStream input)
+        throws java.io.IOException {
+      return com.google.protobuf.GeneratedMessageV3
+          .parseWithIOException(PARSER, input);
+    }
+    public static io.gomatcha.matcha.proto.text.PbText.TextMetrics parseFrom(
+        com.google.protobuf.CodedInputStream input,
+        com.google.protobuf.ExtensionRegistryLite extensionRegistry)
+        throws java.io.IOException {
+      return com.google.protobuf.GeneratedMessageV3
+          .parseWithIOException(PARSER, input, extensionRegistry);
     }
 
-    public io.gomatcha.matcha.proto.text.PbText.TextStyle getDefaultInstanceForType() {
+    public Builder newBuilderForType() { return newBuilder(); }
+    public static Builder newBuilder() {
+      return DEFAULT_INSTANCE.toBuilder();
+    }
+    public static Builder newBuilder(io.gomatcha.matcha.proto.text.PbText.TextMetrics prototype) {
+      return DEFAULT_INSTANCE.toBuilder().mergeFrom(prototype);
+    }
+    public Builder toBuilder() {
+      return this == DEFAULT_INSTANCE
+          ? new Builder() : new Builder().mergeFrom(this);
+    }
+
+    @java.lang.Override
+    protected Builder newBuilderForType(
+        com.google.protobuf.GeneratedMessageV3.BuilderParent parent) {
+      Builder builder = new Builder(parent);
+      return builder;
+    }
+    /**
+     * Protobuf type {@code matcha.text.TextMetrics}
+     */
+    public static final class Builder extends
+        com.google.protobuf.GeneratedMessageV3.Builder<Builder> implements
+        // @@protoc_insertion_point(builder_implements:matcha.text.TextMetrics)
+        io.gomatcha.matcha.proto.text.PbText.TextMetricsOrBuilder {
+      public static final com.google.protobuf.Descriptors.Descriptor
+          getDescriptor() {
+        return io.gomatcha.matcha.proto.text.PbText.internal_static_matcha_text_TextMetrics_descriptor;
+      }
+
+      protected com.google.protobuf.GeneratedMessageV3.FieldAccessorTable
+          internalGetFieldAccessorTable() {
+        return io.gomatcha.matcha.proto.text.PbText.internal_static_matcha_text_TextMetrics_fieldAccessorTable
+            .ensureFieldAccessorsInitialized(
+                io.gomatcha.matcha.proto.text.PbText.TextMetrics.class, io.gomatcha.matcha.proto.text.PbText.TextMetrics.Builder.class);
+      }
+
+      // Construct using io.gomatcha.matcha.proto.text.PbText.TextMetrics.newBuilder()
+      private Builder() {
+        maybeForceBuilderInitialization();
+      }
+
+      private Builder(
+          com.google.protobuf.GeneratedMessageV3.BuilderParent parent) {
+        super(parent);
+        maybeForceBuilderInitialization();
+      }
+      private void maybeForceBuilderInitialization() {
+        if (com.google.protobuf.GeneratedMessageV3
+                .alwaysUseFieldBuilders) {
+        }
+      }
+      public Builder clear() {
+        super.clear();
+        width_ = 0D;
+
+        height_ = 0D;
+
+        firstBaseline_ = 0D;
+
+        lastBaseline_ = 0D;
+
+        return this;
+      }
+
+      public com.google.protobuf.Descriptors.Descriptor
+          getDescriptorForType() {
+        return io.gomatcha.matcha.proto.text.PbText.internal_static_matcha_text_TextMetrics_descriptor;
+      }
+
+      public io.gomatcha.matcha.proto.text.PbText.TextMetrics getDefaultInstanceForType() {
+        return io.gomatcha.matcha.proto.text.PbText.TextMetrics.getDefaultInstance();
+      }
+
+      public io.gomatcha.matcha.proto.text.PbText.TextMetrics build() {
+        io.gomatcha.matcha.proto.text.PbText.TextMetrics result = buildPartial();
+        if (!result.isInitialized()) {
+          throw newUninitializedMessageException(result);
+        }
+        return result;
+      }
+
+      public io.gomatcha.matcha.proto.text.PbText.TextMetrics buildPartial() {
+        io.gomatcha.matcha.proto.text.PbText.TextMetrics result = new io.gomatcha.matcha.proto.text.PbText.TextMetrics(this);
+        result.width_ = width_;
+        result.height_ = height_;
+        result.firstBaseline_ = firstBaseline_;
+        result.lastBaseline_ = lastBaseline_;
+        onBuilt();
+        return result;
+      }
+
+      public Builder clone() {
+        return (Builder) super.clone();
+      }
+      public Builder setField(
+          com.google.protobuf.Descriptors.FieldDescriptor field,
+          Object value) {
+        return (Builder) super.setField(field, value);
+      }
+      public Builder clearField(
+          com.google.protobuf.Descriptors.FieldDescriptor field) {
+        return (Builder) super.clearField(field);
+      }
+      public Builder clearOneof(
+          com.google.protobuf.Descriptors.OneofDescriptor oneof) {
+        return (Builder) super.clearOneof(oneof);
+      }
+      public Builder setRepeatedField(
+          com.google.protobuf.Descriptors.FieldDescriptor field,
+          int index, Object value) {
+        return (Builder) super.setRepeatedField(field, index, value);
+      }
+      public Builder addRepeatedField(
+          com.google.protobuf.Descriptors.FieldDescriptor field,
+          Object value) {
+        return (Builder) super.addRepeatedField(field, value);
+      }
+      public Builder mergeFrom(com.google.protobuf.Message other) {
+        if (other instanceof io.gomatcha.matcha.proto.text.PbText.TextMetrics) {
+          return mergeFrom((io.gomatcha.matcha.proto.text.PbText.TextMetrics)other);
+        } else {
+          super.mergeFrom(other);
+          return this;
+        }
+      }
+
+      public Builder mergeFrom(io.gomatcha.matcha.proto.text.PbText.TextMetrics other) {
+        if (other == io.gomatcha.matcha.proto.text.PbText.TextMetrics.getDefaultInstance()) return this;
+        if (other.getWidth() != 0D) {
+          setWidth(other.getWidth());
+        }
+        if (other.getHeight() != 0D) {
+          setHeight(other.getHeight());
+        }
+        if (other.getFirstBaseline() != 0D) {
+          setFirstBaseline(other.getFirstBaseline());
+        }
+        if (other.getLastBaseline() != 0D) {
+          setLastBaseline(other.getLastBaseline());
+        }
+        onChanged();
+        return this;
+      }
+
+      public final boolean isInitialized() {
+        return true;
+      }
+
+      public Builder mergeFrom(
+          com.google.protobuf.CodedInputStream input,
+          com.google.protobuf.ExtensionRegistryLite extensionRegistry)
+          throws java.io.IOException {
+        io.gomatcha.matcha.proto.text.PbText.TextMetrics parsedMessage = null;
+        try {
+          parsedMessage = PARSER.parsePartialFrom(input, extensionRegistry);
+        } catch (com.google.protobuf.InvalidProtocolBufferException e) {
+          parsedMessage = (io.gomatcha.matcha.proto.text.PbText.TextMetrics) e.getUnfinishedMessage();
+          throw e.unwrapIOException();
+        } finally {
+          if (parsedMessage != null) {
+            mergeFrom(parsedMessage);
+          }
+        }
+        return this;
+      }
+
+      private double width_ ;
+      /**
+       * <code>double width = 1;</code>
+       */
+      public double getWidth() {
+        return width_;
+      }
+      /**
+       * <code>double width = 1;</code>
+       */
+      public Builder setWidth(double value) {
+        
+        width_ = value;
+        onChanged();
+        return this;
+      }
+      /**
+       * <code>double width = 1;</code>
+       */
+      public Builder clearWidth() {
+        
+        width_ = 0D;
+        onChanged();
+        return this;
+      }
+
+      private double height_ ;
+      /**
+       * <code>double height = 2;</code>
+       */
+      public double getHeight() {
+        return height_;
+      }
+      /**
+       * <code>double height = 2;</code>
+       */
+      public Builder setHeight(double value) {
+        
+        height_ = value;
+        onChanged();
+        return this;
+      }
+      /**
+       * <code>double height = 2;</code>
+       */
+      public Builder clearHeight() {
+        
+        height_ = 0D;
+        onChanged();
+        return this;
+      }
+
+      private double firstBaseline_ ;
+      /**
+       * <code>double firstBaseline = 3;</code>
+       */
+      public double getFirstBaseline() {
+        return firstBaseline_;
+      }
+      /**
+       * <code>double firstBaseline = 3;</code>
+       */
+      public Builder setFirstBaseline(double value) {
+        
+        firstBaseline_ = value;
+        onChanged();
+        return this;
+      }
+      /**
+       * <code>double firstBaseline = 3;</code>
+       */
+      public Builder clearFirstBaseline() {
+        
+        firstBaseline_ = 0D;
+        onChanged();
+        return this;
+      }
+
+      private double lastBaseline_ ;
+      /**
+       * <code>double lastBaseline = 4;</code>
+       */
+      public double getLastBaseline() {
+        return lastBaseline_;
+      }
+      /**
+       * <code>double lastBaseline = 4;</code>
+       */
+      public Builder setLastBaseline(double value) {
+        
+        lastBaseline_ = value;
+        onChanged();
+        return this;
+      }
+      /**
+       * <code>double lastBaseline = 4;</code>
+       */
+      public Builder clearLastBaseline() {
+        
+        lastBaseline_ = 0D;
+        onChanged();
+        return this;
+      }
+      public final Builder setUnknownFields(
+          final com.google.protobuf.UnknownFieldSet unknownFields) {
+        return this;
+      }
+
+      public final Builder mergeUnknownFields(
+          final com.google.protobuf.UnknownFieldSet unknownFields) {
+        return this;
+      }
+
+
+      // @@protoc_insertion_point(builder_scope:matcha.text.TextMetrics)
+    }
+
+    // @@protoc_insertion_point(class_scope:matcha.text.TextMetrics)
+    private static final io.gomatcha.matcha.proto.text.PbText.TextMetrics DEFAULT_INSTANCE;
+    static {
+      DEFAULT_INSTANCE = new io.gomatcha.matcha.proto.text.PbText.TextMetrics();
+    }
+
+    public static io.gomatcha.matcha.proto.text.PbText.TextMetrics getDefaultInstance() {
+      return DEFAULT_INSTANCE;
+    }
+
+    private static final com.google.protobuf.Parser<TextMetrics>
+        PARSER = new com.google.protobuf.AbstractParser<TextMetrics>() {
+      public TextMetrics parsePartialFrom(
+          com.google.protobuf.CodedInputStream input,
+          com.google.protobuf.ExtensionRegistryLite extensionRegistry)
+          throws com.google.protobuf.InvalidProtocolBufferException {
+          return new TextMetrics(input, extensionRegistry);
+      }
+    };
+
+    public static com.google.protobuf.Parser<TextMetrics> parser() {
+      return PARSER;
+    }
+
+    @java.lang.Override
+    public com.google.protobuf.Parser<TextMetrics> getParserForType() {
+      return PARSER;
+    }
+
+    public io.gomatcha.matcha.proto.text.PbText.TextMetrics getDefaultInstanceForType() {
       return DEFAULT_INSTANCE;
     }
 
   }
 
+
   private static final com.google.protobuf.Descriptors.Descriptor
     internal_static_matcha_text_SizeFunc_descriptor;
   private static final 
@@ -5809,6 +6914,11 @@ public final class PbText {
   private static final 
     com.google.protobuf.GeneratedMessageV3.FieldAccessorTable
       internal_static_matcha_text_TextStyle_fieldAccessorTable;
+  private static final com.google.protobuf.Descriptors.Descriptor
+    internal_static_matcha_text_TextMetrics_descriptor;
+  private static final 
+    com.google.protobuf.GeneratedMessageV3.FieldAccessorTable
+      internal_static_matcha_text_TextMetrics_fieldAccessorTable;
 
   public static com.google.protobuf.Descriptors.FileDescriptor
       getDescriptor() {
@@ -5828,7 +6938,7 @@ public final class PbText {
       "text\030\001 \001(\t\"U\n\nStyledText\022&\n\006styles\030\001 \003(\013" +
       "2\026.matcha.text.TextStyle\022\037\n\004text\030\002 \001(\0132\021" +
       ".matcha.text.Text\"2\n\004Font\022\016\n\006family\030\001 \001(",
-      "\t\022\014\n\004face\030\002 \001(\t\022\014\n\004size\030\003 \001(\001\"\203\004\n\tTextSt" +
+      "\t\022\014\n\004face\030\002 \001(\t\022\014\n\004size\030\003 \001(\001\"\232\005\n\tTextSt" +
       "yle\022\r\n\005index\030\001 \001(\003\0221\n\rtextAlignment\030\002 \001(" +
       "\0162\032.matcha.text.TextAlignment\022;\n\022striket" +
       "hroughStyle\030\004 \001(\0162\037.matcha.text.Striketh" +
@@ -5841,26 +6951,37 @@ public final class PbText {
       "\022 \001(\003\022 \n\ttextColor\030\024 \001(\0132\r.matcha.Color\022" +
       "#\n\004wrap\030\026 \001(\0162\025.matcha.text.TextWrap\022+\n\n" +
       "truncation\030\030 \001(\0162\027.matcha.text.Truncatio" +
-      "n\022\030\n\020truncationString\030\032 \001(\t*{\n\rTextAlign" +
-      "ment\022\027\n\023TEXT_ALIGNMENT_LEFT\020\000\022\030\n\024TEXT_AL" +
-      "IGNMENT_RIGHT\020\001\022\031\n\025TEXT_ALIGNMENT_CENTER" +
-      "\020\002\022\034\n\030TEXT_ALIGNMENT_JUSTIFIED\020\003*\321\001\n\022Str" +
-      "ikethroughStyle\022\034\n\030STRIKETHROUGH_STYLE_N" +
-      "ONE\020\000\022\036\n\032STRIKETHROUGH_STYLE_SINGLE\020\001\022\036\n" +
-      "\032STRIKETHROUGH_STYLE_DOUBLE\020\002\022\035\n\031STRIKET",
-      "HROUGH_STYLE_THICK\020\003\022\036\n\032STRIKETHROUGH_ST" +
-      "YLE_DOTTED\020\004\022\036\n\032STRIKETHROUGH_STYLE_DASH" +
-      "ED\020\005*\265\001\n\016UnderlineStyle\022\030\n\024UNDRELINE_STY" +
-      "LE_NONE\020\000\022\032\n\026UNDRELINE_STYLE_SINGLE\020\001\022\032\n" +
-      "\026UNDRELINE_STYLE_DOUBLE\020\002\022\031\n\025UNDRELINE_S" +
-      "TYLE_THICK\020\003\022\032\n\026UNDRELINE_STYLE_DOTTED\020\004" +
-      "\022\032\n\026UNDRELINE_STYLE_DASHED\020\005*K\n\010TextWrap" +
-      "\022\022\n\016TEXT_WRAP_NONE\020\000\022\022\n\016TEXT_WRAP_WORD\020\001" +
-      "\022\027\n\023TEXT_WRAP_CHARACTER\020\002*b\n\nTruncation\022" +
-      "\023\n\017TRUNCATION_NONE\020\000\022\024\n\020TRUNCATION_START",
-      "\020\001\022\025\n\021TRUNCATION_MIDDLE\020\002\022\022\n\016TRUNCATION_" +
-      "END\020\003B8\n\035io.gomatcha.matcha.proto.textB\006" +
-      "PbTextZ\004text\242\002\010MatchaPBb\006proto3"
+      "n\022\030\n\020truncationString\030\032 \001(\t\0227\n\020writingDi" +
+      "rection\030\034 \001(\0162\035.matcha.text.WritingDirec" +
+      "tion\022\025\n\rletterSpacing\030\036 \001(\001\022\022\n\nlineHeigh" +
+      "t\030  \001(\001\022\030\n\020paragraphSpacing\030\" \001(\001\022\027\n\017fir" +
+      "stLineIndent\030$ \001(\001\"\205\001\n\013TextMetrics\022\024\n\005wi" +
+      "dth\030\001 \001(\001R\005width\022\026\n\006height\030\002 \001(\001R\006height" +
+      "\022$\n\rfirstBaseline\030\003 \001(\001R\rfirstBaseline\022\"",
+      "\n\014lastBaseline\030\004 \001(\001R\014lastBaseline*{\n\rTe" +
+      "xtAlignment\022\027\n\023TEXT_ALIGNMENT_LEFT\020\000\022\030\n\024" +
+      "TEXT_ALIGNMENT_RIGHT\020\001\022\031\n\025TEXT_ALIGNMENT" +
+      "_CENTER\020\002\022\034\n\030TEXT_ALIGNMENT_JUSTIFIED\020\003*" +
+      "\321\001\n\022StrikethroughStyle\022\034\n\030STRIKETHROUGH_" +
+      "STYLE_NONE\020\000\022\036\n\032STRIKETHROUGH_STYLE_SING" +
+      "LE\020\001\022\036\n\032STRIKETHROUGH_STYLE_DOUBLE\020\002\022\035\n\031" +
+      "STRIKETHROUGH_STYLE_THICK\020\003\022\036\n\032STRIKETHR" +
+      "OUGH_STYLE_DOTTED\020\004\022\036\n\032STRIKETHROUGH_STY" +
+      "LE_DASHED\020\005*\265\001\n\016UnderlineStyle\022\030\n\024UNDREL",
+      "INE_STYLE_NONE\020\000\022\032\n\026UNDRELINE_STYLE_SING" +
+      "LE\020\001\022\032\n\026UNDRELINE_STYLE_DOUBLE\020\002\022\031\n\025UNDR" +
+      "ELINE_STYLE_THICK\020\003\022\032\n\026UNDRELINE_STYLE_D" +
+      "OTTED\020\004\022\032\n\026UNDRELINE_STYLE_DASHED\020\005*K\n\010T" +
+      "extWrap\022\022\n\016TEXT_WRAP_NONE\020\000\022\022\n\016TEXT_WRAP" +
+      "_WORD\020\001\022\027\n\023TEXT_WRAP_CHARACTER\020\002*b\n\nTrun" +
+      "cation\022\023\n\017TRUNCATION_NONE\020\000\022\024\n\020TRUNCATIO" +
+      "N_START\020\001\022\025\n\021TRUNCATION_MIDDLE\020\002\022\022\n\016TRUN" +
+      "CATION_END\020\003*{\n\020WritingDirection\022\035\n\031WRIT" +
+      "ING_DIRECTION_NATURAL\020\000\022#\n\037WRITING_DIREC",
+      "TION_LEFT_TO_RIGHT\020\001\022#\n\037WRITING_DIRECTIO" +
+      "N_RIGHT_TO_LEFT\020\002B8\n\035io.gomatcha.matcha." +
+      "proto.textB\006PbTextZ\004text\242\002\010MatchaPBb\006pro" +
+      "to3"
     };
     com.google.protobuf.Descriptors.FileDescriptor.InternalDescriptorAssigner assigner =
         new com.google.protobuf.Descriptors.FileDescriptor.    InternalDescriptorAssigner() {
@@ -5905,9 +7026,15 @@ public final class PbText {
     internal_static_matcha_text_TextStyle_fieldAccessorTable = new
       com.google.protobuf.GeneratedMessageV3.FieldAccessorTable(
         internal_static_matcha_text_TextStyle_descriptor,
-        new java.lang.String[] { "Index", "TextAlignment", "StrikethroughStyle", "StrikethroughColor", "UnderlineStyle", "UnderlineColor", "Font", "Hyphenation", "LineHeightMultiple", "MaxLines", "TextColor", "Wrap", "Truncation", "TruncationString", });
+        new java.lang.String[] { "Index", "TextAlignment", "StrikethroughStyle", "StrikethroughColor", "UnderlineStyle", "UnderlineColor", "Font", "Hyphenation", "LineHeightMultiple", "MaxLines", "TextColor", "Wrap", "Truncation", "TruncationString", "WritingDirection", "LetterSpacing", "LineHeight", "ParagraphSpacing", "FirstLineIndent", });
     io.gomatcha.matcha.proto.layout.PbLayout.getDescriptor();
     io.gomatcha.matcha.proto.Proto.getDescriptor();
+    internal_static_matcha_text_TextMetrics_descriptor =
+      getDescriptor().getMessageTypes().get(5);
+    internal_static_matcha_text_TextMetrics_fieldAccessorTable = new
+      com.google.protobuf.GeneratedMessageV3.FieldAccessorTable(
+        internal_static_matcha_text_TextMetrics_descriptor,
+        new java.lang.String[] { "Width", "Height", "FirstBaseline", "LastBaseline", });
   }
 
   // @@protoc_insertion_point(outer_class_scope)
@@ -22,6 +22,36 @@ public final class PbStackView {
      * <code>int64 screenId = 3;</code>
      */
     long getScreenId();
+
+    /**
+     * <code>int64 transition = 4;</code>
+     */
+    long getTransition();
+
+    /**
+     * <code>double transitionDuration = 5;</code>
+     */
+    double getTransitionDuration();
+
+    /**
+     * <code>double curveX0 = 6;</code>
+     */
+    double getCurveX0();
+
+    /**
+     * <code>double curveY0 = 7;</code>
+     */
+    double getCurveY0();
+
+    /**
+     * <code>double curveX1 = 8;</code>
+     */
+    double getCurveX1();
+
+    /**
+     * <code>double curveY1 = 9;</code>
+     */
+    double getCurveY1();
   }
   /**
    * Protobuf type {@code matcha.view.android.StackChildView}
@@ -36,6 +66,12 @@ public final class PbStackView {
     }
     private StackChildView() {
       screenId_ = 0L;
+      transition_ = 0L;
+      transitionDuration_ = 0D;
+      curveX0_ = 0D;
+      curveY0_ = 0D;
+      curveX1_ = 0D;
+      curveY1_ = 0D;
     }
 
     @java.lang.Override
@@ -68,6 +104,36 @@ public final class PbStackView {
               screenId_ = input.readInt64();
               break;
             }
+            case 32: {
+
+              transition_ = input.readInt64();
+              break;
+            }
+            case 41: {
+
+              transitionDuration_ = input.readDouble();
+              break;
+            }
+            case 49: {
+
+              curveX0_ = input.readDouble();
+              break;
+            }
+            case 57: {
+
+              curveY0_ = input.readDouble();
+              break;
+            }
+            case 65: {
+
+              curveX1_ = input.readDouble();
+              break;
+            }
+            case 73: {
+
+              curveY1_ = input.readDouble();
+              break;
+            }
           }
         }
       } catch (com.google.protobuf.InvalidProtocolBufferException e) {
@@ -100,6 +166,60 @@ public final class PbStackView {
       return screenId_;
     }
 
+    public static final int TRANSITION_FIELD_NUMBER = 4;
+    private long transition_;
+    /**
+     * <code>int64 transition = 4;</code>
+     */
+    public long getTransition() {
+      return transition_;
+    }
+
+    public static final int TRANSITIONDURATION_FIELD_NUMBER = 5;
+    private double transitionDuration_;
+    /**
+     * <code>double transitionDuration = 5;</code>
+     */
+    public double getTransitionDuration() {
+      return transitionDuration_;
+    }
+
+    public static final int CURVEX0_FIELD_NUMBER = 6;
+    private double curveX0_;
+    /**
+     * <code>double curveX0 = 6;</code>
+     */
+    public double getCurveX0() {
+      return curveX0_;
+    }
+
+    public static final int CURVEY0_FIELD_NUMBER = 7;
+    private double curveY0_;
+    /**
+     * <code>double curveY0 = 7;</code>
+     */
+    public double getCurveY0() {
+      return curveY0_;
+    }
+
+    public static final int CURVEX1_FIELD_NUMBER = 8;
+    private double curveX1_;
+    /**
+     * <code>double curveX1 = 8;</code>
+     */
+    public double getCurveX1() {
+      return curveX1_;
+    }
+
+    public static final int CURVEY1_FIELD_NUMBER = 9;
+    private double curveY1_;
+    /**
+     * <code>double curveY1 = 9;</code>
+     */
+    public double getCurveY1() {
+      return curveY1_;
+    }
+
     private byte memoizedIsInitialized = -1;
     public final boolean isInitialized() {
       byte isInitialized = memoizedIsInitialized;
@@ -115,6 +235,24 @@ public final class PbStackView {
       if (screenId_ != 0L) {
         output.writeInt64(3, screenId_);
       }
+      if (transition_ != 0L) {
+        output.writeInt64(4, transition_);
+      }
+      if (transitionDuration_ != 0D) {
+        output.writeDouble(5, transitionDuration_);
+      }
+      if (curveX0_ != 0D) {
+        output.writeDouble(6, curveX0_);
+      }
+      if (curveY0_ != 0D) {
+        output.writeDouble(7, curveY0_);
+      }
+      if (curveX1_ != 0D) {
+        output.writeDouble(8, curveX1_);
+      }
+      if (curveY1_ != 0D) {
+        output.writeDouble(9, curveY1_);
+      }
     }
 
     public int getSerializedSize() {
@@ -126,6 +264,30 @@ public final class PbStackView {
         size += com.google.protobuf.CodedOutputStream
           .computeInt64Size(3, screenId_);
       }
+      if (transition_ != 0L) {
+        size += com.google.protobuf.CodedOutputStream
+          .computeInt64Size(4, transition_);
+      }
+      if (transitionDuration_ != 0D) {
+        size += com.google.protobuf.CodedOutputStream
+          .computeDoubleSize(5, transitionDuration_);
+      }
+      if (curveX0_ != 0D) {
+        size += com.google.protobuf.CodedOutputStream
+          .computeDoubleSize(6, curveX0_);
+      }
+      if (curveY0_ != 0D) {
+        size += com.google.protobuf.CodedOutputStream
+          .computeDoubleSize(7, curveY0_);
+      }
+      if (curveX1_ != 0D) {
+        size += com.google.protobuf.CodedOutputStream
+          .computeDoubleSize(8, curveX1_);
+      }
+      if (curveY1_ != 0D) {
+        size += com.google.protobuf.CodedOutputStream
+          .computeDoubleSize(9, curveY1_);
+      }
       memoizedSize = size;
       return size;
     }
@@ -144,6 +306,28 @@ public final class PbStackView {
       boolean result = true;
       result = result && (getScreenId()
           == other.getScreenId());
+      result = result && (getTransition()
+          == other.getTransition());
+      result = result && (
+          java.lang.Double.doubleToLongBits(getTransitionDuration())
+          == java.lang.Double.doubleToLongBits(
+              other.getTransitionDuration()));
+      result = result && (
+          java.lang.Double.doubleToLongBits(getCurveX0())
+          == java.lang.Double.doubleToLongBits(
+              other.getCurveX0()));
+      result = result && (
+          java.lang.Double.doubleToLongBits(getCurveY0())
+          == java.lang.Double.doubleToLongBits(
+              other.getCurveY0()));
+      result = result && (
+          java.lang.Double.doubleToLongBits(getCurveX1())
+          == java.lang.Double.doubleToLongBits(
+              other.getCurveX1()));
+      result = result && (
+          java.lang.Double.doubleToLongBits(getCurveY1())
+          == java.lang.Double.doubleToLongBits(
+              other.getCurveY1()));
       return result;
     }
 
@@ -157,6 +341,24 @@ public final class PbStackView {
       hash = (37 * hash) + SCREENID_FIELD_NUMBER;
       hash = (53 * hash) + com.google.protobuf.Internal.hashLong(
           getScreenId());
+      hash = (37 * hash) + TRANSITION_FIELD_NUMBER;
+      hash = (53 * hash) + com.google.protobuf.Internal.hashLong(
+          getTransition());
+      hash = (37 * hash) + TRANSITIONDURATION_FIELD_NUMBER;
+      hash = (53 * hash) + com.google.protobuf.Internal.hashLong(
+          java.lang.Double.doubleToLongBits(getTransitionDuration()));
+      hash = (37 * hash) + CURVEX0_FIELD_NUMBER;
+      hash = (53 * hash) + com.google.protobuf.Internal.hashLong(
+          java.lang.Double.doubleToLongBits(getCurveX0()));
+      hash = (37 * hash) + CURVEY0_FIELD_NUMBER;
+      hash = (53 * hash) + com.google.protobuf.Internal.hashLong(
+          java.lang.Double.doubleToLongBits(getCurveY0()));
+      hash = (37 * hash) + CURVEX1_FIELD_NUMBER;
+      hash = (53 * hash) + com.google.protobuf.Internal.hashLong(
+          java.lang.Double.doubleToLongBits(getCurveX1()));
+      hash = (37 * hash) + CURVEY1_FIELD_NUMBER;
+      hash = (53 * hash) + com.google.protobuf.Internal.hashLong(
+          java.lang.Double.doubleToLongBits(getCurveY1()));
       hash = (29 * hash) + unknownFields.hashCode();
       memoizedHashCode = hash;
       return hash;
@@ -288,6 +490,18 @@ public final class PbStackView {
         super.clear();
         screenId_ = 0L;
 
+        transition_ = 0L;
+
+        transitionDuration_ = 0D;
+
+        curveX0_ = 0D;
+
+        curveY0_ = 0D;
+
+        curveX1_ = 0D;
+
+        curveY1_ = 0D;
+
         return this;
       }
 
@@ -311,6 +525,12 @@ public final class PbStackView {
       public io.gomatcha.matcha.proto.view.android.PbStackView.StackChildView buildPartial() {
         io.gomatcha.matcha.proto.view.android.PbStackView.StackChildView result = new io.gomatcha.matcha.proto.view.android.PbStackView.StackChildView(this);
         result.screenId_ = screenId_;
+        result.transition_ = transition_;
+        result.transitionDuration_ = transitionDuration_;
+        result.curveX0_ = curveX0_;
+        result.curveY0_ = curveY0_;
+        result.curveX1_ = curveX1_;
+        result.curveY1_ = curveY1_;
         onBuilt();
         return result;
       }
@@ -355,6 +575,24 @@ public final class PbStackView {
         if (other.getScreenId() != 0L) {
           setScreenId(other.getScreenId());
         }
+        if (other.getTransition() != 0L) {
+          setTransition(other.getTransition());
+        }
+        if (other.getTransitionDuration() != 0D) {
+          setTransitionDuration(other.getTransitionDuration());
+        }
+        if (other.getCurveX0() != 0D) {
+          setCurveX0(other.getCurveX0());
+        }
+        if (other.getCurveY0() != 0D) {
+          setCurveY0(other.getCurveY0());
+        }
+        if (other.getCurveX1() != 0D) {
+          setCurveX1(other.getCurveX1());
+        }
+        if (other.getCurveY1() != 0D) {
+          setCurveY1(other.getCurveY1());
+        }
         onChanged();
         return this;
       }
@@ -406,6 +644,162 @@ public final class PbStackView {
         onChanged();
         return this;
       }
+
+      private long transition_ ;
+      /**
+       * <code>int64 transition = 4;</code>
+       */
+      public long getTransition() {
+        return transition_;
+      }
+      /**
+       * <code>int64 transition = 4;</code>
+       */
+      public Builder setTransition(long value) {
+        
+        transition_ = value;
+        onChanged();
+        return this;
+      }
+      /**
+       * <code>int64 transition = 4;</code>
+       */
+      public Builder clearTransition() {
+        
+        transition_ = 0L;
+        onChanged();
+        return this;
+      }
+
+      private double transitionDuration_ ;
+      /**
+       * <code>double transitionDuration = 5;</code>
+       */
+      public double getTransitionDuration() {
+        return transitionDuration_;
+      }
+      /**
+       * <code>double transitionDuration = 5;</code>
+       */
+      public Builder setTransitionDuration(double value) {
+        
+        transitionDuration_ = value;
+        onChanged();
+        return this;
+      }
+      /**
+       * <code>double transitionDuration = 5;</code>
+       */
+      public Builder clearTransitionDuration() {
+        
+        transitionDuration_ = 0D;
+        onChanged();
+        return this;
+      }
+
+      private double curveX0_ ;
+      /**
+       * <code>double curveX0 = 6;</code>
+       */
+      public double getCurveX0() {
+        return curveX0_;
+      }
+      /**
+       * <code>double curveX0 = 6;</code>
+       */
+      public Builder setCurveX0(double value) {
+        
+        curveX0_ = value;
+        onChanged();
+        return this;
+      }
+      /**
+       * <code>double curveX0 = 6;</code>
+       */
+      public Builder clearCurveX0() {
+        
+        curveX0_ = 0D;
+        onChanged();
+        return this;
+      }
+
+      private double curveY0_ ;
+      /**
+       * <code>double curveY0 = 7;</code>
+       */
+      public double getCurveY0() {
+        return curveY0_;
+      }
+      /**
+       * <code>double curveY0 = 7;</code>
+       */
+      public Builder setCurveY0(double value) {
+        
+        curveY0_ = value;
+        onChanged();
+        return this;
+      }
+      /**
+       * <code>double curveY0 = 7;</code>
+       */
+      public Builder clearCurveY0() {
+        
+        curveY0_ = 0D;
+        onChanged();
+        return this;
+      }
+
+      private double curveX1_ ;
+      /**
+       * <code>double curveX1 = 8;</code>
+       */
+      public double getCurveX1() {
+        return curveX1_;
+      }
+      /**
+       * <code>double curveX1 = 8;</code>
+       */
+      public Builder setCurveX1(double value) {
+        
+        curveX1_ = value;
+        onChanged();
+        return this;
+      }
+      /**
+       * <code>double curveX1 = 8;</code>
+       */
+      public Builder clearCurveX1() {
+        
+        curveX1_ = 0D;
+        onChanged();
+        return this;
+      }
+
+      private double curveY1_ ;
+      /**
+       * <code>double curveY1 = 9;</code>
+       */
+      public double getCurveY1() {
+        return curveY1_;
+      }
+      /**
+       * <code>double curveY1 = 9;</code>
+       */
+      public Builder setCurveY1(double value) {
+        
+        curveY1_ = value;
+        onChanged();
+        return this;
+      }
+      /**
+       * <code>double curveY1 = 9;</code>
+       */
+      public Builder clearCurveY1() {
+        
+        curveY1_ = 0D;
+        onChanged();
+        return this;
+      }
       public final Builder setUnknownFields(
           final com.google.protobuf.UnknownFieldSet unknownFields) {
         return this;
@@ -4561,23 +4955,26 @@ public final class PbStackView {
       "\n5gomatcha.io/matcha/proto/view/android/" +
       "stackview.proto\022\023matcha.view.android\032$go" +
       "matcha.io/matcha/proto/image.proto\032(goma" +
-      "tcha.io/matcha/proto/text/text.proto\"\"\n\016" +
-      "StackChildView\022\020\n\010screenId\030\003 \001(\003\"B\n\tStac" +
-      "kView\0225\n\010children\030\001 \003(\0132#.matcha.view.an" +
-      "droid.StackChildView\"\364\001\n\010StackBar\022\r\n\005tit" +
-      "le\030\001 \001(\t\022,\n\013styledTitle\030\006 \001(\0132\027.matcha.t" +
-      "ext.StyledText\022\020\n\010subtitle\030\003 \001(\t\022/\n\016styl" +
-      "edSubtitle\030\007 \001(\0132\027.matcha.text.StyledTex",
-      "t\022\034\n\005color\030\004 \001(\0132\r.matcha.Color\0220\n\005items" +
-      "\030\005 \003(\0132!.matcha.view.android.StackBarIte" +
-      "m\022\030\n\020backButtonHidden\030\002 \001(\010\"\214\001\n\014StackBar" +
-      "Item\022\r\n\005title\030\001 \001(\t\022%\n\004icon\030\003 \001(\0132\027.matc" +
-      "ha.ImageOrResource\022\037\n\010iconTint\030\002 \001(\0132\r.m" +
-      "atcha.Color\022\020\n\010disabled\030\004 \001(\010\022\023\n\013onPress" +
-      "Func\030\005 \001(\t\"\030\n\nStackEvent\022\n\n\002id\030\001 \003(\003BO\n%" +
-      "io.gomatcha.matcha.proto.view.androidB\013P" +
-      "bStackViewZ\007android\242\002\017MatchaAndroidPBb\006p" +
-      "roto3"
+      "tcha.io/matcha/proto/text/text.proto\"\226\001\n" +
+      "\016StackChildView\022\020\n\010screenId\030\003 \001(\003\022\022\n\ntra" +
+      "nsition\030\004 \001(\003\022\032\n\022transitionDuration\030\005 \001(" +
+      "\001\022\017\n\007curveX0\030\006 \001(\001\022\017\n\007curveY0\030\007 \001(\001\022\017\n\007c" +
+      "urveX1\030\010 \001(\001\022\017\n\007curveY1\030\t \001(\001\"B\n\tStackVi" +
+      "ew\0225\n\010children\030\001 \003(\0132#.matcha.view.andro" +
+      "id.StackChildView\"\364\001\n\010StackBar\022\r\n\005title\030",
+      "\001 \001(\t\022,\n\013styledTitle\030\006 \001(\0132\027.matcha.text" +
+      ".StyledText\022\020\n\010subtitle\030\003 \001(\t\022/\n\016styledS" +
+      "ubtitle\030\007 \001(\0132\027.matcha.text.StyledText\022\034" +
+      "\n\005color\030\004 \001(\0132\r.matcha.Color\0220\n\005items\030\005 " +
+      "\003(\0132!.matcha.view.android.StackBarItem\022\030" +
+      "\n\020backButtonHidden\030\002 \001(\010\"\214\001\n\014StackBarIte" +
+      "m\022\r\n\005title\030\001 \001(\t\022%\n\004icon\030\003 \001(\0132\027.matcha." +
+      "ImageOrResource\022\037\n\010iconTint\030\002 \001(\0132\r.matc" +
+      "ha.Color\022\020\n\010disabled\030\004 \001(\010\022\023\n\013onPressFun" +
+      "c\030\005 \001(\t\"\030\n\nStackEvent\022\n\n\002id\030\001 \003(\003BO\n%io.",
+      "gomatcha.matcha.proto.view.androidB\013PbSt" +
+      "ackViewZ\007android\242\002\017MatchaAndroidPBb\006prot" +
+      "o3"
     };
     com.google.protobuf.Descriptors.FileDescriptor.InternalDescriptorAssigner assigner =
         new com.google.protobuf.Descriptors.FileDescriptor.    InternalDescriptorAssigner() {
@@ -4598,7 +4995,7 @@ public final class PbStackView {
     internal_static_matcha_view_android_StackChildView_fieldAccessorTable = new
       com.google.protobuf.GeneratedMessageV3.FieldAccessorTable(
         internal_static_matcha_view_android_StackChildView_descriptor,
-        new java.lang.String[] { "ScreenId", });
+        new java.lang.String[] { "ScreenId", "Transition", "TransitionDuration", "CurveX0", "CurveY0", "CurveX1", "CurveY1", });
     internal_static_matcha_view_android_StackView_descriptor =
       getDescriptor().getMessageTypes().get(1);
     internal_static_matcha_view_android_StackView_fieldAccessorTable = new
@@ -7,6 +7,7 @@ import (
 	"gomatcha.io/matcha"
 	"gomatcha.io/matcha/comm"
 	"gomatcha.io/matcha/internal"
+	"gomatcha.io/matcha/internal/clock"
 )
 
 // Value is an struct that runs Animations and emits float64s.
@@ -52,7 +53,7 @@ func (v *Value) Run(a Animation) (cancelFunc func()) {
 		v.animation.cancel()
 	}
 
-	start := time.Now()
+	start := clock.Now()
 	an := &animation{animation: a, ticker: internal.NewTicker(time.Hour * 99), value: v}
 	an.tickerId = an.ticker.Notify(func() {
 		matcha.MainLocker.Lock()
@@ -61,7 +62,7 @@ func (v *Value) Run(a Animation) (cancelFunc func()) {
 			return
 		}
 
-		d := time.Now().Sub(start)
+		d := clock.Since(start)
 
 		v.setValue(a.Tick(d))
 		if d > a.Duration() {
@@ -75,6 +76,12 @@ func (v *Value) Run(a Animation) (cancelFunc func()) {
 	}
 }
 
+// FrameClock returns a notifier that posts a notification on every screen update.
+// It can be passed to comm.Sample to limit updates to once per frame.
+func FrameClock() comm.Notifier {
+	return &internal.FrameClock
+}
+
 // Animation is an interface that represents a float64 that changes over a fixed duration.
 type Animation interface {
 	Duration() time.Duration
@@ -133,6 +140,64 @@ func (a *Basic) Tick(t time.Duration) float64 {
 	return a.Start + ratio*(a.End-a.Start)
 }
 
+// Keyframe is a value that a Keyframes animation reaches at time Time.
+type Keyframe struct {
+	Time  time.Duration
+	Value float64
+	// Ease is the easing from the previous keyframe to this one. It is linear
+	// if nil.
+	Ease FloatInterpolater
+}
+
+// Keyframes is an animation that goes through each of Frames in order. The
+// frames must be sorted by time. Before the first frame the value is the
+// value of the first frame.
+//
+//  // Shake horizontally.
+//  v.Run(&animate.Keyframes{Frames: []animate.Keyframe{
+//  	{Time: 0, Value: 0},
+//  	{Time: 50 * time.Millisecond, Value: 10},
+//  	{Time: 150 * time.Millisecond, Value: -10},
+//  	{Time: 250 * time.Millisecond, Value: 6},
+//  	{Time: 300 * time.Millisecond, Value: 0, Ease: animate.DefaultOutEase},
+//  }})
+type Keyframes struct {
+	Frames []Keyframe
+}
+
+// Duration implements the Animation interface.
+func (a *Keyframes) Duration() time.Duration {
+	if len(a.Frames) == 0 {
+		return 0
+	}
+	return a.Frames[len(a.Frames)-1].Time
+}
+
+// Tick implements the Animation interface.
+func (a *Keyframes) Tick(t time.Duration) float64 {
+	if len(a.Frames) == 0 {
+		return 0
+	}
+	if t <= a.Frames[0].Time {
+		return a.Frames[0].Value
+	}
+	for i := 1; i < len(a.Frames); i++ {
+		prev, next := a.Frames[i-1], a.Frames[i]
+		if t > next.Time {
+			continue
+		}
+		ratio := 1.0
+		if next.Time > prev.Time {
+			ratio = float64(t-prev.Time) / float64(next.Time-prev.Time)
+		}
+		if next.Ease != nil {
+			ratio = next.Ease.Interpolate(ratio)
+		}
+		return prev.Value + ratio*(next.Value-prev.Value)
+	}
+	return a.Frames[len(a.Frames)-1].Value
+}
+
 // type Spring struct {
 // 	Start     float64
 // 	End       float64
@@ -0,0 +1,32 @@
+package animate
+
+import (
+	"testing"
+	"time"
+)
+
+func TestKeyframes(t *testing.T) {
+	a := &Keyframes{Frames: []Keyframe{
+		{Time: 100 * time.Millisecond, Value: 0},
+		{Time: 200 * time.Millisecond, Value: 10},
+		{Time: 200 * time.Millisecond, Value: 20},
+		{Time: 400 * time.Millisecond, Value: -20},
+	}}
+	if d := a.Duration(); d != 400*time.Millisecond {
+		t.Errorf("Duration() = %v", d)
+	}
+	for _, tc := range []struct {
+		t     time.Duration
+		value float64
+	}{
+		{0, 0},
+		{150 * time.Millisecond, 5},
+		{200 * time.Millisecond, 10},
+		{300 * time.Millisecond, 0},
+		{500 * time.Millisecond, -20},
+	} {
+		if v := a.Tick(tc.t); v != tc.value {
+			t.Errorf("Tick(%v) = %v, want %v", tc.t, v, tc.value)
+		}
+	}
+}
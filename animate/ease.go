@@ -0,0 +1,108 @@
+package animate
+
+import (
+	"math"
+
+	"gomatcha.io/matcha/comm"
+)
+
+// Standard easings, which can be used anywhere a FloatInterpolater is.
+var (
+	EaseInQuad     FloatInterpolater = PolyInEase{Exp: 2}
+	EaseOutQuad    FloatInterpolater = PolyOutEase{Exp: 2}
+	EaseInOutQuad  FloatInterpolater = PolyInOutEase{ExpIn: 2, ExpOut: 2}
+	EaseInCubic    FloatInterpolater = PolyInEase{Exp: 3}
+	EaseOutCubic   FloatInterpolater = PolyOutEase{Exp: 3}
+	EaseInOutCubic FloatInterpolater = PolyInOutEase{ExpIn: 3, ExpOut: 3}
+	EaseInOutSine  FloatInterpolater = CubicBezier(0.37, 0, 0.63, 1)
+	EaseOutExpo    FloatInterpolater = CubicBezier(0.16, 1, 0.3, 1)
+	// The back easings overshoot, and go back to their start or end.
+	EaseInBack    FloatInterpolater = CubicBezier(0.36, 0, 0.66, -0.56)
+	EaseOutBack   FloatInterpolater = CubicBezier(0.34, 1.56, 0.64, 1)
+	EaseInOutBack FloatInterpolater = CubicBezier(0.68, -0.6, 0.32, 1.6)
+)
+
+// Material Design easings, from https://m3.material.io/styles/motion.
+var (
+	// MaterialStandard is for views that begin and end on screen.
+	MaterialStandard           FloatInterpolater = CubicBezier(0.2, 0, 0, 1)
+	MaterialStandardAccelerate FloatInterpolater = CubicBezier(0.3, 0, 1, 1)
+	MaterialStandardDecelerate FloatInterpolater = CubicBezier(0, 0, 0, 1)
+	// MaterialEmphasized is for views that begin and end on screen, and draw
+	// attention to the change.
+	MaterialEmphasized FloatInterpolater = emphasizedEase{
+		first:  CubicBezier(0.3, 0, 0.8, 0.15),
+		second: CubicBezier(0.05, 0.7, 0.1, 1),
+	}
+	// MaterialEmphasizedAccelerate is for views that exit the screen.
+	MaterialEmphasizedAccelerate FloatInterpolater = CubicBezier(0.3, 0, 0.8, 0.15)
+	// MaterialEmphasizedDecelerate is for views that enter the screen.
+	MaterialEmphasizedDecelerate FloatInterpolater = CubicBezier(0.05, 0.7, 0.1, 1)
+)
+
+// Spring approximations, see SpringEase.
+var (
+	// SpringSmooth settles without overshooting.
+	SpringSmooth FloatInterpolater = SpringEase{DampingRatio: 1}
+	// SpringSnappy overshoots slightly.
+	SpringSnappy FloatInterpolater = SpringEase{DampingRatio: 0.8}
+	// SpringBouncy overshoots and bounces back a few times.
+	SpringBouncy FloatInterpolater = SpringEase{DampingRatio: 0.5}
+)
+
+// emphasizedEase is Material's emphasized easing, which follows first until
+// 40% of the way, at a sixth of the time, and second for the rest.
+type emphasizedEase struct {
+	first, second CubicBezierEase
+}
+
+const (
+	emphasizedTime  = 1.0 / 6
+	emphasizedValue = 0.4
+)
+
+// Interpolate implements the Interpolater interface.
+func (e emphasizedEase) Interpolate(a float64) float64 {
+	if a < emphasizedTime {
+		return e.first.Interpolate(a/emphasizedTime) * emphasizedValue
+	}
+	return emphasizedValue + e.second.Interpolate((a-emphasizedTime)/(1-emphasizedTime))*(1-emphasizedValue)
+}
+
+// SpringEase approximates a spring that is released at 0 and comes to rest at
+// 1, where it ends. Springs with a DampingRatio below 1 overshoot and bounce,
+// more so as it approaches 0. At 1 or more they settle without overshooting.
+type SpringEase struct {
+	DampingRatio float64
+}
+
+// springDecay is how fast the spring's motion decays, so that its
+// oscillations are below a thousandth by the end of the easing.
+var springDecay = math.Log(1000)
+
+// Interpolate implements the Interpolater interface.
+func (e SpringEase) Interpolate(a float64) float64 {
+	if a <= 0 {
+		return 0
+	}
+	if a >= 1 {
+		return 1
+	}
+	ratio := e.DampingRatio
+	if ratio <= 0 {
+		ratio = 0.01
+	}
+	if ratio >= 1 {
+		// Critically damped.
+		w := springDecay * 1.4
+		return 1 - math.Exp(-w*a)*(1+w*a)
+	}
+	w := springDecay / ratio
+	wd := w * math.Sqrt(1-ratio*ratio)
+	return 1 - math.Exp(-ratio*w*a)*(math.Cos(wd*a)+ratio*w/wd*math.Sin(wd*a))
+}
+
+// Notifier is a convenience method around animate.FloatInterpolate(n, e)
+func (e SpringEase) Notifier(a comm.Float64Notifier) comm.Float64Notifier {
+	return FloatInterpolate(a, e)
+}
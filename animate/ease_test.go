@@ -0,0 +1,45 @@
+package animate
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEase(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		ease  FloatInterpolater
+		a     float64
+		value float64
+	}{
+		{"linear bezier", CubicBezier(0, 0, 1, 1), 0.3, 0.3},
+		{"default", DefaultEase, 0.5, 0.8024},
+		{"in out quad", EaseInOutQuad, 0.25, 0.125},
+		{"in out quad", EaseInOutQuad, 0.5, 0.5},
+		{"in out quad", EaseInOutQuad, 0.75, 0.875},
+		{"emphasized", MaterialEmphasized, 1.0 / 6, 0.4},
+		{"emphasized", MaterialEmphasized, 1, 1},
+		{"spring", SpringBouncy, 0, 0},
+		{"spring", SpringBouncy, 1, 1},
+	} {
+		if v := tc.ease.Interpolate(tc.a); math.Abs(v-tc.value) > 1e-3 {
+			t.Errorf("%v: Interpolate(%v) = %v, want %v", tc.name, tc.a, v, tc.value)
+		}
+	}
+}
+
+func TestSpringEase(t *testing.T) {
+	max := func(e FloatInterpolater) float64 {
+		m := 0.0
+		for a := 0.0; a <= 1; a += 0.01 {
+			m = math.Max(m, e.Interpolate(a))
+		}
+		return m
+	}
+	if m := max(SpringBouncy); m <= 1.1 {
+		t.Errorf("SpringBouncy peaks at %v, want an overshoot", m)
+	}
+	if m := max(SpringSmooth); m > 1 {
+		t.Errorf("SpringSmooth peaks at %v, want no overshoot", m)
+	}
+}
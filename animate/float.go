@@ -3,7 +3,6 @@ package animate
 import (
 	"math"
 
-	"golang.org/x/mobile/exp/sprite/clock" // TODO(KD): remove dependency
 	"gomatcha.io/matcha/comm"
 )
 
@@ -49,11 +48,69 @@ type CubicBezierEase struct {
 	X0, Y0, X1, Y1 float64
 }
 
+// CubicBezier returns an easing along a Cubic Bézier curve, like the CSS
+// cubic-bezier() function.
+//
+//  ease := animate.CubicBezier(0.68, -0.6, 0.32, 1.6)
+func CubicBezier(x0, y0, x1, y1 float64) CubicBezierEase {
+	return CubicBezierEase{X0: x0, Y0: y0, X1: x1, Y1: y1}
+}
+
 // Interpolate implements the Interpolater interface.
 func (e CubicBezierEase) Interpolate(a float64) float64 {
-	f := clock.CubicBezier(float32(e.X0), float32(e.Y0), float32(e.X1), float32(e.Y1))
-	t := f(0, 100000, clock.Time(a*100000))
-	return float64(t)
+	if a <= 0 {
+		return 0
+	}
+	if a >= 1 {
+		return 1
+	}
+	t := e.solve(a)
+	return bezier(e.Y0, e.Y1, t)
+}
+
+// solve returns the parameter of the curve where its X coordinate is x.
+func (e CubicBezierEase) solve(x float64) float64 {
+	// Newton's method converges quickly for most curves.
+	t := x
+	for i := 0; i < 8; i++ {
+		d := bezierSlope(e.X0, e.X1, t)
+		if math.Abs(d) < 1e-6 {
+			break
+		}
+		t2 := t - (bezier(e.X0, e.X1, t)-x)/d
+		if t2 < 0 || t2 > 1 {
+			break
+		}
+		if math.Abs(t2-t) < 1e-9 {
+			return t2
+		}
+		t = t2
+	}
+
+	// Fall back to bisection, as X always increases along the curve.
+	lo, hi := 0.0, 1.0
+	t = x
+	for i := 0; i < 64 && hi-lo > 1e-9; i++ {
+		if bezier(e.X0, e.X1, t) < x {
+			lo = t
+		} else {
+			hi = t
+		}
+		t = (lo + hi) / 2
+	}
+	return t
+}
+
+// bezier returns the coordinate at t of a curve from 0 to 1 with control
+// points p0 and p1.
+func bezier(p0, p1, t float64) float64 {
+	u := 1 - t
+	return 3*u*u*t*p0 + 3*u*t*t*p1 + t*t*t
+}
+
+func bezierSlope(p0, p1, t float64) float64 {
+	u := 1 - t
+	return 3*u*u*p0 + 6*u*t*(p1-p0) + 3*t*t*(1-p1)
 }
 
 // Notifier is a convenience method around animate.FloatInterpolate(n, e).
@@ -105,7 +162,8 @@ func (e PolyOutEase) Notifier(a comm.Float64Notifier) comm.Float64Notifier {
 	return FloatInterpolate(a, e)
 }
 
-// PolyInOutEase interpolates between Start and End with a symmetric polynomial easing.
+// PolyInOutEase interpolates between Start and End with a polynomial easing in
+// the first half, and a reverse polynomial easing in the second half.
 type PolyInOutEase struct {
 	ExpIn  float64
 	ExpOut float64
@@ -114,9 +172,9 @@ type PolyInOutEase struct {
 // Interpolate implements the Interpolater interface.
 func (e PolyInOutEase) Interpolate(a float64) float64 {
 	if a < 0.5 {
-		return math.Pow(a, e.ExpIn)
+		return math.Pow(2*a, e.ExpIn) / 2
 	} else {
-		return 1 - math.Pow(1-a, e.ExpOut)
+		return 1 - math.Pow(2-2*a, e.ExpOut)/2
 	}
 }
 
@@ -0,0 +1,171 @@
+package animate
+
+import (
+	"time"
+
+	"gomatcha.io/matcha"
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/internal"
+	"gomatcha.io/matcha/internal/clock"
+)
+
+// Group is a set of animations of Values, each placed in time, that runs as a
+// whole with RunGroup. Groups are built from Tracks, and combined with
+// Sequence, Parallel and Stagger.
+//
+//  g := animate.Sequence(
+//  	animate.Track(v.scale, &animate.Basic{Start: 0.8, End: 1, Dur: 200 * time.Millisecond}),
+//  	animate.Stagger(50*time.Millisecond,
+//  		animate.Track(v.row1, &animate.Basic{Start: 0, End: 1, Dur: 300 * time.Millisecond}),
+//  		animate.Track(v.row2, &animate.Basic{Start: 0, End: 1, Dur: 300 * time.Millisecond}),
+//  		animate.Track(v.row3, &animate.Basic{Start: 0, End: 1, Dur: 300 * time.Millisecond}),
+//  	),
+//  )
+//  animate.RunGroup(g, func(finished bool) {
+//  	...
+//  })
+type Group interface {
+	// Duration returns how long the group runs for.
+	Duration() time.Duration
+	// tick updates the values of the group's animations that run between
+	// prev, exclusive, and t, inclusive.
+	tick(prev, t time.Duration)
+	// values returns the values that the group animates.
+	values() []*Value
+}
+
+// Track returns a group that runs a on v.
+func Track(v *Value, a Animation) Group {
+	return &track{value: v, animation: a}
+}
+
+type track struct {
+	value     *Value
+	animation Animation
+}
+
+func (t *track) Duration() time.Duration {
+	return t.animation.Duration()
+}
+
+func (t *track) tick(prev, now time.Duration) {
+	if now < 0 {
+		return
+	}
+	if d := t.animation.Duration(); now > d {
+		now = d
+	}
+	t.value.setValue(t.animation.Tick(now))
+}
+
+func (t *track) values() []*Value {
+	return []*Value{t.value}
+}
+
+// Sequence returns a group that runs gs one after another.
+func Sequence(gs ...Group) Group {
+	g := &group{groups: gs}
+	start := time.Duration(0)
+	for _, i := range gs {
+		g.starts = append(g.starts, start)
+		start += i.Duration()
+	}
+	return g
+}
+
+// Parallel returns a group that runs gs at the same time. It runs until the
+// longest of gs ends.
+func Parallel(gs ...Group) Group {
+	return Stagger(0, gs...)
+}
+
+// Stagger returns a group that starts each of gs delay after the previous
+// one, such as to animate the rows of a list in one after another. It runs
+// until the last of gs ends.
+func Stagger(delay time.Duration, gs ...Group) Group {
+	g := &group{groups: gs}
+	for i := range gs {
+		g.starts = append(g.starts, time.Duration(i)*delay)
+	}
+	return g
+}
+
+type group struct {
+	groups []Group
+	starts []time.Duration
+}
+
+func (g *group) Duration() time.Duration {
+	d := time.Duration(0)
+	for i, j := range g.groups {
+		if end := g.starts[i] + j.Duration(); end > d {
+			d = end
+		}
+	}
+	return d
+}
+
+// tick updates the groups that have started by t and had not ended by prev,
+// in order, so that later groups win where they animate the same value.
+func (g *group) tick(prev, t time.Duration) {
+	for i, j := range g.groups {
+		start := g.starts[i]
+		if t >= start && prev < start+j.Duration() {
+			j.tick(prev-start, t-start)
+		}
+	}
+}
+
+func (g *group) values() []*Value {
+	vs := []*Value{}
+	for _, i := range g.groups {
+		vs = append(vs, i.values()...)
+	}
+	return vs
+}
+
+// RunGroup runs g, cancelling any animations that are running on its values.
+// onEnd, if not nil, is called once when g ends, with whether it ran to the
+// end or was cancelled. The values should not be animated separately while g
+// is running.
+func RunGroup(g Group, onEnd func(finished bool)) (cancelFunc func()) {
+	for _, v := range g.values() {
+		if v.animation != nil {
+			v.animation.cancel()
+		}
+	}
+
+	start := clock.Now()
+	prev := time.Duration(-1)
+	ended := false
+	ticker := internal.NewTicker(time.Hour * 99)
+	var tickerId comm.Id
+	end := func(finished bool) {
+		if ended {
+			return
+		}
+		ended = true
+		ticker.Unnotify(tickerId)
+		if onEnd != nil {
+			onEnd(finished)
+		}
+	}
+	tickerId = ticker.Notify(func() {
+		matcha.MainLocker.Lock()
+		defer matcha.MainLocker.Unlock()
+		if ended {
+			return
+		}
+
+		d := clock.Since(start)
+		g.tick(prev, d)
+		prev = d
+		if d > g.Duration() {
+			end(true)
+		}
+	})
+
+	return func() {
+		end(false)
+	}
+}
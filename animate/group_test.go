@@ -0,0 +1,57 @@
+package animate
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGroup(t *testing.T) {
+	ms := time.Millisecond
+	a, b, c := &Value{}, &Value{}, &Value{}
+	g := Sequence(
+		Track(a, &Basic{Start: 0, End: 10, Dur: 100 * ms}),
+		Stagger(50*ms,
+			Track(b, &Basic{Start: 0, End: 10, Dur: 100 * ms}),
+			Track(c, &Basic{Start: 0, End: 10, Dur: 100 * ms}),
+		),
+		Track(a, &Basic{Start: 10, End: 20, Dur: 100 * ms}),
+	)
+	if d := g.Duration(); d != 350*ms {
+		t.Fatalf("Duration() = %v, want 350ms", d)
+	}
+
+	prev := time.Duration(-1)
+	for _, tc := range []struct {
+		t       time.Duration
+		a, b, c float64
+	}{
+		{0, 0, 0, 0},
+		{50 * ms, 5, 0, 0},
+		// a ends between frames, and b and c start.
+		{170 * ms, 10, 7, 2},
+		{260 * ms, 11, 10, 10},
+		{300 * ms, 15, 10, 10},
+		{400 * ms, 20, 10, 10},
+	} {
+		g.tick(prev, tc.t)
+		prev = tc.t
+		if a.Value() != tc.a || b.Value() != tc.b || c.Value() != tc.c {
+			t.Errorf("at %v, values = %v %v %v, want %v %v %v", tc.t, a.Value(), b.Value(), c.Value(), tc.a, tc.b, tc.c)
+		}
+	}
+}
+
+func TestParallel(t *testing.T) {
+	a, b := &Value{}, &Value{}
+	g := Parallel(
+		Track(a, &Basic{Start: 0, End: 1, Dur: 100 * time.Millisecond}),
+		Track(b, &Basic{Start: 0, End: 1, Dur: 300 * time.Millisecond}),
+	)
+	if d := g.Duration(); d != 300*time.Millisecond {
+		t.Errorf("Duration() = %v, want 300ms", d)
+	}
+	g.tick(-1, 150*time.Millisecond)
+	if a.Value() != 1 || b.Value() != 0.5 {
+		t.Errorf("values = %v %v, want 1 0.5", a.Value(), b.Value())
+	}
+}
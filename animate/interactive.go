@@ -0,0 +1,146 @@
+package animate
+
+import (
+	"math"
+	"time"
+
+	"gomatcha.io/matcha/internal/clock"
+)
+
+// Interactive is the progress of a transition from 0 to 1 that is driven by a
+// gesture, such as an interactive back swipe or dragging a sheet to dismiss
+// it. While the gesture is active, Update sets the progress directly. When the
+// gesture ends, Release animates the progress to 1, completing the transition,
+// or back to 0, depending on where the gesture left it and how fast it was
+// moving.
+//
+//  press := &pointer.PressGesture{
+//  	OnEvent: func(e *pointer.PressEvent) {
+//  		switch e.Kind {
+//  		case pointer.EventKindPossible:
+//  			v.dismiss.Begin()
+//  		case pointer.EventKindChanged:
+//  			v.dismiss.Update((e.Position.Y - start) / height)
+//  		default:
+//  			v.dismiss.Release()
+//  		}
+//  	},
+//  }
+type Interactive struct {
+	Value
+	// Duration is how long the transition animates for from 0 to 1 after it
+	// is released without velocity. It is 300 milliseconds if 0.
+	Duration time.Duration
+	// OnEnd is called once the progress has animated to 0 or 1 after Release,
+	// with whether the transition completed.
+	OnEnd func(completed bool)
+
+	lastTime  time.Time
+	lastValue float64
+	velocity  float64
+}
+
+// Begin stops any running animation, so that the gesture can take over the
+// transition from where it is.
+func (i *Interactive) Begin() {
+	i.SetValue(i.Value.Value())
+	i.lastTime = clock.Now()
+	i.lastValue = i.Value.Value()
+	i.velocity = 0
+}
+
+// Update sets the progress of the transition, clamped between 0 and 1, and
+// tracks how fast it is changing.
+func (i *Interactive) Update(progress float64) {
+	progress = math.Min(math.Max(progress, 0), 1)
+	now := clock.Now()
+	if dt := now.Sub(i.lastTime).Seconds(); dt > 0 && !i.lastTime.IsZero() {
+		i.velocity = (progress - i.lastValue) / dt
+	}
+	i.lastTime = now
+	i.lastValue = progress
+	i.SetValue(progress)
+}
+
+// Release completes or rewinds the transition with the velocity of the last
+// updates.
+func (i *Interactive) Release() {
+	i.ReleaseWithVelocity(i.velocity)
+}
+
+// ReleaseWithVelocity completes or rewinds the transition, continuing with
+// velocity, in progress per second.
+func (i *Interactive) ReleaseWithVelocity(velocity float64) {
+	progress := i.Value.Value()
+	target := releaseTarget(progress, velocity)
+	duration := i.Duration
+	if duration == 0 {
+		duration = 300 * time.Millisecond
+	}
+
+	a := &interactiveAnimation{Basic: releaseAnimation(progress, target, velocity, duration)}
+	i.Run(a)
+	i.animation.onComplete = func() {
+		if a.finished && i.OnEnd != nil {
+			i.OnEnd(target == 1)
+		}
+	}
+}
+
+// interactiveAnimation records whether it ran to the end, or was cancelled.
+type interactiveAnimation struct {
+	*Basic
+	finished bool
+}
+
+func (a *interactiveAnimation) Tick(t time.Duration) float64 {
+	if t >= a.Dur {
+		a.finished = true
+		return a.End
+	}
+	return a.Basic.Tick(t)
+}
+
+// releaseVelocity is the velocity, in progress per second, above which a
+// released transition continues in the direction it was moving, wherever it
+// was released.
+const releaseVelocity = 0.5
+
+// releaseTarget returns 1 if a transition released at progress with velocity
+// completes, or 0 if it rewinds.
+func releaseTarget(progress, velocity float64) float64 {
+	switch {
+	case velocity > releaseVelocity:
+		return 1
+	case velocity < -releaseVelocity:
+		return 0
+	case progress >= 0.5:
+		return 1
+	}
+	return 0
+}
+
+// releaseAnimation returns an animation from progress to target that starts
+// with velocity if it is moving towards target, and eases out.
+func releaseAnimation(progress, target, velocity float64, duration time.Duration) *Basic {
+	distance := target - progress
+	dur := time.Duration(math.Abs(distance) * float64(duration))
+	if dur == 0 {
+		return &Basic{Start: progress, End: target}
+	}
+
+	// The easing starts with a slope of its first control point, which
+	// matches the velocity relative to the distance and duration.
+	slope := 0.0
+	if distance != 0 && velocity*distance > 0 {
+		slope = velocity * dur.Seconds() / distance
+	}
+	x0 := 0.3
+	y0 := math.Min(slope*x0, 1)
+	return &Basic{
+		Start: progress,
+		End:   target,
+		Ease:  CubicBezier(x0, y0, 0.58, 1),
+		Dur:   dur,
+	}
+}
@@ -0,0 +1,38 @@
+package animate
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReleaseTarget(t *testing.T) {
+	for _, tc := range []struct {
+		progress, velocity, target float64
+	}{
+		{0.2, 0, 0},
+		{0.6, 0, 1},
+		{0.2, 2, 1},
+		{0.9, -2, 0},
+		{0.4, 0.3, 0},
+	} {
+		if target := releaseTarget(tc.progress, tc.velocity); target != tc.target {
+			t.Errorf("releaseTarget(%v, %v) = %v, want %v", tc.progress, tc.velocity, target, tc.target)
+		}
+	}
+}
+
+func TestReleaseAnimation(t *testing.T) {
+	a := releaseAnimation(0.5, 1, 0, 300*time.Millisecond)
+	if a.Dur != 150*time.Millisecond {
+		t.Errorf("duration = %v", a.Dur)
+	}
+	if v := a.Tick(a.Dur); v != 1 {
+		t.Errorf("Tick(%v) = %v, want 1", a.Dur, v)
+	}
+
+	// Moving towards the target, the animation starts faster.
+	fast := releaseAnimation(0.5, 1, 3, 300*time.Millisecond)
+	if fast.Tick(10*time.Millisecond) <= a.Tick(10*time.Millisecond) {
+		t.Errorf("release with velocity is not faster")
+	}
+}
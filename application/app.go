@@ -18,10 +18,10 @@ package application
 
 import (
 	"errors"
-	"runtime"
 
 	"gomatcha.io/matcha/bridge"
 	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/internal/platform"
 	"gomatcha.io/matcha/layout"
 )
 
@@ -32,10 +32,10 @@ import (
 
 func OpenURL(url string) error {
 	success := true
-	if runtime.GOOS == "android" {
-		success = bridge.Bridge("").Call("openURL", bridge.String(url)).ToBool()
+	if platform.UsesJavaStyleBridge() {
+		success = bridge.Bridge("").CallNow("openURL", bridge.String(url)).ToBool()
 	} else {
-		success = bridge.Bridge("").Call("openURL:", bridge.String(url)).ToBool()
+		success = bridge.Bridge("").CallNow("openURL:", bridge.String(url)).ToBool()
 	}
 	if !success {
 		return errors.New("Unable to open URL")
@@ -61,10 +61,10 @@ func orientation(v int) layout.Edge {
 // EdgeBottom is upside down.
 func Orientation() layout.Edge {
 	var o int64
-	if runtime.GOOS == "android" {
-		o = bridge.Bridge("").Call("orientation").ToInt64()
+	if platform.UsesJavaStyleBridge() {
+		o = bridge.Bridge("").CallNow("orientation").ToInt64()
 	} else {
-		o = bridge.Bridge("").Call("orientation").ToInt64()
+		o = bridge.Bridge("").CallNow("orientation").ToInt64()
 	}
 	return orientation(int(o))
 }
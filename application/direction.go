@@ -0,0 +1,36 @@
+package application
+
+import (
+	"sync"
+
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/layout"
+)
+
+var direction struct {
+	mu          sync.Mutex
+	rightToLeft bool
+}
+
+// IsRightToLeft returns whether views with direction d are laid out from
+// right to left. layout.DirectionAuto follows the language of the system's
+// locale, so that Arabic and Hebrew localizations are mirrored.
+func IsRightToLeft(d layout.Direction) bool {
+	switch d {
+	case layout.DirectionLeftToRight:
+		return false
+	case layout.DirectionRightToLeft:
+		return true
+	}
+	direction.mu.Lock()
+	defer direction.mu.Unlock()
+	return direction.rightToLeft
+}
+
+func init() {
+	bridge.RegisterFunc("gomatcha.io/matcha/application SetRightToLeft", func(rightToLeft bool) {
+		direction.mu.Lock()
+		defer direction.mu.Unlock()
+		direction.rightToLeft = rightToLeft
+	})
+}
@@ -0,0 +1,15 @@
+// +build matcha_extension
+
+package application
+
+import "runtime/debug"
+
+// IsExtension is true if the app was built with --ios-extension to run inside
+// an iOS app extension, such as a Today widget or share extension.
+const IsExtension = true
+
+func init() {
+	// Extensions are terminated when they exceed a small memory limit, so
+	// collect garbage more often.
+	debug.SetGCPercent(25)
+}
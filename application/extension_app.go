@@ -0,0 +1,7 @@
+// +build !matcha_extension
+
+package application
+
+// IsExtension is true if the app was built with --ios-extension to run inside
+// an iOS app extension, such as a Today widget or share extension.
+const IsExtension = false
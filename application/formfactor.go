@@ -0,0 +1,126 @@
+package application
+
+import (
+	"runtime"
+	"sync"
+
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/comm"
+)
+
+// FormFactor is the kind of device the app is running on.
+type FormFactor int
+
+const (
+	FormFactorPhone FormFactor = iota
+	FormFactorTablet
+	FormFactorWatch
+	FormFactorTV
+)
+
+// CurrentFormFactor returns the kind of device the app is running on. It is
+// FormFactorPhone on platforms other than Android.
+func CurrentFormFactor() FormFactor {
+	if runtime.GOOS == "android" {
+		return FormFactor(bridge.Bridge("").CallNow("formFactor").ToInt64())
+	}
+	return FormFactorPhone
+}
+
+// IsScreenRound returns true if the app is running on a watch with a round screen.
+func IsScreenRound() bool {
+	if runtime.GOOS == "android" {
+		return bridge.Bridge("").CallNow("isScreenRound").ToBool()
+	}
+	return false
+}
+
+// RoundScreenInset returns the inset from each edge of the screen that keeps
+// content within the screen of a round watch. It is 0 if the screen is not round.
+//
+//  inset := application.RoundScreenInset()
+//  l.Add(child, func(s *constraint.Solver) {
+//      s.Top(inset)
+//      s.Left(inset)
+//      s.RightEqual(l.Right().Add(-inset))
+//  })
+func RoundScreenInset() float64 {
+	if runtime.GOOS == "android" {
+		return bridge.Bridge("").CallNow("roundScreenInset").ToFloat64()
+	}
+	return 0
+}
+
+// NavigationKey is a directional input from a D-pad, TV remote or rotary input.
+type NavigationKey int
+
+const (
+	NavigationUp NavigationKey = iota
+	NavigationDown
+	NavigationLeft
+	NavigationRight
+	NavigationSelect
+	// The crown or bezel of a watch was rotated. NavigationEvent.Delta is
+	// positive when scrolling down.
+	NavigationRotary
+)
+
+// NavigationEvent describes a navigation input.
+type NavigationEvent struct {
+	Key   NavigationKey
+	Delta float64 // Scroll distance of rotary input, in points.
+}
+
+// Navigation posts notifications for D-pad and rotary input. While it has
+// observers the input is delivered to Go, and the platform's own focus
+// navigation is disabled.
+type Navigation struct {
+	mu       sync.Mutex
+	event    NavigationEvent
+	relay    comm.Relay
+	observed int
+}
+
+var navigation Navigation
+
+// NavigationNotifier returns the Navigation of the app.
+func NavigationNotifier() *Navigation {
+	return &navigation
+}
+
+// Notify implements the comm.Notifier interface.
+func (n *Navigation) Notify(f func()) comm.Id {
+	n.mu.Lock()
+	n.observed += 1
+	n.mu.Unlock()
+	return n.relay.Notify(f)
+}
+
+// Unnotify implements the comm.Notifier interface.
+func (n *Navigation) Unnotify(id comm.Id) {
+	n.mu.Lock()
+	n.observed -= 1
+	n.mu.Unlock()
+	n.relay.Unnotify(id)
+}
+
+// Event returns the most recent navigation event.
+func (n *Navigation) Event() NavigationEvent {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.event
+}
+
+func init() {
+	bridge.RegisterFunc("gomatcha.io/matcha/application OnNavigation", func(key int, delta float64) bool {
+		navigation.mu.Lock()
+		if navigation.observed == 0 {
+			navigation.mu.Unlock()
+			return false
+		}
+		navigation.event = NavigationEvent{Key: NavigationKey(key), Delta: delta}
+		navigation.mu.Unlock()
+		navigation.relay.Signal()
+		return true
+	})
+}
@@ -0,0 +1,83 @@
+package application
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gomatcha.io/matcha/bridge"
+)
+
+// MinOS is the minimum operating system version needed by a component. A zero
+// field means that every version of the platform is supported.
+//
+//  var blurMinOS = application.MinOS{IOS: "8.0", Android: 17}
+type MinOS struct {
+	IOS     string // e.g. "11.0"
+	Android int    // API level, e.g. 21
+}
+
+// Supported returns true if the running OS satisfies m. It is always true on
+// platforms other than iOS and Android, and if the OS version is unknown.
+func (m MinOS) Supported() bool {
+	v := OSVersion()
+	if v == "" {
+		return true
+	}
+	switch runtime.GOOS {
+	case "android":
+		return m.Android == 0 || compareVersions(v, strconv.Itoa(m.Android)) >= 0
+	case "darwin":
+		return m.IOS == "" || compareVersions(v, m.IOS) >= 0
+	}
+	return true
+}
+
+// Require declares that the calling package cannot function below m, and should
+// be called from an init function. It returns false if the running OS does not
+// satisfy m, so the package can disable itself. `matcha build` reports the
+// packages that call Require with a version above the default minimum, as they
+// raise the app's effective minimum OS. Packages that have a fallback for older
+// versions should use view.Fallback or MinOS.Supported instead.
+func Require(m MinOS) bool {
+	return m.Supported()
+}
+
+var osVersion struct {
+	once  sync.Once
+	value string
+}
+
+// OSVersion returns the version of the running OS, e.g. "10.3.1" on iOS. On
+// Android it returns the API level, e.g. "21". It returns an empty string on
+// other platforms.
+func OSVersion() string {
+	osVersion.once.Do(func() {
+		if runtime.GOOS == "android" || runtime.GOOS == "darwin" {
+			osVersion.value = bridge.Bridge("").CallNow("osVersion").ToString()
+		}
+	})
+	return osVersion.value
+}
+
+// compareVersions compares two dot separated versions, and returns -1, 0 or 1 if
+// a is less than, equal to or greater than b. Missing components are 0.
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x < y {
+			return -1
+		} else if x > y {
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,22 @@
+package application
+
+import "testing"
+
+func TestCompareVersions(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"10.3", "10.3", 0},
+		{"10.3", "10.3.0", 0},
+		{"10.3.1", "10.3", 1},
+		{"9.0", "10.0", -1},
+		{"11", "10.3", 1},
+		{"21", "23", -1},
+	}
+	for _, i := range cases {
+		if got := compareVersions(i.a, i.b); got != i.want {
+			t.Errorf("compareVersions(%q, %q) = %v, want %v", i.a, i.b, got, i.want)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/gogo/protobuf/proto"
 	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/internal/platform"
 	pb "gomatcha.io/matcha/proto"
 	"gomatcha.io/matcha/proto/env"
 )
@@ -49,10 +50,10 @@ type ImageResource struct {
 // MustLoadImage loads the image at path.
 func LoadImage(path string) (*ImageResource, error) {
 	var propData []byte
-	if runtime.GOOS == "android" {
-		propData = bridge.Bridge("").Call("getPropertiesForResource", bridge.String(path)).ToInterface().([]byte)
+	if platform.UsesJavaStyleBridge() {
+		propData = bridge.Bridge("").CallNow("getPropertiesForResource", bridge.String(path)).ToInterface().([]byte)
 	} else if runtime.GOOS == "darwin" {
-		propData = bridge.Bridge("").Call("propertiesForResource:", bridge.String(path)).ToInterface().([]byte)
+		propData = bridge.Bridge("").CallNow("propertiesForResource:", bridge.String(path)).ToInterface().([]byte)
 	}
 	props := &pb.ImageProperties{}
 	err := proto.Unmarshal(propData, props)
@@ -110,10 +111,10 @@ func (res *ImageResource) Path() string {
 
 func (res *ImageResource) load() {
 	var data []byte
-	if runtime.GOOS == "android" {
-		data = bridge.Bridge("").Call("getImageForResource", bridge.String(res.path)).ToInterface().([]byte)
+	if platform.UsesJavaStyleBridge() {
+		data = bridge.Bridge("").CallNow("getImageForResource", bridge.String(res.path)).ToInterface().([]byte)
 	} else if runtime.GOOS == "darwin" {
-		data = bridge.Bridge("").Call("imageForResource:", bridge.String(res.path)).ToInterface().([]byte)
+		data = bridge.Bridge("").CallNow("imageForResource:", bridge.String(res.path)).ToInterface().([]byte)
 	}
 	reader := bytes.NewReader(data)
 	img, _, err := image.Decode(reader)
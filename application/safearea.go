@@ -0,0 +1,61 @@
+package application
+
+import (
+	"sync"
+
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/layout"
+)
+
+// SafeArea holds the insets from the edges of the screen that content should
+// stay within, to avoid the status bar, notches, the home indicator and
+// display cutouts. It changes as the device rotates.
+type SafeArea struct {
+	mu     sync.Mutex
+	insets layout.Insets
+	relay  comm.Relay
+}
+
+var safeArea SafeArea
+
+// SafeAreaInsets returns the safe area of the app's screen.
+//
+//  insets := application.SafeAreaInsets()
+//  v.Subscribe(insets)
+//  ...
+//  top := insets.Value().Top
+func SafeAreaInsets() *SafeArea {
+	return &safeArea
+}
+
+// Notify implements the comm.Notifier interface.
+func (a *SafeArea) Notify(f func()) comm.Id {
+	return a.relay.Notify(f)
+}
+
+// Unnotify implements the comm.Notifier interface.
+func (a *SafeArea) Unnotify(id comm.Id) {
+	a.relay.Unnotify(id)
+}
+
+// Value returns the current insets.
+func (a *SafeArea) Value() layout.Insets {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.insets
+}
+
+func init() {
+	bridge.RegisterFunc("gomatcha.io/matcha/application SetSafeAreaInsets", func(top, left, bottom, right float64) {
+		insets := layout.Insets{Top: top, Left: left, Bottom: bottom, Right: right}
+		safeArea.mu.Lock()
+		if safeArea.insets == insets {
+			safeArea.mu.Unlock()
+			return
+		}
+		safeArea.insets = insets
+		safeArea.mu.Unlock()
+		safeArea.relay.Signal()
+	})
+}
@@ -0,0 +1,240 @@
+package bridge
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+func init() {
+	RegisterFunc("gomatcha.io/matcha/bridge CallAsync", callAsync)
+}
+
+// Promise is passed as the last argument of a native method called with
+// CallAsync. The method returns immediately, and completes the call later, on
+// any thread, by calling Resolve.
+//
+// Objective-C:
+//  - (void)fetch:(NSString *)url promise:(MatchaGoValue *)promise {
+//      NSURLSessionTask *task = [NSURLSession.sharedSession dataTaskWithURL:[NSURL URLWithString:url] completionHandler:^(NSData *data, NSURLResponse *response, NSError *error) {
+//          [promise call:@"Resolve", [[MatchaGoValue alloc] initWithObject:data], nil];
+//      }];
+//      [promise call:@"OnCancel", [[MatchaGoValue alloc] initWithObject:task], nil];
+//      [task resume];
+//  }
+type Promise struct {
+	mu       sync.Mutex
+	c        chan *Value
+	done     chan struct{}
+	finished bool
+	canceled bool
+	onCancel *Value
+}
+
+func newPromise() *Promise {
+	return &Promise{
+		c:    make(chan *Value, 1),
+		done: make(chan struct{}),
+	}
+}
+
+// Resolve completes the call with v. Only the first call has an effect, and it
+// is ignored if the call was canceled.
+func (p *Promise) Resolve(v *Value) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.finished {
+		return
+	}
+	p.finished = true
+	p.c <- v
+	close(p.c)
+	close(p.done)
+}
+
+// Canceled returns true if the caller canceled the call.
+func (p *Promise) Canceled() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.canceled
+}
+
+// OnCancel sets a native object whose cancel method is called if the caller
+// cancels the call. It is called immediately if the call was already canceled.
+func (p *Promise) OnCancel(v *Value) {
+	p.mu.Lock()
+	canceled := p.canceled
+	p.onCancel = v
+	p.mu.Unlock()
+
+	if canceled {
+		v.Call("cancel")
+	}
+}
+
+func (p *Promise) cancel() {
+	p.mu.Lock()
+	if p.finished {
+		p.mu.Unlock()
+		return
+	}
+	p.finished = true
+	p.canceled = true
+	onCancel := p.onCancel
+	close(p.c)
+	close(p.done)
+	p.mu.Unlock()
+
+	if onCancel != nil {
+		onCancel.Call("cancel")
+	}
+}
+
+// CallAsync calls a native method that completes asynchronously. A *Promise is
+// appended to args, and the result passed to its Resolve method is sent on the
+// returned channel. If ctx is canceled first, the channel is closed without a
+// value and the cancellation is forwarded to the native method.
+//
+//  select {
+//  case data, ok := <-bridge.Bridge("").CallAsync(ctx, "fetch:promise:", bridge.String(url)):
+//      ...
+//  case <-time.After(time.Second):
+//  }
+func (v *Value) CallAsync(ctx context.Context, s string, args ...*Value) <-chan *Value {
+	p := newPromise()
+	args = append(append([]*Value(nil), args...), Interface(p))
+	v.Call(s, args...)
+	if ctx.Done() != nil {
+		go func() {
+			select {
+			case <-ctx.Done():
+				p.cancel()
+			case <-p.done:
+			}
+		}()
+	}
+	return p.c
+}
+
+// Future is the result of a Go function called asynchronously by native code,
+// with -[MatchaGoValue callAsync:] or GoValue.callAsync(). If the function's
+// first parameter is a context.Context, it is canceled when the Future is. The
+// Future is rejected with an error if the method does not exist or the
+// function panics.
+type Future struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu        sync.Mutex
+	finished  bool
+	canceled  bool
+	results   []reflect.Value
+	err       error
+	listeners []*Value
+}
+
+// Cancel cancels the call. Listeners are notified, and the results of the
+// function are discarded.
+func (f *Future) Cancel() {
+	f.cancel()
+	f.finish(true, nil, nil)
+}
+
+// IsDone returns true if the function returned or the call was canceled.
+func (f *Future) IsDone() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.finished
+}
+
+// IsCanceled returns true if the call was canceled.
+func (f *Future) IsCanceled() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.canceled
+}
+
+// Results returns the return values of the function, or nil if it hasn't
+// returned or the call was canceled or rejected.
+func (f *Future) Results() []reflect.Value {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.results
+}
+
+// Err returns the error the call was rejected with, or nil.
+func (f *Future) Err() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.err
+}
+
+// Listen calls the complete method of the native object v once the Future is
+// done, on the goroutine that finished it.
+func (f *Future) Listen(v *Value) {
+	f.mu.Lock()
+	finished := f.finished
+	if !finished {
+		f.listeners = append(f.listeners, v)
+	}
+	f.mu.Unlock()
+
+	if finished {
+		v.Call("complete")
+	}
+}
+
+func (f *Future) finish(canceled bool, results []reflect.Value, err error) {
+	f.mu.Lock()
+	if f.finished {
+		f.mu.Unlock()
+		return
+	}
+	f.finished = true
+	f.canceled = canceled
+	f.results = results
+	f.err = err
+	listeners := f.listeners
+	f.listeners = nil
+	f.mu.Unlock()
+
+	for _, i := range listeners {
+		i.Call("complete")
+	}
+}
+
+func callAsync(v interface{}, method string, args []reflect.Value) *Future {
+	ctx, cancel := context.WithCancel(context.Background())
+	f := &Future{ctx: ctx, cancel: cancel}
+
+	function := reflect.ValueOf(v)
+	if method != "" {
+		function = function.MethodByName(method)
+	} else {
+		function = callable(function)
+	}
+	if !function.IsValid() || function.Kind() != reflect.Func {
+		cancel()
+		f.finish(false, nil, fmt.Errorf("bridge: %T has no method %q", v, method))
+		return f
+	}
+	if t := function.Type(); t.NumIn() > 0 && t.In(0) == contextType {
+		args = append([]reflect.Value{reflect.ValueOf(ctx)}, args...)
+	}
+
+	go func() {
+		defer cancel()
+		defer func() {
+			if r := recover(); r != nil {
+				f.finish(false, nil, WithCode(fmt.Errorf("bridge: async call panicked: %v", r), 0))
+			}
+		}()
+		results := function.Call(args)
+		f.finish(false, results, nil)
+	}()
+	return f
+}
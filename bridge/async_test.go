@@ -0,0 +1,108 @@
+// +build !matcha
+
+package bridge
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestCallAsync(t *testing.T) {
+	var promise *Promise
+	StubCall = func(bridge string, s string, args []*Value) *Value {
+		promise = args[len(args)-1].ToInterface().(*Promise)
+		return nil
+	}
+	defer func() { StubCall = nil }()
+
+	c := Bridge("").CallAsync(context.Background(), "fetch:promise:", String("a"))
+	promise.Resolve(String("b"))
+	promise.Resolve(String("c"))
+	if v, ok := <-c; !ok || v.ToString() != "b" {
+		t.Error("CallAsync", v, ok)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c = Bridge("").CallAsync(ctx, "fetch:promise:", String("a"))
+	cancel()
+	if v, ok := <-c; ok {
+		t.Error("CallAsync after cancel", v)
+	}
+	if !promise.Canceled() {
+		t.Error("Canceled")
+	}
+}
+
+func TestFuture(t *testing.T) {
+	done := make(chan struct{})
+	StubCall = func(bridge string, s string, args []*Value) *Value {
+		if s == "complete" {
+			close(done)
+		}
+		return nil
+	}
+	defer func() { StubCall = nil }()
+
+	f := callAsync(func(a int) int {
+		return a + 1
+	}, "", []reflect.Value{reflect.ValueOf(1)})
+	f.Listen(Bridge("listener"))
+	<-done
+	if r := f.Results(); len(r) != 1 || r[0].Int() != 2 || f.IsCanceled() {
+		t.Error("Results", r, f.IsCanceled())
+	}
+
+	block := make(chan struct{})
+	defer close(block)
+	f = callAsync(func(ctx context.Context) {
+		select {
+		case <-ctx.Done():
+		case <-block:
+		}
+	}, "", nil)
+	f.Cancel()
+	if !f.IsDone() || !f.IsCanceled() || f.Results() != nil {
+		t.Error("Cancel", f.IsDone(), f.IsCanceled(), f.Results())
+	}
+}
+
+func TestCallAsyncArgs(t *testing.T) {
+	StubCall = func(bridge string, s string, args []*Value) *Value {
+		return nil
+	}
+	defer func() { StubCall = nil }()
+
+	a := String("a")
+	args := make([]*Value, 1, 2)
+	args[0] = a
+	Bridge("").CallAsync(context.Background(), "fetch:promise:", args...)
+	if args = args[:2]; args[1] != nil {
+		t.Error("CallAsync wrote into the caller's args", args[1])
+	}
+}
+
+func TestFutureRejected(t *testing.T) {
+	f := callAsync(func() {}, "Missing", nil)
+	if !f.IsDone() || f.IsCanceled() || f.Err() == nil || f.Results() != nil {
+		t.Error("Unknown method", f.IsDone(), f.IsCanceled(), f.Err(), f.Results())
+	}
+
+	done := make(chan struct{})
+	StubCall = func(bridge string, s string, args []*Value) *Value {
+		if s == "complete" {
+			close(done)
+		}
+		return nil
+	}
+	defer func() { StubCall = nil }()
+
+	f = callAsync(func() {
+		panic("boom")
+	}, "", nil)
+	f.Listen(Bridge("listener"))
+	<-done
+	if f.IsCanceled() || f.Err() == nil || f.Results() != nil {
+		t.Error("Panic", f.IsCanceled(), f.Err(), f.Results())
+	}
+}
@@ -0,0 +1,136 @@
+package bridge
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+var batches struct {
+	open int32 // Read without holding mu, so calls are cheap outside of batches.
+	mu   sync.Mutex
+	m    map[int64]*Batch // By goroutine.
+}
+
+// Batch queues the native calls that the goroutine that began it makes with
+// Value.Call, and sends them to the platform in a single invocation when it
+// ends. Queued calls return Nil, so calls whose results are used must be made
+// with Value.CallNow. Calls made by other goroutines are not queued. The root
+// view wraps each of its updates in a batch.
+//
+//  b := bridge.BeginBatch()
+//  defer b.End()
+type Batch struct {
+	goroutine int64
+	calls     []batchCall
+}
+
+type batchCall struct {
+	v    *Value
+	s    string
+	args []*Value
+}
+
+// BeginBatch begins queueing the native calls made by the calling goroutine.
+// If the goroutine already has an open batch, BeginBatch returns nil and the
+// calls are sent when the open batch ends.
+func BeginBatch() *Batch {
+	b := &Batch{goroutine: goroutineId()}
+
+	batches.mu.Lock()
+	defer batches.mu.Unlock()
+	if _, ok := batches.m[b.goroutine]; ok {
+		return nil
+	}
+	if batches.m == nil {
+		batches.m = map[int64]*Batch{}
+	}
+	batches.m[b.goroutine] = b
+	atomic.AddInt32(&batches.open, 1)
+	return b
+}
+
+// End sends the queued calls in order, and stops queueing. It must be called
+// by the goroutine that began b. End does nothing if b is nil.
+func (b *Batch) End() {
+	if b == nil {
+		return
+	}
+	batches.mu.Lock()
+	if batches.m[b.goroutine] == b {
+		delete(batches.m, b.goroutine)
+		atomic.AddInt32(&batches.open, -1)
+	}
+	batches.mu.Unlock()
+
+	b.flush()
+}
+
+func (b *Batch) flush() {
+	calls := b.calls
+	b.calls = nil
+	if len(calls) == 0 {
+		return
+	}
+	if start, ok := traceStart(); ok {
+		size := 0
+		for _, i := range calls {
+			size += traceValuesSize(i.args)
+		}
+		defer traceEnd(start, "bridge.Batch", GoToNative, size)
+	}
+	callBatch(calls)
+}
+
+// CallNow is like Call, but is never queued by a Batch. The calls that were
+// queued before it are sent first. Calls whose results are used, such as
+// measuring text during layout, must be made with CallNow.
+func (v *Value) CallNow(s string, args ...*Value) *Value {
+	flushBatch()
+	return v.callNow(s, args)
+}
+
+// flushBatch sends the calls queued by the open batch of the calling
+// goroutine, if any.
+func flushBatch() {
+	if b := currentBatch(); b != nil {
+		b.flush()
+	}
+}
+
+// queueCall queues the call and returns true if the calling goroutine has an
+// open batch.
+func queueCall(v *Value, s string, args []*Value) bool {
+	b := currentBatch()
+	if b == nil {
+		return false
+	}
+	checkMainThread(s)
+	b.calls = append(b.calls, batchCall{v: v, s: s, args: append([]*Value(nil), args...)})
+	return true
+}
+
+// currentBatch returns the open batch of the calling goroutine, or nil.
+func currentBatch() *Batch {
+	if atomic.LoadInt32(&batches.open) == 0 {
+		return nil
+	}
+	g := goroutineId()
+	batches.mu.Lock()
+	defer batches.mu.Unlock()
+	return batches.m[g]
+}
+
+// goroutineId returns the id of the calling goroutine, parsed from the
+// "goroutine 1 [running]:" header of its stack trace.
+func goroutineId() int64 {
+	buf := make([]byte, 64)
+	buf = bytes.TrimPrefix(buf[:runtime.Stack(buf, false)], []byte("goroutine "))
+	if i := bytes.IndexByte(buf, ' '); i >= 0 {
+		buf = buf[:i]
+	}
+	id, _ := strconv.ParseInt(string(buf), 10, 64)
+	return id
+}
@@ -0,0 +1,74 @@
+// +build !matcha
+
+package bridge
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBatch(t *testing.T) {
+	calls := []string{}
+	var bArg int64
+	StubCall = func(bridge string, s string, args []*Value) *Value {
+		calls = append(calls, s)
+		if s == "b" {
+			bArg = args[0].ToInt64()
+		}
+		return String("rlt")
+	}
+	flushes := []int{}
+	StubBatch = func(n int) {
+		flushes = append(flushes, n)
+	}
+	defer func() {
+		StubCall = nil
+		StubBatch = nil
+	}()
+
+	b := BeginBatch()
+	if BeginBatch() != nil {
+		t.Error("BeginBatch returned a second batch for the goroutine")
+	}
+	if v := Bridge("").Call("a"); v != nil {
+		t.Errorf("queued call returned %v", v)
+	}
+	args := []*Value{Int64(1)}
+	Bridge("").Call("b", args...)
+	args[0] = Int64(2)
+
+	// Calls made by other goroutines are not queued.
+	done := make(chan struct{})
+	go func() {
+		Bridge("").Call("other")
+		close(done)
+	}()
+	<-done
+	if want := []string{"other"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls before flushing = %v, want %v", calls, want)
+	}
+
+	// CallNow sends the queued calls first.
+	if v := Bridge("").CallNow("measure"); v.ToString() != "rlt" {
+		t.Errorf("CallNow returned %v", v)
+	}
+	Bridge("").Call("c")
+	b.End()
+	if want := []string{"other", "a", "b", "measure", "c"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+	if want := []int{2, 1}; !reflect.DeepEqual(flushes, want) {
+		t.Errorf("flushes = %v, want %v", flushes, want)
+	}
+	if bArg != 1 {
+		t.Errorf("queued call got arg %v, want 1", bArg)
+	}
+
+	// Calls after the batch ends are made immediately.
+	calls = nil
+	Bridge("").Call("d")
+	(*Batch)(nil).End()
+	if want := []string{"d"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls after ending = %v, want %v", calls, want)
+	}
+}
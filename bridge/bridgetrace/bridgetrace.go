@@ -0,0 +1,38 @@
+// Package bridgetrace serves the calls recorded by bridge.StartTrace over HTTP,
+// where `matcha trace dump` reads them. It is kept out of the bridge package so
+// that apps that don't trace don't link net/http.
+//
+//  func init() {
+//      bridge.StartTrace(10000)
+//      go bridgetrace.ListenAndServe("localhost:6062")
+//  }
+//
+// On Android, forward the port first with `adb forward tcp:6062 tcp:6062`, or
+// pass --adb to `matcha trace dump`.
+package bridgetrace
+
+import (
+	"net/http"
+
+	"gomatcha.io/matcha/bridge"
+)
+
+// Path is the path the trace is served at.
+const Path = "/debug/matcha/trace"
+
+// Handler returns a handler that writes the recorded events as JSON.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := bridge.WriteTrace(w); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// ListenAndServe serves Handler at Path on addr.
+func ListenAndServe(addr string) error {
+	mux := http.NewServeMux()
+	mux.Handle(Path, Handler())
+	return http.ListenAndServe(addr, mux)
+}
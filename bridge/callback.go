@@ -0,0 +1,183 @@
+package bridge
+
+import (
+	"context"
+	"reflect"
+	"sync"
+)
+
+func init() {
+	RegisterFunc("gomatcha.io/matcha/bridge NativeCallback", NativeCallback)
+}
+
+// Callback is a function passed across the bridge whose lifetime is managed
+// explicitly. Either side may close it, after which calls are ignored, its
+// context is canceled, and the objects it holds on the other side are released.
+//
+// A callback created with NewCallback wraps a Go function for native code, which
+// calls it like a function and may register an object to be told when Go closes
+// it. A callback created with NativeCallback wraps a method of a native object
+// for Go code. The view package closes callbacks created with view.NewCallback
+// when their view unmounts.
+//
+// Go:
+//  cb := bridge.NewCallback(func(ctx context.Context, data []byte) { ... })
+//  bridge.Bridge("").Call("observe:", bridge.Interface(cb))
+//
+// Objective-C:
+//  - (void)observe:(MatchaGoValue *)callback {
+//      self.callback = callback;
+//      [callback call:@"OnClose", [[MatchaGoValue alloc] initWithObject:self], nil];
+//  }
+//  - (void)sensorChanged:(NSData *)data {
+//      [self.callback call:nil, [[MatchaGoValue alloc] initWithData:data], nil];
+//  }
+//  - (void)cancel {
+//      self.callback = nil;
+//  }
+//  - (void)stop {
+//      [self.callback call:@"Close", nil];
+//  }
+type Callback struct {
+	fn     reflect.Value // Wraps the Go function, for callbacks created with NewCallback.
+	native *Value        // For callbacks created with NativeCallback.
+	method string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	closed  bool
+	onClose []*Value
+}
+
+// NewCallback wraps the Go function f in a callback for native code. If the
+// first parameter of f is a context.Context, it receives the callback's context,
+// and native code passes the remaining arguments. Once the callback is closed,
+// calls return zero values without calling f.
+func NewCallback(f interface{}) *Callback {
+	c := newCallback()
+	fn := reflect.ValueOf(f)
+	t := fn.Type()
+	if t.Kind() != reflect.Func {
+		panic("bridge: NewCallback of non-func " + t.String())
+	}
+	passCtx := t.NumIn() > 0 && t.In(0) == contextType
+
+	in := []reflect.Type{}
+	for i := 0; i < t.NumIn(); i++ {
+		if i == 0 && passCtx {
+			continue
+		}
+		in = append(in, t.In(i))
+	}
+	out := []reflect.Type{}
+	for i := 0; i < t.NumOut(); i++ {
+		out = append(out, t.Out(i))
+	}
+	c.fn = reflect.MakeFunc(reflect.FuncOf(in, out, t.IsVariadic()), func(args []reflect.Value) []reflect.Value {
+		if c.Closed() {
+			rlt := make([]reflect.Value, len(out))
+			for i, o := range out {
+				rlt[i] = reflect.Zero(o)
+			}
+			return rlt
+		}
+		if passCtx {
+			args = append([]reflect.Value{reflect.ValueOf(c.ctx)}, args...)
+		}
+		if t.IsVariadic() {
+			return fn.CallSlice(args)
+		}
+		return fn.Call(args)
+	})
+	return c
+}
+
+// NativeCallback wraps method of the native object v in a callback for Go code.
+// The callback holds v until it is closed. It is called by native code, which
+// passes it to Go with [[MatchaGoValue alloc] initWithFunc:@"gomatcha.io/matcha/bridge NativeCallback"]
+// or GoValue.withFunc on Android.
+func NativeCallback(v *Value, method string) *Callback {
+	c := newCallback()
+	c.native = v
+	c.method = method
+	return c
+}
+
+func newCallback() *Callback {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Callback{ctx: ctx, cancel: cancel}
+}
+
+// Call calls the native method wrapped by the callback with args. It returns Nil
+// if the callback is closed, if it was created with NewCallback, or if the call
+// is queued by a Batch.
+func (c *Callback) Call(args ...*Value) *Value {
+	c.mu.Lock()
+	v := c.native
+	c.mu.Unlock()
+
+	if v == nil {
+		return Nil()
+	}
+	return v.Call(c.method, args...)
+}
+
+// Close invalidates the callback, cancels its context, calls the cancel method of
+// the objects registered with OnClose, and releases the native object it wraps.
+// Closing a closed callback has no effect.
+func (c *Callback) Close() {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return
+	}
+	c.closed = true
+	onClose := c.onClose
+	c.onClose = nil
+	c.native = nil
+	c.mu.Unlock()
+
+	c.cancel()
+	for _, i := range onClose {
+		i.Call("cancel")
+	}
+}
+
+// Closed returns true if the callback was closed.
+func (c *Callback) Closed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+// Context returns a context that is canceled when the callback is closed.
+func (c *Callback) Context() context.Context {
+	return c.ctx
+}
+
+// OnClose registers a native object whose cancel method is called when the
+// callback is closed. It is called immediately if the callback is already closed.
+func (c *Callback) OnClose(v *Value) {
+	c.mu.Lock()
+	closed := c.closed
+	if !closed {
+		c.onClose = append(c.onClose, v)
+	}
+	c.mu.Unlock()
+
+	if closed {
+		v.Call("cancel")
+	}
+}
+
+// callable returns the function called when native code calls rv directly.
+func callable(rv reflect.Value) reflect.Value {
+	if rv.IsValid() && rv.CanInterface() {
+		if c, ok := rv.Interface().(*Callback); ok && c.fn.IsValid() {
+			return c.fn
+		}
+	}
+	return rv
+}
@@ -0,0 +1,52 @@
+// +build !matcha
+
+package bridge
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestCallback(t *testing.T) {
+	calls := []string{}
+	StubCall = func(bridge string, s string, args []*Value) *Value {
+		calls = append(calls, bridge+" "+s)
+		return String("rlt")
+	}
+	defer func() { StubCall = nil }()
+
+	var ctx context.Context
+	c := NewCallback(func(c context.Context, a int) int {
+		ctx = c
+		return a + 1
+	})
+	c.OnClose(Bridge("listener"))
+	f := callable(reflect.ValueOf(c))
+	if rlt := f.Call([]reflect.Value{reflect.ValueOf(1)}); rlt[0].Int() != 2 || ctx != c.Context() {
+		t.Errorf("Call = %v", rlt[0])
+	}
+
+	c.Close()
+	c.Close()
+	if !c.Closed() || c.Context().Err() == nil {
+		t.Error("Close didn't cancel the context")
+	}
+	if rlt := f.Call([]reflect.Value{reflect.ValueOf(1)}); rlt[0].Int() != 0 {
+		t.Errorf("Call after Close = %v", rlt[0])
+	}
+	c.OnClose(Bridge("late"))
+	if want := []string{"listener cancel", "late cancel"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+
+	calls = nil
+	n := NativeCallback(Bridge("native"), "changed:")
+	if v := n.Call(String("a")); v.ToString() != "rlt" {
+		t.Errorf("NativeCallback Call = %v", v)
+	}
+	n.Close()
+	if v := n.Call(String("a")); v != Nil() || len(calls) != 1 {
+		t.Errorf("NativeCallback Call after Close = %v, %v", v, calls)
+	}
+}
@@ -0,0 +1,112 @@
+package bridge
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime/debug"
+)
+
+func init() {
+	RegisterFunc("gomatcha.io/matcha/bridge ErrorJSON", errorJSON)
+}
+
+// Error is a Go error as seen by native code. When the last result of a Go
+// function called with -[MatchaGoValue tryCall:arguments:error:] or
+// GoValue.callOrThrow() is a non-nil error, it is converted to an Error and
+// surfaced as an NSError or a GoException.
+type Error struct {
+	Code    int      `json:"code"`
+	Message string   `json:"message"`
+	Chain   []string `json:"chain"` // Messages of the error and the errors it wraps, outermost first.
+	Stack   string   `json:"stack"` // Stack trace of the goroutine that called WithCode, if any.
+	err     error
+}
+
+// WithCode wraps err with an error code, and records the current stack trace.
+// It returns nil if err is nil.
+//
+//  if resp.StatusCode == 404 {
+//      return nil, bridge.WithCode(fmt.Errorf("%v not found", url), 404)
+//  }
+func WithCode(err error, code int) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{
+		Code:    code,
+		Message: err.Error(),
+		Stack:   string(debug.Stack()),
+		err:     err,
+	}
+}
+
+// NewError converts err into an Error. The chain is followed through Unwrap()
+// and Cause() methods, and the code and stack trace are taken from the
+// outermost Error in it. If no Error in the chain has a stack trace, the
+// current one is recorded. It returns nil if err is nil.
+func NewError(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	e := &Error{Message: err.Error(), err: err}
+	for i := err; i != nil; i = unwrap(i) {
+		if be, ok := i.(*Error); ok {
+			if e.Stack == "" {
+				e.Code = be.Code
+				e.Stack = be.Stack
+			}
+			continue
+		}
+		e.Chain = append(e.Chain, i.Error())
+	}
+	if e.Stack == "" {
+		e.Stack = string(debug.Stack())
+	}
+	return e
+}
+
+// CallError is returned by Value.TryCall when the native method throws an
+// exception.
+type CallError struct {
+	Selector string
+	Message  string // Description of the exception.
+}
+
+// Error implements the error interface.
+func (e *CallError) Error() string {
+	return fmt.Sprintf("bridge: %v threw %v", e.Selector, e.Message)
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the wrapped error.
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+func unwrap(err error) error {
+	switch err := err.(type) {
+	case interface{ Unwrap() error }:
+		return err.Unwrap()
+	case interface{ Cause() error }:
+		return err.Cause()
+	}
+	return nil
+}
+
+// errorJSON returns the JSON encoding of v as an Error if it is a non-nil error,
+// and nil otherwise. Native code calls it on the last result of a function.
+func errorJSON(v interface{}) []byte {
+	err, ok := v.(error)
+	if !ok || err == nil {
+		return nil
+	}
+	data, err := json.Marshal(NewError(err))
+	if err != nil {
+		return nil
+	}
+	return data
+}
@@ -0,0 +1,71 @@
+// +build !matcha
+
+package bridge
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type wrapped struct {
+	msg string
+	err error
+}
+
+func (w wrapped) Error() string { return w.msg + ": " + w.err.Error() }
+func (w wrapped) Cause() error  { return w.err }
+
+func TestNewError(t *testing.T) {
+	if NewError(nil) != nil || WithCode(nil, 1) != nil {
+		t.Error("nil")
+	}
+
+	err := wrapped{"fetch", WithCode(errors.New("not found"), 404)}
+	e := NewError(err)
+	if e.Code != 404 || e.Message != "fetch: not found" {
+		t.Error("NewError", e.Code, e.Message)
+	}
+	if len(e.Chain) != 2 || e.Chain[1] != "not found" {
+		t.Error("Chain", e.Chain)
+	}
+	if !strings.Contains(e.Stack, "TestNewError") {
+		t.Error("Stack", e.Stack)
+	}
+
+	e2 := &Error{}
+	if err := json.Unmarshal(errorJSON(err), e2); err != nil || e2.Code != 404 || e2.Message != e.Message {
+		t.Error("errorJSON", err, e2)
+	}
+	if errorJSON(nil) != nil || errorJSON(fmt.Sprint("a")) != nil {
+		t.Error("errorJSON of non-error")
+	}
+}
+
+func TestNewErrorStack(t *testing.T) {
+	e := NewError(errors.New("plain"))
+	if !strings.Contains(e.Stack, "TestNewErrorStack") {
+		t.Error("Stack", e.Stack)
+	}
+}
+
+func TestTryCall(t *testing.T) {
+	StubCall = func(bridge string, s string, args []*Value) *Value {
+		if s == "fail" {
+			panic("NSInvalidArgumentException: unrecognized selector")
+		}
+		return String("ok")
+	}
+	defer func() { StubCall = nil }()
+
+	if v, err := Bridge("").TryCall("succeed"); err != nil || v.ToString() != "ok" {
+		t.Error("TryCall", v, err)
+	}
+	v, err := Bridge("").TryCall("fail")
+	e, ok := err.(*CallError)
+	if v != nil || !ok || e.Selector != "fail" || !strings.Contains(e.Message, "unrecognized selector") {
+		t.Error("TryCall error", v, err)
+	}
+}
@@ -0,0 +1,154 @@
+package bridge
+
+import (
+	"fmt"
+	"io"
+	"runtime/debug"
+	"sort"
+	"sync/atomic"
+	"text/tabwriter"
+)
+
+var leakTracking int32
+
+// LeakObject is an object referenced across the bridge.
+type LeakObject struct {
+	Foreign bool   // True for native objects referenced by Go, false for Go values referenced by native code.
+	Ref     int64  `json:"ref"`
+	Type    string `json:"type"`  // Class name of native objects, or Go type.
+	Stack   string `json:"stack"` // Allocation stack, if the object was tracked while leak tracking was enabled.
+}
+
+// LeakSnapshot is the set of objects referenced across the bridge at a point in
+// time.
+type LeakSnapshot struct {
+	Objects []LeakObject
+}
+
+// Leak is a group of objects of the same type allocated from the same stack.
+type Leak struct {
+	Foreign bool
+	Type    string
+	Stack   string
+	Count   int
+}
+
+// SetLeakTracking enables or disables recording the allocation stacks of the
+// objects referenced across the bridge, by the Go tracker and by the native
+// trackers. Stacks are only recorded for objects tracked while it is enabled.
+// Recording stacks is slow, and is meant for debugging.
+func SetLeakTracking(enabled bool) {
+	v := int32(0)
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&leakTracking, v)
+	setForeignLeakTracking(enabled)
+}
+
+// TakeLeakSnapshot returns the objects currently referenced across the bridge.
+func TakeLeakSnapshot() *LeakSnapshot {
+	objects := goLeakObjects()
+	for _, i := range foreignLeakObjects() {
+		i.Foreign = true
+		objects = append(objects, i)
+	}
+	return &LeakSnapshot{Objects: objects}
+}
+
+// LeakReport returns the objects of after that aren't in before, grouped by type
+// and allocation stack, most frequent first. Objects that are created and never
+// released between two snapshots, such as views and callbacks kept alive by a
+// reference cycle through native code, show up in every report.
+//
+//  before := bridge.TakeLeakSnapshot()
+//  // Push and pop a view controller.
+//  for _, i := range bridge.LeakReport(before, bridge.TakeLeakSnapshot()) {
+//      fmt.Println(i.Count, i.Type, i.Stack)
+//  }
+func LeakReport(before, after *LeakSnapshot) []Leak {
+	type key struct {
+		foreign bool
+		ref     int64
+		typ     string
+		stack   string
+	}
+	existing := map[key]bool{}
+	if before != nil {
+		for _, i := range before.Objects {
+			existing[key{i.Foreign, i.Ref, i.Type, i.Stack}] = true
+		}
+	}
+
+	type group struct {
+		foreign bool
+		typ     string
+		stack   string
+	}
+	m := map[group]*Leak{}
+	for _, i := range after.Objects {
+		if existing[key{i.Foreign, i.Ref, i.Type, i.Stack}] {
+			continue
+		}
+		g := group{i.Foreign, i.Type, i.Stack}
+		l, ok := m[g]
+		if !ok {
+			l = &Leak{Foreign: i.Foreign, Type: i.Type, Stack: i.Stack}
+			m[g] = l
+		}
+		l.Count++
+	}
+
+	leaks := make([]Leak, 0, len(m))
+	for _, i := range m {
+		leaks = append(leaks, *i)
+	}
+	sort.Slice(leaks, func(i, j int) bool {
+		a, b := leaks[i], leaks[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Stack < b.Stack
+	})
+	return leaks
+}
+
+// WriteLeakReport writes leaks to w as a table, followed by their allocation
+// stacks.
+func WriteLeakReport(w io.Writer, leaks []Leak) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "COUNT\tSIDE\tTYPE")
+	for _, i := range leaks {
+		fmt.Fprintf(tw, "%v\t%v\t%v\n", i.Count, leakSide(i.Foreign), i.Type)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	for _, i := range leaks {
+		if i.Stack == "" {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "\n%v %v %v:\n%v\n", i.Count, leakSide(i.Foreign), i.Type, i.Stack); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func leakSide(foreign bool) string {
+	if foreign {
+		return "native"
+	}
+	return "go"
+}
+
+// leakStack returns the stack of the caller if leak tracking is enabled.
+func leakStack() (string, bool) {
+	if atomic.LoadInt32(&leakTracking) == 0 {
+		return "", false
+	}
+	return string(debug.Stack()), true
+}
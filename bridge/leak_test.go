@@ -0,0 +1,34 @@
+// +build !matcha
+
+package bridge
+
+import "testing"
+
+func TestLeakReport(t *testing.T) {
+	before := &LeakSnapshot{Objects: []LeakObject{
+		{Foreign: true, Ref: 1, Type: "UIView"},
+		{Ref: -1, Type: "func()"},
+	}}
+	after := &LeakSnapshot{Objects: []LeakObject{
+		{Foreign: true, Ref: 1, Type: "UIView"},
+		{Foreign: true, Ref: 2, Type: "UIView", Stack: "a"},
+		{Foreign: true, Ref: 3, Type: "UIView", Stack: "a"},
+		{Foreign: true, Ref: 4, Type: "NSString"},
+		{Ref: -2, Type: "func()"},
+	}}
+
+	leaks := LeakReport(before, after)
+	want := []Leak{
+		{Foreign: true, Type: "UIView", Stack: "a", Count: 2},
+		{Foreign: true, Type: "NSString", Count: 1},
+		{Type: "func()", Count: 1},
+	}
+	if len(leaks) != len(want) {
+		t.Fatalf("got %v, want %v", leaks, want)
+	}
+	for i := range want {
+		if leaks[i] != want[i] {
+			t.Errorf("leaks[%v] = %v, want %v", i, leaks[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package bridge
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+var mainThread struct {
+	checks    int32 // Read without holding mu, so calls are cheap while checks are off.
+	mu        sync.RWMutex
+	selectors map[string]bool
+}
+
+// RunOnMain calls f asynchronously on the main thread. The UIKit, Android and
+// desktop views must only be mutated from the main thread, so native calls that
+// touch them from a goroutine should be wrapped in RunOnMain. If the platform
+// can't dispatch to the main thread, f is called on a new goroutine.
+//
+//  go func() {
+//      data := fetch()
+//      bridge.RunOnMain(func() {
+//          bridge.Bridge("").Call("reload:", bridge.Bytes(data))
+//      })
+//  }()
+func RunOnMain(f func()) {
+	if !runOnMain(f) {
+		go f()
+	}
+}
+
+// IsMainThread returns true if called from the main thread of the app.
+func IsMainThread() bool {
+	return isMainThread()
+}
+
+// MainThreadOnly registers selectors of native methods that mutate views. While
+// main thread checks are enabled, calling one of them with Value.Call off the
+// main thread panics.
+func MainThreadOnly(selectors ...string) {
+	mainThread.mu.Lock()
+	defer mainThread.mu.Unlock()
+	if mainThread.selectors == nil {
+		mainThread.selectors = map[string]bool{}
+	}
+	for _, i := range selectors {
+		mainThread.selectors[i] = true
+	}
+}
+
+// SetMainThreadChecks enables or disables the panics of MainThreadOnly. Checks
+// are enabled by default in apps built with `matcha build --debug`.
+func SetMainThreadChecks(enabled bool) {
+	v := int32(0)
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&mainThread.checks, v)
+}
+
+// checkMainThread panics if the selector s is registered with MainThreadOnly and
+// is called off the main thread.
+func checkMainThread(s string) {
+	if atomic.LoadInt32(&mainThread.checks) == 0 {
+		return
+	}
+	mainThread.mu.RLock()
+	ok := mainThread.selectors[s]
+	mainThread.mu.RUnlock()
+	if ok && !isMainThread() {
+		panic(fmt.Sprintf("bridge: %v called off the main thread, use bridge.RunOnMain", s))
+	}
+}
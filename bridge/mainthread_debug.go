@@ -0,0 +1,7 @@
+// +build matcha_debug
+
+package bridge
+
+func init() {
+	SetMainThreadChecks(true)
+}
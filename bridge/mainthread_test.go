@@ -0,0 +1,45 @@
+// +build !matcha
+
+package bridge
+
+import "testing"
+
+func TestMainThreadChecks(t *testing.T) {
+	defer func() { stubMainThread = true }()
+	defer SetMainThreadChecks(false)
+
+	MainThreadOnly("testMutateView:")
+	SetMainThreadChecks(true)
+	stubMainThread = false
+
+	panics := func(s string) (ok bool) {
+		defer func() { ok = recover() != nil }()
+		checkMainThread(s)
+		return false
+	}
+	if !panics("testMutateView:") {
+		t.Error("expected a panic for a registered selector off the main thread")
+	}
+	if panics("testOther:") {
+		t.Error("unexpected panic for an unregistered selector")
+	}
+
+	stubMainThread = true
+	if panics("testMutateView:") {
+		t.Error("unexpected panic on the main thread")
+	}
+
+	stubMainThread = false
+	SetMainThreadChecks(false)
+	if panics("testMutateView:") {
+		t.Error("unexpected panic with checks disabled")
+	}
+}
+
+func TestRunOnMain(t *testing.T) {
+	called := false
+	RunOnMain(func() { called = true })
+	if !called {
+		t.Error("expected RunOnMain to call f")
+	}
+}
@@ -0,0 +1,286 @@
+package bridge
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// MaxMarshalDepth is the deepest nesting of maps, slices and structs that
+// Marshal and Unmarshal accept.
+const MaxMarshalDepth = 32
+
+var valueType = reflect.TypeOf((*Value)(nil))
+
+// Marshal converts v into a native value. Booleans, numbers, strings and byte
+// slices become NSNumbers, NSStrings and NSData on iOS, and their Java
+// equivalents on Android. Slices and arrays become an NSArray or a
+// java.util.List, and maps with string keys and structs become an NSDictionary
+// or a java.util.Map. Pointers are followed, nil becomes nil, and *Values are
+// passed through.
+//
+// Struct fields are keyed by their name, or by the name in their matcha tag.
+// Unexported fields and fields tagged "-" are skipped, and fields tagged
+// "omitempty" are skipped if they have their zero value.
+//
+//  type Contact struct {
+//      Name   string   `matcha:"name"`
+//      Phones []string `matcha:"phones,omitempty"`
+//      cache  []byte
+//  }
+//
+// Marshal returns an error if v contains a cycle, is nested deeper than
+// MaxMarshalDepth, or contains a type that has no native equivalent, such as a
+// channel or a function.
+func Marshal(v interface{}) (*Value, error) {
+	m := &marshaler{visited: map[visit]bool{}}
+	return m.marshal(reflect.ValueOf(v), 0)
+}
+
+// Unmarshal converts the native value v into the value pointed to by ptr, using
+// the inverse of the rules of Marshal. Values of type *Value are set to the
+// native value itself.
+func Unmarshal(v *Value, ptr interface{}) error {
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("bridge: Unmarshal of non-pointer %T", ptr)
+	}
+	return unmarshal(v, rv.Elem(), 0)
+}
+
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+type marshaler struct {
+	visited map[visit]bool // The pointers, maps and slices being marshaled.
+}
+
+func (m *marshaler) marshal(v reflect.Value, depth int) (*Value, error) {
+	if depth > MaxMarshalDepth {
+		return nil, fmt.Errorf("bridge: Marshal exceeded the maximum depth of %v", MaxMarshalDepth)
+	}
+	if !v.IsValid() {
+		return Nil(), nil
+	}
+	if v.Type() == valueType {
+		if v.IsNil() {
+			return Nil(), nil
+		}
+		return v.Interface().(*Value), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			return Nil(), nil
+		}
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			return Bytes(v.Bytes()), nil
+		}
+		key := visit{v.Pointer(), v.Type()}
+		if m.visited[key] {
+			return nil, fmt.Errorf("bridge: Marshal encountered a cycle through %v", v.Type())
+		}
+		m.visited[key] = true
+		defer delete(m.visited, key)
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return Bool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Int64(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return Int64(int64(v.Uint())), nil
+	case reflect.Float32, reflect.Float64:
+		return Float64(v.Float()), nil
+	case reflect.String:
+		return String(v.String()), nil
+	case reflect.Interface, reflect.Ptr:
+		if v.IsNil() {
+			return Nil(), nil
+		}
+		return m.marshal(v.Elem(), depth)
+	case reflect.Slice, reflect.Array:
+		elems := make([]*Value, v.Len())
+		for i := range elems {
+			elem, err := m.marshal(v.Index(i), depth+1)
+			if err != nil {
+				return nil, err
+			}
+			elems[i] = elem
+		}
+		return list(Array(elems...)), nil
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("bridge: Marshal of map with %v keys", v.Type().Key())
+		}
+		entries := make(map[string]*Value, v.Len())
+		for _, k := range v.MapKeys() {
+			elem, err := m.marshal(v.MapIndex(k), depth+1)
+			if err != nil {
+				return nil, err
+			}
+			entries[k.String()] = elem
+		}
+		return Map(entries), nil
+	case reflect.Struct:
+		entries := map[string]*Value{}
+		for _, f := range structFields(v.Type()) {
+			fv := v.Field(f.index)
+			if f.omitEmpty && isEmpty(fv) {
+				continue
+			}
+			elem, err := m.marshal(fv, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			entries[f.key] = elem
+		}
+		return Map(entries), nil
+	}
+	return nil, fmt.Errorf("bridge: Marshal of unsupported type %v", v.Type())
+}
+
+func unmarshal(v *Value, rv reflect.Value, depth int) error {
+	if depth > MaxMarshalDepth {
+		return fmt.Errorf("bridge: Unmarshal exceeded the maximum depth of %v", MaxMarshalDepth)
+	}
+	if rv.Type() == valueType {
+		rv.Set(reflect.ValueOf(v))
+		return nil
+	}
+	if v == nil || v.IsNil() {
+		rv.Set(reflect.Zero(rv.Type()))
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		rv.SetBool(v.ToBool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := v.ToInt64()
+		if rv.OverflowInt(i) {
+			return fmt.Errorf("bridge: Unmarshal of %v overflows %v", i, rv.Type())
+		}
+		rv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		i := v.ToInt64()
+		if i < 0 || rv.OverflowUint(uint64(i)) {
+			return fmt.Errorf("bridge: Unmarshal of %v overflows %v", i, rv.Type())
+		}
+		rv.SetUint(uint64(i))
+	case reflect.Float32, reflect.Float64:
+		rv.SetFloat(v.ToFloat64())
+	case reflect.String:
+		rv.SetString(v.ToString())
+	case reflect.Ptr:
+		elem := reflect.New(rv.Type().Elem())
+		if err := unmarshal(v, elem.Elem(), depth); err != nil {
+			return err
+		}
+		rv.Set(elem)
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			rv.SetBytes(v.ToBytes())
+			return nil
+		}
+		elems := v.ToArray()
+		slice := reflect.MakeSlice(rv.Type(), len(elems), len(elems))
+		for i, elem := range elems {
+			if err := unmarshal(elem, slice.Index(i), depth+1); err != nil {
+				return err
+			}
+		}
+		rv.Set(slice)
+	case reflect.Array:
+		elems := v.ToArray()
+		if len(elems) != rv.Len() {
+			return fmt.Errorf("bridge: Unmarshal of %v elements into %v", len(elems), rv.Type())
+		}
+		for i, elem := range elems {
+			if err := unmarshal(elem, rv.Index(i), depth+1); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("bridge: Unmarshal into map with %v keys", rv.Type().Key())
+		}
+		entries := v.ToMap()
+		m := reflect.MakeMapWithSize(rv.Type(), len(entries))
+		for k, elem := range entries {
+			ev := reflect.New(rv.Type().Elem()).Elem()
+			if err := unmarshal(elem, ev, depth+1); err != nil {
+				return err
+			}
+			m.SetMapIndex(reflect.ValueOf(k).Convert(rv.Type().Key()), ev)
+		}
+		rv.Set(m)
+	case reflect.Struct:
+		entries := v.ToMap()
+		for _, f := range structFields(rv.Type()) {
+			elem, ok := entries[f.key]
+			if !ok {
+				continue
+			}
+			if err := unmarshal(elem, rv.Field(f.index), depth+1); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("bridge: Unmarshal into unsupported type %v", rv.Type())
+	}
+	return nil
+}
+
+type field struct {
+	index     int
+	key       string
+	omitEmpty bool
+}
+
+func structFields(t reflect.Type) []field {
+	fields := []field{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		tag := strings.Split(f.Tag.Get("matcha"), ",")
+		if tag[0] == "-" {
+			continue
+		}
+		fl := field{index: i, key: f.Name}
+		if tag[0] != "" {
+			fl.key = tag[0]
+		}
+		for _, opt := range tag[1:] {
+			if opt == "omitempty" {
+				fl.omitEmpty = true
+			}
+		}
+		fields = append(fields, fl)
+	}
+	return fields
+}
+
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+// +build !matcha
+
+package bridge
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type contact struct {
+	Name    string         `matcha:"name"`
+	Phones  []string       `matcha:"phones,omitempty"`
+	Age     uint8          `matcha:"age"`
+	Tags    map[string]int `matcha:"tags"`
+	Friend  *contact       `matcha:"friend"`
+	Skip    string         `matcha:"-"`
+	private string
+	Extra   map[string]*Value `matcha:"extra,omitempty"`
+}
+
+func TestMarshal(t *testing.T) {
+	c := &contact{
+		Name:    "a",
+		Age:     3,
+		Tags:    map[string]int{"x": 1},
+		Friend:  &contact{Name: "b", Phones: []string{"1", "2"}},
+		Skip:    "skip",
+		private: "private",
+	}
+	v, err := Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := v.ToMap()
+	if m["name"].ToString() != "a" || m["age"].ToInt64() != 3 || !m["friend"].ToMap()["friend"].IsNil() {
+		t.Error("Marshal", m)
+	}
+	if _, ok := m["phones"]; ok {
+		t.Error("omitempty")
+	}
+	if _, ok := m["Skip"]; ok {
+		t.Error("-")
+	}
+
+	c2 := &contact{}
+	if err := Unmarshal(v, c2); err != nil {
+		t.Fatal(err)
+	}
+	c.Skip, c.private = "", ""
+	if !reflect.DeepEqual(c, c2) {
+		t.Errorf("Unmarshal %+v %+v", c2, c2.Friend)
+	}
+
+	if err := Unmarshal(Int64(256), &c2.Age); err == nil {
+		t.Error("overflow")
+	}
+}
+
+func TestMarshalCycle(t *testing.T) {
+	c := &contact{}
+	c.Friend = c
+	if _, err := Marshal(c); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Error("cycle", err)
+	}
+
+	// Shared, acyclic values are fine.
+	shared := &contact{Name: "s"}
+	if _, err := Marshal([]*contact{shared, shared}); err != nil {
+		t.Error(err)
+	}
+
+	var deep interface{} = 1
+	for i := 0; i < MaxMarshalDepth+1; i++ {
+		deep = []interface{}{deep}
+	}
+	if _, err := Marshal(deep); err == nil || !strings.Contains(err.Error(), "depth") {
+		t.Error("depth", err)
+	}
+	if _, err := Marshal(make(chan int)); err == nil {
+		t.Error("chan")
+	}
+}
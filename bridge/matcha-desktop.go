@@ -0,0 +1,13 @@
+// +build matcha,windows matcha,linux,!android
+
+package bridge
+
+/*
+#cgo CFLAGS:
+#cgo LDFLAGS:
+
+#include "matchaforeign.h"
+#include "matchago.h"
+#include "matchaforeign-desktop.h"
+*/
+import "C"
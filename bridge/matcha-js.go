@@ -0,0 +1,505 @@
+// +build matcha,js
+
+package bridge
+
+// Go support functions for the browser. The JavaScript renderer (matcha.js)
+// installs a `matchaBridge` object that implements the same methods as the
+// Android bridge, and calls back into Go through the `matchaGo` object that is
+// installed here.
+//
+// Go values are passed to JavaScript as {goRef: n} objects, and must be released
+// with matchaGo.untrack(ref) once they are no longer needed.
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"runtime/debug"
+	"syscall/js"
+)
+
+// goRoot is allocated in its declaration rather than in init, since the init
+// funcs of other files in the package, such as async.go, register funcs before
+// this file's init runs.
+var goRoot = struct {
+	types map[string]reflect.Type
+	funcs map[string]reflect.Value
+}{
+	types: map[string]reflect.Type{},
+	funcs: map[string]reflect.Value{},
+}
+
+func init() {
+	matchaGo := js.Global().Get("Object").New()
+	matchaGo.Set("func", js.FuncOf(jsFunc))
+	matchaGo.Set("type", js.FuncOf(jsType))
+	matchaGo.Set("call", js.FuncOf(jsCall))
+	matchaGo.Set("untrack", js.FuncOf(jsUntrack))
+	js.Global().Set("matchaGo", matchaGo)
+}
+
+func RegisterType(str string, t reflect.Type) {
+	goRoot.types[str] = t
+}
+
+func RegisterFunc(str string, f interface{}) {
+	goRoot.funcs[str] = reflect.ValueOf(f)
+	traceRegisterFunc(str, reflect.ValueOf(f))
+}
+
+// Value wraps a JavaScript value.
+type Value struct {
+	value js.Value
+	size  int // Payload size for the tracer.
+}
+
+func newValue(v js.Value) *Value {
+	return &Value{value: v}
+}
+
+// Bridge returns the matchaBridge object installed by matcha.js.
+func Bridge(a string) *Value {
+	return newValue(js.Global().Get("matchaBridge"))
+}
+
+func Nil() *Value {
+	return newValue(js.Null())
+}
+
+func (v *Value) IsNil() bool {
+	return v == nil || v.value.IsNull() || v.value.IsUndefined()
+}
+
+func Bool(v bool) *Value {
+	return newValue(js.ValueOf(v))
+}
+
+func (v *Value) ToBool() bool {
+	if v.IsNil() {
+		return false
+	}
+	return v.value.Truthy()
+}
+
+// Int64 converts v to a JavaScript number. Integers beyond 2^53 lose precision.
+func Int64(v int64) *Value {
+	return newValue(js.ValueOf(float64(v)))
+}
+
+func (v *Value) ToInt64() int64 {
+	if v.IsNil() {
+		return 0
+	}
+	return int64(v.value.Float())
+}
+
+func Float64(v float64) *Value {
+	return newValue(js.ValueOf(v))
+}
+
+func (v *Value) ToFloat64() float64 {
+	if v.IsNil() {
+		return 0
+	}
+	return v.value.Float()
+}
+
+func String(v string) *Value {
+	return &Value{value: js.ValueOf(v), size: len(v)}
+}
+
+func (v *Value) ToString() string {
+	if v.IsNil() {
+		return ""
+	}
+	return v.value.String()
+}
+
+// Bytes copies v into a Uint8Array.
+func Bytes(v []byte) *Value {
+	return &Value{value: jsBytes(v), size: len(v)}
+}
+
+func (v *Value) ToBytes() []byte {
+	if v.IsNil() {
+		return nil
+	}
+	return goBytes(v.value)
+}
+
+// Interface tracks v and passes it to JavaScript as a {goRef: n} object.
+func Interface(v interface{}) *Value {
+	return newValue(jsRef(reflect.ValueOf(v)))
+}
+
+// ToInterface returns the Go value referenced by v. Uint8Arrays are returned as
+// byte slices, and other JavaScript values are returned as is.
+func (v *Value) ToInterface() interface{} {
+	if v.IsNil() {
+		return nil
+	}
+	return goValue(v.value).Interface()
+}
+
+func Array(a ...*Value) *Value {
+	arr := js.Global().Get("Array").New(len(a))
+	size := 0
+	for idx, i := range a {
+		if i == nil {
+			arr.SetIndex(idx, js.Null())
+		} else {
+			arr.SetIndex(idx, i.value)
+			size += i.size
+		}
+	}
+	return &Value{value: arr, size: size}
+}
+
+func (v *Value) ToArray() []*Value {
+	if v.IsNil() {
+		return nil
+	}
+	length := v.value.Length()
+	slice := make([]*Value, length)
+	for i := 0; i < length; i++ {
+		slice[i] = newValue(v.value.Index(i))
+	}
+	return slice
+}
+
+// WeakValue is a weak reference to a JavaScript object. Unlike a Value, it
+// doesn't keep the object alive, so a Go struct can reference an element that
+// references the struct without creating a cycle.
+type WeakValue struct {
+	ref    js.Value
+	strong *Value // Primitives can't be referenced weakly, and are kept.
+}
+
+// Weak returns a weak reference to the JavaScript object of v, backed by a
+// WeakRef.
+func Weak(v *Value) *WeakValue {
+	weakRef := js.Global().Get("WeakRef")
+	t := v.value.Type()
+	if (t != js.TypeObject && t != js.TypeFunction) || weakRef.IsUndefined() {
+		return &WeakValue{strong: v}
+	}
+	return &WeakValue{ref: weakRef.New(v.value)}
+}
+
+// Get returns the JavaScript object, and false if it has been collected.
+func (w *WeakValue) Get() (*Value, bool) {
+	if w.strong != nil {
+		return w.strong, !w.strong.IsNil()
+	}
+	v := newValue(w.ref.Call("deref"))
+	if v.IsNil() {
+		return Nil(), false
+	}
+	return v, true
+}
+
+// JavaScript values are garbage collected by the browser, and aren't tracked.
+func setForeignLeakTracking(enabled bool) {
+}
+
+func foreignLeakObjects() []LeakObject {
+	return nil
+}
+
+// JavaScript has a single thread.
+func isMainThread() bool {
+	return true
+}
+
+func runOnMain(f func()) bool {
+	var cb js.Func
+	cb = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		cb.Release()
+		f()
+		return nil
+	})
+	js.Global().Call("setTimeout", cb, 0)
+	return true
+}
+
+func list(v *Value) *Value {
+	return v
+}
+
+func (v *Value) payloadSize() int {
+	if v == nil {
+		return 0
+	}
+	return v.size
+}
+
+// Map creates a JavaScript object with the entries of m.
+func Map(m map[string]*Value) *Value {
+	obj := js.Global().Get("Object").New()
+	size := 0
+	for k, v := range m {
+		size += len(k)
+		if v == nil {
+			obj.Set(k, js.Null())
+		} else {
+			obj.Set(k, v.value)
+			size += v.size
+		}
+	}
+	return &Value{value: obj, size: size}
+}
+
+func (v *Value) ToMap() map[string]*Value {
+	if v.IsNil() {
+		return nil
+	}
+	keys := js.Global().Get("Object").Call("keys", v.value)
+	m := make(map[string]*Value, keys.Length())
+	for i := 0; i < keys.Length(); i++ {
+		k := keys.Index(i).String()
+		m[k] = newValue(v.value.Get(k))
+	}
+	return m
+}
+
+// Call calls the method s on v. The Objective-C style selector suffix is not
+// used, so the renderer implements the Android method names.
+func (v *Value) Call(s string, args ...*Value) *Value {
+	if queueCall(v, s, args) {
+		return Nil()
+	}
+	return v.callNow(s, args)
+}
+
+func (v *Value) callNow(s string, args []*Value) *Value {
+	checkMainThread(s)
+	if start, ok := traceStart(); ok {
+		defer traceEnd(start, s, GoToNative, traceValuesSize(args))
+	}
+	return v.call(s, args)
+}
+
+// TryCall is like Call, but returns a *CallError instead of panicking if the
+// JavaScript method throws an exception.
+func (v *Value) TryCall(s string, args ...*Value) (rlt *Value, err error) {
+	flushBatch()
+	checkMainThread(s)
+	if start, ok := traceStart(); ok {
+		defer traceEnd(start, s, GoToNative, traceValuesSize(args))
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(js.Error)
+			if !ok {
+				panic(r)
+			}
+			rlt, err = nil, &CallError{Selector: s, Message: e.Error()}
+		}
+	}()
+	return v.call(s, args), nil
+}
+
+func (v *Value) call(s string, args []*Value) *Value {
+	jsArgs := make([]interface{}, len(args))
+	for i, elem := range args {
+		if elem == nil {
+			jsArgs[i] = js.Null()
+		} else {
+			jsArgs[i] = elem.value
+		}
+	}
+	return newValue(v.value.Call(s, jsArgs...))
+}
+
+// callBatch makes the calls in order. Calls don't cross a language boundary
+// in the browser, so there is nothing to gain from a single invocation.
+func callBatch(calls []batchCall) {
+	for _, i := range calls {
+		i.v.call(i.s, i.args)
+	}
+}
+
+func jsBytes(b []byte) js.Value {
+	arr := js.Global().Get("Uint8Array").New(len(b))
+	js.CopyBytesToJS(arr, b)
+	return arr
+}
+
+func goBytes(v js.Value) []byte {
+	b := make([]byte, v.Get("length").Int())
+	js.CopyBytesToGo(b, v)
+	return b
+}
+
+// jsValue converts a Go value to JavaScript. Basic types are copied, and all other
+// values are tracked.
+func jsValue(rv reflect.Value) js.Value {
+	if !rv.IsValid() {
+		return js.Null()
+	}
+	switch rv.Kind() {
+	case reflect.Bool:
+		return js.ValueOf(rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return js.ValueOf(float64(rv.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return js.ValueOf(float64(rv.Uint()))
+	case reflect.Float32, reflect.Float64:
+		return js.ValueOf(rv.Float())
+	case reflect.String:
+		return js.ValueOf(rv.String())
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return jsBytes(rv.Bytes())
+		}
+	}
+	return jsRef(rv)
+}
+
+func jsRef(rv reflect.Value) js.Value {
+	obj := js.Global().Get("Object").New()
+	obj.Set("goRef", float64(matchaGoTrack(rv)))
+	return obj
+}
+
+// goValue converts a JavaScript value to Go. Numbers are returned as float64s,
+// and {goRef: n} objects are returned as the value they reference.
+func goValue(v js.Value) reflect.Value {
+	switch v.Type() {
+	case js.TypeBoolean:
+		return reflect.ValueOf(v.Bool())
+	case js.TypeNumber:
+		return reflect.ValueOf(v.Float())
+	case js.TypeString:
+		return reflect.ValueOf(v.String())
+	case js.TypeObject:
+		if v.InstanceOf(js.Global().Get("Uint8Array")) {
+			return reflect.ValueOf(goBytes(v))
+		}
+		if v.InstanceOf(js.Global().Get("Array")) {
+			rvs := make([]reflect.Value, v.Length())
+			for i := range rvs {
+				rvs[i] = goValue(v.Index(i))
+			}
+			return reflect.ValueOf(rvs)
+		}
+		if ref := v.Get("goRef"); ref.Type() == js.TypeNumber {
+			return matchaGoGet(int64(ref.Int()))
+		}
+	}
+	return reflect.ValueOf(v)
+}
+
+// goArgs converts args to the parameter types of f.
+func goArgs(f reflect.Value, args []js.Value) []reflect.Value {
+	t := f.Type()
+	rvs := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		rv := goValue(arg)
+		var in reflect.Type
+		if t.IsVariadic() && i >= t.NumIn()-1 {
+			in = t.In(t.NumIn() - 1).Elem()
+		} else if i < t.NumIn() {
+			in = t.In(i)
+		}
+		if in != nil && rv.Type() != in && rv.Type().ConvertibleTo(in) {
+			rv = rv.Convert(in)
+		}
+		rvs[i] = rv
+	}
+	return rvs
+}
+
+// matchaGo.func(name) returns a reference to the function registered as name.
+func jsFunc(this js.Value, args []js.Value) interface{} {
+	defer goRecover()
+	str := args[0].String()
+	f, ok := goRoot.funcs[str]
+	if !ok {
+		fmt.Println("No such function:", str)
+		return js.Null()
+	}
+	return jsRef(f)
+}
+
+// matchaGo.type(name) returns a reference to a new value of the type registered
+// as name.
+func jsType(this js.Value, args []js.Value) interface{} {
+	defer goRecover()
+	t, ok := goRoot.types[args[0].String()]
+	if !ok {
+		return js.Null()
+	}
+	return jsRef(reflect.New(t))
+}
+
+// matchaGo.call(ref, method, ...args) calls method on the value referenced by
+// ref, or the value itself if method is empty. It returns an array of results.
+func jsCall(this js.Value, args []js.Value) interface{} {
+	defer goRecover()
+	rv := goValue(args[0])
+	str := args[1].String()
+
+	function := callable(rv)
+	if str != "" {
+		function = rv.MethodByName(str)
+	}
+	in := goArgs(function, args[2:])
+	if start, ok := traceStart(); ok {
+		defer traceEnd(start, traceName(rv, str), NativeToGo, traceArgsSize(in))
+	}
+	rlt := function.Call(in)
+
+	arr := js.Global().Get("Array").New(len(rlt))
+	for i, v := range rlt {
+		arr.SetIndex(i, jsValue(v))
+	}
+	return arr
+}
+
+// matchaGo.untrack(ref) releases a value returned to JavaScript.
+func jsUntrack(this js.Value, args []js.Value) interface{} {
+	defer goRecover()
+	if ref := args[0].Get("goRef"); ref.Type() == js.TypeNumber {
+		matchaGoUntrack(int64(ref.Int()))
+	}
+	return nil
+}
+
+// TrackedGo returns the number of Go values referenced by JavaScript.
+func TrackedGo() int {
+	return tracker.len()
+}
+
+// TrackedForeign returns the number of native objects referenced by Go. JavaScript
+// values are garbage collected by the browser, so it is always 0.
+func TrackedForeign() int {
+	return 0
+}
+
+func matchaGoTrack(v reflect.Value) int64 {
+	return tracker.track(v)
+}
+
+func goLeakObjects() []LeakObject {
+	return tracker.leakObjects()
+}
+
+func matchaGoGet(ref int64) reflect.Value {
+	v, ok := tracker.get(ref)
+	if !ok {
+		panic("Get error. No corresponding object for key.")
+	}
+	return v
+}
+
+func matchaGoUntrack(ref int64) {
+	tracker.untrack(ref)
+}
+
+// Log panics from JavaScript callbacks instead of killing the program.
+func goRecover() {
+	if r := recover(); r != nil {
+		log.Printf("%s %s", r, debug.Stack())
+	}
+}
@@ -0,0 +1,23 @@
+// +build matcha,!js
+
+#include "matchaforeign.h"
+
+void MatchaObjcCallBatch(ObjcRef calls) {
+    int64_t len = MatchaObjcArrayLen(calls);
+    for (int64_t i = 0; i < len; i++) {
+        ObjcRef call = MatchaObjcArrayAt(calls, i);
+        ObjcRef target = MatchaObjcArrayAt(call, 0);
+        ObjcRef selector = MatchaObjcArrayAt(call, 1);
+        ObjcRef args = MatchaObjcArrayAt(call, 2);
+
+        // MatchaObjcCall frees the selector buffer.
+        ObjcRef rlt = MatchaObjcCall(target, MatchaObjcToString(selector), args);
+
+        ObjcRef refs[] = {rlt, args, selector, target, call};
+        for (int j = 0; j < sizeof(refs) / sizeof(refs[0]); j++) {
+            if (refs[j] != 0) {
+                MatchaUntrackObjc(refs[j]);
+            }
+        }
+    }
+}
@@ -0,0 +1,526 @@
+// +build matcha,windows matcha,linux,!android
+
+#ifdef _WIN32
+#define MATCHA_API __declspec(dllexport)
+#else
+#define MATCHA_API __attribute__((visibility("default")))
+#endif
+
+#include "matchaforeign.h"
+#include "matchago.h"
+#include "matchaforeign-desktop.h"
+#include <stdio.h>
+#include <stdlib.h>
+#include <string.h>
+
+#ifdef _WIN32
+#include <windows.h>
+static SRWLOCK sLock = SRWLOCK_INIT;
+static DWORD sMainThread;
+#define MatchaSetMainThread() (sMainThread = GetCurrentThreadId())
+#define MatchaIsMainThread() (GetCurrentThreadId() == sMainThread)
+#define MatchaLockShared() AcquireSRWLockShared(&sLock)
+#define MatchaUnlockShared() ReleaseSRWLockShared(&sLock)
+#define MatchaLock() AcquireSRWLockExclusive(&sLock)
+#define MatchaUnlock() ReleaseSRWLockExclusive(&sLock)
+#define MatchaIncrement(p) InterlockedIncrement64(p)
+#define MatchaDecrement(p) InterlockedDecrement64(p)
+#define MatchaCompareAndSwap(p, old, new) (InterlockedCompareExchange64(p, new, old) == (old))
+#else
+#include <pthread.h>
+static pthread_rwlock_t sLock = PTHREAD_RWLOCK_INITIALIZER;
+static pthread_t sMainThread;
+#define MatchaSetMainThread() (sMainThread = pthread_self())
+#define MatchaIsMainThread() pthread_equal(pthread_self(), sMainThread)
+#define MatchaLockShared() pthread_rwlock_rdlock(&sLock)
+#define MatchaUnlockShared() pthread_rwlock_unlock(&sLock)
+#define MatchaLock() pthread_rwlock_wrlock(&sLock)
+#define MatchaUnlock() pthread_rwlock_unlock(&sLock)
+#define MatchaIncrement(p) __atomic_add_fetch(p, 1, __ATOMIC_SEQ_CST)
+#define MatchaDecrement(p) __atomic_sub_fetch(p, 1, __ATOMIC_SEQ_CST)
+#define MatchaCompareAndSwap(p, old, new) __sync_bool_compare_and_swap(p, old, new)
+#endif
+
+// Tracker
+
+static MatchaValue **sValues; // Indexed by ref-1. Free slots are NULL.
+static int64_t sValuesLen;
+static int64_t sNextFree;
+static MatchaHostCall sHostCall;
+static MatchaHostPost sHostPost;
+
+typedef struct MatchaBridgeEntry {
+    char *name;
+    int64_t object;
+    struct MatchaBridgeEntry *next;
+} MatchaBridgeEntry;
+
+static MatchaBridgeEntry *sBridges;
+
+// MatchaWeak is shared by the weak references to a value. Its fields are
+// guarded by sLock.
+typedef struct MatchaWeak {
+    MatchaValue *value; // NULL once the value has been freed.
+    int64_t refs;
+} MatchaWeak;
+
+static MatchaValue *MatchaNewValue(MatchaKind kind) {
+    MatchaValue *v = calloc(1, sizeof(MatchaValue));
+    v->kind = kind;
+    v->refs = 1;
+    return v;
+}
+
+static void MatchaRetainValue(MatchaValue *v) {
+    if (v != NULL) {
+        MatchaIncrement(&v->refs);
+    }
+}
+
+// MatchaTryRetainValue retains v unless it is being freed.
+static bool MatchaTryRetainValue(MatchaValue *v) {
+    for (;;) {
+        int64_t refs = v->refs;
+        if (refs <= 0) {
+            return false;
+        }
+        if (MatchaCompareAndSwap(&v->refs, refs, refs + 1)) {
+            return true;
+        }
+    }
+}
+
+static void MatchaReleaseValue(MatchaValue *v) {
+    if (v == NULL || MatchaDecrement(&v->refs) > 0) {
+        return;
+    }
+    MatchaLock();
+    if (v->weak != NULL) {
+        v->weak->value = NULL;
+    }
+    MatchaUnlock();
+    switch (v->kind) {
+    case MatchaKindGoRef:
+        matchaGoUntrack(v->u.goRef);
+        break;
+    case MatchaKindString:
+    case MatchaKindBytes:
+        free(v->u.buf.ptr);
+        break;
+    case MatchaKindArray:
+    case MatchaKindMap:
+        for (int64_t i = 0; i < v->u.array.len; i++) {
+            MatchaReleaseValue(v->u.array.elems[i]);
+        }
+        free(v->u.array.elems);
+        break;
+    default:
+        break;
+    }
+    free(v);
+}
+
+// MatchaTrackValue takes ownership of v and returns a new ref to it.
+static ObjcRef MatchaTrackValue(MatchaValue *v) {
+    if (v == NULL) {
+        return 0;
+    }
+    MatchaLock();
+    while (sNextFree < sValuesLen && sValues[sNextFree] != NULL) {
+        sNextFree++;
+    }
+    if (sNextFree == sValuesLen) {
+        int64_t len = sValuesLen == 0 ? 256 : sValuesLen * 2;
+        sValues = realloc(sValues, len * sizeof(MatchaValue *));
+        memset(sValues + sValuesLen, 0, (len - sValuesLen) * sizeof(MatchaValue *));
+        sValuesLen = len;
+    }
+    int64_t idx = sNextFree++;
+    sValues[idx] = v;
+    MatchaUnlock();
+    return idx + 1;
+}
+
+static MatchaValue *MatchaGetValue(ObjcRef ref) {
+    if (ref <= 0) {
+        return NULL;
+    }
+    MatchaLockShared();
+    MatchaValue *v = ref <= sValuesLen ? sValues[ref - 1] : NULL;
+    MatchaUnlockShared();
+    return v;
+}
+
+void MatchaUntrackObjc(ObjcRef ref) {
+    if (ref <= 0) {
+        return;
+    }
+    MatchaLock();
+    MatchaValue *v = NULL;
+    if (ref <= sValuesLen) {
+        v = sValues[ref - 1];
+        sValues[ref - 1] = NULL;
+        if (ref - 1 < sNextFree) {
+            sNextFree = ref - 1;
+        }
+    }
+    MatchaUnlock();
+
+    // Releasing a Go value calls back into Go, so do it without holding the lock.
+    MatchaReleaseValue(v);
+}
+
+// Host
+
+MATCHA_API void MatchaHostInit(MatchaHostCall call) {
+    sHostCall = call;
+    MatchaSetMainThread();
+}
+
+MATCHA_API void MatchaHostSetPost(MatchaHostPost post) {
+    sHostPost = post;
+}
+
+MATCHA_API void MatchaHostSetBridge(const char *name, int64_t object) {
+    MatchaLock();
+    MatchaBridgeEntry *e = calloc(1, sizeof(MatchaBridgeEntry));
+    e->name = strdup(name);
+    e->object = object;
+    e->next = sBridges;
+    sBridges = e;
+    MatchaUnlock();
+}
+
+MATCHA_API const MatchaValue *MatchaHostValue(ObjcRef ref) {
+    return MatchaGetValue(ref);
+}
+
+// Buffers
+
+static MatchaValue *MatchaBufferValue(MatchaKind kind, CGoBuffer buf) {
+    MatchaValue *v = MatchaNewValue(kind);
+    v->u.buf.ptr = malloc(buf.len + 1);
+    if (buf.len > 0) {
+        memcpy(v->u.buf.ptr, buf.ptr, buf.len);
+    }
+    v->u.buf.ptr[buf.len] = '\0';
+    v->u.buf.len = buf.len;
+    free(buf.ptr);
+    return v;
+}
+
+static CGoBuffer MatchaValueToBuffer(MatchaValue *v, MatchaKind kind) {
+    CGoBuffer buf = {0};
+    if (v == NULL || v->kind != kind || v->u.buf.len == 0) {
+        return buf;
+    }
+    buf.ptr = malloc(v->u.buf.len);
+    memcpy(buf.ptr, v->u.buf.ptr, v->u.buf.len);
+    buf.len = v->u.buf.len;
+    return buf;
+}
+
+// Foreign
+
+ObjcRef MatchaForeignBridge(CGoBuffer str) {
+    MatchaValue *name = MatchaBufferValue(MatchaKindString, str);
+    MatchaValue *v = NULL;
+
+    MatchaLockShared();
+    for (MatchaBridgeEntry *e = sBridges; e != NULL; e = e->next) {
+        if (strcmp(e->name, name->u.buf.ptr) == 0) {
+            v = MatchaNewValue(MatchaKindObject);
+            v->u.object = e->object;
+            break;
+        }
+    }
+    MatchaUnlockShared();
+
+    MatchaReleaseValue(name);
+    return MatchaTrackValue(v);
+}
+
+MATCHA_API ObjcRef MatchaObjcBool(bool b) {
+    MatchaValue *v = MatchaNewValue(MatchaKindBool);
+    v->u.b = b;
+    return MatchaTrackValue(v);
+}
+
+bool MatchaObjcToBool(ObjcRef ref) {
+    MatchaValue *v = MatchaGetValue(ref);
+    return v != NULL && v->kind == MatchaKindBool && v->u.b;
+}
+
+MATCHA_API ObjcRef MatchaObjcInt64(int64_t i) {
+    MatchaValue *v = MatchaNewValue(MatchaKindInt64);
+    v->u.i = i;
+    return MatchaTrackValue(v);
+}
+
+int64_t MatchaObjcToInt64(ObjcRef ref) {
+    MatchaValue *v = MatchaGetValue(ref);
+    if (v == NULL) {
+        return 0;
+    } else if (v->kind == MatchaKindFloat64) {
+        return (int64_t)v->u.f;
+    } else if (v->kind != MatchaKindInt64) {
+        return 0;
+    }
+    return v->u.i;
+}
+
+MATCHA_API ObjcRef MatchaObjcFloat64(double f) {
+    MatchaValue *v = MatchaNewValue(MatchaKindFloat64);
+    v->u.f = f;
+    return MatchaTrackValue(v);
+}
+
+double MatchaObjcToFloat64(ObjcRef ref) {
+    MatchaValue *v = MatchaGetValue(ref);
+    if (v == NULL) {
+        return 0;
+    } else if (v->kind == MatchaKindInt64) {
+        return (double)v->u.i;
+    } else if (v->kind != MatchaKindFloat64) {
+        return 0;
+    }
+    return v->u.f;
+}
+
+MATCHA_API ObjcRef MatchaObjcGoRef(GoRef ref) {
+    MatchaValue *v = MatchaNewValue(MatchaKindGoRef);
+    v->u.goRef = ref;
+    return MatchaTrackValue(v);
+}
+
+GoRef MatchaObjcToGoRef(ObjcRef ref) {
+    MatchaValue *v = MatchaGetValue(ref);
+    if (v == NULL || v->kind != MatchaKindGoRef) {
+        return 0;
+    }
+    return v->u.goRef;
+}
+
+MATCHA_API ObjcRef MatchaObjcString(CGoBuffer str) {
+    return MatchaTrackValue(MatchaBufferValue(MatchaKindString, str));
+}
+
+CGoBuffer MatchaObjcToString(ObjcRef ref) {
+    return MatchaValueToBuffer(MatchaGetValue(ref), MatchaKindString);
+}
+
+MATCHA_API ObjcRef MatchaObjcBytes(CGoBuffer bytes) {
+    return MatchaTrackValue(MatchaBufferValue(MatchaKindBytes, bytes));
+}
+
+CGoBuffer MatchaObjcToBytes(ObjcRef ref) {
+    return MatchaValueToBuffer(MatchaGetValue(ref), MatchaKindBytes);
+}
+
+MATCHA_API ObjcRef MatchaObjcArray(int64_t len) {
+    MatchaValue *v = MatchaNewValue(MatchaKindArray);
+    v->u.array.elems = calloc(len > 0 ? len : 1, sizeof(MatchaValue *));
+    v->u.array.len = len;
+    return MatchaTrackValue(v);
+}
+
+// Arrays are only modified while they are being built, before they are shared.
+MATCHA_API void MatchaObjcArraySet(ObjcRef ref, ObjcRef elem, int64_t idx) {
+    MatchaValue *v = MatchaGetValue(ref);
+    if (v == NULL || v->kind != MatchaKindArray || idx < 0 || idx >= v->u.array.len) {
+        return;
+    }
+    MatchaValue *e = MatchaGetValue(elem);
+    MatchaRetainValue(e);
+    MatchaReleaseValue(v->u.array.elems[idx]);
+    v->u.array.elems[idx] = e;
+}
+
+int64_t MatchaObjcArrayLen(ObjcRef ref) {
+    MatchaValue *v = MatchaGetValue(ref);
+    if (v == NULL || v->kind != MatchaKindArray) {
+        return 0;
+    }
+    return v->u.array.len;
+}
+
+ObjcRef MatchaObjcArrayAt(ObjcRef ref, int64_t idx) {
+    MatchaValue *v = MatchaGetValue(ref);
+    if (v == NULL || v->kind != MatchaKindArray || idx < 0 || idx >= v->u.array.len) {
+        return 0;
+    }
+    MatchaValue *e = v->u.array.elems[idx];
+    MatchaRetainValue(e);
+    return MatchaTrackValue(e);
+}
+
+// Lists are plain arrays on desktop.
+ObjcRef MatchaObjcList(ObjcRef ref) {
+    MatchaValue *v = MatchaGetValue(ref);
+    MatchaRetainValue(v);
+    return MatchaTrackValue(v);
+}
+
+// Map
+
+MATCHA_API ObjcRef MatchaObjcMap(ObjcRef keys, ObjcRef values) {
+    MatchaValue *k = MatchaGetValue(keys);
+    MatchaValue *e = MatchaGetValue(values);
+    int64_t len = 0;
+    if (k != NULL && k->kind == MatchaKindArray && e != NULL && e->kind == MatchaKindArray && k->u.array.len == e->u.array.len) {
+        len = k->u.array.len;
+    }
+
+    MatchaValue *v = MatchaNewValue(MatchaKindMap);
+    v->u.array.elems = calloc(len > 0 ? len * 2 : 1, sizeof(MatchaValue *));
+    v->u.array.len = len * 2;
+    for (int64_t i = 0; i < len; i++) {
+        MatchaRetainValue(k->u.array.elems[i]);
+        MatchaRetainValue(e->u.array.elems[i]);
+        v->u.array.elems[i * 2] = k->u.array.elems[i];
+        v->u.array.elems[i * 2 + 1] = e->u.array.elems[i];
+    }
+    return MatchaTrackValue(v);
+}
+
+ObjcRef MatchaObjcMapEntries(ObjcRef ref) {
+    MatchaValue *v = MatchaGetValue(ref);
+    int64_t len = 0;
+    if (v != NULL && v->kind == MatchaKindMap) {
+        len = v->u.array.len / 2;
+    }
+
+    MatchaValue *entries[2];
+    for (int i = 0; i < 2; i++) {
+        entries[i] = MatchaNewValue(MatchaKindArray);
+        entries[i]->u.array.elems = calloc(len > 0 ? len : 1, sizeof(MatchaValue *));
+        entries[i]->u.array.len = len;
+        for (int64_t j = 0; j < len; j++) {
+            MatchaValue *e = v->u.array.elems[j * 2 + i];
+            MatchaRetainValue(e);
+            entries[i]->u.array.elems[j] = e;
+        }
+    }
+
+    MatchaValue *rlt = MatchaNewValue(MatchaKindArray);
+    rlt->u.array.elems = calloc(2, sizeof(MatchaValue *));
+    rlt->u.array.elems[0] = entries[0];
+    rlt->u.array.elems[1] = entries[1];
+    rlt->u.array.len = 2;
+    return MatchaTrackValue(rlt);
+}
+
+// Call
+
+// Weak references
+
+int64_t MatchaObjcWeak(ObjcRef ref) {
+    MatchaValue *v = MatchaGetValue(ref);
+    if (v == NULL) {
+        return 0;
+    }
+    MatchaLock();
+    if (v->weak == NULL) {
+        v->weak = calloc(1, sizeof(MatchaWeak));
+        v->weak->value = v;
+    }
+    MatchaWeak *w = v->weak;
+    w->refs++;
+    MatchaUnlock();
+    return (int64_t)(intptr_t)w;
+}
+
+ObjcRef MatchaObjcWeakGet(int64_t weak) {
+    MatchaWeak *w = (MatchaWeak *)(intptr_t)weak;
+    MatchaLock();
+    MatchaValue *v = w->value;
+    if (v != NULL && !MatchaTryRetainValue(v)) {
+        v = NULL;
+    }
+    MatchaUnlock();
+    return MatchaTrackValue(v);
+}
+
+void MatchaObjcWeakFree(int64_t weak) {
+    MatchaWeak *w = (MatchaWeak *)(intptr_t)weak;
+    MatchaLock();
+    bool last = --w->refs == 0;
+    if (last && w->value != NULL) {
+        w->value->weak = NULL;
+    }
+    MatchaUnlock();
+    if (last) {
+        free(w);
+    }
+}
+
+ObjcRef MatchaObjcCallSentinel() {
+    // Nil array elements are supported, so no sentinel is necessary.
+    return 0;
+}
+
+ObjcRef MatchaObjcCall(ObjcRef ref, CGoBuffer str, ObjcRef args) {
+    MatchaValue *method = MatchaBufferValue(MatchaKindString, str);
+    MatchaValue *v = MatchaGetValue(ref);
+    ObjcRef rlt = 0;
+    if (v != NULL && v->kind == MatchaKindObject && sHostCall != NULL) {
+        rlt = sHostCall(v->u.object, method->u.buf.ptr, MatchaGetValue(args));
+    }
+    MatchaReleaseValue(method);
+    return rlt;
+}
+
+// The host renderers don't throw, so calls never fail.
+ObjcRef MatchaObjcTryCall(ObjcRef ref, CGoBuffer str, ObjcRef args, CGoBuffer *err) {
+    return MatchaObjcCall(ref, str, args);
+}
+
+// Main thread
+
+bool MatchaForeignIsMainThread() {
+    return sHostCall != NULL && MatchaIsMainThread();
+}
+
+bool MatchaForeignRunOnMain(GoRef f) {
+    return sHostPost != NULL && sHostPost(f);
+}
+
+// Other
+
+// Desktop values have no allocation stacks, so only their kinds are reported.
+void MatchaForeignSetLeakTracking(bool enabled) {
+}
+
+ObjcRef MatchaForeignLeakSnapshot() {
+    static const char *kinds[] = {"nil", "bool", "int64", "float64", "goRef", "string", "bytes", "array", "object", "map"};
+
+    CGoBuffer buf = {0};
+    int64_t cap = 64;
+    buf.ptr = malloc(cap);
+    char *ptr = buf.ptr;
+    ptr[buf.len++] = '[';
+    MatchaLockShared();
+    for (int64_t i = 0; i < sValuesLen; i++) {
+        MatchaValue *v = sValues[i];
+        if (v == NULL) {
+            continue;
+        }
+        if (cap - buf.len < 64) {
+            cap *= 2;
+            buf.ptr = ptr = realloc(ptr, cap);
+        }
+        buf.len += snprintf(ptr + buf.len, cap - buf.len, "%s{\"ref\":%lld,\"type\":\"%s\",\"stack\":\"\"}",
+            buf.len > 1 ? "," : "", (long long)(i + 1), kinds[v->kind]);
+    }
+    MatchaUnlockShared();
+    ptr[buf.len++] = ']';
+    return MatchaTrackValue(MatchaBufferValue(MatchaKindString, buf));
+}
+
+void MatchaForeignPanic() {
+#ifdef _WIN32
+    OutputDebugStringA("matcha: Go panic\n");
+#endif
+    fprintf(stderr, "matcha: Go panic\n");
+    abort();
+}
@@ -0,0 +1,80 @@
+// +build matcha,windows matcha,linux,!android
+
+#ifndef MATCHAFOREIGN_DESKTOP_H
+#define MATCHAFOREIGN_DESKTOP_H
+
+// Interface between the Go library and the desktop hosts, the Win32 renderer of
+// the windows target and the GTK renderer of the linux target. The host
+// registers a call function that implements the methods Go invokes through
+// bridge.Bridge(""), and calls into Go with the matchaGo* functions declared in
+// matchago.h.
+
+#include "matchaforeign.h"
+
+#ifndef MATCHA_API
+#ifdef _WIN32
+#define MATCHA_API __declspec(dllimport)
+#else
+#define MATCHA_API
+#endif
+#endif
+
+typedef enum MatchaKind {
+    MatchaKindNil,
+    MatchaKindBool,
+    MatchaKindInt64,
+    MatchaKindFloat64,
+    MatchaKindGoRef,
+    MatchaKindString,
+    MatchaKindBytes,
+    MatchaKindArray,
+    MatchaKindObject,
+    MatchaKindMap, // u.array holds the keys and values, alternating.
+} MatchaKind;
+
+// MatchaValue is a value tracked by the DLL. Values are immutable once they have
+// been created.
+typedef struct MatchaValue {
+    MatchaKind kind;
+    int64_t refs;
+    union {
+        bool b;
+        int64_t i;
+        double f;
+        GoRef goRef;
+        int64_t object; // Host defined handle
+        struct {
+            char *ptr; // NUL terminated
+            int64_t len;
+        } buf;
+        struct {
+            struct MatchaValue **elems;
+            int64_t len;
+        } array;
+    } u;
+    struct MatchaWeak *weak; // Private to the DLL.
+} MatchaValue;
+
+// MatchaHostCall invokes method on the host object. args is NULL or an array,
+// and is only valid for the duration of the call. The returned ref is owned by
+// the caller, and may be 0 for nil.
+typedef ObjcRef (*MatchaHostCall)(int64_t object, const char *method, const MatchaValue *args);
+
+// MatchaHostPost calls the Go func f asynchronously on the main thread with
+// matchaGoCall, and untracks it. It returns false if it can't.
+typedef bool (*MatchaHostPost)(GoRef f);
+
+// MatchaHostInit must be called before any Go function, on the main thread.
+MATCHA_API void MatchaHostInit(MatchaHostCall call);
+
+// MatchaHostSetPost registers the function that implements bridge.RunOnMain.
+MATCHA_API void MatchaHostSetPost(MatchaHostPost post);
+
+// MatchaHostSetBridge registers the object returned by bridge.Bridge(name).
+MATCHA_API void MatchaHostSetBridge(const char *name, int64_t object);
+
+// MatchaHostValue returns the value of ref, which must remain tracked while the
+// value is in use.
+MATCHA_API const MatchaValue *MatchaHostValue(ObjcRef ref);
+
+#endif // MATCHAFOREIGN_DESKTOP_H
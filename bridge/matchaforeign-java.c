@@ -182,6 +182,58 @@ ObjcRef MatchaObjcArrayAt(ObjcRef v, int64_t idx) {
     return (*sEnv)->CallLongMethod(sEnv, sTracker, mid, v, idx);
 }
 
+ObjcRef MatchaObjcList(ObjcRef v) {
+    jclass cls = (*sEnv)->GetObjectClass(sEnv, sTracker);
+    jmethodID mid = (*sEnv)->GetMethodID(sEnv, cls, "foreignList", "(J)J");
+    return (*sEnv)->CallLongMethod(sEnv, sTracker, mid, v);
+}
+
+ObjcRef MatchaObjcMap(ObjcRef keys, ObjcRef values) {
+    jclass cls = (*sEnv)->GetObjectClass(sEnv, sTracker);
+    jmethodID mid = (*sEnv)->GetMethodID(sEnv, cls, "foreignMap", "(JJ)J");
+    return (*sEnv)->CallLongMethod(sEnv, sTracker, mid, keys, values);
+}
+
+ObjcRef MatchaObjcMapEntries(ObjcRef v) {
+    jclass cls = (*sEnv)->GetObjectClass(sEnv, sTracker);
+    jmethodID mid = (*sEnv)->GetMethodID(sEnv, cls, "foreignMapEntries", "(J)J");
+    return (*sEnv)->CallLongMethod(sEnv, sTracker, mid, v);
+}
+
+int64_t MatchaObjcWeak(ObjcRef v) {
+    jclass cls = (*sEnv)->GetObjectClass(sEnv, sTracker);
+    jmethodID mid = (*sEnv)->GetMethodID(sEnv, cls, "get", "(J)Ljava/lang/Object;");
+    jobject object = (*sEnv)->CallObjectMethod(sEnv, sTracker, mid, v);
+    if (object == NULL) {
+        return 0;
+    }
+    jweak weak = (*sEnv)->NewWeakGlobalRef(sEnv, object);
+    (*sEnv)->DeleteLocalRef(sEnv, object);
+    return (int64_t)(intptr_t)weak;
+}
+
+ObjcRef MatchaObjcWeakGet(int64_t weak) {
+    jobject object = (*sEnv)->NewLocalRef(sEnv, (jweak)(intptr_t)weak);
+    if (object == NULL) {
+        return 0;
+    }
+    jclass cls = (*sEnv)->GetObjectClass(sEnv, sTracker);
+    jmethodID mid = (*sEnv)->GetMethodID(sEnv, cls, "track", "(Ljava/lang/Object;)J");
+    ObjcRef ref = (*sEnv)->CallLongMethod(sEnv, sTracker, mid, object);
+    (*sEnv)->DeleteLocalRef(sEnv, object);
+    return ref;
+}
+
+void MatchaObjcWeakFree(int64_t weak) {
+    JNIEnv *env = NULL;
+    jint success = (*sJavaVM)->GetEnv(sJavaVM, (void **)&env, sJavaVersion);
+    if (success == JNI_EDETACHED) {
+        (*sJavaVM)->AttachCurrentThread(sJavaVM, &env, NULL);
+    }
+
+    (*env)->DeleteWeakGlobalRef(env, (jweak)(intptr_t)weak);
+}
+
 ObjcRef MatchaObjcCallSentinel() {
     // Not necessary on android.
     return 0;
@@ -195,12 +247,69 @@ ObjcRef MatchaObjcCall(ObjcRef v, CGoBuffer str, ObjcRef args) {
     return (*sEnv)->CallLongMethod(sEnv, sTracker, mid, v, method, args);
 }
 
+ObjcRef MatchaObjcTryCall(ObjcRef v, CGoBuffer str, ObjcRef args, CGoBuffer *err) {
+    ObjcRef rlt = MatchaObjcCall(v, str, args);
+    jthrowable e = (*sEnv)->ExceptionOccurred(sEnv);
+    if (e == NULL) {
+        return rlt;
+    }
+    (*sEnv)->ExceptionClear(sEnv);
+
+    jclass cls = (*sEnv)->GetObjectClass(sEnv, sTracker);
+    jmethodID mid = (*sEnv)->GetMethodID(sEnv, cls, "foreignDescribeException", "(Ljava/lang/Throwable;)Ljava/lang/String;");
+    jstring desc = (*sEnv)->CallObjectMethod(sEnv, sTracker, mid, e);
+    *err = MatchaStringToCGoBuffer(sEnv, desc);
+    if (err->ptr == NULL) {
+        *err = MatchaStringToCGoBuffer(sEnv, (*sEnv)->NewStringUTF(sEnv, "exception"));
+    }
+    return 0;
+}
+
 void MatchaForeignPanic() {
     jclass cls = (*sEnv)->GetObjectClass(sEnv, sTracker);
     jmethodID mid = (*sEnv)->GetMethodID(sEnv, cls, "foreignPanic", "()V");
     return (*sEnv)->CallVoidMethod(sEnv, sTracker, mid);
 }
 
+// Main thread. These are called from any goroutine, so the thread is attached
+// to the VM first.
+bool MatchaForeignIsMainThread() {
+    JNIEnv *env = NULL;
+    jint success = (*sJavaVM)->GetEnv(sJavaVM, (void **)&env, sJavaVersion);
+    if (success == JNI_EDETACHED) {
+        (*sJavaVM)->AttachCurrentThread(sJavaVM, &env, NULL);
+    }
+
+    jclass cls = (*env)->GetObjectClass(env, sTracker);
+    jmethodID mid = (*env)->GetMethodID(env, cls, "foreignIsMainThread", "()Z");
+    return (*env)->CallBooleanMethod(env, sTracker, mid);
+}
+
+bool MatchaForeignRunOnMain(GoRef f) {
+    JNIEnv *env = NULL;
+    jint success = (*sJavaVM)->GetEnv(sJavaVM, (void **)&env, sJavaVersion);
+    if (success == JNI_EDETACHED) {
+        (*sJavaVM)->AttachCurrentThread(sJavaVM, &env, NULL);
+    }
+
+    jclass cls = (*env)->GetObjectClass(env, sTracker);
+    jmethodID mid = (*env)->GetMethodID(env, cls, "foreignRunOnMain", "(J)V");
+    (*env)->CallVoidMethod(env, sTracker, mid, f);
+    return true;
+}
+
+void MatchaForeignSetLeakTracking(bool enabled) {
+    jclass cls = (*sEnv)->GetObjectClass(sEnv, sTracker);
+    jmethodID mid = (*sEnv)->GetMethodID(sEnv, cls, "foreignSetLeakTracking", "(Z)V");
+    (*sEnv)->CallVoidMethod(sEnv, sTracker, mid, enabled);
+}
+
+ObjcRef MatchaForeignLeakSnapshot() {
+    jclass cls = (*sEnv)->GetObjectClass(sEnv, sTracker);
+    jmethodID mid = (*sEnv)->GetMethodID(sEnv, cls, "foreignLeakSnapshot", "()J");
+    return (*sEnv)->CallLongMethod(sEnv, sTracker, mid);
+}
+
 // Tracker
 ObjcRef MatchaTrackObjc(jobject v) {
     jclass cls = (*sEnv)->GetObjectClass(sEnv, sTracker);
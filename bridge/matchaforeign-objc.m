@@ -3,6 +3,7 @@
 #include <stdio.h>
 #include <stdint.h>
 #include <string.h>
+#include <pthread.h>
 #import <Foundation/Foundation.h>
 #include "matchaforeign.h"
 #include "matchaforeign-objc.h"
@@ -41,9 +42,20 @@
 
 @end
 
+@interface MatchaWeakBox : NSObject
+@property (nonatomic, weak) id object;
+@end
+
+@implementation MatchaWeakBox
+@end
+
+// MatchaTracker holds the objects referenced by Go. Goroutines call into it
+// concurrently, so lookups only take a read lock.
 @interface MatchaTracker : NSObject {
     NSMapTable *_mapTable;
     int64_t _maxKey;
+    NSMutableDictionary<NSNumber *, NSString *> *_stacks; // Allocation stacks while leak tracking is enabled.
+    pthread_rwlock_t _lock;
 }
 @end
 
@@ -63,6 +75,7 @@
         _mapTable = [[NSMapTable alloc] initWithKeyOptions:NSPointerFunctionsObjectPersonality|NSPointerFunctionsStrongMemory 
             valueOptions:NSPointerFunctionsObjectPersonality|NSPointerFunctionsStrongMemory capacity:0];
         _maxKey = 0;
+        pthread_rwlock_init(&_lock, NULL);
     }
     return self;
 }
@@ -71,25 +84,34 @@
     if (object == nil) {
         return 0;
     }
-    @synchronized (self) {
-        _maxKey += 1;
-        [_mapTable setObject:object forKey:@(_maxKey)];
-        return _maxKey;
+    NSString *stack = nil;
+    pthread_rwlock_wrlock(&_lock);
+    if (_stacks != nil) {
+        stack = [[NSThread callStackSymbols] componentsJoinedByString:@"\n"];
+    }
+    _maxKey += 1;
+    ObjcRef key = _maxKey;
+    [_mapTable setObject:object forKey:@(key)];
+    if (stack != nil) {
+        _stacks[@(key)] = stack;
     }
+    pthread_rwlock_unlock(&_lock);
+    return key;
 }
 
 - (void)untrack:(ObjcRef)key {
     if (key == 0) {
         return;
     }
-    @synchronized (self) {
-        id keyObj = @(key);
-        id object = [_mapTable objectForKey:keyObj];
-        if (object == nil) {
-            NSLog(@"UntrackError");
-            @throw @"Untrack error. No corresponding object for key.";
-        }
-        [_mapTable removeObjectForKey:keyObj];
+    id keyObj = @(key);
+    pthread_rwlock_wrlock(&_lock);
+    id object = [_mapTable objectForKey:keyObj];
+    [_mapTable removeObjectForKey:keyObj];
+    [_stacks removeObjectForKey:keyObj];
+    pthread_rwlock_unlock(&_lock);
+    if (object == nil) {
+        NSLog(@"UntrackError");
+        @throw @"Untrack error. No corresponding object for key.";
     }
 }
 
@@ -97,13 +119,39 @@
     if (key == 0) {
         return nil;
     }
-    @synchronized (self) {
-        id object = [_mapTable objectForKey:(id)@(key)];
-        if (object == nil) {
-            @throw @"Get error. No corresponding object for key";
-        }
-        return object;
+    pthread_rwlock_rdlock(&_lock);
+    id object = [_mapTable objectForKey:(id)@(key)];
+    pthread_rwlock_unlock(&_lock);
+    if (object == nil) {
+        @throw @"Get error. No corresponding object for key";
+    }
+    return object;
+}
+
+- (void)setLeakTracking:(BOOL)enabled {
+    pthread_rwlock_wrlock(&_lock);
+    if (!enabled) {
+        _stacks = nil;
+    } else if (_stacks == nil) {
+        _stacks = [NSMutableDictionary dictionary];
     }
+    pthread_rwlock_unlock(&_lock);
+}
+
+- (NSString *)leakSnapshot {
+    NSMutableArray *objects = [NSMutableArray array];
+    pthread_rwlock_rdlock(&_lock);
+    for (NSNumber *key in _mapTable) {
+        id object = [_mapTable objectForKey:key];
+        [objects addObject:@{
+            @"ref": key,
+            @"type": NSStringFromClass([object class]),
+            @"stack": _stacks[key] ?: @"",
+        }];
+    }
+    pthread_rwlock_unlock(&_lock);
+    NSData *data = [NSJSONSerialization dataWithJSONObject:objects options:0 error:nil];
+    return [[NSString alloc] initWithData:data encoding:NSUTF8StringEncoding];
 }
 
 @end
@@ -190,7 +238,8 @@ int64_t MatchaObjcArrayLen(ObjcRef v) {
 
 ObjcRef MatchaObjcArrayAt(ObjcRef v, int64_t index) {
     NSMutableArray *val = MatchaGetObjc(v);
-    return MatchaTrackObjc(val[index]);
+    id obj = val[index];
+    return MatchaTrackObjc(obj == [NSNull null] ? nil : obj);
 }
 
 // Call
@@ -205,6 +254,39 @@ ObjcRef MatchaObjcCallSentinel() {
     return MatchaTrackObjc(sentinel);
 }
 
+// List and Map. Nil elements are passed as call sentinels, and stored as NSNull.
+
+ObjcRef MatchaObjcList(ObjcRef v) {
+    NSArray *val = MatchaGetObjc(v);
+    NSMutableArray *list = [NSMutableArray arrayWithCapacity:val.count];
+    for (id obj in val) {
+        [list addObject:[obj isKindOfClass:[_MatchaObjcCallSentinel class]] ? [NSNull null] : obj];
+    }
+    return MatchaTrackObjc(list);
+}
+
+ObjcRef MatchaObjcMap(ObjcRef keys, ObjcRef values) {
+    NSArray *k = MatchaGetObjc(keys) ?: @[];
+    NSArray *v = MatchaGetObjc(values) ?: @[];
+    NSMutableDictionary *val = [NSMutableDictionary dictionaryWithCapacity:k.count];
+    for (NSInteger i = 0; i < k.count; i++) {
+        id obj = v[i];
+        val[k[i]] = [obj isKindOfClass:[_MatchaObjcCallSentinel class]] ? [NSNull null] : obj;
+    }
+    return MatchaTrackObjc(val);
+}
+
+ObjcRef MatchaObjcMapEntries(ObjcRef v) {
+    NSDictionary *val = MatchaGetObjc(v);
+    NSMutableArray *keys = [NSMutableArray arrayWithCapacity:val.count];
+    NSMutableArray *values = [NSMutableArray arrayWithCapacity:val.count];
+    [val enumerateKeysAndObjectsUsingBlock:^(id key, id obj, BOOL *stop) {
+        [keys addObject:key];
+        [values addObject:obj];
+    }];
+    return MatchaTrackObjc(@[keys, values]);
+}
+
 ObjcRef MatchaObjcCall(ObjcRef v, CGoBuffer cstr, ObjcRef arguments) {
     id obj = MatchaGetObjc(v);
     NSArray *args = MatchaGetObjc(arguments);
@@ -407,6 +489,39 @@ ObjcRef MatchaObjcCall(ObjcRef v, CGoBuffer cstr, ObjcRef arguments) {
     return MatchaTrackObjc(ret);
 }
 
+ObjcRef MatchaObjcTryCall(ObjcRef v, CGoBuffer cstr, ObjcRef arguments, CGoBuffer *err) {
+    @try {
+        return MatchaObjcCall(v, cstr, arguments);
+    } @catch (id e) {
+        NSString *desc = [e description];
+        if ([e isKindOfClass:[NSException class]]) {
+            desc = [NSString stringWithFormat:@"%@: %@", [e name], [e reason]];
+        }
+        *err = MatchaNSStringToCGoBuffer(desc.length > 0 ? desc : @"exception");
+        return 0;
+    }
+}
+
+// Weak references
+
+int64_t MatchaObjcWeak(ObjcRef v) {
+    id object = MatchaGetObjc(v);
+    if (object == nil) {
+        return 0;
+    }
+    MatchaWeakBox *box = [[MatchaWeakBox alloc] init];
+    box.object = object;
+    return (int64_t)(intptr_t)CFBridgingRetain(box);
+}
+
+ObjcRef MatchaObjcWeakGet(int64_t weak) {
+    MatchaWeakBox *box = (__bridge MatchaWeakBox *)(void *)(intptr_t)weak;
+    return MatchaTrackObjc(box.object);
+}
+
+void MatchaObjcWeakFree(int64_t weak) {
+    CFBridgingRelease((void *)(intptr_t)weak);
+}
 
 // Tracker
 
@@ -424,6 +539,28 @@ void MatchaUntrackObjc(ObjcRef key) {
 
 // Other
 
+// Main thread
+
+bool MatchaForeignIsMainThread() {
+    return [NSThread isMainThread];
+}
+
+bool MatchaForeignRunOnMain(GoRef ref) {
+    MatchaGoValue *f = [[MatchaGoValue alloc] initWithGoRef:ref];
+    dispatch_async(dispatch_get_main_queue(), ^{
+        [f call:nil, nil];
+    });
+    return true;
+}
+
+void MatchaForeignSetLeakTracking(bool enabled) {
+    [[MatchaTracker sharedTracker] setLeakTracking:enabled];
+}
+
+ObjcRef MatchaForeignLeakSnapshot() {
+    return MatchaTrackObjc([[MatchaTracker sharedTracker] leakSnapshot]);
+}
+
 void MatchaForeignPanic() {
     @throw [NSException exceptionWithName:@"Golang Panic" reason:@"" userInfo:nil];
 }
@@ -4,14 +4,28 @@
 // https://gomatcha.io/guide/native-bridge/ for more details.
 package bridge
 
-// Value wraps an ObjectiveC object.
+import "fmt"
+
+// Value wraps an ObjectiveC object. Without the matcha build tag it holds the Go
+// value directly so that values round trip through StubCall.
 type Value struct {
-	ref int64
+	value interface{}
 }
 
+type stubBridge string
+
+// StubCall, if set, is called for every Call on a Value returned by Bridge. It
+// is only available without the matcha build tag and allows tests to fake
+// native services. See the matchatest package.
+var StubCall func(bridge string, s string, args []*Value) *Value
+
+// StubBatch, if set, is called with the number of calls each time a Batch sends
+// its queued calls, before they are passed to StubCall.
+var StubBatch func(n int)
+
 // Bridge gets the MatchaObjcBridge singleton, and wraps it in a Value.
 func Bridge(a string) *Value {
-	return nil
+	return &Value{value: stubBridge(a)}
 }
 
 // Nil returns the Value representing the ObjectiveC nil.
@@ -21,83 +35,186 @@ func Nil() *Value {
 
 // IsNil returns true if v wraps the ObjectievC nil.
 func (v *Value) IsNil() bool {
-	return false
+	return v == nil || v.value == nil
+}
+
+func (v *Value) get() interface{} {
+	if v == nil {
+		return nil
+	}
+	return v.value
 }
 
 // Bool creates an NSNumber containing v, and wraps it in a Value.
 func Bool(v bool) *Value {
-	return nil
+	return &Value{value: v}
 }
 
 // ToBool returns v's value expressed as a boolean. v must wrap a NSNumber.
 func (v *Value) ToBool() bool {
-	return false
+	a, _ := v.get().(bool)
+	return a
 }
 
 // Int64 creates an NSNumber containing v, and wraps it in a Value.
 func Int64(v int64) *Value {
-	return nil
+	return &Value{value: v}
 }
 
 // ToInt64 returns v's value expressed as an int64. v must wrap a NSNumber.
 func (v *Value) ToInt64() int64 {
-	return 0
+	a, _ := v.get().(int64)
+	return a
 }
 
 // Float64 creates an NSNumber containing v, and wraps it in a Value.
 func Float64(v float64) *Value {
-	return nil
+	return &Value{value: v}
 }
 
 // ToFloat64 returns v's value expressed as a float64. v must wrap a NSNumber.
 func (v *Value) ToFloat64() float64 {
-	return 0
+	a, _ := v.get().(float64)
+	return a
 }
 
 // String creates an NSString containing v, and wraps it in a Value.
 func String(v string) *Value {
-	return nil
+	return &Value{value: v}
 }
 
 // ToString returns v's value as a string. v must wrap a NSString.
 func (v *Value) ToString() string {
-	return ""
+	a, _ := v.get().(string)
+	return a
 }
 
 // Bytes creates an NSData containing v, and wraps it in a Value.
 func Bytes(v []byte) *Value {
-	return nil
+	return &Value{value: v}
 }
 
 // ToString returns v's value as a byte slice. v must wrap a NSData.
 func (v *Value) ToBytes() []byte {
-	return nil
+	a, _ := v.get().([]byte)
+	return a
 }
 
 // Interface creates an MatchaGoValue containing v, and wraps it in a Value.
 func Interface(v interface{}) *Value {
-	return nil
+	return &Value{value: v}
 }
 
 // ToInterface return's v's value as an interface{}, v must wrap a MatchaGoValue.
 func (v *Value) ToInterface() interface{} {
-	return nil
+	return v.get()
 }
 
 // Array creates an NSArray containing a, and wraps it in a Value.
 func Array(a ...*Value) *Value {
-	return nil
+	return &Value{value: a}
 }
 
 // ToString returns v's elements a slice of Value. v must wrap a NSArray.
 func (v *Value) ToArray() []*Value {
+	a, _ := v.get().([]*Value)
+	return a
+}
+
+// WeakValue is a weak reference to a native object. Unlike a Value, it doesn't
+// keep the object alive, so a Go struct can reference a view that references the
+// struct without creating a cycle. Without the matcha build tag there are no
+// native objects, and the Value is kept.
+type WeakValue struct {
+	value *Value
+}
+
+// Weak returns a weak reference to the native object of v.
+func Weak(v *Value) *WeakValue {
+	return &WeakValue{value: v}
+}
+
+// Get returns the native object, and false if it has been released.
+func (w *WeakValue) Get() (*Value, bool) {
+	if w.value.IsNil() {
+		return Nil(), false
+	}
+	return w.value, true
+}
+
+// Without the matcha build tag, there are no trackers.
+func setForeignLeakTracking(enabled bool) {
+}
+
+func foreignLeakObjects() []LeakObject {
+	return nil
+}
+
+func goLeakObjects() []LeakObject {
 	return nil
 }
 
-// Call calls a method on v with signature s and arguments args.
+// stubMainThread is the result of IsMainThread without the matcha build tag.
+var stubMainThread = true
+
+func isMainThread() bool {
+	return stubMainThread
+}
+
+// StubRunOnMain, if set, is called by RunOnMain instead of calling f
+// immediately. It is only available without the matcha build tag and allows
+// tests to control when main thread funcs run. See the matchatest package.
+var StubRunOnMain func(f func())
+
+// Without the matcha build tag, funcs are run immediately.
+func runOnMain(f func()) bool {
+	if StubRunOnMain != nil {
+		StubRunOnMain(f)
+		return true
+	}
+	f()
+	return true
+}
+
+func list(v *Value) *Value {
+	return v
+}
+
+func (v *Value) payloadSize() int {
+	switch a := v.get().(type) {
+	case string:
+		return len(a)
+	case []byte:
+		return len(a)
+	case []*Value:
+		return traceValuesSize(a)
+	case map[string]*Value:
+		n := 0
+		for k, i := range a {
+			n += len(k) + i.payloadSize()
+		}
+		return n
+	}
+	return 0
+}
+
+// Map creates an NSDictionary containing m, and wraps it in a Value.
+func Map(m map[string]*Value) *Value {
+	return &Value{value: m}
+}
+
+// ToMap returns v's entries as a map of Values. v must wrap a NSDictionary.
+func (v *Value) ToMap() map[string]*Value {
+	a, _ := v.get().(map[string]*Value)
+	return a
+}
+
+// Call calls a method on v with signature s and arguments args. If the calling
+// goroutine has an open Batch, the call is queued and Call returns Nil, so calls
+// whose results are used must be made with CallNow.
 //
 // Go:
-//  rlt := bridge.Bridge().Call("add::", bridge.Int64(1), bridge.Int64(3))
+//  rlt := bridge.Bridge().CallNow("add::", bridge.Int64(1), bridge.Int64(3))
 //  fmt.Printf("1+3=%v", rlt.ToInt64())
 // Objective-C:
 //  @implementation MatchaObjcBridge (Extensions)
@@ -106,5 +223,57 @@ func (v *Value) ToArray() []*Value {
 //  }
 //  @end
 func (v *Value) Call(s string, args ...*Value) *Value {
-	return nil
+	if queueCall(v, s, args) {
+		return Nil()
+	}
+	return v.callNow(s, args)
+}
+
+func (v *Value) callNow(s string, args []*Value) *Value {
+	checkMainThread(s)
+	if start, ok := traceStart(); ok {
+		defer traceEnd(start, s, GoToNative, traceValuesSize(args))
+	}
+	return v.call(s, args)
+}
+
+// TryCall is like Call, but returns a *CallError instead of crashing if the
+// native method throws an exception. Without the matcha build tag, a panic in
+// StubCall stands in for the exception.
+func (v *Value) TryCall(s string, args ...*Value) (rlt *Value, err error) {
+	flushBatch()
+	checkMainThread(s)
+	if start, ok := traceStart(); ok {
+		defer traceEnd(start, s, GoToNative, traceValuesSize(args))
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			rlt, err = nil, &CallError{Selector: s, Message: fmt.Sprint(r)}
+		}
+	}()
+	return v.call(s, args), nil
+}
+
+func (v *Value) call(s string, args []*Value) *Value {
+	b, ok := v.get().(stubBridge)
+	if !ok || StubCall == nil {
+		return nil
+	}
+	return StubCall(string(b), s, args)
+}
+
+// callBatch makes the calls in order.
+func callBatch(calls []batchCall) {
+	if StubBatch != nil {
+		StubBatch(len(calls))
+	}
+	for _, i := range calls {
+		i.v.call(i.s, i.args)
+	}
+}
+
+// TrackedForeign returns the number of native objects referenced by Go. They
+// are released once the Values wrapping them are garbage collected.
+func TrackedForeign() int {
+	return 0
 }
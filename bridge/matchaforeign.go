@@ -1,4 +1,4 @@
-// +build matcha
+// +build matcha,!js
 
 package bridge
 
@@ -15,9 +15,11 @@ package bridge
 import "C"
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"runtime"
+	"sync/atomic"
 )
 
 //export TestFunc
@@ -41,19 +43,30 @@ func TestFunc() {
 }
 
 type Value struct {
-	ref int64
+	ref  int64
+	size int // Payload size for the tracer.
 }
 
+var foreignCount int64
+
 func newValue(ref C.ObjcRef) *Value {
 	v := &Value{ref: int64(ref)}
 	if ref != 0 {
+		atomic.AddInt64(&foreignCount, 1)
 		runtime.SetFinalizer(v, func(a *Value) {
+			atomic.AddInt64(&foreignCount, -1)
 			C.MatchaUntrackObjc(a._ref())
 		})
 	}
 	return v
 }
 
+// TrackedForeign returns the number of native objects referenced by Go. They
+// are released once the Values wrapping them are garbage collected.
+func TrackedForeign() int {
+	return int(atomic.LoadInt64(&foreignCount))
+}
+
 func (v *Value) _ref() C.ObjcRef {
 	return C.ObjcRef(v.ref)
 }
@@ -100,7 +113,9 @@ func (v *Value) ToFloat64() float64 {
 
 func String(v string) *Value {
 	cstr := cString(v)
-	return newValue(C.MatchaObjcString(cstr))
+	val := newValue(C.MatchaObjcString(cstr))
+	val.size = len(v)
+	return val
 }
 
 func (v *Value) ToString() string {
@@ -111,7 +126,9 @@ func (v *Value) ToString() string {
 
 func Bytes(v []byte) *Value {
 	cbytes := cBytes(v)
-	return newValue(C.MatchaObjcBytes(cbytes))
+	val := newValue(C.MatchaObjcBytes(cbytes))
+	val.size = len(v)
+	return val
 }
 
 func (v *Value) ToBytes() []byte {
@@ -143,7 +160,13 @@ func Array(a ...*Value) *Value {
 	ref := C.MatchaObjcArray(C.int64_t(len(a)))
 	array := newValue(ref)
 	for idx, i := range a {
+		if i == nil || (i.IsNil() && runtime.GOOS == "darwin") {
+			// Can't put nil in an NSArray so put a sentinel in.
+			i = callSentinel()
+		}
 		C.MatchaObjcArraySet(ref, i._ref(), C.int64_t(idx))
+		array.size += i.size
+		runtime.KeepAlive(i)
 	}
 	return array
 }
@@ -160,29 +183,181 @@ func (v *Value) ToArray() []*Value {
 	return slice
 }
 
+// list converts the array v into an NSArray or a java.util.List.
+func list(v *Value) *Value {
+	defer runtime.KeepAlive(v)
+	val := newValue(C.MatchaObjcList(v._ref()))
+	val.size = v.size
+	return val
+}
+
+func Map(m map[string]*Value) *Value {
+	keys := make([]*Value, 0, len(m))
+	values := make([]*Value, 0, len(m))
+	for k, v := range m {
+		keys = append(keys, String(k))
+		values = append(values, v)
+	}
+	keysValue := Array(keys...)
+	valuesValue := Array(values...)
+	defer runtime.KeepAlive(keysValue)
+	defer runtime.KeepAlive(valuesValue)
+	val := newValue(C.MatchaObjcMap(keysValue._ref(), valuesValue._ref()))
+	val.size = keysValue.size + valuesValue.size
+	return val
+}
+
+func (v *Value) ToMap() map[string]*Value {
+	defer runtime.KeepAlive(v)
+	entries := newValue(C.MatchaObjcMapEntries(v._ref())).ToArray()
+	keys, values := entries[0].ToArray(), entries[1].ToArray()
+
+	m := make(map[string]*Value, len(keys))
+	for i, k := range keys {
+		m[k.ToString()] = values[i]
+	}
+	return m
+}
+
+func (v *Value) payloadSize() int {
+	if v == nil {
+		return 0
+	}
+	return v.size
+}
+
+// WeakValue is a weak reference to a native object. Unlike a Value, it doesn't
+// keep the object alive, so a Go struct can reference a view that references the
+// struct without creating a cycle.
+type WeakValue struct {
+	weak int64
+}
+
+// Weak returns a weak reference to the native object of v. It is backed by a
+// __weak reference on iOS and a weak global reference on Android.
+func Weak(v *Value) *WeakValue {
+	w := &WeakValue{weak: int64(C.MatchaObjcWeak(v._ref()))}
+	runtime.KeepAlive(v)
+	if w.weak != 0 {
+		runtime.SetFinalizer(w, func(a *WeakValue) {
+			C.MatchaObjcWeakFree(C.int64_t(a.weak))
+		})
+	}
+	return w
+}
+
+// Get returns the native object, and false if it has been released.
+func (w *WeakValue) Get() (*Value, bool) {
+	if w.weak == 0 {
+		return Nil(), false
+	}
+	ref := C.MatchaObjcWeakGet(C.int64_t(w.weak))
+	runtime.KeepAlive(w)
+	if ref == 0 {
+		return Nil(), false
+	}
+	return newValue(ref), true
+}
+
+func setForeignLeakTracking(enabled bool) {
+	C.MatchaForeignSetLeakTracking(C.bool(enabled))
+}
+
+func foreignLeakObjects() []LeakObject {
+	objects := []LeakObject{}
+	if err := json.Unmarshal([]byte(newValue(C.MatchaForeignLeakSnapshot()).ToString()), &objects); err != nil {
+		panic(err)
+	}
+	return objects
+}
+
+func isMainThread() bool {
+	return bool(C.MatchaForeignIsMainThread())
+}
+
+func runOnMain(f func()) bool {
+	ref := matchaGoTrack(reflect.ValueOf(f))
+	if !C.MatchaForeignRunOnMain(ref) {
+		matchaGoUntrack(ref)
+		return false
+	}
+	return true
+}
+
 func callSentinel() *Value {
 	return newValue(C.MatchaObjcCallSentinel())
 }
 
 // Call accepts `nil` in its variadic arguments
 func (v *Value) Call(s string, args ...*Value) *Value {
+	if queueCall(v, s, args) {
+		return Nil()
+	}
+	return v.callNow(s, args)
+}
+
+func (v *Value) callNow(s string, args []*Value) *Value {
+	defer runtime.KeepAlive(v)
+	checkMainThread(s)
+	if start, ok := traceStart(); ok {
+		defer traceEnd(start, s, GoToNative, traceValuesSize(args))
+	}
+	return v.call(s, args)
+}
+
+// TryCall is like Call, but returns a *CallError instead of crashing if the
+// native method throws an exception.
+func (v *Value) TryCall(s string, args ...*Value) (*Value, error) {
+	flushBatch()
+	defer runtime.KeepAlive(v)
+	checkMainThread(s)
+	if start, ok := traceStart(); ok {
+		defer traceEnd(start, s, GoToNative, traceValuesSize(args))
+	}
+
+	argsValue := callArgs(args)
+	defer runtime.KeepAlive(argsValue)
+	var err C.CGoBuffer
+	rlt := newValue(C.MatchaObjcTryCall(v._ref(), cString(s), argsValue._ref(), &err))
+	if err.ptr != nil {
+		return nil, &CallError{Selector: s, Message: goString(err)}
+	}
+	return rlt, nil
+}
+
+func (v *Value) call(s string, args []*Value) *Value {
 	defer runtime.KeepAlive(v)
+	argsValue := callArgs(args)
+	defer runtime.KeepAlive(argsValue)
+	return newValue(C.MatchaObjcCall(v._ref(), cString(s), argsValue._ref()))
+}
 
+// callArgs returns the array of arguments passed to a native method.
+func callArgs(args []*Value) *Value {
+	if len(args) == 0 {
+		return Nil()
+	}
 	if runtime.GOOS == "darwin" {
 		// Can't pass nil through NSArray so put a sentinel in.
+		args = append([]*Value(nil), args...)
 		for i, elem := range args {
 			if elem == nil || elem.IsNil() {
 				args[i] = callSentinel()
 			}
 		}
 	}
+	return Array(args...)
+}
 
-	argsValue := Nil()
-	if len(args) > 0 {
-		argsValue = Array(args...)
-		defer runtime.KeepAlive(argsValue)
+// callBatch sends calls to the platform in one invocation.
+func callBatch(calls []batchCall) {
+	a := make([]*Value, len(calls))
+	for idx, i := range calls {
+		a[idx] = Array(i.v, String(i.s), Array(i.args...))
 	}
-	return newValue(C.MatchaObjcCall(v._ref(), cString(s), argsValue._ref()))
+	array := Array(a...)
+	defer runtime.KeepAlive(array)
+	C.MatchaObjcCallBatch(array._ref())
 }
 
 func cBytes(v []byte) C.CGoBuffer {
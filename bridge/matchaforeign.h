@@ -33,10 +33,21 @@ ObjcRef MatchaObjcArray(int64_t len);
 void MatchaObjcArraySet(ObjcRef v, ObjcRef elem, int64_t idx);
 int64_t MatchaObjcArrayLen(ObjcRef v);
 ObjcRef MatchaObjcArrayAt(ObjcRef v, int64_t index);
+ObjcRef MatchaObjcList(ObjcRef array); // NSArray or java.util.List with the elements of array.
+
+ObjcRef MatchaObjcMap(ObjcRef keys, ObjcRef values); // keys and values are arrays of the same length.
+ObjcRef MatchaObjcMapEntries(ObjcRef v); // Returns an array of two arrays, the keys and the values.
+
+// Weak references
+int64_t MatchaObjcWeak(ObjcRef v); // Returns a weak reference to the object of v, or 0 if v is nil.
+ObjcRef MatchaObjcWeakGet(int64_t weak); // Returns 0 if the object has been released.
+void MatchaObjcWeakFree(int64_t weak);
 
 // Call
 ObjcRef MatchaObjcCallSentinel();
 ObjcRef MatchaObjcCall(ObjcRef v, CGoBuffer str, ObjcRef args);
+ObjcRef MatchaObjcTryCall(ObjcRef v, CGoBuffer str, ObjcRef args, CGoBuffer *err); // Sets err to a description of the exception, if the method throws one.
+void MatchaObjcCallBatch(ObjcRef calls); // Calls each [target, selector, args] array of calls in order.
 
 // Tracker
 void MatchaUntrackObjc(ObjcRef key);
@@ -45,6 +56,14 @@ void MatchaUntrackObjc(ObjcRef key);
 
 void MatchaForeignPanic();
 
+// Main thread
+bool MatchaForeignIsMainThread();
+bool MatchaForeignRunOnMain(GoRef f); // Calls the Go func f asynchronously on the main thread, and untracks it. Returns false if it can't.
+
+// Leak tracking
+void MatchaForeignSetLeakTracking(bool enabled); // Records the allocation stacks of tracked objects while enabled.
+ObjcRef MatchaForeignLeakSnapshot(); // JSON array of the tracked objects, [{"ref":1,"type":"NSString","stack":"..."}].
+
 // ObjcRef MatchaObjcWithGo(GoRef v);
 // GoRef MatchaObjcToGo(ObjcRef v);
 
@@ -48,6 +48,16 @@ JNIEXPORT jlong JNICALL Java_io_gomatcha_bridge_GoValue_matchaGoByteArray(JNIEnv
     return matchaGoBytes(cstr);
 }
 
+JNIEXPORT jlong JNICALL Java_io_gomatcha_bridge_GoValue_matchaGoDirectBuffer(JNIEnv *env, jclass c, jobject v, jint position, jint length) {
+    CGoBuffer buf = {0};
+    char *ptr = (*env)->GetDirectBufferAddress(env, v);
+    if (ptr != NULL && length > 0) {
+        buf.ptr = ptr + position;
+        buf.len = length;
+    }
+    return matchaGoBytesNoCopy(buf);
+}
+
 JNIEXPORT jlong JNICALL Java_io_gomatcha_bridge_GoValue_matchaGoArray(JNIEnv *env, jclass c, jlongArray v) {
     int len = (*env)->GetArrayLength(env, v);
     GoRef array = matchaGoArray();
@@ -98,6 +108,14 @@ JNIEXPORT jbyteArray JNICALL Java_io_gomatcha_bridge_GoValue_matchaGoToByteArray
     return a;
 }
 
+JNIEXPORT jobject JNICALL Java_io_gomatcha_bridge_GoValue_matchaGoToDirectBuffer(JNIEnv *env, jclass c, jlong v) {
+    CGoBuffer buf = matchaGoBytesPointer(v);
+    if (buf.ptr == NULL) {
+        return NULL;
+    }
+    return (*env)->NewDirectByteBuffer(env, buf.ptr, buf.len);
+}
+
 JNIEXPORT jlongArray JNICALL Java_io_gomatcha_bridge_GoValue_matchaGoToArray(JNIEnv *env, jclass c, jlong v) {
     int len = matchaGoArrayLen(v);
     jlongArray array = (*env)->NewLongArray(env, len);
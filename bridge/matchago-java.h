@@ -73,6 +73,14 @@ JNIEXPORT jlong JNICALL Java_io_gomatcha_bridge_GoValue_matchaGoString
 JNIEXPORT jlong JNICALL Java_io_gomatcha_bridge_GoValue_matchaGoByteArray
   (JNIEnv *, jclass, jbyteArray);
 
+/*
+ * Class:     io_gomatcha_bridge_GoValue
+ * Method:    matchaGoDirectBuffer
+ * Signature: (Ljava/nio/ByteBuffer;II)J
+ */
+JNIEXPORT jlong JNICALL Java_io_gomatcha_bridge_GoValue_matchaGoDirectBuffer
+  (JNIEnv *, jclass, jobject, jint, jint);
+
 /*
  * Class:     io_gomatcha_bridge_GoValue
  * Method:    matchaGoArray
@@ -145,6 +153,14 @@ JNIEXPORT jstring JNICALL Java_io_gomatcha_bridge_GoValue_matchaGoToString
 JNIEXPORT jbyteArray JNICALL Java_io_gomatcha_bridge_GoValue_matchaGoToByteArray
   (JNIEnv *, jclass, jlong);
 
+/*
+ * Class:     io_gomatcha_bridge_GoValue
+ * Method:    matchaGoToDirectBuffer
+ * Signature: (J)Ljava/nio/ByteBuffer;
+ */
+JNIEXPORT jobject JNICALL Java_io_gomatcha_bridge_GoValue_matchaGoToDirectBuffer
+  (JNIEnv *, jclass, jlong);
+
 /*
  * Class:     io_gomatcha_bridge_GoValue
  * Method:    matchaGoToArray
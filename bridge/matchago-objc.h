@@ -7,12 +7,21 @@
 #import "matchaforeign.h"
 #import "matchago.h"
 @class MatchaGoValue;
+@class MatchaGoFuture;
+@class MatchaGoStream;
+
+// Go errors are surfaced as NSErrors in MatchaGoErrorDomain, with the code passed
+// to bridge.WithCode.
+extern NSString *const MatchaGoErrorDomain;
+extern NSString *const MatchaGoErrorChainKey; // NSArray<NSString *> of the messages of the error and the errors it wraps.
+extern NSString *const MatchaGoErrorStackKey; // NSString with the Go stack trace, if any.
 
 @interface MatchaGoBridge : NSObject
 + (MatchaGoBridge *)sharedBridge;
 @end
 
 @interface MatchaGoValue : NSObject
++ (NSString *)protocolVersion; // bridge protocol version of the Go library, "major.minor".
 - (id)initWithGoRef:(GoRef)ref; // not for external use.
 - (id)initWithObject:(id)v;
 - (id)initWithBool:(BOOL)v;
@@ -22,6 +31,7 @@
 - (id)initWithDouble:(double)v;
 - (id)initWithString:(NSString *)v;
 - (id)initWithData:(NSData *)v;
+- (id)initWithBytesNoCopy:(void *)bytes length:(NSUInteger)length; // wraps the memory without copying it, it must stay valid while Go uses the value.
 - (id)initWithArray:(NSArray<MatchaGoValue *> *)v;
 - (id)initWithType:(NSString *)typeName;
 - (id)initWithFunc:(NSString *)funcName;
@@ -39,10 +49,40 @@
 - (MatchaGoValue *)elem;
 - (NSArray<MatchaGoValue *> *)call:(NSString *)method, ... NS_REQUIRES_NIL_TERMINATION; // pass in nil for the method to call a closure. varargs should be of MatchaGoValue *.
 - (NSArray<MatchaGoValue *> *)call:(NSString *)method args:(va_list)args; 
+- (NSArray<MatchaGoValue *> *)call:(NSString *)method arguments:(NSArray<MatchaGoValue *> *)args; // for Swift, which can't call variadic methods.
+- (NSArray<MatchaGoValue *> *)tryCall:(NSString *)method arguments:(NSArray<MatchaGoValue *> *)args error:(NSError **)error; // returns nil and sets error if the last result is a non-nil Go error.
+- (NSError *)toError; // returns nil unless the value is a non-nil Go error.
+- (MatchaGoFuture *)callAsync:(NSString *)method, ... NS_REQUIRES_NIL_TERMINATION; // calls the method on a goroutine without blocking the calling thread.
 - (MatchaGoValue *)field:(NSString *)name;
 - (void)setField:(NSString *)name value:(MatchaGoValue *)value;
 - (MatchaGoValue *)objectForKeyedSubscript:(NSString *)key;
 - (void)setObject:(MatchaGoValue *)object forKeyedSubscript:(NSString *)key;
 @end
 
+// MatchaGoFuture is the result of -[MatchaGoValue callAsync:]. If the Go
+// function's first parameter is a context.Context, it is canceled when the
+// future is.
+@interface MatchaGoFuture : NSObject
+- (id)initWithGoValue:(MatchaGoValue *)value; // not for external use.
+@property (nonatomic, readonly) BOOL isDone;
+@property (nonatomic, readonly) BOOL isCanceled;
+@property (nonatomic, readonly) NSError *error; // set if the method does not exist or the function panicked.
+- (void)cancel;
+// The block is called on the main queue with the function's return values, or nil if the call was canceled or failed.
+- (void)then:(void (^)(NSArray<MatchaGoValue *> *results))block;
+@end
+
+// MatchaGoStream wraps a bridge.Stream, a pipe with a bounded buffer that moves
+// large payloads between Go and native code in chunks. The methods block until
+// the other side catches up, so don't call them on the main thread.
+@interface MatchaGoStream : NSObject
+- (id)initWithBufferSize:(NSInteger)size; // a size of 0 uses bridge.DefaultStreamBufferSize.
+- (id)initWithGoValue:(MatchaGoValue *)value;
+@property (nonatomic, readonly) MatchaGoValue *goValue;
+- (NSData *)readDataOfMaxLength:(NSInteger)length error:(NSError **)error; // returns nil at the end of the stream, or with error set if the stream was aborted.
+- (BOOL)writeData:(NSData *)data error:(NSError **)error;
+- (void)close;
+- (void)abort:(NSString *)reason;
+@end
+
 #endif // MOCHIGO_OBJC_H
@@ -24,12 +24,21 @@
 
 @end
 
+NSString *const MatchaGoErrorDomain = @"io.gomatcha.bridge";
+NSString *const MatchaGoErrorChainKey = @"MatchaGoErrorChain";
+NSString *const MatchaGoErrorStackKey = @"MatchaGoErrorStack";
+
 @implementation MatchaGoValue {
     GoRef _ref;
 }
 
 @synthesize ref = _ref;
 
+// Must match bridge.ProtocolMajor and bridge.ProtocolMinor.
++ (NSString *)protocolVersion {
+    return @"1.1";
+}
+
 - (id)initWithGoRef:(GoRef)ref {
     if ((self = [super init])) {
         _ref = ref;
@@ -71,6 +80,11 @@
     return [self initWithGoRef:matchaGoBytes(buf)];
 }
 
+- (id)initWithBytesNoCopy:(void *)bytes length:(NSUInteger)length {
+    CGoBuffer buf = {.ptr = bytes, .len = length};
+    return [self initWithGoRef:matchaGoBytesNoCopy(buf)];
+}
+
 - (id)initWithArray:(NSArray<MatchaGoValue *> *)v {
     GoRef ref = matchaGoArray();
     for (MatchaGoValue *i in v) {
@@ -172,6 +186,57 @@
     return [[MatchaGoValue alloc] initWithGoRef:rlt].toArray;
 }
 
+- (NSArray<MatchaGoValue *> *)call:(NSString *)method arguments:(NSArray<MatchaGoValue *> *)args {
+    MatchaGoValue *argsArray = [[MatchaGoValue alloc] initWithArray:args];
+    GoRef rlt = matchaGoCall(_ref, MatchaNSStringToCGoBuffer(method), argsArray.ref);
+    return [[MatchaGoValue alloc] initWithGoRef:rlt].toArray;
+}
+
+- (NSArray<MatchaGoValue *> *)tryCall:(NSString *)method arguments:(NSArray<MatchaGoValue *> *)args error:(NSError **)error {
+    NSArray<MatchaGoValue *> *rlt = [self call:method arguments:args];
+    NSError *err = rlt.lastObject.toError;
+    if (err != nil) {
+        if (error != NULL) {
+            *error = err;
+        }
+        return nil;
+    }
+    return rlt;
+}
+
+- (NSError *)toError {
+    MatchaGoValue *errorJSON = [[MatchaGoValue alloc] initWithFunc:@"gomatcha.io/matcha/bridge ErrorJSON"];
+    NSData *data = [errorJSON call:nil, self, nil][0].toData;
+    if (data.length == 0) {
+        return nil;
+    }
+    NSDictionary *dict = [NSJSONSerialization JSONObjectWithData:data options:0 error:NULL];
+    NSMutableDictionary *userInfo = [NSMutableDictionary dictionary];
+    userInfo[NSLocalizedDescriptionKey] = dict[@"message"];
+    if ([dict[@"chain"] isKindOfClass:[NSArray class]]) {
+        userInfo[MatchaGoErrorChainKey] = dict[@"chain"];
+    }
+    if ([dict[@"stack"] length] > 0) {
+        userInfo[MatchaGoErrorStackKey] = dict[@"stack"];
+    }
+    return [NSError errorWithDomain:MatchaGoErrorDomain code:[dict[@"code"] integerValue] userInfo:userInfo];
+}
+
+- (MatchaGoFuture *)callAsync:(NSString *)method, ... {
+    NSMutableArray *array = [NSMutableArray array];
+    va_list args;
+    va_start(args, method);
+    id arg = nil;
+    while ((arg = va_arg(args, id))) {
+        [array addObject:arg];
+    }
+    va_end(args);
+    
+    MatchaGoValue *callAsync = [[MatchaGoValue alloc] initWithFunc:@"gomatcha.io/matcha/bridge CallAsync"];
+    MatchaGoValue *future = [callAsync call:nil, self, [[MatchaGoValue alloc] initWithString:method ?: @""], [[MatchaGoValue alloc] initWithArray:array], nil][0];
+    return [[MatchaGoFuture alloc] initWithGoValue:future];
+}
+
 - (MatchaGoValue *)field:(NSString *)name {
     GoRef rlt = matchaGoField(_ref, MatchaNSStringToCGoBuffer(name));
     return [[MatchaGoValue alloc] initWithGoRef:rlt];
@@ -193,4 +258,109 @@
     matchaGoUntrack(_ref);
 }
 
-@end
\ No newline at end of file
+@end
+
+@interface MatchaGoFuture ()
+@property (nonatomic, strong) MatchaGoValue *future;
+@property (nonatomic, strong) NSMutableArray *blocks;
+@property (nonatomic, assign) BOOL completed;
+@end
+
+@implementation MatchaGoFuture
+
+- (id)initWithGoValue:(MatchaGoValue *)value {
+    if ((self = [super init])) {
+        self.future = value;
+        self.blocks = [NSMutableArray array];
+        [value call:@"Listen", [[MatchaGoValue alloc] initWithObject:self], nil];
+    }
+    return self;
+}
+
+- (BOOL)isDone {
+    return [self.future call:@"IsDone", nil][0].toBool;
+}
+
+- (BOOL)isCanceled {
+    return [self.future call:@"IsCanceled", nil][0].toBool;
+}
+
+- (NSError *)error {
+    return [self.future call:@"Err", nil][0].toError;
+}
+
+- (void)cancel {
+    [self.future call:@"Cancel", nil];
+}
+
+- (void)then:(void (^)(NSArray<MatchaGoValue *> *results))block {
+    @synchronized (self) {
+        if (!self.completed) {
+            [self.blocks addObject:[block copy]];
+            return;
+        }
+    }
+    [self callBlocks:@[block]];
+}
+
+// Called by Go once the function returns or the call is canceled.
+- (void)complete {
+    NSArray *blocks = nil;
+    @synchronized (self) {
+        self.completed = YES;
+        blocks = self.blocks;
+        self.blocks = [NSMutableArray array];
+    }
+    [self callBlocks:blocks];
+}
+
+- (void)callBlocks:(NSArray *)blocks {
+    NSArray<MatchaGoValue *> *results = nil;
+    if (!self.isCanceled) {
+        results = [self.future call:@"Results", nil][0].toArray;
+    }
+    dispatch_async(dispatch_get_main_queue(), ^{
+        for (void (^block)(NSArray<MatchaGoValue *> *) in blocks) {
+            block(results);
+        }
+    });
+}
+
+@end
+
+@implementation MatchaGoStream
+
+- (id)initWithBufferSize:(NSInteger)size {
+    MatchaGoValue *newStream = [[MatchaGoValue alloc] initWithFunc:@"gomatcha.io/matcha/bridge NewStream"];
+    return [self initWithGoValue:[newStream call:nil, [[MatchaGoValue alloc] initWithInt:(int)size], nil][0]];
+}
+
+- (id)initWithGoValue:(MatchaGoValue *)value {
+    if ((self = [super init])) {
+        _goValue = value;
+    }
+    return self;
+}
+
+- (NSData *)readDataOfMaxLength:(NSInteger)length error:(NSError **)error {
+    NSArray<MatchaGoValue *> *r = [self.goValue tryCall:@"ReadChunk" arguments:@[[[MatchaGoValue alloc] initWithInt:(int)length]] error:error];
+    if (r == nil) {
+        return nil;
+    }
+    NSData *data = r[0].toData;
+    return data.length > 0 ? data : nil;
+}
+
+- (BOOL)writeData:(NSData *)data error:(NSError **)error {
+    return [self.goValue tryCall:@"WriteChunk" arguments:@[[[MatchaGoValue alloc] initWithData:data]] error:error] != nil;
+}
+
+- (void)close {
+    [self.goValue call:@"Close", nil];
+}
+
+- (void)abort:(NSString *)reason {
+    [self.goValue call:@"Abort", [[MatchaGoValue alloc] initWithString:reason], nil];
+}
+
+@end
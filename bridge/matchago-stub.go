@@ -41,3 +41,8 @@ func RegisterType(str string, t reflect.Type) {
 //  NSLog(@"1+3=%@", @(c.toLongLong));
 func RegisterFunc(str string, f interface{}) {
 }
+
+// TrackedGo returns the number of Go values referenced by native code.
+func TrackedGo() int {
+	return 0
+}
@@ -1,4 +1,4 @@
-// +build matcha
+// +build matcha,!js
 
 package bridge
 
@@ -20,18 +20,21 @@ import (
 	"reflect"
 	"runtime"
 	"runtime/debug"
-	"sync"
+	"unsafe"
 )
 
-var goRoot struct {
+// goRoot is allocated in its declaration rather than in init, since the init
+// funcs of other files in the package, such as async.go, register funcs before
+// this file's init runs.
+var goRoot = struct {
 	types map[string]reflect.Type
 	funcs map[string]reflect.Value
+}{
+	types: map[string]reflect.Type{},
+	funcs: map[string]reflect.Value{},
 }
 
 func init() {
-	goRoot.types = map[string]reflect.Type{}
-	goRoot.funcs = map[string]reflect.Value{}
-
 	RegisterFunc("gomatcha.io/matcha/bridge Panic", func() {
 		panic("test panic")
 	})
@@ -47,6 +50,7 @@ func RegisterType(str string, t reflect.Type) {
 
 func RegisterFunc(str string, f interface{}) {
 	goRoot.funcs[str] = reflect.ValueOf(f)
+	traceRegisterFunc(str, reflect.ValueOf(f))
 }
 
 //export matchaGoForeign
@@ -160,6 +164,31 @@ func matchaGoToBytes(v C.GoRef) C.CGoBuffer {
 	}
 }
 
+//export matchaGoBytesNoCopy
+func matchaGoBytesNoCopy(v C.CGoBuffer) C.GoRef {
+	defer goRecover()
+	var bytes []byte
+	if v.len > 0 {
+		bytes = unsafe.Slice((*byte)(v.ptr), int(v.len))
+	}
+	rv := reflect.ValueOf(bytes)
+	return matchaGoTrack(rv)
+}
+
+//export matchaGoBytesPointer
+func matchaGoBytesPointer(v C.GoRef) C.CGoBuffer {
+	defer goRecover()
+	bytes := matchaGoGet(v).Bytes()
+	if len(bytes) == 0 {
+		return C.CGoBuffer{}
+	}
+	tracker.pin(int64(v), &bytes[0])
+	return C.CGoBuffer{
+		ptr: unsafe.Pointer(&bytes[0]),
+		len: C.int64_t(len(bytes)),
+	}
+}
+
 //export matchaGoArray
 func matchaGoArray() C.GoRef {
 	defer goRecover()
@@ -268,12 +297,15 @@ func matchaGoCall(v C.GoRef, name C.CGoBuffer, args C.GoRef) C.GoRef {
 
 	var function reflect.Value
 	if str == "" {
-		function = rv
+		function = callable(rv)
 	} else {
 		function = rv.MethodByName(str)
 	}
 	argsRv := matchaGoGet(args).Interface().([]reflect.Value)
 
+	if start, ok := traceStart(); ok {
+		defer traceEnd(start, traceName(rv, str), NativeToGo, traceArgsSize(argsRv))
+	}
 	rlt := function.Call(argsRv)
 	return matchaGoTrack(reflect.ValueOf(rlt))
 }
@@ -311,30 +343,21 @@ func matchaGoFieldSet(v C.GoRef, name C.CGoBuffer, elem C.GoRef) {
 	rv.FieldByName(str).Set(matchaGoGet(elem))
 }
 
-var tracker struct {
-	sync.Mutex
-	minRef int64
-	refs   map[int64]reflect.Value
-}
-
-func init() {
-	tracker.refs = map[int64]reflect.Value{}
+// TrackedGo returns the number of Go values referenced by native code.
+func TrackedGo() int {
+	return tracker.len()
 }
 
 func matchaGoTrack(v reflect.Value) C.GoRef {
-	tracker.Lock()
-	defer tracker.Unlock()
+	return C.GoRef(tracker.track(v))
+}
 
-	tracker.minRef -= 1
-	tracker.refs[tracker.minRef] = v
-	return C.GoRef(tracker.minRef)
+func goLeakObjects() []LeakObject {
+	return tracker.leakObjects()
 }
 
 func matchaGoGet(ref C.GoRef) reflect.Value {
-	tracker.Lock()
-	defer tracker.Unlock()
-
-	v, ok := tracker.refs[int64(ref)]
+	v, ok := tracker.get(int64(ref))
 	if !ok {
 		panic("Get error. No corresponding object for key.")
 	}
@@ -344,14 +367,9 @@ func matchaGoGet(ref C.GoRef) reflect.Value {
 //export matchaGoUntrack
 func matchaGoUntrack(ref C.GoRef) {
 	defer goRecover()
-	tracker.Lock()
-	defer tracker.Unlock()
-
-	_, ok := tracker.refs[int64(ref)]
-	if !ok {
+	if !tracker.untrack(int64(ref)) {
 		panic("Untrack error. No corresponding object for key.")
 	}
-	delete(tracker.refs, int64(ref))
 }
 
 // For better crash logs on Android
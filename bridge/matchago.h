@@ -22,6 +22,8 @@ GoRef matchaGoString(CGoBuffer); // Frees the buffer
 CGoBuffer matchaGoToString(GoRef);
 GoRef matchaGoBytes(CGoBuffer); // Frees the buffer
 CGoBuffer matchaGoToBytes(GoRef);
+GoRef matchaGoBytesNoCopy(CGoBuffer); // Wraps the memory of the buffer without copying or freeing it.
+CGoBuffer matchaGoBytesPointer(GoRef); // Pins the bytes until the GoRef is untracked. Don't free the buffer.
 
 GoRef matchaGoArray();
 int64_t matchaGoArrayLen(GoRef);
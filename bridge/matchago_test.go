@@ -0,0 +1,20 @@
+// +build matcha,!js
+
+package bridge
+
+import "testing"
+
+// The funcs registered by the init funcs of other files must survive the init
+// of matchago.go.
+func TestRegisteredFuncs(t *testing.T) {
+	for _, i := range []string{
+		"gomatcha.io/matcha/bridge CallAsync",
+		"gomatcha.io/matcha/bridge ErrorJSON",
+		"gomatcha.io/matcha/bridge NativeCallback",
+		"gomatcha.io/matcha/bridge Panic",
+	} {
+		if _, ok := goRoot.funcs[i]; !ok {
+			t.Error("Missing func", i)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package bridge
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Version of the protocol between the Go library and the native runtime, the
+// MatchaLib library on Android and the Matcha framework on iOS. The major version
+// changes with incompatible changes. Versions with the same major version whose
+// minor versions differ by one are compatible, and the newer side is responsible
+// for not using what the older side lacks.
+//
+//  1.0  Runtimes and Go libraries without the handshake.
+//  1.1  The handshake and the flat view encoding.
+const (
+	ProtocolMajor = 1
+	ProtocolMinor = 1
+)
+
+var protocol struct {
+	mu           sync.Mutex
+	major, minor int
+	handshake    bool
+}
+
+func init() {
+	RegisterFunc("gomatcha.io/matcha/bridge Handshake", handshake)
+}
+
+// ProtocolVersion returns the protocol version of the Go library, "major.minor".
+func ProtocolVersion() string {
+	return fmt.Sprintf("%v.%v", ProtocolMajor, ProtocolMinor)
+}
+
+// NativeProtocol returns the protocol version of the native runtime. Runtimes
+// that predate the handshake are reported as 1.0.
+func NativeProtocol() (major, minor int) {
+	protocol.mu.Lock()
+	defer protocol.mu.Unlock()
+	if !protocol.handshake {
+		return 1, 0
+	}
+	return protocol.major, protocol.minor
+}
+
+// NativeProtocolAtLeast returns true if the protocol version of the native
+// runtime is major.minor or newer.
+func NativeProtocolAtLeast(major, minor int) bool {
+	m, n := NativeProtocol()
+	return m > major || m == major && n >= minor
+}
+
+// handshake is called by the native runtime at init with its protocol version.
+// It returns the version of the Go library, or an error if the two versions
+// are incompatible.
+func handshake(native string) (string, error) {
+	major, minor, err := parseProtocol(native)
+	if err != nil {
+		return "", err
+	}
+	if !protocolCompatible(major, minor) {
+		return "", fmt.Errorf("matcha: the native runtime speaks bridge protocol %v but the Go library speaks %v, rebuild the Go library with the matcha version of the runtime", native, ProtocolVersion())
+	}
+	if minor != ProtocolMinor {
+		log.Printf("matcha: the native runtime speaks bridge protocol %v and the Go library speaks %v, using the compatibility shim", native, ProtocolVersion())
+	}
+
+	protocol.mu.Lock()
+	defer protocol.mu.Unlock()
+	protocol.major, protocol.minor, protocol.handshake = major, minor, true
+	return ProtocolVersion(), nil
+}
+
+func parseProtocol(s string) (major, minor int, err error) {
+	parts := strings.Split(s, ".")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("matcha: invalid bridge protocol version %q", s)
+	}
+	if major, err = strconv.Atoi(parts[0]); err != nil {
+		return 0, 0, fmt.Errorf("matcha: invalid bridge protocol version %q", s)
+	}
+	if minor, err = strconv.Atoi(parts[1]); err != nil {
+		return 0, 0, fmt.Errorf("matcha: invalid bridge protocol version %q", s)
+	}
+	return major, minor, nil
+}
+
+func protocolCompatible(major, minor int) bool {
+	if major != ProtocolMajor {
+		return false
+	}
+	return minor >= ProtocolMinor-1 && minor <= ProtocolMinor+1
+}
@@ -0,0 +1,42 @@
+// +build !matcha
+
+package bridge
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestHandshake(t *testing.T) {
+	defer func() { protocol.handshake = false }()
+
+	if major, minor := NativeProtocol(); major != 1 || minor != 0 {
+		t.Errorf("NativeProtocol() = %v.%v before the handshake, want 1.0", major, minor)
+	}
+	for _, i := range []string{"0.1", "2.1", "1", "a.b", "1.99"} {
+		if _, err := handshake(i); err == nil {
+			t.Errorf("handshake(%q) succeeded", i)
+		}
+	}
+	v, err := handshake(ProtocolVersion())
+	if err != nil || v != ProtocolVersion() {
+		t.Fatalf("handshake(%q) = %q, %v", ProtocolVersion(), v, err)
+	}
+	if !NativeProtocolAtLeast(ProtocolMajor, ProtocolMinor) || NativeProtocolAtLeast(ProtocolMajor, ProtocolMinor+1) {
+		t.Error("unexpected NativeProtocolAtLeast")
+	}
+}
+
+// The native side of the Go library declares the version separately.
+func TestProtocolVersionSources(t *testing.T) {
+	for _, i := range []string{"../cmd/GoValue.java", "matchago-objc.m"} {
+		b, err := ioutil.ReadFile(i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(b), `"`+ProtocolVersion()+`"`) {
+			t.Errorf("%v doesn't declare protocol version %v", i, ProtocolVersion())
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package bridge
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+// DefaultStreamBufferSize is the buffer size of streams created with a size of
+// 0 or less.
+const DefaultStreamBufferSize = 64 << 10
+
+// ErrStreamClosed is returned by writes to a closed Stream.
+var ErrStreamClosed = errors.New("bridge: write to closed stream")
+
+func init() {
+	RegisterFunc("gomatcha.io/matcha/bridge NewStream", NewStream)
+}
+
+// Stream moves large payloads, like files, camera frames or audio buffers,
+// between Go and native code in chunks, so that neither side holds the whole
+// payload in memory. It is a pipe with a bounded buffer: writes block while the
+// buffer is full and reads block while it is empty.
+//
+// A stream has one direction. One side writes and closes it, and the other
+// reads until the end. Go code uses it as an io.Reader or io.Writer, and native
+// code calls its WriteChunk, ReadChunk, Close and Abort methods, wrapped by
+// MatchaGoStream on iOS and GoStream on Android. Native code should not call
+// them from the main thread, since they block.
+//
+// Go:
+//  func Upload(s *bridge.Stream) error {
+//      _, err := io.Copy(dst, s)
+//      return err
+//  }
+//
+// Objective-C:
+//  MatchaGoStream *stream = [[MatchaGoStream alloc] initWithBufferSize:0];
+//  MatchaGoFuture *upload = [[[MatchaGoValue alloc] initWithFunc:@"example.com/app Upload"] callAsync:nil, stream.goValue, nil];
+//  while ((data = nextChunk())) {
+//      [stream writeData:data error:&error];
+//  }
+//  [stream close];
+type Stream struct {
+	mu     sync.Mutex
+	cond   sync.Cond
+	buf    []byte
+	size   int
+	closed bool  // No more writes.
+	err    error // Set by CloseWithError.
+}
+
+// NewStream returns a stream that buffers up to bufferSize bytes, or
+// DefaultStreamBufferSize if bufferSize is 0 or less.
+func NewStream(bufferSize int) *Stream {
+	if bufferSize <= 0 {
+		bufferSize = DefaultStreamBufferSize
+	}
+	s := &Stream{size: bufferSize}
+	s.cond.L = &s.mu
+	return s
+}
+
+// Read reads up to len(p) buffered bytes, blocking until some are written. It
+// returns io.EOF once the stream is closed and drained.
+func (s *Stream) Read(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for len(s.buf) == 0 && !s.closed {
+		s.cond.Wait()
+	}
+	if s.err != nil {
+		return 0, s.err
+	}
+	if len(s.buf) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, s.buf)
+	s.buf = append(s.buf[:0], s.buf[n:]...)
+	s.cond.Broadcast()
+	return n, nil
+}
+
+// Write writes p to the stream, blocking while the buffer is full.
+func (s *Stream) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for len(p) > 0 {
+		for len(s.buf) == s.size && !s.closed {
+			s.cond.Wait()
+		}
+		if s.err != nil {
+			return n, s.err
+		}
+		if s.closed {
+			return n, ErrStreamClosed
+		}
+		m := s.size - len(s.buf)
+		if m > len(p) {
+			m = len(p)
+		}
+		s.buf = append(s.buf, p[:m]...)
+		p = p[m:]
+		n += m
+		s.cond.Broadcast()
+	}
+	return n, nil
+}
+
+// Close ends the stream. The reader receives io.EOF after the buffered bytes.
+func (s *Stream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	s.cond.Broadcast()
+	return nil
+}
+
+// CloseWithError aborts the stream. Buffered bytes are discarded, and pending
+// and later reads and writes return err. Either side may abort.
+func (s *Stream) CloseWithError(err error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err == nil {
+		s.err = err
+	}
+	s.closed = true
+	s.buf = nil
+	s.cond.Broadcast()
+	return nil
+}
+
+// WriteChunk writes b to the stream. It is called by native code.
+func (s *Stream) WriteChunk(b []byte) error {
+	_, err := s.Write(b)
+	return err
+}
+
+// ReadChunk reads up to max bytes, blocking until some are written. It returns
+// nil at the end of the stream. It is called by native code.
+func (s *Stream) ReadChunk(max int) ([]byte, error) {
+	if max <= 0 {
+		max = s.size
+	}
+	b := make([]byte, max)
+	n, err := s.Read(b)
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+
+// Abort aborts the stream with an error with the message reason. It is called
+// by native code.
+func (s *Stream) Abort(reason string) {
+	s.CloseWithError(errors.New(reason))
+}
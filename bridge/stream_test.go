@@ -0,0 +1,57 @@
+// +build !matcha
+
+package bridge
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+)
+
+func TestStream(t *testing.T) {
+	s := NewStream(4)
+	data := []byte("a payload larger than the buffer")
+	go func() {
+		s.WriteChunk(data[:10])
+		s.WriteChunk(data[10:])
+		s.Close()
+	}()
+	got := []byte{}
+	for {
+		b, err := s.ReadChunk(3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b == nil {
+			break
+		}
+		if len(b) > 3 {
+			t.Fatalf("ReadChunk(3) returned %v bytes", len(b))
+		}
+		got = append(got, b...)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("got %q, want %q", got, data)
+	}
+	if _, err := s.Write([]byte{1}); err != ErrStreamClosed {
+		t.Errorf("Write after Close = %v, want ErrStreamClosed", err)
+	}
+
+	s = NewStream(0)
+	go func() {
+		s.Write([]byte("abc"))
+		s.Abort("camera disconnected")
+	}()
+	if _, err := ioutil.ReadAll(s); err == nil || err.Error() != "camera disconnected" {
+		t.Errorf("ReadAll after Abort = %v", err)
+	}
+
+	s = NewStream(1)
+	errStop := errors.New("stop")
+	go s.CloseWithError(errStop)
+	if _, err := io.Copy(s, bytes.NewReader(data)); err != errStop {
+		t.Errorf("Copy after CloseWithError = %v, want %v", err, errStop)
+	}
+}
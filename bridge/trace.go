@@ -0,0 +1,181 @@
+package bridge
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Direction is the direction of a traced call.
+type Direction int
+
+const (
+	// GoToNative is a call of a native method with Value.Call.
+	GoToNative Direction = iota
+	// NativeToGo is a call of a Go function or method by native code.
+	NativeToGo
+)
+
+// String implements the fmt.Stringer interface.
+func (d Direction) String() string {
+	if d == NativeToGo {
+		return "native->go"
+	}
+	return "go->native"
+}
+
+// TraceEvent is a call across the bridge recorded by the tracer.
+type TraceEvent struct {
+	Name      string        `json:"name"` // Selector, method or function name.
+	Direction Direction     `json:"direction"`
+	Bytes     int           `json:"bytes"` // Size of the strings and byte slices passed as arguments.
+	Start     time.Time     `json:"start"`
+	Duration  time.Duration `json:"duration"`
+}
+
+var tracer struct {
+	enabled int32 // Read without holding mu, so calls are cheap while tracing is off.
+	mu      sync.Mutex
+	events  []TraceEvent
+	next    int
+	full    bool
+	names   map[uintptr]string // Registered names of functions, by entry point.
+}
+
+// StartTrace starts recording bridge calls into a ring buffer holding the last
+// size calls. Events recorded by a previous trace are discarded.
+//
+//  func init() {
+//      bridge.StartTrace(10000)
+//      go bridgetrace.ListenAndServe("localhost:6062")
+//  }
+func StartTrace(size int) {
+	if size <= 0 {
+		size = 1
+	}
+	tracer.mu.Lock()
+	defer tracer.mu.Unlock()
+	tracer.events = make([]TraceEvent, size)
+	tracer.next = 0
+	tracer.full = false
+	atomic.StoreInt32(&tracer.enabled, 1)
+}
+
+// StopTrace stops recording calls. The recorded events are kept until the next
+// StartTrace.
+func StopTrace() {
+	atomic.StoreInt32(&tracer.enabled, 0)
+}
+
+// Tracing returns true between StartTrace and StopTrace.
+func Tracing() bool {
+	return atomic.LoadInt32(&tracer.enabled) != 0
+}
+
+// TraceEvents returns the recorded events, oldest first.
+func TraceEvents() []TraceEvent {
+	tracer.mu.Lock()
+	defer tracer.mu.Unlock()
+
+	if !tracer.full {
+		return append([]TraceEvent(nil), tracer.events[:tracer.next]...)
+	}
+	events := make([]TraceEvent, 0, len(tracer.events))
+	events = append(events, tracer.events[tracer.next:]...)
+	return append(events, tracer.events[:tracer.next]...)
+}
+
+// WriteTrace writes the recorded events to w as a JSON array. This is the format
+// read by `matcha trace dump`.
+func WriteTrace(w io.Writer) error {
+	return json.NewEncoder(w).Encode(TraceEvents())
+}
+
+// traceStart returns the start time of a call, and false if tracing is off.
+func traceStart() (time.Time, bool) {
+	if atomic.LoadInt32(&tracer.enabled) == 0 {
+		return time.Time{}, false
+	}
+	return time.Now(), true
+}
+
+func traceEnd(start time.Time, name string, d Direction, bytes int) {
+	e := TraceEvent{Name: name, Direction: d, Bytes: bytes, Start: start, Duration: time.Since(start)}
+
+	tracer.mu.Lock()
+	defer tracer.mu.Unlock()
+	if len(tracer.events) == 0 {
+		return
+	}
+	tracer.events[tracer.next] = e
+	tracer.next++
+	if tracer.next == len(tracer.events) {
+		tracer.next = 0
+		tracer.full = true
+	}
+}
+
+// traceRegisterFunc records the name a function is registered under, so that
+// calls from native code are traced by name.
+func traceRegisterFunc(name string, f reflect.Value) {
+	if f.Kind() != reflect.Func {
+		return
+	}
+	tracer.mu.Lock()
+	defer tracer.mu.Unlock()
+	if tracer.names == nil {
+		tracer.names = map[uintptr]string{}
+	}
+	tracer.names[f.Pointer()] = name
+}
+
+// traceName returns the name of a call of method on rv by native code.
+func traceName(rv reflect.Value, method string) string {
+	if !rv.IsValid() {
+		return method
+	}
+	if method != "" {
+		return rv.Type().String() + "." + method
+	}
+	if rv.Kind() != reflect.Func {
+		return rv.Type().String()
+	}
+	tracer.mu.Lock()
+	name, ok := tracer.names[rv.Pointer()]
+	tracer.mu.Unlock()
+	if ok {
+		return name
+	}
+	if f := runtime.FuncForPC(rv.Pointer()); f != nil {
+		return f.Name()
+	}
+	return rv.Type().String()
+}
+
+// traceValuesSize returns the payload size of args passed to native code.
+func traceValuesSize(args []*Value) int {
+	n := 0
+	for _, i := range args {
+		n += i.payloadSize()
+	}
+	return n
+}
+
+// traceArgsSize returns the payload size of args passed to Go.
+func traceArgsSize(args []reflect.Value) int {
+	n := 0
+	for _, i := range args {
+		switch {
+		case !i.IsValid():
+		case i.Kind() == reflect.String:
+			n += i.Len()
+		case i.Kind() == reflect.Slice && i.Type().Elem().Kind() == reflect.Uint8:
+			n += i.Len()
+		}
+	}
+	return n
+}
@@ -0,0 +1,41 @@
+// +build !matcha
+
+package bridge
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTrace(t *testing.T) {
+	StubCall = func(bridge string, s string, args []*Value) *Value {
+		return nil
+	}
+	defer func() { StubCall = nil }()
+
+	Bridge("").Call("untraced")
+	StartTrace(2)
+	defer StopTrace()
+	Bridge("").Call("a", String("abc"), Array(Bytes([]byte{1, 2})))
+	Bridge("").Call("b")
+	Bridge("").Call("c")
+
+	events := TraceEvents()
+	if len(events) != 2 || events[0].Name != "b" || events[1].Name != "c" {
+		t.Fatal("TraceEvents", events)
+	}
+
+	StartTrace(10)
+	Bridge("").Call("a", String("abc"), Array(Bytes([]byte{1, 2})))
+	StopTrace()
+	Bridge("").Call("b")
+	if events := TraceEvents(); len(events) != 1 || events[0].Bytes != 5 || events[0].Direction != GoToNative {
+		t.Error("Bytes", events)
+	}
+
+	f := func() {}
+	traceRegisterFunc("gomatcha.io/matcha/bridge f", reflect.ValueOf(f))
+	if name := traceName(reflect.ValueOf(f), ""); name != "gomatcha.io/matcha/bridge f" {
+		t.Error("traceName", name)
+	}
+}
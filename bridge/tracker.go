@@ -0,0 +1,122 @@
+package bridge
+
+import (
+	"reflect"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+const trackerShards = 32
+
+var tracker goTracker
+
+// goTracker holds the Go values referenced by native code, keyed by negative
+// refs. Refs are spread over shards so that goroutines calling the bridge
+// concurrently don't contend on a single lock, and lookups, the most frequent
+// operation, only take a shard's read lock.
+type goTracker struct {
+	minRef int64 // Last allocated ref.
+	shards [trackerShards]trackerShard
+}
+
+type trackerShard struct {
+	sync.RWMutex
+	refs   map[int64]reflect.Value
+	stacks map[int64]string // Allocation stacks while leak tracking is enabled.
+	pins   map[int64]*runtime.Pinner
+	_      [64]byte // Keeps shards on separate cache lines.
+}
+
+func (t *goTracker) shard(ref int64) *trackerShard {
+	return &t.shards[uint64(-ref)%trackerShards]
+}
+
+func (t *goTracker) track(v reflect.Value) int64 {
+	stack, ok := leakStack()
+	ref := atomic.AddInt64(&t.minRef, -1)
+
+	s := t.shard(ref)
+	s.Lock()
+	defer s.Unlock()
+	if s.refs == nil {
+		s.refs = map[int64]reflect.Value{}
+	}
+	s.refs[ref] = v
+	if ok {
+		if s.stacks == nil {
+			s.stacks = map[int64]string{}
+		}
+		s.stacks[ref] = stack
+	}
+	return ref
+}
+
+func (t *goTracker) get(ref int64) (reflect.Value, bool) {
+	s := t.shard(ref)
+	s.RLock()
+	defer s.RUnlock()
+	v, ok := s.refs[ref]
+	return v, ok
+}
+
+func (t *goTracker) untrack(ref int64) bool {
+	s := t.shard(ref)
+	s.Lock()
+	defer s.Unlock()
+	if _, ok := s.refs[ref]; !ok {
+		return false
+	}
+	delete(s.refs, ref)
+	delete(s.stacks, ref)
+	if p, ok := s.pins[ref]; ok {
+		p.Unpin()
+		delete(s.pins, ref)
+	}
+	return true
+}
+
+// pin pins the Go memory at p, which native code reads without copying, until
+// ref is untracked.
+func (t *goTracker) pin(ref int64, p interface{}) {
+	s := t.shard(ref)
+	s.Lock()
+	defer s.Unlock()
+	if s.pins == nil {
+		s.pins = map[int64]*runtime.Pinner{}
+	}
+	pinner, ok := s.pins[ref]
+	if !ok {
+		pinner = &runtime.Pinner{}
+		s.pins[ref] = pinner
+	}
+	pinner.Pin(p)
+}
+
+func (t *goTracker) len() int {
+	n := 0
+	for i := range t.shards {
+		s := &t.shards[i]
+		s.RLock()
+		n += len(s.refs)
+		s.RUnlock()
+	}
+	return n
+}
+
+func (t *goTracker) leakObjects() []LeakObject {
+	objects := []LeakObject{}
+	for i := range t.shards {
+		s := &t.shards[i]
+		s.RLock()
+		for ref, v := range s.refs {
+			typ := "<invalid>"
+			if v.IsValid() {
+				typ = v.Type().String()
+			}
+			objects = append(objects, LeakObject{Ref: ref, Type: typ, Stack: s.stacks[ref]})
+		}
+		s.RUnlock()
+	}
+	return objects
+}
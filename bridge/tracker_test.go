@@ -0,0 +1,110 @@
+// +build !matcha
+
+package bridge
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestGoTracker(t *testing.T) {
+	tr := &goTracker{}
+	refs := []int64{}
+	for i := 0; i < 100; i++ {
+		refs = append(refs, tr.track(reflect.ValueOf(i)))
+	}
+	if n := tr.len(); n != 100 {
+		t.Fatalf("len() = %v, want 100", n)
+	}
+	for i, ref := range refs {
+		v, ok := tr.get(ref)
+		if !ok || v.Interface() != i {
+			t.Errorf("get(%v) = %v, %v, want %v", ref, v, ok, i)
+		}
+	}
+	for _, ref := range refs {
+		if !tr.untrack(ref) {
+			t.Errorf("untrack(%v) = false", ref)
+		}
+	}
+	if tr.untrack(refs[0]) {
+		t.Error("untrack of an untracked ref succeeded")
+	}
+	if n := tr.len(); n != 0 {
+		t.Errorf("len() = %v, want 0", n)
+	}
+
+	b := []byte{1, 2, 3}
+	ref := tr.track(reflect.ValueOf(b))
+	tr.pin(ref, &b[0])
+	tr.pin(ref, &b[1])
+	if s := tr.shard(ref); len(s.pins) != 1 {
+		t.Errorf("len(pins) = %v, want 1", len(s.pins))
+	}
+	tr.untrack(ref)
+	if s := tr.shard(ref); len(s.pins) != 0 {
+		t.Errorf("pins left after untrack: %v", len(s.pins))
+	}
+}
+
+// mutexTracker is the tracker serialized by a single mutex that goTracker
+// replaced, for comparison.
+type mutexTracker struct {
+	sync.Mutex
+	minRef int64
+	refs   map[int64]reflect.Value
+}
+
+func (t *mutexTracker) track(v reflect.Value) int64 {
+	t.Lock()
+	defer t.Unlock()
+	t.minRef--
+	t.refs[t.minRef] = v
+	return t.minRef
+}
+
+func (t *mutexTracker) get(ref int64) (reflect.Value, bool) {
+	t.Lock()
+	defer t.Unlock()
+	v, ok := t.refs[ref]
+	return v, ok
+}
+
+func (t *mutexTracker) untrack(ref int64) bool {
+	t.Lock()
+	defer t.Unlock()
+	_, ok := t.refs[ref]
+	delete(t.refs, ref)
+	return ok
+}
+
+type benchTracker interface {
+	track(reflect.Value) int64
+	get(int64) (reflect.Value, bool)
+	untrack(int64) bool
+}
+
+// benchmarkTracker simulates concurrent bridge calls, which track their
+// arguments, look them up a few times and untrack them.
+func benchmarkTracker(b *testing.B, tr benchTracker) {
+	v := reflect.ValueOf(1)
+	b.SetParallelism(8)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			ref := tr.track(v)
+			for i := 0; i < 4; i++ {
+				tr.get(ref)
+			}
+			tr.untrack(ref)
+		}
+	})
+}
+
+func BenchmarkTrackerSharded(b *testing.B) {
+	benchmarkTracker(b, &goTracker{})
+}
+
+func BenchmarkTrackerMutex(b *testing.B) {
+	benchmarkTracker(b, &mutexTracker{refs: map[int64]reflect.Value{}})
+}
@@ -0,0 +1,59 @@
+package io.gomatcha.bridge;
+
+import org.json.JSONArray;
+import org.json.JSONException;
+import org.json.JSONObject;
+
+// GoException is thrown by GoValue.callOrThrow when a Go function returns a
+// non-nil error. The code is the one passed to bridge.WithCode.
+public class GoException extends RuntimeException {
+   private final int code;
+   private final String[] chain;
+   private final String goStackTrace;
+
+   GoException(String message, int code, String[] chain, String goStackTrace) {
+      super(message);
+      this.code = code;
+      this.chain = chain;
+      this.goStackTrace = goStackTrace;
+   }
+
+   static GoException fromJSON(String json) {
+      try {
+         JSONObject obj = new JSONObject(json);
+         JSONArray array = obj.optJSONArray("chain");
+         String[] chain = new String[array == null ? 0 : array.length()];
+         for (int i = 0; i < chain.length; i++) {
+            chain[i] = array.getString(i);
+         }
+         return new GoException(obj.optString("message"), obj.optInt("code"), chain, obj.optString("stack"));
+      } catch (JSONException e) {
+         return new GoException(json, 0, new String[0], "");
+      }
+   }
+
+   public int getCode() {
+      return code;
+   }
+
+   // getChain returns the messages of the error and the errors it wraps,
+   // outermost first.
+   public String[] getChain() {
+      return chain;
+   }
+
+   // getGoStackTrace returns the stack trace recorded by bridge.WithCode, or an
+   // empty string.
+   public String getGoStackTrace() {
+      return goStackTrace;
+   }
+
+   @Override
+   public String toString() {
+      String s = super.toString();
+      if (goStackTrace.length() > 0) {
+         s += "\n" + goStackTrace;
+      }
+      return s;
+   }
+}
@@ -0,0 +1,97 @@
+package io.gomatcha.bridge;
+
+import java.util.ArrayList;
+import java.util.List;
+import java.util.concurrent.CancellationException;
+import java.util.concurrent.CountDownLatch;
+import java.util.concurrent.ExecutionException;
+import java.util.concurrent.Executor;
+import java.util.concurrent.Future;
+import java.util.concurrent.TimeUnit;
+import java.util.concurrent.TimeoutException;
+
+// GoFuture is the result of GoValue.callAsync. It has the same addListener method
+// as Guava's ListenableFuture, so it can be adapted with JdkFutureAdapters or
+// wrapped in a SettableFuture.
+public class GoFuture implements Future<GoValue[]> {
+   private final GoValue future;
+   private final CountDownLatch latch = new CountDownLatch(1);
+   private final List<Runnable> listeners = new ArrayList<Runnable>();
+   private final List<Executor> executors = new ArrayList<Executor>();
+   
+   GoFuture(GoValue future) {
+      this.future = future;
+      future.call("Listen", new GoValue(this));
+   }
+   
+   // Called by Go once the function returns or the call is canceled.
+   public void complete() {
+      List<Runnable> l;
+      List<Executor> e;
+      synchronized (this) {
+         latch.countDown();
+         l = new ArrayList<Runnable>(listeners);
+         e = new ArrayList<Executor>(executors);
+         listeners.clear();
+         executors.clear();
+      }
+      for (int i = 0; i < l.size(); i++) {
+         e.get(i).execute(l.get(i));
+      }
+   }
+   
+   public void addListener(Runnable listener, Executor executor) {
+      synchronized (this) {
+         if (latch.getCount() > 0) {
+            listeners.add(listener);
+            executors.add(executor);
+            return;
+         }
+      }
+      executor.execute(listener);
+   }
+   
+   @Override
+   public boolean cancel(boolean mayInterruptIfRunning) {
+      if (isDone()) {
+         return false;
+      }
+      future.call("Cancel");
+      return isCancelled();
+   }
+   
+   @Override
+   public boolean isCancelled() {
+      return future.call("IsCanceled")[0].toBool();
+   }
+   
+   @Override
+   public boolean isDone() {
+      return latch.getCount() == 0;
+   }
+   
+   @Override
+   public GoValue[] get() throws InterruptedException, ExecutionException {
+      latch.await();
+      return results();
+   }
+   
+   @Override
+   public GoValue[] get(long timeout, TimeUnit unit) throws InterruptedException, ExecutionException, TimeoutException {
+      if (!latch.await(timeout, unit)) {
+         throw new TimeoutException();
+      }
+      return results();
+   }
+   
+   private GoValue[] results() throws ExecutionException {
+      if (isCancelled()) {
+         throw new CancellationException();
+      }
+      GoException e = future.call("Err")[0].toException();
+      if (e != null) {
+         throw new ExecutionException(e);
+      }
+      return future.call("Results")[0].toArray();
+   }
+}
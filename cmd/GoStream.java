@@ -0,0 +1,111 @@
+package io.gomatcha.bridge;
+
+import java.io.IOException;
+import java.io.InputStream;
+import java.io.OutputStream;
+
+// GoStream wraps a bridge.Stream, a pipe with a bounded buffer that moves large
+// payloads between Go and Java in chunks. The methods block until the other
+// side catches up, so don't call them on the main thread.
+public class GoStream {
+   public final GoValue goValue;
+   
+   // A bufferSize of 0 uses bridge.DefaultStreamBufferSize.
+   public GoStream(int bufferSize) {
+      this(GoValue.withFunc("gomatcha.io/matcha/bridge NewStream").call("", new GoValue(bufferSize))[0]);
+   }
+   
+   public GoStream(GoValue v) {
+      goValue = v;
+   }
+   
+   // read returns up to max bytes, or null at the end of the stream. It throws
+   // an IOException if the stream was aborted.
+   public byte[] read(int max) throws IOException {
+      GoValue[] rlt = goValue.call("ReadChunk", new GoValue(max));
+      GoException e = rlt[1].toException();
+      if (e != null) {
+         throw new IOException(e.getMessage(), e);
+      }
+      byte[] b = rlt[0].toByteArray();
+      if (b == null || b.length == 0) {
+         return null;
+      }
+      return b;
+   }
+   
+   public void write(byte[] b) throws IOException {
+      GoException e = goValue.call("WriteChunk", new GoValue(b))[0].toException();
+      if (e != null) {
+         throw new IOException(e.getMessage(), e);
+      }
+   }
+   
+   public void close() {
+      goValue.call("Close");
+   }
+   
+   public void abort(String reason) {
+      goValue.call("Abort", new GoValue(reason));
+   }
+   
+   // inputStream returns an InputStream reading from the stream.
+   public InputStream inputStream() {
+      return new InputStream() {
+         private byte[] chunk;
+         private int offset;
+         
+         @Override
+         public int read() throws IOException {
+            byte[] b = new byte[1];
+            return read(b, 0, 1) == -1 ? -1 : b[0] & 0xff;
+         }
+         
+         @Override
+         public int read(byte[] b, int off, int len) throws IOException {
+            if (len == 0) {
+               return 0;
+            }
+            if (chunk == null || offset == chunk.length) {
+               chunk = GoStream.this.read(len);
+               offset = 0;
+               if (chunk == null) {
+                  return -1;
+               }
+            }
+            int n = Math.min(len, chunk.length - offset);
+            System.arraycopy(chunk, offset, b, off, n);
+            offset += n;
+            return n;
+         }
+         
+         @Override
+         public void close() {
+            GoStream.this.abort("stream closed by the reader");
+         }
+      };
+   }
+   
+   // outputStream returns an OutputStream writing to the stream. Closing it
+   // closes the stream.
+   public OutputStream outputStream() {
+      return new OutputStream() {
+         @Override
+         public void write(int b) throws IOException {
+            GoStream.this.write(new byte[]{(byte) b});
+         }
+         
+         @Override
+         public void write(byte[] b, int off, int len) throws IOException {
+            byte[] chunk = new byte[len];
+            System.arraycopy(b, off, chunk, 0, len);
+            GoStream.this.write(chunk);
+         }
+         
+         @Override
+         public void close() {
+            GoStream.this.close();
+         }
+      };
+   }
+}
@@ -1,5 +1,7 @@
 package io.gomatcha.bridge;
 
+import java.nio.ByteBuffer;
+
 public class GoValue {
    static {
       System.loadLibrary("gojni");
@@ -9,7 +11,12 @@ public class GoValue {
    
    private static native void matchaInit(Object tracker);
    
+   // PROTOCOL_VERSION is the bridge protocol version of the Go library. It must
+   // match bridge.ProtocolMajor and bridge.ProtocolMinor.
+   public static final String PROTOCOL_VERSION = "1.1";
+   
    protected long goRef;
+   private ByteBuffer buffer; // Keeps the memory of fromDirectBuffer alive.
    
    protected GoValue(long goref, boolean empty) {
       this.goRef = goref;
@@ -39,6 +46,18 @@ public class GoValue {
    public GoValue(GoValue[] v) {
       this(makeGoArray(v), false);
    }
+   // fromDirectBuffer returns a Go []byte backed by the memory of the direct
+   // buffer b, from its position to its limit, without copying. Camera frames
+   // and tensors can be passed to Go this way. The slice is valid while the
+   // GoValue is reachable, Go code must copy it to keep it longer.
+   public static GoValue fromDirectBuffer(ByteBuffer b) {
+      if (!b.isDirect()) {
+         throw new IllegalArgumentException("GoValue.fromDirectBuffer: the buffer isn't direct");
+      }
+      GoValue v = new GoValue(matchaGoDirectBuffer(b, b.position(), b.remaining()), false);
+      v.buffer = b;
+      return v;
+   }
    public static GoValue withFunc(String v) {
       return new GoValue(matchaGoFunc(v), false);
    }
@@ -60,6 +79,7 @@ public class GoValue {
    private static native long matchaGoDouble(double a);
    private static native long matchaGoString(String a);
    private static native long matchaGoByteArray(byte[] v);
+   private static native long matchaGoDirectBuffer(ByteBuffer v, int position, int length);
    private static native long matchaGoArray(long[] v);
    private static native long matchaGoFunc(String a);
    private static native long matchaGoType(String a);
@@ -83,6 +103,16 @@ public class GoValue {
    public byte[] toByteArray() {
       return matchaGoToByteArray(this.goRef);
    }
+   // toDirectBuffer returns a direct buffer backed by the memory of the Go
+   // []byte, without copying. The memory is pinned while the GoValue is
+   // reachable, so keep a reference to it as long as the buffer is used.
+   public ByteBuffer toDirectBuffer() {
+      ByteBuffer b = matchaGoToDirectBuffer(this.goRef);
+      if (b == null) {
+         return ByteBuffer.allocateDirect(0);
+      }
+      return b;
+   }
    public GoValue[] toArray() {
       long[] array = matchaGoToArray(this.goRef);
       
@@ -99,6 +129,7 @@ public class GoValue {
    private static native double matchaGoToDouble(long a);
    private static native String matchaGoToString(long a);
    private static native byte[] matchaGoToByteArray(long a);
+   private static native ByteBuffer matchaGoToDirectBuffer(long a);
    private static native long[] matchaGoToArray(long a);
    
    public GoValue elem() {
@@ -122,6 +153,40 @@ public class GoValue {
       return new GoValue(goRef, false).toArray();
    }
    
+   // callOrThrow calls the function or method, and throws a GoException if
+   // its last result is a non-nil Go error.
+   public GoValue[] callOrThrow(String v, GoValue...v2) {
+      GoValue[] rlt = call(v, v2);
+      if (rlt.length > 0) {
+         GoException e = rlt[rlt.length - 1].toException();
+         if (e != null) {
+            throw e;
+         }
+      }
+      return rlt;
+   }
+   
+   // toException returns a GoException describing the value, or null unless it
+   // is a non-nil Go error.
+   public GoException toException() {
+      byte[] json = GoValue.withFunc("gomatcha.io/matcha/bridge ErrorJSON").call("", this)[0].toByteArray();
+      if (json == null || json.length == 0) {
+         return null;
+      }
+      return GoException.fromJSON(new String(json, java.nio.charset.Charset.forName("UTF-8")));
+   }
+   
+   // callAsync calls the function or method on a goroutine, without blocking
+   // the calling thread. If the Go function's first parameter is a
+   // context.Context, it is canceled when the GoFuture is.
+   public GoFuture callAsync(String v, GoValue...v2) {
+      if (v2 == null) {
+         v2 = new GoValue[0];
+      }
+      GoValue f = GoValue.withFunc("gomatcha.io/matcha/bridge CallAsync").call("", this, new GoValue(v), new GoValue(v2))[0];
+      return new GoFuture(f);
+   }
+   
    public GoValue field(String v) {
       return new GoValue(matchaGoField(this.goRef, v), false);
    }
@@ -2,37 +2,57 @@ package io.gomatcha.bridge;
 
 import java.util.Map;
 import java.util.HashMap;
+import android.os.Handler;
+import android.os.Looper;
 import android.util.Log;
 import java.lang.reflect.Method;
 import java.lang.reflect.InvocationTargetException;
+import java.util.ArrayList;
 import java.util.Arrays;
+import java.util.List;
+import java.util.concurrent.ConcurrentHashMap;
+import java.util.concurrent.atomic.AtomicLong;
+import org.json.JSONArray;
+import org.json.JSONException;
+import org.json.JSONObject;
 
+// Tracker holds the Java objects referenced by Go. It is called concurrently from
+// every goroutine calling the bridge, so it doesn't serialize calls on a lock.
 public class Tracker {
     private static final Tracker instance = new Tracker();
-    private Map<Long, Object> mapTable = new HashMap<Long, Object>();
-    private long maxKey = 0;
+    private final Map<Long, Object> mapTable = new ConcurrentHashMap<Long, Object>();
+    private final AtomicLong maxKey = new AtomicLong();
+    private volatile Map<Long, String> stacks = null; // Allocation stacks while leak tracking is enabled.
     private Tracker() {
     }
     public static Tracker singleton() {
         return instance;
     }
-    public synchronized long track(Object v) {
+    public long track(Object v) {
         if (v == null) {
             return 0;
         }
-        this.maxKey += 1;
-        this.mapTable.put(this.maxKey, v);
-        return this.maxKey;
+        long key = this.maxKey.incrementAndGet();
+        this.mapTable.put(key, v);
+        Map<Long, String> stacks = this.stacks;
+        if (stacks != null) {
+            stacks.put(key, Log.getStackTraceString(new Throwable()));
+        }
+        return key;
     }
-    public synchronized void untrack(long v) {
+    public void untrack(long v) {
         if (v == 0) {
             return;
         }
         if (this.mapTable.remove(v) == null) {
             throw new IllegalArgumentException("Tracker doesn't contain key");
         }
+        Map<Long, String> stacks = this.stacks;
+        if (stacks != null) {
+            stacks.remove(v);
+        }
     }
-    public synchronized Object get(long v) {
+    public Object get(long v) {
         if (v == 0) {
             return null;
         }
@@ -43,11 +63,11 @@ public class Tracker {
         }
         return a;
     }
-    public synchronized long foreignBridge(String key) {
+    public long foreignBridge(String key) {
         Bridge bridge = Bridge.singleton();
         return track(bridge.get(key));
     }
-    public synchronized long foreignCall(long v, String method, long args) {
+    public long foreignCall(long v, String method, long args) {
         Object[] va = (Object[])this.get(args);
         int len = 0;
         if (va != null) {
@@ -79,68 +99,142 @@ public class Tracker {
         }
         return test;
     }
-    public synchronized long foreignBool(boolean v) {
+    public String foreignDescribeException(Throwable e) {
+        // Unwrap the RuntimeException and InvocationTargetException added by foreignCall.
+        while (e.getCause() != null) {
+            e = e.getCause();
+        }
+        return e.toString();
+    }
+    public long foreignBool(boolean v) {
         return track(v);
     }
-    public synchronized boolean foreignToBool(long v) {
+    public boolean foreignToBool(long v) {
         boolean a = (Boolean)this.get(v);
         return a;
     }
-    public synchronized long foreignInt64(long v) {
+    public long foreignInt64(long v) {
         return track(v);
     }
-    public synchronized long foreignToInt64(long v) {
+    public long foreignToInt64(long v) {
         Object a = this.get(v);
         if (a instanceof Integer) {
             return ((Integer)a).longValue();
         }
         return (Long)a;
     }
-    public synchronized long foreignFloat64(double v) {
+    public long foreignFloat64(double v) {
         return track(v);
     }
-    public synchronized double foreignToFloat64(long v) {
+    public double foreignToFloat64(long v) {
         Object a = this.get(v);
         if (a instanceof Float) {
             return ((Float)a).doubleValue();
         }
         return (Double)a;
     }
-    public synchronized long foreignGoRef(long v) {
+    public long foreignGoRef(long v) {
         return track(new GoValue(v, false));
     }
-    public synchronized long foreignToGoRef(long v) {
+    public long foreignToGoRef(long v) {
         return ((GoValue)this.get(v)).goRef;
     }
-    public synchronized long foreignString(String v) {
+    public long foreignString(String v) {
         return track(v);
     }
-    public synchronized String foreignToString(long v) {
+    public String foreignToString(long v) {
         return (String)this.get(v);
     }
-    public synchronized long foreignBytes(byte[] v) {
+    public long foreignBytes(byte[] v) {
         return track(v);
     }
-    public synchronized byte[] foreignToBytes(long v) {
+    public byte[] foreignToBytes(long v) {
         return (byte[])this.get(v);
     }
-    public synchronized long foreignArray(int v) {
+    public long foreignArray(int v) {
         Object[] a = new Object[v];
         return track(a);
     }
-    public synchronized void foreignArraySet(long v, long val, int idx) {
+    public void foreignArraySet(long v, long val, int idx) {
         Object[] a = (Object[])this.get(v);
         a[idx] = this.get(val);
     }
-    public synchronized long foreignArrayAt(long v, int idx) {
-        Object[] a = (Object[])this.get(v);
-        return track(a[idx]);
+    public long foreignArrayAt(long v, int idx) {
+        Object a = this.get(v);
+        if (a instanceof List) {
+            return track(((List)a).get(idx));
+        }
+        return track(((Object[])a)[idx]);
     }
-    public synchronized long foreignArrayLen(long v) {
+    public long foreignArrayLen(long v) {
+        Object a = this.get(v);
+        if (a instanceof List) {
+            return ((List)a).size();
+        }
+        return ((Object[])a).length;
+    }
+    public long foreignList(long v) {
         Object[] a = (Object[])this.get(v);
-        return a.length;
+        return track(new ArrayList<Object>(Arrays.asList(a)));
+    }
+    public long foreignMap(long keys, long values) {
+        Object[] k = (Object[])this.get(keys);
+        Object[] v = (Object[])this.get(values);
+        Map<Object, Object> m = new HashMap<Object, Object>();
+        for (int i = 0; k != null && i < k.length; i++) {
+            m.put(k[i], v[i]);
+        }
+        return track(m);
+    }
+    public long foreignMapEntries(long v) {
+        Map<?, ?> m = (Map<?, ?>)this.get(v);
+        Object[] keys = new Object[m.size()];
+        Object[] values = new Object[m.size()];
+        int i = 0;
+        for (Map.Entry<?, ?> e : m.entrySet()) {
+            keys[i] = e.getKey();
+            values[i] = e.getValue();
+            i++;
+        }
+        return track(new Object[]{keys, values});
+    }
+    public boolean foreignIsMainThread() {
+        return Looper.myLooper() == Looper.getMainLooper();
+    }
+    public void foreignRunOnMain(long goRef) {
+        final GoValue f = new GoValue(goRef, false);
+        new Handler(Looper.getMainLooper()).post(new Runnable() {
+            @Override
+            public void run() {
+                f.call("");
+            }
+        });
+    }
+    public synchronized void foreignSetLeakTracking(boolean enabled) {
+        if (!enabled) {
+            this.stacks = null;
+        } else if (this.stacks == null) {
+            this.stacks = new ConcurrentHashMap<Long, String>();
+        }
+    }
+    public long foreignLeakSnapshot() {
+        JSONArray objects = new JSONArray();
+        Map<Long, String> stacks = this.stacks;
+        try {
+            for (Map.Entry<Long, Object> i : this.mapTable.entrySet()) {
+                JSONObject object = new JSONObject();
+                object.put("ref", i.getKey());
+                object.put("type", i.getValue().getClass().getName());
+                String stack = stacks == null ? null : stacks.get(i.getKey());
+                object.put("stack", stack == null ? "" : stack);
+                objects.put(object);
+            }
+        } catch (JSONException e) {
+            throw new RuntimeException(e);
+        }
+        return track(objects.toString());
     }
-    public synchronized void foreignPanic() {
+    public void foreignPanic() {
         throw new RuntimeException("Golang Panic");
     }
 }
@@ -44,11 +44,66 @@ func ParseTargets(a string) map[string]struct{} {
 		case "ios/arm", "ios/arm64", "ios/386", "ios/amd64":
 			targets["ios"] = struct{}{}
 			targets[i] = struct{}{}
+		case "wasm":
+			targets["wasm"] = struct{}{}
+		case "windows":
+			targets["windows"] = struct{}{}
+		case "linux":
+			targets["linux"] = struct{}{}
 		}
 	}
 	return targets
 }
 
+// BindContext returns the build context used to resolve the packages of a bind.
+func BindContext() build.Context {
+	ctx := build.Default
+	ctx.GOARCH = "arm"
+	ctx.GOOS = "darwin"
+	ctx.BuildTags = append(ctx.BuildTags, "ios")
+	ctx.BuildTags = append(ctx.BuildTags, "matcha")
+	return ctx
+}
+
+// ImportBindPackages returns the packages named by args and all of their
+// dependencies, keyed by directory. If args is empty the package in srcDir is used.
+func ImportBindPackages(ctx *build.Context, args []string, srcDir string) (map[string]*build.Package, error) {
+	// Get import paths to be built.
+	importPaths := []string{}
+	if len(args) == 0 {
+		importPaths = append(importPaths, ".")
+	} else {
+		for _, i := range args {
+			i = path.Clean(i)
+			importPaths = append(importPaths, i)
+		}
+	}
+
+	pkgs, err := ImportAll(ctx, importPaths, srcDir, build.ImportComment)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if any of the package is main.
+	for _, pkg := range pkgs {
+		if pkg.Name == "main" {
+			return nil, fmt.Errorf("binding 'main' package (%s) is not supported", pkg.ImportComment)
+		}
+	}
+	return pkgs, nil
+}
+
+// ImportsBridge returns true if pkg imports gomatcha.io/bridge. The ios
+// directories of these packages are copied into the iOS output.
+func ImportsBridge(pkg *build.Package) bool {
+	for _, i := range pkg.Imports {
+		if i == "gomatcha.io/bridge" {
+			return true
+		}
+	}
+	return false
+}
+
 func Build(flags *Flags, args []string) error {
 	iosDir, err := PackageDir(flags, "gomatcha.io/matcha")
 	if err != nil {
@@ -59,16 +114,37 @@ func Build(flags *Flags, args []string) error {
 	return Bind(flags, args)
 }
 
+// Bind builds the packages named by args with the current toolchain. If the
+// toolchain was newly installed and the bind fails, it rolls back to the
+// previous toolchain and tries again.
 func Bind(flags *Flags, args []string) error {
-	targets := ParseTargets(flags.BuildTargets)
-
-	// Make $WORK.
-	tempdir, err := NewTmpDir(flags, "")
+	state, err := LoadToolchainState(flags)
 	if err != nil {
 		return err
 	}
-	if !flags.BuildWork {
-		defer RemoveAll(flags, tempdir)
+	if err := UseToolchain(state); err != nil {
+		return err
+	}
+	err = bind(flags, args)
+	if err == nil {
+		return state.Verify()
+	}
+	if !flags.ShouldRun() {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Bind failed with toolchain %s: %v\n", state.Current, err)
+	if rerr := RollbackToolchain(flags, state); rerr == errNoRollback {
+		return err
+	} else if rerr != nil {
+		return rerr
+	}
+	return bind(flags, args)
+}
+
+func bind(flags *Flags, args []string) error {
+	targets := ParseTargets(flags.BuildTargets)
+	if _, ok := AndroidVariants[flags.AndroidVariant]; !ok {
+		return fmt.Errorf("unknown android variant %q", flags.AndroidVariant)
 	}
 
 	// Get $GOPATH/pkg/gomobile.
@@ -100,39 +176,69 @@ func Bind(flags *Flags, args []string) error {
 		return err
 	}
 
-	// Create a build context.
-	ctx := build.Default
-	ctx.GOARCH = "arm"
-	ctx.GOOS = "darwin"
-	ctx.BuildTags = append(ctx.BuildTags, "ios")
-	ctx.BuildTags = append(ctx.BuildTags, "matcha")
-
-	// Get import paths to be built.
-	importPaths := []string{}
-	srcDir := ""
-	if len(args) == 0 {
-		importPaths = append(importPaths, ".")
-		srcDir = cwd
+	// Make $WORK. When resuming, reuse the work directory of the previous
+	// attempt so that the artifacts of successful targets are kept.
+	var tempdir string
+	var status *ResumeStatus
+	if flags.BuildResume {
+		tempdir = ResumeDir(gomobilepath, cwd, args)
+		if err := Mkdir(flags, tempdir); err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, "WORK="+tempdir)
+		status = LoadResumeStatus(flags, tempdir)
 	} else {
-		for _, i := range args {
-			i = path.Clean(i)
-			importPaths = append(importPaths, i)
+		tempdir, err = NewTmpDir(flags, "")
+		if err != nil {
+			return err
+		}
+		if !flags.BuildWork {
+			defer RemoveAll(flags, tempdir)
 		}
-		srcDir = cwd
 	}
+	toolchain := append(append([]byte{}, installedVersion...), goVersion...)
 
-	// Get packages to be built
-	pkgs, err := ImportAll(&ctx, importPaths, srcDir, build.ImportComment)
+	// Run the pre-build hooks of matcha.yaml. They run before the packages are
+	// loaded, so that generated sources are part of the build.
+	config, err := LoadBuildConfig(cwd)
 	if err != nil {
 		return err
 	}
-
-	// Check if any of the package is main.
-	for _, pkg := range pkgs {
-		if pkg.Name == "main" {
-			return fmt.Errorf("binding 'main' package (%s) is not supported", pkg.ImportComment)
+	hookOutputDir := OutputDir(flags, "ios")
+	for _, i := range []string{"ios", "android", "wasm", "windows", "linux"} {
+		if _, ok := targets[i]; ok {
+			hookOutputDir = OutputDir(flags, i)
+			break
 		}
 	}
+	hookContext := HookContext{
+		Stage:     "pre-build",
+		Targets:   targets,
+		Packages:  args,
+		WorkDir:   tempdir,
+		OutputDir: hookOutputDir,
+	}
+	if err := RunHooks(flags, cwd, config.Hooks.PreBuild, hookContext); err != nil {
+		return err
+	}
+
+	// Create a build context.
+	ctx := BindContext()
+	if flags.IOSExtension {
+		ctx.BuildTags = append(ctx.BuildTags, "matcha_extension")
+	}
+	if flags.BuildDebug {
+		ctx.BuildTags = append(ctx.BuildTags, "matcha_debug")
+	}
+
+	// Get packages to be built
+	pkgs, err := ImportBindPackages(&ctx, args, cwd)
+	if err != nil {
+		return err
+	}
+
+	// Report the packages that affect the app's minimum OS.
+	WriteMinOSReport(os.Stderr, FindMinOS(pkgs))
 
 	// Get the supporting files
 	cmdPath, err := PackageDir(flags, "gomatcha.io/matcha/cmd")
@@ -142,6 +248,12 @@ func Bind(flags *Flags, args []string) error {
 
 	// Begin iOS
 	if _, ok := targets["ios"]; ok {
+		// Extension builds are tracked separately so switching modes rebuilds.
+		iosStatus := "ios/"
+		if flags.IOSExtension {
+			iosStatus = "ios-extension/"
+		}
+
 		// Build the "matcha/bridge" dir
 		gopathDir := filepath.Join(tempdir, "IOS-GOPATH")
 
@@ -171,15 +283,7 @@ func Bind(flags *Flags, args []string) error {
 		if !flags.BuildBinary {
 			// Copy package's ios directory if it imports gomatcha.io/bridge.
 			for _, pkg := range pkgs {
-				importsBridge := false
-				for _, i := range pkg.Imports {
-					if i == "gomatcha.io/bridge" {
-						importsBridge = true
-						break
-					}
-				}
-
-				if importsBridge {
+				if ImportsBridge(pkg) {
 					files, err := ioutil.ReadDir(pkg.Dir)
 					if err != nil {
 						continue
@@ -236,45 +340,96 @@ func Bind(flags *Flags, args []string) error {
 			envs = append(envs, env)
 		}
 
+		if flags.IOSExtension {
+			// Restrict the cgo code to APIs that are available to app extensions.
+			for _, env := range envs {
+				for j, kv := range env {
+					if strings.HasPrefix(kv, "CGO_CFLAGS=") || strings.HasPrefix(kv, "CGO_LDFLAGS=") {
+						env[j] = kv + " -fapplication-extension"
+					}
+				}
+			}
+		}
+
 		type archPath struct {
 			arch string
 			path string
+			key  string
 			err  error
 		}
-		archChan := make(chan archPath)
+		// Buffered, so the builds don't block if the results stop being read.
+		archChan := make(chan archPath, len(envs))
 		for _, i := range envs {
 			go func(env []string) {
 				arch := Getenv(env, "GOARCH")
 				env = append(env, "GOPATH="+gopathDir+string(filepath.ListSeparator)+os.Getenv("GOPATH"))
 				path := filepath.Join(tempdir, "matcha-"+arch+".a")
-				err := GoBuild(flags, mainPath, env, ctx, tempdir, "-buildmode=c-archive", "-o", path)
-				archChan <- archPath{arch, path, err}
+				key, err := TargetKey(flags, toolchain, env, pkgs)
+				if err != nil {
+					archChan <- archPath{arch, path, key, err}
+					return
+				}
+				if status.Done(iosStatus+arch, key, path) {
+					fmt.Fprintf(os.Stderr, "ios/%s unchanged, skipping build.\n", arch)
+					archChan <- archPath{arch, path, key, nil}
+					return
+				}
+				err = GoBuild(flags, mainPath, env, ctx, tempdir, "-buildmode=c-archive", "-o", path)
+				archChan <- archPath{arch, path, key, err}
 			}(i)
 		}
 		archs := []archPath{}
+		var archErr error
 		for i := 0; i < len(envs); i++ {
 			arch := <-archChan
+			// Record every result, so a resumed build only retries the
+			// architectures that failed.
+			if err := status.Set(iosStatus+arch.arch, arch.key, arch.err == nil); err != nil {
+				return err
+			}
 			if arch.err != nil {
-				return arch.err
+				if archErr == nil {
+					archErr = arch.err
+				}
+				continue
 			}
 			archs = append(archs, arch)
 		}
-
-		// Lipo to build fat binary.
-		cmd := exec.Command("xcrun", "lipo", "-create")
-		for _, i := range archs {
-			cmd.Args = append(cmd.Args, "-arch", ArchClang(i.arch), i.path)
+		if archErr != nil {
+			return archErr
 		}
-		cmd.Args = append(cmd.Args, "-o", binaryPath)
-		if err := RunCmd(flags, tempdir, cmd); err != nil {
-			return err
+
+		// Lipo to build fat binary. If no lipo is available, keep the per-arch
+		// archives so they can be combined later on a macOS host.
+		binaryPaths := map[string]string{}
+		switch lipo := LipoAvailable(); lipo {
+		case "xcrun", "llvm-lipo":
+			cmd := exec.Command("llvm-lipo", "-create")
+			if lipo == "xcrun" {
+				cmd = exec.Command("xcrun", "lipo", "-create")
+			}
+			for _, i := range archs {
+				cmd.Args = append(cmd.Args, "-arch", ArchClang(i.arch), i.path)
+			}
+			cmd.Args = append(cmd.Args, "-o", binaryPath)
+			if err := RunCmd(flags, tempdir, cmd); err != nil {
+				return err
+			}
+			binaryPaths["MatchaBridge.a"] = binaryPath
+		default:
+			fmt.Fprintln(os.Stderr, "lipo not available, skipping fat binary creation.")
+			for _, i := range archs {
+				name := "MatchaBridge-" + ArchClang(i.arch) + ".a"
+				path := filepath.Join(filepath.Dir(binaryPath), name)
+				if err := CopyFile(flags, path, i.path); err != nil {
+					return err
+				}
+				binaryPaths[name] = path
+			}
 		}
 
 		// Create output dir
-		outputDir := flags.BuildO
-		if outputDir == "" {
-			outputDir = "Matcha-iOS"
-		}
+		outputDir := OutputDir(flags, "ios")
 
 		if !flags.BuildBinary {
 			if err := RemoveAll(flags, outputDir); err != nil {
@@ -286,11 +441,41 @@ func Bind(flags *Flags, args []string) error {
 				return err
 			}
 		} else {
-			// Copy binary into place.
-			if err := CopyFile(flags, filepath.Join(outputDir, "ios", "MatchaBridge", "MatchaBridge", "MatchaBridge.a"), binaryPath); err != nil {
+			// Copy binaries into place.
+			for name, path := range binaryPaths {
+				if err := CopyFile(flags, filepath.Join(outputDir, "ios", "MatchaBridge", "MatchaBridge", name), path); err != nil {
+					return err
+				}
+			}
+		}
+
+		// Sign the artifacts once they are in the output directory, since
+		// those are the ones that ship.
+		names := []string{}
+		for name := range binaryPaths {
+			names = append(names, name)
+		}
+		for _, path := range IOSSignPaths(outputDir, flags.BuildBinary, names) {
+			if err := Codesign(flags, tempdir, path); err != nil {
 				return err
 			}
 		}
+
+		// Write the Swift overlay next to the MatchaBridge project. Apps add
+		// it to their own target, since it imports the MatchaBridge framework.
+		funcs, typs := FindBridgeDecls(pkgs)
+		if len(funcs) > 0 || len(typs) > 0 {
+			overlayPath := filepath.Join(outputDir, "MatchaBridge", "MatchaOverlay.swift")
+			if flags.BuildBinary {
+				overlayPath = filepath.Join(outputDir, "ios", "MatchaBridge", "MatchaOverlay.swift")
+			}
+			err := WriteFile(flags, overlayPath, func(w io.Writer) error {
+				return WriteSwiftOverlay(w, funcs, typs)
+			})
+			if err != nil {
+				return fmt.Errorf("failed to write the Swift overlay: %v", err)
+			}
+		}
 	}
 	if _, ok := targets["android"]; ok {
 		// Build the "matcha/bridge" dir
@@ -324,6 +509,9 @@ func Bind(flags *Flags, args []string) error {
 		ctx.GOARCH = "arm"
 		ctx.GOOS = "android"
 		ctx.BuildTags = append(ctx.BuildTags, "matcha")
+		if flags.BuildDebug {
+			ctx.BuildTags = append(ctx.BuildTags, "matcha_debug")
+		}
 
 		androidDir := filepath.Join(tempdir, "android")
 		mainPath := filepath.Join(tempdir, "androidlib/main.go")
@@ -344,6 +532,15 @@ func Bind(flags *Flags, args []string) error {
 		if err := CopyFile(flags, filepath.Join(javaDir2, "GoValue.java"), filepath.Join(cmdPath, "GoValue.java")); err != nil {
 			return err
 		}
+		if err := CopyFile(flags, filepath.Join(javaDir2, "GoFuture.java"), filepath.Join(cmdPath, "GoFuture.java")); err != nil {
+			return err
+		}
+		if err := CopyFile(flags, filepath.Join(javaDir2, "GoException.java"), filepath.Join(cmdPath, "GoException.java")); err != nil {
+			return err
+		}
+		if err := CopyFile(flags, filepath.Join(javaDir2, "GoStream.java"), filepath.Join(cmdPath, "GoStream.java")); err != nil {
+			return err
+		}
 		if err := CopyFile(flags, filepath.Join(javaDir2, "Bridge.java"), filepath.Join(cmdPath, "Bridge.java")); err != nil {
 			return err
 		}
@@ -359,7 +556,7 @@ func Bind(flags *Flags, args []string) error {
 
 		// Make aar output file.
 		aarDirPath := filepath.Join(workOutputDir, "MatchaBridge")
-		aarPath := filepath.Join(workOutputDir, "MatchaBridge", "matchabridge.aar")
+		aarPath := filepath.Join(workOutputDir, "MatchaBridge", AndroidAARName(flags.AndroidVariant))
 		if err := Mkdir(flags, aarDirPath); err != nil {
 			return err
 		}
@@ -373,14 +570,27 @@ func Bind(flags *Flags, args []string) error {
 			env := androidENV[arch]
 			env = append(env, "GOPATH="+gopathDir+string(filepath.ListSeparator)+os.Getenv("GOPATH"))
 
+			libPath := filepath.Join(androidDir, "src/main/jniLibs/"+GetAndroidABI(arch)+"/libgojni.so")
+			key, err := TargetKey(flags, toolchain, env, pkgs)
+			if err != nil {
+				return err
+			}
+			if status.Done("android/"+arch, key, libPath) {
+				fmt.Fprintf(os.Stderr, "android/%s unchanged, skipping build.\n", arch)
+				continue
+			}
+
 			err = GoBuild(flags,
 				mainPath,
 				env,
 				ctx,
 				tempdir,
 				"-buildmode=c-shared",
-				"-o="+filepath.Join(androidDir, "src/main/jniLibs/"+GetAndroidABI(arch)+"/libgojni.so"),
+				"-o="+libPath,
 			)
+			if err := status.Set("android/"+arch, key, err == nil); err != nil {
+				return err
+			}
 			if err != nil {
 				return err
 			}
@@ -390,17 +600,247 @@ func Bind(flags *Flags, args []string) error {
 		}
 
 		// Create output dir
-		outputDir := flags.BuildO
-		if outputDir == "" {
-			outputDir = "Matcha-iOS"
-		}
+		outputDir := OutputDir(flags, "android")
 
 		// Copy binary into place.
-		if err := CopyFile(flags, filepath.Join(outputDir, "android", "matchabridge.aar"), aarPath); err != nil {
+		if err := CopyFile(flags, filepath.Join(outputDir, "android", AndroidAARName(flags.AndroidVariant)), aarPath); err != nil {
+			return err
+		}
+
+		// The Kotlin wrappers are shipped as source, so that the bind doesn't
+		// need a Kotlin compiler. Apps add the directory to their source set.
+		if flags.Kotlin {
+			kotlinPath := filepath.Join(outputDir, "android", "kotlin", "io", "gomatcha", "bridge", "MatchaCoroutines.kt")
+			if err := CopyFile(flags, kotlinPath, filepath.Join(cmdPath, "MatchaCoroutines.kt.support")); err != nil {
+				return err
+			}
+		}
+	}
+	if _, ok := targets["wasm"]; ok {
+		err := BindDesktop(flags, DesktopTarget{
+			Name:     "wasm",
+			Env:      WasmEnv(),
+			GOOS:     "js",
+			GOARCH:   "wasm",
+			BindFile: WasmBindFile,
+			Lib:      "matcha.wasm",
+			Host: func(workOutputDir, libPath string) error {
+				// Copy the JavaScript runtime and renderer.
+				wasmExecPath, err := WasmExecPath()
+				if err != nil {
+					return err
+				}
+				if err := CopyFile(flags, filepath.Join(workOutputDir, "wasm_exec.js"), wasmExecPath); err != nil {
+					return err
+				}
+				if err := CopyFile(flags, filepath.Join(workOutputDir, "matcha.js"), filepath.Join(cmdPath, "matcha.js")); err != nil {
+					return err
+				}
+				return CopyFile(flags, filepath.Join(workOutputDir, "index.html"), filepath.Join(cmdPath, "index.html"))
+			},
+		}, tempdir, toolchain, pkgs, status, args[0])
+		if err != nil {
+			return err
+		}
+	}
+	if _, ok := targets["windows"]; ok {
+		gomobpath, err := GoMobilePath()
+		if err != nil {
+			return err
+		}
+		env, err := WindowsEnv(gomobpath)
+		if err != nil {
+			return err
+		}
+		err = BindDesktop(flags, DesktopTarget{
+			Name:      "windows",
+			Env:       env,
+			GOOS:      "windows",
+			GOARCH:    "amd64",
+			BindFile:  DesktopBindFile,
+			Lib:       "matcha.dll",
+			BuildArgs: []string{"-buildmode=c-shared"},
+			Host: func(workOutputDir, libPath string) error {
+				return BuildDesktopHost(flags, env, tempdir, cmdPath, "windows", filepath.Join(workOutputDir, "matcha.exe"), libPath,
+					"-mwindows",
+					"-municode",
+					"-lgdi32",
+					"-lcomctl32",
+					"-lshell32",
+					"-lmsimg32",
+				)
+			},
+		}, tempdir, toolchain, pkgs, status, args[0])
+		if err != nil {
+			return err
+		}
+	}
+	if _, ok := targets["linux"]; ok {
+		env, err := LinuxEnv()
+		if err != nil {
+			return err
+		}
+		err = BindDesktop(flags, DesktopTarget{
+			Name:      "linux",
+			Env:       env,
+			GOOS:      "linux",
+			GOARCH:    Getenv(env, "GOARCH"),
+			BindFile:  DesktopBindFile,
+			Lib:       "libmatcha.so",
+			BuildArgs: []string{"-buildmode=c-shared"},
+			Host: func(workOutputDir, libPath string) error {
+				gtk, err := exec.Command("pkg-config", "--cflags", "--libs", "gtk4").Output()
+				if err != nil {
+					return fmt.Errorf("pkg-config gtk4 failed: %v", err)
+				}
+				hostArgs := append(strings.Fields(string(gtk)), "-lm", "-Wl,-rpath,$ORIGIN")
+				return BuildDesktopHost(flags, env, tempdir, cmdPath, "linux", filepath.Join(workOutputDir, "matcha"), libPath, hostArgs...)
+			},
+		}, tempdir, toolchain, pkgs, status, args[0])
+		if err != nil {
+			return err
+		}
+	}
+
+	// Write the Flutter plugin next to the iOS and Android libraries.
+	if flags.Flutter {
+		outputDir := OutputDir(flags, "ios")
+		if err := Flutter(flags, filepath.Join(outputDir, "flutter")); err != nil {
+			return err
+		}
+	}
+
+	hookContext.Stage = "post-build"
+	return RunHooks(flags, cwd, config.Hooks.PostBuild, hookContext)
+}
+
+// DesktopTarget describes a target that is built with `go build` instead of
+// gomobile: wasm, windows and linux.
+type DesktopTarget struct {
+	Name      string // Name of the target and of its directory in the output.
+	Env       []string
+	GOOS      string
+	GOARCH    string
+	BindFile  string   // Format of the main package, with the bound package as the argument.
+	Lib       string   // File name of the Go build output.
+	BuildArgs []string // Extra arguments to go build, such as -buildmode.
+	// Host, if not nil, adds the files that load the Go build output, such as
+	// the host renderer, to the work output directory.
+	Host func(workOutputDir, libPath string) error
+}
+
+// OutputDir returns the directory that the target is copied into: the -o flag
+// if set, and otherwise a default for the target.
+func OutputDir(flags *Flags, target string) string {
+	if flags.BuildO != "" {
+		return flags.BuildO
+	}
+	switch target {
+	case "wasm":
+		return "Matcha-Wasm"
+	case "windows":
+		return "Matcha-Windows"
+	case "linux":
+		return "Matcha-Linux"
+	}
+	return "Matcha-iOS"
+}
+
+// BindDesktop builds pkg for t into $WORK/matcha-<name>, skipping the Go build
+// if it is unchanged since the last resumed build, and copies the result to
+// the <name> directory of the output.
+func BindDesktop(flags *Flags, t DesktopTarget, tempdir string, toolchain []byte, pkgs map[string]*build.Package, status *ResumeStatus, pkg string) error {
+	// Build the "matcha/bridge" dir
+	gopathDir := filepath.Join(tempdir, strings.ToUpper(t.Name)+"-GOPATH")
+	env := append(append([]string{}, t.Env...), "GOPATH="+gopathDir+string(filepath.ListSeparator)+os.Getenv("GOPATH"))
+
+	ctx := build.Default
+	ctx.GOARCH = t.GOARCH
+	ctx.GOOS = t.GOOS
+	ctx.BuildTags = append(ctx.BuildTags, "matcha")
+	if flags.BuildDebug {
+		ctx.BuildTags = append(ctx.BuildTags, "matcha_debug")
+	}
+
+	mainPath := filepath.Join(tempdir, t.Name+"lib", "main.go")
+	err := WriteFile(flags, mainPath, func(w io.Writer) error {
+		format := fmt.Sprintf(t.BindFile, pkg)
+		_, err := w.Write([]byte(format))
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create the main package for %v: %v", t.Name, err)
+	}
+
+	// Make $WORK/matcha-<name>
+	workOutputDir := filepath.Join(tempdir, "matcha-"+t.Name)
+	if err := Mkdir(flags, workOutputDir); err != nil {
+		return err
+	}
+
+	libPath := filepath.Join(workOutputDir, t.Lib)
+	key, err := TargetKey(flags, toolchain, env, pkgs)
+	if err != nil {
+		return err
+	}
+	if status.Done(t.Name, key, libPath) {
+		fmt.Fprintf(os.Stderr, "%v unchanged, skipping build.\n", t.Name)
+	} else {
+		args := append(append([]string{}, t.BuildArgs...), "-o="+libPath)
+		err = GoBuild(flags, mainPath, env, ctx, tempdir, args...)
+		if err := status.Set(t.Name, key, err == nil); err != nil {
+			return err
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if t.Host != nil {
+		if err := t.Host(workOutputDir, libPath); err != nil {
 			return err
 		}
 	}
-	return nil
+
+	// Copy output directory into place.
+	outputDir := OutputDir(flags, t.Name)
+	if err := RemoveAll(flags, filepath.Join(outputDir, t.Name)); err != nil {
+		return err
+	}
+	return CopyDir(flags, filepath.Join(outputDir, t.Name), workOutputDir)
+}
+
+// BuildDesktopHost compiles the host renderer matcha-<name>.c.support into out,
+// linked against the shared library at libPath.
+func BuildDesktopHost(flags *Flags, env []string, tempdir, cmdPath, name, out, libPath string, args ...string) error {
+	bridgePath, err := PackageDir(flags, "gomatcha.io/matcha/bridge")
+	if err != nil {
+		return err
+	}
+	hostDir := filepath.Join(tempdir, name+"host")
+	for _, i := range []string{"matchaforeign.h", "matchago.h", "matchaforeign-desktop.h"} {
+		if err := CopyFile(flags, filepath.Join(hostDir, i), filepath.Join(bridgePath, i)); err != nil {
+			return err
+		}
+	}
+	if err := CopyFile(flags, filepath.Join(hostDir, "matcha-host.h"), filepath.Join(cmdPath, "matcha-host.h.support")); err != nil {
+		return err
+	}
+	hostPath := filepath.Join(hostDir, "matcha-"+name+".c")
+	if err := CopyFile(flags, hostPath, filepath.Join(cmdPath, "matcha-"+name+".c.support")); err != nil {
+		return err
+	}
+	cc := strings.Fields(Getenv(env, "CC"))
+	cmd := exec.Command(cc[0], cc[1:]...)
+	cmd.Args = append(cmd.Args,
+		"-O2",
+		"-I"+hostDir,
+		"-o", out,
+		hostPath,
+		libPath,
+	)
+	cmd.Args = append(cmd.Args, args...)
+	return RunCmd(flags, tempdir, cmd)
 }
 
 var BindFile = `
@@ -409,6 +849,40 @@ package main
 import (
 	_ "golang.org/x/mobile/bind/java"
     _ "gomatcha.io/matcha/bridge"
+    _ "gomatcha.io/matcha/comm/bridgecomm"
+    _ "%s"
+)
+
+import "C"
+
+func main() {}
+`
+
+// WasmBindFile is the main package of the wasm target. Unlike the mobile targets
+// the Go program runs the event loop, so main blocks while the JavaScript renderer
+// calls into the registered functions.
+var WasmBindFile = `
+package main
+
+import (
+    _ "gomatcha.io/matcha/bridge"
+    _ "gomatcha.io/matcha/comm/bridgecomm"
+    _ "%s"
+)
+
+func main() {
+    select {}
+}
+`
+
+// DesktopBindFile is the main package of the windows and linux targets. It is
+// built as a shared library that is loaded by the desktop host renderers.
+var DesktopBindFile = `
+package main
+
+import (
+    _ "gomatcha.io/matcha/bridge"
+    _ "gomatcha.io/matcha/comm/bridgecomm"
     _ "%s"
 )
 
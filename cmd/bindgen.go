@@ -0,0 +1,899 @@
+package cmd
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// bindgenGoFile is the name of the registration glue written into each bound
+// package.
+const bindgenGoFile = "matcha_bindgen.go"
+
+// BindgenPackage is the exported API of a package bound with `matcha bindgen`.
+type BindgenPackage struct {
+	ImportPath string
+	Name       string // Go package name.
+	Dir        string
+	Funcs      []BindgenFunc
+	Types      []BindgenType
+}
+
+// BindgenFunc is an exported function or method.
+type BindgenFunc struct {
+	Name     string
+	Params   []BindgenParam
+	Results  []string // Go types of the results.
+	Variadic bool
+	Register bool // The package doesn't register the function itself.
+}
+
+// BindgenParam is a parameter of a BindgenFunc. Name is empty for unnamed
+// parameters.
+type BindgenParam struct {
+	Name string
+	Type string
+}
+
+// BindgenType is an exported struct or interface.
+type BindgenType struct {
+	Name      string
+	Interface bool
+	Fields    []BridgeField // Exported fields of structs.
+	Methods   []BindgenFunc
+	Register  bool // The package doesn't register the struct itself.
+	Notifier  bool // Wraps a gomatcha.io/matcha/comm notifier.
+}
+
+// commNotifiers are the gomatcha.io/matcha/comm notifiers that bound APIs may
+// use, mapped to the Go type of their value. They are wrapped in facades with
+// Value and SetValue methods.
+var commNotifiers = map[string]string{
+	"BoolNotifier":    "bool",
+	"IntNotifier":     "int",
+	"Int64Notifier":   "int64",
+	"Float64Notifier": "float64",
+	"StringNotifier":  "string",
+	"BytesNotifier":   "[]byte",
+}
+
+// FindBindgenDecls returns the exported functions, structs and interfaces of
+// pkg. Generic declarations are skipped. Functions and structs that the
+// package already registers with the bridge are not registered again.
+func FindBindgenDecls(pkg *build.Package) (*BindgenPackage, error) {
+	p := &BindgenPackage{ImportPath: pkg.ImportPath, Name: pkg.Name, Dir: pkg.Dir}
+	fset := token.NewFileSet()
+	imports := map[string]string{} // Local name to import path.
+	methods := map[string][]BindgenFunc{}
+	typs := map[string]*BindgenType{}
+	for _, i := range pkg.GoFiles {
+		if i == bindgenGoFile {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(pkg.Dir, i), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, imp := range f.Imports {
+			path, _ := strconv.Unquote(imp.Path.Value)
+			name := filepath.Base(path)
+			if imp.Name != nil {
+				name = imp.Name.Name
+			}
+			imports[name] = path
+		}
+		for _, d := range f.Decls {
+			switch d := d.(type) {
+			case *ast.FuncDecl:
+				if !d.Name.IsExported() || d.Type.TypeParams != nil {
+					continue
+				}
+				fn := bindgenFunc(d.Name.Name, d.Type)
+				if d.Recv == nil {
+					p.Funcs = append(p.Funcs, fn)
+					continue
+				}
+				if recv := receiverName(d.Recv); recv != "" {
+					methods[recv] = append(methods[recv], fn)
+				}
+			case *ast.GenDecl:
+				for _, s := range d.Specs {
+					s, ok := s.(*ast.TypeSpec)
+					if !ok || !s.Name.IsExported() || s.TypeParams != nil || s.Assign.IsValid() {
+						continue
+					}
+					switch t := s.Type.(type) {
+					case *ast.StructType:
+						typs[s.Name.Name] = &BindgenType{Name: s.Name.Name, Fields: structFields(t)}
+					case *ast.InterfaceType:
+						bt := &BindgenType{Name: s.Name.Name, Interface: true}
+						for _, m := range t.Methods.List {
+							ft, ok := m.Type.(*ast.FuncType)
+							if !ok {
+								continue // Embedded interfaces and type sets.
+							}
+							for _, name := range m.Names {
+								if name.IsExported() {
+									bt.Methods = append(bt.Methods, bindgenFunc(name.Name, ft))
+								}
+							}
+						}
+						typs[s.Name.Name] = bt
+					}
+				}
+			}
+		}
+	}
+	for name, t := range typs {
+		if !t.Interface {
+			t.Methods = methods[name]
+		}
+		p.Types = append(p.Types, *t)
+	}
+
+	// Skip what the package already registers, outside of the glue of a
+	// previous run.
+	own := *pkg
+	own.GoFiles = nil
+	for _, i := range pkg.GoFiles {
+		if i != bindgenGoFile {
+			own.GoFiles = append(own.GoFiles, i)
+		}
+	}
+	registered := map[string]bool{}
+	funcs, bridgeTypes := FindBridgeDecls(map[string]*build.Package{own.Dir: &own})
+	for _, i := range funcs {
+		registered[i.Name] = true
+	}
+	for _, i := range bridgeTypes {
+		registered[i.Name] = true
+	}
+	for i := range p.Funcs {
+		p.Funcs[i].Register = !registered[p.funcName(p.Funcs[i].Name)]
+	}
+	for i := range p.Types {
+		p.Types[i].Register = !p.Types[i].Interface && !registered[p.typeName(p.Types[i].Name)]
+	}
+
+	// Add facades for the comm notifiers in use.
+	used := map[string]bool{}
+	for _, t := range p.allTypes() {
+		if n, ok := commNotifier(t, imports); ok {
+			used[n] = true
+		}
+	}
+	for name := range used {
+		if _, ok := typs[name]; ok {
+			return nil, fmt.Errorf("%v: type %v conflicts with the facade of comm.%v", pkg.ImportPath, name, name)
+		}
+		v := commNotifiers[strings.Replace(name, "RW", "", 1)]
+		t := BindgenType{Name: name, Interface: true, Notifier: true}
+		t.Methods = append(t.Methods, BindgenFunc{Name: "Value", Results: []string{v}})
+		if strings.Contains(name, "RW") {
+			t.Methods = append(t.Methods, BindgenFunc{Name: "SetValue", Params: []BindgenParam{{Name: "v", Type: v}}})
+		}
+		p.Types = append(p.Types, t)
+	}
+
+	// Qualified types are resolved to import paths, so that the generators
+	// don't depend on how the package names its imports.
+	resolve := func(t string) string {
+		if n, ok := commNotifier(t, imports); ok {
+			return strings.Replace(t, strings.TrimPrefix(t, "*"), n, 1)
+		}
+		prefix, name := "", t
+		for strings.HasPrefix(name, "*") || strings.HasPrefix(name, "[]") {
+			n := 1
+			if name[0] == '[' {
+				n = 2
+			}
+			prefix, name = prefix+name[:n], name[n:]
+		}
+		if parts := strings.SplitN(name, ".", 2); len(parts) == 2 {
+			if path, ok := imports[parts[0]]; ok {
+				return prefix + path + "." + parts[1]
+			}
+		}
+		return t
+	}
+	resolveFunc := func(f *BindgenFunc) {
+		for i := range f.Params {
+			f.Params[i].Type = resolve(f.Params[i].Type)
+		}
+		for i := range f.Results {
+			f.Results[i] = resolve(f.Results[i])
+		}
+	}
+	for i := range p.Funcs {
+		resolveFunc(&p.Funcs[i])
+	}
+	for i := range p.Types {
+		for j := range p.Types[i].Fields {
+			p.Types[i].Fields[j].Type = resolve(p.Types[i].Fields[j].Type)
+		}
+		for j := range p.Types[i].Methods {
+			resolveFunc(&p.Types[i].Methods[j])
+		}
+	}
+
+	sort.Slice(p.Funcs, func(i, j int) bool { return p.Funcs[i].Name < p.Funcs[j].Name })
+	sort.Slice(p.Types, func(i, j int) bool { return p.Types[i].Name < p.Types[j].Name })
+	for _, t := range p.Types {
+		sort.Slice(t.Methods, func(i, j int) bool { return t.Methods[i].Name < t.Methods[j].Name })
+	}
+	return p, nil
+}
+
+func bindgenFunc(name string, ft *ast.FuncType) BindgenFunc {
+	f := BindgenFunc{Name: name, Results: fieldTypes(ft.Results)}
+	if ft.Params == nil {
+		return f
+	}
+	for _, i := range ft.Params.List {
+		t := types.ExprString(i.Type)
+		if _, ok := i.Type.(*ast.Ellipsis); ok {
+			f.Variadic = true
+		}
+		if len(i.Names) == 0 {
+			f.Params = append(f.Params, BindgenParam{Type: t})
+		}
+		for _, n := range i.Names {
+			f.Params = append(f.Params, BindgenParam{Name: n.Name, Type: t})
+		}
+	}
+	return f
+}
+
+// receiverName returns the name of the receiver's type, or "" if it isn't an
+// exported type of the package.
+func receiverName(recv *ast.FieldList) string {
+	if len(recv.List) != 1 {
+		return ""
+	}
+	t := recv.List[0].Type
+	if star, ok := t.(*ast.StarExpr); ok {
+		t = star.X
+	}
+	ident, ok := t.(*ast.Ident)
+	if !ok || !ident.IsExported() {
+		return ""
+	}
+	return ident.Name
+}
+
+// commNotifier returns the name of the comm notifier facade for the Go type t,
+// if it names one of commNotifiers.
+func commNotifier(t string, imports map[string]string) (string, bool) {
+	parts := strings.SplitN(strings.TrimPrefix(t, "*"), ".", 2)
+	if len(parts) != 2 || imports[parts[0]] != "gomatcha.io/matcha/comm" {
+		return "", false
+	}
+	if _, ok := commNotifiers[strings.Replace(parts[1], "RW", "", 1)]; !ok {
+		return "", false
+	}
+	return parts[1], true
+}
+
+// allTypes returns the Go types used by the package's functions, methods and
+// fields.
+func (p *BindgenPackage) allTypes() []string {
+	ts := []string{}
+	add := func(f BindgenFunc) {
+		for _, i := range f.Params {
+			ts = append(ts, i.Type)
+		}
+		ts = append(ts, f.Results...)
+	}
+	for _, f := range p.Funcs {
+		add(f)
+	}
+	for _, t := range p.Types {
+		for _, f := range t.Fields {
+			ts = append(ts, f.Type)
+		}
+		for _, m := range t.Methods {
+			add(m)
+		}
+	}
+	return ts
+}
+
+func (p *BindgenPackage) funcName(name string) string {
+	return p.ImportPath + " " + name
+}
+
+func (p *BindgenPackage) typeName(name string) string {
+	return p.ImportPath + "." + name
+}
+
+// Bindgen writes typed Objective-C and Java facades for the packages named by
+// args to outDir, and the Go code registering their functions and structs into
+// each package's directory. If kotlin is true, Kotlin facades are written
+// instead of Java. Facades of a package are named after the last element of its
+// import path, with prefix prepended to the Objective-C classes.
+func Bindgen(flags *Flags, args []string, outDir, javaPkg, prefix string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+
+	ctx := BindContext()
+	for _, i := range args {
+		pkg, err := ctx.Import(i, cwd, build.ImportComment)
+		if err != nil {
+			return err
+		}
+		if pkg.Name == "main" {
+			return fmt.Errorf("binding 'main' package (%s) is not supported", pkg.ImportPath)
+		}
+		if build.IsLocalImport(pkg.ImportPath) {
+			return fmt.Errorf("cannot determine the import path of %s, the package must be in GOPATH", pkg.Dir)
+		}
+		p, err := FindBindgenDecls(pkg)
+		if err != nil {
+			return err
+		}
+
+		err = WriteFile(flags, filepath.Join(pkg.Dir, bindgenGoFile), func(w io.Writer) error {
+			return WriteBindgenGo(w, p)
+		})
+		if err != nil {
+			return err
+		}
+
+		g := &bindgen{pkg: p, prefix: prefix, javaPkg: javaPkg}
+		objc := filepath.Join(outDir, "objc", g.objcClass(""))
+		if err := WriteFile(flags, objc+".h", g.writeObjcHeader); err != nil {
+			return err
+		}
+		if err := WriteFile(flags, objc+".m", g.writeObjcImpl); err != nil {
+			return err
+		}
+
+		javaDir := filepath.Join(append([]string{outDir, "java"}, strings.Split(javaPkg, ".")...)...)
+		if flags.Kotlin {
+			err = WriteFile(flags, filepath.Join(javaDir, g.namespace()+".kt"), g.writeKotlin)
+		} else {
+			err = WriteFile(flags, filepath.Join(javaDir, g.namespace()+".java"), g.writeJava)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteBindgenGo writes the Go code registering p's functions and structs with
+// the bridge.
+func WriteBindgenGo(w io.Writer, p *BindgenPackage) error {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "// Generated by matcha bindgen. Do not edit.\n\npackage %s\n\n", p.Name)
+
+	hasFuncs, hasTypes := false, false
+	for _, f := range p.Funcs {
+		hasFuncs = hasFuncs || f.Register
+	}
+	for _, t := range p.Types {
+		hasTypes = hasTypes || t.Register
+	}
+	if !hasFuncs && !hasTypes {
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+	if hasTypes {
+		b.WriteString("import (\n\t\"reflect\"\n\n\t\"gomatcha.io/matcha/bridge\"\n)\n")
+	} else {
+		b.WriteString("import \"gomatcha.io/matcha/bridge\"\n")
+	}
+
+	b.WriteString("\nfunc init() {\n")
+	for _, f := range p.Funcs {
+		if f.Register {
+			fmt.Fprintf(b, "\tbridge.RegisterFunc(%q, %s)\n", p.funcName(f.Name), f.Name)
+		}
+	}
+	for _, t := range p.Types {
+		if t.Register {
+			fmt.Fprintf(b, "\tbridge.RegisterType(%q, reflect.TypeOf(%s{}))\n", p.typeName(t.Name), t.Name)
+		}
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// bindgenLang is a language of the facades.
+type bindgenLang int
+
+const (
+	langObjc bindgenLang = iota
+	langJava
+	langKotlin
+)
+
+// bindgenType describes how a Go type is converted to and from the GoValue of
+// a language.
+type bindgenType struct {
+	Name   string
+	ToGo   string // Format converting the value %s to a GoValue.
+	FromGo string // Format converting the GoValue %s to the type.
+	Zero   string // Objective-C value returned when a call fails.
+}
+
+type bindgen struct {
+	pkg     *BindgenPackage
+	prefix  string
+	javaPkg string
+}
+
+func (g *bindgen) namespace() string {
+	return swiftNamespace(g.pkg.ImportPath)
+}
+
+func (g *bindgen) objcClass(typ string) string {
+	return g.prefix + g.namespace() + typ
+}
+
+func (g *bindgen) findType(name string) (BindgenType, bool) {
+	for _, t := range g.pkg.Types {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return BindgenType{}, false
+}
+
+func (g *bindgen) typeOf(goType string, lang bindgenLang) bindgenType {
+	switch lang {
+	case langObjc:
+		switch goType {
+		case "bool":
+			return bindgenType{"BOOL", "[[MatchaGoValue alloc] initWithBool:%s]", "[%s toBool]", "NO"}
+		case "int":
+			// Values are not converted to the parameter's type, so ints
+			// cross the bridge as C ints.
+			return bindgenType{"NSInteger", "[[MatchaGoValue alloc] initWithInt:(int)%s]", "(NSInteger)[%s toLongLong]", "0"}
+		case "int64":
+			return bindgenType{"long long", "[[MatchaGoValue alloc] initWithLongLong:%s]", "[%s toLongLong]", "0"}
+		case "uint64":
+			return bindgenType{"unsigned long long", "[[MatchaGoValue alloc] initWithUnsignedLongLong:%s]", "[%s toUnsignedLongLong]", "0"}
+		case "float64":
+			return bindgenType{"double", "[[MatchaGoValue alloc] initWithDouble:%s]", "[%s toDouble]", "0"}
+		case "string":
+			return bindgenType{"NSString *", "[[MatchaGoValue alloc] initWithString:%s]", "[%s toString]", "nil"}
+		case "[]byte":
+			return bindgenType{"NSData *", "[[MatchaGoValue alloc] initWithData:%s]", "[%s toData]", "nil"}
+		}
+		if t, ok := g.findType(strings.TrimPrefix(goType, "*")); ok {
+			name := g.objcClass(t.Name)
+			toGo := "%s.goValue"
+			if !t.Interface && !strings.HasPrefix(goType, "*") {
+				toGo = "[%s.goValue elem]"
+			}
+			return bindgenType{name + " *", toGo, "[[" + name + " alloc] initWithGoValue:%s]", "nil"}
+		}
+		return bindgenType{"MatchaGoValue *", "%s", "%s", "nil"}
+	case langJava:
+		switch goType {
+		case "bool":
+			return bindgenType{"boolean", "new GoValue(%s)", "%s.toBool()", ""}
+		case "int":
+			return bindgenType{"int", "new GoValue(%s)", "(int) %s.toLong()", ""}
+		case "int64":
+			return bindgenType{"long", "new GoValue(%s)", "%s.toLong()", ""}
+		case "float64":
+			return bindgenType{"double", "new GoValue(%s)", "%s.toDouble()", ""}
+		case "string":
+			return bindgenType{"String", "new GoValue(%s)", "%s.toString()", ""}
+		case "[]byte":
+			return bindgenType{"byte[]", "new GoValue(%s)", "%s.toByteArray()", ""}
+		}
+		if t, ok := g.findType(strings.TrimPrefix(goType, "*")); ok {
+			toGo := "%s.goValue"
+			if !t.Interface && !strings.HasPrefix(goType, "*") {
+				toGo = "%s.goValue.elem()"
+			}
+			return bindgenType{t.Name, toGo, "new " + t.Name + "(%s)", ""}
+		}
+		return bindgenType{"GoValue", "%s", "%s", ""}
+	default:
+		switch goType {
+		case "bool":
+			return bindgenType{"Boolean", "GoValue(%s)", "%s.toBool()", ""}
+		case "int":
+			return bindgenType{"Int", "GoValue(%s)", "%s.toLong().toInt()", ""}
+		case "int64":
+			return bindgenType{"Long", "GoValue(%s)", "%s.toLong()", ""}
+		case "float64":
+			return bindgenType{"Double", "GoValue(%s)", "%s.toDouble()", ""}
+		case "string":
+			return bindgenType{"String", "GoValue(%s)", "%s.toString()", ""}
+		case "[]byte":
+			return bindgenType{"ByteArray", "GoValue(%s)", "%s.toByteArray()", ""}
+		}
+		if t, ok := g.findType(strings.TrimPrefix(goType, "*")); ok {
+			toGo := "%s.goValue"
+			if !t.Interface && !strings.HasPrefix(goType, "*") {
+				toGo = "%s.goValue.elem()"
+			}
+			return bindgenType{t.Name, toGo, t.Name + "(%s)", ""}
+		}
+		return bindgenType{"GoValue", "%s", "%s", ""}
+	}
+}
+
+// typeComment returns a comment for Go types that are passed through as
+// GoValues but are meant to be used with Matcha's native classes.
+func typeComment(goType string, lang bindgenLang) string {
+	if goType != "gomatcha.io/matcha/view.View" {
+		return ""
+	}
+	if lang == langObjc {
+		return "view.View, display it with -[MatchaViewController initWithGoValue:]."
+	}
+	return "view.View, display it with new MatchaView(context, value)."
+}
+
+// splitResults removes a trailing error from results.
+func splitResults(results []string) ([]string, bool) {
+	if len(results) > 0 && results[len(results)-1] == "error" {
+		return results[:len(results)-1], true
+	}
+	return results, false
+}
+
+func (g *bindgen) writeObjcHeader(w io.Writer) error {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "// Generated by matcha bindgen. Do not edit.\n\n#import <Foundation/Foundation.h>\n#import <MatchaBridge/MatchaBridge.h>\n")
+	for _, t := range g.pkg.Types {
+		fmt.Fprintf(b, "\n@class %s;", g.objcClass(t.Name))
+	}
+	if len(g.pkg.Types) > 0 {
+		b.WriteString("\n")
+	}
+
+	for _, t := range g.pkg.Types {
+		fmt.Fprintf(b, "\n// %s\n@interface %s : NSObject\n", g.goTypeName(t), g.objcClass(t.Name))
+		if !t.Interface {
+			b.WriteString("- (id)init;\n")
+		}
+		b.WriteString("- (id)initWithGoValue:(MatchaGoValue *)value;\n@property (nonatomic, readonly) MatchaGoValue *goValue;\n")
+		for _, f := range t.Fields {
+			ot := g.typeOf(f.Type, langObjc)
+			fmt.Fprintf(b, "@property (nonatomic%s) %s;\n", objcAttributes(ot.Name), objcDecl(ot.Name, swiftIdent(f.Name, false)))
+		}
+		for _, m := range t.Methods {
+			g.writeObjcFunc(b, m, "-", true)
+		}
+		b.WriteString("@end\n")
+	}
+
+	fmt.Fprintf(b, "\n// %s\n@interface %s : NSObject\n", g.pkg.ImportPath, g.objcClass(""))
+	for _, f := range g.pkg.Funcs {
+		g.writeObjcFunc(b, f, "+", true)
+	}
+	b.WriteString("@end\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (g *bindgen) writeObjcImpl(w io.Writer) error {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "// Generated by matcha bindgen. Do not edit.\n\n#import \"%s.h\"\n", g.objcClass(""))
+
+	for _, t := range g.pkg.Types {
+		fmt.Fprintf(b, "\n@implementation %s\n\n", g.objcClass(t.Name))
+		if !t.Interface {
+			fmt.Fprintf(b, "- (id)init {\n    return [self initWithGoValue:[[MatchaGoValue alloc] initWithType:@%q]];\n}\n\n", g.pkg.typeName(t.Name))
+		}
+		b.WriteString("- (id)initWithGoValue:(MatchaGoValue *)value {\n    if ((self = [super init])) {\n        _goValue = value;\n    }\n    return self;\n}\n")
+		for _, f := range t.Fields {
+			ot := g.typeOf(f.Type, langObjc)
+			name := swiftIdent(f.Name, false)
+			fmt.Fprintf(b, "\n- (%s)%s {\n    return %s;\n}\n", strings.TrimSpace(ot.Name), name, fmt.Sprintf(ot.FromGo, fmt.Sprintf("self.goValue[@%q]", f.Name)))
+			fmt.Fprintf(b, "\n- (void)set%s:(%s)v {\n    self.goValue[@%q] = %s;\n}\n", swiftIdent(f.Name, true), strings.TrimSpace(ot.Name), f.Name, fmt.Sprintf(ot.ToGo, "v"))
+		}
+		for _, m := range t.Methods {
+			if g.writeObjcFunc(b, m, "-", false) {
+				g.writeObjcBody(b, m, "self.goValue", "@"+strconv.Quote(m.Name))
+			}
+		}
+		b.WriteString("\n@end\n")
+	}
+
+	fmt.Fprintf(b, "\n@implementation %s\n", g.objcClass(""))
+	for _, f := range g.pkg.Funcs {
+		if g.writeObjcFunc(b, f, "+", false) {
+			g.writeObjcBody(b, f, fmt.Sprintf("[[MatchaGoValue alloc] initWithFunc:@%q]", g.pkg.funcName(f.Name)), "nil")
+		}
+	}
+	b.WriteString("\n@end\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (g *bindgen) goTypeName(t BindgenType) string {
+	if t.Notifier {
+		return "gomatcha.io/matcha/comm." + t.Name
+	}
+	return g.pkg.typeName(t.Name)
+}
+
+// objcDecl returns the declaration of name with the Objective-C type t.
+func objcDecl(t, name string) string {
+	if strings.HasSuffix(t, "*") {
+		return t + name
+	}
+	return t + " " + name
+}
+
+// objcAttributes returns the property attributes of the Objective-C type t.
+func objcAttributes(t string) string {
+	if strings.HasSuffix(t, "*") {
+		return ", strong"
+	}
+	return ", assign"
+}
+
+// writeObjcFunc writes the declaration of f, followed by ";" if decl is true.
+// It returns false if f is skipped.
+func (g *bindgen) writeObjcFunc(b *strings.Builder, f BindgenFunc, kind string, decl bool) bool {
+	if f.Variadic {
+		if decl {
+			fmt.Fprintf(b, "// %s is skipped, variadic functions are not supported.\n", f.Name)
+		}
+		return false
+	}
+	results, throws := splitResults(f.Results)
+	ret := "void"
+	switch len(results) {
+	case 0:
+		if throws {
+			ret = "BOOL"
+		}
+	case 1:
+		ret = strings.TrimSpace(g.typeOf(results[0], langObjc).Name)
+	default:
+		ret = "NSArray<MatchaGoValue *> *"
+	}
+	if decl {
+		if len(results) == 1 {
+			if c := typeComment(results[0], langObjc); c != "" {
+				fmt.Fprintf(b, "// Returns a %s\n", c)
+			}
+		}
+		if throws {
+			b.WriteString("// Sets error and returns nil, 0 or NO if the Go function returns an error.\n")
+		}
+	} else {
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(b, "%s (%s)%s", kind, ret, swiftIdent(f.Name, false))
+	for i, p := range f.Params {
+		label := p.Name
+		if i == 0 || label == "_" {
+			label = ""
+		}
+		if i > 0 {
+			b.WriteString(" ")
+		}
+		fmt.Fprintf(b, "%s:(%s)p%d", label, strings.TrimSpace(g.typeOf(p.Type, langObjc).Name), i)
+	}
+	if throws {
+		if len(f.Params) > 0 {
+			b.WriteString(" error")
+		}
+		b.WriteString(":(NSError **)error")
+	}
+	if decl {
+		b.WriteString(";\n")
+	} else {
+		b.WriteString(" {\n")
+	}
+	return true
+}
+
+func (g *bindgen) writeObjcBody(b *strings.Builder, f BindgenFunc, receiver, method string) {
+	results, throws := splitResults(f.Results)
+	args := []string{}
+	for i, p := range f.Params {
+		args = append(args, fmt.Sprintf(g.typeOf(p.Type, langObjc).ToGo, fmt.Sprintf("p%d", i)))
+	}
+	call := fmt.Sprintf("[%s call:%s arguments:@[%s]]", receiver, method, strings.Join(args, ", "))
+	if throws {
+		call = fmt.Sprintf("[%s tryCall:%s arguments:@[%s] error:error]", receiver, method, strings.Join(args, ", "))
+	}
+
+	switch {
+	case len(results) == 0 && !throws:
+		fmt.Fprintf(b, "    %s;\n", call)
+	case len(results) == 0:
+		fmt.Fprintf(b, "    return %s != nil;\n", call)
+	case len(results) > 1 && !throws:
+		fmt.Fprintf(b, "    return %s;\n", call)
+	default:
+		fmt.Fprintf(b, "    NSArray<MatchaGoValue *> *r = %s;\n", call)
+		if len(results) > 1 {
+			b.WriteString("    return r;\n")
+			break
+		}
+		ot := g.typeOf(results[0], langObjc)
+		if throws {
+			fmt.Fprintf(b, "    if (r == nil) {\n        return %s;\n    }\n", ot.Zero)
+		}
+		fmt.Fprintf(b, "    return %s;\n", fmt.Sprintf(ot.FromGo, "r[0]"))
+	}
+	b.WriteString("}\n")
+}
+
+func (g *bindgen) writeJava(w io.Writer) error {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "// Generated by matcha bindgen. Do not edit.\n\npackage %s;\n\nimport io.gomatcha.bridge.GoValue;\n", g.javaPkg)
+	fmt.Fprintf(b, "\n// %s\npublic final class %s {\n    private %s() {}\n", g.pkg.ImportPath, g.namespace(), g.namespace())
+
+	for _, f := range g.pkg.Funcs {
+		g.writeJavaFunc(b, f, "    ", "public static", fmt.Sprintf("GoValue.withFunc(%q)", g.pkg.funcName(f.Name)), `""`)
+	}
+
+	for _, t := range g.pkg.Types {
+		fmt.Fprintf(b, "\n    // %s\n    public static final class %s {\n        public final GoValue goValue;\n", g.goTypeName(t), t.Name)
+		if !t.Interface {
+			fmt.Fprintf(b, "\n        public %s() {\n            this(GoValue.withType(%q));\n        }\n", t.Name, g.pkg.typeName(t.Name))
+		}
+		fmt.Fprintf(b, "\n        public %s(GoValue v) {\n            goValue = v;\n        }\n", t.Name)
+		for _, f := range t.Fields {
+			jt := g.typeOf(f.Type, langJava)
+			fmt.Fprintf(b, "\n        public %s get%s() {\n            return %s;\n        }\n", jt.Name, f.Name, fmt.Sprintf(jt.FromGo, fmt.Sprintf("goValue.field(%q)", f.Name)))
+			fmt.Fprintf(b, "\n        public void set%s(%s v) {\n            goValue.setField(%q, %s);\n        }\n", f.Name, jt.Name, f.Name, fmt.Sprintf(jt.ToGo, "v"))
+		}
+		for _, m := range t.Methods {
+			g.writeJavaFunc(b, m, "        ", "public", "goValue", strconv.Quote(m.Name))
+		}
+		b.WriteString("    }\n")
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (g *bindgen) writeJavaFunc(b *strings.Builder, f BindgenFunc, indent, modifiers, receiver, method string) {
+	if f.Variadic {
+		fmt.Fprintf(b, "\n%s// %s is skipped, variadic functions are not supported.\n", indent, f.Name)
+		return
+	}
+	results, throws := splitResults(f.Results)
+	params := []string{}
+	args := []string{method}
+	for i, p := range f.Params {
+		jt := g.typeOf(p.Type, langJava)
+		params = append(params, fmt.Sprintf("%s p%d", jt.Name, i))
+		args = append(args, fmt.Sprintf(jt.ToGo, fmt.Sprintf("p%d", i)))
+	}
+	ret := "void"
+	switch len(results) {
+	case 0:
+	case 1:
+		ret = g.typeOf(results[0], langJava).Name
+	default:
+		ret = "GoValue[]"
+	}
+
+	b.WriteString("\n")
+	if len(results) == 1 {
+		if c := typeComment(results[0], langJava); c != "" {
+			fmt.Fprintf(b, "%s// Returns a %s\n", indent, c)
+		}
+	}
+	if throws {
+		fmt.Fprintf(b, "%s// Throws a GoException if the Go function returns an error.\n", indent)
+	}
+	fmt.Fprintf(b, "%s%s %s %s(%s) {\n", indent, modifiers, ret, swiftIdent(f.Name, false), strings.Join(params, ", "))
+	call := "call"
+	if throws {
+		call = "callOrThrow"
+	}
+	switch len(results) {
+	case 0:
+		fmt.Fprintf(b, "%s    %s.%s(%s);\n", indent, receiver, call, strings.Join(args, ", "))
+	case 1:
+		fmt.Fprintf(b, "%s    GoValue[] r = %s.%s(%s);\n", indent, receiver, call, strings.Join(args, ", "))
+		fmt.Fprintf(b, "%s    return %s;\n", indent, fmt.Sprintf(g.typeOf(results[0], langJava).FromGo, "r[0]"))
+	default:
+		fmt.Fprintf(b, "%s    return %s.%s(%s);\n", indent, receiver, call, strings.Join(args, ", "))
+	}
+	fmt.Fprintf(b, "%s}\n", indent)
+}
+
+func (g *bindgen) writeKotlin(w io.Writer) error {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "// Generated by matcha bindgen. Do not edit.\n\npackage %s\n\nimport io.gomatcha.bridge.GoValue\n", g.javaPkg)
+	fmt.Fprintf(b, "\n// %s\nobject %s {", g.pkg.ImportPath, g.namespace())
+
+	for _, f := range g.pkg.Funcs {
+		g.writeKotlinFunc(b, f, "    ", "@JvmStatic fun", fmt.Sprintf("GoValue.withFunc(%q)", g.pkg.funcName(f.Name)), `""`)
+	}
+
+	for _, t := range g.pkg.Types {
+		fmt.Fprintf(b, "\n    // %s\n    class %s(val goValue: GoValue) {", g.goTypeName(t), t.Name)
+		if !t.Interface {
+			fmt.Fprintf(b, "\n        constructor() : this(GoValue.withType(%q))\n", g.pkg.typeName(t.Name))
+		}
+		for _, f := range t.Fields {
+			kt := g.typeOf(f.Type, langKotlin)
+			fmt.Fprintf(b, "\n        var %s: %s\n", swiftIdent(f.Name, false), kt.Name)
+			fmt.Fprintf(b, "            get() = %s\n", fmt.Sprintf(kt.FromGo, fmt.Sprintf("goValue.field(%q)", f.Name)))
+			fmt.Fprintf(b, "            set(v) = goValue.setField(%q, %s)\n", f.Name, fmt.Sprintf(kt.ToGo, "v"))
+		}
+		for _, m := range t.Methods {
+			g.writeKotlinFunc(b, m, "        ", "fun", "goValue", strconv.Quote(m.Name))
+		}
+		b.WriteString("    }\n")
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (g *bindgen) writeKotlinFunc(b *strings.Builder, f BindgenFunc, indent, modifiers, receiver, method string) {
+	if f.Variadic {
+		fmt.Fprintf(b, "\n%s// %s is skipped, variadic functions are not supported.\n", indent, f.Name)
+		return
+	}
+	results, throws := splitResults(f.Results)
+	params := []string{}
+	args := []string{method}
+	for i, p := range f.Params {
+		kt := g.typeOf(p.Type, langKotlin)
+		params = append(params, fmt.Sprintf("p%d: %s", i, kt.Name))
+		args = append(args, fmt.Sprintf(kt.ToGo, fmt.Sprintf("p%d", i)))
+	}
+	ret := ""
+	switch len(results) {
+	case 0:
+	case 1:
+		ret = ": " + g.typeOf(results[0], langKotlin).Name
+	default:
+		ret = ": Array<GoValue>"
+	}
+
+	b.WriteString("\n")
+	if len(results) == 1 {
+		if c := typeComment(results[0], langKotlin); c != "" {
+			fmt.Fprintf(b, "%s// Returns a %s\n", indent, c)
+		}
+	}
+	if throws {
+		fmt.Fprintf(b, "%s// Throws a GoException if the Go function returns an error.\n", indent)
+	}
+	call := "call"
+	if throws {
+		call = "callOrThrow"
+	}
+	fmt.Fprintf(b, "%s%s %s(%s)%s", indent, modifiers, swiftIdent(f.Name, false), strings.Join(params, ", "), ret)
+	switch len(results) {
+	case 0:
+		fmt.Fprintf(b, " {\n%s    %s.%s(%s)\n%s}\n", indent, receiver, call, strings.Join(args, ", "), indent)
+	case 1:
+		fmt.Fprintf(b, " = %s\n", fmt.Sprintf(g.typeOf(results[0], langKotlin).FromGo, fmt.Sprintf("%s.%s(%s)[0]", receiver, call, strings.Join(args, ", "))))
+	default:
+		fmt.Fprintf(b, " = %s.%s(%s)\n", receiver, call, strings.Join(args, ", "))
+	}
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// DepsPackage describes a single package in the dependency graph of a bind.
+type DepsPackage struct {
+	ImportPath    string   `json:"importPath"`
+	Dir           string   `json:"dir"`
+	Imports       []string `json:"imports"`
+	ImportsBridge bool     `json:"importsBridge"` // Package imports gomatcha.io/bridge.
+	HasIOSDir     bool     `json:"hasIOSDir"`     // Package contributes an ios directory to the iOS output.
+}
+
+// Deps writes the package dependency graph used when binding args to w. Format
+// may be "dot" or "json".
+func Deps(flags *Flags, args []string, format string, w io.Writer) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	ctx := BindContext()
+	pkgs, err := ImportBindPackages(&ctx, args, cwd)
+	if err != nil {
+		return err
+	}
+
+	graph := []DepsPackage{}
+	for _, pkg := range pkgs {
+		p := DepsPackage{
+			ImportPath:    pkg.ImportPath,
+			Dir:           pkg.Dir,
+			Imports:       pkg.Imports,
+			ImportsBridge: ImportsBridge(pkg),
+		}
+		if p.ImportsBridge {
+			if fi, err := os.Stat(filepath.Join(pkg.Dir, "ios")); err == nil && fi.IsDir() {
+				p.HasIOSDir = true
+			}
+		}
+		if p.Imports == nil {
+			p.Imports = []string{}
+		}
+		graph = append(graph, p)
+	}
+	sort.Slice(graph, func(i, j int) bool {
+		return graph[i].ImportPath < graph[j].ImportPath
+	})
+
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(graph)
+	case "dot", "":
+		return writeDot(w, graph)
+	default:
+		return fmt.Errorf("unknown format %q. Valid values are: dot, json", format)
+	}
+}
+
+func writeDot(w io.Writer, graph []DepsPackage) error {
+	known := map[string]bool{}
+	for _, i := range graph {
+		known[i.ImportPath] = true
+	}
+
+	if _, err := fmt.Fprintln(w, "digraph deps {"); err != nil {
+		return err
+	}
+	for _, i := range graph {
+		attrs := ""
+		if i.HasIOSDir {
+			attrs = " [style=filled, fillcolor=orange]"
+		} else if i.ImportsBridge {
+			attrs = " [style=filled, fillcolor=yellow]"
+		}
+		if _, err := fmt.Fprintf(w, "\t%q%s;\n", i.ImportPath, attrs); err != nil {
+			return err
+		}
+	}
+	for _, i := range graph {
+		for _, j := range i.Imports {
+			if !known[j] {
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "\t%q -> %q;\n", i.ImportPath, j); err != nil {
+				return err
+			}
+		}
+	}
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Flutter writes a Flutter plugin that renders components registered with
+// gomatcha.io/matcha/flutter as platform views to dir. The plugin is added to
+// the app's pubspec.yaml as a path dependency.
+func Flutter(flags *Flags, dir string) error {
+	err := copySupportFiles(flags, dir, []supportFile{
+		{"pubspec.yaml", "flutter-pubspec.yaml.support"},
+		{filepath.Join("lib", "matcha.dart"), "flutter-matcha.dart.support"},
+		{filepath.Join("ios", "matcha.podspec"), "flutter-matcha.podspec.support"},
+		{filepath.Join("ios", "Classes", "MatchaPlugin.h"), "flutter-MatchaPlugin.h.support"},
+		{filepath.Join("ios", "Classes", "MatchaPlugin.m"), "flutter-MatchaPlugin.m.support"},
+		{filepath.Join("android", "build.gradle"), "flutter-build.gradle.support"},
+		{filepath.Join("android", "src", "main", "java", "io", "gomatcha", "flutter", "MatchaPlugin.java"), "flutter-MatchaPlugin.java.support"},
+	})
+	if err != nil {
+		return err
+	}
+	if err := writeAndroidManifest(flags, dir, "io.gomatcha.flutter"); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Flutter plugin written to %s.\n", dir)
+	return nil
+}
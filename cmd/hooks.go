@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// BuildConfig is the matcha.yaml file in the directory `matcha build` is run
+// from. It configures commands that run before and after the build, such as
+// code generators and asset processors.
+//
+//  hooks:
+//    pre-build:
+//      - name: protobuf
+//        run: protoc --gogofaster_out=. *.proto
+//    post-build:
+//      - name: upload
+//        run: ./scripts/upload.sh "$MATCHA_OUTPUT"
+//        targets: [ios]
+type BuildConfig struct {
+	Hooks struct {
+		PreBuild  []Hook `yaml:"pre-build"`
+		PostBuild []Hook `yaml:"post-build"`
+	} `yaml:"hooks"`
+}
+
+// Hook is a shell command run at a stage of the build.
+type Hook struct {
+	Name    string   `yaml:"name"`
+	Run     string   `yaml:"run"`
+	Targets []string `yaml:"targets"` // Only run if one of these targets is built. Runs for all targets if empty.
+}
+
+// HookContext describes the build to hooks. It is passed to the command as
+// MATCHA_* environment variables.
+type HookContext struct {
+	Stage     string // "pre-build" or "post-build"
+	Targets   map[string]struct{}
+	Packages  []string
+	WorkDir   string
+	OutputDir string
+}
+
+// LoadBuildConfig reads dir/matcha.yaml. A missing file yields an empty config.
+func LoadBuildConfig(dir string) (*BuildConfig, error) {
+	c := &BuildConfig{}
+	path := filepath.Join(dir, "matcha.yaml")
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return c, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := yaml.UnmarshalStrict(data, c); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	for _, i := range append(append([]Hook{}, c.Hooks.PreBuild...), c.Hooks.PostBuild...) {
+		if i.Run == "" {
+			return nil, fmt.Errorf("%s: hook %q has no run command", path, i.Name)
+		}
+	}
+	return c, nil
+}
+
+// Env returns the environment variables describing the build.
+func (c HookContext) Env() []string {
+	targets := []string{}
+	for i := range c.Targets {
+		targets = append(targets, i)
+	}
+	sort.Strings(targets)
+	return []string{
+		"MATCHA_STAGE=" + c.Stage,
+		"MATCHA_TARGETS=" + strings.Join(targets, " "),
+		"MATCHA_PACKAGES=" + strings.Join(c.Packages, " "),
+		"MATCHA_WORK=" + c.WorkDir,
+		"MATCHA_OUTPUT=" + c.OutputDir,
+	}
+}
+
+// RunHooks runs hooks in order from dir, stopping at the first failure.
+func RunHooks(flags *Flags, dir string, hooks []Hook, c HookContext) error {
+	for _, i := range hooks {
+		if !i.matches(c.Targets) {
+			continue
+		}
+		name := i.Name
+		if name == "" {
+			name = i.Run
+		}
+		if flags.BuildV {
+			fmt.Fprintf(os.Stderr, "\n# Running %s hook %s.\n", c.Stage, name)
+		}
+
+		var cmd *exec.Cmd
+		if runtime.GOOS == "windows" {
+			cmd = exec.Command("cmd", "/C", i.Run)
+		} else {
+			cmd = exec.Command("sh", "-c", i.Run)
+		}
+		cmd.Dir = dir
+		cmd.Env = c.Env()
+		if err := RunCmd(flags, c.WorkDir, cmd); err != nil {
+			return fmt.Errorf("%s hook %s failed: %v", c.Stage, name, err)
+		}
+	}
+	return nil
+}
+
+func (h Hook) matches(targets map[string]struct{}) bool {
+	if len(h.Targets) == 0 {
+		return true
+	}
+	for _, i := range h.Targets {
+		if _, ok := targets[i]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -17,6 +17,28 @@ import (
 func Init(flags *Flags) error {
 	start := time.Now()
 
+	// Install a toolchain from a release channel, or keep using the current one.
+	state, err := LoadToolchainState(flags)
+	if err != nil {
+		return err
+	}
+	if flags.ToolchainChannel != "" {
+		release, file, err := FindToolchainRelease(flags.ToolchainChannel)
+		if err != nil {
+			return err
+		}
+		if err := InstallToolchain(flags, release, file); err != nil {
+			return err
+		}
+		state.Stage(flags.ToolchainChannel, release.Version)
+		if err := state.Save(); err != nil {
+			return err
+		}
+	}
+	if err := UseToolchain(state); err != nil {
+		return err
+	}
+
 	// BEGIN ANDORID
 	// toolsDir := filepath.Join("prebuilt", "darwin-x86_64", "bin")
 	// // Try the ndk-bundle SDK package package, if installed.
@@ -73,7 +95,11 @@ func Init(flags *Flags) error {
 	}
 	defer RemoveAll(flags, tmpdir)
 
-	// Install standard libraries for cross compilers.
+	// Install standard libraries for cross compilers. This also warms the
+	// shared GOCACHE for each GOOS/GOARCH pair, see GoCacheEnv.
+	if flags.ShouldPrint() {
+		fmt.Fprintln(os.Stderr, "GOCACHE="+GoEnv("GOCACHE"))
+	}
 	var env []string
 	if env, err = DarwinArmEnv(flags); err != nil {
 		return err
@@ -128,6 +154,35 @@ func Init(flags *Flags) error {
 		return err
 	}
 
+	if err := InstallPkg(flags, tmpdir, "std", WasmEnv()); err != nil {
+		return err
+	}
+
+	// The windows target is optional, so a missing toolchain is not an error.
+	if cc, err := FindWindowsCC(); err != nil {
+		fmt.Fprintf(os.Stderr, "Skipping windows: %v\n", err)
+	} else {
+		ccpath := filepath.Join(gomobilepath, "windows_cc")
+		if flags.ShouldPrint() {
+			fmt.Fprintln(os.Stderr, "echo "+cc+" >", ccpath)
+		}
+		if flags.ShouldRun() {
+			if err := ioutil.WriteFile(ccpath, []byte(cc), 0644); err != nil {
+				return err
+			}
+		}
+		if err := InstallPkg(flags, tmpdir, "std", windowsEnv(cc)); err != nil {
+			return err
+		}
+	}
+
+	// The linux target is built natively, and only if GTK 4 is installed.
+	if env, err := LinuxEnv(); err != nil {
+		fmt.Fprintf(os.Stderr, "Skipping linux: %v\n", err)
+	} else if err := InstallPkg(flags, tmpdir, "std", env); err != nil {
+		return err
+	}
+
 	// Write Go Version to $GOPATH/pkg/gomobile/version
 	verpath := filepath.Join(gomobilepath, "version")
 	if flags.ShouldPrint() {
@@ -183,6 +238,6 @@ func InstallPkg(f *Flags, temporarydir string, pkg string, env []string, args ..
 		cmd.Args = append(cmd.Args, "-work")
 	}
 	cmd.Args = append(cmd.Args, pkg)
-	cmd.Env = append([]string{}, env...)
+	cmd.Env = GoCacheEnv(append([]string{}, env...))
 	return RunCmd(f, temporarydir, cmd)
 }
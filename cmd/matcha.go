@@ -9,9 +9,12 @@ import (
 	"errors"
 	"fmt"
 	"go/build"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
 )
 
@@ -25,6 +28,18 @@ type Flags struct {
 	BuildO       string // output path
 	BuildBinary  bool
 	BuildTargets string
+	BuildResume  bool // --resume
+	BuildDebug   bool // --debug
+
+	CodesignIdentity     string // --codesign-identity
+	CodesignEntitlements string // --entitlements
+
+	AndroidVariant string // --android-variant
+	IOSExtension   bool   // --ios-extension
+	Flutter        bool   // --flutter
+	Kotlin         bool   // --kotlin
+
+	ToolchainChannel string // --channel
 }
 
 func (f *Flags) ShouldPrint() bool {
@@ -55,10 +70,80 @@ func ArchClang(goarch string) string {
 	}
 }
 
+// Codesign signs the file at path with flags.CodesignIdentity. It does nothing if
+// no identity was provided.
+func Codesign(flags *Flags, tmpdir string, path string) error {
+	if flags.CodesignIdentity == "" {
+		return nil
+	}
+	if !XcodeAvailable() {
+		return errors.New("Xcode not available, codesign requires macOS")
+	}
+
+	cmd := exec.Command("xcrun", "codesign", "--force", "--timestamp", "--sign", flags.CodesignIdentity)
+	if flags.CodesignEntitlements != "" {
+		cmd.Args = append(cmd.Args, "--entitlements", flags.CodesignEntitlements)
+	}
+	cmd.Args = append(cmd.Args, path)
+	return RunCmd(flags, tmpdir, cmd)
+}
+
+// IOSSignPaths returns the artifacts of an iOS build in outputDir to sign: the
+// framework and XCFramework bundles at its top level, or the MatchaBridge
+// binaries with names if there are none.
+func IOSSignPaths(outputDir string, buildBinary bool, names []string) []string {
+	paths := []string{}
+	for _, pattern := range []string{"*.framework", "*.xcframework"} {
+		matches, _ := filepath.Glob(filepath.Join(outputDir, pattern))
+		paths = append(paths, matches...)
+	}
+	if len(paths) > 0 {
+		return paths
+	}
+
+	dir := filepath.Join(outputDir, "MatchaBridge", "MatchaBridge")
+	if buildBinary {
+		dir = filepath.Join(outputDir, "ios", "MatchaBridge", "MatchaBridge")
+	}
+	sort.Strings(names)
+	for _, i := range names {
+		paths = append(paths, filepath.Join(dir, i))
+	}
+	return paths
+}
+
+// LipoAvailable returns the command used to create fat binaries, either xcrun or llvm-lipo.
+// It returns an empty string if neither is installed.
+func LipoAvailable() string {
+	if XcodeAvailable() {
+		return "xcrun"
+	}
+	if _, err := exec.LookPath("llvm-lipo"); err == nil {
+		return "llvm-lipo"
+	}
+	return ""
+}
+
+// Returns the environmental variable containing the SDK path when Xcode is not
+// installed. e.g. MATCHA_IPHONEOS_SDK
+func sdkEnvName(sdkName string) string {
+	return "MATCHA_" + strings.ToUpper(sdkName) + "_SDK"
+}
+
 // Get clang path and clang flags (SDK Path).
 func EnvClang(flags *Flags, sdkName string) (_clang, cflags string, err error) {
 	if !XcodeAvailable() {
-		return "", "", errors.New("Xcode not available")
+		// Fall back to a clang on $PATH with a user provided SDK, so that the
+		// Go archives can be cross-compiled on hosts other than macOS.
+		sdk := os.Getenv(sdkEnvName(sdkName))
+		if sdk == "" {
+			return "", "", fmt.Errorf("Xcode not available and $%s is not set", sdkEnvName(sdkName))
+		}
+		clang, err := exec.LookPath("clang")
+		if err != nil {
+			return "", "", fmt.Errorf("Xcode not available and clang not found: %v", err)
+		}
+		return clang, "-isysroot " + sdk, nil
 	}
 
 	// Get the clang path
@@ -162,6 +247,84 @@ func DarwinAmd64Env(f *Flags) ([]string, error) {
 	}, nil
 }
 
+func WasmEnv() []string {
+	return []string{
+		"GOOS=js",
+		"GOARCH=wasm",
+		"CGO_ENABLED=0",
+	}
+}
+
+// Returns the path of the wasm_exec.js support file that matches the Go
+// toolchain. It moved from misc/wasm to lib/wasm in newer releases.
+func WasmExecPath() (string, error) {
+	goroot := GoEnv("GOROOT")
+	for _, i := range []string{"lib", "misc"} {
+		path := filepath.Join(goroot, i, "wasm", "wasm_exec.js")
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("wasm_exec.js not found in %v", goroot)
+}
+
+// Returns the C compiler used to cross compile for windows, preferring the
+// mingw-w64 gcc over clang.
+func FindWindowsCC() (string, error) {
+	if path, err := exec.LookPath("x86_64-w64-mingw32-gcc"); err == nil {
+		return path, nil
+	}
+	if runtime.GOOS == "windows" {
+		if path, err := exec.LookPath("gcc"); err == nil {
+			return path, nil
+		}
+	}
+	if path, err := exec.LookPath("clang"); err == nil {
+		return path + " --target=x86_64-w64-mingw32", nil
+	}
+	return "", errors.New("no mingw-w64 toolchain found. Install x86_64-w64-mingw32-gcc or clang.")
+}
+
+// Returns the environment for the windows target, using the C compiler found
+// by `matcha init`.
+func WindowsEnv(gomobpath string) ([]string, error) {
+	cc, err := ioutil.ReadFile(filepath.Join(gomobpath, "windows_cc"))
+	if err != nil || len(bytes.TrimSpace(cc)) == 0 {
+		return nil, errors.New("windows toolchain not initialized. Install mingw-w64 and run `matcha init`.")
+	}
+	return windowsEnv(strings.TrimSpace(string(cc))), nil
+}
+
+func windowsEnv(cc string) []string {
+	return []string{
+		"GOOS=windows",
+		"GOARCH=amd64",
+		"CC=" + cc,
+		"CGO_ENABLED=1",
+	}
+}
+
+// Returns the environment for the linux target. Unlike windows it is not cross
+// compiled, as the host renderer links against the system's GTK 4.
+func LinuxEnv() ([]string, error) {
+	if runtime.GOOS != "linux" {
+		return nil, errors.New("the linux target can only be built on linux.")
+	}
+	if err := exec.Command("pkg-config", "--exists", "gtk4").Run(); err != nil {
+		return nil, errors.New("gtk4 not found. Install the GTK 4 development package and pkg-config.")
+	}
+	cc := os.Getenv("CC")
+	if cc == "" {
+		cc = "cc"
+	}
+	return []string{
+		"GOOS=linux",
+		"GOARCH=" + runtime.GOARCH,
+		"CC=" + cc,
+		"CGO_ENABLED=1",
+	}, nil
+}
+
 func Getenv(env []string, key string) string {
 	prefix := key + "="
 	for _, kv := range env {
@@ -213,6 +376,20 @@ func GoEnv(name string) string {
 	return strings.TrimSpace(string(val))
 }
 
+// Returns env with GOCACHE set to the host's build cache, unless env already
+// sets it. Builds use temporary GOPATHs, so sharing the cache lets unchanged
+// packages be reused across builds.
+func GoCacheEnv(env []string) []string {
+	if Getenv(env, "GOCACHE") != "" {
+		return env
+	}
+	cache := GoEnv("GOCACHE")
+	if cache == "" || cache == "off" {
+		return env
+	}
+	return append(env, "GOCACHE="+cache)
+}
+
 func GoVersion(f *Flags) ([]byte, error) {
 	cmd := exec.Command("go", "version")
 	if f.ShouldPrint() {
@@ -269,6 +446,6 @@ func GoCmd(f *Flags, subcmd string, srcs []string, env []string, ctx build.Conte
 	}
 	cmd.Args = append(cmd.Args, args...)
 	cmd.Args = append(cmd.Args, srcs...)
-	cmd.Env = append([]string{}, env...)
+	cmd.Env = GoCacheEnv(append([]string{}, env...))
 	return RunCmd(f, tmpdir, cmd)
 }
@@ -0,0 +1,668 @@
+// Copyright 2017 The Matcha Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// matcha.js renders a Matcha view hierarchy into the DOM. It is the browser
+// counterpart of MatchaViewNode on Android and iOS, and implements the methods
+// that Go calls through the bridge package. Usage:
+//
+//  <script src="wasm_exec.js"></script>
+//  <script src="matcha.js"></script>
+//  <script>
+//      matcha.run("matcha.wasm", "gomatcha.io/matcha/examples/todo New", document.body);
+//  </script>
+(function(global) {
+    "use strict";
+
+    // Protobuf decoding. Schemas map field numbers to [name, type, repeated].
+    // Types are scalar names, nested schemas, or ["map", keyType, valueType].
+
+    function Reader(bytes) {
+        this.buf = bytes;
+        this.pos = 0;
+        this.view = new DataView(bytes.buffer, bytes.byteOffset, bytes.byteLength);
+    }
+
+    Reader.prototype.varint = function() {
+        var result = 0, mul = 1, b;
+        do {
+            b = this.buf[this.pos++];
+            result += (b & 0x7f) * mul;
+            mul *= 128;
+        } while (b & 0x80);
+        return result;
+    };
+
+    Reader.prototype.skip = function(wireType) {
+        switch (wireType) {
+        case 0: this.varint(); break;
+        case 1: this.pos += 8; break;
+        case 2: this.pos += this.varint(); break;
+        case 5: this.pos += 4; break;
+        default: throw new Error("matcha: unsupported wire type " + wireType);
+        }
+    };
+
+    function decodeValue(r, wireType, type) {
+        switch (type) {
+        case "int64": case "uint32": case "enum": return r.varint();
+        case "bool": return r.varint() !== 0;
+        case "double":
+            var d = r.view.getFloat64(r.pos, true);
+            r.pos += 8;
+            return d;
+        }
+        var len = r.varint();
+        var bytes = r.buf.subarray(r.pos, r.pos + len);
+        r.pos += len;
+        if (type === "bytes") {
+            return bytes;
+        } else if (type === "string") {
+            return new TextDecoder("utf-8").decode(bytes);
+        } else if (Array.isArray(type)) {
+            var entry = decode(bytes, {1: ["key", type[1]], 2: ["value", type[2]]});
+            if (entry.value === undefined && typeof type[2] === "object") {
+                entry.value = decode(null, type[2]);
+            }
+            return entry;
+        }
+        return decode(bytes, type);
+    }
+
+    function decode(bytes, schema) {
+        var msg = {};
+        for (var k in schema) {
+            var f = schema[k];
+            if (f[2]) {
+                msg[f[0]] = [];
+            } else if (Array.isArray(f[1])) {
+                msg[f[0]] = {};
+            }
+        }
+        if (!bytes) {
+            return msg;
+        }
+        var r = new Reader(bytes);
+        while (r.pos < r.buf.length) {
+            var tag = r.varint();
+            var f = schema[Math.floor(tag / 8)];
+            var wireType = tag & 7;
+            if (!f) {
+                r.skip(wireType);
+                continue;
+            }
+            if (f[2] && wireType === 2 && (f[1] === "int64" || f[1] === "double")) {
+                // Packed repeated scalars.
+                var end = r.pos + r.varint();
+                while (r.pos < end) {
+                    msg[f[0]].push(decodeValue(r, f[1] === "double" ? 1 : 0, f[1]));
+                }
+            } else if (f[2]) {
+                msg[f[0]].push(decodeValue(r, wireType, f[1]));
+            } else if (Array.isArray(f[1])) {
+                var entry = decodeValue(r, wireType, f[1]);
+                msg[f[0]][entry.key] = entry.value;
+            } else {
+                msg[f[0]] = decodeValue(r, wireType, f[1]);
+            }
+        }
+        return msg;
+    }
+
+    // Protobuf encoding of the events sent to Go.
+
+    function Writer() {
+        this.bytes = [];
+    }
+
+    Writer.prototype.varint = function(v) {
+        while (v >= 128) {
+            this.bytes.push((v % 128) | 0x80);
+            v = Math.floor(v / 128);
+        }
+        this.bytes.push(v);
+    };
+
+    Writer.prototype.double = function(field, v) {
+        var b = new Uint8Array(8);
+        new DataView(b.buffer).setFloat64(0, v, true);
+        this.varint(field * 8 + 1);
+        for (var i = 0; i < 8; i++) {
+            this.bytes.push(b[i]);
+        }
+    };
+
+    Writer.prototype.bool = function(field, v) {
+        this.varint(field * 8);
+        this.varint(v ? 1 : 0);
+    };
+
+    Writer.prototype.message = function(field, bytes) {
+        this.varint(field * 8 + 2);
+        this.varint(bytes.length);
+        for (var i = 0; i < bytes.length; i++) {
+            this.bytes.push(bytes[i]);
+        }
+    };
+
+    Writer.prototype.string = function(field, str) {
+        this.message(field, new TextEncoder().encode(str));
+    };
+
+    Writer.prototype.finish = function() {
+        return new Uint8Array(this.bytes);
+    };
+
+    function encodePoint(x, y) {
+        var w = new Writer();
+        w.double(1, x);
+        w.double(2, y);
+        return w.finish();
+    }
+
+    function encodeTextMetrics(width, height, firstBaseline, lastBaseline) {
+        var w = new Writer();
+        w.double(1, width);
+        w.double(2, height);
+        w.double(3, firstBaseline);
+        w.double(4, lastBaseline);
+        return w.finish();
+    }
+
+    function encodeStyledText(str) {
+        var text = new Writer();
+        text.string(1, str);
+        var st = new Writer();
+        st.message(2, text.finish());
+        return st.finish();
+    }
+
+    // Schemas
+
+    var Color = {1: ["red", "uint32"], 2: ["blue", "uint32"], 3: ["green", "uint32"], 4: ["alpha", "uint32"]};
+    var Point = {1: ["x", "double"], 2: ["y", "double"]};
+    var PaintStyle = {
+        1: ["transparency", "double"], 2: ["backgroundColor", Color], 3: ["borderColor", Color],
+        4: ["borderWidth", "double"], 5: ["cornerRadius", "double"], 7: ["shadowRadius", "double"],
+        8: ["shadowOffset", Point], 9: ["shadowColor", Color]
+    };
+    var LayoutPaintNode = {
+        1: ["id", "int64"], 2: ["layoutId", "int64"], 3: ["paintId", "int64"],
+        4: ["minx", "double"], 5: ["miny", "double"], 6: ["maxx", "double"], 7: ["maxy", "double"],
+        8: ["zIndex", "int64"], 9: ["childOrder", "int64", true], 10: ["paintStyle", PaintStyle]
+    };
+    var BuildNode = {
+        1: ["id", "int64"], 2: ["buildId", "int64"], 3: ["bridgeName", "string"], 4: ["bridgeValue", "bytes"],
+        5: ["values", ["map", "string", "bytes"]], 6: ["children", "int64", true], 7: ["altIds", ["map", "int64", "int64"]]
+    };
+    var Root = {
+        2: ["layoutPaintNodes", ["map", "int64", LayoutPaintNode]],
+        3: ["buildNodes", ["map", "int64", BuildNode]]
+    };
+    var Font = {1: ["family", "string"], 2: ["face", "string"], 3: ["size", "double"]};
+    var TextStyle = {
+        1: ["index", "int64"], 2: ["textAlignment", "enum"], 4: ["strikethroughStyle", "enum"],
+        8: ["underlineStyle", "enum"], 12: ["font", Font], 18: ["maxLines", "int64"],
+        20: ["textColor", Color], 22: ["wrap", "enum"], 24: ["truncation", "enum"]
+    };
+    var StyledText = {1: ["styles", TextStyle, true], 2: ["text", {1: ["text", "string"]}]};
+    var SizeFunc = {1: ["text", StyledText], 2: ["minSize", Point], 3: ["maxSize", Point]};
+    var Image = {1: ["width", "int64"], 2: ["height", "int64"], 4: ["stride", "int64"], 3: ["data", "bytes"]};
+    var ImageOrResource = {1: ["image", Image], 2: ["path", "string"]};
+    var Schemas = {
+        "gomatcha.io/matcha/view/textview": StyledText,
+        "gomatcha.io/matcha/view/button": {1: ["str", "string"], 2: ["enabled", "bool"], 3: ["color", Color]},
+        "gomatcha.io/matcha/view/imageview": {1: ["image", ImageOrResource], 2: ["resizeMode", "enum"], 3: ["tint", Color], 5: ["scale", "double"]},
+        "gomatcha.io/matcha/view/scrollview": {1: ["scrollEnabled", "bool"], 4: ["horizontal", "bool"], 5: ["vertical", "bool"]},
+        "gomatcha.io/matcha/view/switch": {1: ["value", "bool"], 2: ["enabled", "bool"]},
+        "gomatcha.io/matcha/view/slider": {1: ["value", "double"], 2: ["maxValue", "double"], 3: ["minValue", "double"], 4: ["enabled", "bool"]},
+        "gomatcha.io/matcha/view/textinput": {
+            1: ["styledText", StyledText], 2: ["placeholderText", StyledText], 10: ["font", Font],
+            4: ["focused", "bool"], 8: ["maxLines", "int64"], 9: ["secureTextEntry", "bool"]
+        }
+    };
+
+    // Styling
+
+    function cssColor(c) {
+        if (!c) {
+            return "";
+        }
+        var a = (c.alpha || 0) / 65535;
+        if (a === 0) {
+            return "rgba(0,0,0,0)";
+        }
+        // Colors are alpha premultiplied 16 bit values.
+        function ch(v) { return Math.round((v || 0) / 257 / a); }
+        return "rgba(" + ch(c.red) + "," + ch(c.green) + "," + ch(c.blue) + "," + a + ")";
+    }
+
+    function cssFont(font) {
+        var family = font && font.family ? font.family : "sans-serif";
+        var size = font && font.size ? font.size : 14;
+        var weight = "", style = "";
+        if (/-bold/i.test(family)) {
+            weight = "bold ";
+            family = family.replace(/-bold/i, "");
+        }
+        if (/-italic/i.test(family)) {
+            style = "italic ";
+            family = family.replace(/-italic/i, "");
+        }
+        return style + weight + size + "px " + family;
+    }
+
+    var alignments = ["left", "right", "center", "justify"];
+
+    function applyStyledText(el, st) {
+        var style = st.styles[0] || {};
+        el.textContent = st.text ? st.text.text || "" : "";
+        el.style.font = cssFont(style.font);
+        el.style.color = cssColor(style.textColor) || "black";
+        el.style.textAlign = alignments[style.textAlignment || 0];
+        el.style.whiteSpace = style.wrap === 0 && style.maxLines === 1 ? "nowrap" : "pre-wrap";
+        el.style.overflow = "hidden";
+        el.style.textOverflow = style.truncation ? "ellipsis" : "clip";
+    }
+
+    var measureEl = null;
+
+    // baselineMarker returns an empty inline block, which sits on the baseline
+    // of the line it is in.
+    function baselineMarker() {
+        var el = document.createElement("span");
+        el.style.display = "inline-block";
+        return el;
+    }
+
+    // sizeForStyledText measures text with a hidden element.
+    function sizeForStyledText(data, maxLines) {
+        var f = decode(data, SizeFunc);
+        if (!measureEl) {
+            measureEl = document.createElement("div");
+            measureEl.style.position = "absolute";
+            measureEl.style.visibility = "hidden";
+            measureEl.style.left = "-10000px";
+            document.body.appendChild(measureEl);
+        }
+        applyStyledText(measureEl, f.text || decode(null, StyledText));
+        var max = f.maxSize || {x: Infinity, y: Infinity};
+        measureEl.style.width = "";
+        measureEl.style.maxWidth = isFinite(max.x) ? max.x + "px" : "";
+        var rect = measureEl.getBoundingClientRect();
+        var height = rect.height;
+
+        var first = baselineMarker();
+        var last = baselineMarker();
+        measureEl.insertBefore(first, measureEl.firstChild);
+        measureEl.appendChild(last);
+        var firstBaseline = first.offsetTop;
+        var lastBaseline = last.offsetTop;
+        measureEl.removeChild(first);
+        measureEl.removeChild(last);
+
+        if (maxLines > 0) {
+            var lineHeight = parseFloat(getComputedStyle(measureEl).lineHeight) || (f.text && f.text.styles[0] && f.text.styles[0].font ? f.text.styles[0].font.size * 1.2 : 17);
+            height = Math.min(height, lineHeight * maxLines);
+            lastBaseline = Math.min(lastBaseline, firstBaseline + lineHeight * (maxLines - 1));
+        }
+        return encodeTextMetrics(Math.ceil(rect.width), Math.ceil(height), firstBaseline, lastBaseline);
+    }
+
+    // Views
+
+    function createView(name, node) {
+        var el;
+        switch (name) {
+        case "gomatcha.io/matcha/view/button":
+            el = document.createElement("button");
+            el.addEventListener("click", function() { node.call("OnPress"); });
+            break;
+        case "gomatcha.io/matcha/view/imageview":
+            el = document.createElement("img");
+            break;
+        case "gomatcha.io/matcha/view/switch":
+            el = document.createElement("input");
+            el.type = "checkbox";
+            el.addEventListener("change", function() {
+                var w = new Writer();
+                w.bool(1, el.checked);
+                node.call("OnChange", w.finish());
+            });
+            break;
+        case "gomatcha.io/matcha/view/slider":
+            el = document.createElement("input");
+            el.type = "range";
+            el.step = "any";
+            function sliderEvent() {
+                var w = new Writer();
+                w.double(1, parseFloat(el.value));
+                return w.finish();
+            }
+            el.addEventListener("input", function() { node.call("OnValueChange", sliderEvent()); });
+            el.addEventListener("change", function() { node.call("OnSubmit", sliderEvent()); });
+            break;
+        case "gomatcha.io/matcha/view/textinput":
+            el = document.createElement("textarea");
+            el.style.resize = "none";
+            el.style.border = "none";
+            el.style.background = "transparent";
+            el.addEventListener("input", function() {
+                var w = new Writer();
+                w.message(1, encodeStyledText(el.value));
+                node.call("OnTextChange", w.finish());
+            });
+            el.addEventListener("keydown", function(e) {
+                if (e.key === "Enter" && el.rows <= 1) {
+                    e.preventDefault();
+                    node.call("OnSubmit");
+                }
+            });
+            function focusEvent(focused) {
+                var w = new Writer();
+                w.bool(1, focused);
+                node.call("OnFocus", w.finish());
+            }
+            el.addEventListener("focus", function() { focusEvent(true); });
+            el.addEventListener("blur", function() { focusEvent(false); });
+            break;
+        case "gomatcha.io/matcha/view/scrollview":
+            el = document.createElement("div");
+            el.matchaContent = document.createElement("div");
+            el.matchaContent.style.position = "relative";
+            el.appendChild(el.matchaContent);
+            el.addEventListener("scroll", function() {
+                var w = new Writer();
+                w.message(1, encodePoint(el.scrollLeft, el.scrollTop));
+                node.call("OnScroll", w.finish());
+            });
+            break;
+        default:
+            el = document.createElement("div");
+        }
+        el.style.position = "absolute";
+        el.style.boxSizing = "border-box";
+        el.style.margin = "0";
+        return el;
+    }
+
+    function setNativeState(el, name, data) {
+        var schema = Schemas[name];
+        if (!schema) {
+            return;
+        }
+        var s = decode(data, schema);
+        switch (name) {
+        case "gomatcha.io/matcha/view/textview":
+            applyStyledText(el, s);
+            break;
+        case "gomatcha.io/matcha/view/button":
+            el.textContent = s.str || "";
+            el.disabled = !s.enabled;
+            el.style.color = cssColor(s.color);
+            break;
+        case "gomatcha.io/matcha/view/imageview":
+            el.style.objectFit = ["contain", "cover", "fill", "none"][s.resizeMode || 0];
+            if (s.image && s.image.image) {
+                el.src = imageURL(s.image.image);
+            } else if (s.image && s.image.path) {
+                el.src = s.image.path;
+            } else {
+                el.removeAttribute("src");
+            }
+            break;
+        case "gomatcha.io/matcha/view/scrollview":
+            el.style.overflowX = s.scrollEnabled && s.horizontal ? "auto" : "hidden";
+            el.style.overflowY = s.scrollEnabled && s.vertical ? "auto" : "hidden";
+            break;
+        case "gomatcha.io/matcha/view/switch":
+            el.checked = s.value;
+            el.disabled = !s.enabled;
+            break;
+        case "gomatcha.io/matcha/view/slider":
+            el.min = s.minValue;
+            el.max = s.maxValue;
+            el.value = s.value;
+            el.disabled = !s.enabled;
+            break;
+        case "gomatcha.io/matcha/view/textinput":
+            var str = s.styledText && s.styledText.text ? s.styledText.text.text || "" : "";
+            if (el.value !== str) {
+                el.value = str;
+            }
+            el.placeholder = s.placeholderText && s.placeholderText.text ? s.placeholderText.text.text || "" : "";
+            el.style.font = cssFont(s.font);
+            el.rows = s.maxLines || 1;
+            if (s.focused && document.activeElement !== el) {
+                el.focus();
+            } else if (!s.focused && document.activeElement === el) {
+                el.blur();
+            }
+            break;
+        }
+    }
+
+    function imageURL(img) {
+        var canvas = document.createElement("canvas");
+        canvas.width = img.width;
+        canvas.height = img.height;
+        var ctx = canvas.getContext("2d");
+        var data = ctx.createImageData(img.width, img.height);
+        var stride = img.stride || img.width * 4;
+        for (var y = 0; y < img.height; y++) {
+            data.data.set(img.data.subarray(y * stride, y * stride + img.width * 4), y * img.width * 4);
+        }
+        ctx.putImageData(data, 0, 0);
+        return canvas.toDataURL();
+    }
+
+    function applyPaintStyle(el, s) {
+        s = s || {};
+        el.style.opacity = 1 - (s.transparency || 0);
+        el.style.backgroundColor = cssColor(s.backgroundColor);
+        el.style.borderRadius = (s.cornerRadius || 0) + "px";
+        if (s.borderColor) {
+            el.style.border = (s.borderWidth || 0) + "px solid " + cssColor(s.borderColor);
+        } else {
+            el.style.border = "none";
+        }
+        if (s.shadowColor) {
+            var offset = s.shadowOffset || {x: 0, y: 0};
+            el.style.boxShadow = (offset.x || 0) + "px " + (offset.y || 0) + "px " + (s.shadowRadius || 0) + "px " + cssColor(s.shadowColor);
+        } else {
+            el.style.boxShadow = "";
+        }
+    }
+
+    // ViewNode mirrors MatchaViewNode.java.
+    function ViewNode(parent, root, id) {
+        this.parent = parent;
+        this.root = root;
+        this.id = id;
+        this.buildId = 0;
+        this.layoutId = 0;
+        this.paintId = 0;
+        this.children = {};
+        this.name = "";
+        this.el = null;
+    }
+
+    ViewNode.prototype.call = function(func) {
+        var args = Array.prototype.slice.call(arguments, 1);
+        this.root.call(func, this.id, args);
+    };
+
+    ViewNode.prototype.container = function() {
+        return this.el.matchaContent || this.el;
+    };
+
+    ViewNode.prototype.setRoot = function(root) {
+        var layoutPaintNode = root.layoutPaintNodes[this.id];
+        var buildNode = root.buildNodes[this.id];
+
+        if (!this.el) {
+            this.name = buildNode ? buildNode.bridgeName : "";
+            this.el = createView(this.name, this);
+        }
+
+        var children = this.children;
+        if (buildNode && this.buildId !== buildNode.buildId) {
+            children = {};
+            for (var k in this.children) {
+                if (!root.buildNodes[k]) {
+                    this.container().removeChild(this.children[k].el);
+                }
+            }
+            for (var i = 0; i < buildNode.children.length; i++) {
+                var id = buildNode.children[i];
+                children[id] = this.children[id] || new ViewNode(this, this.root, id);
+            }
+        }
+
+        for (var k in children) {
+            children[k].setRoot(root);
+            if (children[k].el.parentNode !== this.container()) {
+                this.container().appendChild(children[k].el);
+            }
+        }
+
+        if (buildNode && this.buildId !== buildNode.buildId) {
+            this.buildId = buildNode.buildId;
+            setNativeState(this.el, this.name, buildNode.bridgeValue);
+        }
+
+        if (layoutPaintNode && this.layoutId !== layoutPaintNode.layoutId) {
+            this.layoutId = layoutPaintNode.layoutId;
+
+            for (var i = 0; i < layoutPaintNode.childOrder.length; i++) {
+                var child = children[layoutPaintNode.childOrder[i]];
+                if (child) {
+                    child.el.style.zIndex = i;
+                }
+            }
+
+            var minX = layoutPaintNode.minx, minY = layoutPaintNode.miny;
+            var maxX = layoutPaintNode.maxx, maxY = layoutPaintNode.maxy;
+            // Scroll view content is offset by the scroll position in Go.
+            if (this.parent && this.parent.el.matchaContent) {
+                minX += this.parent.el.scrollLeft;
+                maxX += this.parent.el.scrollLeft;
+                minY += this.parent.el.scrollTop;
+                maxY += this.parent.el.scrollTop;
+                this.parent.el.matchaContent.style.width = (maxX - minX) + "px";
+                this.parent.el.matchaContent.style.height = (maxY - minY) + "px";
+                minX = 0;
+                minY = 0;
+            }
+            if (this.parent) {
+                this.el.style.left = minX + "px";
+                this.el.style.top = minY + "px";
+            }
+            this.el.style.width = (maxX - minX) + "px";
+            this.el.style.height = (maxY - minY) + "px";
+        }
+
+        if (layoutPaintNode && this.paintId !== layoutPaintNode.paintId) {
+            this.paintId = layoutPaintNode.paintId;
+            applyPaintStyle(this.el, layoutPaintNode.paintStyle);
+        }
+
+        this.children = children;
+    };
+
+    // Go interop
+
+    function goCall(ref, method) {
+        return global.matchaGo.call.apply(null, [ref, method].concat(Array.prototype.slice.call(arguments, 2)));
+    }
+
+    var roots = {};
+
+    function MatchaView(container, viewRef) {
+        var newRoot = global.matchaGo.func("gomatcha.io/matcha/view NewRoot");
+        this.ref = goCall(newRoot, "", viewRef)[0];
+        this.id = goCall(this.ref, "Id")[0];
+        this.node = new ViewNode(null, this, goCall(this.ref, "ViewId")[0]);
+        this.container = container;
+        roots[this.id] = this;
+
+        var self = this;
+        function resize() {
+            goCall(self.ref, "SetSize", container.clientWidth, container.clientHeight);
+        }
+        global.addEventListener("resize", resize);
+        resize();
+    }
+
+    MatchaView.prototype.call = function(func, viewId, args) {
+        // Dispatch asynchronously, so Go is never reentered from a bridge call.
+        var self = this;
+        setTimeout(function() {
+            goCall(self.ref, "Call", func, viewId, args);
+        }, 0);
+    };
+
+    MatchaView.prototype.update = function(data) {
+        this.node.setRoot(decode(data, Root));
+        if (this.node.el.parentNode !== this.container) {
+            this.node.el.style.position = "relative";
+            this.container.appendChild(this.node.el);
+        }
+    };
+
+    // matchaBridge implements the Android bridge methods called from Go.
+    global.matchaBridge = {
+        updateViewWithProtobuf: function(id, data) {
+            var root = roots[id];
+            if (!root) {
+                return false;
+            }
+            root.update(data);
+            return true;
+        },
+        sizeForStyledText: sizeForStyledText,
+        openURL: function(url) {
+            return global.open(url, "_blank") !== null;
+        },
+        orientation: function() {
+            return global.innerWidth > global.innerHeight ? 3 : 1;
+        },
+        displayAlert: function(data) {
+            console.log("matcha: alerts are not supported in the browser");
+        },
+        getPropertiesForResource: function(path) {
+            return null;
+        },
+        getImageForResource: function(path) {
+            return null;
+        }
+    };
+
+    // run loads the wasm binary at url, creates the view returned by the Go
+    // function registered as funcName and renders it into container.
+    function run(url, funcName, container) {
+        var go = new global.Go();
+        var load = WebAssembly.instantiateStreaming ?
+            WebAssembly.instantiateStreaming(fetch(url), go.importObject) :
+            fetch(url).then(function(r) { return r.arrayBuffer(); }).then(function(b) { return WebAssembly.instantiate(b, go.importObject); });
+        return load.then(function(result) {
+            go.run(result.instance);
+
+            var f = global.matchaGo.func(funcName);
+            var view = new MatchaView(container, goCall(f, "")[0]);
+
+            var screenUpdate = global.matchaGo.func("gomatcha.io/matcha/animate screenUpdate");
+            function frame() {
+                goCall(screenUpdate, "");
+                global.requestAnimationFrame(frame);
+            }
+            global.requestAnimationFrame(frame);
+            return view;
+        });
+    }
+
+    global.matcha = {
+        run: run,
+        decode: decode
+    };
+})(typeof window !== "undefined" ? window : this);
@@ -33,6 +33,18 @@ var (
 	buildO       string // -o
 	buildBinary  bool   // -binary
 	buildTargets string // --targets
+	buildResume  bool   // --resume
+	buildDebug   bool   // --debug
+
+	codesignIdentity     string // --codesign-identity
+	codesignEntitlements string // --entitlements
+
+	androidVariant string // --android-variant
+	iosExtension   bool   // --ios-extension
+	flutter        bool   // --flutter
+	kotlin         bool   // --kotlin
+
+	toolchainChannel string // --channel
 )
 
 func init() {
@@ -43,6 +55,7 @@ func init() {
 	flags.BoolVar(&buildWork, "work", false, "print the name of the temporary work directory and do not delete it when exiting.")
 	flags.StringVar(&buildGcflags, "gcflags", "", "arguments to pass on each go tool compile invocation.")
 	flags.StringVar(&buildLdflags, "ldflags", "", "arguments to pass on each go tool link invocation.")
+	flags.StringVar(&toolchainChannel, "channel", "", "installs the newest Go toolchain of a release channel. Builds roll back to the previous toolchain if they fail with it. Valid values are: stable, beta.")
 
 	RootCmd.AddCommand(InitCmd)
 }
@@ -60,6 +73,8 @@ var InitCmd = &cobra.Command{
 			BuildGcflags: buildGcflags,
 			BuildLdflags: buildLdflags,
 			BuildTargets: buildTargets,
+
+			ToolchainChannel: toolchainChannel,
 		}
 		if err := cmd.Init(flags); err != nil {
 			fmt.Println(err)
@@ -75,7 +90,15 @@ func init() {
 	flags.BoolVar(&buildWork, "work", false, "print the name of the temporary work directory and do not delete it when exiting.")
 	flags.StringVar(&buildGcflags, "gcflags", "", "arguments to pass on each go tool compile invocation.")
 	flags.StringVar(&buildLdflags, "ldflags", "", "arguments to pass on each go tool link invocation.")
-	flags.StringVar(&buildTargets, "targets", "", "space separated os/arch. Valid values are: android, ios, android/arm, android/arm64, android/386, android/amd64, ios/arm, ios/arm64, ios/386, ios/amd64.")
+	flags.StringVar(&buildTargets, "targets", "", "space separated os/arch. Valid values are: android, ios, android/arm, android/arm64, android/386, android/amd64, ios/arm, ios/arm64, ios/386, ios/amd64, wasm, windows, linux.")
+	flags.BoolVar(&buildResume, "resume", false, "reuse the work directory of the previous build and only rebuild targets whose inputs have changed.")
+	flags.BoolVar(&buildDebug, "debug", false, "builds with the matcha_debug tag, which enables runtime checks such as the bridge's main thread assertions.")
+	flags.StringVar(&codesignIdentity, "codesign-identity", "", "signs the iOS binary with the given identity.")
+	flags.StringVar(&codesignEntitlements, "entitlements", "", "path to an entitlements plist used when signing the iOS binary.")
+	flags.StringVar(&androidVariant, "android-variant", "", "builds the Android library for another form factor. Valid values are: wear, tv.")
+	flags.BoolVar(&iosExtension, "ios-extension", false, "builds the iOS library for app extensions, such as widgets and share extensions.")
+	flags.BoolVar(&flutter, "flutter", false, "also writes a Flutter plugin that embeds Matcha views as platform views.")
+	flags.BoolVar(&kotlin, "kotlin", false, "also writes Kotlin coroutine and Flow wrappers for the Android library.")
 
 	RootCmd.AddCommand(BuildCmd)
 }
@@ -93,6 +116,16 @@ var BuildCmd = &cobra.Command{
 			BuildGcflags: buildGcflags,
 			BuildLdflags: buildLdflags,
 			BuildTargets: buildTargets,
+			BuildResume:  buildResume,
+			BuildDebug:   buildDebug,
+
+			CodesignIdentity:     codesignIdentity,
+			CodesignEntitlements: codesignEntitlements,
+
+			AndroidVariant: androidVariant,
+			IOSExtension:   iosExtension,
+			Flutter:        flutter,
+			Kotlin:         kotlin,
 		}
 		if err := cmd.Build(flags, args); err != nil {
 			fmt.Println(err)
@@ -100,6 +133,156 @@ var BuildCmd = &cobra.Command{
 	},
 }
 
+var (
+	depsFormat string // --format
+	depsOutput string // -o
+)
+
+func init() {
+	flags := DepsCmd.Flags()
+	flags.StringVar(&depsFormat, "format", "dot", "output format. Valid values are: dot, json.")
+	flags.StringVar(&depsOutput, "o", "", "write the graph to the named file instead of stdout.")
+
+	RootCmd.AddCommand(DepsCmd)
+}
+
+var DepsCmd = &cobra.Command{
+	Use:   "deps",
+	Short: "Prints the package dependency graph used by matcha build",
+	Long:  ``,
+	Run: func(command *cobra.Command, args []string) {
+		out := os.Stdout
+		if depsOutput != "" {
+			f, err := os.Create(depsOutput)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			defer f.Close()
+			out = f
+		}
+		if err := cmd.Deps(&cmd.Flags{}, args, depsFormat, out); err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+var reactNativeOutput string // -o
+
+func init() {
+	flags := ReactNativeCmd.Flags()
+	flags.BoolVar(&buildN, "n", false, "print the commands but do not run them.")
+	flags.BoolVar(&buildX, "x", false, "print the commands.")
+	flags.StringVar(&reactNativeOutput, "o", "react-native-matcha", "directory to write the module to.")
+
+	RootCmd.AddCommand(ReactNativeCmd)
+}
+
+var ReactNativeCmd = &cobra.Command{
+	Use:   "reactnative",
+	Short: "Generates a React Native module that embeds Matcha views",
+	Long:  ``,
+	Run: func(command *cobra.Command, args []string) {
+		flags := &cmd.Flags{
+			BuildN: buildN,
+			BuildX: buildX,
+		}
+		if err := cmd.ReactNative(flags, reactNativeOutput); err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+var (
+	bindgenOutput  string // -o
+	bindgenJavaPkg string // --javapkg
+	bindgenPrefix  string // --prefix
+)
+
+func init() {
+	flags := BindgenCmd.Flags()
+	flags.BoolVar(&buildN, "n", false, "print the commands but do not run them.")
+	flags.BoolVar(&buildX, "x", false, "print the commands.")
+	flags.StringVar(&bindgenOutput, "o", "bindings", "directory to write the Objective-C and Java facades to.")
+	flags.StringVar(&bindgenJavaPkg, "javapkg", "io.gomatcha.bindings", "package of the Java and Kotlin facades.")
+	flags.StringVar(&bindgenPrefix, "prefix", "", "prefix of the Objective-C classes.")
+	flags.BoolVar(&kotlin, "kotlin", false, "writes Kotlin facades instead of Java.")
+
+	RootCmd.AddCommand(BindgenCmd)
+}
+
+var BindgenCmd = &cobra.Command{
+	Use:   "bindgen",
+	Short: "Generates typed Objective-C and Java bindings for the exported API of Go packages",
+	Long: `Bindgen registers the exported functions and structs of each package with the
+bridge, by writing matcha_bindgen.go into the package, and writes Objective-C
+and Java facades calling them through MatchaGoValue and GoValue. Methods of
+exported structs and interfaces are wrapped too. Notifiers of
+gomatcha.io/matcha/comm get facades with value getters and setters, and
+view.View values are passed through for MatchaViewController and MatchaView.`,
+	Run: func(command *cobra.Command, args []string) {
+		flags := &cmd.Flags{
+			BuildN: buildN,
+			BuildX: buildX,
+			Kotlin: kotlin,
+		}
+		if err := cmd.Bindgen(flags, args, bindgenOutput, bindgenJavaPkg, bindgenPrefix); err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+var (
+	traceAddr   string // --addr
+	traceFormat string // --format
+	traceOutput string // -o
+	traceADB    bool   // --adb
+)
+
+func init() {
+	flags := TraceDumpCmd.Flags()
+	flags.BoolVar(&buildN, "n", false, "print the commands but do not run them.")
+	flags.BoolVar(&buildX, "x", false, "print the commands.")
+	flags.StringVar(&traceAddr, "addr", "localhost:6062", "address the app serves the trace on with bridgetrace.ListenAndServe.")
+	flags.StringVar(&traceFormat, "format", "summary", "output format. Valid values are: summary, json.")
+	flags.StringVar(&traceOutput, "o", "", "write the trace to the named file instead of stdout.")
+	flags.BoolVar(&traceADB, "adb", false, "forward the port to the connected Android device with adb first.")
+
+	TraceCmd.AddCommand(TraceDumpCmd)
+	RootCmd.AddCommand(TraceCmd)
+}
+
+var TraceCmd = &cobra.Command{
+	Use:   "trace",
+	Short: "Inspects the calls between Go and native code recorded by bridge.StartTrace",
+	Long:  ``,
+}
+
+var TraceDumpCmd = &cobra.Command{
+	Use:   "dump",
+	Short: "Prints the bridge calls recorded by a running app",
+	Long:  ``,
+	Run: func(command *cobra.Command, args []string) {
+		out := os.Stdout
+		if traceOutput != "" {
+			f, err := os.Create(traceOutput)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			defer f.Close()
+			out = f
+		}
+		flags := &cmd.Flags{
+			BuildN: buildN,
+			BuildX: buildX,
+		}
+		if err := cmd.TraceDump(flags, traceAddr, traceFormat, traceADB, out); err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
 /*
 func init() {
 	flags := InstallCmd.Flags()
@@ -29,6 +29,35 @@ const (
 	minAndroidAPI  = 15
 )
 
+// AndroidVariant is the AAR manifest of an --android-variant.
+type AndroidVariant struct {
+	MinAPI   int
+	Features []string // uses-feature elements
+}
+
+var AndroidVariants = map[string]AndroidVariant{
+	"": {MinAPI: minAndroidAPI},
+	"wear": {
+		MinAPI:   23,
+		Features: []string{`<uses-feature android:name="android.hardware.type.watch"/>`},
+	},
+	"tv": {
+		MinAPI: 21,
+		Features: []string{
+			`<uses-feature android:name="android.software.leanback" android:required="false"/>`,
+			`<uses-feature android:name="android.hardware.touchscreen" android:required="false"/>`,
+		},
+	},
+}
+
+// AndroidAARName returns the file name of the AAR built for variant.
+func AndroidAARName(variant string) string {
+	if variant == "" {
+		return "matchabridge.aar"
+	}
+	return "matchabridge-" + variant + ".aar"
+}
+
 const manifestHeader = `Manifest-Version: 1.0
 Created-By: 1.0 (Go)
 
@@ -268,8 +297,9 @@ func BuildAAR(flags *Flags, androidDir string, pkgs []*build.Package, androidArc
 		return err
 	}
 	const manifestFmt = `<manifest xmlns:android="http://schemas.android.com/apk/res/android" package=%q>
-<uses-sdk android:minSdkVersion="%d"/></manifest>`
-	fmt.Fprintf(w, manifestFmt, "go."+pkgs[0].Name+".gojni", minAndroidAPI)
+<uses-sdk android:minSdkVersion="%d"/>%s</manifest>`
+	variant := AndroidVariants[flags.AndroidVariant]
+	fmt.Fprintf(w, manifestFmt, "go."+pkgs[0].Name+".gojni", variant.MinAPI, strings.Join(variant.Features, ""))
 
 	w, err = aarwcreate("proguard.txt")
 	if err != nil {
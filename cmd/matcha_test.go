@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestIOSSignPaths(t *testing.T) {
+	dir, err := ioutil.TempDir("", "matcha-sign")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// The binaries are signed where they are copied to.
+	names := []string{"MatchaBridge-x86_64.a", "MatchaBridge-arm64.a"}
+	want := []string{
+		filepath.Join(dir, "MatchaBridge", "MatchaBridge", "MatchaBridge-arm64.a"),
+		filepath.Join(dir, "MatchaBridge", "MatchaBridge", "MatchaBridge-x86_64.a"),
+	}
+	if paths := IOSSignPaths(dir, false, names); !reflect.DeepEqual(paths, want) {
+		t.Errorf("paths = %v, want %v", paths, want)
+	}
+	want = []string{filepath.Join(dir, "ios", "MatchaBridge", "MatchaBridge", "MatchaBridge.a")}
+	if paths := IOSSignPaths(dir, true, []string{"MatchaBridge.a"}); !reflect.DeepEqual(paths, want) {
+		t.Errorf("binary paths = %v, want %v", paths, want)
+	}
+
+	// Framework bundles in the output are signed instead.
+	for _, i := range []string{"MatchaBridge.framework", "MatchaBridge.xcframework"} {
+		if err := os.Mkdir(filepath.Join(dir, i), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want = []string{filepath.Join(dir, "MatchaBridge.framework"), filepath.Join(dir, "MatchaBridge.xcframework")}
+	if paths := IOSSignPaths(dir, false, names); !reflect.DeepEqual(paths, want) {
+		t.Errorf("framework paths = %v, want %v", paths, want)
+	}
+}
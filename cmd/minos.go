@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The default minimum OS versions of the iOS and Android projects.
+const (
+	defaultMinIOS     = "10.3"
+	defaultMinAndroid = 16
+)
+
+// MinOSUse is an application.MinOS literal found in the source of a package.
+type MinOSUse struct {
+	ImportPath string
+	IOS        string
+	Android    int
+	Required   bool // The literal is passed to application.Require.
+}
+
+// FindMinOS returns the application.MinOS literals in pkgs whose versions are
+// above the default minimum OS, sorted by import path.
+func FindMinOS(pkgs map[string]*build.Package) []MinOSUse {
+	uses := []MinOSUse{}
+	for _, pkg := range pkgs {
+		fset := token.NewFileSet()
+		for _, i := range pkg.GoFiles {
+			f, err := parser.ParseFile(fset, filepath.Join(pkg.Dir, i), nil, 0)
+			if err != nil {
+				continue
+			}
+			uses = append(uses, findMinOS(pkg.ImportPath, f)...)
+		}
+	}
+	sort.SliceStable(uses, func(i, j int) bool {
+		return uses[i].ImportPath < uses[j].ImportPath
+	})
+	return uses
+}
+
+func findMinOS(importPath string, f *ast.File) []MinOSUse {
+	// Literals passed to Require raise the minimum OS, all others have a fallback.
+	required := map[*ast.CompositeLit]bool{}
+	uses := []MinOSUse{}
+	ast.Inspect(f, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok && len(call.Args) > 0 && isIdent(call.Fun, "Require") {
+			if lit, ok := call.Args[0].(*ast.CompositeLit); ok {
+				required[lit] = true
+			}
+		}
+		lit, ok := n.(*ast.CompositeLit)
+		if !ok || !isIdent(lit.Type, "MinOS") {
+			return true
+		}
+		use := MinOSUse{ImportPath: importPath, Required: required[lit]}
+		for _, i := range lit.Elts {
+			kv, ok := i.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			key, ok := kv.Key.(*ast.Ident)
+			value, ok2 := kv.Value.(*ast.BasicLit)
+			if !ok || !ok2 {
+				continue
+			}
+			switch key.Name {
+			case "IOS":
+				use.IOS, _ = strconv.Unquote(value.Value)
+			case "Android":
+				use.Android, _ = strconv.Atoi(value.Value)
+			}
+		}
+		if use.Android > defaultMinAndroid || compareVersions(use.IOS, defaultMinIOS) > 0 {
+			uses = append(uses, use)
+		}
+		return true
+	})
+	return uses
+}
+
+// isIdent returns true if e is the identifier name, or a selector of it.
+func isIdent(e ast.Expr, name string) bool {
+	switch e := e.(type) {
+	case *ast.Ident:
+		return e.Name == name
+	case *ast.SelectorExpr:
+		return e.Sel.Name == name
+	}
+	return false
+}
+
+// compareVersions compares two dot separated versions, and returns -1, 0 or 1 if
+// a is less than, equal to or greater than b.
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x < y {
+			return -1
+		} else if x > y {
+			return 1
+		}
+	}
+	return 0
+}
+
+// WriteMinOSReport writes which packages raise the app's minimum OS, and which
+// degrade on versions below their minimum.
+func WriteMinOSReport(w io.Writer, uses []MinOSUse) {
+	if len(uses) == 0 {
+		return
+	}
+	minIOS, minAndroid := defaultMinIOS, defaultMinAndroid
+	for _, i := range uses {
+		if !i.Required {
+			continue
+		}
+		if compareVersions(i.IOS, minIOS) > 0 {
+			minIOS = i.IOS
+		}
+		if i.Android > minAndroid {
+			minAndroid = i.Android
+		}
+	}
+
+	fmt.Fprintf(w, "Minimum OS: iOS %v, Android API %v\n", minIOS, minAndroid)
+	for _, i := range uses {
+		verb := "degrades below"
+		if i.Required {
+			verb = "requires"
+		}
+		versions := []string{}
+		if compareVersions(i.IOS, defaultMinIOS) > 0 {
+			versions = append(versions, "iOS "+i.IOS)
+		}
+		if i.Android > defaultMinAndroid {
+			versions = append(versions, "Android API "+strconv.Itoa(i.Android))
+		}
+		fmt.Fprintf(w, "  %v %v %v\n", i.ImportPath, verb, strings.Join(versions, ", "))
+	}
+}
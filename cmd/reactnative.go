@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// supportFile is a file of a generated module, copied from a .support file in
+// gomatcha.io/matcha/cmd.
+type supportFile struct {
+	dst, src string
+}
+
+func copySupportFiles(flags *Flags, dir string, files []supportFile) error {
+	cmdPath, err := PackageDir(flags, "gomatcha.io/matcha/cmd")
+	if err != nil {
+		return err
+	}
+	for _, i := range files {
+		if err := CopyFile(flags, filepath.Join(dir, i.dst), filepath.Join(cmdPath, i.src)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReactNative writes a React Native module that renders components registered
+// with gomatcha.io/matcha/reactnative to dir. The module is added to a React
+// Native app like any other native module, next to the Matcha libraries built
+// by `matcha build`.
+func ReactNative(flags *Flags, dir string) error {
+	err := copySupportFiles(flags, dir, []supportFile{
+		{"package.json", "react-native-package.json.support"},
+		{"index.js", "react-native-index.js.support"},
+		{"react-native-matcha.podspec", "react-native-matcha.podspec.support"},
+		{filepath.Join("ios", "MatchaReactView.m"), "react-native-MatchaReactView.m.support"},
+		{filepath.Join("android", "build.gradle"), "react-native-build.gradle.support"},
+		{filepath.Join("android", "src", "main", "java", "io", "gomatcha", "reactnative", "MatchaReactPackage.java"), "react-native-MatchaReactPackage.java.support"},
+	})
+	if err != nil {
+		return err
+	}
+	if err := writeAndroidManifest(flags, dir, "io.gomatcha.reactnative"); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "React Native module written to %s.\n", dir)
+	return nil
+}
+
+// writeAndroidManifest writes the manifest of an Android library with no
+// components to dir/android.
+func writeAndroidManifest(flags *Flags, dir, pkg string) error {
+	manifest := filepath.Join(dir, "android", "src", "main", "AndroidManifest.xml")
+	return WriteFile(flags, manifest, func(w io.Writer) error {
+		_, err := fmt.Fprintf(w, "<manifest xmlns:android=\"http://schemas.android.com/apk/res/android\" package=%q/>\n", pkg)
+		return err
+	})
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go/build"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ResumeDir returns the work directory used by `matcha build --resume`. Unlike
+// the temporary work directory, it is stable across invocations for the same
+// packages and working directory, so artifacts of successful targets can be
+// reused: $GOPATH/pkg/matcha/resume/<hash>.
+func ResumeDir(gomobilepath, cwd string, args []string) string {
+	h := sha1.New()
+	io.WriteString(h, cwd)
+	for _, i := range args {
+		io.WriteString(h, "\x00"+i)
+	}
+	return filepath.Join(gomobilepath, "resume", hex.EncodeToString(h.Sum(nil))[:16])
+}
+
+// ResumeStatus records the input key of each target that was built successfully
+// in a resume directory.
+type ResumeStatus struct {
+	flags *Flags
+	path  string
+
+	mu      sync.Mutex
+	Targets map[string]string `json:"targets"`
+}
+
+// LoadResumeStatus reads the status file in dir. A missing or unreadable file
+// yields an empty status, causing every target to be rebuilt.
+func LoadResumeStatus(flags *Flags, dir string) *ResumeStatus {
+	s := &ResumeStatus{
+		flags:   flags,
+		path:    filepath.Join(dir, "status.json"),
+		Targets: map[string]string{},
+	}
+	data, err := ReadFile(flags, s.path)
+	if err != nil || len(data) == 0 {
+		return s
+	}
+	if err := json.Unmarshal(data, s); err != nil || s.Targets == nil {
+		s.Targets = map[string]string{}
+	}
+	return s
+}
+
+// Done returns true if target was last built successfully with key, and all
+// of its artifacts still exist. It returns false for a nil status.
+func (s *ResumeStatus) Done(target, key string, artifacts ...string) bool {
+	if s == nil || !s.flags.ShouldRun() {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.Targets[target] != key {
+		return false
+	}
+	for _, i := range artifacts {
+		if _, err := os.Stat(i); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Set records the result of building target with key, and writes the status
+// file. A failed target is removed so that it is rebuilt on the next attempt.
+// It does nothing for a nil status.
+func (s *ResumeStatus) Set(target, key string, success bool) error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if success {
+		s.Targets[target] = key
+	} else {
+		delete(s.Targets, target)
+	}
+	return WriteFile(s.flags, s.path, func(w io.Writer) error {
+		data, err := json.MarshalIndent(s, "", "\t")
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	})
+}
+
+// TargetKey returns a hash of the inputs of a target: the toolchain version,
+// the build environment and flags, and the contents of the source files of pkgs.
+// The files excluded by build constraints are hashed too, since pkgs are imported
+// for darwin even when the target is Android or desktop.
+func TargetKey(flags *Flags, toolchain []byte, env []string, pkgs map[string]*build.Package) (string, error) {
+	h := sha1.New()
+	h.Write(toolchain)
+	fmt.Fprintf(h, "\x00gcflags=%s\x00ldflags=%s\x00binary=%v\x00debug=%v\x00", flags.BuildGcflags, flags.BuildLdflags, flags.BuildBinary, flags.BuildDebug)
+
+	env2 := append([]string(nil), env...)
+	sort.Strings(env2)
+	io.WriteString(h, strings.Join(env2, "\x00"))
+
+	dirs := []string{}
+	for dir := range pkgs {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	for _, dir := range dirs {
+		pkg := pkgs[dir]
+		files := []string{}
+		for _, i := range [][]string{pkg.GoFiles, pkg.CgoFiles, pkg.IgnoredGoFiles, pkg.CFiles, pkg.CXXFiles, pkg.MFiles, pkg.HFiles, pkg.SFiles, pkg.IgnoredOtherFiles} {
+			files = append(files, i...)
+		}
+		sort.Strings(files)
+
+		for _, i := range files {
+			data, err := ioutil.ReadFile(filepath.Join(pkg.Dir, i))
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprintf(h, "\x00%s\x00%d\x00", filepath.Join(pkg.Dir, i), len(data))
+			h.Write(data)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"go/build"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTargetKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "matcha-resume")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	write := func(name, data string) {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	key := func() string {
+		ctx := build.Default
+		ctx.GOOS = "darwin"
+		pkg, err := ctx.ImportDir(dir, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		k, err := TargetKey(&Flags{}, []byte("go1"), []string{"GOOS=android"}, map[string]*build.Package{dir: pkg})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return k
+	}
+
+	write("p.go", "package p\n")
+	write("p-java.c", "// +build android\n\nint a;\n")
+	key1 := key()
+	if key2 := key(); key2 != key1 {
+		t.Errorf("key changed without edits: %v, %v", key1, key2)
+	}
+
+	// Files for other platforms than darwin are hashed.
+	write("p-java.c", "// +build android\n\nint b;\n")
+	if key2 := key(); key2 == key1 {
+		t.Error("key did not change after editing an android file")
+	}
+}
@@ -0,0 +1,408 @@
+package cmd
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// BridgeFunc is a function registered with bridge.RegisterFunc.
+type BridgeFunc struct {
+	Name    string   // Registered name, e.g. "gomatcha.io/matcha/examples/simple Add"
+	Params  []string // Go types of the parameters.
+	Results []string // Go types of the results.
+}
+
+// BridgeType is a struct registered with bridge.RegisterType.
+type BridgeType struct {
+	Name       string // Registered name, e.g. "gomatcha.io/matcha/layout.Point"
+	ImportPath string
+	GoName     string
+	Fields     []BridgeField
+}
+
+// BridgeField is an exported field of a BridgeType.
+type BridgeField struct {
+	Name    string
+	Type    string
+	JSONKey string
+}
+
+// FindBridgeDecls returns the functions and structs that the app's packages in
+// pkgs register with the bridge. Packages of Matcha itself are skipped, except
+// for the examples. Only literal names, function literals, functions declared in
+// the same package and reflect.TypeOf(T{}) are recognized.
+func FindBridgeDecls(pkgs map[string]*build.Package) ([]BridgeFunc, []BridgeType) {
+	funcs := []BridgeFunc{}
+	typs := []BridgeType{}
+	for _, pkg := range pkgs {
+		if strings.HasPrefix(pkg.ImportPath+"/", "gomatcha.io/matcha/") && !strings.HasPrefix(pkg.ImportPath, "gomatcha.io/matcha/examples") {
+			continue
+		}
+
+		fset := token.NewFileSet()
+		files := []*ast.File{}
+		decls := map[string]*ast.FuncDecl{}
+		specs := map[string]*ast.TypeSpec{}
+		for _, i := range pkg.GoFiles {
+			f, err := parser.ParseFile(fset, filepath.Join(pkg.Dir, i), nil, 0)
+			if err != nil {
+				continue
+			}
+			files = append(files, f)
+			for _, d := range f.Decls {
+				switch d := d.(type) {
+				case *ast.FuncDecl:
+					if d.Recv == nil {
+						decls[d.Name.Name] = d
+					}
+				case *ast.GenDecl:
+					for _, s := range d.Specs {
+						if s, ok := s.(*ast.TypeSpec); ok {
+							specs[s.Name.Name] = s
+						}
+					}
+				}
+			}
+		}
+
+		for _, f := range files {
+			ast.Inspect(f, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok || len(call.Args) != 2 {
+					return true
+				}
+				lit, ok := call.Args[0].(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					return true
+				}
+				name, _ := strconv.Unquote(lit.Value)
+
+				switch {
+				case isIdent(call.Fun, "RegisterFunc"):
+					var ft *ast.FuncType
+					switch fn := call.Args[1].(type) {
+					case *ast.FuncLit:
+						ft = fn.Type
+					case *ast.Ident:
+						if d, ok := decls[fn.Name]; ok {
+							ft = d.Type
+						}
+					}
+					if ft != nil {
+						funcs = append(funcs, BridgeFunc{Name: name, Params: fieldTypes(ft.Params), Results: fieldTypes(ft.Results)})
+					}
+				case isIdent(call.Fun, "RegisterType"):
+					// reflect.TypeOf(T{})
+					arg, ok := call.Args[1].(*ast.CallExpr)
+					if !ok || !isIdent(arg.Fun, "TypeOf") || len(arg.Args) != 1 {
+						return true
+					}
+					comp, ok := arg.Args[0].(*ast.CompositeLit)
+					if !ok {
+						return true
+					}
+					ident, ok := comp.Type.(*ast.Ident)
+					if !ok {
+						return true
+					}
+					spec, ok := specs[ident.Name]
+					if !ok {
+						return true
+					}
+					if st, ok := spec.Type.(*ast.StructType); ok {
+						typs = append(typs, BridgeType{Name: name, ImportPath: pkg.ImportPath, GoName: ident.Name, Fields: structFields(st)})
+					}
+				}
+				return true
+			})
+		}
+	}
+	sort.Slice(funcs, func(i, j int) bool { return funcs[i].Name < funcs[j].Name })
+	sort.Slice(typs, func(i, j int) bool { return typs[i].Name < typs[j].Name })
+	return funcs, typs
+}
+
+// fieldTypes returns the type of each entry of a parameter or result list.
+func fieldTypes(l *ast.FieldList) []string {
+	ts := []string{}
+	if l == nil {
+		return ts
+	}
+	for _, i := range l.List {
+		t := types.ExprString(i.Type)
+		n := len(i.Names)
+		if n == 0 {
+			n = 1
+		}
+		for j := 0; j < n; j++ {
+			ts = append(ts, t)
+		}
+	}
+	return ts
+}
+
+func structFields(st *ast.StructType) []BridgeField {
+	fields := []BridgeField{}
+	for _, i := range st.Fields.List {
+		key := ""
+		if i.Tag != nil {
+			tag, _ := strconv.Unquote(i.Tag.Value)
+			key = strings.Split(reflect.StructTag(tag).Get("json"), ",")[0]
+		}
+		if key == "-" {
+			continue
+		}
+		for _, name := range i.Names {
+			if !ast.IsExported(name.Name) {
+				continue
+			}
+			k := key
+			if k == "" {
+				k = name.Name
+			}
+			fields = append(fields, BridgeField{Name: name.Name, Type: types.ExprString(i.Type), JSONKey: k})
+		}
+	}
+	return fields
+}
+
+// swiftType describes how a Go type is converted to and from a MatchaGoValue.
+type swiftType struct {
+	Name   string // Swift type
+	ToGo   string // Format converting the Swift value %s to a MatchaGoValue.
+	FromGo string // Format converting the MatchaGoValue %s to the Swift type.
+}
+
+func swiftTypeOf(goType string, structs map[string]BridgeType) swiftType {
+	switch goType {
+	case "bool":
+		return swiftType{"Bool", "MatchaGoValue(bool: %s)", "%s.toBool()"}
+	case "int":
+		// Values are not converted to the parameter's type, so ints cross the
+		// bridge as C ints.
+		return swiftType{"Int", "MatchaGoValue(int: Int32(%s))", "Int(%s.toLongLong())"}
+	case "int64":
+		return swiftType{"Int64", "MatchaGoValue(longLong: %s)", "%s.toLongLong()"}
+	case "uint64":
+		return swiftType{"UInt64", "MatchaGoValue(unsignedLongLong: %s)", "%s.toUnsignedLongLong()"}
+	case "float64":
+		return swiftType{"Double", "MatchaGoValue(double: %s)", "%s.toDouble()"}
+	case "string":
+		return swiftType{"String", "MatchaGoValue(string: %s)", "%s.toString()"}
+	case "[]byte":
+		return swiftType{"Data", "MatchaGoValue(data: %s)", "%s.toData()"}
+	}
+	if t, ok := structs[strings.TrimPrefix(goType, "*")]; ok {
+		name := swiftIdent(t.GoName, true)
+		if strings.HasPrefix(goType, "*") {
+			return swiftType{name, "%s.toGoValue()", name + "(goValue: %s)"}
+		}
+		return swiftType{name, "%s.toGoValue().elem()", name + "(goValue: %s)"}
+	}
+	return swiftType{"MatchaGoValue", "%s", "%s"}
+}
+
+// swiftIdent converts a Go identifier to a Swift type name, or a property or
+// function name if upper is false.
+func swiftIdent(s string, upper bool) string {
+	r := []rune(s)
+	if upper {
+		if len(r) > 0 {
+			r[0] = unicode.ToUpper(r[0])
+		}
+		return string(r)
+	}
+	// Lower the leading initialism: "URLString" becomes "urlString".
+	for i := 0; i < len(r) && unicode.IsUpper(r[i]); i++ {
+		if i > 0 && i+1 < len(r) && unicode.IsLower(r[i+1]) {
+			break
+		}
+		r[i] = unicode.ToLower(r[i])
+	}
+	return string(r)
+}
+
+// swiftNamespace returns the enum name that holds the functions of importPath.
+func swiftNamespace(importPath string) string {
+	parts := strings.FieldsFunc(path.Base(importPath), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i := range parts {
+		parts[i] = swiftIdent(parts[i], true)
+	}
+	return strings.Join(parts, "")
+}
+
+// WriteSwiftOverlay writes Swift wrappers for funcs and typs to w. Functions
+// are grouped in an enum per package, functions whose last result is an error
+// throw an NSError in MatchaGoErrorDomain, and structs become Codable Swift
+// structs.
+func WriteSwiftOverlay(w io.Writer, funcs []BridgeFunc, typs []BridgeType) error {
+	structs := map[string]BridgeType{}
+	for _, i := range typs {
+		structs[i.GoName] = i
+	}
+
+	b := &strings.Builder{}
+	b.WriteString(`// Generated by matcha build. Do not edit.
+
+import Foundation
+import MatchaBridge
+
+private func matchaCall(_ name: String, _ args: [MatchaGoValue]) -> [MatchaGoValue] {
+    return MatchaGoValue(func: name).call(nil, arguments: args)
+}
+
+private func matchaTryCall(_ name: String, _ args: [MatchaGoValue]) throws -> [MatchaGoValue] {
+    return try MatchaGoValue(func: name).tryCall(nil, arguments: args)
+}
+`)
+
+	for _, t := range typs {
+		name := swiftIdent(t.GoName, true)
+		fmt.Fprintf(b, "\n/// %s\npublic struct %s: Codable {\n", t.Name, name)
+		params := []string{}
+		for _, f := range t.Fields {
+			st := swiftTypeOf(f.Type, structs)
+			if st.Name == "MatchaGoValue" {
+				continue
+			}
+			fmt.Fprintf(b, "    public var %s: %s\n", swiftIdent(f.Name, false), st.Name)
+			params = append(params, fmt.Sprintf("%s: %s", swiftIdent(f.Name, false), st.Name))
+		}
+
+		b.WriteString("\n    enum CodingKeys: String, CodingKey {\n")
+		for _, f := range t.Fields {
+			if swiftTypeOf(f.Type, structs).Name != "MatchaGoValue" {
+				fmt.Fprintf(b, "        case %s = %q\n", swiftIdent(f.Name, false), f.JSONKey)
+			}
+		}
+		b.WriteString("    }\n")
+
+		fmt.Fprintf(b, "\n    public init(%s) {\n", strings.Join(params, ", "))
+		for _, f := range t.Fields {
+			if swiftTypeOf(f.Type, structs).Name != "MatchaGoValue" {
+				fmt.Fprintf(b, "        self.%s = %s\n", swiftIdent(f.Name, false), swiftIdent(f.Name, false))
+			}
+		}
+		b.WriteString("    }\n")
+
+		b.WriteString("\n    public init(goValue: MatchaGoValue) {\n")
+		for _, f := range t.Fields {
+			st := swiftTypeOf(f.Type, structs)
+			if st.Name != "MatchaGoValue" {
+				fmt.Fprintf(b, "        %s = %s\n", swiftIdent(f.Name, false), fmt.Sprintf(st.FromGo, fmt.Sprintf("goValue[%q]", f.Name)))
+			}
+		}
+		b.WriteString("    }\n")
+
+		fmt.Fprintf(b, "\n    public func toGoValue() -> MatchaGoValue {\n        let v = MatchaGoValue(type: %q)\n", t.Name)
+		for _, f := range t.Fields {
+			st := swiftTypeOf(f.Type, structs)
+			if st.Name != "MatchaGoValue" {
+				fmt.Fprintf(b, "        v[%q] = %s\n", f.Name, fmt.Sprintf(st.ToGo, swiftIdent(f.Name, false)))
+			}
+		}
+		b.WriteString("        return v\n    }\n}\n")
+	}
+
+	// Group functions by package.
+	namespaces := map[string][]BridgeFunc{}
+	paths := []string{}
+	for _, f := range funcs {
+		parts := strings.SplitN(f.Name, " ", 2)
+		if len(parts) != 2 || !token.IsIdentifier(parts[1]) {
+			continue
+		}
+		if _, ok := namespaces[parts[0]]; !ok {
+			paths = append(paths, parts[0])
+		}
+		namespaces[parts[0]] = append(namespaces[parts[0]], f)
+	}
+	sort.Strings(paths)
+
+	for _, p := range paths {
+		fmt.Fprintf(b, "\n/// %s\npublic enum %s {\n", p, swiftNamespace(p))
+		for _, f := range namespaces[p] {
+			writeSwiftFunc(b, f, structs)
+		}
+		b.WriteString("}\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeSwiftFunc(b *strings.Builder, f BridgeFunc, structs map[string]BridgeType) {
+	goName := strings.SplitN(f.Name, " ", 2)[1]
+	for _, i := range f.Params {
+		if strings.HasPrefix(i, "...") {
+			fmt.Fprintf(b, "    // %s is skipped, variadic functions are not supported.\n", goName)
+			return
+		}
+	}
+
+	params := []string{}
+	args := []string{}
+	for i, t := range f.Params {
+		st := swiftTypeOf(t, structs)
+		params = append(params, fmt.Sprintf("_ p%d: %s", i, st.Name))
+		args = append(args, fmt.Sprintf(st.ToGo, fmt.Sprintf("p%d", i)))
+	}
+
+	results := f.Results
+	throws := len(results) > 0 && results[len(results)-1] == "error"
+	if throws {
+		results = results[:len(results)-1]
+	}
+	rets := []string{}
+	values := []string{}
+	for i, t := range results {
+		st := swiftTypeOf(t, structs)
+		rets = append(rets, st.Name)
+		values = append(values, fmt.Sprintf(st.FromGo, fmt.Sprintf("r[%d]", i)))
+	}
+
+	sig := fmt.Sprintf("public static func %s(%s)", swiftIdent(goName, false), strings.Join(params, ", "))
+	if throws {
+		sig += " throws"
+	}
+	switch len(rets) {
+	case 0:
+	case 1:
+		sig += " -> " + rets[0]
+	default:
+		sig += " -> (" + strings.Join(rets, ", ") + ")"
+	}
+
+	fmt.Fprintf(b, "    %s {\n", sig)
+	assign := "let r = "
+	if len(results) == 0 {
+		assign = "_ = "
+	}
+	if throws {
+		fmt.Fprintf(b, "        %stry matchaTryCall(%q, [%s])\n", assign, f.Name, strings.Join(args, ", "))
+	} else {
+		fmt.Fprintf(b, "        %smatchaCall(%q, [%s])\n", assign, f.Name, strings.Join(args, ", "))
+	}
+	switch len(values) {
+	case 0:
+	case 1:
+		fmt.Fprintf(b, "        return %s\n", values[0])
+	default:
+		fmt.Fprintf(b, "        return (%s)\n", strings.Join(values, ", "))
+	}
+	b.WriteString("    }\n")
+}
@@ -0,0 +1,380 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"time"
+)
+
+// ToolchainIndexURL lists the Go releases that can be installed with
+// `matcha init --channel`, newest first.
+var ToolchainIndexURL = "https://go.dev/dl/?mode=json&include=all"
+
+// ToolchainDownloadURL is the base URL of release archives and their signatures.
+var ToolchainDownloadURL = "https://dl.google.com/go/"
+
+// ToolchainRetries is the number of attempts made to download a file.
+const ToolchainRetries = 3
+
+// ToolchainRelease is an entry of the release index.
+type ToolchainRelease struct {
+	Version string          `json:"version"`
+	Stable  bool            `json:"stable"`
+	Files   []ToolchainFile `json:"files"`
+}
+
+// ToolchainFile is a downloadable file of a ToolchainRelease.
+type ToolchainFile struct {
+	Filename string `json:"filename"`
+	OS       string `json:"os"`
+	Arch     string `json:"arch"`
+	Sha256   string `json:"sha256"`
+	Size     int64  `json:"size"`
+	Kind     string `json:"kind"`
+}
+
+// ToolchainsDir returns the directory that installed toolchains are kept in:
+// $GOPATH/pkg/matcha-toolchains. It is separate from $GOPATH/pkg/matcha,
+// which `matcha init` deletes.
+func ToolchainsDir() (string, error) {
+	gomobilepath, err := GoMobilePath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(gomobilepath), "matcha-toolchains"), nil
+}
+
+// ToolchainState records the toolchain used by binds. A newly installed
+// toolchain is staged as Current until a bind succeeds with it. If a bind fails
+// with a staged toolchain, binds roll back to Previous.
+type ToolchainState struct {
+	flags *Flags
+	path  string
+
+	Channel  string `json:"channel"`
+	Current  string `json:"current"`
+	Previous string `json:"previous"`
+	Verified bool   `json:"verified"` // A bind succeeded with Current.
+}
+
+// LoadToolchainState reads the state file in ToolchainsDir. A missing file
+// yields an empty state, meaning the go command on $PATH is used.
+func LoadToolchainState(flags *Flags) (*ToolchainState, error) {
+	dir, err := ToolchainsDir()
+	if err != nil {
+		return nil, err
+	}
+	s := &ToolchainState{
+		flags: flags,
+		path:  filepath.Join(dir, "state.json"),
+	}
+	data, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return s, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("invalid toolchain state %s: %v", s.path, err)
+	}
+	return s, nil
+}
+
+// Save writes the state file.
+func (s *ToolchainState) Save() error {
+	return WriteFile(s.flags, s.path, func(w io.Writer) error {
+		data, err := json.MarshalIndent(s, "", "\t")
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	})
+}
+
+// Stage makes version the current toolchain. The last verified toolchain is
+// kept as the rollback target.
+func (s *ToolchainState) Stage(channel, version string) {
+	s.Channel = channel
+	if s.Current == version {
+		return
+	}
+	if s.Verified {
+		s.Previous = s.Current
+	}
+	s.Current = version
+	s.Verified = false
+}
+
+// Verify marks the current toolchain as verified and saves the state.
+func (s *ToolchainState) Verify() error {
+	if s.Current == "" || s.Verified {
+		return nil
+	}
+	s.Verified = true
+	return s.Save()
+}
+
+// Rollback makes the previous toolchain current. It returns false if there is
+// nothing to roll back to.
+func (s *ToolchainState) Rollback() bool {
+	if s.Current == "" || s.Verified || s.Previous == "" {
+		return false
+	}
+	s.Current = s.Previous
+	s.Previous = ""
+	s.Verified = true
+	return true
+}
+
+// UseToolchain points GOROOT and $PATH at the current toolchain, so that
+// subsequent go commands run with it. It does nothing if no toolchain was
+// installed with `matcha init --channel`.
+func UseToolchain(s *ToolchainState) error {
+	if s.Current == "" {
+		return nil
+	}
+	dir, err := ToolchainsDir()
+	if err != nil {
+		return err
+	}
+	goroot := filepath.Join(dir, s.Current, "go")
+	if _, err := os.Stat(filepath.Join(goroot, "bin")); err != nil && s.flags.ShouldRun() {
+		return fmt.Errorf("toolchain %s is missing, run `matcha init --channel %s`", s.Current, s.Channel)
+	}
+	if s.flags.ShouldPrint() {
+		fmt.Fprintln(os.Stderr, "GOROOT="+goroot)
+	}
+	os.Setenv("GOROOT", goroot)
+	os.Setenv("GOTOOLCHAIN", "local")
+	os.Setenv("PATH", filepath.Join(goroot, "bin")+string(filepath.ListSeparator)+os.Getenv("PATH"))
+	return nil
+}
+
+// FindToolchainRelease returns the newest release of channel, "stable" or
+// "beta", and its archive for the host.
+func FindToolchainRelease(channel string) (*ToolchainRelease, *ToolchainFile, error) {
+	if channel != "stable" && channel != "beta" {
+		return nil, nil, fmt.Errorf("unknown toolchain channel %q", channel)
+	}
+
+	releases := []*ToolchainRelease{}
+	err := retry(func() error {
+		resp, err := http.Get(ToolchainIndexURL)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("GET %s: %s", ToolchainIndexURL, resp.Status)
+		}
+		return json.NewDecoder(resp.Body).Decode(&releases)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, r := range releases {
+		if channel == "stable" && !r.Stable {
+			continue
+		}
+		for i, f := range r.Files {
+			if f.OS == runtime.GOOS && f.Arch == runtime.GOARCH && f.Kind == "archive" {
+				return r, &r.Files[i], nil
+			}
+		}
+	}
+	return nil, nil, fmt.Errorf("no %s toolchain for %s/%s", channel, runtime.GOOS, runtime.GOARCH)
+}
+
+// InstallToolchain downloads, verifies and unpacks f into ToolchainsDir. An
+// interrupted download is resumed. It does nothing if the release is already
+// installed.
+func InstallToolchain(flags *Flags, r *ToolchainRelease, f *ToolchainFile) error {
+	dir, err := ToolchainsDir()
+	if err != nil {
+		return err
+	}
+	dst := filepath.Join(dir, r.Version)
+	if _, err := os.Stat(filepath.Join(dst, "go", "bin")); err == nil {
+		fmt.Fprintf(os.Stderr, "Toolchain %s already installed.\n", r.Version)
+		return nil
+	}
+	if err := Mkdir(flags, dir); err != nil {
+		return err
+	}
+
+	url := ToolchainDownloadURL + f.Filename
+	archive := filepath.Join(dir, f.Filename)
+	partial := archive + ".partial"
+	if flags.ShouldPrint() {
+		fmt.Fprintln(os.Stderr, "download", url, ">", archive)
+	}
+	if flags.ShouldRun() {
+		if _, err := os.Stat(archive); err != nil {
+			if err := retry(func() error { return download(url, partial) }); err != nil {
+				return err
+			}
+			if err := os.Rename(partial, archive); err != nil {
+				return err
+			}
+		}
+		// A corrupt archive is removed so that the next attempt downloads it again.
+		if err := verifyChecksum(archive, f.Sha256); err != nil {
+			os.Remove(archive)
+			return err
+		}
+		if err := verifySignature(flags, url, archive); err != nil {
+			os.Remove(archive)
+			return err
+		}
+	}
+
+	// Unpack into a temporary directory, so that an interrupted install is
+	// not mistaken for a complete one.
+	tmp := dst + ".tmp"
+	if err := RemoveAll(flags, tmp); err != nil {
+		return err
+	}
+	if err := Mkdir(flags, tmp); err != nil {
+		return err
+	}
+	if err := RunCmd(flags, "", exec.Command("tar", "-xf", archive, "-C", tmp)); err != nil {
+		return err
+	}
+	if err := RemoveAll(flags, dst); err != nil {
+		return err
+	}
+	if flags.ShouldPrint() {
+		fmt.Fprintf(os.Stderr, "mv %s %s\n", tmp, dst)
+	}
+	if flags.ShouldRun() {
+		if err := os.Rename(tmp, dst); err != nil {
+			return err
+		}
+	}
+	return RemoveAll(flags, archive)
+}
+
+// download appends the remainder of url to path, starting at its current size.
+func download(url, path string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	offset, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		fmt.Fprintf(os.Stderr, "Resuming download of %s at %d bytes.\n", url, offset)
+	case http.StatusOK:
+		// The server ignored the range, so start over.
+		if err := f.Truncate(0); err != nil {
+			return err
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+	case http.StatusRequestedRangeNotSatisfiable:
+		// Already complete.
+		return nil
+	default:
+		return fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	_, err = io.Copy(f, resp.Body)
+	return err
+}
+
+func verifyChecksum(path, sha string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	if sum := hex.EncodeToString(h.Sum(nil)); sum != sha {
+		return fmt.Errorf("checksum mismatch for %s: got %s, want %s", path, sum, sha)
+	}
+	return nil
+}
+
+// verifySignature checks the detached signature of the archive at url with
+// gpg. The Go release signing key must be in the user's keyring.
+func verifySignature(flags *Flags, url, path string) error {
+	if _, err := exec.LookPath("gpg"); err != nil {
+		fmt.Fprintf(os.Stderr, "Skipping signature verification of %s: gpg not found.\n", path)
+		return nil
+	}
+	sig := path + ".asc"
+	defer os.Remove(sig)
+	os.Remove(sig)
+	if err := retry(func() error { return download(url+".asc", sig) }); err != nil {
+		return err
+	}
+	if err := RunCmd(flags, "", exec.Command("gpg", "--verify", sig, path)); err != nil {
+		return fmt.Errorf("signature verification failed: %v", err)
+	}
+	return nil
+}
+
+// retry calls f up to ToolchainRetries times, waiting longer after each failure.
+func retry(f func() error) error {
+	var err error
+	for i := 0; i < ToolchainRetries; i++ {
+		if i > 0 {
+			fmt.Fprintf(os.Stderr, "%v, retrying.\n", err)
+			time.Sleep(time.Duration(i) * 2 * time.Second)
+		}
+		if err = f(); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+var errNoRollback = errors.New("no toolchain to roll back to")
+
+// RollbackToolchain makes the previous toolchain current and reinstalls the
+// standard libraries with it.
+func RollbackToolchain(flags *Flags, s *ToolchainState) error {
+	failed := s.Current
+	if !s.Rollback() {
+		return errNoRollback
+	}
+	fmt.Fprintf(os.Stderr, "Rolling back from toolchain %s to %s.\n", failed, s.Current)
+	if err := s.Save(); err != nil {
+		return err
+	}
+	return Init(flags)
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os/exec"
+	"sort"
+	"text/tabwriter"
+	"time"
+)
+
+// TracePath is the path gomatcha.io/matcha/bridge/bridgetrace serves the trace at.
+const TracePath = "/debug/matcha/trace"
+
+// TraceEvent is a bridge call, as written by bridge.WriteTrace.
+type TraceEvent struct {
+	Name      string        `json:"name"`
+	Direction int           `json:"direction"` // 0 for Go to native, 1 for native to Go.
+	Bytes     int           `json:"bytes"`
+	Start     time.Time     `json:"start"`
+	Duration  time.Duration `json:"duration"`
+}
+
+// TraceSummary aggregates the calls of a method or function in one direction.
+type TraceSummary struct {
+	Name      string
+	Direction int
+	Calls     int
+	Bytes     int
+	Total     time.Duration
+	Max       time.Duration
+}
+
+// TraceDump fetches the trace from the app serving it at addr, and writes it to
+// w. The "json" format writes the events as is, and the "summary" format writes
+// a table of the calls grouped by name, most frequent first. If adb is true, the
+// port is forwarded to the connected Android device first.
+func TraceDump(flags *Flags, addr, format string, adb bool, w io.Writer) error {
+	if format != "summary" && format != "json" {
+		return fmt.Errorf("unknown trace format %q", format)
+	}
+	if adb {
+		_, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return err
+		}
+		cmd := exec.Command("adb", "forward", "tcp:"+port, "tcp:"+port)
+		if err := RunCmd(flags, "", cmd); err != nil {
+			return err
+		}
+	}
+	if !flags.ShouldRun() {
+		return nil
+	}
+
+	resp, err := http.Get("http://" + addr + TracePath)
+	if err != nil {
+		return fmt.Errorf("failed to fetch the trace, is the app serving it with bridgetrace.ListenAndServe? %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch the trace: %v", resp.Status)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if format == "json" {
+		_, err := w.Write(data)
+		return err
+	}
+	events := []TraceEvent{}
+	if err := json.Unmarshal(data, &events); err != nil {
+		return fmt.Errorf("failed to parse the trace: %v", err)
+	}
+	return WriteTraceSummary(w, events)
+}
+
+// SummarizeTrace groups events by direction and name, most frequent first.
+func SummarizeTrace(events []TraceEvent) []TraceSummary {
+	type key struct {
+		name      string
+		direction int
+	}
+	m := map[key]*TraceSummary{}
+	for _, i := range events {
+		k := key{i.Name, i.Direction}
+		s, ok := m[k]
+		if !ok {
+			s = &TraceSummary{Name: i.Name, Direction: i.Direction}
+			m[k] = s
+		}
+		s.Calls++
+		s.Bytes += i.Bytes
+		s.Total += i.Duration
+		if i.Duration > s.Max {
+			s.Max = i.Duration
+		}
+	}
+
+	summaries := make([]TraceSummary, 0, len(m))
+	for _, i := range m {
+		summaries = append(summaries, *i)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		a, b := summaries[i], summaries[j]
+		if a.Calls != b.Calls {
+			return a.Calls > b.Calls
+		}
+		if a.Total != b.Total {
+			return a.Total > b.Total
+		}
+		return a.Name < b.Name
+	})
+	return summaries
+}
+
+// WriteTraceSummary writes the summary of events to w as a table.
+func WriteTraceSummary(w io.Writer, events []TraceEvent) error {
+	var span time.Duration
+	if len(events) > 0 {
+		last := events[len(events)-1]
+		span = last.Start.Add(last.Duration).Sub(events[0].Start)
+	}
+	fmt.Fprintf(w, "%v calls over %v\n\n", len(events), span)
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "CALLS\tDIRECTION\tTOTAL\tAVG\tMAX\tBYTES\tNAME")
+	for _, i := range SummarizeTrace(events) {
+		direction := "go->native"
+		if i.Direction == 1 {
+			direction = "native->go"
+		}
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t%v\t%v\n", i.Calls, direction, i.Total, i.Total/time.Duration(i.Calls), i.Max, i.Bytes, i.Name)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,22 @@
+// Package bridgecomm lets native code observe comm.Notifiers through the
+// bridge. It is linked into every app by `matcha build`, and is used by the
+// Kotlin Flow adapter.
+package bridgecomm
+
+import (
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/comm"
+)
+
+func init() {
+	// Observe lets native code observe a Notifier. The onNotify method of the
+	// native object is called on each notification.
+	bridge.RegisterFunc("gomatcha.io/matcha/comm/bridgecomm Observe", func(n comm.Notifier, v *bridge.Value) comm.Id {
+		return n.Notify(func() {
+			v.Call("onNotify")
+		})
+	})
+	bridge.RegisterFunc("gomatcha.io/matcha/comm/bridgecomm Unobserve", func(n comm.Notifier, id comm.Id) {
+		n.Unnotify(id)
+	})
+}
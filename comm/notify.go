@@ -148,3 +148,15 @@ type DurationRWNotifier interface {
 	DurationNotifier
 	SetValue(time.Duration)
 }
+
+// TimeNotifier wraps Notifier with an additional Value() method which returns a time.Time.
+type TimeNotifier interface {
+	Notifier
+	Value() time.Time
+}
+
+// TimeRWNotifier wraps TimeNotifier with an additional SetValue(time.Time) method.
+type TimeRWNotifier interface {
+	TimeNotifier
+	SetValue(time.Time)
+}
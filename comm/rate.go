@@ -0,0 +1,244 @@
+package comm
+
+import (
+	"sync"
+	"time"
+
+	"gomatcha.io/matcha"
+	"gomatcha.io/matcha/internal/clock"
+)
+
+// Debounce returns a Notifier that posts a single notification once n has stopped
+// posting notifications for at least d. It is useful for coalescing bursts of
+// updates, such as keystrokes in a search field, into a single rebuild. The
+// returned Notifier only subscribes to n while it has observers. Values should
+// continue to be read from n.
+func Debounce(n Notifier, d time.Duration) Notifier {
+	v := &debouncer{duration: d}
+	v.source.sources = []Notifier{n}
+	v.source.funcs = []func(){v.trigger}
+	v.source.stop = v.stop
+	return v
+}
+
+type debouncer struct {
+	source   derived
+	duration time.Duration
+
+	mu    sync.Mutex
+	timer clock.Timer
+}
+
+// Notify implements the Notifier interface.
+func (v *debouncer) Notify(f func()) Id {
+	return v.source.Notify(f)
+}
+
+// Unnotify implements the Notifier interface.
+func (v *debouncer) Unnotify(id Id) {
+	v.source.Unnotify(id)
+}
+
+func (v *debouncer) trigger() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.timer != nil {
+		v.timer.Stop()
+	}
+	v.timer = clock.AfterFunc(v.duration, v.fire)
+}
+
+func (v *debouncer) fire() {
+	matcha.MainLocker.Lock()
+	defer matcha.MainLocker.Unlock()
+
+	v.source.relay.Signal()
+}
+
+func (v *debouncer) stop() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.timer != nil {
+		v.timer.Stop()
+		v.timer = nil
+	}
+}
+
+// Throttle returns a Notifier that posts at most one notification every d. The
+// first notification from n is forwarded immediately, and any notifications
+// received during the following interval are collapsed into one that is posted
+// when the interval ends. The returned Notifier only subscribes to n while it
+// has observers. Values should continue to be read from n.
+func Throttle(n Notifier, d time.Duration) Notifier {
+	v := &throttler{duration: d}
+	v.source.sources = []Notifier{n}
+	v.source.funcs = []func(){v.trigger}
+	v.source.stop = v.stop
+	return v
+}
+
+type throttler struct {
+	source   derived
+	duration time.Duration
+
+	mu      sync.Mutex
+	timer   clock.Timer
+	pending bool
+}
+
+// Notify implements the Notifier interface.
+func (v *throttler) Notify(f func()) Id {
+	return v.source.Notify(f)
+}
+
+// Unnotify implements the Notifier interface.
+func (v *throttler) Unnotify(id Id) {
+	v.source.Unnotify(id)
+}
+
+func (v *throttler) trigger() {
+	v.mu.Lock()
+	if v.timer != nil {
+		v.pending = true
+		v.mu.Unlock()
+		return
+	}
+	v.timer = clock.AfterFunc(v.duration, v.tick)
+	v.mu.Unlock()
+
+	v.source.relay.Signal()
+}
+
+func (v *throttler) tick() {
+	matcha.MainLocker.Lock()
+	defer matcha.MainLocker.Unlock()
+
+	v.mu.Lock()
+	if !v.pending {
+		v.timer = nil
+		v.mu.Unlock()
+		return
+	}
+	v.pending = false
+	v.timer = clock.AfterFunc(v.duration, v.tick)
+	v.mu.Unlock()
+
+	v.source.relay.Signal()
+}
+
+func (v *throttler) stop() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.timer != nil {
+		v.timer.Stop()
+		v.timer = nil
+	}
+	v.pending = false
+}
+
+// Sample returns a Notifier that posts at most one notification each time ticker
+// posts, and only if n has posted since the previous tick. Passing the screen's
+// frame clock (see animate.FrameClock) aligns updates from high frequency sources,
+// such as scroll offsets, with the display refresh. The returned Notifier only
+// subscribes to n and ticker while it has observers. Values should continue to be
+// read from n.
+func Sample(n, ticker Notifier) Notifier {
+	v := &sampler{}
+	v.source.sources = []Notifier{n, ticker}
+	v.source.funcs = []func(){v.mark, v.tick}
+	v.source.stop = v.stop
+	return v
+}
+
+type sampler struct {
+	source derived
+
+	mu    sync.Mutex
+	dirty bool
+}
+
+// Notify implements the Notifier interface.
+func (v *sampler) Notify(f func()) Id {
+	return v.source.Notify(f)
+}
+
+// Unnotify implements the Notifier interface.
+func (v *sampler) Unnotify(id Id) {
+	v.source.Unnotify(id)
+}
+
+func (v *sampler) mark() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.dirty = true
+}
+
+func (v *sampler) tick() {
+	v.mu.Lock()
+	dirty := v.dirty
+	v.dirty = false
+	v.mu.Unlock()
+
+	if dirty {
+		v.source.relay.Signal()
+	}
+}
+
+func (v *sampler) stop() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.dirty = false
+}
+
+// derived manages the observers of a Notifier that is computed from other
+// notifiers. It subscribes funcs[i] to sources[i] when the first observer is
+// added, and unsubscribes and calls stop when the last observer is removed.
+type derived struct {
+	relay   Relay
+	sources []Notifier
+	funcs   []func()
+	stop    func()
+
+	mu    sync.Mutex
+	count int
+	ids   []Id
+}
+
+func (d *derived) Notify(f func()) Id {
+	id := d.relay.Notify(f)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.count += 1
+	if d.count == 1 {
+		d.ids = make([]Id, len(d.sources))
+		for i, n := range d.sources {
+			d.ids[i] = n.Notify(d.funcs[i])
+		}
+	}
+	return id
+}
+
+func (d *derived) Unnotify(id Id) {
+	d.relay.Unnotify(id)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.count -= 1
+	if d.count == 0 {
+		for i, n := range d.sources {
+			n.Unnotify(d.ids[i])
+		}
+		d.ids = nil
+		if d.stop != nil {
+			d.stop()
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package comm_test
+
+import (
+	"testing"
+	"time"
+
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/matchatest"
+)
+
+func TestSample(t *testing.T) {
+	value := &comm.IntValue{}
+	ticker := &comm.Relay{}
+	s := comm.Sample(value, ticker)
+
+	count := 0
+	id := s.Notify(func() {
+		count += 1
+	})
+
+	ticker.Signal()
+	if count != 0 {
+		t.Error("Sample posted without a change")
+	}
+
+	value.SetValue(1)
+	value.SetValue(2)
+	value.SetValue(3)
+	if count != 0 {
+		t.Error("Sample posted before a tick")
+	}
+
+	ticker.Signal()
+	ticker.Signal()
+	if count != 1 {
+		t.Error("Sample did not coalesce changes", count)
+	}
+
+	s.Unnotify(id)
+	value.SetValue(4)
+	ticker.Signal()
+	if count != 1 {
+		t.Error("Sample posted after Unnotify", count)
+	}
+}
+
+func TestDebounce(t *testing.T) {
+	clock := matchatest.NewClock()
+	defer clock.Close()
+
+	value := &comm.IntValue{}
+	d := comm.Debounce(value, time.Millisecond*20)
+
+	count := 0
+	id := d.Notify(func() {
+		count += 1
+	})
+
+	for i := 1; i <= 5; i++ {
+		value.SetValue(i)
+		clock.Advance(time.Millisecond * 10)
+	}
+	if count != 0 {
+		t.Error("Debounce posted before the changes stopped", count)
+	}
+
+	clock.Advance(time.Millisecond * 10)
+	if count != 1 {
+		t.Error("Debounce did not coalesce changes", count)
+	}
+
+	d.Unnotify(id)
+	value.SetValue(6)
+	clock.Advance(time.Millisecond * 100)
+	if count != 1 {
+		t.Error("Debounce posted after Unnotify", count)
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	clock := matchatest.NewClock()
+	defer clock.Close()
+
+	value := &comm.IntValue{}
+	d := comm.Throttle(value, time.Millisecond*20)
+
+	count := 0
+	id := d.Notify(func() {
+		count += 1
+	})
+
+	value.SetValue(1)
+	if count != 1 {
+		t.Error("Throttle did not forward the first change", count)
+	}
+
+	value.SetValue(2)
+	value.SetValue(3)
+	clock.Advance(time.Millisecond * 10)
+	if count != 1 {
+		t.Error("Throttle posted during the interval", count)
+	}
+
+	clock.Advance(time.Millisecond * 10)
+	if count != 2 {
+		t.Error("Throttle did not coalesce changes at the end of the interval", count)
+	}
+
+	// The interval ends without changes, so the next change is forwarded.
+	clock.Advance(time.Millisecond * 20)
+	value.SetValue(4)
+	if count != 3 {
+		t.Error("Throttle did not forward a change after a quiet interval", count)
+	}
+
+	d.Unnotify(id)
+	value.SetValue(5)
+	clock.Advance(time.Millisecond * 100)
+	if count != 3 {
+		t.Error("Throttle posted after Unnotify", count)
+	}
+}
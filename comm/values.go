@@ -1,6 +1,9 @@
 package comm
 
-import "sync"
+import (
+	"sync"
+	"time"
+)
 
 // Float64Value implements the Float64RWNotifier interface.
 type Float64Value struct {
@@ -73,3 +76,111 @@ func (v *IntValue) SetValue(val int) {
 		v.mutex.Unlock()
 	}
 }
+
+// BoolValue implements the BoolRWNotifier interface.
+type BoolValue struct {
+	value bool
+	relay Relay
+	mutex sync.Mutex
+}
+
+// Notify implements the BoolNotifier interface.
+func (v *BoolValue) Notify(f func()) Id {
+	return v.relay.Notify(f)
+}
+
+// Unnotify implements the BoolNotifier interface.
+func (v *BoolValue) Unnotify(id Id) {
+	v.relay.Unnotify(id)
+}
+
+// Value implements the BoolNotifier interface.
+func (v *BoolValue) Value() bool {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return v.value
+}
+
+// SetValue updates v.Value() and notifies any observers.
+func (v *BoolValue) SetValue(val bool) {
+	v.mutex.Lock()
+	if val != v.value {
+		v.value = val
+		v.mutex.Unlock()
+		v.relay.Signal()
+	} else {
+		v.mutex.Unlock()
+	}
+}
+
+// TimeValue implements the TimeRWNotifier interface.
+type TimeValue struct {
+	value time.Time
+	relay Relay
+	mutex sync.Mutex
+}
+
+// Notify implements the TimeNotifier interface.
+func (v *TimeValue) Notify(f func()) Id {
+	return v.relay.Notify(f)
+}
+
+// Unnotify implements the TimeNotifier interface.
+func (v *TimeValue) Unnotify(id Id) {
+	v.relay.Unnotify(id)
+}
+
+// Value implements the TimeNotifier interface.
+func (v *TimeValue) Value() time.Time {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return v.value
+}
+
+// SetValue updates v.Value() and notifies any observers.
+func (v *TimeValue) SetValue(val time.Time) {
+	v.mutex.Lock()
+	if !val.Equal(v.value) {
+		v.value = val
+		v.mutex.Unlock()
+		v.relay.Signal()
+	} else {
+		v.mutex.Unlock()
+	}
+}
+
+// StringValue implements the StringRWNotifier interface.
+type StringValue struct {
+	value string
+	relay Relay
+	mutex sync.Mutex
+}
+
+// Notify implements the StringNotifier interface.
+func (v *StringValue) Notify(f func()) Id {
+	return v.relay.Notify(f)
+}
+
+// Unnotify implements the StringNotifier interface.
+func (v *StringValue) Unnotify(id Id) {
+	v.relay.Unnotify(id)
+}
+
+// Value implements the StringNotifier interface.
+func (v *StringValue) Value() string {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return v.value
+}
+
+// SetValue updates v.Value() and notifies any observers.
+func (v *StringValue) SetValue(val string) {
+	v.mutex.Lock()
+	if val != v.value {
+		v.value = val
+		v.mutex.Unlock()
+		v.relay.Signal()
+	} else {
+		v.mutex.Unlock()
+	}
+}
@@ -0,0 +1,125 @@
+// Package config provides kill switches for framework features. New features are
+// registered as a Switch that is on by default. An app can fetch switch values
+// from its own remote config and apply them with Set, so that a feature with a
+// bug found after release can be turned off in the field without shipping a new
+// binary.
+//
+//  func init() {
+//      // Apply the values fetched by the previous launch before any view is built.
+//      config.Set(loadCachedConfig())
+//      go func() {
+//          values := fetchRemoteConfig()
+//          saveCachedConfig(values)
+//          config.Set(values)
+//      }()
+//  }
+package config
+
+import (
+	"sort"
+	"sync"
+
+	"gomatcha.io/matcha/comm"
+)
+
+var registry = struct {
+	mu        sync.Mutex
+	switches  map[string]*Switch
+	overrides map[string]bool
+}{
+	switches:  map[string]*Switch{},
+	overrides: map[string]bool{},
+}
+
+// Switch is a kill switch for a feature. It implements the comm.BoolNotifier
+// interface, so views can subscribe to it and rebuild when it is toggled.
+type Switch struct {
+	name    string
+	enabled bool
+	value   bool
+	relay   comm.Relay
+}
+
+// NewSwitch registers a switch named name, whose value is enabled unless it is
+// overridden by Set. Names should be prefixed by the import path of the package,
+// e.g. "gomatcha.io/matcha/view profile". NewSwitch panics if the name is
+// already registered, so it should be called when initializing package variables.
+func NewSwitch(name string, enabled bool) *Switch {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if _, ok := registry.switches[name]; ok {
+		panic("config: switch already registered: " + name)
+	}
+	s := &Switch{name: name, enabled: enabled, value: enabled}
+	if v, ok := registry.overrides[name]; ok {
+		s.value = v
+	}
+	registry.switches[name] = s
+	return s
+}
+
+// Name returns the name of the switch.
+func (s *Switch) Name() string {
+	return s.name
+}
+
+// Value returns true if the feature is enabled.
+func (s *Switch) Value() bool {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	return s.value
+}
+
+// Notify implements the comm.BoolNotifier interface.
+func (s *Switch) Notify(f func()) comm.Id {
+	return s.relay.Notify(f)
+}
+
+// Unnotify implements the comm.BoolNotifier interface.
+func (s *Switch) Unnotify(id comm.Id) {
+	s.relay.Unnotify(id)
+}
+
+// Set replaces the overridden values of switches, keyed by name. Switches that
+// are not in values revert to their default. Names that are not registered are
+// kept, and apply to switches registered later. Observers of switches whose value
+// changed are notified.
+func Set(values map[string]bool) {
+	registry.mu.Lock()
+	overrides := make(map[string]bool, len(values))
+	for k, v := range values {
+		overrides[k] = v
+	}
+	registry.overrides = overrides
+
+	changed := []*Switch{}
+	for name, s := range registry.switches {
+		value, ok := overrides[name]
+		if !ok {
+			value = s.enabled
+		}
+		if value != s.value {
+			s.value = value
+			changed = append(changed, s)
+		}
+	}
+	registry.mu.Unlock()
+
+	for _, i := range changed {
+		i.relay.Signal()
+	}
+}
+
+// Switches returns the names of the registered switches in sorted order.
+func Switches() []string {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	names := make([]string, 0, len(registry.switches))
+	for i := range registry.switches {
+		names = append(names, i)
+	}
+	sort.Strings(names)
+	return names
+}
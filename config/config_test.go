@@ -0,0 +1,47 @@
+package config
+
+import "testing"
+
+// resetRegistry clears the registered switches and overrides, so that tests
+// can register the same names on every run.
+func resetRegistry() {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.switches = map[string]*Switch{}
+	registry.overrides = map[string]bool{}
+}
+
+func TestSet(t *testing.T) {
+	resetRegistry()
+	defer resetRegistry()
+
+	a := NewSwitch("test a", true)
+	b := NewSwitch("test b", false)
+
+	notified := 0
+	id := a.Notify(func() { notified += 1 })
+	defer a.Unnotify(id)
+
+	Set(map[string]bool{"test a": false, "test c": true})
+	if a.Value() || b.Value() {
+		t.Errorf("got %v %v, want false false", a.Value(), b.Value())
+	}
+	if notified != 1 {
+		t.Errorf("notified %v times, want 1", notified)
+	}
+
+	// Switches registered after Set use its values.
+	c := NewSwitch("test c", false)
+	if !c.Value() {
+		t.Error("expected override of late switch")
+	}
+
+	// Missing values revert to the default.
+	Set(nil)
+	if !a.Value() || b.Value() || c.Value() {
+		t.Errorf("got %v %v %v, want true false false", a.Value(), b.Value(), c.Value())
+	}
+	if notified != 2 {
+		t.Errorf("notified %v times, want 2", notified)
+	}
+}
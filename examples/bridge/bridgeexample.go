@@ -40,11 +40,11 @@ func (v *BridgeView) Build(ctx view.Context) view.Model {
 	var str string
 	var str2 string
 	if runtime.GOOS == "android" {
-		str = bridge.Bridge("gomatcha.io/matcha/example").Call("callWithGoValues", bridge.Interface(123)).ToInterface().(string)
-		str2 = bridge.Bridge("gomatcha.io/matcha/example").Call("callWithForeignValues", bridge.Int64(456)).ToString()
+		str = bridge.Bridge("gomatcha.io/matcha/example").CallNow("callWithGoValues", bridge.Interface(123)).ToInterface().(string)
+		str2 = bridge.Bridge("gomatcha.io/matcha/example").CallNow("callWithForeignValues", bridge.Int64(456)).ToString()
 	} else {
-		str = bridge.Bridge("gomatcha.io/matcha/example").Call("callWithGoValues:", bridge.Interface(123)).ToInterface().(string)
-		str2 = bridge.Bridge("gomatcha.io/matcha/example").Call("callWithForeignValues:", bridge.Int64(456)).ToString()
+		str = bridge.Bridge("gomatcha.io/matcha/example").CallNow("callWithGoValues:", bridge.Interface(123)).ToInterface().(string)
+		str2 = bridge.Bridge("gomatcha.io/matcha/example").CallNow("callWithForeignValues:", bridge.Int64(456)).ToString()
 	}
 
 	chl1 := view.NewTextView()
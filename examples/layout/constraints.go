@@ -5,6 +5,7 @@ import (
 	"gomatcha.io/matcha/bridge"
 	"gomatcha.io/matcha/layout/constraint"
 	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/text"
 	"gomatcha.io/matcha/view"
 )
 
@@ -60,6 +61,23 @@ func (v *ConstraintsView) Build(ctx view.Context) view.Model {
 		s.Height(50)
 	})
 
+	// Labels of different sizes, aligned on the baseline of their text.
+	title := view.NewTextView()
+	title.String = "Title"
+	title.Style.SetFont(text.FontWithName("HelveticaNeue", 34))
+	g5 := l.Add(title, func(s *constraint.Solver) {
+		s.TopEqual(g3.Bottom().Add(10))
+		s.LeftEqual(g3.Left())
+	})
+
+	subtitle := view.NewTextView()
+	subtitle.String = "Subtitle"
+	subtitle.Style.SetFont(text.FontWithName("HelveticaNeue", 13))
+	_ = l.Add(subtitle, func(s *constraint.Solver) {
+		s.FirstBaselineEqual(g5.FirstBaseline())
+		s.LeftEqual(g5.Right().Add(8))
+	})
+
 	return view.Model{
 		Children: l.Views(),
 		Layouter: l,
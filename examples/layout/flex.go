@@ -0,0 +1,93 @@
+package layout
+
+import (
+	"fmt"
+
+	"golang.org/x/image/colornames"
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/layout/flex"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+)
+
+func init() {
+	bridge.RegisterFunc("gomatcha.io/matcha/examples/layout NewFlexView", func() view.View {
+		return NewFlexView()
+	})
+}
+
+type FlexView struct {
+	view.Embed
+}
+
+func NewFlexView() *FlexView {
+	return &FlexView{}
+}
+
+func (v *FlexView) Build(ctx view.Context) view.Model {
+	l := &flex.Layouter{
+		Padding: flex.Insets{Top: 20, Left: 10, Bottom: 10, Right: 10},
+		Gap:     10,
+	}
+
+	// A header row with a fixed size icon and a title that fills the rest.
+	header := &flex.Layouter{
+		Direction:  flex.DirectionRow,
+		AlignItems: flex.AlignCenter,
+		Gap:        10,
+	}
+	icon := view.NewBasicView()
+	icon.Painter = &paint.Style{BackgroundColor: colornames.Orange}
+	header.Add(icon, func(i *flex.Item) {
+		i.Width = 40
+		i.Height = 40
+	})
+	title := view.NewTextView()
+	title.String = "Flex layout"
+	header.Add(title, func(i *flex.Item) {
+		i.Grow = 1
+	})
+	headerView := view.NewBasicView()
+	headerView.Children = header.Views()
+	headerView.Layouter = header
+	l.Add(headerView, func(i *flex.Item) {
+		i.Height = 40
+	})
+
+	// Tiles that wrap onto new lines.
+	tiles := &flex.Layouter{
+		Direction: flex.DirectionRow,
+		Wrap:      flex.WrapNormal,
+		Justify:   flex.JustifySpaceBetween,
+		Gap:       10,
+	}
+	for i := 0; i < 7; i++ {
+		tile := view.NewTextView()
+		tile.String = fmt.Sprintf("Tile %v", i)
+		tile.PaintStyle = &paint.Style{BackgroundColor: colornames.Lightblue}
+		tiles.Add(tile, func(i *flex.Item) {
+			i.Width = 90
+			i.Height = 60
+		})
+	}
+	tilesView := view.NewBasicView()
+	tilesView.Children = tiles.Views()
+	tilesView.Layouter = tiles
+	l.Add(tilesView, nil)
+
+	// A footer pushed to the bottom by a growing spacer.
+	l.Add(view.NewBasicView(), func(i *flex.Item) {
+		i.Grow = 1
+	})
+	footer := view.NewTextView()
+	footer.String = "Footer"
+	l.Add(footer, func(i *flex.Item) {
+		i.AlignSelf = flex.AlignCenter
+	})
+
+	return view.Model{
+		Children: l.Views(),
+		Layouter: l,
+		Painter:  &paint.Style{BackgroundColor: colornames.White},
+	}
+}
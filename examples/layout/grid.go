@@ -0,0 +1,64 @@
+package layout
+
+import (
+	"fmt"
+
+	"golang.org/x/image/colornames"
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/layout/grid"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+)
+
+func init() {
+	bridge.RegisterFunc("gomatcha.io/matcha/examples/layout NewGridView", func() view.View {
+		return NewGridView()
+	})
+}
+
+type GridView struct {
+	view.Embed
+}
+
+func NewGridView() *GridView {
+	return &GridView{}
+}
+
+func (v *GridView) Build(ctx view.Context) view.Model {
+	rows := grid.Fixed(100)
+	l := &grid.Layouter{
+		Columns:   []grid.Track{grid.Fraction(1), grid.Fraction(1), grid.Fraction(1)},
+		Rows:      []grid.Track{grid.Fixed(60)},
+		AutoRows:  &rows,
+		ColumnGap: 10,
+		RowGap:    10,
+	}
+
+	header := view.NewTextView()
+	header.String = "Dashboard"
+	header.PaintStyle = &paint.Style{BackgroundColor: colornames.Lightgray}
+	l.Add(header, func(c *grid.Cell) {
+		c.ColumnSpan = 3
+	})
+
+	chart := view.NewTextView()
+	chart.String = "Chart"
+	chart.PaintStyle = &paint.Style{BackgroundColor: colornames.Orange}
+	l.Add(chart, func(c *grid.Cell) {
+		c.ColumnSpan = 2
+		c.RowSpan = 2
+	})
+
+	for i := 0; i < 6; i++ {
+		tile := view.NewTextView()
+		tile.String = fmt.Sprintf("Tile %v", i)
+		tile.PaintStyle = &paint.Style{BackgroundColor: colornames.Lightblue}
+		l.Add(tile, nil)
+	}
+
+	return view.Model{
+		Children: l.Views(),
+		Layouter: l,
+		Painter:  &paint.Style{BackgroundColor: colornames.White},
+	}
+}
@@ -0,0 +1,70 @@
+package layout
+
+import (
+	"golang.org/x/image/colornames"
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/layout/stack"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+)
+
+func init() {
+	bridge.RegisterFunc("gomatcha.io/matcha/examples/layout NewStackView", func() view.View {
+		return NewStackView()
+	})
+}
+
+type StackView struct {
+	view.Embed
+}
+
+func NewStackView() *StackView {
+	return &StackView{}
+}
+
+func (v *StackView) Build(ctx view.Context) view.Model {
+	l := &stack.Layouter{Spacing: 10}
+
+	// A row with a title that takes the space between two buttons.
+	row := &stack.Layouter{
+		Axis:      layout.AxisX,
+		Spacing:   8,
+		Alignment: stack.AlignCenter,
+	}
+	back := view.NewButton()
+	back.String = "Back"
+	row.Add(back, nil)
+	title := view.NewTextView()
+	title.String = "Stacks"
+	row.Add(title, func(i *stack.Item) {
+		i.Weight = 1
+	})
+	done := view.NewButton()
+	done.String = "Done"
+	row.Add(done, nil)
+	rowView := view.NewBasicView()
+	rowView.Children = row.Views()
+	rowView.Layouter = row
+	l.Add(rowView, nil)
+
+	for _, s := range []string{"First", "Second", "Third"} {
+		label := view.NewTextView()
+		label.String = s
+		label.PaintStyle = &paint.Style{BackgroundColor: colornames.Lightblue}
+		l.Add(label, nil)
+	}
+
+	l.AddSpacer(1)
+	footer := view.NewTextView()
+	footer.String = "Footer"
+	l.Add(footer, func(i *stack.Item) {
+		i.Alignment = stack.AlignCenter
+	})
+
+	return view.Model{
+		Children: l.Views(),
+		Layouter: l,
+		Painter:  &paint.Style{BackgroundColor: colornames.White},
+	}
+}
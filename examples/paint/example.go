@@ -43,10 +43,12 @@ func (v *PaintView) Build(ctx view.Context) view.Model {
 
 	chl2 := view.NewBasicView()
 	chl2.Painter = &paint.Style{
-		BackgroundColor: colornames.Yellow,
-		ShadowRadius:    4,
-		ShadowOffset:    layout.Pt(5, 5),
-		ShadowColor:     colornames.Black,
+		BackgroundColor:    colornames.Yellow,
+		CornerRadius:       10,
+		ShadowRadius:       4,
+		ShadowOffset:       layout.Pt(5, 5),
+		ShadowColor:        colornames.Black,
+		ShadowTransparency: 0.5,
 	}
 	g2 := l.Add(chl2, func(s *constraint.Solver) {
 		s.TopEqual(g1.Bottom().Add(20))
@@ -76,6 +78,36 @@ func (v *PaintView) Build(ctx view.Context) view.Model {
 		s.Height(100)
 	})
 
+	chl5 := view.NewBasicView()
+	chl5.Painter = &paint.Style{
+		CornerRadius: 10,
+		Gradient: paint.NewLinearGradient(45,
+			paint.GradientStop{Offset: 0, Color: colornames.Orange},
+			paint.GradientStop{Offset: 1, Color: colornames.Purple},
+		),
+	}
+	g5 := l.Add(chl5, func(s *constraint.Solver) {
+		s.TopEqual(g1.Top())
+		s.LeftEqual(g1.Right().Add(20))
+		s.Width(100)
+		s.Height(100)
+	})
+
+	chl6 := view.NewBasicView()
+	chl6.Painter = &paint.Style{
+		Gradient: paint.NewRadialGradient(
+			paint.GradientStop{Offset: 0, Color: colornames.White},
+			paint.GradientStop{Offset: 0.5, Color: colornames.Yellow},
+			paint.GradientStop{Offset: 1, Color: colornames.Red},
+		),
+	}
+	_ = l.Add(chl6, func(s *constraint.Solver) {
+		s.TopEqual(g5.Bottom().Add(20))
+		s.LeftEqual(g5.Left())
+		s.Width(100)
+		s.Height(100)
+	})
+
 	return view.Model{
 		Children: l.Views(),
 		Layouter: l,
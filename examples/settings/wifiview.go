@@ -1,8 +1,11 @@
 package settings
 
 import (
+	"strings"
+
 	"golang.org/x/image/colornames"
 	"gomatcha.io/matcha/application"
+	"gomatcha.io/matcha/comm"
 	"gomatcha.io/matcha/layout/constraint"
 	"gomatcha.io/matcha/layout/table"
 	"gomatcha.io/matcha/paint"
@@ -10,11 +13,13 @@ import (
 	"gomatcha.io/matcha/view"
 	"gomatcha.io/matcha/view/android"
 	"gomatcha.io/matcha/view/ios"
+	"gomatcha.io/matcha/view/searchbar"
 )
 
 type WifiView struct {
 	view.Embed
-	app *App
+	app   *App
+	query comm.StringValue
 }
 
 func NewWifiView(app *App) *WifiView {
@@ -27,8 +32,10 @@ func NewWifiView(app *App) *WifiView {
 func (v *WifiView) Lifecycle(from, to view.Stage) {
 	if view.EntersStage(from, to, view.StageMounted) {
 		v.Subscribe(v.app.Wifi)
+		v.Subscribe(&v.query)
 	} else if view.ExitsStage(from, to, view.StageMounted) {
 		v.Unsubscribe(v.app.Wifi)
+		v.Unsubscribe(&v.query)
 	}
 }
 
@@ -78,6 +85,9 @@ func (v *WifiView) Build(ctx view.Context) view.Model {
 				if ssid == v.app.Wifi.CurrentSSID() {
 					continue
 				}
+				if !strings.Contains(strings.ToLower(ssid), strings.ToLower(v.query.Value())) {
+					continue
+				}
 
 				info := NewInfoButton()
 				info.OnPress = func() {
@@ -130,12 +140,16 @@ func (v *WifiView) Build(ctx view.Context) view.Model {
 	scrollView.ContentChildren = l.Views()
 	scrollView.ContentLayouter = l
 
+	search := &searchbar.Bar{
+		Placeholder: "Search Networks",
+		Text:        &v.query,
+	}
 	return view.Model{
 		Children: []view.View{scrollView},
 		Painter:  &paint.Style{BackgroundColor: backgroundColor},
 		Options: []view.Option{
-			&ios.StackBar{Title: "Wifi"},
-			&android.StackBar{Title: "Wifi"},
+			&ios.StackBar{Title: "Wifi", SearchBar: search},
+			&android.StackBar{Title: "Wifi", SearchBar: search},
 		},
 	}
 }
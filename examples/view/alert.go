@@ -8,6 +8,8 @@ import (
 	"gomatcha.io/matcha/layout/constraint"
 	"gomatcha.io/matcha/paint"
 	"gomatcha.io/matcha/view"
+	"gomatcha.io/matcha/view/alert"
+	"gomatcha.io/matcha/view/snackbar"
 )
 
 func init() {
@@ -83,12 +85,72 @@ func (v *AlertView) Build(ctx view.Context) view.Model {
 		cancel := &view.AlertButton{Title: "Cancel", OnPress: func() { fmt.Println("OnPress Cancel") }}
 		view.Alert("Title", "Message", ok, cancel, other)
 	}
-	_ = l.Add(chl5, func(s *constraint.Solver) {
+	g5 := l.Add(chl5, func(s *constraint.Solver) {
 		s.TopEqual(g4.Bottom())
 		s.Left(0)
 		s.Width(200)
 	})
 
+	chl6 := view.NewButton()
+	chl6.String = "Action Sheet"
+	chl6.OnPress = func() {
+		share := &alert.Button{Title: "Share", OnPress: func() { fmt.Println("OnPress Share") }}
+		del := &alert.Button{Title: "Delete", Style: alert.ButtonStyleDestructive}
+		cancel := &alert.Button{Title: "Cancel", Style: alert.ButtonStyleCancel}
+		c := alert.ActionSheet("Photo", "", share, del, cancel)
+		go func() {
+			fmt.Println("Action sheet result", (<-c).Button)
+		}()
+	}
+	g6 := l.Add(chl6, func(s *constraint.Solver) {
+		s.TopEqual(g5.Bottom())
+		s.Left(0)
+		s.Width(200)
+	})
+
+	chl7 := view.NewButton()
+	chl7.String = "Prompt"
+	chl7.OnPress = func() {
+		user := &alert.TextField{Placeholder: "User"}
+		password := &alert.TextField{Placeholder: "Password", Secure: true}
+		login := &alert.Button{Title: "Log In", OnPress: func() { fmt.Println("OnPress Log In", user.Text) }}
+		cancel := &alert.Button{Title: "Cancel", Style: alert.ButtonStyleCancel}
+		alert.Prompt("Log In", "", []*alert.TextField{user, password}, login, cancel)
+	}
+	g7 := l.Add(chl7, func(s *constraint.Solver) {
+		s.TopEqual(g6.Bottom())
+		s.Left(0)
+		s.Width(200)
+	})
+
+	chl8 := view.NewButton()
+	chl8.String = "Snackbar"
+	chl8.OnPress = func() {
+		snackbar.Show(&snackbar.Message{Text: "Message sent"})
+	}
+	g8 := l.Add(chl8, func(s *constraint.Solver) {
+		s.TopEqual(g7.Bottom())
+		s.Left(0)
+		s.Width(200)
+	})
+
+	chl9 := view.NewButton()
+	chl9.String = "Snackbar with Action"
+	chl9.OnPress = func() {
+		undo := &snackbar.Action{Title: "Undo", OnPress: func() { fmt.Println("OnPress Undo") }}
+		c := snackbar.Show(&snackbar.Message{Text: "Photo deleted", Action: undo, Duration: snackbar.DurationLong})
+		// Queued after the first one.
+		snackbar.Show(&snackbar.Message{Text: "Album updated"})
+		go func() {
+			fmt.Println("Snackbar result", (<-c).Action)
+		}()
+	}
+	_ = l.Add(chl9, func(s *constraint.Solver) {
+		s.TopEqual(g8.Bottom())
+		s.Left(0)
+		s.Width(200)
+	})
+
 	return view.Model{
 		Children: l.Views(),
 		Layouter: l,
@@ -0,0 +1,119 @@
+package android
+
+import (
+	"fmt"
+
+	"golang.org/x/image/colornames"
+	"gomatcha.io/matcha/application"
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/layout/constraint"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+	"gomatcha.io/matcha/view/android"
+	"gomatcha.io/matcha/view/android/bottomnav"
+	"gomatcha.io/matcha/view/router"
+)
+
+func init() {
+	bridge.RegisterFunc("gomatcha.io/matcha/examples/view/android NewBottomNavView", func() view.View {
+		app := &BottomNavApp{
+			tab:   &comm.IntValue{},
+			likes: &comm.IntValue{},
+		}
+		for idx, title := range []string{"Home", "Likes", "Share"} {
+			title := title
+			item := &bottomnav.Item{Title: title, Stack: &android.Stack{}}
+			item.Router = router.New(item.Stack)
+			item.Router.Handle("/", func(p router.Params) view.View {
+				return NewBottomNavChild(app, item.Router, title, 0)
+			})
+			item.Router.Handle("/"+title+"/:depth", func(p router.Params) view.View {
+				depth := 0
+				fmt.Sscan(p["depth"], &depth)
+				return NewBottomNavChild(app, item.Router, title, depth)
+			})
+			item.Router.Open("/")
+			app.items = append(app.items, item)
+			if idx == 1 {
+				item.BadgeCount = app.likes
+			}
+		}
+		app.items[0].Icon = application.MustLoadImage("insta_heart")
+		app.items[1].Icon = application.MustLoadImage("insta_like")
+		app.items[2].Icon = application.MustLoadImage("insta_share")
+
+		v := bottomnav.New()
+		v.Items = app.items
+		v.Selected = app.tab
+		v.StackBarColor = colornames.Lightgray
+		return v
+	})
+}
+
+type BottomNavApp struct {
+	items []*bottomnav.Item
+	tab   *comm.IntValue
+	likes *comm.IntValue
+}
+
+type BottomNavChild struct {
+	view.Embed
+	app    *BottomNavApp
+	router *router.Router
+	title  string
+	depth  int
+}
+
+func NewBottomNavChild(app *BottomNavApp, r *router.Router, title string, depth int) *BottomNavChild {
+	return &BottomNavChild{app: app, router: r, title: title, depth: depth}
+}
+
+func (v *BottomNavChild) Build(ctx view.Context) view.Model {
+	l := &constraint.Layouter{}
+
+	push := view.NewButton()
+	push.String = "Push"
+	push.OnPress = func() {
+		v.router.Push(fmt.Sprintf("/%v/%v", v.title, v.depth+1))
+	}
+	g := l.Add(push, func(s *constraint.Solver) {
+		s.Top(20)
+		s.Left(20)
+	})
+
+	like := view.NewButton()
+	like.String = "Like"
+	like.OnPress = func() {
+		v.app.likes.SetValue(v.app.likes.Value() + 1)
+	}
+	g = l.Add(like, func(s *constraint.Solver) {
+		s.TopEqual(g.Bottom().Add(20))
+		s.Left(20)
+	})
+
+	open := view.NewButton()
+	open.String = "Open myapp://Share/3"
+	open.OnPress = func() {
+		if idx, err := bottomnav.Open(v.app.items, "myapp://Share/3"); err == nil {
+			v.app.tab.SetValue(idx)
+		}
+	}
+	l.Add(open, func(s *constraint.Solver) {
+		s.TopEqual(g.Bottom().Add(20))
+		s.Left(20)
+	})
+
+	title := v.title
+	if v.depth > 0 {
+		title = fmt.Sprintf("%v %v", v.title, v.depth)
+	}
+	return view.Model{
+		Children: l.Views(),
+		Layouter: l,
+		Painter:  &paint.Style{BackgroundColor: colornames.White},
+		Options: []view.Option{
+			&android.StackBar{Title: title},
+		},
+	}
+}
@@ -0,0 +1,85 @@
+package android
+
+import (
+	"fmt"
+
+	"golang.org/x/image/colornames"
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/layout/constraint"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+	"gomatcha.io/matcha/view/android/drawer"
+)
+
+func init() {
+	bridge.RegisterFunc("gomatcha.io/matcha/examples/view/android NewDrawerView", func() view.View {
+		return NewDrawerView()
+	})
+}
+
+type DrawerView struct {
+	view.Embed
+	open     *comm.BoolValue
+	selected int
+}
+
+func NewDrawerView() *DrawerView {
+	return &DrawerView{open: &comm.BoolValue{}}
+}
+
+func (v *DrawerView) Build(ctx view.Context) view.Model {
+	sections := []string{"Inbox", "Starred", "Sent", "Drafts"}
+
+	menu := &constraint.Layouter{}
+	g := menu.Top()
+	for idx, i := range sections {
+		idx := idx
+		button := view.NewButton()
+		button.String = i
+		button.OnPress = func() {
+			v.selected = idx
+			v.open.SetValue(false)
+			v.Signal()
+		}
+		guide := menu.Add(button, func(s *constraint.Solver) {
+			s.TopEqual(g.Add(20))
+			s.Left(20)
+		})
+		g = guide.Bottom()
+	}
+	menuView := view.NewBasicView()
+	menuView.Children = menu.Views()
+	menuView.Layouter = menu
+	menuView.Painter = &paint.Style{BackgroundColor: colornames.White}
+
+	content := &constraint.Layouter{}
+	label := view.NewTextView()
+	label.String = fmt.Sprintf("%v, swipe from the left edge to open the drawer.", sections[v.selected])
+	lg := content.Add(label, func(s *constraint.Solver) {
+		s.Top(20)
+		s.Left(20)
+		s.RightEqual(content.Right().Add(-20))
+	})
+	button := view.NewButton()
+	button.String = "Open Drawer"
+	button.OnPress = func() {
+		v.open.SetValue(true)
+	}
+	content.Add(button, func(s *constraint.Solver) {
+		s.TopEqual(lg.Bottom().Add(20))
+		s.Left(20)
+	})
+	contentView := view.NewBasicView()
+	contentView.Children = content.Views()
+	contentView.Layouter = content
+	contentView.Painter = &paint.Style{BackgroundColor: colornames.Lightgray}
+
+	d := drawer.New()
+	d.Menu = menuView
+	d.Content = contentView
+	d.Open = v.open
+	return view.Model{
+		Children: []view.View{d},
+	}
+}
@@ -26,11 +26,11 @@ func init() {
 
 		v2 := NewPagerChildView()
 		v2.PaintStyle = &paint.Style{BackgroundColor: colornames.White}
-		v2.PagerButton = &android.PagerButton{Title: "Title 2"}
+		v2.PagerButton = &android.PagerButton{Title: "Title 2", Badge: "3"}
 
 		v3 := NewPagerChildView()
 		v3.PaintStyle = &paint.Style{BackgroundColor: colornames.Black}
-		v3.PagerButton = &android.PagerButton{Title: "Title 3"}
+		v3.PagerButton = &android.PagerButton{Title: "Title 3", BadgeDot: true}
 
 		app.Pages.SetViews(v1, v2, v3)
 		app.Pages.SetSelectedIndex(2)
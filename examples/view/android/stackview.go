@@ -82,10 +82,13 @@ func (v *StackChild) Build(ctx view.Context) view.Model {
 			// v.bar.Title = "Updated"
 			// v.Signal()
 
+			// Cycle through the transitions as screens are pushed.
 			child := NewStackChild(v.app)
 			child.Index = v.Index + 1
 			child.Color = colornames.White
-			v.app.stack.Push(child)
+			v.app.stack.PushWithTransition(child, &android.Transition{
+				Style: android.TransitionStyle(child.Index % 5),
+			})
 		},
 	}
 
@@ -101,15 +104,27 @@ func (v *StackChild) Build(ctx view.Context) view.Model {
 		fmt.Println("OnPress")
 	}
 
+	overflowItem := android.NewStackBarItem()
+	overflowItem.Title = "Settings"
+	overflowItem.Overflow = true
+	overflowItem.OnPress = func() {
+		fmt.Println("OnPress Settings")
+	}
+
+	// Alternate the title modes as screens are pushed.
+	titleMode := android.TitleMode(v.Index % 3)
+
 	return view.Model{
 		Painter: &paint.Style{BackgroundColor: v.Color},
 		Options: []view.Option{
 			pointer.GestureList{tap},
 			&android.StackBar{
-				StyledTitle:    title,
-				StyledSubtitle: subtitle,
-				Color:          colornames.White,
-				Items:          []*android.StackBarItem{item},
+				StyledTitle:     title,
+				StyledSubtitle:  subtitle,
+				Color:           colornames.White,
+				Items:           []*android.StackBarItem{item, overflowItem},
+				TitleMode:       titleMode,
+				ScrollEdgeColor: colornames.Whitesmoke,
 			},
 		},
 	}
@@ -0,0 +1,97 @@
+package view
+
+import (
+	"fmt"
+	"image/color"
+
+	"golang.org/x/image/colornames"
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/layout/constraint"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+	"gomatcha.io/matcha/view/barcodescanner"
+)
+
+func init() {
+	bridge.RegisterFunc("gomatcha.io/matcha/examples/view NewBarcodeScannerView", func() view.View {
+		return NewBarcodeScannerView()
+	})
+}
+
+type BarcodeScannerView struct {
+	view.Embed
+	barcode *barcodescanner.Barcode
+	torch   bool
+	paused  bool
+}
+
+func NewBarcodeScannerView() *BarcodeScannerView {
+	return &BarcodeScannerView{}
+}
+
+func (v *BarcodeScannerView) Build(ctx view.Context) view.Model {
+	l := &constraint.Layouter{}
+
+	scanner := barcodescanner.New()
+	scanner.Region = layout.Rt(40, 100, 280, 340)
+	scanner.OverlayColor = color.NRGBA{A: 0x99}
+	scanner.Torch = v.torch
+	scanner.Paused = v.paused
+	scanner.OnScan = func(b barcodescanner.Barcode) {
+		v.barcode = &b
+		v.Signal()
+	}
+	scanner.OnError = func(err error) {
+		fmt.Println("barcodescanner error", err)
+	}
+	l.Add(scanner, func(s *constraint.Solver) {
+		s.TopEqual(l.Top())
+		s.LeftEqual(l.Left())
+		s.WidthEqual(l.Width())
+		s.HeightEqual(l.Height())
+	})
+
+	label := view.NewTextView()
+	label.String = "Point the camera at a barcode"
+	if v.barcode != nil {
+		label.String = fmt.Sprintf("%v: %v", v.barcode.Format, v.barcode.Value)
+	}
+	label.Style.SetTextColor(colornames.White)
+	l.Add(label, func(s *constraint.Solver) {
+		s.Top(360)
+		s.Left(40)
+		s.RightEqual(l.Right().Add(-40))
+	})
+
+	torch := view.NewButton()
+	torch.String = "Torch"
+	torch.OnPress = func() {
+		v.torch = !v.torch
+		v.Signal()
+	}
+	g := l.Add(torch, func(s *constraint.Solver) {
+		s.BottomEqual(l.Bottom().Add(-20))
+		s.LeftEqual(l.Left().Add(20))
+	})
+
+	pause := view.NewButton()
+	pause.String = "Pause"
+	if v.paused {
+		pause.String = "Resume"
+	}
+	pause.OnPress = func() {
+		v.paused = !v.paused
+		v.Signal()
+	}
+	l.Add(pause, func(s *constraint.Solver) {
+		s.BottomEqual(g.Bottom())
+		s.RightEqual(l.Right().Add(-20))
+	})
+
+	return view.Model{
+		Children: l.Views(),
+		Layouter: l,
+		Painter:  &paint.Style{BackgroundColor: colornames.Black},
+	}
+}
@@ -0,0 +1,118 @@
+package view
+
+import (
+	"golang.org/x/image/colornames"
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/layout/constraint"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/text"
+	"gomatcha.io/matcha/view"
+	"gomatcha.io/matcha/view/blurview"
+)
+
+func init() {
+	bridge.RegisterFunc("gomatcha.io/matcha/examples/view NewBlurView", func() view.View {
+		return NewBlurView()
+	})
+}
+
+var blurStyles = []struct {
+	title string
+	style blurview.Style
+}{
+	{"Regular", blurview.StyleRegular},
+	{"Light", blurview.StyleLight},
+	{"Dark", blurview.StyleDark},
+	{"Thin", blurview.StyleThin},
+	{"Thick", blurview.StyleThick},
+}
+
+type BlurView struct {
+	view.Embed
+	style    int
+	vibrancy bool
+}
+
+func NewBlurView() *BlurView {
+	return &BlurView{}
+}
+
+func (v *BlurView) Build(ctx view.Context) view.Model {
+	// Colored stripes to blur.
+	cl := &constraint.Layouter{}
+	stripes := []paint.Style{
+		{BackgroundColor: colornames.Red},
+		{BackgroundColor: colornames.Orange},
+		{BackgroundColor: colornames.Yellow},
+		{BackgroundColor: colornames.Green},
+		{BackgroundColor: colornames.Blue},
+	}
+	for idx := range stripes {
+		stripe := view.NewBasicView()
+		stripe.Painter = &stripes[idx]
+		x := float64(idx) * 40
+		cl.Add(stripe, func(s *constraint.Solver) {
+			s.Top(0)
+			s.Left(x)
+			s.Width(20)
+			s.HeightEqual(cl.Height())
+		})
+	}
+	content := view.NewBasicView()
+	content.Children = cl.Views()
+	content.Layouter = cl
+
+	ol := &constraint.Layouter{}
+	label := view.NewTextView()
+	label.String = blurStyles[v.style].title
+	label.Style.SetFont(text.DefaultBoldFont(24))
+	ol.Add(label, func(s *constraint.Solver) {
+		s.CenterXEqual(ol.CenterX())
+		s.CenterYEqual(ol.CenterY())
+	})
+	overlay := view.NewBasicView()
+	overlay.Children = ol.Views()
+	overlay.Layouter = ol
+
+	blur := blurview.New()
+	blur.Content = content
+	blur.Overlay = overlay
+	blur.Style = blurStyles[v.style].style
+	blur.Vibrancy = v.vibrancy
+
+	l := &constraint.Layouter{}
+	g := l.Add(blur, func(s *constraint.Solver) {
+		s.Top(20)
+		s.LeftEqual(l.Left().Add(20))
+		s.RightEqual(l.Right().Add(-20))
+		s.Height(200)
+	})
+
+	style := view.NewButton()
+	style.String = "Next Style"
+	style.OnPress = func() {
+		v.style = (v.style + 1) % len(blurStyles)
+		v.Signal()
+	}
+	g = l.Add(style, func(s *constraint.Solver) {
+		s.TopEqual(g.Bottom().Add(20))
+		s.Left(20)
+	})
+
+	vibrancy := view.NewButton()
+	vibrancy.String = "Toggle Vibrancy"
+	vibrancy.OnPress = func() {
+		v.vibrancy = !v.vibrancy
+		v.Signal()
+	}
+	l.Add(vibrancy, func(s *constraint.Solver) {
+		s.TopEqual(g.Bottom().Add(20))
+		s.Left(20)
+	})
+
+	return view.Model{
+		Children: l.Views(),
+		Layouter: l,
+		Painter:  &paint.Style{BackgroundColor: colornames.White},
+	}
+}
@@ -0,0 +1,101 @@
+package view
+
+import (
+	"fmt"
+
+	"golang.org/x/image/colornames"
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/layout/constraint"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+	"gomatcha.io/matcha/view/camera"
+)
+
+func init() {
+	bridge.RegisterFunc("gomatcha.io/matcha/examples/view NewCameraView", func() view.View {
+		return NewCameraView()
+	})
+}
+
+type CameraView struct {
+	view.Embed
+	camera *camera.View
+	frames int
+}
+
+func NewCameraView() *CameraView {
+	v := &CameraView{}
+	v.camera = camera.New()
+	v.camera.OnFrame = func(f *camera.Frame) {
+		// Called on a background thread.
+		v.frames++
+		if v.frames%30 != 0 {
+			return
+		}
+		sum := 0
+		for _, i := range f.Pix[:f.Width*f.Height] {
+			sum += int(i)
+		}
+		fmt.Println("frame", f.Width, f.Height, "brightness", sum/(f.Width*f.Height))
+	}
+	v.camera.OnPhoto = func(jpeg []byte) {
+		fmt.Println("photo", len(jpeg))
+	}
+	v.camera.OnError = func(err error) {
+		fmt.Println("camera error", err)
+	}
+	return v
+}
+
+func (v *CameraView) Build(ctx view.Context) view.Model {
+	l := &constraint.Layouter{}
+
+	l.Add(v.camera, func(s *constraint.Solver) {
+		s.TopEqual(l.Top())
+		s.LeftEqual(l.Left())
+		s.WidthEqual(l.Width())
+		s.HeightEqual(l.Height())
+	})
+
+	capture := view.NewButton()
+	capture.String = "Capture"
+	capture.OnPress = func() {
+		v.camera.CapturePhoto()
+	}
+	captureGuide := l.Add(capture, func(s *constraint.Solver) {
+		s.BottomEqual(l.Bottom().Add(-20))
+		s.LeftEqual(l.Left().Add(20))
+	})
+
+	torch := view.NewButton()
+	torch.String = "Torch"
+	torch.OnPress = func() {
+		v.camera.Torch = !v.camera.Torch
+		v.camera.Signal()
+	}
+	l.Add(torch, func(s *constraint.Solver) {
+		s.BottomEqual(captureGuide.Bottom())
+		s.RightEqual(l.Right().Add(-20))
+	})
+
+	flip := view.NewButton()
+	flip.String = "Flip"
+	flip.OnPress = func() {
+		if v.camera.Position == camera.PositionBack {
+			v.camera.Position = camera.PositionFront
+		} else {
+			v.camera.Position = camera.PositionBack
+		}
+		v.camera.Signal()
+	}
+	l.Add(flip, func(s *constraint.Solver) {
+		s.BottomEqual(captureGuide.Bottom())
+		s.CenterXEqual(l.CenterX())
+	})
+
+	return view.Model{
+		Children: l.Views(),
+		Layouter: l,
+		Painter:  &paint.Style{BackgroundColor: colornames.Black},
+	}
+}
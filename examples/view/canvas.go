@@ -0,0 +1,168 @@
+package view
+
+import (
+	"fmt"
+	"math"
+
+	"golang.org/x/image/colornames"
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/layout/constraint"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/pointer"
+	"gomatcha.io/matcha/text"
+	"gomatcha.io/matcha/view"
+	"gomatcha.io/matcha/view/canvas"
+)
+
+func init() {
+	bridge.RegisterFunc("gomatcha.io/matcha/examples/view NewCanvasView", func() view.View {
+		return NewCanvasView()
+	})
+}
+
+var sparklineValues = []float64{0.2, 0.5, 0.4, 0.8, 0.6, 0.9, 0.3, 0.7}
+
+type CanvasView struct {
+	view.Embed
+	gauge float64
+}
+
+func NewCanvasView() *CanvasView {
+	return &CanvasView{gauge: 0.6}
+}
+
+func (v *CanvasView) Build(ctx view.Context) view.Model {
+	l := &constraint.Layouter{}
+
+	sparkline := canvas.New()
+	sparkline.Draw = func(c *canvas.Context) {
+		size := c.Size()
+		step := size.X / float64(len(sparklineValues)-1)
+		for i, value := range sparklineValues {
+			if i == 0 {
+				c.MoveTo(0, size.Y*(1-value))
+			} else {
+				c.LineTo(float64(i)*step, size.Y*(1-value))
+			}
+		}
+		c.SetStrokeColor(colornames.Blue)
+		c.SetLineWidth(2)
+		c.SetLineJoin(canvas.LineJoinRound)
+		c.Stroke()
+	}
+	g := l.Add(sparkline, func(s *constraint.Solver) {
+		s.Top(20)
+		s.LeftEqual(l.Left().Add(20))
+		s.RightEqual(l.Right().Add(-20))
+		s.Height(60)
+	})
+
+	gauge := canvas.New()
+	gauge.Draw = func(c *canvas.Context) {
+		size := c.Size()
+		center := layout.Pt(size.X/2, size.Y-10)
+		radius := math.Min(size.X/2, size.Y) - 20
+		c.SetLineWidth(12)
+		c.SetLineCap(canvas.LineCapRound)
+		c.SetStrokeColor(colornames.Lightgray)
+		c.Arc(center, radius, math.Pi, 2*math.Pi, false)
+		c.Stroke()
+		c.BeginPath()
+		c.SetStrokeColor(colornames.Orange)
+		c.Arc(center, radius, math.Pi, math.Pi*(1+v.gauge), false)
+		c.Stroke()
+		c.SetFont(text.DefaultBoldFont(20))
+		c.FillText(fmt.Sprintf("%d%%", int(v.gauge*100)), layout.Pt(center.X-20, center.Y-40))
+	}
+	g = l.Add(gauge, func(s *constraint.Solver) {
+		s.TopEqual(g.Bottom().Add(20))
+		s.LeftEqual(l.Left().Add(20))
+		s.RightEqual(l.Right().Add(-20))
+		s.Height(120)
+	})
+
+	button := view.NewButton()
+	button.String = "Change Gauge"
+	button.OnPress = func() {
+		v.gauge = math.Mod(v.gauge+0.15, 1)
+		v.Signal()
+	}
+	g = l.Add(button, func(s *constraint.Solver) {
+		s.TopEqual(g.Bottom().Add(20))
+		s.Left(20)
+	})
+
+	l.Add(NewSignatureView(), func(s *constraint.Solver) {
+		s.TopEqual(g.Bottom().Add(20))
+		s.LeftEqual(l.Left().Add(20))
+		s.RightEqual(l.Right().Add(-20))
+		s.Height(200)
+	})
+
+	return view.Model{
+		Children: l.Views(),
+		Layouter: l,
+		Painter:  &paint.Style{BackgroundColor: colornames.White},
+	}
+}
+
+// SignatureView draws the strokes of the user's finger.
+type SignatureView struct {
+	view.Embed
+	strokes [][]layout.Point
+}
+
+func NewSignatureView() *SignatureView {
+	return &SignatureView{}
+}
+
+func (v *SignatureView) Build(ctx view.Context) view.Model {
+	l := &constraint.Layouter{}
+
+	c := canvas.New()
+	c.Draw = func(c *canvas.Context) {
+		c.SetStrokeColor(colornames.Black)
+		c.SetLineWidth(3)
+		c.SetLineCap(canvas.LineCapRound)
+		c.SetLineJoin(canvas.LineJoinRound)
+		for _, stroke := range v.strokes {
+			for i, p := range stroke {
+				if i == 0 {
+					c.MoveTo(p.X, p.Y)
+				} else {
+					c.LineTo(p.X, p.Y)
+				}
+			}
+		}
+		c.Stroke()
+	}
+	l.Add(c, func(s *constraint.Solver) {
+		s.TopEqual(l.Top())
+		s.BottomEqual(l.Bottom())
+		s.LeftEqual(l.Left())
+		s.RightEqual(l.Right())
+	})
+
+	return view.Model{
+		Children: l.Views(),
+		Layouter: l,
+		Painter: &paint.Style{
+			BorderColor: colornames.Lightgray,
+			BorderWidth: 1,
+		},
+		Options: []view.Option{
+			pointer.GestureList{&pointer.PressGesture{
+				OnEvent: func(e *pointer.PressEvent) {
+					if e.Kind == pointer.EventKindPossible {
+						v.strokes = append(v.strokes, []layout.Point{e.Position})
+					} else if len(v.strokes) > 0 {
+						last := len(v.strokes) - 1
+						v.strokes[last] = append(v.strokes[last], e.Position)
+					}
+					v.Signal()
+				},
+			}},
+		},
+	}
+}
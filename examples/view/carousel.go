@@ -0,0 +1,117 @@
+package view
+
+import (
+	"fmt"
+
+	"golang.org/x/image/colornames"
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/layout/constraint"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+	"gomatcha.io/matcha/view/pagerview"
+)
+
+func init() {
+	bridge.RegisterFunc("gomatcha.io/matcha/examples/view NewCarouselView", func() view.View {
+		return NewCarouselView()
+	})
+}
+
+var carouselColors = []paint.Style{
+	{BackgroundColor: colornames.Lightcoral},
+	{BackgroundColor: colornames.Lightskyblue},
+	{BackgroundColor: colornames.Palegreen},
+	{BackgroundColor: colornames.Khaki},
+	{BackgroundColor: colornames.Plum},
+}
+
+type CarouselView struct {
+	view.Embed
+	current *comm.IntValue
+}
+
+func NewCarouselView() *CarouselView {
+	return &CarouselView{current: &comm.IntValue{}}
+}
+
+func (v *CarouselView) Build(ctx view.Context) view.Model {
+	l := &constraint.Layouter{}
+
+	pager := pagerview.New()
+	pager.Count = len(carouselColors)
+	pager.Current = v.current
+	pager.Page = func(index int) view.View {
+		return NewCarouselPage(index)
+	}
+	g := l.Add(pager, func(s *constraint.Solver) {
+		s.Top(0)
+		s.Left(0)
+		s.WidthEqual(l.Width())
+		s.Height(300)
+	})
+
+	dots := pagerview.NewDots()
+	dots.Count = len(carouselColors)
+	dots.Current = v.current
+	g = l.Add(dots, func(s *constraint.Solver) {
+		s.TopEqual(g.Bottom().Add(10))
+		s.CenterXEqual(l.CenterX())
+	})
+
+	next := view.NewButton()
+	next.String = "Next Page"
+	next.OnPress = func() {
+		v.current.SetValue((v.current.Value() + 1) % len(carouselColors))
+	}
+	l.Add(next, func(s *constraint.Solver) {
+		s.TopEqual(g.Bottom().Add(20))
+		s.Left(20)
+	})
+
+	return view.Model{
+		Children: l.Views(),
+		Layouter: l,
+		Painter:  &paint.Style{BackgroundColor: colornames.White},
+	}
+}
+
+// CarouselPage counts how many times it is tapped, to show that pages keep their
+// state while they are near the current page.
+type CarouselPage struct {
+	view.Embed
+	index int
+	taps  int
+}
+
+func NewCarouselPage(index int) *CarouselPage {
+	return &CarouselPage{index: index}
+}
+
+func (v *CarouselPage) Build(ctx view.Context) view.Model {
+	l := &constraint.Layouter{}
+
+	label := view.NewTextView()
+	label.String = fmt.Sprintf("Page %v", v.index+1)
+	g := l.Add(label, func(s *constraint.Solver) {
+		s.CenterXEqual(l.CenterX())
+		s.CenterYEqual(l.CenterY().Add(-20))
+	})
+
+	button := view.NewButton()
+	button.String = fmt.Sprintf("Tapped %v times", v.taps)
+	button.OnPress = func() {
+		v.taps += 1
+		v.Signal()
+	}
+	l.Add(button, func(s *constraint.Solver) {
+		s.TopEqual(g.Bottom().Add(10))
+		s.CenterXEqual(l.CenterX())
+	})
+
+	return view.Model{
+		Children: l.Views(),
+		Layouter: l,
+		Painter:  &carouselColors[v.index],
+	}
+}
@@ -0,0 +1,102 @@
+package view
+
+import (
+	"fmt"
+	"math/rand"
+
+	"golang.org/x/image/colornames"
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/layout/constraint"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+	"gomatcha.io/matcha/view/chart"
+)
+
+func init() {
+	bridge.RegisterFunc("gomatcha.io/matcha/examples/view NewChartView", func() view.View {
+		return NewChartView()
+	})
+}
+
+type ChartView struct {
+	view.Embed
+	data     *chart.Value
+	kind     chart.Kind
+	selected string
+}
+
+func NewChartView() *ChartView {
+	v := &ChartView{data: &chart.Value{}}
+	v.randomize()
+	return v
+}
+
+func (v *ChartView) randomize() {
+	values := func() []float64 {
+		vs := make([]float64, 5)
+		for i := range vs {
+			vs[i] = float64(rand.Intn(100))
+		}
+		return vs
+	}
+	v.data.SetValue(chart.Data{
+		Labels: []string{"Mon", "Tue", "Wed", "Thu", "Fri"},
+		Series: []chart.Series{
+			{Name: "Walk", Values: values()},
+			{Name: "Run", Values: values()},
+		},
+	})
+}
+
+func (v *ChartView) Build(ctx view.Context) view.Model {
+	l := &constraint.Layouter{}
+
+	c := chart.New()
+	c.Kind = v.kind
+	c.Data = v.data
+	c.OnSelect = func(series, index int) {
+		data := v.data.Value()
+		v.selected = fmt.Sprintf("%v %v: %v", data.Series[series].Name, data.Labels[index], data.Series[series].Values[index])
+		v.Signal()
+	}
+	g := l.Add(c, func(s *constraint.Solver) {
+		s.Top(20)
+		s.LeftEqual(l.Left().Add(20))
+		s.RightEqual(l.Right().Add(-20))
+		s.Height(240)
+	})
+
+	label := view.NewTextView()
+	label.String = v.selected
+	g = l.Add(label, func(s *constraint.Solver) {
+		s.TopEqual(g.Bottom().Add(20))
+		s.Left(20)
+	})
+
+	kind := view.NewButton()
+	kind.String = "Next Kind"
+	kind.OnPress = func() {
+		v.kind = (v.kind + 1) % 3
+		v.Signal()
+	}
+	g = l.Add(kind, func(s *constraint.Solver) {
+		s.TopEqual(g.Bottom().Add(20))
+		s.Left(20)
+	})
+
+	randomize := view.NewButton()
+	randomize.String = "Randomize"
+	randomize.OnPress = func() {
+		v.randomize()
+	}
+	l.Add(randomize, func(s *constraint.Solver) {
+		s.TopEqual(g.Bottom().Add(20))
+		s.Left(20)
+	})
+
+	return view.Model{
+		Children: l.Views(),
+		Layouter: l,
+		Painter:  &paint.Style{BackgroundColor: colornames.White},
+	}
+}
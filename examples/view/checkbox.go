@@ -0,0 +1,121 @@
+package view
+
+import (
+	"fmt"
+
+	"golang.org/x/image/colornames"
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/layout/constraint"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+	"gomatcha.io/matcha/view/checkbox"
+	"gomatcha.io/matcha/view/radiogroup"
+)
+
+func init() {
+	bridge.RegisterFunc("gomatcha.io/matcha/examples/view NewCheckboxView", func() view.View {
+		return NewCheckboxView()
+	})
+}
+
+type CheckboxView struct {
+	view.Embed
+	news    *comm.BoolValue
+	updates *comm.BoolValue
+	mode    *comm.IntValue
+}
+
+func NewCheckboxView() *CheckboxView {
+	v := &CheckboxView{
+		news:    &comm.BoolValue{},
+		updates: &comm.BoolValue{},
+		mode:    &comm.IntValue{},
+	}
+	v.updates.SetValue(true)
+	v.mode.SetValue(-1)
+	return v
+}
+
+func (v *CheckboxView) Lifecycle(from, to view.Stage) {
+	if view.EntersStage(from, to, view.StageMounted) {
+		v.Subscribe(v.news)
+		v.Subscribe(v.updates)
+		v.Subscribe(v.mode)
+	} else if view.ExitsStage(from, to, view.StageMounted) {
+		v.Unsubscribe(v.news)
+		v.Unsubscribe(v.updates)
+		v.Unsubscribe(v.mode)
+	}
+}
+
+func (v *CheckboxView) Build(ctx view.Context) view.Model {
+	l := &constraint.Layouter{}
+
+	news := checkbox.New()
+	news.Title = "Newsletter"
+	news.Value = v.news
+	g := l.Add(news, func(s *constraint.Solver) {
+		s.Top(20)
+		s.Left(20)
+		s.RightEqual(l.Right().Add(-20))
+	})
+
+	updates := checkbox.New()
+	updates.Title = "Product updates"
+	updates.Value = v.updates
+	updates.Color = colornames.Orange
+	g = l.Add(updates, func(s *constraint.Solver) {
+		s.TopEqual(g.Bottom())
+		s.Left(20)
+		s.RightEqual(l.Right().Add(-20))
+	})
+
+	disabled := checkbox.New()
+	disabled.Title = "Disabled"
+	disabled.Enabled = false
+	g = l.Add(disabled, func(s *constraint.Solver) {
+		s.TopEqual(g.Bottom())
+		s.Left(20)
+		s.RightEqual(l.Right().Add(-20))
+	})
+
+	modes := []string{"Walk", "Bike", "Drive"}
+	group := radiogroup.New()
+	group.Options = modes
+	group.Selected = v.mode
+	g = l.Add(group, func(s *constraint.Solver) {
+		s.TopEqual(g.Bottom().Add(20))
+		s.Left(20)
+		s.RightEqual(l.Right().Add(-20))
+	})
+
+	mode := "none"
+	if i := v.mode.Value(); i >= 0 && i < len(modes) {
+		mode = modes[i]
+	}
+	label := view.NewTextView()
+	label.String = fmt.Sprintf("Newsletter: %v, Updates: %v, Mode: %v", v.news.Value(), v.updates.Value(), mode)
+	g = l.Add(label, func(s *constraint.Solver) {
+		s.TopEqual(g.Bottom().Add(20))
+		s.Left(20)
+	})
+
+	reset := view.NewButton()
+	reset.String = "Reset"
+	reset.OnPress = func() {
+		v.news.SetValue(false)
+		v.updates.SetValue(true)
+		v.mode.SetValue(-1)
+	}
+	l.Add(reset, func(s *constraint.Solver) {
+		s.TopEqual(g.Bottom().Add(20))
+		s.Left(20)
+	})
+
+	return view.Model{
+		Children: l.Views(),
+		Layouter: l,
+		Painter:  &paint.Style{BackgroundColor: colornames.White},
+	}
+}
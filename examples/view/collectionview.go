@@ -0,0 +1,83 @@
+package view
+
+import (
+	"fmt"
+	"strconv"
+
+	"golang.org/x/image/colornames"
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/layout/constraint"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+	"gomatcha.io/matcha/view/collectionview"
+)
+
+func init() {
+	bridge.RegisterFunc("gomatcha.io/matcha/examples/view NewCollectionView", func() view.View {
+		return NewCollectionView()
+	})
+}
+
+type CollectionView struct {
+	view.Embed
+}
+
+func NewCollectionView() *CollectionView {
+	return &CollectionView{}
+}
+
+func (v *CollectionView) Build(ctx view.Context) view.Model {
+	l := &constraint.Layouter{}
+
+	grid := collectionview.New()
+	grid.DataSource = tiles(1000)
+	grid.Columns = 3
+	grid.Spacing = 2
+	grid.OnSelect = func(index int) {
+		fmt.Println("selected", index)
+	}
+	l.Add(grid, func(s *constraint.Solver) {
+		s.TopEqual(l.Top())
+		s.LeftEqual(l.Left())
+		s.WidthEqual(l.Width())
+		s.HeightEqual(l.Height())
+	})
+
+	return view.Model{
+		Children: l.Views(),
+		Layouter: l,
+		Painter:  &paint.Style{BackgroundColor: colornames.White},
+	}
+}
+
+// tiles is a data source whose every seventh item spans the whole grid.
+type tiles int
+
+func (t tiles) Count() int {
+	return int(t)
+}
+
+func (t tiles) ReuseId(index int) string {
+	if index%7 == 0 {
+		return "banner"
+	}
+	return "tile"
+}
+
+func (t tiles) View(index int) view.View {
+	label := view.NewTextView()
+	label.String = strconv.Itoa(index)
+	label.PaintStyle = &paint.Style{BackgroundColor: colornames.Lightblue}
+	if index%7 == 0 {
+		label.String = "Banner " + strconv.Itoa(index/7)
+		label.PaintStyle = &paint.Style{BackgroundColor: colornames.Pink}
+	}
+	return label
+}
+
+func (t tiles) Span(index int) int {
+	if index%7 == 0 {
+		return 3
+	}
+	return 1
+}
@@ -0,0 +1,67 @@
+package view
+
+import (
+	"fmt"
+	"image/color"
+
+	"golang.org/x/image/colornames"
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/layout/constraint"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+	"gomatcha.io/matcha/view/contextmenu"
+)
+
+func init() {
+	bridge.RegisterFunc("gomatcha.io/matcha/examples/view NewContextMenuView", func() view.View {
+		return NewContextMenuView()
+	})
+}
+
+type ContextMenuView struct {
+	view.Embed
+	color color.Color
+}
+
+func NewContextMenuView() *ContextMenuView {
+	return &ContextMenuView{color: colornames.Blue}
+}
+
+func (v *ContextMenuView) setColor(c color.Color) func() {
+	return func() {
+		v.color = c
+		v.Signal()
+	}
+}
+
+func (v *ContextMenuView) Build(ctx view.Context) view.Model {
+	l := &constraint.Layouter{}
+
+	box := view.NewBasicView()
+	box.Painter = &paint.Style{BackgroundColor: v.color}
+	menu := &contextmenu.Menu{
+		Title: "Box",
+		Items: []*contextmenu.Item{
+			{Title: "Color", Items: []*contextmenu.Item{
+				{Title: "Blue", OnSelect: v.setColor(colornames.Blue)},
+				{Title: "Green", OnSelect: v.setColor(colornames.Green)},
+				{Title: "Orange", OnSelect: v.setColor(colornames.Orange)},
+			}},
+			{Title: "Copy", OnSelect: func() { fmt.Println("Copy") }},
+			{Title: "Paste", Disabled: true},
+			{Title: "Delete", Destructive: true, OnSelect: v.setColor(colornames.Lightgray)},
+		},
+	}
+	l.Add(view.WithOptions(box, menu), func(s *constraint.Solver) {
+		s.Top(100)
+		s.Left(100)
+		s.Width(150)
+		s.Height(150)
+	})
+
+	return view.Model{
+		Children: l.Views(),
+		Layouter: l,
+		Painter:  &paint.Style{BackgroundColor: colornames.White},
+	}
+}
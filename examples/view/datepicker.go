@@ -0,0 +1,69 @@
+package view
+
+import (
+	"time"
+
+	"golang.org/x/image/colornames"
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/layout/constraint"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+	"gomatcha.io/matcha/view/datepicker"
+)
+
+func init() {
+	bridge.RegisterFunc("gomatcha.io/matcha/examples/view NewDatePickerView", func() view.View {
+		return NewDatePickerView()
+	})
+}
+
+type DatePickerView struct {
+	view.Embed
+	value comm.TimeValue
+}
+
+func NewDatePickerView() *DatePickerView {
+	v := &DatePickerView{}
+	v.value.SetValue(time.Now())
+	return v
+}
+
+func (v *DatePickerView) Lifecycle(from, to view.Stage) {
+	if view.EntersStage(from, to, view.StageMounted) {
+		v.Subscribe(&v.value)
+	} else if view.ExitsStage(from, to, view.StageMounted) {
+		v.Unsubscribe(&v.value)
+	}
+}
+
+func (v *DatePickerView) Build(ctx view.Context) view.Model {
+	l := &constraint.Layouter{}
+
+	label := view.NewTextView()
+	label.String = v.value.Value().Format("Mon Jan 2 2006 15:04")
+	g := l.Add(label, func(s *constraint.Solver) {
+		s.Top(20)
+		s.Left(20)
+	})
+
+	modes := []datepicker.Mode{datepicker.ModeDate, datepicker.ModeTime, datepicker.ModeDateTime}
+	for _, i := range modes {
+		picker := datepicker.New()
+		picker.Mode = i
+		picker.Value = &v.value
+		picker.Min = time.Now().AddDate(0, -1, 0)
+		picker.Max = time.Now().AddDate(1, 0, 0)
+		g = l.Add(picker, func(s *constraint.Solver) {
+			s.TopEqual(g.Bottom().Add(20))
+			s.LeftEqual(l.Left().Add(20))
+			s.RightEqual(l.Right().Add(-20))
+		})
+	}
+
+	return view.Model{
+		Children: l.Views(),
+		Layouter: l,
+		Painter:  &paint.Style{BackgroundColor: colornames.White},
+	}
+}
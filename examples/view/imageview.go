@@ -4,6 +4,7 @@ package view
 import (
 	_ "image/jpeg"
 	_ "image/png"
+	"strings"
 
 	"golang.org/x/image/colornames"
 	"gomatcha.io/matcha/application"
@@ -11,9 +12,16 @@ import (
 	"gomatcha.io/matcha/layout/constraint"
 	"gomatcha.io/matcha/paint"
 	"gomatcha.io/matcha/pointer"
+	"gomatcha.io/matcha/svg"
 	"gomatcha.io/matcha/view"
 )
 
+const starSVG = `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24">
+	<path d="M12 17.27L18.18 21l-1.64-7.03L22 9.24l-7.19-.61L12 2 9.19 8.63 2 9.24l5.46 4.73L5.82 21z"/>
+</svg>`
+
+var star, _ = svg.Decode(strings.NewReader(starSVG))
+
 func init() {
 	bridge.RegisterFunc("gomatcha.io/matcha/examples/view NewImageView", func() view.View {
 		return NewImageView()
@@ -70,6 +78,15 @@ func (v *ImageView) Build(ctx view.Context) view.Model {
 		s.HeightEqual(g2.Height())
 	})
 
+	chl4 := view.NewImageView()
+	chl4.Image = star.Sized(48, 48)
+	chl4.ResizeMode = view.ImageResizeModeCenter
+	chl4.ImageTint = colornames.Orange
+	l.Add(chl4, func(s *constraint.Solver) {
+		s.TopEqual(g1.Top())
+		s.Left(20)
+	})
+
 	tap := &pointer.TapGesture{
 		Count: 1,
 		OnEvent: func(e *pointer.TapEvent) {
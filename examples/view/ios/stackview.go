@@ -1,6 +1,7 @@
 package ios
 
 import (
+	"fmt"
 	"image/color"
 
 	"golang.org/x/image/colornames"
@@ -102,15 +103,31 @@ func (v *StackChild) Build(ctx view.Context) view.Model {
 	leftView.Painter = &paint.Style{BackgroundColor: colornames.Yellow}
 	leftView.Layouter = l3
 
+	share := ios.NewStackBarItem()
+	share.Title = "Share"
+	share.OnPress = func() {
+		fmt.Println("OnPress Share")
+	}
+
+	settings := ios.NewStackBarItem()
+	settings.Title = "Settings"
+	settings.Overflow = true
+	settings.OnPress = func() {
+		fmt.Println("OnPress Settings")
+	}
+
 	return view.Model{
 		Painter: &paint.Style{BackgroundColor: v.Color},
 		Options: []view.Option{
 			pointer.GestureList{tap},
 			&ios.StackBar{
-				Title:      "Title",
-				TitleView:  titleView,
-				RightViews: []view.View{rightView},
-				LeftViews:  []view.View{leftView},
+				Title:           "Title",
+				TitleView:       titleView,
+				RightViews:      []view.View{rightView},
+				LeftViews:       []view.View{leftView},
+				Items:           []*ios.StackBarItem{share, settings},
+				TitleMode:       ios.TitleModeCollapsing,
+				ScrollEdgeColor: color.Transparent,
 			},
 		},
 	}
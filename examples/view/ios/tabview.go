@@ -5,6 +5,7 @@ import (
 
 	"golang.org/x/image/colornames"
 	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/comm"
 	"gomatcha.io/matcha/paint"
 	"gomatcha.io/matcha/pointer"
 	"gomatcha.io/matcha/view"
@@ -27,16 +28,23 @@ func init() {
 		view2 := NewTabChild(app)
 		view2.Color = colornames.Red
 		view2.button = &ios.TabButton{
-			Title: "Title 2",
+			Title:      "Title 2",
+			BadgeCount: &app.count,
 			// Icon:         env.MustLoadImage("TabMap"),
 			// SelectedIcon: env.MustLoadImage("TabMapFilled"),
 		}
 
 		view3 := NewTabChild(app)
 		view3.Color = colornames.Yellow
+		view3.button.BadgeDot = true
 
 		view4 := NewTabChild(app)
 		view4.Color = colornames.Green
+		view4.button.Visible = &app.showLast
+
+		view5 := NewTabChild(app)
+		view5.button.Title = "Disabled"
+		view5.button.Disabled = true
 
 		v := ios.NewTabView()
 		v.BarColor = colornames.White
@@ -49,13 +57,16 @@ func init() {
 			view2,
 			view3,
 			view4,
+			view5,
 		)
 		return v
 	})
 }
 
 type TabApp struct {
-	tabs *ios.Tabs
+	tabs     *ios.Tabs
+	count    comm.IntValue
+	showLast comm.BoolValue
 }
 
 type TabChild struct {
@@ -81,6 +92,8 @@ func (v *TabChild) Build(ctx view.Context) view.Model {
 		Count: 1,
 		OnEvent: func(e *pointer.TapEvent) {
 			v.app.tabs.SetSelectedIndex(0)
+			v.app.count.SetValue(v.app.count.Value() + 1)
+			v.app.showLast.SetValue(!v.app.showLast.Value())
 			v.button.Title = "Updated"
 			v.Signal()
 		},
@@ -0,0 +1,89 @@
+package view
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"golang.org/x/image/colornames"
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/layout/constraint"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+	"gomatcha.io/matcha/view/listview"
+)
+
+func init() {
+	bridge.RegisterFunc("gomatcha.io/matcha/examples/view NewListView", func() view.View {
+		return NewListView()
+	})
+}
+
+type ListView struct {
+	view.Embed
+	refreshing comm.BoolValue
+}
+
+func NewListView() *ListView {
+	return &ListView{}
+}
+
+func (v *ListView) Build(ctx view.Context) view.Model {
+	l := &constraint.Layouter{}
+
+	list := listview.New()
+	list.DataSource = numbers(10000)
+	list.OnSelect = func(index int) {
+		fmt.Println("selected", index)
+	}
+	list.Refreshing = &v.refreshing
+	list.OnRefresh = func() {
+		time.AfterFunc(time.Second*2, func() {
+			v.refreshing.SetValue(false)
+		})
+	}
+	l.Add(list, func(s *constraint.Solver) {
+		s.TopEqual(l.Top())
+		s.LeftEqual(l.Left())
+		s.WidthEqual(l.Width())
+		s.HeightEqual(l.Height())
+	})
+
+	return view.Model{
+		Children: l.Views(),
+		Layouter: l,
+		Painter:  &paint.Style{BackgroundColor: colornames.White},
+	}
+}
+
+// numbers is a data source with rows of two kinds, to exercise reuse.
+type numbers int
+
+func (n numbers) Count() int {
+	return int(n)
+}
+
+func (n numbers) ReuseId(index int) string {
+	if index%10 == 0 {
+		return "header"
+	}
+	return "row"
+}
+
+func (n numbers) View(index int) view.View {
+	label := view.NewTextView()
+	label.String = "Row " + strconv.Itoa(index)
+	if index%10 == 0 {
+		label.String = "Rows " + strconv.Itoa(index) + "-" + strconv.Itoa(index+9)
+		label.PaintStyle = &paint.Style{BackgroundColor: colornames.Lightgray}
+	}
+	return label
+}
+
+func (n numbers) Height(index int, width float64) float64 {
+	if index%10 == 0 {
+		return 28
+	}
+	return 44
+}
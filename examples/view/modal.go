@@ -0,0 +1,104 @@
+package view
+
+import (
+	"fmt"
+
+	"golang.org/x/image/colornames"
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/layout/constraint"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+	"gomatcha.io/matcha/view/modal"
+)
+
+func init() {
+	bridge.RegisterFunc("gomatcha.io/matcha/examples/view NewModalView", func() view.View {
+		return NewModalView()
+	})
+}
+
+type ModalView struct {
+	view.Embed
+	style     modal.Style
+	presented comm.BoolValue
+}
+
+func NewModalView() *ModalView {
+	return &ModalView{}
+}
+
+func (v *ModalView) present(style modal.Style) func() {
+	return func() {
+		v.style = style
+		v.Signal()
+		v.presented.SetValue(true)
+	}
+}
+
+func (v *ModalView) Build(ctx view.Context) view.Model {
+	l := &constraint.Layouter{}
+	styles := []struct {
+		title string
+		style modal.Style
+	}{
+		{"Full Screen", modal.StyleFullScreen},
+		{"Page Sheet", modal.StylePageSheet},
+		{"Bottom Sheet", modal.StyleBottomSheet},
+	}
+	top := l.Top()
+	for _, i := range styles {
+		button := view.NewButton()
+		button.String = i.title
+		button.OnPress = v.present(i.style)
+		g := l.Add(button, func(s *constraint.Solver) {
+			s.TopEqual(top.Add(20))
+			s.Left(20)
+		})
+		top = g.Bottom()
+	}
+
+	dl := &constraint.Layouter{}
+	dismiss := view.NewButton()
+	dismiss.String = "Dismiss"
+	dismiss.OnPress = func() {
+		v.presented.SetValue(false)
+	}
+	dl.Add(dismiss, func(s *constraint.Solver) {
+		s.Top(20)
+		s.CenterXEqual(dl.CenterX())
+	})
+	details := view.NewBasicView()
+	details.Children = dl.Views()
+	details.Layouter = dl
+	details.Painter = &paint.Style{BackgroundColor: colornames.Lightblue}
+
+	content := view.NewBasicView()
+	content.Children = l.Views()
+	content.Layouter = l
+	content.Painter = &paint.Style{BackgroundColor: colornames.White}
+
+	m := modal.New()
+	m.Content = content
+	m.Modal = details
+	m.Style = v.style
+	m.Presented = &v.presented
+	m.OnPresent = func() {
+		fmt.Println("presented")
+	}
+	m.OnDismiss = func() {
+		fmt.Println("dismissed")
+	}
+
+	ml := &constraint.Layouter{}
+	ml.Add(m, func(s *constraint.Solver) {
+		s.TopEqual(ml.Top())
+		s.LeftEqual(ml.Left())
+		s.WidthEqual(ml.Width())
+		s.HeightEqual(ml.Height())
+	})
+	return view.Model{
+		Children: ml.Views(),
+		Layouter: ml,
+	}
+}
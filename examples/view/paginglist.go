@@ -0,0 +1,73 @@
+package view
+
+import (
+	"math/rand"
+	"strconv"
+	"time"
+
+	"golang.org/x/image/colornames"
+	"gomatcha.io/matcha"
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/layout/constraint"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+	"gomatcha.io/matcha/view/listview"
+)
+
+func init() {
+	bridge.RegisterFunc("gomatcha.io/matcha/examples/view NewPagingListView", func() view.View {
+		return NewPagingListView()
+	})
+}
+
+// PagingListView loads rows 20 at a time, failing every few pages to show the
+// retry footer.
+type PagingListView struct {
+	view.Embed
+	items  words
+	paging listview.PageValue
+}
+
+func NewPagingListView() *PagingListView {
+	return &PagingListView{}
+}
+
+func (v *PagingListView) Build(ctx view.Context) view.Model {
+	l := &constraint.Layouter{}
+
+	list := listview.New()
+	list.DataSource = v.items
+	list.Paging = &v.paging
+	list.OnReachedEnd = func() {
+		time.AfterFunc(time.Second, func() {
+			matcha.MainLocker.Lock()
+			defer matcha.MainLocker.Unlock()
+
+			if rand.Intn(4) == 0 {
+				v.paging.SetValue(listview.PageFailed)
+				return
+			}
+			for i := 0; i < 20; i++ {
+				v.items = append(v.items, "Item "+strconv.Itoa(len(v.items)))
+			}
+			if len(v.items) >= 200 {
+				v.paging.SetValue(listview.PageEnd)
+			} else {
+				v.paging.SetValue(listview.PageIdle)
+			}
+			v.Signal()
+		})
+	}
+	l.Add(list, func(s *constraint.Solver) {
+		s.TopEqual(l.Top())
+		s.LeftEqual(l.Left())
+		s.WidthEqual(l.Width())
+		s.HeightEqual(l.Height())
+	})
+
+	return view.Model{
+		Children: l.Views(),
+		Layouter: l,
+		Painter:  &paint.Style{BackgroundColor: colornames.White},
+	}
+}
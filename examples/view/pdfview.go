@@ -0,0 +1,120 @@
+package view
+
+import (
+	"fmt"
+
+	"golang.org/x/image/colornames"
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/layout/constraint"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/text"
+	"gomatcha.io/matcha/view"
+	"gomatcha.io/matcha/view/pdfview"
+)
+
+func init() {
+	bridge.RegisterFunc("gomatcha.io/matcha/examples/view NewPDFView", func() view.View {
+		return NewPDFView()
+	})
+}
+
+type PDFView struct {
+	view.Embed
+	page      *comm.IntValue
+	pageCount int
+	search    string
+	matches   []pdfview.Match
+}
+
+func NewPDFView() *PDFView {
+	return &PDFView{page: &comm.IntValue{}}
+}
+
+func (v *PDFView) Lifecycle(from, to view.Stage) {
+	if view.EntersStage(from, to, view.StageMounted) {
+		v.Subscribe(v.page)
+	} else if view.ExitsStage(from, to, view.StageMounted) {
+		v.Unsubscribe(v.page)
+	}
+}
+
+func (v *PDFView) Build(ctx view.Context) view.Model {
+	l := &constraint.Layouter{}
+
+	input := view.NewTextInput()
+	input.Placeholder = "Search"
+	input.OnSubmit = func(t *text.Text) {
+		v.search = t.String()
+		v.Signal()
+	}
+	g := l.Add(input, func(s *constraint.Solver) {
+		s.Top(10)
+		s.Left(20)
+		s.RightEqual(l.Right().Add(-20))
+		s.Height(40)
+	})
+
+	label := view.NewTextView()
+	label.String = fmt.Sprintf("Page %v of %v", v.page.Value()+1, v.pageCount)
+	if v.search != "" {
+		label.String += fmt.Sprintf(", %v matches", len(v.matches))
+	}
+	g = l.Add(label, func(s *constraint.Solver) {
+		s.TopEqual(g.Bottom().Add(10))
+		s.Left(20)
+	})
+
+	prev := view.NewButton()
+	prev.String = "Previous"
+	prev.OnPress = func() {
+		if v.page.Value() > 0 {
+			v.page.SetValue(v.page.Value() - 1)
+		}
+	}
+	l.Add(prev, func(s *constraint.Solver) {
+		s.CenterYEqual(g.CenterY())
+		s.RightEqual(l.Right().Add(-110))
+	})
+
+	next := view.NewButton()
+	next.String = "Next"
+	next.OnPress = func() {
+		if v.page.Value() < v.pageCount-1 {
+			v.page.SetValue(v.page.Value() + 1)
+		}
+	}
+	l.Add(next, func(s *constraint.Solver) {
+		s.CenterYEqual(g.CenterY())
+		s.RightEqual(l.Right().Add(-20))
+	})
+
+	pdf := pdfview.New()
+	pdf.URL = "https://www.w3.org/WAI/ER/tests/xhtml/testfiles/resources/pdf/dummy.pdf"
+	pdf.Page = v.page
+	pdf.Search = v.search
+	pdf.OnLoad = func(pageCount int) {
+		v.pageCount = pageCount
+		v.Signal()
+	}
+	pdf.OnSearch = func(matches []pdfview.Match) {
+		v.matches = matches
+		v.Signal()
+	}
+	pdf.OnError = func(err error) {
+		fmt.Println("PDFView error", err)
+	}
+	pdf.PaintStyle = &paint.Style{BackgroundColor: colornames.Lightgray}
+	l.Add(pdf, func(s *constraint.Solver) {
+		s.TopEqual(g.Bottom().Add(10))
+		s.Left(0)
+		s.WidthEqual(l.Width())
+		s.BottomEqual(l.Bottom())
+	})
+
+	return view.Model{
+		Children: l.Views(),
+		Layouter: l,
+		Painter:  &paint.Style{BackgroundColor: colornames.White},
+	}
+}
@@ -0,0 +1,88 @@
+package view
+
+import (
+	"fmt"
+
+	"golang.org/x/image/colornames"
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/layout/constraint"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+	"gomatcha.io/matcha/view/picker"
+)
+
+func init() {
+	bridge.RegisterFunc("gomatcha.io/matcha/examples/view NewPickerView", func() view.View {
+		return NewPickerView()
+	})
+}
+
+var (
+	pickerSizes  = []string{"Small", "Medium", "Large"}
+	pickerColors = []string{"Red", "Green", "Blue", "Yellow"}
+)
+
+type PickerView struct {
+	view.Embed
+	size  comm.IntValue
+	color comm.IntValue
+}
+
+func NewPickerView() *PickerView {
+	v := &PickerView{}
+	v.color.SetValue(2)
+	return v
+}
+
+func (v *PickerView) Lifecycle(from, to view.Stage) {
+	if view.EntersStage(from, to, view.StageMounted) {
+		v.Subscribe(&v.size)
+		v.Subscribe(&v.color)
+	} else if view.ExitsStage(from, to, view.StageMounted) {
+		v.Unsubscribe(&v.size)
+		v.Unsubscribe(&v.color)
+	}
+}
+
+func (v *PickerView) Build(ctx view.Context) view.Model {
+	l := &constraint.Layouter{}
+
+	label := view.NewTextView()
+	label.String = fmt.Sprintf("%v %v", pickerSizes[v.size.Value()], pickerColors[v.color.Value()])
+	g := l.Add(label, func(s *constraint.Solver) {
+		s.Top(20)
+		s.Left(20)
+	})
+
+	p := picker.New()
+	p.Components = []*picker.Component{
+		{Options: pickerSizes, Selected: &v.size},
+		{Options: pickerColors, Selected: &v.color},
+	}
+	p.OnChange = func(component, index int) {
+		fmt.Println("picker", component, index)
+	}
+	g = l.Add(p, func(s *constraint.Solver) {
+		s.TopEqual(g.Bottom().Add(20))
+		s.LeftEqual(l.Left().Add(20))
+		s.RightEqual(l.Right().Add(-20))
+	})
+
+	reset := view.NewButton()
+	reset.String = "Reset"
+	reset.OnPress = func() {
+		v.size.SetValue(0)
+		v.color.SetValue(0)
+	}
+	l.Add(reset, func(s *constraint.Solver) {
+		s.TopEqual(g.Bottom().Add(20))
+		s.Left(20)
+	})
+
+	return view.Model{
+		Children: l.Views(),
+		Layouter: l,
+		Painter:  &paint.Style{BackgroundColor: colornames.White},
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"gomatcha.io/matcha/paint"
 	"gomatcha.io/matcha/view"
 	"gomatcha.io/matcha/view/ios"
+	"gomatcha.io/matcha/view/progress"
 )
 
 func init() {
@@ -18,7 +19,8 @@ func init() {
 
 type ProgressView struct {
 	view.Embed
-	value *comm.Float64Value
+	value         *comm.Float64Value
+	indeterminate bool
 }
 
 func NewProgressView() *ProgressView {
@@ -39,6 +41,27 @@ func (v *ProgressView) Build(ctx view.Context) view.Model {
 		s.Width(200)
 	})
 
+	linear := progress.New()
+	linear.Value = v.value
+	linear.Animated = true
+	linear.Indeterminate = v.indeterminate
+	l.Add(linear, func(s *constraint.Solver) {
+		s.Top(130)
+		s.Left(100)
+		s.Width(200)
+	})
+
+	circular := progress.New()
+	circular.Style = progress.StyleCircular
+	circular.Value = v.value
+	circular.Animated = true
+	circular.Indeterminate = v.indeterminate
+	circular.Color = colornames.Orange
+	l.Add(circular, func(s *constraint.Solver) {
+		s.Top(150)
+		s.Left(100)
+	})
+
 	sliderv := view.NewSlider()
 	sliderv.MaxValue = 1
 	sliderv.MinValue = 0
@@ -51,6 +74,17 @@ func (v *ProgressView) Build(ctx view.Context) view.Model {
 		s.Width(200)
 	})
 
+	button := view.NewButton()
+	button.String = "Toggle Indeterminate"
+	button.OnPress = func() {
+		v.indeterminate = !v.indeterminate
+		v.Signal()
+	}
+	l.Add(button, func(s *constraint.Solver) {
+		s.Top(250)
+		s.Left(100)
+	})
+
 	return view.Model{
 		Children: l.Views(),
 		Layouter: l,
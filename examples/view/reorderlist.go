@@ -0,0 +1,92 @@
+package view
+
+import (
+	"strconv"
+
+	"golang.org/x/image/colornames"
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/layout/constraint"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+	"gomatcha.io/matcha/view/listview"
+)
+
+func init() {
+	bridge.RegisterFunc("gomatcha.io/matcha/examples/view NewReorderListView", func() view.View {
+		return NewReorderListView()
+	})
+}
+
+type ReorderListView struct {
+	view.Embed
+	items   words
+	handles bool
+}
+
+func NewReorderListView() *ReorderListView {
+	items := words{}
+	for i := 0; i < 50; i++ {
+		items = append(items, "Item "+strconv.Itoa(i))
+	}
+	return &ReorderListView{items: items, handles: true}
+}
+
+func (v *ReorderListView) Build(ctx view.Context) view.Model {
+	l := &constraint.Layouter{}
+
+	button := view.NewButton()
+	button.String = "Use long press"
+	if !v.handles {
+		button.String = "Use handles"
+	}
+	button.OnPress = func() {
+		v.handles = !v.handles
+		v.Signal()
+	}
+	g := l.Add(button, func(s *constraint.Solver) {
+		s.TopEqual(l.Top().Add(10))
+		s.LeftEqual(l.Left().Add(20))
+	})
+
+	list := listview.New()
+	list.DataSource = v.items
+	list.Reorder = listview.ReorderLongPress
+	if v.handles {
+		list.Reorder = listview.ReorderHandle
+	}
+	list.OnMove = func(from, to int) {
+		item := v.items[from]
+		items := append(v.items[:from:from], v.items[from+1:]...)
+		v.items = append(items[:to:to], append(words{item}, items[to:]...)...)
+		v.Signal()
+	}
+	l.Add(list, func(s *constraint.Solver) {
+		s.TopEqual(g.Bottom().Add(10))
+		s.LeftEqual(l.Left())
+		s.WidthEqual(l.Width())
+		s.BottomEqual(l.Bottom())
+	})
+
+	return view.Model{
+		Children: l.Views(),
+		Layouter: l,
+		Painter:  &paint.Style{BackgroundColor: colornames.White},
+	}
+}
+
+// words is a data source that displays a row for each string.
+type words []string
+
+func (w words) Count() int {
+	return len(w)
+}
+
+func (w words) ReuseId(index int) string {
+	return "word"
+}
+
+func (w words) View(index int) view.View {
+	label := view.NewTextView()
+	label.String = w[index]
+	return label
+}
@@ -0,0 +1,66 @@
+package view
+
+import (
+	"golang.org/x/image/colornames"
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/layout/constraint"
+	"gomatcha.io/matcha/layout/table"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+)
+
+func init() {
+	bridge.RegisterFunc("gomatcha.io/matcha/examples/view NewScrollHeaderView", func() view.View {
+		return NewScrollHeaderView()
+	})
+}
+
+type ScrollHeaderView struct {
+	view.Embed
+}
+
+func NewScrollHeaderView() *ScrollHeaderView {
+	return &ScrollHeaderView{}
+}
+
+func (v *ScrollHeaderView) Build(ctx view.Context) view.Model {
+	// The header's image fills it as it stretches and collapses, and the title
+	// stays at its bottom.
+	header := &constraint.Layouter{}
+	img := view.NewImageView()
+	img.URL = "https://avatars0.githubusercontent.com/u/758035?v=4&s=460"
+	img.ResizeMode = view.ImageResizeModeFill
+	header.Add(img, func(s *constraint.Solver) {
+		s.TopEqual(header.Top())
+		s.LeftEqual(header.Left())
+		s.WidthEqual(header.Width())
+		s.HeightEqual(header.Height())
+	})
+	title := view.NewTextView()
+	title.String = "Profile"
+	header.Add(title, func(s *constraint.Solver) {
+		s.BottomEqual(header.Bottom().Add(-12))
+		s.Left(16)
+	})
+	headerView := view.NewBasicView()
+	headerView.Children = header.Views()
+	headerView.Layouter = header
+	headerView.Painter = &paint.Style{BackgroundColor: colornames.Lightblue}
+
+	childLayouter := &table.Layouter{}
+	for i := 0; i < 20; i++ {
+		childLayouter.Add(NewTableCell(), nil)
+	}
+
+	scrollview := view.NewScrollView()
+	scrollview.Header = headerView
+	scrollview.HeaderHeight = 240
+	scrollview.HeaderMinHeight = 64
+	scrollview.HeaderParallax = 0.5
+	scrollview.ContentLayouter = childLayouter
+	scrollview.ContentChildren = childLayouter.Views()
+	scrollview.PaintStyle = &paint.Style{BackgroundColor: colornames.White}
+	return view.Model{
+		Children: []view.View{scrollview},
+	}
+}
@@ -1,6 +1,8 @@
 package view
 
 import (
+	"fmt"
+
 	"golang.org/x/image/colornames"
 	"gomatcha.io/matcha/bridge"
 	"gomatcha.io/matcha/keyboard"
@@ -19,12 +21,14 @@ func init() {
 type TextView struct {
 	view.Embed
 	text      *text.Text
+	note      *text.Text
 	responder *keyboard.Responder
 }
 
 func NewTextView() *TextView {
 	return &TextView{
 		text:      text.New("blah"),
+		note:      text.New(""),
 		responder: &keyboard.Responder{},
 	}
 }
@@ -59,6 +63,13 @@ func (v *TextView) Build(ctx view.Context) view.Model {
 	st := text.NewStyledText("Subtitle", style)
 	st.Set(style2, 0, 3)
 
+	link := &text.Style{}
+	link.SetBackgroundColor(colornames.Yellow)
+	link.SetOnTap(func() {
+		fmt.Println("tapped title")
+	})
+	st.Update(link, 4, 7)
+
 	chl := view.NewTextView()
 	chl.StyledText = st
 	chlP := view.WithPainter(chl, &paint.Style{BackgroundColor: colornames.Blue})
@@ -116,6 +127,65 @@ func (v *TextView) Build(ctx view.Context) view.Model {
 		s.Height(100)
 	})
 
+	note := view.NewTextInput()
+	note.Text = v.note
+	note.Placeholder = "Note"
+	note.MaxLines = 4
+	note.PaintStyle = &paint.Style{BackgroundColor: colornames.Lightgray}
+	l.Add(note, func(s *constraint.Solver) {
+		s.Top(250)
+		s.Left(100)
+		s.Width(200)
+	})
+
+	selectable := view.NewTextView()
+	selectable.String = "This text can be selected and copied."
+	selectable.Selectable = true
+	selectable.OnSelectionChange = func(start, end int) {
+		fmt.Println("selected", start, end)
+	}
+	l.Add(selectable, func(s *constraint.Solver) {
+		s.Top(350)
+		s.Left(100)
+		s.Width(200)
+	})
+
+	links := view.NewTextView()
+	links.String = "Visit www.gomatcha.io, mail hello@example.com or call +1 555 123 4567."
+	links.DataDetectors = view.DetectAll
+	links.OnLink = func(l view.Link) {
+		fmt.Println("link", l.URL)
+	}
+	l.Add(links, func(s *constraint.Solver) {
+		s.Top(420)
+		s.Left(100)
+		s.Width(200)
+	})
+
+	rtl := view.NewTextView()
+	rtl.String = "مرحبا بالعالم، hello 👩‍👩‍👧 שלום עולם"
+	rtl.Style.SetWritingDirection(text.WritingDirectionRightToLeft)
+	rtl.Style.SetTruncation(text.TruncationEnd)
+	l.Add(rtl, func(s *constraint.Solver) {
+		s.Top(490)
+		s.Left(100)
+		s.Width(200)
+		s.Height(20)
+	})
+
+	paragraphs := view.NewTextView()
+	paragraphs.String = "Spaced out letters.\nTall lines with an indented first line, and room after each paragraph."
+	paragraphs.Style.SetLetterSpacing(1.5)
+	paragraphs.Style.SetLineHeight(24)
+	paragraphs.Style.SetParagraphSpacing(10)
+	paragraphs.Style.SetFirstLineIndent(20)
+	paragraphs.Style.SetHyphenation(1)
+	l.Add(paragraphs, func(s *constraint.Solver) {
+		s.Top(520)
+		s.Left(100)
+		s.Width(200)
+	})
+
 	return view.Model{
 		Children: l.Views(),
 		Painter:  &paint.Style{BackgroundColor: colornames.White},
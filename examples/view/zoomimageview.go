@@ -0,0 +1,77 @@
+package view
+
+import (
+	"fmt"
+
+	"golang.org/x/image/colornames"
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/layout/constraint"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+	"gomatcha.io/matcha/view/zoomimageview"
+)
+
+func init() {
+	bridge.RegisterFunc("gomatcha.io/matcha/examples/view NewZoomImageView", func() view.View {
+		return NewZoomImageView()
+	})
+}
+
+type ZoomImageView struct {
+	view.Embed
+	scale *comm.Float64Value
+}
+
+func NewZoomImageView() *ZoomImageView {
+	v := &ZoomImageView{scale: &comm.Float64Value{}}
+	v.scale.SetValue(1)
+	return v
+}
+
+func (v *ZoomImageView) Lifecycle(from, to view.Stage) {
+	if view.EntersStage(from, to, view.StageMounted) {
+		v.Subscribe(v.scale)
+	} else if view.ExitsStage(from, to, view.StageMounted) {
+		v.Unsubscribe(v.scale)
+	}
+}
+
+func (v *ZoomImageView) Build(ctx view.Context) view.Model {
+	l := &constraint.Layouter{}
+
+	zoom := zoomimageview.New()
+	zoom.URL = "https://avatars0.githubusercontent.com/u/758035?v=4&s=460"
+	zoom.MaxScale = 5
+	zoom.Scale = v.scale
+	zoom.PaintStyle = &paint.Style{BackgroundColor: colornames.Black}
+	g := l.Add(zoom, func(s *constraint.Solver) {
+		s.Top(0)
+		s.Left(0)
+		s.WidthEqual(l.Width())
+		s.Height(400)
+	})
+
+	label := view.NewTextView()
+	label.String = fmt.Sprintf("Scale: %.2f", v.scale.Value())
+	g = l.Add(label, func(s *constraint.Solver) {
+		s.TopEqual(g.Bottom().Add(20))
+		s.Left(20)
+	})
+
+	reset := view.NewButton()
+	reset.String = "Reset Zoom"
+	reset.OnPress = func() {
+		v.scale.SetValue(1)
+	}
+	l.Add(reset, func(s *constraint.Solver) {
+		s.TopEqual(g.Bottom().Add(20))
+		s.Left(20)
+	})
+
+	return view.Model{
+		Children: l.Views(),
+		Layouter: l,
+		Painter:  &paint.Style{BackgroundColor: colornames.White},
+	}
+}
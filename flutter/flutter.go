@@ -0,0 +1,128 @@
+// Package flutter embeds Matcha views in Flutter apps as platform views, so that
+// an app can be migrated one screen at a time. Components registered with
+// Register are rendered by the MatchaView widget of the Flutter plugin generated
+// by `matcha build --flutter`.
+//
+//  func init() {
+//      flutter.Register("settings", func(ctx *flutter.Context) view.View {
+//          return NewSettingsView(ctx)
+//      })
+//  }
+//
+// And in Dart:
+//
+//  import 'package:matcha/matcha.dart';
+//
+//  MatchaView(component: 'settings', params: {'userId': 42})
+package flutter
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"gomatcha.io/matcha"
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/view"
+)
+
+// Context connects a component to its MatchaView widget.
+type Context struct {
+	params map[string]interface{}
+}
+
+// Params returns the params of the MatchaView widget, decoded from JSON.
+func (c *Context) Params() map[string]interface{} {
+	return c.params
+}
+
+// LifecycleState is the state of the Flutter app, mirroring AppLifecycleState.
+type LifecycleState int
+
+const (
+	LifecycleResumed LifecycleState = iota
+	LifecycleInactive
+	LifecyclePaused
+	LifecycleDetached
+)
+
+// Lifecycle posts notifications when the Flutter app changes state. Platform
+// views are not told when the app is backgrounded, so components can use this
+// to pause work.
+type Lifecycle struct {
+	state LifecycleState
+	relay comm.Relay
+}
+
+var lifecycle Lifecycle
+
+// AppLifecycle returns the Lifecycle of the Flutter app.
+func AppLifecycle() *Lifecycle {
+	return &lifecycle
+}
+
+// State returns the current state of the app. It must be called on the main thread.
+func (l *Lifecycle) State() LifecycleState {
+	return l.state
+}
+
+// Notify implements the comm.Notifier interface.
+func (l *Lifecycle) Notify(f func()) comm.Id {
+	return l.relay.Notify(f)
+}
+
+// Unnotify implements the comm.Notifier interface.
+func (l *Lifecycle) Unnotify(id comm.Id) {
+	l.relay.Unnotify(id)
+}
+
+var registry = struct {
+	mu         sync.Mutex
+	components map[string]func(*Context) view.View
+}{
+	components: map[string]func(*Context) view.View{},
+}
+
+// Register makes the view returned by f available to Flutter as name. It panics
+// if name is already registered.
+func Register(name string, f func(*Context) view.View) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if _, ok := registry.components[name]; ok {
+		panic("flutter: component registered twice: " + name)
+	}
+	registry.components[name] = f
+}
+
+func init() {
+	bridge.RegisterFunc("gomatcha.io/matcha/flutter New", func(name string, params string) view.View {
+		matcha.MainLocker.Lock()
+		defer matcha.MainLocker.Unlock()
+
+		registry.mu.Lock()
+		f, ok := registry.components[name]
+		registry.mu.Unlock()
+		if !ok {
+			fmt.Println("flutter: unknown component", name)
+			return view.NewBasicView()
+		}
+
+		ctx := &Context{params: map[string]interface{}{}}
+		if err := json.Unmarshal([]byte(params), &ctx.params); err != nil {
+			fmt.Println("flutter: invalid params", err)
+		}
+		return f(ctx)
+	})
+	bridge.RegisterFunc("gomatcha.io/matcha/flutter SetLifecycle", func(state int) {
+		matcha.MainLocker.Lock()
+		defer matcha.MainLocker.Unlock()
+
+		if lifecycle.state == LifecycleState(state) {
+			return
+		}
+		lifecycle.state = LifecycleState(state)
+		lifecycle.relay.Signal()
+	})
+}
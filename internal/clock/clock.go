@@ -0,0 +1,68 @@
+// Package clock provides the time source used by matcha so that it can be
+// replaced in tests.
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the interface that wraps Now and AfterFunc.
+type Clock interface {
+	Now() time.Time
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is returned by Clock.AfterFunc. Stop prevents the timer from firing.
+type Timer interface {
+	Stop() bool
+}
+
+var (
+	mu    sync.Mutex
+	clock Clock = systemClock{}
+)
+
+// Set replaces the current clock with c and returns the previous clock. If c is nil the system clock is used.
+func Set(c Clock) Clock {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if c == nil {
+		c = systemClock{}
+	}
+	prev := clock
+	clock = c
+	return prev
+}
+
+func get() Clock {
+	mu.Lock()
+	defer mu.Unlock()
+	return clock
+}
+
+// Now returns the current time.
+func Now() time.Time {
+	return get().Now()
+}
+
+// Since returns the time elapsed since t.
+func Since(t time.Time) time.Duration {
+	return get().Now().Sub(t)
+}
+
+// AfterFunc calls f in its own goroutine after duration d.
+func AfterFunc(d time.Duration, f func()) Timer {
+	return get().AfterFunc(d, f)
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
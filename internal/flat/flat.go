@@ -0,0 +1,322 @@
+// Package flat implements the flat encoding of the view hierarchy. Unlike the
+// protobuf encoding, nodes are fixed size records that platforms read in place,
+// without a deserialization pass.
+//
+// All integers are little endian, and offsets are from the start of the buffer.
+// The buffer starts with a header:
+//
+//  0   "MTF1"
+//  4   uint32 node count
+//  8   uint32 middleware count
+//  12  uint32 middleware table offset
+//
+// followed by the node records, sorted by id so that they can be found with a
+// binary search:
+//
+//  0   int64 id
+//  8   int64 build id
+//  16  int64 layout id
+//  24  int64 paint id
+//  32  float64 minx, miny, maxx, maxy
+//  64  int64 z index
+//  72  uint32 flags
+//  76  uint32 children count, offset of the int64 ids
+//  84  uint32 child order count, offset of the int64 ids
+//  92  uint32 bridge name offset, length
+//  100 uint32 bridge value offset, length
+//  108 uint32 values count, offset of the entries
+//  116 uint32 padding
+//  120 float64 transparency, border width, corner radius, shadow radius
+//  152 float64 shadow offset x, y
+//  168 uint16 red, green, blue, alpha of the background color
+//  176 uint16 red, green, blue, alpha of the border color
+//  184 uint16 red, green, blue, alpha of the shadow color
+//
+// Values and middleware are tables of entries of 4 uint32, the key offset and
+// length, and the value offset and length. Middleware values are protobuf
+// encoded google.protobuf.Any messages.
+package flat
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"sort"
+)
+
+// Magic is the first 4 bytes of an encoded root.
+const Magic = "MTF1"
+
+const (
+	headerSize = 16
+	nodeSize   = 192
+	entrySize  = 16
+)
+
+// Flags of a node record.
+const (
+	HasBuild       = 1 << iota // The build fields are set.
+	HasLayoutPaint             // The layout and paint fields are set.
+	HasBackgroundColor
+	HasBorderColor
+	HasShadowColor
+)
+
+// Color is a color with 16 bits per channel, as returned by color.Color.RGBA.
+type Color struct {
+	R, G, B, A uint16
+}
+
+// Entry is a key and value of a values or middleware table.
+type Entry struct {
+	Key   string
+	Value []byte
+}
+
+// Node is a view node.
+type Node struct {
+	Id    int64
+	Flags uint32
+
+	BuildId     int64
+	Children    []int64
+	BridgeName  string
+	BridgeValue []byte
+	Values      []Entry
+
+	LayoutId               int64
+	PaintId                int64
+	MinX, MinY, MaxX, MaxY float64
+	ZIndex                 int64
+	ChildOrder             []int64
+
+	Transparency    float64
+	BorderWidth     float64
+	CornerRadius    float64
+	ShadowRadius    float64
+	ShadowOffsetX   float64
+	ShadowOffsetY   float64
+	BackgroundColor Color
+	BorderColor     Color
+	ShadowColor     Color
+}
+
+// Encode encodes nodes and the middleware of a root. Nodes are sorted by id.
+func Encode(nodes []Node, middleware []Entry) []byte {
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Id < nodes[j].Id
+	})
+
+	e := &encoder{}
+	middlewareOffset := headerSize + len(nodes)*nodeSize
+	e.buf = make([]byte, middlewareOffset+len(middleware)*entrySize)
+	copy(e.buf, Magic)
+	e.putUint32(4, uint32(len(nodes)))
+	e.putUint32(8, uint32(len(middleware)))
+	e.putUint32(12, uint32(middlewareOffset))
+	e.putEntries(middlewareOffset, middleware)
+
+	for i, n := range nodes {
+		o := headerSize + i*nodeSize
+		e.putUint64(o, uint64(n.Id))
+		e.putUint64(o+8, uint64(n.BuildId))
+		e.putUint64(o+16, uint64(n.LayoutId))
+		e.putUint64(o+24, uint64(n.PaintId))
+		e.putFloat64(o+32, n.MinX)
+		e.putFloat64(o+40, n.MinY)
+		e.putFloat64(o+48, n.MaxX)
+		e.putFloat64(o+56, n.MaxY)
+		e.putUint64(o+64, uint64(n.ZIndex))
+		e.putUint32(o+72, n.Flags)
+		e.putInt64s(o+76, n.Children)
+		e.putInt64s(o+84, n.ChildOrder)
+		e.putBytes(o+92, []byte(n.BridgeName))
+		e.putBytes(o+100, n.BridgeValue)
+		e.putUint32(o+108, uint32(len(n.Values)))
+		e.putUint32(o+112, uint32(len(e.buf)))
+		e.buf = append(e.buf, make([]byte, len(n.Values)*entrySize)...)
+		e.putEntries(int(e.getUint32(o+112)), n.Values)
+		e.putFloat64(o+120, n.Transparency)
+		e.putFloat64(o+128, n.BorderWidth)
+		e.putFloat64(o+136, n.CornerRadius)
+		e.putFloat64(o+144, n.ShadowRadius)
+		e.putFloat64(o+152, n.ShadowOffsetX)
+		e.putFloat64(o+160, n.ShadowOffsetY)
+		e.putColor(o+168, n.BackgroundColor)
+		e.putColor(o+176, n.BorderColor)
+		e.putColor(o+184, n.ShadowColor)
+	}
+	return e.buf
+}
+
+type encoder struct {
+	buf []byte
+}
+
+func (e *encoder) getUint32(o int) uint32 {
+	return binary.LittleEndian.Uint32(e.buf[o:])
+}
+
+func (e *encoder) putUint32(o int, v uint32) {
+	binary.LittleEndian.PutUint32(e.buf[o:], v)
+}
+
+func (e *encoder) putUint64(o int, v uint64) {
+	binary.LittleEndian.PutUint64(e.buf[o:], v)
+}
+
+func (e *encoder) putFloat64(o int, v float64) {
+	e.putUint64(o, math.Float64bits(v))
+}
+
+func (e *encoder) putColor(o int, c Color) {
+	binary.LittleEndian.PutUint16(e.buf[o:], c.R)
+	binary.LittleEndian.PutUint16(e.buf[o+2:], c.G)
+	binary.LittleEndian.PutUint16(e.buf[o+4:], c.B)
+	binary.LittleEndian.PutUint16(e.buf[o+6:], c.A)
+}
+
+// putBytes appends b to the buffer and writes its offset and length at o.
+func (e *encoder) putBytes(o int, b []byte) {
+	e.putUint32(o, uint32(len(e.buf)))
+	e.putUint32(o+4, uint32(len(b)))
+	e.buf = append(e.buf, b...)
+}
+
+// putInt64s appends ids to the buffer and writes their count and offset at o.
+func (e *encoder) putInt64s(o int, ids []int64) {
+	e.putUint32(o, uint32(len(ids)))
+	e.putUint32(o+4, uint32(len(e.buf)))
+	for _, i := range ids {
+		e.buf = binary.LittleEndian.AppendUint64(e.buf, uint64(i))
+	}
+}
+
+// putEntries writes the table of entries at o, which must already be allocated.
+func (e *encoder) putEntries(o int, entries []Entry) {
+	for i, entry := range entries {
+		e.putBytes(o+i*entrySize, []byte(entry.Key))
+		e.putBytes(o+i*entrySize+8, entry.Value)
+	}
+}
+
+// Root reads an encoded root in place.
+type Root []byte
+
+// Decode checks the header of b and returns it as a Root.
+func Decode(b []byte) (Root, error) {
+	if len(b) < headerSize || string(b[:4]) != Magic {
+		return nil, errors.New("flat: invalid header")
+	}
+	r := Root(b)
+	if len(b) < headerSize+r.Len()*nodeSize {
+		return nil, errors.New("flat: truncated node table")
+	}
+	return r, nil
+}
+
+// Len returns the number of nodes.
+func (r Root) Len() int {
+	return int(binary.LittleEndian.Uint32(r[4:]))
+}
+
+// Find returns the index of the node with id, or -1.
+func (r Root) Find(id int64) int {
+	n := r.Len()
+	i := sort.Search(n, func(i int) bool {
+		return r.int64(headerSize+i*nodeSize) >= id
+	})
+	if i < n && r.int64(headerSize+i*nodeSize) == id {
+		return i
+	}
+	return -1
+}
+
+// Node decodes the node at index i.
+func (r Root) Node(i int) Node {
+	o := headerSize + i*nodeSize
+	return Node{
+		Id:              r.int64(o),
+		BuildId:         r.int64(o + 8),
+		LayoutId:        r.int64(o + 16),
+		PaintId:         r.int64(o + 24),
+		MinX:            r.float64(o + 32),
+		MinY:            r.float64(o + 40),
+		MaxX:            r.float64(o + 48),
+		MaxY:            r.float64(o + 56),
+		ZIndex:          r.int64(o + 64),
+		Flags:           r.uint32(o + 72),
+		Children:        r.int64s(o + 76),
+		ChildOrder:      r.int64s(o + 84),
+		BridgeName:      string(r.bytes(o + 92)),
+		BridgeValue:     r.bytes(o + 100),
+		Values:          r.entries(o + 108),
+		Transparency:    r.float64(o + 120),
+		BorderWidth:     r.float64(o + 128),
+		CornerRadius:    r.float64(o + 136),
+		ShadowRadius:    r.float64(o + 144),
+		ShadowOffsetX:   r.float64(o + 152),
+		ShadowOffsetY:   r.float64(o + 160),
+		BackgroundColor: r.color(o + 168),
+		BorderColor:     r.color(o + 176),
+		ShadowColor:     r.color(o + 184),
+	}
+}
+
+// Middleware returns the middleware entries.
+func (r Root) Middleware() []Entry {
+	return r.entries(8)
+}
+
+func (r Root) uint32(o int) uint32 {
+	return binary.LittleEndian.Uint32(r[o:])
+}
+
+func (r Root) int64(o int) int64 {
+	return int64(binary.LittleEndian.Uint64(r[o:]))
+}
+
+func (r Root) float64(o int) float64 {
+	return math.Float64frombits(binary.LittleEndian.Uint64(r[o:]))
+}
+
+func (r Root) color(o int) Color {
+	return Color{
+		R: binary.LittleEndian.Uint16(r[o:]),
+		G: binary.LittleEndian.Uint16(r[o+2:]),
+		B: binary.LittleEndian.Uint16(r[o+4:]),
+		A: binary.LittleEndian.Uint16(r[o+6:]),
+	}
+}
+
+func (r Root) bytes(o int) []byte {
+	off, n := r.uint32(o), r.uint32(o+4)
+	if n == 0 {
+		return nil
+	}
+	return r[off : off+n]
+}
+
+func (r Root) int64s(o int) []int64 {
+	n, off := int(r.uint32(o)), int(r.uint32(o+4))
+	ids := make([]int64, n)
+	for i := range ids {
+		ids[i] = r.int64(off + i*8)
+	}
+	return ids
+}
+
+func (r Root) entries(o int) []Entry {
+	n, off := int(r.uint32(o)), int(r.uint32(o+4))
+	if n == 0 {
+		return nil
+	}
+	entries := make([]Entry, n)
+	for i := range entries {
+		entries[i] = Entry{
+			Key:   string(r.bytes(off + i*entrySize)),
+			Value: r.bytes(off + i*entrySize + 8),
+		}
+	}
+	return entries
+}
@@ -0,0 +1,64 @@
+package flat
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	nodes := []Node{
+		{
+			Id:              3,
+			Flags:           HasLayoutPaint | HasBackgroundColor,
+			LayoutId:        4,
+			PaintId:         5,
+			MinX:            1,
+			MinY:            2,
+			MaxX:            3,
+			MaxY:            4,
+			ChildOrder:      []int64{},
+			Children:        []int64{},
+			Transparency:    0.5,
+			BackgroundColor: Color{R: 0xffff, A: 0xffff},
+		},
+		{
+			Id:          1,
+			Flags:       HasBuild | HasLayoutPaint,
+			BuildId:     2,
+			Children:    []int64{3, 7},
+			ChildOrder:  []int64{7, 3},
+			BridgeName:  "gomatcha.io/matcha/view/basicview",
+			BridgeValue: []byte{1, 2, 3},
+			Values:      []Entry{{Key: "gomatcha.io/matcha/touch", Value: []byte{4}}},
+			ZIndex:      -1,
+		},
+	}
+	middleware := []Entry{{Key: "statusbar", Value: []byte{5, 6}}}
+
+	r, err := Decode(Encode(nodes, middleware))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Len() != 2 {
+		t.Fatalf("Len() = %v, want 2", r.Len())
+	}
+	for _, want := range nodes {
+		i := r.Find(want.Id)
+		if i < 0 {
+			t.Fatalf("Find(%v) = -1", want.Id)
+		}
+		if got := r.Node(i); !reflect.DeepEqual(got, want) {
+			t.Errorf("Node(%v) = %+v, want %+v", i, got, want)
+		}
+	}
+	if i := r.Find(2); i != -1 {
+		t.Errorf("Find(2) = %v, want -1", i)
+	}
+	if got := r.Middleware(); !reflect.DeepEqual(got, middleware) {
+		t.Errorf("Middleware() = %v, want %v", got, middleware)
+	}
+
+	if _, err := Decode([]byte("MTF0")); err == nil {
+		t.Error("expected an error for an invalid header")
+	}
+}
@@ -0,0 +1,11 @@
+// Package platform describes the platform that the app is built for.
+package platform
+
+import "runtime"
+
+// UsesJavaStyleBridge returns true if native methods are called with Java style
+// names, such as "displayAlert", rather than Objective-C selectors, such as
+// "displayAlert:". The web and desktop renderers follow the Android bridge.
+func UsesJavaStyleBridge() bool {
+	return runtime.GOOS == "android" || runtime.GOOS == "js" || runtime.GOOS == "windows" || runtime.GOOS == "linux"
+}
@@ -0,0 +1,138 @@
+// Package recycle implements the cell reuse shared by the listview and
+// collectionview packages.
+package recycle
+
+import (
+	"sort"
+	"strconv"
+
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/view"
+)
+
+// Recycler assigns the rows of a recycling view to cells. A cell keeps its key
+// as it moves to another row with the same reuse identifier, so that the row's
+// view and native view are updated in place rather than rebuilt.
+type Recycler struct {
+	slots map[int]slot
+	next  int
+	rows  []int64
+}
+
+type slot struct {
+	reuseId string
+	key     string
+}
+
+// Cells returns the cells of the rows from first up to last. The cells of the
+// rows built by the previous call are kept, or handed to new rows with the same
+// reuse identifier.
+func (r *Recycler) Cells(first, last int, reuseId func(row int) string, viewFor func(row int) view.View) []view.View {
+	reuseIds := make([]string, last-first)
+	for row := first; row < last; row++ {
+		reuseIds[row-first] = reuseId(row)
+	}
+
+	prev := r.slots
+	r.slots = map[int]slot{}
+	for row := first; row < last; row++ {
+		if s, ok := prev[row]; ok && s.reuseId == reuseIds[row-first] {
+			r.slots[row] = s
+			delete(prev, row)
+		}
+	}
+	free := map[string][]string{}
+	for _, s := range prev {
+		free[s.reuseId] = append(free[s.reuseId], s.key)
+	}
+	for _, keys := range free {
+		sort.Strings(keys)
+	}
+	for row := first; row < last; row++ {
+		if _, ok := r.slots[row]; ok {
+			continue
+		}
+		id := reuseIds[row-first]
+		var key string
+		if keys := free[id]; len(keys) > 0 {
+			key = keys[0]
+			free[id] = keys[1:]
+		} else {
+			r.next++
+			key = id + " " + strconv.Itoa(r.next)
+		}
+		r.slots[row] = slot{reuseId: id, key: key}
+	}
+
+	cells := []view.View{}
+	r.rows = nil
+	for row := first; row < last; row++ {
+		c := &Cell{Child: viewFor(row)}
+		c.Key = r.slots[row].key
+		cells = append(cells, c)
+		r.rows = append(r.rows, int64(row))
+	}
+	return cells
+}
+
+// Move moves the cell of the row at from to the row at to, and shifts the
+// cells of the rows in between, so that the cells follow their rows when a row
+// is reordered.
+func (r *Recycler) Move(from, to int) {
+	slots := make(map[int]slot, len(r.slots))
+	for row, s := range r.slots {
+		switch {
+		case row == from:
+			row = to
+		case from < to && row > from && row <= to:
+			row--
+		case to < from && row >= to && row < from:
+			row++
+		}
+		slots[row] = s
+	}
+	r.slots = slots
+}
+
+// Rows returns the row displayed by each cell returned by the last call to
+// Cells.
+func (r *Recycler) Rows() []int64 {
+	return r.rows
+}
+
+// Cell wraps the view of a row, and sizes it to fill the cell.
+type Cell struct {
+	view.Embed
+	Child view.View
+}
+
+// Update implements the view.View interface.
+func (c *Cell) Update(v2 view.View) {
+	view.CopyFields(c, v2)
+}
+
+// Build implements the view.View interface.
+func (c *Cell) Build(ctx view.Context) view.Model {
+	return view.Model{
+		Children: []view.View{c.Child},
+		Layouter: &cellLayouter{},
+	}
+}
+
+type cellLayouter struct{}
+
+func (l *cellLayouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	size := ctx.MinSize()
+	g := ctx.LayoutChild(0, size, size)
+	g.Frame = layout.Rt(0, 0, size.X, size.Y)
+	return layout.Guide{Frame: layout.Rt(0, 0, size.X, size.Y)}, []layout.Guide{g}
+}
+
+func (l *cellLayouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+func (l *cellLayouter) Unnotify(id comm.Id) {
+	// no-op
+}
@@ -0,0 +1,40 @@
+package recycle
+
+import (
+	"testing"
+
+	"gomatcha.io/matcha/view"
+)
+
+func TestMove(t *testing.T) {
+	r := &Recycler{}
+	reuseId := func(row int) string { return "row" }
+	viewFor := func(row int) view.View { return nil }
+	r.Cells(0, 4, reuseId, viewFor)
+	keys := func() []string {
+		k := []string{}
+		for row := 0; row < 4; row++ {
+			k = append(k, r.slots[row].key)
+		}
+		return k
+	}
+	before := keys()
+
+	r.Move(0, 2)
+	after := keys()
+	want := []string{before[1], before[2], before[0], before[3]}
+	for i := range want {
+		if after[i] != want[i] {
+			t.Fatalf("Move(0, 2) = %v, want %v", after, want)
+		}
+	}
+
+	r.Move(3, 1)
+	after2 := keys()
+	want = []string{after[0], after[3], after[1], after[2]}
+	for i := range want {
+		if after2[i] != want[i] {
+			t.Fatalf("Move(3, 1) = %v, want %v", after2, want)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"gomatcha.io/matcha/bridge"
 	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/internal/clock"
 )
 
 var tickers = struct {
@@ -18,11 +19,18 @@ var tickers = struct {
 	maxKey: 0,
 }
 
+// FrameClock posts a notification on every screen update.
+var FrameClock comm.Relay
+
 func init() {
-	bridge.RegisterFunc("gomatcha.io/matcha/animate screenUpdate", screenUpdate)
+	bridge.RegisterFunc("gomatcha.io/matcha/animate screenUpdate", ScreenUpdate)
 }
 
-func screenUpdate() {
+// ScreenUpdate signals FrameClock and all running tickers. It is called by the
+// native display link on every frame.
+func ScreenUpdate() {
+	FrameClock.Signal()
+
 	tickers.mu.Lock()
 	ts := []*Ticker{}
 	for _, i := range tickers.ts {
@@ -40,7 +48,7 @@ type Ticker struct {
 	mu       sync.Mutex
 	funcs    map[comm.Id]func()
 	maxId    comm.Id
-	timer    *time.Timer
+	timer    clock.Timer
 	start    time.Time
 	duration time.Duration
 }
@@ -53,10 +61,10 @@ func NewTicker(duration time.Duration) *Ticker {
 	t := &Ticker{
 		key:      tickers.maxKey,
 		funcs:    map[comm.Id]func(){},
-		start:    time.Now(),
+		start:    clock.Now(),
 		duration: duration,
 	}
-	t.timer = time.AfterFunc(duration, func() {
+	t.timer = clock.AfterFunc(duration, func() {
 		t.Stop()
 	})
 	tickers.ts[t.key] = t
@@ -80,7 +88,7 @@ func (t *Ticker) Unnotify(key comm.Id) {
 }
 
 func (t *Ticker) Value() float64 {
-	v := float64(time.Since(t.start)) / float64(t.duration)
+	v := float64(clock.Since(t.start)) / float64(t.duration)
 	if v < 0 {
 		v = 0
 	} else if v > 1 {
@@ -11,6 +11,7 @@ import (
 	"gomatcha.io/matcha/application"
 	"gomatcha.io/matcha/bridge"
 	"gomatcha.io/matcha/proto"
+	"gomatcha.io/matcha/svg"
 )
 
 func init() {
@@ -29,6 +30,10 @@ func ImageMarshalProtobuf(img image.Image) *proto.ImageOrResource {
 		return &proto.ImageOrResource{
 			Path: res.Path(),
 		}
+	} else if svgImg, ok := img.(*svg.Image); ok {
+		return &proto.ImageOrResource{
+			Image: proto.ImageEncode(svgImg.RGBA()),
+		}
 	} else {
 		return &proto.ImageOrResource{
 			Image: proto.ImageEncode(img),
@@ -0,0 +1,6 @@
+#import <UIKit/UIKit.h>
+#import <AVFoundation/AVFoundation.h>
+#import "MatchaCameraView.h"
+
+@interface MatchaBarcodeScannerView : MatchaCameraView <AVCaptureMetadataOutputObjectsDelegate>
+@end
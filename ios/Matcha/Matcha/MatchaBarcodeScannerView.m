@@ -0,0 +1,145 @@
+#import "MatchaBarcodeScannerView.h"
+#import "MatchaViewController_Private.h"
+#import "MatchaView_Private.h"
+
+static UIColor *MatchaBarcodeScannerColor(long long argb) {
+    return [UIColor colorWithRed:((argb >> 16) & 0xFF) / 255.0 green:((argb >> 8) & 0xFF) / 255.0 blue:(argb & 0xFF) / 255.0 alpha:((argb >> 24) & 0xFF) / 255.0];
+}
+
+// MatchaBarcodeScannerFormats maps metadata types to the Format constants in
+// Go. AVFoundation reports UPC-A codes as EAN-13 codes with a leading zero, so
+// UPC-A has no type of its own.
+static NSDictionary<AVMetadataObjectType, NSNumber *> *MatchaBarcodeScannerFormats() {
+    return @{
+        AVMetadataObjectTypeQRCode: @(1 << 0),
+        AVMetadataObjectTypeEAN13Code: @(1 << 1),
+        AVMetadataObjectTypeEAN8Code: @(1 << 2),
+        AVMetadataObjectTypeUPCECode: @(1 << 4),
+        AVMetadataObjectTypeCode128Code: @(1 << 5),
+        AVMetadataObjectTypeCode39Code: @(1 << 6),
+        AVMetadataObjectTypePDF417Code: @(1 << 7),
+        AVMetadataObjectTypeAztecCode: @(1 << 8),
+        AVMetadataObjectTypeDataMatrixCode: @(1 << 9),
+    };
+}
+
+@interface MatchaBarcodeScannerView ()
+@property (nonatomic, strong) AVCaptureMetadataOutput *metadataOutput;
+@property (nonatomic, strong) CAShapeLayer *overlayLayer;
+@property (nonatomic, assign) long long formats;
+@property (nonatomic, assign) CGRect region;
+@property (nonatomic, assign) BOOL paused;
+@end
+
+@implementation MatchaBarcodeScannerView
+
++ (void)load {
+    [MatchaViewController registerView:@"gomatcha.io/matcha/view/barcodescanner" block:^(MatchaViewNode *node){
+        return [[MatchaBarcodeScannerView alloc] initWithViewNode:node];
+    }];
+}
+
+- (id)initWithViewNode:(MatchaViewNode *)viewNode {
+    if ((self = [super initWithViewNode:viewNode])) {
+        self.metadataOutput = [[AVCaptureMetadataOutput alloc] init];
+        [self.metadataOutput setMetadataObjectsDelegate:self queue:dispatch_get_main_queue()];
+        
+        self.overlayLayer = [CAShapeLayer layer];
+        self.overlayLayer.fillRule = kCAFillRuleEvenOdd;
+        self.overlayLayer.fillColor = nil;
+        [self.layer addSublayer:self.overlayLayer];
+    }
+    return self;
+}
+
+- (void)configure {
+    [super configure];
+    dispatch_async(self.sessionQueue, ^{
+        [self.session beginConfiguration];
+        if ([self.session canAddOutput:self.metadataOutput]) {
+            [self.session addOutput:self.metadataOutput];
+        }
+        [self.session commitConfiguration];
+    });
+}
+
+- (void)updateState {
+    [super updateState];
+    NSArray<MatchaGoValue *> *state = [self.viewNode call:@"ScannerState", nil];
+    if (state.count < 7) {
+        return;
+    }
+    long long formats = state[0].toLongLong;
+    self.region = CGRectMake(state[1].toDouble, state[2].toDouble, state[3].toDouble, state[4].toDouble);
+    long long overlayColor = state[5].toLongLong;
+    self.paused = state[6].toBool;
+    
+    self.overlayLayer.fillColor = overlayColor == -1 ? nil : MatchaBarcodeScannerColor(overlayColor).CGColor;
+    if (formats != self.formats) {
+        self.formats = formats;
+        NSDictionary<AVMetadataObjectType, NSNumber *> *all = MatchaBarcodeScannerFormats();
+        // The camera's input is added on the session queue, which the
+        // available types depend on, so the types are set after it.
+        dispatch_async(self.sessionQueue, ^{
+            NSMutableArray<AVMetadataObjectType> *types = [NSMutableArray array];
+            NSArray<AVMetadataObjectType> *available = self.metadataOutput.availableMetadataObjectTypes;
+            for (AVMetadataObjectType type in all) {
+                if ((formats & all[type].longLongValue) != 0 && [available containsObject:type]) {
+                    [types addObject:type];
+                }
+            }
+            self.metadataOutput.metadataObjectTypes = types;
+        });
+    }
+    [self setNeedsLayout];
+}
+
+- (void)layoutSubviews {
+    [super layoutSubviews];
+    
+    UIBezierPath *path = [UIBezierPath bezierPathWithRect:self.bounds];
+    if (!CGRectIsEmpty(self.region)) {
+        [path appendPath:[UIBezierPath bezierPathWithRect:self.region]];
+    }
+    self.overlayLayer.frame = self.bounds;
+    self.overlayLayer.path = path.CGPath;
+    
+    // The rect of interest is in the coordinates of the camera, which are
+    // rotated and scaled relative to the view.
+    CGRect rect = CGRectMake(0, 0, 1, 1);
+    if (!CGRectIsEmpty(self.region) && !CGRectIsEmpty(self.bounds)) {
+        rect = [(AVCaptureVideoPreviewLayer *)self.layer metadataOutputRectOfInterestForRect:self.region];
+    }
+    dispatch_async(self.sessionQueue, ^{
+        self.metadataOutput.rectOfInterest = rect;
+    });
+}
+
+- (void)captureOutput:(AVCaptureOutput *)output didOutputMetadataObjects:(NSArray<__kindof AVMetadataObject *> *)metadataObjects fromConnection:(AVCaptureConnection *)connection {
+    if (self.paused) {
+        return;
+    }
+    NSDictionary<AVMetadataObjectType, NSNumber *> *all = MatchaBarcodeScannerFormats();
+    AVCaptureVideoPreviewLayer *layer = (AVCaptureVideoPreviewLayer *)self.layer;
+    for (AVMetadataObject *object in metadataObjects) {
+        if (![object isKindOfClass:[AVMetadataMachineReadableCodeObject class]]) {
+            continue;
+        }
+        NSString *value = ((AVMetadataMachineReadableCodeObject *)object).stringValue;
+        NSNumber *format = all[object.type];
+        if (value == nil || format == nil) {
+            continue;
+        }
+        CGRect bounds = [layer transformedMetadataObjectForMetadataObject:object].bounds;
+        [self.viewNode call:@"OnScan",
+            [[MatchaGoValue alloc] initWithLongLong:format.longLongValue],
+            [[MatchaGoValue alloc] initWithString:value],
+            [[MatchaGoValue alloc] initWithDouble:bounds.origin.x],
+            [[MatchaGoValue alloc] initWithDouble:bounds.origin.y],
+            [[MatchaGoValue alloc] initWithDouble:bounds.size.width],
+            [[MatchaGoValue alloc] initWithDouble:bounds.size.height],
+            nil];
+    }
+}
+
+@end
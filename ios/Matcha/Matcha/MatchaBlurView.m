@@ -0,0 +1,125 @@
+#import "MatchaBlurView.h"
+#import "MatchaViewController.h"
+
+@interface MatchaBlurView ()
+@property (nonatomic, strong) NSArray<UIView *> *childViews;
+@property (nonatomic, strong) UIView *contentView;
+@property (nonatomic, strong) UIView *overlayView;
+@property (nonatomic, strong) UIVisualEffectView *effectView;
+@property (nonatomic, strong) UIVisualEffectView *vibrancyView;
+@property (nonatomic, assign) long long style;
+@property (nonatomic, assign) BOOL needsUpdate;
+@end
+
+@implementation MatchaBlurView
+
++ (void)load {
+    [MatchaViewController registerView:@"gomatcha.io/matcha/view/blurview" block:^(MatchaViewNode *node){
+        return [[MatchaBlurView alloc] initWithViewNode:node];
+    }];
+}
+
+- (id)initWithViewNode:(MatchaViewNode *)viewNode {
+    if ((self = [super initWithFrame:CGRectZero])) {
+        self.viewNode = viewNode;
+        self.style = -1;
+        self.effectView = [[UIVisualEffectView alloc] initWithEffect:nil];
+        [self addSubview:self.effectView];
+    }
+    return self;
+}
+
+- (void)setNativeState:(NSData *)nativeState {
+    // Go can't be called while it is updating the views, so the state is read on
+    // the next layout pass.
+    self.needsUpdate = YES;
+    [self setNeedsLayout];
+}
+
+- (void)setMatchaChildViews:(NSArray<UIView *> *)childViews {
+    self.childViews = childViews;
+    self.needsUpdate = YES;
+    [self setNeedsLayout];
+}
+
+- (void)layoutSubviews {
+    [super layoutSubviews];
+    if (self.needsUpdate) {
+        self.needsUpdate = NO;
+        [self update];
+    }
+    self.effectView.frame = self.bounds;
+    self.vibrancyView.frame = self.effectView.contentView.bounds;
+    self.contentView.frame = (CGRect){CGPointZero, self.contentView.frame.size};
+    self.overlayView.frame = (CGRect){CGPointZero, self.overlayView.frame.size};
+}
+
+- (void)update {
+    NSArray<MatchaGoValue *> *state = [self.viewNode call:@"State", nil];
+    long long style = state[0].toLongLong;
+    BOOL vibrancy = state[2].toBool;
+    NSInteger contentIdx = (NSInteger)state[3].toLongLong;
+    NSInteger overlayIdx = (NSInteger)state[4].toLongLong;
+    
+    UIBlurEffect *blur = nil;
+    if (style != self.style || (vibrancy != (self.vibrancyView != nil))) {
+        self.style = style;
+        blur = [UIBlurEffect effectWithStyle:[self blurEffectStyle:style]];
+        self.effectView.effect = blur;
+        
+        [self.vibrancyView removeFromSuperview];
+        self.vibrancyView = nil;
+        if (vibrancy) {
+            self.vibrancyView = [[UIVisualEffectView alloc] initWithEffect:[UIVibrancyEffect effectForBlurEffect:blur]];
+            [self.effectView.contentView addSubview:self.vibrancyView];
+        }
+        // The overlay moves into the new vibrancy view.
+        [self.overlayView removeFromSuperview];
+        self.overlayView = nil;
+    }
+    
+    UIView *contentView = contentIdx >= 0 && contentIdx < self.childViews.count ? self.childViews[contentIdx] : nil;
+    if (self.contentView != contentView) {
+        [self.contentView removeFromSuperview];
+        self.contentView = contentView;
+        if (contentView != nil) {
+            [self insertSubview:contentView belowSubview:self.effectView];
+        }
+    }
+    
+    UIView *overlayView = overlayIdx >= 0 && overlayIdx < self.childViews.count ? self.childViews[overlayIdx] : nil;
+    if (self.overlayView != overlayView) {
+        [self.overlayView removeFromSuperview];
+        self.overlayView = overlayView;
+        if (overlayView != nil) {
+            UIView *parent = self.vibrancyView != nil ? self.vibrancyView.contentView : self.effectView.contentView;
+            [parent addSubview:overlayView];
+        }
+    }
+}
+
+- (UIBlurEffectStyle)blurEffectStyle:(long long)style {
+    switch (style) {
+    case 1:
+        return UIBlurEffectStyleLight;
+    case 2:
+        return UIBlurEffectStyleDark;
+    case 3:
+        if (@available(iOS 13.0, *)) {
+            return UIBlurEffectStyleSystemThinMaterial;
+        }
+        return UIBlurEffectStyleExtraLight;
+    case 4:
+        if (@available(iOS 13.0, *)) {
+            return UIBlurEffectStyleSystemThickMaterial;
+        }
+        return UIBlurEffectStyleProminent;
+    default:
+        if (@available(iOS 13.0, *)) {
+            return UIBlurEffectStyleSystemMaterial;
+        }
+        return UIBlurEffectStyleRegular;
+    }
+}
+
+@end
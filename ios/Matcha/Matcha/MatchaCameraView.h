@@ -0,0 +1,14 @@
+#import <UIKit/UIKit.h>
+#import <AVFoundation/AVFoundation.h>
+#import "MatchaView.h"
+
+@interface MatchaCameraView : UIView <MatchaChildView, AVCaptureVideoDataOutputSampleBufferDelegate, AVCapturePhotoCaptureDelegate>
+@property (nonatomic, weak) MatchaViewNode *viewNode;
+// Subclasses add outputs to the session on sessionQueue.
+@property (nonatomic, strong, readonly) AVCaptureSession *session;
+@property (nonatomic, strong, readonly) dispatch_queue_t sessionQueue;
+- (id)initWithViewNode:(MatchaViewNode *)viewNode;
+- (void)updateState;
+- (void)configure;
+- (void)callError:(NSString *)message;
+@end
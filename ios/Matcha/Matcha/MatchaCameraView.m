@@ -0,0 +1,240 @@
+#import "MatchaCameraView.h"
+#import "MatchaViewController_Private.h"
+#import "MatchaView_Private.h"
+
+@interface MatchaCameraView ()
+@property (nonatomic, strong) AVCaptureSession *session;
+@property (nonatomic, strong) AVCaptureDeviceInput *input;
+@property (nonatomic, strong) AVCapturePhotoOutput *photoOutput;
+@property (nonatomic, strong) AVCaptureVideoDataOutput *videoOutput;
+@property (nonatomic, strong) dispatch_queue_t sessionQueue;
+@property (nonatomic, strong) dispatch_queue_t frameQueue;
+@property (nonatomic, strong) MatchaGoValue *onFrame;
+@property (nonatomic, strong) NSMutableData *frameData; // Reused for every frame, only accessed on frameQueue.
+@property (nonatomic, assign) BOOL needsState;
+@property (nonatomic, assign) long long position;
+@property (nonatomic, assign) BOOL torch;
+@property (atomic, assign) long long viewId;
+@property (atomic, assign) long long frameWidth;
+@property (nonatomic, assign) long long capture;
+@property (nonatomic, assign) BOOL configured;
+@end
+
+@implementation MatchaCameraView
+
++ (void)load {
+    [MatchaViewController registerView:@"gomatcha.io/matcha/view/camera" block:^(MatchaViewNode *node){
+        return [[MatchaCameraView alloc] initWithViewNode:node];
+    }];
+}
+
++ (Class)layerClass {
+    return [AVCaptureVideoPreviewLayer class];
+}
+
+- (id)initWithViewNode:(MatchaViewNode *)viewNode {
+    if ((self = [super initWithFrame:CGRectZero])) {
+        self.viewNode = viewNode;
+        self.session = [[AVCaptureSession alloc] init];
+        self.session.sessionPreset = AVCaptureSessionPresetHigh;
+        self.sessionQueue = dispatch_queue_create("io.gomatcha.camera.session", DISPATCH_QUEUE_SERIAL);
+        self.frameQueue = dispatch_queue_create("io.gomatcha.camera.frames", DISPATCH_QUEUE_SERIAL);
+        self.onFrame = [[MatchaGoValue alloc] initWithFunc:@"gomatcha.io/matcha/view/camera onFrame"];
+        self.frameData = [NSMutableData data];
+        self.position = -1;
+        
+        AVCaptureVideoPreviewLayer *layer = (AVCaptureVideoPreviewLayer *)self.layer;
+        layer.session = self.session;
+        layer.videoGravity = AVLayerVideoGravityResizeAspectFill;
+    }
+    return self;
+}
+
+- (void)setNativeState:(NSData *)nativeState {
+    // Go can't be called while it is updating the views, so the state is read
+    // once the update completes.
+    if (self.needsState) {
+        return;
+    }
+    self.needsState = YES;
+    dispatch_async(dispatch_get_main_queue(), ^{
+        self.needsState = NO;
+        [self updateState];
+    });
+}
+
+- (void)updateState {
+    NSArray<MatchaGoValue *> *state = [self.viewNode call:@"State", nil];
+    if (state.count < 5) {
+        return;
+    }
+    self.viewId = state[0].toLongLong;
+    long long position = state[1].toLongLong;
+    BOOL torch = state[2].toBool;
+    long long frameWidth = state[3].toLongLong;
+    long long capture = state[4].toLongLong;
+    
+    if (!self.configured) {
+        self.configured = YES;
+        self.capture = capture;
+        [self configure];
+    }
+    if (position != self.position) {
+        self.position = position;
+        [self setCameraPosition:position == 1 ? AVCaptureDevicePositionFront : AVCaptureDevicePositionBack];
+    }
+    if (torch != self.torch) {
+        self.torch = torch;
+        [self setTorchOn:torch];
+    }
+    self.frameWidth = frameWidth;
+    if (capture != self.capture) {
+        self.capture = capture;
+        [self capturePhoto];
+    }
+}
+
+- (void)configure {
+    AVAuthorizationStatus status = [AVCaptureDevice authorizationStatusForMediaType:AVMediaTypeVideo];
+    if (status == AVAuthorizationStatusDenied || status == AVAuthorizationStatusRestricted) {
+        [self callError:@"camera: permission denied"];
+        return;
+    }
+    if (status == AVAuthorizationStatusNotDetermined) {
+        dispatch_suspend(self.sessionQueue);
+        [AVCaptureDevice requestAccessForMediaType:AVMediaTypeVideo completionHandler:^(BOOL granted) {
+            if (!granted) {
+                dispatch_async(dispatch_get_main_queue(), ^{
+                    [self callError:@"camera: permission denied"];
+                });
+            }
+            dispatch_resume(self.sessionQueue);
+        }];
+    }
+    
+    dispatch_async(self.sessionQueue, ^{
+        [self.session beginConfiguration];
+        self.photoOutput = [[AVCapturePhotoOutput alloc] init];
+        if ([self.session canAddOutput:self.photoOutput]) {
+            [self.session addOutput:self.photoOutput];
+        }
+        self.videoOutput = [[AVCaptureVideoDataOutput alloc] init];
+        self.videoOutput.alwaysDiscardsLateVideoFrames = YES;
+        self.videoOutput.videoSettings = @{(id)kCVPixelBufferPixelFormatTypeKey: @(kCVPixelFormatType_420YpCbCr8BiPlanarFullRange)};
+        [self.videoOutput setSampleBufferDelegate:self queue:self.frameQueue];
+        if ([self.session canAddOutput:self.videoOutput]) {
+            [self.session addOutput:self.videoOutput];
+        }
+        [self.session commitConfiguration];
+    });
+}
+
+- (void)setCameraPosition:(AVCaptureDevicePosition)position {
+    dispatch_async(self.sessionQueue, ^{
+        AVCaptureDevice *device = [AVCaptureDevice defaultDeviceWithDeviceType:AVCaptureDeviceTypeBuiltInWideAngleCamera mediaType:AVMediaTypeVideo position:position];
+        NSError *error = nil;
+        AVCaptureDeviceInput *input = device ? [AVCaptureDeviceInput deviceInputWithDevice:device error:&error] : nil;
+        if (input == nil) {
+            dispatch_async(dispatch_get_main_queue(), ^{
+                [self callError:error.localizedDescription ?: @"camera: no camera available"];
+            });
+            return;
+        }
+        [self.session beginConfiguration];
+        if (self.input) {
+            [self.session removeInput:self.input];
+        }
+        if ([self.session canAddInput:input]) {
+            [self.session addInput:input];
+            self.input = input;
+        }
+        [self.session commitConfiguration];
+    });
+}
+
+- (void)setTorchOn:(BOOL)on {
+    dispatch_async(self.sessionQueue, ^{
+        AVCaptureDevice *device = self.input.device;
+        if (!device.hasTorch || ![device lockForConfiguration:nil]) {
+            return;
+        }
+        device.torchMode = on ? AVCaptureTorchModeOn : AVCaptureTorchModeOff;
+        [device unlockForConfiguration];
+    });
+}
+
+- (void)capturePhoto {
+    dispatch_async(self.sessionQueue, ^{
+        if (self.input == nil) {
+            dispatch_async(dispatch_get_main_queue(), ^{
+                [self callError:@"camera: not running"];
+            });
+            return;
+        }
+        AVCapturePhotoSettings *settings = [AVCapturePhotoSettings photoSettingsWithFormat:@{AVVideoCodecKey: AVVideoCodecJPEG}];
+        [self.photoOutput capturePhotoWithSettings:settings delegate:self];
+    });
+}
+
+- (void)captureOutput:(AVCapturePhotoOutput *)output didFinishProcessingPhoto:(AVCapturePhoto *)photo error:(NSError *)error {
+    NSData *data = error ? nil : photo.fileDataRepresentation;
+    dispatch_async(dispatch_get_main_queue(), ^{
+        if (data == nil) {
+            [self callError:error.localizedDescription ?: @"camera: capture failed"];
+            return;
+        }
+        [self.viewNode call:@"OnPhoto", [[MatchaGoValue alloc] initWithData:data], nil];
+    });
+}
+
+- (void)captureOutput:(AVCaptureOutput *)output didOutputSampleBuffer:(CMSampleBufferRef)sampleBuffer fromConnection:(AVCaptureConnection *)connection {
+    long long frameWidth = self.frameWidth;
+    if (frameWidth <= 0) {
+        return;
+    }
+    CVPixelBufferRef pixelBuffer = CMSampleBufferGetImageBuffer(sampleBuffer);
+    CVPixelBufferLockBaseAddress(pixelBuffer, kCVPixelBufferLock_ReadOnly);
+    
+    // Downsample the luminance plane by skipping pixels.
+    size_t width = CVPixelBufferGetWidthOfPlane(pixelBuffer, 0);
+    size_t height = CVPixelBufferGetHeightOfPlane(pixelBuffer, 0);
+    size_t stride = CVPixelBufferGetBytesPerRowOfPlane(pixelBuffer, 0);
+    const uint8_t *src = CVPixelBufferGetBaseAddressOfPlane(pixelBuffer, 0);
+    size_t step = MAX((width + frameWidth - 1) / frameWidth, 1);
+    size_t w = width / step;
+    size_t h = height / step;
+    self.frameData.length = w * h;
+    uint8_t *dst = self.frameData.mutableBytes;
+    for (size_t y = 0; y < h; y++) {
+        const uint8_t *row = src + y * step * stride;
+        for (size_t x = 0; x < w; x++) {
+            dst[y * w + x] = row[x * step];
+        }
+    }
+    CVPixelBufferUnlockBaseAddress(pixelBuffer, kCVPixelBufferLock_ReadOnly);
+    
+    [self.onFrame call:nil,
+        [[MatchaGoValue alloc] initWithLongLong:self.viewId],
+        [[MatchaGoValue alloc] initWithLongLong:w],
+        [[MatchaGoValue alloc] initWithLongLong:h],
+        [[MatchaGoValue alloc] initWithBytesNoCopy:dst length:w * h],
+        nil];
+}
+
+- (void)callError:(NSString *)message {
+    [self.viewNode call:@"OnError", [[MatchaGoValue alloc] initWithString:message], nil];
+}
+
+- (void)didMoveToWindow {
+    [super didMoveToWindow];
+    BOOL visible = self.window != nil;
+    dispatch_async(self.sessionQueue, ^{
+        if (visible && !self.session.running) {
+            [self.session startRunning];
+        } else if (!visible && self.session.running) {
+            [self.session stopRunning];
+        }
+    });
+}
+
+@end
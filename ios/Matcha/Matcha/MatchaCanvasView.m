@@ -0,0 +1,155 @@
+#import "MatchaCanvasView.h"
+#import "MatchaViewController.h"
+#import "MatchaProtobuf.h"
+
+static UIColor *MatchaCanvasColor(NSArray *op) {
+    return [UIColor colorWithRed:[op[1] doubleValue] green:[op[2] doubleValue] blue:[op[3] doubleValue] alpha:[op[4] doubleValue]];
+}
+
+@implementation MatchaCanvasView
+
++ (void)load {
+    [MatchaViewController registerView:@"gomatcha.io/matcha/view/canvas" block:^(MatchaViewNode *node){
+        return [[MatchaCanvasView alloc] initWithViewNode:node];
+    }];
+}
+
+- (id)initWithViewNode:(MatchaViewNode *)viewNode {
+    if ((self = [super initWithFrame:CGRectZero])) {
+        self.viewNode = viewNode;
+        self.opaque = NO;
+        self.contentMode = UIViewContentModeRedraw;
+    }
+    return self;
+}
+
+- (void)setNativeState:(NSData *)nativeState {
+    // Go can't be called while it is updating the views, so the display list is
+    // read when the view is drawn.
+    [self setNeedsDisplay];
+}
+
+- (void)drawRect:(CGRect)rect {
+    CGSize size = self.bounds.size;
+    MatchaGoValue *width = [[MatchaGoValue alloc] initWithDouble:size.width];
+    MatchaGoValue *height = [[MatchaGoValue alloc] initWithDouble:size.height];
+    NSData *data = [self.viewNode call:@"Draw", width, height, nil][0].toData;
+    NSArray<NSArray *> *ops = data == nil ? nil : [NSJSONSerialization JSONObjectWithData:data options:0 error:nil];
+    if (![ops isKindOfClass:[NSArray class]]) {
+        return;
+    }
+    
+    CGContextRef ctx = UIGraphicsGetCurrentContext();
+    CGMutablePathRef path = CGPathCreateMutable();
+    
+    // The fill color, font, text alignment and direction aren't saved by CGContextSaveGState,
+    // since text is drawn with UIKit, so they are kept in a separate stack.
+    UIColor *fillColor = [UIColor blackColor];
+    UIFont *font = [UIFont systemFontOfSize:[UIFont systemFontSize]];
+    NSInteger textAlign = 0;
+    NSInteger direction = 0;
+    NSMutableArray *stack = [NSMutableArray array];
+    CGContextSetFillColorWithColor(ctx, fillColor.CGColor);
+    CGContextSetStrokeColorWithColor(ctx, fillColor.CGColor);
+    
+    for (NSArray *op in ops) {
+        if (![op isKindOfClass:[NSArray class]] || op.count == 0) {
+            continue;
+        }
+        NSString *name = op[0];
+        double (^arg)(NSInteger) = ^double(NSInteger i) {
+            return i < op.count ? [op[i] doubleValue] : 0;
+        };
+        if ([name isEqual:@"save"]) {
+            CGContextSaveGState(ctx);
+            [stack addObject:@[fillColor, font, @(textAlign), @(direction)]];
+        } else if ([name isEqual:@"restore"]) {
+            if (stack.count > 0) {
+                CGContextRestoreGState(ctx);
+                fillColor = stack.lastObject[0];
+                font = stack.lastObject[1];
+                textAlign = [stack.lastObject[2] integerValue];
+                direction = [stack.lastObject[3] integerValue];
+                [stack removeLastObject];
+            }
+        } else if ([name isEqual:@"translate"]) {
+            CGContextTranslateCTM(ctx, arg(1), arg(2));
+        } else if ([name isEqual:@"scale"]) {
+            CGContextScaleCTM(ctx, arg(1), arg(2));
+        } else if ([name isEqual:@"rotate"]) {
+            CGContextRotateCTM(ctx, arg(1));
+        } else if ([name isEqual:@"begin"]) {
+            CGPathRelease(path);
+            path = CGPathCreateMutable();
+        } else if ([name isEqual:@"move"]) {
+            CGPathMoveToPoint(path, NULL, arg(1), arg(2));
+        } else if ([name isEqual:@"line"]) {
+            if (CGPathIsEmpty(path)) {
+                CGPathMoveToPoint(path, NULL, arg(1), arg(2));
+            } else {
+                CGPathAddLineToPoint(path, NULL, arg(1), arg(2));
+            }
+        } else if ([name isEqual:@"quad"]) {
+            CGPathAddQuadCurveToPoint(path, NULL, arg(1), arg(2), arg(3), arg(4));
+        } else if ([name isEqual:@"cubic"]) {
+            CGPathAddCurveToPoint(path, NULL, arg(1), arg(2), arg(3), arg(4), arg(5), arg(6));
+        } else if ([name isEqual:@"arc"]) {
+            CGPathAddRelativeArc(path, NULL, arg(1), arg(2), arg(3), arg(4), arg(5));
+        } else if ([name isEqual:@"rect"]) {
+            CGPathAddRect(path, NULL, CGRectMake(arg(1), arg(2), arg(3), arg(4)));
+        } else if ([name isEqual:@"ellipse"]) {
+            CGPathAddEllipseInRect(path, NULL, CGRectMake(arg(1), arg(2), arg(3), arg(4)));
+        } else if ([name isEqual:@"close"]) {
+            if (!CGPathIsEmpty(path)) {
+                CGPathCloseSubpath(path);
+            }
+        } else if ([name isEqual:@"fillColor"]) {
+            fillColor = MatchaCanvasColor(op);
+            CGContextSetFillColorWithColor(ctx, fillColor.CGColor);
+        } else if ([name isEqual:@"strokeColor"]) {
+            CGContextSetStrokeColorWithColor(ctx, MatchaCanvasColor(op).CGColor);
+        } else if ([name isEqual:@"lineWidth"]) {
+            CGContextSetLineWidth(ctx, arg(1));
+        } else if ([name isEqual:@"lineCap"]) {
+            CGLineCap caps[] = {kCGLineCapButt, kCGLineCapRound, kCGLineCapSquare};
+            CGContextSetLineCap(ctx, caps[MIN(MAX((NSInteger)arg(1), 0), 2)]);
+        } else if ([name isEqual:@"lineJoin"]) {
+            CGLineJoin joins[] = {kCGLineJoinMiter, kCGLineJoinRound, kCGLineJoinBevel};
+            CGContextSetLineJoin(ctx, joins[MIN(MAX((NSInteger)arg(1), 0), 2)]);
+        } else if ([name isEqual:@"font"]) {
+            font = [UIFont matchaFontWithName:op[1] size:arg(2)] ?: [UIFont systemFontOfSize:arg(2)];
+        } else if ([name isEqual:@"textAlign"]) {
+            textAlign = (NSInteger)arg(1);
+        } else if ([name isEqual:@"direction"]) {
+            direction = (NSInteger)arg(1);
+        } else if ([name isEqual:@"fill"]) {
+            CGContextAddPath(ctx, path);
+            CGContextFillPath(ctx);
+        } else if ([name isEqual:@"stroke"]) {
+            CGContextAddPath(ctx, path);
+            CGContextStrokePath(ctx);
+        } else if ([name isEqual:@"clip"]) {
+            CGContextAddPath(ctx, path);
+            CGContextClip(ctx);
+        } else if ([name isEqual:@"text"]) {
+            NSString *str = op[1];
+            NSMutableParagraphStyle *paragraphStyle = [[NSMutableParagraphStyle alloc] init];
+            paragraphStyle.baseWritingDirection = direction == 1 ? NSWritingDirectionLeftToRight : direction == 2 ? NSWritingDirectionRightToLeft : NSWritingDirectionNatural;
+            NSDictionary *attr = @{NSFontAttributeName:font, NSForegroundColorAttributeName:fillColor, NSParagraphStyleAttributeName:paragraphStyle};
+            CGFloat x = arg(2);
+            if (textAlign == 1) {
+                x -= [str sizeWithAttributes:attr].width / 2;
+            } else if (textAlign == 2) {
+                x -= [str sizeWithAttributes:attr].width;
+            }
+            [str drawAtPoint:CGPointMake(x, arg(3)) withAttributes:attr];
+        }
+    }
+    
+    for (NSInteger i = 0; i < stack.count; i++) {
+        CGContextRestoreGState(ctx);
+    }
+    CGPathRelease(path);
+}
+
+@end
@@ -0,0 +1,11 @@
+#import <UIKit/UIKit.h>
+#import "MatchaView.h"
+
+@interface MatchaCarouselView : UIView <MatchaChildView, UIPageViewControllerDataSource, UIPageViewControllerDelegate>
+@property (nonatomic, weak) MatchaViewNode *viewNode;
+@end
+
+@interface MatchaCarouselPage : UIViewController
+@property (nonatomic, assign) NSInteger index;
+@property (nonatomic, strong) UIView *matchaView;
+@end
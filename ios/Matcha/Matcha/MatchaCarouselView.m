@@ -0,0 +1,162 @@
+#import "MatchaCarouselView.h"
+#import "MatchaViewController.h"
+
+@interface MatchaCarouselView ()
+@property (nonatomic, strong) UIPageViewController *pageViewController;
+@property (nonatomic, strong) NSArray<UIView *> *childViews;
+@property (nonatomic, strong) NSMutableDictionary<NSNumber *, MatchaCarouselPage *> *pages;
+@property (nonatomic, assign) NSInteger count;
+@property (nonatomic, assign) NSInteger current;
+@property (nonatomic, assign) BOOL needsUpdate;
+@end
+
+@implementation MatchaCarouselView
+
++ (void)load {
+    [MatchaViewController registerView:@"gomatcha.io/matcha/view/pagerview" block:^(MatchaViewNode *node){
+        return [[MatchaCarouselView alloc] initWithViewNode:node];
+    }];
+}
+
+- (id)initWithViewNode:(MatchaViewNode *)viewNode {
+    if ((self = [super initWithFrame:CGRectZero])) {
+        self.viewNode = viewNode;
+        self.pages = [NSMutableDictionary dictionary];
+        self.current = -1;
+        self.pageViewController = [[UIPageViewController alloc] initWithTransitionStyle:UIPageViewControllerTransitionStyleScroll navigationOrientation:UIPageViewControllerNavigationOrientationHorizontal options:nil];
+        self.pageViewController.dataSource = self;
+        self.pageViewController.delegate = self;
+        [self addSubview:self.pageViewController.view];
+    }
+    return self;
+}
+
+- (void)setNativeState:(NSData *)nativeState {
+    // Go can't be called while it is updating the views, so the pages are read
+    // on the next layout pass.
+    self.needsUpdate = YES;
+    [self setNeedsLayout];
+}
+
+- (void)setMatchaChildViews:(NSArray<UIView *> *)childViews {
+    self.childViews = childViews;
+    self.needsUpdate = YES;
+    [self setNeedsLayout];
+}
+
+- (void)layoutSubviews {
+    [super layoutSubviews];
+    self.pageViewController.view.frame = self.bounds;
+    if (self.needsUpdate) {
+        self.needsUpdate = NO;
+        [self update];
+    }
+}
+
+- (void)update {
+    NSArray<MatchaGoValue *> *state = [self.viewNode call:@"State", nil];
+    NSInteger count = (NSInteger)state[0].toLongLong;
+    NSInteger current = (NSInteger)state[1].toLongLong;
+    NSArray<MatchaGoValue *> *indexes = state[2].toArray;
+    
+    NSMutableDictionary<NSNumber *, UIView *> *views = [NSMutableDictionary dictionary];
+    for (NSInteger i = 0; i < indexes.count && i < self.childViews.count; i++) {
+        views[@(indexes[i].toLongLong)] = self.childViews[i];
+    }
+    
+    // Keep the pages that are built or displayed, and give them their views.
+    NSMutableDictionary<NSNumber *, MatchaCarouselPage *> *pages = [NSMutableDictionary dictionary];
+    for (MatchaCarouselPage *page in self.pageViewController.viewControllers) {
+        pages[@(page.index)] = page;
+    }
+    for (NSNumber *index in views) {
+        pages[index] = [self pageAtIndex:index.integerValue];
+    }
+    [pages removeObjectsForKeys:[pages keysOfEntriesPassingTest:^BOOL(NSNumber *key, id obj, BOOL *stop) {
+        return key.integerValue >= count;
+    }].allObjects];
+    self.pages = pages;
+    for (MatchaCarouselPage *page in self.pages.allValues) {
+        page.matchaView = views[@(page.index)];
+    }
+    
+    BOOL countChanged = count != self.count;
+    self.count = count;
+    if (count == 0) {
+        self.current = -1;
+        [self.pageViewController setViewControllers:@[[[UIViewController alloc] init]] direction:UIPageViewControllerNavigationDirectionForward animated:NO completion:nil];
+    } else if (current != self.current || countChanged) {
+        // Scroll to the page set by Go. The data source is reset when the count
+        // changes, since the neighbouring pages may have been added or removed.
+        BOOL animated = self.current != -1 && self.window != nil && current != self.current;
+        UIPageViewControllerNavigationDirection direction = current < self.current ? UIPageViewControllerNavigationDirectionReverse : UIPageViewControllerNavigationDirectionForward;
+        self.current = current;
+        [self.pageViewController setViewControllers:@[[self pageAtIndex:current]] direction:direction animated:animated completion:nil];
+    }
+}
+
+- (MatchaCarouselPage *)pageAtIndex:(NSInteger)index {
+    MatchaCarouselPage *page = self.pages[@(index)];
+    if (page == nil) {
+        // The page is built by Go once the user swipes to it.
+        page = [[MatchaCarouselPage alloc] init];
+        page.index = index;
+        self.pages[@(index)] = page;
+    }
+    return page;
+}
+
+#pragma mark - UIPageViewControllerDataSource
+
+- (UIViewController *)pageViewController:(UIPageViewController *)pageViewController viewControllerBeforeViewController:(UIViewController *)viewController {
+    if (![viewController isKindOfClass:[MatchaCarouselPage class]]) {
+        return nil;
+    }
+    NSInteger index = ((MatchaCarouselPage *)viewController).index - 1;
+    if (index < 0) {
+        return nil;
+    }
+    return [self pageAtIndex:index];
+}
+
+- (UIViewController *)pageViewController:(UIPageViewController *)pageViewController viewControllerAfterViewController:(UIViewController *)viewController {
+    if (![viewController isKindOfClass:[MatchaCarouselPage class]]) {
+        return nil;
+    }
+    NSInteger index = ((MatchaCarouselPage *)viewController).index + 1;
+    if (index >= self.count) {
+        return nil;
+    }
+    return [self pageAtIndex:index];
+}
+
+#pragma mark - UIPageViewControllerDelegate
+
+- (void)pageViewController:(UIPageViewController *)pageViewController didFinishAnimating:(BOOL)finished previousViewControllers:(NSArray<UIViewController *> *)previousViewControllers transitionCompleted:(BOOL)completed {
+    MatchaCarouselPage *page = (MatchaCarouselPage *)pageViewController.viewControllers.firstObject;
+    if (!completed || ![page isKindOfClass:[MatchaCarouselPage class]] || page.index == self.current) {
+        return;
+    }
+    self.current = page.index;
+    [self.viewNode call:@"OnChange", [[MatchaGoValue alloc] initWithLongLong:page.index], nil];
+}
+
+@end
+
+@implementation MatchaCarouselPage
+
+- (void)setMatchaView:(UIView *)matchaView {
+    if (_matchaView == matchaView) {
+        return;
+    }
+    // The view may have moved to another page already.
+    if (_matchaView.superview == self.view) {
+        [_matchaView removeFromSuperview];
+    }
+    _matchaView = matchaView;
+    if (matchaView != nil) {
+        [self.view addSubview:matchaView];
+    }
+}
+
+@end
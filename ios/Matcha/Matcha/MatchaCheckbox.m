@@ -0,0 +1,112 @@
+#import "MatchaCheckbox.h"
+#import "MatchaViewController.h"
+
+static UIColor *MatchaCheckboxColor(long long argb) {
+    return [UIColor colorWithRed:((argb >> 16) & 0xFF) / 255.0 green:((argb >> 8) & 0xFF) / 255.0 blue:(argb & 0xFF) / 255.0 alpha:((argb >> 24) & 0xFF) / 255.0];
+}
+
+static const CGFloat MatchaCheckboxSize = 22;
+
+@interface MatchaCheckbox ()
+@property (nonatomic, strong) CAShapeLayer *boxLayer;
+@property (nonatomic, strong) CAShapeLayer *checkLayer;
+@property (nonatomic, strong) UILabel *titleLabel;
+@property (nonatomic, assign) long long color;
+@property (nonatomic, assign) BOOL checked;
+@property (nonatomic, assign) BOOL needsUpdate;
+@end
+
+@implementation MatchaCheckbox
+
++ (void)load {
+    [MatchaViewController registerView:@"gomatcha.io/matcha/view/checkbox" block:^(MatchaViewNode *node){
+        return [[MatchaCheckbox alloc] initWithViewNode:node];
+    }];
+}
+
+- (id)initWithViewNode:(MatchaViewNode *)viewNode {
+    if ((self = [super initWithFrame:CGRectZero])) {
+        self.viewNode = viewNode;
+        self.color = -1;
+        
+        self.boxLayer = [CAShapeLayer layer];
+        self.boxLayer.lineWidth = 2;
+        self.boxLayer.path = [UIBezierPath bezierPathWithRoundedRect:CGRectMake(1, 1, MatchaCheckboxSize - 2, MatchaCheckboxSize - 2) cornerRadius:4].CGPath;
+        [self.layer addSublayer:self.boxLayer];
+        
+        UIBezierPath *check = [UIBezierPath bezierPath];
+        [check moveToPoint:CGPointMake(MatchaCheckboxSize * 0.25, MatchaCheckboxSize * 0.52)];
+        [check addLineToPoint:CGPointMake(MatchaCheckboxSize * 0.43, MatchaCheckboxSize * 0.7)];
+        [check addLineToPoint:CGPointMake(MatchaCheckboxSize * 0.76, MatchaCheckboxSize * 0.32)];
+        self.checkLayer = [CAShapeLayer layer];
+        self.checkLayer.path = check.CGPath;
+        self.checkLayer.lineWidth = 2.5;
+        self.checkLayer.lineCap = kCALineCapRound;
+        self.checkLayer.lineJoin = kCALineJoinRound;
+        self.checkLayer.fillColor = nil;
+        self.checkLayer.strokeColor = [UIColor whiteColor].CGColor;
+        [self.layer addSublayer:self.checkLayer];
+        
+        self.titleLabel = [[UILabel alloc] init];
+        self.titleLabel.font = [UIFont preferredFontForTextStyle:UIFontTextStyleBody];
+        [self addSubview:self.titleLabel];
+        
+        self.isAccessibilityElement = YES;
+        [self addTarget:self action:@selector(onTap:) forControlEvents:UIControlEventTouchUpInside];
+        [self updateColors];
+    }
+    return self;
+}
+
+- (void)setNativeState:(NSData *)nativeState {
+    // Go can't be called while it is updating the views, so the state is read
+    // on the next layout pass.
+    self.needsUpdate = YES;
+    [self setNeedsLayout];
+}
+
+- (void)layoutSubviews {
+    [super layoutSubviews];
+    if (self.needsUpdate) {
+        self.needsUpdate = NO;
+        [self update];
+    }
+    CGFloat y = (self.bounds.size.height - MatchaCheckboxSize) / 2;
+    self.boxLayer.frame = CGRectMake(0, y, MatchaCheckboxSize, MatchaCheckboxSize);
+    self.checkLayer.frame = self.boxLayer.frame;
+    CGFloat left = MatchaCheckboxSize + 12;
+    self.titleLabel.frame = CGRectMake(left, 0, MAX(self.bounds.size.width - left, 0), self.bounds.size.height);
+}
+
+- (void)update {
+    NSArray<MatchaGoValue *> *state = [self.viewNode call:@"State", nil];
+    self.checked = state[0].toBool;
+    self.enabled = state[1].toBool;
+    self.titleLabel.text = state[2].toString;
+    self.color = state[3].toLongLong;
+    self.accessibilityLabel = self.titleLabel.text;
+    [self updateColors];
+}
+
+- (void)tintColorDidChange {
+    [super tintColorDidChange];
+    [self updateColors];
+}
+
+- (void)updateColors {
+    UIColor *color = self.color == -1 ? self.tintColor : MatchaCheckboxColor(self.color);
+    UIColor *border = self.checked ? color : [UIColor colorWithWhite:0 alpha:0.54];
+    self.boxLayer.strokeColor = border.CGColor;
+    self.boxLayer.fillColor = self.checked ? color.CGColor : [UIColor clearColor].CGColor;
+    self.checkLayer.hidden = !self.checked;
+    self.alpha = self.enabled ? 1 : 0.4;
+    self.accessibilityTraits = UIAccessibilityTraitButton | (self.checked ? UIAccessibilityTraitSelected : 0) | (self.enabled ? 0 : UIAccessibilityTraitNotEnabled);
+}
+
+- (void)onTap:(id)sender {
+    self.checked = !self.checked;
+    [self updateColors];
+    [self.viewNode call:@"OnChange", [[MatchaGoValue alloc] initWithBool:self.checked], nil];
+}
+
+@end
@@ -0,0 +1,10 @@
+#import <UIKit/UIKit.h>
+#import "MatchaView.h"
+
+@interface MatchaCollectionView : UICollectionView <MatchaChildView, UICollectionViewDataSource, UICollectionViewDelegateFlowLayout>
+@property (nonatomic, weak) MatchaViewNode *viewNode;
+@end
+
+@interface MatchaCollectionViewCell : UICollectionViewCell
+@property (nonatomic, strong) UIView *matchaView;
+@end
@@ -0,0 +1,165 @@
+#import "MatchaCollectionView.h"
+#import "MatchaViewController_Private.h"
+#import "MatchaView_Private.h"
+
+@interface MatchaCollectionView ()
+@property (nonatomic, strong) UICollectionViewFlowLayout *flowLayout;
+@property (nonatomic, strong) NSArray<UIView *> *childViews;
+@property (nonatomic, strong) NSDictionary<NSNumber *, UIView *> *itemViews;
+@property (nonatomic, strong) NSMutableSet<NSString *> *reuseIds;
+@property (nonatomic, assign) BOOL needsReload;
+@property (nonatomic, assign) long long generation;
+@property (nonatomic, assign) NSInteger count;
+@property (nonatomic, assign) NSInteger firstVisibleItem;
+@property (nonatomic, assign) NSInteger lastVisibleItem;
+@end
+
+@implementation MatchaCollectionView
+
++ (void)load {
+    [MatchaViewController registerView:@"gomatcha.io/matcha/view/collectionview" block:^(MatchaViewNode *node){
+        return [[MatchaCollectionView alloc] initWithViewNode:node];
+    }];
+}
+
+- (id)initWithViewNode:(MatchaViewNode *)viewNode {
+    UICollectionViewFlowLayout *flowLayout = [[UICollectionViewFlowLayout alloc] init];
+    if ((self = [super initWithFrame:CGRectZero collectionViewLayout:flowLayout])) {
+        self.flowLayout = flowLayout;
+        self.viewNode = viewNode;
+        self.dataSource = self;
+        self.delegate = self;
+        self.backgroundColor = [UIColor clearColor];
+        self.reuseIds = [NSMutableSet set];
+        self.generation = -1;
+        self.firstVisibleItem = -1;
+        self.lastVisibleItem = -1;
+    }
+    return self;
+}
+
+- (void)setNativeState:(NSData *)nativeState {
+    // Go can't be called while it is updating the views, so the items are read
+    // on the next layout pass.
+    self.needsReload = YES;
+    [self setNeedsLayout];
+}
+
+- (void)setMatchaChildViews:(NSArray<UIView *> *)childViews {
+    self.childViews = childViews;
+    self.needsReload = YES;
+    [self setNeedsLayout];
+}
+
+- (void)layoutSubviews {
+    if (self.needsReload) {
+        self.needsReload = NO;
+        [self reloadItems];
+    }
+    [super layoutSubviews];
+    [self updateVisibleItems];
+}
+
+- (void)reloadItems {
+    NSArray<MatchaGoValue *> *rows = [self.viewNode call:@"Rows", nil];
+    NSInteger count = (NSInteger)rows[0].toLongLong;
+    long long generation = rows[1].toLongLong;
+    
+    NSArray<MatchaGoValue *> *childRows = [[self.viewNode call:@"ChildRows", nil][0] toArray];
+    NSMutableDictionary<NSNumber *, UIView *> *itemViews = [NSMutableDictionary dictionary];
+    for (NSInteger i = 0; i < childRows.count && i < self.childViews.count; i++) {
+        itemViews[@(childRows[i].toLongLong)] = self.childViews[i];
+    }
+    self.itemViews = itemViews;
+    
+    if (generation != self.generation || count != self.count) {
+        NSArray<MatchaGoValue *> *grid = [self.viewNode call:@"Grid", nil];
+        UICollectionViewScrollDirection direction = grid[1].toBool ? UICollectionViewScrollDirectionHorizontal : UICollectionViewScrollDirectionVertical;
+        if (self.flowLayout.scrollDirection != direction) {
+            self.flowLayout.scrollDirection = direction;
+        }
+        self.flowLayout.minimumInteritemSpacing = grid[2].toDouble;
+        self.flowLayout.minimumLineSpacing = grid[2].toDouble;
+        
+        self.generation = generation;
+        self.count = count;
+        [self reloadData];
+    } else {
+        for (NSIndexPath *i in self.indexPathsForVisibleItems) {
+            MatchaCollectionViewCell *cell = (MatchaCollectionViewCell *)[self cellForItemAtIndexPath:i];
+            cell.matchaView = itemViews[@(i.item)];
+        }
+    }
+}
+
+- (void)updateVisibleItems {
+    NSInteger first = NSIntegerMax;
+    NSInteger last = 0;
+    for (NSIndexPath *i in self.indexPathsForVisibleItems) {
+        first = MIN(first, i.item);
+        last = MAX(last, i.item + 1);
+    }
+    if (first == NSIntegerMax) {
+        first = 0;
+    }
+    if (first == self.firstVisibleItem && last == self.lastVisibleItem) {
+        return;
+    }
+    self.firstVisibleItem = first;
+    self.lastVisibleItem = last;
+    [self.viewNode call:@"OnVisible", [[MatchaGoValue alloc] initWithLongLong:first], [[MatchaGoValue alloc] initWithLongLong:last], nil];
+}
+
+- (NSInteger)collectionView:(UICollectionView *)collectionView numberOfItemsInSection:(NSInteger)section {
+    return self.count;
+}
+
+- (CGSize)collectionView:(UICollectionView *)collectionView layout:(UICollectionViewLayout *)collectionViewLayout sizeForItemAtIndexPath:(NSIndexPath *)indexPath {
+    NSArray<MatchaGoValue *> *item = [self.viewNode call:@"Item", [[MatchaGoValue alloc] initWithLongLong:indexPath.item], nil];
+    return CGSizeMake(item[2].toDouble, item[3].toDouble);
+}
+
+- (UICollectionViewCell *)collectionView:(UICollectionView *)collectionView cellForItemAtIndexPath:(NSIndexPath *)indexPath {
+    NSString *reuseId = [self.viewNode call:@"Item", [[MatchaGoValue alloc] initWithLongLong:indexPath.item], nil][0].toString;
+    if (![self.reuseIds containsObject:reuseId]) {
+        [self.reuseIds addObject:reuseId];
+        [self registerClass:[MatchaCollectionViewCell class] forCellWithReuseIdentifier:reuseId];
+    }
+    MatchaCollectionViewCell *cell = [collectionView dequeueReusableCellWithReuseIdentifier:reuseId forIndexPath:indexPath];
+    cell.matchaView = self.itemViews[@(indexPath.item)];
+    return cell;
+}
+
+- (void)collectionView:(UICollectionView *)collectionView didSelectItemAtIndexPath:(NSIndexPath *)indexPath {
+    [collectionView deselectItemAtIndexPath:indexPath animated:YES];
+    [self.viewNode call:@"OnSelect", [[MatchaGoValue alloc] initWithLongLong:indexPath.item], nil];
+}
+
+- (void)scrollViewDidScroll:(UIScrollView *)scrollView {
+    [self updateVisibleItems];
+}
+
+@end
+
+@implementation MatchaCollectionViewCell
+
+- (void)setMatchaView:(UIView *)matchaView {
+    if (_matchaView == matchaView) {
+        return;
+    }
+    // The view may have moved to another cell already.
+    if (_matchaView.superview == self.contentView) {
+        [_matchaView removeFromSuperview];
+    }
+    _matchaView = matchaView;
+    if (matchaView != nil) {
+        [self.contentView addSubview:matchaView];
+    }
+}
+
+- (void)prepareForReuse {
+    [super prepareForReuse];
+    self.matchaView = nil;
+}
+
+@end
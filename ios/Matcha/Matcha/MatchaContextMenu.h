@@ -0,0 +1,10 @@
+#import <UIKit/UIKit.h>
+#import "MatchaView.h"
+
+// MatchaContextMenu shows the context menu of a view when it is long-pressed.
+@interface MatchaContextMenu : NSObject <UIContextMenuInteractionDelegate>
+- (id)initWithViewNode:(MatchaViewNode *)viewNode;
+- (void)attachToView:(UIView *)view;
+- (void)detach;
+@property (nonatomic, weak) MatchaViewNode *viewNode;
+@end
@@ -0,0 +1,196 @@
+#import "MatchaContextMenu.h"
+#import "MatchaProtobuf.h"
+#import "MatchaView_Private.h"
+
+static NSString *const MatchaContextMenuKey = @"gomatcha.io/matcha/contextmenu";
+
+// Must match the flags in view/contextmenu.
+enum {
+    MatchaContextMenuFlagDestructive = 1 << 0,
+    MatchaContextMenuFlagDisabled = 1 << 1,
+    MatchaContextMenuFlagSubmenu = 1 << 2,
+};
+
+@interface MatchaContextMenuItem : NSObject
+@property (nonatomic, assign) NSInteger index;
+@property (nonatomic, strong) NSString *title;
+@property (nonatomic, assign) int64_t flags;
+@property (nonatomic, strong) UIImage *icon;
+@property (nonatomic, strong) NSMutableArray<MatchaContextMenuItem *> *children;
+@end
+
+@implementation MatchaContextMenuItem
+@end
+
+@interface MatchaContextMenu ()
+@property (nonatomic, weak) UIView *view;
+@property (nonatomic, strong) id interaction;
+@property (nonatomic, strong) UILongPressGestureRecognizer *longPress;
+@end
+
+@implementation MatchaContextMenu
+
+- (id)initWithViewNode:(MatchaViewNode *)viewNode {
+    if ((self = [super init])) {
+        self.viewNode = viewNode;
+    }
+    return self;
+}
+
+- (void)attachToView:(UIView *)view {
+    if (self.view == view) {
+        return;
+    }
+    [self detach];
+    self.view = view;
+    view.userInteractionEnabled = YES;
+    if (@available(iOS 13.0, *)) {
+        UIContextMenuInteraction *interaction = [[UIContextMenuInteraction alloc] initWithDelegate:self];
+        [view addInteraction:interaction];
+        self.interaction = interaction;
+    } else {
+        self.longPress = [[UILongPressGestureRecognizer alloc] initWithTarget:self action:@selector(onLongPress:)];
+        [view addGestureRecognizer:self.longPress];
+    }
+}
+
+- (void)detach {
+    if (@available(iOS 13.0, *)) {
+        if (self.interaction != nil) {
+            [self.view removeInteraction:self.interaction];
+        }
+    }
+    if (self.longPress != nil) {
+        [self.view removeGestureRecognizer:self.longPress];
+    }
+    self.interaction = nil;
+    self.longPress = nil;
+    self.view = nil;
+}
+
+// Returns the root items of the menu, and sets title to the menu's title.
+- (NSArray<MatchaContextMenuItem *> *)itemsWithTitle:(NSString **)title {
+    NSArray<MatchaGoValue *> *rlt = [self.viewNode call:[NSString stringWithFormat:@"%@ Items", MatchaContextMenuKey], nil];
+    if (rlt.count < 5) {
+        return @[];
+    }
+    *title = rlt[0].toString;
+    NSArray<MatchaGoValue *> *parents = rlt[1].toArray;
+    NSArray<MatchaGoValue *> *titles = rlt[2].toArray;
+    NSArray<MatchaGoValue *> *flags = rlt[3].toArray;
+    NSArray<MatchaGoValue *> *icons = rlt[4].toArray;
+    
+    NSMutableArray<MatchaContextMenuItem *> *items = [NSMutableArray array];
+    NSMutableArray<MatchaContextMenuItem *> *roots = [NSMutableArray array];
+    for (NSInteger i = 0; i < parents.count; i++) {
+        MatchaContextMenuItem *item = [[MatchaContextMenuItem alloc] init];
+        item.index = i;
+        item.title = titles[i].toString;
+        item.flags = flags[i].toLongLong;
+        item.children = [NSMutableArray array];
+        NSData *icon = icons[i].toData;
+        if (icon.length > 0) {
+            MatchaPBImageOrResource *pbIcon = [MatchaPBImageOrResource parseFromData:icon error:nil];
+            item.icon = [[UIImage alloc] initWithImageOrResourceProtobuf:pbIcon];
+        }
+        [items addObject:item];
+        
+        // Parents always come before their children.
+        NSInteger parent = (NSInteger)parents[i].toLongLong;
+        if (parent >= 0 && parent < i) {
+            [items[parent].children addObject:item];
+        } else {
+            [roots addObject:item];
+        }
+    }
+    return roots;
+}
+
+- (void)select:(MatchaContextMenuItem *)item {
+    [self.viewNode call:[NSString stringWithFormat:@"%@ OnSelect", MatchaContextMenuKey], [[MatchaGoValue alloc] initWithLongLong:item.index], nil];
+}
+
+#pragma mark - UIContextMenuInteractionDelegate
+
+- (UIMenuElement *)menuElementWithItem:(MatchaContextMenuItem *)item API_AVAILABLE(ios(13.0)) {
+    if (item.flags & MatchaContextMenuFlagSubmenu) {
+        NSMutableArray<UIMenuElement *> *children = [NSMutableArray array];
+        for (MatchaContextMenuItem *i in item.children) {
+            [children addObject:[self menuElementWithItem:i]];
+        }
+        UIMenuOptions options = (item.flags & MatchaContextMenuFlagDestructive) ? UIMenuOptionsDestructive : 0;
+        return [UIMenu menuWithTitle:item.title image:item.icon identifier:nil options:options children:children];
+    }
+    
+    __weak MatchaContextMenu *weakSelf = self;
+    UIAction *action = [UIAction actionWithTitle:item.title image:item.icon identifier:nil handler:^(UIAction *action) {
+        [weakSelf select:item];
+    }];
+    if (item.flags & MatchaContextMenuFlagDestructive) {
+        action.attributes |= UIMenuElementAttributesDestructive;
+    }
+    if (item.flags & MatchaContextMenuFlagDisabled) {
+        action.attributes |= UIMenuElementAttributesDisabled;
+    }
+    return action;
+}
+
+- (UIContextMenuConfiguration *)contextMenuInteraction:(UIContextMenuInteraction *)interaction configurationForMenuAtLocation:(CGPoint)location API_AVAILABLE(ios(13.0)) {
+    NSString *title = nil;
+    NSArray<MatchaContextMenuItem *> *items = [self itemsWithTitle:&title];
+    if (items.count == 0) {
+        return nil;
+    }
+    NSMutableArray<UIMenuElement *> *children = [NSMutableArray array];
+    for (MatchaContextMenuItem *i in items) {
+        [children addObject:[self menuElementWithItem:i]];
+    }
+    UIMenu *menu = [UIMenu menuWithTitle:title ?: @"" children:children];
+    return [UIContextMenuConfiguration configurationWithIdentifier:nil previewProvider:nil actionProvider:^UIMenu *(NSArray<UIMenuElement *> *suggestedActions) {
+        return menu;
+    }];
+}
+
+#pragma mark - Action sheet
+
+- (void)onLongPress:(UILongPressGestureRecognizer *)recognizer {
+    if (recognizer.state != UIGestureRecognizerStateBegan) {
+        return;
+    }
+    NSString *title = nil;
+    NSArray<MatchaContextMenuItem *> *items = [self itemsWithTitle:&title];
+    if (items.count > 0) {
+        [self presentItems:items title:title];
+    }
+}
+
+- (void)presentItems:(NSArray<MatchaContextMenuItem *> *)items title:(NSString *)title {
+    UIViewController *presenter = self.view.window.rootViewController;
+    while (presenter.presentedViewController != nil) {
+        presenter = presenter.presentedViewController;
+    }
+    if (presenter == nil) {
+        return;
+    }
+    
+    UIAlertController *alert = [UIAlertController alertControllerWithTitle:(title.length > 0 ? title : nil) message:nil preferredStyle:UIAlertControllerStyleActionSheet];
+    for (MatchaContextMenuItem *i in items) {
+        UIAlertActionStyle style = (i.flags & MatchaContextMenuFlagDestructive) ? UIAlertActionStyleDestructive : UIAlertActionStyleDefault;
+        __weak MatchaContextMenu *weakSelf = self;
+        UIAlertAction *action = [UIAlertAction actionWithTitle:i.title style:style handler:^(UIAlertAction *action) {
+            if (i.flags & MatchaContextMenuFlagSubmenu) {
+                [weakSelf presentItems:i.children title:i.title];
+            } else {
+                [weakSelf select:i];
+            }
+        }];
+        action.enabled = !(i.flags & MatchaContextMenuFlagDisabled);
+        [alert addAction:action];
+    }
+    [alert addAction:[UIAlertAction actionWithTitle:@"Cancel" style:UIAlertActionStyleCancel handler:nil]];
+    alert.popoverPresentationController.sourceView = self.view;
+    alert.popoverPresentationController.sourceRect = self.view.bounds;
+    [presenter presentViewController:alert animated:YES completion:nil];
+}
+
+@end
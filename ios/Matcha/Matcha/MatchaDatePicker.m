@@ -0,0 +1,78 @@
+#import "MatchaDatePicker.h"
+#import "MatchaViewController.h"
+
+@interface MatchaDatePicker ()
+@property (nonatomic, assign) BOOL needsUpdate;
+@end
+
+@implementation MatchaDatePicker
+
++ (void)load {
+    [MatchaViewController registerView:@"gomatcha.io/matcha/view/datepicker" block:^(MatchaViewNode *node){
+        return [[MatchaDatePicker alloc] initWithViewNode:node];
+    }];
+}
+
+- (id)initWithViewNode:(MatchaViewNode *)viewNode {
+    if ((self = [super initWithFrame:CGRectZero])) {
+        self.viewNode = viewNode;
+        if (@available(iOS 13.4, *)) {
+            self.preferredDatePickerStyle = UIDatePickerStyleWheels;
+        }
+        [self addTarget:self action:@selector(onChange:) forControlEvents:UIControlEventValueChanged];
+    }
+    return self;
+}
+
+- (void)setNativeState:(NSData *)nativeState {
+    // Go can't be called while it is updating the views, so the state is read on
+    // the next layout pass.
+    self.needsUpdate = YES;
+    [self setNeedsLayout];
+}
+
+- (void)layoutSubviews {
+    if (self.needsUpdate) {
+        self.needsUpdate = NO;
+        [self update];
+    }
+    [super layoutSubviews];
+}
+
+- (void)update {
+    NSArray<MatchaGoValue *> *state = [self.viewNode call:@"State", nil];
+    long long mode = state[0].toLongLong;
+    NSDate *date = [self dateWithMillis:state[1].toLongLong];
+    long long min = state[2].toLongLong;
+    long long max = state[3].toLongLong;
+    BOOL enabled = state[4].toBool;
+    
+    UIDatePickerMode datePickerMode = UIDatePickerModeDate;
+    if (mode == 1) {
+        datePickerMode = UIDatePickerModeTime;
+    } else if (mode == 2) {
+        datePickerMode = UIDatePickerModeDateAndTime;
+    }
+    if (self.datePickerMode != datePickerMode) {
+        self.datePickerMode = datePickerMode;
+    }
+    self.minimumDate = min != 0 ? [self dateWithMillis:min] : nil;
+    self.maximumDate = max != 0 ? [self dateWithMillis:max] : nil;
+    if (![self.date isEqual:date]) {
+        self.date = date;
+    }
+    if (self.enabled != enabled) {
+        self.enabled = enabled;
+    }
+}
+
+- (NSDate *)dateWithMillis:(long long)millis {
+    return [NSDate dateWithTimeIntervalSince1970:millis / 1000.0];
+}
+
+- (void)onChange:(id)sender {
+    long long millis = (long long)(self.date.timeIntervalSince1970 * 1000);
+    [self.viewNode call:@"OnChange", [[MatchaGoValue alloc] initWithLongLong:millis], nil];
+}
+
+@end
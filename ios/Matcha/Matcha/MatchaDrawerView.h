@@ -0,0 +1,6 @@
+#import <UIKit/UIKit.h>
+#import "MatchaView.h"
+
+@interface MatchaDrawerView : UIView <MatchaChildView, UIGestureRecognizerDelegate>
+@property (nonatomic, weak) MatchaViewNode *viewNode;
+@end
@@ -0,0 +1,187 @@
+#import "MatchaDrawerView.h"
+#import "MatchaViewController.h"
+
+static UIColor *MatchaDrawerColor(long long argb) {
+    return [UIColor colorWithRed:((argb >> 16) & 0xFF) / 255.0 green:((argb >> 8) & 0xFF) / 255.0 blue:(argb & 0xFF) / 255.0 alpha:((argb >> 24) & 0xFF) / 255.0];
+}
+
+@interface MatchaDrawerView ()
+@property (nonatomic, strong) UIView *contentView;
+@property (nonatomic, strong) UIView *scrimView;
+@property (nonatomic, strong) UIView *menuView;
+@property (nonatomic, strong) UIView *contentChild;
+@property (nonatomic, strong) UIView *menuChild;
+@property (nonatomic, strong) UIScreenEdgePanGestureRecognizer *edgePan;
+@property (nonatomic, strong) UIPanGestureRecognizer *pan;
+@property (nonatomic, assign) CGFloat menuWidth;
+@property (nonatomic, assign) CGFloat progress; // 0 while closed, 1 while open.
+@property (nonatomic, assign) BOOL open; // The last value read from or sent to Go.
+@property (nonatomic, assign) BOOL needsUpdate;
+@end
+
+@implementation MatchaDrawerView
+
++ (void)load {
+    [MatchaViewController registerView:@"gomatcha.io/matcha/view/android/drawer" block:^(MatchaViewNode *node){
+        return [[MatchaDrawerView alloc] initWithViewNode:node];
+    }];
+}
+
+- (id)initWithViewNode:(MatchaViewNode *)viewNode {
+    if ((self = [super initWithFrame:CGRectZero])) {
+        self.viewNode = viewNode;
+        self.clipsToBounds = YES;
+        
+        self.contentView = [[UIView alloc] init];
+        [self addSubview:self.contentView];
+        
+        self.scrimView = [[UIView alloc] init];
+        self.scrimView.backgroundColor = [UIColor colorWithWhite:0 alpha:0.4];
+        self.scrimView.alpha = 0;
+        self.scrimView.hidden = YES;
+        [self.scrimView addGestureRecognizer:[[UITapGestureRecognizer alloc] initWithTarget:self action:@selector(scrimTapped:)]];
+        [self addSubview:self.scrimView];
+        
+        self.menuView = [[UIView alloc] init];
+        self.menuView.layer.shadowColor = [UIColor blackColor].CGColor;
+        self.menuView.layer.shadowOpacity = 0;
+        self.menuView.layer.shadowRadius = 8;
+        [self addSubview:self.menuView];
+        
+        self.edgePan = [[UIScreenEdgePanGestureRecognizer alloc] initWithTarget:self action:@selector(panned:)];
+        self.edgePan.edges = UIRectEdgeLeft;
+        [self addGestureRecognizer:self.edgePan];
+        
+        self.pan = [[UIPanGestureRecognizer alloc] initWithTarget:self action:@selector(panned:)];
+        self.pan.delegate = self;
+        [self addGestureRecognizer:self.pan];
+    }
+    return self;
+}
+
+- (void)setNativeState:(NSData *)nativeState {
+    // Go can't be called while it is updating the views, so the state is read
+    // on the next layout pass.
+    self.needsUpdate = YES;
+    [self setNeedsLayout];
+}
+
+- (void)setMatchaChildViews:(NSArray<UIView *> *)childViews {
+    UIView *contentChild = childViews.count > 0 ? childViews[0] : nil;
+    if (contentChild != self.contentChild) {
+        [self.contentChild removeFromSuperview];
+        self.contentChild = contentChild;
+        if (contentChild != nil) {
+            [self.contentView addSubview:contentChild];
+        }
+    }
+    UIView *menuChild = childViews.count > 1 ? childViews[1] : nil;
+    if (menuChild != self.menuChild) {
+        [self.menuChild removeFromSuperview];
+        self.menuChild = menuChild;
+        if (menuChild != nil) {
+            [self.menuView addSubview:menuChild];
+        }
+    }
+    self.needsUpdate = YES;
+    [self setNeedsLayout];
+}
+
+- (void)layoutSubviews {
+    [super layoutSubviews];
+    if (self.needsUpdate) {
+        self.needsUpdate = NO;
+        [self update];
+    }
+    self.contentView.frame = self.bounds;
+    self.scrimView.frame = self.bounds;
+    [self applyProgress];
+}
+
+- (void)update {
+    NSArray<MatchaGoValue *> *state = [self.viewNode call:@"State", nil];
+    BOOL open = state[0].toBool;
+    self.menuWidth = state[1].toDouble;
+    self.scrimView.backgroundColor = MatchaDrawerColor(state[2].toLongLong);
+    BOOL locked = state[3].toBool;
+    self.edgePan.enabled = !locked;
+    self.pan.enabled = !locked;
+    
+    if (open != self.open) {
+        self.open = open;
+        [self animateToProgress:open ? 1 : 0 animated:self.window != nil];
+    }
+}
+
+- (void)applyProgress {
+    CGFloat height = self.bounds.size.height;
+    self.menuView.frame = CGRectMake(-self.menuWidth * (1 - self.progress), 0, self.menuWidth, height);
+    self.menuView.layer.shadowOpacity = self.progress > 0 ? 0.3 : 0;
+    self.scrimView.alpha = self.progress;
+    self.scrimView.hidden = self.progress == 0;
+}
+
+- (void)animateToProgress:(CGFloat)progress animated:(BOOL)animated {
+    self.progress = progress;
+    if (!animated) {
+        [self applyProgress];
+        return;
+    }
+    self.scrimView.hidden = NO;
+    [UIView animateWithDuration:0.25 delay:0 options:UIViewAnimationOptionCurveEaseOut | UIViewAnimationOptionBeginFromCurrentState animations:^{
+        [self applyProgress];
+    } completion:^(BOOL finished) {
+        self.scrimView.hidden = self.progress == 0;
+    }];
+}
+
+- (void)setOpenByUser:(BOOL)open {
+    [self animateToProgress:open ? 1 : 0 animated:YES];
+    if (open != self.open) {
+        self.open = open;
+        [self.viewNode call:@"OnChange", [[MatchaGoValue alloc] initWithBool:open], nil];
+    }
+}
+
+- (void)scrimTapped:(UITapGestureRecognizer *)recognizer {
+    [self setOpenByUser:NO];
+}
+
+- (void)panned:(UIPanGestureRecognizer *)recognizer {
+    if (self.menuWidth <= 0) {
+        return;
+    }
+    CGFloat translation = [recognizer translationInView:self].x;
+    CGFloat start = self.open ? 1 : 0;
+    switch (recognizer.state) {
+    case UIGestureRecognizerStateBegan:
+    case UIGestureRecognizerStateChanged:
+        self.progress = MAX(0, MIN(1, start + translation / self.menuWidth));
+        [self applyProgress];
+        break;
+    case UIGestureRecognizerStateEnded: {
+        // The drawer settles in the direction it was thrown, or to the nearest
+        // state if it was released.
+        CGFloat velocity = [recognizer velocityInView:self].x;
+        BOOL open = fabs(velocity) > 300 ? velocity > 0 : self.progress > 0.5;
+        [self setOpenByUser:open];
+        break;
+    }
+    default:
+        [self setOpenByUser:self.open];
+        break;
+    }
+}
+
+#pragma mark - UIGestureRecognizerDelegate
+
+- (BOOL)gestureRecognizerShouldBegin:(UIGestureRecognizer *)gestureRecognizer {
+    if (gestureRecognizer == self.pan) {
+        // The open drawer is closed by dragging it or the scrim to the left.
+        CGPoint velocity = [self.pan velocityInView:self];
+        return self.open && velocity.x < 0 && fabs(velocity.x) > fabs(velocity.y);
+    }
+    return YES;
+}
+
+@end
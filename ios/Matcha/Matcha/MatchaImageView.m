@@ -1,5 +1,13 @@
 #import "MatchaImageView.h"
 #import "MatchaViewController.h"
+#import <ImageIO/ImageIO.h>
+
+@interface MatchaImageView ()
+@property (nonatomic, strong) UIImage *stillImage;
+@property (nonatomic, assign) long long animationGeneration;
+@property (nonatomic, assign) bool needsAnimation;
+@property (nonatomic, assign) bool paused;
+@end
 
 @implementation MatchaImageView
 
@@ -40,9 +48,119 @@
         image = [image imageWithRenderingMode:UIImageRenderingModeAlwaysTemplate];
     }
     
-    if (![self.image isEqual:image]) {
+    self.stillImage = image;
+    if (self.animationImages == nil && ![self.image isEqual:image]) {
         self.image = image;
     }
+    
+    // Go can't be called while it is updating the views, so the animation is
+    // read once the update completes.
+    self.needsAnimation = true;
+    [self setNeedsLayout];
+}
+
+- (void)layoutSubviews {
+    [super layoutSubviews];
+    if (self.needsAnimation) {
+        self.needsAnimation = false;
+        [self updateAnimation];
+    }
+}
+
+- (void)updateAnimation {
+    NSArray<MatchaGoValue *> *animation = [self.viewNode call:@"Animation", nil];
+    long long generation = animation[0].toLongLong;
+    bool paused = animation[1].toBool;
+    NSInteger loopCount = (NSInteger)animation[2].toLongLong;
+    
+    bool restart = false;
+    if (generation != self.animationGeneration) {
+        self.animationGeneration = generation;
+        restart = true;
+        [self stopAnimating];
+        self.animationImages = nil;
+        self.layer.speed = 1;
+        self.layer.timeOffset = 0;
+        self.paused = false;
+        
+        NSData *data = nil;
+        if (generation != 0) {
+            data = [self.viewNode call:@"AnimationData", nil][0].toData;
+        }
+        if (data != nil && ![self loadAnimation:data]) {
+            data = nil;
+        }
+        if (data == nil) {
+            self.image = self.stillImage;
+            return;
+        }
+    }
+    if (self.animationImages == nil) {
+        return;
+    }
+    
+    // UIImageView displays its image once the animation ends, so finite
+    // animations end on their last frame.
+    self.image = loopCount == 0 ? self.stillImage : self.animationImages.lastObject;
+    if (restart || self.animationRepeatCount != loopCount) {
+        self.animationRepeatCount = loopCount;
+        [self startAnimating];
+    }
+    [self setAnimationPaused:paused];
+}
+
+- (bool)loadAnimation:(NSData *)data {
+    CGImageSourceRef source = CGImageSourceCreateWithData((__bridge CFDataRef)data, NULL);
+    if (source == NULL) {
+        return false;
+    }
+    size_t count = CGImageSourceGetCount(source);
+    NSMutableArray<UIImage *> *images = [NSMutableArray array];
+    NSTimeInterval duration = 0;
+    for (size_t i = 0; i < count; i++) {
+        CGImageRef cgImage = CGImageSourceCreateImageAtIndex(source, i, NULL);
+        if (cgImage == NULL) {
+            continue;
+        }
+        [images addObject:[UIImage imageWithCGImage:cgImage scale:self.stillImage.scale orientation:UIImageOrientationUp]];
+        CGImageRelease(cgImage);
+        
+        NSDictionary *properties = (__bridge_transfer NSDictionary *)CGImageSourceCopyPropertiesAtIndex(source, i, NULL);
+        NSDictionary *gif = properties[(__bridge NSString *)kCGImagePropertyGIFDictionary];
+        NSDictionary *png = properties[(__bridge NSString *)kCGImagePropertyPNGDictionary];
+        NSNumber *delay = gif[(__bridge NSString *)kCGImagePropertyGIFUnclampedDelayTime] ?: gif[(__bridge NSString *)kCGImagePropertyGIFDelayTime];
+        if (delay == nil) {
+            delay = png[(__bridge NSString *)kCGImagePropertyAPNGUnclampedDelayTime] ?: png[(__bridge NSString *)kCGImagePropertyAPNGDelayTime];
+        }
+        // Browsers play frames with very short delays at 10 frames per second.
+        duration += delay.doubleValue >= 0.02 ? delay.doubleValue : 0.1;
+    }
+    CFRelease(source);
+    if (images.count < 2) {
+        return false;
+    }
+    self.animationImages = images;
+    self.animationDuration = duration;
+    return true;
+}
+
+- (void)setAnimationPaused:(bool)paused {
+    if (paused == self.paused) {
+        return;
+    }
+    self.paused = paused;
+    CALayer *layer = self.layer;
+    if (paused) {
+        CFTimeInterval time = [layer convertTime:CACurrentMediaTime() fromLayer:nil];
+        layer.speed = 0;
+        layer.timeOffset = time;
+    } else {
+        CFTimeInterval offset = layer.timeOffset;
+        layer.speed = 1;
+        layer.timeOffset = 0;
+        layer.beginTime = 0;
+        layer.beginTime = [layer convertTime:CACurrentMediaTime() fromLayer:nil] - offset;
+    }
 }
 
 @end
@@ -0,0 +1,10 @@
+#import <UIKit/UIKit.h>
+#import "MatchaView.h"
+
+@interface MatchaListView : UITableView <MatchaChildView, UITableViewDataSource, UITableViewDelegate>
+@property (nonatomic, weak) MatchaViewNode *viewNode;
+@end
+
+@interface MatchaListViewCell : UITableViewCell
+@property (nonatomic, strong) UIView *matchaView;
+@end
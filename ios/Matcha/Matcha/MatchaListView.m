@@ -0,0 +1,243 @@
+#import "MatchaListView.h"
+#import "MatchaViewController_Private.h"
+#import "MatchaView_Private.h"
+
+@interface MatchaListView () <UITableViewDragDelegate, UITableViewDropDelegate>
+@property (nonatomic, strong) NSArray<UIView *> *childViews;
+@property (nonatomic, strong) NSDictionary<NSNumber *, UIView *> *rowViews;
+@property (nonatomic, strong) NSMutableSet<NSString *> *reuseIds;
+@property (nonatomic, assign) BOOL needsReload;
+@property (nonatomic, assign) long long generation;
+@property (nonatomic, assign) NSInteger count;
+@property (nonatomic, assign) NSInteger firstVisibleRow;
+@property (nonatomic, assign) NSInteger lastVisibleRow;
+@property (nonatomic, assign) long long reorder;
+@end
+
+@implementation MatchaListView
+
++ (void)load {
+    [MatchaViewController registerView:@"gomatcha.io/matcha/view/listview" block:^(MatchaViewNode *node){
+        return [[MatchaListView alloc] initWithViewNode:node];
+    }];
+}
+
+- (id)initWithViewNode:(MatchaViewNode *)viewNode {
+    if ((self = [super initWithFrame:CGRectZero style:UITableViewStylePlain])) {
+        self.viewNode = viewNode;
+        self.dataSource = self;
+        self.delegate = self;
+        self.estimatedRowHeight = 44;
+        self.separatorStyle = UITableViewCellSeparatorStyleNone;
+        self.reuseIds = [NSMutableSet set];
+        self.generation = -1;
+        self.firstVisibleRow = -1;
+        self.lastVisibleRow = -1;
+    }
+    return self;
+}
+
+- (void)setNativeState:(NSData *)nativeState {
+    // Go can't be called while it is updating the views, so the rows are read
+    // on the next layout pass.
+    self.needsReload = YES;
+    [self setNeedsLayout];
+}
+
+- (void)setMatchaChildViews:(NSArray<UIView *> *)childViews {
+    self.childViews = childViews;
+    self.needsReload = YES;
+    [self setNeedsLayout];
+}
+
+- (void)layoutSubviews {
+    if (self.needsReload) {
+        self.needsReload = NO;
+        [self reloadRows];
+        [self updateRefreshControl];
+        [self updateReorder];
+    }
+    [super layoutSubviews];
+    [self updateVisibleRows];
+}
+
+- (void)reloadRows {
+    NSArray<MatchaGoValue *> *rows = [self.viewNode call:@"Rows", nil];
+    NSInteger count = (NSInteger)rows[0].toLongLong;
+    long long generation = rows[1].toLongLong;
+    
+    NSArray<MatchaGoValue *> *childRows = [[self.viewNode call:@"ChildRows", nil][0] toArray];
+    NSMutableDictionary<NSNumber *, UIView *> *rowViews = [NSMutableDictionary dictionary];
+    for (NSInteger i = 0; i < childRows.count && i < self.childViews.count; i++) {
+        rowViews[@(childRows[i].toLongLong)] = self.childViews[i];
+    }
+    self.rowViews = rowViews;
+    
+    if (generation != self.generation || count != self.count) {
+        self.generation = generation;
+        self.count = count;
+        [self reloadData];
+    } else {
+        for (NSIndexPath *i in self.indexPathsForVisibleRows) {
+            MatchaListViewCell *cell = (MatchaListViewCell *)[self cellForRowAtIndexPath:i];
+            cell.matchaView = rowViews[@(i.row)];
+        }
+    }
+}
+
+- (void)updateRefreshControl {
+    NSArray<MatchaGoValue *> *state = [self.viewNode call:@"Refresh", nil];
+    BOOL enabled = state[0].toBool;
+    BOOL refreshing = state[1].toBool;
+    if (!enabled) {
+        self.refreshControl = nil;
+        return;
+    }
+    if (self.refreshControl == nil) {
+        self.refreshControl = [[UIRefreshControl alloc] init];
+        [self.refreshControl addTarget:self action:@selector(onRefresh:) forControlEvents:UIControlEventValueChanged];
+    }
+    if (refreshing && !self.refreshControl.refreshing) {
+        [self.refreshControl beginRefreshing];
+    } else if (!refreshing && self.refreshControl.refreshing) {
+        [self.refreshControl endRefreshing];
+    }
+}
+
+// updateReorder enables dragging rows by their reorder controls, which are
+// displayed while the table is editing, or by long pressing them, which uses
+// drag and drop on iOS 11.
+- (void)updateReorder {
+    long long reorder = [self.viewNode call:@"Reorder", nil][0].toLongLong;
+    if (reorder == self.reorder) {
+        return;
+    }
+    self.reorder = reorder;
+    self.allowsSelectionDuringEditing = YES;
+    [self setEditing:reorder == 2 animated:NO];
+    if (@available(iOS 11.0, *)) {
+        self.dragDelegate = reorder == 1 ? self : nil;
+        self.dropDelegate = reorder == 1 ? self : nil;
+        self.dragInteractionEnabled = reorder == 1;
+    }
+}
+
+- (void)onRefresh:(UIRefreshControl *)sender {
+    [self.viewNode call:@"OnRefresh", nil];
+}
+
+- (void)updateVisibleRows {
+    NSArray<NSIndexPath *> *paths = self.indexPathsForVisibleRows;
+    NSInteger first = paths.count > 0 ? paths.firstObject.row : 0;
+    NSInteger last = paths.count > 0 ? paths.lastObject.row + 1 : 0;
+    if (first == self.firstVisibleRow && last == self.lastVisibleRow) {
+        return;
+    }
+    self.firstVisibleRow = first;
+    self.lastVisibleRow = last;
+    [self.viewNode call:@"OnVisible", [[MatchaGoValue alloc] initWithLongLong:first], [[MatchaGoValue alloc] initWithLongLong:last], nil];
+}
+
+- (NSInteger)tableView:(UITableView *)tableView numberOfRowsInSection:(NSInteger)section {
+    return self.count;
+}
+
+- (CGFloat)tableView:(UITableView *)tableView heightForRowAtIndexPath:(NSIndexPath *)indexPath {
+    return [self.viewNode call:@"Row", [[MatchaGoValue alloc] initWithLongLong:indexPath.row], nil][1].toDouble;
+}
+
+- (UITableViewCell *)tableView:(UITableView *)tableView cellForRowAtIndexPath:(NSIndexPath *)indexPath {
+    NSString *reuseId = [self.viewNode call:@"Row", [[MatchaGoValue alloc] initWithLongLong:indexPath.row], nil][0].toString;
+    if (![self.reuseIds containsObject:reuseId]) {
+        [self.reuseIds addObject:reuseId];
+        [self registerClass:[MatchaListViewCell class] forCellReuseIdentifier:reuseId];
+    }
+    MatchaListViewCell *cell = [tableView dequeueReusableCellWithIdentifier:reuseId forIndexPath:indexPath];
+    cell.matchaView = self.rowViews[@(indexPath.row)];
+    return cell;
+}
+
+- (void)tableView:(UITableView *)tableView didSelectRowAtIndexPath:(NSIndexPath *)indexPath {
+    [tableView deselectRowAtIndexPath:indexPath animated:YES];
+    [self.viewNode call:@"OnSelect", [[MatchaGoValue alloc] initWithLongLong:indexPath.row], nil];
+}
+
+- (BOOL)tableView:(UITableView *)tableView canMoveRowAtIndexPath:(NSIndexPath *)indexPath {
+    return self.reorder != 0;
+}
+
+- (UITableViewCellEditingStyle)tableView:(UITableView *)tableView editingStyleForRowAtIndexPath:(NSIndexPath *)indexPath {
+    return UITableViewCellEditingStyleNone;
+}
+
+- (BOOL)tableView:(UITableView *)tableView shouldIndentWhileEditingRowAtIndexPath:(NSIndexPath *)indexPath {
+    return NO;
+}
+
+- (void)tableView:(UITableView *)tableView moveRowAtIndexPath:(NSIndexPath *)sourceIndexPath toIndexPath:(NSIndexPath *)destinationIndexPath {
+    if (sourceIndexPath.row == destinationIndexPath.row) {
+        return;
+    }
+    [self.viewNode call:@"OnMove", [[MatchaGoValue alloc] initWithLongLong:sourceIndexPath.row], [[MatchaGoValue alloc] initWithLongLong:destinationIndexPath.row], nil];
+}
+
+- (NSArray<UIDragItem *> *)tableView:(UITableView *)tableView itemsForBeginningDragSession:(id<UIDragSession>)session atIndexPath:(NSIndexPath *)indexPath API_AVAILABLE(ios(11.0)) {
+    UIDragItem *item = [[UIDragItem alloc] initWithItemProvider:[[NSItemProvider alloc] init]];
+    item.localObject = indexPath;
+    return @[item];
+}
+
+- (BOOL)tableView:(UITableView *)tableView dragSessionIsRestrictedToDraggingApplication:(id<UIDragSession>)session API_AVAILABLE(ios(11.0)) {
+    return YES;
+}
+
+- (UITableViewDropProposal *)tableView:(UITableView *)tableView dropSessionDidUpdate:(id<UIDropSession>)session withDestinationIndexPath:(NSIndexPath *)destinationIndexPath API_AVAILABLE(ios(11.0)) {
+    if (session.localDragSession == nil) {
+        return [[UITableViewDropProposal alloc] initWithDropOperation:UIDropOperationForbidden];
+    }
+    return [[UITableViewDropProposal alloc] initWithDropOperation:UIDropOperationMove intent:UITableViewDropIntentInsertAtDestinationIndexPath];
+}
+
+- (void)tableView:(UITableView *)tableView performDropWithCoordinator:(id<UITableViewDropCoordinator>)coordinator API_AVAILABLE(ios(11.0)) {
+    // Rows dragged within the table are moved by
+    // tableView:moveRowAtIndexPath:toIndexPath:.
+}
+
+- (void)scrollViewDidScroll:(UIScrollView *)scrollView {
+    [self updateVisibleRows];
+}
+
+@end
+
+@implementation MatchaListViewCell
+
+- (id)initWithStyle:(UITableViewCellStyle)style reuseIdentifier:(NSString *)reuseIdentifier {
+    if ((self = [super initWithStyle:style reuseIdentifier:reuseIdentifier])) {
+        self.selectionStyle = UITableViewCellSelectionStyleNone;
+        self.backgroundColor = [UIColor clearColor];
+        // The reorder control is only displayed while the table is editing.
+        self.showsReorderControl = YES;
+    }
+    return self;
+}
+
+- (void)setMatchaView:(UIView *)matchaView {
+    if (_matchaView == matchaView) {
+        return;
+    }
+    // The view may have moved to another cell already.
+    if (_matchaView.superview == self.contentView) {
+        [_matchaView removeFromSuperview];
+    }
+    _matchaView = matchaView;
+    if (matchaView != nil) {
+        [self.contentView addSubview:matchaView];
+    }
+}
+
+- (void)prepareForReuse {
+    [super prepareForReuse];
+    self.matchaView = nil;
+}
+
+@end
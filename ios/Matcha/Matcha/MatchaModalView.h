@@ -0,0 +1,12 @@
+#import <UIKit/UIKit.h>
+#import "MatchaView.h"
+
+@interface MatchaModalView : UIView <MatchaChildView, UIAdaptivePresentationControllerDelegate>
+@property (nonatomic, weak) MatchaViewNode *viewNode;
+@end
+
+// MatchaModalViewController displays the modal view of a MatchaModalView.
+@interface MatchaModalViewController : UIViewController
+@property (nonatomic, weak) MatchaModalView *modalView;
+@property (nonatomic, strong) UIView *matchaView;
+@end
@@ -0,0 +1,173 @@
+#import "MatchaModalView.h"
+#import "MatchaViewController_Private.h"
+#import "MatchaView_Private.h"
+
+@interface MatchaModalView ()
+@property (nonatomic, strong) NSArray<UIView *> *childViews;
+@property (nonatomic, strong) UIView *contentView;
+@property (nonatomic, strong) MatchaModalViewController *modalViewController;
+@property (nonatomic, assign) BOOL needsUpdate;
+@property (nonatomic, assign) BOOL dismissing;
+@property (nonatomic, assign) CGSize modalSize;
+- (void)modalViewControllerDidLayout:(MatchaModalViewController *)viewController;
+@end
+
+@implementation MatchaModalView
+
++ (void)load {
+    [MatchaViewController registerView:@"gomatcha.io/matcha/view/modal" block:^(MatchaViewNode *node){
+        return [[MatchaModalView alloc] initWithViewNode:node];
+    }];
+}
+
+- (id)initWithViewNode:(MatchaViewNode *)viewNode {
+    if ((self = [super initWithFrame:CGRectZero])) {
+        self.viewNode = viewNode;
+    }
+    return self;
+}
+
+- (void)setNativeState:(NSData *)nativeState {
+    // Go can't be called while it is updating the views, so the state is read on
+    // the next layout pass.
+    self.needsUpdate = YES;
+    [self setNeedsLayout];
+}
+
+- (void)setMatchaChildViews:(NSArray<UIView *> *)childViews {
+    self.childViews = childViews;
+    self.needsUpdate = YES;
+    [self setNeedsLayout];
+}
+
+- (void)didMoveToWindow {
+    [super didMoveToWindow];
+    if (self.window != nil && self.needsUpdate) {
+        [self setNeedsLayout];
+    }
+}
+
+- (void)layoutSubviews {
+    [super layoutSubviews];
+    if (self.needsUpdate && self.window != nil) {
+        self.needsUpdate = NO;
+        [self update];
+    }
+    self.contentView.frame = (CGRect){CGPointZero, self.contentView.frame.size};
+}
+
+- (void)update {
+    NSArray<MatchaGoValue *> *state = [self.viewNode call:@"State", nil];
+    BOOL presented = state[0].toBool;
+    long long style = state[1].toLongLong;
+    NSArray<MatchaGoValue *> *detents = state[2].toArray;
+    NSInteger contentIdx = (NSInteger)state[3].toLongLong;
+    NSInteger modalIdx = (NSInteger)state[4].toLongLong;
+    
+    UIView *contentView = contentIdx >= 0 && contentIdx < self.childViews.count ? self.childViews[contentIdx] : nil;
+    if (self.contentView != contentView) {
+        [self.contentView removeFromSuperview];
+        self.contentView = contentView;
+        if (contentView != nil) {
+            [self addSubview:contentView];
+        }
+    }
+    
+    UIView *modalView = modalIdx >= 0 && modalIdx < self.childViews.count ? self.childViews[modalIdx] : nil;
+    if (modalView != nil && self.modalViewController.matchaView != modalView) {
+        [self.modalViewController.matchaView removeFromSuperview];
+        self.modalViewController.matchaView = modalView;
+        [self.modalViewController.view addSubview:modalView];
+    }
+    
+    if (presented && self.modalViewController == nil && modalView != nil) {
+        [self presentView:modalView style:style detents:detents];
+    } else if (!presented && self.modalViewController != nil && !self.dismissing) {
+        self.dismissing = YES;
+        [self.modalViewController.presentingViewController dismissViewControllerAnimated:YES completion:^{
+            [self didDismiss];
+        }];
+    }
+}
+
+- (void)presentView:(UIView *)modalView style:(long long)style detents:(NSArray<MatchaGoValue *> *)detents {
+    UIViewController *presenter = nil;
+    for (UIResponder *i = self; i != nil; i = i.nextResponder) {
+        if ([i isKindOfClass:[UIViewController class]]) {
+            presenter = (UIViewController *)i;
+            break;
+        }
+    }
+    while (presenter.presentedViewController != nil) {
+        presenter = presenter.presentedViewController;
+    }
+    if (presenter == nil) {
+        return;
+    }
+    
+    MatchaModalViewController *vc = [[MatchaModalViewController alloc] init];
+    vc.modalView = self;
+    vc.matchaView = modalView;
+    vc.view.backgroundColor = [UIColor whiteColor];
+    [vc.view addSubview:modalView];
+    if (style == 0) {
+        vc.modalPresentationStyle = UIModalPresentationFullScreen;
+    } else {
+        vc.modalPresentationStyle = UIModalPresentationPageSheet;
+    }
+    if (style == 2) {
+        if (@available(iOS 15.0, *)) {
+            NSMutableArray<UISheetPresentationControllerDetent *> *sheetDetents = [NSMutableArray array];
+            for (MatchaGoValue *i in detents) {
+                [sheetDetents addObject:i.toLongLong == 0 ? [UISheetPresentationControllerDetent mediumDetent] : [UISheetPresentationControllerDetent largeDetent]];
+            }
+            vc.sheetPresentationController.detents = sheetDetents;
+            vc.sheetPresentationController.prefersGrabberVisible = YES;
+        }
+    }
+    if (@available(iOS 13.0, *)) {
+        vc.presentationController.delegate = self;
+    }
+    self.modalViewController = vc;
+    [presenter presentViewController:vc animated:YES completion:^{
+        [self.viewNode call:@"OnPresent", nil];
+    }];
+}
+
+- (void)didDismiss {
+    [self.modalViewController.matchaView removeFromSuperview];
+    self.modalViewController = nil;
+    self.dismissing = NO;
+    self.modalSize = CGSizeZero;
+    [self.viewNode call:@"OnDismiss", nil];
+}
+
+- (void)modalViewControllerDidLayout:(MatchaModalViewController *)viewController {
+    if (viewController != self.modalViewController) {
+        return;
+    }
+    CGSize size = viewController.view.bounds.size;
+    viewController.matchaView.frame = (CGRect){CGPointZero, viewController.matchaView.frame.size};
+    if (!CGSizeEqualToSize(size, self.modalSize)) {
+        self.modalSize = size;
+        [self.viewNode call:@"OnSize", [[MatchaGoValue alloc] initWithDouble:size.width], [[MatchaGoValue alloc] initWithDouble:size.height], nil];
+    }
+}
+
+#pragma mark - UIAdaptivePresentationControllerDelegate
+
+- (void)presentationControllerDidDismiss:(UIPresentationController *)presentationController {
+    // The user swiped the sheet down.
+    [self didDismiss];
+}
+
+@end
+
+@implementation MatchaModalViewController
+
+- (void)viewDidLayoutSubviews {
+    [super viewDidLayoutSubviews];
+    [self.modalView modalViewControllerDidLayout:self];
+}
+
+@end
@@ -1,15 +1,31 @@
 #import <UIKit/UIKit.h>
 #import <MatchaBridge/MatchaBridge.h>
 
+// MatchaSharedApplication returns the shared UIApplication, or nil inside an app
+// extension where it is unavailable.
+UIApplication *MatchaSharedApplication(void);
+
 @interface MatchaObjcBridge_X : NSObject
 + (NSMapTable *)viewControllers;
 + (void)configure;
 - (MatchaGoValue *)sizeForAttributedString:(NSData *)data maxLines:(int)maxLines;
+- (NSString *)registerFont:(NSString *)name data:(NSData *)data;
 - (bool)updateId:(NSInteger)identifier withProtobuf:(NSData *)protobuf;
 - (NSString *)assetsDir;
 - (MatchaGoValue *)imageForResource:(NSString *)path;
 - (MatchaGoValue *)propertiesForResource:(NSString *)path;
 - (void)displayAlert:(NSData *)protobuf;
+- (void)presentDialog:(long long)identifier style:(long long)style title:(NSString *)title message:(NSString *)message buttons:(NSArray<NSArray *> *)buttons fields:(NSArray<NSArray *> *)fields;
+- (void)presentSnackbar:(long long)identifier text:(NSString *)text action:(NSString *)action duration:(long long)duration;
+- (void)dismissSnackbar:(long long)identifier;
 - (BOOL)openURL:(NSString *)url;
 - (int)orientation;
+- (NSString *)osVersion;
+- (long long)nativeViewCount:(NSInteger)identifier;
+- (NSArray *)mediaAlbums;
+- (NSArray *)mediaAssetsForAlbum:(NSString *)album;
+- (void)requestMediaThumbnail:(NSString *)asset size:(long long)size id:(long long)identifier;
+- (void)cancelMediaThumbnail:(long long)identifier;
+- (void)startCachingMedia:(NSArray<NSString *> *)assets size:(long long)size;
+- (void)stopCachingMedia:(NSArray<NSString *> *)assets size:(long long)size;
 @end
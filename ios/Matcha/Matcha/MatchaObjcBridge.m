@@ -4,7 +4,52 @@
 #import "MatchaViewController_Private.h"
 #import "MatchaDeadlockLogger.h"
 #import "MatchaProtobuf.h"
+#import "MatchaSnackbar.h"
 #import <CoreText/CoreText.h>
+#import <Photos/Photos.h>
+
+UIApplication *MatchaSharedApplication(void) {
+    if ([[NSBundle mainBundle].bundlePath hasSuffix:@".appex"]) {
+        return nil;
+    }
+    // +sharedApplication is marked unavailable when building with
+    // APPLICATION_EXTENSION_API_ONLY, so look it up at runtime.
+    return [UIApplication performSelector:@selector(sharedApplication)];
+}
+
+// Bridge protocol version of the Matcha framework. See bridge.ProtocolMajor and
+// bridge.ProtocolMinor.
+static NSString *const MatchaProtocolVersion = @"1.1";
+
+static BOOL MatchaProtocolCompatible(NSString *version) {
+    NSArray<NSString *> *a = [version componentsSeparatedByString:@"."];
+    NSArray<NSString *> *b = [MatchaProtocolVersion componentsSeparatedByString:@"."];
+    if (a.count != 2 || ![a[0] isEqualToString:b[0]]) {
+        return NO;
+    }
+    return labs(a[1].integerValue - b[1].integerValue) <= 1;
+}
+
+// MatchaHandshake checks that the Go library speaks a compatible bridge protocol,
+// and returns its version. Go libraries that predate the handshake speak 1.0.
+static NSString *MatchaHandshake(void) {
+    NSString *version = @"1.0";
+    if ([MatchaGoValue respondsToSelector:@selector(protocolVersion)]) {
+        version = [MatchaGoValue protocolVersion];
+    }
+    if (!MatchaProtocolCompatible(version)) {
+        [NSException raise:NSInternalInconsistencyException format:@"matcha: the Go library speaks bridge protocol %@ but the Matcha framework speaks %@, rebuild the Go library with the matcha version of the framework", version, MatchaProtocolVersion];
+    }
+    if (![version isEqualToString:@"1.0"]) {
+        MatchaGoValue *handshake = [[MatchaGoValue alloc] initWithFunc:@"gomatcha.io/matcha/bridge Handshake"];
+        NSError *error = nil;
+        [handshake tryCall:nil arguments:@[[[MatchaGoValue alloc] initWithString:MatchaProtocolVersion]] error:&error];
+        if (error != nil) {
+            [NSException raise:NSInternalInconsistencyException format:@"%@", error.localizedDescription];
+        }
+    }
+    return version;
+}
 
 @implementation MatchaObjcBridge_X
 
@@ -21,6 +66,7 @@
     static dispatch_once_t sOnce = 0;
     dispatch_once(&sOnce, ^{
         [MatchaDeadlockLogger sharedLogger]; // Initialize
+        MatchaHandshake();
         
         MatchaObjcBridge_X *x = [[MatchaObjcBridge_X alloc] init];
         [[MatchaObjcBridge sharedBridge] setObject:x forKey:@""];
@@ -37,6 +83,12 @@
 
         [[NSNotificationCenter defaultCenter] addObserver:x selector:@selector(didChangeOrientation:) name:UIApplicationDidChangeStatusBarOrientationNotification object:nil];
         [x didChangeOrientation:nil];
+        
+        [[NSNotificationCenter defaultCenter] addObserver:x selector:@selector(keyboardWillChangeFrame:) name:UIKeyboardWillChangeFrameNotification object:nil];
+        
+        BOOL rightToLeft = [UIApplication sharedApplication].userInterfaceLayoutDirection == UIUserInterfaceLayoutDirectionRightToLeft;
+        MatchaGoValue *directionFunc = [[MatchaGoValue alloc] initWithFunc:@"gomatcha.io/matcha/application SetRightToLeft"];
+        [directionFunc call:nil, [[MatchaGoValue alloc] initWithBool:rightToLeft], nil];
     });
 }
 
@@ -65,6 +117,8 @@
     
     CGFloat maxWidth = 0;
     CGFloat maxHeight = 0;
+    CGFloat firstBaseline = 0;
+    CGFloat lastBaseline = 0;
     if (maxLines == 0) {
         maxLines = (int)count;
     }
@@ -78,13 +132,25 @@
         if (flipped.y + descent > maxHeight) {
             maxHeight = flipped.y + descent;
         }
+        if (i == 0) {
+            firstBaseline = flipped.y;
+        }
+        lastBaseline = flipped.y;
     }
     
     CFRelease(framesetterRef);
     CFRelease(frameRef);
     
-    MatchaLayoutPBPoint *point = [[MatchaLayoutPBPoint alloc] initWithCGSize:CGSizeMake(ceil(maxWidth), ceil(maxHeight))];
-    return [[MatchaGoValue alloc] initWithData:point.data];
+    MatchaPBTextMetrics *metrics = [[MatchaPBTextMetrics alloc] init];
+    metrics.width = ceil(maxWidth);
+    metrics.height = ceil(maxHeight);
+    metrics.firstBaseline = firstBaseline;
+    metrics.lastBaseline = lastBaseline;
+    return [[MatchaGoValue alloc] initWithData:metrics.data];
+}
+
+- (NSString *)registerFont:(NSString *)name data:(NSData *)data {
+    return [UIFont matchaRegisterFont:data name:name] ?: @"";
 }
 
 - (void)screenUpdate {
@@ -151,18 +217,83 @@
     for (UIAlertAction *i in actions) {
         [alert addAction:i];
     }
-    [[UIApplication sharedApplication].keyWindow.rootViewController presentViewController:alert animated:YES completion:nil];
+    UIViewController *presenter = MatchaSharedApplication().keyWindow.rootViewController;
+    if (presenter == nil) {
+        // App extensions have no key window, so present from a Matcha view.
+        presenter = [[MatchaObjcBridge_X viewControllers] objectEnumerator].nextObject;
+    }
+    [presenter presentViewController:alert animated:YES completion:nil];
+}
+
+- (void)presentDialog:(long long)identifier style:(long long)style title:(NSString *)title message:(NSString *)message buttons:(NSArray<NSArray *> *)buttons fields:(NSArray<NSArray *> *)fields {
+    UIAlertControllerStyle alertStyle = style == 1 ? UIAlertControllerStyleActionSheet : UIAlertControllerStyleAlert;
+    UIAlertController *alert = [UIAlertController alertControllerWithTitle:(title.length > 0 ? title : nil) message:(message.length > 0 ? message : nil) preferredStyle:alertStyle];
+    
+    for (NSArray *i in fields) {
+        [alert addTextFieldWithConfigurationHandler:^(UITextField *textField) {
+            textField.placeholder = i[0];
+            textField.text = i[1];
+            textField.secureTextEntry = [i[2] boolValue];
+        }];
+    }
+    
+    __weak UIAlertController *weakAlert = alert;
+    for (NSInteger i = 0; i < buttons.count; i++) {
+        NSArray *button = buttons[i];
+        UIAlertActionStyle actionStyle = UIAlertActionStyleDefault;
+        if ([button[1] longLongValue] == 1) {
+            actionStyle = UIAlertActionStyleCancel;
+        } else if ([button[1] longLongValue] == 2) {
+            actionStyle = UIAlertActionStyleDestructive;
+        }
+        UIAlertAction *action = [UIAlertAction actionWithTitle:button[0] style:actionStyle handler:^(UIAlertAction *a){
+            NSMutableArray<MatchaGoValue *> *text = [NSMutableArray array];
+            for (UITextField *j in weakAlert.textFields) {
+                [text addObject:[[MatchaGoValue alloc] initWithString:j.text ?: @""]];
+            }
+            MatchaGoValue *onDismiss = [[MatchaGoValue alloc] initWithFunc:@"gomatcha.io/matcha/view/alert onDismiss"];
+            [onDismiss call:nil, [[MatchaGoValue alloc] initWithLongLong:identifier], [[MatchaGoValue alloc] initWithLongLong:i], [[MatchaGoValue alloc] initWithArray:text], nil];
+        }];
+        [alert addAction:action];
+    }
+    
+    UIViewController *presenter = MatchaSharedApplication().keyWindow.rootViewController;
+    if (presenter == nil) {
+        // App extensions have no key window, so present from a Matcha view.
+        presenter = [[MatchaObjcBridge_X viewControllers] objectEnumerator].nextObject;
+    }
+    while (presenter.presentedViewController != nil) {
+        presenter = presenter.presentedViewController;
+    }
+    // Action sheets are displayed in a popover on iPad.
+    alert.popoverPresentationController.sourceView = presenter.view;
+    alert.popoverPresentationController.sourceRect = CGRectMake(CGRectGetMidX(presenter.view.bounds), CGRectGetMaxY(presenter.view.bounds), 0, 0);
+    alert.popoverPresentationController.permittedArrowDirections = 0;
+    [presenter presentViewController:alert animated:YES completion:nil];
+}
+
+- (void)presentSnackbar:(long long)identifier text:(NSString *)text action:(NSString *)action duration:(long long)duration {
+    [MatchaSnackbar presentWithId:identifier text:text action:action duration:duration];
+}
+
+- (void)dismissSnackbar:(long long)identifier {
+    [MatchaSnackbar dismissWithId:identifier];
 }
 
 - (BOOL)openURL:(NSString *)url {
 #pragma GCC diagnostic push
 #pragma GCC diagnostic ignored "-Wdeprecated-declarations"
-    return [[UIApplication sharedApplication] openURL:[NSURL URLWithString:url]];
+    return [MatchaSharedApplication() openURL:[NSURL URLWithString:url]];
 #pragma GCC diagnostic pop
 }
 
 - (int)orientation {
-    UIInterfaceOrientation orientation = [UIApplication sharedApplication].statusBarOrientation;
+    UIApplication *application = MatchaSharedApplication();
+    if (application == nil) {
+        CGSize size = UIScreen.mainScreen.bounds.size;
+        return size.width > size.height ? 3 : 0;
+    }
+    UIInterfaceOrientation orientation = application.statusBarOrientation;
     if (orientation == UIInterfaceOrientationPortrait) {
         return 0;
     } else if (orientation == UIInterfaceOrientationPortraitUpsideDown) {
@@ -175,6 +306,161 @@
     return 0;
 }
 
+- (NSString *)osVersion {
+    return [UIDevice currentDevice].systemVersion;
+}
+
+- (long long)nativeViewCount:(NSInteger)identifier {
+    MatchaViewController *vc = [[MatchaObjcBridge_X viewControllers] objectForKey:@(identifier)];
+    if (vc == nil) {
+        return -1;
+    }
+    return [vc nativeViewCount];
+}
+
++ (PHCachingImageManager *)mediaImageManager {
+    static PHCachingImageManager *sManager;
+    static dispatch_once_t sOnce;
+    dispatch_once(&sOnce, ^{
+        sManager = [[PHCachingImageManager alloc] init];
+    });
+    return sManager;
+}
+
+// Assets by local identifier, filled as albums are fetched.
++ (NSMutableDictionary<NSString *, PHAsset *> *)mediaAssets {
+    static NSMutableDictionary *sAssets;
+    static dispatch_once_t sOnce;
+    dispatch_once(&sOnce, ^{
+        sAssets = [NSMutableDictionary dictionary];
+    });
+    return sAssets;
+}
+
++ (NSMutableDictionary<NSNumber *, NSNumber *> *)mediaRequests {
+    static NSMutableDictionary *sRequests;
+    static dispatch_once_t sOnce;
+    dispatch_once(&sOnce, ^{
+        sRequests = [NSMutableDictionary dictionary];
+    });
+    return sRequests;
+}
+
+- (BOOL)mediaAuthorized {
+    PHAuthorizationStatus status = [PHPhotoLibrary authorizationStatus];
+    if (status == PHAuthorizationStatusNotDetermined) {
+        [PHPhotoLibrary requestAuthorization:^(PHAuthorizationStatus status) {}];
+    }
+    return status == PHAuthorizationStatusAuthorized;
+}
+
+- (NSArray *)mediaAlbums {
+    if (![self mediaAuthorized]) {
+        return nil;
+    }
+    NSMutableArray *albums = [NSMutableArray array];
+    void (^add)(PHFetchResult<PHAssetCollection *> *) = ^(PHFetchResult<PHAssetCollection *> *collections) {
+        for (PHAssetCollection *i in collections) {
+            NSInteger count = [PHAsset fetchAssetsInAssetCollection:i options:nil].count;
+            if (count == 0) {
+                continue;
+            }
+            [albums addObject:@[i.localIdentifier, i.localizedTitle ?: @"", @(count)]];
+        }
+    };
+    add([PHAssetCollection fetchAssetCollectionsWithType:PHAssetCollectionTypeSmartAlbum subtype:PHAssetCollectionSubtypeSmartAlbumUserLibrary options:nil]);
+    add([PHAssetCollection fetchAssetCollectionsWithType:PHAssetCollectionTypeSmartAlbum subtype:PHAssetCollectionSubtypeSmartAlbumFavorites options:nil]);
+    add([PHAssetCollection fetchAssetCollectionsWithType:PHAssetCollectionTypeSmartAlbum subtype:PHAssetCollectionSubtypeSmartAlbumVideos options:nil]);
+    add([PHAssetCollection fetchAssetCollectionsWithType:PHAssetCollectionTypeAlbum subtype:PHAssetCollectionSubtypeAny options:nil]);
+    return albums;
+}
+
+- (NSArray *)mediaAssetsForAlbum:(NSString *)album {
+    if (![self mediaAuthorized]) {
+        return nil;
+    }
+    PHAssetCollection *collection = [PHAssetCollection fetchAssetCollectionsWithLocalIdentifiers:@[album] options:nil].firstObject;
+    if (collection == nil) {
+        return @[];
+    }
+    PHFetchOptions *options = [[PHFetchOptions alloc] init];
+    options.sortDescriptors = @[[NSSortDescriptor sortDescriptorWithKey:@"creationDate" ascending:NO]];
+    PHFetchResult<PHAsset *> *result = [PHAsset fetchAssetsInAssetCollection:collection options:options];
+
+    NSMutableDictionary *cache = [MatchaObjcBridge_X mediaAssets];
+    NSMutableArray *assets = [NSMutableArray arrayWithCapacity:result.count];
+    for (PHAsset *i in result) {
+        cache[i.localIdentifier] = i;
+        [assets addObject:i.localIdentifier];
+    }
+    return assets;
+}
+
+- (NSArray<PHAsset *> *)mediaAssetsWithIdentifiers:(NSArray<NSString *> *)identifiers {
+    NSMutableDictionary *cache = [MatchaObjcBridge_X mediaAssets];
+    NSMutableArray *assets = [NSMutableArray arrayWithCapacity:identifiers.count];
+    for (NSString *i in identifiers) {
+        PHAsset *asset = cache[i];
+        if (asset == nil) {
+            asset = [PHAsset fetchAssetsWithLocalIdentifiers:@[i] options:nil].firstObject;
+            cache[i] = asset;
+        }
+        if (asset != nil) {
+            [assets addObject:asset];
+        }
+    }
+    return assets;
+}
+
+- (void)requestMediaThumbnail:(NSString *)asset size:(long long)size id:(long long)identifier {
+    PHAsset *phasset = [self mediaAssetsWithIdentifiers:@[asset]].firstObject;
+    if (phasset == nil) {
+        [self didLoadMediaThumbnail:nil id:identifier];
+        return;
+    }
+
+    PHImageRequestOptions *options = [[PHImageRequestOptions alloc] init];
+    options.deliveryMode = PHImageRequestOptionsDeliveryModeOpportunistic;
+    options.resizeMode = PHImageRequestOptionsResizeModeFast;
+    options.networkAccessAllowed = YES;
+    options.synchronous = NO;
+    PHImageRequestID requestId = [[MatchaObjcBridge_X mediaImageManager] requestImageForAsset:phasset targetSize:CGSizeMake(size, size) contentMode:PHImageContentModeAspectFill options:options resultHandler:^(UIImage *image, NSDictionary *info) {
+        // Wait for the final image rather than the degraded placeholder.
+        if ([info[PHImageResultIsDegradedKey] boolValue]) {
+            return;
+        }
+        [self didLoadMediaThumbnail:image id:identifier];
+    }];
+    [MatchaObjcBridge_X mediaRequests][@(identifier)] = @(requestId);
+}
+
+- (void)didLoadMediaThumbnail:(UIImage *)image id:(long long)identifier {
+    // Always call back asynchronously, since Go holds its lock while requesting.
+    dispatch_async(dispatch_get_main_queue(), ^{
+        [[MatchaObjcBridge_X mediaRequests] removeObjectForKey:@(identifier)];
+        NSData *data = image == nil ? [NSData data] : UIImageJPEGRepresentation(image, 0.8);
+        MatchaGoValue *onThumbnail = [[MatchaGoValue alloc] initWithFunc:@"gomatcha.io/matcha/view/media onThumbnail"];
+        [onThumbnail call:nil, [[MatchaGoValue alloc] initWithLongLong:identifier], [[MatchaGoValue alloc] initWithData:data], nil];
+    });
+}
+
+- (void)cancelMediaThumbnail:(long long)identifier {
+    NSNumber *requestId = [MatchaObjcBridge_X mediaRequests][@(identifier)];
+    if (requestId == nil) {
+        return;
+    }
+    [[MatchaObjcBridge_X mediaRequests] removeObjectForKey:@(identifier)];
+    [[MatchaObjcBridge_X mediaImageManager] cancelImageRequest:(PHImageRequestID)requestId.intValue];
+}
+
+- (void)startCachingMedia:(NSArray<NSString *> *)assets size:(long long)size {
+    [[MatchaObjcBridge_X mediaImageManager] startCachingImagesForAssets:[self mediaAssetsWithIdentifiers:assets] targetSize:CGSizeMake(size, size) contentMode:PHImageContentModeAspectFill options:nil];
+}
+
+- (void)stopCachingMedia:(NSArray<NSString *> *)assets size:(long long)size {
+    [[MatchaObjcBridge_X mediaImageManager] stopCachingImagesForAssets:[self mediaAssetsWithIdentifiers:assets] targetSize:CGSizeMake(size, size) contentMode:PHImageContentModeAspectFill options:nil];
+}
+
 - (void)didChangeOrientation:(NSNotification *)note {
     static MatchaGoValue *orientationFunc = nil;
     if (orientationFunc == nil) {
@@ -183,4 +469,42 @@
     [orientationFunc call:nil, [[MatchaGoValue alloc] initWithInt:self.orientation], nil];
 }
 
+- (void)keyboardWillChangeFrame:(NSNotification *)note {
+    static MatchaGoValue *insetFunc = nil;
+    if (insetFunc == nil) {
+        insetFunc = [[MatchaGoValue alloc] initWithFunc:@"gomatcha.io/matcha/keyboard SetInset"];
+    }
+    CGRect frame = [note.userInfo[UIKeyboardFrameEndUserInfoKey] CGRectValue];
+    double duration = [note.userInfo[UIKeyboardAnimationDurationUserInfoKey] doubleValue];
+    UIViewAnimationCurve curve = [note.userInfo[UIKeyboardAnimationCurveUserInfoKey] integerValue];
+    double height = MAX(CGRectGetMaxY(UIScreen.mainScreen.bounds) - CGRectGetMinY(frame), 0);
+    
+    // The keyboard animates with a private curve, which is close to this one.
+    double x0 = 0.38, y0 = 0.7, x1 = 0.125, y1 = 1;
+    switch (curve) {
+    case UIViewAnimationCurveEaseInOut:
+        x0 = 0.42, y0 = 0, x1 = 0.58, y1 = 1;
+        break;
+    case UIViewAnimationCurveEaseIn:
+        x0 = 0.42, y0 = 0, x1 = 1, y1 = 1;
+        break;
+    case UIViewAnimationCurveEaseOut:
+        x0 = 0, y0 = 0, x1 = 0.58, y1 = 1;
+        break;
+    case UIViewAnimationCurveLinear:
+        x0 = 0, y0 = 0, x1 = 1, y1 = 1;
+        break;
+    default:
+        break;
+    }
+    [insetFunc call:nil,
+        [[MatchaGoValue alloc] initWithDouble:height],
+        [[MatchaGoValue alloc] initWithDouble:duration],
+        [[MatchaGoValue alloc] initWithDouble:x0],
+        [[MatchaGoValue alloc] initWithDouble:y0],
+        [[MatchaGoValue alloc] initWithDouble:x1],
+        [[MatchaGoValue alloc] initWithDouble:y1],
+        nil];
+}
+
 @end
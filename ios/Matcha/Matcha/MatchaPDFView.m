@@ -0,0 +1,138 @@
+#import "MatchaPDFView.h"
+#import <PDFKit/PDFKit.h>
+#import "MatchaViewController.h"
+
+@interface MatchaPDFView ()
+@property (nonatomic, strong) UIView *pdfView; // PDFView on iOS 11 and later.
+@property (nonatomic, assign) long long generation;
+@property (nonatomic, assign) long long searchGeneration;
+@property (nonatomic, assign) long long page; // The last page read from or sent to Go.
+@property (nonatomic, assign) BOOL needsUpdate;
+@end
+
+@implementation MatchaPDFView
+
++ (void)load {
+    [MatchaViewController registerView:@"gomatcha.io/matcha/view/pdfview" block:^(MatchaViewNode *node){
+        return [[MatchaPDFView alloc] initWithViewNode:node];
+    }];
+}
+
+- (id)initWithViewNode:(MatchaViewNode *)viewNode {
+    if ((self = [super initWithFrame:CGRectZero])) {
+        self.viewNode = viewNode;
+        if (@available(iOS 11.0, *)) {
+            PDFView *pdfView = [[PDFView alloc] init];
+            pdfView.autoScales = YES;
+            pdfView.displayMode = kPDFDisplaySinglePageContinuous;
+            pdfView.displayDirection = kPDFDisplayDirectionVertical;
+            [self addSubview:pdfView];
+            self.pdfView = pdfView;
+            [[NSNotificationCenter defaultCenter] addObserver:self selector:@selector(pageChanged:) name:PDFViewPageChangedNotification object:pdfView];
+        }
+    }
+    return self;
+}
+
+- (void)dealloc {
+    [[NSNotificationCenter defaultCenter] removeObserver:self];
+}
+
+- (void)setNativeState:(NSData *)nativeState {
+    // Go can't be called while it is updating the views, so the state is read
+    // on the next layout pass.
+    self.needsUpdate = YES;
+    [self setNeedsLayout];
+}
+
+- (void)layoutSubviews {
+    [super layoutSubviews];
+    self.pdfView.frame = self.bounds;
+    if (self.needsUpdate) {
+        self.needsUpdate = NO;
+        [self update];
+    }
+}
+
+- (void)update {
+    if (@available(iOS 11.0, *)) {
+        PDFView *pdfView = (PDFView *)self.pdfView;
+        NSArray<MatchaGoValue *> *state = [self.viewNode call:@"State", nil];
+        long long generation = state[0].toLongLong;
+        long long page = state[1].toLongLong;
+        long long searchGeneration = state[2].toLongLong;
+        
+        if (generation != self.generation) {
+            self.generation = generation;
+            self.page = 0;
+            NSData *data = [self.viewNode call:@"Document", nil][0].toData;
+            PDFDocument *document = data.length > 0 ? [[PDFDocument alloc] initWithData:data] : nil;
+            pdfView.document = document;
+            if (document != nil) {
+                [self.viewNode call:@"OnLoad", [[MatchaGoValue alloc] initWithLongLong:document.pageCount], nil];
+            } else if (data.length > 0) {
+                [self.viewNode call:@"OnError", [[MatchaGoValue alloc] initWithString:@"pdfview: the document can't be read"], nil];
+            }
+            self.searchGeneration = 0;
+        }
+        
+        PDFDocument *document = pdfView.document;
+        if (document != nil && page != self.page && page >= 0 && page < (long long)document.pageCount) {
+            self.page = page;
+            [pdfView goToPage:[document pageAtIndex:page]];
+        }
+        if (document != nil && searchGeneration != self.searchGeneration) {
+            self.searchGeneration = searchGeneration;
+            [self search:state[3].toString generation:searchGeneration];
+        }
+    } else {
+        NSArray<MatchaGoValue *> *state = [self.viewNode call:@"State", nil];
+        if (state[0].toLongLong != self.generation) {
+            self.generation = state[0].toLongLong;
+            [self.viewNode call:@"OnError", [[MatchaGoValue alloc] initWithString:@"pdfview: PDFKit requires iOS 11"], nil];
+        }
+    }
+}
+
+- (void)search:(NSString *)text generation:(long long)generation API_AVAILABLE(ios(11.0)) {
+    PDFView *pdfView = (PDFView *)self.pdfView;
+    PDFDocument *document = pdfView.document;
+    NSArray<PDFSelection *> *selections = @[];
+    if (text.length > 0) {
+        selections = [document findString:text withOptions:NSCaseInsensitiveSearch];
+    }
+    pdfView.highlightedSelections = selections.count > 0 ? selections : nil;
+    
+    NSMutableArray<MatchaGoValue *> *pages = [NSMutableArray array];
+    NSMutableArray<MatchaGoValue *> *texts = [NSMutableArray array];
+    for (PDFSelection *selection in selections) {
+        PDFPage *page = selection.pages.firstObject;
+        PDFSelection *line = [selection copy];
+        [line extendSelectionForLineBoundaries];
+        NSString *lineText = [line.string stringByTrimmingCharactersInSet:[NSCharacterSet whitespaceAndNewlineCharacterSet]];
+        [pages addObject:[[MatchaGoValue alloc] initWithLongLong:page != nil ? [document indexForPage:page] : 0]];
+        [texts addObject:[[MatchaGoValue alloc] initWithString:lineText ?: @""]];
+    }
+    if (selections.count > 0) {
+        [pdfView goToSelection:selections.firstObject];
+    }
+    [self.viewNode call:@"OnSearch", [[MatchaGoValue alloc] initWithLongLong:generation], [[MatchaGoValue alloc] initWithArray:pages], [[MatchaGoValue alloc] initWithArray:texts], nil];
+}
+
+- (void)pageChanged:(NSNotification *)notification {
+    if (@available(iOS 11.0, *)) {
+        PDFView *pdfView = (PDFView *)self.pdfView;
+        PDFPage *currentPage = pdfView.currentPage;
+        if (currentPage == nil) {
+            return;
+        }
+        long long page = [pdfView.document indexForPage:currentPage];
+        if (page == self.page) {
+            return;
+        }
+        self.page = page;
+        [self.viewNode call:@"OnPage", [[MatchaGoValue alloc] initWithLongLong:page], nil];
+    }
+}
+
+@end
@@ -0,0 +1,6 @@
+#import <UIKit/UIKit.h>
+#import "MatchaView.h"
+
+@interface MatchaPicker : UIPickerView <MatchaChildView, UIPickerViewDataSource, UIPickerViewDelegate>
+@property (nonatomic, weak) MatchaViewNode *viewNode;
+@end
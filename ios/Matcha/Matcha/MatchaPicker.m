@@ -0,0 +1,102 @@
+#import "MatchaPicker.h"
+#import "MatchaViewController.h"
+
+@interface MatchaPicker ()
+@property (nonatomic, strong) NSArray<NSArray<NSString *> *> *components;
+@property (nonatomic, assign) BOOL enabled;
+@property (nonatomic, assign) BOOL needsUpdate;
+@end
+
+@implementation MatchaPicker
+
++ (void)load {
+    [MatchaViewController registerView:@"gomatcha.io/matcha/view/picker" block:^(MatchaViewNode *node){
+        return [[MatchaPicker alloc] initWithViewNode:node];
+    }];
+}
+
+- (id)initWithViewNode:(MatchaViewNode *)viewNode {
+    if ((self = [super initWithFrame:CGRectZero])) {
+        self.viewNode = viewNode;
+        self.components = @[];
+        self.dataSource = self;
+        self.delegate = self;
+    }
+    return self;
+}
+
+- (void)setNativeState:(NSData *)nativeState {
+    // Go can't be called while it is updating the views, so the state is read on
+    // the next layout pass.
+    self.needsUpdate = YES;
+    [self setNeedsLayout];
+}
+
+- (void)layoutSubviews {
+    if (self.needsUpdate) {
+        self.needsUpdate = NO;
+        [self update];
+    }
+    [super layoutSubviews];
+}
+
+- (void)update {
+    NSArray<MatchaGoValue *> *state = [self.viewNode call:@"State", nil];
+    NSArray<MatchaGoValue *> *counts = state[0].toArray;
+    NSArray<MatchaGoValue *> *options = state[1].toArray;
+    NSArray<MatchaGoValue *> *selected = state[2].toArray;
+    self.enabled = state[3].toBool;
+    
+    NSMutableArray<NSArray<NSString *> *> *components = [NSMutableArray array];
+    NSInteger offset = 0;
+    for (MatchaGoValue *i in counts) {
+        NSMutableArray<NSString *> *component = [NSMutableArray array];
+        for (NSInteger j = 0; j < i.toLongLong && offset < options.count; j++, offset++) {
+            [component addObject:options[offset].toString];
+        }
+        [components addObject:component];
+    }
+    if (![self.components isEqual:components]) {
+        self.components = components;
+        [self reloadAllComponents];
+    }
+    
+    for (NSInteger i = 0; i < selected.count && i < self.components.count; i++) {
+        NSInteger row = (NSInteger)selected[i].toLongLong;
+        if (row < self.components[i].count && [self selectedRowInComponent:i] != row) {
+            [self selectRow:row inComponent:i animated:NO];
+        }
+    }
+    self.userInteractionEnabled = self.enabled;
+    self.alpha = self.enabled ? 1 : 0.5;
+}
+
+#pragma mark - UIPickerViewDataSource
+
+- (NSInteger)numberOfComponentsInPickerView:(UIPickerView *)pickerView {
+    return self.components.count;
+}
+
+- (NSInteger)pickerView:(UIPickerView *)pickerView numberOfRowsInComponent:(NSInteger)component {
+    return self.components[component].count;
+}
+
+#pragma mark - UIPickerViewDelegate
+
+- (NSString *)pickerView:(UIPickerView *)pickerView titleForRow:(NSInteger)row forComponent:(NSInteger)component {
+    return self.components[component][row];
+}
+
+- (void)pickerView:(UIPickerView *)pickerView didSelectRow:(NSInteger)row inComponent:(NSInteger)component {
+    [self.viewNode call:@"OnSelect", [[MatchaGoValue alloc] initWithLongLong:component], [[MatchaGoValue alloc] initWithLongLong:row], nil];
+}
+
+- (void)setAlpha:(CGFloat)alpha {
+    // Disabled pickers are dimmed, don't allow MatchaViewNode to reset the alpha back to 1.
+    if (self.enabled == false && alpha > 0.99) {
+        return;
+    }
+    [super setAlpha:alpha];
+}
+
+@end
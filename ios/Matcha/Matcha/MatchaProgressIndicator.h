@@ -0,0 +1,6 @@
+#import <UIKit/UIKit.h>
+#import "MatchaView.h"
+
+@interface MatchaProgressIndicator : UIView <MatchaChildView>
+@property (nonatomic, weak) MatchaViewNode *viewNode;
+@end
@@ -0,0 +1,178 @@
+#import "MatchaProgressIndicator.h"
+#import "MatchaViewController.h"
+
+static UIColor *MatchaProgressColor(long long argb) {
+    return [UIColor colorWithRed:((argb >> 16) & 0xFF) / 255.0 green:((argb >> 8) & 0xFF) / 255.0 blue:(argb & 0xFF) / 255.0 alpha:((argb >> 24) & 0xFF) / 255.0];
+}
+
+@interface MatchaProgressIndicator ()
+@property (nonatomic, strong) CAShapeLayer *trackLayer;
+@property (nonatomic, strong) CAShapeLayer *progressLayer;
+@property (nonatomic, assign) long long style;
+@property (nonatomic, assign) double progress;
+@property (nonatomic, assign) BOOL indeterminate;
+@property (nonatomic, assign) long long color;
+@property (nonatomic, assign) long long trackColor;
+@property (nonatomic, assign) BOOL needsUpdate;
+@end
+
+@implementation MatchaProgressIndicator
+
++ (void)load {
+    [MatchaViewController registerView:@"gomatcha.io/matcha/view/progress" block:^(MatchaViewNode *node){
+        return [[MatchaProgressIndicator alloc] initWithViewNode:node];
+    }];
+}
+
+- (id)initWithViewNode:(MatchaViewNode *)viewNode {
+    if ((self = [super initWithFrame:CGRectZero])) {
+        self.viewNode = viewNode;
+        self.style = -1;
+        self.color = -1;
+        self.trackColor = -1;
+        self.trackLayer = [CAShapeLayer layer];
+        self.trackLayer.fillColor = nil;
+        self.trackLayer.lineCap = kCALineCapRound;
+        [self.layer addSublayer:self.trackLayer];
+        self.progressLayer = [CAShapeLayer layer];
+        self.progressLayer.fillColor = nil;
+        self.progressLayer.lineCap = kCALineCapRound;
+        self.progressLayer.strokeEnd = 0;
+        [self.layer addSublayer:self.progressLayer];
+    }
+    return self;
+}
+
+- (void)setNativeState:(NSData *)nativeState {
+    // Go can't be called while it is updating the views, so the state is read on
+    // the next layout pass.
+    self.needsUpdate = YES;
+    [self setNeedsLayout];
+}
+
+- (void)tintColorDidChange {
+    [super tintColorDidChange];
+    [self updateColors];
+}
+
+- (void)layoutSubviews {
+    [super layoutSubviews];
+    [self updatePath];
+    if (self.needsUpdate) {
+        self.needsUpdate = NO;
+        [self update];
+    }
+}
+
+- (void)updatePath {
+    [CATransaction begin];
+    [CATransaction setDisableActions:YES];
+    CGRect bounds = self.bounds;
+    UIBezierPath *path = nil;
+    CGFloat lineWidth = 0;
+    if (self.style == 1) { // Circular
+        // The ring starts at the top, and is centered so that the layer can be
+        // rotated while indeterminate.
+        CGFloat d = MIN(bounds.size.width, bounds.size.height);
+        lineWidth = d / 10;
+        path = [UIBezierPath bezierPathWithArcCenter:CGPointMake(CGRectGetMidX(bounds), CGRectGetMidY(bounds)) radius:(d - lineWidth) / 2 startAngle:-M_PI_2 endAngle:3 * M_PI_2 clockwise:YES];
+    } else {
+        lineWidth = bounds.size.height;
+        path = [UIBezierPath bezierPath];
+        [path moveToPoint:CGPointMake(lineWidth / 2, CGRectGetMidY(bounds))];
+        [path addLineToPoint:CGPointMake(MAX(bounds.size.width - lineWidth / 2, lineWidth / 2), CGRectGetMidY(bounds))];
+    }
+    for (CAShapeLayer *layer in @[self.trackLayer, self.progressLayer]) {
+        layer.bounds = bounds;
+        layer.position = CGPointMake(CGRectGetMidX(bounds), CGRectGetMidY(bounds));
+        layer.path = path.CGPath;
+        layer.lineWidth = lineWidth;
+    }
+    [CATransaction commit];
+}
+
+- (void)update {
+    NSArray<MatchaGoValue *> *state = [self.viewNode call:@"State", nil];
+    long long style = state[0].toLongLong;
+    double progress = state[1].toDouble;
+    BOOL indeterminate = state[2].toBool;
+    BOOL animated = state[3].toBool;
+    self.color = state[4].toLongLong;
+    self.trackColor = state[5].toLongLong;
+    [self updateColors];
+    
+    if (style != self.style) {
+        self.style = style;
+        self.indeterminate = NO;
+        [self.progressLayer removeAllAnimations];
+        [self updatePath];
+    }
+    
+    if (indeterminate) {
+        if (!self.indeterminate) {
+            self.indeterminate = YES;
+            [self startIndeterminate];
+        }
+        return;
+    }
+    if (self.indeterminate) {
+        self.indeterminate = NO;
+        [self.progressLayer removeAllAnimations];
+    }
+    
+    [CATransaction begin];
+    [CATransaction setDisableActions:!animated];
+    [CATransaction setAnimationDuration:0.25];
+    self.progressLayer.strokeStart = 0;
+    self.progressLayer.strokeEnd = progress;
+    [CATransaction commit];
+}
+
+- (void)updateColors {
+    UIColor *color = self.color == -1 ? self.tintColor : MatchaProgressColor(self.color);
+    UIColor *trackColor = self.trackColor == -1 ? [color colorWithAlphaComponent:0.2] : MatchaProgressColor(self.trackColor);
+    [CATransaction begin];
+    [CATransaction setDisableActions:YES];
+    self.progressLayer.strokeColor = color.CGColor;
+    self.trackLayer.strokeColor = trackColor.CGColor;
+    [CATransaction commit];
+}
+
+- (void)startIndeterminate {
+    [CATransaction begin];
+    [CATransaction setDisableActions:YES];
+    if (self.style == 1) {
+        // A partial ring spins around the track.
+        self.progressLayer.strokeStart = 0;
+        self.progressLayer.strokeEnd = 0.75;
+        CABasicAnimation *rotation = [CABasicAnimation animationWithKeyPath:@"transform.rotation.z"];
+        rotation.fromValue = @0;
+        rotation.toValue = @(2 * M_PI);
+        rotation.duration = 1;
+        rotation.repeatCount = HUGE_VALF;
+        rotation.removedOnCompletion = NO;
+        [self.progressLayer addAnimation:rotation forKey:@"indeterminate"];
+    } else {
+        // A segment slides across the track.
+        self.progressLayer.strokeStart = 0;
+        self.progressLayer.strokeEnd = 0;
+        CABasicAnimation *end = [CABasicAnimation animationWithKeyPath:@"strokeEnd"];
+        end.fromValue = @0;
+        end.toValue = @1;
+        end.duration = 0.75;
+        CABasicAnimation *start = [CABasicAnimation animationWithKeyPath:@"strokeStart"];
+        start.fromValue = @0;
+        start.toValue = @1;
+        start.beginTime = 0.25;
+        start.duration = 0.75;
+        CAAnimationGroup *group = [CAAnimationGroup animation];
+        group.animations = @[end, start];
+        group.duration = 1;
+        group.repeatCount = HUGE_VALF;
+        group.removedOnCompletion = NO;
+        [self.progressLayer addAnimation:group forKey:@"indeterminate"];
+    }
+    [CATransaction commit];
+}
+
+@end
@@ -42,6 +42,12 @@ typedef struct MatchaColor {
 @end
 
 @interface UIFont (Matcha)
+// matchaRegisterFont registers the font in data under name, and returns an
+// error message, or nil if it was registered.
++ (NSString *)matchaRegisterFont:(NSData *)data name:(NSString *)name;
+// matchaFontWithName returns the font with the name it was registered under,
+// or with its PostScript name.
++ (UIFont *)matchaFontWithName:(NSString *)name size:(CGFloat)size;
 - (id)initWithProtobuf:(MatchaPBFont *)value;
 - (MatchaPBFont *)protobuf;
 @end
@@ -1,4 +1,5 @@
 #import <Foundation/Foundation.h>
+#import <CoreText/CoreText.h>
 #import "MatchaProtobuf.h"
 
 @implementation UIColor (Matcha)
@@ -79,13 +80,28 @@ CGColorRef MatchaCGColorCreateWithProtobuf(MatchaPBColor *value) {
     NSMutableDictionary *dictionary = [[NSMutableDictionary alloc] init];
     dictionary[NSParagraphStyleAttributeName] = paragraphStyle;
     
+    NSWritingDirection direction;
+    switch (style.writingDirection) {
+        case MatchaPBWritingDirection_WritingDirectionLeftToRight:
+            direction = NSWritingDirectionLeftToRight;
+            break;
+        case MatchaPBWritingDirection_WritingDirectionRightToLeft:
+            direction = NSWritingDirectionRightToLeft;
+            break;
+        default:
+            direction = NSWritingDirectionNatural;
+    }
+    paragraphStyle.baseWritingDirection = direction;
+    
+    // Left is the start of the paragraph's direction, like ALIGN_NORMAL on
+    // Android.
     NSTextAlignment alignment;
     switch (style.textAlignment) {
         case 0:
-            alignment = NSTextAlignmentLeft;
+            alignment = NSTextAlignmentNatural;
             break;
         case 1:
-            alignment = NSTextAlignmentRight;
+            alignment = direction == NSWritingDirectionRightToLeft ? NSTextAlignmentLeft : NSTextAlignmentRight;
             break;
         case 2:
             alignment = NSTextAlignmentCenter;
@@ -94,7 +110,7 @@ CGColorRef MatchaCGColorCreateWithProtobuf(MatchaPBColor *value) {
             alignment = NSTextAlignmentJustified;
             break;
         default:
-            alignment = NSTextAlignmentLeft;
+            alignment = NSTextAlignmentNatural;
     }
     paragraphStyle.alignment = alignment;
     
@@ -152,8 +168,17 @@ CGColorRef MatchaCGColorCreateWithProtobuf(MatchaPBColor *value) {
     
     dictionary[NSUnderlineColorAttributeName] = [[UIColor alloc] initWithProtobuf:style.underlineColor];
     dictionary[NSFontAttributeName] = [[UIFont alloc] initWithProtobuf:style.font];
-    dictionary[NSHyphenationFactorDocumentAttribute] = @(style.hyphenation);
+    paragraphStyle.hyphenationFactor = style.hyphenation;
     paragraphStyle.lineHeightMultiple = style.lineHeightMultiple;
+    if (style.lineHeight > 0) {
+        paragraphStyle.minimumLineHeight = style.lineHeight;
+        paragraphStyle.maximumLineHeight = style.lineHeight;
+    }
+    paragraphStyle.paragraphSpacing = style.paragraphSpacing;
+    paragraphStyle.firstLineHeadIndent = style.firstLineIndent;
+    if (style.letterSpacing != 0) {
+        dictionary[NSKernAttributeName] = @(style.letterSpacing);
+    }
     // TODO(KD): AttributeKeyMaxLines
     dictionary[NSForegroundColorAttributeName] = [[UIColor alloc] initWithProtobuf:style.textColor];
     // TODO(KD): AttributeKeyTextWrap
@@ -169,6 +194,7 @@ CGColorRef MatchaCGColorCreateWithProtobuf(MatchaPBColor *value) {
     if (paragraphStyle) {
         int alignment;
         switch (paragraphStyle.alignment) {
+        case NSTextAlignmentNatural:
         case NSTextAlignmentLeft:
             alignment = 0;
             break;
@@ -253,11 +279,17 @@ CGColorRef MatchaCGColorCreateWithProtobuf(MatchaPBColor *value) {
         style.font = ((UIFont *)dictionary[NSFontAttributeName]).protobuf;
     }
     
-    if (dictionary[NSHyphenationFactorDocumentAttribute]) {
-        style.hyphenation = ((NSNumber *)dictionary[NSHyphenationFactorDocumentAttribute]).integerValue;
+    if (dictionary[NSKernAttributeName]) {
+        style.letterSpacing = ((NSNumber *)dictionary[NSKernAttributeName]).doubleValue;
     }
     
+    style.hyphenation = paragraphStyle.hyphenationFactor;
     style.lineHeightMultiple = paragraphStyle.lineHeightMultiple;
+    if (paragraphStyle.minimumLineHeight == paragraphStyle.maximumLineHeight) {
+        style.lineHeight = paragraphStyle.maximumLineHeight;
+    }
+    style.paragraphSpacing = paragraphStyle.paragraphSpacing;
+    style.firstLineIndent = paragraphStyle.firstLineHeadIndent;
     if (dictionary[NSForegroundColorAttributeName]) {
         style.textColor = ((UIColor *)dictionary[NSForegroundColorAttributeName]).protobuf;
     }  
@@ -313,8 +345,52 @@ CGColorRef MatchaCGColorCreateWithProtobuf(MatchaPBColor *value) {
 
 @implementation UIFont (Matcha)
 
+// MatchaFontNames maps the names of the fonts registered by Go to their
+// PostScript names.
+static NSMutableDictionary<NSString *, NSString *> *MatchaFontNames(void) {
+    static NSMutableDictionary *sNames;
+    static dispatch_once_t sOnce;
+    dispatch_once(&sOnce, ^{
+        sNames = [NSMutableDictionary dictionary];
+    });
+    return sNames;
+}
+
++ (NSString *)matchaRegisterFont:(NSData *)data name:(NSString *)name {
+    CGDataProviderRef provider = CGDataProviderCreateWithCFData((__bridge CFDataRef)data);
+    CGFontRef font = CGFontCreateWithDataProvider(provider);
+    CGDataProviderRelease(provider);
+    if (font == NULL) {
+        return @"text: font data can't be read";
+    }
+    NSString *postScriptName = (__bridge_transfer NSString *)CGFontCopyPostScriptName(font);
+    CFErrorRef error = NULL;
+    // Registering a font twice fails, which is fine if it is the same font.
+    if (!CTFontManagerRegisterGraphicsFont(font, &error) && [UIFont fontWithName:postScriptName size:12] == nil) {
+        NSString *msg = [NSString stringWithFormat:@"text: %@", ((__bridge NSError *)error).localizedDescription];
+        CFRelease(error);
+        CGFontRelease(font);
+        return msg;
+    }
+    if (error != NULL) {
+        CFRelease(error);
+    }
+    CGFontRelease(font);
+    @synchronized (MatchaFontNames()) {
+        MatchaFontNames()[name] = postScriptName;
+    }
+    return nil;
+}
+
++ (UIFont *)matchaFontWithName:(NSString *)name size:(CGFloat)size {
+    @synchronized (MatchaFontNames()) {
+        name = MatchaFontNames()[name] ?: name;
+    }
+    return [UIFont fontWithName:name size:size];
+}
+
 - (id)initWithProtobuf:(MatchaPBFont *)value {
-    return [UIFont fontWithName:value.family size:value.size];
+    return [UIFont matchaFontWithName:value.family size:value.size];
 //    NSMutableDictionary *attr = [NSMutableDictionary dictionary];
 //    attr[UIFontDescriptorFamilyAttribute] = value.family;
 //    attr[UIFontDescriptorFaceAttribute] = value.face;
@@ -0,0 +1,161 @@
+#import "MatchaRadioGroup.h"
+#import "MatchaViewController.h"
+
+static UIColor *MatchaRadioGroupColor(long long argb) {
+    return [UIColor colorWithRed:((argb >> 16) & 0xFF) / 255.0 green:((argb >> 8) & 0xFF) / 255.0 blue:(argb & 0xFF) / 255.0 alpha:((argb >> 24) & 0xFF) / 255.0];
+}
+
+static const CGFloat MatchaRadioButtonSize = 22;
+
+// MatchaRadioButton is a row of the group, with a circle that is filled while
+// it is selected.
+@interface MatchaRadioButton : UIControl
+@property (nonatomic, strong) CAShapeLayer *ringLayer;
+@property (nonatomic, strong) CAShapeLayer *dotLayer;
+@property (nonatomic, strong) UILabel *titleLabel;
+- (void)setColor:(UIColor *)color;
+@end
+
+@implementation MatchaRadioButton
+
+- (id)initWithFrame:(CGRect)frame {
+    if ((self = [super initWithFrame:frame])) {
+        self.ringLayer = [CAShapeLayer layer];
+        self.ringLayer.lineWidth = 2;
+        self.ringLayer.fillColor = nil;
+        self.ringLayer.path = [UIBezierPath bezierPathWithOvalInRect:CGRectMake(1, 1, MatchaRadioButtonSize - 2, MatchaRadioButtonSize - 2)].CGPath;
+        [self.layer addSublayer:self.ringLayer];
+        
+        CGFloat inset = MatchaRadioButtonSize * 0.27;
+        self.dotLayer = [CAShapeLayer layer];
+        self.dotLayer.path = [UIBezierPath bezierPathWithOvalInRect:CGRectMake(inset, inset, MatchaRadioButtonSize - inset * 2, MatchaRadioButtonSize - inset * 2)].CGPath;
+        [self.layer addSublayer:self.dotLayer];
+        
+        self.titleLabel = [[UILabel alloc] init];
+        self.titleLabel.font = [UIFont preferredFontForTextStyle:UIFontTextStyleBody];
+        [self addSubview:self.titleLabel];
+        self.isAccessibilityElement = YES;
+    }
+    return self;
+}
+
+- (void)layoutSubviews {
+    [super layoutSubviews];
+    CGFloat y = (self.bounds.size.height - MatchaRadioButtonSize) / 2;
+    self.ringLayer.frame = CGRectMake(0, y, MatchaRadioButtonSize, MatchaRadioButtonSize);
+    self.dotLayer.frame = self.ringLayer.frame;
+    CGFloat left = MatchaRadioButtonSize + 12;
+    self.titleLabel.frame = CGRectMake(left, 0, MAX(self.bounds.size.width - left, 0), self.bounds.size.height);
+}
+
+- (void)setColor:(UIColor *)color {
+    self.ringLayer.strokeColor = self.selected ? color.CGColor : [UIColor colorWithWhite:0 alpha:0.54].CGColor;
+    self.dotLayer.fillColor = color.CGColor;
+    self.dotLayer.hidden = !self.selected;
+    self.accessibilityLabel = self.titleLabel.text;
+    self.accessibilityTraits = UIAccessibilityTraitButton | (self.selected ? UIAccessibilityTraitSelected : 0);
+}
+
+@end
+
+@interface MatchaRadioGroup ()
+@property (nonatomic, strong) NSArray<NSString *> *options;
+@property (nonatomic, strong) NSMutableArray<MatchaRadioButton *> *buttons;
+@property (nonatomic, assign) NSInteger selected;
+@property (nonatomic, assign) BOOL enabled;
+@property (nonatomic, assign) long long color;
+@property (nonatomic, assign) BOOL needsUpdate;
+@end
+
+@implementation MatchaRadioGroup
+
++ (void)load {
+    [MatchaViewController registerView:@"gomatcha.io/matcha/view/radiogroup" block:^(MatchaViewNode *node){
+        return [[MatchaRadioGroup alloc] initWithViewNode:node];
+    }];
+}
+
+- (id)initWithViewNode:(MatchaViewNode *)viewNode {
+    if ((self = [super initWithFrame:CGRectZero])) {
+        self.viewNode = viewNode;
+        self.options = @[];
+        self.buttons = [NSMutableArray array];
+        self.selected = -1;
+        self.color = -1;
+    }
+    return self;
+}
+
+- (void)setNativeState:(NSData *)nativeState {
+    // Go can't be called while it is updating the views, so the state is read
+    // on the next layout pass.
+    self.needsUpdate = YES;
+    [self setNeedsLayout];
+}
+
+- (void)layoutSubviews {
+    [super layoutSubviews];
+    if (self.needsUpdate) {
+        self.needsUpdate = NO;
+        [self update];
+    }
+    CGFloat height = self.buttons.count > 0 ? self.bounds.size.height / self.buttons.count : 0;
+    for (NSInteger i = 0; i < self.buttons.count; i++) {
+        self.buttons[i].frame = CGRectMake(0, height * i, self.bounds.size.width, height);
+    }
+}
+
+- (void)update {
+    NSArray<MatchaGoValue *> *state = [self.viewNode call:@"State", nil];
+    NSMutableArray<NSString *> *options = [NSMutableArray array];
+    for (MatchaGoValue *i in state[0].toArray) {
+        [options addObject:i.toString];
+    }
+    self.selected = (NSInteger)state[1].toLongLong;
+    self.enabled = state[2].toBool;
+    self.color = state[3].toLongLong;
+    
+    if (![self.options isEqual:options]) {
+        self.options = options;
+        for (MatchaRadioButton *i in self.buttons) {
+            [i removeFromSuperview];
+        }
+        [self.buttons removeAllObjects];
+        for (NSString *i in options) {
+            MatchaRadioButton *button = [[MatchaRadioButton alloc] init];
+            button.titleLabel.text = i;
+            [button addTarget:self action:@selector(onTap:) forControlEvents:UIControlEventTouchUpInside];
+            [self addSubview:button];
+            [self.buttons addObject:button];
+        }
+    }
+    [self updateButtons];
+}
+
+- (void)tintColorDidChange {
+    [super tintColorDidChange];
+    [self updateButtons];
+}
+
+- (void)updateButtons {
+    UIColor *color = self.color == -1 ? self.tintColor : MatchaRadioGroupColor(self.color);
+    for (NSInteger i = 0; i < self.buttons.count; i++) {
+        MatchaRadioButton *button = self.buttons[i];
+        button.selected = i == self.selected;
+        button.enabled = self.enabled;
+        [button setColor:color];
+    }
+    self.alpha = self.enabled ? 1 : 0.4;
+}
+
+- (void)onTap:(MatchaRadioButton *)sender {
+    NSInteger index = [self.buttons indexOfObject:sender];
+    if (index == NSNotFound || index == self.selected) {
+        return;
+    }
+    self.selected = index;
+    [self updateButtons];
+    [self.viewNode call:@"OnSelect", [[MatchaGoValue alloc] initWithLongLong:index], nil];
+}
+
+@end
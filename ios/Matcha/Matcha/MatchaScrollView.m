@@ -3,6 +3,10 @@
 #import "MatchaViewController_Private.h"
 #import "MatchaView_Private.h"
 
+@interface MatchaScrollView ()
+@property (nonatomic, assign) BOOL needsRefreshState;
+@end
+
 @implementation MatchaScrollView
 
 + (void)load {
@@ -15,10 +19,47 @@
     if ((self = [super initWithFrame:CGRectZero])) {
         self.viewNode = viewNode;
         self.delegate = self;
+        [[NSNotificationCenter defaultCenter] addObserver:self selector:@selector(keyboardDidShow:) name:UIKeyboardDidShowNotification object:nil];
     }
     return self;
 }
 
+- (void)dealloc {
+    [[NSNotificationCenter defaultCenter] removeObserver:self];
+}
+
+- (void)keyboardDidShow:(NSNotification *)note {
+    UIView *responder = [self firstResponderInView:self];
+    if (responder == nil) {
+        return;
+    }
+    
+    // Scroll the focused view above the keyboard, within the content.
+    CGRect keyboard = [self convertRect:[note.userInfo[UIKeyboardFrameEndUserInfoKey] CGRectValue] fromView:nil];
+    CGRect rect = [self convertRect:responder.bounds fromView:responder];
+    CGFloat bottom = MIN(CGRectGetMaxY(self.bounds), CGRectGetMinY(keyboard));
+    CGFloat delta = CGRectGetMaxY(rect) - bottom;
+    CGFloat maxY = MAX(self.contentSize.height - self.bounds.size.height, 0);
+    if (delta > 0) {
+        CGPoint offset = self.contentOffset;
+        offset.y = MIN(offset.y + delta, maxY);
+        [self setContentOffset:offset animated:YES];
+    }
+}
+
+- (UIView *)firstResponderInView:(UIView *)view {
+    if (view.isFirstResponder) {
+        return view;
+    }
+    for (UIView *i in view.subviews) {
+        UIView *responder = [self firstResponderInView:i];
+        if (responder != nil) {
+            return responder;
+        }
+    }
+    return nil;
+}
+
 - (void)setNativeState:(NSData *)nativeState {
     MatchaViewPBScrollView *state = [MatchaViewPBScrollView parseFromData:nativeState error:nil];
     if (self.scrollEnabled != state.scrollEnabled) {
@@ -36,6 +77,42 @@
     if (self.alwaysBounceHorizontal != state.horizontal) {
         self.alwaysBounceHorizontal = state.horizontal;
     }
+    
+    // Go can't be called while it is updating the views, so the refresh state is
+    // read on the next layout pass.
+    self.needsRefreshState = YES;
+    [self setNeedsLayout];
+}
+
+- (void)layoutSubviews {
+    if (self.needsRefreshState) {
+        self.needsRefreshState = NO;
+        [self updateRefreshControl];
+    }
+    [super layoutSubviews];
+}
+
+- (void)updateRefreshControl {
+    NSArray<MatchaGoValue *> *state = [self.viewNode call:@"Refresh", nil];
+    BOOL enabled = state[0].toBool;
+    BOOL refreshing = state[1].toBool;
+    if (!enabled) {
+        self.refreshControl = nil;
+        return;
+    }
+    if (self.refreshControl == nil) {
+        self.refreshControl = [[UIRefreshControl alloc] init];
+        [self.refreshControl addTarget:self action:@selector(onRefresh:) forControlEvents:UIControlEventValueChanged];
+    }
+    if (refreshing && !self.refreshControl.refreshing) {
+        [self.refreshControl beginRefreshing];
+    } else if (!refreshing && self.refreshControl.refreshing) {
+        [self.refreshControl endRefreshing];
+    }
+}
+
+- (void)onRefresh:(UIRefreshControl *)sender {
+    [self.viewNode call:@"OnRefresh", nil];
 }
 
 - (void)scrollViewDidScroll:(UIScrollView *)scrollView {
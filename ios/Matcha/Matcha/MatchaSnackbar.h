@@ -0,0 +1,9 @@
+#import <UIKit/UIKit.h>
+
+// MatchaSnackbar displays the messages of the view/snackbar package in a banner
+// at the bottom of the screen. Go queues the messages, so at most one is
+// displayed at a time.
+@interface MatchaSnackbar : UIView
++ (void)presentWithId:(long long)identifier text:(NSString *)text action:(NSString *)action duration:(long long)duration;
++ (void)dismissWithId:(long long)identifier;
+@end
@@ -0,0 +1,140 @@
+#import "MatchaSnackbar.h"
+#import "MatchaObjcBridge.h"
+
+static MatchaSnackbar *MatchaCurrentSnackbar = nil;
+
+@interface MatchaSnackbar ()
+@property (nonatomic, assign) long long identifier;
+@property (nonatomic, strong) UILabel *label;
+@property (nonatomic, strong) UIButton *button;
+@property (nonatomic, assign) BOOL dismissed;
+@end
+
+@implementation MatchaSnackbar
+
++ (void)presentWithId:(long long)identifier text:(NSString *)text action:(NSString *)action duration:(long long)duration {
+    UIView *container = MatchaSharedApplication().keyWindow;
+    if (container == nil) {
+        // App extensions have no key window, so present in a Matcha view.
+        container = [[[MatchaObjcBridge_X viewControllers] objectEnumerator].nextObject view];
+    }
+    if (container == nil) {
+        // Nothing is displayed, so the next message is displayed instead.
+        [MatchaSnackbar onDismiss:identifier action:NO];
+        return;
+    }
+    
+    MatchaSnackbar *snackbar = [[MatchaSnackbar alloc] initWithId:identifier text:text action:action];
+    [container addSubview:snackbar];
+    NSLayoutYAxisAnchor *bottom = container.bottomAnchor;
+    if (@available(iOS 11.0, *)) {
+        bottom = container.safeAreaLayoutGuide.bottomAnchor;
+    }
+    [NSLayoutConstraint activateConstraints:@[
+        [snackbar.leadingAnchor constraintEqualToAnchor:container.leadingAnchor constant:8],
+        [snackbar.trailingAnchor constraintEqualToAnchor:container.trailingAnchor constant:-8],
+        [snackbar.bottomAnchor constraintEqualToAnchor:bottom constant:-8],
+    ]];
+    [container layoutIfNeeded];
+    
+    // Slide in from the bottom of the screen.
+    snackbar.transform = CGAffineTransformMakeTranslation(0, container.bounds.size.height - snackbar.frame.origin.y);
+    [UIView animateWithDuration:0.25 delay:0 options:UIViewAnimationOptionCurveEaseOut animations:^{
+        snackbar.transform = CGAffineTransformIdentity;
+    } completion:nil];
+    MatchaCurrentSnackbar = snackbar;
+    
+    if (duration >= 0) {
+        __weak MatchaSnackbar *weakSnackbar = snackbar;
+        dispatch_after(dispatch_time(DISPATCH_TIME_NOW, (int64_t)(duration * NSEC_PER_MSEC)), dispatch_get_main_queue(), ^{
+            [weakSnackbar dismissWithAction:NO];
+        });
+    }
+}
+
++ (void)dismissWithId:(long long)identifier {
+    if (MatchaCurrentSnackbar.identifier == identifier) {
+        [MatchaCurrentSnackbar dismissWithAction:NO];
+    }
+}
+
++ (void)onDismiss:(long long)identifier action:(BOOL)action {
+    MatchaGoValue *onDismiss = [[MatchaGoValue alloc] initWithFunc:@"gomatcha.io/matcha/view/snackbar onDismiss"];
+    [onDismiss call:nil, [[MatchaGoValue alloc] initWithLongLong:identifier], [[MatchaGoValue alloc] initWithBool:action], nil];
+}
+
+- (id)initWithId:(long long)identifier text:(NSString *)text action:(NSString *)action {
+    if ((self = [super initWithFrame:CGRectZero])) {
+        self.identifier = identifier;
+        self.translatesAutoresizingMaskIntoConstraints = NO;
+        self.backgroundColor = [UIColor colorWithWhite:0.2 alpha:1];
+        self.layer.cornerRadius = 4;
+        self.layer.shadowColor = [UIColor blackColor].CGColor;
+        self.layer.shadowOpacity = 0.2;
+        self.layer.shadowOffset = CGSizeMake(0, 2);
+        self.layer.shadowRadius = 4;
+        
+        self.label = [[UILabel alloc] init];
+        self.label.translatesAutoresizingMaskIntoConstraints = NO;
+        self.label.text = text;
+        self.label.textColor = [UIColor whiteColor];
+        self.label.font = [UIFont systemFontOfSize:15];
+        self.label.numberOfLines = 2;
+        [self addSubview:self.label];
+        
+        self.button = [UIButton buttonWithType:UIButtonTypeSystem];
+        self.button.translatesAutoresizingMaskIntoConstraints = NO;
+        self.button.titleLabel.font = [UIFont boldSystemFontOfSize:15];
+        self.button.tintColor = [UIColor colorWithRed:0.5 green:0.8 blue:1 alpha:1];
+        [self.button setTitle:action forState:UIControlStateNormal];
+        [self.button setContentHuggingPriority:UILayoutPriorityRequired forAxis:UILayoutConstraintAxisHorizontal];
+        [self.button setContentCompressionResistancePriority:UILayoutPriorityRequired forAxis:UILayoutConstraintAxisHorizontal];
+        [self.button addTarget:self action:@selector(buttonPressed) forControlEvents:UIControlEventTouchUpInside];
+        self.button.hidden = action.length == 0;
+        [self addSubview:self.button];
+        
+        [NSLayoutConstraint activateConstraints:@[
+            [self.heightAnchor constraintGreaterThanOrEqualToConstant:48],
+            [self.label.leadingAnchor constraintEqualToAnchor:self.leadingAnchor constant:16],
+            [self.label.topAnchor constraintGreaterThanOrEqualToAnchor:self.topAnchor constant:14],
+            [self.label.centerYAnchor constraintEqualToAnchor:self.centerYAnchor],
+            [self.button.leadingAnchor constraintEqualToAnchor:self.label.trailingAnchor constant:8],
+            [self.button.trailingAnchor constraintEqualToAnchor:self.trailingAnchor constant:(action.length > 0 ? -8 : 0)],
+            [self.button.centerYAnchor constraintEqualToAnchor:self.centerYAnchor],
+        ]];
+        if (action.length == 0) {
+            [self.button.widthAnchor constraintEqualToConstant:0].active = YES;
+        }
+        
+        UISwipeGestureRecognizer *swipe = [[UISwipeGestureRecognizer alloc] initWithTarget:self action:@selector(swiped)];
+        swipe.direction = UISwipeGestureRecognizerDirectionDown;
+        [self addGestureRecognizer:swipe];
+    }
+    return self;
+}
+
+- (void)buttonPressed {
+    [self dismissWithAction:YES];
+}
+
+- (void)swiped {
+    [self dismissWithAction:NO];
+}
+
+- (void)dismissWithAction:(BOOL)action {
+    if (self.dismissed) {
+        return;
+    }
+    self.dismissed = YES;
+    if (MatchaCurrentSnackbar == self) {
+        MatchaCurrentSnackbar = nil;
+    }
+    [UIView animateWithDuration:0.2 delay:0 options:UIViewAnimationOptionCurveEaseIn animations:^{
+        self.transform = CGAffineTransformMakeTranslation(0, self.superview.bounds.size.height - self.frame.origin.y + self.transform.ty);
+    } completion:^(BOOL finished) {
+        [self removeFromSuperview];
+        [MatchaSnackbar onDismiss:self.identifier action:action];
+    }];
+}
+
+@end
@@ -2,6 +2,7 @@
 #import "MatchaView.h"
 @class MatchaViewNode;
 @class GPBInt64Array;
+@class MatchaStackBar;
 
 @interface MatchaStackView : UINavigationController <MatchaChildViewController, UINavigationControllerDelegate>
 - (id)initWithViewNode:(MatchaViewNode *)viewNode;
@@ -11,9 +12,10 @@
 //Internal
 @property (nonatomic, strong) NSArray<NSNumber *> *prevIds;
 @property (nonatomic, strong) NSArray *prev;
+@property (nonatomic, strong) NSArray<MatchaStackBar *> *bars;
 @end
 
-@interface MatchaStackBar : UIViewController <MatchaChildViewController>
+@interface MatchaStackBar : UIViewController <MatchaChildViewController, UISearchResultsUpdating, UISearchBarDelegate>
 - (id)initWithViewNode:(MatchaViewNode *)viewNode;
 @property (nonatomic, weak) MatchaViewNode *viewNode;
 @property (nonatomic, strong) NSData *nativeState;
@@ -26,4 +28,13 @@
 @property (nonatomic, strong) UIView *titleView;
 @property (nonatomic, strong) NSArray *rightViews;
 @property (nonatomic, strong) NSArray *leftViews;
+@property (nonatomic, weak) MatchaStackView *stackView;
+@property (nonatomic, weak) UIViewController *screen;
+@property (nonatomic, strong) UISearchController *searchController;
+@property (nonatomic, strong) NSArray<NSNumber *> *overflow;
+@property (nonatomic, strong) NSArray<MatchaGoValue *> *overflowTitles;
+@property (nonatomic, strong) NSArray<MatchaGoValue *> *overflowFlags;
+@property (nonatomic, assign) BOOL needsUpdate;
+- (void)updateBar;
+- (void)updateSearch;
 @end
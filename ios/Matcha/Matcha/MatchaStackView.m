@@ -6,10 +6,39 @@
 #import "MatchaView_Private.h"
 
 #define VIEW_ID_KEY @"matchaViewId"
+#define TRANSITION_KEY @"matchaTransition"
+
+// These match the flags and title modes in view/ios/stackview.go.
+static const long long MatchaStackBarItemFlagDisabled = 1 << 0;
+static const long long MatchaStackBarItemFlagOverflow = 1 << 1;
+static const long long MatchaStackBarTitleModeStandard = 0;
+static const long long MatchaStackBarTitleModeCollapsing = 2;
+
+// These match the transition styles in view/ios/transition.go.
+static const long long MatchaStackTransitionDefault = 0;
+static const long long MatchaStackTransitionSlide = 1;
+static const long long MatchaStackTransitionFade = 2;
+static const long long MatchaStackTransitionModal = 3;
+static const long long MatchaStackTransitionNone = 4;
+
+static UIScrollView *MatchaStackBarFindScrollView(UIView *view) {
+    if ([view isKindOfClass:[UIScrollView class]]) {
+        return (UIScrollView *)view;
+    }
+    for (UIView *i in view.subviews) {
+        UIScrollView *scrollView = MatchaStackBarFindScrollView(i);
+        if (scrollView != nil) {
+            return scrollView;
+        }
+    }
+    return nil;
+}
 
 @interface UIViewController (MatchaStackScreen)
 - (void)matcha_setViewId:(int64_t)value;
 - (int64_t)matcha_viewId;
+- (void)matcha_setTransition:(MatchaiOSPBStackChildView *)value;
+- (MatchaiOSPBStackChildView *)matcha_transition;
 @end
 
 @implementation UIViewController (MatchaStackScreen)
@@ -26,6 +55,92 @@
     }
 }
 
+- (void)matcha_setTransition:(MatchaiOSPBStackChildView *)value {
+    @synchronized (self) {
+        objc_setAssociatedObject(self, TRANSITION_KEY, value, OBJC_ASSOCIATION_RETAIN);
+    }
+}
+
+- (MatchaiOSPBStackChildView *)matcha_transition {
+    @synchronized (self) {
+        return objc_getAssociatedObject(self, TRANSITION_KEY);
+    }
+}
+
+@end
+
+// MatchaStackTransition animates a screen that is pushed with a custom
+// transition, or the reverse when it is popped.
+@interface MatchaStackTransition : NSObject <UIViewControllerAnimatedTransitioning>
+- (id)initWithProtobuf:(MatchaiOSPBStackChildView *)transition push:(BOOL)push;
+@property (nonatomic, strong) MatchaiOSPBStackChildView *transition;
+@property (nonatomic, assign) BOOL push;
+@end
+
+@implementation MatchaStackTransition
+
+- (id)initWithProtobuf:(MatchaiOSPBStackChildView *)transition push:(BOOL)push {
+    if ((self = [super init])) {
+        self.transition = transition;
+        self.push = push;
+    }
+    return self;
+}
+
+- (NSTimeInterval)transitionDuration:(id<UIViewControllerContextTransitioning>)context {
+    return self.transition.transitionDuration;
+}
+
+- (void)animateTransition:(id<UIViewControllerContextTransitioning>)context {
+    UIView *container = context.containerView;
+    UIView *fromView = [context viewForKey:UITransitionContextFromViewKey];
+    UIView *toView = [context viewForKey:UITransitionContextToViewKey];
+    toView.frame = [context finalFrameForViewController:[context viewControllerForKey:UITransitionContextToViewControllerKey]];
+    if (self.push) {
+        [container addSubview:toView];
+    } else {
+        [container insertSubview:toView belowSubview:fromView];
+    }
+    
+    // The pushed screen moves between the identity and where it is hidden.
+    UIView *view = self.push ? toView : fromView;
+    CGAffineTransform hiddenTransform = CGAffineTransformIdentity;
+    CGFloat hiddenAlpha = 1;
+    if (self.transition.transition == MatchaStackTransitionSlide) {
+        CGFloat width = container.bounds.size.width;
+        if ([UIApplication sharedApplication].userInterfaceLayoutDirection == UIUserInterfaceLayoutDirectionRightToLeft) {
+            width = -width;
+        }
+        hiddenTransform = CGAffineTransformMakeTranslation(width, 0);
+    } else if (self.transition.transition == MatchaStackTransitionModal) {
+        hiddenTransform = CGAffineTransformMakeTranslation(0, container.bounds.size.height);
+    } else if (self.transition.transition == MatchaStackTransitionFade) {
+        hiddenAlpha = 0;
+    }
+    if (self.push) {
+        view.transform = hiddenTransform;
+        view.alpha = hiddenAlpha;
+    }
+    
+    UICubicTimingParameters *curve = [[UICubicTimingParameters alloc] initWithControlPoint1:CGPointMake(self.transition.curveX0, self.transition.curveY0) controlPoint2:CGPointMake(self.transition.curveX1, self.transition.curveY1)];
+    UIViewPropertyAnimator *animator = [[UIViewPropertyAnimator alloc] initWithDuration:[self transitionDuration:context] timingParameters:curve];
+    BOOL push = self.push;
+    [animator addAnimations:^{
+        view.transform = push ? CGAffineTransformIdentity : hiddenTransform;
+        view.alpha = push ? 1 : hiddenAlpha;
+    }];
+    [animator addCompletion:^(UIViewAnimatingPosition position) {
+        view.transform = CGAffineTransformIdentity;
+        view.alpha = 1;
+        BOOL cancelled = context.transitionWasCancelled;
+        if (cancelled) {
+            [toView removeFromSuperview];
+        }
+        [context completeTransition:!cancelled];
+    }];
+    [animator startAnimation];
+}
+
 @end
 
 @implementation MatchaStackView
@@ -67,6 +182,7 @@
     }
 
     NSMutableArray *viewControllers = [NSMutableArray array];
+    NSMutableArray *bars = [NSMutableArray array];
     for (NSInteger i = 0; i < view.childrenArray.count; i++) {
         MatchaiOSPBStackChildView *childView = view.childrenArray[i];
         MatchaStackBar *bar = (id)childVCs[i * 2];
@@ -81,10 +197,20 @@
             vc.navigationItem.backBarButtonItem = [[UIBarButtonItem alloc] initWithTitle:bar.backButtonTitle style:UIBarButtonItemStylePlain target:nil action:nil];
         }
         [vc matcha_setViewId:childView.screenId];
+        [vc matcha_setTransition:childView];
         [viewControllers addObject:vc];
+        
+        bar.stackView = self;
+        bar.screen = vc;
+        bar.needsUpdate = YES;
+        [bars addObject:bar];
     }
+    self.bars = bars;
+    [self.view setNeedsLayout];
     
-    if (self.viewControllers.count == viewControllers.count) {
+    // Screens are pushed and popped with the transition of the top screen.
+    UIViewController *top = viewControllers.count > self.viewControllers.count ? viewControllers.lastObject : self.viewControllers.lastObject;
+    if (self.viewControllers.count == viewControllers.count || top.matcha_transition.transition == MatchaStackTransitionNone) {
         [self setViewControllers:viewControllers animated:NO];
     } else {
         [self setViewControllers:viewControllers animated:YES];
@@ -92,10 +218,32 @@
     self.prev = viewControllers;
 }
 
+- (id<UIViewControllerAnimatedTransitioning>)navigationController:(UINavigationController *)navigationController animationControllerForOperation:(UINavigationControllerOperation)operation fromViewController:(UIViewController *)fromVC toViewController:(UIViewController *)toVC {
+    BOOL push = operation == UINavigationControllerOperationPush;
+    MatchaiOSPBStackChildView *transition = push ? toVC.matcha_transition : fromVC.matcha_transition;
+    if (transition == nil || transition.transition == MatchaStackTransitionDefault || transition.transition == MatchaStackTransitionNone) {
+        return nil;
+    }
+    return [[MatchaStackTransition alloc] initWithProtobuf:transition push:push];
+}
+
 //- (void)navigationController:(UINavigationController *)navigationController willShowViewController:(UIViewController *)viewController animated:(BOOL)animated {
 //    NSLog(@"willShow");
 //}
 
+- (void)viewWillLayoutSubviews {
+    [super viewWillLayoutSubviews];
+    // Go can't be called while it is updating the views, so the bar options and
+    // search bars are read on the next layout pass.
+    for (MatchaStackBar *i in self.bars) {
+        if (i.needsUpdate) {
+            i.needsUpdate = NO;
+            [i updateBar];
+            [i updateSearch];
+        }
+    }
+}
+
 - (void)navigationController:(UINavigationController *)navigationController didShowViewController:(UIViewController *)viewController animated:(BOOL)animated {
     [self update];
 }
@@ -166,6 +314,192 @@
         idx +=1;
     }
     self.leftViews = leftViews;
+    
+    self.needsUpdate = YES;
+    [self.stackView.view setNeedsLayout];
+}
+
+- (void)updateBar {
+    NSArray<MatchaGoValue *> *state = [self.viewNode call:@"Bar State", nil];
+    long long titleMode = state[0].toLongLong;
+    NSData *scrollEdgeColor = state[1].toData;
+    NSArray<MatchaGoValue *> *titles = state[2].toArray;
+    NSArray<MatchaGoValue *> *icons = state[3].toArray;
+    NSArray<MatchaGoValue *> *flags = state[4].toArray;
+    
+    NSMutableArray<UIBarButtonItem *> *items = [NSMutableArray array];
+    NSMutableArray<NSNumber *> *overflow = [NSMutableArray array];
+    for (NSInteger i = 0; i < titles.count; i++) {
+        long long flag = flags[i].toLongLong;
+        if (flag & MatchaStackBarItemFlagOverflow) {
+            [overflow addObject:@(i)];
+            continue;
+        }
+        UIImage *icon = nil;
+        NSData *iconData = icons[i].toData;
+        if (iconData.length > 0) {
+            icon = [[UIImage alloc] initWithImageOrResourceProtobuf:[MatchaPBImageOrResource parseFromData:iconData error:nil]];
+        }
+        UIBarButtonItem *item = nil;
+        if (icon != nil) {
+            item = [[UIBarButtonItem alloc] initWithImage:icon style:UIBarButtonItemStylePlain target:self action:@selector(itemPressed:)];
+        } else {
+            item = [[UIBarButtonItem alloc] initWithTitle:titles[i].toString style:UIBarButtonItemStylePlain target:self action:@selector(itemPressed:)];
+        }
+        item.tag = i;
+        item.enabled = (flag & MatchaStackBarItemFlagDisabled) == 0;
+        [items addObject:item];
+    }
+    if (overflow.count > 0) {
+        // The overflow button is at the right edge of the bar.
+        UIBarButtonItem *more = nil;
+        if (@available(iOS 13.0, *)) {
+            more = [[UIBarButtonItem alloc] initWithImage:[UIImage systemImageNamed:@"ellipsis.circle"] style:UIBarButtonItemStylePlain target:nil action:nil];
+        } else {
+            more = [[UIBarButtonItem alloc] initWithTitle:@"More" style:UIBarButtonItemStylePlain target:nil action:nil];
+        }
+        if (@available(iOS 14.0, *)) {
+            NSMutableArray<UIMenuElement *> *actions = [NSMutableArray array];
+            __weak MatchaStackBar *weakSelf = self;
+            for (NSNumber *i in overflow) {
+                UIAction *action = [UIAction actionWithTitle:titles[i.integerValue].toString image:nil identifier:nil handler:^(UIAction *action) {
+                    [weakSelf.viewNode call:@"Bar OnPress", [[MatchaGoValue alloc] initWithLongLong:i.longLongValue], nil];
+                }];
+                if (flags[i.integerValue].toLongLong & MatchaStackBarItemFlagDisabled) {
+                    action.attributes = UIMenuElementAttributesDisabled;
+                }
+                [actions addObject:action];
+            }
+            more.menu = [UIMenu menuWithTitle:@"" children:actions];
+        } else {
+            more.target = self;
+            more.action = @selector(morePressed:);
+        }
+        [items insertObject:more atIndex:0];
+    }
+    self.overflow = overflow;
+    self.overflowTitles = titles;
+    self.overflowFlags = flags;
+    self.screen.navigationItem.rightBarButtonItems = [items arrayByAddingObjectsFromArray:self.rightViews ?: @[]];
+    
+    if (@available(iOS 11.0, *)) {
+        if (titleMode == MatchaStackBarTitleModeStandard) {
+            self.screen.navigationItem.largeTitleDisplayMode = UINavigationItemLargeTitleDisplayModeNever;
+        } else {
+            self.screen.navigationItem.largeTitleDisplayMode = UINavigationItemLargeTitleDisplayModeAlways;
+            self.stackView.navigationBar.prefersLargeTitles = YES;
+        }
+    }
+    if (@available(iOS 13.0, *)) {
+        UINavigationBarAppearance *appearance = nil;
+        if (scrollEdgeColor.length > 0) {
+            UIColor *color = [[UIColor alloc] initWithProtobuf:[MatchaPBColor parseFromData:scrollEdgeColor error:nil]];
+            CGFloat alpha = 0;
+            [color getRed:nil green:nil blue:nil alpha:&alpha];
+            appearance = [[UINavigationBarAppearance alloc] init];
+            if (alpha == 0) {
+                [appearance configureWithTransparentBackground];
+            } else {
+                [appearance configureWithOpaqueBackground];
+                appearance.backgroundColor = color;
+            }
+            NSDictionary *titleAttributes = self.stackView.navigationBar.titleTextAttributes;
+            if (titleAttributes != nil) {
+                appearance.titleTextAttributes = titleAttributes;
+            }
+        }
+        self.screen.navigationItem.scrollEdgeAppearance = appearance;
+    }
+    if (@available(iOS 15.0, *)) {
+        // The bar only follows a scroll view that it finds itself, or that is
+        // set here.
+        UIScrollView *scrollView = nil;
+        if (titleMode == MatchaStackBarTitleModeCollapsing || scrollEdgeColor.length > 0) {
+            scrollView = MatchaStackBarFindScrollView(self.screen.view);
+        }
+        [self.screen setContentScrollView:scrollView forEdge:NSDirectionalRectEdgeTop];
+    }
+}
+
+- (void)itemPressed:(UIBarButtonItem *)item {
+    [self.viewNode call:@"Bar OnPress", [[MatchaGoValue alloc] initWithLongLong:item.tag], nil];
+}
+
+- (void)morePressed:(UIBarButtonItem *)item {
+    UIAlertController *alert = [UIAlertController alertControllerWithTitle:nil message:nil preferredStyle:UIAlertControllerStyleActionSheet];
+    __weak MatchaStackBar *weakSelf = self;
+    for (NSNumber *i in self.overflow) {
+        UIAlertAction *action = [UIAlertAction actionWithTitle:self.overflowTitles[i.integerValue].toString style:UIAlertActionStyleDefault handler:^(UIAlertAction *action) {
+            [weakSelf.viewNode call:@"Bar OnPress", [[MatchaGoValue alloc] initWithLongLong:i.longLongValue], nil];
+        }];
+        action.enabled = (self.overflowFlags[i.integerValue].toLongLong & MatchaStackBarItemFlagDisabled) == 0;
+        [alert addAction:action];
+    }
+    [alert addAction:[UIAlertAction actionWithTitle:@"Cancel" style:UIAlertActionStyleCancel handler:nil]];
+    alert.popoverPresentationController.barButtonItem = item;
+    [self.screen presentViewController:alert animated:YES completion:nil];
+}
+
+- (void)updateSearch {
+    if (@available(iOS 11.0, *)) {
+        NSArray<MatchaGoValue *> *state = [self.viewNode call:@"Search State", nil];
+        BOOL enabled = state[0].toBool;
+        NSString *placeholder = state[1].toString;
+        NSString *text = state[2].toString;
+        BOOL focused = state[3].toBool;
+        
+        if (!enabled) {
+            self.searchController = nil;
+            self.screen.navigationItem.searchController = nil;
+            return;
+        }
+        if (self.searchController == nil) {
+            self.searchController = [[UISearchController alloc] initWithSearchResultsController:nil];
+            self.searchController.obscuresBackgroundDuringPresentation = NO;
+            self.searchController.searchResultsUpdater = self;
+            self.searchController.searchBar.delegate = self;
+        }
+        UISearchBar *searchBar = self.searchController.searchBar;
+        searchBar.placeholder = placeholder;
+        if (![searchBar.text isEqual:text]) {
+            searchBar.text = text;
+        }
+        if (self.screen.navigationItem.searchController != self.searchController) {
+            self.screen.navigationItem.searchController = self.searchController;
+            self.screen.navigationItem.hidesSearchBarWhenScrolling = NO;
+        }
+        if (focused && !searchBar.isFirstResponder) {
+            self.searchController.active = YES;
+            [searchBar becomeFirstResponder];
+        } else if (!focused && searchBar.isFirstResponder) {
+            [searchBar resignFirstResponder];
+        }
+    }
+}
+
+#pragma mark - UISearchResultsUpdating
+
+- (void)updateSearchResultsForSearchController:(UISearchController *)searchController {
+    NSString *text = searchController.searchBar.text ?: @"";
+    [self.viewNode call:@"Search OnChange", [[MatchaGoValue alloc] initWithString:text], nil];
+}
+
+#pragma mark - UISearchBarDelegate
+
+- (void)searchBarTextDidBeginEditing:(UISearchBar *)searchBar {
+    [self.viewNode call:@"Search OnFocus", [[MatchaGoValue alloc] initWithBool:YES], nil];
+}
+
+- (void)searchBarTextDidEndEditing:(UISearchBar *)searchBar {
+    [self.viewNode call:@"Search OnFocus", [[MatchaGoValue alloc] initWithBool:NO], nil];
+}
+
+- (void)searchBarSearchButtonClicked:(UISearchBar *)searchBar {
+    [self.viewNode call:@"Search OnSubmit", [[MatchaGoValue alloc] initWithString:searchBar.text ?: @""], nil];
+}
+
+- (void)searchBarCancelButtonClicked:(UISearchBar *)searchBar {
+    [self.viewNode call:@"Search OnCancel", nil];
 }
 
 - (void)setMatchaChildLayout:(NSArray<MatchaViewPBLayoutPaintNode *> *)layoutPaintNodes {
@@ -4,6 +4,10 @@
 #import "MatchaViewController.h"
 #import "MatchaView_Private.h"
 
+@interface MatchaTabView ()
+@property (nonatomic, assign) BOOL needsTabFlags;
+@end
+
 @implementation MatchaTabView
 
 + (void)load {
@@ -50,6 +54,31 @@
     
     self.viewControllers = viewControllers;
     self.selectedIndex = (int)pbTabNavigator.selectedIndex;
+    
+    // Go can't be called while it is updating the views, so the rest of the tab
+    // state is read on the next layout pass.
+    self.needsTabFlags = YES;
+    [self.view setNeedsLayout];
+}
+
+- (void)viewWillLayoutSubviews {
+    [super viewWillLayoutSubviews];
+    if (self.needsTabFlags) {
+        self.needsTabFlags = NO;
+        NSArray<MatchaGoValue *> *flags = [[self.viewNode call:@"TabFlags", nil][0] toArray];
+        for (NSInteger i = 0; i < flags.count && i < self.viewControllers.count; i++) {
+            long long flag = flags[i].toLongLong;
+            UITabBarItem *item = self.viewControllers[i].tabBarItem;
+            item.enabled = (flag & 1) == 0;
+            if (flag & 2) {
+                item.badgeValue = @""; // An empty badge is displayed as a dot.
+            }
+        }
+    }
+}
+
+- (BOOL)tabBarController:(UITabBarController *)tabBarController shouldSelectViewController:(UIViewController *)viewController {
+    return viewController.tabBarItem.enabled;
 }
 
 - (void)tabBarController:(UITabBarController *)tabBarController didSelectViewController:(UIViewController *)viewController {
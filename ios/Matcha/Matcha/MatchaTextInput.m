@@ -47,6 +47,7 @@
     self.keyboardType = MatchaKeyboardTypeWithProtobuf(view.keyboardType);
     self.keyboardAppearance = MatchaKeyboardAppearanceWithProtobuf(view.keyboardAppearance);
     self.multiline = view.maxLines != 1;
+    [self setNeedsLayout];
     self.secureTextEntry = view.secureTextEntry;
     
     if (self.hasFocus && !self.isFirstResponder) {
@@ -56,6 +57,24 @@
     }
 }
 
+- (void)layoutSubviews {
+    [super layoutSubviews];
+    
+    // Multiline inputs scroll once their content outgrows the height Go gave them.
+    if (self.multiline) {
+        CGSize size = [self sizeThatFits:CGSizeMake(self.bounds.size.width, CGFLOAT_MAX)];
+        bool scroll = size.height > self.bounds.size.height + 0.5;
+        if (scroll != self.scrollEnabled) {
+            self.scrollEnabled = scroll;
+            if (scroll) {
+                [self scrollRangeToVisible:self.selectedRange];
+            }
+        }
+    } else if (self.scrollEnabled) {
+        self.scrollEnabled = false;
+    }
+}
+
 - (CGRect)caretRectForPosition:(UITextPosition *)position {
     CGRect originalRect = [super caretRectForPosition:position];
     if (self.font && originalRect.size.height > 2) {
@@ -74,10 +93,11 @@
     MatchaViewPBTextInputEvent *event = [[MatchaViewPBTextInputEvent alloc] init];
     event.styledText = self.attributedText.protobuf;
     [self.viewNode call:@"OnTextChange", [[MatchaGoValue alloc] initWithData:event.data], nil];
+    [self setNeedsLayout];
 }
 
 - (BOOL)textView:(UITextView *)textView shouldChangeTextInRange:(NSRange)range replacementText:(NSString *)text {
-    if ([text isEqualToString:@"\n"]) {
+    if (!self.multiline && [text isEqualToString:@"\n"]) {
         [self.viewNode call:@"OnSubmit", nil];
         return NO;
     }
@@ -2,6 +2,6 @@
 #import "MatchaView.h"
 #import "MatchaProtobuf.h"
 
-@interface MatchaTextView : UILabel <MatchaChildView>
+@interface MatchaTextView : UILabel <MatchaChildView, UITextViewDelegate>
 @property (nonatomic, weak) MatchaViewNode *viewNode;
 @end
@@ -1,6 +1,17 @@
 #import "MatchaTextView.h"
 #import "MatchaViewController.h"
 
+@interface MatchaTextView ()
+@property (nonatomic, strong) NSAttributedString *styledText;
+@property (nonatomic, strong) NSArray<NSValue *> *tapRanges;
+@property (nonatomic, strong) NSAttributedString *spannedText; // The styled text with the spans' backgrounds.
+@property (nonatomic, assign) NSRange pressedRange;
+@property (nonatomic, assign) NSLineBreakMode truncationMode;
+@property (nonatomic, assign) bool needsSpans;
+@property (nonatomic, strong) UITextView *selectableView; // Displays the text while it is selectable.
+@property (nonatomic, assign) bool updatingSelectableView;
+@end
+
 @implementation MatchaTextView
 
 + (void)load {
@@ -12,6 +23,8 @@
 - (id)initWithViewNode:(MatchaViewNode *)viewNode {
     if ((self = [super initWithFrame:CGRectZero])) {
         self.viewNode = viewNode;
+        self.pressedRange = NSMakeRange(NSNotFound, 0);
+        [self addGestureRecognizer:[[UITapGestureRecognizer alloc] initWithTarget:self action:@selector(onTap:)]];
     }
     return self;
 }
@@ -19,8 +32,212 @@
 - (void)setNativeState:(NSData *)nativeState {
     MatchaPBStyledText *text = [MatchaPBStyledText parseFromData:nativeState error:nil];
     NSAttributedString *attrString = [[NSAttributedString alloc] initWithProtobuf:text];
+    switch (text.stylesArray.firstObject.truncation) {
+        case MatchaPBTruncation_TruncationStart:
+            self.truncationMode = NSLineBreakByTruncatingHead;
+            break;
+        case MatchaPBTruncation_TruncationMiddle:
+            self.truncationMode = NSLineBreakByTruncatingMiddle;
+            break;
+        case MatchaPBTruncation_TruncationEnd:
+            self.truncationMode = NSLineBreakByTruncatingTail;
+            break;
+        default:
+            self.truncationMode = NSLineBreakByWordWrapping;
+    }
+    self.styledText = attrString;
+    self.spannedText = attrString;
     self.attributedText = attrString;
     self.numberOfLines = 0;
+    
+    // Go can't be called while it is updating the views, so the spans are read
+    // once the update completes.
+    self.needsSpans = true;
+    [self setNeedsLayout];
+}
+
+// The label's line break mode truncates the text where it runs out of room,
+// by whole characters, while a truncating paragraph style would truncate every
+// line.
+- (void)setAttributedText:(NSAttributedString *)attributedText {
+    [super setAttributedText:attributedText];
+    self.lineBreakMode = self.truncationMode;
+}
+
+- (void)layoutSubviews {
+    [super layoutSubviews];
+    if (self.needsSpans) {
+        self.needsSpans = false;
+        [self updateSpans];
+        [self updateSelectable];
+    }
+    self.selectableView.frame = self.bounds;
+}
+
+- (void)updateSpans {
+    NSArray<MatchaGoValue *> *spans = [[self.viewNode call:@"Spans", nil][0] toArray];
+    NSMutableArray<NSValue *> *tapRanges = [NSMutableArray array];
+    NSMutableAttributedString *str = nil;
+    for (NSInteger i = 0; i + 3 < spans.count; i += 4) {
+        NSInteger start = (NSInteger)spans[i].toLongLong;
+        NSInteger end = (NSInteger)spans[i+1].toLongLong;
+        uint32_t argb = (uint32_t)spans[i+2].toLongLong;
+        if (start < 0 || end > self.styledText.length || start >= end) {
+            continue;
+        }
+        NSRange range = NSMakeRange(start, end - start);
+        if (argb != 0) {
+            if (str == nil) {
+                str = [self.styledText mutableCopy];
+            }
+            UIColor *color = [UIColor colorWithRed:((argb >> 16) & 0xff)/255.0 green:((argb >> 8) & 0xff)/255.0 blue:(argb & 0xff)/255.0 alpha:((argb >> 24) & 0xff)/255.0];
+            [str addAttribute:NSBackgroundColorAttributeName value:color range:range];
+        }
+        if (spans[i+3].toLongLong != 0) {
+            [tapRanges addObject:[NSValue valueWithRange:range]];
+        }
+    }
+    self.spannedText = str ?: self.styledText;
+    self.attributedText = self.spannedText;
+    self.pressedRange = NSMakeRange(NSNotFound, 0);
+    self.tapRanges = tapRanges;
+    self.userInteractionEnabled = tapRanges.count > 0;
+}
+
+// updateSelectable displays the text in a text view while it is selectable,
+// since labels can't be selected.
+- (void)updateSelectable {
+    BOOL selectable = [self.viewNode call:@"Selectable", nil][0].toBool;
+    if (!selectable) {
+        if (self.selectableView != nil) {
+            [self.selectableView removeFromSuperview];
+            self.selectableView = nil;
+            [self setNeedsDisplay];
+        }
+        return;
+    }
+    if (self.selectableView == nil) {
+        self.selectableView = [[UITextView alloc] initWithFrame:self.bounds];
+        self.selectableView.editable = NO;
+        self.selectableView.selectable = YES;
+        self.selectableView.scrollEnabled = NO;
+        self.selectableView.backgroundColor = [UIColor clearColor];
+        self.selectableView.textContainerInset = UIEdgeInsetsZero;
+        self.selectableView.textContainer.lineFragmentPadding = 0;
+        self.selectableView.delegate = self;
+        [self addSubview:self.selectableView];
+        [self setNeedsDisplay];
+    }
+    // Go's text is set first, so that the selection it clears isn't reported
+    // back.
+    self.updatingSelectableView = true;
+    self.selectableView.attributedText = self.spannedText;
+    self.updatingSelectableView = false;
+    self.userInteractionEnabled = YES;
+}
+
+- (void)drawTextInRect:(CGRect)rect {
+    if (self.selectableView == nil) {
+        [super drawTextInRect:rect];
+    }
+}
+
+- (void)textViewDidChangeSelection:(UITextView *)textView {
+    if (self.updatingSelectableView) {
+        return;
+    }
+    NSRange range = textView.selectedRange;
+    [self.viewNode call:@"OnSelectionChange", [[MatchaGoValue alloc] initWithLongLong:range.location], [[MatchaGoValue alloc] initWithLongLong:NSMaxRange(range)], nil];
+}
+
+- (void)onTap:(UITapGestureRecognizer *)recognizer {
+    NSUInteger index = 0;
+    NSRange range = [self tapRangeAtPoint:[recognizer locationInView:self] index:&index];
+    if (range.location != NSNotFound) {
+        [self.viewNode call:@"OnTap", [[MatchaGoValue alloc] initWithLongLong:index], nil];
+    }
+}
+
+// The tappable range under a touch is highlighted while it is pressed.
+- (void)touchesBegan:(NSSet<UITouch *> *)touches withEvent:(UIEvent *)event {
+    [super touchesBegan:touches withEvent:event];
+    [self setPressedRange:[self tapRangeAtPoint:[touches.anyObject locationInView:self] index:NULL]];
+}
+
+- (void)touchesMoved:(NSSet<UITouch *> *)touches withEvent:(UIEvent *)event {
+    [super touchesMoved:touches withEvent:event];
+    NSRange range = [self tapRangeAtPoint:[touches.anyObject locationInView:self] index:NULL];
+    if (!NSEqualRanges(range, self.pressedRange)) {
+        [self setPressedRange:NSMakeRange(NSNotFound, 0)];
+    }
+}
+
+- (void)touchesEnded:(NSSet<UITouch *> *)touches withEvent:(UIEvent *)event {
+    [super touchesEnded:touches withEvent:event];
+    [self setPressedRange:NSMakeRange(NSNotFound, 0)];
+}
+
+- (void)touchesCancelled:(NSSet<UITouch *> *)touches withEvent:(UIEvent *)event {
+    [super touchesCancelled:touches withEvent:event];
+    [self setPressedRange:NSMakeRange(NSNotFound, 0)];
+}
+
+- (void)setPressedRange:(NSRange)pressedRange {
+    if (NSEqualRanges(pressedRange, _pressedRange)) {
+        return;
+    }
+    _pressedRange = pressedRange;
+    if (self.spannedText == nil || self.selectableView != nil) {
+        return;
+    }
+    if (pressedRange.location == NSNotFound) {
+        self.attributedText = self.spannedText;
+        return;
+    }
+    NSMutableAttributedString *str = [self.spannedText mutableCopy];
+    [str addAttribute:NSBackgroundColorAttributeName value:[UIColor colorWithWhite:0 alpha:0.2] range:pressedRange];
+    self.attributedText = str;
+}
+
+// tapRangeAtPoint returns the tappable range that contains the character at
+// point, and sets index to the character. The range's location is NSNotFound
+// if there is none.
+- (NSRange)tapRangeAtPoint:(CGPoint)point index:(NSUInteger *)index {
+    NSRange notFound = NSMakeRange(NSNotFound, 0);
+    if (self.tapRanges.count == 0 || self.attributedText.length == 0) {
+        return notFound;
+    }
+    
+    // Lay out the text like the label to find the tapped character.
+    NSTextStorage *storage = [[NSTextStorage alloc] initWithAttributedString:self.attributedText];
+    NSLayoutManager *layoutManager = [[NSLayoutManager alloc] init];
+    NSTextContainer *container = [[NSTextContainer alloc] initWithSize:self.bounds.size];
+    container.lineFragmentPadding = 0;
+    container.maximumNumberOfLines = self.numberOfLines;
+    container.lineBreakMode = self.lineBreakMode;
+    [layoutManager addTextContainer:container];
+    [storage addLayoutManager:layoutManager];
+    
+    // UILabel centers its text vertically.
+    CGRect used = [layoutManager usedRectForTextContainer:container];
+    point.y -= (self.bounds.size.height - used.size.height) / 2;
+    
+    CGFloat fraction = 0;
+    NSUInteger glyph = [layoutManager glyphIndexForPoint:point inTextContainer:container fractionOfDistanceThroughGlyph:&fraction];
+    CGRect glyphRect = [layoutManager boundingRectForGlyphRange:NSMakeRange(glyph, 1) inTextContainer:container];
+    if (!CGRectContainsPoint(glyphRect, point)) {
+        return notFound;
+    }
+    NSUInteger i = [layoutManager characterIndexForGlyphAtIndex:glyph];
+    for (NSValue *r in self.tapRanges) {
+        if (NSLocationInRange(i, r.rangeValue)) {
+            if (index != NULL) {
+                *index = i;
+            }
+            return r.rangeValue;
+        }
+    }
+    return notFound;
 }
 
 @end
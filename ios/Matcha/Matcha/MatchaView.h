@@ -7,6 +7,8 @@
 @protocol MatchaChildView <NSObject>
 - (id)initWithViewNode:(MatchaViewNode *)viewNode; // viewNode should be weakly retained
 - (void)setNativeState:(NSData *)nativeState;
+@optional
+- (void)setMatchaChildViews:(NSArray<UIView *> *)childViews; // for views that place their children themselves, the children are not added as subviews.
 @end
 
 @protocol MatchaChildViewController <NSObject>
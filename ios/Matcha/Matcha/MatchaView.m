@@ -9,6 +9,7 @@
 #import "MatchaUnknownView.h"
 #import "MatchaView_Private.h"
 #import "MatchaBuildNode.h"
+#import "MatchaContextMenu.h"
 
 UIView<MatchaChildView> *MatchaViewWithNode(MatchaBuildNode *node, MatchaViewNode *viewNode);
 static NSLock *sLock = nil;
@@ -50,6 +51,48 @@ UIGestureRecognizer *MatchaGestureRecognizerWithPB(int64_t viewId, GPBAny *any,
     return nil;
 }
 
+// MatchaGradientLayerUpdate configures layer with the JSON gradient sent by the
+// paint style. It returns NO if the gradient can't be drawn.
+BOOL MatchaGradientLayerUpdate(CAGradientLayer *layer, NSData *data) {
+    NSDictionary *gradient = [NSJSONSerialization JSONObjectWithData:data options:0 error:nil];
+    if (![gradient isKindOfClass:[NSDictionary class]]) {
+        return NO;
+    }
+    NSMutableArray *colors = [NSMutableArray array];
+    for (NSArray<NSNumber *> *i in gradient[@"colors"]) {
+        UIColor *color = [UIColor colorWithRed:i[0].doubleValue green:i[1].doubleValue blue:i[2].doubleValue alpha:i[3].doubleValue];
+        [colors addObject:(id)color.CGColor];
+    }
+    if (colors.count == 1) {
+        [colors addObject:colors[0]];
+    }
+    layer.colors = colors;
+    layer.locations = colors.count == [gradient[@"locations"] count] ? gradient[@"locations"] : nil;
+    
+    if ([gradient[@"type"] integerValue] == 1) { // Radial
+        if (@available(iOS 12.0, *)) {
+            NSArray<NSNumber *> *center = gradient[@"center"];
+            CGFloat radius = [gradient[@"radius"] doubleValue];
+            CGSize size = layer.bounds.size;
+            CGFloat aspect = size.height > 0 ? size.width / size.height : 1;
+            layer.type = kCAGradientLayerRadial;
+            layer.startPoint = CGPointMake(center[0].doubleValue, center[1].doubleValue);
+            layer.endPoint = CGPointMake(center[0].doubleValue + radius, center[1].doubleValue + radius * aspect);
+            return YES;
+        }
+        return NO;
+    }
+    
+    // A linear gradient runs through the middle of the view. At 0 degrees it
+    // goes from top to bottom, and at 90 from left to right.
+    CGFloat angle = [gradient[@"angle"] doubleValue] * M_PI / 180;
+    CGFloat dx = sin(angle) / 2, dy = cos(angle) / 2;
+    layer.type = kCAGradientLayerAxial;
+    layer.startPoint = CGPointMake(0.5 - dx, 0.5 - dy);
+    layer.endPoint = CGPointMake(0.5 + dx, 0.5 + dy);
+    return YES;
+}
+
 UIView<MatchaChildView> *MatchaViewWithNode(MatchaBuildNode *node, MatchaViewNode *viewNode) {
     NSString *name = node.nativeViewName;
     UIView<MatchaChildView> *child = nil;
@@ -81,6 +124,8 @@ UIViewController<MatchaChildViewController> *MatchaViewControllerWithNode(Matcha
 - (id)initWithParent:(MatchaViewNode *)node rootVC:(MatchaViewController *)rootVC identifier:(NSNumber *)identifier;
 @property (nonatomic, strong) UIView<MatchaChildView> *view;
 @property (nonatomic, strong) NSDictionary<NSNumber *, UIGestureRecognizer *> *touchRecognizers;
+@property (nonatomic, strong) MatchaContextMenu *contextMenu;
+@property (nonatomic, strong) CAGradientLayer *gradientLayer;
 
 - (void)setRoot:(MatchaViewPBRoot *)root;
 @property (nonatomic, strong) UIViewController<MatchaChildViewController> *viewController;
@@ -95,6 +140,7 @@ UIViewController<MatchaChildViewController> *MatchaViewControllerWithNode(Matcha
 - (UIViewController *)materializedViewController;
 - (UIViewController *)wrappedViewController;
 - (UIView *)materializedView;
+- (BOOL)isContainerView;
 
 @property (nonatomic, assign) CGRect frame;
 @end
@@ -118,6 +164,14 @@ UIViewController<MatchaChildViewController> *MatchaViewControllerWithNode(Matcha
     return self;
 }
 
+- (NSInteger)nodeCount {
+    NSInteger count = 1;
+    for (MatchaViewNode *i in self.children.allValues) {
+        count += [i nodeCount];
+    }
+    return count;
+}
+
 - (void)setRoot:(MatchaViewPBRoot *)root {
     MatchaViewPBLayoutPaintNode *pbLayoutPaintNode = [root.layoutPaintNodes objectForKey:self.identifier.longLongValue];
     
@@ -191,12 +245,19 @@ UIViewController<MatchaChildViewController> *MatchaViewControllerWithNode(Matcha
         }
         
         // Add/remove subviews
+        if (self.isContainerView) {
+            NSMutableArray<UIView *> *childViews = [NSMutableArray array];
+            for (MatchaViewNode *i in childrenArray) {
+                [childViews addObject:i.materializedView];
+            }
+            self.view.matchaChildViews = childViews;
+        }
         for (NSNumber *i in addedKeys) {
             MatchaViewNode *child = children[i];
             // child.view.node = [[MatchaBuildNode alloc] initWithProtobuf:[root.buildNodes objectForKey:i.longLongValue]];
             
-            if (self.viewController) {
-                // no-op. The view controller will handle this itself.
+            if (self.viewController || self.isContainerView) {
+                // no-op. The view controller or container view will handle this itself.
             } else if (child.view) {
                 [self.materializedView addSubview:child.view];
             } else if (child.viewController) {
@@ -208,6 +269,8 @@ UIViewController<MatchaChildViewController> *MatchaViewControllerWithNode(Matcha
             MatchaViewNode *child = self.children[i];
             if (self.viewController) {
                 // no-op
+            } else if (self.isContainerView) {
+                [child.materializedView removeFromSuperview];
             } else if (child.view) {
                 [child.view removeFromSuperview];
             } else if (child.viewController) {
@@ -254,11 +317,24 @@ UIViewController<MatchaChildViewController> *MatchaViewControllerWithNode(Matcha
             }
             self.touchRecognizers = touchRecognizers;
         }
+        
+        // Update context menu
+        if (self.view) {
+            if (buildNode.nativeValues[@"gomatcha.io/matcha/contextmenu"] != nil) {
+                if (self.contextMenu == nil) {
+                    self.contextMenu = [[MatchaContextMenu alloc] initWithViewNode:self];
+                }
+                [self.contextMenu attachToView:self.view];
+            } else if (self.contextMenu != nil) {
+                [self.contextMenu detach];
+                self.contextMenu = nil;
+            }
+        }
     }
 
     // Layout subviews
     if (pbLayoutPaintNode != nil && pbLayoutPaintNode.layoutId != self.layoutPaintNode.layoutId) {
-        if (self.view) {
+        if (self.view && !self.isContainerView) {
             for (NSInteger i = 0; i < pbLayoutPaintNode.childOrderArray.count; i++) {
                 NSNumber *key = @([pbLayoutPaintNode.childOrderArray valueAtIndex:i]);
                 UIView *subview = children[key].view;
@@ -279,6 +355,12 @@ UIViewController<MatchaChildViewController> *MatchaViewControllerWithNode(Matcha
             scrollView.matchaContentOffset = origin;
             scrollView.contentOffset = origin;
             scrollView.contentSize = f.size;
+        } else if (self.parent.isContainerView) { // the parent positions the view, only set its size
+            f.origin = CGPointZero;
+            if (!CGRectEqualToRect(f, self.frame)) {
+                self.materializedView.frame = f;
+                self.frame = f;
+            }
         } else if (self.parent.viewController == nil) { // let view controllers do their own layout
             if (!CGRectEqualToRect(f, self.frame)) {
                 self.materializedView.frame = f;
@@ -316,7 +398,9 @@ UIViewController<MatchaChildViewController> *MatchaViewControllerWithNode(Matcha
         self.view.layer.shadowColor = shadowColor;
         self.view.layer.shadowOpacity = pbLayoutPaintNode.paintStyle.hasShadowColor ? 1 : 0;
         if (pbLayoutPaintNode.paintStyle.cornerRadius != 0) {
-            self.view.clipsToBounds = YES; // TODO(KD): Be better about this...
+            // Clipping would also clip the shadow, so views with shadows only
+            // round their background and border.
+            self.view.clipsToBounds = !pbLayoutPaintNode.paintStyle.hasShadowColor;
         }
         if (borderColor) {
             CFRelease(borderColor);
@@ -326,6 +410,43 @@ UIViewController<MatchaChildViewController> *MatchaViewControllerWithNode(Matcha
         }
     }
     
+    // Shadows of views with a background follow its rounded corners. Views
+    // without one keep the default shadow, which follows their content.
+    if (pbLayoutPaintNode != nil && (pbLayoutPaintNode.layoutId != self.layoutPaintNode.layoutId || pbLayoutPaintNode.paintId != self.layoutPaintNode.paintId)) {
+        MatchaPaintPBStyle *style = pbLayoutPaintNode.paintStyle;
+        if (style.hasShadowColor && style.hasBackgroundColor) {
+            self.view.layer.shadowPath = [UIBezierPath bezierPathWithRoundedRect:self.view.bounds cornerRadius:style.cornerRadius].CGPath;
+        } else {
+            self.view.layer.shadowPath = nil;
+        }
+    }
+    
+    // Gradients are drawn by a layer below the subviews, and sent with the
+    // native values since the paint style doesn't include them.
+    NSString *gradientKey = @"gomatcha.io/matcha/paint gradient";
+    BOOL gradientChanged = buildNode != nil && ![buildNode.nativeValues[gradientKey] isEqual:self.buildNode.nativeValues[gradientKey]];
+    if (self.view && pbLayoutPaintNode != nil && (gradientChanged || pbLayoutPaintNode.layoutId != self.layoutPaintNode.layoutId || pbLayoutPaintNode.paintId != self.layoutPaintNode.paintId)) {
+        NSData *gradient = (buildNode ?: self.buildNode).nativeValues[gradientKey];
+        if (gradient != nil) {
+            if (self.gradientLayer == nil) {
+                self.gradientLayer = [CAGradientLayer layer];
+            }
+            [CATransaction begin];
+            [CATransaction setDisableActions:YES];
+            self.gradientLayer.frame = self.view.bounds;
+            self.gradientLayer.cornerRadius = pbLayoutPaintNode.paintStyle.cornerRadius;
+            self.gradientLayer.hidden = !MatchaGradientLayerUpdate(self.gradientLayer, gradient);
+            [self.view.layer insertSublayer:self.gradientLayer atIndex:0];
+            [CATransaction commit];
+        } else if (self.gradientLayer != nil) {
+            [self.gradientLayer removeFromSuperlayer];
+            self.gradientLayer = nil;
+        }
+        if (gradient != nil && pbLayoutPaintNode.paintStyle.hasShadowColor) {
+            self.view.layer.shadowPath = [UIBezierPath bezierPathWithRoundedRect:self.view.bounds cornerRadius:pbLayoutPaintNode.paintStyle.cornerRadius].CGPath;
+        }
+    }
+    
     if (pbLayoutPaintNode != nil) {
         _layoutPaintNode = pbLayoutPaintNode;
     }
@@ -363,6 +484,10 @@ UIViewController<MatchaChildViewController> *MatchaViewControllerWithNode(Matcha
     return _wrappedViewController;
 }
 
+- (BOOL)isContainerView {
+    return [self.view respondsToSelector:@selector(setMatchaChildViews:)];
+}
+
 - (UIView *)materializedView {
     return self.viewController.view ?: self.view;
 }
@@ -13,3 +13,12 @@ typedef UIViewController<MatchaChildViewController> *(^MatchaViewControllerRegis
 + (void)registerView:(NSString *)viewName block:(MatchaViewRegistrationBlock)block;
 + (void)registerViewController:(NSString *)viewName block:(MatchaViewControllerRegistrationBlock)block;
 @end
+
+// MatchaExtensionViewController displays the view returned by a registered Go
+// function. It is the entry point of app extensions built with --ios-extension,
+// and can be used as the principal class of a Today widget or share extension.
+//
+//  bridge.RegisterFunc("github.com/example/widget New", func() view.View { ... })
+@interface MatchaExtensionViewController : MatchaViewController
+- (id)initWithFuncName:(NSString *)name;
+@end
@@ -50,6 +50,23 @@
     }
 }
 
+- (void)viewSafeAreaInsetsDidChange {
+    [super viewSafeAreaInsetsDidChange];
+    if (@available(iOS 11.0, *)) {
+        static MatchaGoValue *safeAreaFunc = nil;
+        if (safeAreaFunc == nil) {
+            safeAreaFunc = [[MatchaGoValue alloc] initWithFunc:@"gomatcha.io/matcha/application SetSafeAreaInsets"];
+        }
+        UIEdgeInsets insets = self.view.safeAreaInsets;
+        [safeAreaFunc call:nil,
+            [[MatchaGoValue alloc] initWithDouble:insets.top],
+            [[MatchaGoValue alloc] initWithDouble:insets.left],
+            [[MatchaGoValue alloc] initWithDouble:insets.bottom],
+            [[MatchaGoValue alloc] initWithDouble:insets.right],
+            nil];
+    }
+}
+
 - (NSArray<MatchaGoValue *> *)call:(NSString *)funcId viewId:(int64_t)viewId args2:(NSArray *)args {
     MatchaGoValue *goValue = [[MatchaGoValue alloc] initWithString:funcId];
     MatchaGoValue *goViewId = [[MatchaGoValue alloc] initWithLongLong:viewId];
@@ -69,6 +86,10 @@
     return [self.goValue call:@"Call", goValue, goViewId, [[MatchaGoValue alloc] initWithArray:array], nil];
 }
 
+- (NSInteger)nativeViewCount {
+    return [self.viewNode nodeCount];
+}
+
 - (void)update:(MatchaViewPBRoot *)root {
     self.updating = true;
     [self.viewNode setRoot:root];
@@ -76,7 +97,7 @@
     GPBAny *any = root.middleware[@"gomatcha.io/matcha/app activity"];
     if (any) {
         MatchaAppPBActivityIndicator *indicator = (id)[any unpackMessageClass:[MatchaAppPBActivityIndicator class] error:NULL];
-        [UIApplication.sharedApplication setNetworkActivityIndicatorVisible:indicator.visible];
+        [MatchaSharedApplication() setNetworkActivityIndicatorVisible:indicator.visible];
     }
     
     any = root.middleware[@"gomatcha.io/matcha/app statusbar"];
@@ -122,3 +143,15 @@
 }
 
 @end
+
+@implementation MatchaExtensionViewController
+
+- (id)initWithFuncName:(NSString *)name {
+    MatchaGoValue *value = [[[MatchaGoValue alloc] initWithFunc:name] call:nil, nil][0];
+    if ((self = [super initWithGoValue:value])) {
+        self.preferredContentSize = CGSizeMake(0, 110);
+    }
+    return self;
+}
+
+@end
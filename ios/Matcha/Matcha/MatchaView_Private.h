@@ -15,4 +15,5 @@ void MatchaRegisterViewController(NSString *string, MatchaViewControllerRegistra
 - (UIViewController<MatchaChildViewController> *)viewController;
 - (UIView<MatchaChildView> *)view;
 - (MatchaViewController *)rootVC;
+- (NSInteger)nodeCount;
 @end
@@ -0,0 +1,6 @@
+#import <UIKit/UIKit.h>
+#import "MatchaView.h"
+
+@interface MatchaZoomImageView : UIView <MatchaChildView, UIScrollViewDelegate>
+@property (nonatomic, weak) MatchaViewNode *viewNode;
+@end
@@ -0,0 +1,143 @@
+#import "MatchaZoomImageView.h"
+#import "MatchaViewController.h"
+
+@interface MatchaZoomImageView ()
+@property (nonatomic, strong) UIScrollView *scrollView;
+@property (nonatomic, strong) UIView *contentView;
+@property (nonatomic, strong) UIView *childView;
+@property (nonatomic, assign) CGFloat doubleTapScale;
+@property (nonatomic, assign) CGFloat scale; // The last scale read from or sent to Go.
+@property (nonatomic, assign) BOOL needsUpdate;
+@end
+
+@implementation MatchaZoomImageView
+
++ (void)load {
+    [MatchaViewController registerView:@"gomatcha.io/matcha/view/zoomimageview" block:^(MatchaViewNode *node){
+        return [[MatchaZoomImageView alloc] initWithViewNode:node];
+    }];
+}
+
+- (id)initWithViewNode:(MatchaViewNode *)viewNode {
+    if ((self = [super initWithFrame:CGRectZero])) {
+        self.viewNode = viewNode;
+        self.scale = 1;
+        self.doubleTapScale = 2;
+        self.scrollView = [[UIScrollView alloc] init];
+        self.scrollView.delegate = self;
+        self.scrollView.showsVerticalScrollIndicator = NO;
+        self.scrollView.showsHorizontalScrollIndicator = NO;
+        self.scrollView.decelerationRate = UIScrollViewDecelerationRateFast;
+        if (@available(iOS 11.0, *)) {
+            self.scrollView.contentInsetAdjustmentBehavior = UIScrollViewContentInsetAdjustmentNever;
+        }
+        [self addSubview:self.scrollView];
+        
+        self.contentView = [[UIView alloc] init];
+        [self.scrollView addSubview:self.contentView];
+        
+        UITapGestureRecognizer *doubleTap = [[UITapGestureRecognizer alloc] initWithTarget:self action:@selector(doubleTapped:)];
+        doubleTap.numberOfTapsRequired = 2;
+        [self.scrollView addGestureRecognizer:doubleTap];
+    }
+    return self;
+}
+
+- (void)setNativeState:(NSData *)nativeState {
+    // Go can't be called while it is updating the views, so the scales are read
+    // on the next layout pass.
+    self.needsUpdate = YES;
+    [self setNeedsLayout];
+}
+
+- (void)setMatchaChildViews:(NSArray<UIView *> *)childViews {
+    UIView *childView = childViews.firstObject;
+    if (childView != self.childView) {
+        [self.childView removeFromSuperview];
+        self.childView = childView;
+        if (childView != nil) {
+            [self.contentView addSubview:childView];
+        }
+    }
+    self.needsUpdate = YES;
+    [self setNeedsLayout];
+}
+
+- (void)layoutSubviews {
+    [super layoutSubviews];
+    if (!CGRectEqualToRect(self.scrollView.frame, self.bounds)) {
+        // The content fills the view at a scale of 1.
+        CGFloat zoomScale = self.scrollView.zoomScale;
+        self.scrollView.zoomScale = 1;
+        self.scrollView.frame = self.bounds;
+        self.contentView.frame = CGRectMake(0, 0, self.bounds.size.width, self.bounds.size.height);
+        self.scrollView.contentSize = self.bounds.size;
+        self.scrollView.zoomScale = zoomScale;
+        [self centerContent];
+    }
+    if (self.needsUpdate) {
+        self.needsUpdate = NO;
+        [self update];
+    }
+}
+
+- (void)update {
+    NSArray<MatchaGoValue *> *state = [self.viewNode call:@"State", nil];
+    self.scrollView.minimumZoomScale = state[0].toDouble;
+    self.scrollView.maximumZoomScale = state[1].toDouble;
+    self.doubleTapScale = state[2].toDouble;
+    CGFloat scale = state[3].toDouble;
+    if (fabs(scale - self.scale) > 0.001) {
+        // Zoom around the center of the visible content.
+        self.scale = scale;
+        CGRect visible = [self.scrollView convertRect:self.scrollView.bounds toView:self.contentView];
+        [self zoomToScale:scale center:CGPointMake(CGRectGetMidX(visible), CGRectGetMidY(visible)) animated:self.window != nil];
+    }
+}
+
+- (void)zoomToScale:(CGFloat)scale center:(CGPoint)center animated:(BOOL)animated {
+    CGSize size = CGSizeMake(self.bounds.size.width / scale, self.bounds.size.height / scale);
+    CGRect rect = CGRectMake(center.x - size.width / 2, center.y - size.height / 2, size.width, size.height);
+    [self.scrollView zoomToRect:rect animated:animated];
+    if (!animated) {
+        [self centerContent];
+    }
+}
+
+- (void)doubleTapped:(UITapGestureRecognizer *)recognizer {
+    CGFloat min = self.scrollView.minimumZoomScale;
+    CGPoint center = [recognizer locationInView:self.contentView];
+    if (self.scrollView.zoomScale > min + 0.01) {
+        [self zoomToScale:min center:center animated:YES];
+    } else {
+        [self zoomToScale:self.doubleTapScale center:center animated:YES];
+    }
+}
+
+// centerContent centers the content while it is smaller than the view.
+- (void)centerContent {
+    CGSize size = self.scrollView.contentSize;
+    CGFloat x = MAX((self.bounds.size.width - size.width) / 2, 0);
+    CGFloat y = MAX((self.bounds.size.height - size.height) / 2, 0);
+    self.scrollView.contentInset = UIEdgeInsetsMake(y, x, y, x);
+}
+
+#pragma mark - UIScrollViewDelegate
+
+- (UIView *)viewForZoomingInScrollView:(UIScrollView *)scrollView {
+    return self.contentView;
+}
+
+- (void)scrollViewDidZoom:(UIScrollView *)scrollView {
+    [self centerContent];
+}
+
+- (void)scrollViewDidEndZooming:(UIScrollView *)scrollView withView:(UIView *)view atScale:(CGFloat)scale {
+    if (fabs(scale - self.scale) <= 0.001) {
+        return;
+    }
+    self.scale = scale;
+    [self.viewNode call:@"OnZoom", [[MatchaGoValue alloc] initWithDouble:scale], nil];
+}
+
+@end
@@ -154,6 +154,28 @@ GPBEnumDescriptor *MatchaPBTruncation_EnumDescriptor(void);
  **/
 BOOL MatchaPBTruncation_IsValidValue(int32_t value);
 
+#pragma mark - Enum MatchaPBWritingDirection
+
+typedef GPB_ENUM(MatchaPBWritingDirection) {
+  /**
+   * Value used if any message's field encounters a value that is not defined
+   * by this enum. The message will also have C functions to get/set the rawValue
+   * of the field.
+   **/
+  MatchaPBWritingDirection_GPBUnrecognizedEnumeratorValue = kGPBUnrecognizedEnumeratorValue,
+  MatchaPBWritingDirection_WritingDirectionNatural = 0,
+  MatchaPBWritingDirection_WritingDirectionLeftToRight = 1,
+  MatchaPBWritingDirection_WritingDirectionRightToLeft = 2,
+};
+
+GPBEnumDescriptor *MatchaPBWritingDirection_EnumDescriptor(void);
+
+/**
+ * Checks to see if the given value is defined by the enum or was not known at
+ * the time this source was generated.
+ **/
+BOOL MatchaPBWritingDirection_IsValidValue(int32_t value);
+
 #pragma mark - MatchaPBTextRoot
 
 /**
@@ -259,6 +281,11 @@ typedef GPB_ENUM(MatchaPBTextStyle_FieldNumber) {
   MatchaPBTextStyle_FieldNumber_Wrap = 22,
   MatchaPBTextStyle_FieldNumber_Truncation = 24,
   MatchaPBTextStyle_FieldNumber_TruncationString = 26,
+  MatchaPBTextStyle_FieldNumber_WritingDirection = 28,
+  MatchaPBTextStyle_FieldNumber_LetterSpacing = 30,
+  MatchaPBTextStyle_FieldNumber_LineHeight = 32,
+  MatchaPBTextStyle_FieldNumber_ParagraphSpacing = 34,
+  MatchaPBTextStyle_FieldNumber_FirstLineIndent = 36,
 };
 
 @interface MatchaPBTextStyle : GPBMessage
@@ -300,6 +327,16 @@ typedef GPB_ENUM(MatchaPBTextStyle_FieldNumber) {
 
 @property(nonatomic, readwrite, copy, null_resettable) NSString *truncationString;
 
+@property(nonatomic, readwrite) MatchaPBWritingDirection writingDirection;
+
+@property(nonatomic, readwrite) double letterSpacing;
+
+@property(nonatomic, readwrite) double lineHeight;
+
+@property(nonatomic, readwrite) double paragraphSpacing;
+
+@property(nonatomic, readwrite) double firstLineIndent;
+
 @end
 
 /**
@@ -362,6 +399,39 @@ int32_t MatchaPBTextStyle_Truncation_RawValue(MatchaPBTextStyle *message);
  **/
 void SetMatchaPBTextStyle_Truncation_RawValue(MatchaPBTextStyle *message, int32_t value);
 
+/**
+ * Fetches the raw value of a @c MatchaPBTextStyle's @c writingDirection property, even
+ * if the value was not defined by the enum at the time the code was generated.
+ **/
+int32_t MatchaPBTextStyle_WritingDirection_RawValue(MatchaPBTextStyle *message);
+/**
+ * Sets the raw value of an @c MatchaPBTextStyle's @c writingDirection property, allowing
+ * it to be set to a value that was not defined by the enum at the time the code
+ * was generated.
+ **/
+void SetMatchaPBTextStyle_WritingDirection_RawValue(MatchaPBTextStyle *message, int32_t value);
+
+#pragma mark - MatchaPBTextMetrics
+
+typedef GPB_ENUM(MatchaPBTextMetrics_FieldNumber) {
+  MatchaPBTextMetrics_FieldNumber_Width = 1,
+  MatchaPBTextMetrics_FieldNumber_Height = 2,
+  MatchaPBTextMetrics_FieldNumber_FirstBaseline = 3,
+  MatchaPBTextMetrics_FieldNumber_LastBaseline = 4,
+};
+
+@interface MatchaPBTextMetrics : GPBMessage
+
+@property(nonatomic, readwrite) double width;
+
+@property(nonatomic, readwrite) double height;
+
+@property(nonatomic, readwrite) double firstBaseline;
+
+@property(nonatomic, readwrite) double lastBaseline;
+
+@end
+
 NS_ASSUME_NONNULL_END
 
 CF_EXTERN_C_END
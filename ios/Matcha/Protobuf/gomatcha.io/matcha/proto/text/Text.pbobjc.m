@@ -250,6 +250,43 @@ BOOL MatchaPBTruncation_IsValidValue(int32_t value__) {
   }
 }
 
+#pragma mark - Enum MatchaPBWritingDirection
+
+GPBEnumDescriptor *MatchaPBWritingDirection_EnumDescriptor(void) {
+  static GPBEnumDescriptor *descriptor = NULL;
+  if (!descriptor) {
+    static const char *valueNames =
+        "WritingDirectionNatural\000WritingDirectionL"
+        "eftToRight\000WritingDirectionRightToLeft\000";
+    static const int32_t values[] = {
+        MatchaPBWritingDirection_WritingDirectionNatural,
+        MatchaPBWritingDirection_WritingDirectionLeftToRight,
+        MatchaPBWritingDirection_WritingDirectionRightToLeft,
+    };
+    GPBEnumDescriptor *worker =
+        [GPBEnumDescriptor allocDescriptorForName:GPBNSStringifySymbol(MatchaPBWritingDirection)
+                                       valueNames:valueNames
+                                           values:values
+                                            count:(uint32_t)(sizeof(values) / sizeof(int32_t))
+                                     enumVerifier:MatchaPBWritingDirection_IsValidValue];
+    if (!OSAtomicCompareAndSwapPtrBarrier(nil, worker, (void * volatile *)&descriptor)) {
+      [worker release];
+    }
+  }
+  return descriptor;
+}
+
+BOOL MatchaPBWritingDirection_IsValidValue(int32_t value__) {
+  switch (value__) {
+    case MatchaPBWritingDirection_WritingDirectionNatural:
+    case MatchaPBWritingDirection_WritingDirectionLeftToRight:
+    case MatchaPBWritingDirection_WritingDirectionRightToLeft:
+      return YES;
+    default:
+      return NO;
+  }
+}
+
 #pragma mark - MatchaPBSizeFunc
 
 @implementation MatchaPBSizeFunc
@@ -500,6 +537,11 @@ typedef struct MatchaPBFont__storage_ {
 @dynamic wrap;
 @dynamic truncation;
 @dynamic truncationString;
+@dynamic writingDirection;
+@dynamic letterSpacing;
+@dynamic lineHeight;
+@dynamic paragraphSpacing;
+@dynamic firstLineIndent;
 
 typedef struct MatchaPBTextStyle__storage_ {
   uint32_t _has_storage_[1];
@@ -508,6 +550,7 @@ typedef struct MatchaPBTextStyle__storage_ {
   MatchaPBUnderlineStyle underlineStyle;
   MatchaPBTextWrap wrap;
   MatchaPBTruncation truncation;
+  MatchaPBWritingDirection writingDirection;
   MatchaPBColor *strikethroughColor;
   MatchaPBColor *underlineColor;
   MatchaPBFont *font;
@@ -517,6 +560,10 @@ typedef struct MatchaPBTextStyle__storage_ {
   double hyphenation;
   double lineHeightMultiple;
   int64_t maxLines;
+  double letterSpacing;
+  double lineHeight;
+  double paragraphSpacing;
+  double firstLineIndent;
 } MatchaPBTextStyle__storage_;
 
 // This method is threadsafe because it is initially called
@@ -651,6 +698,51 @@ typedef struct MatchaPBTextStyle__storage_ {
         .flags = (GPBFieldFlags)(GPBFieldOptional | GPBFieldTextFormatNameCustom),
         .dataType = GPBDataTypeString,
       },
+      {
+        .name = "writingDirection",
+        .dataTypeSpecific.enumDescFunc = MatchaPBWritingDirection_EnumDescriptor,
+        .number = MatchaPBTextStyle_FieldNumber_WritingDirection,
+        .hasIndex = 14,
+        .offset = (uint32_t)offsetof(MatchaPBTextStyle__storage_, writingDirection),
+        .flags = (GPBFieldFlags)(GPBFieldOptional | GPBFieldTextFormatNameCustom | GPBFieldHasEnumDescriptor),
+        .dataType = GPBDataTypeEnum,
+      },
+      {
+        .name = "letterSpacing",
+        .dataTypeSpecific.className = NULL,
+        .number = MatchaPBTextStyle_FieldNumber_LetterSpacing,
+        .hasIndex = 15,
+        .offset = (uint32_t)offsetof(MatchaPBTextStyle__storage_, letterSpacing),
+        .flags = (GPBFieldFlags)(GPBFieldOptional | GPBFieldTextFormatNameCustom),
+        .dataType = GPBDataTypeDouble,
+      },
+      {
+        .name = "lineHeight",
+        .dataTypeSpecific.className = NULL,
+        .number = MatchaPBTextStyle_FieldNumber_LineHeight,
+        .hasIndex = 16,
+        .offset = (uint32_t)offsetof(MatchaPBTextStyle__storage_, lineHeight),
+        .flags = (GPBFieldFlags)(GPBFieldOptional | GPBFieldTextFormatNameCustom),
+        .dataType = GPBDataTypeDouble,
+      },
+      {
+        .name = "paragraphSpacing",
+        .dataTypeSpecific.className = NULL,
+        .number = MatchaPBTextStyle_FieldNumber_ParagraphSpacing,
+        .hasIndex = 17,
+        .offset = (uint32_t)offsetof(MatchaPBTextStyle__storage_, paragraphSpacing),
+        .flags = (GPBFieldFlags)(GPBFieldOptional | GPBFieldTextFormatNameCustom),
+        .dataType = GPBDataTypeDouble,
+      },
+      {
+        .name = "firstLineIndent",
+        .dataTypeSpecific.className = NULL,
+        .number = MatchaPBTextStyle_FieldNumber_FirstLineIndent,
+        .hasIndex = 18,
+        .offset = (uint32_t)offsetof(MatchaPBTextStyle__storage_, firstLineIndent),
+        .flags = (GPBFieldFlags)(GPBFieldOptional | GPBFieldTextFormatNameCustom),
+        .dataType = GPBDataTypeDouble,
+      },
     };
     GPBDescriptor *localDescriptor =
         [GPBDescriptor allocDescriptorForClass:[MatchaPBTextStyle class]
@@ -662,7 +754,8 @@ typedef struct MatchaPBTextStyle__storage_ {
                                          flags:GPBDescriptorInitializationFlag_None];
 #if !GPBOBJC_SKIP_MESSAGE_TEXTFORMAT_EXTRAS
     static const char *extraTextFormatInfo =
-        "\t\002\r\000\004\022\000\006\022\000\010\016\000\n\016\000\020\022\000\022\010\000\024\t\000\032\020\000";
+        "\016\002\r\000\004\022\000\006\022\000\010\016\000\n\016\000\020\022\000\022\010\000\024\t\000\032\020\000\034\020\000\036\r\000"
+        " \n\000\"\020\000$\017\000";
     [localDescriptor setupExtraTextInfo:extraTextFormatInfo];
 #endif  // !GPBOBJC_SKIP_MESSAGE_TEXTFORMAT_EXTRAS
     NSAssert(descriptor == nil, @"Startup recursed!");
@@ -733,6 +826,98 @@ void SetMatchaPBTextStyle_Truncation_RawValue(MatchaPBTextStyle *message, int32_
   GPBSetInt32IvarWithFieldInternal(message, field, value, descriptor.file.syntax);
 }
 
+int32_t MatchaPBTextStyle_WritingDirection_RawValue(MatchaPBTextStyle *message) {
+  GPBDescriptor *descriptor = [MatchaPBTextStyle descriptor];
+  GPBFieldDescriptor *field = [descriptor fieldWithNumber:MatchaPBTextStyle_FieldNumber_WritingDirection];
+  return GPBGetMessageInt32Field(message, field);
+}
+
+void SetMatchaPBTextStyle_WritingDirection_RawValue(MatchaPBTextStyle *message, int32_t value) {
+  GPBDescriptor *descriptor = [MatchaPBTextStyle descriptor];
+  GPBFieldDescriptor *field = [descriptor fieldWithNumber:MatchaPBTextStyle_FieldNumber_WritingDirection];
+  GPBSetInt32IvarWithFieldInternal(message, field, value, descriptor.file.syntax);
+}
+
+#pragma mark - MatchaPBTextMetrics
+
+@implementation MatchaPBTextMetrics
+
+@dynamic width;
+@dynamic height;
+@dynamic firstBaseline;
+@dynamic lastBaseline;
+
+typedef struct MatchaPBTextMetrics__storage_ {
+  uint32_t _has_storage_[1];
+  double width;
+  double height;
+  double firstBaseline;
+  double lastBaseline;
+} MatchaPBTextMetrics__storage_;
+
+// This method is threadsafe because it is initially called
+// in +initialize for each subclass.
++ (GPBDescriptor *)descriptor {
+  static GPBDescriptor *descriptor = nil;
+  if (!descriptor) {
+    static GPBMessageFieldDescription fields[] = {
+      {
+        .name = "width",
+        .dataTypeSpecific.className = NULL,
+        .number = MatchaPBTextMetrics_FieldNumber_Width,
+        .hasIndex = 0,
+        .offset = (uint32_t)offsetof(MatchaPBTextMetrics__storage_, width),
+        .flags = GPBFieldOptional,
+        .dataType = GPBDataTypeDouble,
+      },
+      {
+        .name = "height",
+        .dataTypeSpecific.className = NULL,
+        .number = MatchaPBTextMetrics_FieldNumber_Height,
+        .hasIndex = 1,
+        .offset = (uint32_t)offsetof(MatchaPBTextMetrics__storage_, height),
+        .flags = GPBFieldOptional,
+        .dataType = GPBDataTypeDouble,
+      },
+      {
+        .name = "firstBaseline",
+        .dataTypeSpecific.className = NULL,
+        .number = MatchaPBTextMetrics_FieldNumber_FirstBaseline,
+        .hasIndex = 2,
+        .offset = (uint32_t)offsetof(MatchaPBTextMetrics__storage_, firstBaseline),
+        .flags = GPBFieldOptional | GPBFieldTextFormatNameCustom,
+        .dataType = GPBDataTypeDouble,
+      },
+      {
+        .name = "lastBaseline",
+        .dataTypeSpecific.className = NULL,
+        .number = MatchaPBTextMetrics_FieldNumber_LastBaseline,
+        .hasIndex = 3,
+        .offset = (uint32_t)offsetof(MatchaPBTextMetrics__storage_, lastBaseline),
+        .flags = GPBFieldOptional | GPBFieldTextFormatNameCustom,
+        .dataType = GPBDataTypeDouble,
+      },
+    };
+    GPBDescriptor *localDescriptor =
+        [GPBDescriptor allocDescriptorForClass:[MatchaPBTextMetrics class]
+                                     rootClass:[MatchaPBTextRoot class]
+                                          file:MatchaPBTextRoot_FileDescriptor()
+                                        fields:fields
+                                    fieldCount:(uint32_t)(sizeof(fields) / sizeof(GPBMessageFieldDescription))
+                                   storageSize:sizeof(MatchaPBTextMetrics__storage_)
+                                         flags:GPBDescriptorInitializationFlag_None];
+#if !GPBOBJC_SKIP_MESSAGE_TEXTFORMAT_EXTRAS
+    static const char *extraTextFormatInfo =
+        "\002\003\r\000\004\014\000";
+    [localDescriptor setupExtraTextInfo:extraTextFormatInfo];
+#endif  // !GPBOBJC_SKIP_MESSAGE_TEXTFORMAT_EXTRAS
+    NSAssert(descriptor == nil, @"Startup recursed!");
+    descriptor = localDescriptor;
+  }
+  return descriptor;
+}
+
+@end
 
 #pragma clang diagnostic pop
 
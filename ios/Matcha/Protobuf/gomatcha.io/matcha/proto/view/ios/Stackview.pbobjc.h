@@ -52,12 +52,30 @@ NS_ASSUME_NONNULL_BEGIN
 
 typedef GPB_ENUM(MatchaiOSPBStackChildView_FieldNumber) {
   MatchaiOSPBStackChildView_FieldNumber_ScreenId = 3,
+  MatchaiOSPBStackChildView_FieldNumber_Transition = 4,
+  MatchaiOSPBStackChildView_FieldNumber_TransitionDuration = 5,
+  MatchaiOSPBStackChildView_FieldNumber_CurveX0 = 6,
+  MatchaiOSPBStackChildView_FieldNumber_CurveY0 = 7,
+  MatchaiOSPBStackChildView_FieldNumber_CurveX1 = 8,
+  MatchaiOSPBStackChildView_FieldNumber_CurveY1 = 9,
 };
 
 @interface MatchaiOSPBStackChildView : GPBMessage
 
 @property(nonatomic, readwrite) int64_t screenId;
 
+@property(nonatomic, readwrite) int64_t transition;
+
+@property(nonatomic, readwrite) double transitionDuration;
+
+@property(nonatomic, readwrite) double curveX0;
+
+@property(nonatomic, readwrite) double curveY0;
+
+@property(nonatomic, readwrite) double curveX1;
+
+@property(nonatomic, readwrite) double curveY1;
+
 @end
 
 #pragma mark - MatchaiOSPBStackView
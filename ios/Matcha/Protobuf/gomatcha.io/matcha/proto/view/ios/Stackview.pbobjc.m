@@ -50,10 +50,22 @@ static GPBFileDescriptor *MatchaiOSPBStackviewRoot_FileDescriptor(void) {
 @implementation MatchaiOSPBStackChildView
 
 @dynamic screenId;
+@dynamic transition;
+@dynamic transitionDuration;
+@dynamic curveX0;
+@dynamic curveY0;
+@dynamic curveX1;
+@dynamic curveY1;
 
 typedef struct MatchaiOSPBStackChildView__storage_ {
   uint32_t _has_storage_[1];
   int64_t screenId;
+  int64_t transition;
+  double transitionDuration;
+  double curveX0;
+  double curveY0;
+  double curveX1;
+  double curveY1;
 } MatchaiOSPBStackChildView__storage_;
 
 // This method is threadsafe because it is initially called
@@ -71,6 +83,60 @@ typedef struct MatchaiOSPBStackChildView__storage_ {
         .flags = (GPBFieldFlags)(GPBFieldOptional | GPBFieldTextFormatNameCustom),
         .dataType = GPBDataTypeInt64,
       },
+      {
+        .name = "transition",
+        .dataTypeSpecific.className = NULL,
+        .number = MatchaiOSPBStackChildView_FieldNumber_Transition,
+        .hasIndex = 1,
+        .offset = (uint32_t)offsetof(MatchaiOSPBStackChildView__storage_, transition),
+        .flags = GPBFieldOptional,
+        .dataType = GPBDataTypeInt64,
+      },
+      {
+        .name = "transitionDuration",
+        .dataTypeSpecific.className = NULL,
+        .number = MatchaiOSPBStackChildView_FieldNumber_TransitionDuration,
+        .hasIndex = 2,
+        .offset = (uint32_t)offsetof(MatchaiOSPBStackChildView__storage_, transitionDuration),
+        .flags = (GPBFieldFlags)(GPBFieldOptional | GPBFieldTextFormatNameCustom),
+        .dataType = GPBDataTypeDouble,
+      },
+      {
+        .name = "curveX0",
+        .dataTypeSpecific.className = NULL,
+        .number = MatchaiOSPBStackChildView_FieldNumber_CurveX0,
+        .hasIndex = 3,
+        .offset = (uint32_t)offsetof(MatchaiOSPBStackChildView__storage_, curveX0),
+        .flags = (GPBFieldFlags)(GPBFieldOptional | GPBFieldTextFormatNameCustom),
+        .dataType = GPBDataTypeDouble,
+      },
+      {
+        .name = "curveY0",
+        .dataTypeSpecific.className = NULL,
+        .number = MatchaiOSPBStackChildView_FieldNumber_CurveY0,
+        .hasIndex = 4,
+        .offset = (uint32_t)offsetof(MatchaiOSPBStackChildView__storage_, curveY0),
+        .flags = (GPBFieldFlags)(GPBFieldOptional | GPBFieldTextFormatNameCustom),
+        .dataType = GPBDataTypeDouble,
+      },
+      {
+        .name = "curveX1",
+        .dataTypeSpecific.className = NULL,
+        .number = MatchaiOSPBStackChildView_FieldNumber_CurveX1,
+        .hasIndex = 5,
+        .offset = (uint32_t)offsetof(MatchaiOSPBStackChildView__storage_, curveX1),
+        .flags = (GPBFieldFlags)(GPBFieldOptional | GPBFieldTextFormatNameCustom),
+        .dataType = GPBDataTypeDouble,
+      },
+      {
+        .name = "curveY1",
+        .dataTypeSpecific.className = NULL,
+        .number = MatchaiOSPBStackChildView_FieldNumber_CurveY1,
+        .hasIndex = 6,
+        .offset = (uint32_t)offsetof(MatchaiOSPBStackChildView__storage_, curveY1),
+        .flags = (GPBFieldFlags)(GPBFieldOptional | GPBFieldTextFormatNameCustom),
+        .dataType = GPBDataTypeDouble,
+      },
     };
     GPBDescriptor *localDescriptor =
         [GPBDescriptor allocDescriptorForClass:[MatchaiOSPBStackChildView class]
@@ -82,7 +148,7 @@ typedef struct MatchaiOSPBStackChildView__storage_ {
                                          flags:GPBDescriptorInitializationFlag_None];
 #if !GPBOBJC_SKIP_MESSAGE_TEXTFORMAT_EXTRAS
     static const char *extraTextFormatInfo =
-        "\001\003\010\000";
+        "\006\003\010\000\005\022\000\006\007\000\007\007\000\010\007\000\t\007\000";
     [localDescriptor setupExtraTextInfo:extraTextFormatInfo];
 #endif  // !GPBOBJC_SKIP_MESSAGE_TEXTFORMAT_EXTRAS
     NSAssert(descriptor == nil, @"Startup recursed!");
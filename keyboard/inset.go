@@ -0,0 +1,95 @@
+package keyboard
+
+import (
+	"sync"
+	"time"
+
+	"gomatcha.io/matcha/animate"
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/comm"
+)
+
+// Inset holds how much of the bottom of the screen is covered by the keyboard,
+// and how the keyboard animates as it is shown and hidden.
+type Inset struct {
+	mu       sync.Mutex
+	height   float64
+	duration time.Duration
+	curve    animate.FloatInterpolater
+	relay    comm.Relay
+}
+
+var inset = Inset{curve: animate.DefaultInOutEase}
+
+// ScreenInset returns the keyboard's inset on the app's screen. It is
+// updated as the keyboard starts to animate, so that views can animate along
+// with it.
+//
+//  inset := keyboard.ScreenInset()
+//  v.Subscribe(inset)
+//  ...
+//  bottom := inset.Height()
+func ScreenInset() *Inset {
+	return &inset
+}
+
+// Notify implements the comm.Notifier interface.
+func (i *Inset) Notify(f func()) comm.Id {
+	return i.relay.Notify(f)
+}
+
+// Unnotify implements the comm.Notifier interface.
+func (i *Inset) Unnotify(id comm.Id) {
+	i.relay.Unnotify(id)
+}
+
+// Height returns the height of the keyboard over the screen, or 0 if it is
+// hidden.
+func (i *Inset) Height() float64 {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.height
+}
+
+// Duration returns the duration of the keyboard's last animation.
+func (i *Inset) Duration() time.Duration {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.duration
+}
+
+// Curve returns the easing of the keyboard's last animation.
+func (i *Inset) Curve() animate.FloatInterpolater {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.curve
+}
+
+// Animate runs an animation on v to the keyboard's height, with the same
+// duration and easing as the keyboard.
+func (i *Inset) Animate(v *animate.Value) (cancelFunc func()) {
+	i.mu.Lock()
+	a := &animate.Basic{
+		Start: v.Value(),
+		End:   i.height,
+		Ease:  i.curve,
+		Dur:   i.duration,
+	}
+	i.mu.Unlock()
+	return v.Run(a)
+}
+
+func init() {
+	bridge.RegisterFunc("gomatcha.io/matcha/keyboard SetInset", func(height, duration, x0, y0, x1, y1 float64) {
+		inset.mu.Lock()
+		if inset.height == height {
+			inset.mu.Unlock()
+			return
+		}
+		inset.height = height
+		inset.duration = time.Duration(duration * float64(time.Second))
+		inset.curve = animate.CubicBezierEase{X0: x0, Y0: y0, X1: x1, Y1: y1}
+		inset.mu.Unlock()
+		inset.relay.Signal()
+	})
+}
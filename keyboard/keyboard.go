@@ -8,6 +8,9 @@
 //  button.OnTap = func() {
 //  	v.responder.Dismiss()
 //  }
+//
+// ScreenInset reports how much of the screen the keyboard covers, so that
+// content can be moved above it, see also view.ScrollView's KeyboardInset.
 package keyboard
 
 import (
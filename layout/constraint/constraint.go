@@ -5,9 +5,12 @@ package constraint
 import (
 	"fmt"
 	"math"
+	"sort"
 
+	"gomatcha.io/matcha/application"
 	"gomatcha.io/matcha/comm"
 	"gomatcha.io/matcha/internal/device"
+	"gomatcha.io/matcha/keyboard"
 	"gomatcha.io/matcha/layout"
 	"gomatcha.io/matcha/view"
 )
@@ -32,6 +35,33 @@ func (c comparison) String() string {
 	return ""
 }
 
+// Priority is how strongly a constraint is held. When constraints conflict, the
+// solver breaks the constraints with the lowest priority first. Constraints of
+// the same priority are applied in the order they were added, and later ones
+// are broken first.
+type Priority int
+
+const (
+	// PriorityRequired is the default priority of constraints.
+	PriorityRequired Priority = iota
+	PriorityHigh
+	// PriorityLow is for optional constraints, which are only held if they do
+	// not conflict with any other constraint.
+	PriorityLow
+)
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityRequired:
+		return "required"
+	case PriorityHigh:
+		return "high"
+	case PriorityLow:
+		return "low"
+	}
+	return ""
+}
+
 type attribute int
 
 const (
@@ -43,6 +73,10 @@ const (
 	heightAttr
 	centerXAttr
 	centerYAttr
+	firstBaselineAttr
+	lastBaselineAttr
+	leadingAttr
+	trailingAttr
 )
 
 func (a attribute) String() string {
@@ -63,6 +97,14 @@ func (a attribute) String() string {
 		return "CenterX"
 	case centerYAttr:
 		return "CenterY"
+	case firstBaselineAttr:
+		return "FirstBaseline"
+	case lastBaselineAttr:
+		return "LastBaseline"
+	case leadingAttr:
+		return "Leading"
+	case trailingAttr:
+		return "Trailing"
 	}
 	return ""
 }
@@ -142,6 +184,10 @@ func (a guideAnchor) value(sys *Layouter) float64 {
 		g = *sys.min.matchaGuide
 	case maxId:
 		g = *sys.max.matchaGuide
+	case safeAreaId:
+		g = layout.Guide{Frame: sys.Guide.matchaGuide.Frame.Inset(application.SafeAreaInsets().Value())}
+	case keyboardId:
+		g = layout.Guide{Frame: sys.Guide.matchaGuide.Frame.Inset(layout.Insets{Bottom: keyboard.ScreenInset().Height()})}
 	default:
 		g = *sys.children2[a.guide.index].matchaGuide
 	}
@@ -167,6 +213,51 @@ func (a guideAnchor) value(sys *Layouter) float64 {
 		return g.CenterX()
 	case centerYAttr:
 		return g.CenterY()
+	case firstBaselineAttr:
+		return g.Top() + baselineOffset(g, g.FirstBaseline)
+	case lastBaselineAttr:
+		return g.Top() + baselineOffset(g, g.LastBaseline)
+	case leadingAttr:
+		if sys.rightToLeft {
+			return sys.Guide.matchaGuide.Width() - g.Right()
+		}
+		return g.Left()
+	case trailingAttr:
+		if sys.rightToLeft {
+			return sys.Guide.matchaGuide.Width() - g.Left()
+		}
+		return g.Right()
+	}
+	return 0
+}
+
+// baselineOffset returns the distance from the top of g to baseline. Views
+// without text use their bottom edge as their baseline.
+func baselineOffset(g layout.Guide, baseline float64) float64 {
+	if baseline == 0 {
+		return g.Height()
+	}
+	return baseline
+}
+
+// parentAnchor is an attribute of the guide that a solver's view is placed in.
+// For children that is the layouter's Guide, and for the layouter's Guide it
+// is the MinGuide.
+type parentAnchor struct {
+	index     int
+	attribute attribute
+}
+
+func (a parentAnchor) value(sys *Layouter) float64 {
+	g := sys.Guide.matchaGuide
+	if a.index == rootId {
+		g = sys.min.matchaGuide
+	}
+	switch a.attribute {
+	case widthAttr:
+		return g.Width()
+	case heightAttr:
+		return g.Height()
 	}
 	return 0
 }
@@ -209,6 +300,20 @@ func (g *Guide) Left() *Anchor {
 	return &Anchor{guideAnchor{guide: g, attribute: leftAttr}}
 }
 
+// Leading returns the left edge, or the right edge if the layouter is right to
+// left, as an Anchor. Leading and trailing anchors are measured from the
+// leading edge of the layouter's Guide, so they are meant to be used with each
+// other and not with left and right anchors. See Layouter.Direction.
+func (g *Guide) Leading() *Anchor {
+	return &Anchor{guideAnchor{guide: g, attribute: leadingAttr}}
+}
+
+// Trailing returns the right edge, or the left edge if the layouter is right
+// to left, as an Anchor.
+func (g *Guide) Trailing() *Anchor {
+	return &Anchor{guideAnchor{guide: g, attribute: trailingAttr}}
+}
+
 // Width returns the width of g as an Anchor.
 func (g *Guide) Width() *Anchor {
 	return &Anchor{guideAnchor{guide: g, attribute: widthAttr}}
@@ -229,9 +334,21 @@ func (g *Guide) CenterY() *Anchor {
 	return &Anchor{guideAnchor{guide: g, attribute: centerYAttr}}
 }
 
+// FirstBaseline returns the baseline of the first line of text in g as an
+// Anchor.
+func (g *Guide) FirstBaseline() *Anchor {
+	return &Anchor{guideAnchor{guide: g, attribute: firstBaselineAttr}}
+}
+
+// LastBaseline returns the baseline of the last line of text in g as an
+// Anchor.
+func (g *Guide) LastBaseline() *Anchor {
+	return &Anchor{guideAnchor{guide: g, attribute: lastBaselineAttr}}
+}
+
 // Solve immediately calls solveFunc to update the constraints for g.
 func (g *Guide) Solve(solveFunc func(*Solver)) {
-	s := &Solver{index: g.index}
+	s := newSolver(g.index)
 	if solveFunc != nil {
 		solveFunc(s)
 	}
@@ -251,7 +368,7 @@ func (g *Guide) add(v view.View, solveFunc func(*Solver)) *Guide {
 		system:      g.system,
 		matchaGuide: nil,
 	}
-	s := &Solver{index: chl.index}
+	s := newSolver(chl.index)
 	if solveFunc != nil {
 		solveFunc(s)
 	}
@@ -272,6 +389,7 @@ type constraint struct {
 	attribute  attribute
 	comparison comparison
 	anchor     anchor
+	priority   Priority
 }
 
 func (c constraint) String() string {
@@ -282,7 +400,41 @@ func (c constraint) String() string {
 type Solver struct {
 	debug       bool
 	index       int
+	priority    Priority
 	constraints []constraint
+
+	huggingX, huggingY       Priority
+	resistanceX, resistanceY Priority
+	aspectRatio              float64
+}
+
+func newSolver(index int) *Solver {
+	return &Solver{
+		index:       index,
+		huggingX:    PriorityLow,
+		huggingY:    PriorityLow,
+		resistanceX: PriorityHigh,
+		resistanceY: PriorityHigh,
+	}
+}
+
+// intrinsicConstraints returns the content hugging and compression resistance
+// constraints of the view, if it has an intrinsic size.
+func (s *Solver) intrinsicConstraints(ctx layout.Context) []constraint {
+	c, ok := ctx.(layout.IntrinsicContext)
+	if s.index == rootId || !ok {
+		return nil
+	}
+	size, ok := c.ChildIntrinsicSize(s.index, ctx.MaxSize())
+	if !ok {
+		return nil
+	}
+	return []constraint{
+		{attribute: widthAttr, comparison: less, anchor: constAnchor(size.X), priority: s.huggingX},
+		{attribute: heightAttr, comparison: less, anchor: constAnchor(size.Y), priority: s.huggingY},
+		{attribute: widthAttr, comparison: greater, anchor: constAnchor(size.X), priority: s.resistanceX},
+		{attribute: heightAttr, comparison: greater, anchor: constAnchor(size.Y), priority: s.resistanceY},
+	}
 }
 
 func (s *Solver) solve(sys *Layouter, ctx layout.Context) {
@@ -292,7 +444,36 @@ func (s *Solver) solve(sys *Layouter, ctx layout.Context) {
 		fmt.Println("constraint - Begin solving")
 	}
 
-	for _, i := range s.constraints {
+	// Apply the constraints from the highest to the lowest priority, so that
+	// lower priority constraints are broken first.
+	constraints := append([]constraint(nil), s.constraints...)
+	constraints = append(constraints, s.intrinsicConstraints(ctx)...)
+	sort.SliceStable(constraints, func(a, b int) bool {
+		return constraints[a].priority < constraints[b].priority
+	})
+
+	// Baselines are only known once the view has been sized, so baseline
+	// constraints are applied after the other constraints.
+	var baselines []constraint
+	var baselineRanges []_range
+
+	// With view.DebugLayout, the constraints that are held and broken are
+	// logged along with the solved frame.
+	logging := view.DebuggingLayout()
+	var held, broken []string
+	logConstraint := func(c constraint, valid bool) {
+		desc := fmt.Sprintf("%v%v%v", c.attribute, c.comparison, c.anchor.value(sys))
+		if c.priority != PriorityRequired {
+			desc += fmt.Sprintf(" (%v)", c.priority)
+		}
+		if valid {
+			held = append(held, desc)
+		} else {
+			broken = append(broken, desc)
+		}
+	}
+
+	for _, i := range constraints {
 		copy := cr
 
 		// Generate the range from constraint
@@ -306,8 +487,32 @@ func (s *Solver) solve(sys *Layouter, ctx layout.Context) {
 			r = _range{min: math.Inf(-1), max: i.anchor.value(sys)}
 		}
 
+		// Leading and trailing constraints are mirrored into right and left
+		// constraints in right to left layouts.
+		attr := i.attribute
+		switch attr {
+		case leadingAttr:
+			attr = leftAttr
+			if sys.rightToLeft {
+				attr = rightAttr
+				r = _range{min: sys.Guide.matchaGuide.Width() - r.max, max: sys.Guide.matchaGuide.Width() - r.min}
+			}
+		case trailingAttr:
+			attr = rightAttr
+			if sys.rightToLeft {
+				attr = leftAttr
+				r = _range{min: sys.Guide.matchaGuide.Width() - r.max, max: sys.Guide.matchaGuide.Width() - r.min}
+			}
+		}
+
+		if attr == firstBaselineAttr || attr == lastBaselineAttr {
+			baselines = append(baselines, i)
+			baselineRanges = append(baselineRanges, r)
+			continue
+		}
+
 		// Update the solver
-		switch i.attribute {
+		switch attr {
 		case leftAttr:
 			copy.left = copy.left.intersect(r)
 		case rightAttr:
@@ -330,10 +535,13 @@ func (s *Solver) solve(sys *Layouter, ctx layout.Context) {
 			if copy.isValid() {
 				fmt.Printf("constraint - Adding constraint: %v%v%v\n", i.attribute, i.comparison, r)
 			} else {
-				fmt.Printf("constraint - Ignoring constraint: %v%v%v\n", i.attribute, i.comparison, r)
+				fmt.Printf("constraint - Breaking %v constraint: %v%v%v\n", i.priority, i.attribute, i.comparison, r)
 			}
 			fmt.Printf("constraint - Rect %v\n", copy)
 		}
+		if logging {
+			logConstraint(i, copy.isValid())
+		}
 
 		// Validate that the new system is well-formed. Otherwise ignore the changes.
 		if !copy.isValid() {
@@ -350,18 +558,37 @@ func (s *Solver) solve(sys *Layouter, ctx layout.Context) {
 		parent = *sys.Guide.matchaGuide
 	}
 
+	// Update the width and height ranges based on other constraints.
+	_, cr = cr.solveWidth(0)
+	_, cr = cr.solveHeight(0)
+
+	// Limit the width and height ranges to the sizes with the aspect ratio.
+	aspectRatio := s.aspectRatio
+	if aspectRatio > 0 {
+		copy := cr
+		copy.width = copy.width.intersect(_range{min: cr.height.min * aspectRatio, max: cr.height.max * aspectRatio})
+		copy.height = copy.height.intersect(_range{min: cr.width.min / aspectRatio, max: cr.width.max / aspectRatio})
+		if copy.isValid() {
+			cr = copy
+		} else {
+			aspectRatio = 0
+			if s.debug {
+				fmt.Printf("constraint - Breaking aspect ratio: %v\n", s.aspectRatio)
+			}
+			if logging {
+				broken = append(broken, fmt.Sprintf("AspectRatio=%v", s.aspectRatio))
+			}
+		}
+	}
+
 	// Solve for width & height.
 	var width, height float64
 	var g layout.Guide
 	if s.index == rootId {
 		g = layout.Guide{}
-		width, _ = cr.solveWidth(parent.Width())
-		height, _ = cr.solveHeight(parent.Height())
+		width = cr.width.nearest(parent.Width())
+		height = cr.height.nearest(parent.Height())
 	} else {
-		// Update the width and height ranges based on other constraints.
-		_, cr = cr.solveWidth(0)
-		_, cr = cr.solveHeight(0)
-
 		if s.debug {
 			fmt.Printf("constraint - Solving for child size with min: %v max: %v\n", layout.Pt(cr.width.min, cr.height.min), layout.Pt(cr.width.max, cr.height.max))
 		}
@@ -391,6 +618,16 @@ func (s *Solver) solve(sys *Layouter, ctx layout.Context) {
 		}
 	}
 
+	// Fit the size to the aspect ratio, keeping the width unless only the
+	// height is fixed.
+	if aspectRatio > 0 {
+		if cr.height.min == cr.height.max && cr.width.min != cr.width.max {
+			width = cr.width.nearest(height * aspectRatio)
+		} else {
+			height = cr.height.nearest(width / aspectRatio)
+		}
+	}
+
 	// Solve for centerX & centerY using new width & height.
 	cr.width = cr.width.intersect(_range{min: width, max: width})
 	cr.height = cr.height.intersect(_range{min: height, max: height})
@@ -398,6 +635,39 @@ func (s *Solver) solve(sys *Layouter, ctx layout.Context) {
 		fmt.Println("cr", cr)
 		panic("constraint - system inconsistency")
 	}
+
+	// Convert the baseline constraints into constraints on the top edge.
+	for idx, i := range baselines {
+		if s.index == rootId {
+			if s.debug {
+				fmt.Printf("constraint - Breaking %v constraint: %v%v%v, the layouter has no baseline\n", i.priority, i.attribute, i.comparison, baselineRanges[idx])
+			}
+			continue
+		}
+		offset := baselineOffset(g, g.FirstBaseline)
+		if i.attribute == lastBaselineAttr {
+			offset = baselineOffset(g, g.LastBaseline)
+		}
+		r := baselineRanges[idx]
+		copy := cr
+		copy.top = copy.top.intersect(_range{min: r.min - offset, max: r.max - offset})
+
+		if s.debug {
+			if copy.isValid() {
+				fmt.Printf("constraint - Adding constraint: %v%v%v\n", i.attribute, i.comparison, r)
+			} else {
+				fmt.Printf("constraint - Breaking %v constraint: %v%v%v\n", i.priority, i.attribute, i.comparison, r)
+			}
+			fmt.Printf("constraint - Rect %v\n", copy)
+		}
+		if logging {
+			logConstraint(i, copy.isValid())
+		}
+		if !copy.isValid() {
+			continue
+		}
+		cr = copy
+	}
 	var centerX, centerY float64
 	if s.index == rootId {
 		centerX = width / 2
@@ -421,27 +691,85 @@ func (s *Solver) solve(sys *Layouter, ctx layout.Context) {
 	if s.debug {
 		fmt.Println("constraint - Solved position", g)
 	}
+	if logging {
+		name := "Guide"
+		if s.index != rootId {
+			name = fmt.Sprintf("%T", sys.views[s.index])
+		}
+		fmt.Printf("constraint - %v %v: %v\n", name, g.Frame, held)
+		if len(broken) > 0 {
+			fmt.Printf("constraint - %v broke: %v\n", name, broken)
+		}
+	}
 }
 
-// Debug adds debug logging for the solver.
+// Debug adds debug logging for the solver, including the constraints that are
+// broken.
 func (s *Solver) Debug() {
 	s.debug = true
 }
 
+// ContentHugging sets the priority with which the view resists growing larger
+// than its intrinsic size along axis, which may be layout.AxisX|layout.AxisY.
+// It is PriorityLow by default. See view.IntrinsicSizer.
+func (s *Solver) ContentHugging(axis layout.Axis, p Priority) {
+	if axis&layout.AxisX != 0 {
+		s.huggingX = p
+	}
+	if axis&layout.AxisY != 0 {
+		s.huggingY = p
+	}
+}
+
+// CompressionResistance sets the priority with which the view resists
+// shrinking smaller than its intrinsic size along axis, which may be
+// layout.AxisX|layout.AxisY. It is PriorityHigh by default.
+func (s *Solver) CompressionResistance(axis layout.Axis, p Priority) {
+	if axis&layout.AxisX != 0 {
+		s.resistanceX = p
+	}
+	if axis&layout.AxisY != 0 {
+		s.resistanceY = p
+	}
+}
+
+// AspectRatio keeps the width and height of the view in the ratio w:h, within
+// the bounds set by the other constraints. The ratio is broken if the other
+// constraints do not allow it.
+//
+//  s.WidthEqual(l.Guide.Width())
+//  s.AspectRatio(16, 9)
+func (s *Solver) AspectRatio(w, h float64) {
+	if w <= 0 || h <= 0 {
+		s.aspectRatio = 0
+		return
+	}
+	s.aspectRatio = w / h
+}
+
+// Priority sets the priority of the constraints that are added to s after it.
+//
+//  s.Priority(constraint.PriorityLow)
+//  s.Width(200) // Optional, if the view does not fit.
+//  s.Priority(constraint.PriorityRequired)
+func (s *Solver) Priority(p Priority) {
+	s.priority = p
+}
+
 func (s *Solver) Top(v float64) {
 	s.TopEqual(Const(v))
 }
 
 func (s *Solver) TopEqual(a *Anchor) {
-	s.constraints = append(s.constraints, constraint{attribute: topAttr, comparison: equal, anchor: a.anchor})
+	s.constraints = append(s.constraints, constraint{attribute: topAttr, comparison: equal, anchor: a.anchor, priority: s.priority})
 }
 
 func (s *Solver) TopLess(a *Anchor) {
-	s.constraints = append(s.constraints, constraint{attribute: topAttr, comparison: less, anchor: a.anchor})
+	s.constraints = append(s.constraints, constraint{attribute: topAttr, comparison: less, anchor: a.anchor, priority: s.priority})
 }
 
 func (s *Solver) TopGreater(a *Anchor) {
-	s.constraints = append(s.constraints, constraint{attribute: topAttr, comparison: greater, anchor: a.anchor})
+	s.constraints = append(s.constraints, constraint{attribute: topAttr, comparison: greater, anchor: a.anchor, priority: s.priority})
 }
 
 func (s *Solver) Right(v float64) {
@@ -449,15 +777,15 @@ func (s *Solver) Right(v float64) {
 }
 
 func (s *Solver) RightEqual(a *Anchor) {
-	s.constraints = append(s.constraints, constraint{attribute: rightAttr, comparison: equal, anchor: a.anchor})
+	s.constraints = append(s.constraints, constraint{attribute: rightAttr, comparison: equal, anchor: a.anchor, priority: s.priority})
 }
 
 func (s *Solver) RightLess(a *Anchor) {
-	s.constraints = append(s.constraints, constraint{attribute: rightAttr, comparison: less, anchor: a.anchor})
+	s.constraints = append(s.constraints, constraint{attribute: rightAttr, comparison: less, anchor: a.anchor, priority: s.priority})
 }
 
 func (s *Solver) RightGreater(a *Anchor) {
-	s.constraints = append(s.constraints, constraint{attribute: rightAttr, comparison: greater, anchor: a.anchor})
+	s.constraints = append(s.constraints, constraint{attribute: rightAttr, comparison: greater, anchor: a.anchor, priority: s.priority})
 }
 
 func (s *Solver) Bottom(v float64) {
@@ -465,15 +793,15 @@ func (s *Solver) Bottom(v float64) {
 }
 
 func (s *Solver) BottomEqual(a *Anchor) {
-	s.constraints = append(s.constraints, constraint{attribute: bottomAttr, comparison: equal, anchor: a.anchor})
+	s.constraints = append(s.constraints, constraint{attribute: bottomAttr, comparison: equal, anchor: a.anchor, priority: s.priority})
 }
 
 func (s *Solver) BottomLess(a *Anchor) {
-	s.constraints = append(s.constraints, constraint{attribute: bottomAttr, comparison: less, anchor: a.anchor})
+	s.constraints = append(s.constraints, constraint{attribute: bottomAttr, comparison: less, anchor: a.anchor, priority: s.priority})
 }
 
 func (s *Solver) BottomGreater(a *Anchor) {
-	s.constraints = append(s.constraints, constraint{attribute: bottomAttr, comparison: greater, anchor: a.anchor})
+	s.constraints = append(s.constraints, constraint{attribute: bottomAttr, comparison: greater, anchor: a.anchor, priority: s.priority})
 }
 
 func (s *Solver) Left(v float64) {
@@ -481,15 +809,47 @@ func (s *Solver) Left(v float64) {
 }
 
 func (s *Solver) LeftEqual(a *Anchor) {
-	s.constraints = append(s.constraints, constraint{attribute: leftAttr, comparison: equal, anchor: a.anchor})
+	s.constraints = append(s.constraints, constraint{attribute: leftAttr, comparison: equal, anchor: a.anchor, priority: s.priority})
 }
 
 func (s *Solver) LeftLess(a *Anchor) {
-	s.constraints = append(s.constraints, constraint{attribute: leftAttr, comparison: less, anchor: a.anchor})
+	s.constraints = append(s.constraints, constraint{attribute: leftAttr, comparison: less, anchor: a.anchor, priority: s.priority})
 }
 
 func (s *Solver) LeftGreater(a *Anchor) {
-	s.constraints = append(s.constraints, constraint{attribute: leftAttr, comparison: greater, anchor: a.anchor})
+	s.constraints = append(s.constraints, constraint{attribute: leftAttr, comparison: greater, anchor: a.anchor, priority: s.priority})
+}
+
+func (s *Solver) Leading(v float64) {
+	s.LeadingEqual(Const(v))
+}
+
+func (s *Solver) LeadingEqual(a *Anchor) {
+	s.constraints = append(s.constraints, constraint{attribute: leadingAttr, comparison: equal, anchor: a.anchor, priority: s.priority})
+}
+
+func (s *Solver) LeadingLess(a *Anchor) {
+	s.constraints = append(s.constraints, constraint{attribute: leadingAttr, comparison: less, anchor: a.anchor, priority: s.priority})
+}
+
+func (s *Solver) LeadingGreater(a *Anchor) {
+	s.constraints = append(s.constraints, constraint{attribute: leadingAttr, comparison: greater, anchor: a.anchor, priority: s.priority})
+}
+
+func (s *Solver) Trailing(v float64) {
+	s.TrailingEqual(Const(v))
+}
+
+func (s *Solver) TrailingEqual(a *Anchor) {
+	s.constraints = append(s.constraints, constraint{attribute: trailingAttr, comparison: equal, anchor: a.anchor, priority: s.priority})
+}
+
+func (s *Solver) TrailingLess(a *Anchor) {
+	s.constraints = append(s.constraints, constraint{attribute: trailingAttr, comparison: less, anchor: a.anchor, priority: s.priority})
+}
+
+func (s *Solver) TrailingGreater(a *Anchor) {
+	s.constraints = append(s.constraints, constraint{attribute: trailingAttr, comparison: greater, anchor: a.anchor, priority: s.priority})
 }
 
 func (s *Solver) Width(v float64) {
@@ -497,15 +857,15 @@ func (s *Solver) Width(v float64) {
 }
 
 func (s *Solver) WidthEqual(a *Anchor) {
-	s.constraints = append(s.constraints, constraint{attribute: widthAttr, comparison: equal, anchor: a.anchor})
+	s.constraints = append(s.constraints, constraint{attribute: widthAttr, comparison: equal, anchor: a.anchor, priority: s.priority})
 }
 
 func (s *Solver) WidthLess(a *Anchor) {
-	s.constraints = append(s.constraints, constraint{attribute: widthAttr, comparison: less, anchor: a.anchor})
+	s.constraints = append(s.constraints, constraint{attribute: widthAttr, comparison: less, anchor: a.anchor, priority: s.priority})
 }
 
 func (s *Solver) WidthGreater(a *Anchor) {
-	s.constraints = append(s.constraints, constraint{attribute: widthAttr, comparison: greater, anchor: a.anchor})
+	s.constraints = append(s.constraints, constraint{attribute: widthAttr, comparison: greater, anchor: a.anchor, priority: s.priority})
 }
 
 func (s *Solver) Height(v float64) {
@@ -513,15 +873,15 @@ func (s *Solver) Height(v float64) {
 }
 
 func (s *Solver) HeightEqual(a *Anchor) {
-	s.constraints = append(s.constraints, constraint{attribute: heightAttr, comparison: equal, anchor: a.anchor})
+	s.constraints = append(s.constraints, constraint{attribute: heightAttr, comparison: equal, anchor: a.anchor, priority: s.priority})
 }
 
 func (s *Solver) HeightLess(a *Anchor) {
-	s.constraints = append(s.constraints, constraint{attribute: heightAttr, comparison: less, anchor: a.anchor})
+	s.constraints = append(s.constraints, constraint{attribute: heightAttr, comparison: less, anchor: a.anchor, priority: s.priority})
 }
 
 func (s *Solver) HeightGreater(a *Anchor) {
-	s.constraints = append(s.constraints, constraint{attribute: heightAttr, comparison: greater, anchor: a.anchor})
+	s.constraints = append(s.constraints, constraint{attribute: heightAttr, comparison: greater, anchor: a.anchor, priority: s.priority})
 }
 
 func (s *Solver) CenterX(v float64) {
@@ -529,15 +889,15 @@ func (s *Solver) CenterX(v float64) {
 }
 
 func (s *Solver) CenterXEqual(a *Anchor) {
-	s.constraints = append(s.constraints, constraint{attribute: centerXAttr, comparison: equal, anchor: a.anchor})
+	s.constraints = append(s.constraints, constraint{attribute: centerXAttr, comparison: equal, anchor: a.anchor, priority: s.priority})
 }
 
 func (s *Solver) CenterXLess(a *Anchor) {
-	s.constraints = append(s.constraints, constraint{attribute: centerXAttr, comparison: less, anchor: a.anchor})
+	s.constraints = append(s.constraints, constraint{attribute: centerXAttr, comparison: less, anchor: a.anchor, priority: s.priority})
 }
 
 func (s *Solver) CenterXGreater(a *Anchor) {
-	s.constraints = append(s.constraints, constraint{attribute: centerXAttr, comparison: greater, anchor: a.anchor})
+	s.constraints = append(s.constraints, constraint{attribute: centerXAttr, comparison: greater, anchor: a.anchor, priority: s.priority})
 }
 
 func (s *Solver) CenterY(v float64) {
@@ -545,15 +905,76 @@ func (s *Solver) CenterY(v float64) {
 }
 
 func (s *Solver) CenterYEqual(a *Anchor) {
-	s.constraints = append(s.constraints, constraint{attribute: centerYAttr, comparison: equal, anchor: a.anchor})
+	s.constraints = append(s.constraints, constraint{attribute: centerYAttr, comparison: equal, anchor: a.anchor, priority: s.priority})
 }
 
 func (s *Solver) CenterYLess(a *Anchor) {
-	s.constraints = append(s.constraints, constraint{attribute: centerYAttr, comparison: less, anchor: a.anchor})
+	s.constraints = append(s.constraints, constraint{attribute: centerYAttr, comparison: less, anchor: a.anchor, priority: s.priority})
 }
 
 func (s *Solver) CenterYGreater(a *Anchor) {
-	s.constraints = append(s.constraints, constraint{attribute: centerYAttr, comparison: greater, anchor: a.anchor})
+	s.constraints = append(s.constraints, constraint{attribute: centerYAttr, comparison: greater, anchor: a.anchor, priority: s.priority})
+}
+
+func (s *Solver) FirstBaseline(v float64) {
+	s.FirstBaselineEqual(Const(v))
+}
+
+func (s *Solver) FirstBaselineEqual(a *Anchor) {
+	s.constraints = append(s.constraints, constraint{attribute: firstBaselineAttr, comparison: equal, anchor: a.anchor, priority: s.priority})
+}
+
+func (s *Solver) FirstBaselineLess(a *Anchor) {
+	s.constraints = append(s.constraints, constraint{attribute: firstBaselineAttr, comparison: less, anchor: a.anchor, priority: s.priority})
+}
+
+func (s *Solver) FirstBaselineGreater(a *Anchor) {
+	s.constraints = append(s.constraints, constraint{attribute: firstBaselineAttr, comparison: greater, anchor: a.anchor, priority: s.priority})
+}
+
+func (s *Solver) LastBaseline(v float64) {
+	s.LastBaselineEqual(Const(v))
+}
+
+func (s *Solver) LastBaselineEqual(a *Anchor) {
+	s.constraints = append(s.constraints, constraint{attribute: lastBaselineAttr, comparison: equal, anchor: a.anchor, priority: s.priority})
+}
+
+func (s *Solver) LastBaselineLess(a *Anchor) {
+	s.constraints = append(s.constraints, constraint{attribute: lastBaselineAttr, comparison: less, anchor: a.anchor, priority: s.priority})
+}
+
+func (s *Solver) LastBaselineGreater(a *Anchor) {
+	s.constraints = append(s.constraints, constraint{attribute: lastBaselineAttr, comparison: greater, anchor: a.anchor, priority: s.priority})
+}
+
+// WidthPercent sets the width to p percent of the width of the parent.
+func (s *Solver) WidthPercent(p float64) {
+	s.WidthEqual(s.parentPercent(widthAttr, p))
+}
+
+// HeightPercent sets the height to p percent of the height of the parent.
+func (s *Solver) HeightPercent(p float64) {
+	s.HeightEqual(s.parentPercent(heightAttr, p))
+}
+
+// CenterXPercent places the center at p percent of the width of the parent,
+// from its left edge. 50 centers the view horizontally.
+func (s *Solver) CenterXPercent(p float64) {
+	s.CenterXEqual(s.parentPercent(widthAttr, p))
+}
+
+// CenterYPercent places the center at p percent of the height of the parent,
+// from its top edge. 50 centers the view vertically.
+func (s *Solver) CenterYPercent(p float64) {
+	s.CenterYEqual(s.parentPercent(heightAttr, p))
+}
+
+func (s *Solver) parentPercent(a attribute, p float64) *Anchor {
+	return &Anchor{multiplierAnchor{
+		multiplier: p / 100,
+		underlying: parentAnchor{index: s.index, attribute: a},
+	}}
 }
 
 func (s *Solver) String() string {
@@ -563,16 +984,25 @@ func (s *Solver) String() string {
 type systemId int
 
 const (
-	rootId int = -1
-	minId  int = -2
-	maxId  int = -3
+	rootId     int = -1
+	minId      int = -2
+	maxId      int = -3
+	safeAreaId int = -4
+	keyboardId int = -5
 )
 
 type Layouter struct {
 	// Guide represents the size of the view that the layouter is attached to. By default, Guide is the same size as MinGuide.
 	Guide
+	// Direction is the direction that leading and trailing anchors follow. It
+	// follows the system's locale by default.
+	Direction layout.Direction
+
+	rightToLeft    bool
 	min            Guide
 	max            Guide
+	safeArea       *Guide
+	keyboard       *Guide
 	solvers        []*Solver
 	zIndex         int
 	notifiers      []comm.Notifier
@@ -607,9 +1037,46 @@ func (l *Layouter) MaxGuide() *Guide {
 	return &l.max
 }
 
+// SafeAreaGuide returns a guide for the part of l's Guide within the safe area
+// of the screen, which avoids notches, the status bar and the home indicator.
+// The safe area is inset from the edges of the screen, see
+// application.SafeAreaInsets, so the guide is meant for layouters of views that
+// fill the screen.
+//
+//  l.Add(header, func(s *constraint.Solver) {
+//  	s.TopEqual(l.SafeAreaGuide().Top())
+//  	s.LeftEqual(l.SafeAreaGuide().Left())
+//  	s.RightEqual(l.SafeAreaGuide().Right())
+//  })
+func (l *Layouter) SafeAreaGuide() *Guide {
+	l.initialize()
+	if l.safeArea == nil {
+		l.safeArea = &Guide{index: safeAreaId, system: l}
+		l.notifiers = append(l.notifiers, application.SafeAreaInsets())
+	}
+	return l.safeArea
+}
+
+// KeyboardGuide returns a guide for the part of l's Guide above the keyboard.
+// As with SafeAreaGuide, it is meant for layouters of views that fill the
+// screen.
+//
+//  l.Add(composer, func(s *constraint.Solver) {
+//  	s.BottomEqual(l.KeyboardGuide().Bottom())
+//  })
+func (l *Layouter) KeyboardGuide() *Guide {
+	l.initialize()
+	if l.keyboard == nil {
+		l.keyboard = &Guide{index: keyboardId, system: l}
+		l.notifiers = append(l.notifiers, keyboard.ScreenInset())
+	}
+	return l.keyboard
+}
+
 // Layout evaluates the constraints and returns the calculated guide and child guides.
 func (l *Layouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
 	l.initialize()
+	l.rightToLeft = application.IsRightToLeft(l.Direction)
 	l.min.matchaGuide = &layout.Guide{
 		Frame: layout.Rt(0, 0, ctx.MinSize().X, ctx.MinSize().Y),
 	}
@@ -735,6 +1202,11 @@ func newConstrainedRect() constrainedRect {
 }
 
 func (cr constrainedRect) isValid() bool {
+	for _, r := range []_range{cr.left, cr.right, cr.top, cr.bottom, cr.width, cr.height, cr.centerX, cr.centerY} {
+		if !r.isValid() {
+			return false
+		}
+	}
 	_, r1 := cr.solveWidth(0)
 	_, r2 := cr.solveHeight(0)
 	_, r3 := cr.solveCenterX(0)
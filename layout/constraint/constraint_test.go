@@ -3,6 +3,9 @@ package constraint
 import (
 	"math"
 	"testing"
+
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/layout/layouttest"
 )
 
 func TestConstrainedRect(t *testing.T) {
@@ -187,3 +190,214 @@ func TestSolveWidth(t *testing.T) {
 		t.Errorf("Incorrect solution: (%v, %v)", w, ok)
 	}
 }
+
+func TestPriority(t *testing.T) {
+	l := &Layouter{}
+	l.Add(nil, func(s *Solver) {
+		s.Priority(PriorityLow)
+		s.Left(50)
+		s.Width(200)
+		s.Priority(PriorityHigh)
+		s.Left(20)
+		s.Priority(PriorityRequired)
+		s.WidthLess(Const(100))
+		s.Top(0)
+	})
+
+	ctx := &layouttest.Context{
+		Min:   layout.Pt(300, 300),
+		Max:   layout.Pt(300, 300),
+		Sizes: []layout.Point{{X: 50, Y: 10}},
+	}
+	_, gs := l.Layout(ctx)
+	// The low priority constraints conflict with the others, and are broken.
+	if want := layout.Rt(20, 0, 70, 10); gs[0].Frame != want {
+		t.Errorf("frame = %v, want %v", gs[0].Frame, want)
+	}
+}
+
+func TestPercent(t *testing.T) {
+	l := &Layouter{}
+	l.Add(nil, func(s *Solver) {
+		s.WidthPercent(60)
+		s.HeightPercent(25)
+		s.CenterXPercent(50)
+		s.CenterYPercent(25)
+	})
+
+	ctx := &layouttest.Context{
+		Min:   layout.Pt(200, 400),
+		Max:   layout.Pt(200, 400),
+		Sizes: []layout.Point{{X: 10, Y: 10}},
+	}
+	_, gs := l.Layout(ctx)
+	if want := layout.Rt(40, 50, 160, 150); gs[0].Frame != want {
+		t.Errorf("frame = %v, want %v", gs[0].Frame, want)
+	}
+}
+
+func TestDirection(t *testing.T) {
+	for _, tc := range []struct {
+		direction   layout.Direction
+		icon, label layout.Rect
+	}{
+		{layout.DirectionLeftToRight, layout.Rt(10, 0, 30, 20), layout.Rt(38, 0, 88, 20)},
+		{layout.DirectionRightToLeft, layout.Rt(170, 0, 190, 20), layout.Rt(112, 0, 162, 20)},
+	} {
+		l := &Layouter{Direction: tc.direction}
+		icon := l.Add(nil, func(s *Solver) {
+			s.Top(0)
+			s.LeadingEqual(l.Leading().Add(10))
+		})
+		l.Add(nil, func(s *Solver) {
+			s.Top(0)
+			s.LeadingEqual(icon.Trailing().Add(8))
+		})
+
+		ctx := &layouttest.Context{
+			Min:   layout.Pt(200, 100),
+			Max:   layout.Pt(200, 100),
+			Sizes: []layout.Point{{X: 20, Y: 20}, {X: 50, Y: 20}},
+		}
+		_, gs := l.Layout(ctx)
+		if gs[0].Frame != tc.icon || gs[1].Frame != tc.label {
+			t.Errorf("%v: frames = %v, %v, want %v, %v", tc.direction, gs[0].Frame, gs[1].Frame, tc.icon, tc.label)
+		}
+	}
+}
+
+func TestBaseline(t *testing.T) {
+	l := &Layouter{}
+	title := l.Add(nil, func(s *Solver) {
+		s.Top(10)
+		s.Left(0)
+	})
+	l.Add(nil, func(s *Solver) {
+		s.FirstBaselineEqual(title.FirstBaseline())
+		s.LeftEqual(title.Right())
+	})
+	l.Add(nil, func(s *Solver) {
+		s.TopEqual(title.LastBaseline())
+		s.Left(0)
+	})
+
+	ctx := &layouttest.Context{
+		Min:       layout.Pt(200, 200),
+		Max:       layout.Pt(200, 200),
+		Sizes:     []layout.Point{{X: 50, Y: 40}, {X: 30, Y: 20}, {X: 10, Y: 10}},
+		Baselines: []float64{32, 16},
+	}
+	_, gs := l.Layout(ctx)
+	want := []layout.Rect{
+		layout.Rt(0, 10, 50, 50),
+		layout.Rt(50, 26, 80, 46),
+		layout.Rt(0, 42, 10, 52),
+	}
+	for i, w := range want {
+		if gs[i].Frame != w {
+			t.Errorf("child %v frame = %v, want %v", i, gs[i].Frame, w)
+		}
+	}
+}
+
+// intrinsicContext is a layouttest.Context that implements layout.IntrinsicContext.
+type intrinsicContext struct {
+	layouttest.Context
+	intrinsic []layout.Point
+}
+
+func (c *intrinsicContext) ChildIntrinsicSize(idx int, max layout.Point) (layout.Point, bool) {
+	return c.intrinsic[idx], true
+}
+
+func TestIntrinsicSize(t *testing.T) {
+	l := &Layouter{}
+	l.Add(nil, func(s *Solver) {
+		s.Top(0)
+		s.Left(0)
+	})
+	l.Add(nil, func(s *Solver) {
+		s.Top(0)
+		s.Left(0)
+		s.Priority(PriorityLow)
+		s.Width(100)
+		s.WidthLess(Const(20))
+	})
+	l.Add(nil, func(s *Solver) {
+		s.Top(0)
+		s.Left(0)
+		s.ContentHugging(layout.AxisX, PriorityRequired)
+		s.CompressionResistance(layout.AxisY, PriorityLow)
+		s.Priority(PriorityHigh)
+		s.Width(100)
+		s.Height(20)
+	})
+
+	ctx := &intrinsicContext{
+		Context: layouttest.Context{
+			Min:   layout.Pt(200, 200),
+			Max:   layout.Pt(200, 200),
+			Sizes: []layout.Point{{}, {}, {}},
+		},
+		intrinsic: []layout.Point{{X: 40, Y: 30}, {X: 40, Y: 30}, {X: 40, Y: 30}},
+	}
+	_, gs := l.Layout(ctx)
+	want := []layout.Rect{
+		// Views are sized to their content.
+		layout.Rt(0, 0, 40, 30),
+		// Low priority constraints grow the view over its content hugging,
+		// but do not compress it.
+		layout.Rt(0, 0, 100, 30),
+		// Required hugging holds the width, and the low compression
+		// resistance lets the view shrink.
+		layout.Rt(0, 0, 40, 20),
+	}
+	for i, w := range want {
+		if gs[i].Frame != w {
+			t.Errorf("child %v frame = %v, want %v", i, gs[i].Frame, w)
+		}
+	}
+}
+
+func TestAspectRatio(t *testing.T) {
+	l := &Layouter{}
+	l.Add(nil, func(s *Solver) {
+		s.Top(0)
+		s.Left(0)
+		s.WidthEqual(l.Guide.Width())
+		s.AspectRatio(16, 9)
+	})
+	l.Add(nil, func(s *Solver) {
+		s.Top(0)
+		s.Left(0)
+		s.Height(90)
+		s.AspectRatio(2, 1)
+	})
+	l.Add(nil, func(s *Solver) {
+		s.Top(0)
+		s.Left(0)
+		s.WidthLess(Const(100))
+		s.HeightGreater(Const(80))
+		s.AspectRatio(2, 1)
+	})
+
+	for _, width := range []float64{320, 160} {
+		ctx := &layouttest.Context{
+			Min:   layout.Pt(width, 400),
+			Max:   layout.Pt(width, 400),
+			Sizes: []layout.Point{{}, {}, {}},
+		}
+		_, gs := l.Layout(ctx)
+		want := []layout.Rect{
+			layout.Rt(0, 0, width, width*9/16),
+			layout.Rt(0, 0, 180, 90),
+			// The ratio conflicts with the other constraints, and is broken.
+			layout.Rt(0, 0, 0, 80),
+		}
+		for i, w := range want {
+			if gs[i].Frame != w {
+				t.Errorf("width %v: child %v frame = %v, want %v", width, i, gs[i].Frame, w)
+			}
+		}
+	}
+}
@@ -0,0 +1,584 @@
+/*
+Package flex implements a flexbox layout system. Views are placed in rows or
+columns that wrap, grow, shrink and align them like CSS flexbox.
+
+ l := &flex.Layouter{
+ 	Direction:  flex.DirectionRow,
+ 	Justify:    flex.JustifySpaceBetween,
+ 	AlignItems: flex.AlignCenter,
+ }
+
+ l.Add(iconView, func(i *flex.Item) {
+ 	i.Width = 40
+ 	i.Height = 40
+ })
+ l.Add(titleView, func(i *flex.Item) {
+ 	i.Grow = 1
+ 	i.Margin.Left = 8
+ })
+
+ return view.Model{
+ 	Children: l.Views(),
+ 	Layouter: l,
+ }
+
+The zero values match Yoga's defaults rather than CSS's: items are laid out in
+a column, don't shrink, and wrapped lines are packed at the start. The
+layouter is as large as its content, within the minimum and maximum size of
+its view, so items only grow into the minimum size.
+*/
+package flex
+
+import (
+	"math"
+
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/view"
+)
+
+// Auto sizes an item to its content when it is used as a Basis, Width or
+// Height. Any negative value does the same.
+const Auto = -1
+
+// Direction is the main axis that items are placed along.
+type Direction int
+
+const (
+	// DirectionColumn places items from top to bottom.
+	DirectionColumn Direction = iota
+	// DirectionRow places items from left to right.
+	DirectionRow
+	// DirectionColumnReverse places items from bottom to top.
+	DirectionColumnReverse
+	// DirectionRowReverse places items from right to left.
+	DirectionRowReverse
+)
+
+// Wrap is whether items that don't fit along the main axis are moved to new
+// lines.
+type Wrap int
+
+const (
+	// WrapNone keeps all items on a single line.
+	WrapNone Wrap = iota
+	// WrapNormal adds lines after the first one, below it for rows and to its
+	// right for columns.
+	WrapNormal
+	// WrapReverse adds lines before the first one.
+	WrapReverse
+)
+
+// Justify is how the free space of a line is distributed along the main axis.
+type Justify int
+
+const (
+	JustifyStart Justify = iota
+	JustifyCenter
+	JustifyEnd
+	// JustifySpaceBetween places the first and last items at the ends of the
+	// line, with equal space between the items.
+	JustifySpaceBetween
+	// JustifySpaceAround gives each item equal space on both sides, so the
+	// space at the ends of the line is half the space between items.
+	JustifySpaceAround
+	// JustifySpaceEvenly places items with equal space between them and at
+	// the ends of the line.
+	JustifySpaceEvenly
+)
+
+// Align is how items are placed along the cross axis of their line, or how
+// lines are placed in the layouter.
+type Align int
+
+const (
+	// AlignAuto uses the layouter's AlignItems for an item's AlignSelf. As
+	// AlignItems it stretches items, and as AlignContent it packs lines at the
+	// start.
+	AlignAuto Align = iota
+	AlignStart
+	AlignCenter
+	AlignEnd
+	// AlignStretch fills the line with items that have no cross size, or
+	// fills the layouter with lines.
+	AlignStretch
+	// AlignSpaceBetween and AlignSpaceAround only apply to AlignContent, and
+	// distribute the free space between lines like the Justify options.
+	AlignSpaceBetween
+	AlignSpaceAround
+)
+
+// Insets are the space around the edges of a rectangle.
+type Insets struct {
+	Top, Left, Bottom, Right float64
+}
+
+// Item holds how a view is sized and placed by a Layouter.
+type Item struct {
+	// Grow is the share of the line's free space that the item grows by.
+	Grow float64
+	// Shrink is how much the item shrinks, relative to its basis and the
+	// other items, when the line overflows.
+	Shrink float64
+	// Basis is the main size of the item before it grows or shrinks. It
+	// defaults to Auto, which uses the item's Width or Height, or measures the
+	// view.
+	Basis float64
+	// Width and Height fix the size of the item. They default to Auto, which
+	// measures the view, or stretches it along the cross axis.
+	Width  float64
+	Height float64
+	// The item is never sized outside of these. The maximums default to
+	// infinity.
+	MinWidth  float64
+	MinHeight float64
+	MaxWidth  float64
+	MaxHeight float64
+	// Margin is the space outside of the item.
+	Margin Insets
+	// AlignSelf overrides the layouter's AlignItems for this item.
+	AlignSelf Align
+}
+
+// Layouter places views along rows or columns.
+type Layouter struct {
+	Direction Direction
+	Wrap      Wrap
+	Justify   Justify
+	// AlignItems is how items are placed along the cross axis of their line.
+	AlignItems Align
+	// AlignContent is how lines are placed when the layouter wraps.
+	AlignContent Align
+	// Padding is the space between the edges of the view and its items.
+	Padding Insets
+	// Gap is the space between items, and between lines.
+	Gap   float64
+	items []*Item
+	views []view.View
+}
+
+// Add adds v to the layouter. f is called to adjust the item that sizes and
+// places v, and may be nil.
+func (l *Layouter) Add(v view.View, f func(*Item)) {
+	i := &Item{
+		Basis:     Auto,
+		Width:     Auto,
+		Height:    Auto,
+		MaxWidth:  math.Inf(1),
+		MaxHeight: math.Inf(1),
+	}
+	if f != nil {
+		f(i)
+	}
+	l.items = append(l.items, i)
+	l.views = append(l.views, v)
+}
+
+// Views returns all views that have been added to l.
+func (l *Layouter) Views() []view.View {
+	return l.views
+}
+
+// Notify implements the view.Layouter interface.
+func (l *Layouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+// Unnotify implements the view.Layouter interface.
+func (l *Layouter) Unnotify(id comm.Id) {
+	// no-op
+}
+
+// axes converts between points and main and cross sizes.
+type axes struct {
+	row bool
+}
+
+func (a axes) main(p layout.Point) float64 {
+	if a.row {
+		return p.X
+	}
+	return p.Y
+}
+
+func (a axes) cross(p layout.Point) float64 {
+	if a.row {
+		return p.Y
+	}
+	return p.X
+}
+
+func (a axes) pt(main, cross float64) layout.Point {
+	if a.row {
+		return layout.Pt(main, cross)
+	}
+	return layout.Pt(cross, main)
+}
+
+// flexItem is an item during a layout, in main and cross sizes. Margins are
+// ordered from the start of the axes, which are flipped by the reverse
+// directions.
+type flexItem struct {
+	*Item
+	index                          int
+	align                          Align
+	fixedMain, fixedCross          float64
+	minMain, maxMain               float64
+	minCross, maxCross             float64
+	marginMainStart, marginMainEnd float64
+	marginCrossStart               float64
+	marginCrossEnd                 float64
+	base, hypo                     float64
+	main, cross                    float64
+	mainPos, crossPos              float64
+	frozen                         bool
+}
+
+func (it *flexItem) marginMain() float64 {
+	return it.marginMainStart + it.marginMainEnd
+}
+
+func (it *flexItem) marginCross() float64 {
+	return it.marginCrossStart + it.marginCrossEnd
+}
+
+func (it *flexItem) clampMain(v float64) float64 {
+	return math.Max(math.Min(v, it.maxMain), it.minMain)
+}
+
+func (it *flexItem) clampCross(v float64) float64 {
+	return math.Max(math.Min(v, it.maxCross), it.minCross)
+}
+
+type flexLine struct {
+	items    []*flexItem
+	cross    float64
+	crossPos float64
+}
+
+func (l *Layouter) newItem(a axes, index int) *flexItem {
+	i := l.items[index]
+	it := &flexItem{Item: i, index: index, align: i.AlignSelf}
+	if it.align == AlignAuto {
+		it.align = l.AlignItems
+	}
+	if it.align == AlignAuto {
+		it.align = AlignStretch
+	}
+	m := i.Margin
+	reverse := l.Direction == DirectionRowReverse || l.Direction == DirectionColumnReverse
+	if a.row {
+		it.fixedMain, it.fixedCross = i.Width, i.Height
+		it.minMain, it.maxMain = i.MinWidth, i.MaxWidth
+		it.minCross, it.maxCross = i.MinHeight, i.MaxHeight
+		it.marginMainStart, it.marginMainEnd = m.Left, m.Right
+		it.marginCrossStart, it.marginCrossEnd = m.Top, m.Bottom
+	} else {
+		it.fixedMain, it.fixedCross = i.Height, i.Width
+		it.minMain, it.maxMain = i.MinHeight, i.MaxHeight
+		it.minCross, it.maxCross = i.MinWidth, i.MaxWidth
+		it.marginMainStart, it.marginMainEnd = m.Top, m.Bottom
+		it.marginCrossStart, it.marginCrossEnd = m.Left, m.Right
+	}
+	if reverse {
+		it.marginMainStart, it.marginMainEnd = it.marginMainEnd, it.marginMainStart
+	}
+	if l.Wrap == WrapReverse {
+		it.marginCrossStart, it.marginCrossEnd = it.marginCrossEnd, it.marginCrossStart
+	}
+	return it
+}
+
+// Layout implements the view.Layouter interface.
+func (l *Layouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	a := axes{row: l.Direction == DirectionRow || l.Direction == DirectionRowReverse}
+	p := l.Padding
+	padding := layout.Pt(p.Left+p.Right, p.Top+p.Bottom)
+	minMain := math.Max(a.main(ctx.MinSize())-a.main(padding), 0)
+	maxMain := math.Max(a.main(ctx.MaxSize())-a.main(padding), 0)
+	minCross := math.Max(a.cross(ctx.MinSize())-a.cross(padding), 0)
+	maxCross := math.Max(a.cross(ctx.MaxSize())-a.cross(padding), 0)
+	// A single line fills a fixed cross size, so stretched items are measured
+	// with it.
+	definiteCross := l.Wrap == WrapNone && minCross == maxCross
+
+	count := len(l.items)
+	if ctx.ChildCount() < count {
+		count = ctx.ChildCount()
+	}
+	items := make([]*flexItem, count)
+	for i := range items {
+		it := l.newItem(a, i)
+		items[i] = it
+
+		// Cross size that the item is measured with.
+		cmin, cmax := it.minCross, it.clampCross(maxCross-it.marginCross())
+		if it.fixedCross >= 0 {
+			cmin = it.clampCross(it.fixedCross)
+			cmax = cmin
+		} else if it.align == AlignStretch && definiteCross {
+			cmin = it.clampCross(minCross - it.marginCross())
+			cmax = cmin
+		}
+
+		switch {
+		case it.Basis >= 0:
+			it.base = it.Basis
+		case it.fixedMain >= 0:
+			it.base = it.fixedMain
+		default:
+			g := ctx.LayoutChild(i, a.pt(0, cmin), a.pt(math.Max(maxMain-it.marginMain(), 0), cmax))
+			it.base = a.main(layout.Pt(g.Width(), g.Height()))
+		}
+		it.hypo = it.clampMain(it.base)
+	}
+
+	// Break the items into lines.
+	lines := []*flexLine{}
+	var line *flexLine
+	used := 0.0
+	for _, it := range items {
+		outer := it.hypo + it.marginMain()
+		if line == nil || (l.Wrap != WrapNone && len(line.items) > 0 && used+l.Gap+outer > maxMain) {
+			line = &flexLine{}
+			lines = append(lines, line)
+			used = outer
+		} else {
+			used += l.Gap + outer
+		}
+		line.items = append(line.items, it)
+	}
+
+	// The main size of the layouter fits its longest line.
+	content := 0.0
+	for _, line := range lines {
+		content = math.Max(content, l.hypoMain(line))
+	}
+	containerMain := math.Max(math.Min(content, maxMain), minMain)
+
+	for _, line := range lines {
+		l.resolve(line, containerMain)
+	}
+
+	// Measure the items at their main sizes, and size the lines to fit them.
+	for _, line := range lines {
+		for _, it := range line.items {
+			cmin, cmax := it.minCross, it.clampCross(maxCross-it.marginCross())
+			if it.fixedCross >= 0 {
+				cmin = it.clampCross(it.fixedCross)
+				cmax = cmin
+			} else if it.align == AlignStretch && definiteCross {
+				cmin = it.clampCross(minCross - it.marginCross())
+				cmax = cmin
+			}
+			g := ctx.LayoutChild(it.index, a.pt(it.main, cmin), a.pt(it.main, cmax))
+			it.cross = a.cross(layout.Pt(g.Width(), g.Height()))
+			line.cross = math.Max(line.cross, it.cross+it.marginCross())
+		}
+	}
+	content = l.Gap * float64(len(lines)-1)
+	for _, line := range lines {
+		content += line.cross
+	}
+	containerCross := math.Max(math.Min(content, maxCross), minCross)
+	if l.Wrap == WrapNone && len(lines) == 1 {
+		lines[0].cross = containerCross
+		content = containerCross
+	}
+
+	// Place the lines.
+	free := containerCross - content
+	pos, between := 0.0, 0.0
+	switch l.AlignContent {
+	case AlignStretch:
+		if free > 0 {
+			for _, line := range lines {
+				line.cross += free / float64(len(lines))
+			}
+		}
+	case AlignCenter:
+		pos = free / 2
+	case AlignEnd:
+		pos = free
+	case AlignSpaceBetween:
+		if free > 0 && len(lines) > 1 {
+			between = free / float64(len(lines)-1)
+		}
+	case AlignSpaceAround:
+		if free > 0 {
+			between = free / float64(len(lines))
+			pos = between / 2
+		}
+	}
+	for _, line := range lines {
+		line.crossPos = pos
+		pos += line.cross + l.Gap + between
+	}
+
+	for _, line := range lines {
+		l.justify(line, containerMain)
+		for _, it := range line.items {
+			if it.align == AlignStretch && it.fixedCross < 0 {
+				it.cross = it.clampCross(line.cross - it.marginCross())
+			}
+			switch it.align {
+			case AlignCenter:
+				it.crossPos = it.marginCrossStart + (line.cross-it.cross-it.marginCross())/2
+			case AlignEnd:
+				it.crossPos = line.cross - it.cross - it.marginCrossEnd
+			default:
+				it.crossPos = it.marginCrossStart
+			}
+			it.crossPos += line.crossPos
+		}
+	}
+
+	gs := make([]layout.Guide, count)
+	for i, it := range items {
+		size := a.pt(it.main, it.cross)
+		ctx.LayoutChild(i, size, size)
+
+		mainPos, crossPos := it.mainPos, it.crossPos
+		if l.Direction == DirectionRowReverse || l.Direction == DirectionColumnReverse {
+			mainPos = containerMain - mainPos - it.main
+		}
+		if l.Wrap == WrapReverse {
+			crossPos = containerCross - crossPos - it.cross
+		}
+		origin := a.pt(mainPos, crossPos)
+		origin = layout.Pt(origin.X+p.Left, origin.Y+p.Top)
+		gs[i] = layout.Guide{
+			Frame:  layout.Rt(origin.X, origin.Y, origin.X+size.X, origin.Y+size.Y),
+			ZIndex: i,
+		}
+	}
+	size := a.pt(containerMain, containerCross)
+	g := layout.Guide{Frame: layout.Rt(0, 0, size.X+padding.X, size.Y+padding.Y)}
+	return g, gs
+}
+
+// hypoMain returns the main size of line before its items grow or shrink.
+func (l *Layouter) hypoMain(line *flexLine) float64 {
+	size := l.Gap * float64(len(line.items)-1)
+	for _, it := range line.items {
+		size += it.hypo + it.marginMain()
+	}
+	return size
+}
+
+// resolve grows or shrinks the items of line to fill space, following the
+// CSS flexbox algorithm. Items that reach their minimum or maximum size are
+// frozen, and the rest of the free space is distributed again among the
+// others.
+func (l *Layouter) resolve(line *flexLine, space float64) {
+	growing := l.hypoMain(line) < space
+	for _, it := range line.items {
+		it.main = it.hypo
+		factor := it.Shrink
+		if growing {
+			factor = it.Grow
+		}
+		it.frozen = factor == 0 || (growing && it.base > it.hypo) || (!growing && it.base < it.hypo)
+	}
+
+	gaps := l.Gap * float64(len(line.items)-1)
+	initialFree := space - gaps
+	for _, it := range line.items {
+		if it.frozen {
+			initialFree -= it.main + it.marginMain()
+		} else {
+			initialFree -= it.base + it.marginMain()
+		}
+	}
+
+	diffs := make([]float64, len(line.items))
+	for {
+		free := space - gaps
+		factors, scaled := 0.0, 0.0
+		unfrozen := false
+		for _, it := range line.items {
+			if it.frozen {
+				free -= it.main + it.marginMain()
+				continue
+			}
+			unfrozen = true
+			free -= it.base + it.marginMain()
+			if growing {
+				factors += it.Grow
+			} else {
+				factors += it.Shrink
+				scaled += it.Shrink * it.base
+			}
+		}
+		if !unfrozen {
+			return
+		}
+		// Factors that add up to less than 1 only distribute part of the
+		// free space.
+		if factors < 1 && math.Abs(initialFree*factors) < math.Abs(free) {
+			free = initialFree * factors
+		}
+
+		violation := 0.0
+		for k, it := range line.items {
+			if it.frozen {
+				continue
+			}
+			target := it.base
+			if growing && factors > 0 {
+				target += free * it.Grow / factors
+			} else if !growing && scaled > 0 {
+				target += free * it.Shrink * it.base / scaled
+			}
+			it.main = it.clampMain(target)
+			diffs[k] = it.main - target
+			violation += diffs[k]
+		}
+		for k, it := range line.items {
+			if !it.frozen && (violation == 0 || (violation > 0 && diffs[k] > 0) || (violation < 0 && diffs[k] < 0)) {
+				it.frozen = true
+			}
+		}
+	}
+}
+
+// justify places the items of line along the main axis.
+func (l *Layouter) justify(line *flexLine, space float64) {
+	n := float64(len(line.items))
+	free := space - l.Gap*(n-1)
+	for _, it := range line.items {
+		free -= it.main + it.marginMain()
+	}
+
+	justify := l.Justify
+	if free < 0 {
+		switch justify {
+		case JustifySpaceBetween:
+			justify = JustifyStart
+		case JustifySpaceAround, JustifySpaceEvenly:
+			justify = JustifyCenter
+		}
+	}
+	pos, between := 0.0, 0.0
+	switch justify {
+	case JustifyCenter:
+		pos = free / 2
+	case JustifyEnd:
+		pos = free
+	case JustifySpaceBetween:
+		if n > 1 {
+			between = free / (n - 1)
+		}
+	case JustifySpaceAround:
+		between = free / n
+		pos = between / 2
+	case JustifySpaceEvenly:
+		between = free / (n + 1)
+		pos = between
+	}
+	for _, it := range line.items {
+		pos += it.marginMainStart
+		it.mainPos = pos
+		pos += it.main + it.marginMainEnd + l.Gap + between
+	}
+}
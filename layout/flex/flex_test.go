@@ -0,0 +1,301 @@
+package flex
+
+import (
+	"math"
+	"testing"
+
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/layout/layouttest"
+)
+
+type flexTest struct {
+	name   string
+	l      *Layouter
+	items  []func(*Item)
+	sizes  []layout.Point // Intrinsic sizes of the children, 0 if nil.
+	min    layout.Point
+	max    layout.Point
+	frame  layout.Rect
+	frames []layout.Rect
+}
+
+func width(w float64) func(*Item) {
+	return func(i *Item) { i.Width = w }
+}
+
+func height(h float64) func(*Item) {
+	return func(i *Item) { i.Height = h }
+}
+
+func square(s float64) func(*Item) {
+	return func(i *Item) { i.Width, i.Height = s, s }
+}
+
+// The cases mirror fixtures from Yoga's gentest suite, with its defaults: a
+// column direction and items that don't shrink.
+var flexTests = []flexTest{
+	{
+		name:   "justify_content_row_flex_start",
+		l:      &Layouter{Direction: DirectionRow},
+		items:  []func(*Item){width(10), width(10), width(10)},
+		min:    layout.Pt(102, 102),
+		max:    layout.Pt(102, 102),
+		frame:  layout.Rt(0, 0, 102, 102),
+		frames: []layout.Rect{layout.Rt(0, 0, 10, 102), layout.Rt(10, 0, 20, 102), layout.Rt(20, 0, 30, 102)},
+	},
+	{
+		name:   "justify_content_row_flex_end",
+		l:      &Layouter{Direction: DirectionRow, Justify: JustifyEnd},
+		items:  []func(*Item){width(10), width(10), width(10)},
+		min:    layout.Pt(102, 102),
+		max:    layout.Pt(102, 102),
+		frame:  layout.Rt(0, 0, 102, 102),
+		frames: []layout.Rect{layout.Rt(72, 0, 82, 102), layout.Rt(82, 0, 92, 102), layout.Rt(92, 0, 102, 102)},
+	},
+	{
+		name:   "justify_content_row_center",
+		l:      &Layouter{Direction: DirectionRow, Justify: JustifyCenter},
+		items:  []func(*Item){width(10), width(10), width(10)},
+		min:    layout.Pt(102, 102),
+		max:    layout.Pt(102, 102),
+		frame:  layout.Rt(0, 0, 102, 102),
+		frames: []layout.Rect{layout.Rt(36, 0, 46, 102), layout.Rt(46, 0, 56, 102), layout.Rt(56, 0, 66, 102)},
+	},
+	{
+		name:   "justify_content_row_space_between",
+		l:      &Layouter{Direction: DirectionRow, Justify: JustifySpaceBetween},
+		items:  []func(*Item){width(10), width(10), width(10)},
+		min:    layout.Pt(102, 102),
+		max:    layout.Pt(102, 102),
+		frame:  layout.Rt(0, 0, 102, 102),
+		frames: []layout.Rect{layout.Rt(0, 0, 10, 102), layout.Rt(46, 0, 56, 102), layout.Rt(92, 0, 102, 102)},
+	},
+	{
+		name:   "justify_content_row_space_around",
+		l:      &Layouter{Direction: DirectionRow, Justify: JustifySpaceAround},
+		items:  []func(*Item){width(10), width(10), width(10)},
+		min:    layout.Pt(102, 102),
+		max:    layout.Pt(102, 102),
+		frame:  layout.Rt(0, 0, 102, 102),
+		frames: []layout.Rect{layout.Rt(12, 0, 22, 102), layout.Rt(46, 0, 56, 102), layout.Rt(80, 0, 90, 102)},
+	},
+	{
+		name:   "justify_content_row_space_evenly",
+		l:      &Layouter{Direction: DirectionRow, Justify: JustifySpaceEvenly},
+		items:  []func(*Item){width(10), width(10), width(10)},
+		min:    layout.Pt(102, 102),
+		max:    layout.Pt(102, 102),
+		frame:  layout.Rt(0, 0, 102, 102),
+		frames: []layout.Rect{layout.Rt(18, 0, 28, 102), layout.Rt(46, 0, 56, 102), layout.Rt(74, 0, 84, 102)},
+	},
+	{
+		name:   "justify_content_column_center",
+		l:      &Layouter{Justify: JustifyCenter},
+		items:  []func(*Item){height(10), height(10), height(10)},
+		min:    layout.Pt(102, 102),
+		max:    layout.Pt(102, 102),
+		frame:  layout.Rt(0, 0, 102, 102),
+		frames: []layout.Rect{layout.Rt(0, 36, 102, 46), layout.Rt(0, 46, 102, 56), layout.Rt(0, 56, 102, 66)},
+	},
+	{
+		name:   "align_items_stretch",
+		l:      &Layouter{},
+		items:  []func(*Item){height(10)},
+		min:    layout.Pt(100, 100),
+		max:    layout.Pt(100, 100),
+		frame:  layout.Rt(0, 0, 100, 100),
+		frames: []layout.Rect{layout.Rt(0, 0, 100, 10)},
+	},
+	{
+		name:   "align_items_center",
+		l:      &Layouter{AlignItems: AlignCenter},
+		items:  []func(*Item){square(10)},
+		min:    layout.Pt(100, 100),
+		max:    layout.Pt(100, 100),
+		frame:  layout.Rt(0, 0, 100, 100),
+		frames: []layout.Rect{layout.Rt(45, 0, 55, 10)},
+	},
+	{
+		name:   "align_items_flex_end",
+		l:      &Layouter{AlignItems: AlignEnd},
+		items:  []func(*Item){square(10)},
+		min:    layout.Pt(100, 100),
+		max:    layout.Pt(100, 100),
+		frame:  layout.Rt(0, 0, 100, 100),
+		frames: []layout.Rect{layout.Rt(90, 0, 100, 10)},
+	},
+	{
+		name: "align_self_center",
+		l:    &Layouter{},
+		items: []func(*Item){func(i *Item) {
+			i.Width, i.Height = 10, 10
+			i.AlignSelf = AlignCenter
+		}},
+		min:    layout.Pt(100, 100),
+		max:    layout.Pt(100, 100),
+		frame:  layout.Rt(0, 0, 100, 100),
+		frames: []layout.Rect{layout.Rt(45, 0, 55, 10)},
+	},
+	{
+		name: "flex_basis_flex_grow_column",
+		l:    &Layouter{},
+		items: []func(*Item){
+			func(i *Item) { i.Grow, i.Basis = 1, 50 },
+			func(i *Item) { i.Grow = 1 },
+		},
+		min:    layout.Pt(100, 100),
+		max:    layout.Pt(100, 100),
+		frame:  layout.Rt(0, 0, 100, 100),
+		frames: []layout.Rect{layout.Rt(0, 0, 100, 75), layout.Rt(0, 75, 100, 100)},
+	},
+	{
+		name: "flex_grow_less_than_factor_one",
+		l:    &Layouter{},
+		items: []func(*Item){
+			func(i *Item) { i.Grow, i.Basis = 0.2, 40 },
+			func(i *Item) { i.Grow = 0.2 },
+			func(i *Item) { i.Grow = 0.4 },
+		},
+		min:    layout.Pt(200, 500),
+		max:    layout.Pt(200, 500),
+		frame:  layout.Rt(0, 0, 200, 500),
+		frames: []layout.Rect{layout.Rt(0, 0, 200, 132), layout.Rt(0, 132, 200, 224), layout.Rt(0, 224, 200, 408)},
+	},
+	{
+		name: "flex_shrink_flex_grow_row",
+		l:    &Layouter{Direction: DirectionRow},
+		items: []func(*Item){
+			func(i *Item) { i.Shrink, i.Width, i.Height = 1, 120, 10 },
+			func(i *Item) { i.Shrink, i.Width, i.Height = 1, 60, 10 },
+		},
+		min:    layout.Pt(120, 100),
+		max:    layout.Pt(120, 100),
+		frame:  layout.Rt(0, 0, 120, 100),
+		frames: []layout.Rect{layout.Rt(0, 0, 80, 10), layout.Rt(80, 0, 120, 10)},
+	},
+	{
+		name: "flex_grow_within_max_width",
+		l:    &Layouter{Direction: DirectionRow},
+		items: []func(*Item){
+			func(i *Item) { i.Grow, i.MaxWidth, i.Height = 1, 20, 20 },
+			func(i *Item) { i.Grow, i.Height = 1, 20 },
+		},
+		min:    layout.Pt(100, 20),
+		max:    layout.Pt(100, 20),
+		frame:  layout.Rt(0, 0, 100, 20),
+		frames: []layout.Rect{layout.Rt(0, 0, 20, 20), layout.Rt(20, 0, 100, 20)},
+	},
+	{
+		name:  "wrap_row",
+		l:     &Layouter{Direction: DirectionRow, Wrap: WrapNormal},
+		items: []func(*Item){square(10), square(10), square(10), square(10)},
+		min:   layout.Pt(30, 0),
+		max:   layout.Pt(30, math.Inf(1)),
+		frame: layout.Rt(0, 0, 30, 20),
+		frames: []layout.Rect{
+			layout.Rt(0, 0, 10, 10), layout.Rt(10, 0, 20, 10), layout.Rt(20, 0, 30, 10),
+			layout.Rt(0, 10, 10, 20),
+		},
+	},
+	{
+		name:  "wrap_reverse_row",
+		l:     &Layouter{Direction: DirectionRow, Wrap: WrapReverse},
+		items: []func(*Item){square(10), square(10), square(10), square(10)},
+		min:   layout.Pt(30, 0),
+		max:   layout.Pt(30, math.Inf(1)),
+		frame: layout.Rt(0, 0, 30, 20),
+		frames: []layout.Rect{
+			layout.Rt(0, 10, 10, 20), layout.Rt(10, 10, 20, 20), layout.Rt(20, 10, 30, 20),
+			layout.Rt(0, 0, 10, 10),
+		},
+	},
+	{
+		name:  "align_content_stretch_row",
+		l:     &Layouter{Direction: DirectionRow, Wrap: WrapNormal, AlignContent: AlignStretch},
+		items: []func(*Item){width(10), width(10), width(10), width(10)},
+		min:   layout.Pt(20, 100),
+		max:   layout.Pt(20, 100),
+		frame: layout.Rt(0, 0, 20, 100),
+		frames: []layout.Rect{
+			layout.Rt(0, 0, 10, 50), layout.Rt(10, 0, 20, 50),
+			layout.Rt(0, 50, 10, 100), layout.Rt(10, 50, 20, 100),
+		},
+	},
+	{
+		name:   "flex_direction_column_reverse",
+		l:      &Layouter{Direction: DirectionColumnReverse},
+		items:  []func(*Item){height(10), height(10)},
+		min:    layout.Pt(100, 100),
+		max:    layout.Pt(100, 100),
+		frame:  layout.Rt(0, 0, 100, 100),
+		frames: []layout.Rect{layout.Rt(0, 90, 100, 100), layout.Rt(0, 80, 100, 90)},
+	},
+	{
+		name:   "flex_direction_row_reverse",
+		l:      &Layouter{Direction: DirectionRowReverse},
+		items:  []func(*Item){width(10), width(10)},
+		min:    layout.Pt(100, 100),
+		max:    layout.Pt(100, 100),
+		frame:  layout.Rt(0, 0, 100, 100),
+		frames: []layout.Rect{layout.Rt(90, 0, 100, 100), layout.Rt(80, 0, 90, 100)},
+	},
+	{
+		name: "margin_start_and_stretch",
+		l:    &Layouter{Direction: DirectionRow},
+		items: []func(*Item){func(i *Item) {
+			i.Width = 10
+			i.Margin = Insets{Top: 5, Left: 10, Bottom: 15}
+		}},
+		min:    layout.Pt(100, 100),
+		max:    layout.Pt(100, 100),
+		frame:  layout.Rt(0, 0, 100, 100),
+		frames: []layout.Rect{layout.Rt(10, 5, 20, 85)},
+	},
+	{
+		name:   "padding_no_size",
+		l:      &Layouter{Padding: Insets{Top: 10, Left: 10, Bottom: 10, Right: 10}},
+		items:  []func(*Item){height(10)},
+		min:    layout.Pt(100, 100),
+		max:    layout.Pt(100, 100),
+		frame:  layout.Rt(0, 0, 100, 100),
+		frames: []layout.Rect{layout.Rt(10, 10, 90, 20)},
+	},
+	{
+		name:   "column_gap",
+		l:      &Layouter{Direction: DirectionRow, Gap: 10},
+		items:  []func(*Item){width(10), width(10), width(10)},
+		min:    layout.Pt(100, 100),
+		max:    layout.Pt(100, 100),
+		frame:  layout.Rt(0, 0, 100, 100),
+		frames: []layout.Rect{layout.Rt(0, 0, 10, 100), layout.Rt(20, 0, 30, 100), layout.Rt(40, 0, 50, 100)},
+	},
+	{
+		name:   "measured_content_size",
+		l:      &Layouter{Direction: DirectionRow, AlignItems: AlignStart},
+		items:  []func(*Item){nil, nil},
+		sizes:  []layout.Point{layout.Pt(40, 20), layout.Pt(20, 30)},
+		max:    layout.Pt(math.Inf(1), math.Inf(1)),
+		frame:  layout.Rt(0, 0, 60, 30),
+		frames: []layout.Rect{layout.Rt(0, 0, 40, 20), layout.Rt(40, 0, 60, 30)},
+	},
+}
+
+func TestLayout(t *testing.T) {
+	for _, c := range flexTests {
+		for _, f := range c.items {
+			c.l.Add(nil, f)
+		}
+		ctx := &layouttest.Context{Min: c.min, Max: c.max, Sizes: make([]layout.Point, len(c.items))}
+		copy(ctx.Sizes, c.sizes)
+
+		g, gs := c.l.Layout(ctx)
+		if g.Frame != c.frame {
+			t.Errorf("%v: frame = %v, want %v", c.name, g.Frame, c.frame)
+		}
+		for i, want := range c.frames {
+			if gs[i].Frame != want {
+				t.Errorf("%v: child %v frame = %v, want %v", c.name, i, gs[i].Frame, want)
+			}
+		}
+	}
+}
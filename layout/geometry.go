@@ -25,6 +25,17 @@ const (
 	EdgeRight
 )
 
+// Direction is the order that views are laid out in horizontally.
+type Direction int
+
+const (
+	// DirectionAuto follows the language of the system's locale, see
+	// application.IsRightToLeft.
+	DirectionAuto Direction = iota
+	DirectionLeftToRight
+	DirectionRightToLeft
+)
+
 // Rect represents a 2D rectangle with the top left corner at Min and the bottom
 // right corner at Max.
 type Rect struct {
@@ -60,6 +71,16 @@ func (r Rect) Add(p Point) Rect {
 	return n
 }
 
+// Inset returns r with its edges moved inwards by i.
+func (r Rect) Inset(i Insets) Rect {
+	return Rt(r.Min.X+i.Left, r.Min.Y+i.Top, r.Max.X-i.Right, r.Max.Y-i.Bottom)
+}
+
+// Mirror returns r flipped horizontally within a space that is width wide.
+func (r Rect) Mirror(width float64) Rect {
+	return Rt(width-r.Max.X, r.Min.Y, width-r.Min.X, r.Max.Y)
+}
+
 // String returns a string description of r.
 func (r Rect) String() string {
 	return fmt.Sprintf("Rect{%v, %v, %v, %v}", r.Min.X, r.Min.Y, r.Max.X, r.Max.Y)
@@ -95,3 +116,8 @@ type PointNotifier interface {
 	comm.Notifier
 	Value() Point
 }
+
+// Insets represents distances inwards from the edges of a rectangle.
+type Insets struct {
+	Top, Left, Bottom, Right float64
+}
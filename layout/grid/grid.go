@@ -0,0 +1,337 @@
+/*
+Package grid implements a grid layout system. Views are placed in the cells of
+columns and rows, and may span several of them.
+
+ l := &grid.Layouter{
+ 	Columns:   []grid.Track{grid.Fixed(80), grid.Fraction(1), grid.Fraction(2)},
+ 	ColumnGap: 10,
+ 	RowGap:    10,
+ }
+
+ l.Add(headerView, func(c *grid.Cell) {
+ 	c.ColumnSpan = 3
+ })
+ l.Add(iconView, nil) // Placed in the next free cell.
+ l.Add(chartView, func(c *grid.Cell) {
+ 	c.ColumnSpan = 2
+ 	c.RowSpan = 2
+ })
+
+ return view.Model{
+ 	Children: l.Views(),
+ 	Layouter: l,
+ }
+
+Views fill the area of their cells. Rows that aren't listed in Rows are sized
+by AutoRows, which fits their content by default.
+*/
+package grid
+
+import (
+	"math"
+
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/view"
+)
+
+// Auto places a cell in the next free column or row when it is used as its
+// Column or Row. Any negative value does the same.
+const Auto = -1
+
+type trackKind int
+
+const (
+	trackFixed trackKind = iota
+	trackFlexible
+	trackFraction
+)
+
+// Track is the size of a column or a row.
+type Track struct {
+	kind     trackKind
+	size     float64
+	min, max float64
+}
+
+// Fixed returns a track of size points.
+func Fixed(size float64) Track {
+	return Track{kind: trackFixed, size: size}
+}
+
+// Flexible returns a track that fits the views in it, within min and max
+// points. Views that span several tracks aren't measured.
+func Flexible(min, max float64) Track {
+	return Track{kind: trackFlexible, min: min, max: max}
+}
+
+// Content returns a track that fits the views in it.
+func Content() Track {
+	return Flexible(0, math.Inf(1))
+}
+
+// Fraction returns a track that takes a share of the space left by the other
+// tracks, in proportion to f and the fractions of those tracks. If the size of
+// the layouter along the tracks is unbounded, fractional tracks fit their
+// views like Content.
+func Fraction(f float64) Track {
+	return Track{kind: trackFraction, size: f}
+}
+
+// Cell holds where a view is placed in a Layouter.
+type Cell struct {
+	// Column and Row are the indexes of the first column and row of the cell.
+	// They default to Auto, which places the cell after the previous ones,
+	// from left to right and top to bottom.
+	Column int
+	Row    int
+	// ColumnSpan and RowSpan are the number of columns and rows of the cell.
+	// They default to 1.
+	ColumnSpan int
+	RowSpan    int
+}
+
+// Layouter places views in a grid.
+type Layouter struct {
+	// Columns are the columns of the grid. If it is empty, the grid has a
+	// single column that fills the layouter.
+	Columns []Track
+	// Rows are the first rows of the grid. Further rows are sized by AutoRows.
+	Rows []Track
+	// AutoRows is the size of rows that aren't listed in Rows. It defaults to
+	// Content.
+	AutoRows *Track
+	// ColumnGap and RowGap are the space between columns and rows.
+	ColumnGap float64
+	RowGap    float64
+	cells     []*Cell
+	views     []view.View
+}
+
+// Add adds v to the layouter. f is called to adjust the cell that v is placed
+// in, and may be nil.
+func (l *Layouter) Add(v view.View, f func(*Cell)) {
+	c := &Cell{Column: Auto, Row: Auto, ColumnSpan: 1, RowSpan: 1}
+	if f != nil {
+		f(c)
+	}
+	l.cells = append(l.cells, c)
+	l.views = append(l.views, v)
+}
+
+// Views returns all views that have been added to l.
+func (l *Layouter) Views() []view.View {
+	return l.views
+}
+
+// Notify implements the view.Layouter interface.
+func (l *Layouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+// Unnotify implements the view.Layouter interface.
+func (l *Layouter) Unnotify(id comm.Id) {
+	// no-op
+}
+
+// area is the columns and rows that a cell covers.
+type area struct {
+	col, row         int
+	colSpan, rowSpan int
+}
+
+// place returns the area of each cell. Cells with a column and a row are
+// placed first, and the others fill the free cells around them.
+func place(cells []*Cell, columns int) []area {
+	areas := make([]area, len(cells))
+	taken := map[[2]int]bool{}
+	free := func(a area) bool {
+		for r := a.row; r < a.row+a.rowSpan; r++ {
+			for c := a.col; c < a.col+a.colSpan; c++ {
+				if taken[[2]int{r, c}] {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	take := func(a area) {
+		for r := a.row; r < a.row+a.rowSpan; r++ {
+			for c := a.col; c < a.col+a.colSpan; c++ {
+				taken[[2]int{r, c}] = true
+			}
+		}
+	}
+	for i, c := range cells {
+		a := area{col: c.Column, row: c.Row, colSpan: c.ColumnSpan, rowSpan: c.RowSpan}
+		if a.colSpan < 1 {
+			a.colSpan = 1
+		}
+		if a.colSpan > columns {
+			a.colSpan = columns
+		}
+		if a.rowSpan < 1 {
+			a.rowSpan = 1
+		}
+		if a.col >= 0 && a.col+a.colSpan > columns {
+			a.col = columns - a.colSpan
+		}
+		areas[i] = a
+		if a.col >= 0 && a.row >= 0 {
+			take(a)
+		}
+	}
+
+	cursorRow, cursorCol := 0, 0
+	for i, a := range areas {
+		if a.col >= 0 && a.row >= 0 {
+			continue
+		}
+		switch {
+		case a.col >= 0:
+			// The column is fixed, so the first free row is used.
+			for a.row = 0; !free(a); a.row++ {
+			}
+		case a.row >= 0:
+			for a.col = 0; a.col+a.colSpan <= columns && !free(a); a.col++ {
+			}
+			if a.col+a.colSpan > columns {
+				// The row is full, so the cell overlaps its start.
+				a.col = 0
+			}
+		default:
+			a.row, a.col = cursorRow, cursorCol
+			for {
+				if a.col+a.colSpan > columns {
+					a.row++
+					a.col = 0
+				}
+				if free(a) {
+					break
+				}
+				a.col++
+			}
+			cursorRow, cursorCol = a.row, a.col+a.colSpan
+		}
+		take(a)
+		areas[i] = a
+	}
+	return areas
+}
+
+// Layout implements the view.Layouter interface.
+func (l *Layouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	count := len(l.cells)
+	if ctx.ChildCount() < count {
+		count = ctx.ChildCount()
+	}
+	columns := l.Columns
+	if len(columns) == 0 {
+		columns = []Track{Fraction(1)}
+	}
+	areas := place(l.cells[:count], len(columns))
+
+	rows := append([]Track(nil), l.Rows...)
+	autoRow := Content()
+	if l.AutoRows != nil {
+		autoRow = *l.AutoRows
+	}
+	for _, a := range areas {
+		for len(rows) < a.row+a.rowSpan {
+			rows = append(rows, autoRow)
+		}
+	}
+
+	// Columns are measured with unbounded heights, and rows with the widths
+	// of their cells.
+	widths := l.sizeTracks(columns, l.ColumnGap, ctx.MinSize().X, ctx.MaxSize().X, func(i int) (int, int) {
+		return areas[i].col, areas[i].colSpan
+	}, func(i int, max float64) float64 {
+		return ctx.LayoutChild(i, layout.Pt(0, 0), layout.Pt(max, math.Inf(1))).Width()
+	}, count)
+	xs := offsets(widths, l.ColumnGap)
+
+	heights := l.sizeTracks(rows, l.RowGap, ctx.MinSize().Y, ctx.MaxSize().Y, func(i int) (int, int) {
+		return areas[i].row, areas[i].rowSpan
+	}, func(i int, max float64) float64 {
+		a := areas[i]
+		w := xs[a.col+a.colSpan] - xs[a.col] - l.ColumnGap
+		return ctx.LayoutChild(i, layout.Pt(w, 0), layout.Pt(w, max)).Height()
+	}, count)
+	ys := offsets(heights, l.RowGap)
+
+	gs := make([]layout.Guide, count)
+	for i, a := range areas {
+		frame := layout.Rt(xs[a.col], ys[a.row], xs[a.col+a.colSpan]-l.ColumnGap, ys[a.row+a.rowSpan]-l.RowGap)
+		size := layout.Pt(frame.Max.X-frame.Min.X, frame.Max.Y-frame.Min.Y)
+		ctx.LayoutChild(i, size, size)
+		gs[i] = layout.Guide{Frame: frame, ZIndex: i}
+	}
+
+	width := clamp(xs[len(xs)-1]-l.ColumnGap, ctx.MinSize().X, ctx.MaxSize().X)
+	height := clamp(ys[len(ys)-1]-l.RowGap, ctx.MinSize().Y, ctx.MaxSize().Y)
+	if len(rows) == 0 {
+		height = ctx.MinSize().Y
+	}
+	return layout.Guide{Frame: layout.Rt(0, 0, width, height)}, gs
+}
+
+// sizeTracks returns the sizes of tracks. span returns the first track and
+// number of tracks of a cell, and measure returns the size of a cell that is
+// at most max.
+func (l *Layouter) sizeTracks(tracks []Track, gap, min, max float64, span func(int) (int, int), measure func(int, float64) float64, count int) []float64 {
+	sizes := make([]float64, len(tracks))
+	space := max
+	if math.IsInf(space, 1) {
+		space = min
+	}
+	space -= gap * float64(len(tracks)-1)
+
+	fractions := 0.0
+	fill := !math.IsInf(max, 1)
+	for i, t := range tracks {
+		switch {
+		case t.kind == trackFixed:
+			sizes[i] = t.size
+		case t.kind == trackFlexible || (t.kind == trackFraction && !fill):
+			tmin, tmax := t.min, t.max
+			if t.kind == trackFraction {
+				tmin, tmax = 0, math.Inf(1)
+			}
+			size := 0.0
+			for c := 0; c < count; c++ {
+				if first, n := span(c); first == i && n == 1 {
+					size = math.Max(size, measure(c, tmax))
+				}
+			}
+			sizes[i] = clamp(size, tmin, tmax)
+		default:
+			fractions += t.size
+			continue
+		}
+		space -= sizes[i]
+	}
+	if fill && fractions > 0 {
+		space = math.Max(space, 0)
+		for i, t := range tracks {
+			if t.kind == trackFraction {
+				sizes[i] = space * t.size / fractions
+			}
+		}
+	}
+	return sizes
+}
+
+// offsets returns the start of each track, followed by the end of the last
+// one. Each track ends gap before the start of the next one.
+func offsets(sizes []float64, gap float64) []float64 {
+	o := make([]float64, len(sizes)+1)
+	for i, s := range sizes {
+		o[i+1] = o[i] + s + gap
+	}
+	return o
+}
+
+func clamp(v, min, max float64) float64 {
+	return math.Max(math.Min(v, max), min)
+}
@@ -0,0 +1,90 @@
+package grid
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/layout/layouttest"
+)
+
+func TestPlace(t *testing.T) {
+	cells := []*Cell{
+		{Column: 1, Row: 0, ColumnSpan: 2, RowSpan: 1},
+		{Column: Auto, Row: Auto, ColumnSpan: 1, RowSpan: 2},
+		{Column: Auto, Row: Auto, ColumnSpan: 1, RowSpan: 1},
+		{Column: Auto, Row: Auto, ColumnSpan: 3, RowSpan: 1},
+		{Column: 2, Row: Auto, ColumnSpan: 1, RowSpan: 1},
+	}
+	got := place(cells, 3)
+	want := []area{
+		{col: 1, row: 0, colSpan: 2, rowSpan: 1},
+		{col: 0, row: 0, colSpan: 1, rowSpan: 2},
+		{col: 1, row: 1, colSpan: 1, rowSpan: 1},
+		{col: 0, row: 2, colSpan: 3, rowSpan: 1},
+		{col: 2, row: 1, colSpan: 1, rowSpan: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("place() = %v, want %v", got, want)
+	}
+}
+
+func TestLayout(t *testing.T) {
+	l := &Layouter{
+		Columns:   []Track{Fixed(20), Fraction(2), Fraction(5)},
+		Rows:      []Track{Fixed(30)},
+		ColumnGap: 10,
+		RowGap:    5,
+	}
+	l.Add(nil, func(c *Cell) { c.ColumnSpan = 3 })
+	l.Add(nil, nil)
+	l.Add(nil, func(c *Cell) { c.ColumnSpan = 2 })
+	l.Add(nil, nil)
+
+	ctx := &layouttest.Context{
+		Min:   layout.Pt(110, 0),
+		Max:   layout.Pt(110, math.Inf(1)),
+		Sizes: []layout.Point{{}, {X: 5, Y: 40}, {X: 5, Y: 15}, {X: 5, Y: 25}},
+	}
+	g, gs := l.Layout(ctx)
+	// The fractions share 110 - 20 - 2*10 = 70 points, and the second row
+	// fits the tallest view in it.
+	if want := layout.Rt(0, 0, 110, 105); g.Frame != want {
+		t.Errorf("frame = %v, want %v", g.Frame, want)
+	}
+	want := []layout.Rect{
+		layout.Rt(0, 0, 110, 30),
+		layout.Rt(0, 35, 20, 75),
+		layout.Rt(30, 35, 110, 75),
+		layout.Rt(0, 80, 20, 105),
+	}
+	for i, w := range want {
+		if gs[i].Frame != w {
+			t.Errorf("child %v frame = %v, want %v", i, gs[i].Frame, w)
+		}
+	}
+}
+
+func TestFlexibleColumns(t *testing.T) {
+	l := &Layouter{
+		Columns: []Track{Flexible(10, 30), Content(), Fraction(1)},
+	}
+	l.Add(nil, nil)
+	l.Add(nil, nil)
+	l.Add(nil, nil)
+
+	ctx := &layouttest.Context{
+		Min:   layout.Pt(0, 0),
+		Max:   layout.Pt(math.Inf(1), math.Inf(1)),
+		Sizes: []layout.Point{{X: 50, Y: 10}, {X: 15, Y: 10}, {X: 25, Y: 10}},
+	}
+	g, gs := l.Layout(ctx)
+	// Fractions fit their content when the width is unbounded.
+	if want := layout.Rt(0, 0, 70, 10); g.Frame != want {
+		t.Errorf("frame = %v, want %v", g.Frame, want)
+	}
+	if want := layout.Rt(30, 0, 45, 10); gs[1].Frame != want {
+		t.Errorf("child 1 frame = %v, want %v", gs[1].Frame, want)
+	}
+}
@@ -29,10 +29,24 @@ type Context interface {
 	LayoutChild(idx int, minSize, maxSize Point) Guide
 }
 
+// IntrinsicContext is implemented by Contexts that can measure the content of
+// their children. See view.IntrinsicSizer.
+type IntrinsicContext interface {
+	Context
+	// ChildIntrinsicSize returns the intrinsic size of the child at idx laid
+	// out within max, and false if the child has none.
+	ChildIntrinsicSize(idx int, max Point) (Point, bool)
+}
+
 // Guide represents the position of a view.
 type Guide struct {
 	Frame  Rect
 	ZIndex int
+	// FirstBaseline and LastBaseline are the distances from the top of Frame to
+	// the baselines of the first and last lines of text in the view. They are 0
+	// if the view has no text.
+	FirstBaseline float64
+	LastBaseline  float64
 }
 
 // MarshalProtobuf serializes g into a protobuf object.
@@ -0,0 +1,45 @@
+// Package layouttest provides utilities for testing layouters.
+package layouttest
+
+import (
+	"math"
+
+	"gomatcha.io/matcha/layout"
+)
+
+// Context is a layout.Context whose children have an intrinsic size, and are
+// sized to it within the limits they are laid out with.
+//
+//  ctx := &layouttest.Context{
+//      Min:   layout.Pt(100, 0),
+//      Max:   layout.Pt(100, math.Inf(1)),
+//      Sizes: []layout.Point{{X: 20, Y: 30}},
+//  }
+//  g, gs := l.Layout(ctx)
+type Context struct {
+	Min, Max  layout.Point
+	Sizes     []layout.Point // Intrinsic sizes of the children.
+	Baselines []float64      // Baselines of the children, 0 if not set.
+}
+
+// MinSize implements the layout.Context interface.
+func (c *Context) MinSize() layout.Point { return c.Min }
+
+// MaxSize implements the layout.Context interface.
+func (c *Context) MaxSize() layout.Point { return c.Max }
+
+// ChildCount implements the layout.Context interface.
+func (c *Context) ChildCount() int { return len(c.Sizes) }
+
+// LayoutChild implements the layout.Context interface.
+func (c *Context) LayoutChild(idx int, min, max layout.Point) layout.Guide {
+	s := c.Sizes[idx]
+	w := math.Max(math.Min(s.X, max.X), min.X)
+	h := math.Max(math.Min(s.Y, max.Y), min.Y)
+	g := layout.Guide{Frame: layout.Rt(0, 0, w, h)}
+	if idx < len(c.Baselines) {
+		g.FirstBaseline = c.Baselines[idx]
+		g.LastBaseline = c.Baselines[idx]
+	}
+	return g
+}
@@ -0,0 +1,241 @@
+/*
+Package stack implements a layout system that places views one after another in
+a row or a column, with spacing between them.
+
+ l := &stack.Layouter{
+ 	Axis:      layout.AxisX,
+ 	Spacing:   8,
+ 	Alignment: stack.AlignCenter,
+ }
+
+ l.Add(iconView, nil)
+ l.Add(titleView, func(i *stack.Item) {
+ 	i.Weight = 1 // Takes the space left by the other views.
+ })
+ l.Add(buttonView, nil)
+
+ return view.Model{
+ 	Children: l.Views(),
+ 	Layouter: l,
+ }
+
+Views without a weight are sized by their own layouters, and are at least their
+intrinsic size, see view.IntrinsicSizer. The stack is as long as its views,
+within the minimum and maximum size of its view, and views with a weight share
+the space left in the minimum size.
+*/
+package stack
+
+import (
+	"math"
+
+	"gomatcha.io/matcha/application"
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/view"
+)
+
+// Alignment is how views are placed across the axis of the stack.
+type Alignment int
+
+const (
+	// AlignAuto uses the layouter's Alignment for an item. As the layouter's
+	// Alignment it fills the stack.
+	AlignAuto Alignment = iota
+	// AlignFill sizes views to the width of a column, or the height of a row.
+	AlignFill
+	// AlignStart places views at the leading edge of a column, or the top of
+	// a row.
+	AlignStart
+	AlignCenter
+	// AlignEnd places views at the trailing edge of a column, or the bottom of
+	// a row.
+	AlignEnd
+)
+
+// Item holds how a view is sized and placed by a Layouter.
+type Item struct {
+	// Weight is the share of the space left by the other views that the view
+	// takes. Views with a weight ignore their own size along the axis.
+	Weight float64
+	// Alignment overrides the layouter's Alignment for this view.
+	Alignment Alignment
+}
+
+// Layouter places views along a row or a column.
+type Layouter struct {
+	// Axis is the direction that views are placed in. Views are placed from
+	// the leading edge along layout.AxisX, and from top to bottom otherwise.
+	Axis layout.Axis
+	// Direction is the direction of the leading and trailing edges. It follows
+	// the system's locale by default, so rows are placed from right to left in
+	// right to left languages.
+	Direction layout.Direction
+	// Spacing is the space between views.
+	Spacing   float64
+	Alignment Alignment
+	items     []*Item
+	views     []view.View
+}
+
+// Add adds v to the layouter. f is called to adjust the item that sizes and
+// places v, and may be nil.
+func (l *Layouter) Add(v view.View, f func(*Item)) {
+	i := &Item{}
+	if f != nil {
+		f(i)
+	}
+	l.items = append(l.items, i)
+	l.views = append(l.views, v)
+}
+
+// AddSpacer adds an empty view with weight, which pushes apart the views
+// around it.
+func (l *Layouter) AddSpacer(weight float64) {
+	l.Add(view.NewBasicView(), func(i *Item) {
+		i.Weight = weight
+	})
+}
+
+// Views returns all views that have been added to l.
+func (l *Layouter) Views() []view.View {
+	return l.views
+}
+
+// Notify implements the view.Layouter interface.
+func (l *Layouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+// Unnotify implements the view.Layouter interface.
+func (l *Layouter) Unnotify(id comm.Id) {
+	// no-op
+}
+
+// Layout implements the view.Layouter interface.
+func (l *Layouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	row := l.Axis == layout.AxisX
+	main := func(p layout.Point) float64 {
+		if row {
+			return p.X
+		}
+		return p.Y
+	}
+	cross := func(p layout.Point) float64 {
+		if row {
+			return p.Y
+		}
+		return p.X
+	}
+	pt := func(main, cross float64) layout.Point {
+		if row {
+			return layout.Pt(main, cross)
+		}
+		return layout.Pt(cross, main)
+	}
+	size := func(g layout.Guide) layout.Point {
+		return layout.Pt(g.Width(), g.Height())
+	}
+
+	count := len(l.items)
+	if ctx.ChildCount() < count {
+		count = ctx.ChildCount()
+	}
+	aligns := make([]Alignment, count)
+	mains := make([]float64, count)
+	crosses := make([]float64, count)
+	minCross, maxCross := cross(ctx.MinSize()), cross(ctx.MaxSize())
+
+	// Measure the views without weights. Filled views take the cross size of
+	// the stack, which fits the other views.
+	used := l.Spacing * math.Max(float64(count-1), 0)
+	weights := 0.0
+	containerCross := minCross
+	for i := 0; i < count; i++ {
+		aligns[i] = l.items[i].Alignment
+		if aligns[i] == AlignAuto {
+			aligns[i] = l.Alignment
+		}
+		if aligns[i] == AlignAuto {
+			aligns[i] = AlignFill
+		}
+		if l.items[i].Weight > 0 {
+			weights += l.items[i].Weight
+			continue
+		}
+		if aligns[i] != AlignFill {
+			in := intrinsicSize(ctx, i, pt(math.Inf(1), maxCross))
+			s := size(ctx.LayoutChild(i, pt(main(in), math.Min(cross(in), maxCross)), pt(math.Inf(1), maxCross)))
+			mains[i], crosses[i] = main(s), cross(s)
+			containerCross = math.Max(containerCross, crosses[i])
+		}
+	}
+	containerCross = math.Min(containerCross, maxCross)
+	for i := 0; i < count; i++ {
+		if l.items[i].Weight > 0 {
+			continue
+		}
+		if aligns[i] == AlignFill {
+			in := intrinsicSize(ctx, i, pt(math.Inf(1), containerCross))
+			s := size(ctx.LayoutChild(i, pt(main(in), containerCross), pt(math.Inf(1), containerCross)))
+			mains[i] = main(s)
+		}
+		used += mains[i]
+	}
+
+	// Share the space that is left between the views with weights.
+	containerMain := math.Max(math.Min(used, main(ctx.MaxSize())), main(ctx.MinSize()))
+	left := math.Max(containerMain-used, 0)
+	for i := 0; i < count; i++ {
+		if w := l.items[i].Weight; w > 0 {
+			mains[i] = left * w / weights
+		}
+	}
+
+	gs := make([]layout.Guide, count)
+	pos := 0.0
+	for i := 0; i < count; i++ {
+		switch {
+		case aligns[i] == AlignFill:
+			crosses[i] = containerCross
+			ctx.LayoutChild(i, pt(mains[i], containerCross), pt(mains[i], containerCross))
+		case l.items[i].Weight > 0:
+			crosses[i] = cross(size(ctx.LayoutChild(i, pt(mains[i], 0), pt(mains[i], containerCross))))
+		default:
+			ctx.LayoutChild(i, pt(mains[i], crosses[i]), pt(mains[i], crosses[i]))
+		}
+
+		crossPos := 0.0
+		switch aligns[i] {
+		case AlignCenter:
+			crossPos = (containerCross - crosses[i]) / 2
+		case AlignEnd:
+			crossPos = containerCross - crosses[i]
+		}
+		origin := pt(pos, crossPos)
+		s := pt(mains[i], crosses[i])
+		gs[i] = layout.Guide{
+			Frame:  layout.Rt(origin.X, origin.Y, origin.X+s.X, origin.Y+s.Y),
+			ZIndex: i,
+		}
+		pos += mains[i] + l.Spacing
+	}
+	s := pt(containerMain, containerCross)
+	if application.IsRightToLeft(l.Direction) {
+		for i := range gs {
+			gs[i].Frame = gs[i].Frame.Mirror(s.X)
+		}
+	}
+	return layout.Guide{Frame: layout.Rt(0, 0, s.X, s.Y)}, gs
+}
+
+// intrinsicSize returns the intrinsic size of the child at idx within max, or
+// zero if it has none.
+func intrinsicSize(ctx layout.Context, idx int, max layout.Point) layout.Point {
+	if c, ok := ctx.(layout.IntrinsicContext); ok {
+		if s, ok := c.ChildIntrinsicSize(idx, max); ok {
+			return s
+		}
+	}
+	return layout.Point{}
+}
@@ -0,0 +1,114 @@
+package stack
+
+import (
+	"math"
+	"testing"
+
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/layout/layouttest"
+)
+
+func TestColumn(t *testing.T) {
+	l := &Layouter{Spacing: 10}
+	l.Add(nil, nil)
+	l.Add(nil, func(i *Item) { i.Alignment = AlignCenter })
+	l.Add(nil, func(i *Item) { i.Alignment = AlignEnd })
+
+	ctx := &layouttest.Context{
+		Min:   layout.Pt(100, 0),
+		Max:   layout.Pt(100, math.Inf(1)),
+		Sizes: []layout.Point{{X: 20, Y: 30}, {X: 20, Y: 10}, {X: 40, Y: 20}},
+	}
+	g, gs := l.Layout(ctx)
+	if want := layout.Rt(0, 0, 100, 80); g.Frame != want {
+		t.Errorf("frame = %v, want %v", g.Frame, want)
+	}
+	want := []layout.Rect{
+		layout.Rt(0, 0, 100, 30),
+		layout.Rt(40, 40, 60, 50),
+		layout.Rt(60, 60, 100, 80),
+	}
+	for i, w := range want {
+		if gs[i].Frame != w {
+			t.Errorf("child %v frame = %v, want %v", i, gs[i].Frame, w)
+		}
+	}
+}
+
+func TestRowWeights(t *testing.T) {
+	l := &Layouter{Axis: layout.AxisX, Spacing: 10, Alignment: AlignStart}
+	l.Add(nil, nil)
+	l.Add(nil, func(i *Item) { i.Weight = 1 })
+	l.Add(nil, func(i *Item) { i.Weight = 3 })
+
+	ctx := &layouttest.Context{
+		Min:   layout.Pt(200, 0),
+		Max:   layout.Pt(200, 50),
+		Sizes: []layout.Point{{X: 40, Y: 30}, {X: 500, Y: 10}, {X: 0, Y: 20}},
+	}
+	g, gs := l.Layout(ctx)
+	// The weighted views share 200 - 40 - 2*10 = 140 points.
+	if want := layout.Rt(0, 0, 200, 30); g.Frame != want {
+		t.Errorf("frame = %v, want %v", g.Frame, want)
+	}
+	want := []layout.Rect{
+		layout.Rt(0, 0, 40, 30),
+		layout.Rt(50, 0, 85, 10),
+		layout.Rt(95, 0, 200, 20),
+	}
+	for i, w := range want {
+		if gs[i].Frame != w {
+			t.Errorf("child %v frame = %v, want %v", i, gs[i].Frame, w)
+		}
+	}
+}
+
+func TestRightToLeft(t *testing.T) {
+	l := &Layouter{Axis: layout.AxisX, Direction: layout.DirectionRightToLeft, Spacing: 10, Alignment: AlignStart}
+	l.Add(nil, nil)
+	l.Add(nil, nil)
+
+	ctx := &layouttest.Context{
+		Min:   layout.Pt(200, 0),
+		Max:   layout.Pt(200, 50),
+		Sizes: []layout.Point{{X: 40, Y: 30}, {X: 20, Y: 10}},
+	}
+	_, gs := l.Layout(ctx)
+	want := []layout.Rect{
+		layout.Rt(160, 0, 200, 30),
+		layout.Rt(130, 0, 150, 10),
+	}
+	for i, w := range want {
+		if gs[i].Frame != w {
+			t.Errorf("child %v frame = %v, want %v", i, gs[i].Frame, w)
+		}
+	}
+}
+
+// intrinsicContext is a layouttest.Context that implements layout.IntrinsicContext.
+type intrinsicContext struct {
+	layouttest.Context
+	intrinsic []layout.Point
+}
+
+func (c *intrinsicContext) ChildIntrinsicSize(idx int, max layout.Point) (layout.Point, bool) {
+	return c.intrinsic[idx], true
+}
+
+func TestIntrinsicSize(t *testing.T) {
+	l := &Layouter{Alignment: AlignStart}
+	l.Add(nil, nil)
+
+	ctx := &intrinsicContext{
+		Context: layouttest.Context{
+			Min:   layout.Pt(0, 0),
+			Max:   layout.Pt(100, math.Inf(1)),
+			Sizes: []layout.Point{{}},
+		},
+		intrinsic: []layout.Point{{X: 40, Y: 30}},
+	}
+	_, gs := l.Layout(ctx)
+	if want := layout.Rt(0, 0, 40, 30); gs[0].Frame != want {
+		t.Errorf("frame = %v, want %v", gs[0].Frame, want)
+	}
+}
@@ -0,0 +1,105 @@
+// +build !matcha
+
+package matchatest
+
+import (
+	"fmt"
+	"sync"
+
+	"gomatcha.io/matcha/bridge"
+)
+
+// BridgeCall records a call made on bridge.Bridge().
+type BridgeCall struct {
+	Bridge   string
+	Selector string
+	Args     []*bridge.Value
+
+	// Batch numbers the bridge.Batch flush that sent the call, starting at 1.
+	// It is 0 for calls that weren't queued.
+	Batch int
+}
+
+// Bridge fakes native services reached through bridge.Bridge(). Calls with a
+// registered handler return the handler's result, all other calls return nil.
+//
+//  b := matchatest.NewBridge()
+//  defer b.Close()
+//  b.Handle("openURL:", func(args []*bridge.Value) *bridge.Value {
+//      return bridge.Bool(true)
+//  })
+type Bridge struct {
+	mu       sync.Mutex
+	handlers map[string]func([]*bridge.Value) *bridge.Value
+	calls    []BridgeCall
+	prev     func(string, string, []*bridge.Value) *bridge.Value
+	prevB    func(int)
+
+	batch   int // Number of the last flush.
+	batched int // Calls of the last flush not yet made.
+}
+
+// NewBridge creates a Bridge and installs it. Call Close to uninstall it.
+func NewBridge() *Bridge {
+	b := &Bridge{
+		handlers: map[string]func([]*bridge.Value) *bridge.Value{},
+		prev:     bridge.StubCall,
+		prevB:    bridge.StubBatch,
+	}
+	bridge.StubCall = b.call
+	bridge.StubBatch = b.flush
+	return b
+}
+
+// Close uninstalls b.
+func (b *Bridge) Close() {
+	bridge.StubCall = b.prev
+	bridge.StubBatch = b.prevB
+}
+
+// Handle registers f to be called for the selector s. Android and iOS
+// selectors may differ, so both may need to be registered.
+func (b *Bridge) Handle(s string, f func(args []*bridge.Value) *bridge.Value) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[s] = f
+}
+
+// Calls returns all calls made since b was created.
+func (b *Bridge) Calls() []BridgeCall {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return append([]BridgeCall(nil), b.calls...)
+}
+
+func (b *Bridge) call(name string, s string, args []*bridge.Value) *bridge.Value {
+	b.mu.Lock()
+	c := BridgeCall{Bridge: name, Selector: s, Args: args}
+	if b.batched > 0 {
+		c.Batch = b.batch
+		b.batched -= 1
+	}
+	b.calls = append(b.calls, c)
+	f, ok := b.handlers[s]
+	b.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+	return f(args)
+}
+
+func (b *Bridge) flush(n int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.batch += 1
+	b.batched = n
+}
+
+// String implements the fmt.Stringer interface.
+func (c BridgeCall) String() string {
+	return fmt.Sprintf("%v %v %v", c.Bridge, c.Selector, len(c.Args))
+}
@@ -0,0 +1,144 @@
+/*
+Package matchatest provides utilities for testing views and view models
+without a running app.
+
+Clock replaces the time source used by tickers, animations and the comm rate
+helpers, and drives screen updates. Bridge fakes native services that are
+reached through bridge.Bridge(), such as application.OpenURL. Scheduler
+queues the funcs passed to bridge.RunOnMain until the test runs them.
+
+Matcha does not wrap the clipboard or runtime permissions yet, so there are no
+fakes for them. Apps that call them through the bridge can fake them with
+Bridge.Handle.
+
+    func TestSearch(t *testing.T) {
+        clock := matchatest.NewClock()
+        defer clock.Close()
+
+        query := &comm.StringValue{}
+        ...
+        clock.Advance(time.Second)
+    }
+*/
+package matchatest
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"gomatcha.io/matcha/internal"
+	"gomatcha.io/matcha/internal/clock"
+)
+
+// FrameDuration is the time between screen updates emitted by Clock.Advance.
+const FrameDuration = time.Second / 60
+
+// Clock is a fake clock. Time only moves forward when Advance is called.
+type Clock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*timer
+	prev   clock.Clock
+}
+
+// NewClock creates a Clock and installs it as the time source for matcha.
+// Call Close to restore the system clock.
+func NewClock() *Clock {
+	c := &Clock{
+		now: time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+	c.prev = clock.Set(c)
+	return c
+}
+
+// Close restores the clock that was installed before c.
+func (c *Clock) Close() {
+	clock.Set(c.prev)
+}
+
+// Now returns the fake current time.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// AfterFunc calls f once c has been advanced by d. f is called synchronously from Advance.
+func (c *Clock) AfterFunc(d time.Duration, f func()) clock.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &timer{clock: c, when: c.now.Add(d), f: f}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+// Frame triggers a single screen update without advancing time. Animations
+// and views with pending changes are updated.
+func (c *Clock) Frame() {
+	internal.ScreenUpdate()
+}
+
+// Advance moves the clock forward by d in increments of FrameDuration. After
+// each increment any expired timers are fired and a screen update is triggered.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	end := c.now.Add(d)
+	c.mu.Unlock()
+
+	for {
+		c.mu.Lock()
+		next := c.now.Add(FrameDuration)
+		if next.After(end) {
+			next = end
+		}
+		c.now = next
+		c.mu.Unlock()
+
+		c.fire()
+		c.Frame()
+
+		if !next.Before(end) {
+			return
+		}
+	}
+}
+
+func (c *Clock) fire() {
+	for {
+		c.mu.Lock()
+		sort.SliceStable(c.timers, func(i, j int) bool {
+			return c.timers[i].when.Before(c.timers[j].when)
+		})
+		if len(c.timers) == 0 || c.timers[0].when.After(c.now) {
+			c.mu.Unlock()
+			return
+		}
+		t := c.timers[0]
+		c.timers = c.timers[1:]
+		c.mu.Unlock()
+
+		t.f()
+	}
+}
+
+type timer struct {
+	clock *Clock
+	when  time.Time
+	f     func()
+}
+
+func (t *timer) Stop() bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for idx, i := range c.timers {
+		if i == t {
+			c.timers = append(c.timers[:idx], c.timers[idx+1:]...)
+			return true
+		}
+	}
+	return false
+}
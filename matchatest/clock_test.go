@@ -0,0 +1,53 @@
+package matchatest
+
+import (
+	"testing"
+	"time"
+
+	"gomatcha.io/matcha/animate"
+	"gomatcha.io/matcha/comm"
+)
+
+func TestClockAnimation(t *testing.T) {
+	clock := NewClock()
+	defer clock.Close()
+
+	v := &animate.Value{}
+	v.Run(&animate.Basic{Start: 0, End: 100, Dur: time.Second})
+
+	clock.Advance(time.Second / 2)
+	if v.Value() != 50 {
+		t.Error("Unexpected value", v.Value())
+	}
+
+	clock.Advance(time.Second)
+	if v.Value() != 100 {
+		t.Error("Unexpected value", v.Value())
+	}
+}
+
+func TestClockDebounce(t *testing.T) {
+	clock := NewClock()
+	defer clock.Close()
+
+	value := &comm.IntValue{}
+	d := comm.Debounce(value, time.Second)
+	count := 0
+	id := d.Notify(func() {
+		count += 1
+	})
+	defer d.Unnotify(id)
+
+	value.SetValue(1)
+	clock.Advance(time.Second / 2)
+	value.SetValue(2)
+	clock.Advance(time.Second / 2)
+	if count != 0 {
+		t.Error("Debounce posted early", count)
+	}
+
+	clock.Advance(time.Second / 2)
+	if count != 1 {
+		t.Error("Debounce did not post", count)
+	}
+}
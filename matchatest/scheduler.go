@@ -0,0 +1,69 @@
+// +build !matcha
+
+package matchatest
+
+import (
+	"sync"
+
+	"gomatcha.io/matcha/bridge"
+)
+
+// Scheduler fakes the main thread. Funcs passed to bridge.RunOnMain are queued
+// until Run is called, so tests can check the state before and after they run.
+//
+//  s := matchatest.NewScheduler()
+//  defer s.Close()
+//  go load()
+//  ...
+//  s.Run()
+type Scheduler struct {
+	mu    sync.Mutex
+	funcs []func()
+	prev  func(func())
+}
+
+// NewScheduler creates a Scheduler and installs it. Call Close to uninstall it.
+func NewScheduler() *Scheduler {
+	s := &Scheduler{prev: bridge.StubRunOnMain}
+	bridge.StubRunOnMain = s.enqueue
+	return s
+}
+
+// Close uninstalls s. Queued funcs are dropped.
+func (s *Scheduler) Close() {
+	bridge.StubRunOnMain = s.prev
+}
+
+// Pending returns the number of queued funcs.
+func (s *Scheduler) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.funcs)
+}
+
+// Run calls the queued funcs in order, including any that they queue, until
+// none are left. It returns the number of funcs called.
+func (s *Scheduler) Run() int {
+	n := 0
+	for {
+		s.mu.Lock()
+		if len(s.funcs) == 0 {
+			s.mu.Unlock()
+			return n
+		}
+		f := s.funcs[0]
+		s.funcs = s.funcs[1:]
+		s.mu.Unlock()
+
+		f()
+		n += 1
+	}
+}
+
+func (s *Scheduler) enqueue(f func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.funcs = append(s.funcs, f)
+}
@@ -0,0 +1,35 @@
+// +build !matcha
+
+package matchatest
+
+import (
+	"testing"
+
+	"gomatcha.io/matcha/bridge"
+)
+
+func TestScheduler(t *testing.T) {
+	s := NewScheduler()
+	defer s.Close()
+
+	order := []int{}
+	bridge.RunOnMain(func() {
+		order = append(order, 1)
+		bridge.RunOnMain(func() {
+			order = append(order, 3)
+		})
+	})
+	bridge.RunOnMain(func() {
+		order = append(order, 2)
+	})
+	if len(order) != 0 || s.Pending() != 2 {
+		t.Fatal("expected funcs to be queued", order, s.Pending())
+	}
+
+	if n := s.Run(); n != 3 {
+		t.Error("Unexpected count", n)
+	}
+	if len(order) != 3 || order[0] != 1 || order[1] != 2 || order[2] != 3 {
+		t.Error("Unexpected order", order)
+	}
+}
@@ -0,0 +1,64 @@
+package paint
+
+import (
+	"image/color"
+
+	"gomatcha.io/matcha/layout"
+)
+
+// GradientType is the shape of a gradient.
+type GradientType int
+
+const (
+	GradientLinear GradientType = iota
+	GradientRadial
+)
+
+// GradientStop is a color of a gradient, at an offset between 0 and 1.
+type GradientStop struct {
+	Offset float64
+	Color  color.Color
+}
+
+// Gradient is a linear or radial gradient fill. It is drawn with
+// CAGradientLayer on iOS and GradientDrawable on Android.
+//
+//  &paint.Style{
+//      Gradient: paint.NewLinearGradient(90,
+//          paint.GradientStop{0, colornames.Orange},
+//          paint.GradientStop{1, colornames.Purple},
+//      ),
+//  }
+type Gradient struct {
+	Type  GradientType
+	Stops []GradientStop
+	// Angle is the direction of a linear gradient, in degrees. At 0 the
+	// gradient goes from top to bottom, and at 90 from left to right. Android
+	// rounds it to a multiple of 45.
+	Angle float64
+	// Center is the center of a radial gradient, relative to the view's size.
+	// The middle of the view is (0.5, 0.5).
+	Center layout.Point
+	// Radius is the radius of a radial gradient, relative to the view's width.
+	Radius float64
+}
+
+// NewLinearGradient returns a linear gradient with the given angle and stops.
+func NewLinearGradient(angle float64, stops ...GradientStop) *Gradient {
+	return &Gradient{
+		Type:  GradientLinear,
+		Stops: stops,
+		Angle: angle,
+	}
+}
+
+// NewRadialGradient returns a radial gradient in the middle of the view, which
+// reaches its left and right edges.
+func NewRadialGradient(stops ...GradientStop) *Gradient {
+	return &Gradient{
+		Type:   GradientRadial,
+		Stops:  stops,
+		Center: layout.Pt(0.5, 0.5),
+		Radius: 0.5,
+	}
+}
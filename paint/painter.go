@@ -35,10 +35,20 @@ type Style struct {
 	BorderWidth     float64
 	// CornerRadius is only supported for imageview on android.
 	CornerRadius float64
-	// Shadows are not supported on android. And do not work with corner radius on iOS (https://stackoverflow.com/q/11437750).
+	// The shadow is drawn if ShadowColor is set. Views with a shadow and a
+	// corner radius round their background and border, but don't clip their
+	// children, since that would also clip the shadow. On Android, shadows
+	// are drawn with the view's elevation, which is ShadowRadius. They follow
+	// the outline of the background, and ShadowOffset is ignored. ShadowColor
+	// is only supported on Android 9 and later.
 	ShadowRadius float64
 	ShadowOffset layout.Point
 	ShadowColor  color.Color
+	// ShadowTransparency is the transparency of the shadow, like Transparency
+	// is for the view. The shadow's opacity is 1 - ShadowTransparency.
+	ShadowTransparency float64
+	// Gradient, if set, is drawn instead of BackgroundColor.
+	Gradient *Gradient
 }
 
 func (s *Style) MarshalProtobuf() *paint.Style {
@@ -50,10 +60,25 @@ func (s *Style) MarshalProtobuf() *paint.Style {
 		CornerRadius:    s.CornerRadius,
 		ShadowRadius:    s.ShadowRadius,
 		ShadowOffset:    s.ShadowOffset.MarshalProtobuf(),
-		ShadowColor:     pb.ColorEncode(s.ShadowColor),
+		ShadowColor:     pb.ColorEncode(s.shadowColor()),
 	}
 }
 
+// shadowColor returns ShadowColor with its alpha scaled by the shadow's
+// opacity.
+func (s *Style) shadowColor() color.Color {
+	if s.ShadowColor == nil || s.ShadowTransparency == 0 {
+		return s.ShadowColor
+	}
+	opacity := 1 - s.ShadowTransparency
+	if opacity < 0 {
+		opacity = 0
+	}
+	c := color.NRGBAModel.Convert(s.ShadowColor).(color.NRGBA)
+	c.A = uint8(float64(c.A) * opacity)
+	return c
+}
+
 // PaintStyle implements the Painter interface.
 func (s *Style) PaintStyle() Style {
 	if s == nil {
@@ -79,15 +104,16 @@ type notifier struct {
 
 // AnimatedStyle is the animated version of Style.
 type AnimatedStyle struct {
-	Style           Style
-	Transparency    comm.Float64Notifier
-	BackgroundColor comm.ColorNotifier
-	BorderColor     comm.ColorNotifier
-	BorderWidth     comm.Float64Notifier
-	CornerRadius    comm.Float64Notifier
-	ShadowRadius    comm.Float64Notifier
-	ShadowOffset    layout.PointNotifier
-	ShadowColor     comm.ColorNotifier
+	Style              Style
+	Transparency       comm.Float64Notifier
+	BackgroundColor    comm.ColorNotifier
+	BorderColor        comm.ColorNotifier
+	BorderWidth        comm.Float64Notifier
+	CornerRadius       comm.Float64Notifier
+	ShadowRadius       comm.Float64Notifier
+	ShadowOffset       layout.PointNotifier
+	ShadowColor        comm.ColorNotifier
+	ShadowTransparency comm.Float64Notifier
 
 	maxId          comm.Id
 	groupNotifiers map[comm.Id]notifier
@@ -120,6 +146,9 @@ func (as *AnimatedStyle) PaintStyle() Style {
 	if as.ShadowColor != nil {
 		s.ShadowColor = as.ShadowColor.Value()
 	}
+	if as.ShadowTransparency != nil {
+		s.ShadowTransparency = as.ShadowTransparency.Value()
+	}
 	return s
 }
 
@@ -151,6 +180,9 @@ func (as *AnimatedStyle) Notify(f func()) comm.Id {
 	if as.ShadowColor != nil {
 		n.Subscribe(as.ShadowColor)
 	}
+	if as.ShadowTransparency != nil {
+		n.Subscribe(as.ShadowTransparency)
+	}
 
 	as.maxId += 1
 	if as.groupNotifiers == nil {
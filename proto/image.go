@@ -24,6 +24,15 @@ func ImageEncode(img image.Image) *Image {
 	}
 
 	bounds := img.Bounds()
+	if rgba, ok := img.(*image.RGBA); ok && bounds.Min == (image.Point{}) {
+		return &Image{
+			Width:  int64(bounds.Dx()),
+			Height: int64(bounds.Dy()),
+			Stride: int64(rgba.Stride),
+			Data:   rgba.Pix,
+		}
+	}
+
 	newImg := image.NewRGBA(bounds)
 	for x := bounds.Min.X; x < bounds.Max.X; x++ {
 		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
@@ -13,6 +13,7 @@ It has these top-level messages:
 	StyledText
 	Font
 	TextStyle
+	TextMetrics
 */
 package text
 
@@ -177,6 +178,30 @@ func (x Truncation) String() string {
 }
 func (Truncation) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
+type WritingDirection int32
+
+const (
+	WritingDirection_WRITING_DIRECTION_NATURAL       WritingDirection = 0
+	WritingDirection_WRITING_DIRECTION_LEFT_TO_RIGHT WritingDirection = 1
+	WritingDirection_WRITING_DIRECTION_RIGHT_TO_LEFT WritingDirection = 2
+)
+
+var WritingDirection_name = map[int32]string{
+	0: "WRITING_DIRECTION_NATURAL",
+	1: "WRITING_DIRECTION_LEFT_TO_RIGHT",
+	2: "WRITING_DIRECTION_RIGHT_TO_LEFT",
+}
+var WritingDirection_value = map[string]int32{
+	"WRITING_DIRECTION_NATURAL":       0,
+	"WRITING_DIRECTION_LEFT_TO_RIGHT": 1,
+	"WRITING_DIRECTION_RIGHT_TO_LEFT": 2,
+}
+
+func (x WritingDirection) String() string {
+	return proto.EnumName(WritingDirection_name, int32(x))
+}
+func (WritingDirection) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
 type SizeFunc struct {
 	Text    *StyledText          `protobuf:"bytes,1,opt,name=text" json:"text,omitempty"`
 	MinSize *matcha_layout.Point `protobuf:"bytes,2,opt,name=minSize" json:"minSize,omitempty"`
@@ -296,6 +321,11 @@ type TextStyle struct {
 	Wrap               TextWrap           `protobuf:"varint,22,opt,name=wrap,enum=matcha.text.TextWrap" json:"wrap,omitempty"`
 	Truncation         Truncation         `protobuf:"varint,24,opt,name=truncation,enum=matcha.text.Truncation" json:"truncation,omitempty"`
 	TruncationString   string             `protobuf:"bytes,26,opt,name=truncationString" json:"truncationString,omitempty"`
+	WritingDirection   WritingDirection   `protobuf:"varint,28,opt,name=writingDirection,enum=matcha.text.WritingDirection" json:"writingDirection,omitempty"`
+	LetterSpacing      float64            `protobuf:"fixed64,30,opt,name=letterSpacing" json:"letterSpacing,omitempty"`
+	LineHeight         float64            `protobuf:"fixed64,32,opt,name=lineHeight" json:"lineHeight,omitempty"`
+	ParagraphSpacing   float64            `protobuf:"fixed64,34,opt,name=paragraphSpacing" json:"paragraphSpacing,omitempty"`
+	FirstLineIndent    float64            `protobuf:"fixed64,36,opt,name=firstLineIndent" json:"firstLineIndent,omitempty"`
 }
 
 func (m *TextStyle) Reset()                    { *m = TextStyle{} }
@@ -401,74 +431,162 @@ func (m *TextStyle) GetTruncationString() string {
 	return ""
 }
 
+func (m *TextStyle) GetWritingDirection() WritingDirection {
+	if m != nil {
+		return m.WritingDirection
+	}
+	return WritingDirection_WRITING_DIRECTION_NATURAL
+}
+
+func (m *TextStyle) GetLetterSpacing() float64 {
+	if m != nil {
+		return m.LetterSpacing
+	}
+	return 0
+}
+
+func (m *TextStyle) GetLineHeight() float64 {
+	if m != nil {
+		return m.LineHeight
+	}
+	return 0
+}
+
+func (m *TextStyle) GetParagraphSpacing() float64 {
+	if m != nil {
+		return m.ParagraphSpacing
+	}
+	return 0
+}
+
+func (m *TextStyle) GetFirstLineIndent() float64 {
+	if m != nil {
+		return m.FirstLineIndent
+	}
+	return 0
+}
+type TextMetrics struct {
+	Width         float64 `protobuf:"fixed64,1,opt,name=width" json:"width,omitempty"`
+	Height        float64 `protobuf:"fixed64,2,opt,name=height" json:"height,omitempty"`
+	FirstBaseline float64 `protobuf:"fixed64,3,opt,name=firstBaseline" json:"firstBaseline,omitempty"`
+	LastBaseline  float64 `protobuf:"fixed64,4,opt,name=lastBaseline" json:"lastBaseline,omitempty"`
+}
+
+func (m *TextMetrics) Reset()                    { *m = TextMetrics{} }
+func (m *TextMetrics) String() string            { return proto.CompactTextString(m) }
+func (*TextMetrics) ProtoMessage()               {}
+func (*TextMetrics) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *TextMetrics) GetWidth() float64 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+func (m *TextMetrics) GetHeight() float64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *TextMetrics) GetFirstBaseline() float64 {
+	if m != nil {
+		return m.FirstBaseline
+	}
+	return 0
+}
+
+func (m *TextMetrics) GetLastBaseline() float64 {
+	if m != nil {
+		return m.LastBaseline
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*SizeFunc)(nil), "matcha.text.SizeFunc")
 	proto.RegisterType((*Text)(nil), "matcha.text.Text")
 	proto.RegisterType((*StyledText)(nil), "matcha.text.StyledText")
 	proto.RegisterType((*Font)(nil), "matcha.text.Font")
 	proto.RegisterType((*TextStyle)(nil), "matcha.text.TextStyle")
+	proto.RegisterType((*TextMetrics)(nil), "matcha.text.TextMetrics")
 	proto.RegisterEnum("matcha.text.TextAlignment", TextAlignment_name, TextAlignment_value)
 	proto.RegisterEnum("matcha.text.StrikethroughStyle", StrikethroughStyle_name, StrikethroughStyle_value)
 	proto.RegisterEnum("matcha.text.UnderlineStyle", UnderlineStyle_name, UnderlineStyle_value)
 	proto.RegisterEnum("matcha.text.TextWrap", TextWrap_name, TextWrap_value)
 	proto.RegisterEnum("matcha.text.Truncation", Truncation_name, Truncation_value)
+	proto.RegisterEnum("matcha.text.WritingDirection", WritingDirection_name, WritingDirection_value)
 }
 
 func init() { proto.RegisterFile("gomatcha.io/matcha/proto/text/text.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
 	// 833 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x55, 0xe1, 0x72, 0xea, 0x54,
-	0x10, 0xbe, 0x29, 0x14, 0xe9, 0xd6, 0x62, 0xee, 0x5e, 0xda, 0x9b, 0xa2, 0x57, 0x3b, 0x1d, 0x9d,
-	0xa9, 0x5c, 0x87, 0xce, 0xd4, 0x71, 0xf4, 0x8f, 0x33, 0x52, 0x72, 0x28, 0xb1, 0x34, 0x74, 0x0e,
-	0x87, 0xa9, 0xfa, 0xa7, 0x93, 0xd2, 0x14, 0x32, 0x42, 0xc2, 0xc0, 0x61, 0x04, 0x7d, 0x10, 0xff,
-	0xfb, 0x0e, 0x3e, 0x80, 0x8f, 0xe0, 0x1b, 0x39, 0x67, 0x93, 0x00, 0x09, 0xc1, 0x3f, 0xe4, 0xec,
-	0x7e, 0xdf, 0xee, 0x7e, 0x7b, 0x76, 0x43, 0xe0, 0x62, 0x10, 0x8c, 0x1d, 0xd9, 0x1f, 0x3a, 0x35,
-	0x2f, 0xb8, 0x0c, 0x4f, 0x97, 0x93, 0x69, 0x20, 0x83, 0x4b, 0xe9, 0x2e, 0x24, 0xfd, 0xd4, 0xc8,
-	0xc6, 0xc3, 0x88, 0xa7, 0x5c, 0x95, 0xaf, 0x76, 0x86, 0x8d, 0x9c, 0x65, 0x30, 0x97, 0xd1, 0x23,
-	0x0c, 0xad, 0x7c, 0xbe, 0x93, 0xed, 0x8d, 0x9d, 0x81, 0x1b, 0xb2, 0xce, 0xff, 0xd4, 0xa0, 0xd8,
-	0xf5, 0x7e, 0x77, 0x9b, 0x73, 0xbf, 0x8f, 0xef, 0x21, 0xaf, 0x0a, 0x19, 0xda, 0x99, 0x76, 0x71,
-	0x78, 0xf5, 0xb6, 0xb6, 0x51, 0xbc, 0xd6, 0x95, 0xcb, 0x91, 0xfb, 0x2c, 0xdc, 0x85, 0xe4, 0x44,
-	0xc2, 0x1a, 0x7c, 0x30, 0xf6, 0x7c, 0x15, 0x6b, 0xec, 0x11, 0xbf, 0x1c, 0xf3, 0x23, 0x19, 0xf7,
-	0x81, 0xe7, 0x4b, 0x1e, 0x93, 0x88, 0xef, 0x2c, 0x88, 0x9f, 0xfb, 0x5f, 0x7e, 0x48, 0x3a, 0xaf,
-	0x40, 0x5e, 0x55, 0x43, 0xdc, 0x10, 0x75, 0x10, 0xd6, 0x3e, 0xef, 0x03, 0xac, 0xf5, 0x60, 0x0d,
-	0x0a, 0x33, 0x65, 0xcd, 0x0c, 0xed, 0x2c, 0x77, 0x71, 0x78, 0x75, 0x92, 0x10, 0xae, 0x28, 0x44,
-	0xe6, 0x11, 0x0b, 0xbf, 0x88, 0x32, 0x86, 0xb2, 0x5f, 0x6f, 0xb1, 0xa3, 0x22, 0x4d, 0xc8, 0x37,
-	0x03, 0x5f, 0xe2, 0x09, 0x14, 0x5e, 0x9c, 0xb1, 0x37, 0x5a, 0x46, 0x12, 0x22, 0x4b, 0x09, 0x7b,
-	0x71, 0xfa, 0x61, 0xf7, 0x07, 0x9c, 0xce, 0xca, 0x37, 0x8b, 0x3b, 0xd4, 0x38, 0x9d, 0xcf, 0xff,
-	0xd9, 0x87, 0x83, 0x95, 0x08, 0x2c, 0xc3, 0xbe, 0xe7, 0x3f, 0xbb, 0x0b, 0x4a, 0x96, 0xe3, 0xa1,
-	0x81, 0x3f, 0xc0, 0x91, 0xaa, 0x59, 0x1f, 0x79, 0x03, 0x7f, 0xec, 0xfa, 0xa1, 0xb6, 0xd2, 0x55,
-	0x65, 0x4b, 0xdb, 0x8a, 0xc1, 0x93, 0x01, 0xd8, 0x01, 0x9c, 0xc9, 0xa9, 0xf7, 0xab, 0x2b, 0x87,
-	0xd3, 0x60, 0x3e, 0x18, 0x52, 0x35, 0x23, 0x4f, 0x69, 0x3e, 0x4b, 0x4d, 0x32, 0x4d, 0xe3, 0x19,
-	0xa1, 0xf8, 0x7d, 0x2a, 0x61, 0x23, 0x18, 0x05, 0x53, 0xa3, 0x40, 0x77, 0x76, 0x14, 0x27, 0x24,
-	0x27, 0xcf, 0x20, 0x62, 0x03, 0x4a, 0x73, 0xff, 0xd9, 0x9d, 0x8e, 0x3c, 0xdf, 0x0d, 0xb5, 0x14,
-	0x49, 0xcb, 0xc7, 0x09, 0x2d, 0xbd, 0x04, 0x85, 0xa7, 0x42, 0xf0, 0x9b, 0x8d, 0x24, 0x61, 0x7d,
-	0xc8, 0xaa, 0x9f, 0x22, 0xa9, 0x01, 0xbf, 0x04, 0xbe, 0x34, 0x3e, 0xcc, 0x18, 0xb0, 0x1a, 0x29,
-	0x27, 0x18, 0xcf, 0xe0, 0x70, 0xb8, 0x9c, 0x0c, 0x5d, 0xdf, 0x91, 0x5e, 0xe0, 0x1b, 0x25, 0x9a,
-	0xd9, 0xa6, 0x0b, 0x6b, 0x80, 0x2a, 0x6b, 0xcb, 0xf5, 0x06, 0x43, 0x79, 0x37, 0x1f, 0x49, 0x6f,
-	0x32, 0x72, 0x0d, 0x9d, 0x88, 0x19, 0x08, 0x56, 0xa0, 0x38, 0x76, 0x16, 0x6d, 0xcf, 0x77, 0x67,
-	0x06, 0xd2, 0x7c, 0x57, 0x36, 0xbe, 0x87, 0x03, 0x25, 0x20, 0x6c, 0xa3, 0x9c, 0xd5, 0xc6, 0x1a,
-	0xc7, 0x2f, 0x21, 0xff, 0xdb, 0xd4, 0x99, 0x18, 0x27, 0x74, 0x67, 0xc7, 0x5b, 0x6b, 0xf0, 0x30,
-	0x75, 0x26, 0x9c, 0x28, 0xf8, 0x2d, 0x80, 0x9c, 0xce, 0xfd, 0x7e, 0xd8, 0x84, 0x41, 0x01, 0xc9,
-	0x57, 0x57, 0xac, 0x60, 0xbe, 0x41, 0xc5, 0x2a, 0xe8, 0x6b, 0x4b, 0x2d, 0x85, 0x3f, 0x30, 0x2a,
-	0xb4, 0xcb, 0x5b, 0xfe, 0xea, 0x1f, 0x70, 0x94, 0xd8, 0x3e, 0x7c, 0x0b, 0x6f, 0x04, 0xfb, 0x49,
-	0x3c, 0xd6, 0xdb, 0xd6, 0x8d, 0x7d, 0xc7, 0x6c, 0xf1, 0xd8, 0x66, 0x4d, 0xa1, 0xbf, 0x42, 0x03,
-	0xca, 0x29, 0x80, 0x5b, 0x37, 0x2d, 0xa1, 0x6b, 0x78, 0x0a, 0xc7, 0x29, 0xa4, 0xc1, 0x6c, 0xc1,
-	0xb8, 0xbe, 0x87, 0x9f, 0x80, 0x91, 0x82, 0x7e, 0xec, 0x75, 0x85, 0xd5, 0xb4, 0x98, 0xa9, 0xe7,
-	0xaa, 0xff, 0x6a, 0x80, 0xdb, 0x4b, 0xab, 0x82, 0xba, 0x82, 0x5b, 0xb7, 0x4c, 0xb4, 0x78, 0xa7,
-	0x77, 0xd3, 0x7a, 0xec, 0x8a, 0x9f, 0xdb, 0xec, 0xd1, 0xee, 0xd8, 0x4c, 0x7f, 0x85, 0x9f, 0x42,
-	0x25, 0x0b, 0xed, 0x5a, 0xf6, 0x4d, 0x9b, 0xe9, 0xda, 0x2e, 0xdc, 0xec, 0xf4, 0xae, 0xdb, 0x4c,
-	0xdf, 0xc3, 0x77, 0x70, 0x9a, 0x85, 0x8b, 0x96, 0xd5, 0xb8, 0xd5, 0x73, 0xbb, 0xc3, 0x85, 0x60,
-	0xa6, 0x9e, 0xdf, 0x89, 0xd7, 0xbb, 0x2d, 0x66, 0xea, 0xfb, 0xd5, 0xbf, 0x35, 0x28, 0x25, 0x97,
-	0x5f, 0xdd, 0x5c, 0xcf, 0x36, 0x39, 0x6b, 0x5b, 0x36, 0x4b, 0xf6, 0x52, 0x81, 0x93, 0x34, 0xb2,
-	0xea, 0x23, 0x03, 0x5b, 0xf5, 0x70, 0x0a, 0xc7, 0x69, 0x2c, 0xd6, 0x9f, 0x19, 0x16, 0x69, 0xcf,
-	0xc2, 0x62, 0xdd, 0xb7, 0x50, 0x8c, 0xf7, 0x0f, 0x11, 0x4a, 0x34, 0xb5, 0x07, 0x5e, 0xbf, 0x8f,
-	0xa5, 0x26, 0x7c, 0x0f, 0x1d, 0x6e, 0xea, 0xda, 0x6a, 0x57, 0xc8, 0xd7, 0x68, 0xd5, 0x79, 0xbd,
-	0x41, 0x63, 0xaf, 0x3e, 0x01, 0xac, 0x77, 0x13, 0xdf, 0xc0, 0x47, 0x82, 0xf7, 0xec, 0x46, 0x5d,
-	0x58, 0x1d, 0x3b, 0xce, 0x57, 0x06, 0x7d, 0xc3, 0xd9, 0x15, 0x75, 0xae, 0x56, 0xe9, 0x18, 0x5e,
-	0x6f, 0x78, 0xef, 0x2c, 0xd3, 0xa4, 0x7e, 0x55, 0xf1, 0xb5, 0x9b, 0xd9, 0xa6, 0x9e, 0xbb, 0xfe,
-	0x0e, 0xde, 0x79, 0x41, 0x6d, 0xf5, 0x2d, 0x8c, 0x1e, 0xf4, 0xf1, 0xa3, 0xb7, 0xe3, 0xba, 0x70,
-	0xff, 0xa4, 0x3a, 0xfa, 0x85, 0xfe, 0xf4, 0xff, 0xda, 0x2b, 0xde, 0x11, 0xe3, 0xfe, 0xfa, 0xa9,
-	0x40, 0xa4, 0xaf, 0xff, 0x0b, 0x00, 0x00, 0xff, 0xff, 0xd1, 0x69, 0xf1, 0x56, 0xae, 0x07, 0x00,
-	0x00,
+	// 1014 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7d, 0x56, 0x5f, 0x73, 0xda, 0x46,
+	0x10, 0x2f, 0x7f, 0x42, 0xf0, 0x3a, 0x26, 0xca, 0x05, 0x3b, 0x0a, 0x8d, 0x1d, 0x0f, 0x71, 0x67,
+	0x5c, 0xda, 0xc1, 0x33, 0xe9, 0x74, 0xd2, 0x97, 0xce, 0x54, 0x80, 0x30, 0xaa, 0x41, 0x78, 0x8e,
+	0x63, 0x48, 0xfa, 0xc2, 0xc8, 0x58, 0x06, 0x4d, 0x41, 0x62, 0x84, 0x18, 0xdb, 0xc9, 0x73, 0x3f,
+	0x43, 0xdf, 0xfb, 0xd4, 0x2f, 0xd0, 0x0f, 0xd2, 0x6f, 0xd4, 0xbb, 0x95, 0x04, 0x48, 0x88, 0xbe,
+	0xc0, 0xdd, 0xfe, 0x7e, 0xbb, 0xfb, 0xdb, 0xdb, 0xbd, 0x03, 0x38, 0x1f, 0x3b, 0x33, 0xc3, 0x1b,
+	0x4d, 0x8c, 0xaa, 0xe5, 0x5c, 0xf8, 0xab, 0x8b, 0xb9, 0xeb, 0x78, 0xce, 0x85, 0x67, 0x3e, 0x78,
+	0xf8, 0x51, 0xc5, 0x3d, 0xd9, 0x0f, 0x78, 0xc2, 0x54, 0xfa, 0x7e, 0xa7, 0xdb, 0xd4, 0x78, 0x74,
+	0x96, 0x5e, 0xf0, 0xe5, 0xbb, 0x96, 0xce, 0x76, 0xb2, 0xad, 0x99, 0x31, 0x36, 0x7d, 0x56, 0xf9,
+	0xcf, 0x14, 0xe4, 0x7b, 0xd6, 0x67, 0xb3, 0xb9, 0xb4, 0x47, 0xe4, 0x3b, 0xc8, 0x8a, 0x44, 0x72,
+	0xea, 0x34, 0x75, 0xbe, 0xff, 0xfe, 0x55, 0x75, 0x23, 0x79, 0xb5, 0xe7, 0x3d, 0x4e, 0xcd, 0x5b,
+	0xc6, 0x97, 0x14, 0x49, 0xa4, 0x0a, 0x4f, 0x67, 0x96, 0x2d, 0x7c, 0xe5, 0x34, 0xf2, 0x8b, 0x21,
+	0x3f, 0x90, 0x71, 0xed, 0x58, 0xb6, 0x47, 0x43, 0x12, 0xf2, 0x8d, 0x07, 0xe4, 0x67, 0xfe, 0x97,
+	0xef, 0x93, 0xca, 0x25, 0xc8, 0x8a, 0x6c, 0x84, 0x6c, 0x88, 0xda, 0xf3, 0x73, 0x97, 0x47, 0x00,
+	0x6b, 0x3d, 0x3c, 0x72, 0x6e, 0x21, 0x76, 0x0b, 0xce, 0xc9, 0xf0, 0xc0, 0x47, 0x11, 0xe1, 0x82,
+	0x82, 0x64, 0x1a, 0xb0, 0xc8, 0x37, 0x41, 0x44, 0x5f, 0xf6, 0x8b, 0x2d, 0x76, 0x90, 0xa4, 0x09,
+	0xd9, 0xa6, 0x63, 0x7b, 0xe4, 0x08, 0x72, 0x77, 0xc6, 0xcc, 0x9a, 0x3e, 0x06, 0x12, 0x82, 0x9d,
+	0x10, 0x76, 0x67, 0x8c, 0xfc, 0xea, 0xb9, 0x30, 0xb1, 0x16, 0xb6, 0x45, 0x58, 0x61, 0x8a, 0xe2,
+	0xba, 0xfc, 0xf7, 0x53, 0xd8, 0x5b, 0x89, 0x20, 0x45, 0x78, 0x62, 0xd9, 0xb7, 0xe6, 0x03, 0x06,
+	0xcb, 0x50, 0x7f, 0x43, 0x7e, 0x81, 0x03, 0x91, 0x53, 0x99, 0x5a, 0x63, 0x7b, 0x66, 0xda, 0xbe,
+	0xb6, 0xc2, 0xfb, 0xd2, 0x96, 0xb6, 0x15, 0x83, 0x46, 0x1d, 0x48, 0x17, 0xc8, 0xc2, 0x73, 0xad,
+	0xdf, 0x4d, 0x6f, 0xe2, 0x3a, 0xcb, 0xf1, 0x04, 0xb3, 0xc9, 0x59, 0x0c, 0xf3, 0x36, 0xd6, 0xc9,
+	0x38, 0x8d, 0x26, 0xb8, 0x92, 0x9f, 0x63, 0x01, 0xeb, 0xce, 0xd4, 0x71, 0xe5, 0x1c, 0x9e, 0xd9,
+	0x41, 0x18, 0x10, 0x8d, 0x34, 0x81, 0x48, 0xea, 0x50, 0x58, 0xf2, 0xd2, 0xdc, 0xa9, 0x65, 0x9b,
+	0xbe, 0x96, 0x3c, 0x6a, 0xf9, 0x3a, 0xa2, 0xa5, 0x1f, 0xa1, 0xd0, 0x98, 0x0b, 0xf9, 0x71, 0x23,
+	0x88, 0x9f, 0x1f, 0x92, 0xf2, 0xc7, 0x48, 0xa2, 0xc1, 0x77, 0xbc, 0x73, 0xf2, 0xb3, 0x84, 0x06,
+	0x8b, 0x96, 0x52, 0x84, 0xc9, 0x29, 0xec, 0x4f, 0x1e, 0xe7, 0x13, 0xd3, 0x36, 0x3c, 0xcb, 0xb1,
+	0xe5, 0x02, 0xf6, 0x6c, 0xd3, 0xc4, 0x27, 0x8b, 0x88, 0xa8, 0x2d, 0xd3, 0x1a, 0x4f, 0xbc, 0xce,
+	0x72, 0xea, 0x59, 0x73, 0x5e, 0x88, 0x84, 0xc4, 0x04, 0x84, 0x94, 0x20, 0xcf, 0xc7, 0xb7, 0xcd,
+	0x81, 0x85, 0x4c, 0xb0, 0xbf, 0xab, 0x3d, 0xbf, 0x5c, 0x7b, 0x42, 0x80, 0x5f, 0x46, 0x31, 0xa9,
+	0x8c, 0x35, 0x4e, 0xbe, 0x85, 0xec, 0xbd, 0x6b, 0xcc, 0xe5, 0x23, 0x3c, 0xb3, 0xc3, 0xad, 0x31,
+	0x18, 0x70, 0x90, 0x22, 0x85, 0x7c, 0x00, 0xf0, 0x5c, 0x7e, 0x7b, 0xfd, 0x22, 0x64, 0x74, 0x88,
+	0x5e, 0x5d, 0xb6, 0x82, 0xe9, 0x06, 0x95, 0x54, 0x40, 0x5a, 0xef, 0xc4, 0x50, 0xd8, 0x63, 0xb9,
+	0x84, 0xb3, 0xbc, 0x65, 0x27, 0x1a, 0x48, 0xf7, 0xae, 0xe5, 0xf1, 0x65, 0xc3, 0x72, 0xcd, 0x11,
+	0xa6, 0x7a, 0x83, 0xa9, 0x8e, 0x23, 0xa9, 0x06, 0x31, 0x12, 0xdd, 0x72, 0x23, 0x67, 0x70, 0x30,
+	0x35, 0x3d, 0xcf, 0x74, 0x7b, 0x73, 0x63, 0x24, 0x72, 0x9e, 0xe0, 0x71, 0x46, 0x8d, 0xe4, 0x04,
+	0x60, 0x7d, 0xbe, 0xf2, 0x29, 0x52, 0x36, 0x2c, 0x42, 0xfc, 0xdc, 0x70, 0x8d, 0x31, 0x3f, 0x81,
+	0x49, 0x18, 0xa8, 0x8c, 0xac, 0x2d, 0x3b, 0x39, 0x87, 0xe7, 0x77, 0x96, 0xbb, 0xf0, 0x44, 0x1f,
+	0x34, 0x3e, 0x29, 0x7c, 0x32, 0xce, 0x90, 0x1a, 0x37, 0x97, 0xff, 0x48, 0xc1, 0xbe, 0x38, 0xde,
+	0x8e, 0xc9, 0xcb, 0x1e, 0x2d, 0xc4, 0x65, 0xbd, 0xb7, 0x6e, 0xbd, 0x09, 0x5e, 0xd6, 0x14, 0xf5,
+	0x37, 0xe2, 0x41, 0x98, 0xf8, 0xba, 0xd2, 0x68, 0x0e, 0x76, 0xa2, 0x32, 0x0c, 0x58, 0x33, 0x16,
+	0xa6, 0x90, 0x1a, 0xbc, 0x02, 0x51, 0x23, 0x29, 0xc3, 0xb3, 0xa9, 0xb1, 0x41, 0xca, 0x22, 0x29,
+	0x62, 0xab, 0x7c, 0x81, 0x83, 0xc8, 0x65, 0x27, 0xaf, 0xe0, 0x25, 0x53, 0x3f, 0xb2, 0xa1, 0xd2,
+	0xd6, 0x2e, 0xf5, 0x8e, 0xaa, 0xb3, 0x61, 0x5b, 0x6d, 0x32, 0xe9, 0x2b, 0x22, 0x43, 0x31, 0x06,
+	0x50, 0xed, 0xb2, 0xc5, 0xa4, 0x14, 0x79, 0x0d, 0x87, 0x31, 0xa4, 0xce, 0x3f, 0x54, 0x2a, 0xa5,
+	0xc9, 0x1b, 0x90, 0x63, 0xd0, 0xaf, 0xfd, 0x1e, 0xd3, 0x9a, 0x9a, 0xda, 0x90, 0x32, 0x95, 0x7f,
+	0x53, 0x40, 0xb6, 0xdf, 0x08, 0xe1, 0xd4, 0x63, 0x54, 0xbb, 0x52, 0x59, 0x8b, 0x76, 0xfb, 0x97,
+	0xad, 0x61, 0x8f, 0x7d, 0x6a, 0xab, 0x43, 0xbd, 0xab, 0xab, 0x5c, 0xc7, 0x09, 0x94, 0x92, 0xd0,
+	0x9e, 0xa6, 0x5f, 0xb6, 0x55, 0xae, 0x66, 0x07, 0xde, 0xe8, 0xf6, 0x6b, 0x1c, 0x4f, 0x93, 0x63,
+	0x78, 0x9d, 0x84, 0xb3, 0x96, 0x56, 0xbf, 0x92, 0x32, 0xbb, 0xdd, 0x19, 0xe3, 0x9a, 0xb3, 0x3b,
+	0x71, 0xa5, 0xd7, 0xe2, 0xf8, 0x93, 0xca, 0x3f, 0x29, 0x28, 0x44, 0xdf, 0x1a, 0x71, 0x72, 0x7d,
+	0xbd, 0x41, 0xd5, 0xb6, 0xa6, 0xab, 0xd1, 0x5a, 0x4a, 0x70, 0x14, 0x47, 0x56, 0x75, 0x24, 0x60,
+	0xab, 0x1a, 0xf8, 0x89, 0xc7, 0xb1, 0x50, 0x7f, 0xa2, 0x5b, 0xa0, 0x3d, 0x09, 0x0b, 0x75, 0x5f,
+	0x41, 0x3e, 0xbc, 0xee, 0xfc, 0xb7, 0xa5, 0x80, 0x5d, 0x1b, 0x50, 0xe5, 0x3a, 0x94, 0x1a, 0xb1,
+	0x0d, 0xba, 0xb4, 0xc1, 0x25, 0x86, 0xb3, 0x82, 0xb6, 0x7a, 0x4b, 0xa1, 0x4a, 0x1d, 0xdb, 0x5e,
+	0xb9, 0x01, 0x58, 0x3f, 0x05, 0xe4, 0x25, 0x3c, 0x67, 0xb4, 0xaf, 0xd7, 0x15, 0xa6, 0x75, 0xf5,
+	0x30, 0x5e, 0x11, 0xa4, 0x0d, 0x63, 0x8f, 0x29, 0x54, 0x8c, 0xd2, 0x21, 0xbc, 0xd8, 0xb0, 0x76,
+	0xb4, 0x46, 0x03, 0xeb, 0x15, 0xc9, 0xd7, 0x66, 0x55, 0x17, 0xc3, 0xf3, 0x05, 0xa4, 0xf8, 0x1b,
+	0x20, 0x7a, 0x3b, 0xa0, 0x1a, 0xe3, 0x47, 0x38, 0x6c, 0x68, 0x54, 0xad, 0xfb, 0x09, 0x15, 0xd6,
+	0xa7, 0x4a, 0x9b, 0xe7, 0x7c, 0x07, 0x6f, 0xb7, 0x61, 0x31, 0xde, 0x43, 0xd6, 0x5d, 0x4d, 0x73,
+	0x22, 0x09, 0x41, 0xc1, 0xc2, 0xcb, 0x90, 0xae, 0xfd, 0x04, 0xc7, 0x96, 0x53, 0x5d, 0xfd, 0xef,
+	0x09, 0xbe, 0xf0, 0x8f, 0x0e, 0x3e, 0x4f, 0xb5, 0xdc, 0xf5, 0x8d, 0x38, 0xce, 0xdf, 0xf0, 0x07,
+	0xfe, 0xaf, 0x74, 0xbe, 0x83, 0x8c, 0xeb, 0xda, 0x4d, 0x0e, 0x49, 0x3f, 0xfc, 0x07, 0x47, 0x3b,
+	0x29, 0x9d, 0x9a, 0x09, 0x00, 0x00,
 }
@@ -15,7 +15,13 @@ var _ = fmt.Errorf
 var _ = math.Inf
 
 type StackChildView struct {
-	ScreenId int64 `protobuf:"varint,3,opt,name=screenId" json:"screenId,omitempty"`
+	ScreenId           int64   `protobuf:"varint,3,opt,name=screenId" json:"screenId,omitempty"`
+	Transition         int64   `protobuf:"varint,4,opt,name=transition" json:"transition,omitempty"`
+	TransitionDuration float64 `protobuf:"fixed64,5,opt,name=transitionDuration" json:"transitionDuration,omitempty"`
+	CurveX0            float64 `protobuf:"fixed64,6,opt,name=curveX0" json:"curveX0,omitempty"`
+	CurveY0            float64 `protobuf:"fixed64,7,opt,name=curveY0" json:"curveY0,omitempty"`
+	CurveX1            float64 `protobuf:"fixed64,8,opt,name=curveX1" json:"curveX1,omitempty"`
+	CurveY1            float64 `protobuf:"fixed64,9,opt,name=curveY1" json:"curveY1,omitempty"`
 }
 
 func (m *StackChildView) Reset()                    { *m = StackChildView{} }
@@ -30,6 +36,48 @@ func (m *StackChildView) GetScreenId() int64 {
 	return 0
 }
 
+func (m *StackChildView) GetTransition() int64 {
+	if m != nil {
+		return m.Transition
+	}
+	return 0
+}
+
+func (m *StackChildView) GetTransitionDuration() float64 {
+	if m != nil {
+		return m.TransitionDuration
+	}
+	return 0
+}
+
+func (m *StackChildView) GetCurveX0() float64 {
+	if m != nil {
+		return m.CurveX0
+	}
+	return 0
+}
+
+func (m *StackChildView) GetCurveY0() float64 {
+	if m != nil {
+		return m.CurveY0
+	}
+	return 0
+}
+
+func (m *StackChildView) GetCurveX1() float64 {
+	if m != nil {
+		return m.CurveX1
+	}
+	return 0
+}
+
+func (m *StackChildView) GetCurveY1() float64 {
+	if m != nil {
+		return m.CurveY1
+	}
+	return 0
+}
+
 type StackView struct {
 	Children []*StackChildView `protobuf:"bytes,1,rep,name=children" json:"children,omitempty"`
 }
@@ -187,34 +235,38 @@ func init() {
 }
 
 var fileDescriptor1 = []byte{
-	// 459 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x52, 0x5d, 0x6b, 0xd4, 0x40,
-	0x14, 0x25, 0x9b, 0xa6, 0x4d, 0x6f, 0x74, 0x95, 0x51, 0x30, 0x2c, 0x3e, 0xc4, 0x54, 0x21, 0x7e,
-	0x90, 0x40, 0x45, 0xc4, 0xa7, 0x62, 0x8a, 0xe2, 0x82, 0xd2, 0x65, 0xb6, 0xf8, 0xe0, 0x5b, 0x92,
-	0xb9, 0xb4, 0x83, 0xd9, 0x19, 0x99, 0xcc, 0xb6, 0xf5, 0xef, 0xf8, 0x33, 0x7c, 0xf4, 0x97, 0x49,
-	0xee, 0xec, 0x86, 0xad, 0x75, 0xfb, 0x92, 0xc9, 0x3d, 0xf7, 0x9c, 0x33, 0x73, 0x0f, 0x17, 0xde,
-	0x9c, 0xe9, 0x45, 0x65, 0x9b, 0xf3, 0x2a, 0x97, 0xba, 0x70, 0x7f, 0xc5, 0x0f, 0xa3, 0xad, 0x2e,
-	0x2e, 0x24, 0x5e, 0x16, 0x95, 0x12, 0x46, 0x4b, 0x51, 0x74, 0xb6, 0x6a, 0xbe, 0xf7, 0x48, 0x4e,
-	0x4d, 0xf6, 0x60, 0x25, 0x22, 0x68, 0x45, 0x9a, 0x3c, 0xdd, 0xea, 0x25, 0x17, 0xd5, 0x19, 0x3a,
-	0xe9, 0x24, 0xdb, 0xca, 0xb2, 0x78, 0x65, 0xe9, 0xe3, 0x98, 0xe9, 0x2b, 0x18, 0xcf, 0xfb, 0x7b,
-	0x8f, 0xcf, 0x65, 0x2b, 0xbe, 0x4a, 0xbc, 0x64, 0x13, 0x08, 0xbb, 0xc6, 0x20, 0xaa, 0xa9, 0x88,
-	0xfd, 0xc4, 0xcb, 0x7c, 0x3e, 0xd4, 0xe9, 0x67, 0xd8, 0x27, 0x36, 0x11, 0x8f, 0x20, 0x6c, 0x7a,
-	0x95, 0x41, 0x15, 0x7b, 0x89, 0x9f, 0x45, 0x87, 0x07, 0xf9, 0x7f, 0x9e, 0x9c, 0x5f, 0xf7, 0xe7,
-	0x83, 0x28, 0xfd, 0x33, 0x82, 0x90, 0x9a, 0x65, 0x65, 0xd8, 0x43, 0x08, 0xac, 0xb4, 0x2d, 0xc6,
-	0x5e, 0xe2, 0x65, 0xfb, 0xdc, 0x15, 0xec, 0x1d, 0x44, 0x9d, 0xfd, 0xd9, 0xa2, 0x38, 0xa5, 0xde,
-	0x6e, 0xe2, 0x65, 0xd1, 0xe1, 0xa3, 0xf5, 0x35, 0x34, 0xc7, 0xdc, 0xf5, 0xf1, 0xca, 0xf2, 0x4d,
-	0x2e, 0xcd, 0xb1, 0xac, 0x9d, 0xa7, 0x4f, 0x9e, 0x43, 0xcd, 0x8e, 0x60, 0xec, 0xa8, 0xf3, 0x35,
-	0x63, 0xef, 0x76, 0xe7, 0x7f, 0xe8, 0xec, 0x00, 0x82, 0x46, 0xb7, 0xda, 0xc4, 0x3b, 0xa4, 0xbb,
-	0xbb, 0xd6, 0x1d, 0xf7, 0x20, 0x77, 0x3d, 0xf6, 0x16, 0x02, 0x69, 0x71, 0xd1, 0xc5, 0x01, 0xa5,
-	0xf3, 0x64, 0x7b, 0x3a, 0x65, 0x65, 0xa6, 0x16, 0x17, 0xdc, 0xf1, 0xd9, 0x0b, 0xb8, 0x5f, 0xf7,
-	0xe8, 0xd2, 0x5a, 0xad, 0x3e, 0x49, 0x21, 0x50, 0xc5, 0xa3, 0xc4, 0xcb, 0x42, 0x7e, 0x03, 0x4f,
-	0x7f, 0x7b, 0x70, 0x67, 0xd3, 0x63, 0x4b, 0x90, 0x2f, 0x61, 0x47, 0x36, 0x5a, 0x51, 0x12, 0x1b,
-	0x73, 0x4e, 0xfb, 0xa5, 0x39, 0x31, 0x1c, 0x3b, 0xbd, 0x34, 0x0d, 0x72, 0x22, 0xb1, 0xe7, 0x10,
-	0xf6, 0xe7, 0xa9, 0x54, 0x96, 0xee, 0xbd, 0x31, 0xe0, 0xd0, 0xee, 0x53, 0x16, 0xb2, 0xab, 0xea,
-	0x16, 0x05, 0x65, 0x11, 0xf2, 0xa1, 0x66, 0x09, 0x44, 0x5a, 0xcd, 0x0c, 0x76, 0xdd, 0xc7, 0xa5,
-	0x6a, 0xe2, 0x80, 0xde, 0xb3, 0x09, 0xa5, 0x8f, 0x01, 0xe8, 0xed, 0x1f, 0x2e, 0x50, 0x59, 0x36,
-	0x86, 0x91, 0x14, 0xb4, 0x4a, 0x3e, 0x1f, 0x49, 0x51, 0x9e, 0xc0, 0x33, 0xa9, 0xf3, 0x61, 0x95,
-	0x57, 0x07, 0xed, 0xed, 0xb5, 0x0c, 0xcb, 0x68, 0x56, 0x0f, 0x6b, 0xf9, 0x6d, 0x6f, 0x85, 0xfe,
-	0x1a, 0xdd, 0xfb, 0x42, 0x8a, 0xf7, 0xae, 0x9e, 0x95, 0xf5, 0x2e, 0x69, 0x5f, 0xff, 0x0d, 0x00,
-	0x00, 0xff, 0xff, 0xfd, 0xb1, 0xc0, 0x3b, 0x91, 0x03, 0x00, 0x00,
+	// 524 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7d, 0x53, 0xdb, 0x6e, 0xd3, 0x40,
+	0x10, 0x95, 0xe3, 0xa6, 0x71, 0xc6, 0x10, 0xd0, 0x82, 0x84, 0x15, 0x21, 0x14, 0x52, 0x90, 0x52,
+	0x90, 0xec, 0x26, 0x08, 0x21, 0x9e, 0x10, 0x29, 0x20, 0x22, 0x81, 0x1a, 0x6d, 0x2a, 0xd4, 0xf2,
+	0xe6, 0xcb, 0xaa, 0x5d, 0x91, 0x78, 0xd1, 0x7a, 0x1d, 0xe0, 0x77, 0xf8, 0x0c, 0x1e, 0xf9, 0x16,
+	0x3e, 0x84, 0xdd, 0xd9, 0xc4, 0x71, 0x68, 0xc3, 0x8b, 0xbd, 0x33, 0xe7, 0x9c, 0x19, 0xcf, 0xd9,
+	0x31, 0x3c, 0xbf, 0x10, 0x8b, 0x58, 0xa5, 0x97, 0x71, 0xc8, 0x45, 0x64, 0x4f, 0xd1, 0x57, 0x29,
+	0x94, 0x88, 0x96, 0x9c, 0x7d, 0x8b, 0xe2, 0x3c, 0x93, 0x82, 0x67, 0x51, 0xa1, 0xe2, 0xf4, 0x8b,
+	0xc9, 0x84, 0x08, 0x92, 0x3b, 0x2b, 0x11, 0xa6, 0x56, 0xa4, 0xee, 0xa3, 0x9d, 0xb5, 0xf8, 0x22,
+	0xbe, 0x60, 0x56, 0xda, 0x1d, 0xec, 0x64, 0x29, 0xf6, 0x5d, 0xe1, 0xc3, 0x32, 0xfb, 0x7f, 0x1c,
+	0xe8, 0xcc, 0x4c, 0xe3, 0xe3, 0x4b, 0x3e, 0xcf, 0x3e, 0xe9, 0x56, 0xa4, 0x0b, 0x5e, 0x91, 0x4a,
+	0xc6, 0xf2, 0x49, 0x16, 0xb8, 0x3d, 0x67, 0xe0, 0xd2, 0x2a, 0x26, 0x0f, 0x00, 0x94, 0x8c, 0xf3,
+	0x82, 0x2b, 0x2e, 0xf2, 0x60, 0x0f, 0xd1, 0x5a, 0x86, 0x84, 0x40, 0x36, 0xd1, 0x9b, 0x52, 0xc6,
+	0xc8, 0x6b, 0x6a, 0x9e, 0x43, 0xaf, 0x41, 0x48, 0x00, 0xad, 0xb4, 0x94, 0x4b, 0x76, 0x76, 0x14,
+	0xec, 0x23, 0x69, 0x1d, 0x56, 0xc8, 0xf9, 0x51, 0xd0, 0xaa, 0x21, 0xe7, 0x1b, 0xe4, 0x6c, 0x18,
+	0x78, 0x75, 0xcd, 0x70, 0xa3, 0x19, 0x06, 0xed, 0xba, 0x66, 0xd8, 0xff, 0x00, 0x6d, 0x9c, 0x12,
+	0x07, 0x7c, 0x05, 0x5e, 0x6a, 0xa6, 0x95, 0x2c, 0x0f, 0x9c, 0x9e, 0x3b, 0xf0, 0x47, 0x07, 0xe1,
+	0x35, 0x5e, 0x87, 0xdb, 0xbe, 0xd0, 0x4a, 0xd4, 0xff, 0xdd, 0x00, 0x0f, 0xc1, 0x71, 0x2c, 0xc9,
+	0x5d, 0x68, 0x2a, 0xae, 0xe6, 0x4c, 0x97, 0x72, 0x06, 0x6d, 0x6a, 0x03, 0xf2, 0x12, 0xfc, 0x42,
+	0xfd, 0x98, 0xb3, 0xec, 0x14, 0x31, 0x33, 0x9c, 0x3f, 0xba, 0xb7, 0x6e, 0x83, 0x17, 0x30, 0xb3,
+	0xb8, 0x3e, 0xd2, 0x3a, 0x17, 0xfd, 0x2f, 0x13, 0x5b, 0xd3, 0xc5, 0x9a, 0x55, 0xac, 0x3f, 0xbd,
+	0x63, 0xa9, 0xb3, 0x35, 0xa3, 0xf5, 0xff, 0xca, 0xff, 0xd0, 0xc9, 0x01, 0x34, 0x53, 0x31, 0x17,
+	0x12, 0xef, 0xce, 0x1f, 0xdd, 0x5c, 0xeb, 0x8e, 0x4d, 0x92, 0x5a, 0x8c, 0xbc, 0x80, 0x26, 0x57,
+	0x6c, 0x51, 0xe8, 0x8b, 0x33, 0xee, 0x3c, 0xdc, 0xed, 0x8e, 0x36, 0x60, 0xa2, 0x99, 0xd4, 0xf2,
+	0xc9, 0x13, 0xb8, 0x9d, 0x98, 0x6c, 0xa9, 0x94, 0xc8, 0xdf, 0xf3, 0x2c, 0xd3, 0x0e, 0x37, 0x74,
+	0x23, 0x8f, 0x5e, 0xc9, 0xf7, 0x7f, 0x39, 0x70, 0xa3, 0x5e, 0x63, 0x87, 0x91, 0x4f, 0x61, 0x8f,
+	0xa7, 0x7a, 0x87, 0xdc, 0xed, 0x39, 0x27, 0x66, 0xdb, 0x4f, 0x24, 0x65, 0x85, 0x28, 0x65, 0xca,
+	0x28, 0x92, 0xc8, 0x21, 0x78, 0xe6, 0x7d, 0xca, 0x73, 0x85, 0x7d, 0xaf, 0x0c, 0x58, 0xc1, 0xc6,
+	0xe5, 0x8c, 0x17, 0x71, 0xa2, 0xcd, 0x41, 0x2f, 0x3c, 0x5a, 0xc5, 0xa4, 0x07, 0xbe, 0xc8, 0xa7,
+	0x92, 0x15, 0xc5, 0xbb, 0x32, 0x4f, 0x71, 0x7d, 0xdb, 0xb4, 0x9e, 0xea, 0xdf, 0x07, 0xc0, 0x6f,
+	0x7f, 0xbb, 0x64, 0xba, 0x56, 0x07, 0x1a, 0x3c, 0xc3, 0x55, 0x72, 0xa9, 0x3e, 0x8d, 0x4f, 0xe0,
+	0x31, 0x17, 0x61, 0xf5, 0x0f, 0xae, 0x5e, 0xf8, 0xc3, 0x6d, 0x79, 0x38, 0xf6, 0xa7, 0x49, 0xb5,
+	0x96, 0x9f, 0x5b, 0xab, 0xec, 0xcf, 0xc6, 0xad, 0x8f, 0xa8, 0x78, 0x6d, 0xe3, 0xe9, 0x38, 0xd9,
+	0x47, 0xed, 0xb3, 0xbf, 0x34, 0xf4, 0x61, 0xe1, 0x4a, 0x04, 0x00, 0x00,
 }
@@ -15,7 +15,13 @@ var _ = fmt.Errorf
 var _ = math.Inf
 
 type StackChildView struct {
-	ScreenId int64 `protobuf:"varint,3,opt,name=screenId" json:"screenId,omitempty"`
+	ScreenId           int64   `protobuf:"varint,3,opt,name=screenId" json:"screenId,omitempty"`
+	Transition         int64   `protobuf:"varint,4,opt,name=transition" json:"transition,omitempty"`
+	TransitionDuration float64 `protobuf:"fixed64,5,opt,name=transitionDuration" json:"transitionDuration,omitempty"`
+	CurveX0            float64 `protobuf:"fixed64,6,opt,name=curveX0" json:"curveX0,omitempty"`
+	CurveY0            float64 `protobuf:"fixed64,7,opt,name=curveY0" json:"curveY0,omitempty"`
+	CurveX1            float64 `protobuf:"fixed64,8,opt,name=curveX1" json:"curveX1,omitempty"`
+	CurveY1            float64 `protobuf:"fixed64,9,opt,name=curveY1" json:"curveY1,omitempty"`
 }
 
 func (m *StackChildView) Reset()                    { *m = StackChildView{} }
@@ -30,6 +36,48 @@ func (m *StackChildView) GetScreenId() int64 {
 	return 0
 }
 
+func (m *StackChildView) GetTransition() int64 {
+	if m != nil {
+		return m.Transition
+	}
+	return 0
+}
+
+func (m *StackChildView) GetTransitionDuration() float64 {
+	if m != nil {
+		return m.TransitionDuration
+	}
+	return 0
+}
+
+func (m *StackChildView) GetCurveX0() float64 {
+	if m != nil {
+		return m.CurveX0
+	}
+	return 0
+}
+
+func (m *StackChildView) GetCurveY0() float64 {
+	if m != nil {
+		return m.CurveY0
+	}
+	return 0
+}
+
+func (m *StackChildView) GetCurveX1() float64 {
+	if m != nil {
+		return m.CurveX1
+	}
+	return 0
+}
+
+func (m *StackChildView) GetCurveY1() float64 {
+	if m != nil {
+		return m.CurveY1
+	}
+	return 0
+}
+
 type StackView struct {
 	Children       []*StackChildView      `protobuf:"bytes,1,rep,name=children" json:"children,omitempty"`
 	TitleTextStyle *matcha_text.TextStyle `protobuf:"bytes,2,opt,name=titleTextStyle" json:"titleTextStyle,omitempty"`
@@ -160,32 +208,36 @@ func init() {
 func init() { proto.RegisterFile("gomatcha.io/matcha/proto/view/ios/stackview.proto", fileDescriptor2) }
 
 var fileDescriptor2 = []byte{
-	// 424 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0xcf, 0x8e, 0x94, 0x40,
-	0x10, 0xc6, 0x03, 0xb8, 0x2b, 0x5b, 0x38, 0xac, 0xe9, 0xa8, 0x21, 0x13, 0x13, 0x91, 0x6c, 0x0c,
-	0x1a, 0x03, 0x71, 0xf5, 0xa6, 0xf1, 0x00, 0x31, 0xd1, 0x83, 0x71, 0xd2, 0x33, 0xf1, 0xe0, 0xad,
-	0x81, 0x76, 0xe8, 0x0c, 0x43, 0x1b, 0xe8, 0xf9, 0xe3, 0xcb, 0x78, 0xf0, 0x0d, 0x7d, 0x03, 0x43,
-	0x31, 0xd3, 0x0a, 0x3a, 0x7b, 0x81, 0xae, 0xaf, 0x7e, 0x5f, 0x51, 0x5d, 0x14, 0xbc, 0x58, 0xca,
-	0x35, 0x53, 0x79, 0xc9, 0x22, 0x21, 0xe3, 0xfe, 0x14, 0x7f, 0x6b, 0xa4, 0x92, 0xf1, 0x56, 0xf0,
-	0x5d, 0x2c, 0x64, 0x1b, 0xb7, 0x8a, 0xe5, 0xab, 0x2e, 0x8a, 0x30, 0x41, 0x2e, 0x0f, 0x06, 0x94,
-	0x84, 0x6c, 0xa7, 0x57, 0x27, 0x6b, 0x88, 0x35, 0x5b, 0xf2, 0xde, 0x36, 0x0d, 0x4f, 0x52, 0x8a,
-	0xef, 0x15, 0x3e, 0x7a, 0x32, 0x78, 0x0e, 0xee, 0xbc, 0xfb, 0x66, 0x5a, 0x8a, 0xaa, 0xf8, 0x2c,
-	0xf8, 0x8e, 0x4c, 0xc1, 0x6e, 0xf3, 0x86, 0xf3, 0xfa, 0x43, 0xe1, 0x59, 0xbe, 0x11, 0x5a, 0x54,
-	0xc7, 0xc1, 0x2f, 0x03, 0x2e, 0x10, 0x47, 0xf2, 0x35, 0xd8, 0x79, 0x67, 0x6b, 0x78, 0xed, 0x19,
-	0xbe, 0x15, 0x3a, 0xd7, 0x8f, 0xa2, 0x51, 0xbf, 0xd1, 0xb0, 0x38, 0xd5, 0x06, 0xf2, 0x16, 0x5c,
-	0x25, 0x54, 0xc5, 0x17, 0x7c, 0xaf, 0xe6, 0xea, 0x7b, 0xc5, 0x3d, 0xd3, 0x37, 0x42, 0xe7, 0xfa,
-	0xc1, 0xb1, 0x04, 0x36, 0xa9, 0xb3, 0x74, 0x44, 0x93, 0x37, 0x30, 0xc9, 0x58, 0xbe, 0xfa, 0x63,
-	0xb7, 0x6e, 0xb4, 0x0f, 0x61, 0xf2, 0x14, 0xec, 0x8c, 0x35, 0xa9, 0xac, 0x64, 0xe3, 0xdd, 0x42,
-	0xe3, 0xe4, 0x68, 0x44, 0x91, 0xea, 0x74, 0xf0, 0xc3, 0x04, 0x1b, 0x6f, 0x91, 0xb0, 0x86, 0xdc,
-	0x83, 0x33, 0xec, 0xc3, 0x33, 0x7c, 0x23, 0xbc, 0xa0, 0x7d, 0x40, 0x9e, 0xc1, 0xdd, 0xae, 0x7c,
-	0xb2, 0x51, 0x4a, 0xd6, 0xef, 0x45, 0x51, 0xf0, 0xda, 0xbb, 0xed, 0x1b, 0xa1, 0x4d, 0xff, 0xd1,
-	0xc9, 0x2b, 0xb8, 0x9f, 0x6f, 0x5a, 0x25, 0xd7, 0x89, 0xce, 0x2c, 0xb0, 0xa2, 0x89, 0x86, 0xff,
-	0x27, 0x49, 0x08, 0x97, 0xd9, 0x88, 0xb7, 0xb0, 0x83, 0xb1, 0x4c, 0x02, 0xb8, 0x53, 0xb2, 0x16,
-	0xcf, 0xdd, 0xc4, 0xf1, 0x76, 0x36, 0x1d, 0x68, 0xe4, 0x09, 0xb8, 0x8d, 0x58, 0x96, 0xaa, 0x0b,
-	0x52, 0xb9, 0xa9, 0x95, 0x77, 0x86, 0x3f, 0x7a, 0xa4, 0x92, 0x2b, 0x98, 0x54, 0xfc, 0xeb, 0x5f,
-	0xd8, 0x39, 0x62, 0x43, 0x31, 0x78, 0x08, 0x80, 0xf3, 0x79, 0xb7, 0xe5, 0xb5, 0x22, 0x2e, 0x98,
-	0xa2, 0xc0, 0x75, 0xb0, 0xa8, 0x29, 0x8a, 0x24, 0x85, 0xc7, 0x42, 0x46, 0x7a, 0x1f, 0x0f, 0x2f,
-	0x5c, 0x3e, 0xbd, 0x25, 0x89, 0x33, 0xcb, 0xf4, 0x5a, 0x7d, 0xb1, 0x84, 0x6c, 0x7f, 0x9a, 0xce,
-	0x47, 0x24, 0xc5, 0xa7, 0xf9, 0x2c, 0xc9, 0xce, 0x91, 0x7f, 0xf9, 0x3b, 0x00, 0x00, 0xff, 0xff,
-	0x83, 0x8d, 0x87, 0xa8, 0x42, 0x03, 0x00, 0x00,
+	// 487 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7d, 0x53, 0xdd, 0x6e, 0xd3, 0x30,
+	0x14, 0x56, 0x12, 0xd6, 0xa5, 0x27, 0xb4, 0x43, 0xd6, 0x40, 0x51, 0x85, 0x60, 0x44, 0x13, 0x2a,
+	0x5c, 0x24, 0x4b, 0xe1, 0x0e, 0xc4, 0x45, 0x0a, 0x12, 0x5c, 0x20, 0x26, 0x77, 0x42, 0x1b, 0x77,
+	0xf9, 0xf1, 0x5a, 0x8b, 0x34, 0x9e, 0x1c, 0xa7, 0xc0, 0xcb, 0x70, 0xc1, 0xb3, 0xf1, 0x02, 0xbc,
+	0x01, 0xce, 0xe9, 0x9a, 0xa6, 0x61, 0xe3, 0x26, 0xf1, 0xf9, 0x7e, 0x8e, 0x7d, 0x3e, 0xd9, 0x10,
+	0xce, 0xc5, 0x32, 0x56, 0xe9, 0x22, 0xf6, 0xb9, 0x08, 0xd6, 0xab, 0xe0, 0x4a, 0x0a, 0x25, 0x82,
+	0x15, 0x67, 0xdf, 0x02, 0x2e, 0xca, 0xa0, 0x54, 0x71, 0xfa, 0xb5, 0xae, 0x7c, 0x24, 0xc8, 0xc1,
+	0xb5, 0x01, 0x21, 0x2d, 0x18, 0x1d, 0xdf, 0xda, 0x83, 0x2f, 0xe3, 0x39, 0x5b, 0xdb, 0x46, 0xe3,
+	0x5b, 0x55, 0x8a, 0x7d, 0x57, 0xf8, 0x59, 0x2b, 0xbd, 0xdf, 0x06, 0x0c, 0x67, 0xf5, 0xa6, 0xd3,
+	0x05, 0xcf, 0xb3, 0xcf, 0x7a, 0x1b, 0x32, 0x02, 0xbb, 0x4c, 0x25, 0x63, 0xc5, 0x87, 0xcc, 0xb5,
+	0x8e, 0x8c, 0xb1, 0x45, 0x9b, 0x9a, 0x3c, 0x02, 0x50, 0x32, 0x2e, 0x4a, 0xae, 0xb8, 0x28, 0xdc,
+	0x3b, 0xc8, 0xb6, 0x10, 0xe2, 0x03, 0xd9, 0x56, 0x6f, 0x2b, 0x19, 0xa3, 0x6e, 0x4f, 0xeb, 0x0c,
+	0x7a, 0x03, 0x43, 0x5c, 0xd8, 0x4f, 0x2b, 0xb9, 0x62, 0xe7, 0x27, 0x6e, 0x0f, 0x45, 0x9b, 0xb2,
+	0x61, 0x2e, 0x4e, 0xdc, 0xfd, 0x16, 0x73, 0xb1, 0x65, 0xce, 0x43, 0xd7, 0x6e, 0x7b, 0xc2, 0xad,
+	0x27, 0x74, 0xfb, 0x6d, 0x4f, 0xe8, 0xfd, 0x31, 0xa0, 0x8f, 0x63, 0xe2, 0x84, 0xaf, 0xc0, 0x4e,
+	0xeb, 0x71, 0x25, 0x2b, 0x5c, 0xe3, 0xc8, 0x1a, 0x3b, 0x93, 0xc7, 0x7e, 0x27, 0x68, 0x7f, 0x37,
+	0x14, 0xda, 0x18, 0xc8, 0x1b, 0x18, 0x2a, 0xae, 0x72, 0x76, 0xa6, 0x43, 0x9c, 0xa9, 0x1f, 0x39,
+	0x73, 0x4d, 0xbd, 0x97, 0x33, 0x79, 0xb0, 0x69, 0x81, 0xe9, 0x36, 0x2c, 0xed, 0xa8, 0xc9, 0x6b,
+	0x18, 0x24, 0xba, 0xf5, 0xd6, 0x6e, 0xfd, 0xd7, 0xbe, 0x2b, 0x26, 0xcf, 0xc0, 0x4e, 0x62, 0x39,
+	0x15, 0xb9, 0x90, 0x18, 0xbf, 0x33, 0x19, 0x6c, 0x8c, 0x08, 0xd2, 0x86, 0xf6, 0x7e, 0x9a, 0x60,
+	0xe3, 0x14, 0x51, 0x2c, 0xc9, 0x21, 0xec, 0xe1, 0x39, 0xf4, 0xbc, 0xc6, 0xb8, 0x4f, 0xd7, 0x05,
+	0x79, 0x0e, 0xf7, 0xea, 0xf6, 0x51, 0xa5, 0x94, 0x28, 0xde, 0xf3, 0x2c, 0xd3, 0x81, 0xd4, 0x69,
+	0xdb, 0xf4, 0x1f, 0x9c, 0xbc, 0x84, 0xfb, 0x69, 0x55, 0x2a, 0xb1, 0x8c, 0x1a, 0xe6, 0x0c, 0x3b,
+	0x9a, 0x68, 0xb8, 0x99, 0x24, 0x63, 0x38, 0x48, 0x3a, 0x7a, 0x0b, 0x4f, 0xd0, 0x85, 0x89, 0x07,
+	0x77, 0x17, 0x71, 0x89, 0xeb, 0x3a, 0x71, 0x9c, 0xce, 0xa6, 0x3b, 0x18, 0x79, 0x0a, 0x43, 0xc9,
+	0xe7, 0x0b, 0x55, 0x17, 0x53, 0x51, 0x15, 0x0a, 0xaf, 0x96, 0x45, 0x3b, 0x28, 0x39, 0x86, 0x41,
+	0xce, 0x2e, 0x5b, 0xb2, 0x1e, 0xca, 0x76, 0x41, 0xef, 0x21, 0x00, 0xe6, 0xf3, 0x6e, 0xc5, 0xb4,
+	0x67, 0x08, 0x26, 0xcf, 0xf0, 0x3a, 0x58, 0x54, 0xaf, 0xa2, 0x29, 0x3c, 0xe1, 0xc2, 0x6f, 0x1e,
+	0xd2, 0xf5, 0x0f, 0x5f, 0x4d, 0x73, 0x4b, 0x22, 0xe7, 0x34, 0x69, 0xae, 0xd5, 0x17, 0x4b, 0x23,
+	0xbf, 0x4c, 0xe7, 0x23, 0x2a, 0xf9, 0xa7, 0xd9, 0x69, 0x94, 0xf4, 0x50, 0xff, 0xe2, 0x2f, 0x8c,
+	0x85, 0x6f, 0xf6, 0xfb, 0x03, 0x00, 0x00,
 }
@@ -0,0 +1,142 @@
+// Package reactnative embeds Matcha views in React Native apps, so that an app
+// can be migrated one screen at a time. Components registered with Register are
+// rendered by the <MatchaView> component of the React Native module generated
+// by `matcha reactnative`.
+//
+//  func init() {
+//      reactnative.Register("settings", func(ctx *reactnative.Context) view.View {
+//          return NewSettingsView(ctx)
+//      })
+//  }
+//
+// And in JavaScript:
+//
+//  import MatchaView from 'react-native-matcha';
+//
+//  <MatchaView component="settings" userId={42} onSave={e => console.log(e.nativeEvent)} />
+package reactnative
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"sync"
+
+	"gomatcha.io/matcha"
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/view"
+)
+
+// Props are the props of a <MatchaView>, decoded from JSON.
+type Props map[string]interface{}
+
+// Context connects a component to its <MatchaView>.
+type Context struct {
+	id    int64
+	props Props
+	relay comm.Relay
+}
+
+// Props returns the current props of the component. It must be called on the
+// main thread.
+func (c *Context) Props() Props {
+	return c.props
+}
+
+// Notify implements the comm.Notifier interface. It is signaled when React
+// Native updates the props.
+func (c *Context) Notify(f func()) comm.Id {
+	return c.relay.Notify(f)
+}
+
+// Unnotify implements the comm.Notifier interface.
+func (c *Context) Unnotify(id comm.Id) {
+	c.relay.Unnotify(id)
+}
+
+// Emit calls the on<Event> prop of the <MatchaView> with body, encoded as JSON.
+// For example Emit("save", x) calls onSave.
+func (c *Context) Emit(event string, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	b := bridge.Bridge("gomatcha.io/matcha/reactnative")
+	if b.IsNil() {
+		return fmt.Errorf("reactnative: module not loaded")
+	}
+	if runtime.GOOS == "android" {
+		b.Call("emit", bridge.Int64(c.id), bridge.String(event), bridge.String(string(data)))
+	} else {
+		b.Call("emit:event:body:", bridge.Int64(c.id), bridge.String(event), bridge.String(string(data)))
+	}
+	return nil
+}
+
+var registry = struct {
+	mu         sync.Mutex
+	components map[string]func(*Context) view.View
+}{
+	components: map[string]func(*Context) view.View{},
+}
+
+// contexts are the mounted components, keyed by the id assigned by React Native.
+// Guarded by matcha.MainLocker.
+var contexts = map[int64]*Context{}
+
+// Register makes the view returned by f available to React Native as name. It
+// panics if name is already registered.
+func Register(name string, f func(*Context) view.View) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if _, ok := registry.components[name]; ok {
+		panic("reactnative: component registered twice: " + name)
+	}
+	registry.components[name] = f
+}
+
+func decodeProps(data string) Props {
+	props := Props{}
+	if err := json.Unmarshal([]byte(data), &props); err != nil {
+		fmt.Println("reactnative: invalid props", err)
+	}
+	return props
+}
+
+func init() {
+	bridge.RegisterFunc("gomatcha.io/matcha/reactnative New", func(id int64, name string, props string) view.View {
+		matcha.MainLocker.Lock()
+		defer matcha.MainLocker.Unlock()
+
+		registry.mu.Lock()
+		f, ok := registry.components[name]
+		registry.mu.Unlock()
+		if !ok {
+			fmt.Println("reactnative: unknown component", name)
+			return view.NewBasicView()
+		}
+
+		ctx := &Context{id: id, props: decodeProps(props)}
+		contexts[id] = ctx
+		return f(ctx)
+	})
+	bridge.RegisterFunc("gomatcha.io/matcha/reactnative SetProps", func(id int64, props string) {
+		matcha.MainLocker.Lock()
+		defer matcha.MainLocker.Unlock()
+
+		ctx, ok := contexts[id]
+		if !ok {
+			return
+		}
+		ctx.props = decodeProps(props)
+		ctx.relay.Signal()
+	})
+	bridge.RegisterFunc("gomatcha.io/matcha/reactnative Remove", func(id int64) {
+		matcha.MainLocker.Lock()
+		defer matcha.MainLocker.Unlock()
+
+		delete(contexts, id)
+	})
+}
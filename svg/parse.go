@@ -0,0 +1,602 @@
+package svg
+
+import (
+	"encoding/xml"
+	"errors"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+
+	"golang.org/x/image/colornames"
+)
+
+// kappa is the distance of the control points of the cubic bezier that
+// approximates a quarter of a unit circle.
+const kappa = 0.5522847498
+
+// matrix is an affine transform that maps (x, y) to
+// (a*x + c*y + e, b*x + d*y + f).
+type matrix [6]float64
+
+var identity = matrix{1, 0, 0, 1, 0, 0}
+
+// mul returns the transform that applies n, then m.
+func (m matrix) mul(n matrix) matrix {
+	return matrix{
+		m[0]*n[0] + m[2]*n[1],
+		m[1]*n[0] + m[3]*n[1],
+		m[0]*n[2] + m[2]*n[3],
+		m[1]*n[2] + m[3]*n[3],
+		m[0]*n[4] + m[2]*n[5] + m[4],
+		m[1]*n[4] + m[3]*n[5] + m[5],
+	}
+}
+
+func (m matrix) apply(x, y float64) (float64, float64) {
+	return m[0]*x + m[2]*y + m[4], m[1]*x + m[3]*y + m[5]
+}
+
+// state holds the attributes inherited by the children of an element.
+type state struct {
+	fill    color.Color // nil if the shapes aren't filled.
+	opacity float64
+	m       matrix
+}
+
+type parser struct {
+	img   *Image
+	root  bool
+	stack []state
+	skip  int // Depth inside elements whose content isn't drawn.
+}
+
+func (p *parser) start(e xml.StartElement) error {
+	if p.skip > 0 {
+		p.skip++
+		return nil
+	}
+	switch e.Name.Local {
+	case "defs", "symbol", "clipPath", "mask", "pattern", "marker", "linearGradient", "radialGradient", "style", "text":
+		p.skip = 1
+		return nil
+	}
+
+	attrs := map[string]string{}
+	for _, i := range e.Attr {
+		attrs[i.Name.Local] = i.Value
+	}
+	for _, i := range strings.Split(attrs["style"], ";") {
+		if kv := strings.SplitN(i, ":", 2); len(kv) == 2 {
+			attrs[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+		}
+	}
+
+	st := state{fill: color.Black, opacity: 1, m: identity}
+	if len(p.stack) > 0 {
+		st = p.stack[len(p.stack)-1]
+	}
+	if v, ok := attrs["transform"]; ok {
+		m, err := parseTransform(v)
+		if err != nil {
+			return err
+		}
+		st.m = st.m.mul(m)
+	}
+	if v, ok := attrs["fill"]; ok {
+		st.fill = parseColor(v, st.fill)
+	}
+	if v, ok := attrs["fill-opacity"]; ok {
+		st.opacity *= parseNumber(v, 1)
+	}
+	if v, ok := attrs["opacity"]; ok {
+		st.opacity *= parseNumber(v, 1)
+	}
+	p.stack = append(p.stack, st)
+
+	b := &builder{m: st.m}
+	switch e.Name.Local {
+	case "svg":
+		if !p.root {
+			p.root = true
+			p.parseRoot(attrs)
+		}
+	case "path":
+		if err := b.path(attrs["d"]); err != nil {
+			return err
+		}
+	case "rect":
+		b.rect(attr(attrs, "x"), attr(attrs, "y"), attr(attrs, "width"), attr(attrs, "height"), attrs)
+	case "circle":
+		r := attr(attrs, "r")
+		b.ellipse(attr(attrs, "cx"), attr(attrs, "cy"), r, r)
+	case "ellipse":
+		b.ellipse(attr(attrs, "cx"), attr(attrs, "cy"), attr(attrs, "rx"), attr(attrs, "ry"))
+	case "polygon", "polyline":
+		b.polygon(attrs["points"])
+	}
+	if len(b.ops) > 0 && st.fill != nil {
+		c := color.NRGBAModel.Convert(st.fill).(color.NRGBA)
+		c.A = uint8(math.Max(0, math.Min(1, st.opacity)) * float64(c.A))
+		if c.A > 0 {
+			p.img.shapes = append(p.img.shapes, shape{ops: b.ops, fill: c})
+		}
+	}
+	return nil
+}
+
+func (p *parser) end() {
+	if p.skip > 0 {
+		p.skip--
+	} else if len(p.stack) > 0 {
+		p.stack = p.stack[:len(p.stack)-1]
+	}
+}
+
+func (p *parser) parseRoot(attrs map[string]string) {
+	img := p.img
+	img.width = attr(attrs, "width")
+	img.height = attr(attrs, "height")
+
+	sc := &scanner{s: attrs["viewBox"]}
+	for i := range img.viewBox {
+		v, ok := sc.number()
+		if !ok {
+			img.viewBox = [4]float64{0, 0, img.width, img.height}
+			break
+		}
+		img.viewBox[i] = v
+	}
+	if img.width == 0 || img.height == 0 {
+		img.width, img.height = img.viewBox[2], img.viewBox[3]
+	}
+}
+
+// attr returns the length in attribute k, or 0.
+func attr(attrs map[string]string, k string) float64 {
+	return length(attrs[k])
+}
+
+// length returns the length in s, ignoring px units, or 0.
+func length(s string) float64 {
+	return parseNumber(strings.TrimSuffix(strings.TrimSpace(s), "px"), 0)
+}
+
+func parseNumber(s string, def float64) float64 {
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+// parseColor returns the color in s, nil for none, or inherited if s isn't
+// supported.
+func parseColor(s string, inherited color.Color) color.Color {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch {
+	case s == "none" || strings.HasPrefix(s, "url("):
+		return nil
+	case s == "currentcolor":
+		return color.Black
+	case strings.HasPrefix(s, "#"):
+		hex := s[1:]
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		v, err := strconv.ParseUint(hex, 16, 32)
+		if len(hex) != 6 || err != nil {
+			return inherited
+		}
+		return color.NRGBA{uint8(v >> 16), uint8(v >> 8), uint8(v), 0xff}
+	case strings.HasPrefix(s, "rgb(") && strings.HasSuffix(s, ")"):
+		parts := strings.Split(s[4:len(s)-1], ",")
+		if len(parts) != 3 {
+			return inherited
+		}
+		c := [3]uint8{}
+		for i, part := range parts {
+			part = strings.TrimSpace(part)
+			v := 0.0
+			if strings.HasSuffix(part, "%") {
+				v = parseNumber(strings.TrimSuffix(part, "%"), 0) * 255 / 100
+			} else {
+				v = parseNumber(part, 0)
+			}
+			c[i] = uint8(math.Max(0, math.Min(255, v)))
+		}
+		return color.NRGBA{c[0], c[1], c[2], 0xff}
+	}
+	if c, ok := colornames.Map[s]; ok {
+		return c
+	}
+	return inherited
+}
+
+func parseTransform(s string) (matrix, error) {
+	m := identity
+	for {
+		s = strings.TrimLeft(s, " \t\r\n,")
+		if s == "" {
+			return m, nil
+		}
+		open := strings.IndexByte(s, '(')
+		close := strings.IndexByte(s, ')')
+		if open < 0 || close < open {
+			return m, errors.New("svg: invalid transform")
+		}
+		name := strings.TrimSpace(s[:open])
+		sc := &scanner{s: s[open+1 : close]}
+		args := []float64{}
+		for {
+			v, ok := sc.number()
+			if !ok {
+				break
+			}
+			args = append(args, v)
+		}
+		s = s[close+1:]
+
+		arg := func(i int, def float64) float64 {
+			if i < len(args) {
+				return args[i]
+			}
+			return def
+		}
+		var t matrix
+		switch name {
+		case "matrix":
+			if len(args) != 6 {
+				return m, errors.New("svg: invalid matrix transform")
+			}
+			copy(t[:], args)
+		case "translate":
+			t = matrix{1, 0, 0, 1, arg(0, 0), arg(1, 0)}
+		case "scale":
+			t = matrix{arg(0, 1), 0, 0, arg(1, arg(0, 1)), 0, 0}
+		case "rotate":
+			a := arg(0, 0) * math.Pi / 180
+			cx, cy := arg(1, 0), arg(2, 0)
+			sin, cos := math.Sincos(a)
+			t = matrix{1, 0, 0, 1, cx, cy}.mul(matrix{cos, sin, -sin, cos, 0, 0}).mul(matrix{1, 0, 0, 1, -cx, -cy})
+		case "skewX":
+			t = matrix{1, 0, math.Tan(arg(0, 0) * math.Pi / 180), 1, 0, 0}
+		case "skewY":
+			t = matrix{1, math.Tan(arg(0, 0) * math.Pi / 180), 0, 1, 0, 0}
+		default:
+			return m, errors.New("svg: unknown transform " + name)
+		}
+		m = m.mul(t)
+	}
+}
+
+// scanner reads the numbers, flags and commands of path data.
+type scanner struct {
+	s string
+	i int
+}
+
+func (sc *scanner) skipSeparators() {
+	for sc.i < len(sc.s) {
+		switch sc.s[sc.i] {
+		case ' ', '\t', '\r', '\n', ',':
+			sc.i++
+		default:
+			return
+		}
+	}
+}
+
+func (sc *scanner) done() bool {
+	sc.skipSeparators()
+	return sc.i >= len(sc.s)
+}
+
+// command returns the next command letter, if the next token is one.
+func (sc *scanner) command() (byte, bool) {
+	sc.skipSeparators()
+	if sc.i < len(sc.s) {
+		c := sc.s[sc.i]
+		if c != 'e' && c != 'E' && (c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z') {
+			sc.i++
+			return c, true
+		}
+	}
+	return 0, false
+}
+
+func (sc *scanner) number() (float64, bool) {
+	sc.skipSeparators()
+	start := sc.i
+	digits := func() {
+		for sc.i < len(sc.s) && sc.s[sc.i] >= '0' && sc.s[sc.i] <= '9' {
+			sc.i++
+		}
+	}
+	if sc.i < len(sc.s) && (sc.s[sc.i] == '-' || sc.s[sc.i] == '+') {
+		sc.i++
+	}
+	digits()
+	if sc.i < len(sc.s) && sc.s[sc.i] == '.' {
+		sc.i++
+		digits()
+	}
+	if sc.i < len(sc.s) && (sc.s[sc.i] == 'e' || sc.s[sc.i] == 'E') {
+		sc.i++
+		if sc.i < len(sc.s) && (sc.s[sc.i] == '-' || sc.s[sc.i] == '+') {
+			sc.i++
+		}
+		digits()
+	}
+	v, err := strconv.ParseFloat(sc.s[start:sc.i], 64)
+	if err != nil {
+		sc.i = start
+		return 0, false
+	}
+	return v, true
+}
+
+// flag reads an arc flag, which may not be followed by a separator.
+func (sc *scanner) flag() (bool, bool) {
+	sc.skipSeparators()
+	if sc.i < len(sc.s) && (sc.s[sc.i] == '0' || sc.s[sc.i] == '1') {
+		sc.i++
+		return sc.s[sc.i-1] == '1', true
+	}
+	return false, false
+}
+
+// builder converts shapes to the ops of a shape, transformed by m.
+type builder struct {
+	m      matrix
+	ops    []op
+	x, y   float64 // Current point.
+	sx, sy float64 // Start of the subpath.
+	cx, cy float64 // Last control point, for smooth curves.
+	curve  byte    // Kind of the last segment, 'c' or 'q', if it was a curve.
+}
+
+func (b *builder) moveTo(x, y float64) {
+	tx, ty := b.m.apply(x, y)
+	b.ops = append(b.ops, op{kind: 'M', pts: [6]float64{tx, ty}})
+	b.x, b.y, b.sx, b.sy = x, y, x, y
+}
+
+func (b *builder) lineTo(x, y float64) {
+	tx, ty := b.m.apply(x, y)
+	b.ops = append(b.ops, op{kind: 'L', pts: [6]float64{tx, ty}})
+	b.x, b.y = x, y
+}
+
+func (b *builder) cubicTo(x1, y1, x2, y2, x, y float64) {
+	o := op{kind: 'C'}
+	o.pts[0], o.pts[1] = b.m.apply(x1, y1)
+	o.pts[2], o.pts[3] = b.m.apply(x2, y2)
+	o.pts[4], o.pts[5] = b.m.apply(x, y)
+	b.ops = append(b.ops, o)
+	b.x, b.y = x, y
+}
+
+func (b *builder) close() {
+	b.ops = append(b.ops, op{kind: 'Z'})
+	b.x, b.y = b.sx, b.sy
+}
+
+// arcTo appends the elliptical arc of the path data's A command, converted to
+// cubic beziers of at most a quarter turn.
+func (b *builder) arcTo(rx, ry, rotation float64, large, sweep bool, x, y float64) {
+	x0, y0 := b.x, b.y
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	if rx == 0 || ry == 0 || (x0 == x && y0 == y) {
+		b.lineTo(x, y)
+		return
+	}
+
+	// Compute the center, following the SVG implementation notes.
+	sin, cos := math.Sincos(rotation * math.Pi / 180)
+	dx, dy := (x0-x)/2, (y0-y)/2
+	x1 := cos*dx + sin*dy
+	y1 := -sin*dx + cos*dy
+	if l := x1*x1/(rx*rx) + y1*y1/(ry*ry); l > 1 {
+		rx, ry = rx*math.Sqrt(l), ry*math.Sqrt(l)
+	}
+	num := rx*rx*ry*ry - rx*rx*y1*y1 - ry*ry*x1*x1
+	den := rx*rx*y1*y1 + ry*ry*x1*x1
+	co := math.Sqrt(math.Max(0, num/den))
+	if large == sweep {
+		co = -co
+	}
+	cx1 := co * rx * y1 / ry
+	cy1 := -co * ry * x1 / rx
+	cx := cos*cx1 - sin*cy1 + (x0+x)/2
+	cy := sin*cx1 + cos*cy1 + (y0+y)/2
+
+	angle := func(ux, uy, vx, vy float64) float64 {
+		return math.Atan2(ux*vy-uy*vx, ux*vx+uy*vy)
+	}
+	theta := angle(1, 0, (x1-cx1)/rx, (y1-cy1)/ry)
+	delta := angle((x1-cx1)/rx, (y1-cy1)/ry, (-x1-cx1)/rx, (-y1-cy1)/ry)
+	if !sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	} else if sweep && delta < 0 {
+		delta += 2 * math.Pi
+	}
+
+	// point returns the point of the ellipse at angle a, and its derivative.
+	point := func(a float64) (px, py, tx, ty float64) {
+		sa, ca := math.Sincos(a)
+		px = cx + rx*ca*cos - ry*sa*sin
+		py = cy + rx*ca*sin + ry*sa*cos
+		tx = -rx*sa*cos - ry*ca*sin
+		ty = -rx*sa*sin + ry*ca*cos
+		return
+	}
+	n := int(math.Ceil(math.Abs(delta) / (math.Pi / 2)))
+	step := delta / float64(n)
+	k := 4.0 / 3 * math.Tan(step/4)
+	for i := 0; i < n; i++ {
+		a1 := theta + step*float64(i)
+		a2 := a1 + step
+		p1x, p1y, t1x, t1y := point(a1)
+		p2x, p2y, t2x, t2y := point(a2)
+		if i == n-1 {
+			p2x, p2y = x, y
+		}
+		b.cubicTo(p1x+k*t1x, p1y+k*t1y, p2x-k*t2x, p2y-k*t2y, p2x, p2y)
+	}
+}
+
+// pathArgs is the number of arguments of the path data commands.
+var pathArgs = map[byte]int{'m': 2, 'l': 2, 'h': 1, 'v': 1, 'c': 6, 's': 4, 'q': 4, 't': 2, 'a': 7, 'z': 0}
+
+func (b *builder) path(d string) error {
+	sc := &scanner{s: d}
+	cmd := byte(0)
+	for !sc.done() {
+		if c, ok := sc.command(); ok {
+			cmd = c
+		} else if cmd == 0 || cmd|0x20 == 'z' {
+			return errors.New("svg: invalid path data")
+		}
+
+		rel := cmd >= 'a'
+		ox, oy := 0.0, 0.0
+		if rel {
+			ox, oy = b.x, b.y
+		}
+		n, ok := pathArgs[cmd|0x20]
+		if !ok {
+			return errors.New("svg: unknown path command " + string(cmd))
+		}
+		args := make([]float64, n)
+		for i := range args {
+			var ok bool
+			if cmd|0x20 == 'a' && (i == 3 || i == 4) {
+				var f bool
+				f, ok = sc.flag()
+				if f {
+					args[i] = 1
+				}
+			} else {
+				args[i], ok = sc.number()
+			}
+			if !ok {
+				return errors.New("svg: invalid path data")
+			}
+		}
+
+		// Control point used by the next smooth curve, if any.
+		cx, cy, smooth := 0.0, 0.0, byte(0)
+		switch cmd | 0x20 {
+		case 'm':
+			b.moveTo(ox+args[0], oy+args[1])
+			// Coordinates after a moveto are implicit linetos.
+			cmd -= 'm' - 'l'
+		case 'l':
+			b.lineTo(ox+args[0], oy+args[1])
+		case 'h':
+			b.lineTo(ox+args[0], b.y)
+		case 'v':
+			if rel {
+				b.lineTo(b.x, oy+args[0])
+			} else {
+				b.lineTo(b.x, args[0])
+			}
+		case 'c':
+			cx, cy, smooth = ox+args[2], oy+args[3], 'c'
+			b.cubicTo(ox+args[0], oy+args[1], cx, cy, ox+args[4], oy+args[5])
+		case 's':
+			x1, y1 := b.x, b.y
+			if b.curve == 'c' {
+				x1, y1 = 2*b.x-b.cx, 2*b.y-b.cy
+			}
+			cx, cy, smooth = ox+args[0], oy+args[1], 'c'
+			b.cubicTo(x1, y1, cx, cy, ox+args[2], oy+args[3])
+		case 'q', 't':
+			qx, qy := b.x, b.y
+			if cmd|0x20 == 'q' {
+				qx, qy = ox+args[0], oy+args[1]
+				args = args[2:]
+			} else if b.curve == 'q' {
+				qx, qy = 2*b.x-b.cx, 2*b.y-b.cy
+			}
+			x, y := ox+args[0], oy+args[1]
+			b.cubicTo(b.x+2.0/3*(qx-b.x), b.y+2.0/3*(qy-b.y), x+2.0/3*(qx-x), y+2.0/3*(qy-y), x, y)
+			cx, cy, smooth = qx, qy, 'q'
+		case 'a':
+			b.arcTo(args[0], args[1], args[2], args[3] != 0, args[4] != 0, ox+args[5], oy+args[6])
+		case 'z':
+			b.close()
+		}
+		b.cx, b.cy, b.curve = cx, cy, smooth
+	}
+	return nil
+}
+
+func (b *builder) rect(x, y, w, h float64, attrs map[string]string) {
+	if w <= 0 || h <= 0 {
+		return
+	}
+	rx, hasRx := attrs["rx"]
+	ry, hasRy := attrs["ry"]
+	if !hasRx {
+		rx = ry
+	} else if !hasRy {
+		ry = rx
+	}
+	rw := math.Min(math.Max(length(rx), 0), w/2)
+	rh := math.Min(math.Max(length(ry), 0), h/2)
+	if rw == 0 || rh == 0 {
+		b.moveTo(x, y)
+		b.lineTo(x+w, y)
+		b.lineTo(x+w, y+h)
+		b.lineTo(x, y+h)
+		b.close()
+		return
+	}
+	kw, kh := rw*kappa, rh*kappa
+	b.moveTo(x+rw, y)
+	b.lineTo(x+w-rw, y)
+	b.cubicTo(x+w-rw+kw, y, x+w, y+rh-kh, x+w, y+rh)
+	b.lineTo(x+w, y+h-rh)
+	b.cubicTo(x+w, y+h-rh+kh, x+w-rw+kw, y+h, x+w-rw, y+h)
+	b.lineTo(x+rw, y+h)
+	b.cubicTo(x+rw-kw, y+h, x, y+h-rh+kh, x, y+h-rh)
+	b.lineTo(x, y+rh)
+	b.cubicTo(x, y+rh-kh, x+rw-kw, y, x+rw, y)
+	b.close()
+}
+
+func (b *builder) ellipse(cx, cy, rx, ry float64) {
+	if rx <= 0 || ry <= 0 {
+		return
+	}
+	kx, ky := rx*kappa, ry*kappa
+	b.moveTo(cx+rx, cy)
+	b.cubicTo(cx+rx, cy+ky, cx+kx, cy+ry, cx, cy+ry)
+	b.cubicTo(cx-kx, cy+ry, cx-rx, cy+ky, cx-rx, cy)
+	b.cubicTo(cx-rx, cy-ky, cx-kx, cy-ry, cx, cy-ry)
+	b.cubicTo(cx+kx, cy-ry, cx+rx, cy-ky, cx+rx, cy)
+	b.close()
+}
+
+// polygon appends the points of a polygon or polyline, which are both filled
+// as closed shapes.
+func (b *builder) polygon(points string) {
+	sc := &scanner{s: points}
+	for i := 0; ; i++ {
+		x, ok := sc.number()
+		y, ok2 := sc.number()
+		if !ok || !ok2 {
+			break
+		}
+		if i == 0 {
+			b.moveTo(x, y)
+		} else {
+			b.lineTo(x, y)
+		}
+	}
+	if len(b.ops) > 0 {
+		b.close()
+	}
+}
@@ -0,0 +1,193 @@
+// Package svg decodes SVG icons and rasterizes them at the screen's scale, so
+// they can be displayed by a view.ImageView without shipping PNGs for each
+// density.
+//
+//  img, err := svg.Decode(strings.NewReader(iconSVG))
+//  if err != nil {
+//      ...
+//  }
+//  v := view.NewImageView()
+//  v.Image = img.Sized(24, 24)
+//
+// Only filled shapes are supported: the path, rect, circle, ellipse, polygon
+// and polyline elements, grouped with g elements and positioned with transform
+// attributes. Strokes, gradients, text, clipping and masks are ignored.
+package svg
+
+import (
+	"encoding/xml"
+	"errors"
+	"image"
+	"image/color"
+	"io"
+	"math"
+	"sync"
+
+	"golang.org/x/image/vector"
+	"gomatcha.io/matcha/internal/device"
+)
+
+func init() {
+	image.RegisterFormat("svg", "<svg", decodeImage, decodeConfig)
+	image.RegisterFormat("svg", "<?xml", decodeImage, decodeConfig)
+}
+
+// Image is a decoded SVG document. It implements the image.Image interface,
+// rasterized at its size in points times the screen scale. Rasterizations are
+// cached, and shared by the images returned by Sized.
+type Image struct {
+	width   float64
+	height  float64
+	viewBox [4]float64
+	shapes  []shape
+	cache   *cache
+}
+
+type cache struct {
+	mu     sync.Mutex
+	images map[image.Point]*image.RGBA
+}
+
+type shape struct {
+	ops  []op // In the coordinates of the viewBox.
+	fill color.NRGBA
+}
+
+type op struct {
+	kind byte // 'M', 'L', 'C' or 'Z'
+	pts  [6]float64
+}
+
+// Decode reads an SVG document from r.
+func Decode(r io.Reader) (*Image, error) {
+	p := &parser{img: &Image{cache: &cache{}}}
+	d := xml.NewDecoder(r)
+	d.Strict = false
+	d.AutoClose = xml.HTMLAutoClose
+	d.Entity = xml.HTMLEntity
+	for {
+		t, err := d.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		switch t := t.(type) {
+		case xml.StartElement:
+			if err := p.start(t); err != nil {
+				return nil, err
+			}
+		case xml.EndElement:
+			p.end()
+		}
+	}
+	if !p.root {
+		return nil, errors.New("svg: missing svg element")
+	}
+	return p.img, nil
+}
+
+// Size returns the size of the image in points, from the width and height
+// attributes of the svg element, or from its viewBox.
+func (img *Image) Size() (width, height float64) {
+	return img.width, img.height
+}
+
+// Sized returns a copy of the image displayed at the given size in points.
+func (img *Image) Sized(width, height float64) *Image {
+	c := *img
+	c.width = width
+	c.height = height
+	return &c
+}
+
+// Scale returns the screen scale the image is rasterized at. It lets
+// view.ImageView display the image at its size in points.
+func (img *Image) Scale() float64 {
+	return device.ScreenScale
+}
+
+// ColorModel implements the image.Image interface.
+func (img *Image) ColorModel() color.Model {
+	return color.RGBAModel
+}
+
+// Bounds implements the image.Image interface.
+func (img *Image) Bounds() image.Rectangle {
+	scale := img.Scale()
+	return image.Rect(0, 0, int(math.Ceil(img.width*scale)), int(math.Ceil(img.height*scale)))
+}
+
+// At implements the image.Image interface.
+func (img *Image) At(x, y int) color.Color {
+	return img.RGBA().At(x, y)
+}
+
+// RGBA returns the image rasterized at its bounds.
+func (img *Image) RGBA() *image.RGBA {
+	size := img.Bounds().Size()
+	return img.Rasterize(size.X, size.Y)
+}
+
+// Rasterize returns the image rasterized at width by height pixels. The viewBox
+// is scaled to fit and centered, like with the default preserveAspectRatio. The
+// returned image is cached and must not be modified.
+func (img *Image) Rasterize(width, height int) *image.RGBA {
+	key := image.Pt(width, height)
+	img.cache.mu.Lock()
+	defer img.cache.mu.Unlock()
+	if dst, ok := img.cache.images[key]; ok {
+		return dst
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	vb := img.viewBox
+	if width > 0 && height > 0 && vb[2] > 0 && vb[3] > 0 {
+		scale := math.Min(float64(width)/vb[2], float64(height)/vb[3])
+		dx := (float64(width)-vb[2]*scale)/2 - vb[0]*scale
+		dy := (float64(height)-vb[3]*scale)/2 - vb[1]*scale
+		pt := func(x, y float64) (float32, float32) {
+			return float32(x*scale + dx), float32(y*scale + dy)
+		}
+
+		z := vector.NewRasterizer(width, height)
+		for _, s := range img.shapes {
+			z.Reset(width, height)
+			for _, o := range s.ops {
+				switch o.kind {
+				case 'M':
+					z.MoveTo(pt(o.pts[0], o.pts[1]))
+				case 'L':
+					z.LineTo(pt(o.pts[0], o.pts[1]))
+				case 'C':
+					x1, y1 := pt(o.pts[0], o.pts[1])
+					x2, y2 := pt(o.pts[2], o.pts[3])
+					x3, y3 := pt(o.pts[4], o.pts[5])
+					z.CubeTo(x1, y1, x2, y2, x3, y3)
+				case 'Z':
+					z.ClosePath()
+				}
+			}
+			z.Draw(dst, dst.Bounds(), image.NewUniform(s.fill), image.Point{})
+		}
+	}
+
+	if img.cache.images == nil {
+		img.cache.images = map[image.Point]*image.RGBA{}
+	}
+	img.cache.images[key] = dst
+	return dst
+}
+
+func decodeImage(r io.Reader) (image.Image, error) {
+	return Decode(r)
+}
+
+func decodeConfig(r io.Reader) (image.Config, error) {
+	img, err := Decode(r)
+	if err != nil {
+		return image.Config{}, err
+	}
+	size := img.Bounds().Size()
+	return image.Config{ColorModel: img.ColorModel(), Width: size.X, Height: size.Y}, nil
+}
@@ -0,0 +1,66 @@
+package svg
+
+import (
+	"image/color"
+	"strings"
+	"testing"
+)
+
+const testSVG = `<svg xmlns="http://www.w3.org/2000/svg" width="20px" height="10" viewBox="0 0 40 20">
+	<defs><rect width="40" height="20" fill="blue"/></defs>
+	<g fill="#f00" transform="translate(20 0)">
+		<path d="M0 0h20v20H0z"/>
+	</g>
+	<path d="M10 10m-5 0a5 5 0 1 0 10 0a5 5 0 1 0-10 0" style="fill: rgb(0, 0, 255)" opacity=".5"/>
+</svg>`
+
+func TestDecode(t *testing.T) {
+	img, err := Decode(strings.NewReader(testSVG))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w, h := img.Size(); w != 20 || h != 10 {
+		t.Errorf("Size = %v, %v", w, h)
+	}
+
+	dst := img.Rasterize(20, 10)
+	for _, c := range []struct {
+		x, y int
+		want color.RGBA
+	}{
+		{15, 5, color.RGBA{0xff, 0, 0, 0xff}}, // Translated group.
+		{5, 5, color.RGBA{0, 0, 0x80, 0x80}},  // Center of the circle, premultiplied.
+		{1, 1, color.RGBA{}},                  // Outside of the circle, and not drawn by defs.
+	} {
+		got := dst.RGBAAt(c.x, c.y)
+		if diff(got.R, c.want.R) > 2 || diff(got.B, c.want.B) > 2 || diff(got.A, c.want.A) > 2 {
+			t.Errorf("At(%v, %v) = %v, want %v", c.x, c.y, got, c.want)
+		}
+	}
+	if img.Rasterize(20, 10) != dst || img.Sized(40, 20).Rasterize(20, 10) != dst {
+		t.Error("Rasterize isn't cached")
+	}
+	if b := img.Sized(40, 20).Bounds(); b.Dx() != 40 || b.Dy() != 20 {
+		t.Error("Bounds =", b)
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	for _, s := range []string{
+		`<html></html>`,
+		`<svg viewBox="0 0 1 1"><path d="M0 0 L"/></svg>`,
+		`<svg viewBox="0 0 1 1"><path d="M0 0z 1 1"/></svg>`,
+		`<svg viewBox="0 0 1 1"><g transform="spin(1)"/></svg>`,
+	} {
+		if _, err := Decode(strings.NewReader(s)); err == nil {
+			t.Errorf("Decode(%q) succeeded", s)
+		}
+	}
+}
+
+func diff(a, b uint8) int {
+	if a > b {
+		return int(a - b)
+	}
+	return int(b - a)
+}
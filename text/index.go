@@ -0,0 +1,40 @@
+package text
+
+import "unicode/utf8"
+
+// UTF16Index returns the index in UTF-16 code units of the byte at index i of
+// str. StyledText indexes text by bytes, and native views by UTF-16 code
+// units. An index within a character returns the character's start, so styles
+// never split a character.
+func UTF16Index(str string, i int) int {
+	n := 0
+	for idx, r := range str {
+		if _, size := utf8.DecodeRuneInString(str[idx:]); idx+size > i {
+			break
+		}
+		n += utf16Len(r)
+	}
+	return n
+}
+
+// ByteIndex returns the byte index in str of the UTF-16 code unit at index i.
+// It is the inverse of UTF16Index.
+func ByteIndex(str string, i int) int {
+	n := 0
+	for idx, r := range str {
+		n += utf16Len(r)
+		if n > i {
+			return idx
+		}
+	}
+	return len(str)
+}
+
+// utf16Len returns the number of UTF-16 code units that encode r. Characters
+// outside the Basic Multilingual Plane, like most emoji, take two.
+func utf16Len(r rune) int {
+	if r >= 0x10000 {
+		return 2
+	}
+	return 1
+}
@@ -0,0 +1,32 @@
+package text
+
+import "testing"
+
+func TestUTF16Index(t *testing.T) {
+	// "a", Hebrew alef (2 bytes, 1 unit), a thumbs up emoji (4 bytes, 2 units).
+	str := "aא\U0001f44db"
+	for _, c := range []struct{ bytes, units int }{
+		{0, 0}, {1, 1}, {3, 2}, {7, 4}, {8, 5},
+	} {
+		if got := UTF16Index(str, c.bytes); got != c.units {
+			t.Errorf("UTF16Index(%d) = %d, want %d", c.bytes, got, c.units)
+		}
+		if got := ByteIndex(str, c.units); got != c.bytes {
+			t.Errorf("ByteIndex(%d) = %d, want %d", c.units, got, c.bytes)
+		}
+	}
+	// Indices within a character return its start.
+	if got := UTF16Index(str, 5); got != 2 {
+		t.Errorf("UTF16Index(5) = %d, want 2", got)
+	}
+	if got := ByteIndex(str, 3); got != 3 {
+		t.Errorf("ByteIndex(3) = %d, want 3", got)
+	}
+
+	st := NewStyledText(str, &Style{})
+	st.Update(&Style{}, 3, 6)
+	pb := st.MarshalProtobuf()
+	if len(pb.Styles) != 3 || pb.Styles[1].Index != 2 || pb.Styles[2].Index != 4 {
+		t.Errorf("MarshalProtobuf() indices = %v", pb.Styles)
+	}
+}
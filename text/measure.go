@@ -0,0 +1,57 @@
+package text
+
+import (
+	"strconv"
+	"sync"
+
+	"gomatcha.io/matcha/layout"
+)
+
+// maxCachedSizes is the number of measurements kept by the size cache. The
+// cache is emptied once it is full, which is cheaper than tracking usage and
+// fine for the few hundred strings an app displays at a time.
+const maxCachedSizes = 512
+
+var sizeCache struct {
+	mu sync.Mutex
+	m  map[string]Metrics
+}
+
+// Measure returns the size of st laid out within max, wrapping its lines at
+// max.X. The size is measured synchronously by the platform's text engine, so
+// layouts and list row heights can be computed in Go before the text is
+// displayed. Measurements are cached.
+//
+//  st := text.NewStyledText(message, style)
+//  height := text.Measure(st, layout.Pt(width, math.Inf(1))).Y
+func Measure(st *StyledText, max layout.Point) layout.Point {
+	return st.Size(layout.Pt(0, 0), max, 0)
+}
+
+func sizeCacheKey(data []byte, maxLines int) string {
+	return strconv.Itoa(maxLines) + " " + string(data)
+}
+
+func cachedMetrics(key string) (Metrics, bool) {
+	sizeCache.mu.Lock()
+	defer sizeCache.mu.Unlock()
+	m, ok := sizeCache.m[key]
+	return m, ok
+}
+
+func cacheMetrics(key string, m Metrics) {
+	sizeCache.mu.Lock()
+	defer sizeCache.mu.Unlock()
+	if sizeCache.m == nil || len(sizeCache.m) >= maxCachedSizes {
+		sizeCache.m = map[string]Metrics{}
+	}
+	sizeCache.m[key] = m
+}
+
+// clearSizeCache discards the measurements, which change when fonts are
+// registered.
+func clearSizeCache() {
+	sizeCache.mu.Lock()
+	defer sizeCache.mu.Unlock()
+	sizeCache.m = nil
+}
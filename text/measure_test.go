@@ -0,0 +1,35 @@
+package text
+
+import (
+	"strconv"
+	"testing"
+
+	"gomatcha.io/matcha/layout"
+)
+
+func TestSizeCache(t *testing.T) {
+	clearSizeCache()
+	key := sizeCacheKey([]byte("text"), 2)
+	if _, ok := cachedMetrics(key); ok {
+		t.Fatal("empty cache returned a size")
+	}
+	m := Metrics{Size: layout.Pt(10, 20), FirstBaseline: 15, LastBaseline: 15}
+	cacheMetrics(key, m)
+	if p, ok := cachedMetrics(key); !ok || p != m {
+		t.Fatalf("cachedMetrics = %v, %v", p, ok)
+	}
+	if _, ok := cachedMetrics(sizeCacheKey([]byte("text"), 1)); ok {
+		t.Fatal("sizes with different line limits share a key")
+	}
+
+	for i := 0; i < maxCachedSizes; i++ {
+		cacheMetrics(strconv.Itoa(i), Metrics{})
+	}
+	if n := len(sizeCache.m); n > maxCachedSizes {
+		t.Fatalf("cache holds %v sizes, want at most %v", n, maxCachedSizes)
+	}
+	clearSizeCache()
+	if _, ok := cachedMetrics(strconv.Itoa(maxCachedSizes - 1)); ok {
+		t.Fatal("clearSizeCache kept a size")
+	}
+}
@@ -1,9 +1,12 @@
 package text
 
 import (
+	"errors"
 	"image/color"
 	"runtime"
 
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/internal/platform"
 	pb "gomatcha.io/matcha/proto"
 	pbtext "gomatcha.io/matcha/proto/text"
 )
@@ -55,7 +58,7 @@ func (a UnderlineStyle) MarshalProtobuf() pbtext.UnderlineStyle {
 }
 
 func DefaultFont(size float64) *Font {
-	if runtime.GOOS == "android" {
+	if platform.UsesJavaStyleBridge() {
 		return FontWithName("sans-serif", size)
 	} else if runtime.GOOS == "darwin" {
 		return FontWithName("HelveticaNeue", size)
@@ -64,7 +67,7 @@ func DefaultFont(size float64) *Font {
 }
 
 func DefaultBoldFont(size float64) *Font {
-	if runtime.GOOS == "android" {
+	if platform.UsesJavaStyleBridge() {
 		return FontWithName("sans-serif-bold", size)
 	} else if runtime.GOOS == "darwin" {
 		return FontWithName("HelveticaNeue-Bold", size)
@@ -72,8 +75,17 @@ func DefaultBoldFont(size float64) *Font {
 	return &Font{}
 }
 
+func DefaultMonospaceFont(size float64) *Font {
+	if platform.UsesJavaStyleBridge() {
+		return FontWithName("monospace", size)
+	} else if runtime.GOOS == "darwin" {
+		return FontWithName("Menlo-Regular", size)
+	}
+	return &Font{}
+}
+
 func DefaultItalicFont(size float64) *Font {
-	if runtime.GOOS == "android" {
+	if platform.UsesJavaStyleBridge() {
 		return FontWithName("sans-serif-italic", size)
 	} else if runtime.GOOS == "darwin" {
 		return FontWithName("HelveticaNeue-Italic", size)
@@ -88,6 +100,33 @@ func FontWithName(name string, size float64) *Font {
 	}
 }
 
+// RegisterFont registers the TrueType or OpenType font in data under name, so
+// that FontWithName(name, size) displays it. Fonts bundled as Go resources can
+// be used without adding them to the iOS and Android projects. Register fonts
+// before the views that use them are built.
+//
+//  data, _ := ioutil.ReadFile("fonts/Lobster.ttf")
+//  if err := text.RegisterFont("Lobster", data); err != nil {
+//      ...
+//  }
+//  style.SetFont(text.FontWithName("Lobster", 24))
+func RegisterFont(name string, data []byte) error {
+	if name == "" || len(data) == 0 {
+		return errors.New("text: RegisterFont requires a name and font data")
+	}
+	var msg string
+	if platform.UsesJavaStyleBridge() {
+		msg = bridge.Bridge("").CallNow("registerFont", bridge.String(name), bridge.Bytes(data)).ToString()
+	} else if runtime.GOOS == "darwin" {
+		msg = bridge.Bridge("").CallNow("registerFont:data:", bridge.String(name), bridge.Bytes(data)).ToString()
+	}
+	if msg != "" {
+		return errors.New(msg)
+	}
+	clearSizeCache()
+	return nil
+}
+
 // StrikethroughStyle represents a text font.
 type Font struct {
 	name string // Postscript name
@@ -128,6 +167,23 @@ func (a Truncation) MarshalProtobuf() pbtext.Truncation {
 	return pbtext.Truncation(a)
 }
 
+// WritingDirection is the base direction of a paragraph, which orders its
+// runs of left-to-right and right-to-left text.
+type WritingDirection int
+
+const (
+	// WritingDirectionNatural takes the direction from the first strong
+	// character of the paragraph, so Arabic and Hebrew paragraphs are right to
+	// left.
+	WritingDirectionNatural WritingDirection = iota
+	WritingDirectionLeftToRight
+	WritingDirectionRightToLeft
+)
+
+func (a WritingDirection) MarshalProtobuf() pbtext.WritingDirection {
+	return pbtext.WritingDirection(a)
+}
+
 type styleKey int
 
 const (
@@ -144,6 +200,14 @@ const (
 	styleKeyWrap
 	styleKeyTruncation
 	styleKeyTruncationString
+	styleKeyBackgroundColor
+	styleKeyOnTap
+	styleKeyLink
+	styleKeyWritingDirection
+	styleKeyLetterSpacing
+	styleKeyLineHeight
+	styleKeyParagraphSpacing
+	styleKeyFirstLineIndent
 )
 
 // Style holds a group of text formatting options.
@@ -198,6 +262,16 @@ func (f *Style) get(k styleKey) interface{} {
 		return TruncationNone
 	case styleKeyTruncationString:
 		return "…"
+	case styleKeyBackgroundColor:
+		return color.Color(nil)
+	case styleKeyOnTap:
+		return (func())(nil)
+	case styleKeyLink:
+		return ""
+	case styleKeyWritingDirection:
+		return WritingDirectionNatural
+	case styleKeyLetterSpacing, styleKeyLineHeight, styleKeyParagraphSpacing, styleKeyFirstLineIndent:
+		return float64(0.0)
 	}
 	return nil
 }
@@ -217,11 +291,14 @@ func (f *Style) copy() *Style {
 		attributes: map[styleKey]interface{}{},
 		cleared:    map[styleKey]bool{},
 	}
+	if f == nil {
+		return c
+	}
 	for k, v := range f.attributes {
 		c.attributes[k] = v
 	}
 	for k, v := range f.cleared {
-		c.attributes[k] = v
+		c.cleared[k] = v
 	}
 	return c
 }
@@ -254,6 +331,11 @@ func (f *Style) MarshalProtobuf() *pbtext.TextStyle {
 		Wrap:               f.get(styleKeyWrap).(Wrap).MarshalProtobuf(),
 		Truncation:         f.get(styleKeyTruncation).(Truncation).MarshalProtobuf(),
 		TruncationString:   f.get(styleKeyTruncationString).(string),
+		WritingDirection:   f.get(styleKeyWritingDirection).(WritingDirection).MarshalProtobuf(),
+		LetterSpacing:      f.get(styleKeyLetterSpacing).(float64),
+		LineHeight:         f.get(styleKeyLineHeight).(float64),
+		ParagraphSpacing:   f.get(styleKeyParagraphSpacing).(float64),
+		FirstLineIndent:    f.get(styleKeyFirstLineIndent).(float64),
 	}
 }
 
@@ -329,6 +411,9 @@ func (f *Style) ClearFont() {
 	f.clear(styleKeyFont)
 }
 
+// Hyphenation returns how eagerly words are hyphenated at the end of lines,
+// from 0, which never hyphenates, to 1. On Android it applies to the whole
+// text view, and requires Android 6.0.
 func (f *Style) Hyphenation() float64 {
 	return f.get(styleKeyHyphenation).(float64)
 }
@@ -341,6 +426,8 @@ func (f *Style) ClearHyphenation() {
 	f.clear(styleKeyHyphenation)
 }
 
+// LineHeightMultiple returns the factor that the natural height of lines is
+// multiplied by.
 func (f *Style) LineHeightMultiple() float64 {
 	return f.get(styleKeyLineHeightMultiple).(float64)
 }
@@ -353,6 +440,62 @@ func (f *Style) ClearLineHeightMultiple() {
 	f.clear(styleKeyLineHeightMultiple)
 }
 
+// LineHeight returns the height of lines in points, or 0 to use the natural
+// height of the font. If it is set, LineHeightMultiple is ignored.
+func (f *Style) LineHeight() float64 {
+	return f.get(styleKeyLineHeight).(float64)
+}
+
+func (f *Style) SetLineHeight(v float64) {
+	f.set(styleKeyLineHeight, v)
+}
+
+func (f *Style) ClearLineHeight() {
+	f.clear(styleKeyLineHeight)
+}
+
+// LetterSpacing returns the space added between characters, in points.
+// Negative values tighten the text. On Android it requires Android 5.0.
+func (f *Style) LetterSpacing() float64 {
+	return f.get(styleKeyLetterSpacing).(float64)
+}
+
+func (f *Style) SetLetterSpacing(v float64) {
+	f.set(styleKeyLetterSpacing, v)
+}
+
+func (f *Style) ClearLetterSpacing() {
+	f.clear(styleKeyLetterSpacing)
+}
+
+// ParagraphSpacing returns the space added after the paragraphs that end with
+// this style, in points.
+func (f *Style) ParagraphSpacing() float64 {
+	return f.get(styleKeyParagraphSpacing).(float64)
+}
+
+func (f *Style) SetParagraphSpacing(v float64) {
+	f.set(styleKeyParagraphSpacing, v)
+}
+
+func (f *Style) ClearParagraphSpacing() {
+	f.clear(styleKeyParagraphSpacing)
+}
+
+// FirstLineIndent returns the indent of the first line of the paragraphs that
+// start with this style, in points.
+func (f *Style) FirstLineIndent() float64 {
+	return f.get(styleKeyFirstLineIndent).(float64)
+}
+
+func (f *Style) SetFirstLineIndent(v float64) {
+	f.set(styleKeyFirstLineIndent, v)
+}
+
+func (f *Style) ClearFirstLineIndent() {
+	f.clear(styleKeyFirstLineIndent)
+}
+
 func (f *Style) TextColor() color.Color {
 	return f.get(styleKeyTextColor).(color.Color)
 }
@@ -400,3 +543,61 @@ func (f *Style) SetTruncationString(v string) {
 func (f *Style) ClearTruncationString() {
 	f.clear(styleKeyTruncationString)
 }
+
+// BackgroundColor returns the color behind the text, or nil if there is none.
+func (f *Style) BackgroundColor() color.Color {
+	c, _ := f.get(styleKeyBackgroundColor).(color.Color)
+	return c
+}
+
+func (f *Style) SetBackgroundColor(v color.Color) {
+	f.set(styleKeyBackgroundColor, v)
+}
+
+func (f *Style) ClearBackgroundColor() {
+	f.clear(styleKeyBackgroundColor)
+}
+
+// OnTap returns the function called when the text is tapped, or nil if it
+// isn't tappable. Text views call it on the main thread.
+func (f *Style) OnTap() func() {
+	return f.get(styleKeyOnTap).(func())
+}
+
+func (f *Style) SetOnTap(v func()) {
+	f.set(styleKeyOnTap, v)
+}
+
+func (f *Style) ClearOnTap() {
+	f.clear(styleKeyOnTap)
+}
+
+// WritingDirection returns the base direction of the paragraphs that start
+// with this style. AlignmentLeft aligns text to the start of its direction, so
+// right-to-left paragraphs are aligned to the right. On Android the direction
+// of the whole text is taken from its first paragraph.
+func (f *Style) WritingDirection() WritingDirection {
+	return f.get(styleKeyWritingDirection).(WritingDirection)
+}
+
+func (f *Style) SetWritingDirection(v WritingDirection) {
+	f.set(styleKeyWritingDirection, v)
+}
+
+func (f *Style) ClearWritingDirection() {
+	f.clear(styleKeyWritingDirection)
+}
+
+// Link returns the URL that the text links to, or "" if it isn't a link. Text
+// views make links tappable, and open them when they are tapped.
+func (f *Style) Link() string {
+	return f.get(styleKeyLink).(string)
+}
+
+func (f *Style) SetLink(v string) {
+	f.set(styleKeyLink, v)
+}
+
+func (f *Style) ClearLink() {
+	f.clear(styleKeyLink)
+}
@@ -6,8 +6,8 @@ import (
 
 	"github.com/gogo/protobuf/proto"
 	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/internal/platform"
 	"gomatcha.io/matcha/layout"
-	pb "gomatcha.io/matcha/proto/layout"
 	pbtext "gomatcha.io/matcha/proto/text"
 )
 
@@ -31,6 +31,45 @@ func NewStyledText(str string, s *Style) *StyledText {
 	return st
 }
 
+// Span is a range of a StyledText with a single style. End is exclusive.
+type Span struct {
+	Start int
+	End   int
+	Style *Style
+}
+
+// Spans returns the ranges of st in order, with copies of their styles.
+func (st *StyledText) Spans() []Span {
+	spans := []Span{}
+	for idx, i := range st.styles {
+		end := st.text.runeCount
+		if idx < len(st.styles)-1 {
+			end = st.styles[idx+1].index
+		}
+		if end > i.index {
+			spans = append(spans, Span{Start: i.index, End: end, Style: i.style.copy()})
+		}
+	}
+	return spans
+}
+
+// String returns the unstyled text.
+func (st *StyledText) String() string {
+	return st.text.String()
+}
+
+// Copy returns a copy of st, which can be styled without changing st.
+func (st *StyledText) Copy() *StyledText {
+	c := &StyledText{
+		text:   New(st.text.String()),
+		styles: make([]styleRange, len(st.styles)),
+	}
+	for idx, i := range st.styles {
+		c.styles[idx] = styleRange{index: i.index, style: i.style.copy()}
+	}
+	return c
+}
+
 // func (st *StyledText) Text() *Text {
 // 	return st.text
 // }
@@ -107,7 +146,26 @@ func (st *StyledText) update(f func(*Style) *Style, start, end int) {
 	st.styles = styles
 }
 
+// Metrics holds the measurements of laid out text.
+type Metrics struct {
+	Size layout.Point
+	// FirstBaseline is the distance from the top of the text to the baseline
+	// of its first line.
+	FirstBaseline float64
+	// LastBaseline is the distance from the top of the text to the baseline of
+	// its last line.
+	LastBaseline float64
+}
+
+// Size measures st laid out between min and max, with at most maxLines lines,
+// or any number of lines if it is 0. Sizes are cached, see Measure.
 func (st *StyledText) Size(min layout.Point, max layout.Point, maxLines int) layout.Point {
+	return st.Metrics(min, max, maxLines).Size
+}
+
+// Metrics measures st like Size, and also returns the baselines of its first
+// and last lines.
+func (st *StyledText) Metrics(min layout.Point, max layout.Point, maxLines int) Metrics {
 	if st.text.String() == "" {
 		st = &StyledText{
 			text: New("A"),
@@ -122,22 +180,32 @@ func (st *StyledText) Size(min layout.Point, max layout.Point, maxLines int) lay
 	}
 	data, err := proto.Marshal(sizeFunc)
 	if err != nil {
-		return layout.Pt(0, 0)
+		return Metrics{}
+	}
+	key := sizeCacheKey(data, maxLines)
+	if m, ok := cachedMetrics(key); ok {
+		return m
 	}
 
-	var pointData []byte
-	if runtime.GOOS == "android" {
-		pointData = bridge.Bridge("").Call("sizeForStyledText", bridge.Bytes(data), bridge.Int64(int64(maxLines))).ToInterface().([]byte)
+	var metricsData []byte
+	if platform.UsesJavaStyleBridge() {
+		metricsData = bridge.Bridge("").CallNow("sizeForStyledText", bridge.Bytes(data), bridge.Int64(int64(maxLines))).ToInterface().([]byte)
 	} else if runtime.GOOS == "darwin" {
-		pointData = bridge.Bridge("").Call("sizeForAttributedString:maxLines:", bridge.Bytes(data), bridge.Int64(int64(maxLines))).ToInterface().([]byte)
+		metricsData = bridge.Bridge("").CallNow("sizeForAttributedString:maxLines:", bridge.Bytes(data), bridge.Int64(int64(maxLines))).ToInterface().([]byte)
 	}
-	pbpoint := &pb.Point{}
-	err = proto.Unmarshal(pointData, pbpoint)
+	pbmetrics := &pbtext.TextMetrics{}
+	err = proto.Unmarshal(metricsData, pbmetrics)
 	if err != nil {
-		fmt.Println("StyledText.Size(): Decode error", err)
-		return layout.Pt(0, 0)
+		fmt.Println("StyledText.Metrics(): Decode error", err)
+		return Metrics{}
+	}
+	m := Metrics{
+		Size:          layout.Pt(pbmetrics.Width, pbmetrics.Height),
+		FirstBaseline: pbmetrics.FirstBaseline,
+		LastBaseline:  pbmetrics.LastBaseline,
 	}
-	return layout.Pt(pbpoint.X, pbpoint.Y)
+	cacheMetrics(key, m)
+	return m
 }
 
 func (st *StyledText) MarshalProtobuf() *pbtext.StyledText {
@@ -145,10 +213,13 @@ func (st *StyledText) MarshalProtobuf() *pbtext.StyledText {
 		return nil
 	}
 
+	// Native views index text by UTF-16 code units, so the byte indices of
+	// non-ASCII text are converted.
+	str := st.text.String()
 	styles := []*pbtext.TextStyle{}
 	for _, i := range st.styles {
 		style := i.style.MarshalProtobuf()
-		style.Index = int64(i.index)
+		style.Index = int64(UTF16Index(str, i.index))
 		styles = append(styles, style)
 	}
 
@@ -1,5 +1,10 @@
 package text
 
+import (
+	"image/color"
+	"testing"
+)
+
 // func TestXxx(t *testing.T) {
 // 	text := New("cafe\u0301")
 // 	if text.ByteAt(3) != byte('e') {
@@ -14,3 +19,35 @@ package text
 // 		t.Error("Incorrect glyph", text.GlyphAt(4))
 // 	}
 // }
+
+func TestStyledTextSpans(t *testing.T) {
+	tapped := false
+	link := &Style{}
+	link.SetBackgroundColor(color.White)
+	link.SetOnTap(func() { tapped = true })
+
+	st := NewStyledText("Read the docs", &Style{})
+	st.Update(link, 9, 12)
+
+	spans := st.Spans()
+	if len(spans) != 2 || spans[0].Start != 0 || spans[0].End != 9 || spans[1].Start != 9 || spans[1].End != 13 {
+		t.Fatalf("Spans = %v", spans)
+	}
+	if spans[0].Style.BackgroundColor() != nil || spans[0].Style.OnTap() != nil {
+		t.Error("Unstyled span has a background or tap handler")
+	}
+	if spans[1].Style.BackgroundColor() != color.White {
+		t.Error("BackgroundColor =", spans[1].Style.BackgroundColor())
+	}
+	st.At(10).OnTap()()
+	if !tapped {
+		t.Error("OnTap wasn't called")
+	}
+
+	clear := &Style{}
+	clear.ClearOnTap()
+	st.Update(clear, 9, 12)
+	if st.At(10).OnTap() != nil || st.At(10).BackgroundColor() != color.White {
+		t.Error("Clearing OnTap changed the other attributes")
+	}
+}
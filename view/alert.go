@@ -5,6 +5,7 @@ import (
 
 	"github.com/gogo/protobuf/proto"
 	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/internal/platform"
 	pbview "gomatcha.io/matcha/proto/view"
 )
 
@@ -13,6 +14,7 @@ var alerts map[int64]*_alert
 
 func init() {
 	alerts = map[int64]*_alert{}
+	bridge.MainThreadOnly("displayAlert", "displayAlert:")
 	bridge.RegisterFunc("gomatcha.io/matcha/view/alert onPress", func(id, idx int64) {
 		alert, ok := alerts[id]
 		if !ok {
@@ -53,7 +55,7 @@ func (a *_alert) display() {
 	if err != nil {
 		return
 	}
-	if runtime.GOOS == "android" {
+	if platform.UsesJavaStyleBridge() {
 		bridge.Bridge("").Call("displayAlert", bridge.Bytes(data))
 	} else if runtime.GOOS == "darwin" {
 		bridge.Bridge("").Call("displayAlert:", bridge.Bytes(data))
@@ -61,6 +63,7 @@ func (a *_alert) display() {
 }
 
 // Alert displays an alert with the given title, message and buttons. If no buttons are passed, a default OK button is created.
+// See package view/alert for action sheets and text prompts.
 func Alert(title, message string, buttons ...*AlertButton) {
 	if len(buttons) == 0 {
 		buttons = []*AlertButton{&AlertButton{Title: "OK"}}
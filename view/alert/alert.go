@@ -0,0 +1,169 @@
+// Package alert presents native alerts, action sheets and text prompts, with
+// UIAlertController on iOS and AlertDialog on Android.
+//
+// The pressed button is delivered to its OnPress callback, and to the returned
+// channel.
+//
+//  delete := &alert.Button{Title: "Delete", Style: alert.ButtonStyleDestructive, OnPress: v.delete}
+//  cancel := &alert.Button{Title: "Cancel", Style: alert.ButtonStyleCancel}
+//  alert.ActionSheet("Delete photo?", "", delete, cancel)
+//
+// Prompts copy the entered text back into their text fields before the button
+// is delivered.
+//
+//  name := &alert.TextField{Placeholder: "Name"}
+//  ok := &alert.Button{Title: "OK", OnPress: func() {
+//      v.rename(name.Text)
+//  }}
+//  alert.Prompt("Rename", "", []*alert.TextField{name}, ok)
+package alert
+
+import (
+	"runtime"
+
+	"gomatcha.io/matcha/bridge"
+)
+
+type style int
+
+const (
+	styleAlert style = iota
+	styleActionSheet
+)
+
+var dialogMaxId int64
+var dialogs map[int64]*dialog
+
+func init() {
+	dialogs = map[int64]*dialog{}
+	bridge.MainThreadOnly("presentDialog", "presentDialog:style:title:message:buttons:fields:")
+	bridge.RegisterFunc("gomatcha.io/matcha/view/alert onDismiss", func(id, button int64, text []string) {
+		d, ok := dialogs[id]
+		if !ok {
+			return
+		}
+		delete(dialogs, id)
+		d.dismiss(int(button), text)
+	})
+}
+
+// ButtonStyle is the appearance of a Button.
+type ButtonStyle int
+
+const (
+	ButtonStyleDefault ButtonStyle = iota
+	// ButtonStyleCancel buttons are pressed when the dialog is dismissed
+	// without choosing a button, for example by tapping outside of an action
+	// sheet. A dialog has at most one cancel button.
+	ButtonStyleCancel
+	// ButtonStyleDestructive buttons are displayed in red on iOS.
+	ButtonStyleDestructive
+)
+
+// Button is a button of a dialog.
+type Button struct {
+	Title   string
+	Style   ButtonStyle
+	OnPress func()
+}
+
+// TextField is a text field of a prompt.
+type TextField struct {
+	Placeholder string
+	// Text is the initial text of the field. It is set to the entered text when
+	// the prompt is dismissed.
+	Text string
+	// Secure hides the entered text, for passwords.
+	Secure bool
+}
+
+// Result describes how a dialog was dismissed.
+type Result struct {
+	// Button is the index of the pressed button, or -1 if the dialog was
+	// dismissed without choosing one and has no cancel button.
+	Button int
+	// Text is the text entered in each text field of a prompt.
+	Text []string
+}
+
+type dialog struct {
+	style   style
+	title   string
+	message string
+	buttons []*Button
+	fields  []*TextField
+	c       chan Result
+}
+
+// Alert displays an alert with the given title, message and buttons. If no
+// buttons are passed, an OK button is added. The returned channel receives the
+// result once the alert is dismissed.
+func Alert(title, message string, buttons ...*Button) <-chan Result {
+	return present(&dialog{style: styleAlert, title: title, message: message, buttons: buttons})
+}
+
+// ActionSheet displays an action sheet with the given title, message and
+// buttons. The title and message may be empty. The returned channel receives
+// the result once the action sheet is dismissed.
+func ActionSheet(title, message string, buttons ...*Button) <-chan Result {
+	return present(&dialog{style: styleActionSheet, title: title, message: message, buttons: buttons})
+}
+
+// Prompt displays an alert with text fields. If no buttons are passed, an OK
+// button is added. The returned channel receives the result, including the
+// entered text, once the prompt is dismissed.
+func Prompt(title, message string, fields []*TextField, buttons ...*Button) <-chan Result {
+	return present(&dialog{style: styleAlert, title: title, message: message, buttons: buttons, fields: fields})
+}
+
+func present(d *dialog) <-chan Result {
+	d.c = make(chan Result, 1)
+	if d.style == styleAlert && len(d.buttons) == 0 {
+		d.buttons = []*Button{&Button{Title: "OK"}}
+	}
+	if runtime.GOOS != "android" && runtime.GOOS != "darwin" {
+		d.dismiss(-1, nil)
+		return d.c
+	}
+
+	dialogMaxId += 1
+	dialogs[dialogMaxId] = d
+
+	buttons := []*bridge.Value{}
+	for _, i := range d.buttons {
+		buttons = append(buttons, bridge.Array(bridge.String(i.Title), bridge.Int64(int64(i.Style))))
+	}
+	fields := []*bridge.Value{}
+	for _, i := range d.fields {
+		fields = append(fields, bridge.Array(bridge.String(i.Placeholder), bridge.String(i.Text), bridge.Bool(i.Secure)))
+	}
+	args := []*bridge.Value{
+		bridge.Int64(dialogMaxId),
+		bridge.Int64(int64(d.style)),
+		bridge.String(d.title),
+		bridge.String(d.message),
+		bridge.Array(buttons...),
+		bridge.Array(fields...),
+	}
+	if runtime.GOOS == "android" {
+		bridge.Bridge("").Call("presentDialog", args...)
+	} else {
+		bridge.Bridge("").Call("presentDialog:style:title:message:buttons:fields:", args...)
+	}
+	return d.c
+}
+
+func (d *dialog) dismiss(button int, text []string) {
+	for idx, i := range d.fields {
+		if idx < len(text) {
+			i.Text = text[idx]
+		}
+	}
+	if button < -1 || button >= len(d.buttons) {
+		button = -1
+	}
+	if button >= 0 && d.buttons[button].OnPress != nil {
+		d.buttons[button].OnPress()
+	}
+	d.c <- Result{Button: button, Text: text}
+}
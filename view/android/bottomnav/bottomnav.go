@@ -0,0 +1,250 @@
+// Package bottomnav implements a Material bottom navigation bar. Each item
+// displays its own android.StackView, so switching between items keeps the
+// screens that were pushed onto each of them.
+//
+//  home := bottomnav.NewItem("Home", homeIcon, NewHomeView(app))
+//  home.Router = router.New(home.Stack)
+//  home.Router.Handle("/", ...)
+//  search := bottomnav.NewItem("Search", searchIcon, NewSearchView(app))
+//
+//  v := bottomnav.New()
+//  v.Items = []*bottomnav.Item{home, search}
+//  v.Selected = &app.tab // *comm.IntValue
+//
+// Deep links are opened in the first item whose router has a route for them.
+//
+//  idx, err := bottomnav.Open(items, "myapp://users/42")
+//  if err == nil {
+//      app.tab.SetValue(idx)
+//  }
+package bottomnav
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"strconv"
+
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/internal"
+	"gomatcha.io/matcha/layout/constraint"
+	"gomatcha.io/matcha/text"
+	"gomatcha.io/matcha/view"
+	"gomatcha.io/matcha/view/android"
+	"gomatcha.io/matcha/view/router"
+)
+
+// barHeight is the height of a Material bottom navigation bar.
+const barHeight = 56
+
+// Item is a destination of the bar.
+type Item struct {
+	Title string
+	Icon  image.Image
+	// Badge is displayed over the icon.
+	Badge string
+	// BadgeCount, if set, is displayed as the badge instead of Badge. The badge
+	// is hidden while the count is 0.
+	BadgeCount comm.IntNotifier
+	// Stack holds the screens of the item. It is displayed with an
+	// android.StackView while the item is selected.
+	Stack *android.Stack
+	// Router, if set, is used by Open to display deep links in the item. It
+	// should push onto Stack.
+	Router *router.Router
+}
+
+// NewItem returns an item whose stack displays root.
+func NewItem(title string, icon image.Image, root view.View) *Item {
+	s := &android.Stack{}
+	s.SetViews(root)
+	return &Item{
+		Title: title,
+		Icon:  icon,
+		Stack: s,
+	}
+}
+
+func (i *Item) badge() string {
+	if i.BadgeCount != nil {
+		if c := i.BadgeCount.Value(); c != 0 {
+			return strconv.Itoa(c)
+		}
+		return ""
+	}
+	return i.Badge
+}
+
+// Open opens rawurl with the router of the first item that has a route for it,
+// and returns the index of that item. It returns an error if none of the items
+// can open rawurl.
+func Open(items []*Item, rawurl string) (int, error) {
+	for idx, i := range items {
+		if i.Router == nil {
+			continue
+		}
+		if err := i.Router.Open(rawurl); err == nil {
+			return idx, nil
+		}
+	}
+	return -1, errors.New("bottomnav: no item can open " + rawurl)
+}
+
+// View displays the stack of the selected item above a bottom navigation bar.
+type View struct {
+	view.Embed
+	Items []*Item
+	// Selected is the index of the selected item. It is set when the user
+	// selects an item, and setting it displays the item.
+	Selected *comm.IntValue
+	// OnReselect is called when the user selects the item that is already
+	// selected. If nil, the item's stack is popped to its root view.
+	OnReselect func(index int)
+	// BarColor is the background color of the bar. If nil, it is white.
+	BarColor color.Color
+	// SelectedColor is the color of the selected item. If nil, the theme's
+	// primary color is used.
+	SelectedColor color.Color
+	// UnselectedColor is the color of the other items. If nil, they are gray.
+	UnselectedColor color.Color
+	// StackBarColor and StackTitleStyle are passed to the stack views of the
+	// items.
+	StackBarColor   color.Color
+	StackTitleStyle *text.Style
+
+	prevSelected *comm.IntValue
+	notifiers    []comm.Notifier // BadgeCount of the items.
+}
+
+// New returns a new view.
+func New() *View {
+	return &View{
+		Selected: &comm.IntValue{},
+	}
+}
+
+// Lifecycle implements the view.View interface.
+func (v *View) Lifecycle(from, to view.Stage) {
+	if view.ExitsStage(from, to, view.StageMounted) {
+		v.Unsubscribe(v.prevSelected)
+		v.subscribe(nil)
+	}
+}
+
+// Update implements the view.View interface.
+func (v *View) Update(v2 view.View) {
+	view.CopyFields(v, v2)
+	if v.Selected == nil {
+		v.Selected = &comm.IntValue{}
+	}
+}
+
+// Build implements the view.View interface.
+func (v *View) Build(ctx view.Context) view.Model {
+	if v.Selected != v.prevSelected {
+		if v.prevSelected != nil {
+			v.Unsubscribe(v.prevSelected)
+		}
+		v.Subscribe(v.Selected)
+		v.prevSelected = v.Selected
+	}
+
+	selected := v.Selected.Value()
+	if selected < 0 || selected >= len(v.Items) {
+		selected = 0
+	}
+
+	titles := make([]string, len(v.Items))
+	icons := make([][]byte, len(v.Items))
+	badges := make([]string, len(v.Items))
+	notifiers := []comm.Notifier{}
+	for idx, i := range v.Items {
+		titles[idx] = i.Title
+		if i.Icon != nil {
+			icons[idx] = internal.MarshalProtobuf(internal.ImageMarshalProtobuf(i.Icon))
+		}
+		badges[idx] = i.badge()
+		if i.BadgeCount != nil {
+			notifiers = append(notifiers, i.BadgeCount)
+		}
+	}
+	v.subscribe(notifiers)
+
+	l := &constraint.Layouter{}
+	if selected < len(v.Items) {
+		// The stack view is keyed by item, so that each item keeps its own
+		// native stack.
+		stack := android.NewStackView()
+		stack.Key = strconv.Itoa(selected)
+		stack.Stack = v.Items[selected].Stack
+		stack.BarColor = v.StackBarColor
+		stack.TitleStyle = v.StackTitleStyle
+		l.Add(stack, func(s *constraint.Solver) {
+			s.Top(0)
+			s.Left(0)
+			s.WidthEqual(l.MaxGuide().Width())
+			s.HeightEqual(l.MaxGuide().Height().Add(-barHeight))
+		})
+	}
+
+	return view.Model{
+		Children:       l.Views(),
+		Layouter:       l,
+		NativeViewName: "gomatcha.io/matcha/view/android/bottomnav",
+		NativeFuncs: map[string]interface{}{
+			// State returns the title, icon and badge of each item, the
+			// selected index, and the bar, selected and unselected colors as
+			// ARGB, or -1 for the default colors.
+			"State": func() ([]string, [][]byte, []string, int64, int64, int64, int64) {
+				return titles, icons, badges, int64(selected), argb(v.BarColor), argb(v.SelectedColor), argb(v.UnselectedColor)
+			},
+			"OnSelect": func(index int64) {
+				if index < 0 || int(index) >= len(v.Items) {
+					return
+				}
+				v.Selected.SetValue(int(index))
+			},
+			"OnReselect": func(index int64) {
+				if index < 0 || int(index) >= len(v.Items) {
+					return
+				}
+				if v.OnReselect != nil {
+					v.OnReselect(int(index))
+					return
+				}
+				s := v.Items[index].Stack
+				for len(s.Views()) > 1 {
+					s.Pop()
+				}
+			},
+		},
+	}
+}
+
+// subscribe updates the view's subscriptions to the badge counts of its items.
+func (v *View) subscribe(notifiers []comm.Notifier) {
+	for _, i := range v.notifiers {
+		found := false
+		for _, j := range notifiers {
+			if i == j {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.Unsubscribe(i)
+		}
+	}
+	for _, i := range notifiers {
+		v.Subscribe(i)
+	}
+	v.notifiers = notifiers
+}
+
+func argb(c color.Color) int64 {
+	if c == nil {
+		return -1
+	}
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return int64(n.A)<<24 | int64(n.R)<<16 | int64(n.G)<<8 | int64(n.B)
+}
@@ -0,0 +1,156 @@
+// Package drawer implements a navigation drawer, a menu that slides over the
+// content from the left edge of the screen. It displays a DrawerLayout on
+// Android, and an equivalent slide-over on iOS.
+//
+//  v := drawer.New()
+//  v.Menu = NewMenuView(app)
+//  v.Content = NewContentView(app)
+//  v.Open = &app.drawerOpen // *comm.BoolValue
+//
+// The drawer is opened by swiping from the left edge or by setting Open, and
+// closed by swiping it back, tapping the dimmed content or setting Open.
+package drawer
+
+import (
+	"image/color"
+	"math"
+
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+)
+
+// View displays Content, with Menu in a drawer above it.
+type View struct {
+	view.Embed
+	Menu    view.View
+	Content view.View
+	// Open is whether the drawer is open. It is set when the user opens or
+	// closes the drawer, and setting it animates the drawer.
+	Open *comm.BoolValue
+	// MenuWidth is the width of the drawer. It is 280 by default, and never
+	// wider than the view minus 56 points, so that the content can be tapped.
+	MenuWidth float64
+	// ScrimColor dims the content while the drawer is open. If nil, it is
+	// translucent black.
+	ScrimColor color.Color
+	// Locked prevents the drawer from being opened or closed by swiping. It
+	// can still be opened and closed with Open.
+	Locked     bool
+	PaintStyle *paint.Style
+
+	prevOpen *comm.BoolValue
+}
+
+// New returns a new view.
+func New() *View {
+	return &View{
+		Open:      &comm.BoolValue{},
+		MenuWidth: 280,
+	}
+}
+
+// Lifecycle implements the view.View interface.
+func (v *View) Lifecycle(from, to view.Stage) {
+	if view.ExitsStage(from, to, view.StageMounted) {
+		v.Unsubscribe(v.prevOpen)
+	}
+}
+
+// Update implements the view.View interface.
+func (v *View) Update(v2 view.View) {
+	view.CopyFields(v, v2)
+	if v.Open == nil {
+		v.Open = &comm.BoolValue{}
+	}
+}
+
+// Build implements the view.View interface.
+func (v *View) Build(ctx view.Context) view.Model {
+	if v.Open != v.prevOpen {
+		if v.prevOpen != nil {
+			v.Unsubscribe(v.prevOpen)
+		}
+		v.Subscribe(v.Open)
+		v.prevOpen = v.Open
+	}
+
+	// The content is always the first child, and the menu the second.
+	children := []view.View{}
+	if v.Content != nil {
+		children = append(children, v.Content)
+	} else {
+		children = append(children, view.NewBasicView())
+	}
+	if v.Menu != nil {
+		children = append(children, v.Menu)
+	}
+
+	l := &layouter{menuWidth: v.MenuWidth}
+	scrim := int64(0x66000000)
+	if v.ScrimColor != nil {
+		n := color.NRGBAModel.Convert(v.ScrimColor).(color.NRGBA)
+		scrim = int64(n.A)<<24 | int64(n.R)<<16 | int64(n.G)<<8 | int64(n.B)
+	}
+
+	var painter paint.Painter
+	if v.PaintStyle != nil {
+		painter = v.PaintStyle
+	}
+	return view.Model{
+		Children:       children,
+		Layouter:       l,
+		Painter:        painter,
+		NativeViewName: "gomatcha.io/matcha/view/android/drawer",
+		NativeFuncs: map[string]interface{}{
+			// State returns whether the drawer is open, its width, the scrim
+			// color as ARGB, and whether it is locked.
+			"State": func() (bool, float64, int64, bool) {
+				return v.Open.Value(), l.width, scrim, v.Locked
+			},
+			"OnChange": func(open bool) {
+				v.Open.SetValue(open)
+			},
+		},
+	}
+}
+
+// menuWidth returns the width of the drawer in a view of the given width.
+func menuWidth(width, viewWidth float64) float64 {
+	if width <= 0 {
+		width = 280
+	}
+	return math.Max(math.Min(width, viewWidth-56), 0)
+}
+
+// layouter gives the content the view's size, and the menu the drawer's
+// width. The native views position the menu.
+type layouter struct {
+	menuWidth float64
+	width     float64 // The width of the drawer, once laid out.
+}
+
+func (l *layouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	size := ctx.MinSize()
+	l.width = menuWidth(l.menuWidth, size.X)
+	gs := make([]layout.Guide, ctx.ChildCount())
+	for i := range gs {
+		s := size
+		if i == 1 {
+			s.X = l.width
+		}
+		g := ctx.LayoutChild(i, s, s)
+		g.Frame = layout.Rt(0, 0, s.X, s.Y)
+		gs[i] = g
+	}
+	return layout.Guide{Frame: layout.Rt(0, 0, size.X, size.Y)}, gs
+}
+
+func (l *layouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+func (l *layouter) Unnotify(id comm.Id) {
+	// no-op
+}
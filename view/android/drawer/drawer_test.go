@@ -0,0 +1,19 @@
+package drawer
+
+import "testing"
+
+func TestMenuWidth(t *testing.T) {
+	for _, tc := range []struct {
+		width, viewWidth, want float64
+	}{
+		{280, 400, 280},
+		{0, 400, 280},
+		{280, 320, 264},
+		{500, 1024, 500},
+		{280, 40, 0},
+	} {
+		if got := menuWidth(tc.width, tc.viewWidth); got != tc.want {
+			t.Errorf("menuWidth(%v, %v) = %v, want %v", tc.width, tc.viewWidth, got, tc.want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package android
 
 import (
+	"strconv"
+
 	"gomatcha.io/matcha/comm"
 	"gomatcha.io/matcha/internal"
 	"gomatcha.io/matcha/layout/constraint"
@@ -59,6 +61,8 @@ func (s *Pages) Unnotify(id comm.Id) {
 type PagerView struct {
 	view.Embed
 	Pages *Pages
+
+	notifiers []comm.Notifier // BadgeCount and Visible of the page buttons.
 }
 
 // NewPagerView returns a new view.
@@ -78,6 +82,10 @@ func (v *PagerView) Lifecycle(from, to view.Stage) {
 		v.Subscribe(v.Pages)
 	} else if view.ExitsStage(from, to, view.StageMounted) {
 		v.Unsubscribe(v.Pages)
+		for _, i := range v.notifiers {
+			v.Unsubscribe(i)
+		}
+		v.notifiers = nil
 	}
 }
 
@@ -97,7 +105,12 @@ func (v *PagerView) Build(ctx view.Context) view.Model {
 	l := &constraint.Layouter{}
 
 	childrenPb := []*pbandroid.PagerChildView{}
-	for _, chld := range v.Pages.Views() {
+	visible := []int{} // Index in Pages of each displayed page.
+	badges := []string{}
+	flags := []int64{}
+	notifiers := []comm.Notifier{}
+	selectedIndex := 0
+	for idx, chld := range v.Pages.Views() {
 		// Find the button
 		var button *PagerButton
 
@@ -114,6 +127,22 @@ func (v *PagerView) Build(ctx view.Context) view.Model {
 			}
 		}
 
+		if button.BadgeCount != nil {
+			notifiers = append(notifiers, button.BadgeCount)
+		}
+		if button.Visible != nil {
+			notifiers = append(notifiers, button.Visible)
+			if !button.Visible.Value() {
+				continue
+			}
+		}
+		if idx == v.Pages.SelectedIndex() {
+			selectedIndex = len(visible)
+		}
+		visible = append(visible, idx)
+		badges = append(badges, button.badge())
+		flags = append(flags, button.flags())
+
 		// Add the child.
 		l.Add(chld, func(s *constraint.Solver) {
 			s.TopEqual(constraint.Const(0))
@@ -131,6 +160,8 @@ func (v *PagerView) Build(ctx view.Context) view.Model {
 		})
 	}
 
+	v.subscribe(notifiers)
+
 	// var selectedTextStyle *pbtext.TextStyle
 	// if v.SelectedTextStyle != nil {
 	// 	selectedTextStyle = v.SelectedTextStyle.MarshalProtobuf()
@@ -147,7 +178,7 @@ func (v *PagerView) Build(ctx view.Context) view.Model {
 		NativeViewName: "gomatcha.io/matcha/view/android PagerView",
 		NativeViewState: internal.MarshalProtobuf(&pbandroid.PagerView{
 			ChildViews:    childrenPb,
-			SelectedIndex: int64(v.Pages.SelectedIndex()),
+			SelectedIndex: int64(selectedIndex),
 			// BarColor:            pb.ColorEncode(v.BarColor),
 			// SelectedColor:       pb.ColorEncode(v.SelectedColor),
 			// UnselectedColor:     pb.ColorEncode(v.UnselectedColor),
@@ -156,14 +187,77 @@ func (v *PagerView) Build(ctx view.Context) view.Model {
 		}),
 		NativeFuncs: map[string]interface{}{
 			"OnSelect": func(index int) {
-				v.Pages.SetSelectedIndex(index)
+				if index >= 0 && index < len(visible) {
+					v.Pages.SetSelectedIndex(visible[index])
+				}
+			},
+			// Tabs returns the badge and the flags of each displayed page. Bit
+			// 0 of the flags disables the page, and bit 1 displays its badge as
+			// a dot.
+			"Tabs": func() ([]string, []int64) {
+				return badges, flags
 			},
 		},
 	}
 }
 
+// subscribe updates the view's subscriptions to the notifiers of its page
+// buttons.
+func (v *PagerView) subscribe(notifiers []comm.Notifier) {
+	for _, i := range v.notifiers {
+		found := false
+		for _, j := range notifiers {
+			if i == j {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.Unsubscribe(i)
+		}
+	}
+	for _, i := range notifiers {
+		v.Subscribe(i)
+	}
+	v.notifiers = notifiers
+}
+
 type PagerButton struct {
 	Title string
+	// Badge is displayed after the title, for example the number of unread
+	// items.
+	Badge string
+	// BadgeCount, if set, is displayed as the badge instead of Badge. The badge
+	// is hidden while the count is 0.
+	BadgeCount comm.IntNotifier
+	// BadgeDot displays the badge as a dot without text.
+	BadgeDot bool
+	// Disabled prevents the user from selecting the page.
+	Disabled bool
+	// Visible, if set, hides the page while its value is false. Pages are added
+	// and removed as it changes.
+	Visible comm.BoolNotifier
+}
+
+func (t *PagerButton) badge() string {
+	if t.BadgeCount != nil {
+		if c := t.BadgeCount.Value(); c != 0 {
+			return strconv.Itoa(c)
+		}
+		return ""
+	}
+	return t.Badge
+}
+
+func (t *PagerButton) flags() int64 {
+	var f int64
+	if t.Disabled {
+		f |= 1
+	}
+	if t.BadgeDot {
+		f |= 2
+	}
+	return f
 }
 
 func (t *PagerButton) OptionKey() string {
@@ -1,6 +1,7 @@
 package android
 
 import (
+	"fmt"
 	"image"
 	"image/color"
 	"strconv"
@@ -19,6 +20,7 @@ import (
 	"gomatcha.io/matcha/proto/view/android"
 	"gomatcha.io/matcha/text"
 	"gomatcha.io/matcha/view"
+	"gomatcha.io/matcha/view/searchbar"
 )
 
 // Stack represents a list of views to be shown in the StackView. It can be manipulated outside of a Build() call.
@@ -26,13 +28,15 @@ type Stack struct {
 	relay       comm.Relay
 	childIds    []int64
 	childrenMap map[int64]view.View
+	transitions map[int64]*Transition
 	maxId       int64
 }
 
+// SetViews replaces the views in the stack.
 func (s *Stack) SetViews(vs ...view.View) {
-	if s.childrenMap == nil {
-		s.childrenMap = map[int64]view.View{}
-	}
+	s.childIds = nil
+	s.childrenMap = map[int64]view.View{}
+	s.transitions = map[int64]*Transition{}
 
 	for _, i := range vs {
 		s.maxId += 1
@@ -55,23 +59,51 @@ func (s *Stack) Views() []view.View {
 	return vs
 }
 
+// Push adds vs to the top of the stack with the default transition.
 func (s *Stack) Push(vs view.View) {
+	s.PushWithTransition(vs, nil)
+}
+
+// PushWithTransition adds vs to the top of the stack, animating it in with t.
+// It is animated out with the same transition when it is popped. If t is nil,
+// the default transition is used.
+func (s *Stack) PushWithTransition(vs view.View, t *Transition) {
+	if s.childrenMap == nil {
+		s.childrenMap = map[int64]view.View{}
+	}
+	if s.transitions == nil {
+		s.transitions = map[int64]*Transition{}
+	}
 	s.maxId += 1
 
 	s.childIds = append(s.childIds, s.maxId)
 	s.childrenMap[s.maxId] = vs
+	if t != nil {
+		s.transitions[s.maxId] = t
+	}
 	s.relay.Signal()
 }
 
+// Pop removes the top view of the stack, reversing the transition that it was
+// pushed with.
 func (s *Stack) Pop() {
 	if len(s.childIds) <= 1 {
 		return
 	}
-	delete(s.childrenMap, s.childIds[len(s.childIds)-1])
+	id := s.childIds[len(s.childIds)-1]
+	delete(s.childrenMap, id)
+	delete(s.transitions, id)
 	s.childIds = s.childIds[:len(s.childIds)-1]
 	s.relay.Signal()
 }
 
+// setScreen attributes rendering costs to the view on top of the stack.
+func (s *Stack) setScreen() {
+	if count := len(s.childIds); count > 0 {
+		view.SetScreen(fmt.Sprintf("%T", s.childrenMap[s.childIds[count-1]]))
+	}
+}
+
 func (s *Stack) Notify(f func()) comm.Id {
 	return s.relay.Notify(f)
 }
@@ -86,6 +118,9 @@ type StackView struct {
 	BarColor      color.Color
 	TitleStyle    *text.Style
 	SubtitleStyle *text.Style
+
+	screenStack *Stack
+	screenId    comm.Id
 }
 
 // NewStackView returns a new view.
@@ -105,6 +140,12 @@ func (v *StackView) Lifecycle(from, to view.Stage) {
 	} else if view.ExitsStage(from, to, view.StageMounted) {
 		v.Unsubscribe(v.Stack)
 	}
+
+	if view.EntersStage(from, to, view.StageVisible) {
+		v.watchScreen()
+	} else if view.ExitsStage(from, to, view.StageVisible) {
+		v.unwatchScreen()
+	}
 }
 
 // Update implements the view.View interface.
@@ -117,6 +158,27 @@ func (v *StackView) Update(v2 view.View) {
 		v.Stack = &Stack{}
 	}
 	v.Subscribe(v.Stack)
+
+	if v.screenStack != nil && v.screenStack != v.Stack {
+		v.unwatchScreen()
+		v.watchScreen()
+	}
+}
+
+// watchScreen attributes rendering costs to the top view of the stack while
+// the stack view is visible, and again whenever views are pushed or popped.
+func (v *StackView) watchScreen() {
+	s := v.Stack
+	v.screenStack = s
+	v.screenId = s.Notify(s.setScreen)
+	s.setScreen()
+}
+
+func (v *StackView) unwatchScreen() {
+	if v.screenStack != nil {
+		v.screenStack.Unnotify(v.screenId)
+		v.screenStack = nil
+	}
 }
 
 // Build implements the view.View interface.
@@ -142,6 +204,10 @@ func (v *StackView) Build(ctx view.Context) view.Model {
 		}
 
 		// Add the bar.
+		barHeight := 56.0
+		if bar.TitleMode != TitleModeStandard {
+			barHeight = 112
+		}
 		barV := &stackBarView{
 			Embed:           view.Embed{Key: strconv.Itoa(int(id))},
 			Bar:             bar,
@@ -154,7 +220,7 @@ func (v *StackView) Build(ctx view.Context) view.Model {
 			s.Top(0)
 			s.Left(0)
 			s.WidthEqual(l.MaxGuide().Width())
-			s.Height(56)
+			s.Height(barHeight)
 		})
 
 		// Add the child.
@@ -162,13 +228,15 @@ func (v *StackView) Build(ctx view.Context) view.Model {
 			s.Top(0)
 			s.Left(0)
 			s.WidthEqual(l.MaxGuide().Width())
-			s.HeightEqual(l.MaxGuide().Height().Add(-56)) // TODO(KD): Respect bar actual height, shorter when rotated, etc...
+			s.HeightEqual(l.MaxGuide().Height().Add(-barHeight)) // TODO(KD): Respect bar actual height, shorter when rotated, etc...
 		})
 
 		// Add ids to protobuf.
-		childrenPb = append(childrenPb, &android.StackChildView{
+		child := &android.StackChildView{
 			ScreenId: int64(id),
-		})
+		}
+		v.Stack.transitions[id].marshal(child)
+		childrenPb = append(childrenPb, child)
 	}
 
 	return view.Model{
@@ -196,9 +264,39 @@ type stackBarView struct {
 	BarColor        color.Color
 	Bar             *StackBar
 	NeedsBackButton bool
+
+	notifiers []comm.Notifier // Text and Focused of the search bar.
+}
+
+func (v *stackBarView) Lifecycle(from, to view.Stage) {
+	if view.ExitsStage(from, to, view.StageMounted) {
+		v.subscribe(nil)
+	}
+}
+
+// subscribe updates the view's subscriptions to the values of its search bar.
+func (v *stackBarView) subscribe(notifiers []comm.Notifier) {
+	for _, i := range v.notifiers {
+		found := false
+		for _, j := range notifiers {
+			if i == j {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.Unsubscribe(i)
+		}
+	}
+	for _, i := range notifiers {
+		v.Subscribe(i)
+	}
+	v.notifiers = notifiers
 }
 
 func (v *stackBarView) Build(ctx view.Context) view.Model {
+	v.subscribe(v.Bar.SearchBar.Notifiers())
+
 	col := v.Bar.Color
 	if col == nil {
 		col = v.BarColor
@@ -217,14 +315,25 @@ func (v *stackBarView) Build(ctx view.Context) view.Model {
 		styledSubtitle = text.NewStyledText(v.Bar.Subtitle, v.SubtitleStyle)
 	}
 
-	funcs := map[string]interface{}{}
+	funcs := v.Bar.SearchBar.NativeFuncs()
 	items := []*android.StackBarItem{}
+	overflow := []bool{}
 	for idx, i := range v.Bar.Items {
 		button := i.marshalProtobuf()
 		button.OnPressFunc = strconv.Itoa(idx)
 		items = append(items, button)
+		overflow = append(overflow, i.Overflow)
 		funcs[strconv.Itoa(idx)] = i.OnPress
 	}
+	var scrollEdgeColor []byte
+	if v.Bar.ScrollEdgeColor != nil {
+		scrollEdgeColor = internal.MarshalProtobuf(pb.ColorEncode(v.Bar.ScrollEdgeColor))
+	}
+	// Bar State returns the title mode, the scroll edge color and whether each
+	// item is in the overflow menu.
+	funcs["Bar State"] = func() (int64, []byte, []bool) {
+		return int64(v.Bar.TitleMode), scrollEdgeColor, overflow
+	}
 
 	return view.Model{
 		Painter:        &paint.Style{BackgroundColor: col},
@@ -241,6 +350,19 @@ func (v *stackBarView) Build(ctx view.Context) view.Model {
 	}
 }
 
+// TitleMode is the size of the title of a StackBar.
+type TitleMode int
+
+const (
+	// TitleModeStandard displays the title in the toolbar.
+	TitleModeStandard TitleMode = iota
+	// TitleModeLarge displays a large title below the toolbar.
+	TitleModeLarge
+	// TitleModeCollapsing displays a large title that collapses into the
+	// toolbar as the screen's content scrolls.
+	TitleModeCollapsing
+)
+
 type StackBar struct {
 	Title          string
 	StyledTitle    *text.StyledText
@@ -248,6 +370,13 @@ type StackBar struct {
 	StyledSubtitle *text.StyledText
 	Color          color.Color
 	Items          []*StackBarItem
+	// SearchBar, if set, is displayed as a search action in the toolbar.
+	SearchBar *searchbar.Bar
+	TitleMode TitleMode
+	// ScrollEdgeColor, if set, is the color of the bar while the screen's
+	// content is scrolled to its top. The bar is raised above the content with
+	// Color once it scrolls.
+	ScrollEdgeColor color.Color
 }
 
 func (t *StackBar) OptionKey() string {
@@ -260,7 +389,10 @@ type StackBarItem struct {
 	Icon        image.Image
 	IconTint    color.Color
 	Enabled     bool
-	OnPress     func()
+	// Overflow places the item in the toolbar's overflow menu instead of
+	// displaying it as an action.
+	Overflow bool
+	OnPress  func()
 }
 
 func NewStackBarItem() *StackBarItem {
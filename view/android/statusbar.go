@@ -5,6 +5,8 @@ import (
 
 	"github.com/gogo/protobuf/proto"
 	"golang.org/x/image/colornames"
+	"gomatcha.io/matcha/application"
+	"gomatcha.io/matcha/config"
 	"gomatcha.io/matcha/internal"
 	"gomatcha.io/matcha/internal/radix"
 	pb "gomatcha.io/matcha/proto"
@@ -21,6 +23,11 @@ const (
 	StatusBarStyleDark
 )
 
+// Dark status bar icons require Android 6.0.
+var darkStatusBarMinOS = application.MinOS{Android: 23}
+
+var darkStatusBarSwitch = config.NewSwitch("gomatcha.io/matcha/view/android darkstatusbar", true)
+
 // If multiple views have a statusBar, the most recently mounted one will be used.
 //  return view.Model{
 //      Options: []view.Option{
@@ -74,6 +81,10 @@ func (m *statusBarMiddleware) MarshalProtobuf() proto.Message {
 			statusBar = node.Value.(*StatusBar)
 		}
 	})
+	if statusBar.Style == StatusBarStyleDark && (!darkStatusBarMinOS.Supported() || !darkStatusBarSwitch.Value()) {
+		// Light icons would be unreadable on a light color, so fall back to black.
+		statusBar = &StatusBar{Style: StatusBarStyleLight, Color: colornames.Black}
+	}
 	return &pbapp.StatusBar{
 		Style: statusBar.Style == StatusBarStyleLight,
 		Color: pb.ColorEncode(statusBar.Color),
@@ -0,0 +1,68 @@
+package android
+
+import (
+	"time"
+
+	"gomatcha.io/matcha/animate"
+	"gomatcha.io/matcha/proto/view/android"
+)
+
+// TransitionStyle is how a view is animated as it is pushed onto a Stack.
+type TransitionStyle int
+
+const (
+	// TransitionDefault fades the view in as it rises from below its final
+	// position.
+	TransitionDefault TransitionStyle = iota
+	// TransitionSlide slides the view in from the trailing edge of the screen,
+	// over the previous view.
+	TransitionSlide
+	// TransitionFade fades the view in over the previous view.
+	TransitionFade
+	// TransitionModal slides the view up from the bottom of the screen, over
+	// the previous view.
+	TransitionModal
+	// TransitionNone shows the view without animating it.
+	TransitionNone
+)
+
+// Transition configures how a view is pushed onto a Stack, and popped off it.
+// Views are popped with the reverse of the transition they were pushed with.
+//
+//  v.Stack.PushWithTransition(child, &android.Transition{
+//  	Style:    android.TransitionModal,
+//  	Duration: 400 * time.Millisecond,
+//  	Curve:    &animate.CubicBezierEase{X0: 0.2, Y0: 0, X1: 0, Y1: 1},
+//  })
+type Transition struct {
+	Style TransitionStyle
+	// Duration is how long the transition animates for. It is 200
+	// milliseconds if 0.
+	Duration time.Duration
+	// Curve is the easing of the transition. It eases in and out if nil.
+	Curve *animate.CubicBezierEase
+}
+
+// defaultTransitionCurve is close to AccelerateDecelerateInterpolator.
+var defaultTransitionCurve = animate.CubicBezierEase{X0: 0.42, Y0: 0, X1: 0.58, Y1: 1}
+
+// marshal sets the transition's fields of child, resolving the defaults.
+func (t *Transition) marshal(child *android.StackChildView) {
+	if t == nil {
+		return
+	}
+	duration := t.Duration
+	if duration == 0 {
+		duration = 200 * time.Millisecond
+	}
+	curve := t.Curve
+	if curve == nil {
+		curve = &defaultTransitionCurve
+	}
+	child.Transition = int64(t.Style)
+	child.TransitionDuration = duration.Seconds()
+	child.CurveX0 = curve.X0
+	child.CurveY0 = curve.Y0
+	child.CurveX1 = curve.X1
+	child.CurveY1 = curve.Y1
+}
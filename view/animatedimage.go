@@ -0,0 +1,54 @@
+package view
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/gif"
+)
+
+// AnimatedImage is an animated GIF or APNG. It implements the image.Image
+// interface with its first frame, and ImageView plays it with the platform's
+// animated image support. APNGs are only animated on iOS, and display their
+// first frame on Android.
+type AnimatedImage struct {
+	image.Image
+	data []byte
+}
+
+// DecodeAnimatedImage decodes the animated GIF or APNG in data. It returns an
+// error if data is not an animated image.
+func DecodeAnimatedImage(data []byte) (*AnimatedImage, error) {
+	if !isAnimated(data) {
+		return nil, errors.New("view: not an animated GIF or APNG")
+	}
+	first, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return &AnimatedImage{Image: first, data: data}, nil
+}
+
+// isAnimated returns true if data is a GIF with several frames, or a PNG with
+// an animation control chunk.
+func isAnimated(data []byte) bool {
+	switch {
+	case bytes.HasPrefix(data, []byte("GIF8")):
+		g, err := gif.DecodeAll(bytes.NewReader(data))
+		return err == nil && len(g.Image) > 1
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		// The acTL chunk must come before the image data.
+		for i := 8; i+8 <= len(data); {
+			length := int(binary.BigEndian.Uint32(data[i:]))
+			switch string(data[i+4 : i+8]) {
+			case "acTL":
+				return true
+			case "IDAT":
+				return false
+			}
+			i += 12 + length
+		}
+	}
+	return false
+}
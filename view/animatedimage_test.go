@@ -0,0 +1,59 @@
+package view
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"testing"
+)
+
+func TestDecodeAnimatedImage(t *testing.T) {
+	frame := image.NewPaletted(image.Rect(0, 0, 2, 2), color.Palette{color.Black, color.White})
+	encodeGIF := func(frames int) []byte {
+		g := &gif.GIF{}
+		for i := 0; i < frames; i++ {
+			g.Image = append(g.Image, frame)
+			g.Delay = append(g.Delay, 10)
+		}
+		buf := &bytes.Buffer{}
+		if err := gif.EncodeAll(buf, g); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+
+	buf := &bytes.Buffer{}
+	if err := png.Encode(buf, frame); err != nil {
+		t.Fatal(err)
+	}
+	still := buf.Bytes()
+	// Insert an acTL chunk after the signature and the IHDR chunk, which is 25
+	// bytes long.
+	acTL := []byte{0, 0, 0, 8, 'a', 'c', 'T', 'L', 0, 0, 0, 2, 0, 0, 0, 0}
+	acTL = append(acTL, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(acTL[16:], crc32.ChecksumIEEE(acTL[4:16]))
+	apng := append(append(append([]byte{}, still[:33]...), acTL...), still[33:]...)
+
+	for _, c := range []struct {
+		name     string
+		data     []byte
+		animated bool
+	}{
+		{"gif", encodeGIF(2), true},
+		{"still gif", encodeGIF(1), false},
+		{"apng", apng, true},
+		{"png", still, false},
+		{"garbage", []byte("GIF8"), false},
+	} {
+		img, err := DecodeAnimatedImage(c.data)
+		if (err == nil) != c.animated {
+			t.Errorf("%v: DecodeAnimatedImage error = %v", c.name, err)
+		} else if err == nil && img.Bounds() != frame.Bounds() {
+			t.Errorf("%v: Bounds = %v", c.name, img.Bounds())
+		}
+	}
+}
@@ -0,0 +1,186 @@
+// Package barcodescanner implements a camera preview that decodes QR codes and
+// barcodes, with AVFoundation's metadata output on iOS and the Google Play
+// services barcode detector on Android.
+//
+// On iOS the app's Info.plist must contain NSCameraUsageDescription, and on
+// Android the app must hold the CAMERA permission and the device must have
+// Google Play services.
+//
+//  v := barcodescanner.New()
+//  v.Formats = barcodescanner.FormatQR
+//  v.Region = layout.Rt(40, 100, 280, 340)
+//  v.OnScan = func(b barcodescanner.Barcode) {
+//      fmt.Println(b.Format, b.Value)
+//  }
+package barcodescanner
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"time"
+
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+	"gomatcha.io/matcha/view/camera"
+)
+
+// DefaultRepeatInterval is the RepeatInterval of new views.
+const DefaultRepeatInterval = time.Second
+
+// Format is a set of barcode symbologies.
+type Format int
+
+const (
+	FormatQR Format = 1 << iota
+	FormatEAN13
+	FormatEAN8
+	FormatUPCA
+	FormatUPCE
+	FormatCode128
+	FormatCode39
+	FormatPDF417
+	FormatAztec
+	FormatDataMatrix
+
+	// FormatAll contains every symbology.
+	FormatAll Format = 1<<iota - 1
+)
+
+var formatNames = []string{"QR", "EAN13", "EAN8", "UPCA", "UPCE", "Code128", "Code39", "PDF417", "Aztec", "DataMatrix"}
+
+// String implements the fmt.Stringer interface.
+func (f Format) String() string {
+	for i, name := range formatNames {
+		if f == 1<<uint(i) {
+			return name
+		}
+	}
+	return fmt.Sprintf("Format(%d)", int(f))
+}
+
+// Barcode is a decoded barcode.
+type Barcode struct {
+	Format Format
+	Value  string
+	// Bounds is the frame of the barcode in the view's coordinates.
+	Bounds layout.Rect
+}
+
+// View displays a camera preview and reports the barcodes in it.
+type View struct {
+	view.Embed
+	// Formats are the symbologies to decode. If it is 0, all of them are.
+	Formats Format
+	// Region limits scanning to a part of the view, in the view's coordinates.
+	// Barcodes whose center is outside of it are ignored. If it is empty, the
+	// whole view is scanned.
+	Region layout.Rect
+	// OverlayColor dims the view outside of Region. If it is nil, the view
+	// isn't dimmed.
+	OverlayColor color.Color
+	Position     camera.Position
+	// Torch turns on the torch of the back camera.
+	Torch bool
+	// Paused stops reporting barcodes while the preview keeps running.
+	Paused bool
+	// OnScan is called with the barcodes that are found. A barcode is reported
+	// once while it stays in view, and again after it has been out of view for
+	// RepeatInterval.
+	OnScan         func(Barcode)
+	RepeatInterval time.Duration
+	// OnError is called if the camera can't be started, for example because the
+	// user denied access to it, or if the detector isn't available.
+	OnError    func(error)
+	PaintStyle *paint.Style
+
+	id       view.Id
+	last     Barcode
+	lastTime time.Time
+}
+
+// New returns a new view.
+func New() *View {
+	return &View{
+		RepeatInterval: DefaultRepeatInterval,
+	}
+}
+
+// Build implements the view.View interface.
+func (v *View) Build(ctx view.Context) view.Model {
+	path := ctx.Path()
+	v.id = path[len(path)-1]
+
+	var painter paint.Painter
+	if v.PaintStyle != nil {
+		painter = v.PaintStyle
+	}
+	return view.Model{
+		Painter:        painter,
+		NativeViewName: "gomatcha.io/matcha/view/barcodescanner",
+		NativeFuncs: map[string]interface{}{
+			// State matches the state of the camera view, which the native
+			// views extend. Frames aren't delivered to Go and photos aren't
+			// taken.
+			"State": func() (int64, int64, bool, int64, int64) {
+				return int64(v.id), int64(v.Position), v.Torch, 0, 0
+			},
+			// ScannerState returns the formats to decode, the region, the color
+			// of the overlay, or -1 if there is none, and whether scanning is
+			// paused.
+			"ScannerState": func() (int64, float64, float64, float64, float64, int64, bool) {
+				formats := v.Formats
+				if formats == 0 {
+					formats = FormatAll
+				}
+				r := v.Region
+				return int64(formats), r.Min.X, r.Min.Y, r.Max.X - r.Min.X, r.Max.Y - r.Min.Y, argb(v.OverlayColor), v.Paused
+			},
+			"OnScan": func(format int64, value string, x, y, width, height float64) {
+				v.scan(Barcode{
+					Format: Format(format),
+					Value:  value,
+					Bounds: layout.Rt(x, y, x+width, y+height),
+				}, time.Now())
+			},
+			"OnError": func(msg string) {
+				if v.OnError != nil {
+					v.OnError(errors.New(msg))
+				}
+			},
+		},
+	}
+}
+
+func (v *View) scan(b Barcode, now time.Time) {
+	if v.Paused || v.OnScan == nil || !inRegion(v.Region, b.Bounds) {
+		return
+	}
+	if b.Format == v.last.Format && b.Value == v.last.Value && now.Sub(v.lastTime) < v.RepeatInterval {
+		v.lastTime = now
+		return
+	}
+	v.last = b
+	v.lastTime = now
+	v.OnScan(b)
+}
+
+// inRegion returns true if the center of bounds is in region, or if region is
+// empty.
+func inRegion(region, bounds layout.Rect) bool {
+	if region.Max.X <= region.Min.X || region.Max.Y <= region.Min.Y {
+		return true
+	}
+	x := (bounds.Min.X + bounds.Max.X) / 2
+	y := (bounds.Min.Y + bounds.Max.Y) / 2
+	return x >= region.Min.X && x < region.Max.X && y >= region.Min.Y && y < region.Max.Y
+}
+
+func argb(c color.Color) int64 {
+	if c == nil {
+		return -1
+	}
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return int64(n.A)<<24 | int64(n.R)<<16 | int64(n.G)<<8 | int64(n.B)
+}
@@ -0,0 +1,62 @@
+package barcodescanner
+
+import (
+	"testing"
+	"time"
+
+	"gomatcha.io/matcha/layout"
+)
+
+func TestInRegion(t *testing.T) {
+	region := layout.Rt(100, 100, 200, 200)
+	tests := []struct {
+		region layout.Rect
+		bounds layout.Rect
+		want   bool
+	}{
+		{layout.Rect{}, layout.Rt(0, 0, 10, 10), true},
+		{region, layout.Rt(120, 120, 180, 180), true},
+		{region, layout.Rt(50, 50, 170, 170), true},
+		{region, layout.Rt(0, 0, 90, 90), false},
+		{region, layout.Rt(150, 190, 250, 290), false},
+	}
+	for _, tt := range tests {
+		if got := inRegion(tt.region, tt.bounds); got != tt.want {
+			t.Errorf("inRegion(%v, %v) = %v, want %v", tt.region, tt.bounds, got, tt.want)
+		}
+	}
+}
+
+func TestScanRepeat(t *testing.T) {
+	var scans []Barcode
+	v := New()
+	v.OnScan = func(b Barcode) {
+		scans = append(scans, b)
+	}
+	now := time.Now()
+	qr := Barcode{Format: FormatQR, Value: "a"}
+	v.scan(qr, now)
+	v.scan(qr, now.Add(500*time.Millisecond))
+	v.scan(qr, now.Add(1200*time.Millisecond))
+	if len(scans) != 1 {
+		t.Fatalf("barcode in view reported %v times, want 1", len(scans))
+	}
+	v.scan(Barcode{Format: FormatQR, Value: "b"}, now.Add(1300*time.Millisecond))
+	v.scan(qr, now.Add(1400*time.Millisecond))
+	if len(scans) != 3 {
+		t.Fatalf("got %v scans, want 3", len(scans))
+	}
+	v.scan(qr, now.Add(3*time.Second))
+	if len(scans) != 4 {
+		t.Fatalf("barcode reported %v times after the interval, want 4", len(scans))
+	}
+}
+
+func TestFormatString(t *testing.T) {
+	if s := FormatCode128.String(); s != "Code128" {
+		t.Errorf("FormatCode128.String() = %q", s)
+	}
+	if FormatAll != 1<<10-1 {
+		t.Errorf("FormatAll = %b", FormatAll)
+	}
+}
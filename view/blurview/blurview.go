@@ -0,0 +1,107 @@
+// Package blurview implements a translucent material, that blurs the views
+// behind it. It is displayed with UIVisualEffectView on iOS, and with a blur
+// RenderEffect on Android 12 and later. Earlier versions of Android dim the
+// content with the color of the style instead.
+//
+//  v := blurview.New()
+//  v.Content = headerImage
+//  v.Overlay = title
+//  v.Style = blurview.StyleDark
+package blurview
+
+import (
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/view"
+)
+
+// Style is the appearance of the material.
+type Style int
+
+const (
+	// StyleRegular adapts to the light or dark appearance of the app on iOS 13
+	// and later.
+	StyleRegular Style = iota
+	StyleLight
+	StyleDark
+	// StyleThin is more translucent than StyleRegular.
+	StyleThin
+	// StyleThick is more opaque than StyleRegular.
+	StyleThick
+)
+
+// View displays Content blurred, and Overlay over it. Both fill the view.
+type View struct {
+	view.Embed
+	// Content is displayed behind the material. It may be nil, in which case
+	// the views behind the blur view are blurred on iOS. On Android only
+	// Content is blurred.
+	Content view.View
+	// Overlay is displayed sharp over the material. It may be nil.
+	Overlay view.View
+	Style   Style
+	// Radius is the blur radius in points on Android. iOS uses the radius of
+	// the style.
+	Radius float64
+	// Vibrancy displays Overlay with a vibrancy effect on iOS, which blends
+	// it with the blurred content.
+	Vibrancy bool
+}
+
+// New returns a new view.
+func New() *View {
+	return &View{
+		Radius: 20,
+	}
+}
+
+// Build implements the view.View interface.
+func (v *View) Build(ctx view.Context) view.Model {
+	contentIdx, overlayIdx := -1, -1
+	children := []view.View{}
+	if v.Content != nil {
+		contentIdx = len(children)
+		children = append(children, v.Content)
+	}
+	if v.Overlay != nil {
+		overlayIdx = len(children)
+		children = append(children, v.Overlay)
+	}
+
+	return view.Model{
+		Children:       children,
+		Layouter:       &layouter{},
+		NativeViewName: "gomatcha.io/matcha/view/blurview",
+		NativeFuncs: map[string]interface{}{
+			// State returns the style, the blur radius, whether vibrancy is
+			// enabled, and the indexes of the content and overlay children, or
+			// -1 if they are missing.
+			"State": func() (int64, float64, bool, int64, int64) {
+				return int64(v.Style), v.Radius, v.Vibrancy, int64(contentIdx), int64(overlayIdx)
+			},
+		},
+	}
+}
+
+// layouter gives the children the view's size. The native views position them.
+type layouter struct {
+}
+
+func (l *layouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	size := ctx.MinSize()
+	gs := make([]layout.Guide, ctx.ChildCount())
+	for i := range gs {
+		g := ctx.LayoutChild(i, size, size)
+		g.Frame = layout.Rt(0, 0, size.X, size.Y)
+		gs[i] = g
+	}
+	return layout.Guide{Frame: layout.Rt(0, 0, size.X, size.Y)}, gs
+}
+
+func (l *layouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+func (l *layouter) Unnotify(id comm.Id) {
+	// no-op
+}
@@ -8,6 +8,7 @@ import (
 
 	"gomatcha.io/matcha/comm"
 	"gomatcha.io/matcha/internal"
+	"gomatcha.io/matcha/internal/platform"
 	"gomatcha.io/matcha/layout"
 	"gomatcha.io/matcha/paint"
 	"gomatcha.io/matcha/pointer"
@@ -63,7 +64,7 @@ type buttonLayouter struct {
 }
 
 func (l *buttonLayouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
-	if runtime.GOOS == "android" {
+	if platform.UsesJavaStyleBridge() {
 		style := &text.Style{}
 		style.SetFont(text.DefaultFont(14))
 		st := text.NewStyledText(strings.ToUpper(l.str), style)
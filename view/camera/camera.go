@@ -0,0 +1,171 @@
+// Package camera implements a live camera preview, backed by AVFoundation on
+// iOS and android.hardware.Camera on Android. It takes photos, controls the
+// torch, and optionally delivers downsampled grayscale frames to Go for
+// on-device processing, such as decoding QR codes.
+//
+// On iOS the app's Info.plist must contain NSCameraUsageDescription, and on
+// Android the app must hold the CAMERA permission.
+//
+//  v := camera.New()
+//  v.FrameWidth = 320
+//  v.OnFrame = func(f *camera.Frame) {
+//      if code, ok := decode(f.Image()); ok {
+//          ...
+//      }
+//  }
+//  v.OnPhoto = func(jpeg []byte) {
+//      ...
+//  }
+package camera
+
+import (
+	"errors"
+	"image"
+	"sync"
+
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+)
+
+// DefaultFrameWidth is the width of the frames of new views.
+const DefaultFrameWidth = 320
+
+var frameFuncs struct {
+	mu sync.Mutex
+	m  map[view.Id]func(*Frame)
+}
+
+func init() {
+	frameFuncs.m = map[view.Id]func(*Frame){}
+	bridge.RegisterFunc("gomatcha.io/matcha/view/camera onFrame", onFrame)
+}
+
+// onFrame is called by the native views on their capture threads, with pix
+// pointing to native memory that is only valid until it returns.
+func onFrame(id, width, height int64, pix []byte) {
+	frameFuncs.mu.Lock()
+	f := frameFuncs.m[view.Id(id)]
+	frameFuncs.mu.Unlock()
+
+	if f != nil && int64(len(pix)) >= width*height {
+		f(&Frame{Width: int(width), Height: int(height), Pix: pix})
+	}
+}
+
+// Position is the camera displayed by a View.
+type Position int
+
+const (
+	PositionBack Position = iota
+	PositionFront
+)
+
+// Frame is a downsampled grayscale frame of the preview.
+type Frame struct {
+	Width  int
+	Height int
+	// Pix holds the luminance of the pixels, row by row, one byte per pixel. It
+	// refers to native memory that is reused for the next frame, so it is only
+	// valid during the call to OnFrame and must be copied to be kept.
+	Pix []byte
+}
+
+// Image returns a copy of the frame.
+func (f *Frame) Image() *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, f.Width, f.Height))
+	copy(img.Pix, f.Pix)
+	return img
+}
+
+// View displays a live preview of a camera.
+type View struct {
+	view.Embed
+	Position Position
+	// Torch turns on the torch of the back camera.
+	Torch bool
+	// OnFrame, if set, is called with the frames of the preview, downsampled to
+	// at most FrameWidth pixels wide. It is called on a background thread, one
+	// frame at a time, and frames that arrive while it runs are dropped.
+	OnFrame    func(*Frame)
+	FrameWidth int
+	// OnPhoto is called with the JPEG data of the photos taken by CapturePhoto.
+	OnPhoto func(jpeg []byte)
+	// OnError is called if the camera can't be started, for example because the
+	// user denied access to it, or if a photo can't be taken.
+	OnError    func(error)
+	PaintStyle *paint.Style
+
+	id      view.Id
+	capture int64
+}
+
+// New returns a new view.
+func New() *View {
+	return &View{
+		FrameWidth: DefaultFrameWidth,
+	}
+}
+
+// CapturePhoto takes a photo. OnPhoto or OnError is called with the result.
+func (v *View) CapturePhoto() {
+	v.capture++
+	v.Signal()
+}
+
+// Lifecycle implements the view.View interface.
+func (v *View) Lifecycle(from, to view.Stage) {
+	if view.ExitsStage(from, to, view.StageMounted) {
+		frameFuncs.mu.Lock()
+		delete(frameFuncs.m, v.id)
+		frameFuncs.mu.Unlock()
+	}
+}
+
+// Build implements the view.View interface.
+func (v *View) Build(ctx view.Context) view.Model {
+	path := ctx.Path()
+	v.id = path[len(path)-1]
+
+	frameFuncs.mu.Lock()
+	if v.OnFrame != nil {
+		frameFuncs.m[v.id] = v.OnFrame
+	} else {
+		delete(frameFuncs.m, v.id)
+	}
+	frameFuncs.mu.Unlock()
+
+	var painter paint.Painter
+	if v.PaintStyle != nil {
+		painter = v.PaintStyle
+	}
+	return view.Model{
+		Painter:        painter,
+		NativeViewName: "gomatcha.io/matcha/view/camera",
+		NativeFuncs: map[string]interface{}{
+			// State returns the view's id, the camera position, whether the
+			// torch is on, the width of the frames, or 0 if they aren't
+			// delivered, and a counter that is incremented by CapturePhoto.
+			"State": func() (int64, int64, bool, int64, int64) {
+				frameWidth := int64(0)
+				if v.OnFrame != nil {
+					frameWidth = int64(v.FrameWidth)
+					if frameWidth <= 0 {
+						frameWidth = DefaultFrameWidth
+					}
+				}
+				return int64(v.id), int64(v.Position), v.Torch, frameWidth, v.capture
+			},
+			"OnPhoto": func(jpeg []byte) {
+				if v.OnPhoto != nil {
+					v.OnPhoto(jpeg)
+				}
+			},
+			"OnError": func(msg string) {
+				if v.OnError != nil {
+					v.OnError(errors.New(msg))
+				}
+			},
+		},
+	}
+}
@@ -0,0 +1,62 @@
+// Package canvas implements a view that is drawn by a Go function. The
+// function records paths, fills, strokes, text and transforms into a Context,
+// which is sent to the native view as a display list and drawn with
+// CoreGraphics on iOS and android.graphics.Canvas on Android.
+//
+//  v := canvas.New()
+//  v.Draw = func(c *canvas.Context) {
+//      size := c.Size()
+//      c.SetStrokeColor(colornames.Blue)
+//      c.SetLineWidth(2)
+//      c.MoveTo(0, size.Y)
+//      for i, value := range values {
+//          c.LineTo(float64(i+1)*size.X/float64(len(values)), size.Y*(1-value))
+//      }
+//      c.Stroke()
+//  }
+//
+// Draw is called again whenever the view is rebuilt or resized, so views that
+// draw changing values should call Signal when they change.
+package canvas
+
+import (
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+)
+
+// View is drawn by Draw.
+type View struct {
+	view.Embed
+	// Draw records the drawing of the view into a context with its size. It is
+	// called on the main thread.
+	Draw       func(c *Context)
+	PaintStyle *paint.Style
+}
+
+// New returns a new view.
+func New() *View {
+	return &View{}
+}
+
+// Build implements the view.View interface.
+func (v *View) Build(ctx view.Context) view.Model {
+	var painter paint.Painter
+	if v.PaintStyle != nil {
+		painter = v.PaintStyle
+	}
+	return view.Model{
+		Painter:        painter,
+		NativeViewName: "gomatcha.io/matcha/view/canvas",
+		NativeFuncs: map[string]interface{}{
+			// Draw returns the display list of the view at the given size.
+			"Draw": func(width, height float64) []byte {
+				c := newContext(layout.Pt(width, height))
+				if v.Draw != nil {
+					v.Draw(c)
+				}
+				return c.marshal()
+			},
+		},
+	}
+}
@@ -0,0 +1,241 @@
+package canvas
+
+import (
+	"encoding/json"
+	"image/color"
+	"math"
+
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/text"
+)
+
+// LineCap is the shape of the ends of stroked lines.
+type LineCap int
+
+const (
+	LineCapButt LineCap = iota
+	LineCapRound
+	LineCapSquare
+)
+
+// LineJoin is the shape of the corners of stroked paths.
+type LineJoin int
+
+const (
+	LineJoinMiter LineJoin = iota
+	LineJoinRound
+	LineJoinBevel
+)
+
+// TextAlign is the horizontal alignment of text drawn by FillText.
+type TextAlign int
+
+const (
+	TextAlignLeft TextAlign = iota
+	TextAlignCenter
+	TextAlignRight
+)
+
+// Context records the drawing of a canvas view. Like an HTML canvas, it has a
+// current path, which is built with MoveTo, LineTo and the other path methods
+// and is drawn by Fill and Stroke until BeginPath starts a new one.
+//
+// Coordinates are in points, with the origin at the top left of the view.
+// Angles are in radians, measured clockwise from the positive x axis.
+type Context struct {
+	size layout.Point
+	ops  [][]interface{}
+}
+
+func newContext(size layout.Point) *Context {
+	return &Context{size: size}
+}
+
+// Size returns the size of the view.
+func (c *Context) Size() layout.Point {
+	return c.size
+}
+
+func (c *Context) op(args ...interface{}) {
+	c.ops = append(c.ops, args)
+}
+
+func (c *Context) marshal() []byte {
+	if c.ops == nil {
+		c.ops = [][]interface{}{}
+	}
+	data, _ := json.Marshal(c.ops)
+	return data
+}
+
+// Save pushes the transform, clipping, colors, line and text settings onto a
+// stack. They are restored by Restore.
+func (c *Context) Save() {
+	c.op("save")
+}
+
+// Restore pops the settings saved by Save.
+func (c *Context) Restore() {
+	c.op("restore")
+}
+
+// Translate moves the origin by x and y.
+func (c *Context) Translate(x, y float64) {
+	c.op("translate", x, y)
+}
+
+// Scale scales the coordinates by x and y.
+func (c *Context) Scale(x, y float64) {
+	c.op("scale", x, y)
+}
+
+// Rotate rotates the coordinates by angle.
+func (c *Context) Rotate(angle float64) {
+	c.op("rotate", angle)
+}
+
+// BeginPath clears the current path.
+func (c *Context) BeginPath() {
+	c.op("begin")
+}
+
+// MoveTo starts a new subpath at x, y.
+func (c *Context) MoveTo(x, y float64) {
+	c.op("move", x, y)
+}
+
+// LineTo adds a line to x, y.
+func (c *Context) LineTo(x, y float64) {
+	c.op("line", x, y)
+}
+
+// QuadTo adds a quadratic Bézier curve to x, y with the control point cx, cy.
+func (c *Context) QuadTo(cx, cy, x, y float64) {
+	c.op("quad", cx, cy, x, y)
+}
+
+// CubicTo adds a cubic Bézier curve to x, y with the control points c1x, c1y
+// and c2x, c2y.
+func (c *Context) CubicTo(c1x, c1y, c2x, c2y, x, y float64) {
+	c.op("cubic", c1x, c1y, c2x, c2y, x, y)
+}
+
+// Arc adds an arc of the circle around center, from the angle start to end. If
+// the current subpath isn't empty, a line is added to the start of the arc.
+// The arc is drawn clockwise, or counterclockwise if counterclockwise is set.
+func (c *Context) Arc(center layout.Point, radius, start, end float64, counterclockwise bool) {
+	sweep := math.Mod(end-start, 2*math.Pi)
+	if !counterclockwise && sweep < 0 {
+		sweep += 2 * math.Pi
+	} else if counterclockwise && sweep > 0 {
+		sweep -= 2 * math.Pi
+	}
+	if sweep == 0 && end != start {
+		sweep = 2 * math.Pi
+		if counterclockwise {
+			sweep = -sweep
+		}
+	}
+
+	// Android can't draw a full circle with a single arc, so it is split in
+	// two.
+	if math.Abs(sweep) == 2*math.Pi {
+		c.op("arc", center.X, center.Y, radius, start, sweep/2)
+		start += sweep / 2
+		sweep /= 2
+	}
+	c.op("arc", center.X, center.Y, radius, start, sweep)
+}
+
+// Rect adds r to the path as a closed subpath.
+func (c *Context) Rect(r layout.Rect) {
+	c.op("rect", r.Min.X, r.Min.Y, r.Max.X-r.Min.X, r.Max.Y-r.Min.Y)
+}
+
+// Ellipse adds the ellipse inside r to the path as a closed subpath.
+func (c *Context) Ellipse(r layout.Rect) {
+	c.op("ellipse", r.Min.X, r.Min.Y, r.Max.X-r.Min.X, r.Max.Y-r.Min.Y)
+}
+
+// ClosePath adds a line to the start of the current subpath and closes it.
+func (c *Context) ClosePath() {
+	c.op("close")
+}
+
+// SetFillColor sets the color used by Fill and FillText. It is black by
+// default.
+func (c *Context) SetFillColor(v color.Color) {
+	c.op(append([]interface{}{"fillColor"}, colorArgs(v)...)...)
+}
+
+// SetStrokeColor sets the color used by Stroke. It is black by default.
+func (c *Context) SetStrokeColor(v color.Color) {
+	c.op(append([]interface{}{"strokeColor"}, colorArgs(v)...)...)
+}
+
+// SetLineWidth sets the width of stroked lines. It is 1 by default.
+func (c *Context) SetLineWidth(width float64) {
+	c.op("lineWidth", width)
+}
+
+// SetLineCap sets the shape of the ends of stroked lines.
+func (c *Context) SetLineCap(v LineCap) {
+	c.op("lineCap", int(v))
+}
+
+// SetLineJoin sets the shape of the corners of stroked paths.
+func (c *Context) SetLineJoin(v LineJoin) {
+	c.op("lineJoin", int(v))
+}
+
+// SetFont sets the font used by FillText.
+func (c *Context) SetFont(f *text.Font) {
+	pb := f.MarshalProtobuf()
+	c.op("font", pb.Family, pb.Size)
+}
+
+// SetTextAlign sets the alignment of text drawn by FillText. It is
+// TextAlignLeft by default.
+func (c *Context) SetTextAlign(v TextAlign) {
+	c.op("textAlign", int(v))
+}
+
+// SetWritingDirection sets the base direction of text drawn by FillText. It
+// is text.WritingDirectionNatural by default, which takes the direction from
+// the first strong character of the text.
+func (c *Context) SetWritingDirection(v text.WritingDirection) {
+	c.op("direction", int(v))
+}
+
+// Fill fills the current path with the fill color.
+func (c *Context) Fill() {
+	c.op("fill")
+}
+
+// Stroke draws the current path with the stroke color and line settings.
+func (c *Context) Stroke() {
+	c.op("stroke")
+}
+
+// Clip intersects the clipping region with the current path. Later drawing is
+// limited to the region until Restore.
+func (c *Context) Clip() {
+	c.op("clip")
+}
+
+// FillText draws str on a single line with the font and fill color. p is the
+// top of the text, at its left edge, center or right edge depending on the
+// text alignment.
+func (c *Context) FillText(str string, p layout.Point) {
+	c.op("text", str, p.X, p.Y)
+}
+
+// colorArgs returns the red, green, blue and alpha components of v between 0
+// and 1.
+func colorArgs(v color.Color) []interface{} {
+	if v == nil {
+		return []interface{}{0.0, 0.0, 0.0, 0.0}
+	}
+	c := color.NRGBA64Model.Convert(v).(color.NRGBA64)
+	return []interface{}{float64(c.R) / 0xffff, float64(c.G) / 0xffff, float64(c.B) / 0xffff, float64(c.A) / 0xffff}
+}
@@ -0,0 +1,53 @@
+package canvas
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"gomatcha.io/matcha/layout"
+)
+
+func TestContext(t *testing.T) {
+	c := newContext(layout.Pt(10, 20))
+	c.SetFillColor(color.NRGBA{R: 0xff, A: 0xff})
+	c.Rect(layout.Rt(1, 2, 4, 6))
+	c.Fill()
+	got := string(c.marshal())
+	want := `[["fillColor",1,0,0,1],["rect",1,2,3,4],["fill"]]`
+	if got != want {
+		t.Errorf("marshal() = %v, want %v", got, want)
+	}
+}
+
+func TestArc(t *testing.T) {
+	for _, tc := range []struct {
+		start, end       float64
+		counterclockwise bool
+		want             []float64
+	}{
+		{0, math.Pi / 2, false, []float64{math.Pi / 2}},
+		{0, math.Pi / 2, true, []float64{-3 * math.Pi / 2}},
+		{math.Pi / 2, 0, false, []float64{3 * math.Pi / 2}},
+		{0, 2 * math.Pi, false, []float64{math.Pi, math.Pi}},
+		{0, 2 * math.Pi, true, []float64{-math.Pi, -math.Pi}},
+		{0, 0, false, []float64{0}},
+	} {
+		c := newContext(layout.Pt(0, 0))
+		c.Arc(layout.Pt(0, 0), 1, tc.start, tc.end, tc.counterclockwise)
+		sweeps := []float64{}
+		for _, op := range c.ops {
+			sweeps = append(sweeps, op[5].(float64))
+		}
+		if len(sweeps) != len(tc.want) {
+			t.Errorf("Arc(%v, %v, %v) sweeps = %v, want %v", tc.start, tc.end, tc.counterclockwise, sweeps, tc.want)
+			continue
+		}
+		for i := range sweeps {
+			if math.Abs(sweeps[i]-tc.want[i]) > 1e-9 {
+				t.Errorf("Arc(%v, %v, %v) sweeps = %v, want %v", tc.start, tc.end, tc.counterclockwise, sweeps, tc.want)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,236 @@
+// Package chart implements line, bar and pie charts. Charts are drawn in Go
+// with view/canvas, and animate to new data when their Value changes.
+//
+//  data := &chart.Value{}
+//  data.SetValue(chart.Data{
+//      Labels: []string{"Mon", "Tue", "Wed"},
+//      Series: []chart.Series{{Name: "Steps", Values: []float64{4200, 8100, 6300}}},
+//  })
+//
+//  v := chart.New()
+//  v.Kind = chart.KindBar
+//  v.Data = data
+//  v.OnSelect = func(series, index int) {
+//      fmt.Println(data.Value().Labels[index])
+//  }
+package chart
+
+import (
+	"image/color"
+	"reflect"
+	"sync"
+	"time"
+
+	"gomatcha.io/matcha/animate"
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/pointer"
+	"gomatcha.io/matcha/view"
+	"gomatcha.io/matcha/view/canvas"
+)
+
+// Kind is the type of chart.
+type Kind int
+
+const (
+	KindLine Kind = iota
+	KindBar
+	// KindPie displays the first series, with a slice for each value.
+	KindPie
+)
+
+// Series is a named list of values.
+type Series struct {
+	Name   string
+	Values []float64
+	// Color is the color of the series. If nil, a color is chosen from the
+	// default palette.
+	Color color.Color
+}
+
+// Data is the data displayed by a chart.
+type Data struct {
+	// Labels are displayed along the x axis of line and bar charts, and next
+	// to the slices of pie charts.
+	Labels []string
+	Series []Series
+}
+
+// Value is a notifier for Data.
+type Value struct {
+	value Data
+	relay comm.Relay
+	mutex sync.Mutex
+}
+
+// Notify implements the comm.Notifier interface.
+func (v *Value) Notify(f func()) comm.Id {
+	return v.relay.Notify(f)
+}
+
+// Unnotify implements the comm.Notifier interface.
+func (v *Value) Unnotify(id comm.Id) {
+	v.relay.Unnotify(id)
+}
+
+// Value returns the current data.
+func (v *Value) Value() Data {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return v.value
+}
+
+// SetValue updates v.Value() and notifies any observers.
+func (v *Value) SetValue(val Data) {
+	v.mutex.Lock()
+	v.value = val
+	v.mutex.Unlock()
+	v.relay.Signal()
+}
+
+// View displays a chart.
+type View struct {
+	view.Embed
+	Kind Kind
+	Data *Value
+	// OnSelect is called with the series and index of the value the user
+	// taps. The value is highlighted until another one is tapped.
+	OnSelect func(series, index int)
+	// Duration is the length of the animation when Data changes. It is 300ms
+	// by default, and 0 disables animations.
+	Duration   time.Duration
+	PaintStyle *paint.Style
+
+	prevData *Value
+	from     Data
+	to       Data
+	progress animate.Value
+	size     layout.Point
+	selected struct {
+		series, index int
+		ok            bool
+	}
+}
+
+// New returns a new view.
+func New() *View {
+	return &View{
+		Data:     &Value{},
+		Duration: time.Millisecond * 300,
+	}
+}
+
+// Lifecycle implements the view.View interface.
+func (v *View) Lifecycle(from, to view.Stage) {
+	if view.EntersStage(from, to, view.StageMounted) {
+		v.Subscribe(&v.progress)
+	} else if view.ExitsStage(from, to, view.StageMounted) {
+		v.Unsubscribe(v.prevData)
+		v.Unsubscribe(&v.progress)
+	}
+}
+
+// Update implements the view.View interface.
+func (v *View) Update(v2 view.View) {
+	view.CopyFields(v, v2)
+	if v.Data == nil {
+		v.Data = &Value{}
+	}
+}
+
+// Build implements the view.View interface.
+func (v *View) Build(ctx view.Context) view.Model {
+	if v.Data != v.prevData {
+		if v.prevData != nil {
+			v.Unsubscribe(v.prevData)
+		}
+		v.Subscribe(v.Data)
+		v.prevData = v.Data
+	}
+
+	// Animate from the currently displayed values to the new ones. The first
+	// data is displayed without animating.
+	data := v.Data.Value()
+	if !reflect.DeepEqual(data, v.to) {
+		if v.to.Series == nil || v.Duration == 0 {
+			v.from = data
+		} else {
+			v.from = interpolate(v.from, v.to, v.progress.Value())
+			v.progress.SetValue(0)
+			v.progress.Run(&animate.Basic{
+				Start: 0,
+				End:   1,
+				Ease:  animate.DefaultEase,
+				Dur:   v.Duration,
+			})
+		}
+		v.to = data
+	}
+	displayed := interpolate(v.from, v.to, v.progress.Value())
+
+	c := canvas.New()
+	c.Draw = func(c *canvas.Context) {
+		ch := &chart{kind: v.Kind, data: displayed, rng: valueRange(v.to), size: c.Size()}
+		if v.selected.ok {
+			ch.selSeries, ch.selIndex, ch.hasSel = v.selected.series, v.selected.index, true
+		}
+		ch.draw(c)
+	}
+
+	var painter paint.Painter
+	if v.PaintStyle != nil {
+		painter = v.PaintStyle
+	}
+	return view.Model{
+		Children: []view.View{c},
+		Layouter: &layouter{view: v},
+		Painter:  painter,
+		Options: []view.Option{
+			pointer.GestureList{&pointer.TapGesture{
+				Count: 1,
+				OnEvent: func(e *pointer.TapEvent) {
+					if e.Kind != pointer.EventKindRecognized {
+						return
+					}
+					ch := &chart{kind: v.Kind, data: v.to, rng: valueRange(v.to), size: v.size}
+					series, index, ok := ch.hit(e.Position)
+					if !ok {
+						return
+					}
+					v.selected.series, v.selected.index, v.selected.ok = series, index, true
+					v.Signal()
+					if v.OnSelect != nil {
+						v.OnSelect(series, index)
+					}
+				},
+			}},
+		},
+	}
+}
+
+// layouter gives the canvas the chart's size, and records it for hit testing
+// taps.
+type layouter struct {
+	view *View
+}
+
+func (l *layouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	size := ctx.MinSize()
+	l.view.size = size
+	gs := make([]layout.Guide, ctx.ChildCount())
+	for i := range gs {
+		g := ctx.LayoutChild(i, size, size)
+		g.Frame = layout.Rt(0, 0, size.X, size.Y)
+		gs[i] = g
+	}
+	return layout.Guide{Frame: layout.Rt(0, 0, size.X, size.Y)}, gs
+}
+
+func (l *layouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+func (l *layouter) Unnotify(id comm.Id) {
+	// no-op
+}
@@ -0,0 +1,67 @@
+package chart
+
+import (
+	"reflect"
+	"testing"
+
+	"gomatcha.io/matcha/layout"
+)
+
+func TestValueRange(t *testing.T) {
+	for _, tc := range []struct {
+		values []float64
+		want   [2]float64
+	}{
+		{nil, [2]float64{0, 1}},
+		{[]float64{1, 7}, [2]float64{0, 8}},
+		{[]float64{-3, 90}, [2]float64{-50, 100}},
+		{[]float64{0.1, 0.35}, [2]float64{0, 0.4}},
+	} {
+		got := valueRange(Data{Series: []Series{{Values: tc.values}}})
+		if got != tc.want {
+			t.Errorf("valueRange(%v) = %v, want %v", tc.values, got, tc.want)
+		}
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	from := Data{Series: []Series{{Values: []float64{0, 10}}}}
+	to := Data{Series: []Series{{Values: []float64{10, 20, 30}}}}
+	got := interpolate(from, to, 0.5).Series[0].Values
+	if want := []float64{5, 15, 15}; !reflect.DeepEqual(got, want) {
+		t.Errorf("interpolate() = %v, want %v", got, want)
+	}
+}
+
+func TestHit(t *testing.T) {
+	data := Data{Series: []Series{
+		{Values: []float64{1, 2, 3}},
+		{Values: []float64{3, 2, 1}},
+	}}
+	size := layout.Pt(340, 220)
+
+	line := &chart{kind: KindLine, data: data, rng: valueRange(data), size: size}
+	p := layout.Pt(line.x(2), line.y(1))
+	if s, i, ok := line.hit(p); !ok || s != 1 || i != 2 {
+		t.Errorf("line hit(%v) = %v, %v, %v, want 1, 2, true", p, s, i, ok)
+	}
+
+	bar := &chart{kind: KindBar, data: data, rng: valueRange(data), size: size}
+	r := bar.bar(0, 1)
+	p = layout.Pt((r.Min.X+r.Max.X)/2, r.Max.Y-1)
+	if s, i, ok := bar.hit(p); !ok || s != 0 || i != 1 {
+		t.Errorf("bar hit(%v) = %v, %v, %v, want 0, 1, true", p, s, i, ok)
+	}
+
+	pie := &chart{kind: KindPie, data: data, size: size}
+	center, radius, _, _ := pie.pie()
+	// The slices are 1/6, 2/6 and 3/6 of the pie, clockwise from the top, so
+	// the left of the pie is in the last one.
+	p = layout.Pt(center.X-radius/2, center.Y)
+	if s, i, ok := pie.hit(p); !ok || s != 0 || i != 2 {
+		t.Errorf("pie hit(%v) = %v, %v, %v, want 0, 2, true", p, s, i, ok)
+	}
+	if _, _, ok := pie.hit(layout.Pt(0, 0)); ok {
+		t.Errorf("pie hit outside the pie")
+	}
+}
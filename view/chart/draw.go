@@ -0,0 +1,333 @@
+package chart
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"golang.org/x/image/colornames"
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/text"
+	"gomatcha.io/matcha/view/canvas"
+)
+
+// palette colors series that don't have a color.
+var palette = []color.Color{
+	colornames.Dodgerblue,
+	colornames.Orange,
+	colornames.Mediumseagreen,
+	colornames.Crimson,
+	colornames.Mediumpurple,
+	colornames.Gold,
+	colornames.Lightseagreen,
+	colornames.Hotpink,
+}
+
+const (
+	axisTicks   = 4
+	labelHeight = 20 // Height below the plot for the x axis labels.
+	axisWidth   = 40 // Width left of the plot for the y axis labels.
+	pieOffset   = 8  // Distance a selected slice is moved out of the pie.
+)
+
+// chart lays out and draws data at a given size.
+type chart struct {
+	kind      Kind
+	data      Data
+	rng       [2]float64 // The values at the bottom and top of the y axis.
+	size      layout.Point
+	selSeries int
+	selIndex  int
+	hasSel    bool
+}
+
+func (c *chart) count() int {
+	n := 0
+	for _, s := range c.data.Series {
+		if len(s.Values) > n {
+			n = len(s.Values)
+		}
+	}
+	return n
+}
+
+func (c *chart) color(series, index int) color.Color {
+	if c.kind == KindPie {
+		return palette[index%len(palette)]
+	}
+	if col := c.data.Series[series].Color; col != nil {
+		return col
+	}
+	return palette[series%len(palette)]
+}
+
+// plot returns the area inside the axes of line and bar charts.
+func (c *chart) plot() layout.Rect {
+	return layout.Rt(axisWidth, 10, math.Max(c.size.X-10, axisWidth), math.Max(c.size.Y-labelHeight, 10))
+}
+
+// y returns the height of value in the plot.
+func (c *chart) y(value float64) float64 {
+	p := c.plot()
+	t := 0.0
+	if c.rng[1] != c.rng[0] {
+		t = (value - c.rng[0]) / (c.rng[1] - c.rng[0])
+	}
+	return p.Max.Y - t*(p.Max.Y-p.Min.Y)
+}
+
+// x returns the horizontal center of the index'th value in the plot.
+func (c *chart) x(index int) float64 {
+	p := c.plot()
+	n := c.count()
+	if c.kind == KindBar {
+		return p.Min.X + (float64(index)+0.5)*(p.Max.X-p.Min.X)/float64(n)
+	}
+	if n <= 1 {
+		return (p.Min.X + p.Max.X) / 2
+	}
+	return p.Min.X + float64(index)*(p.Max.X-p.Min.X)/float64(n-1)
+}
+
+// bar returns the frame of the bar of a value in a bar chart.
+func (c *chart) bar(series, index int) layout.Rect {
+	p := c.plot()
+	group := (p.Max.X - p.Min.X) / float64(c.count())
+	width := group * 0.8 / float64(len(c.data.Series))
+	left := c.x(index) - group*0.4 + float64(series)*width
+	top, bottom := c.y(c.data.Series[series].Values[index]), c.y(math.Max(c.rng[0], 0))
+	if top > bottom {
+		top, bottom = bottom, top
+	}
+	return layout.Rt(left, top, left+width, bottom)
+}
+
+// pie returns the center and radius of a pie chart, and the start angle and
+// sweep of each slice.
+func (c *chart) pie() (center layout.Point, radius float64, starts, sweeps []float64) {
+	center = layout.Pt(c.size.X/2, c.size.Y/2)
+	radius = math.Max(math.Min(c.size.X, c.size.Y)/2-pieOffset-labelHeight, 0)
+	if len(c.data.Series) == 0 {
+		return center, radius, nil, nil
+	}
+	values := c.data.Series[0].Values
+	total := 0.0
+	for _, v := range values {
+		total += math.Max(v, 0)
+	}
+	angle := -math.Pi / 2
+	for _, v := range values {
+		sweep := 0.0
+		if total > 0 {
+			sweep = math.Max(v, 0) / total * 2 * math.Pi
+		}
+		starts = append(starts, angle)
+		sweeps = append(sweeps, sweep)
+		angle += sweep
+	}
+	return center, radius, starts, sweeps
+}
+
+func (c *chart) draw(ctx *canvas.Context) {
+	ctx.SetFont(text.DefaultFont(11))
+	if c.kind == KindPie {
+		c.drawPie(ctx)
+		return
+	}
+	if c.count() == 0 {
+		return
+	}
+	c.drawAxes(ctx)
+
+	for s, series := range c.data.Series {
+		if c.kind == KindBar {
+			for i := range series.Values {
+				ctx.BeginPath()
+				ctx.Rect(c.bar(s, i))
+				ctx.SetFillColor(c.color(s, i))
+				ctx.Fill()
+				if c.hasSel && c.selSeries == s && c.selIndex == i {
+					ctx.SetStrokeColor(colornames.Black)
+					ctx.SetLineWidth(2)
+					ctx.Stroke()
+				}
+			}
+			continue
+		}
+
+		ctx.BeginPath()
+		for i, v := range series.Values {
+			if i == 0 {
+				ctx.MoveTo(c.x(i), c.y(v))
+			} else {
+				ctx.LineTo(c.x(i), c.y(v))
+			}
+		}
+		ctx.SetStrokeColor(c.color(s, 0))
+		ctx.SetLineWidth(2)
+		ctx.SetLineJoin(canvas.LineJoinRound)
+		ctx.Stroke()
+		if c.hasSel && c.selSeries == s && c.selIndex < len(series.Values) {
+			p := layout.Pt(c.x(c.selIndex), c.y(series.Values[c.selIndex]))
+			ctx.BeginPath()
+			ctx.Ellipse(layout.Rt(p.X-5, p.Y-5, p.X+5, p.Y+5))
+			ctx.SetFillColor(c.color(s, 0))
+			ctx.Fill()
+		}
+	}
+}
+
+func (c *chart) drawAxes(ctx *canvas.Context) {
+	p := c.plot()
+	ctx.SetStrokeColor(colornames.Lightgray)
+	ctx.SetLineWidth(1)
+	ctx.SetFillColor(colornames.Gray)
+	ctx.SetTextAlign(canvas.TextAlignRight)
+	for i := 0; i <= axisTicks; i++ {
+		value := c.rng[0] + float64(i)*(c.rng[1]-c.rng[0])/axisTicks
+		y := c.y(value)
+		ctx.BeginPath()
+		ctx.MoveTo(p.Min.X, y)
+		ctx.LineTo(p.Max.X, y)
+		ctx.Stroke()
+		ctx.FillText(formatValue(value), layout.Pt(p.Min.X-4, y-7))
+	}
+
+	ctx.SetTextAlign(canvas.TextAlignCenter)
+	for i, label := range c.data.Labels {
+		if i >= c.count() {
+			break
+		}
+		ctx.FillText(label, layout.Pt(c.x(i), p.Max.Y+4))
+	}
+}
+
+func (c *chart) drawPie(ctx *canvas.Context) {
+	center, radius, starts, sweeps := c.pie()
+	ctx.SetTextAlign(canvas.TextAlignCenter)
+	for i := range starts {
+		mid := starts[i] + sweeps[i]/2
+		dir := layout.Pt(math.Cos(mid), math.Sin(mid))
+		p := center
+		if c.hasSel && c.selIndex == i {
+			p = layout.Pt(center.X+dir.X*pieOffset, center.Y+dir.Y*pieOffset)
+		}
+		if sweeps[i] > 0 {
+			ctx.BeginPath()
+			ctx.MoveTo(p.X, p.Y)
+			ctx.Arc(p, radius, starts[i], starts[i]+sweeps[i], false)
+			ctx.ClosePath()
+			ctx.SetFillColor(c.color(0, i))
+			ctx.Fill()
+		}
+		if i < len(c.data.Labels) && sweeps[i] > 0 {
+			ctx.SetFillColor(colornames.Gray)
+			ctx.FillText(c.data.Labels[i], layout.Pt(center.X+dir.X*(radius+pieOffset+10), center.Y+dir.Y*(radius+pieOffset+10)-7))
+		}
+	}
+}
+
+// hit returns the series and index of the value at p.
+func (c *chart) hit(p layout.Point) (series, index int, ok bool) {
+	if c.kind == KindPie {
+		center, radius, starts, sweeps := c.pie()
+		d := layout.Pt(p.X-center.X, p.Y-center.Y)
+		if math.Hypot(d.X, d.Y) > radius+pieOffset {
+			return 0, 0, false
+		}
+		angle := math.Atan2(d.Y, d.X)
+		for i := range starts {
+			// Angles start at the top of the pie, at -π/2.
+			a := math.Mod(angle-starts[i]+4*math.Pi, 2*math.Pi)
+			if a < sweeps[i] {
+				return 0, i, true
+			}
+		}
+		return 0, 0, false
+	}
+
+	n := c.count()
+	plot := c.plot()
+	if n == 0 || p.X < plot.Min.X-10 || p.X > plot.Max.X+10 || p.Y < plot.Min.Y-10 || p.Y > plot.Max.Y+10 {
+		return 0, 0, false
+	}
+
+	// Pick the closest index, then the series closest to p at that index.
+	index = 0
+	for i := 1; i < n; i++ {
+		if math.Abs(c.x(i)-p.X) < math.Abs(c.x(index)-p.X) {
+			index = i
+		}
+	}
+	series, best := -1, math.Inf(1)
+	for s, values := range c.data.Series {
+		if index >= len(values.Values) {
+			continue
+		}
+		var d float64
+		if c.kind == KindBar {
+			r := c.bar(s, index)
+			d = math.Max(math.Max(r.Min.X-p.X, p.X-r.Max.X), 0)
+		} else {
+			d = math.Abs(c.y(values.Values[index]) - p.Y)
+		}
+		if d < best {
+			series, best = s, d
+		}
+	}
+	return series, index, series >= 0
+}
+
+// valueRange returns the range of the y axis, which includes 0 and is rounded
+// to the tick spacing.
+func valueRange(d Data) [2]float64 {
+	lo, hi := 0.0, 0.0
+	for _, s := range d.Series {
+		for _, v := range s.Values {
+			lo, hi = math.Min(lo, v), math.Max(hi, v)
+		}
+	}
+	if lo == hi {
+		return [2]float64{0, 1}
+	}
+	step := niceStep((hi - lo) / axisTicks)
+	return [2]float64{math.Floor(lo/step) * step, math.Ceil(hi/step) * step}
+}
+
+// niceStep rounds v up to 1, 2 or 5 times a power of 10.
+func niceStep(v float64) float64 {
+	exp := math.Pow(10, math.Floor(math.Log10(v)))
+	for _, m := range []float64{1, 2, 5, 10} {
+		if v <= m*exp {
+			return m * exp
+		}
+	}
+	return 10 * exp
+}
+
+func formatValue(v float64) string {
+	if v == math.Trunc(v) {
+		return fmt.Sprintf("%d", int64(v))
+	}
+	return fmt.Sprintf("%.2g", v)
+}
+
+// interpolate returns the data t of the way between from and to. Values that
+// are missing from from start at 0.
+func interpolate(from, to Data, t float64) Data {
+	if t >= 1 {
+		return to
+	}
+	d := Data{Labels: to.Labels, Series: make([]Series, len(to.Series))}
+	for s, series := range to.Series {
+		d.Series[s] = Series{Name: series.Name, Color: series.Color, Values: make([]float64, len(series.Values))}
+		for i, v := range series.Values {
+			prev := 0.0
+			if s < len(from.Series) && i < len(from.Series[s].Values) {
+				prev = from.Series[s].Values[i]
+			}
+			d.Series[s].Values[i] = prev + (v-prev)*t
+		}
+	}
+	return d
+}
@@ -0,0 +1,234 @@
+package chat
+
+import (
+	"image/color"
+	"math"
+	"strings"
+	"time"
+
+	"golang.org/x/image/colornames"
+
+	"gomatcha.io/matcha/application"
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/internal/clock"
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/text"
+	"gomatcha.io/matcha/view"
+)
+
+// BubbleView displays a single message. Outgoing messages are aligned to the
+// trailing edge and incoming messages to the leading edge.
+type BubbleView struct {
+	view.Embed
+	Message    *Message
+	ShowAuthor bool
+	Color      color.Color
+	Direction  layout.Direction
+}
+
+// NewBubbleView returns a new view.
+func NewBubbleView() *BubbleView {
+	return &BubbleView{}
+}
+
+// Build implements the view.View interface.
+func (v *BubbleView) Build(ctx view.Context) view.Model {
+	textColor := color.Color(colornames.Black)
+	if v.Message.Outgoing {
+		textColor = colornames.White
+	}
+
+	textView := view.NewTextView()
+	textView.String = v.Message.Text
+	textView.Style.SetFont(text.DefaultFont(16))
+	textView.Style.SetTextColor(textColor)
+
+	bubble := view.NewBasicView()
+	bubble.Children = []view.View{textView}
+	bubble.Layouter = &insetLayouter{insets: layout.Pt(12, 8)}
+	bubble.Painter = &paint.Style{BackgroundColor: v.Color, CornerRadius: 16}
+
+	children := []view.View{bubble}
+	if v.ShowAuthor {
+		author := view.NewTextView()
+		author.String = v.Message.Author
+		author.MaxLines = 1
+		author.Style.SetFont(text.DefaultFont(12))
+		author.Style.SetTextColor(colornames.Gray)
+		children = append(children, author)
+	}
+
+	return view.Model{
+		Children: children,
+		Layouter: &bubbleLayouter{
+			outgoing:    v.Message.Outgoing,
+			rightToLeft: application.IsRightToLeft(v.Direction),
+		},
+	}
+}
+
+type bubbleLayouter struct {
+	outgoing    bool
+	rightToLeft bool
+}
+
+func (l *bubbleLayouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	width := ctx.MinSize().X
+	maxWidth := math.Floor(width * 0.75)
+
+	y := 2.0
+	gs := make([]layout.Guide, ctx.ChildCount())
+	if ctx.ChildCount() > 1 {
+		a := ctx.LayoutChild(1, layout.Pt(0, 0), layout.Pt(maxWidth, math.Inf(1)))
+		a.Frame = layout.Rt(bubbleMargin+12, y, bubbleMargin+12+a.Width(), y+a.Height())
+		gs[1] = a
+		y += a.Height() + 2
+	}
+
+	b := ctx.LayoutChild(0, layout.Pt(0, 0), layout.Pt(maxWidth, math.Inf(1)))
+	x := float64(bubbleMargin)
+	if l.outgoing {
+		x = width - bubbleMargin - b.Width()
+	}
+	b.Frame = layout.Rt(x, y, x+b.Width(), y+b.Height())
+	gs[0] = b
+	y += b.Height() + 2
+
+	if l.rightToLeft {
+		for i := range gs {
+			gs[i].Frame = gs[i].Frame.Mirror(width)
+		}
+	}
+	return layout.Guide{Frame: layout.Rt(0, 0, width, y)}, gs
+}
+
+func (l *bubbleLayouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+func (l *bubbleLayouter) Unnotify(id comm.Id) {
+	// no-op
+}
+
+// insetLayouter sizes the view to fit its only child, plus insets on each side.
+type insetLayouter struct {
+	insets layout.Point
+}
+
+func (l *insetLayouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	max := layout.Pt(ctx.MaxSize().X-l.insets.X*2, ctx.MaxSize().Y-l.insets.Y*2)
+	g := ctx.LayoutChild(0, layout.Pt(0, 0), max)
+	g.Frame = layout.Rt(l.insets.X, l.insets.Y, l.insets.X+g.Width(), l.insets.Y+g.Height())
+	return layout.Guide{Frame: layout.Rt(0, 0, g.Width()+l.insets.X*2, g.Height()+l.insets.Y*2)}, []layout.Guide{g}
+}
+
+func (l *insetLayouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+func (l *insetLayouter) Unnotify(id comm.Id) {
+	// no-op
+}
+
+// DaySeparator displays the date of the messages that follow it.
+type DaySeparator struct {
+	view.Embed
+	Time time.Time
+}
+
+// NewDaySeparator returns a new view.
+func NewDaySeparator() *DaySeparator {
+	return &DaySeparator{}
+}
+
+// Build implements the view.View interface.
+func (v *DaySeparator) Build(ctx view.Context) view.Model {
+	label := view.NewTextView()
+	label.String = dayString(v.Time, clock.Now())
+	label.MaxLines = 1
+	label.Style.SetFont(text.DefaultBoldFont(12))
+	label.Style.SetTextColor(colornames.Gray)
+
+	return view.Model{
+		Children: []view.View{label},
+		Layouter: &separatorLayouter{},
+	}
+}
+
+// separatorLayouter centers its only child horizontally.
+type separatorLayouter struct {
+}
+
+func (l *separatorLayouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	width := ctx.MinSize().X
+	g := ctx.LayoutChild(0, layout.Pt(0, 0), layout.Pt(width, math.Inf(1)))
+	x := math.Floor((width - g.Width()) / 2)
+	g.Frame = layout.Rt(x, 12, x+g.Width(), 12+g.Height())
+	return layout.Guide{Frame: layout.Rt(0, 0, width, g.Height()+24)}, []layout.Guide{g}
+}
+
+func (l *separatorLayouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+func (l *separatorLayouter) Unnotify(id comm.Id) {
+	// no-op
+}
+
+// dayString returns a description of the day of t, relative to now.
+func dayString(t, now time.Time) string {
+	if sameDay(t, now) {
+		return "Today"
+	} else if sameDay(t, now.AddDate(0, 0, -1)) {
+		return "Yesterday"
+	} else if t.Year() == now.Year() {
+		return t.Format("Monday, January 2")
+	}
+	return t.Format("January 2, 2006")
+}
+
+// TypingIndicator displays which users are typing.
+type TypingIndicator struct {
+	view.Embed
+	Names     []string
+	Color     color.Color
+	Direction layout.Direction
+}
+
+// NewTypingIndicator returns a new view.
+func NewTypingIndicator() *TypingIndicator {
+	return &TypingIndicator{}
+}
+
+// Build implements the view.View interface.
+func (v *TypingIndicator) Build(ctx view.Context) view.Model {
+	str := "•••"
+	switch len(v.Names) {
+	case 0:
+	case 1:
+		str = v.Names[0] + " is typing…"
+	case 2:
+		str = v.Names[0] + " and " + v.Names[1] + " are typing…"
+	default:
+		str = strings.Join(v.Names[:len(v.Names)-1], ", ") + " and " + v.Names[len(v.Names)-1] + " are typing…"
+	}
+
+	label := view.NewTextView()
+	label.String = str
+	label.MaxLines = 1
+	label.Style.SetFont(text.DefaultFont(14))
+	label.Style.SetTextColor(colornames.Gray)
+
+	bubble := view.NewBasicView()
+	bubble.Children = []view.View{label}
+	bubble.Layouter = &insetLayouter{insets: layout.Pt(12, 6)}
+	bubble.Painter = &paint.Style{BackgroundColor: v.Color, CornerRadius: 14}
+
+	return view.Model{
+		Children: []view.View{bubble},
+		Layouter: &bubbleLayouter{rightToLeft: application.IsRightToLeft(v.Direction)},
+	}
+}
+
+const bubbleMargin = 10
@@ -0,0 +1,125 @@
+// Package chat implements views for building messaging interfaces. View combines
+// a ListView, which displays messages anchored to the bottom of the screen, with
+// a Composer for writing new messages.
+//
+//  v := chat.NewView()
+//  v.Messages = messages
+//  v.OnSend = func(str string) {
+//      messages = append(messages, &chat.Message{Text: str, Time: time.Now(), Outgoing: true})
+//      ...
+//  }
+package chat
+
+import (
+	"image/color"
+	"time"
+
+	"gomatcha.io/matcha/keyboard"
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/layout/constraint"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+)
+
+// Message is a single message displayed in a ListView.
+type Message struct {
+	// Id uniquely identifies the message. It is used as the key of its view.
+	Id string
+	// Author is displayed above incoming messages if it is different from
+	// the author of the previous message.
+	Author   string
+	Text     string
+	Time     time.Time
+	Outgoing bool
+}
+
+// View displays a list of messages along with a composer. The composer stays
+// above the keyboard, and the list scrolls to the most recent message when the
+// keyboard is shown.
+type View struct {
+	view.Embed
+	Messages []*Message
+	// Typing is the names of the users that are currently typing.
+	Typing      []string
+	Placeholder string
+	OnSend      func(string)
+	// OnAttach is called when the attachment button is pressed. The button is
+	// hidden if OnAttach is nil.
+	OnAttach   func()
+	PaintStyle *paint.Style
+	// Direction is the direction of the leading and trailing edges of the
+	// messages and the composer. It follows the system's locale by default.
+	Direction layout.Direction
+
+	anchor    Anchor
+	responder keyboard.Responder
+	visible   bool
+}
+
+// NewView returns a new view.
+func NewView() *View {
+	return &View{
+		PaintStyle: &paint.Style{BackgroundColor: color.White},
+	}
+}
+
+// Lifecycle implements the view.View interface.
+func (v *View) Lifecycle(from, to view.Stage) {
+	if view.EntersStage(from, to, view.StageMounted) {
+		v.Subscribe(&v.responder)
+	} else if view.ExitsStage(from, to, view.StageMounted) {
+		v.Unsubscribe(&v.responder)
+	}
+}
+
+// Build implements the view.View interface.
+func (v *View) Build(ctx view.Context) view.Model {
+	// Keep the latest message visible above the keyboard.
+	if visible := v.responder.Visible(); visible != v.visible {
+		v.visible = visible
+		if visible {
+			v.anchor.ScrollToBottom()
+		}
+	}
+
+	l := &constraint.Layouter{}
+
+	composer := NewComposer()
+	composer.Placeholder = v.Placeholder
+	composer.Responder = &v.responder
+	composer.OnAttach = v.OnAttach
+	composer.Direction = v.Direction
+	composer.OnSend = func(str string) {
+		v.anchor.ScrollToBottom()
+		if v.OnSend != nil {
+			v.OnSend(str)
+		}
+	}
+	composerGuide := l.Add(composer, func(s *constraint.Solver) {
+		s.BottomEqual(l.KeyboardGuide().Bottom())
+		s.LeftEqual(l.Left())
+		s.RightEqual(l.Right())
+	})
+
+	list := NewListView()
+	list.Messages = v.Messages
+	list.Typing = v.Typing
+	list.Anchor = &v.anchor
+	list.Direction = v.Direction
+	l.Add(list, func(s *constraint.Solver) {
+		s.TopEqual(l.Top())
+		s.BottomEqual(composerGuide.Top())
+		s.LeftEqual(l.Left())
+		s.RightEqual(l.Right())
+	})
+
+	var painter paint.Painter
+	if v.PaintStyle != nil {
+		painter = v.PaintStyle
+	}
+	return view.Model{
+		Children: l.Views(),
+		Layouter: l,
+		Painter:  painter,
+	}
+}
@@ -0,0 +1,187 @@
+package chat
+
+import (
+	"image/color"
+	"math"
+	"strings"
+
+	"gomatcha.io/matcha/application"
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/keyboard"
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/text"
+	"gomatcha.io/matcha/view"
+)
+
+// Composer is a text input for writing messages. It grows with its contents up
+// to MaxLines, and has a send button and an optional attachment button.
+type Composer struct {
+	view.Embed
+	Text        *text.Text
+	Placeholder string
+	// MaxLines is the number of lines the composer grows to before scrolling.
+	MaxLines  int
+	Responder *keyboard.Responder
+	OnSend    func(string)
+	// OnAttach is called when the attachment button is pressed. The button is
+	// hidden if OnAttach is nil.
+	OnAttach   func()
+	PaintStyle *paint.Style
+	// Direction is the direction of the leading edge, where the attachment
+	// button is placed, and the trailing edge, where the send button is
+	// placed. It follows the system's locale by default.
+	Direction layout.Direction
+
+	text     *text.Text
+	prevText *text.Text
+}
+
+// NewComposer returns a new view.
+func NewComposer() *Composer {
+	return &Composer{
+		MaxLines:   5,
+		PaintStyle: &paint.Style{BackgroundColor: color.RGBA{R: 248, G: 248, B: 248, A: 255}},
+		text:       text.New(""),
+	}
+}
+
+// Lifecycle implements the view.View interface.
+func (v *Composer) Lifecycle(from, to view.Stage) {
+	if view.ExitsStage(from, to, view.StageMounted) {
+		if v.prevText != nil {
+			v.Unsubscribe(v.prevText)
+			v.prevText = nil
+		}
+	}
+}
+
+// Build implements the view.View interface.
+func (v *Composer) Build(ctx view.Context) view.Model {
+	t := v.Text
+	if t == nil {
+		t = v.text
+	}
+	// Rebuild as the text changes, so the composer can grow.
+	if t != v.prevText {
+		if v.prevText != nil {
+			v.Unsubscribe(v.prevText)
+		}
+		v.Subscribe(t)
+		v.prevText = t
+	}
+
+	style := &text.Style{}
+	style.SetFont(text.DefaultFont(17))
+
+	maxLines := v.MaxLines
+	if maxLines < 1 {
+		maxLines = 1
+	}
+
+	input := view.NewTextInput()
+	input.Text = t
+	input.Style = style
+	input.Placeholder = v.Placeholder
+	input.MaxLines = maxLines
+	input.Responder = v.Responder
+
+	str := strings.TrimSpace(t.String())
+	send := view.NewButton()
+	send.String = "Send"
+	send.Enabled = str != ""
+	send.OnPress = func() {
+		if str == "" {
+			return
+		}
+		t.SetString("")
+		if v.OnSend != nil {
+			v.OnSend(str)
+		}
+	}
+
+	children := []view.View{input, send}
+	if v.OnAttach != nil {
+		attach := view.NewButton()
+		attach.String = "+"
+		attach.OnPress = func() {
+			v.OnAttach()
+		}
+		children = append(children, attach)
+	}
+
+	var painter paint.Painter
+	if v.PaintStyle != nil {
+		painter = v.PaintStyle
+	}
+	return view.Model{
+		Children: children,
+		Layouter: &composerLayouter{
+			styledText:  text.NewStyledText(t.String(), style),
+			style:       style,
+			maxLines:    maxLines,
+			rightToLeft: application.IsRightToLeft(v.Direction),
+		},
+		Painter: painter,
+	}
+}
+
+// composerLayouter places the attachment button, if any, on the leading edge and
+// the send button on the trailing edge, both aligned to the bottom. The input
+// fills the remaining width and is sized to fit its text.
+type composerLayouter struct {
+	styledText  *text.StyledText
+	style       *text.Style
+	maxLines    int
+	rightToLeft bool
+}
+
+func (l *composerLayouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	const padding = 8
+	width := ctx.MinSize().X
+	gs := make([]layout.Guide, ctx.ChildCount())
+
+	send := ctx.LayoutChild(1, layout.Pt(0, 0), layout.Pt(width, math.Inf(1)))
+	left := float64(padding)
+	var attach layout.Guide
+	if ctx.ChildCount() > 2 {
+		attach = ctx.LayoutChild(2, layout.Pt(0, 0), layout.Pt(width, math.Inf(1)))
+		left += attach.Width() + padding
+	}
+	right := width - send.Width() - padding*2
+	inputWidth := math.Max(right-left, 0)
+
+	// Size the input to its text, between one and maxLines lines.
+	line := text.NewStyledText("A", l.style).Size(layout.Pt(0, 0), layout.Pt(inputWidth, math.Inf(1)), 1)
+	size := l.styledText.Size(layout.Pt(0, 0), layout.Pt(inputWidth, math.Inf(1)), l.maxLines)
+	inputHeight := math.Max(size.Y, line.Y)
+
+	height := math.Max(inputHeight, math.Max(send.Height(), attach.Height())) + padding*2
+	bottom := height - padding
+
+	input := ctx.LayoutChild(0, layout.Pt(inputWidth, inputHeight), layout.Pt(inputWidth, inputHeight))
+	input.Frame = layout.Rt(left, bottom-inputHeight, left+inputWidth, bottom)
+	gs[0] = input
+
+	send.Frame = layout.Rt(width-padding-send.Width(), bottom-send.Height(), width-padding, bottom)
+	gs[1] = send
+
+	if ctx.ChildCount() > 2 {
+		attach.Frame = layout.Rt(padding, bottom-attach.Height(), padding+attach.Width(), bottom)
+		gs[2] = attach
+	}
+	if l.rightToLeft {
+		for i := range gs {
+			gs[i].Frame = gs[i].Frame.Mirror(width)
+		}
+	}
+	return layout.Guide{Frame: layout.Rt(0, 0, width, height)}, gs
+}
+
+func (l *composerLayouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+func (l *composerLayouter) Unnotify(id comm.Id) {
+	// no-op
+}
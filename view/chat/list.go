@@ -0,0 +1,259 @@
+package chat
+
+import (
+	"image/color"
+	"math"
+	"time"
+
+	"gomatcha.io/matcha/application"
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/view"
+)
+
+// Anchor tracks whether a ListView is scrolled to its most recent message. While
+// it is, the list stays scrolled to the bottom as messages are added.
+type Anchor struct {
+	scrollPosition view.ScrollPosition
+	relay          comm.Relay
+	detached       bool
+	viewport       float64
+	content        float64
+}
+
+// AtBottom returns true if the list is scrolled to the most recent message.
+func (a *Anchor) AtBottom() bool {
+	return !a.detached
+}
+
+// ScrollToBottom scrolls the list to the most recent message.
+func (a *Anchor) ScrollToBottom() {
+	if a.detached {
+		a.detached = false
+		a.relay.Signal()
+	} else {
+		a.scroll()
+	}
+}
+
+// Notify implements the comm.Notifier interface.
+func (a *Anchor) Notify(f func()) comm.Id {
+	return a.relay.Notify(f)
+}
+
+// Unnotify implements the comm.Notifier interface.
+func (a *Anchor) Unnotify(id comm.Id) {
+	a.relay.Unnotify(id)
+}
+
+func (a *Anchor) setOffset(y float64) {
+	detached := y < a.bottom()-anchorThreshold
+	if detached != a.detached {
+		a.detached = detached
+		a.relay.Signal()
+	}
+}
+
+func (a *Anchor) bottom() float64 {
+	return math.Max(a.content-a.viewport, 0)
+}
+
+func (a *Anchor) scroll() {
+	if a.scrollPosition.Value().Y != a.bottom() {
+		a.scrollPosition.SetValue(layout.Pt(0, a.bottom()))
+	}
+}
+
+// ListView displays messages from oldest to newest, anchored to the bottom of
+// the view. Messages sent on different days are divided by separators, and a
+// button to scroll to the most recent message is shown when the user scrolls
+// away from it.
+type ListView struct {
+	view.Embed
+	Messages []*Message
+	// Typing is the names of the users that are currently typing.
+	Typing              []string
+	Anchor              *Anchor
+	BubbleColor         color.Color
+	OutgoingBubbleColor color.Color
+	// Direction is the direction of the leading and trailing edges that
+	// messages are aligned to. It follows the system's locale by default.
+	Direction layout.Direction
+
+	anchor     Anchor
+	prevAnchor *Anchor
+}
+
+// NewListView returns a new view.
+func NewListView() *ListView {
+	return &ListView{
+		BubbleColor:         color.RGBA{R: 229, G: 229, B: 234, A: 255},
+		OutgoingBubbleColor: color.RGBA{R: 0, G: 122, B: 255, A: 255},
+	}
+}
+
+// Lifecycle implements the view.View interface.
+func (v *ListView) Lifecycle(from, to view.Stage) {
+	if view.ExitsStage(from, to, view.StageMounted) {
+		if v.prevAnchor != nil {
+			v.Unsubscribe(v.prevAnchor)
+			v.prevAnchor = nil
+		}
+	}
+}
+
+// Build implements the view.View interface.
+func (v *ListView) Build(ctx view.Context) view.Model {
+	anchor := v.Anchor
+	if anchor == nil {
+		anchor = &v.anchor
+	}
+	if anchor != v.prevAnchor {
+		if v.prevAnchor != nil {
+			v.Unsubscribe(v.prevAnchor)
+		}
+		v.Subscribe(anchor)
+		v.prevAnchor = anchor
+	}
+
+	children := []view.View{}
+	var prev *Message
+	for _, i := range v.Messages {
+		if prev == nil || !sameDay(prev.Time, i.Time) {
+			sep := NewDaySeparator()
+			sep.Key = "day " + i.Id
+			sep.Time = i.Time
+			children = append(children, sep)
+		}
+
+		bubble := NewBubbleView()
+		bubble.Key = i.Id
+		bubble.Message = i
+		bubble.Direction = v.Direction
+		bubble.ShowAuthor = !i.Outgoing && i.Author != "" && (prev == nil || prev.Author != i.Author || !sameDay(prev.Time, i.Time))
+		if i.Outgoing {
+			bubble.Color = v.OutgoingBubbleColor
+		} else {
+			bubble.Color = v.BubbleColor
+		}
+		children = append(children, bubble)
+		prev = i
+	}
+	if len(v.Typing) > 0 {
+		typing := NewTypingIndicator()
+		typing.Names = v.Typing
+		typing.Color = v.BubbleColor
+		typing.Direction = v.Direction
+		children = append(children, typing)
+	}
+
+	scrollView := view.NewScrollView()
+	scrollView.ScrollPosition = &anchor.scrollPosition
+	scrollView.ContentChildren = children
+	scrollView.ContentLayouter = &contentLayouter{anchor: anchor}
+	scrollView.OnScroll = func(p layout.Point) {
+		anchor.setOffset(p.Y)
+	}
+
+	l := &listLayouter{anchor: anchor, rightToLeft: application.IsRightToLeft(v.Direction)}
+	viewChildren := []view.View{scrollView}
+	if !anchor.AtBottom() {
+		button := view.NewButton()
+		button.String = "↓"
+		button.OnPress = func() {
+			anchor.ScrollToBottom()
+		}
+		viewChildren = append(viewChildren, button)
+	}
+	return view.Model{
+		Children: viewChildren,
+		Layouter: l,
+	}
+}
+
+func sameDay(a, b time.Time) bool {
+	y1, m1, d1 := a.Date()
+	y2, m2, d2 := b.Date()
+	return y1 == y2 && m1 == m2 && d1 == d2
+}
+
+// listLayouter fills the view with the scroll view and places the scroll to
+// bottom button, if any, in the bottom trailing corner.
+type listLayouter struct {
+	anchor      *Anchor
+	rightToLeft bool
+}
+
+func (l *listLayouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	size := ctx.MinSize()
+	l.anchor.viewport = size.Y
+
+	g := ctx.LayoutChild(0, size, size)
+	g.Frame = layout.Rt(0, 0, size.X, size.Y)
+	gs := []layout.Guide{g}
+
+	if ctx.ChildCount() > 1 {
+		b := ctx.LayoutChild(1, layout.Pt(0, 0), size)
+		b.Frame = layout.Rt(size.X-b.Width()-15, size.Y-b.Height()-15, size.X-15, size.Y-15)
+		if l.rightToLeft {
+			b.Frame = b.Frame.Mirror(size.X)
+		}
+		b.ZIndex = 1
+		gs = append(gs, b)
+	}
+	return layout.Guide{Frame: layout.Rt(0, 0, size.X, size.Y)}, gs
+}
+
+func (l *listLayouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+func (l *listLayouter) Unnotify(id comm.Id) {
+	// no-op
+}
+
+// contentLayouter stacks the messages vertically. If they do not fill the
+// viewport they are pushed to the bottom of it. While the anchor is attached
+// the scroll position is kept at the bottom of the content.
+type contentLayouter struct {
+	anchor *Anchor
+}
+
+func (l *contentLayouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	width := ctx.MinSize().X
+
+	gs := make([]layout.Guide, ctx.ChildCount())
+	height := float64(contentPadding * 2)
+	for i := range gs {
+		gs[i] = ctx.LayoutChild(i, layout.Pt(width, 0), layout.Pt(width, math.Inf(1)))
+		height += gs[i].Height()
+	}
+
+	y := contentPadding + math.Max(l.anchor.viewport-height, 0)
+	for i := range gs {
+		h := gs[i].Height()
+		gs[i].Frame = layout.Rt(0, y, width, y+h)
+		y += h
+	}
+
+	l.anchor.content = math.Max(height, l.anchor.viewport)
+	if l.anchor.AtBottom() {
+		l.anchor.scroll()
+	}
+	return layout.Guide{Frame: layout.Rt(0, 0, width, l.anchor.content)}, gs
+}
+
+func (l *contentLayouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+func (l *contentLayouter) Unnotify(id comm.Id) {
+	// no-op
+}
+
+const (
+	contentPadding = 8
+	// anchorThreshold is how far the user can scroll up from the most recent
+	// message before the list stops following new messages.
+	anchorThreshold = 44
+)
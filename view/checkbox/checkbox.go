@@ -0,0 +1,127 @@
+// Package checkbox implements a checkbox with an optional title. It displays a
+// Material CheckBox on Android, and a drawn box with a checkmark on iOS.
+//
+//  v := checkbox.New()
+//  v.Title = "Remember me"
+//  v.Value = &app.remember // *comm.BoolValue
+//
+// Unlike view.Switch, checkboxes are meant for forms where several options can
+// be selected. Use radiogroup for options that exclude each other.
+package checkbox
+
+import (
+	"image/color"
+	"math"
+	"runtime"
+
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+)
+
+// View is a checkbox.
+type View struct {
+	view.Embed
+	// Title is displayed next to the box. Tapping it toggles the checkbox.
+	Title string
+	// Value is whether the checkbox is checked. It is set when the user taps
+	// the checkbox, and setting it updates the checkbox.
+	Value *comm.BoolValue
+	// OnChange is called after Value is set by the user.
+	OnChange func(checked bool)
+	Enabled  bool
+	// Color is the color of the checked box. If nil, the platform's tint color
+	// is used.
+	Color      color.Color
+	PaintStyle *paint.Style
+
+	prevValue *comm.BoolValue
+}
+
+// New returns a new view.
+func New() *View {
+	return &View{
+		Value:   &comm.BoolValue{},
+		Enabled: true,
+	}
+}
+
+// Lifecycle implements the view.View interface.
+func (v *View) Lifecycle(from, to view.Stage) {
+	if view.ExitsStage(from, to, view.StageMounted) {
+		v.Unsubscribe(v.prevValue)
+	}
+}
+
+// Update implements the view.View interface.
+func (v *View) Update(v2 view.View) {
+	view.CopyFields(v, v2)
+	if v.Value == nil {
+		v.Value = &comm.BoolValue{}
+	}
+}
+
+// Build implements the view.View interface.
+func (v *View) Build(ctx view.Context) view.Model {
+	if v.Value != v.prevValue {
+		if v.prevValue != nil {
+			v.Unsubscribe(v.prevValue)
+		}
+		v.Subscribe(v.Value)
+		v.prevValue = v.Value
+	}
+
+	var painter paint.Painter
+	if v.PaintStyle != nil {
+		painter = v.PaintStyle
+	}
+	return view.Model{
+		Painter:        painter,
+		Layouter:       &layouter{},
+		NativeViewName: "gomatcha.io/matcha/view/checkbox",
+		NativeFuncs: map[string]interface{}{
+			// State returns whether the checkbox is checked and enabled, the
+			// title, and the color as ARGB, or -1 for the default color.
+			"State": func() (bool, bool, string, int64) {
+				return v.Value.Value(), v.Enabled, v.Title, argb(v.Color)
+			},
+			"OnChange": func(checked bool) {
+				v.Value.SetValue(checked)
+				if v.OnChange != nil {
+					v.OnChange(checked)
+				}
+			},
+		},
+	}
+}
+
+func argb(c color.Color) int64 {
+	if c == nil {
+		return -1
+	}
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return int64(n.A)<<24 | int64(n.R)<<16 | int64(n.G)<<8 | int64(n.B)
+}
+
+// layouter gives the checkbox the height of a touch target. It fills the width
+// it is given, so that the title can be tapped.
+type layouter struct {
+}
+
+func (l *layouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	height := 44.0
+	if runtime.GOOS == "android" {
+		height = 48
+	}
+	min := ctx.MinSize()
+	return layout.Guide{Frame: layout.Rt(0, 0, math.Max(min.X, height), math.Max(min.Y, height))}, nil
+}
+
+func (l *layouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+func (l *layouter) Unnotify(id comm.Id) {
+	// no-op
+}
@@ -0,0 +1,267 @@
+// Package collectionview implements a grid that displays large numbers of items
+// by recycling native cells, backed by UICollectionView on iOS and RecyclerView
+// with a GridLayoutManager on Android. Its items are provided by a
+// listview.DataSource and recycled like the rows of a listview.ListView.
+//
+//  grid := collectionview.New()
+//  grid.DataSource = photos
+//  grid.Columns = 3
+//  grid.Spacing = 1
+package collectionview
+
+import (
+	"math"
+
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/internal/recycle"
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+	"gomatcha.io/matcha/view/listview"
+)
+
+const (
+	// DefaultItemLength is the item length of new collection views.
+	DefaultItemLength = 100
+
+	overscanLines = 2  // Lines of items built beyond each end of the visible items.
+	initialItems  = 32 // Items built before the native view reports the visible items.
+)
+
+// Spanner is implemented by data sources whose items span several columns.
+type Spanner interface {
+	// Span returns the number of columns spanned by the item at index. It is
+	// clamped between 1 and Columns.
+	Span(index int) int
+}
+
+// CollectionView displays the items of DataSource in a grid. Items flow along
+// the lines of the grid, the rows of a vertical grid or the columns of a
+// horizontal one, and start a new line when they don't fit in the current one.
+type CollectionView struct {
+	view.Embed
+	DataSource listview.DataSource
+	// Columns is the number of columns of a vertical grid, or the number of rows
+	// of a horizontal one.
+	Columns int
+	// Horizontal scrolls the grid horizontally.
+	Horizontal bool
+	// ItemLength is the height of the items of a vertical grid, or the width of
+	// the items of a horizontal one, if DataSource is not a listview.Sizer.
+	// Sizers are passed the width of the items of a vertical grid, and return
+	// their height, or the height of the items of a horizontal one, and return
+	// their width.
+	ItemLength float64
+	// Spacing is the space between the items.
+	Spacing float64
+	// OnSelect is called with the index of an item when it is tapped.
+	OnSelect   func(index int)
+	PaintStyle *paint.Style
+
+	reload     bool
+	generation int64
+	count      int
+	first      int // Visible items, as reported by the native view.
+	last       int
+	size       layout.Point
+	recycler   recycle.Recycler
+}
+
+// New returns a new view.
+func New() *CollectionView {
+	return &CollectionView{
+		Columns:    2,
+		ItemLength: DefaultItemLength,
+		last:       initialItems,
+		reload:     true,
+	}
+}
+
+// Update implements the view.View interface.
+func (v *CollectionView) Update(v2 view.View) {
+	view.CopyFields(v, v2)
+	v.reload = true
+}
+
+// Reload rebuilds the items from DataSource. Call it after the items change.
+func (v *CollectionView) Reload() {
+	v.reload = true
+	v.Signal()
+}
+
+// Build implements the view.View interface.
+func (v *CollectionView) Build(ctx view.Context) view.Model {
+	if v.reload {
+		v.reload = false
+		v.generation++
+		v.count = 0
+		if v.DataSource != nil {
+			v.count = v.DataSource.Count()
+		}
+	}
+
+	overscan := overscanLines * v.columns()
+	first := clampItem(v.first-overscan, v.count)
+	last := clampItem(v.last+overscan, v.count)
+	var cells []view.View
+	if v.DataSource != nil {
+		cells = v.recycler.Cells(first, last, v.DataSource.ReuseId, v.DataSource.View)
+	}
+
+	var painter paint.Painter
+	if v.PaintStyle != nil {
+		painter = v.PaintStyle
+	}
+	return view.Model{
+		Children:       cells,
+		Layouter:       &gridLayouter{grid: v, first: first},
+		Painter:        painter,
+		NativeViewName: "gomatcha.io/matcha/view/collectionview",
+		NativeFuncs: map[string]interface{}{
+			// Rows returns the number of items, and a generation that changes
+			// when the native view must reload them.
+			"Rows": func() (int64, int64) {
+				return int64(v.count), v.generation
+			},
+			"Grid": func() (int64, bool, float64) {
+				return int64(v.columns()), v.Horizontal, v.Spacing
+			},
+			// Item returns the reuse identifier, span and size of an item.
+			"Item": func(index int64) (string, int64, float64, float64) {
+				if index < 0 || int(index) >= v.count {
+					return "", 1, 0, 0
+				}
+				size := v.itemSize(int(index))
+				return v.DataSource.ReuseId(int(index)), int64(v.span(int(index))), size.X, size.Y
+			},
+			// ChildRows returns the item displayed by each child view.
+			"ChildRows": func() []int64 {
+				return v.recycler.Rows()
+			},
+			"OnVisible": func(first, last int64) {
+				if int(first) != v.first || int(last) != v.last {
+					v.first, v.last = int(first), int(last)
+					v.Signal()
+				}
+			},
+			"OnSelect": func(index int64) {
+				if v.OnSelect != nil {
+					v.OnSelect(int(index))
+				}
+			},
+		},
+	}
+}
+
+func (v *CollectionView) columns() int {
+	if v.Columns < 1 {
+		return 1
+	}
+	return v.Columns
+}
+
+func (v *CollectionView) span(index int) int {
+	s, ok := v.DataSource.(Spanner)
+	if !ok {
+		return 1
+	}
+	span := s.Span(index)
+	if span < 1 {
+		return 1
+	}
+	if span > v.columns() {
+		return v.columns()
+	}
+	return span
+}
+
+// columnWidth returns the width of the columns of a vertical grid, or the
+// height of the rows of a horizontal one.
+func (v *CollectionView) columnWidth() float64 {
+	columns := float64(v.columns())
+	cross := v.size.X
+	if v.Horizontal {
+		cross = v.size.Y
+	}
+	return math.Max((cross-v.Spacing*(columns-1))/columns, 0)
+}
+
+// itemSize returns the size of the item at index.
+func (v *CollectionView) itemSize(index int) layout.Point {
+	span := float64(v.span(index))
+	width := v.columnWidth()*span + v.Spacing*(span-1)
+
+	length := v.ItemLength
+	if s, ok := v.DataSource.(listview.Sizer); ok {
+		length = s.Height(index, width)
+	}
+	if v.Horizontal {
+		return layout.Pt(length, width)
+	}
+	return layout.Pt(width, length)
+}
+
+func clampItem(item, items int) int {
+	if item < 0 {
+		return 0
+	}
+	if item > items {
+		return items
+	}
+	return item
+}
+
+// gridLayouter flows the cells along the lines of the grid. The native views
+// place them in their cells themselves.
+type gridLayouter struct {
+	grid  *CollectionView
+	first int
+}
+
+func (l *gridLayouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	grid := l.grid
+	size := ctx.MinSize()
+	if grid.size != size {
+		grid.size = size
+		grid.Reload()
+	}
+
+	// Flow the items from the start to find the position of the first cell.
+	columns := grid.columns()
+	gs := make([]layout.Guide, ctx.ChildCount())
+	column, offset, lineLength := 0, 0.0, 0.0
+	for i := 0; i < l.first+len(gs) && i < grid.count; i++ {
+		span := grid.span(i)
+		if column+span > columns {
+			column = 0
+			offset += lineLength + grid.Spacing
+			lineLength = 0
+		}
+		itemSize := grid.itemSize(i)
+		length := itemSize.Y
+		if grid.Horizontal {
+			length = itemSize.X
+		}
+		if i >= l.first {
+			cross := float64(column) * (grid.columnWidth() + grid.Spacing)
+			g := ctx.LayoutChild(i-l.first, itemSize, itemSize)
+			if grid.Horizontal {
+				g.Frame = layout.Rt(offset, cross, offset+itemSize.X, cross+itemSize.Y)
+			} else {
+				g.Frame = layout.Rt(cross, offset, cross+itemSize.X, offset+itemSize.Y)
+			}
+			gs[i-l.first] = g
+		}
+		lineLength = math.Max(lineLength, length)
+		column += span
+	}
+	return layout.Guide{Frame: layout.Rt(0, 0, size.X, size.Y)}, gs
+}
+
+func (l *gridLayouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+func (l *gridLayouter) Unnotify(id comm.Id) {
+	// no-op
+}
@@ -0,0 +1,177 @@
+// Package console implements a view for displaying large, quickly growing logs,
+// such as the output of a developer tool or a terminal.
+//
+//  buf := console.NewBuffer()
+//  go func() {
+//      for line := range lines {
+//          buf.Append(line)
+//      }
+//  }()
+//
+//  v := console.NewView()
+//  v.Buffer = buf
+package console
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"gomatcha.io/matcha"
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/internal/clock"
+)
+
+// Buffer is an append-only list of lines. Lines may be appended from any
+// goroutine, and are batched so that observers are notified at most once every
+// FlushInterval. Other methods must be called while holding matcha.MainLocker,
+// as views do during Build.
+type Buffer struct {
+	// MaxLines is the number of lines that are kept. Once it is exceeded the oldest
+	// lines are dropped. If MaxLines is 0 no lines are dropped.
+	MaxLines int
+
+	mu      sync.Mutex
+	pending []string
+	partial string
+	timer   clock.Timer
+
+	// Only accessed while holding matcha.MainLocker.
+	lines   []string
+	dropped int
+	relay   comm.Relay
+}
+
+// FlushInterval is how long appended lines are batched before they are added to
+// a Buffer.
+const FlushInterval = time.Second / 60
+
+// NewBuffer returns a new buffer that keeps up to 10000 lines.
+func NewBuffer() *Buffer {
+	return &Buffer{MaxLines: 10000}
+}
+
+// Append adds lines to the end of b. It is safe to call from any goroutine.
+func (b *Buffer) Append(lines ...string) {
+	if len(lines) == 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.pending = append(b.pending, lines...)
+	b.schedule()
+}
+
+// Write implements the io.Writer interface. p is split into lines, and a trailing
+// incomplete line is held until it is terminated by a later Write. It is safe to
+// call from any goroutine.
+func (b *Buffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	str := b.partial + string(p)
+	lines := strings.Split(str, "\n")
+	b.partial = lines[len(lines)-1]
+	lines = lines[:len(lines)-1]
+	for i, l := range lines {
+		lines[i] = strings.TrimSuffix(l, "\r")
+	}
+	if len(lines) > 0 {
+		b.pending = append(b.pending, lines...)
+		b.schedule()
+	}
+	return len(p), nil
+}
+
+func (b *Buffer) schedule() {
+	if b.timer == nil {
+		b.timer = clock.AfterFunc(FlushInterval, b.flush)
+	}
+}
+
+func (b *Buffer) flush() {
+	matcha.MainLocker.Lock()
+	defer matcha.MainLocker.Unlock()
+
+	b.mu.Lock()
+	pending := b.pending
+	b.pending = nil
+	b.timer = nil
+	b.mu.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+	b.lines = append(b.lines, pending...)
+	if b.MaxLines > 0 && len(b.lines) > b.MaxLines {
+		n := len(b.lines) - b.MaxLines
+		b.dropped += n
+		// Copy rather than reslice, so the dropped lines can be collected.
+		b.lines = append([]string(nil), b.lines[n:]...)
+	}
+	b.relay.Signal()
+}
+
+// Len returns the number of lines in b.
+func (b *Buffer) Len() int {
+	return len(b.lines)
+}
+
+// Line returns the line at index i.
+func (b *Buffer) Line(i int) string {
+	return b.lines[i]
+}
+
+// Dropped returns the total number of lines that have been dropped from the start
+// of b because of MaxLines or Clear.
+func (b *Buffer) Dropped() int {
+	return b.dropped
+}
+
+// Clear removes all lines from b.
+func (b *Buffer) Clear() {
+	b.mu.Lock()
+	b.pending = nil
+	b.partial = ""
+	b.mu.Unlock()
+
+	if len(b.lines) == 0 {
+		return
+	}
+	b.dropped += len(b.lines)
+	b.lines = nil
+	b.relay.Signal()
+}
+
+// Search returns the index of the first line after from that contains query,
+// ignoring case. If reverse is true it searches backwards from from. Search wraps
+// around the ends of b, and returns -1 if no line matches.
+func (b *Buffer) Search(query string, from int, reverse bool) int {
+	n := len(b.lines)
+	if query == "" || n == 0 {
+		return -1
+	}
+	query = strings.ToLower(query)
+	for i := 1; i <= n; i++ {
+		idx := from + i
+		if reverse {
+			idx = from - i
+		}
+		idx = ((idx % n) + n) % n
+		if strings.Contains(strings.ToLower(b.lines[idx]), query) {
+			return idx
+		}
+	}
+	return -1
+}
+
+// Notify implements the comm.Notifier interface.
+func (b *Buffer) Notify(f func()) comm.Id {
+	return b.relay.Notify(f)
+}
+
+// Unnotify implements the comm.Notifier interface.
+func (b *Buffer) Unnotify(id comm.Id) {
+	b.relay.Unnotify(id)
+}
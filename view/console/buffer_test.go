@@ -0,0 +1,57 @@
+package console
+
+import (
+	"fmt"
+	"testing"
+
+	"gomatcha.io/matcha/matchatest"
+)
+
+func TestBufferBatch(t *testing.T) {
+	clock := matchatest.NewClock()
+	defer clock.Close()
+
+	b := &Buffer{MaxLines: 3}
+	count := 0
+	b.Notify(func() {
+		count += 1
+	})
+
+	for i := 0; i < 5; i++ {
+		b.Append(fmt.Sprintf("line %d", i))
+	}
+	fmt.Fprint(b, "partial")
+	if b.Len() != 0 {
+		t.Error("Lines added before flush", b.Len())
+	}
+
+	clock.Advance(FlushInterval)
+	if count != 1 {
+		t.Error("Unexpected notification count", count)
+	}
+	if b.Len() != 3 || b.Line(0) != "line 2" || b.Dropped() != 2 {
+		t.Error("Unexpected lines", b.lines, b.Dropped())
+	}
+
+	fmt.Fprint(b, " line\r\nnext\n")
+	clock.Advance(FlushInterval)
+	if b.Line(1) != "partial line" || b.Line(2) != "next" {
+		t.Error("Unexpected lines", b.lines)
+	}
+}
+
+func TestBufferSearch(t *testing.T) {
+	b := &Buffer{lines: []string{"Error: a", "ok", "error: b"}}
+	if idx := b.Search("ERROR", 0, false); idx != 2 {
+		t.Error("Unexpected index", idx)
+	}
+	if idx := b.Search("error", 2, false); idx != 0 {
+		t.Error("Unexpected index", idx)
+	}
+	if idx := b.Search("error", 0, true); idx != 2 {
+		t.Error("Unexpected index", idx)
+	}
+	if idx := b.Search("missing", 0, false); idx != -1 {
+		t.Error("Unexpected index", idx)
+	}
+}
@@ -0,0 +1,327 @@
+package console
+
+import (
+	"image/color"
+	"math"
+	"strings"
+
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/text"
+	"gomatcha.io/matcha/view"
+)
+
+// Tail controls the scroll position of a View. While it is following, the view
+// stays scrolled to the last line as lines are appended.
+type Tail struct {
+	scrollPosition view.ScrollPosition
+	relay          comm.Relay
+	detached       bool
+	target         int
+	hasTarget      bool
+	viewport       float64
+	content        float64
+	lineHeight     float64
+}
+
+// Following returns true if the view is scrolled to the last line.
+func (t *Tail) Following() bool {
+	return !t.detached
+}
+
+// Follow scrolls the view to the last line, and keeps it there as lines are
+// appended.
+func (t *Tail) Follow() {
+	t.hasTarget = false
+	if t.detached {
+		t.detached = false
+		t.relay.Signal()
+	} else {
+		t.scroll()
+	}
+}
+
+// ScrollToLine stops following and scrolls the view so that line idx is visible.
+func (t *Tail) ScrollToLine(idx int) {
+	t.target = idx
+	t.hasTarget = true
+	t.detached = true
+	t.relay.Signal()
+}
+
+// Notify implements the comm.Notifier interface.
+func (t *Tail) Notify(f func()) comm.Id {
+	return t.relay.Notify(f)
+}
+
+// Unnotify implements the comm.Notifier interface.
+func (t *Tail) Unnotify(id comm.Id) {
+	t.relay.Unnotify(id)
+}
+
+func (t *Tail) setOffset(y float64) {
+	detached := y < t.bottom()-t.lineHeight
+	if detached != t.detached {
+		t.detached = detached
+		t.relay.Signal()
+	}
+}
+
+func (t *Tail) bottom() float64 {
+	return math.Max(t.content-t.viewport, 0)
+}
+
+func (t *Tail) scroll() {
+	if t.scrollPosition.Value().Y != t.bottom() {
+		t.scrollPosition.SetValue(layout.Pt(0, t.bottom()))
+	}
+}
+
+// View displays the lines of a Buffer in a single column, one line per row.
+// Only the rows near the visible area are built, so buffers of any length scroll
+// quickly.
+type View struct {
+	view.Embed
+	Buffer *Buffer
+	Tail   *Tail
+	// Query highlights the lines that contain it, ignoring case.
+	Query          string
+	Font           *text.Font
+	TextColor      color.Color
+	HighlightColor color.Color
+	PaintStyle     *paint.Style
+
+	tail       Tail
+	prevTail   *Tail
+	prevBuffer *Buffer
+	dropped    int
+	font       *text.Font
+	lineHeight float64
+
+	// Set by the layouter.
+	offset      float64
+	first, last int
+}
+
+// NewView returns a new view.
+func NewView() *View {
+	return &View{
+		Font:           text.DefaultMonospaceFont(12),
+		TextColor:      color.RGBA{R: 220, G: 220, B: 220, A: 255},
+		HighlightColor: color.RGBA{R: 120, G: 100, B: 0, A: 255},
+		PaintStyle:     &paint.Style{BackgroundColor: color.RGBA{R: 30, G: 30, B: 30, A: 255}},
+	}
+}
+
+// Lifecycle implements the view.View interface.
+func (v *View) Lifecycle(from, to view.Stage) {
+	if view.ExitsStage(from, to, view.StageMounted) {
+		if v.prevTail != nil {
+			v.Unsubscribe(v.prevTail)
+			v.prevTail = nil
+		}
+		if v.prevBuffer != nil {
+			v.Unsubscribe(v.prevBuffer)
+			v.prevBuffer = nil
+		}
+	}
+}
+
+// Build implements the view.View interface.
+func (v *View) Build(ctx view.Context) view.Model {
+	tail := v.Tail
+	if tail == nil {
+		tail = &v.tail
+	}
+	if tail != v.prevTail {
+		if v.prevTail != nil {
+			v.Unsubscribe(v.prevTail)
+		}
+		v.Subscribe(tail)
+		v.prevTail = tail
+	}
+	if v.Buffer != v.prevBuffer {
+		if v.prevBuffer != nil {
+			v.Unsubscribe(v.prevBuffer)
+		}
+		if v.Buffer != nil {
+			v.Subscribe(v.Buffer)
+			v.dropped = v.Buffer.Dropped()
+		}
+		v.prevBuffer = v.Buffer
+	}
+
+	style := &text.Style{}
+	style.SetFont(v.Font)
+	style.SetTextColor(v.TextColor)
+	style.SetWrap(text.WrapNone)
+	style.SetTruncation(text.TruncationEnd)
+	if v.Font != v.font || v.lineHeight == 0 {
+		v.font = v.Font
+		v.lineHeight = lineHeight(style)
+	}
+	tail.lineHeight = v.lineHeight
+
+	count := 0
+	if v.Buffer != nil {
+		count = v.Buffer.Len()
+
+		// Keep the same lines in view while detached, as lines are dropped from the
+		// start of the buffer.
+		if dropped := v.Buffer.Dropped() - v.dropped; dropped > 0 && tail.detached {
+			y := math.Max(tail.scrollPosition.Value().Y-float64(dropped)*tail.lineHeight, 0)
+			tail.scrollPosition.SetValue(layout.Pt(0, y))
+			v.offset = y
+		}
+		v.dropped = v.Buffer.Dropped()
+	}
+	if tail.hasTarget {
+		y := float64(tail.target)*tail.lineHeight - tail.viewport/2
+		y = math.Max(math.Min(y, float64(count)*tail.lineHeight-tail.viewport), 0)
+		tail.scrollPosition.SetValue(layout.Pt(0, y))
+		tail.hasTarget = false
+		v.offset = y
+	}
+	if !tail.detached {
+		v.offset = math.Max(float64(count)*tail.lineHeight-tail.viewport, 0)
+	}
+	v.first, v.last = v.visibleRows(tail, count)
+
+	query := strings.ToLower(v.Query)
+	rows := make([]view.View, 0, v.last-v.first)
+	for i := v.first; i < v.last; i++ {
+		str := v.Buffer.Line(i)
+
+		row := view.NewTextView()
+		row.StyledText = text.NewStyledText(str, style)
+		row.MaxLines = 1
+		if query != "" && strings.Contains(strings.ToLower(str), query) {
+			row.PaintStyle = &paint.Style{BackgroundColor: v.HighlightColor}
+		}
+		rows = append(rows, row)
+	}
+
+	scrollView := view.NewScrollView()
+	scrollView.ScrollPosition = &tail.scrollPosition
+	scrollView.ContentChildren = rows
+	scrollView.ContentLayouter = &contentLayouter{view: v, tail: tail, count: count}
+	scrollView.OnScroll = func(p layout.Point) {
+		v.offset = p.Y
+		tail.setOffset(p.Y)
+		if first, last := v.visibleRows(tail, count); first != v.first || last != v.last {
+			v.Signal()
+		}
+	}
+
+	var painter paint.Painter
+	if v.PaintStyle != nil {
+		painter = v.PaintStyle
+	}
+	return view.Model{
+		Children: []view.View{scrollView},
+		Layouter: &viewportLayouter{view: v, tail: tail, count: count},
+		Painter:  painter,
+	}
+}
+
+// visibleRows returns the range of lines that intersect the viewport, extended by
+// overscanRows in each direction.
+func (v *View) visibleRows(tail *Tail, count int) (int, int) {
+	if tail.lineHeight <= 0 || tail.viewport <= 0 {
+		return 0, 0
+	}
+	first := int(math.Floor(v.offset/tail.lineHeight)) - overscanRows
+	last := int(math.Ceil((v.offset+tail.viewport)/tail.lineHeight)) + overscanRows
+	return clamp(first, count), clamp(last, count)
+}
+
+func clamp(row, count int) int {
+	if row < 0 {
+		return 0
+	}
+	if row > count {
+		return count
+	}
+	return row
+}
+
+func lineHeight(style *text.Style) float64 {
+	size := text.NewStyledText("M", style).Size(layout.Pt(0, 0), layout.Pt(math.Inf(1), math.Inf(1)), 1)
+	if size.Y > 0 {
+		return math.Ceil(size.Y)
+	}
+	return 15
+}
+
+// viewportLayouter fills the view with its scroll view, and records the size of
+// the viewport.
+type viewportLayouter struct {
+	view  *View
+	tail  *Tail
+	count int
+}
+
+func (l *viewportLayouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	size := ctx.MinSize()
+	if l.tail.viewport != size.Y {
+		l.tail.viewport = size.Y
+		if !l.tail.detached {
+			l.view.offset = math.Max(float64(l.count)*l.tail.lineHeight-size.Y, 0)
+		}
+		if first, last := l.view.visibleRows(l.tail, l.count); first != l.view.first || last != l.view.last {
+			l.view.Signal()
+		}
+	}
+
+	g := ctx.LayoutChild(0, size, size)
+	g.Frame = layout.Rt(0, 0, size.X, size.Y)
+	return layout.Guide{Frame: layout.Rt(0, 0, size.X, size.Y)}, []layout.Guide{g}
+}
+
+func (l *viewportLayouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+func (l *viewportLayouter) Unnotify(id comm.Id) {
+	// no-op
+}
+
+// contentLayouter positions the built rows at their line, and sizes the content to
+// fit every line. While the tail is following, the scroll position is kept at the
+// bottom of the content.
+type contentLayouter struct {
+	view  *View
+	tail  *Tail
+	count int
+}
+
+func (l *contentLayouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	width := ctx.MinSize().X
+	height := l.tail.lineHeight
+
+	gs := make([]layout.Guide, ctx.ChildCount())
+	for i := range gs {
+		y := float64(l.view.first+i) * height
+		g := ctx.LayoutChild(i, layout.Pt(width, height), layout.Pt(width, height))
+		g.Frame = layout.Rt(0, y, width, y+height)
+		gs[i] = g
+	}
+
+	l.tail.content = float64(l.count) * height
+	if l.tail.Following() {
+		l.tail.scroll()
+	}
+	return layout.Guide{Frame: layout.Rt(0, 0, width, l.tail.content)}, gs
+}
+
+func (l *contentLayouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+func (l *contentLayouter) Unnotify(id comm.Id) {
+	// no-op
+}
+
+// overscanRows is the number of lines built beyond the viewport.
+const overscanRows = 10
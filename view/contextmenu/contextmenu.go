@@ -0,0 +1,146 @@
+// Package contextmenu implements context menus, shown when a view is
+// long-pressed. They are displayed with UIContextMenuInteraction on iOS 13 and
+// later, with an action sheet on earlier versions of iOS, and with a PopupMenu
+// on Android.
+//
+//  return view.Model{
+//      Options: []view.Option{
+//          &contextmenu.Menu{
+//              Items: []*contextmenu.Item{
+//                  {Title: "Share", OnSelect: v.share},
+//                  {Title: "Delete", Destructive: true, OnSelect: v.delete},
+//              },
+//          },
+//      },
+//  }
+package contextmenu
+
+import (
+	"image"
+
+	"github.com/gogo/protobuf/proto"
+	"gomatcha.io/matcha/internal"
+	"gomatcha.io/matcha/view"
+)
+
+const key = "gomatcha.io/matcha/contextmenu"
+
+func init() {
+	internal.RegisterMiddleware(func() interface{} {
+		return &middleware{}
+	})
+}
+
+// Menu is a view.Option that adds a context menu to a view.
+type Menu struct {
+	// Title is displayed above the items. It may be empty.
+	Title string
+	Items []*Item
+}
+
+// OptionKey implements the view.Option interface.
+func (m *Menu) OptionKey() string {
+	return key
+}
+
+// Item is an item of a context menu.
+type Item struct {
+	Title string
+	// Icon is displayed next to the title on iOS. It may be nil.
+	Icon image.Image
+	// Destructive displays the item in red.
+	Destructive bool
+	Disabled    bool
+	// Items, if set, are displayed in a submenu when the item is selected.
+	Items []*Item
+	// OnSelect is called when the item is selected. It is not called for items
+	// with a submenu.
+	OnSelect func()
+}
+
+// Flags describing the items to the native views.
+const (
+	flagDestructive = 1 << iota
+	flagDisabled
+	flagSubmenu
+)
+
+type middleware struct{}
+
+func (m *middleware) MarshalProtobuf() proto.Message {
+	return nil
+}
+
+func (m *middleware) Key() string {
+	return key
+}
+
+func (m *middleware) Build(ctx view.Context, next *view.Model) {
+	var menu *Menu
+	for _, i := range next.Options {
+		if i, ok := i.(*Menu); ok {
+			menu = i
+		}
+	}
+	if menu == nil {
+		return
+	}
+
+	// The items are flattened in depth first order. Each item's parent comes
+	// before it, and root items have a parent of -1.
+	var items []*Item
+	var parents []int64
+	var flatten func(parent int64, children []*Item)
+	flatten = func(parent int64, children []*Item) {
+		for _, i := range children {
+			if i == nil {
+				continue
+			}
+			items = append(items, i)
+			parents = append(parents, parent)
+			flatten(int64(len(items)-1), i.Items)
+		}
+	}
+	flatten(-1, menu.Items)
+
+	if next.NativeOptions == nil {
+		next.NativeOptions = map[string][]byte{}
+	}
+	next.NativeOptions[key] = []byte{1}
+
+	if next.NativeFuncs == nil {
+		next.NativeFuncs = map[string]interface{}{}
+	}
+	// Items returns the menu's title, and the parent, title, flags and icon of
+	// each item. The menu is read when it is shown, so it doesn't need to be
+	// sent to the native views on every update.
+	next.NativeFuncs[key+" Items"] = func() (string, []int64, []string, []int64, [][]byte) {
+		titles := make([]string, len(items))
+		flags := make([]int64, len(items))
+		icons := make([][]byte, len(items))
+		for idx, i := range items {
+			titles[idx] = i.Title
+			if i.Destructive {
+				flags[idx] |= flagDestructive
+			}
+			if i.Disabled {
+				flags[idx] |= flagDisabled
+			}
+			if len(i.Items) > 0 {
+				flags[idx] |= flagSubmenu
+			}
+			if i.Icon != nil {
+				icons[idx] = internal.MarshalProtobuf(internal.ImageMarshalProtobuf(i.Icon))
+			}
+		}
+		return menu.Title, parents, titles, flags, icons
+	}
+	next.NativeFuncs[key+" OnSelect"] = func(index int64) {
+		if index < 0 || int(index) >= len(items) {
+			return
+		}
+		if f := items[index].OnSelect; f != nil {
+			f()
+		}
+	}
+}
@@ -0,0 +1,159 @@
+// Package datepicker implements a view for choosing dates and times. It
+// displays a UIDatePicker on iOS, and on Android a field that opens the date
+// and time picker dialogs when tapped.
+//
+//  v := datepicker.New()
+//  v.Mode = datepicker.ModeDateTime
+//  v.Min = time.Now()
+//  v.Value = app.Reminder // *comm.TimeValue
+package datepicker
+
+import (
+	"runtime"
+	"time"
+
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+)
+
+// Mode is the part of the time that can be chosen.
+type Mode int
+
+const (
+	ModeDate Mode = iota
+	ModeTime
+	// ModeDateTime chooses a date and a time. On Android the time dialog is
+	// shown after the date dialog.
+	ModeDateTime
+)
+
+// View is a date and time picker.
+type View struct {
+	view.Embed
+	Mode Mode
+	// Value is the selected time. It is set when the user chooses a time, and
+	// setting it updates the picker. If it is zero, the picker displays the
+	// current time.
+	Value *comm.TimeValue
+	// Min and Max bound the times the user can choose. They are ignored if zero.
+	Min time.Time
+	Max time.Time
+	// OnChange is called with the chosen time, after Value is set.
+	OnChange   func(time.Time)
+	Enabled    bool
+	PaintStyle *paint.Style
+
+	prevValue *comm.TimeValue
+}
+
+// New returns a new view.
+func New() *View {
+	return &View{
+		Value:   &comm.TimeValue{},
+		Enabled: true,
+	}
+}
+
+// Lifecycle implements the view.View interface.
+func (v *View) Lifecycle(from, to view.Stage) {
+	if view.ExitsStage(from, to, view.StageMounted) {
+		v.Unsubscribe(v.prevValue)
+	}
+}
+
+// Update implements the view.View interface.
+func (v *View) Update(v2 view.View) {
+	view.CopyFields(v, v2)
+	if v.Value == nil {
+		v.Value = &comm.TimeValue{}
+	}
+}
+
+// Build implements the view.View interface.
+func (v *View) Build(ctx view.Context) view.Model {
+	if v.Value != v.prevValue {
+		if v.prevValue != nil {
+			v.Unsubscribe(v.prevValue)
+		}
+		v.prevValue = v.Value
+		if v.Value != nil {
+			v.Subscribe(v.Value)
+		}
+	}
+
+	value := time.Time{}
+	if v.Value != nil {
+		value = v.Value.Value()
+	}
+	if value.IsZero() {
+		value = time.Now()
+	}
+	value = v.clamp(value)
+
+	var painter paint.Painter
+	if v.PaintStyle != nil {
+		painter = v.PaintStyle
+	}
+	return view.Model{
+		Painter:        painter,
+		Layouter:       &layouter{},
+		NativeViewName: "gomatcha.io/matcha/view/datepicker",
+		NativeFuncs: map[string]interface{}{
+			// State returns the mode, the selected time and the bounds, in
+			// milliseconds since the Unix epoch, and whether the picker is
+			// enabled. The bounds are 0 if they are unset.
+			"State": func() (int64, int64, int64, int64, bool) {
+				return int64(v.Mode), millis(value), millis(v.Min), millis(v.Max), v.Enabled
+			},
+			"OnChange": func(ms int64) {
+				t := v.clamp(time.Unix(0, ms*int64(time.Millisecond)))
+				if v.Value != nil {
+					v.Value.SetValue(t)
+				}
+				if v.OnChange != nil {
+					v.OnChange(t)
+				}
+			},
+		},
+	}
+}
+
+func (v *View) clamp(t time.Time) time.Time {
+	if !v.Min.IsZero() && t.Before(v.Min) {
+		t = v.Min
+	}
+	if !v.Max.IsZero() && t.After(v.Max) {
+		t = v.Max
+	}
+	return t
+}
+
+func millis(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+// layouter gives the picker the height of UIDatePicker's wheels on iOS, and of
+// a text field on Android.
+type layouter struct {
+}
+
+func (l *layouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	height := 216.0
+	if runtime.GOOS == "android" {
+		height = 48
+	}
+	return layout.Guide{Frame: layout.Rt(0, 0, ctx.MinSize().X, height)}, nil
+}
+
+func (l *layouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+func (l *layouter) Unnotify(id comm.Id) {
+	// no-op
+}
@@ -0,0 +1,64 @@
+package view
+
+import (
+	"image/color"
+	"sync/atomic"
+
+	"gomatcha.io/matcha"
+	"gomatcha.io/matcha/paint"
+)
+
+var debugLayout int32
+
+// DebugLayout outlines the frame of every view on top of the running app, and
+// logs the constraints that placed each view laid out by a constraint.Layouter.
+// Nested views are outlined in different colors. It is meant for development
+// builds, in place of printing guide values.
+//
+//  view.DebugLayout(true)
+func DebugLayout(enabled bool) {
+	v := int32(0)
+	if enabled {
+		v = 1
+	}
+	if atomic.SwapInt32(&debugLayout, v) == v {
+		return
+	}
+
+	// DebugLayout may be called with the MainLocker held, so views are laid
+	// out again asynchronously.
+	go func() {
+		matcha.MainLocker.Lock()
+		defer matcha.MainLocker.Unlock()
+
+		for _, r := range liveRoots {
+			for id := range r.root.nodes {
+				r.root.addFlag(id, layoutFlag)
+			}
+		}
+	}()
+}
+
+// DebuggingLayout returns true if DebugLayout is enabled.
+func DebuggingLayout() bool {
+	return atomic.LoadInt32(&debugLayout) != 0
+}
+
+var debugColors = []color.Color{
+	color.RGBA{R: 255, G: 59, B: 48, A: 255},
+	color.RGBA{R: 0, G: 122, B: 255, A: 255},
+	color.RGBA{R: 52, G: 199, B: 89, A: 255},
+	color.RGBA{R: 255, G: 149, B: 0, A: 255},
+	color.RGBA{R: 175, G: 82, B: 222, A: 255},
+}
+
+// debugPaintStyle returns s with an outline in the color for depth. Views with
+// a border of their own keep it.
+func debugPaintStyle(s paint.Style, depth int) paint.Style {
+	if s.BorderWidth > 0 {
+		return s
+	}
+	s.BorderColor = debugColors[depth%len(debugColors)]
+	s.BorderWidth = 1
+	return s
+}
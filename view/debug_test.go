@@ -0,0 +1,21 @@
+package view
+
+import (
+	"testing"
+
+	"golang.org/x/image/colornames"
+	"gomatcha.io/matcha/paint"
+)
+
+func TestDebugPaintStyle(t *testing.T) {
+	s := debugPaintStyle(paint.Style{BackgroundColor: colornames.White}, 1)
+	if s.BorderWidth != 1 || s.BorderColor != debugColors[1] || s.BackgroundColor != colornames.White {
+		t.Errorf("debugPaintStyle() = %+v", s)
+	}
+
+	// Borders of the view are kept.
+	s = debugPaintStyle(paint.Style{BorderColor: colornames.Black, BorderWidth: 2}, 0)
+	if s.BorderWidth != 2 || s.BorderColor != colornames.Black {
+		t.Errorf("debugPaintStyle() = %+v", s)
+	}
+}
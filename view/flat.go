@@ -0,0 +1,122 @@
+package view
+
+import (
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"gomatcha.io/matcha"
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/internal/flat"
+	pbcolor "gomatcha.io/matcha/proto"
+	pb "gomatcha.io/matcha/proto/view"
+)
+
+// Encodings of the view hierarchy.
+const (
+	encodingFlat     = "flat"
+	encodingProtobuf = "protobuf"
+)
+
+// viewEncoding is the encoding negotiated with the platform. Guarded by
+// matcha.MainLocker.
+var viewEncoding = encodingProtobuf
+
+func init() {
+	bridge.MainThreadOnly("updateViewWithFlatBuffer")
+	bridge.RegisterFunc("gomatcha.io/matcha/view NegotiateEncoding", negotiateEncoding)
+}
+
+// negotiateEncoding is called by the platform at bridge init with the encodings
+// it can read, separated by commas. It returns the encoding used for updates.
+// Platforms that don't negotiate are sent protobufs.
+func negotiateEncoding(supported string) string {
+	matcha.MainLocker.Lock()
+	defer matcha.MainLocker.Unlock()
+
+	viewEncoding = encodingProtobuf
+	for _, i := range strings.Split(supported, ",") {
+		if i == encodingFlat {
+			viewEncoding = encodingFlat
+		}
+	}
+	return viewEncoding
+}
+
+// MarshalFlat encodes the root with the flat encoding.
+func (root *nodeRoot) MarshalFlat() ([]byte, error) {
+	return marshalFlat(root.MarshalProtobuf())
+}
+
+func marshalFlat(r *pb.Root) ([]byte, error) {
+	nodes := make([]flat.Node, 0, len(r.LayoutPaintNodes))
+	index := map[int64]int{}
+	node := func(id int64) *flat.Node {
+		i, ok := index[id]
+		if !ok {
+			i = len(nodes)
+			index[id] = i
+			nodes = append(nodes, flat.Node{Id: id})
+		}
+		return &nodes[i]
+	}
+
+	for id, i := range r.LayoutPaintNodes {
+		n := node(id)
+		n.Flags |= flat.HasLayoutPaint
+		n.LayoutId = i.LayoutId
+		n.PaintId = i.PaintId
+		n.MinX, n.MinY, n.MaxX, n.MaxY = i.Minx, i.Miny, i.Maxx, i.Maxy
+		n.ZIndex = i.ZIndex
+		n.ChildOrder = i.ChildOrder
+
+		if s := i.PaintStyle; s != nil {
+			n.Transparency = s.Transparency
+			n.BorderWidth = s.BorderWidth
+			n.CornerRadius = s.CornerRadius
+			n.ShadowRadius = s.ShadowRadius
+			if s.ShadowOffset != nil {
+				n.ShadowOffsetX, n.ShadowOffsetY = s.ShadowOffset.X, s.ShadowOffset.Y
+			}
+			if s.BackgroundColor != nil {
+				n.Flags |= flat.HasBackgroundColor
+				n.BackgroundColor = flatColor(s.BackgroundColor)
+			}
+			if s.BorderColor != nil {
+				n.Flags |= flat.HasBorderColor
+				n.BorderColor = flatColor(s.BorderColor)
+			}
+			if s.ShadowColor != nil {
+				n.Flags |= flat.HasShadowColor
+				n.ShadowColor = flatColor(s.ShadowColor)
+			}
+		}
+	}
+	for id, i := range r.BuildNodes {
+		n := node(id)
+		n.Flags |= flat.HasBuild
+		n.BuildId = i.BuildId
+		n.Children = i.Children
+		n.BridgeName = i.BridgeName
+		n.BridgeValue = i.BridgeValue
+		for k, v := range i.Values {
+			n.Values = append(n.Values, flat.Entry{Key: k, Value: v})
+		}
+	}
+
+	middleware := make([]flat.Entry, 0, len(r.Middleware))
+	for k, v := range r.Middleware {
+		var data []byte
+		if v != nil {
+			var err error
+			if data, err = proto.Marshal(v); err != nil {
+				return nil, err
+			}
+		}
+		middleware = append(middleware, flat.Entry{Key: k, Value: data})
+	}
+	return flat.Encode(nodes, middleware), nil
+}
+
+func flatColor(c *pbcolor.Color) flat.Color {
+	return flat.Color{R: uint16(c.Red), G: uint16(c.Green), B: uint16(c.Blue), A: uint16(c.Alpha)}
+}
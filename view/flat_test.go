@@ -0,0 +1,52 @@
+package view
+
+import (
+	"testing"
+
+	"gomatcha.io/matcha/internal/flat"
+	pbcolor "gomatcha.io/matcha/proto"
+	pbpaint "gomatcha.io/matcha/proto/paint"
+	pb "gomatcha.io/matcha/proto/view"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	defer func() { viewEncoding = encodingProtobuf }()
+
+	if e := negotiateEncoding("protobuf"); e != encodingProtobuf {
+		t.Errorf("negotiateEncoding(protobuf) = %v", e)
+	}
+	if e := negotiateEncoding("flat,protobuf"); e != encodingFlat {
+		t.Errorf("negotiateEncoding(flat,protobuf) = %v", e)
+	}
+}
+
+func TestMarshalFlat(t *testing.T) {
+	data, err := marshalFlat(&pb.Root{
+		LayoutPaintNodes: map[int64]*pb.LayoutPaintNode{
+			1: {Id: 1, LayoutId: 2, Maxx: 100, ChildOrder: []int64{2}},
+			2: {Id: 2, PaintStyle: &pbpaint.Style{Transparency: 0.5, BackgroundColor: &pbcolor.Color{Red: 0xffff, Alpha: 0xffff}}},
+		},
+		BuildNodes: map[int64]*pb.BuildNode{
+			2: {Id: 2, BuildId: 3, BridgeName: "a", Values: map[string][]byte{"b": {1}}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := flat.Decode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n := r.Node(r.Find(1))
+	if n.Flags != flat.HasLayoutPaint || n.LayoutId != 2 || n.MaxX != 100 || len(n.ChildOrder) != 1 {
+		t.Errorf("unexpected node 1 %+v", n)
+	}
+	n = r.Node(r.Find(2))
+	if n.Flags != flat.HasBuild|flat.HasLayoutPaint|flat.HasBackgroundColor || n.BuildId != 3 || n.BridgeName != "a" || n.Transparency != 0.5 || n.BackgroundColor.R != 0xffff {
+		t.Errorf("unexpected node 2 %+v", n)
+	}
+	if len(n.Values) != 1 || n.Values[0].Key != "b" {
+		t.Errorf("unexpected values %v", n.Values)
+	}
+}
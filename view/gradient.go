@@ -0,0 +1,75 @@
+package view
+
+import (
+	"encoding/json"
+	"image/color"
+	"math"
+
+	"github.com/gogo/protobuf/proto"
+	"gomatcha.io/matcha/internal"
+	"gomatcha.io/matcha/paint"
+)
+
+const gradientKey = "gomatcha.io/matcha/paint gradient"
+
+func init() {
+	internal.RegisterMiddleware(func() interface{} { return &gradientMiddleware{} })
+}
+
+// gradientMiddleware sends the gradient of a view's paint style to the native
+// views, which don't receive it with the rest of the style.
+type gradientMiddleware struct{}
+
+func (m *gradientMiddleware) MarshalProtobuf() proto.Message {
+	return nil
+}
+
+func (m *gradientMiddleware) Key() string {
+	return gradientKey
+}
+
+func (m *gradientMiddleware) Build(ctx Context, next *Model) {
+	if next.Painter == nil {
+		return
+	}
+	g := next.Painter.PaintStyle().Gradient
+	if g == nil || len(g.Stops) == 0 {
+		return
+	}
+	if next.NativeOptions == nil {
+		next.NativeOptions = map[string][]byte{}
+	}
+	next.NativeOptions[gradientKey] = marshalGradient(g)
+}
+
+// marshalGradient encodes g as JSON. Colors are red, green, blue and alpha
+// between 0 and 1, and stops are sorted by offset.
+func marshalGradient(g *paint.Gradient) []byte {
+	v := struct {
+		Type      int          `json:"type"`
+		Colors    [][4]float64 `json:"colors"`
+		Locations []float64    `json:"locations"`
+		Angle     float64      `json:"angle"`
+		Center    [2]float64   `json:"center"`
+		Radius    float64      `json:"radius"`
+	}{
+		Type:   int(g.Type),
+		Angle:  g.Angle,
+		Center: [2]float64{g.Center.X, g.Center.Y},
+		Radius: g.Radius,
+	}
+	prev := math.Inf(-1)
+	for _, i := range g.Stops {
+		c := [4]float64{}
+		if i.Color != nil {
+			nrgba := color.NRGBA64Model.Convert(i.Color).(color.NRGBA64)
+			c = [4]float64{float64(nrgba.R) / 0xffff, float64(nrgba.G) / 0xffff, float64(nrgba.B) / 0xffff, float64(nrgba.A) / 0xffff}
+		}
+		offset := math.Max(prev, math.Min(math.Max(i.Offset, 0), 1))
+		prev = offset
+		v.Colors = append(v.Colors, c)
+		v.Locations = append(v.Locations, offset)
+	}
+	data, _ := json.Marshal(v)
+	return data
+}
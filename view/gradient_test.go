@@ -0,0 +1,21 @@
+package view
+
+import (
+	"image/color"
+	"testing"
+
+	"gomatcha.io/matcha/paint"
+)
+
+func TestMarshalGradient(t *testing.T) {
+	g := paint.NewLinearGradient(90,
+		paint.GradientStop{Offset: 0.5, Color: color.NRGBA{R: 0xff, A: 0xff}},
+		paint.GradientStop{Offset: 0.25, Color: color.NRGBA{B: 0xff, A: 0xff}},
+		paint.GradientStop{Offset: 2, Color: nil},
+	)
+	got := string(marshalGradient(g))
+	want := `{"type":0,"colors":[[1,0,0,1],[0,0,1,1],[0,0,0,0]],"locations":[0.5,0.5,1],"angle":90,"center":[0,0],"radius":0}`
+	if got != want {
+		t.Errorf("marshalGradient() = %v, want %v", got, want)
+	}
+}
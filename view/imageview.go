@@ -1,6 +1,7 @@
 package view
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -8,16 +9,17 @@ import (
 	"image/color"
 	_ "image/jpeg"
 	_ "image/png"
+	"io/ioutil"
 	"net/http"
 
 	"gomatcha.io/matcha"
-	"gomatcha.io/matcha/application"
 	"gomatcha.io/matcha/comm"
 	"gomatcha.io/matcha/internal"
 	"gomatcha.io/matcha/layout"
 	"gomatcha.io/matcha/paint"
 	pb "gomatcha.io/matcha/proto"
 	pbview "gomatcha.io/matcha/proto/view"
+	_ "gomatcha.io/matcha/svg"
 )
 
 type ImageResizeMode int
@@ -38,7 +40,10 @@ func (m ImageResizeMode) MarshalProtobuf() pbview.ImageResizeMode {
 	return pbview.ImageResizeMode(m)
 }
 
-// ImageView implements a view that displays an image.
+// ImageView implements a view that displays an image. Images loaded from a URL
+// may be PNG, JPEG or SVG, and Image may be an *svg.Image to display an icon
+// at any size. Animated GIFs and APNGs, loaded from a URL or decoded with
+// DecodeAnimatedImage, are played.
 type ImageView struct {
 	Embed
 	Image      image.Image
@@ -46,10 +51,17 @@ type ImageView struct {
 	ResizeMode ImageResizeMode
 	ImageTint  color.Color
 	PaintStyle *paint.Style
+	// Paused pauses animated images on their current frame.
+	Paused bool
+	// LoopCount is the number of times animated images play before stopping,
+	// or 0 to loop forever.
+	LoopCount int
 
 	cancelFunc context.CancelFunc
 	err        error
 	image      *pb.ImageOrResource
+	animation  []byte // Data of the animated image, if any.
+	generation int64  // Incremented when animation changes.
 }
 
 // NewImageView returns a new view.
@@ -77,6 +89,28 @@ func (v *ImageView) Update(v2 View) {
 	}
 }
 
+// IntrinsicSize implements the view.IntrinsicSizer interface. It is the
+// natural size of Image, or zero if there is none.
+func (v *ImageView) IntrinsicSize(max layout.Point) layout.Point {
+	if v.Image == nil {
+		return layout.Pt(0, 0)
+	}
+	bounds := v.Image.Bounds()
+	scale := imageScale(v.Image)
+	return layout.Pt(float64(bounds.Dx())/scale, float64(bounds.Dy())/scale)
+}
+
+// imageScale returns the scale that img is displayed at. Resources and SVG
+// images are displayed at the screen's scale.
+func imageScale(img image.Image) float64 {
+	if res, ok := img.(interface {
+		Scale() float64
+	}); ok {
+		return res.Scale()
+	}
+	return 1
+}
+
 // Build implements view.View.
 func (v *ImageView) Build(ctx Context) Model {
 	// Default to Center if we don't have an image
@@ -86,10 +120,7 @@ func (v *ImageView) Build(ctx Context) Model {
 	if v.Image != nil {
 		bounds = v.Image.Bounds()
 		resizeMode = v.ResizeMode
-
-		if res, ok := v.Image.(*application.ImageResource); ok {
-			scale = res.Scale()
-		}
+		scale = imageScale(v.Image)
 	}
 
 	var painter paint.Painter
@@ -106,17 +137,35 @@ func (v *ImageView) Build(ctx Context) Model {
 			ResizeMode: v.ResizeMode.MarshalProtobuf(),
 			Tint:       pb.ColorEncode(v.ImageTint),
 		}),
+		NativeFuncs: map[string]interface{}{
+			// Animation returns a generation that changes with the animated
+			// image, or 0 if the image isn't animated, whether it is paused,
+			// and its loop count.
+			"Animation": func() (int64, bool, int64) {
+				if v.animation == nil {
+					return 0, v.Paused, int64(v.LoopCount)
+				}
+				return v.generation, v.Paused, int64(v.LoopCount)
+			},
+			"AnimationData": func() []byte {
+				return v.animation
+			},
+		},
 	}
 }
 
 func (v *ImageView) begin() {
-	if v.Image != nil {
+	if a, ok := v.Image.(*AnimatedImage); ok {
+		v.image = internal.ImageMarshalProtobuf(a.Image)
+		v.animation = a.data
+		v.generation++
+	} else if v.Image != nil {
 		v.image = internal.ImageMarshalProtobuf(v.Image)
 	} else if v.URL != "" {
 		c, cancelFunc := context.WithCancel(context.Background())
 		v.cancelFunc = cancelFunc
 		go func(url string) {
-			image, err := loadImageURL(url)
+			image, animation, err := loadImageURL(url)
 
 			matcha.MainLocker.Lock()
 			defer matcha.MainLocker.Unlock()
@@ -128,6 +177,10 @@ func (v *ImageView) begin() {
 				v.cancelFunc = nil
 				v.image = image
 				v.err = err
+				if animation != nil {
+					v.animation = animation
+					v.generation++
+				}
 				v.Signal()
 			}
 		}(v.URL)
@@ -142,6 +195,7 @@ func (v *ImageView) end() {
 		v.cancelFunc = nil
 	}
 	v.image = nil
+	v.animation = nil
 	v.err = nil
 }
 
@@ -180,17 +234,26 @@ func (l *imageViewLayouter) Unnotify(id comm.Id) {
 	// no-op
 }
 
-func loadImageURL(url string) (*pb.ImageOrResource, error) {
+// loadImageURL returns the image at url, and its data if it is animated.
+func loadImageURL(url string) (*pb.ImageOrResource, []byte, error) {
 	resp, err := http.Get(url)
 	if err != nil {
 		fmt.Println("loadImageURL error", err)
-		return nil, err
+		return nil, nil, err
 	}
 	defer resp.Body.Close()
 
-	img, _, err := image.Decode(resp.Body)
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		fmt.Println("loadImageURL error", err)
+		return nil, nil, err
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
 	if err != nil {
 		fmt.Println("decodeImage error", err)
 	}
-	return internal.ImageMarshalProtobuf(img), nil
+	if !isAnimated(data) {
+		data = nil
+	}
+	return internal.ImageMarshalProtobuf(img), data, nil
 }
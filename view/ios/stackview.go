@@ -2,6 +2,7 @@ package ios
 
 import (
 	"fmt"
+	"image"
 	"image/color"
 	"strconv"
 
@@ -14,6 +15,7 @@ import (
 	pbios "gomatcha.io/matcha/proto/view/ios"
 	"gomatcha.io/matcha/text"
 	"gomatcha.io/matcha/view"
+	"gomatcha.io/matcha/view/searchbar"
 )
 
 // Stack represents a list of views to be shown in the StackView. It can be manipulated outside of a Build() call.
@@ -21,13 +23,15 @@ type Stack struct {
 	relay       comm.Relay
 	childIds    []int64
 	childrenMap map[int64]view.View
+	transitions map[int64]*Transition
 	maxId       int64
 }
 
+// SetViews replaces the views in the stack.
 func (s *Stack) SetViews(vs ...view.View) {
-	if s.childrenMap == nil {
-		s.childrenMap = map[int64]view.View{}
-	}
+	s.childIds = nil
+	s.childrenMap = map[int64]view.View{}
+	s.transitions = map[int64]*Transition{}
 
 	for _, i := range vs {
 		s.maxId += 1
@@ -50,23 +54,51 @@ func (s *Stack) Views() []view.View {
 	return vs
 }
 
+// Push adds vs to the top of the stack with the default transition.
 func (s *Stack) Push(vs view.View) {
+	s.PushWithTransition(vs, nil)
+}
+
+// PushWithTransition adds vs to the top of the stack, animating it in with t.
+// It is animated out with the same transition when it is popped. If t is nil,
+// the default transition is used.
+func (s *Stack) PushWithTransition(vs view.View, t *Transition) {
+	if s.childrenMap == nil {
+		s.childrenMap = map[int64]view.View{}
+	}
+	if s.transitions == nil {
+		s.transitions = map[int64]*Transition{}
+	}
 	s.maxId += 1
 
 	s.childIds = append(s.childIds, s.maxId)
 	s.childrenMap[s.maxId] = vs
+	if t != nil {
+		s.transitions[s.maxId] = t
+	}
 	s.relay.Signal()
 }
 
+// Pop removes the top view of the stack, reversing the transition that it was
+// pushed with.
 func (s *Stack) Pop() {
 	if len(s.childIds) <= 1 {
 		return
 	}
-	delete(s.childrenMap, s.childIds[len(s.childIds)-1])
+	id := s.childIds[len(s.childIds)-1]
+	delete(s.childrenMap, id)
+	delete(s.transitions, id)
 	s.childIds = s.childIds[:len(s.childIds)-1]
 	s.relay.Signal()
 }
 
+// setScreen attributes rendering costs to the view on top of the stack.
+func (s *Stack) setScreen() {
+	if count := len(s.childIds); count > 0 {
+		view.SetScreen(fmt.Sprintf("%T", s.childrenMap[s.childIds[count-1]]))
+	}
+}
+
 func (s *Stack) Notify(f func()) comm.Id {
 	return s.relay.Notify(f)
 }
@@ -113,6 +145,9 @@ type StackView struct {
 	TitleStyle *text.Style
 	BackStyle  *text.Style
 	BarColor   color.Color
+
+	screenStack *Stack
+	screenId    comm.Id
 }
 
 // NewStackView returns a new view.
@@ -132,6 +167,12 @@ func (v *StackView) Lifecycle(from, to view.Stage) {
 	} else if view.ExitsStage(from, to, view.StageMounted) {
 		v.Unsubscribe(v.Stack)
 	}
+
+	if view.EntersStage(from, to, view.StageVisible) {
+		v.watchScreen()
+	} else if view.ExitsStage(from, to, view.StageVisible) {
+		v.unwatchScreen()
+	}
 }
 
 func (v *StackView) Update(v2 view.View) {
@@ -143,6 +184,27 @@ func (v *StackView) Update(v2 view.View) {
 		v.Stack = &Stack{}
 	}
 	v.Subscribe(v.Stack)
+
+	if v.screenStack != nil && v.screenStack != v.Stack {
+		v.unwatchScreen()
+		v.watchScreen()
+	}
+}
+
+// watchScreen attributes rendering costs to the top view of the stack while
+// the stack view is visible, and again whenever views are pushed or popped.
+func (v *StackView) watchScreen() {
+	s := v.Stack
+	v.screenStack = s
+	v.screenId = s.Notify(s.setScreen)
+	s.setScreen()
+}
+
+func (v *StackView) unwatchScreen() {
+	if v.screenStack != nil {
+		v.screenStack.Unnotify(v.screenId)
+		v.screenStack = nil
+	}
 }
 
 // Build implements the view.View interface.
@@ -168,6 +230,10 @@ func (v *StackView) Build(ctx view.Context) view.Model {
 		}
 
 		// Add the bar.
+		barHeight := 44.0
+		if bar.TitleMode != TitleModeStandard {
+			barHeight = 96
+		}
 		barV := &stackBarView{
 			Embed: view.Embed{Key: strconv.Itoa(int(id))},
 			Bar:   bar,
@@ -176,7 +242,7 @@ func (v *StackView) Build(ctx view.Context) view.Model {
 			s.Top(0)
 			s.Left(0)
 			s.WidthEqual(l.MaxGuide().Width())
-			s.Height(44)
+			s.Height(barHeight)
 		})
 
 		// Add the child.
@@ -184,13 +250,15 @@ func (v *StackView) Build(ctx view.Context) view.Model {
 			s.Top(0)
 			s.Left(0)
 			s.WidthEqual(l.MaxGuide().Width())
-			s.HeightEqual(l.MaxGuide().Height().Add(-64)) // TODO(KD): Respect bar actual height, shorter when rotated, etc...
+			s.HeightEqual(l.MaxGuide().Height().Add(-20 - barHeight)) // TODO(KD): Respect bar actual height, shorter when rotated, etc...
 		})
 
 		// Add ids to protobuf.
-		childrenPb = append(childrenPb, &pbios.StackChildView{
+		child := &pbios.StackChildView{
 			ScreenId: int64(id),
-		})
+		}
+		v.Stack.transitions[id].marshal(child)
+		childrenPb = append(childrenPb, child)
 	}
 
 	var titleTextStyle *pbtext.TextStyle
@@ -231,9 +299,39 @@ func (v *StackView) Build(ctx view.Context) view.Model {
 type stackBarView struct {
 	view.Embed
 	Bar *StackBar
+
+	notifiers []comm.Notifier // Text and Focused of the search bar.
+}
+
+func (v *stackBarView) Lifecycle(from, to view.Stage) {
+	if view.ExitsStage(from, to, view.StageMounted) {
+		v.subscribe(nil)
+	}
+}
+
+// subscribe updates the view's subscriptions to the values of its search bar.
+func (v *stackBarView) subscribe(notifiers []comm.Notifier) {
+	for _, i := range v.notifiers {
+		found := false
+		for _, j := range notifiers {
+			if i == j {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.Unsubscribe(i)
+		}
+	}
+	for _, i := range notifiers {
+		v.Subscribe(i)
+	}
+	v.notifiers = notifiers
 }
 
 func (v *stackBarView) Build(ctx view.Context) view.Model {
+	v.subscribe(v.Bar.SearchBar.Notifiers())
+
 	l := &constraint.Layouter{}
 
 	// iOS does the layouting for us. We just need the correct sizes.
@@ -269,6 +367,40 @@ func (v *stackBarView) Build(ctx view.Context) view.Model {
 		})
 	}
 
+	funcs := v.Bar.SearchBar.NativeFuncs()
+	titles := make([]string, len(v.Bar.Items))
+	icons := make([][]byte, len(v.Bar.Items))
+	flags := make([]int64, len(v.Bar.Items))
+	for idx, i := range v.Bar.Items {
+		titles[idx] = i.Title
+		if i.Icon != nil {
+			icons[idx] = internal.MarshalProtobuf(internal.ImageMarshalProtobuf(i.Icon))
+		}
+		if !i.Enabled {
+			flags[idx] |= itemFlagDisabled
+		}
+		if i.Overflow {
+			flags[idx] |= itemFlagOverflow
+		}
+	}
+	var scrollEdgeColor []byte
+	if v.Bar.ScrollEdgeColor != nil {
+		scrollEdgeColor = internal.MarshalProtobuf(pb.ColorEncode(v.Bar.ScrollEdgeColor))
+	}
+	// Bar State returns the title mode, the scroll edge color, and the title,
+	// icon and flags of each item.
+	funcs["Bar State"] = func() (int64, []byte, []string, [][]byte, []int64) {
+		return int64(v.Bar.TitleMode), scrollEdgeColor, titles, icons, flags
+	}
+	funcs["Bar OnPress"] = func(index int64) {
+		if index < 0 || int(index) >= len(v.Bar.Items) {
+			return
+		}
+		if f := v.Bar.Items[index].OnPress; f != nil {
+			f()
+		}
+	}
+
 	return view.Model{
 		Layouter:       l,
 		Children:       l.Views(),
@@ -282,9 +414,24 @@ func (v *stackBarView) Build(ctx view.Context) view.Model {
 			RightViewCount:        rightViewCount,
 			LeftViewCount:         leftViewCount,
 		}),
+		NativeFuncs: funcs,
 	}
 }
 
+// TitleMode is the size of the title of a StackBar.
+type TitleMode int
+
+const (
+	// TitleModeStandard displays the title in the bar.
+	TitleModeStandard TitleMode = iota
+	// TitleModeLarge displays a large title below the bar, on iOS 11 and later.
+	TitleModeLarge
+	// TitleModeCollapsing displays a large title that collapses into the bar
+	// as the screen's first scroll view scrolls. The scroll view is only found
+	// on iOS 15 and later, otherwise it behaves like TitleModeLarge.
+	TitleModeCollapsing
+)
+
 type StackBar struct {
 	Title            string
 	BackButtonTitle  string
@@ -293,8 +440,41 @@ type StackBar struct {
 	TitleView  view.View
 	RightViews []view.View
 	LeftViews  []view.View
+	// Items are displayed at the right of the bar, from right to left, before
+	// RightViews. Items with Overflow set are displayed in a menu instead.
+	Items []*StackBarItem
+	// SearchBar, if set, is displayed below the title on iOS 11 and later.
+	SearchBar *searchbar.Bar
+	TitleMode TitleMode
+	// ScrollEdgeColor, if set, is the color of the bar while the screen's
+	// content is scrolled to its top, on iOS 13 and later. A transparent color
+	// hides the bar's background and shadow.
+	ScrollEdgeColor color.Color
 }
 
 func (t *StackBar) OptionKey() string {
 	return "gomatcha.io/view/ios StackBar"
 }
+
+const (
+	itemFlagDisabled = 1 << iota
+	itemFlagOverflow
+)
+
+// StackBarItem is a button in a StackBar.
+type StackBarItem struct {
+	Title   string
+	Icon    image.Image // If set, it is displayed instead of the title, except in the overflow menu.
+	Enabled bool
+	// Overflow displays the item in a menu behind a button at the right of the
+	// bar.
+	Overflow bool
+	OnPress  func()
+}
+
+// NewStackBarItem returns a new item.
+func NewStackBarItem() *StackBarItem {
+	return &StackBarItem{
+		Enabled: true,
+	}
+}
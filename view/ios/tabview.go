@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"image"
 	"image/color"
+	"strconv"
 
 	"github.com/gogo/protobuf/proto"
 	"gomatcha.io/matcha/comm"
@@ -72,6 +73,8 @@ type TabView struct {
 	UnselectedTextStyle *text.Style
 	SelectedColor       color.Color
 	UnselectedColor     color.Color
+
+	notifiers []comm.Notifier // BadgeCount and Visible of the tab buttons.
 }
 
 // NewTabView returns a new view.
@@ -90,6 +93,10 @@ func (v *TabView) Lifecycle(from, to view.Stage) {
 		v.Subscribe(v.Tabs)
 	} else if view.ExitsStage(from, to, view.StageMounted) {
 		v.Unsubscribe(v.Tabs)
+		for _, i := range v.notifiers {
+			v.Unsubscribe(i)
+		}
+		v.notifiers = nil
 	}
 }
 
@@ -110,7 +117,11 @@ func (v *TabView) Build(ctx view.Context) view.Model {
 	l := &constraint.Layouter{}
 
 	childrenPb := []*pbios.TabChildView{}
-	for _, chld := range v.Tabs.Views() {
+	visible := []int{} // Index in Tabs of each displayed tab.
+	flags := []int64{}
+	notifiers := []comm.Notifier{}
+	selectedIndex := 0
+	for idx, chld := range v.Tabs.Views() {
 		// Find the button
 		var button *TabButton
 
@@ -127,6 +138,21 @@ func (v *TabView) Build(ctx view.Context) view.Model {
 			}
 		}
 
+		if button.BadgeCount != nil {
+			notifiers = append(notifiers, button.BadgeCount)
+		}
+		if button.Visible != nil {
+			notifiers = append(notifiers, button.Visible)
+			if !button.Visible.Value() {
+				continue
+			}
+		}
+		if idx == v.Tabs.SelectedIndex() {
+			selectedIndex = len(visible)
+		}
+		visible = append(visible, idx)
+		flags = append(flags, button.flags())
+
 		// Add the child.
 		l.Add(chld, func(s *constraint.Solver) {
 			s.TopEqual(constraint.Const(0))
@@ -140,9 +166,10 @@ func (v *TabView) Build(ctx view.Context) view.Model {
 			Title:        button.Title,
 			Icon:         internal.ImageMarshalProtobuf(button.Icon),
 			SelectedIcon: internal.ImageMarshalProtobuf(button.SelectedIcon),
-			Badge:        button.Badge,
+			Badge:        button.badge(),
 		})
 	}
+	v.subscribe(notifiers)
 
 	var selectedTextStyle *pbtext.TextStyle
 	if v.SelectedTextStyle != nil {
@@ -160,7 +187,7 @@ func (v *TabView) Build(ctx view.Context) view.Model {
 		NativeViewName: "gomatcha.io/matcha/view/tabscreen",
 		NativeViewState: internal.MarshalProtobuf(&pbios.TabView{
 			Screens:             childrenPb,
-			SelectedIndex:       int64(v.Tabs.SelectedIndex()),
+			SelectedIndex:       int64(selectedIndex),
 			BarColor:            pb.ColorEncode(v.BarColor),
 			SelectedColor:       pb.ColorEncode(v.SelectedColor),
 			UnselectedColor:     pb.ColorEncode(v.UnselectedColor),
@@ -176,18 +203,78 @@ func (v *TabView) Build(ctx view.Context) view.Model {
 					return
 				}
 
-				v.Tabs.SetSelectedIndex(int(pbevent.SelectedIndex))
+				if idx := int(pbevent.SelectedIndex); idx >= 0 && idx < len(visible) {
+					v.Tabs.SetSelectedIndex(visible[idx])
+				}
+			},
+			// TabFlags returns the state of each displayed tab that is not
+			// part of the native view state. Bit 0 disables the tab, and bit 1
+			// displays its badge as a dot.
+			"TabFlags": func() []int64 {
+				return flags
 			},
 		},
 	}
 }
 
+// subscribe updates the view's subscriptions to the notifiers of its tab
+// buttons.
+func (v *TabView) subscribe(notifiers []comm.Notifier) {
+	for _, i := range v.notifiers {
+		found := false
+		for _, j := range notifiers {
+			if i == j {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.Unsubscribe(i)
+		}
+	}
+	for _, i := range notifiers {
+		v.Subscribe(i)
+	}
+	v.notifiers = notifiers
+}
+
 // TabButton describes a UITabBarItem.
 type TabButton struct {
 	Title        string
 	Icon         image.Image
 	SelectedIcon image.Image
 	Badge        string
+	// BadgeCount, if set, is displayed as the badge instead of Badge. The badge
+	// is hidden while the count is 0.
+	BadgeCount comm.IntNotifier
+	// BadgeDot displays the badge as a dot without text.
+	BadgeDot bool
+	// Disabled prevents the user from selecting the tab.
+	Disabled bool
+	// Visible, if set, hides the tab while its value is false. Tabs are added
+	// and removed as it changes.
+	Visible comm.BoolNotifier
+}
+
+func (t *TabButton) badge() string {
+	if t.BadgeCount != nil {
+		if c := t.BadgeCount.Value(); c != 0 {
+			return strconv.Itoa(c)
+		}
+		return ""
+	}
+	return t.Badge
+}
+
+func (t *TabButton) flags() int64 {
+	var f int64
+	if t.Disabled {
+		f |= 1
+	}
+	if t.BadgeDot {
+		f |= 2
+	}
+	return f
 }
 
 func (t *TabButton) OptionKey() string {
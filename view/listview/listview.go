@@ -0,0 +1,385 @@
+// Package listview implements a list that displays large numbers of rows by
+// recycling native cells, backed by UITableView on iOS and RecyclerView on
+// Android. The rows are described in Go by a DataSource, and only the rows near
+// the visible area are built.
+//
+//  type contacts []string
+//
+//  func (c contacts) Count() int               { return len(c) }
+//  func (c contacts) ReuseId(index int) string { return "contact" }
+//  func (c contacts) View(index int) view.View {
+//      label := view.NewTextView()
+//      label.String = c[index]
+//      return label
+//  }
+//
+//  list := listview.New()
+//  list.DataSource = contacts{"Ann", "Bob"}
+//
+// Rows can be dragged to reorder them by setting Reorder and OnMove, which
+// must move the row in the data source.
+//
+//  list.Reorder = listview.ReorderHandle
+//  list.OnMove = func(from, to int) {
+//      c := app.contacts
+//      row := c[from]
+//      c = append(c[:from], c[from+1:]...)
+//      app.contacts = append(c[:to], append([]string{row}, c[to:]...)...)
+//  }
+//
+// Long lists can be loaded a page at a time with OnReachedEnd.
+//
+//  list.Paging = app.paging // *listview.PageValue
+//  list.OnReachedEnd = func() {
+//      go app.fetchPage() // Sets app.paging to PageIdle when done.
+//  }
+package listview
+
+import (
+	"math"
+
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/internal/recycle"
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+)
+
+const (
+	// DefaultRowHeight is the height of the rows of new lists.
+	DefaultRowHeight = 44
+
+	// HandleWidth is the width of the handles displayed by ReorderHandle.
+	HandleWidth = 44
+
+	overscanRows = 4  // Rows built beyond each end of the visible rows.
+	initialRows  = 16 // Rows built before the native view reports the visible rows.
+)
+
+// Reorder is how the rows of a ListView are dragged to reorder them.
+type Reorder int
+
+const (
+	// ReorderNone doesn't reorder the rows.
+	ReorderNone Reorder = iota
+	// ReorderLongPress drags a row once it is long pressed. On iOS it requires
+	// iOS 11.
+	ReorderLongPress
+	// ReorderHandle displays a handle at the trailing edge of each row, which
+	// drags the row when touched. The rows are narrowed by HandleWidth to make
+	// room for it.
+	ReorderHandle
+)
+
+// DataSource provides the rows of a ListView.
+type DataSource interface {
+	// Count returns the number of rows.
+	Count() int
+	// ReuseId returns the reuse identifier of the row at index. Rows with the
+	// same identifier share native cells, and the view built for one of them is
+	// updated in place to display another, so View must return views of the same
+	// type for them.
+	ReuseId(index int) string
+	// View returns the view displayed by the row at index.
+	View(index int) view.View
+}
+
+// Sizer is implemented by data sources whose rows have different heights.
+type Sizer interface {
+	// Height returns the height of the row at index, in a list of the given
+	// width.
+	Height(index int, width float64) float64
+}
+
+// ListView displays the rows of DataSource in a vertically scrolling list.
+// Changes to the rows are displayed when the list is updated by its parent or
+// when Reload is called.
+type ListView struct {
+	view.Embed
+	DataSource DataSource
+	// RowHeight is the height of the rows if DataSource is not a Sizer.
+	RowHeight float64
+	// OnSelect is called with the index of a row when it is tapped.
+	OnSelect func(index int)
+	// Refreshing is true while the pull-to-refresh control is spinning. The
+	// control is only displayed if OnRefresh is set.
+	Refreshing *comm.BoolValue
+	// OnRefresh is called when the user pulls down to refresh, after Refreshing
+	// is set to true. Set Refreshing to false when the refresh completes.
+	OnRefresh func()
+	// Reorder is how rows are dragged to reorder them. Rows are only reordered
+	// if OnMove is set.
+	Reorder Reorder
+	// OnMove is called when the user drags the row at from to to. It must move
+	// the row in DataSource before it returns, and the rows in between shift by
+	// one. On Android it is called each time the dragged row passes another
+	// one, and on iOS once the row is dropped.
+	OnMove func(from, to int)
+	// OnReachedEnd is called to load the next page of rows when the user
+	// scrolls within EndThreshold rows of the end of the list, after Paging is
+	// set to PageLoading. Set Paging to PageIdle once the rows are added,
+	// PageFailed if they can't be loaded, or PageEnd if there are no more. It
+	// isn't called again until the number of rows changes.
+	OnReachedEnd func()
+	EndThreshold int
+	// Paging is the state of the next page. Like Refreshing, it must be kept
+	// across builds of the list.
+	Paging *PageValue
+	// Footer returns the view displayed after the rows while the next page is
+	// loading or failed to load. If it is nil, a spinner or a retry button is
+	// displayed.
+	Footer       func(state PageState) view.View
+	FooterHeight float64
+	PaintStyle   *paint.Style
+
+	reload     bool
+	generation int64
+	count      int
+	rows       int // count, plus the footer.
+	endCount   int // count when OnReachedEnd was last called.
+	first      int // Visible rows, as reported by the native view.
+	last       int
+	width      float64
+	recycler   recycle.Recycler
+
+	prevRefreshing *comm.BoolValue
+	prevPaging     *PageValue
+}
+
+// New returns a new view.
+func New() *ListView {
+	return &ListView{
+		RowHeight:    DefaultRowHeight,
+		Refreshing:   &comm.BoolValue{},
+		EndThreshold: DefaultEndThreshold,
+		Paging:       &PageValue{},
+		FooterHeight: DefaultFooterHeight,
+		last:         initialRows,
+		endCount:     -1,
+		reload:       true,
+	}
+}
+
+// Lifecycle implements the view.View interface.
+func (v *ListView) Lifecycle(from, to view.Stage) {
+	if view.ExitsStage(from, to, view.StageMounted) {
+		v.Unsubscribe(v.prevRefreshing)
+		v.Unsubscribe(v.prevPaging)
+	}
+}
+
+// Update implements the view.View interface.
+func (v *ListView) Update(v2 view.View) {
+	view.CopyFields(v, v2)
+	v.reload = true
+	if v.Refreshing == nil {
+		v.Refreshing = &comm.BoolValue{}
+	}
+	if v.Paging == nil {
+		v.Paging = &PageValue{}
+	}
+}
+
+// Reload rebuilds the rows from DataSource. Call it after the rows change.
+func (v *ListView) Reload() {
+	v.reload = true
+	v.Signal()
+}
+
+// Build implements the view.View interface.
+func (v *ListView) Build(ctx view.Context) view.Model {
+	if v.reload {
+		v.reload = false
+		v.generation++
+		v.count = 0
+		if v.DataSource != nil {
+			v.count = v.DataSource.Count()
+		}
+	}
+	if v.Refreshing != v.prevRefreshing {
+		if v.prevRefreshing != nil {
+			v.Unsubscribe(v.prevRefreshing)
+		}
+		v.prevRefreshing = v.Refreshing
+		if v.Refreshing != nil {
+			v.Subscribe(v.Refreshing)
+		}
+	}
+	if v.Paging != v.prevPaging {
+		if v.prevPaging != nil {
+			v.Unsubscribe(v.prevPaging)
+		}
+		v.prevPaging = v.Paging
+		if v.Paging != nil {
+			v.Subscribe(v.Paging)
+		}
+	}
+	v.rows = v.count
+	if v.hasFooter() {
+		v.rows++
+	}
+
+	first := clampRow(v.first-overscanRows, v.rows)
+	last := clampRow(v.last+overscanRows, v.rows)
+	var cells []view.View
+	if v.DataSource != nil {
+		cells = v.recycler.Cells(first, last, v.reuseId, v.viewFor)
+	}
+
+	var painter paint.Painter
+	if v.PaintStyle != nil {
+		painter = v.PaintStyle
+	}
+	return view.Model{
+		Children:       cells,
+		Layouter:       &listLayouter{list: v, first: first},
+		Painter:        painter,
+		NativeViewName: "gomatcha.io/matcha/view/listview",
+		NativeFuncs: map[string]interface{}{
+			// Rows returns the number of rows, and a generation that changes
+			// when the native view must reload them.
+			"Rows": func() (int64, int64) {
+				v.checkEnd()
+				return int64(v.rows), v.generation
+			},
+			// Row returns the reuse identifier and height of a row.
+			"Row": func(index int64) (string, float64) {
+				if index < 0 || int(index) >= v.rows {
+					return "", 0
+				}
+				return v.reuseId(int(index)), v.rowHeight(int(index))
+			},
+			// ChildRows returns the row displayed by each child view.
+			"ChildRows": func() []int64 {
+				return v.recycler.Rows()
+			},
+			"OnVisible": func(first, last int64) {
+				if int(first) != v.first || int(last) != v.last {
+					v.first, v.last = int(first), int(last)
+					v.Signal()
+				}
+				v.checkEnd()
+			},
+			"OnSelect": func(index int64) {
+				if v.OnSelect != nil && int(index) < v.count {
+					v.OnSelect(int(index))
+				}
+			},
+			// Refresh returns whether the refresh control is displayed, and
+			// whether it is spinning.
+			"Refresh": func() (bool, bool) {
+				return v.OnRefresh != nil, v.Refreshing != nil && v.Refreshing.Value()
+			},
+			"OnRefresh": func() {
+				if v.Refreshing != nil {
+					v.Refreshing.SetValue(true)
+				}
+				if v.OnRefresh != nil {
+					v.OnRefresh()
+				}
+			},
+			// Reorder returns how rows are reordered.
+			"Reorder": func() int64 {
+				return int64(v.reorder())
+			},
+			// OnMove is called once the native view has moved the row, so the
+			// cells follow their rows rather than being reloaded.
+			"OnMove": func(from, to int64) {
+				if v.OnMove == nil || from < 0 || to < 0 || int(from) >= v.count || int(to) >= v.count {
+					return
+				}
+				v.recycler.Move(int(from), int(to))
+				v.OnMove(int(from), int(to))
+				v.Signal()
+			},
+		},
+	}
+}
+
+func (v *ListView) reorder() Reorder {
+	if v.OnMove == nil {
+		return ReorderNone
+	}
+	return v.Reorder
+}
+
+func (v *ListView) reuseId(row int) string {
+	if row >= v.count {
+		return footerReuseId
+	}
+	return v.DataSource.ReuseId(row)
+}
+
+func (v *ListView) viewFor(row int) view.View {
+	if row >= v.count {
+		return v.footerView()
+	}
+	return v.DataSource.View(row)
+}
+
+func (v *ListView) rowHeight(row int) float64 {
+	if row >= v.count {
+		return v.FooterHeight
+	}
+	if s, ok := v.DataSource.(Sizer); ok {
+		return s.Height(row, v.width)
+	}
+	return v.RowHeight
+}
+
+func clampRow(row, rows int) int {
+	if row < 0 {
+		return 0
+	}
+	if row > rows {
+		return rows
+	}
+	return row
+}
+
+// listLayouter positions the cells one under another. The native views place
+// them in their cells themselves.
+type listLayouter struct {
+	list  *ListView
+	first int
+}
+
+func (l *listLayouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	list := l.list
+	size := ctx.MinSize()
+	width := size.X
+	if list.reorder() == ReorderHandle {
+		width = math.Max(width-HandleWidth, 0)
+	}
+	if list.width != width {
+		list.width = width
+		if _, ok := list.DataSource.(Sizer); ok {
+			list.Reload()
+		}
+	}
+
+	y := float64(l.first) * list.RowHeight
+	if _, ok := list.DataSource.(Sizer); ok {
+		y = 0
+		for row := 0; row < l.first; row++ {
+			y += list.rowHeight(row)
+		}
+	}
+	gs := make([]layout.Guide, ctx.ChildCount())
+	for i := range gs {
+		height := list.rowHeight(l.first + i)
+		g := ctx.LayoutChild(i, layout.Pt(width, height), layout.Pt(width, height))
+		g.Frame = layout.Rt(0, y, width, y+height)
+		gs[i] = g
+		y += height
+	}
+	return layout.Guide{Frame: layout.Rt(0, 0, size.X, size.Y)}, gs
+}
+
+func (l *listLayouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+func (l *listLayouter) Unnotify(id comm.Id) {
+	// no-op
+}
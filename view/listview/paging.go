@@ -0,0 +1,132 @@
+package listview
+
+import (
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/layout/constraint"
+	"gomatcha.io/matcha/view"
+	"gomatcha.io/matcha/view/progress"
+)
+
+const (
+	// DefaultEndThreshold is the EndThreshold of new lists.
+	DefaultEndThreshold = 5
+	// DefaultFooterHeight is the FooterHeight of new lists.
+	DefaultFooterHeight = 56
+
+	footerReuseId = "gomatcha.io/matcha/view/listview footer"
+)
+
+// PageState is the state of the next page of a list that is loaded by
+// OnReachedEnd.
+type PageState int
+
+const (
+	// PageIdle waits for the user to scroll near the end of the list.
+	PageIdle PageState = iota
+	// PageLoading displays the loading footer.
+	PageLoading
+	// PageFailed displays the footer with a retry button.
+	PageFailed
+	// PageEnd stops loading pages, because the list is complete.
+	PageEnd
+)
+
+// PageValue holds a PageState and notifies when it changes.
+type PageValue struct {
+	value comm.IntValue
+}
+
+// Notify implements the comm.Notifier interface.
+func (v *PageValue) Notify(f func()) comm.Id {
+	return v.value.Notify(f)
+}
+
+// Unnotify implements the comm.Notifier interface.
+func (v *PageValue) Unnotify(id comm.Id) {
+	v.value.Unnotify(id)
+}
+
+// Value returns the state.
+func (v *PageValue) Value() PageState {
+	return PageState(v.value.Value())
+}
+
+// SetValue sets the state and notifies.
+func (v *PageValue) SetValue(s PageState) {
+	v.value.SetValue(int(s))
+}
+
+// LoadNextPage sets Paging to PageLoading and calls OnReachedEnd, for example
+// to retry a page that failed to load from a custom footer.
+func (v *ListView) LoadNextPage() {
+	if v.OnReachedEnd == nil {
+		return
+	}
+	v.endCount = v.count
+	v.Paging.SetValue(PageLoading)
+	v.OnReachedEnd()
+}
+
+// checkEnd calls OnReachedEnd if the visible rows are within EndThreshold rows
+// of the end. It is only called once for each number of rows, so that a page
+// that doesn't add any rows isn't requested again and again.
+func (v *ListView) checkEnd() {
+	if v.OnReachedEnd == nil || v.Paging.Value() != PageIdle || v.count == v.endCount {
+		return
+	}
+	if v.last < v.count-v.EndThreshold {
+		return
+	}
+	v.LoadNextPage()
+}
+
+// hasFooter returns true if the footer is displayed after the rows.
+func (v *ListView) hasFooter() bool {
+	if v.OnReachedEnd == nil {
+		return false
+	}
+	s := v.Paging.Value()
+	return s == PageLoading || s == PageFailed
+}
+
+func (v *ListView) footerView() view.View {
+	state := v.Paging.Value()
+	if v.Footer != nil {
+		return v.Footer(state)
+	}
+	return &footer{state: state, retry: v.LoadNextPage}
+}
+
+// footer is the default footer. It displays a spinner while the page loads,
+// and a retry button if it failed.
+type footer struct {
+	view.Embed
+	state PageState
+	retry func()
+}
+
+// Build implements the view.View interface.
+func (v *footer) Build(ctx view.Context) view.Model {
+	l := &constraint.Layouter{}
+	if v.state == PageFailed {
+		button := view.NewButton()
+		button.String = "Retry"
+		button.OnPress = v.retry
+		l.Add(button, func(s *constraint.Solver) {
+			s.CenterXEqual(l.CenterX())
+			s.CenterYEqual(l.CenterY())
+		})
+	} else {
+		spinner := progress.New()
+		spinner.Style = progress.StyleCircular
+		spinner.Indeterminate = true
+		l.Add(spinner, func(s *constraint.Solver) {
+			s.CenterXEqual(l.CenterX())
+			s.CenterYEqual(l.CenterY())
+		})
+	}
+	return view.Model{
+		Children: l.Views(),
+		Layouter: l,
+	}
+}
@@ -0,0 +1,47 @@
+package listview
+
+import "testing"
+
+func TestCheckEnd(t *testing.T) {
+	calls := 0
+	v := New()
+	v.OnReachedEnd = func() {
+		calls++
+	}
+	v.count = 50
+	v.last = 20
+	v.checkEnd()
+	if calls != 0 {
+		t.Fatalf("OnReachedEnd called away from the end")
+	}
+
+	v.last = 46
+	v.checkEnd()
+	if calls != 1 || v.Paging.Value() != PageLoading {
+		t.Fatalf("got %v calls and state %v, want 1 call and PageLoading", calls, v.Paging.Value())
+	}
+	v.checkEnd()
+	if calls != 1 {
+		t.Fatalf("OnReachedEnd called while loading")
+	}
+
+	// A page that doesn't add any rows isn't requested again.
+	v.Paging.SetValue(PageIdle)
+	v.checkEnd()
+	if calls != 1 {
+		t.Fatalf("OnReachedEnd called again for the same rows")
+	}
+
+	v.count = 100
+	v.last = 97
+	v.checkEnd()
+	if calls != 2 {
+		t.Fatalf("OnReachedEnd not called after a page was added")
+	}
+
+	v.Paging.SetValue(PageFailed)
+	v.LoadNextPage()
+	if calls != 3 || v.Paging.Value() != PageLoading {
+		t.Fatalf("LoadNextPage didn't retry")
+	}
+}
@@ -0,0 +1,56 @@
+package media
+
+import (
+	"container/list"
+	"image"
+)
+
+// cache holds the most recently used thumbnails, so cells that are scrolled back
+// into view don't wait on the library.
+type cache struct {
+	max     int
+	order   *list.List
+	entries map[cacheKey]*list.Element
+}
+
+type cacheKey struct {
+	asset string
+	size  int
+}
+
+type cacheEntry struct {
+	key   cacheKey
+	image image.Image
+}
+
+func newCache(max int) *cache {
+	return &cache{
+		max:     max,
+		order:   list.New(),
+		entries: map[cacheKey]*list.Element{},
+	}
+}
+
+func (c *cache) get(asset string, size int) image.Image {
+	e, ok := c.entries[cacheKey{asset, size}]
+	if !ok {
+		return nil
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*cacheEntry).image
+}
+
+func (c *cache) add(asset string, size int, img image.Image) {
+	key := cacheKey{asset, size}
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*cacheEntry).image = img
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, image: img})
+	for c.order.Len() > c.max {
+		e := c.order.Back()
+		c.order.Remove(e)
+		delete(c.entries, e.Value.(*cacheEntry).key)
+	}
+}
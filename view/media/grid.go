@@ -0,0 +1,530 @@
+package media
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"strconv"
+
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/internal/device"
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/pointer"
+	"gomatcha.io/matcha/text"
+	"gomatcha.io/matcha/view"
+)
+
+// Grid displays the thumbnails of an album in square cells. Only the rows near
+// the visible area are built, and thumbnails of the rows just beyond them are
+// cached ahead of time, so large albums scroll quickly.
+type Grid struct {
+	view.Embed
+	Library Library
+	// Album is the id of the album to display.
+	Album   string
+	Columns int
+	Spacing float64
+	// Selection is updated as cells are tapped. If Selection is nil, OnPress is
+	// called instead.
+	Selection  *Selection
+	OnPress    func(asset string)
+	PaintStyle *paint.Style
+
+	scrollPosition view.ScrollPosition
+	cache          *cache
+	library        Library
+	album          string
+	assets         []string
+	err            error
+	prevSelection  *Selection
+
+	// Set by the layouter.
+	width    float64
+	viewport float64
+	offset   float64
+
+	// Rows that have been built and rows whose thumbnails are being cached.
+	first, last           int
+	cacheFirst, cacheLast int
+	cacheSize             int
+}
+
+// NewGrid returns a new view.
+func NewGrid() *Grid {
+	return &Grid{
+		Columns: 4,
+		Spacing: 1,
+	}
+}
+
+// Lifecycle implements the view.View interface.
+func (v *Grid) Lifecycle(from, to view.Stage) {
+	if view.ExitsStage(from, to, view.StageMounted) {
+		if v.prevSelection != nil {
+			v.Unsubscribe(v.prevSelection)
+			v.prevSelection = nil
+		}
+		v.stopCaching()
+	}
+}
+
+// Build implements the view.View interface.
+func (v *Grid) Build(ctx view.Context) view.Model {
+	if v.cache == nil {
+		v.cache = newCache(thumbnailCacheSize)
+	}
+	if v.Selection != v.prevSelection {
+		if v.prevSelection != nil {
+			v.Unsubscribe(v.prevSelection)
+		}
+		if v.Selection != nil {
+			v.Subscribe(v.Selection)
+		}
+		v.prevSelection = v.Selection
+	}
+
+	// Reload the assets and scroll to the top when the album changes.
+	if v.Library != v.library || v.Album != v.album {
+		v.stopCaching()
+		v.library = v.Library
+		v.album = v.Album
+		v.assets = nil
+		v.err = nil
+		if v.Library != nil {
+			v.assets, v.err = v.Library.Assets(v.Album)
+		}
+		v.offset = 0
+		v.first, v.last = v.visibleRows()
+		v.scrollPosition.SetValue(layout.Pt(0, 0))
+	}
+
+	columns := v.columns()
+	size := v.cellSize()
+	pixels := int(math.Ceil(size * device.ScreenScale))
+
+	cells := []view.View{}
+	for row := v.first; row < v.last; row++ {
+		for col := 0; col < columns; col++ {
+			idx := row*columns + col
+			if idx >= len(v.assets) {
+				break
+			}
+			asset := v.assets[idx]
+
+			cell := newCell()
+			cell.Key = asset
+			cell.Library = v.Library
+			cell.Cache = v.cache
+			cell.Asset = asset
+			cell.Size = pixels
+			cell.Index = -1
+			if v.Selection != nil {
+				cell.Index = v.Selection.Index(asset)
+			}
+			cell.OnPress = func() {
+				if v.Selection != nil {
+					v.Selection.Toggle(asset)
+				} else if v.OnPress != nil {
+					v.OnPress(asset)
+				}
+			}
+			cells = append(cells, cell)
+		}
+	}
+
+	scrollView := view.NewScrollView()
+	scrollView.ScrollPosition = &v.scrollPosition
+	scrollView.ContentChildren = cells
+	scrollView.ContentLayouter = &gridLayouter{grid: v}
+	scrollView.OnScroll = func(p layout.Point) {
+		v.offset = p.Y
+		v.update()
+	}
+
+	children := []view.View{scrollView}
+	if v.err != nil {
+		label := view.NewTextView()
+		label.String = v.err.Error()
+		label.Style.SetFont(text.DefaultFont(15))
+		label.Style.SetTextColor(color.Gray{Y: 142})
+		label.Style.SetAlignment(text.AlignmentCenter)
+		children = append(children, label)
+	}
+
+	var painter paint.Painter
+	if v.PaintStyle != nil {
+		painter = v.PaintStyle
+	}
+	return view.Model{
+		Children: children,
+		Layouter: &viewportLayouter{grid: v},
+		Painter:  painter,
+	}
+}
+
+func (v *Grid) columns() int {
+	if v.Columns < 1 {
+		return 1
+	}
+	return v.Columns
+}
+
+func (v *Grid) cellSize() float64 {
+	columns := float64(v.columns())
+	return math.Max((v.width-v.Spacing*(columns-1))/columns, 0)
+}
+
+func (v *Grid) rows() int {
+	columns := v.columns()
+	return (len(v.assets) + columns - 1) / columns
+}
+
+// visibleRows returns the range of rows that intersect the viewport, extended by
+// overscanRows in each direction.
+func (v *Grid) visibleRows() (int, int) {
+	rowHeight := v.cellSize() + v.Spacing
+	if rowHeight <= v.Spacing {
+		return 0, 0
+	}
+	first := int(math.Floor(v.offset/rowHeight)) - overscanRows
+	last := int(math.Ceil((v.offset+v.viewport)/rowHeight)) + overscanRows
+	return clampRow(first, v.rows()), clampRow(last, v.rows())
+}
+
+func clampRow(row, rows int) int {
+	if row < 0 {
+		return 0
+	}
+	if row > rows {
+		return rows
+	}
+	return row
+}
+
+// update rebuilds the grid if the visible rows have changed, and caches the
+// thumbnails of the rows ahead of them.
+func (v *Grid) update() {
+	first, last := v.visibleRows()
+	if first != v.first || last != v.last {
+		v.first, v.last = first, last
+		v.Signal()
+	}
+
+	if v.Library == nil {
+		return
+	}
+	size := int(math.Ceil(v.cellSize() * device.ScreenScale))
+	cacheFirst := clampRow(first-prefetchRows, v.rows())
+	cacheLast := clampRow(last+prefetchRows, v.rows())
+	if size != v.cacheSize {
+		v.stopCaching()
+		v.cacheSize = size
+	}
+	if cacheFirst == v.cacheFirst && cacheLast == v.cacheLast {
+		return
+	}
+
+	var start, stop []string
+	for row := min(cacheFirst, v.cacheFirst); row < max(cacheLast, v.cacheLast); row++ {
+		cached := row >= v.cacheFirst && row < v.cacheLast
+		caching := row >= cacheFirst && row < cacheLast
+		if cached == caching {
+			continue
+		}
+		assets := v.row(row)
+		if caching {
+			start = append(start, assets...)
+		} else {
+			stop = append(stop, assets...)
+		}
+	}
+	v.Library.StopCaching(stop, size)
+	v.Library.StartCaching(start, size)
+	v.cacheFirst, v.cacheLast = cacheFirst, cacheLast
+}
+
+func (v *Grid) stopCaching() {
+	if v.library != nil && v.cacheLast > v.cacheFirst {
+		var assets []string
+		for row := v.cacheFirst; row < v.cacheLast; row++ {
+			assets = append(assets, v.row(row)...)
+		}
+		v.library.StopCaching(assets, v.cacheSize)
+	}
+	v.cacheFirst, v.cacheLast = 0, 0
+}
+
+func (v *Grid) row(row int) []string {
+	columns := v.columns()
+	start := clampRow(row*columns, len(v.assets))
+	end := clampRow((row+1)*columns, len(v.assets))
+	return v.assets[start:end]
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// viewportLayouter fills the grid with its scroll view, and records the size of
+// the viewport. The error label, if any, is centered over it.
+type viewportLayouter struct {
+	grid *Grid
+}
+
+func (l *viewportLayouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	size := ctx.MinSize()
+	if l.grid.width != size.X || l.grid.viewport != size.Y {
+		l.grid.width = size.X
+		l.grid.viewport = size.Y
+		l.grid.update()
+	}
+
+	g := ctx.LayoutChild(0, size, size)
+	g.Frame = layout.Rt(0, 0, size.X, size.Y)
+	gs := []layout.Guide{g}
+
+	if ctx.ChildCount() > 1 {
+		width := math.Max(size.X-30, 0)
+		label := ctx.LayoutChild(1, layout.Pt(width, 0), layout.Pt(width, size.Y))
+		y := (size.Y - label.Height()) / 2
+		label.Frame = layout.Rt(15, y, 15+width, y+label.Height())
+		label.ZIndex = 1
+		gs = append(gs, label)
+	}
+	return layout.Guide{Frame: layout.Rt(0, 0, size.X, size.Y)}, gs
+}
+
+func (l *viewportLayouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+func (l *viewportLayouter) Unnotify(id comm.Id) {
+	// no-op
+}
+
+// gridLayouter positions the built cells at their row and column, and sizes the
+// content to fit every row.
+type gridLayouter struct {
+	grid *Grid
+}
+
+func (l *gridLayouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	grid := l.grid
+	width := ctx.MinSize().X
+	columns := grid.columns()
+	size := grid.cellSize()
+
+	gs := make([]layout.Guide, ctx.ChildCount())
+	for i := range gs {
+		idx := grid.first*columns + i
+		x := float64(idx%columns) * (size + grid.Spacing)
+		y := float64(idx/columns) * (size + grid.Spacing)
+
+		g := ctx.LayoutChild(i, layout.Pt(size, size), layout.Pt(size, size))
+		g.Frame = layout.Rt(x, y, x+size, y+size)
+		gs[i] = g
+	}
+
+	height := math.Max(float64(grid.rows())*(size+grid.Spacing)-grid.Spacing, 0)
+	return layout.Guide{Frame: layout.Rt(0, 0, width, height)}, gs
+}
+
+func (l *gridLayouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+func (l *gridLayouter) Unnotify(id comm.Id) {
+	// no-op
+}
+
+// cell displays the thumbnail of an asset, and its position in the selection if
+// it is selected.
+type cell struct {
+	view.Embed
+	Library Library
+	Cache   *cache
+	Asset   string
+	Size    int
+	// Index is the position of the asset in the selection, or -1.
+	Index   int
+	OnPress func()
+
+	image     image.Image
+	requestId int64
+	cancel    func()
+}
+
+func newCell() *cell {
+	return &cell{}
+}
+
+// Lifecycle implements the view.View interface.
+func (v *cell) Lifecycle(from, to view.Stage) {
+	if view.EntersStage(from, to, view.StageMounted) {
+		v.request()
+	} else if view.ExitsStage(from, to, view.StageMounted) {
+		v.cancelRequest()
+	}
+}
+
+// Update implements the view.View interface.
+func (v *cell) Update(v2 view.View) {
+	prev := v2.(*cell)
+	if prev.Asset != v.Asset || prev.Size != v.Size || prev.Library != v.Library {
+		v.cancelRequest()
+		view.CopyFields(v, v2)
+		v.request()
+	} else {
+		view.CopyFields(v, v2)
+	}
+}
+
+func (v *cell) request() {
+	v.image = nil
+	if v.Library == nil || v.Size == 0 {
+		return
+	}
+	if img := v.Cache.get(v.Asset, v.Size); img != nil {
+		v.image = img
+		return
+	}
+
+	v.requestId += 1
+	id, asset, size := v.requestId, v.Asset, v.Size
+	v.cancel = v.Library.Thumbnail(asset, size, func(img image.Image, err error) {
+		if err != nil {
+			return
+		}
+		v.Cache.add(asset, size, img)
+		if id == v.requestId {
+			v.image = img
+			v.Signal()
+		}
+	})
+}
+
+func (v *cell) cancelRequest() {
+	v.requestId += 1
+	if v.cancel != nil {
+		v.cancel()
+		v.cancel = nil
+	}
+}
+
+// Build implements the view.View interface.
+func (v *cell) Build(ctx view.Context) view.Model {
+	imageView := view.NewImageView()
+	imageView.Image = v.image
+	imageView.ResizeMode = view.ImageResizeModeFill
+	imageView.PaintStyle = &paint.Style{BackgroundColor: color.RGBA{R: 229, G: 229, B: 234, A: 255}}
+	children := []view.View{imageView}
+
+	if v.Index >= 0 {
+		overlay := view.NewBasicView()
+		overlay.Painter = &paint.Style{BackgroundColor: color.RGBA{R: 255, G: 255, B: 255, A: 77}}
+
+		label := view.NewTextView()
+		label.String = strconv.Itoa(v.Index + 1)
+		label.MaxLines = 1
+		label.Style.SetFont(text.DefaultBoldFont(13))
+		label.Style.SetTextColor(color.White)
+		label.Style.SetAlignment(text.AlignmentCenter)
+
+		badge := view.NewBasicView()
+		badge.Children = []view.View{label}
+		badge.Layouter = &badgeLayouter{}
+		badge.Painter = &paint.Style{
+			BackgroundColor: color.RGBA{R: 0, G: 122, B: 255, A: 255},
+			BorderColor:     color.White,
+			BorderWidth:     1.5,
+			CornerRadius:    badgeSize / 2,
+		}
+		children = append(children, overlay, badge)
+	}
+
+	tap := &pointer.TapGesture{
+		Count: 1,
+		OnEvent: func(e *pointer.TapEvent) {
+			if e.Kind == pointer.EventKindRecognized && v.OnPress != nil {
+				v.OnPress()
+			}
+		},
+	}
+	return view.Model{
+		Children: children,
+		Layouter: &cellLayouter{},
+		Options: []view.Option{
+			pointer.GestureList{tap},
+		},
+	}
+}
+
+// cellLayouter fills the cell with the image and overlay, and places the badge in
+// the top right corner.
+type cellLayouter struct{}
+
+func (l *cellLayouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	size := ctx.MinSize()
+	gs := make([]layout.Guide, ctx.ChildCount())
+	for i := range gs {
+		if i == 2 {
+			g := ctx.LayoutChild(i, layout.Pt(badgeSize, badgeSize), layout.Pt(badgeSize, badgeSize))
+			g.Frame = layout.Rt(size.X-badgeSize-4, 4, size.X-4, 4+badgeSize)
+			gs[i] = g
+			continue
+		}
+		g := ctx.LayoutChild(i, size, size)
+		g.Frame = layout.Rt(0, 0, size.X, size.Y)
+		gs[i] = g
+	}
+	return layout.Guide{Frame: layout.Rt(0, 0, size.X, size.Y)}, gs
+}
+
+func (l *cellLayouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+func (l *cellLayouter) Unnotify(id comm.Id) {
+	// no-op
+}
+
+// badgeLayouter centers the label vertically in the badge.
+type badgeLayouter struct{}
+
+func (l *badgeLayouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	size := ctx.MinSize()
+	g := ctx.LayoutChild(0, layout.Pt(size.X, 0), size)
+	y := (size.Y - g.Height()) / 2
+	g.Frame = layout.Rt(0, y, size.X, y+g.Height())
+	return layout.Guide{Frame: layout.Rt(0, 0, size.X, size.Y)}, []layout.Guide{g}
+}
+
+func (l *badgeLayouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+func (l *badgeLayouter) Unnotify(id comm.Id) {
+	// no-op
+}
+
+const (
+	badgeSize = 24
+	// overscanRows is the number of rows built beyond the viewport.
+	overscanRows = 2
+	// prefetchRows is the number of rows beyond the built rows whose thumbnails
+	// are cached ahead of time.
+	prefetchRows = 6
+	// thumbnailCacheSize is the number of decoded thumbnails kept in memory.
+	thumbnailCacheSize = 300
+)
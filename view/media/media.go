@@ -0,0 +1,108 @@
+// Package media implements an in-app picker for the photos and videos in the
+// device's media library. It is meant for apps that need a custom picker rather
+// than the system sheet.
+//
+//  picker := media.NewPicker()
+//  picker.OnDone = func(assets []string) {
+//      ...
+//  }
+package media
+
+import (
+	"errors"
+	"image"
+
+	"gomatcha.io/matcha/comm"
+)
+
+// ErrUnsupported is returned by a Library on platforms without a media library.
+var ErrUnsupported = errors.New("media: library is not supported on this platform")
+
+// Album is a collection of assets, such as the camera roll.
+type Album struct {
+	Id    string
+	Title string
+	Count int
+}
+
+// Library provides the albums and thumbnails displayed by a Grid. Assets are
+// identified by opaque strings, the PHAsset local identifier on iOS and the
+// content URI on Android.
+type Library interface {
+	Albums() ([]*Album, error)
+	// Assets returns the assets in album, newest first.
+	Assets(album string) ([]string, error)
+	// Thumbnail requests an image of asset that fills a square of size pixels. f is
+	// called once with the result, while holding matcha.MainLocker. The returned
+	// func cancels the request.
+	Thumbnail(asset string, size int, f func(image.Image, error)) (cancel func())
+	// StartCaching prepares thumbnails of assets ahead of time, so they are quick to
+	// request once they are scrolled into view.
+	StartCaching(assets []string, size int)
+	// StopCaching stops preparing thumbnails of assets.
+	StopCaching(assets []string, size int)
+}
+
+// Selection is the ordered list of assets that have been selected in a Grid.
+type Selection struct {
+	// Max is the maximum number of assets that can be selected. If Max is 0 there
+	// is no limit.
+	Max    int
+	assets []string
+	relay  comm.Relay
+}
+
+// Assets returns the selected assets in the order they were selected.
+func (s *Selection) Assets() []string {
+	return append([]string(nil), s.assets...)
+}
+
+// Len returns the number of selected assets.
+func (s *Selection) Len() int {
+	return len(s.assets)
+}
+
+// Index returns the position of asset in the selection, or -1 if it is not selected.
+func (s *Selection) Index(asset string) int {
+	for i, a := range s.assets {
+		if a == asset {
+			return i
+		}
+	}
+	return -1
+}
+
+// Toggle selects asset, or deselects it if it is already selected. It returns
+// false if asset could not be selected because the selection is full.
+func (s *Selection) Toggle(asset string) bool {
+	if idx := s.Index(asset); idx != -1 {
+		s.assets = append(s.assets[:idx], s.assets[idx+1:]...)
+		s.relay.Signal()
+		return true
+	}
+	if s.Max > 0 && len(s.assets) >= s.Max {
+		return false
+	}
+	s.assets = append(s.assets, asset)
+	s.relay.Signal()
+	return true
+}
+
+// Clear deselects all assets.
+func (s *Selection) Clear() {
+	if len(s.assets) == 0 {
+		return
+	}
+	s.assets = nil
+	s.relay.Signal()
+}
+
+// Notify implements the comm.Notifier interface.
+func (s *Selection) Notify(f func()) comm.Id {
+	return s.relay.Notify(f)
+}
+
+// Unnotify implements the comm.Notifier interface.
+func (s *Selection) Unnotify(id comm.Id) {
+	s.relay.Unnotify(id)
+}
@@ -0,0 +1,153 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	_ "image/jpeg"
+	"runtime"
+
+	"gomatcha.io/matcha"
+	"gomatcha.io/matcha/bridge"
+)
+
+var thumbnailMaxId int64
+var thumbnails map[int64]func(image.Image, error)
+
+func init() {
+	thumbnails = map[int64]func(image.Image, error){}
+	bridge.RegisterFunc("gomatcha.io/matcha/view/media onThumbnail", func(id int64, data []byte) {
+		matcha.MainLocker.Lock()
+		defer matcha.MainLocker.Unlock()
+
+		f, ok := thumbnails[id]
+		if !ok {
+			return
+		}
+		delete(thumbnails, id)
+
+		if len(data) == 0 {
+			f(nil, errors.New("media: thumbnail unavailable"))
+			return
+		}
+		img, _, err := image.Decode(bytes.NewReader(data))
+		f(img, err)
+	})
+}
+
+// DefaultLibrary returns the device's media library, backed by
+// PHCachingImageManager on iOS and MediaStore on Android. On iOS the app's
+// Info.plist must contain NSPhotoLibraryUsageDescription, and on Android the app
+// must hold the READ_EXTERNAL_STORAGE permission.
+func DefaultLibrary() Library {
+	return nativeLibrary{}
+}
+
+type nativeLibrary struct{}
+
+func (l nativeLibrary) Albums() ([]*Album, error) {
+	var v *bridge.Value
+	if runtime.GOOS == "android" {
+		v = bridge.Bridge("").CallNow("getMediaAlbums")
+	} else if runtime.GOOS == "darwin" {
+		v = bridge.Bridge("").CallNow("mediaAlbums")
+	} else {
+		return nil, ErrUnsupported
+	}
+	if v.IsNil() {
+		return nil, errors.New("media: permission denied")
+	}
+
+	albums := []*Album{}
+	for _, i := range v.ToArray() {
+		fields := i.ToArray()
+		if len(fields) != 3 {
+			continue
+		}
+		albums = append(albums, &Album{
+			Id:    fields[0].ToString(),
+			Title: fields[1].ToString(),
+			Count: int(fields[2].ToInt64()),
+		})
+	}
+	return albums, nil
+}
+
+func (l nativeLibrary) Assets(album string) ([]string, error) {
+	var v *bridge.Value
+	if runtime.GOOS == "android" {
+		v = bridge.Bridge("").CallNow("getMediaAssets", bridge.String(album))
+	} else if runtime.GOOS == "darwin" {
+		v = bridge.Bridge("").CallNow("mediaAssetsForAlbum:", bridge.String(album))
+	} else {
+		return nil, ErrUnsupported
+	}
+	if v.IsNil() {
+		return nil, errors.New("media: permission denied")
+	}
+
+	assets := []string{}
+	for _, i := range v.ToArray() {
+		assets = append(assets, i.ToString())
+	}
+	return assets, nil
+}
+
+func (l nativeLibrary) Thumbnail(asset string, size int, f func(image.Image, error)) func() {
+	if runtime.GOOS != "android" && runtime.GOOS != "darwin" {
+		f(nil, ErrUnsupported)
+		return func() {}
+	}
+
+	thumbnailMaxId += 1
+	id := thumbnailMaxId
+	thumbnails[id] = f
+
+	// The result is delivered asynchronously through onThumbnail.
+	if runtime.GOOS == "android" {
+		bridge.Bridge("").Call("requestMediaThumbnail", bridge.String(asset), bridge.Int64(int64(size)), bridge.Int64(id))
+	} else {
+		bridge.Bridge("").Call("requestMediaThumbnail:size:id:", bridge.String(asset), bridge.Int64(int64(size)), bridge.Int64(id))
+	}
+	return func() {
+		if _, ok := thumbnails[id]; !ok {
+			return
+		}
+		delete(thumbnails, id)
+		if runtime.GOOS == "android" {
+			bridge.Bridge("").Call("cancelMediaThumbnail", bridge.Int64(id))
+		} else {
+			bridge.Bridge("").Call("cancelMediaThumbnail:", bridge.Int64(id))
+		}
+	}
+}
+
+func (l nativeLibrary) StartCaching(assets []string, size int) {
+	if len(assets) == 0 {
+		return
+	}
+	if runtime.GOOS == "android" {
+		bridge.Bridge("").Call("startCachingMedia", stringArray(assets), bridge.Int64(int64(size)))
+	} else if runtime.GOOS == "darwin" {
+		bridge.Bridge("").Call("startCachingMedia:size:", stringArray(assets), bridge.Int64(int64(size)))
+	}
+}
+
+func (l nativeLibrary) StopCaching(assets []string, size int) {
+	if len(assets) == 0 {
+		return
+	}
+	if runtime.GOOS == "android" {
+		bridge.Bridge("").Call("stopCachingMedia", stringArray(assets), bridge.Int64(int64(size)))
+	} else if runtime.GOOS == "darwin" {
+		bridge.Bridge("").Call("stopCachingMedia:size:", stringArray(assets), bridge.Int64(int64(size)))
+	}
+}
+
+func stringArray(a []string) *bridge.Value {
+	vs := make([]*bridge.Value, len(a))
+	for i, s := range a {
+		vs[i] = bridge.String(s)
+	}
+	return bridge.Array(vs...)
+}
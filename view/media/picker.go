@@ -0,0 +1,269 @@
+package media
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/layout/constraint"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/pointer"
+	"gomatcha.io/matcha/text"
+	"gomatcha.io/matcha/view"
+)
+
+// Picker is a full screen media picker. It displays a bar of the library's albums
+// above a Grid of the selected album, and lets the user select multiple assets.
+type Picker struct {
+	view.Embed
+	// Library defaults to DefaultLibrary().
+	Library Library
+	Columns int
+	// MaxSelection is the maximum number of assets that can be selected. If it is 0
+	// there is no limit.
+	MaxSelection int
+	OnDone       func(assets []string)
+	OnCancel     func()
+	PaintStyle   *paint.Style
+
+	library   Library
+	albums    []*Album
+	album     string
+	err       error
+	selection Selection
+}
+
+// NewPicker returns a new view.
+func NewPicker() *Picker {
+	return &Picker{
+		Columns:    4,
+		PaintStyle: &paint.Style{BackgroundColor: color.White},
+	}
+}
+
+// Lifecycle implements the view.View interface.
+func (v *Picker) Lifecycle(from, to view.Stage) {
+	if view.EntersStage(from, to, view.StageMounted) {
+		v.Subscribe(&v.selection)
+	} else if view.ExitsStage(from, to, view.StageMounted) {
+		v.Unsubscribe(&v.selection)
+	}
+}
+
+// Build implements the view.View interface.
+func (v *Picker) Build(ctx view.Context) view.Model {
+	library := v.Library
+	if library == nil {
+		library = DefaultLibrary()
+	}
+	if library != v.library {
+		v.library = library
+		v.albums, v.err = library.Albums()
+		v.album = ""
+		if len(v.albums) > 0 {
+			v.album = v.albums[0].Id
+		}
+		v.selection.Clear()
+	}
+	v.selection.Max = v.MaxSelection
+
+	l := &constraint.Layouter{}
+
+	cancel := view.NewButton()
+	cancel.String = "Cancel"
+	cancel.OnPress = func() {
+		if v.OnCancel != nil {
+			v.OnCancel()
+		}
+	}
+	cancelGuide := l.Add(cancel, func(s *constraint.Solver) {
+		s.TopEqual(l.Top().Add(barPadding))
+		s.LeadingEqual(l.Leading().Add(barPadding))
+	})
+
+	done := view.NewButton()
+	done.String = "Done"
+	if n := v.selection.Len(); n > 0 {
+		done.String = fmt.Sprintf("Done (%d)", n)
+	}
+	done.Enabled = v.selection.Len() > 0
+	done.OnPress = func() {
+		if v.OnDone != nil {
+			v.OnDone(v.selection.Assets())
+		}
+	}
+	l.Add(done, func(s *constraint.Solver) {
+		s.CenterYEqual(cancelGuide.CenterY())
+		s.TrailingEqual(l.Trailing().Add(-barPadding))
+	})
+
+	tabs := []view.View{}
+	for _, i := range v.albums {
+		album := i
+		tab := newAlbumTab()
+		tab.Key = album.Id
+		tab.Album = album
+		tab.Selected = album.Id == v.album
+		tab.OnPress = func() {
+			if v.album != album.Id {
+				v.album = album.Id
+				v.Signal()
+			}
+		}
+		tabs = append(tabs, tab)
+	}
+	albumBar := view.NewScrollView()
+	albumBar.ScrollAxes = layout.AxisX
+	albumBar.IndicatorAxes = 0
+	albumBar.ContentChildren = tabs
+	albumBar.ContentLayouter = &tabsLayouter{}
+	albumBarGuide := l.Add(albumBar, func(s *constraint.Solver) {
+		s.TopEqual(cancelGuide.Bottom().Add(barPadding))
+		s.LeftEqual(l.Left())
+		s.RightEqual(l.Right())
+		s.Height(albumBarHeight)
+	})
+
+	grid := NewGrid()
+	grid.Library = library
+	grid.Album = v.album
+	grid.Columns = v.Columns
+	grid.Selection = &v.selection
+	l.Add(grid, func(s *constraint.Solver) {
+		s.TopEqual(albumBarGuide.Bottom())
+		s.LeftEqual(l.Left())
+		s.RightEqual(l.Right())
+		s.BottomEqual(l.Bottom())
+	})
+
+	if v.err != nil {
+		label := view.NewTextView()
+		label.String = v.err.Error()
+		label.Style.SetFont(text.DefaultFont(15))
+		label.Style.SetTextColor(color.Gray{Y: 142})
+		label.Style.SetAlignment(text.AlignmentCenter)
+		l.Add(label, func(s *constraint.Solver) {
+			s.CenterYEqual(l.CenterY())
+			s.LeftEqual(l.Left().Add(15))
+			s.RightEqual(l.Right().Add(-15))
+		})
+	}
+
+	var painter paint.Painter
+	if v.PaintStyle != nil {
+		painter = v.PaintStyle
+	}
+	return view.Model{
+		Children: l.Views(),
+		Layouter: l,
+		Painter:  painter,
+	}
+}
+
+// albumTab displays the title and size of an album in the album bar.
+type albumTab struct {
+	view.Embed
+	Album    *Album
+	Selected bool
+	OnPress  func()
+}
+
+func newAlbumTab() *albumTab {
+	return &albumTab{}
+}
+
+// Build implements the view.View interface.
+func (v *albumTab) Build(ctx view.Context) view.Model {
+	label := view.NewTextView()
+	label.String = fmt.Sprintf("%s %d", v.Album.Title, v.Album.Count)
+	label.MaxLines = 1
+	if v.Selected {
+		label.Style.SetFont(text.DefaultBoldFont(15))
+		label.Style.SetTextColor(color.Black)
+	} else {
+		label.Style.SetFont(text.DefaultFont(15))
+		label.Style.SetTextColor(color.Gray{Y: 142})
+	}
+	children := []view.View{label}
+
+	if v.Selected {
+		underline := view.NewBasicView()
+		underline.Painter = &paint.Style{BackgroundColor: color.RGBA{R: 0, G: 122, B: 255, A: 255}}
+		children = append(children, underline)
+	}
+
+	tap := &pointer.TapGesture{
+		Count: 1,
+		OnEvent: func(e *pointer.TapEvent) {
+			if e.Kind == pointer.EventKindRecognized && v.OnPress != nil {
+				v.OnPress()
+			}
+		},
+	}
+	return view.Model{
+		Children: children,
+		Layouter: &albumTabLayouter{},
+		Options: []view.Option{
+			pointer.GestureList{tap},
+		},
+	}
+}
+
+// albumTabLayouter centers the label vertically and places the underline, if any,
+// along the bottom.
+type albumTabLayouter struct{}
+
+func (l *albumTabLayouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	height := ctx.MinSize().Y
+	label := ctx.LayoutChild(0, layout.Pt(0, 0), layout.Pt(math.Inf(1), height))
+	width := label.Width() + barPadding*2
+	y := (height - label.Height()) / 2
+	label.Frame = layout.Rt(barPadding, y, barPadding+label.Width(), y+label.Height())
+	gs := []layout.Guide{label}
+
+	if ctx.ChildCount() > 1 {
+		underline := ctx.LayoutChild(1, layout.Pt(width, 2), layout.Pt(width, 2))
+		underline.Frame = layout.Rt(0, height-2, width, height)
+		gs = append(gs, underline)
+	}
+	return layout.Guide{Frame: layout.Rt(0, 0, width, height)}, gs
+}
+
+func (l *albumTabLayouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+func (l *albumTabLayouter) Unnotify(id comm.Id) {
+	// no-op
+}
+
+// tabsLayouter places the album tabs side by side.
+type tabsLayouter struct{}
+
+func (l *tabsLayouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	height := ctx.MinSize().Y
+	gs := make([]layout.Guide, ctx.ChildCount())
+	x := 0.0
+	for i := range gs {
+		g := ctx.LayoutChild(i, layout.Pt(0, height), layout.Pt(math.Inf(1), height))
+		g.Frame = layout.Rt(x, 0, x+g.Width(), height)
+		x += g.Width()
+		gs[i] = g
+	}
+	return layout.Guide{Frame: layout.Rt(0, 0, x, height)}, gs
+}
+
+func (l *tabsLayouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+func (l *tabsLayouter) Unnotify(id comm.Id) {
+	// no-op
+}
+
+const (
+	barPadding     = 10
+	albumBarHeight = 44
+)
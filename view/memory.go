@@ -0,0 +1,109 @@
+package view
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"time"
+
+	"gomatcha.io/matcha"
+	"gomatcha.io/matcha/bridge"
+)
+
+// liveRoots contains the roots whose native view has not been released. It is
+// guarded by matcha.MainLocker.
+var liveRoots = map[int64]*root{}
+
+// MemoryStats counts the objects that are alive in a root.
+type MemoryStats struct {
+	Root        int64
+	Views       int // Go view instances.
+	NativeViews int // Views in the native hierarchy, or -1 if unknown.
+	Images      int // Image views.
+}
+
+// MemorySnapshot counts the objects that are alive across the Go and native
+// boundary. Taking snapshots before and after repeatedly navigating to a screen
+// shows which objects that screen leaks.
+//
+//  before := view.TakeMemorySnapshot()
+//  // Push and pop the screen a few times.
+//  view.WriteMemoryReport(os.Stdout, before, view.TakeMemorySnapshot())
+type MemorySnapshot struct {
+	Time           time.Time
+	Roots          []MemoryStats // Sorted by root id.
+	TrackedGo      int           // Go values referenced by native code.
+	TrackedForeign int           // Native objects referenced by Go.
+}
+
+// TakeMemorySnapshot counts the objects that are currently alive. Run the garbage
+// collector beforehand for accurate foreign object counts.
+func TakeMemorySnapshot() MemorySnapshot {
+	matcha.MainLocker.Lock()
+	defer matcha.MainLocker.Unlock()
+
+	s := MemorySnapshot{
+		Time:           time.Now(),
+		TrackedGo:      bridge.TrackedGo(),
+		TrackedForeign: bridge.TrackedForeign(),
+	}
+	for id, r := range liveRoots {
+		stats := MemoryStats{Root: id, NativeViews: -1}
+		countNodes(r.root.node, &stats)
+		if runtime.GOOS == "android" {
+			stats.NativeViews = int(bridge.Bridge("").CallNow("nativeViewCount", bridge.Int64(id)).ToInt64())
+		} else if runtime.GOOS == "darwin" {
+			stats.NativeViews = int(bridge.Bridge("").CallNow("nativeViewCount:", bridge.Int64(id)).ToInt64())
+		}
+		s.Roots = append(s.Roots, stats)
+	}
+	sort.Slice(s.Roots, func(i, j int) bool {
+		return s.Roots[i].Root < s.Roots[j].Root
+	})
+	return s
+}
+
+func countNodes(n *node, stats *MemoryStats) {
+	stats.Views += 1
+	if n.model != nil && n.model.NativeViewName == "gomatcha.io/matcha/view/imageview" {
+		stats.Images += 1
+	}
+	for _, i := range n.children {
+		countNodes(i, stats)
+	}
+}
+
+// WriteMemoryReport writes the counts in cur and their growth since prev to w.
+// Roots that are not in prev are reported as growing from 0.
+func WriteMemoryReport(w io.Writer, prev, cur MemorySnapshot) error {
+	prevRoots := map[int64]MemoryStats{}
+	for _, i := range prev.Roots {
+		prevRoots[i.Root] = i
+	}
+
+	_, err := fmt.Fprintf(w, "Memory after %v: %v roots, %v tracked Go values (%+d), %v tracked foreign objects (%+d)\n",
+		cur.Time.Sub(prev.Time).Round(time.Millisecond),
+		len(cur.Roots),
+		cur.TrackedGo, cur.TrackedGo-prev.TrackedGo,
+		cur.TrackedForeign, cur.TrackedForeign-prev.TrackedForeign)
+	if err != nil {
+		return err
+	}
+	for _, i := range cur.Roots {
+		p := prevRoots[i.Root]
+		native := "unknown"
+		if i.NativeViews >= 0 {
+			native = fmt.Sprintf("%v (%+d)", i.NativeViews, i.NativeViews-p.NativeViews)
+			if p.NativeViews < 0 {
+				native = fmt.Sprintf("%v (%+d)", i.NativeViews, i.NativeViews)
+			}
+		}
+		_, err := fmt.Fprintf(w, "  root %v: %v views (%+d), %v native views, %v images (%+d)\n",
+			i.Root, i.Views, i.Views-p.Views, native, i.Images, i.Images-p.Images)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+// Package modal implements modal presentation. A View displays its Content
+// inline, and presents its Modal view over the rest of the app while Presented
+// is true, with a presented UIViewController on iOS and a Dialog on Android.
+//
+//  v := modal.New()
+//  v.Content = list
+//  v.Modal = details
+//  v.Style = modal.StyleBottomSheet
+//  v.OnDismiss = func() {
+//      ...
+//  }
+//  ...
+//  v.Presented.SetValue(true)
+package modal
+
+import (
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+)
+
+// Style is the way the modal view is presented.
+type Style int
+
+const (
+	// StyleFullScreen covers the whole screen.
+	StyleFullScreen Style = iota
+	// StylePageSheet covers the screen on phones, leaving the presenting view
+	// visible behind it on iOS 13 and later. On tablets it is a centered
+	// sheet. The user can dismiss it by swiping it down on iOS, or with the back
+	// button on Android.
+	StylePageSheet
+	// StyleBottomSheet slides up from the bottom of the screen, with the height
+	// of its first detent. The user can dismiss it by swiping it down, or by
+	// tapping outside of it on Android.
+	StyleBottomSheet
+)
+
+// Detent is a height at which a bottom sheet rests.
+type Detent int
+
+const (
+	// DetentMedium covers about half of the screen.
+	DetentMedium Detent = iota
+	// DetentLarge covers almost all of the screen.
+	DetentLarge
+)
+
+// View displays Content, and presents Modal while Presented is true.
+type View struct {
+	view.Embed
+	Content view.View
+	Modal   view.View
+	Style   Style
+	// Detents are the heights the user can resize a bottom sheet to. They are
+	// only supported on iOS 15 and later, and on Android the sheet has the
+	// height of the first detent. If empty, the sheet is resizable between
+	// DetentMedium and DetentLarge.
+	Detents []Detent
+	// Presented is true while Modal is presented. Setting it presents or
+	// dismisses Modal, and it is set to false when the user dismisses Modal.
+	Presented *comm.BoolValue
+	// OnPresent is called once the presentation animation completes.
+	OnPresent func()
+	// OnDismiss is called once Modal is dismissed, by setting Presented to
+	// false or by the user.
+	OnDismiss  func()
+	PaintStyle *paint.Style
+
+	prevPresented *comm.BoolValue
+	showing       bool         // Modal is presented or being dismissed.
+	modalSize     layout.Point // Size of the presented view, as reported by the native view.
+}
+
+// New returns a new view.
+func New() *View {
+	return &View{
+		Presented: &comm.BoolValue{},
+	}
+}
+
+// Lifecycle implements the view.View interface.
+func (v *View) Lifecycle(from, to view.Stage) {
+	if view.ExitsStage(from, to, view.StageMounted) {
+		v.Unsubscribe(v.prevPresented)
+	}
+}
+
+// Update implements the view.View interface.
+func (v *View) Update(v2 view.View) {
+	view.CopyFields(v, v2)
+	if v.Presented == nil {
+		v.Presented = &comm.BoolValue{}
+	}
+}
+
+// Build implements the view.View interface.
+func (v *View) Build(ctx view.Context) view.Model {
+	if v.Presented != v.prevPresented {
+		if v.prevPresented != nil {
+			v.Unsubscribe(v.prevPresented)
+		}
+		v.prevPresented = v.Presented
+		if v.Presented != nil {
+			v.Subscribe(v.Presented)
+		}
+	}
+	presented := v.Presented != nil && v.Presented.Value() && v.Modal != nil
+	if presented {
+		v.showing = true
+	}
+
+	// The modal view stays in the hierarchy until the dismissal completes.
+	contentIdx, modalIdx := -1, -1
+	children := []view.View{}
+	if v.Content != nil {
+		contentIdx = len(children)
+		children = append(children, v.Content)
+	}
+	if v.Modal != nil && v.showing {
+		modalIdx = len(children)
+		children = append(children, v.Modal)
+	}
+
+	detents := []int64{}
+	for _, i := range v.Detents {
+		detents = append(detents, int64(i))
+	}
+	if len(detents) == 0 {
+		detents = []int64{int64(DetentMedium), int64(DetentLarge)}
+	}
+
+	var painter paint.Painter
+	if v.PaintStyle != nil {
+		painter = v.PaintStyle
+	}
+	return view.Model{
+		Children:       children,
+		Layouter:       &modalLayouter{content: contentIdx, modal: modalIdx, modalSize: v.modalSize},
+		Painter:        painter,
+		NativeViewName: "gomatcha.io/matcha/view/modal",
+		NativeFuncs: map[string]interface{}{
+			// State returns whether Modal is presented, the presentation style,
+			// the detents of bottom sheets, and the indexes of the content and
+			// modal children, or -1 if they are missing.
+			"State": func() (bool, int64, []int64, int64, int64) {
+				return presented, int64(v.Style), detents, int64(contentIdx), int64(modalIdx)
+			},
+			"OnSize": func(width, height float64) {
+				if size := layout.Pt(width, height); size != v.modalSize {
+					v.modalSize = size
+					v.Signal()
+				}
+			},
+			"OnPresent": func() {
+				if v.OnPresent != nil {
+					v.OnPresent()
+				}
+			},
+			"OnDismiss": func() {
+				v.showing = false
+				v.Signal()
+				if v.Presented != nil {
+					v.Presented.SetValue(false)
+				}
+				if v.OnDismiss != nil {
+					v.OnDismiss()
+				}
+			},
+		},
+	}
+}
+
+// modalLayouter gives the content the view's size, and the modal view the size
+// of the native view presenting it. The native views position them.
+type modalLayouter struct {
+	content   int
+	modal     int
+	modalSize layout.Point
+}
+
+func (l *modalLayouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	size := ctx.MinSize()
+	gs := make([]layout.Guide, ctx.ChildCount())
+	if l.content >= 0 {
+		g := ctx.LayoutChild(l.content, size, size)
+		g.Frame = layout.Rt(0, 0, size.X, size.Y)
+		gs[l.content] = g
+	}
+	if l.modal >= 0 {
+		modalSize := l.modalSize
+		if modalSize.X == 0 && modalSize.Y == 0 {
+			modalSize = size
+		}
+		g := ctx.LayoutChild(l.modal, modalSize, modalSize)
+		g.Frame = layout.Rt(0, 0, modalSize.X, modalSize.Y)
+		gs[l.modal] = g
+	}
+	return layout.Guide{Frame: layout.Rt(0, 0, size.X, size.Y)}, gs
+}
+
+func (l *modalLayouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+func (l *modalLayouter) Unnotify(id comm.Id) {
+	// no-op
+}
@@ -17,6 +17,7 @@ import (
 	"gomatcha.io/matcha/bridge"
 	"gomatcha.io/matcha/comm"
 	"gomatcha.io/matcha/internal"
+	"gomatcha.io/matcha/internal/platform"
 	"gomatcha.io/matcha/layout"
 	"gomatcha.io/matcha/layout/full"
 	"gomatcha.io/matcha/paint"
@@ -41,6 +42,7 @@ type root struct {
 }
 
 func init() {
+	bridge.MainThreadOnly("updateViewWithProtobuf", "updateId:withProtobuf:")
 	bridge.RegisterFunc("gomatcha.io/matcha/view NewRoot", func(v View) *root {
 		return _newRoot(v)
 	})
@@ -61,32 +63,52 @@ func (r *root) start() {
 	defer matcha.MainLocker.Unlock()
 
 	id := r.id
+	liveRoots[id] = r
 	r.ticker = internal.NewTicker(time.Hour * 99999)
 	_ = r.ticker.Notify(func() {
 		matcha.MainLocker.Lock()
 		defer matcha.MainLocker.Unlock()
 
+		// Native calls made during the update are sent to the platform
+		// together, before the views are updated.
+		b := bridge.BeginBatch()
+		defer b.End()
+
+		start := time.Now()
 		if !r.root.update(r.size) {
 			// nothing changed
 			return
 		}
 
-		pb, err := r.root.MarshalProtobuf2()
+		var pb []byte
+		var err error
+		if viewEncoding == encodingFlat {
+			pb, err = r.root.MarshalFlat()
+		} else {
+			pb, err = r.root.MarshalProtobuf2()
+		}
 		if err != nil {
 			fmt.Println("err", err)
 			return
 		}
+		buildTime := time.Since(start)
 
 		// fmt.Println(r.root.node.debugString())
 		fmt.Println("Update") // TODO(KD): Remove.
 
+		start = time.Now()
 		success := false
-		if runtime.GOOS == "android" {
-			success = bridge.Bridge("").Call("updateViewWithProtobuf", bridge.Int64(id), bridge.Bytes(pb)).ToBool()
+		if viewEncoding == encodingFlat {
+			success = bridge.Bridge("").CallNow("updateViewWithFlatBuffer", bridge.Int64(id), bridge.Bytes(pb)).ToBool()
+		} else if platform.UsesJavaStyleBridge() {
+			success = bridge.Bridge("").CallNow("updateViewWithProtobuf", bridge.Int64(id), bridge.Bytes(pb)).ToBool()
 		} else if runtime.GOOS == "darwin" {
-			success = bridge.Bridge("").Call("updateId:withProtobuf:", bridge.Int64(id), bridge.Bytes(pb)).ToBool()
+			success = bridge.Bridge("").CallNow("updateId:withProtobuf:", bridge.Int64(id), bridge.Bytes(pb)).ToBool()
 		}
+		profileUpdate(len(pb), buildTime, time.Since(start))
 		if !success {
+			// The native view was released.
+			delete(liveRoots, id)
 			r.ticker.Stop()
 		}
 	})
@@ -153,6 +175,21 @@ func (ctx *viewContext) Path() []Id {
 	return ctx.node.path
 }
 
+// NewCallback returns bridge.NewCallback(f), closed when the view built with ctx
+// unmounts. Callbacks created in Build are kept until then, so views that are
+// rebuilt often should create theirs once.
+func NewCallback(ctx Context, f interface{}) *bridge.Callback {
+	return CloseOnUnmount(ctx, bridge.NewCallback(f))
+}
+
+// CloseOnUnmount closes c when the view built with ctx unmounts, and returns c.
+func CloseOnUnmount(ctx Context, c *bridge.Callback) *bridge.Callback {
+	if vc, ok := ctx.(*viewContext); ok && vc.node != nil {
+		vc.node.callbacks = append(vc.node.callbacks, c)
+	}
+	return c
+}
+
 type updateFlag int
 
 const (
@@ -180,6 +217,11 @@ type nodeRoot struct {
 
 	flagMu      sync.Mutex
 	updateFlags map[Id]updateFlag
+
+	// layoutPass counts layouts, and layoutDirty holds the nodes that need
+	// layout in the current one, with their ancestors.
+	layoutPass  int64
+	layoutDirty map[Id]bool
 }
 
 func newRoot(v View) *nodeRoot {
@@ -268,6 +310,17 @@ func (root *nodeRoot) build() {
 }
 
 func (root *nodeRoot) layout(minSize layout.Point, maxSize layout.Point) {
+	root.layoutPass += 1
+	root.layoutDirty = map[Id]bool{}
+	for id, f := range root.updateFlags {
+		if !f.needsLayout() {
+			continue
+		}
+		for n := root.nodes[id]; n != nil && !root.layoutDirty[n.id]; n = n.parent {
+			root.layoutDirty[n.id] = true
+		}
+	}
+
 	g := root.node.layout(minSize, maxSize)
 	g.Frame = g.Frame.Add(layout.Pt(-g.Frame.Min.X, -g.Frame.Min.Y)) // Move Frame.Min to the origin.
 	root.node.layoutGuide = &g
@@ -291,15 +344,24 @@ func (root *nodeRoot) call(funcId string, viewId int64, args []reflect.Value) []
 	}
 	v := reflect.ValueOf(f)
 
+	// Some bridges can't represent every Go type, such as JavaScript passing all
+	// numbers as float64. Convert arguments to the parameter types where possible.
+	t := v.Type()
+	for i, arg := range args {
+		if i < t.NumIn() && arg.IsValid() && arg.Type() != t.In(i) && arg.Type().ConvertibleTo(t.In(i)) {
+			args[i] = arg.Convert(t.In(i))
+		}
+	}
 	return v.Call(args)
 }
 
 type node struct {
-	id    Id
-	path  []Id
-	root  *nodeRoot
-	view  View
-	stage Stage
+	id     Id
+	path   []Id
+	root   *nodeRoot
+	parent *node
+	view   View
+	stage  Stage
 
 	buildId       int64
 	buildPbId     int64
@@ -314,11 +376,15 @@ type node struct {
 	layoutGuide    *layout.Guide
 	layoutMinSize  layout.Point
 	layoutMaxSize  layout.Point
+	layoutPass     int64
+	layoutResult   *layout.Guide // The guide returned by the layouter.
 
 	paintId       int64
 	paintNotify   bool
 	paintNotifyId comm.Id
 	paintOptions  paint.Style
+
+	callbacks []*bridge.Callback // Closed by done.
 }
 
 func (n *node) marshalLayoutPaintProtobuf(m map[int64]*pb.LayoutPaintNode) {
@@ -478,10 +544,11 @@ func (n *node) build() {
 				copy(path, n.path)
 				path[len(n.path)] = id
 				children = append(children, &node{
-					id:   id,
-					path: path,
-					view: newView,
-					root: n.root,
+					id:     id,
+					path:   path,
+					view:   newView,
+					root:   n.root,
+					parent: n,
 				})
 
 				// Mark as needing rebuild
@@ -543,12 +610,15 @@ func (n *node) build() {
 func (n *node) layout(minSize layout.Point, maxSize layout.Point) layout.Guide {
 	n.layoutId += 1
 
-	// If node has no children, has the same min/max size, and does not need relayout, return the previous guide.
-	if len(n.children) == 0 && n.layoutGuide != nil && n.layoutMinSize == minSize && n.layoutMaxSize == maxSize && !n.root.updateFlags[n.id].needsLayout() {
-		return *n.layoutGuide
+	// If node has the same min/max size as its last layout, and neither it nor
+	// its descendants needed relayout since, return the previous guide. The
+	// guides of its children are also unchanged.
+	if n.layoutResult != nil && n.layoutMinSize == minSize && n.layoutMaxSize == maxSize && (n.layoutPass == n.root.layoutPass || !n.root.layoutDirty[n.id]) {
+		return *n.layoutResult
 	}
 	n.layoutMinSize = minSize
 	n.layoutMaxSize = maxSize
+	n.layoutPass = n.root.layoutPass
 
 	// Create the LayoutContext
 	ctx := &layoutContext{
@@ -563,6 +633,16 @@ func (n *node) layout(minSize layout.Point, maxSize layout.Point) layout.Guide {
 			child := n.children[idx]
 			return child.layout(minSize, maxSize)
 		},
+		sizeFunc: func(idx int, max layout.Point) (layout.Point, bool) {
+			if idx >= len(n.children) {
+				return layout.Point{}, false
+			}
+			sizer, ok := n.children[idx].view.(IntrinsicSizer)
+			if !ok {
+				return layout.Point{}, false
+			}
+			return sizer.IntrinsicSize(max), true
+		},
 	}
 
 	// Perform layout
@@ -572,6 +652,7 @@ func (n *node) layout(minSize layout.Point, maxSize layout.Point) layout.Guide {
 	}
 	g, gs := layouter.Layout(ctx)
 	g = ctx.fitGuide(g)
+	n.layoutResult = &g
 
 	//
 	for idx, i := range n.children {
@@ -590,6 +671,9 @@ func (n *node) paint() {
 		} else {
 			n.paintOptions = paint.Style{}
 		}
+		if DebuggingLayout() {
+			n.paintOptions = debugPaintStyle(n.paintOptions, len(n.path)-1)
+		}
 	}
 
 	// Recursively update children
@@ -611,6 +695,10 @@ func (n *node) done() {
 	if n.paintNotify {
 		n.model.Painter.Unnotify(n.paintNotifyId)
 	}
+	for _, i := range n.callbacks {
+		i.Close()
+	}
+	n.callbacks = nil
 
 	for _, i := range n.children {
 		i.done()
@@ -639,6 +727,7 @@ type layoutContext struct {
 	maxnSize   layout.Point
 	childCount int
 	layoutFunc func(int, layout.Point, layout.Point) layout.Guide // TODO(KD): this should be private...
+	sizeFunc   func(int, layout.Point) (layout.Point, bool)
 }
 
 func (l *layoutContext) MinSize() layout.Point {
@@ -659,6 +748,11 @@ func (l *layoutContext) LayoutChild(idx int, minSize, maxSize layout.Point) layo
 	return g
 }
 
+// ChildIntrinsicSize implements the layout.IntrinsicContext interface.
+func (l *layoutContext) ChildIntrinsicSize(idx int, max layout.Point) (layout.Point, bool) {
+	return l.sizeFunc(idx, max)
+}
+
 func (l *layoutContext) fitGuide(g layout.Guide) layout.Guide {
 	if g.Width() < l.MinSize().X {
 		g.Frame.Max.X = l.MinSize().X - g.Frame.Min.X
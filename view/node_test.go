@@ -0,0 +1,118 @@
+package view
+
+import (
+	"testing"
+
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/layout"
+)
+
+// columnLayouter places children one below another, and counts its layouts.
+type columnLayouter struct {
+	count *int
+}
+
+func (l *columnLayouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	*l.count += 1
+	gs := []layout.Guide{}
+	y := 0.0
+	for i := 0; i < ctx.ChildCount(); i++ {
+		g := ctx.LayoutChild(i, layout.Pt(ctx.MinSize().X, 0), layout.Pt(ctx.MinSize().X, 10))
+		g.Frame = g.Frame.Add(layout.Pt(0, y))
+		y += g.Height()
+		gs = append(gs, g)
+	}
+	return layout.Guide{Frame: layout.Rt(0, 0, ctx.MinSize().X, y)}, gs
+}
+
+func (l *columnLayouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+func (l *columnLayouter) Unnotify(id comm.Id) {
+	// no-op
+}
+
+type columnTestView struct {
+	Embed
+	depth, width int
+	count        *int
+}
+
+func (v *columnTestView) Build(ctx Context) Model {
+	children := []View{}
+	if v.depth > 0 {
+		for i := 0; i < v.width; i++ {
+			children = append(children, &columnTestView{depth: v.depth - 1, width: v.width, count: v.count})
+		}
+	}
+	return Model{
+		Children: children,
+		Layouter: &columnLayouter{count: v.count},
+	}
+}
+
+// leaf returns the first node without children under n.
+func leaf(n *node) *node {
+	for len(n.children) > 0 {
+		n = n.children[0]
+	}
+	return n
+}
+
+func TestLayoutCache(t *testing.T) {
+	count := 0
+	root := newRoot(&columnTestView{depth: 3, width: 3, count: &count})
+	root.update(layout.Pt(100, 100))
+	if count != 40 {
+		t.Fatalf("first layout laid out %v views, want 40", count)
+	}
+
+	count = 0
+	root.addFlag(leaf(root.node).id, layoutFlag)
+	root.update(layout.Pt(100, 100))
+	if count != 4 {
+		t.Errorf("relayout of a leaf laid out %v views, want 4", count)
+	}
+
+	// Children are laid out with the same sizes, and are not laid out again.
+	count = 0
+	root.addFlag(root.node.id, layoutFlag)
+	root.update(layout.Pt(100, 200))
+	if count != 1 {
+		t.Errorf("resized layout laid out %v views, want 1", count)
+	}
+	if g := leaf(root.node).layoutGuide; g == nil || g.Height() != 0 {
+		t.Errorf("leaf guide = %v", g)
+	}
+}
+
+func benchmarkLayout(b *testing.B, dirty func(root *nodeRoot) Id) {
+	count := 0
+	root := newRoot(&columnTestView{depth: 4, width: 6, count: &count})
+	root.update(layout.Pt(100, 100))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		root.addFlag(dirty(root), layoutFlag)
+		root.update(layout.Pt(100, 100))
+	}
+}
+
+// BenchmarkLayoutLeaf relayouts a hierarchy of 1555 views after a change to
+// one of its leaves.
+func BenchmarkLayoutLeaf(b *testing.B) {
+	benchmarkLayout(b, func(root *nodeRoot) Id {
+		return leaf(root.node).id
+	})
+}
+
+// BenchmarkLayoutAll relayouts every view in a hierarchy of 1555 views.
+func BenchmarkLayoutAll(b *testing.B) {
+	benchmarkLayout(b, func(root *nodeRoot) Id {
+		for _, n := range root.nodes {
+			root.updateFlags[n.id] |= layoutFlag
+		}
+		return root.node.id
+	})
+}
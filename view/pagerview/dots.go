@@ -0,0 +1,140 @@
+package pagerview
+
+import (
+	"image/color"
+	"math"
+
+	"golang.org/x/image/colornames"
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/pointer"
+	"gomatcha.io/matcha/view"
+	"gomatcha.io/matcha/view/canvas"
+)
+
+const (
+	dotSize    = 7
+	dotSpacing = 16 // Distance between the centers of the dots.
+)
+
+// Dots is a page indicator, with a dot for each page. Tapping a dot sets
+// Current.
+type Dots struct {
+	view.Embed
+	Count int
+	// Current is the index of the highlighted dot. It is usually shared with a
+	// pager view.
+	Current *comm.IntValue
+	// Color is the color of the dots. It is light gray by default.
+	Color color.Color
+	// CurrentColor is the color of the current dot. It is dark gray by default.
+	CurrentColor color.Color
+
+	prevCurrent *comm.IntValue
+}
+
+// NewDots returns a new view.
+func NewDots() *Dots {
+	return &Dots{
+		Current:      &comm.IntValue{},
+		Color:        colornames.Lightgray,
+		CurrentColor: colornames.Dimgray,
+	}
+}
+
+// Lifecycle implements the view.View interface.
+func (v *Dots) Lifecycle(from, to view.Stage) {
+	if view.ExitsStage(from, to, view.StageMounted) {
+		v.Unsubscribe(v.prevCurrent)
+	}
+}
+
+// Update implements the view.View interface.
+func (v *Dots) Update(v2 view.View) {
+	view.CopyFields(v, v2)
+	if v.Current == nil {
+		v.Current = &comm.IntValue{}
+	}
+}
+
+// Build implements the view.View interface.
+func (v *Dots) Build(ctx view.Context) view.Model {
+	if v.Current != v.prevCurrent {
+		if v.prevCurrent != nil {
+			v.Unsubscribe(v.prevCurrent)
+		}
+		v.Subscribe(v.Current)
+		v.prevCurrent = v.Current
+	}
+
+	count, current := v.Count, v.Current.Value()
+	c := canvas.New()
+	c.Draw = func(c *canvas.Context) {
+		size := c.Size()
+		left := dotsLeft(size, count)
+		for i := 0; i < count; i++ {
+			center := layout.Pt(left+float64(i)*dotSpacing, size.Y/2)
+			c.BeginPath()
+			c.Ellipse(layout.Rt(center.X-dotSize/2, center.Y-dotSize/2, center.X+dotSize/2, center.Y+dotSize/2))
+			if i == current {
+				c.SetFillColor(v.CurrentColor)
+			} else {
+				c.SetFillColor(v.Color)
+			}
+			c.Fill()
+		}
+	}
+
+	l := &dotsLayouter{count: count}
+	return view.Model{
+		Children: []view.View{c},
+		Layouter: l,
+		Options: []view.Option{
+			pointer.GestureList{&pointer.TapGesture{
+				Count: 1,
+				OnEvent: func(e *pointer.TapEvent) {
+					if e.Kind != pointer.EventKindRecognized || count == 0 {
+						return
+					}
+					i := int(math.Floor((e.Position.X-dotsLeft(l.size, count))/dotSpacing + 0.5))
+					if i >= 0 && i < count {
+						v.Current.SetValue(i)
+					}
+				},
+			}},
+		},
+	}
+}
+
+// dotsLeft returns the center of the first dot, so that the dots are centered
+// in a view of the given size.
+func dotsLeft(size layout.Point, count int) float64 {
+	return (size.X - float64(count-1)*dotSpacing) / 2
+}
+
+// dotsLayouter is wide enough for the dots unless it is given more space, and
+// gives the canvas its size.
+type dotsLayouter struct {
+	count int
+	size  layout.Point
+}
+
+func (l *dotsLayouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	min := ctx.MinSize()
+	l.size = layout.Pt(math.Max(min.X, float64(l.count)*dotSpacing), math.Max(min.Y, 20))
+	gs := make([]layout.Guide, ctx.ChildCount())
+	for i := range gs {
+		g := ctx.LayoutChild(i, l.size, l.size)
+		g.Frame = layout.Rt(0, 0, l.size.X, l.size.Y)
+		gs[i] = g
+	}
+	return layout.Guide{Frame: layout.Rt(0, 0, l.size.X, l.size.Y)}, gs
+}
+
+func (l *dotsLayouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+func (l *dotsLayouter) Unnotify(id comm.Id) {
+	// no-op
+}
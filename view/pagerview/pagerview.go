@@ -0,0 +1,163 @@
+// Package pagerview implements a horizontally paged carousel. It is displayed
+// with a UIPageViewController on iOS and a ViewPager on Android. Pages are
+// built lazily, so only the current page and its neighbours exist at a time.
+//
+//  v := pagerview.New()
+//  v.Count = len(photos)
+//  v.Page = func(index int) view.View {
+//      return NewPhotoView(photos[index])
+//  }
+//  v.Current = &app.currentPhoto // *comm.IntValue
+//
+//  dots := pagerview.NewDots()
+//  dots.Count = len(photos)
+//  dots.Current = &app.currentPhoto
+package pagerview
+
+import (
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+)
+
+// View displays Count pages, one at a time.
+type View struct {
+	view.Embed
+	Count int
+	// Page returns the view of the page at index. It is only called for the
+	// pages within Preload of the current page.
+	Page func(index int) view.View
+	// Current is the index of the displayed page. It is set when the user
+	// swipes to another page, and setting it scrolls to the page.
+	Current *comm.IntValue
+	// Preload is the number of pages on each side of the current page that
+	// are built ahead of time. It is 1 by default.
+	Preload int
+	// OnChange is called with the index of the page the user swipes to, after
+	// Current is set.
+	OnChange   func(int)
+	PaintStyle *paint.Style
+
+	prevCurrent *comm.IntValue
+}
+
+// New returns a new view.
+func New() *View {
+	return &View{
+		Current: &comm.IntValue{},
+		Preload: 1,
+	}
+}
+
+// Lifecycle implements the view.View interface.
+func (v *View) Lifecycle(from, to view.Stage) {
+	if view.ExitsStage(from, to, view.StageMounted) {
+		v.Unsubscribe(v.prevCurrent)
+	}
+}
+
+// Update implements the view.View interface.
+func (v *View) Update(v2 view.View) {
+	view.CopyFields(v, v2)
+	if v.Current == nil {
+		v.Current = &comm.IntValue{}
+	}
+}
+
+// Build implements the view.View interface.
+func (v *View) Build(ctx view.Context) view.Model {
+	if v.Current != v.prevCurrent {
+		if v.prevCurrent != nil {
+			v.Unsubscribe(v.prevCurrent)
+		}
+		v.Subscribe(v.Current)
+		v.prevCurrent = v.Current
+	}
+
+	current := v.Current.Value()
+	if current >= v.Count {
+		current = v.Count - 1
+	}
+	if current < 0 {
+		current = 0
+	}
+
+	// Each page is wrapped in a view keyed by its index, so it keeps its
+	// state as the pages around it are added and removed.
+	children := []view.View{}
+	indexes := []int64{}
+	if v.Page != nil {
+		for i := current - v.Preload; i <= current+v.Preload; i++ {
+			if i < 0 || i >= v.Count {
+				continue
+			}
+			children = append(children, &page{Embed: view.NewEmbed(i), Child: v.Page(i)})
+			indexes = append(indexes, int64(i))
+		}
+	}
+
+	var painter paint.Painter
+	if v.PaintStyle != nil {
+		painter = v.PaintStyle
+	}
+	return view.Model{
+		Children:       children,
+		Layouter:       &layouter{},
+		Painter:        painter,
+		NativeViewName: "gomatcha.io/matcha/view/pagerview",
+		NativeFuncs: map[string]interface{}{
+			// State returns the number of pages, the current page, and the
+			// index of the page of each child.
+			"State": func() (int64, int64, []int64) {
+				return int64(v.Count), int64(current), indexes
+			},
+			"OnChange": func(index int64) {
+				v.Current.SetValue(int(index))
+				if v.OnChange != nil {
+					v.OnChange(int(index))
+				}
+			},
+		},
+	}
+}
+
+// page holds the view of a page.
+type page struct {
+	view.Embed
+	Child view.View
+}
+
+func (v *page) Build(ctx view.Context) view.Model {
+	if v.Child == nil {
+		return view.Model{}
+	}
+	return view.Model{
+		Children: []view.View{v.Child},
+		Layouter: &layouter{},
+	}
+}
+
+// layouter gives the children the view's size. The native views position the
+// pages.
+type layouter struct {
+}
+
+func (l *layouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	size := ctx.MinSize()
+	gs := make([]layout.Guide, ctx.ChildCount())
+	for i := range gs {
+		g := ctx.LayoutChild(i, size, size)
+		g.Frame = layout.Rt(0, 0, size.X, size.Y)
+		gs[i] = g
+	}
+	return layout.Guide{Frame: layout.Rt(0, 0, size.X, size.Y)}, gs
+}
+
+func (l *layouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+func (l *layouter) Unnotify(id comm.Id) {
+	// no-op
+}
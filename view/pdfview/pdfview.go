@@ -0,0 +1,237 @@
+// Package pdfview displays PDF documents, with PDFKit on iOS 11 and later and
+// PdfRenderer on Android 5.0 and later. Documents can be pinched to zoom, and
+// pages are scrolled vertically on iOS and swiped horizontally on Android.
+//
+//  v := pdfview.New()
+//  v.URL = "https://example.com/manual.pdf"
+//  v.Page = &app.page // *comm.IntValue
+//  v.OnLoad = func(pageCount int) {
+//      app.pageCount = pageCount
+//  }
+//
+// Setting Search finds the text in the document and calls OnSearch with the
+// matches. Text search is only available on iOS.
+package pdfview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"gomatcha.io/matcha"
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+)
+
+// Match is a search result.
+type Match struct {
+	// Page is the index of the page that contains the match.
+	Page int
+	// Text is the line of text that contains the match.
+	Text string
+}
+
+// View displays the document at Path, Data or URL, whichever is set first.
+type View struct {
+	view.Embed
+	Path string
+	Data []byte
+	URL  string
+	// Page is the index of the displayed page. It is set as the user changes
+	// pages, and setting it displays the page.
+	Page *comm.IntValue
+	// OnLoad is called with the number of pages once the document is loaded.
+	OnLoad func(pageCount int)
+	// OnError is called if the document can't be loaded or read.
+	OnError func(err error)
+	// Search is the text to find in the document. The matches are highlighted,
+	// and the first one is displayed.
+	Search string
+	// OnSearch is called with the matches once a search completes. On Android
+	// it is always called without any matches.
+	OnSearch   func(matches []Match)
+	PaintStyle *paint.Style
+
+	cancelFunc       context.CancelFunc
+	data             []byte
+	generation       int64 // Incremented when data changes.
+	prevSearch       string
+	searchGeneration int64 // Incremented when Search changes.
+	prevPage         *comm.IntValue
+}
+
+// New returns a new view.
+func New() *View {
+	return &View{
+		Page: &comm.IntValue{},
+	}
+}
+
+// Lifecycle implements the view.View interface.
+func (v *View) Lifecycle(from, to view.Stage) {
+	if view.EntersStage(from, to, view.StageMounted) {
+		v.begin()
+	} else if view.ExitsStage(from, to, view.StageMounted) {
+		v.end()
+		v.Unsubscribe(v.prevPage)
+	}
+}
+
+// Update implements the view.View interface.
+func (v *View) Update(v2 view.View) {
+	next := v2.(*View)
+	if next.Path != v.Path || next.URL != v.URL || !sameData(next.Data, v.Data) {
+		v.end()
+		view.CopyFields(v, v2)
+		v.begin()
+	} else {
+		view.CopyFields(v, v2)
+	}
+	if v.Page == nil {
+		v.Page = &comm.IntValue{}
+	}
+}
+
+// Build implements the view.View interface.
+func (v *View) Build(ctx view.Context) view.Model {
+	if v.Page != v.prevPage {
+		if v.prevPage != nil {
+			v.Unsubscribe(v.prevPage)
+		}
+		v.Subscribe(v.Page)
+		v.prevPage = v.Page
+	}
+	if v.Search != v.prevSearch {
+		v.prevSearch = v.Search
+		v.searchGeneration++
+	}
+
+	var painter paint.Painter
+	if v.PaintStyle != nil {
+		painter = v.PaintStyle
+	}
+	return view.Model{
+		Painter:        painter,
+		NativeViewName: "gomatcha.io/matcha/view/pdfview",
+		NativeFuncs: map[string]interface{}{
+			// State returns the document and search generations, the page and
+			// the search text. The document is read with Document when its
+			// generation changes, and the search is run when its generation
+			// changes.
+			"State": func() (int64, int64, int64, string) {
+				return v.generation, int64(v.Page.Value()), v.searchGeneration, v.Search
+			},
+			"Document": func() []byte {
+				return v.data
+			},
+			"OnLoad": func(pageCount int64) {
+				if v.OnLoad != nil {
+					v.OnLoad(int(pageCount))
+				}
+			},
+			"OnError": func(msg string) {
+				v.fail(errors.New(msg))
+			},
+			"OnPage": func(page int64) {
+				v.Page.SetValue(int(page))
+			},
+			"OnSearch": func(generation int64, pages []int64, texts []string) {
+				if generation != v.searchGeneration || v.OnSearch == nil {
+					return
+				}
+				matches := make([]Match, len(pages))
+				for i := range matches {
+					matches[i] = Match{Page: int(pages[i]), Text: texts[i]}
+				}
+				v.OnSearch(matches)
+			},
+		},
+	}
+}
+
+func (v *View) begin() {
+	var load func() ([]byte, error)
+	if v.Path != "" {
+		path := v.Path
+		load = func() ([]byte, error) {
+			return ioutil.ReadFile(path)
+		}
+	} else if v.Data != nil {
+		v.data = v.Data
+		v.generation++
+		return
+	} else if v.URL != "" {
+		url := v.URL
+		load = func() ([]byte, error) {
+			return loadURL(url)
+		}
+	} else {
+		return
+	}
+
+	c, cancelFunc := context.WithCancel(context.Background())
+	v.cancelFunc = cancelFunc
+	go func() {
+		data, err := load()
+
+		matcha.MainLocker.Lock()
+		defer matcha.MainLocker.Unlock()
+
+		select {
+		case <-c.Done():
+		default:
+			v.cancelFunc()
+			v.cancelFunc = nil
+			if err != nil {
+				v.fail(err)
+				return
+			}
+			v.data = data
+			v.generation++
+			v.Signal()
+		}
+	}()
+}
+
+func (v *View) end() {
+	if v.cancelFunc != nil {
+		v.cancelFunc()
+		v.cancelFunc = nil
+	}
+	if v.data != nil {
+		v.data = nil
+		v.generation++
+	}
+}
+
+func (v *View) fail(err error) {
+	if v.OnError != nil {
+		v.OnError(err)
+	} else {
+		fmt.Println("pdfview error", err)
+	}
+}
+
+func loadURL(url string) ([]byte, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("pdfview: %v returned %v", url, resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+// sameData returns true if a and b are the same slice.
+func sameData(a, b []byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	return len(a) == 0 || &a[0] == &b[0]
+}
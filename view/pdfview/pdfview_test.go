@@ -0,0 +1,22 @@
+package pdfview
+
+import "testing"
+
+func TestSameData(t *testing.T) {
+	a := []byte("%PDF-1.4")
+	b := []byte("%PDF-1.4")
+	for _, tc := range []struct {
+		a, b []byte
+		same bool
+	}{
+		{nil, nil, true},
+		{nil, []byte{}, true},
+		{a, a, true},
+		{a, a[:4], false},
+		{a, b, false},
+	} {
+		if same := sameData(tc.a, tc.b); same != tc.same {
+			t.Errorf("sameData(%q, %q) = %v, want %v", tc.a, tc.b, same, tc.same)
+		}
+	}
+}
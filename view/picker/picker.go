@@ -0,0 +1,155 @@
+// Package picker implements a view for choosing among a list of options. It
+// displays a UIPickerView on iOS, and a Spinner for each component on Android.
+//
+//  size := &picker.Component{
+//      Options:  []string{"Small", "Medium", "Large"},
+//      Selected: &app.Size, // *comm.IntValue
+//  }
+//  v := picker.New()
+//  v.Components = []*picker.Component{size}
+package picker
+
+import (
+	"runtime"
+
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+)
+
+// Component is a column of options. On iOS the components are displayed as
+// wheels side by side, and on Android as dropdowns.
+type Component struct {
+	Options []string
+	// Selected is the index of the selected option. It is set when the user
+	// selects an option, and setting it updates the picker.
+	Selected *comm.IntValue
+}
+
+// View is a picker view.
+type View struct {
+	view.Embed
+	Components []*Component
+	// OnChange is called with the component and index of the selected option,
+	// after the component's Selected value is set.
+	OnChange   func(component, index int)
+	Enabled    bool
+	PaintStyle *paint.Style
+
+	notifiers []comm.Notifier // Selected of the components.
+}
+
+// New returns a new view.
+func New() *View {
+	return &View{
+		Enabled: true,
+	}
+}
+
+// Lifecycle implements the view.View interface.
+func (v *View) Lifecycle(from, to view.Stage) {
+	if view.ExitsStage(from, to, view.StageMounted) {
+		for _, i := range v.notifiers {
+			v.Unsubscribe(i)
+		}
+		v.notifiers = nil
+	}
+}
+
+// Build implements the view.View interface.
+func (v *View) Build(ctx view.Context) view.Model {
+	notifiers := []comm.Notifier{}
+	counts := []int64{}
+	options := []string{}
+	selected := []int64{}
+	for _, i := range v.Components {
+		idx := 0
+		if i.Selected != nil {
+			notifiers = append(notifiers, i.Selected)
+			idx = i.Selected.Value()
+		}
+		if idx < 0 || idx >= len(i.Options) {
+			idx = 0
+		}
+		counts = append(counts, int64(len(i.Options)))
+		options = append(options, i.Options...)
+		selected = append(selected, int64(idx))
+	}
+	v.subscribe(notifiers)
+
+	var painter paint.Painter
+	if v.PaintStyle != nil {
+		painter = v.PaintStyle
+	}
+	return view.Model{
+		Painter:        painter,
+		Layouter:       &layouter{},
+		NativeViewName: "gomatcha.io/matcha/view/picker",
+		NativeFuncs: map[string]interface{}{
+			// State returns the number of options in each component, the
+			// options of all components, the selected index in each component,
+			// and whether the picker is enabled.
+			"State": func() ([]int64, []string, []int64, bool) {
+				return counts, options, selected, v.Enabled
+			},
+			"OnSelect": func(component, index int64) {
+				if component < 0 || int(component) >= len(v.Components) {
+					return
+				}
+				c := v.Components[component]
+				if index < 0 || int(index) >= len(c.Options) {
+					return
+				}
+				if c.Selected != nil {
+					c.Selected.SetValue(int(index))
+				}
+				if v.OnChange != nil {
+					v.OnChange(int(component), int(index))
+				}
+			},
+		},
+	}
+}
+
+// subscribe updates the view's subscriptions to the Selected values of its
+// components.
+func (v *View) subscribe(notifiers []comm.Notifier) {
+	for _, i := range v.notifiers {
+		found := false
+		for _, j := range notifiers {
+			if i == j {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.Unsubscribe(i)
+		}
+	}
+	for _, i := range notifiers {
+		v.Subscribe(i)
+	}
+	v.notifiers = notifiers
+}
+
+// layouter gives the picker the height of UIPickerView on iOS, and of a
+// Spinner on Android.
+type layouter struct {
+}
+
+func (l *layouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	height := 216.0
+	if runtime.GOOS == "android" {
+		height = 48
+	}
+	return layout.Guide{Frame: layout.Rt(0, 0, ctx.MinSize().X, height)}, nil
+}
+
+func (l *layouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+func (l *layouter) Unnotify(id comm.Id) {
+	// no-op
+}
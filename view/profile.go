@@ -0,0 +1,115 @@
+package view
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"gomatcha.io/matcha/config"
+)
+
+const frameDuration = time.Second / 60
+
+// ScreenStats describes the rendering cost attributed to a screen.
+type ScreenStats struct {
+	Screen        string
+	Updates       int
+	BridgeBytes   int64         // Size of the updates sent across the bridge.
+	BuildTime     time.Duration // Time spent building, laying out and painting.
+	ApplyTime     time.Duration // Time spent applying updates natively.
+	DroppedFrames int           // Frames missed because an update took longer than a frame.
+}
+
+var profile = struct {
+	mu     sync.Mutex
+	screen string
+	stats  map[string]*ScreenStats
+}{
+	screen: "default",
+	stats:  map[string]*ScreenStats{},
+}
+
+// SetScreen sets the name of the currently visible screen. Rendering costs are
+// attributed to this screen until SetScreen is called again. StackViews call
+// SetScreen with the type of their top view.
+func SetScreen(name string) {
+	profile.mu.Lock()
+	defer profile.mu.Unlock()
+
+	profile.screen = name
+}
+
+// CurrentScreen returns the name of the currently visible screen.
+func CurrentScreen() string {
+	profile.mu.Lock()
+	defer profile.mu.Unlock()
+
+	return profile.screen
+}
+
+// profileSwitch disables collecting ScreenStats.
+var profileSwitch = config.NewSwitch("gomatcha.io/matcha/view profile", true)
+
+func profileUpdate(bytes int, build, apply time.Duration) {
+	if !profileSwitch.Value() {
+		return
+	}
+
+	profile.mu.Lock()
+	defer profile.mu.Unlock()
+
+	s, ok := profile.stats[profile.screen]
+	if !ok {
+		s = &ScreenStats{Screen: profile.screen}
+		profile.stats[profile.screen] = s
+	}
+	s.Updates += 1
+	s.BridgeBytes += int64(bytes)
+	s.BuildTime += build
+	s.ApplyTime += apply
+	s.DroppedFrames += int((build + apply) / frameDuration)
+}
+
+// ScreenReport returns the stats for every screen, sorted by name.
+func ScreenReport() []ScreenStats {
+	profile.mu.Lock()
+	defer profile.mu.Unlock()
+
+	report := []ScreenStats{}
+	for _, i := range profile.stats {
+		report = append(report, *i)
+	}
+	sort.Slice(report, func(i, j int) bool {
+		return report[i].Screen < report[j].Screen
+	})
+	return report
+}
+
+// ResetScreenReport clears all collected stats.
+func ResetScreenReport() {
+	profile.mu.Lock()
+	defer profile.mu.Unlock()
+
+	profile.stats = map[string]*ScreenStats{}
+}
+
+// WriteScreenReport writes the ScreenReport to w as CSV.
+func WriteScreenReport(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"screen", "updates", "bridge_bytes", "build_ms", "apply_ms", "dropped_frames"})
+	for _, i := range ScreenReport() {
+		cw.Write([]string{
+			i.Screen,
+			strconv.Itoa(i.Updates),
+			strconv.FormatInt(i.BridgeBytes, 10),
+			strconv.FormatFloat(i.BuildTime.Seconds()*1000, 'f', 3, 64),
+			strconv.FormatFloat(i.ApplyTime.Seconds()*1000, 'f', 3, 64),
+			strconv.Itoa(i.DroppedFrames),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
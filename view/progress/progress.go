@@ -0,0 +1,134 @@
+// Package progress implements linear and circular progress indicators. They
+// display the progress of a task between 0 and 1, or an animation if the
+// progress is unknown. They are drawn natively on iOS and Android.
+//
+//  v := progress.New()
+//  v.Style = progress.StyleCircular
+//  v.Value = download.Progress // comm.Float64Notifier
+//  v.Animated = true
+//
+// Unlike ios.ProgressView, progress views are supported on Android.
+package progress
+
+import (
+	"image/color"
+	"math"
+
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+)
+
+// Style is the shape of the indicator.
+type Style int
+
+const (
+	// StyleLinear is a horizontal bar that fills the view's width.
+	StyleLinear Style = iota
+	// StyleCircular is a ring, 36 points wide unless the view is given a
+	// larger size.
+	StyleCircular
+)
+
+// View displays progress.
+type View struct {
+	view.Embed
+	Style Style
+	// Value is the progress between 0 and 1. The view is updated when it
+	// changes.
+	Value comm.Float64Notifier
+	// Indeterminate displays a looping animation instead of Value, for tasks
+	// whose progress is unknown.
+	Indeterminate bool
+	// Animated animates the indicator between values.
+	Animated bool
+	// Color is the color of the progress. If nil, the platform's tint color is
+	// used.
+	Color color.Color
+	// TrackColor is the color of the remaining part of the indicator. If nil, a
+	// translucent Color is used.
+	TrackColor color.Color
+	PaintStyle *paint.Style
+
+	prevValue comm.Float64Notifier
+}
+
+// New returns a new view.
+func New() *View {
+	return &View{}
+}
+
+// Lifecycle implements the view.View interface.
+func (v *View) Lifecycle(from, to view.Stage) {
+	if view.ExitsStage(from, to, view.StageMounted) {
+		if v.prevValue != nil {
+			v.Unsubscribe(v.prevValue)
+		}
+	}
+}
+
+// Build implements the view.View interface.
+func (v *View) Build(ctx view.Context) view.Model {
+	if v.Value != v.prevValue {
+		if v.prevValue != nil {
+			v.Unsubscribe(v.prevValue)
+		}
+		if v.Value != nil {
+			v.Subscribe(v.Value)
+		}
+		v.prevValue = v.Value
+	}
+
+	value := 0.0
+	if v.Value != nil {
+		value = math.Min(math.Max(v.Value.Value(), 0), 1)
+	}
+
+	var painter paint.Painter
+	if v.PaintStyle != nil {
+		painter = v.PaintStyle
+	}
+	return view.Model{
+		Painter:        painter,
+		Layouter:       &layouter{style: v.Style},
+		NativeViewName: "gomatcha.io/matcha/view/progress",
+		NativeFuncs: map[string]interface{}{
+			// State returns the style, the progress, whether it is
+			// indeterminate or animated, and the colors as ARGB, or -1 for the
+			// default colors.
+			"State": func() (int64, float64, bool, bool, int64, int64) {
+				return int64(v.Style), value, v.Indeterminate, v.Animated, argb(v.Color), argb(v.TrackColor)
+			},
+		},
+	}
+}
+
+func argb(c color.Color) int64 {
+	if c == nil {
+		return -1
+	}
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return int64(n.A)<<24 | int64(n.R)<<16 | int64(n.G)<<8 | int64(n.B)
+}
+
+type layouter struct {
+	style Style
+}
+
+func (l *layouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	min := ctx.MinSize()
+	if l.style == StyleCircular {
+		d := math.Max(math.Min(min.X, min.Y), 36)
+		return layout.Guide{Frame: layout.Rt(0, 0, math.Max(d, min.X), math.Max(d, min.Y))}, nil
+	}
+	return layout.Guide{Frame: layout.Rt(0, 0, min.X, math.Max(4, min.Y))}, nil
+}
+
+func (l *layouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+func (l *layouter) Unnotify(id comm.Id) {
+	// no-op
+}
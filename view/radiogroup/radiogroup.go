@@ -0,0 +1,142 @@
+// Package radiogroup implements a list of radio buttons, of which at most one
+// is selected. It displays a RadioGroup of Material RadioButtons on Android,
+// and drawn radio buttons on iOS.
+//
+//  v := radiogroup.New()
+//  v.Options = []string{"Walk", "Bike", "Drive"}
+//  v.Selected = &app.mode // *comm.IntValue
+package radiogroup
+
+import (
+	"image/color"
+	"math"
+	"runtime"
+
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+)
+
+// View is a group of radio buttons, stacked vertically.
+type View struct {
+	view.Embed
+	Options []string
+	// Selected is the index of the selected option, or -1 if none is
+	// selected. It is set when the user selects an option, and setting it
+	// updates the buttons.
+	Selected *comm.IntValue
+	// OnChange is called with the index of the option after Selected is set by
+	// the user.
+	OnChange func(index int)
+	Enabled  bool
+	// Color is the color of the selected button. If nil, the platform's tint
+	// color is used.
+	Color      color.Color
+	PaintStyle *paint.Style
+
+	prevSelected *comm.IntValue
+}
+
+// New returns a new view without a selected option.
+func New() *View {
+	v := &View{
+		Selected: &comm.IntValue{},
+		Enabled:  true,
+	}
+	v.Selected.SetValue(-1)
+	return v
+}
+
+// Lifecycle implements the view.View interface.
+func (v *View) Lifecycle(from, to view.Stage) {
+	if view.ExitsStage(from, to, view.StageMounted) {
+		v.Unsubscribe(v.prevSelected)
+	}
+}
+
+// Update implements the view.View interface.
+func (v *View) Update(v2 view.View) {
+	view.CopyFields(v, v2)
+	if v.Selected == nil {
+		v.Selected = &comm.IntValue{}
+		v.Selected.SetValue(-1)
+	}
+}
+
+// Build implements the view.View interface.
+func (v *View) Build(ctx view.Context) view.Model {
+	if v.Selected != v.prevSelected {
+		if v.prevSelected != nil {
+			v.Unsubscribe(v.prevSelected)
+		}
+		v.Subscribe(v.Selected)
+		v.prevSelected = v.Selected
+	}
+
+	selected := v.Selected.Value()
+	if selected < 0 || selected >= len(v.Options) {
+		selected = -1
+	}
+
+	var painter paint.Painter
+	if v.PaintStyle != nil {
+		painter = v.PaintStyle
+	}
+	return view.Model{
+		Painter:        painter,
+		Layouter:       &layouter{count: len(v.Options)},
+		NativeViewName: "gomatcha.io/matcha/view/radiogroup",
+		NativeFuncs: map[string]interface{}{
+			// State returns the options, the selected index, whether the group
+			// is enabled, and the color as ARGB, or -1 for the default color.
+			"State": func() ([]string, int64, bool, int64) {
+				return v.Options, int64(selected), v.Enabled, argb(v.Color)
+			},
+			"OnSelect": func(index int64) {
+				if index < 0 || int(index) >= len(v.Options) {
+					return
+				}
+				v.Selected.SetValue(int(index))
+				if v.OnChange != nil {
+					v.OnChange(int(index))
+				}
+			},
+		},
+	}
+}
+
+func argb(c color.Color) int64 {
+	if c == nil {
+		return -1
+	}
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return int64(n.A)<<24 | int64(n.R)<<16 | int64(n.G)<<8 | int64(n.B)
+}
+
+// rowHeight returns the height of each option, which is a touch target.
+func rowHeight() float64 {
+	if runtime.GOOS == "android" {
+		return 48
+	}
+	return 44
+}
+
+// layouter is tall enough for a row per option, and fills the width it is
+// given.
+type layouter struct {
+	count int
+}
+
+func (l *layouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	min := ctx.MinSize()
+	return layout.Guide{Frame: layout.Rt(0, 0, min.X, math.Max(min.Y, float64(l.count)*rowHeight()))}, nil
+}
+
+func (l *layouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+func (l *layouter) Unnotify(id comm.Id) {
+	// no-op
+}
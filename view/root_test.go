@@ -0,0 +1,38 @@
+// +build !matcha
+
+package view
+
+import (
+	"testing"
+
+	"gomatcha.io/matcha/bridge"
+	"gomatcha.io/matcha/internal"
+	"gomatcha.io/matcha/matchatest"
+)
+
+type bridgeTestView struct {
+	Embed
+}
+
+func (v *bridgeTestView) Build(ctx Context) Model {
+	bridge.Bridge("").Call("setTitle:", bridge.String("title"))
+	bridge.Bridge("").Call("setBadgeCount:", bridge.Int64(1))
+	return Model{}
+}
+
+func TestRootUpdateBatch(t *testing.T) {
+	b := matchatest.NewBridge()
+	defer b.Close()
+
+	_newRoot(&bridgeTestView{})
+	internal.ScreenUpdate()
+
+	// The calls made while building arrive together in the first flush.
+	batches := map[string]int{}
+	for _, i := range b.Calls() {
+		batches[i.Selector] = i.Batch
+	}
+	if batches["setTitle:"] != 1 || batches["setBadgeCount:"] != 1 {
+		t.Errorf("calls = %v, batches = %v", b.Calls(), batches)
+	}
+}
@@ -0,0 +1,286 @@
+// Package router maps URLs to the views of a stack view, so that screens can be
+// pushed by path, opened from deep links, and restored after the app is
+// relaunched. It works with both ios.Stack and android.Stack.
+//
+//  r := router.New(stackview.Stack)
+//  r.Handle("/", func(p router.Params) view.View {
+//      return NewRootView(app)
+//  })
+//  r.Handle("/users/:id", func(p router.Params) view.View {
+//      return NewUserView(app, p["id"])
+//  })
+//  ...
+//  // Displays the root view, with the user view on top of it.
+//  err := r.Open("myapp://users/42")
+//
+// Patterns are paths whose segments may be parameters, such as ":id", which
+// match a single segment, or a final wildcard, such as "*rest", which matches
+// one or more remaining segments. Routes are tried in the order they are
+// added.
+package router
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"gomatcha.io/matcha/view"
+)
+
+// Stack is the list of views displayed by a stack view. It is implemented by
+// ios.Stack and android.Stack.
+type Stack interface {
+	SetViews(...view.View)
+	Views() []view.View
+	Push(view.View)
+	Pop()
+}
+
+// Params are the parameters of a matched URL. They contain the values of the
+// pattern's parameters, and the URL's query values.
+type Params map[string]string
+
+type route struct {
+	pattern  string
+	segments []string
+	f        func(Params) view.View
+}
+
+// Router pushes views onto a stack by URL. Views that are added to the stack
+// directly, without the router, are left out of Paths and State.
+type Router struct {
+	stack  Stack
+	routes []*route
+	paths  map[view.View]string
+}
+
+// New returns a router for s.
+func New(s Stack) *Router {
+	return &Router{
+		stack: s,
+		paths: map[view.View]string{},
+	}
+}
+
+// Handle adds a route that displays the view returned by f for URLs matching
+// pattern.
+func (r *Router) Handle(pattern string, f func(Params) view.View) {
+	r.routes = append(r.routes, &route{
+		pattern:  pattern,
+		segments: split(pattern),
+		f:        f,
+	})
+}
+
+// Push pushes the view for rawurl onto the stack. It returns an error if no
+// route matches rawurl.
+func (r *Router) Push(rawurl string) error {
+	path, v, err := r.view(rawurl)
+	if err != nil {
+		return err
+	}
+	r.paths[v] = path
+	r.stack.Push(v)
+	return nil
+}
+
+// Replace replaces the top view of the stack with the view for rawurl.
+func (r *Router) Replace(rawurl string) error {
+	path, v, err := r.view(rawurl)
+	if err != nil {
+		return err
+	}
+	views := r.stack.Views()
+	if len(views) == 0 {
+		views = []view.View{v}
+	} else {
+		views[len(views)-1] = v
+	}
+	r.paths[v] = path
+	r.setViews(views)
+	return nil
+}
+
+// Pop removes the top view of the stack. The root view is never removed.
+func (r *Router) Pop() {
+	r.stack.Pop()
+}
+
+// PopTo removes views from the stack until the top view was pushed with
+// rawurl. It returns an error, and leaves the stack unchanged, if the stack
+// doesn't contain such a view.
+func (r *Router) PopTo(rawurl string) error {
+	path, _, err := parse(rawurl)
+	if err != nil {
+		return err
+	}
+	views := r.stack.Views()
+	for i := len(views) - 1; i >= 0; i-- {
+		if p, ok := r.paths[views[i]]; ok && p == path {
+			r.setViews(views[:i+1])
+			return nil
+		}
+	}
+	return fmt.Errorf("router: %v is not in the stack", path)
+}
+
+// Open replaces the stack with the views for a deep link. A view is added for
+// each prefix of the URL's path that matches a route, so "myapp://users/42"
+// displays the views for "/", "/users" and "/users/42" if they have routes. The
+// full path must match a route. The query values are passed to each view.
+//
+// For http and https URLs the host is ignored. For other schemes, the host is
+// the first segment of the path.
+func (r *Router) Open(rawurl string) error {
+	path, query, err := parse(rawurl)
+	if err != nil {
+		return err
+	}
+	segments := split(path)
+	views := []view.View{}
+	paths := []string{}
+	for i := 0; i <= len(segments); i++ {
+		prefix := "/" + strings.Join(segments[:i], "/")
+		v, ok := r.match(prefix, query)
+		if !ok {
+			if i == len(segments) {
+				return fmt.Errorf("router: no route for %v", path)
+			}
+			continue
+		}
+		views = append(views, v)
+		paths = append(paths, prefix)
+	}
+	for idx, i := range views {
+		r.paths[i] = paths[idx]
+	}
+	r.setViews(views)
+	return nil
+}
+
+// Paths returns the path of each view in the stack, from the root to the top.
+func (r *Router) Paths() []string {
+	paths := []string{}
+	for _, i := range r.stack.Views() {
+		if p, ok := r.paths[i]; ok {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+// State serializes the stack, so that it can be restored with Restore.
+func (r *Router) State() []byte {
+	data, _ := json.Marshal(r.Paths())
+	return data
+}
+
+// Restore replaces the stack with the views in state, which was returned by
+// State. It returns an error, and leaves the stack unchanged, if state is
+// invalid or one of its paths no longer has a route.
+func (r *Router) Restore(state []byte) error {
+	paths := []string{}
+	if err := json.Unmarshal(state, &paths); err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		return errors.New("router: empty state")
+	}
+	views := []view.View{}
+	for _, i := range paths {
+		path, v, err := r.view(i)
+		if err != nil {
+			return err
+		}
+		r.paths[v] = path
+		views = append(views, v)
+	}
+	r.setViews(views)
+	return nil
+}
+
+// setViews replaces the stack, and forgets the paths of the removed views.
+func (r *Router) setViews(views []view.View) {
+	r.stack.SetViews(views...)
+	paths := map[view.View]string{}
+	for _, i := range views {
+		if p, ok := r.paths[i]; ok {
+			paths[i] = p
+		}
+	}
+	r.paths = paths
+}
+
+func (r *Router) view(rawurl string) (string, view.View, error) {
+	path, query, err := parse(rawurl)
+	if err != nil {
+		return "", nil, err
+	}
+	v, ok := r.match(path, query)
+	if !ok {
+		return "", nil, fmt.Errorf("router: no route for %v", path)
+	}
+	return path, v, nil
+}
+
+func (r *Router) match(path string, query url.Values) (view.View, bool) {
+	segments := split(path)
+	for _, i := range r.routes {
+		if params, ok := i.match(segments); ok {
+			for k, v := range query {
+				if _, ok := params[k]; !ok && len(v) > 0 {
+					params[k] = v[0]
+				}
+			}
+			return i.f(params), true
+		}
+	}
+	return nil, false
+}
+
+func (rt *route) match(segments []string) (Params, bool) {
+	params := Params{}
+	for idx, i := range rt.segments {
+		if strings.HasPrefix(i, "*") && idx < len(segments) {
+			params[i[1:]] = strings.Join(segments[idx:], "/")
+			return params, true
+		}
+		if idx >= len(segments) {
+			return nil, false
+		}
+		if strings.HasPrefix(i, ":") {
+			params[i[1:]] = segments[idx]
+		} else if i != segments[idx] {
+			return nil, false
+		}
+	}
+	if len(segments) != len(rt.segments) {
+		return nil, false
+	}
+	return params, true
+}
+
+// parse returns the cleaned path and the query values of rawurl.
+func parse(rawurl string) (string, url.Values, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return "", nil, err
+	}
+	path := u.Path
+	if u.Scheme != "" && u.Scheme != "http" && u.Scheme != "https" {
+		path = u.Host + "/" + path
+	}
+	return "/" + strings.Join(split(path), "/"), u.Query(), nil
+}
+
+func split(path string) []string {
+	segments := []string{}
+	for _, i := range strings.Split(path, "/") {
+		if i != "" {
+			segments = append(segments, i)
+		}
+	}
+	return segments
+}
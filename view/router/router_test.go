@@ -0,0 +1,99 @@
+package router
+
+import (
+	"reflect"
+	"testing"
+
+	"gomatcha.io/matcha/view"
+	"gomatcha.io/matcha/view/ios"
+)
+
+type testView struct {
+	view.Embed
+	path string
+}
+
+func newRouter() (*Router, *ios.Stack) {
+	s := &ios.Stack{}
+	r := New(s)
+	for _, i := range []string{"/", "/users", "/users/:id", "/files/*path"} {
+		pattern := i
+		r.Handle(pattern, func(p Params) view.View {
+			return &testView{path: pattern + " " + p["id"] + p["path"] + p["tab"]}
+		})
+	}
+	return r, s
+}
+
+func stackPaths(s *ios.Stack) []string {
+	paths := []string{}
+	for _, i := range s.Views() {
+		paths = append(paths, i.(*testView).path)
+	}
+	return paths
+}
+
+func TestOpen(t *testing.T) {
+	r, s := newRouter()
+	if err := r.Open("myapp://users/42?tab=posts"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := stackPaths(s), []string{"/ posts", "/users posts", "/users/:id 42posts"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if err := r.Open("https://example.com/files/a/b"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := r.Paths(), []string{"/", "/files/a", "/files/a/b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if err := r.Open("myapp://unknown"); err == nil {
+		t.Error("expected error for unknown route")
+	}
+}
+
+func TestPushPop(t *testing.T) {
+	r, s := newRouter()
+	r.Open("/")
+	r.Push("/users")
+	r.Push("/users/1")
+	r.Push("/users/2")
+	if err := r.Replace("/users/3"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := r.Paths(), []string{"/", "/users", "/users/1", "/users/3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if err := r.PopTo("/users"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := r.Paths(), []string{"/", "/users"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// Pops from the stack view are reflected in the paths.
+	s.Pop()
+	if got, want := r.Paths(), []string{"/"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if err := r.Push("/users/1/2"); err == nil {
+		t.Error("expected error for unknown route")
+	}
+}
+
+func TestRestore(t *testing.T) {
+	r, _ := newRouter()
+	r.Open("/users/7")
+	state := r.State()
+
+	r2, s2 := newRouter()
+	if err := r2.Restore(state); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := stackPaths(s2), []string{"/ ", "/users ", "/users/:id 7"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if err := r2.Restore([]byte("[]")); err == nil {
+		t.Error("expected error for empty state")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"gomatcha.io/matcha/animate"
 	"gomatcha.io/matcha/comm"
 	"gomatcha.io/matcha/internal"
+	"gomatcha.io/matcha/keyboard"
 	"gomatcha.io/matcha/layout"
 	"gomatcha.io/matcha/paint"
 	pbview "gomatcha.io/matcha/proto/view"
@@ -21,17 +22,44 @@ type ScrollView struct {
 	ScrollEnabled  bool
 	ScrollPosition *ScrollPosition
 	OnScroll       func(position layout.Point)
+	// Refreshing is true while the pull-to-refresh control is spinning. The
+	// control is only displayed if OnRefresh is set.
+	Refreshing *comm.BoolValue
+	// OnRefresh is called when the user pulls down to refresh, after Refreshing
+	// is set to true. Set Refreshing to false when the refresh completes.
+	OnRefresh func()
+	// Header, if set, is displayed above the content. It is HeaderHeight tall
+	// while the view is scrolled to the top, stretches as the view is pulled
+	// past the top, and collapses as the content scrolls until it is
+	// HeaderMinHeight tall. It then stays at the top of the view, for example
+	// under a navigation bar. Headers only stretch on iOS, where scroll views
+	// bounce.
+	Header          View
+	HeaderHeight    float64
+	HeaderMinHeight float64
+	// HeaderParallax is how much of the scroll offset the header's top stays
+	// in place for while it collapses. At 0 it scrolls with the content, at 1
+	// its top stays pinned and it shrinks, and at 0.5 it scrolls at half the
+	// speed of the content.
+	HeaderParallax float64
+	// KeyboardInset extends the content by the height of the keyboard while
+	// it is displayed, so that the content below it can be scrolled into
+	// view. Focused text inputs are scrolled above the keyboard.
+	KeyboardInset bool
 
 	ContentChildren []View
 	ContentPainter  paint.Painter
 	ContentLayouter layout.Layouter
 	PaintStyle      *paint.Style
+
+	prevRefreshing *comm.BoolValue
 }
 
 // NewScrollView returns a new view.
 func NewScrollView() *ScrollView {
 	return &ScrollView{
 		ScrollPosition: &ScrollPosition{},
+		Refreshing:     &comm.BoolValue{},
 		ScrollAxes:     layout.AxisY,
 		IndicatorAxes:  layout.AxisY | layout.AxisX,
 		ScrollEnabled:  true,
@@ -44,6 +72,9 @@ func (v *ScrollView) Lifecycle(from, to Stage) {
 			v.ScrollPosition = &ScrollPosition{}
 		}
 	}
+	if ExitsStage(from, to, StageMounted) {
+		v.Unsubscribe(v.prevRefreshing)
+	}
 }
 
 func (v *ScrollView) Update(v2 View) {
@@ -52,14 +83,46 @@ func (v *ScrollView) Update(v2 View) {
 	if v.ScrollPosition == nil {
 		v.ScrollPosition = &ScrollPosition{}
 	}
+	if v.Refreshing == nil {
+		v.Refreshing = &comm.BoolValue{}
+	}
 }
 
 // Build implements View.
 func (v *ScrollView) Build(ctx Context) Model {
+	if v.Refreshing != v.prevRefreshing {
+		if v.prevRefreshing != nil {
+			v.Unsubscribe(v.prevRefreshing)
+		}
+		v.prevRefreshing = v.Refreshing
+		if v.Refreshing != nil {
+			v.Subscribe(v.Refreshing)
+		}
+	}
+
 	child := NewBasicView()
 	child.Children = v.ContentChildren
 	child.Layouter = v.ContentLayouter
 	child.Painter = v.ContentPainter
+	if v.Header != nil {
+		// The header is drawn above the content, so that it covers it once
+		// collapsed.
+		content := child
+		child = NewBasicView()
+		child.Children = []View{content, v.Header}
+		child.Layouter = &scrollHeaderLayouter{
+			height:         v.HeaderHeight,
+			minHeight:      v.HeaderMinHeight,
+			parallax:       v.HeaderParallax,
+			scrollPosition: v.ScrollPosition,
+		}
+	}
+	if v.KeyboardInset {
+		content := child
+		child = NewBasicView()
+		child.Children = []View{content}
+		child.Layouter = &scrollKeyboardLayouter{inset: keyboard.ScreenInset()}
+	}
 
 	var painter paint.Painter
 	if v.PaintStyle != nil {
@@ -98,6 +161,19 @@ func (v *ScrollView) Build(ctx Context) Model {
 					v.OnScroll(offset)
 				}
 			},
+			// Refresh returns whether the refresh control is displayed, and
+			// whether it is spinning.
+			"Refresh": func() (bool, bool) {
+				return v.OnRefresh != nil, v.Refreshing != nil && v.Refreshing.Value()
+			},
+			"OnRefresh": func() {
+				if v.Refreshing != nil {
+					v.Refreshing.SetValue(true)
+				}
+				if v.OnRefresh != nil {
+					v.OnRefresh()
+				}
+			},
 		},
 	}
 }
@@ -137,6 +213,77 @@ func (l *scrollViewLayouter) Unnotify(id comm.Id) {
 	l.scrollPosition.Unnotify(id)
 }
 
+// scrollHeaderLayouter places the content below the header, and the header at
+// the top of the visible area. It is laid out again whenever the view scrolls.
+type scrollHeaderLayouter struct {
+	height         float64
+	minHeight      float64
+	parallax       float64
+	scrollPosition *ScrollPosition
+}
+
+func (l *scrollHeaderLayouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	g := ctx.LayoutChild(0, ctx.MinSize(), ctx.MaxSize())
+	g.Frame = layout.Rt(0, l.height, g.Width(), l.height+g.Height())
+
+	y, height := headerFrame(l.scrollPosition.Value().Y, l.height, l.minHeight, l.parallax)
+	size := layout.Pt(g.Width(), height)
+	hg := ctx.LayoutChild(1, size, size)
+	hg.Frame = layout.Rt(0, y, g.Width(), y+height)
+
+	return layout.Guide{
+		Frame: layout.Rt(0, 0, g.Width(), l.height+g.Height()),
+	}, []layout.Guide{g, hg}
+}
+
+func (l *scrollHeaderLayouter) Notify(f func()) comm.Id {
+	return l.scrollPosition.Notify(f)
+}
+
+func (l *scrollHeaderLayouter) Unnotify(id comm.Id) {
+	l.scrollPosition.Unnotify(id)
+}
+
+// scrollKeyboardLayouter places the content at the top, and leaves space below
+// it for the keyboard. It is laid out again whenever the keyboard changes.
+type scrollKeyboardLayouter struct {
+	inset *keyboard.Inset
+}
+
+func (l *scrollKeyboardLayouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	g := ctx.LayoutChild(0, ctx.MinSize(), ctx.MaxSize())
+	g.Frame = layout.Rt(0, 0, g.Width(), g.Height())
+	return layout.Guide{
+		Frame: layout.Rt(0, 0, g.Width(), g.Height()+l.inset.Height()),
+	}, []layout.Guide{g}
+}
+
+func (l *scrollKeyboardLayouter) Notify(f func()) comm.Id {
+	return l.inset.Notify(f)
+}
+
+func (l *scrollKeyboardLayouter) Unnotify(id comm.Id) {
+	l.inset.Unnotify(id)
+}
+
+// headerFrame returns the top and height of a header in the scroll view's
+// content, when the content is scrolled to offset.
+func headerFrame(offset, height, minHeight, parallax float64) (float64, float64) {
+	minHeight = math.Min(minHeight, height)
+	parallax = math.Min(math.Max(parallax, 0), 1)
+	switch {
+	case offset <= 0:
+		// Stretch to fill the space above the content.
+		return offset, height - offset
+	case height-offset <= minHeight:
+		// Stay at the top of the view.
+		return offset, minHeight
+	default:
+		y := offset * parallax
+		return y, height - y
+	}
+}
+
 type ScrollPosition struct {
 	X           animate.Value
 	Y           animate.Value
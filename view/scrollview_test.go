@@ -0,0 +1,27 @@
+package view
+
+import "testing"
+
+func TestHeaderFrame(t *testing.T) {
+	for _, tc := range []struct {
+		offset, height, minHeight, parallax float64
+		y, h                                float64
+	}{
+		// Stretched.
+		{-50, 200, 0, 0, -50, 250},
+		{0, 200, 64, 1, 0, 200},
+		// Collapsing.
+		{100, 200, 64, 1, 100, 100},
+		{100, 200, 64, 0, 0, 200},
+		{100, 200, 64, 0.5, 50, 150},
+		// Collapsed.
+		{136, 200, 64, 0, 136, 64},
+		{500, 200, 64, 0.5, 500, 64},
+		{500, 200, 300, 0, 500, 200},
+	} {
+		y, h := headerFrame(tc.offset, tc.height, tc.minHeight, tc.parallax)
+		if y != tc.y || h != tc.h {
+			t.Errorf("headerFrame(%v, %v, %v, %v) = %v, %v, want %v, %v", tc.offset, tc.height, tc.minHeight, tc.parallax, y, h, tc.y, tc.h)
+		}
+	}
+}
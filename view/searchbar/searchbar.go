@@ -0,0 +1,110 @@
+// Package searchbar implements a search field in the navigation bar of a stack
+// view. It is displayed with a UISearchController on iOS 11 and later, and with
+// a SearchView in the toolbar on Android. Add it to a screen with the SearchBar
+// field of ios.StackBar or android.StackBar.
+//
+//  bar := &searchbar.Bar{
+//      Placeholder: "Search",
+//      Text:        &v.query, // comm.StringValue
+//  }
+//  return view.Model{
+//      Options: []view.Option{
+//          &ios.StackBar{Title: "Contacts", SearchBar: bar},
+//      },
+//  }
+//
+// The view subscribes to v.query and filters its list by v.query.Value().
+package searchbar
+
+import "gomatcha.io/matcha/comm"
+
+// Bar is a search field.
+type Bar struct {
+	Placeholder string
+	// Text is the query. It is set as the user types, and setting it updates
+	// the search field.
+	Text *comm.StringValue
+	// Focused is true while the search field is being edited. Setting it
+	// focuses or unfocuses the search field.
+	Focused *comm.BoolValue
+	// OnChange is called with the query as the user types, after Text is set.
+	OnChange func(string)
+	// OnSubmit is called with the query when the user presses the search key.
+	OnSubmit func(string)
+	// OnCancel is called when the user cancels the search, after Text is
+	// cleared and Focused is set to false.
+	OnCancel func()
+}
+
+// Notifiers returns the values the stack bar displaying b subscribes to.
+func (b *Bar) Notifiers() []comm.Notifier {
+	if b == nil {
+		return nil
+	}
+	ns := []comm.Notifier{}
+	if b.Text != nil {
+		ns = append(ns, b.Text)
+	}
+	if b.Focused != nil {
+		ns = append(ns, b.Focused)
+	}
+	return ns
+}
+
+// NativeFuncs returns the functions called by the native search field. They
+// are added to the stack bar's view.Model by the stack views, and b may be nil
+// if the bar has no search field.
+func (b *Bar) NativeFuncs() map[string]interface{} {
+	text, focused := "", false
+	if b != nil && b.Text != nil {
+		text = b.Text.Value()
+	}
+	if b != nil && b.Focused != nil {
+		focused = b.Focused.Value()
+	}
+	return map[string]interface{}{
+		// State returns whether the bar has a search field, its placeholder,
+		// the query and whether the field is focused.
+		"Search State": func() (bool, string, string, bool) {
+			if b == nil {
+				return false, "", "", false
+			}
+			return true, b.Placeholder, text, focused
+		},
+		"Search OnChange": func(query string) {
+			if b == nil {
+				return
+			}
+			if b.Text != nil {
+				b.Text.SetValue(query)
+			}
+			if b.OnChange != nil {
+				b.OnChange(query)
+			}
+		},
+		"Search OnFocus": func(focused bool) {
+			if b != nil && b.Focused != nil {
+				b.Focused.SetValue(focused)
+			}
+		},
+		"Search OnSubmit": func(query string) {
+			if b != nil && b.OnSubmit != nil {
+				b.OnSubmit(query)
+			}
+		},
+		"Search OnCancel": func() {
+			if b == nil {
+				return
+			}
+			if b.Text != nil {
+				b.Text.SetValue("")
+			}
+			if b.Focused != nil {
+				b.Focused.SetValue(false)
+			}
+			if b.OnCancel != nil {
+				b.OnCancel()
+			}
+		},
+	}
+}
@@ -0,0 +1,84 @@
+package view
+
+import (
+	"sync"
+
+	"gomatcha.io/matcha/internal"
+)
+
+var services = struct {
+	mu sync.Mutex
+	m  map[interface{}]interface{}
+}{
+	m: map[interface{}]interface{}{},
+}
+
+// Provide registers service under key for the entire app. It is typically called
+// at app start, before the root view is created. Keys should be of an unexported
+// type to avoid collisions, similar to context.Context. Providing a nil service
+// removes the registration.
+//
+//  type storeKey struct{}
+//
+//  func init() {
+//      view.Provide(storeKey{}, NewStore())
+//  }
+func Provide(key, service interface{}) {
+	services.mu.Lock()
+	defer services.mu.Unlock()
+
+	if service == nil {
+		delete(services.m, key)
+		return
+	}
+	services.m[key] = service
+}
+
+// Service returns the service for key. Services provided with WithService by an
+// ancestor of the view being built take precedence over those registered with
+// Provide. ctx may be nil, in which case only services registered with Provide
+// are considered. Returns nil if no service is found.
+//
+//  func (v *MyView) Build(ctx view.Context) view.Model {
+//      store := view.Service(ctx, storeKey{}).(*Store)
+//      ...
+//  }
+func Service(ctx Context, key interface{}) interface{} {
+	if c, ok := ctx.(*viewContext); ok && c != nil {
+		for n := c.node; n != nil; n = n.parent {
+			if sv, ok := n.view.(*serviceView); ok && sv.key == key {
+				return sv.service
+			}
+		}
+	}
+
+	services.mu.Lock()
+	defer services.mu.Unlock()
+	return services.m[key]
+}
+
+// WithService wraps the view v, so that v and its descendants resolve key to
+// service. It can be used to override services in tests and previews.
+func WithService(v View, key, service interface{}) View {
+	return &serviceView{View: v, key: key, service: service}
+}
+
+type serviceView struct {
+	View
+	key     interface{}
+	service interface{}
+}
+
+func (v *serviceView) ViewKey() interface{} {
+	return struct {
+		A interface{}
+		B interface{}
+	}{A: v.View.ViewKey(), B: internal.ReflectName(v.View)}
+}
+
+func (v *serviceView) Update(v2 View) {
+	sv := v2.(*serviceView)
+	v.key = sv.key
+	v.service = sv.service
+	v.View.Update(sv.View)
+}
@@ -0,0 +1,43 @@
+package view
+
+import (
+	"testing"
+
+	"gomatcha.io/matcha/layout"
+)
+
+type serviceTestKey struct{}
+
+type serviceTestView struct {
+	Embed
+	child   View
+	service interface{}
+}
+
+func (v *serviceTestView) Build(ctx Context) Model {
+	v.service = Service(ctx, serviceTestKey{})
+	if v.child != nil {
+		return Model{Children: []View{v.child}}
+	}
+	return Model{}
+}
+
+func TestService(t *testing.T) {
+	Provide(serviceTestKey{}, "app")
+	defer Provide(serviceTestKey{}, nil)
+
+	child := &serviceTestView{}
+	parent := &serviceTestView{child: WithService(child, serviceTestKey{}, "override")}
+	root := newRoot(parent)
+	root.update(layout.Pt(100, 100))
+
+	if parent.service != "app" {
+		t.Error("Unexpected service", parent.service)
+	}
+	if child.service != "override" {
+		t.Error("Unexpected service", child.service)
+	}
+	if Service(nil, serviceTestKey{}) != "app" {
+		t.Error("Unexpected service", Service(nil, serviceTestKey{}))
+	}
+}
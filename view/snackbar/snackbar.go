@@ -0,0 +1,152 @@
+// Package snackbar displays brief messages at the bottom of the screen, as
+// Material Snackbars on Android and a banner on iOS. Messages are queued and
+// displayed one at a time.
+//
+//  undo := &snackbar.Action{Title: "Undo", OnPress: v.restore}
+//  snackbar.Show(&snackbar.Message{Text: "Photo deleted", Action: undo})
+//
+// The returned channel receives the result once the message is dismissed.
+//
+//  r := <-snackbar.Show(&snackbar.Message{Text: "Sent"})
+package snackbar
+
+import (
+	"runtime"
+	"time"
+
+	"gomatcha.io/matcha/bridge"
+)
+
+const (
+	// DurationShort is the default duration of a message.
+	DurationShort = 1500 * time.Millisecond
+	// DurationLong is the duration of messages that take longer to read.
+	DurationLong = 2750 * time.Millisecond
+	// DurationIndefinite messages are displayed until their action is pressed,
+	// they are swiped away, or Dismiss is called.
+	DurationIndefinite time.Duration = -1
+)
+
+// Action is the button of a message.
+type Action struct {
+	Title   string
+	OnPress func()
+}
+
+// Message is a message to display.
+type Message struct {
+	Text   string
+	Action *Action
+	// Duration is how long the message is displayed. It is DurationShort if
+	// zero.
+	Duration time.Duration
+}
+
+// Result describes how a message was dismissed.
+type Result struct {
+	// Action is true if the message's action was pressed.
+	Action bool
+}
+
+type snackbar struct {
+	id  int64
+	msg *Message
+	c   chan Result
+}
+
+var (
+	maxId int64
+	// queue holds the messages to display. The first one is displayed.
+	queue []*snackbar
+
+	// display and hide are replaced in tests.
+	display func(*snackbar)
+	hide    func(id int64)
+)
+
+func init() {
+	display = displayNative
+	hide = hideNative
+	bridge.MainThreadOnly("presentSnackbar", "presentSnackbar:text:action:duration:", "dismissSnackbar", "dismissSnackbar:")
+	bridge.RegisterFunc("gomatcha.io/matcha/view/snackbar onDismiss", func(id int64, action bool) {
+		dismissed(id, action)
+	})
+}
+
+// Show displays the message once the messages before it are dismissed. The
+// returned channel receives the result once it is dismissed.
+func Show(m *Message) <-chan Result {
+	maxId += 1
+	s := &snackbar{id: maxId, msg: m, c: make(chan Result, 1)}
+	queue = append(queue, s)
+	if len(queue) == 1 {
+		display(s)
+	}
+	return s.c
+}
+
+// Dismiss hides the displayed message. The next message in the queue is then
+// displayed.
+func Dismiss() {
+	if len(queue) > 0 {
+		hide(queue[0].id)
+	}
+}
+
+// dismissed is called when the displayed message is dismissed, and displays the
+// next one.
+func dismissed(id int64, action bool) {
+	if len(queue) == 0 || queue[0].id != id {
+		return
+	}
+	s := queue[0]
+	queue = queue[1:]
+
+	if action && s.msg.Action != nil && s.msg.Action.OnPress != nil {
+		s.msg.Action.OnPress()
+	}
+	s.c <- Result{Action: action && s.msg.Action != nil}
+
+	if len(queue) > 0 {
+		display(queue[0])
+	}
+}
+
+func displayNative(s *snackbar) {
+	if runtime.GOOS != "android" && runtime.GOOS != "darwin" {
+		dismissed(s.id, false)
+		return
+	}
+
+	action := ""
+	if s.msg.Action != nil {
+		action = s.msg.Action.Title
+	}
+	duration := s.msg.Duration
+	if duration == 0 {
+		duration = DurationShort
+	}
+	ms := int64(-1)
+	if duration > 0 {
+		ms = int64(duration / time.Millisecond)
+	}
+	args := []*bridge.Value{
+		bridge.Int64(s.id),
+		bridge.String(s.msg.Text),
+		bridge.String(action),
+		bridge.Int64(ms),
+	}
+	if runtime.GOOS == "android" {
+		bridge.Bridge("").Call("presentSnackbar", args...)
+	} else {
+		bridge.Bridge("").Call("presentSnackbar:text:action:duration:", args...)
+	}
+}
+
+func hideNative(id int64) {
+	if runtime.GOOS == "android" {
+		bridge.Bridge("").Call("dismissSnackbar", bridge.Int64(id))
+	} else if runtime.GOOS == "darwin" {
+		bridge.Bridge("").Call("dismissSnackbar:", bridge.Int64(id))
+	}
+}
@@ -0,0 +1,53 @@
+package snackbar
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQueue(t *testing.T) {
+	displayed := []string{}
+	hidden := []int64{}
+	display = func(s *snackbar) {
+		displayed = append(displayed, s.msg.Text)
+	}
+	hide = func(id int64) {
+		hidden = append(hidden, id)
+	}
+	defer func() {
+		display, hide, queue = displayNative, hideNative, nil
+	}()
+
+	pressed := false
+	c1 := Show(&Message{Text: "a", Action: &Action{Title: "Undo", OnPress: func() { pressed = true }}})
+	c2 := Show(&Message{Text: "b"})
+	if want := []string{"a"}; !reflect.DeepEqual(displayed, want) {
+		t.Fatalf("displayed %v, want %v", displayed, want)
+	}
+
+	// Dismissing a message that isn't displayed is ignored.
+	dismissed(queue[1].id, false)
+	if len(queue) != 2 {
+		t.Fatalf("len(queue) = %v, want 2", len(queue))
+	}
+
+	dismissed(queue[0].id, true)
+	if r := <-c1; !r.Action || !pressed {
+		t.Errorf("result %v, pressed %v, want action pressed", r, pressed)
+	}
+	if want := []string{"a", "b"}; !reflect.DeepEqual(displayed, want) {
+		t.Errorf("displayed %v, want %v", displayed, want)
+	}
+
+	Dismiss()
+	if len(hidden) != 1 || hidden[0] != queue[0].id {
+		t.Errorf("hidden %v, want [%v]", hidden, queue[0].id)
+	}
+	dismissed(queue[0].id, true)
+	if r := <-c2; r.Action {
+		t.Errorf("result %v for a message without an action", r)
+	}
+	if len(queue) != 0 {
+		t.Errorf("len(queue) = %v, want 0", len(queue))
+	}
+}
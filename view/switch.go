@@ -2,10 +2,10 @@ package view
 
 import (
 	"fmt"
-	"runtime"
 
 	"github.com/gogo/protobuf/proto"
 	"gomatcha.io/matcha/internal"
+	"gomatcha.io/matcha/internal/platform"
 	"gomatcha.io/matcha/layout"
 	"gomatcha.io/matcha/paint"
 	protoview "gomatcha.io/matcha/proto/view"
@@ -29,7 +29,7 @@ func NewSwitch() *Switch {
 // Build implements view.View.
 func (v *Switch) Build(ctx Context) Model {
 	var rect layout.Rect
-	if runtime.GOOS == "android" {
+	if platform.UsesJavaStyleBridge() {
 		rect = layout.Rt(0, 0, 61, 40)
 	} else {
 		rect = layout.Rt(0, 0, 51, 31)
@@ -2,13 +2,16 @@ package view
 
 import (
 	"fmt"
+	"math"
 	"runtime"
+	"strings"
 
 	"golang.org/x/image/colornames"
 
 	"github.com/gogo/protobuf/proto"
 	"gomatcha.io/matcha/comm"
 	"gomatcha.io/matcha/internal"
+	"gomatcha.io/matcha/internal/platform"
 	"gomatcha.io/matcha/keyboard"
 	"gomatcha.io/matcha/layout"
 	"gomatcha.io/matcha/paint"
@@ -31,10 +34,14 @@ type TextInput struct {
 	Responder        *keyboard.Responder
 	prevResponder    *keyboard.Responder
 	responder        *keyboard.Responder
-	MaxLines         int // This is used only for sizing.
-	OnChange         func(*text.Text)
-	OnSubmit         func(*text.Text)
-	OnFocus          func(*keyboard.Responder)
+	// MaxLines is the number of lines displayed by the input. Single-line inputs,
+	// the default, call OnSubmit when return is pressed. Multiline inputs insert
+	// newlines instead, and grow with their content up to MaxLines lines, or
+	// without limit if MaxLines is 0, after which they scroll.
+	MaxLines int
+	OnChange func(*text.Text)
+	OnSubmit func(*text.Text)
+	OnFocus  func(*keyboard.Responder)
 }
 
 // NewTextInput returns a new view.
@@ -58,7 +65,7 @@ func (v *TextInput) Build(ctx Context) Model {
 	style := v.Style
 	if style == nil {
 		style = &text.Style{}
-		if runtime.GOOS == "android" {
+		if platform.UsesJavaStyleBridge() {
 			style.SetFont(text.DefaultFont(18))
 		} else if runtime.GOOS == "darwin" {
 			style.SetFont(text.DefaultFont(18))
@@ -74,7 +81,7 @@ func (v *TextInput) Build(ctx Context) Model {
 	placeholderStyle := v.PlaceholderStyle
 	if placeholderStyle == nil {
 		placeholderStyle = &text.Style{}
-		if runtime.GOOS == "android" {
+		if platform.UsesJavaStyleBridge() {
 			placeholderStyle.SetFont(text.DefaultFont(18))
 			placeholderStyle.SetTextColor(colornames.Gray)
 		} else if runtime.GOOS == "darwin" {
@@ -105,7 +112,7 @@ func (v *TextInput) Build(ctx Context) Model {
 		painter = v.PaintStyle
 	}
 	return Model{
-		Layouter:       &textInputLayouter{style: style, styledText: st, maxLines: v.MaxLines},
+		Layouter:       &textInputLayouter{style: style, text: t, maxLines: v.MaxLines},
 		Painter:        painter,
 		NativeViewName: "gomatcha.io/matcha/view/textinput",
 		NativeViewState: internal.MarshalProtobuf(&pbview.TextInput{
@@ -173,9 +180,9 @@ func (v *TextInput) Build(ctx Context) Model {
 }
 
 type textInputLayouter struct {
-	style      *text.Style
-	styledText *text.StyledText
-	maxLines   int
+	style    *text.Style
+	text     *text.Text
+	maxLines int
 }
 
 func (l *textInputLayouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
@@ -184,16 +191,32 @@ func (l *textInputLayouter) Layout(ctx layout.Context) (layout.Guide, []layout.G
 		size := st.Size(layout.Pt(0, 0), ctx.MaxSize(), 1)
 		g := layout.Guide{Frame: layout.Rt(0, 0, ctx.MinSize().X, size.Y)}
 		return g, nil
-	} else {
-		g := layout.Guide{Frame: layout.Rt(0, 0, ctx.MinSize().X, ctx.MinSize().Y)}
-		return g, nil
 	}
+
+	// Size multiline inputs to their content, measuring the line after a
+	// trailing newline, and clamp it to the layout's bounds.
+	str := l.text.String()
+	if str == "" || strings.HasSuffix(str, "\n") {
+		str += "A"
+	}
+	width := ctx.MinSize().X
+	size := text.NewStyledText(str, l.style).Size(layout.Pt(width, 0), layout.Pt(width, math.Inf(1)), l.maxLines)
+	height := math.Min(math.Max(size.Y, ctx.MinSize().Y), ctx.MaxSize().Y)
+	g := layout.Guide{Frame: layout.Rt(0, 0, width, height)}
+	return g, nil
 }
 
+// Notify relayouts multiline inputs when their text changes.
 func (l *textInputLayouter) Notify(f func()) comm.Id {
-	return 0 // no-op
+	if l.maxLines == 1 {
+		return 0 // no-op
+	}
+	return l.text.Notify(f)
 }
 
 func (l *textInputLayouter) Unnotify(id comm.Id) {
-	// no-op
+	if l.maxLines == 1 {
+		return // no-op
+	}
+	l.text.Unnotify(id)
 }
@@ -0,0 +1,164 @@
+package view
+
+import (
+	"image/color"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gomatcha.io/matcha/application"
+	"gomatcha.io/matcha/text"
+)
+
+// DataDetector is a set of kinds of links that a TextView finds in its text.
+type DataDetector int
+
+const (
+	// DetectURLs finds web addresses, such as "https://gomatcha.io" or
+	// "www.gomatcha.io".
+	DetectURLs DataDetector = 1 << iota
+	// DetectPhoneNumbers finds phone numbers, such as "+1 (555) 123-4567".
+	DetectPhoneNumbers
+	// DetectEmails finds email addresses.
+	DetectEmails
+
+	// DetectAll finds every kind of link.
+	DetectAll DataDetector = 1<<iota - 1
+)
+
+// Link is a link in the text of a TextView.
+type Link struct {
+	// Kind is the detector that found the link, or 0 if it is a range of
+	// StyledText with a text.Style link.
+	Kind DataDetector
+	// URL is the address of the link. Phone numbers and email addresses have
+	// "tel:" and "mailto:" URLs.
+	URL string
+	// Start and End are the range of the link in the text. End is exclusive.
+	Start int
+	End   int
+}
+
+var (
+	urlRegexp   = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"]*[^\s<>"'.,;:!?)\]]`)
+	emailRegexp = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	phoneRegexp = regexp.MustCompile(`\+?\(?\d[\d\-. ()]{5,}\d`)
+	dateRegexp  = regexp.MustCompile(`^(\d{4}[-./]\d{1,2}[-./]\d{1,2}|\d{1,2}[-./]\d{1,2}[-./]\d{2,4})$`)
+)
+
+// defaultLinkStyle returns the style of detected links if LinkStyle is nil.
+func defaultLinkStyle() *text.Style {
+	s := &text.Style{}
+	s.SetTextColor(color.RGBA{0, 122, 255, 255})
+	s.SetUnderlineStyle(text.UnderlineStyleSingle)
+	return s
+}
+
+// detectLinks returns the links of the kinds in d found in str, in order.
+// Links that overlap an earlier one are skipped, so an email address isn't
+// also detected as a URL.
+func detectLinks(str string, d DataDetector) []Link {
+	links := []Link{}
+	find := func(kind DataDetector, re *regexp.Regexp, url func(string) string) {
+		if d&kind == 0 {
+			return
+		}
+		for _, i := range re.FindAllStringIndex(str, -1) {
+			match := str[i[0]:i[1]]
+			u := url(match)
+			if u == "" {
+				continue
+			}
+			links = append(links, Link{Kind: kind, URL: u, Start: i[0], End: i[1]})
+		}
+	}
+	find(DetectEmails, emailRegexp, func(s string) string {
+		return "mailto:" + s
+	})
+	find(DetectURLs, urlRegexp, func(s string) string {
+		if strings.HasPrefix(strings.ToLower(s), "www.") {
+			return "http://" + s
+		}
+		return s
+	})
+	find(DetectPhoneNumbers, phoneRegexp, func(s string) string {
+		digits := []rune{}
+		for _, r := range s {
+			if (r >= '0' && r <= '9') || (r == '+' && len(digits) == 0) {
+				digits = append(digits, r)
+			}
+		}
+		// Shorter numbers are more likely to be amounts.
+		if len(digits) < 7 || dateRegexp.MatchString(s) {
+			return ""
+		}
+		return "tel:" + string(digits)
+	})
+
+	// Earlier detectors take precedence over overlapping links.
+	found := []Link{}
+	for _, l := range links {
+		overlaps := false
+		for _, f := range found {
+			if l.Start < f.End && f.Start < l.End {
+				overlaps = true
+				break
+			}
+		}
+		if !overlaps {
+			found = append(found, l)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].Start < found[j].Start
+	})
+	return found
+}
+
+// styleLinks returns st with the links in d detected and styled with style.
+// st is copied if it is changed.
+func styleLinks(st *text.StyledText, d DataDetector, style *text.Style) (*text.StyledText, []Link) {
+	if d == 0 {
+		return st, nil
+	}
+	links := detectLinks(st.String(), d)
+	if len(links) == 0 {
+		return st, nil
+	}
+	if style == nil {
+		style = defaultLinkStyle()
+	}
+	st = st.Copy()
+	for _, l := range links {
+		link := &text.Style{}
+		link.SetLink(l.URL)
+		st.Update(style, l.Start, l.End-1)
+		st.Update(link, l.Start, l.End-1)
+	}
+	return st, links
+}
+
+// linkAt returns the link with url at index, either one of the detected links
+// or the span of st that contains it.
+func linkAt(st *text.StyledText, links []Link, index int, url string) Link {
+	for _, l := range links {
+		if index >= l.Start && index < l.End {
+			return l
+		}
+	}
+	for _, i := range st.Spans() {
+		if index >= i.Start && index < i.End {
+			return Link{URL: url, Start: i.Start, End: i.End}
+		}
+	}
+	return Link{URL: url, Start: index, End: index + 1}
+}
+
+// openLink calls OnLink, or opens the link if it is nil.
+func (v *TextView) openLink(l Link) {
+	if v.OnLink != nil {
+		v.OnLink(l)
+		return
+	}
+	application.OpenURL(l.URL)
+}
@@ -0,0 +1,42 @@
+package view
+
+import (
+	"reflect"
+	"testing"
+
+	"gomatcha.io/matcha/text"
+)
+
+func TestDetectLinks(t *testing.T) {
+	str := "Mail ann@example.com, see www.gomatcha.io. or call +1 (555) 123-4567 on 2017-08-01."
+	got := detectLinks(str, DetectAll)
+	want := []Link{
+		{Kind: DetectEmails, URL: "mailto:ann@example.com", Start: 5, End: 20},
+		{Kind: DetectURLs, URL: "http://www.gomatcha.io", Start: 26, End: 41},
+		{Kind: DetectPhoneNumbers, URL: "tel:+15551234567", Start: 51, End: 68},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("detectLinks() = %v, want %v", got, want)
+	}
+
+	if got := detectLinks(str, DetectURLs); len(got) != 1 || got[0].Kind != DetectURLs {
+		t.Errorf("detectLinks(DetectURLs) = %v", got)
+	}
+}
+
+func TestStyleLinks(t *testing.T) {
+	st := text.NewStyledText("Go to https://gomatcha.io now", &text.Style{})
+	styled, links := styleLinks(st, DetectURLs, nil)
+	if len(links) != 1 {
+		t.Fatalf("styleLinks() found %v", links)
+	}
+	if st.At(10).Link() != "" {
+		t.Error("styleLinks() changed the original text")
+	}
+	if styled.At(10).Link() != "https://gomatcha.io" || styled.At(5).Link() != "" || styled.At(25).Link() != "" {
+		t.Error("styleLinks() styled the wrong range")
+	}
+	if l := linkAt(styled, links, 10, "https://gomatcha.io"); l != links[0] {
+		t.Errorf("linkAt() = %v, want %v", l, links[0])
+	}
+}
@@ -1,6 +1,8 @@
 package view
 
 import (
+	"image/color"
+
 	"gomatcha.io/matcha/comm"
 	"gomatcha.io/matcha/internal"
 	"gomatcha.io/matcha/layout"
@@ -8,7 +10,29 @@ import (
 	"gomatcha.io/matcha/text"
 )
 
-// TextView displays a multiline text region within it bounds.
+// TextView displays a multiline text region within it bounds. The ranges of
+// StyledText may have a background color, and tap handlers that make them
+// behave like inline links.
+//
+//  st := text.NewStyledText("Read the docs", style)
+//  link := &text.Style{}
+//  link.SetUnderlineStyle(text.UnderlineStyleSingle)
+//  link.SetOnTap(func() {
+//      ...
+//  })
+//  st.Update(link, 9, 12)
+//
+// Ranges styled with text.Style's SetLink, and the URLs, phone numbers and
+// email addresses found by DataDetectors, are links. They are highlighted
+// while pressed, and tapping one calls OnLink, or opens it if OnLink is nil.
+//
+//  v.DataDetectors = view.DetectAll
+//  v.OnLink = func(l view.Link) {
+//      fmt.Println(l.URL)
+//  }
+//
+// Selectable text can be selected with the platform's selection handles, and
+// copied or shared.
 type TextView struct {
 	Embed
 	PaintStyle *paint.Style
@@ -17,6 +41,20 @@ type TextView struct {
 	Style      *text.Style
 	StyledText *text.StyledText // TODO(KD): subscribe to StyledText and Text
 	MaxLines   int
+	// Selectable lets the user select the text, and copy or share it.
+	Selectable bool
+	// OnSelectionChange is called with the selected range of a Selectable view
+	// as the user changes it. End is exclusive, and equal to start once the
+	// selection is cleared.
+	OnSelectionChange func(start, end int)
+	// DataDetectors are the kinds of links found in the text.
+	DataDetectors DataDetector
+	// LinkStyle is applied to the detected links. If it is nil, they are blue
+	// and underlined.
+	LinkStyle *text.Style
+	// OnLink is called when a link is tapped. If it is nil, the link is opened
+	// with application.OpenURL.
+	OnLink func(Link)
 }
 
 // NewTextView returns a new view.
@@ -26,8 +64,7 @@ func NewTextView() *TextView {
 	}
 }
 
-// Build implements the view.View interface.
-func (v *TextView) Build(ctx Context) Model {
+func (v *TextView) styledText() *text.StyledText {
 	st := v.StyledText
 	if st == nil {
 		t := v.Text
@@ -36,6 +73,19 @@ func (v *TextView) Build(ctx Context) Model {
 		}
 		st = text.NewStyledText(t.String(), v.Style)
 	}
+	return st
+}
+
+// IntrinsicSize implements the view.IntrinsicSizer interface.
+func (v *TextView) IntrinsicSize(max layout.Point) layout.Point {
+	st, _ := styleLinks(v.styledText(), v.DataDetectors, v.LinkStyle)
+	return st.Size(layout.Pt(0, 0), max, v.MaxLines)
+}
+
+// Build implements the view.View interface.
+func (v *TextView) Build(ctx Context) Model {
+	st, links := styleLinks(v.styledText(), v.DataDetectors, v.LinkStyle)
+	str := st.String()
 
 	painter := paint.Painter(nil)
 	if v.PaintStyle != nil {
@@ -46,6 +96,55 @@ func (v *TextView) Build(ctx Context) Model {
 		Layouter:        &textViewLayouter{styledText: st, maxLines: v.MaxLines},
 		NativeViewName:  "gomatcha.io/matcha/view/textview",
 		NativeViewState: internal.MarshalProtobuf(st.MarshalProtobuf()),
+		NativeFuncs: map[string]interface{}{
+			// Spans returns the ranges with a background color, a tap
+			// handler or a link, as groups of start, end, background color as ARGB, or
+			// 0 if there is none, and 1 if the range is tappable.
+			"Spans": func() []int64 {
+				spans := []int64{}
+				for _, i := range st.Spans() {
+					bg := i.Style.BackgroundColor()
+					tappable := i.Style.OnTap() != nil || i.Style.Link() != ""
+					if bg == nil && !tappable {
+						continue
+					}
+					argb := int64(0)
+					if bg != nil {
+						c := color.NRGBAModel.Convert(bg).(color.NRGBA)
+						argb = int64(c.A)<<24 | int64(c.R)<<16 | int64(c.G)<<8 | int64(c.B)
+					}
+					tap := int64(0)
+					if tappable {
+						tap = 1
+					}
+					spans = append(spans, int64(text.UTF16Index(str, i.Start)), int64(text.UTF16Index(str, i.End)), argb, tap)
+				}
+				return spans
+			},
+			"Selectable": func() bool {
+				return v.Selectable
+			},
+			"OnSelectionChange": func(start, end int64) {
+				if v.OnSelectionChange != nil {
+					v.OnSelectionChange(text.ByteIndex(str, int(start)), text.ByteIndex(str, int(end)))
+				}
+			},
+			// OnTap is called with the index of the tapped character.
+			"OnTap": func(nativeIndex int64) {
+				index := text.ByteIndex(str, int(nativeIndex))
+				s := st.At(index)
+				if s == nil {
+					return
+				}
+				if f := s.OnTap(); f != nil {
+					f()
+					return
+				}
+				if url := s.Link(); url != "" {
+					v.openLink(linkAt(st, links, index, url))
+				}
+			},
+		},
 	}
 }
 
@@ -55,8 +154,12 @@ type textViewLayouter struct {
 }
 
 func (l *textViewLayouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
-	size := l.styledText.Size(layout.Pt(0, 0), ctx.MaxSize(), l.maxLines)
-	g := layout.Guide{Frame: layout.Rt(0, 0, size.X, size.Y)}
+	m := l.styledText.Metrics(layout.Pt(0, 0), ctx.MaxSize(), l.maxLines)
+	g := layout.Guide{
+		Frame:         layout.Rt(0, 0, m.Size.X, m.Size.Y),
+		FirstBaseline: m.FirstBaseline,
+		LastBaseline:  m.LastBaseline,
+	}
 	return g, nil
 }
 
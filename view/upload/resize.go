@@ -0,0 +1,85 @@
+package upload
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+)
+
+// Resize returns img scaled down proportionally so that neither its width nor
+// its height exceed maxSize. Each destination pixel is the average of the source
+// pixels it covers. img is returned unchanged if it already fits, or if maxSize
+// is zero.
+func Resize(img image.Image, maxSize int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if maxSize <= 0 || (w <= maxSize && h <= maxSize) {
+		return img
+	}
+
+	dw, dh := maxSize, maxSize
+	if w > h {
+		dh = h * maxSize / w
+	} else {
+		dw = w * maxSize / h
+	}
+	if dw < 1 {
+		dw = 1
+	}
+	if dh < 1 {
+		dh = 1
+	}
+
+	src, ok := img.(*image.RGBA)
+	if !ok {
+		src = image.NewRGBA(image.Rect(0, 0, w, h))
+		draw.Draw(src, src.Bounds(), img, b.Min, draw.Src)
+	}
+	sb := src.Bounds()
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		y0, y1 := y*h/dh, (y+1)*h/dh
+		if y1 == y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < dw; x++ {
+			x0, x1 := x*w/dw, (x+1)*w/dw
+			if x1 == x0 {
+				x1 = x0 + 1
+			}
+
+			var r, g, bl, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				i := src.PixOffset(sb.Min.X+x0, sb.Min.Y+sy)
+				for sx := x0; sx < x1; sx++ {
+					r += uint32(src.Pix[i])
+					g += uint32(src.Pix[i+1])
+					bl += uint32(src.Pix[i+2])
+					a += uint32(src.Pix[i+3])
+					n += 1
+					i += 4
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{uint8(r / n), uint8(g / n), uint8(bl / n), uint8(a / n)})
+		}
+	}
+	return dst
+}
+
+// Encode compresses img as a JPEG with the given quality, ranging from 1 to
+// 100. A quality of zero uses the image/jpeg default.
+func Encode(img image.Image, quality int) ([]byte, error) {
+	opts := &jpeg.Options{Quality: jpeg.DefaultQuality}
+	if quality > 0 {
+		opts.Quality = quality
+	}
+
+	buf := &bytes.Buffer{}
+	if err := jpeg.Encode(buf, img, opts); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
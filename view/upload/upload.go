@@ -0,0 +1,343 @@
+// Package upload implements an image upload component. It combines picking an
+// image, resizing and compressing it on the device, uploading it off the main
+// thread and displaying the progress, with support for retrying and cancelling.
+//
+// The package does not present a native picker or schedule transfers that
+// outlive the app. Apps supply a Picker, for example one built on
+// view/media, and an Uploader. HTTPUploader uploads in-process with net/http.
+//
+//  task := upload.NewTask(picker, &upload.HTTPUploader{URL: "https://example.com/upload"})
+//  task.MaxSize = 1024
+//
+//  v := upload.NewView()
+//  v.Task = task
+package upload
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"net/http"
+
+	"gomatcha.io/matcha"
+	"gomatcha.io/matcha/comm"
+)
+
+// ErrCancelled is returned by Task.Err after the upload has been cancelled.
+var ErrCancelled = errors.New("upload: cancelled")
+
+// Picker presents an image picker to the user. Pick must call f exactly once,
+// on any goroutine, with the selected image or an error. A nil image and nil
+// error indicate that the user dismissed the picker.
+type Picker interface {
+	Pick(f func(image.Image, error))
+}
+
+// PickerFunc adapts a function to the Picker interface.
+type PickerFunc func(f func(image.Image, error))
+
+// Pick implements the Picker interface.
+func (p PickerFunc) Pick(f func(image.Image, error)) {
+	p(f)
+}
+
+// Uploader transfers data to a server. Upload is called on a background
+// goroutine and should periodically call progress with a value between 0 and 1.
+// It should return promptly with ctx.Err() once ctx is cancelled.
+type Uploader interface {
+	Upload(ctx context.Context, data []byte, contentType string, progress func(float64)) error
+}
+
+// HTTPUploader uploads data as the body of an HTTP request.
+type HTTPUploader struct {
+	// URL is the destination of the request.
+	URL string
+	// Method defaults to "POST".
+	Method string
+	// Header is added to every request.
+	Header http.Header
+	// Client defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// Upload implements the Uploader interface.
+func (u *HTTPUploader) Upload(ctx context.Context, data []byte, contentType string, progress func(float64)) error {
+	method := u.Method
+	if method == "" {
+		method = "POST"
+	}
+	client := u.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	body := &progressReader{r: bytes.NewReader(data), total: len(data), progress: progress}
+	req, err := http.NewRequest(method, u.URL, body)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.ContentLength = int64(len(data))
+	for k, v := range u.Header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("upload: server returned %v", resp.Status)
+	}
+	return nil
+}
+
+type progressReader struct {
+	r        io.Reader
+	total    int
+	read     int
+	progress func(float64)
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.read += n
+	if r.progress != nil && r.total > 0 {
+		r.progress(float64(r.read) / float64(r.total))
+	}
+	return n, err
+}
+
+// State describes the stage of a Task.
+type State int
+
+const (
+	// StateIdle is the state of a new Task.
+	StateIdle State = iota
+	// StatePicking is the state while the picker is presented.
+	StatePicking
+	// StateUploading is the state while the image is being compressed and uploaded.
+	StateUploading
+	// StateFailed is the state after an upload fails or is cancelled. The task
+	// may be retried.
+	StateFailed
+	// StateDone is the state after the upload completes.
+	StateDone
+)
+
+func (s State) String() string {
+	switch s {
+	case StateIdle:
+		return "Idle"
+	case StatePicking:
+		return "Picking"
+	case StateUploading:
+		return "Uploading"
+	case StateFailed:
+		return "Failed"
+	case StateDone:
+		return "Done"
+	}
+	return fmt.Sprintf("State(%v)", int(s))
+}
+
+// Task tracks a single image upload. Its methods must be called on the main
+// thread, and it notifies its observers whenever its state or progress changes.
+type Task struct {
+	Picker   Picker
+	Uploader Uploader
+	// MaxSize is the maximum width or height of the uploaded image in pixels.
+	// Larger images are scaled down proportionally. Zero disables resizing.
+	MaxSize int
+	// Quality is the JPEG quality, ranging from 1 to 100. Zero uses the
+	// image/jpeg default.
+	Quality int
+	// OnComplete is called on the main thread after the upload completes.
+	OnComplete func()
+
+	relay    comm.Relay
+	state    State
+	progress comm.Float64Value
+	image    image.Image
+	err      error
+	cancel   context.CancelFunc
+	attempt  int
+}
+
+// NewTask returns a new task that picks images with p and uploads them with u.
+func NewTask(p Picker, u Uploader) *Task {
+	return &Task{
+		Picker:   p,
+		Uploader: u,
+	}
+}
+
+// Notify implements the comm.Notifier interface.
+func (t *Task) Notify(f func()) comm.Id {
+	return t.relay.Notify(f)
+}
+
+// Unnotify implements the comm.Notifier interface.
+func (t *Task) Unnotify(id comm.Id) {
+	t.relay.Unnotify(id)
+}
+
+// State returns the current state of the task.
+func (t *Task) State() State {
+	return t.state
+}
+
+// Image returns the picked image, or nil if no image has been picked.
+func (t *Task) Image() image.Image {
+	return t.image
+}
+
+// Err returns the error of the last attempt, if the task has failed.
+func (t *Task) Err() error {
+	return t.err
+}
+
+// Progress returns a notifier for the upload progress, ranging from 0 to 1.
+func (t *Task) Progress() comm.Float64Notifier {
+	return &t.progress
+}
+
+// Pick presents the picker and begins uploading the selected image. Any upload
+// that is in progress is cancelled.
+func (t *Task) Pick() {
+	if t.Picker == nil || t.state == StatePicking {
+		return
+	}
+	t.stop()
+	t.state = StatePicking
+	t.relay.Signal()
+
+	t.Picker.Pick(func(img image.Image, err error) {
+		// The picker may call f synchronously while the main lock is held.
+		go t.withLock(func() {
+			if t.state != StatePicking {
+				return
+			}
+			if err != nil {
+				t.fail(err)
+				return
+			}
+			if img == nil {
+				t.state = StateIdle
+				if t.image != nil {
+					t.state = StateFailed
+				}
+				t.relay.Signal()
+				return
+			}
+			t.image = img
+			t.start()
+		})
+	})
+}
+
+// SetImage begins uploading img, skipping the picker.
+func (t *Task) SetImage(img image.Image) {
+	t.stop()
+	t.image = img
+	t.start()
+}
+
+// Retry uploads the previously picked image again. It does nothing unless the
+// task has failed.
+func (t *Task) Retry() {
+	if t.state != StateFailed || t.image == nil {
+		return
+	}
+	t.start()
+}
+
+// Cancel stops the upload that is in progress. The task moves to StateFailed
+// with ErrCancelled and may be retried.
+func (t *Task) Cancel() {
+	if t.state != StateUploading {
+		return
+	}
+	t.stop()
+	t.fail(ErrCancelled)
+}
+
+func (t *Task) start() {
+	if t.Uploader == nil {
+		t.fail(errors.New("upload: no Uploader"))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.cancel = cancel
+	t.attempt += 1
+	attempt := t.attempt
+	t.state = StateUploading
+	t.err = nil
+	t.progress.SetValue(0)
+	t.relay.Signal()
+
+	img, maxSize, quality, uploader := t.image, t.MaxSize, t.Quality, t.Uploader
+	go func() {
+		err := ctx.Err()
+		var data []byte
+		if err == nil {
+			data, err = Encode(Resize(img, maxSize), quality)
+		}
+		if err == nil {
+			err = uploader.Upload(ctx, data, "image/jpeg", func(p float64) {
+				t.withLock(func() {
+					if t.attempt == attempt && t.state == StateUploading {
+						t.progress.SetValue(p)
+					}
+				})
+			})
+		}
+
+		t.withLock(func() {
+			if t.attempt != attempt || t.state != StateUploading {
+				return
+			}
+			t.cancel()
+			t.cancel = nil
+			if err != nil {
+				t.fail(err)
+				return
+			}
+			t.state = StateDone
+			t.progress.SetValue(1)
+			t.relay.Signal()
+			if t.OnComplete != nil {
+				t.OnComplete()
+			}
+		})
+	}()
+}
+
+func (t *Task) stop() {
+	if t.cancel != nil {
+		t.cancel()
+		t.cancel = nil
+	}
+	t.attempt += 1
+}
+
+func (t *Task) fail(err error) {
+	t.state = StateFailed
+	t.err = err
+	t.relay.Signal()
+}
+
+// withLock runs f while holding the main lock. Callbacks from the picker and
+// uploader arrive on arbitrary goroutines.
+func (t *Task) withLock(f func()) {
+	matcha.MainLocker.Lock()
+	defer matcha.MainLocker.Unlock()
+	f()
+}
@@ -0,0 +1,180 @@
+package upload
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+	"time"
+
+	"gomatcha.io/matcha"
+)
+
+func TestResize(t *testing.T) {
+	for _, tc := range []struct {
+		w, h, maxSize int
+		wantW, wantH  int
+	}{
+		{100, 50, 0, 100, 50},
+		{100, 50, 100, 100, 50},
+		{100, 50, 200, 100, 50},
+		{100, 50, 20, 20, 10},
+		{50, 100, 20, 10, 20},
+		{100, 100, 30, 30, 30},
+		{1000, 1, 10, 10, 1},
+		{1, 1000, 10, 1, 10},
+	} {
+		img := image.NewRGBA(image.Rect(0, 0, tc.w, tc.h))
+		b := Resize(img, tc.maxSize).Bounds()
+		if b.Dx() != tc.wantW || b.Dy() != tc.wantH {
+			t.Errorf("Resize(%vx%v, %v) = %vx%v, want %vx%v", tc.w, tc.h, tc.maxSize, b.Dx(), b.Dy(), tc.wantW, tc.wantH)
+		}
+	}
+}
+
+func TestResizeAverage(t *testing.T) {
+	// Alternating black and white columns average to grey.
+	img := image.NewRGBA(image.Rect(10, 10, 14, 12))
+	for y := 10; y < 12; y++ {
+		for x := 10; x < 14; x++ {
+			c := color.RGBA{A: 255}
+			if x%2 == 0 {
+				c = color.RGBA{R: 200, G: 100, B: 50, A: 255}
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+
+	dst := Resize(img, 2).(*image.RGBA)
+	want := color.RGBA{R: 100, G: 50, B: 25, A: 255}
+	for _, p := range []image.Point{{0, 0}, {1, 0}} {
+		if c := dst.RGBAAt(p.X, p.Y); c != want {
+			t.Errorf("pixel %v = %v, want %v", p, c, want)
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 64, 32))
+	for i := range img.Pix {
+		img.Pix[i] = uint8(i)
+	}
+
+	sizes := map[int]int{}
+	for _, quality := range []int{0, 1, 50, 100} {
+		data, err := Encode(img, quality)
+		if err != nil {
+			t.Errorf("Encode(%v): %v", quality, err)
+			continue
+		}
+		decoded, err := jpeg.Decode(bytes.NewReader(data))
+		if err != nil {
+			t.Errorf("Encode(%v) is not a jpeg: %v", quality, err)
+			continue
+		}
+		if b := decoded.Bounds(); b.Dx() != 64 || b.Dy() != 32 {
+			t.Errorf("Encode(%v) bounds = %v, want 64x32", quality, b)
+		}
+		sizes[quality] = len(data)
+	}
+	if sizes[1] >= sizes[100] {
+		t.Errorf("quality 1 is %v bytes, quality 100 is %v bytes", sizes[1], sizes[100])
+	}
+}
+
+type fakeUploader struct {
+	errs     []error
+	attempts int
+}
+
+func (u *fakeUploader) Upload(ctx context.Context, data []byte, contentType string, progress func(float64)) error {
+	u.attempts += 1
+	if len(u.errs) == 0 {
+		return nil
+	}
+	err := u.errs[0]
+	u.errs = u.errs[1:]
+	return err
+}
+
+func TestTaskRetry(t *testing.T) {
+	errFailed := errors.New("failed")
+	for _, tc := range []struct {
+		errs     []error
+		retries  int
+		state    State
+		err      error
+		attempts int
+	}{
+		{nil, 0, StateDone, nil, 1},
+		{[]error{errFailed}, 0, StateFailed, errFailed, 1},
+		{[]error{errFailed}, 1, StateDone, nil, 2},
+		{[]error{errFailed, errFailed}, 1, StateFailed, errFailed, 2},
+		{[]error{errFailed, errFailed}, 2, StateDone, nil, 3},
+	} {
+		u := &fakeUploader{errs: tc.errs}
+		task := NewTask(nil, u)
+
+		matcha.MainLocker.Lock()
+		task.SetImage(image.NewRGBA(image.Rect(0, 0, 4, 4)))
+		matcha.MainLocker.Unlock()
+		for i := 0; i < tc.retries; i++ {
+			waitDone(t, task)
+			matcha.MainLocker.Lock()
+			task.Retry()
+			matcha.MainLocker.Unlock()
+		}
+		waitDone(t, task)
+
+		matcha.MainLocker.Lock()
+		state, err, attempts := task.State(), task.Err(), u.attempts
+		matcha.MainLocker.Unlock()
+		if state != tc.state || err != tc.err || attempts != tc.attempts {
+			t.Errorf("errs %v, %v retries: got %v %v after %v attempts, want %v %v after %v", tc.errs, tc.retries, state, err, attempts, tc.state, tc.err, tc.attempts)
+		}
+	}
+}
+
+func TestTaskCancel(t *testing.T) {
+	u := &blockingUploader{started: make(chan struct{})}
+	task := NewTask(nil, u)
+
+	matcha.MainLocker.Lock()
+	task.SetImage(image.NewRGBA(image.Rect(0, 0, 4, 4)))
+	matcha.MainLocker.Unlock()
+	<-u.started
+
+	matcha.MainLocker.Lock()
+	task.Cancel()
+	state, err := task.State(), task.Err()
+	matcha.MainLocker.Unlock()
+	if state != StateFailed || err != ErrCancelled {
+		t.Errorf("got %v %v, want %v %v", state, err, StateFailed, ErrCancelled)
+	}
+}
+
+type blockingUploader struct {
+	started chan struct{}
+}
+
+func (u *blockingUploader) Upload(ctx context.Context, data []byte, contentType string, progress func(float64)) error {
+	close(u.started)
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// waitDone waits for the upload in progress to finish.
+func waitDone(t *testing.T, task *Task) {
+	for start := time.Now(); time.Since(start) < time.Second; time.Sleep(time.Millisecond) {
+		matcha.MainLocker.Lock()
+		state := task.State()
+		matcha.MainLocker.Unlock()
+		if state != StateUploading {
+			return
+		}
+	}
+	t.Fatal("upload did not finish")
+}
@@ -0,0 +1,168 @@
+package upload
+
+import (
+	"image"
+	"image/color"
+
+	"gomatcha.io/matcha/layout/constraint"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/text"
+	"gomatcha.io/matcha/view"
+)
+
+// View displays the picked image of a Task along with the upload progress, and
+// buttons to pick, cancel and retry.
+type View struct {
+	view.Embed
+	Task *Task
+	// PickTitle is the title of the button that presents the picker.
+	PickTitle     string
+	ProgressColor color.Color
+	TrackColor    color.Color
+	PaintStyle    *paint.Style
+
+	task      *Task
+	source    image.Image
+	thumbnail image.Image
+}
+
+// NewView returns a new view.
+func NewView() *View {
+	return &View{
+		PickTitle:     "Choose Photo",
+		ProgressColor: color.RGBA{R: 0, G: 122, B: 255, A: 255},
+		TrackColor:    color.RGBA{R: 229, G: 229, B: 234, A: 255},
+	}
+}
+
+// Lifecycle implements the view.View interface.
+func (v *View) Lifecycle(from, to view.Stage) {
+	if view.ExitsStage(from, to, view.StageMounted) {
+		if v.task != nil {
+			v.Unsubscribe(v.task)
+			v.Unsubscribe(v.task.Progress())
+			v.task = nil
+		}
+	}
+}
+
+// Build implements the view.View interface.
+func (v *View) Build(ctx view.Context) view.Model {
+	if v.Task != v.task {
+		if v.task != nil {
+			v.Unsubscribe(v.task)
+			v.Unsubscribe(v.task.Progress())
+		}
+		if v.Task != nil {
+			v.Subscribe(v.Task)
+			v.Subscribe(v.Task.Progress())
+		}
+		v.task = v.Task
+	}
+
+	state := StateIdle
+	progress := 0.0
+	var img image.Image
+	if v.Task != nil {
+		state = v.Task.State()
+		progress = v.Task.Progress().Value()
+		img = v.Task.Image()
+	}
+
+	// Only marshal a small copy of the image to the native view.
+	if img != v.source {
+		v.source = img
+		v.thumbnail = nil
+		if img != nil {
+			v.thumbnail = Resize(img, thumbnailSize)
+		}
+	}
+
+	l := &constraint.Layouter{}
+	l.Solve(func(s *constraint.Solver) {
+		s.Height(imageHeight + 2 + 50)
+		s.WidthEqual(l.MaxGuide().Width())
+	})
+
+	imageView := view.NewImageView()
+	imageView.Image = v.thumbnail
+	imageView.ResizeMode = view.ImageResizeModeFit
+	imageGuide := l.Add(imageView, func(s *constraint.Solver) {
+		s.TopEqual(l.Top())
+		s.CenterXEqual(l.CenterX())
+		s.HeightEqual(constraint.Const(imageHeight))
+		s.WidthLess(l.Width())
+	})
+
+	track := view.NewBasicView()
+	track.Painter = &paint.Style{BackgroundColor: v.TrackColor}
+	trackGuide := l.Add(track, func(s *constraint.Solver) {
+		s.Height(2)
+		s.TopEqual(imageGuide.Bottom())
+		s.LeftEqual(l.Left())
+		s.RightEqual(l.Right())
+	})
+
+	if state == StateUploading || state == StateDone {
+		bar := view.NewBasicView()
+		bar.Painter = &paint.Style{BackgroundColor: v.ProgressColor}
+		l.Add(bar, func(s *constraint.Solver) {
+			s.TopEqual(trackGuide.Top())
+			s.HeightEqual(trackGuide.Height())
+			s.LeftEqual(trackGuide.Left())
+			s.WidthEqual(trackGuide.Width().Mul(progress))
+		})
+	}
+
+	button := view.NewButton()
+	switch state {
+	case StateIdle, StatePicking, StateDone:
+		button.String = v.PickTitle
+		button.Enabled = state != StatePicking && v.Task != nil
+		button.OnPress = func() {
+			v.Task.Pick()
+		}
+	case StateUploading:
+		button.String = "Cancel"
+		button.OnPress = func() {
+			v.Task.Cancel()
+		}
+	case StateFailed:
+		button.String = "Retry"
+		button.OnPress = func() {
+			v.Task.Retry()
+		}
+	}
+	buttonGuide := l.Add(button, func(s *constraint.Solver) {
+		s.TopEqual(trackGuide.Bottom().Add(5))
+		s.RightEqual(l.Right().Add(-15))
+	})
+
+	if state == StateFailed && v.Task.Err() != nil && v.Task.Err() != ErrCancelled {
+		errView := view.NewTextView()
+		errView.String = v.Task.Err().Error()
+		errView.MaxLines = 1
+		errView.Style.SetFont(text.DefaultFont(14))
+		errView.Style.SetTextColor(color.RGBA{R: 255, G: 59, B: 48, A: 255})
+		l.Add(errView, func(s *constraint.Solver) {
+			s.CenterYEqual(buttonGuide.CenterY())
+			s.LeftEqual(l.Left().Add(15))
+			s.RightEqual(buttonGuide.Left().Add(-15))
+		})
+	}
+
+	var painter paint.Painter
+	if v.PaintStyle != nil {
+		painter = v.PaintStyle
+	}
+	return view.Model{
+		Children: l.Views(),
+		Layouter: l,
+		Painter:  painter,
+	}
+}
+
+const (
+	imageHeight   = 200
+	thumbnailSize = 512
+)
@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"sync"
 
+	"gomatcha.io/matcha/application"
 	"gomatcha.io/matcha/comm"
 	"gomatcha.io/matcha/internal"
 	"gomatcha.io/matcha/layout"
@@ -22,6 +23,15 @@ type View interface {
 	comm.Notifier
 }
 
+// IntrinsicSizer is implemented by views whose content has a natural size, like
+// text and images. Layouters that support it, like constraint.Layouter and
+// stack.Layouter, size these views to their content when they are not given a
+// width or height.
+type IntrinsicSizer interface {
+	// IntrinsicSize returns the size of the view's content laid out within max.
+	IntrinsicSize(max layout.Point) layout.Point
+}
+
 type Option interface {
 	OptionKey() string
 }
@@ -189,3 +199,14 @@ func (v *optionsView) Build(ctx Context) Model {
 	m.Options = append(m.Options, v.options...)
 	return m
 }
+
+// Fallback returns v if the running OS satisfies min, and fallback otherwise.
+// Components that have a simpler substitute on older OS versions can use it so
+// they don't raise the app's minimum OS.
+//  blur := view.Fallback(application.MinOS{Android: 17}, NewBlurView(), NewBasicView())
+func Fallback(min application.MinOS, v, fallback View) View {
+	if min.Supported() {
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,152 @@
+// Package zoomimageview implements an image viewer that can be zoomed by
+// pinching or double tapping, and panned with momentum once zoomed. It is
+// displayed with a UIScrollView on iOS, and a custom view on Android.
+//
+//  v := zoomimageview.New()
+//  v.URL = photo.URL
+//  v.MaxScale = 5
+//  v.Scale = &app.zoom // *comm.Float64Value
+package zoomimageview
+
+import (
+	"image"
+	"math"
+
+	"gomatcha.io/matcha/comm"
+	"gomatcha.io/matcha/layout"
+	"gomatcha.io/matcha/paint"
+	"gomatcha.io/matcha/view"
+)
+
+// View displays an image that fits the view at a scale of 1.
+type View struct {
+	view.Embed
+	Image image.Image
+	URL   string
+	// MinScale is the smallest scale the image can be zoomed out to. It is 1
+	// by default.
+	MinScale float64
+	// MaxScale is the largest scale the image can be zoomed in to. It is 4 by
+	// default.
+	MaxScale float64
+	// DoubleTapScale is the scale that double tapping zooms in to. Double
+	// tapping a zoomed image zooms back out to MinScale. It is 2 by default.
+	DoubleTapScale float64
+	// Scale is the zoom level. It is set when the user finishes zooming, and
+	// setting it zooms the image around its center.
+	Scale      *comm.Float64Value
+	PaintStyle *paint.Style
+
+	prevScale *comm.Float64Value
+}
+
+// New returns a new view.
+func New() *View {
+	return &View{
+		MinScale:       1,
+		MaxScale:       4,
+		DoubleTapScale: 2,
+		Scale:          &comm.Float64Value{},
+	}
+}
+
+// Lifecycle implements the view.View interface.
+func (v *View) Lifecycle(from, to view.Stage) {
+	if view.ExitsStage(from, to, view.StageMounted) {
+		v.Unsubscribe(v.prevScale)
+	}
+}
+
+// Update implements the view.View interface.
+func (v *View) Update(v2 view.View) {
+	view.CopyFields(v, v2)
+	if v.Scale == nil {
+		v.Scale = &comm.Float64Value{}
+	}
+}
+
+// Build implements the view.View interface.
+func (v *View) Build(ctx view.Context) view.Model {
+	if v.Scale != v.prevScale {
+		if v.prevScale != nil {
+			v.Unsubscribe(v.prevScale)
+		}
+		v.Subscribe(v.Scale)
+		v.prevScale = v.Scale
+	}
+
+	min, max, doubleTap := v.scales()
+	scale := clamp(v.Scale.Value(), min, max)
+
+	img := view.NewImageView()
+	img.Image = v.Image
+	img.URL = v.URL
+	img.ResizeMode = view.ImageResizeModeFit
+
+	var painter paint.Painter
+	if v.PaintStyle != nil {
+		painter = v.PaintStyle
+	}
+	return view.Model{
+		Children:       []view.View{img},
+		Layouter:       &layouter{},
+		Painter:        painter,
+		NativeViewName: "gomatcha.io/matcha/view/zoomimageview",
+		NativeFuncs: map[string]interface{}{
+			// State returns the minimum, maximum and double tap scales, and
+			// the scale.
+			"State": func() (float64, float64, float64, float64) {
+				return min, max, doubleTap, scale
+			},
+			"OnZoom": func(s float64) {
+				v.Scale.SetValue(s)
+			},
+		},
+	}
+}
+
+// scales returns the minimum, maximum and double tap scales, replacing the
+// ones that are unset or out of order.
+func (v *View) scales() (min, max, doubleTap float64) {
+	min, max, doubleTap = v.MinScale, v.MaxScale, v.DoubleTapScale
+	if min <= 0 {
+		min = 1
+	}
+	if max <= 0 {
+		max = 4
+	}
+	max = math.Max(min, max)
+	if doubleTap <= 0 {
+		doubleTap = 2
+	}
+	return min, max, clamp(doubleTap, min, max)
+}
+
+// clamp returns s between min and max. A scale of 0, the zero value of Scale,
+// is min.
+func clamp(s, min, max float64) float64 {
+	return math.Min(math.Max(s, min), max)
+}
+
+// layouter gives the image the view's size. The native views zoom it.
+type layouter struct {
+}
+
+func (l *layouter) Layout(ctx layout.Context) (layout.Guide, []layout.Guide) {
+	size := ctx.MinSize()
+	gs := make([]layout.Guide, ctx.ChildCount())
+	for i := range gs {
+		g := ctx.LayoutChild(i, size, size)
+		g.Frame = layout.Rt(0, 0, size.X, size.Y)
+		gs[i] = g
+	}
+	return layout.Guide{Frame: layout.Rt(0, 0, size.X, size.Y)}, gs
+}
+
+func (l *layouter) Notify(f func()) comm.Id {
+	return 0 // no-op
+}
+
+func (l *layouter) Unnotify(id comm.Id) {
+	// no-op
+}
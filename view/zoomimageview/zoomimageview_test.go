@@ -0,0 +1,21 @@
+package zoomimageview
+
+import "testing"
+
+func TestScales(t *testing.T) {
+	for _, tc := range []struct {
+		v                   *View
+		min, max, doubleTap float64
+	}{
+		{&View{}, 1, 4, 2},
+		{New(), 1, 4, 2},
+		{&View{MinScale: 0.5, MaxScale: 8, DoubleTapScale: 3}, 0.5, 8, 3},
+		{&View{MinScale: 2, MaxScale: 1}, 2, 2, 2},
+		{&View{MaxScale: 10, DoubleTapScale: 20}, 1, 10, 10},
+	} {
+		min, max, doubleTap := tc.v.scales()
+		if min != tc.min || max != tc.max || doubleTap != tc.doubleTap {
+			t.Errorf("%+v scales() = %v, %v, %v, want %v, %v, %v", tc.v, min, max, doubleTap, tc.min, tc.max, tc.doubleTap)
+		}
+	}
+}